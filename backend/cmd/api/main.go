@@ -12,6 +12,7 @@ import (
 
 	httpin "narratives/internal/adapters/in/http/console"
 	"narratives/internal/adapters/in/http/middleware"
+	uc "narratives/internal/application/usecase"
 
 	consoleDI "narratives/internal/platform/di/console"
 	introductionDI "narratives/internal/platform/di/introduction"
@@ -38,6 +39,35 @@ func closeIfPossible(name string, value any) {
 	}
 }
 
+// runInventoryReservationSweeper は Cloud Scheduler を使わない環境（ローカル等）向けに、
+// 期限切れ在庫引当の解放を interval ごとに実行する。
+func runInventoryReservationSweeper(
+	ctx context.Context,
+	interval time.Duration,
+	sweeper *uc.InventoryReservationUsecase,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			result, err := sweeper.ReleaseExpired(ctx, 0)
+			if err != nil {
+				log.Printf("[sweeper] inventory reservation release error: %v (result=%+v)", err, result)
+				continue
+			}
+
+			if result.Scanned > 0 {
+				log.Printf("[sweeper] inventory reservation release result=%+v", result)
+			}
+		}
+	}
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -69,6 +99,18 @@ func main() {
 
 	deps := consoleCont.RouterDeps()
 
+	// ------------------------------------------------------------
+	// Local inventory reservation sweeper (optional)
+	// ------------------------------------------------------------
+	sweepCtx, cancelSweep := context.WithCancel(ctx)
+	defer cancelSweep()
+
+	if interval := infra.InventoryReservationSweepInterval; interval > 0 &&
+		consoleCont.InventoryReservationUC != nil {
+		log.Printf("[boot] inventory reservation sweeper enabled interval=%s", interval)
+		go runInventoryReservationSweeper(sweepCtx, interval, consoleCont.InventoryReservationUC)
+	}
+
 	// ------------------------------------------------------------
 	// Build full mux BEFORE ListenAndServe
 	// ------------------------------------------------------------
@@ -130,6 +172,8 @@ func main() {
 		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
 		<-c
 
+		cancelSweep()
+
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 25*time.Second)
 		defer cancel()
 
@@ -0,0 +1,134 @@
+// backend/internal/adapters/in/http/console/handler/audit_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	auditdom "narratives/internal/domain/audit"
+)
+
+// AuditHandler searches the company's audit log:
+//   - GET /audit-logs?entityType=&entityId=&memberId=&from=&to=&limit=
+//   - GET /audit-logs?...&format=csv   -> CSV download
+//
+// from / to are RFC3339 and filter createdAt in [from, to).
+type AuditHandler struct {
+	uc *usecase.AuditUsecase
+}
+
+func NewAuditHandler(uc *usecase.AuditUsecase) http.Handler {
+	return &AuditHandler{uc: uc}
+}
+
+const auditLogsPath = "/audit-logs"
+
+func (h *AuditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "audit_usecase_not_wired")
+		return
+	}
+
+	if strings.TrimSuffix(r.URL.Path, "/") != auditLogsPath {
+		writeNotFound(w)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	h.search(w, r)
+}
+
+func (h *AuditHandler) search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	in := usecase.SearchAuditLogsInput{
+		EntityType:    auditdom.EntityType(strings.TrimSpace(q.Get("entityType"))),
+		EntityID:      strings.TrimSpace(q.Get("entityId")),
+		ActorMemberID: strings.TrimSpace(q.Get("memberId")),
+	}
+
+	if v := strings.TrimSpace(q.Get("from")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from (expected RFC3339)")
+			return
+		}
+		in.From = t
+	}
+	if v := strings.TrimSpace(q.Get("to")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to (expected RFC3339)")
+			return
+		}
+		in.To = t
+	}
+	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		in.Limit = n
+	}
+
+	switch strings.ToLower(strings.TrimSpace(q.Get("format"))) {
+	case "", "json":
+		items, err := h.uc.Search(r.Context(), in)
+		if err != nil {
+			writeAuditErr(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"items": items})
+
+	case "csv":
+		file, err := h.uc.Export(r.Context(), in)
+		if err != nil {
+			writeAuditErr(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", file.ContentType)
+		w.Header().Set(
+			"Content-Disposition",
+			`attachment; filename="`+file.FileName+`"`,
+		)
+		w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(file.Content)
+
+	default:
+		writeError(w, http.StatusBadRequest, "invalid format (expected json or csv)")
+	}
+}
+
+func writeAuditErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+
+	case errors.Is(err, auditdom.ErrInvalidCompanyID),
+		errors.Is(err, auditdom.ErrInvalidRange):
+		code = http.StatusBadRequest
+
+	case errors.Is(err, usecase.ErrAuditNotConfigured):
+		code = http.StatusNotImplemented
+	}
+
+	writeError(w, code, err.Error())
+}
@@ -0,0 +1,281 @@
+// backend/internal/adapters/in/http/console/handler/billing_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	billingdom "narratives/internal/domain/billing"
+	companydom "narratives/internal/domain/company"
+)
+
+// BillingHandler handles commission plans and monthly billing statements:
+//   - GET  /billing/plan                      -> current company のプラン
+//   - GET  /billing/plans                     -> 登録済みのプラン（運営）
+//   - GET  /billing/plans/{companyId}         （運営）
+//   - PUT  /billing/plans/{companyId}         { salesBasisPoints, mintFeePerItem, networkCostBasisPoints, monthlyFee }（運営）
+//   - GET  /billing/statements
+//   - POST /billing/statements                { period: "YYYY-MM" }
+//   - GET  /billing/statements/{period}?format=csv|pdf
+type BillingHandler struct {
+	uc *usecase.BillingUsecase
+}
+
+func NewBillingHandler(uc *usecase.BillingUsecase) http.Handler {
+	return &BillingHandler{uc: uc}
+}
+
+const billingPath = "/billing"
+
+func (h *BillingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "billing_usecase_not_wired")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if !strings.HasPrefix(path, billingPath+"/") {
+		writeNotFound(w)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, billingPath+"/"), "/")
+
+	switch parts[0] {
+	case "plan":
+		if len(parts) != 1 {
+			writeNotFound(w)
+			return
+		}
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+
+		item, err := h.uc.CurrentPlan(r.Context())
+		if err != nil {
+			writeBillingErr(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, item)
+
+	case "plans":
+		h.servePlans(w, r, parts[1:])
+
+	case "statements":
+		h.serveStatements(w, r, parts[1:])
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+// ============================================================
+// Plans
+// ============================================================
+
+type setBillingPlanRequest struct {
+	SalesBasisPoints       int `json:"salesBasisPoints"`
+	MintFeePerItem         int `json:"mintFeePerItem"`
+	NetworkCostBasisPoints int `json:"networkCostBasisPoints"`
+	MonthlyFee             int `json:"monthlyFee"`
+}
+
+func (h *BillingHandler) servePlans(
+	w http.ResponseWriter,
+	r *http.Request,
+	parts []string,
+) {
+	switch len(parts) {
+	case 0:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+
+		items, err := h.uc.ListPlans(r.Context())
+		if err != nil {
+			writeBillingErr(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"items": items})
+
+	case 1:
+		companyID := strings.TrimSpace(parts[0])
+
+		switch r.Method {
+		case http.MethodGet:
+			item, err := h.uc.GetPlan(r.Context(), companyID)
+			if err != nil {
+				writeBillingErr(w, err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, item)
+
+		case http.MethodPut:
+			var req setBillingPlanRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid json")
+				return
+			}
+
+			item, err := h.uc.SetPlan(r.Context(), usecase.SetBillingPlanInput{
+				CompanyID:              companyID,
+				SalesBasisPoints:       req.SalesBasisPoints,
+				MintFeePerItem:         req.MintFeePerItem,
+				NetworkCostBasisPoints: req.NetworkCostBasisPoints,
+				MonthlyFee:             req.MonthlyFee,
+			})
+			if err != nil {
+				writeBillingErr(w, err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, item)
+
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+// ============================================================
+// Statements
+// ============================================================
+
+type generateBillingStatementRequest struct {
+	// Period は対象月（YYYY-MM, JST）です。
+	Period string `json:"period"`
+}
+
+func (h *BillingHandler) serveStatements(
+	w http.ResponseWriter,
+	r *http.Request,
+	parts []string,
+) {
+	switch len(parts) {
+	case 0:
+		switch r.Method {
+		case http.MethodGet:
+			items, err := h.uc.ListStatements(r.Context())
+			if err != nil {
+				writeBillingErr(w, err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, map[string]any{"items": items})
+
+		case http.MethodPost:
+			var req generateBillingStatementRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid json")
+				return
+			}
+
+			item, err := h.uc.GenerateStatement(r.Context(), req.Period)
+			if err != nil {
+				writeBillingErr(w, err)
+				return
+			}
+
+			writeJSON(w, http.StatusCreated, item)
+
+		default:
+			methodNotAllowed(w)
+		}
+
+	case 1:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+
+		period := strings.TrimSpace(parts[0])
+
+		format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+		if format == "" || format == "json" {
+			item, err := h.uc.GetStatement(r.Context(), period)
+			if err != nil {
+				writeBillingErr(w, err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, item)
+			return
+		}
+
+		file, err := h.uc.ExportStatement(
+			r.Context(),
+			period,
+			usecase.BillingStatementFormat(format),
+		)
+		if err != nil {
+			writeBillingErr(w, err)
+			return
+		}
+
+		writeBillingFile(w, file)
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+func writeBillingFile(w http.ResponseWriter, file usecase.BillingFile) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set(
+		"Content-Disposition",
+		`attachment; filename="`+file.FileName+`"`,
+	)
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(file.Content)
+}
+
+func writeBillingErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+
+	case errors.Is(err, billingdom.ErrInvalidCompanyID),
+		errors.Is(err, billingdom.ErrInvalidSalesBasisPoints),
+		errors.Is(err, billingdom.ErrInvalidMintFeePerItem),
+		errors.Is(err, billingdom.ErrInvalidNetworkCostBasisPoints),
+		errors.Is(err, billingdom.ErrInvalidMonthlyFee),
+		errors.Is(err, billingdom.ErrInvalidPeriod),
+		errors.Is(err, billingdom.ErrInvalidStatementID),
+		errors.Is(err, usecase.ErrBillingInvalidFormat):
+		code = http.StatusBadRequest
+
+	case errors.Is(err, billingdom.ErrNotFound),
+		errors.Is(err, companydom.ErrNotFound):
+		code = http.StatusNotFound
+
+	case errors.Is(err, billingdom.ErrPeriodNotClosed):
+		code = http.StatusConflict
+
+	case errors.Is(err, usecase.ErrBillingForbidden):
+		code = http.StatusForbidden
+
+	case errors.Is(err, usecase.ErrBillingNotConfigured):
+		code = http.StatusNotImplemented
+	}
+
+	writeError(w, code, err.Error())
+}
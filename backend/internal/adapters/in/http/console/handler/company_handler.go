@@ -251,6 +251,9 @@ type updateCompanyRequest struct {
 	Name     *string `json:"name,omitempty"`
 	Admin    *string `json:"admin,omitempty"`
 	IsActive *bool   `json:"isActive,omitempty"`
+
+	// InvoiceRegistrationNumber: 空文字で登録解除
+	InvoiceRegistrationNumber *string `json:"invoiceRegistrationNumber,omitempty"`
 }
 
 func (h *CompanyHandler) update(
@@ -307,6 +310,8 @@ func (h *CompanyHandler) update(
 		Admin:     request.Admin,
 		IsActive:  request.IsActive,
 		UpdatedBy: &updatedBy,
+
+		InvoiceRegistrationNumber: request.InvoiceRegistrationNumber,
 	}
 
 	_, err := h.uc.Update(
@@ -373,7 +378,8 @@ func writeCompanyErr(
 	statusCode := http.StatusInternalServerError
 
 	switch err {
-	case companydom.ErrInvalidID:
+	case companydom.ErrInvalidID,
+		companydom.ErrInvalidInvoiceRegistrationNumber:
 		statusCode = http.StatusBadRequest
 
 	case companydom.ErrNotFound:
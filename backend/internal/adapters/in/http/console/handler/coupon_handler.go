@@ -0,0 +1,283 @@
+// backend/internal/adapters/in/http/console/handler/coupon_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	coupondom "narratives/internal/domain/coupon"
+)
+
+// CouponHandler handles coupon issuance and reporting for the company:
+//   - GET   /coupons
+//   - POST  /coupons
+//   - GET   /coupons/{id}
+//   - PATCH /coupons/{id}
+//   - GET   /coupons/{id}/redemptions
+type CouponHandler struct {
+	uc *usecase.CouponUsecase
+}
+
+func NewCouponHandler(uc *usecase.CouponUsecase) http.Handler {
+	return &CouponHandler{uc: uc}
+}
+
+const couponsPath = "/coupons"
+
+func (h *CouponHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "coupon_usecase_not_wired")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	if path == couponsPath {
+		switch r.Method {
+		case http.MethodGet:
+			h.list(w, r)
+		case http.MethodPost:
+			h.create(w, r)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	if !strings.HasPrefix(path, couponsPath+"/") {
+		writeNotFound(w)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, couponsPath+"/"), "/")
+	id := strings.TrimSpace(parts[0])
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			h.get(w, r, id)
+		case http.MethodPatch:
+			h.update(w, r, id)
+		default:
+			methodNotAllowed(w)
+		}
+
+	case len(parts) == 2 && parts[1] == "redemptions":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.redemptions(w, r, id)
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (h *CouponHandler) list(w http.ResponseWriter, r *http.Request) {
+	items, err := h.uc.ListForCompany(r.Context())
+	if err != nil {
+		writeCouponErr(w, err)
+		return
+	}
+
+	if items == nil {
+		items = []coupondom.Coupon{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *CouponHandler) get(w http.ResponseWriter, r *http.Request, id string) {
+	item, err := h.uc.GetForCompany(r.Context(), id)
+	if err != nil {
+		writeCouponErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+type createCouponRequest struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	DiscountType      string `json:"discountType"`
+	Amount            int    `json:"amount"`
+	Percent           int    `json:"percent"`
+	MaxDiscountAmount int    `json:"maxDiscountAmount"`
+	MinSubtotal       int    `json:"minSubtotal"`
+
+	BrandIDs            []string `json:"brandIds"`
+	ProductBlueprintIDs []string `json:"productBlueprintIds"`
+
+	UsageLimit     int `json:"usageLimit"`
+	PerAvatarLimit int `json:"perAvatarLimit"`
+
+	ValidFrom  *time.Time `json:"validFrom"`
+	ValidUntil *time.Time `json:"validUntil"`
+
+	// Active は省略時 true。
+	Active *bool `json:"active"`
+}
+
+func (h *CouponHandler) create(w http.ResponseWriter, r *http.Request) {
+	var req createCouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	item, err := h.uc.CreateForCompany(r.Context(), usecase.CreateCouponInput{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+
+		DiscountType:      coupondom.DiscountType(strings.TrimSpace(req.DiscountType)),
+		Amount:            req.Amount,
+		Percent:           req.Percent,
+		MaxDiscountAmount: req.MaxDiscountAmount,
+		MinSubtotal:       req.MinSubtotal,
+
+		BrandIDs:            req.BrandIDs,
+		ProductBlueprintIDs: req.ProductBlueprintIDs,
+
+		UsageLimit:     req.UsageLimit,
+		PerAvatarLimit: req.PerAvatarLimit,
+
+		ValidFrom:  req.ValidFrom,
+		ValidUntil: req.ValidUntil,
+
+		Active: active,
+	})
+	if err != nil {
+		writeCouponErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, item)
+}
+
+type updateCouponRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+
+	Amount            *int `json:"amount"`
+	Percent           *int `json:"percent"`
+	MaxDiscountAmount *int `json:"maxDiscountAmount"`
+	MinSubtotal       *int `json:"minSubtotal"`
+
+	BrandIDs            *[]string `json:"brandIds"`
+	ProductBlueprintIDs *[]string `json:"productBlueprintIds"`
+
+	UsageLimit     *int `json:"usageLimit"`
+	PerAvatarLimit *int `json:"perAvatarLimit"`
+
+	ValidFrom       *time.Time `json:"validFrom"`
+	ValidUntil      *time.Time `json:"validUntil"`
+	ClearValidFrom  bool       `json:"clearValidFrom"`
+	ClearValidUntil bool       `json:"clearValidUntil"`
+
+	Active *bool `json:"active"`
+}
+
+func (h *CouponHandler) update(w http.ResponseWriter, r *http.Request, id string) {
+	var req updateCouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	item, err := h.uc.UpdateForCompany(r.Context(), id, coupondom.UpdateInput{
+		Name:        req.Name,
+		Description: req.Description,
+
+		Amount:            req.Amount,
+		Percent:           req.Percent,
+		MaxDiscountAmount: req.MaxDiscountAmount,
+		MinSubtotal:       req.MinSubtotal,
+
+		BrandIDs:            req.BrandIDs,
+		ProductBlueprintIDs: req.ProductBlueprintIDs,
+
+		UsageLimit:     req.UsageLimit,
+		PerAvatarLimit: req.PerAvatarLimit,
+
+		ValidFrom:       req.ValidFrom,
+		ValidUntil:      req.ValidUntil,
+		ClearValidFrom:  req.ClearValidFrom,
+		ClearValidUntil: req.ClearValidUntil,
+
+		Active: req.Active,
+	})
+	if err != nil {
+		writeCouponErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+// redemptions returns the usage report: counts per status and the total
+// discount granted on paid orders.
+func (h *CouponHandler) redemptions(w http.ResponseWriter, r *http.Request, id string) {
+	report, err := h.uc.RedemptionReport(r.Context(), id)
+	if err != nil {
+		writeCouponErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+func writeCouponErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+
+	case errors.Is(err, coupondom.ErrInvalidID),
+		errors.Is(err, coupondom.ErrInvalidCompanyID),
+		errors.Is(err, coupondom.ErrInvalidCode),
+		errors.Is(err, coupondom.ErrInvalidName),
+		errors.Is(err, coupondom.ErrInvalidDescription),
+		errors.Is(err, coupondom.ErrInvalidDiscountType),
+		errors.Is(err, coupondom.ErrInvalidAmount),
+		errors.Is(err, coupondom.ErrInvalidPercent),
+		errors.Is(err, coupondom.ErrInvalidLimit),
+		errors.Is(err, coupondom.ErrInvalidValidity),
+		errors.Is(err, coupondom.ErrInvalidScope):
+		code = http.StatusBadRequest
+
+	case errors.Is(err, coupondom.ErrNotFound):
+		code = http.StatusNotFound
+
+	case errors.Is(err, coupondom.ErrConflict):
+		code = http.StatusConflict
+
+	case errors.Is(err, usecase.ErrCouponNotConfigured):
+		code = http.StatusNotImplemented
+	}
+
+	writeError(w, code, err.Error())
+}
@@ -0,0 +1,167 @@
+// backend/internal/adapters/in/http/console/handler/escrow_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	escrowdom "narratives/internal/domain/escrow"
+	refunddom "narratives/internal/domain/refund"
+)
+
+// EscrowHandler handles brand-side resale escrows:
+//   - GET  /escrows?status=
+//   - GET  /escrows/{id}
+//   - POST /escrows/{id}/resolve   { resolution: "release" | "refund", note }
+type EscrowHandler struct {
+	uc *usecase.EscrowUsecase
+}
+
+func NewEscrowHandler(uc *usecase.EscrowUsecase) http.Handler {
+	return &EscrowHandler{uc: uc}
+}
+
+const escrowsPath = "/escrows"
+
+func (h *EscrowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "escrow_usecase_not_wired")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	if path == escrowsPath {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.list(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(path, escrowsPath+"/") {
+		writeNotFound(w)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, escrowsPath+"/"), "/")
+	id := strings.TrimSpace(parts[0])
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.get(w, r, id)
+
+	case len(parts) == 2 && parts[1] == "resolve":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+		h.resolve(w, r, id)
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (h *EscrowHandler) list(w http.ResponseWriter, r *http.Request) {
+	status := escrowdom.Status(strings.TrimSpace(r.URL.Query().Get("status")))
+
+	items, err := h.uc.ListForCompany(r.Context(), status)
+	if err != nil {
+		writeEscrowErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (h *EscrowHandler) get(w http.ResponseWriter, r *http.Request, id string) {
+	item, err := h.uc.GetForCompany(r.Context(), id)
+	if err != nil {
+		writeEscrowErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+type resolveEscrowRequest struct {
+	Resolution string `json:"resolution"`
+	Note       string `json:"note"`
+}
+
+func (h *EscrowHandler) resolve(w http.ResponseWriter, r *http.Request, id string) {
+	var req resolveEscrowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	item, err := h.uc.ResolveDispute(r.Context(), usecase.ResolveEscrowDisputeInput{
+		EscrowID:   id,
+		Resolution: escrowdom.Resolution(strings.TrimSpace(req.Resolution)),
+		Note:       req.Note,
+	})
+	if err != nil {
+		writeEscrowErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+func writeEscrowErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+
+	case errors.Is(err, escrowdom.ErrInvalidID),
+		errors.Is(err, escrowdom.ErrInvalidStatus),
+		errors.Is(err, escrowdom.ErrInvalidResolution),
+		errors.Is(err, escrowdom.ErrInvalidCompanyID),
+		errors.Is(err, refunddom.ErrInvalidItems),
+		errors.Is(err, refunddom.ErrNothingToRefund),
+		errors.Is(err, refunddom.ErrQtyExceedsRemaining),
+		errors.Is(err, refunddom.ErrAmountExceedsPaid):
+		code = http.StatusBadRequest
+
+	case errors.Is(err, escrowdom.ErrNotFound):
+		code = http.StatusNotFound
+
+	case errors.Is(err, escrowdom.ErrConflict),
+		errors.Is(err, escrowdom.ErrNotDisputed),
+		errors.Is(err, escrowdom.ErrNotTransferred),
+		errors.Is(err, escrowdom.ErrInvalidRefundID),
+		errors.Is(err, usecase.ErrRefundOrderNotPaid),
+		errors.Is(err, usecase.ErrRefundPaymentNotSucceeded),
+		errors.Is(err, refunddom.ErrItemNotRefundable),
+		errors.Is(err, refunddom.ErrConflict):
+		code = http.StatusConflict
+
+	case errors.Is(err, usecase.ErrEscrowNotConfigured):
+		code = http.StatusNotImplemented
+
+	case errors.Is(err, usecase.ErrRefundStripeFailed):
+		code = http.StatusBadGateway
+	}
+
+	writeError(w, code, err.Error())
+}
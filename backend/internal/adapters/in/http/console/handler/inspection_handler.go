@@ -17,6 +17,7 @@ import (
 	inspectiondom "narratives/internal/domain/inspection"
 	productdom "narratives/internal/domain/product"
 	pbdom "narratives/internal/domain/productBlueprint"
+	productiondom "narratives/internal/domain/production"
 )
 
 // ProductBlueprint の modelRefs（displayOrder 含む）を引くための最小ポート
@@ -60,6 +61,10 @@ func (h *InspectorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.getInspectionsByProductionID(w, r)
 		return
 
+	case r.Method == http.MethodGet && r.URL.Path == "/products/inspections/defect-reasons":
+		h.listDefectReasons(w, r)
+		return
+
 	case r.Method == http.MethodPatch && r.URL.Path == "/products/inspections":
 		h.updateInspection(w, r)
 		return
@@ -87,6 +92,10 @@ type inspectionRecordResponse struct {
 	InspectedBy      string  `json:"inspectedBy,omitempty"`   // 表示名
 	InspectedByID    *string `json:"inspectedById,omitempty"` // デバッグ用（UIは無視してOK）
 	InspectedAt      any     `json:"inspectedAt,omitempty"`
+
+	DefectReasons   []string `json:"defectReasons,omitempty"`
+	DefectNote      *string  `json:"defectNote,omitempty"`
+	DefectPhotoURLs []string `json:"defectPhotoUrls,omitempty"`
 }
 
 type inspectionBatchResponse struct {
@@ -221,6 +230,10 @@ func (h *InspectorHandler) getInspectionsByProductionID(w http.ResponseWriter, r
 			InspectedBy:      inspectedByName,
 			InspectedByID:    inspectedByID,
 			InspectedAt:      item.InspectedAt,
+
+			DefectReasons:   item.DefectReasons,
+			DefectNote:      item.DefectNote,
+			DefectPhotoURLs: item.DefectPhotoURLs,
 		})
 	}
 
@@ -246,6 +259,44 @@ func (h *InspectorHandler) getInspectionsByProductionID(w http.ResponseWriter, r
 	writeInspectionJSON(w, http.StatusOK, resp)
 }
 
+// ------------------------------------------------------------
+// GET /products/inspections/defect-reasons?productionId=...
+// ------------------------------------------------------------
+
+func (h *InspectorHandler) listDefectReasons(w http.ResponseWriter, r *http.Request) {
+	if h.inspectionUC == nil {
+		writeInspectionError(w, http.StatusInternalServerError, "inspection usecase is not configured")
+		return
+	}
+
+	productionID := r.URL.Query().Get("productionId")
+	if productionID == "" {
+		writeInspectionError(w, http.StatusBadRequest, "productionId is required")
+		return
+	}
+
+	reasons, err := h.inspectionUC.ListDefectReasons(r.Context(), productionID)
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, inspectiondom.ErrInvalidInspectionProductionID):
+			code = http.StatusBadRequest
+		case errors.Is(err, productiondom.ErrNotFound),
+			errors.Is(err, pbdom.ErrNotFound):
+			code = http.StatusNotFound
+		}
+		writeInspectionError(w, code, err.Error())
+		return
+	}
+
+	writeInspectionJSON(w, http.StatusOK, map[string]any{
+		"productionId":  productionID,
+		"maxPhotos":     inspectiondom.MaxDefectPhotos,
+		"maxNoteLength": inspectiondom.MaxDefectNoteLength,
+		"defectReasons": reasons,
+	})
+}
+
 // ------------------------------------------------------------
 // PATCH /products/inspections
 // ------------------------------------------------------------
@@ -263,6 +314,11 @@ func (h *InspectorHandler) updateInspection(w http.ResponseWriter, r *http.Reque
 		ProductID        string                          `json:"productId"`
 		InspectionResult *inspectiondom.InspectionResult `json:"inspectionResult"`
 		InspectedAt      *time.Time                      `json:"inspectedAt"`
+
+		// failed の場合のみ（任意）
+		DefectReasons   []string `json:"defectReasons"`
+		DefectNote      *string  `json:"defectNote"`
+		DefectPhotoURLs []string `json:"defectPhotoUrls"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -306,6 +362,15 @@ func (h *InspectorHandler) updateInspection(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	var defect *inspectiondom.DefectDetail
+	if len(req.DefectReasons) > 0 || req.DefectNote != nil || len(req.DefectPhotoURLs) > 0 {
+		defect = &inspectiondom.DefectDetail{
+			Reasons:   req.DefectReasons,
+			Note:      req.DefectNote,
+			PhotoURLs: req.DefectPhotoURLs,
+		}
+	}
+
 	batch, err := h.inspectionUC.UpdateInspectionForProduct(
 		ctx,
 		req.ProductionID,
@@ -313,6 +378,7 @@ func (h *InspectorHandler) updateInspection(w http.ResponseWriter, r *http.Reque
 		req.InspectionResult,
 		inspectedByMemberID,
 		&inspectedAt,
+		defect,
 	)
 	if err != nil {
 		code := http.StatusInternalServerError
@@ -322,7 +388,10 @@ func (h *InspectorHandler) updateInspection(w http.ResponseWriter, r *http.Reque
 			errors.Is(err, inspectiondom.ErrInvalidInspectionResult),
 			errors.Is(err, inspectiondom.ErrInvalidInspectedBy),
 			errors.Is(err, inspectiondom.ErrInvalidInspectedAt),
-			errors.Is(err, inspectiondom.ErrInvalidInspectionStatus):
+			errors.Is(err, inspectiondom.ErrInvalidInspectionStatus),
+			errors.Is(err, inspectiondom.ErrInvalidDefectReasons),
+			errors.Is(err, inspectiondom.ErrInvalidDefectNote),
+			errors.Is(err, inspectiondom.ErrInvalidDefectPhotoURLs):
 			code = http.StatusBadRequest
 		case errors.Is(err, inspectiondom.ErrNotFound):
 			code = http.StatusNotFound
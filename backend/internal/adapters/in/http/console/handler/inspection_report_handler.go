@@ -0,0 +1,141 @@
+// backend/internal/adapters/in/http/console/handler/inspection_report_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	companyquery "narratives/internal/application/query/console"
+	inspectiondom "narratives/internal/domain/inspection"
+	productbpdom "narratives/internal/domain/productBlueprint"
+	productiondom "narratives/internal/domain/production"
+)
+
+// InspectionReportHandler は検品の不良率・歩留まりレポート API です:
+//   - GET /products/inspection-reports/defects?productionId=
+//   - GET /products/inspection-reports/yield?productBlueprintId=&assigneeId=&from=&to=&includeInspecting=
+//
+// from / to は RFC3339 または YYYY-MM-DD（production の createdAt、to は含まない）。
+type InspectionReportHandler struct {
+	q *companyquery.InspectionAnalyticsQuery
+}
+
+func NewInspectionReportHandler(q *companyquery.InspectionAnalyticsQuery) http.Handler {
+	return &InspectionReportHandler{q: q}
+}
+
+const inspectionReportsPath = "/products/inspection-reports"
+
+func (h *InspectionReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.q == nil {
+		writeError(w, http.StatusInternalServerError, "inspection_analytics_query_not_wired")
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	switch strings.TrimSuffix(r.URL.Path, "/") {
+	case inspectionReportsPath + "/defects":
+		h.defects(w, r)
+	case inspectionReportsPath + "/yield":
+		h.yield(w, r)
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (h *InspectionReportHandler) defects(w http.ResponseWriter, r *http.Request) {
+	productionID := strings.TrimSpace(r.URL.Query().Get("productionId"))
+	if productionID == "" {
+		writeError(w, http.StatusBadRequest, "productionId query parameter is required")
+		return
+	}
+
+	report, err := h.q.GetProductionDefectReport(r.Context(), productionID)
+	if err != nil {
+		writeInspectionReportErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (h *InspectionReportHandler) yield(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := companyquery.InspectionYieldFilter{
+		ProductBlueprintID: strings.TrimSpace(q.Get("productBlueprintId")),
+		AssigneeID:         strings.TrimSpace(q.Get("assigneeId")),
+	}
+
+	var err error
+	if filter.From, err = parseInspectionReportDate(q.Get("from")); err != nil {
+		writeError(w, http.StatusBadRequest, "from must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+	if filter.To, err = parseInspectionReportDate(q.Get("to")); err != nil {
+		writeError(w, http.StatusBadRequest, "to must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+
+	if s := strings.TrimSpace(q.Get("includeInspecting")); s != "" {
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "includeInspecting must be a boolean")
+			return
+		}
+		filter.IncludeInspecting = v
+	}
+
+	result, err := h.q.CompareInspectionYield(r.Context(), filter)
+	if err != nil {
+		writeInspectionReportErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func parseInspectionReportDate(s string) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func writeInspectionReportErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+	case errors.Is(err, productbpdom.ErrInvalidCompanyID),
+		errors.Is(err, inspectiondom.ErrInvalidInspectionProductionID):
+		code = http.StatusBadRequest
+	case errors.Is(err, inspectiondom.ErrNotFound),
+		errors.Is(err, productiondom.ErrNotFound),
+		errors.Is(err, productbpdom.ErrNotFound):
+		code = http.StatusNotFound
+	}
+
+	writeError(w, code, err.Error())
+}
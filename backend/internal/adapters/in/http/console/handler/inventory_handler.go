@@ -27,6 +27,9 @@ type InventoryHandler struct {
 
 	// listCreate 画面用 Query
 	LQ *invquery.ListCreateQuery
+
+	// 在庫アラート設定・履歴（nil の場合 endpoint は 501）
+	SA *usecase.StockAlertUsecase
 }
 
 func NewInventoryHandler(
@@ -69,6 +72,12 @@ type updateInventoryTransportationRequest struct {
 func (h *InventoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimSuffix(r.URL.Path, "/")
 
+	// /inventory/stock-alerts, /inventory/{id}/stock-alert(s|-settings)
+	// GET /inventory/{id} より先に判定する
+	if h.serveStockAlert(w, r, path) {
+		return
+	}
+
 	// ============================================================
 	// Query endpoints (read-only DTO)
 	// ============================================================
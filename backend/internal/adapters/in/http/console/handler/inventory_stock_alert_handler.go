@@ -0,0 +1,343 @@
+// backend/internal/adapters/in/http/console/handler/inventory_stock_alert_handler.go
+package consoleHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	invdom "narratives/internal/domain/inventory"
+)
+
+// ============================================================
+// Stock alert endpoints
+// - GET /inventory/stock-alerts                   : current company の発火中アラート（console 通知）
+// - GET /inventory/{inventoryId}/stock-alerts     : inventory のアラート履歴
+// - GET /inventory/{inventoryId}/stock-alert-settings
+// - PUT /inventory/{inventoryId}/stock-alert-settings
+// ============================================================
+
+const (
+	inventoryStockAlertsPath          = "/inventory/stock-alerts"
+	inventoryStockAlertsSuffix        = "/stock-alerts"
+	inventoryStockAlertSettingsSuffix = "/stock-alert-settings"
+)
+
+// WithStockAlertUsecase は在庫アラート endpoint を有効にする。
+func (h *InventoryHandler) WithStockAlertUsecase(
+	sa *usecase.StockAlertUsecase,
+) *InventoryHandler {
+	if h == nil {
+		return h
+	}
+
+	h.SA = sa
+
+	return h
+}
+
+type stockAlertSettingRequest struct {
+	Enabled          bool           `json:"enabled"`
+	DefaultThreshold *int           `json:"defaultThreshold"`
+	ModelThresholds  map[string]int `json:"modelThresholds"`
+	NotifyEmails     []string       `json:"notifyEmails"`
+}
+
+type stockAlertSettingResponse struct {
+	InventoryID      string         `json:"inventoryId"`
+	Enabled          bool           `json:"enabled"`
+	DefaultThreshold *int           `json:"defaultThreshold"`
+	ModelThresholds  map[string]int `json:"modelThresholds"`
+	NotifyEmails     []string       `json:"notifyEmails"`
+	UpdatedAt        *time.Time     `json:"updatedAt"`
+	UpdatedBy        string         `json:"updatedBy"`
+}
+
+type stockAlertResponse struct {
+	ID                string     `json:"id"`
+	InventoryID       string     `json:"inventoryId"`
+	ModelID           string     `json:"modelId"`
+	Status            string     `json:"status"`
+	Threshold         int        `json:"threshold"`
+	Available         int        `json:"available"`
+	Accumulation      int        `json:"accumulation"`
+	ReservedCount     int        `json:"reservedCount"`
+	FiredAt           time.Time  `json:"firedAt"`
+	ResolvedAt        *time.Time `json:"resolvedAt"`
+	ResolvedAvailable *int       `json:"resolvedAvailable"`
+	NotifiedEmails    []string   `json:"notifiedEmails"`
+	NotifiedAt        *time.Time `json:"notifiedAt"`
+	NotificationError string     `json:"notificationError"`
+}
+
+// serveStockAlert は在庫アラート関連の path を処理し、処理した場合 true を返す。
+func (h *InventoryHandler) serveStockAlert(
+	w http.ResponseWriter,
+	r *http.Request,
+	path string,
+) bool {
+	switch {
+	case path == inventoryStockAlertsPath:
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return true
+		}
+		h.ListOpenStockAlerts(w, r)
+		return true
+
+	case strings.HasPrefix(path, "/inventory/") &&
+		strings.HasSuffix(path, inventoryStockAlertsSuffix):
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return true
+		}
+		h.ListStockAlertsByPath(w, r, path)
+		return true
+
+	case strings.HasPrefix(path, "/inventory/") &&
+		strings.HasSuffix(path, inventoryStockAlertSettingsSuffix):
+		switch r.Method {
+		case http.MethodGet:
+			h.GetStockAlertSettingByPath(w, r, path)
+		case http.MethodPut:
+			h.SaveStockAlertSettingByPath(w, r, path)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return true
+	}
+
+	return false
+}
+
+func (h *InventoryHandler) ListOpenStockAlerts(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if h == nil || h.SA == nil {
+		writeInventoryError(w, http.StatusNotImplemented, "stock alert usecase is not configured")
+		return
+	}
+
+	ctx := r.Context()
+
+	companyID := usecase.CompanyIDFromContext(ctx)
+	if companyID == "" {
+		writeInventoryError(w, http.StatusBadRequest, "companyId is required")
+		return
+	}
+
+	alerts, err := h.SA.ListOpenAlerts(ctx, companyID)
+	if err != nil {
+		writeStockAlertError(w, err)
+		return
+	}
+
+	writeInventoryJSON(w, http.StatusOK, toStockAlertResponses(alerts))
+}
+
+func (h *InventoryHandler) ListStockAlertsByPath(
+	w http.ResponseWriter,
+	r *http.Request,
+	path string,
+) {
+	if h == nil || h.SA == nil {
+		writeInventoryError(w, http.StatusNotImplemented, "stock alert usecase is not configured")
+		return
+	}
+
+	inventoryID, ok := stockAlertInventoryIDFromPath(path, inventoryStockAlertsSuffix)
+	if !ok {
+		writeInventoryError(w, http.StatusBadRequest, "invalid inventory id")
+		return
+	}
+
+	limit := 0
+	if raw := strings.TrimSpace(r.URL.Query().Get("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			writeInventoryError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = n
+	}
+
+	ctx := r.Context()
+
+	alerts, err := h.SA.ListAlerts(
+		ctx,
+		usecase.CompanyIDFromContext(ctx),
+		inventoryID,
+		limit,
+	)
+	if err != nil {
+		writeStockAlertError(w, err)
+		return
+	}
+
+	writeInventoryJSON(w, http.StatusOK, toStockAlertResponses(alerts))
+}
+
+func (h *InventoryHandler) GetStockAlertSettingByPath(
+	w http.ResponseWriter,
+	r *http.Request,
+	path string,
+) {
+	if h == nil || h.SA == nil {
+		writeInventoryError(w, http.StatusNotImplemented, "stock alert usecase is not configured")
+		return
+	}
+
+	inventoryID, ok := stockAlertInventoryIDFromPath(path, inventoryStockAlertSettingsSuffix)
+	if !ok {
+		writeInventoryError(w, http.StatusBadRequest, "invalid inventory id")
+		return
+	}
+
+	ctx := r.Context()
+
+	setting, err := h.SA.GetSetting(
+		ctx,
+		usecase.CompanyIDFromContext(ctx),
+		inventoryID,
+	)
+	if err != nil {
+		writeStockAlertError(w, err)
+		return
+	}
+
+	writeInventoryJSON(w, http.StatusOK, toStockAlertSettingResponse(setting))
+}
+
+func (h *InventoryHandler) SaveStockAlertSettingByPath(
+	w http.ResponseWriter,
+	r *http.Request,
+	path string,
+) {
+	if h == nil || h.SA == nil {
+		writeInventoryError(w, http.StatusNotImplemented, "stock alert usecase is not configured")
+		return
+	}
+
+	inventoryID, ok := stockAlertInventoryIDFromPath(path, inventoryStockAlertSettingsSuffix)
+	if !ok {
+		writeInventoryError(w, http.StatusBadRequest, "invalid inventory id")
+		return
+	}
+
+	var req stockAlertSettingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeInventoryError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ctx := r.Context()
+
+	setting, err := h.SA.SaveSetting(
+		ctx,
+		usecase.SaveStockAlertSettingInput{
+			CompanyID:        usecase.CompanyIDFromContext(ctx),
+			MemberID:         usecase.MemberIDFromContext(ctx),
+			InventoryID:      inventoryID,
+			Enabled:          req.Enabled,
+			DefaultThreshold: req.DefaultThreshold,
+			ModelThresholds:  req.ModelThresholds,
+			NotifyEmails:     req.NotifyEmails,
+		},
+	)
+	if err != nil {
+		writeStockAlertError(w, err)
+		return
+	}
+
+	writeInventoryJSON(w, http.StatusOK, toStockAlertSettingResponse(setting))
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func stockAlertInventoryIDFromPath(path string, suffix string) (string, bool) {
+	rest := strings.TrimPrefix(path, "/inventory/")
+	rest = strings.TrimSuffix(rest, suffix)
+	inventoryID := strings.Trim(rest, "/")
+
+	if inventoryID == "" ||
+		inventoryID == "ids" ||
+		strings.Contains(inventoryID, "/") {
+		return "", false
+	}
+
+	return inventoryID, true
+}
+
+func writeStockAlertError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, invdom.ErrNotFound):
+		writeInventoryError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrStockAlertCompanyIDRequired),
+		errors.Is(err, invdom.ErrInvalidStockAlertThreshold),
+		errors.Is(err, invdom.ErrInvalidStockAlertNotifyEmail),
+		errors.Is(err, invdom.ErrTooManyStockAlertNotifyEmail),
+		isInventoryProbablyBadRequest(err):
+		writeInventoryError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeInventoryError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func toStockAlertSettingResponse(s invdom.StockAlertSetting) stockAlertSettingResponse {
+	out := stockAlertSettingResponse{
+		InventoryID:      s.InventoryID,
+		Enabled:          s.Enabled,
+		DefaultThreshold: s.DefaultThreshold,
+		ModelThresholds:  s.ModelThresholds,
+		NotifyEmails:     s.NotifyEmails,
+		UpdatedBy:        s.UpdatedBy,
+	}
+	if out.ModelThresholds == nil {
+		out.ModelThresholds = map[string]int{}
+	}
+	if out.NotifyEmails == nil {
+		out.NotifyEmails = []string{}
+	}
+	if !s.UpdatedAt.IsZero() {
+		t := s.UpdatedAt
+		out.UpdatedAt = &t
+	}
+
+	return out
+}
+
+func toStockAlertResponses(alerts []invdom.StockAlert) []stockAlertResponse {
+	out := make([]stockAlertResponse, 0, len(alerts))
+	for _, a := range alerts {
+		notified := a.NotifiedEmails
+		if notified == nil {
+			notified = []string{}
+		}
+
+		out = append(out, stockAlertResponse{
+			ID:                a.ID,
+			InventoryID:       a.InventoryID,
+			ModelID:           a.ModelID,
+			Status:            string(a.Status),
+			Threshold:         a.Threshold,
+			Available:         a.Available,
+			Accumulation:      a.Accumulation,
+			ReservedCount:     a.ReservedCount,
+			FiredAt:           a.FiredAt,
+			ResolvedAt:        a.ResolvedAt,
+			ResolvedAvailable: a.ResolvedAvailable,
+			NotifiedEmails:    notified,
+			NotifiedAt:        a.NotifiedAt,
+			NotificationError: a.NotificationError,
+		})
+	}
+
+	return out
+}
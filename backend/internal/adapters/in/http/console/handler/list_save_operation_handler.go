@@ -315,6 +315,8 @@ func listSaveOperationHTTPStatus(err error, operation listdom.SaveOperation) int
 		return http.StatusBadRequest
 	case errors.Is(err, listdom.ErrInvalidListImageDisplayOrder):
 		return http.StatusBadRequest
+	case errors.Is(err, listdom.ErrInvalidGating):
+		return http.StatusBadRequest
 	case errors.Is(err, listdom.ErrSaveOperationNotFound):
 		return http.StatusNotFound
 	case errors.Is(err, listdom.ErrNotFound):
@@ -357,6 +359,8 @@ func listSaveOperationErrorCode(err error, operation listdom.SaveOperation) stri
 		return "invalid_created_by"
 	case errors.Is(err, listdom.ErrInvalidListImageDisplayOrder):
 		return "invalid_display_order"
+	case errors.Is(err, listdom.ErrInvalidGating):
+		return "invalid_gating"
 	case errors.Is(err, listdom.ErrSaveOperationNotFound):
 		return "save_operation_not_found"
 	case errors.Is(err, listdom.ErrNotFound):
@@ -13,6 +13,7 @@ import (
 	memberusecase "narratives/internal/application/usecase"
 	common "narratives/internal/domain/common"
 	memberdom "narratives/internal/domain/member"
+	permissiondom "narratives/internal/domain/permission"
 )
 
 // -----------------------------------------------------------------------------
@@ -45,6 +46,7 @@ func NewMemberHandler(repo memberdom.Repository) http.Handler {
 // Contract:
 // - string 項目は値が空でも必ず JSON に含める。
 // - permissions は未設定でも [] を返す。
+// - roles は未割当でも [] を返す。
 // - assignedBrands は未割当の場合 null を返す。
 // - updatedAt / updatedBy は未設定の場合 null を返す。
 // - displayName は backend で生成した値を返す。
@@ -57,6 +59,7 @@ type memberResponse struct {
 	LastNameKana   string     `json:"lastNameKana"`
 	Email          string     `json:"email"`
 	Permissions    []string   `json:"permissions"`
+	Roles          []string   `json:"roles"`
 	AssignedBrands []string   `json:"assignedBrands"`
 	CompanyID      string     `json:"companyId"`
 	Status         string     `json:"status"`
@@ -72,6 +75,11 @@ func toMemberResponse(docID string, m memberdom.Member) memberResponse {
 		permissions = []string{}
 	}
 
+	roles := m.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
 	assignedBrands := m.AssignedBrands
 	if len(assignedBrands) == 0 {
 		assignedBrands = nil
@@ -86,6 +94,7 @@ func toMemberResponse(docID string, m memberdom.Member) memberResponse {
 		LastNameKana:   m.LastNameKana,
 		Email:          m.Email,
 		Permissions:    permissions,
+		Roles:          roles,
 		AssignedBrands: assignedBrands,
 		CompanyID:      m.CompanyID,
 		Status:         m.Status,
@@ -204,6 +213,12 @@ func (h *MemberHandler) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 権限の直接付与はロール割当と同等に扱う。
+	if len(req.Permissions) > 0 && !httpmw.HasPermission(r, permissiondom.NameMemberRolesAssign) {
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": "forbidden", "permission": permissiondom.NameMemberRolesAssign})
+		return
+	}
+
 	rec, err := h.memberUC.Create(
 		r.Context(),
 		memberusecase.CreateMemberInput{
@@ -263,6 +278,12 @@ func (h *MemberHandler) update(w http.ResponseWriter, r *http.Request, id string
 		return
 	}
 
+	// 権限の直接付与はロール割当と同等に扱う。
+	if req.Permissions != nil && !httpmw.HasPermission(r, permissiondom.NameMemberRolesAssign) {
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": "forbidden", "permission": permissiondom.NameMemberRolesAssign})
+		return
+	}
+
 	rec, err := h.memberUC.Update(
 		r.Context(),
 		memberusecase.UpdateMemberInput{
@@ -463,6 +484,8 @@ func writeMemberErr(w http.ResponseWriter, err error) {
 		code = http.StatusConflict
 	case errors.Is(err, memberdom.ErrPreconditionFailed):
 		code = http.StatusPreconditionFailed
+	case errors.Is(err, permissiondom.ErrSystemPermission):
+		code = http.StatusForbidden
 	}
 
 	writeJSON(w, code, map[string]string{"error": err.Error()})
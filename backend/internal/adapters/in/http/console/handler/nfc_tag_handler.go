@@ -0,0 +1,197 @@
+// backend/internal/adapters/in/http/console/handler/nfc_tag_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	nfcdom "narratives/internal/domain/nfc"
+)
+
+// NFCTagHandler handles NFC tag provisioning for printed products:
+//   - GET  /products/nfc-tags?productionId=
+//   - POST /products/nfc-tags/{productId}/keys       (タグ書き込み用の鍵・URL テンプレート)
+//   - POST /products/nfc-tags/{productId}/provision  {"uid": "04AABBCCDDEEFF"}
+//   - POST /products/nfc-tags/{productId}/revoke
+//   - POST /products/nfc-tags/{productId}/reissue
+type NFCTagHandler struct {
+	uc *usecase.NFCTagUsecase
+}
+
+func NewNFCTagHandler(uc *usecase.NFCTagUsecase) http.Handler {
+	return &NFCTagHandler{uc: uc}
+}
+
+const nfcTagsPath = "/products/nfc-tags"
+
+type nfcTagResponse struct {
+	ProductID    string `json:"productId"`
+	ProductionID string `json:"productionId"`
+
+	Status     nfcdom.Status `json:"status"`
+	KeyVersion int           `json:"keyVersion"`
+	UID        string        `json:"uid,omitempty"`
+
+	LastCounter    *uint32    `json:"lastCounter,omitempty"`
+	LastVerifiedAt *time.Time `json:"lastVerifiedAt,omitempty"`
+
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+	ProvisionedAt *time.Time `json:"provisionedAt,omitempty"`
+	RevokedAt     *time.Time `json:"revokedAt,omitempty"`
+}
+
+func toNFCTagResponse(t nfcdom.Tag) nfcTagResponse {
+	out := nfcTagResponse{
+		ProductID:      t.ProductID,
+		ProductionID:   t.ProductionID,
+		Status:         t.Status,
+		KeyVersion:     t.KeyVersion,
+		UID:            t.UID,
+		LastVerifiedAt: t.LastVerifiedAt,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+		ProvisionedAt:  t.ProvisionedAt,
+		RevokedAt:      t.RevokedAt,
+	}
+	if t.LastVerifiedAt != nil {
+		ctr := t.LastCounter
+		out.LastCounter = &ctr
+	}
+	return out
+}
+
+func (h *NFCTagHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "nfc_tag_usecase_not_wired")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	if path == nfcTagsPath {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.list(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(path, nfcTagsPath+"/") {
+		writeNotFound(w)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, nfcTagsPath+"/"), "/")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		writeNotFound(w)
+		return
+	}
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	productID := strings.TrimSpace(parts[0])
+	ctx := r.Context()
+
+	switch parts[1] {
+	case "keys":
+		// 鍵は書き込み端末でのみ使う。キャッシュさせない。
+		w.Header().Set("Cache-Control", "no-store")
+
+		keys, err := h.uc.IssueKeys(ctx, productID)
+		if err != nil {
+			writeNFCTagErr(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, keys)
+
+	case "provision":
+		var req struct {
+			UID string `json:"uid"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+
+		h.update(w, ctx, productID, func(ctx context.Context, id string) (nfcdom.Tag, error) {
+			return h.uc.Provision(ctx, id, req.UID)
+		})
+
+	case "revoke":
+		h.update(w, ctx, productID, h.uc.Revoke)
+
+	case "reissue":
+		h.update(w, ctx, productID, h.uc.Reissue)
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (h *NFCTagHandler) list(w http.ResponseWriter, r *http.Request) {
+	productionID := strings.Trim(r.URL.Query().Get("productionId"), " \t\r\n/")
+	if productionID == "" {
+		writeError(w, http.StatusBadRequest, "productionId query parameter is required")
+		return
+	}
+
+	tags, err := h.uc.ListByProductionID(r.Context(), productionID)
+	if err != nil {
+		writeNFCTagErr(w, err)
+		return
+	}
+
+	out := make([]nfcTagResponse, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, toNFCTagResponse(t))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": out})
+}
+
+func (h *NFCTagHandler) update(
+	w http.ResponseWriter,
+	ctx context.Context,
+	productID string,
+	fn func(ctx context.Context, productID string) (nfcdom.Tag, error),
+) {
+	t, err := fn(ctx, productID)
+	if err != nil {
+		writeNFCTagErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toNFCTagResponse(t))
+}
+
+func writeNFCTagErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, nfcdom.ErrNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, nfcdom.ErrInvalidProductID),
+		errors.Is(err, nfcdom.ErrInvalidProductionID),
+		errors.Is(err, nfcdom.ErrInvalidUID):
+		code = http.StatusBadRequest
+	case errors.Is(err, nfcdom.ErrInvalidCompanyID):
+		code = http.StatusForbidden
+	case errors.Is(err, nfcdom.ErrInvalidStatus):
+		code = http.StatusConflict
+	case errors.Is(err, usecase.ErrNFCKeysUnavailable):
+		code = http.StatusServiceUnavailable
+	}
+
+	writeError(w, code, err.Error())
+}
@@ -4,6 +4,7 @@ package consoleHandler
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -12,34 +13,39 @@ import (
 	usecase "narratives/internal/application/usecase"
 	common "narratives/internal/domain/common"
 	orderdom "narratives/internal/domain/order"
+	refunddom "narratives/internal/domain/refund"
+	transportationdom "narratives/internal/domain/transportation"
 )
 
 // OrderHandler handles:
 //   - GET /orders/items
 //   - GET /orders/undispatched-count
 //   - PATCH /orders/{id}/dispatch
+//   - PATCH /orders/{id}/status
+//   - POST /orders/{id}/refunds
+//   - GET /orders/{id}/refunds
 //   - GET /orders/{id}
 type OrderHandler struct {
-	uc                     *usecase.OrderUsecase
-	paymentFlowUC          *usecase.PaymentFlowUsecase
-	q                      *orderq.OrderManagementQuery
-	detailQ                *orderq.OrderDetailQuery
-	dispatchNotificationUC usecase.OrderDispatchNotificationUsecasePort
+	uc            *usecase.OrderUsecase
+	paymentFlowUC *usecase.PaymentFlowUsecase
+	refundUC      *usecase.RefundUsecase
+	q             *orderq.OrderManagementQuery
+	detailQ       *orderq.OrderDetailQuery
 }
 
 func NewOrderHandler(
 	uc *usecase.OrderUsecase,
 	paymentFlowUC *usecase.PaymentFlowUsecase,
+	refundUC *usecase.RefundUsecase,
 	q *orderq.OrderManagementQuery,
 	detailQ *orderq.OrderDetailQuery,
-	dispatchNotificationUC usecase.OrderDispatchNotificationUsecasePort,
 ) http.Handler {
 	return &OrderHandler{
-		uc:                     uc,
-		paymentFlowUC:          paymentFlowUC,
-		q:                      q,
-		detailQ:                detailQ,
-		dispatchNotificationUC: dispatchNotificationUC,
+		uc:            uc,
+		paymentFlowUC: paymentFlowUC,
+		refundUC:      refundUC,
+		q:             q,
+		detailQ:       detailQ,
 	}
 }
 
@@ -68,6 +74,36 @@ func (h *OrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.dispatch(w, r, id)
 		return
 
+	case r.Method == http.MethodPatch &&
+		strings.HasPrefix(r.URL.Path, "/orders/") &&
+		strings.HasSuffix(r.URL.Path, "/status"):
+		id := strings.TrimSuffix(
+			strings.TrimPrefix(
+				r.URL.Path,
+				"/orders/",
+			),
+			"/status",
+		)
+		h.updateStatus(w, r, id)
+		return
+
+	case (r.Method == http.MethodPost || r.Method == http.MethodGet) &&
+		strings.HasPrefix(r.URL.Path, "/orders/") &&
+		strings.HasSuffix(r.URL.Path, "/refunds"):
+		id := strings.TrimSuffix(
+			strings.TrimPrefix(
+				r.URL.Path,
+				"/orders/",
+			),
+			"/refunds",
+		)
+		if r.Method == http.MethodPost {
+			h.issueRefund(w, r, id)
+			return
+		}
+		h.listRefunds(w, r, id)
+		return
+
 	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/orders/"):
 		id := strings.TrimPrefix(r.URL.Path, "/orders/")
 		h.get(w, r, id)
@@ -119,13 +155,21 @@ func (h *OrderHandler) dispatch(w http.ResponseWriter, r *http.Request, id strin
 		h.uc == nil ||
 		h.paymentFlowUC == nil ||
 		h.q == nil ||
-		h.detailQ == nil ||
-		h.dispatchNotificationUC == nil {
+		h.detailQ == nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_ = json.NewEncoder(w).Encode(map[string]string{"error": "order_dispatch_not_wired"})
 		return
 	}
 
+	// body は任意。伝票情報を省略した場合は注文時の carrier で発送する。
+	var req dispatchOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil &&
+		!errors.Is(err, io.EOF) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid json"})
+		return
+	}
+
 	allowedInventoryIDs, err := h.q.AllowedInventoryIDSet(ctx)
 	if err != nil {
 		writeOrderErr(w, err)
@@ -135,6 +179,9 @@ func (h *OrderHandler) dispatch(w http.ResponseWriter, r *http.Request, id strin
 	dispatchInput := usecase.DispatchOrderItemsInput{
 		ID:                  id,
 		AllowedInventoryIDs: allowedInventoryIDs,
+
+		Carrier:        transportationdom.Carrier(strings.TrimSpace(req.Carrier)),
+		TrackingNumber: req.TrackingNumber,
 	}
 
 	// 決済前に、このConsole企業が発送できる対象商品を持つことを確認する。
@@ -159,7 +206,9 @@ func (h *OrderHandler) dispatch(w http.ResponseWriter, r *http.Request, id strin
 		return
 	}
 
-	result, err := h.uc.DispatchItems(
+	// 発送通知はDispatchItemsが発送状態と同じtransactionで保存する
+	// ItemDispatchedイベントから送る（outbox relay）。
+	_, err = h.uc.DispatchItems(
 		ctx,
 		dispatchInput,
 	)
@@ -168,13 +217,59 @@ func (h *OrderHandler) dispatch(w http.ResponseWriter, r *http.Request, id strin
 		return
 	}
 
-	// DispatchItemsが既に発送済みを返した場合も必ずEnsureDeliveryを呼ぶ。
-	// 発送状態保存後、通知outbox作成前に処理が停止したケースを再実行で修復する。
-	_, err = h.dispatchNotificationUC.EnsureDelivery(
-		ctx,
-		result.Order,
-		result.TargetItems,
-	)
+	dto, err := h.detailQ.GetByID(ctx, id)
+	if err != nil {
+		writeOrderErr(w, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(dto)
+}
+
+type dispatchOrderRequest struct {
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"trackingNumber"`
+}
+
+type updateOrderStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// updateStatus moves the current company's items to preparing or delivered.
+func (h *OrderHandler) updateStatus(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	id = strings.Trim(id, " \t\r\n/")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid id"})
+		return
+	}
+
+	if h == nil || h.uc == nil || h.q == nil || h.detailQ == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "order_status_not_wired"})
+		return
+	}
+
+	var req updateOrderStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid json"})
+		return
+	}
+
+	allowedInventoryIDs, err := h.q.AllowedInventoryIDSet(ctx)
+	if err != nil {
+		writeOrderErr(w, err)
+		return
+	}
+
+	_, err = h.uc.UpdateItemsStatus(ctx, usecase.UpdateOrderItemsStatusInput{
+		ID:                  id,
+		AllowedInventoryIDs: allowedInventoryIDs,
+		Status:              orderdom.Status(strings.TrimSpace(req.Status)),
+	})
 	if err != nil {
 		writeOrderErr(w, err)
 		return
@@ -189,6 +284,100 @@ func (h *OrderHandler) dispatch(w http.ResponseWriter, r *http.Request, id strin
 	_ = json.NewEncoder(w).Encode(dto)
 }
 
+type issueRefundRequest struct {
+	Items []struct {
+		ItemIndex int `json:"itemIndex"`
+		Qty       int `json:"qty"`
+	} `json:"items"`
+	Reason string `json:"reason"`
+	Note   string `json:"note"`
+}
+
+// issueRefund refunds order items owned by the current Console company.
+// Amounts are computed server-side from the Order snapshot.
+func (h *OrderHandler) issueRefund(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	id = strings.Trim(id, " \t\r\n/")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid id"})
+		return
+	}
+
+	if h == nil || h.refundUC == nil || h.q == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "order_refund_not_wired"})
+		return
+	}
+
+	var req issueRefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid json"})
+		return
+	}
+
+	allowedInventoryIDs, err := h.q.AllowedInventoryIDSet(ctx)
+	if err != nil {
+		writeOrderErr(w, err)
+		return
+	}
+
+	items := make([]usecase.IssueRefundItemInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, usecase.IssueRefundItemInput{
+			ItemIndex: item.ItemIndex,
+			Qty:       item.Qty,
+		})
+	}
+
+	refund, err := h.refundUC.IssueRefund(ctx, usecase.IssueRefundInput{
+		OrderID:             id,
+		Items:               items,
+		Reason:              refunddom.Reason(strings.TrimSpace(req.Reason)),
+		Note:                req.Note,
+		RequestedBy:         usecase.MemberIDFromContext(ctx),
+		AllowedInventoryIDs: allowedInventoryIDs,
+	})
+	if err != nil {
+		writeOrderErr(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(refund)
+}
+
+func (h *OrderHandler) listRefunds(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	id = strings.Trim(id, " \t\r\n/")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid id"})
+		return
+	}
+
+	if h == nil || h.refundUC == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "order_refund_not_wired"})
+		return
+	}
+
+	refunds, err := h.refundUC.ListByOrderID(ctx, id)
+	if err != nil {
+		writeOrderErr(w, err)
+		return
+	}
+
+	if refunds == nil {
+		refunds = []refunddom.Refund{}
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"items": refunds})
+}
+
 func (h *OrderHandler) listItemRows(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -301,15 +490,29 @@ func writeOrderErr(w http.ResponseWriter, err error) {
 
 	switch {
 	case errors.Is(err, orderdom.ErrInvalidID),
+		errors.Is(err, orderdom.ErrInvalidStatus),
+		errors.Is(err, orderdom.ErrInvalidDispatch),
+		errors.Is(err, transportationdom.ErrInvalidTrackingNumber),
 		errors.Is(err, usecase.ErrPaymentFlowPaymentIDEmpty),
-		errors.Is(err, usecase.ErrPaymentFlowAmountInvalid):
+		errors.Is(err, usecase.ErrPaymentFlowAmountInvalid),
+		errors.Is(err, refunddom.ErrInvalidItems),
+		errors.Is(err, refunddom.ErrInvalidReason),
+		errors.Is(err, refunddom.ErrInvalidRequestedBy),
+		errors.Is(err, refunddom.ErrNothingToRefund),
+		errors.Is(err, refunddom.ErrQtyExceedsRemaining),
+		errors.Is(err, refunddom.ErrAmountExceedsPaid):
 		code = http.StatusBadRequest
 
+	case errors.Is(err, usecase.ErrRefundItemNotAllowed):
+		code = http.StatusForbidden
+
 	case errors.Is(err, orderdom.ErrNotFound),
-		errors.Is(err, usecase.ErrPaymentFlowOrderNotFound):
+		errors.Is(err, usecase.ErrPaymentFlowOrderNotFound),
+		errors.Is(err, refunddom.ErrNotFound):
 		code = http.StatusNotFound
 
 	case errors.Is(err, orderdom.ErrConflict),
+		errors.Is(err, orderdom.ErrInvalidStatusTransition),
 		errors.Is(err, usecase.ErrPaymentFlowOrderAlreadyPaid),
 		errors.Is(err, usecase.ErrPaymentFlowPaymentMethodMismatch),
 		errors.Is(err, usecase.ErrPaymentFlowDispatchRequiresAction),
@@ -319,8 +522,15 @@ func writeOrderErr(w http.ResponseWriter, err error) {
 		errors.Is(err, usecase.ErrPaymentFlowDispatchPaymentMismatch),
 		errors.Is(err, usecase.ErrPaymentFlowDispatchPaidStateInvalid),
 		errors.Is(err, usecase.ErrPaymentFlowStripePaymentIntentFailed),
-		errors.Is(err, usecase.ErrPaymentFlowStripePaymentIntentCanceled):
+		errors.Is(err, usecase.ErrPaymentFlowStripePaymentIntentCanceled),
+		errors.Is(err, usecase.ErrRefundOrderNotPaid),
+		errors.Is(err, usecase.ErrRefundPaymentNotSucceeded),
+		errors.Is(err, refunddom.ErrItemNotRefundable),
+		errors.Is(err, refunddom.ErrConflict):
 		code = http.StatusConflict
+
+	case errors.Is(err, usecase.ErrRefundStripeFailed):
+		code = http.StatusBadGateway
 	}
 
 	w.WriteHeader(code)
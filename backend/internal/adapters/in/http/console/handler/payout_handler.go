@@ -0,0 +1,392 @@
+// backend/internal/adapters/in/http/console/handler/payout_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	accdom "narratives/internal/domain/account"
+	payoutdom "narratives/internal/domain/payout"
+)
+
+// PayoutHandler handles the payout ledger and payout batches:
+//   - GET  /payouts/entries?recipientType=&recipientId=&batchId=&unbatched=true
+//   - GET  /payouts/recipients
+//   - PUT  /payouts/recipients/{recipientType}/{recipientId}   { accountId }
+//   - GET  /payouts/batches?status=
+//   - POST /payouts/batches                                    { cutoffAt, transferDate: "YYYY-MM-DD" }
+//   - GET  /payouts/batches/{id}
+//   - POST /payouts/batches/{id}/approve | cancel | paid
+//   - POST /payouts/batches/{id}/export                        -> 全銀協フォーマットの振込ファイル
+//   - GET  /payouts/batches/{id}/statements/{recipientType}/{recipientId} -> 支払明細書 PDF
+type PayoutHandler struct {
+	uc *usecase.PayoutUsecase
+
+	location *time.Location
+}
+
+func NewPayoutHandler(uc *usecase.PayoutUsecase) http.Handler {
+	return &PayoutHandler{
+		uc:       uc,
+		location: time.FixedZone("JST", 9*60*60),
+	}
+}
+
+const payoutsPath = "/payouts"
+
+func (h *PayoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "payout_usecase_not_wired")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if !strings.HasPrefix(path, payoutsPath+"/") {
+		writeNotFound(w)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, payoutsPath+"/"), "/")
+
+	switch parts[0] {
+	case "entries":
+		if len(parts) != 1 {
+			writeNotFound(w)
+			return
+		}
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.listEntries(w, r)
+
+	case "recipients":
+		h.serveRecipients(w, r, parts[1:])
+
+	case "batches":
+		h.serveBatches(w, r, parts[1:])
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+// ============================================================
+// Entries / recipients
+// ============================================================
+
+func (h *PayoutHandler) listEntries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := payoutdom.EntryFilter{
+		RecipientType: payoutdom.RecipientType(strings.TrimSpace(q.Get("recipientType"))),
+		RecipientID:   strings.TrimSpace(q.Get("recipientId")),
+		BatchID:       strings.TrimSpace(q.Get("batchId")),
+	}
+	if v := strings.TrimSpace(q.Get("unbatched")); v != "" {
+		unbatched, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid unbatched")
+			return
+		}
+		filter.Unbatched = unbatched
+	}
+
+	items, err := h.uc.ListEntries(r.Context(), filter)
+	if err != nil {
+		writePayoutErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+type setPayoutRecipientRequest struct {
+	AccountID string `json:"accountId"`
+}
+
+func (h *PayoutHandler) serveRecipients(
+	w http.ResponseWriter,
+	r *http.Request,
+	parts []string,
+) {
+	switch len(parts) {
+	case 0:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+
+		items, err := h.uc.ListRecipients(r.Context())
+		if err != nil {
+			writePayoutErr(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"items": items})
+
+	case 2:
+		if r.Method != http.MethodPut {
+			methodNotAllowed(w)
+			return
+		}
+
+		var req setPayoutRecipientRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid json")
+			return
+		}
+
+		item, err := h.uc.SetRecipientAccount(r.Context(), usecase.SetPayoutRecipientInput{
+			RecipientType: payoutdom.RecipientType(strings.TrimSpace(parts[0])),
+			RecipientID:   strings.TrimSpace(parts[1]),
+			AccountID:     req.AccountID,
+		})
+		if err != nil {
+			writePayoutErr(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, item)
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+// ============================================================
+// Batches
+// ============================================================
+
+func (h *PayoutHandler) serveBatches(
+	w http.ResponseWriter,
+	r *http.Request,
+	parts []string,
+) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			h.listBatches(w, r)
+		case http.MethodPost:
+			h.createBatch(w, r)
+		default:
+			methodNotAllowed(w)
+		}
+		return
+	}
+
+	id := strings.TrimSpace(parts[0])
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+
+		item, err := h.uc.GetBatch(r.Context(), id)
+		if err != nil {
+			writePayoutErr(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, item)
+
+	case len(parts) == 2 && parts[1] == "export":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+
+		file, err := h.uc.ExportTransferFile(r.Context(), id)
+		if err != nil {
+			writePayoutErr(w, err)
+			return
+		}
+
+		writePayoutFile(w, file)
+
+	case len(parts) == 2:
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+		h.transition(w, r, id, parts[1])
+
+	case len(parts) == 4 && parts[1] == "statements":
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+
+		file, err := h.uc.Statement(
+			r.Context(),
+			id,
+			payoutdom.RecipientType(strings.TrimSpace(parts[2])),
+			strings.TrimSpace(parts[3]),
+		)
+		if err != nil {
+			writePayoutErr(w, err)
+			return
+		}
+
+		writePayoutFile(w, file)
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (h *PayoutHandler) listBatches(w http.ResponseWriter, r *http.Request) {
+	status := payoutdom.BatchStatus(strings.TrimSpace(r.URL.Query().Get("status")))
+
+	items, err := h.uc.ListBatches(r.Context(), status)
+	if err != nil {
+		writePayoutErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+type createPayoutBatchRequest struct {
+	// CutoffAt は RFC3339。省略時は現在時刻。
+	CutoffAt string `json:"cutoffAt"`
+
+	// TransferDate は振込指定日（YYYY-MM-DD, JST）。
+	TransferDate string `json:"transferDate"`
+}
+
+func (h *PayoutHandler) createBatch(w http.ResponseWriter, r *http.Request) {
+	var req createPayoutBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	var cutoffAt time.Time
+	if v := strings.TrimSpace(req.CutoffAt); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid cutoffAt")
+			return
+		}
+		cutoffAt = t
+	}
+
+	transferDate, err := time.ParseInLocation(
+		"2006-01-02",
+		strings.TrimSpace(req.TransferDate),
+		h.location,
+	)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid transferDate")
+		return
+	}
+
+	result, err := h.uc.CreateBatch(r.Context(), usecase.CreatePayoutBatchInput{
+		CutoffAt:     cutoffAt,
+		TransferDate: transferDate,
+	})
+	if err != nil {
+		writePayoutErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+func (h *PayoutHandler) transition(
+	w http.ResponseWriter,
+	r *http.Request,
+	id string,
+	action string,
+) {
+	var (
+		item payoutdom.Batch
+		err  error
+	)
+
+	switch action {
+	case "approve":
+		item, err = h.uc.ApproveBatch(r.Context(), id)
+	case "cancel":
+		item, err = h.uc.CancelBatch(r.Context(), id)
+	case "paid":
+		item, err = h.uc.MarkBatchPaid(r.Context(), id)
+	default:
+		writeNotFound(w)
+		return
+	}
+	if err != nil {
+		writePayoutErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+func writePayoutFile(w http.ResponseWriter, file usecase.PayoutFile) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set(
+		"Content-Disposition",
+		`attachment; filename="`+file.FileName+`"`,
+	)
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(file.Content)
+}
+
+func writePayoutErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+
+	case errors.Is(err, payoutdom.ErrInvalidID),
+		errors.Is(err, payoutdom.ErrInvalidBatchID),
+		errors.Is(err, payoutdom.ErrInvalidBatchStatus),
+		errors.Is(err, payoutdom.ErrInvalidCompanyID),
+		errors.Is(err, payoutdom.ErrInvalidRecipientType),
+		errors.Is(err, payoutdom.ErrInvalidRecipientID),
+		errors.Is(err, payoutdom.ErrInvalidAccountID),
+		errors.Is(err, payoutdom.ErrInvalidTransferDate),
+		errors.Is(err, payoutdom.ErrInvalidCreatedBy),
+		errors.Is(err, usecase.ErrPayoutAccountNotReady):
+		code = http.StatusBadRequest
+
+	case errors.Is(err, payoutdom.ErrNotFound),
+		errors.Is(err, accdom.ErrNotFound),
+		errors.Is(err, usecase.ErrPayoutRecipientNotInBatch),
+		errors.Is(err, usecase.ErrPayoutAccountNotOwned):
+		code = http.StatusNotFound
+
+	case errors.Is(err, payoutdom.ErrConflict),
+		errors.Is(err, payoutdom.ErrInvalidTransition),
+		errors.Is(err, payoutdom.ErrEmptyBatch),
+		errors.Is(err, payoutdom.ErrTooManyEntries):
+		code = http.StatusConflict
+
+	case errors.Is(err, payoutdom.ErrSelfApproval):
+		code = http.StatusForbidden
+
+	case errors.Is(err, usecase.ErrPayoutNotConfigured):
+		code = http.StatusNotImplemented
+	}
+
+	writeError(w, code, err.Error())
+}
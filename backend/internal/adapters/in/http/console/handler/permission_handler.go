@@ -3,16 +3,25 @@ package consoleHandler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	usecase "narratives/internal/application/usecase"
 	dcommon "narratives/internal/domain/common"
+	memberdom "narratives/internal/domain/member"
 	permissiondom "narratives/internal/domain/permission"
 )
 
 // PermissionHandler は /permissions 関連のエンドポイントを担当します。
+//   - GET /permissions
+//   - GET /permissions/{id}
+//   - GET /permissions/roles
+//   - POST /permissions/roles
+//   - PUT /permissions/roles/{id}
+//   - DELETE /permissions/roles/{id}
+//   - PUT /permissions/members/{memberId}/roles
 type PermissionHandler struct {
 	uc *usecase.PermissionUsecase
 }
@@ -26,7 +35,35 @@ func NewPermissionHandler(uc *usecase.PermissionUsecase) http.Handler {
 func (h *PermissionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
+	path := strings.TrimRight(r.URL.Path, "/")
+
 	switch {
+	// ロール一覧: GET /permissions/roles
+	case r.Method == http.MethodGet && path == "/permissions/roles":
+		h.listRoles(w, r)
+
+	// ロール作成: POST /permissions/roles
+	case r.Method == http.MethodPost && path == "/permissions/roles":
+		h.saveRole(w, r, "")
+
+	// ロール更新: PUT /permissions/roles/{id}
+	case r.Method == http.MethodPut && strings.HasPrefix(path, "/permissions/roles/"):
+		h.saveRole(w, r, strings.TrimPrefix(path, "/permissions/roles/"))
+
+	// ロール削除: DELETE /permissions/roles/{id}
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/permissions/roles/"):
+		h.deleteRole(w, r, strings.TrimPrefix(path, "/permissions/roles/"))
+
+	// メンバーへのロール割当: PUT /permissions/members/{memberId}/roles
+	case r.Method == http.MethodPut &&
+		strings.HasPrefix(path, "/permissions/members/") &&
+		strings.HasSuffix(path, "/roles"):
+		memberID := strings.TrimSuffix(
+			strings.TrimPrefix(path, "/permissions/members/"),
+			"/roles",
+		)
+		h.assignMemberRoles(w, r, memberID)
+
 	// 一覧: GET /permissions または /permissions/
 	case r.Method == http.MethodGet &&
 		(r.URL.Path == "/permissions" || r.URL.Path == "/permissions/"):
@@ -115,14 +152,108 @@ func (h *PermissionHandler) get(w http.ResponseWriter, r *http.Request, id strin
 	_ = json.NewEncoder(w).Encode(perm)
 }
 
+// GET /permissions/roles
+func (h *PermissionHandler) listRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.uc.ListRoles(r.Context())
+	if err != nil {
+		writePermissionErr(w, err)
+		return
+	}
+
+	if roles == nil {
+		roles = []permissiondom.Role{}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]any{"items": roles})
+}
+
+type saveRoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// POST /permissions/roles, PUT /permissions/roles/{id}
+func (h *PermissionHandler) saveRole(w http.ResponseWriter, r *http.Request, id string) {
+	var req saveRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid json"})
+		return
+	}
+
+	role, err := h.uc.SaveRole(r.Context(), usecase.SaveRoleInput{
+		ID:          strings.TrimSpace(id),
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		writePermissionErr(w, err)
+		return
+	}
+
+	code := http.StatusOK
+	if id == "" {
+		code = http.StatusCreated
+	}
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(role)
+}
+
+// DELETE /permissions/roles/{id}
+func (h *PermissionHandler) deleteRole(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.uc.DeleteRole(r.Context(), id); err != nil {
+		writePermissionErr(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type assignMemberRolesRequest struct {
+	RoleIDs []string `json:"roleIds"`
+}
+
+// PUT /permissions/members/{memberId}/roles
+func (h *PermissionHandler) assignMemberRoles(w http.ResponseWriter, r *http.Request, memberID string) {
+	var req assignMemberRolesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid json"})
+		return
+	}
+
+	rec, err := h.uc.AssignMemberRoles(r.Context(), memberID, req.RoleIDs)
+	if err != nil {
+		writePermissionErr(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(toMemberResponse(rec.DocID, rec.Member))
+}
+
 // エラーハンドリング
 func writePermissionErr(w http.ResponseWriter, err error) {
 	code := http.StatusInternalServerError
-	switch err {
-	case permissiondom.ErrInvalidID:
+	switch {
+	case errors.Is(err, permissiondom.ErrInvalidID),
+		errors.Is(err, permissiondom.ErrInvalidRoleID),
+		errors.Is(err, permissiondom.ErrInvalidRoleName),
+		errors.Is(err, permissiondom.ErrUnknownPermission),
+		errors.Is(err, usecase.ErrPermissionCompanyRequired):
 		code = http.StatusBadRequest
-	case permissiondom.ErrNotFound:
+	case errors.Is(err, permissiondom.ErrBuiltinRole),
+		errors.Is(err, permissiondom.ErrSystemPermission):
+		code = http.StatusForbidden
+	case errors.Is(err, permissiondom.ErrNotFound),
+		errors.Is(err, permissiondom.ErrRoleNotFound),
+		errors.Is(err, memberdom.ErrNotFound):
 		code = http.StatusNotFound
+	case errors.Is(err, permissiondom.ErrConflict),
+		errors.Is(err, memberdom.ErrConflict):
+		code = http.StatusConflict
 	}
 	w.WriteHeader(code)
 	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
@@ -0,0 +1,239 @@
+// backend/internal/adapters/in/http/console/handler/print_label_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	printdom "narratives/internal/domain/print"
+	productdom "narratives/internal/domain/product"
+	productiondom "narratives/internal/domain/production"
+)
+
+// PrintLabelHandler は工場向けの QR ラベル印刷 API です:
+//   - GET    /products/print-labels?productionId=&templateId=   (A4 ラベルシート PDF)
+//   - GET    /products/qr/{productId}?format=png|svg&size=512   (QR コード画像)
+//   - GET    /products/print-label-templates                    (一覧 + 組み込みの default)
+//   - POST   /products/print-label-templates
+//   - GET    /products/print-label-templates/{id}
+//   - PUT    /products/print-label-templates/{id}
+//   - DELETE /products/print-label-templates/{id}
+type PrintLabelHandler struct {
+	uc *usecase.PrintLabelUsecase
+}
+
+func NewPrintLabelHandler(uc *usecase.PrintLabelUsecase) http.Handler {
+	return &PrintLabelHandler{uc: uc}
+}
+
+const (
+	printLabelsPath         = "/products/print-labels"
+	productQRCodePath       = "/products/qr"
+	printLabelTemplatesPath = "/products/print-label-templates"
+)
+
+func (h *PrintLabelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "print_label_usecase_not_wired")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	switch {
+	case path == printLabelsPath:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.labelSheet(w, r)
+
+	case strings.HasPrefix(path, productQRCodePath+"/"):
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.qrCode(w, r, strings.TrimPrefix(path, productQRCodePath+"/"))
+
+	case path == printLabelTemplatesPath:
+		switch r.Method {
+		case http.MethodGet:
+			h.listTemplates(w, r)
+		case http.MethodPost:
+			h.createTemplate(w, r)
+		default:
+			methodNotAllowed(w)
+		}
+
+	case strings.HasPrefix(path, printLabelTemplatesPath+"/"):
+		id := strings.TrimPrefix(path, printLabelTemplatesPath+"/")
+		if id == "" || strings.Contains(id, "/") {
+			writeNotFound(w)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			t, err := h.uc.GetLabelTemplate(r.Context(), id)
+			if err != nil {
+				writePrintLabelErr(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, t)
+		case http.MethodPut:
+			h.updateTemplate(w, r, id)
+		case http.MethodDelete:
+			if err := h.uc.DeleteLabelTemplate(r.Context(), id); err != nil {
+				writePrintLabelErr(w, err)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (h *PrintLabelHandler) labelSheet(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	productionID := strings.Trim(q.Get("productionId"), " \t\r\n/")
+	if productionID == "" {
+		writeError(w, http.StatusBadRequest, "productionId query parameter is required")
+		return
+	}
+
+	pdf, err := h.uc.RenderLabelSheet(
+		r.Context(),
+		productionID,
+		strings.TrimSpace(q.Get("templateId")),
+	)
+	if err != nil {
+		writePrintLabelErr(w, err)
+		return
+	}
+
+	writePrintLabelFile(w, "application/pdf", "labels-"+productionID+".pdf", pdf)
+}
+
+func (h *PrintLabelHandler) qrCode(w http.ResponseWriter, r *http.Request, productID string) {
+	q := r.URL.Query()
+
+	format := usecase.QRCodeFormat(strings.ToLower(strings.TrimSpace(q.Get("format"))))
+
+	size := 0
+	if s := strings.TrimSpace(q.Get("size")); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "size must be a positive integer")
+			return
+		}
+		size = n
+	}
+
+	img, contentType, err := h.uc.RenderQRCode(r.Context(), productID, format, size)
+	if err != nil {
+		writePrintLabelErr(w, err)
+		return
+	}
+
+	ext := string(usecase.QRCodeFormatPNG)
+	if contentType == "image/svg+xml" {
+		ext = string(usecase.QRCodeFormatSVG)
+	}
+
+	writePrintLabelFile(w, contentType, "qr-"+productID+"."+ext, img)
+}
+
+func (h *PrintLabelHandler) listTemplates(w http.ResponseWriter, r *http.Request) {
+	items, err := h.uc.ListLabelTemplates(r.Context())
+	if err != nil {
+		writePrintLabelErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"default": printdom.DefaultLabelTemplate(),
+		"items":   items,
+	})
+}
+
+func (h *PrintLabelHandler) createTemplate(w http.ResponseWriter, r *http.Request) {
+	var req printdom.LabelTemplate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	t, err := h.uc.CreateLabelTemplate(r.Context(), req)
+	if err != nil {
+		writePrintLabelErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, t)
+}
+
+func (h *PrintLabelHandler) updateTemplate(w http.ResponseWriter, r *http.Request, id string) {
+	var req printdom.LabelTemplate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	t, err := h.uc.UpdateLabelTemplate(r.Context(), id, req)
+	if err != nil {
+		writePrintLabelErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, t)
+}
+
+func writePrintLabelFile(w http.ResponseWriter, contentType, fileName string, b []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set(
+		"Content-Disposition",
+		`inline; filename="`+fileName+`"`,
+	)
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b)
+}
+
+func writePrintLabelErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+	case errors.Is(err, printdom.ErrNotFound),
+		errors.Is(err, printdom.ErrLabelTemplateNotFound),
+		errors.Is(err, productdom.ErrNotFound),
+		errors.Is(err, productiondom.ErrNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, printdom.ErrInvalidPrintLogProductionID),
+		errors.Is(err, printdom.ErrInvalidPrintLogItems),
+		errors.Is(err, printdom.ErrInvalidLabelTemplateID),
+		errors.Is(err, printdom.ErrInvalidLabelTemplateName),
+		errors.Is(err, printdom.ErrInvalidLabelTemplateLayout),
+		errors.Is(err, printdom.ErrLabelTemplateDoesNotFit),
+		errors.Is(err, printdom.ErrLabelTemplateQRTooSmall),
+		errors.Is(err, usecase.ErrPrintLabelInvalidFormat),
+		errors.Is(err, usecase.ErrPrintLabelInvalidProduct):
+		code = http.StatusBadRequest
+	case errors.Is(err, printdom.ErrLabelTemplateReadOnly):
+		code = http.StatusConflict
+	}
+
+	writeError(w, code, err.Error())
+}
@@ -41,6 +41,9 @@ func (h *ProductBlueprintHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		h.list(w, r)
 	case r.Method == http.MethodPost && path == "/product-blueprints":
 		h.post(w, r)
+	case (r.Method == http.MethodPut || r.Method == http.MethodPatch) && strings.HasPrefix(path, "/product-blueprints/") && strings.HasSuffix(path, "/customs"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/product-blueprints/"), "/customs")
+		h.updateCustoms(w, r, id)
 	case (r.Method == http.MethodPut || r.Method == http.MethodPatch) && strings.HasPrefix(path, "/product-blueprints/"):
 		id := strings.TrimPrefix(path, "/product-blueprints/")
 		h.update(w, r, id)
@@ -112,6 +115,24 @@ type UpdateProductBlueprintInput struct {
 	AssigneeId   string            `json:"assigneeId"`
 }
 
+// ---------------------------------------------------
+// PUT/PATCH /product-blueprints/{id}/customs
+// ---------------------------------------------------
+
+type ProductBlueprintCustomsInput struct {
+	HSCode        string `json:"hsCode"`
+	Description   string `json:"description"`
+	OriginCountry string `json:"originCountry"`
+	DeclaredValue int64  `json:"declaredValue"`
+}
+
+type ProductBlueprintCustomsOutput struct {
+	HSCode        string `json:"hsCode"`
+	Description   string `json:"description"`
+	OriginCountry string `json:"originCountry"`
+	DeclaredValue int64  `json:"declaredValue"`
+}
+
 // ---------------------------------------------------
 // GET /product-blueprints
 // ---------------------------------------------------
@@ -214,6 +235,9 @@ type ProductBlueprintDetailOutput struct {
 	AssigneeName string `json:"assigneeName"`
 	Printed      bool   `json:"printed"`
 
+	// Customsは国外発送時の税関告知情報です。未設定の場合は省略します。
+	Customs *ProductBlueprintCustomsOutput `json:"customs,omitempty"`
+
 	CreatedBy     string `json:"createdBy"`
 	CreatedByName string `json:"createdByName"`
 	CreatedAt     string `json:"createdAt"`
@@ -388,6 +412,58 @@ func (h *ProductBlueprintHandler) update(w http.ResponseWriter, r *http.Request,
 	_ = json.NewEncoder(w).Encode(output)
 }
 
+// ---------------------------------------------------
+// PUT/PATCH /product-blueprints/{id}/customs
+// ---------------------------------------------------
+
+// updateCustomsは税関情報だけを更新します。印刷済みでも更新できます。
+func (h *ProductBlueprintHandler) updateCustoms(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	if !validProductBlueprintID(id) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid id"})
+		return
+	}
+
+	var input ProductBlueprintCustomsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid json"})
+		return
+	}
+
+	updated, err := h.uc.UpdateCustoms(
+		ctx,
+		id,
+		pbdom.CustomsInfo{
+			HSCode:        input.HSCode,
+			Description:   input.Description,
+			OriginCountry: input.OriginCountry,
+			DeclaredValue: input.DeclaredValue,
+		},
+		memberIDPointerFromContext(ctx),
+	)
+	if err != nil {
+		writeProductBlueprintErr(w, err)
+		return
+	}
+
+	row, err := h.detailQuery.GetByID(ctx, updated.ID)
+	if err != nil {
+		writeProductBlueprintErr(w, err)
+		return
+	}
+
+	output, err := h.toDetailOutput(row)
+	if err != nil {
+		writeProductBlueprintErr(w, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(output)
+}
+
 // ---------------------------------------------------
 // DELETE /product-blueprints/{id}
 // ---------------------------------------------------
@@ -605,6 +681,16 @@ func (h *ProductBlueprintHandler) toDetailOutput(
 		}
 	}
 
+	var customs *ProductBlueprintCustomsOutput
+	if !productBlueprint.Customs.IsZero() {
+		customs = &ProductBlueprintCustomsOutput{
+			HSCode:        productBlueprint.Customs.HSCode,
+			Description:   productBlueprint.Customs.Description,
+			OriginCountry: productBlueprint.Customs.ResolvedOriginCountry(),
+			DeclaredValue: productBlueprint.Customs.DeclaredValue,
+		}
+	}
+
 	return ProductBlueprintDetailOutput{
 		ID:          productBlueprint.ID,
 		ProductName: productBlueprint.ProductName,
@@ -621,6 +707,7 @@ func (h *ProductBlueprintHandler) toDetailOutput(
 		AssigneeId:     productBlueprint.AssigneeID,
 		AssigneeName:   resolved.Names.AssigneeName,
 		Printed:        productBlueprint.Printed,
+		Customs:        customs,
 		CreatedBy:      createdBy,
 		CreatedByName:  resolved.Names.CreatedByName,
 		CreatedAt:      createdAt,
@@ -11,21 +11,25 @@ import (
 	"narratives/internal/adapters/in/http/middleware"
 	companyquery "narratives/internal/application/query/console"
 	usecase "narratives/internal/application/usecase"
+	productbpdom "narratives/internal/domain/productBlueprint"
 	productiondom "narratives/internal/domain/production"
 )
 
 type ProductionHandler struct {
-	query *companyquery.CompanyProductionQueryService
-	uc    *usecase.ProductionUsecase
+	query    *companyquery.CompanyProductionQueryService
+	progress *companyquery.ProductionProgressQuery
+	uc       *usecase.ProductionUsecase
 }
 
 func NewProductionHandler(
 	companyProductionQueryService *companyquery.CompanyProductionQueryService,
+	progressQuery *companyquery.ProductionProgressQuery,
 	uc *usecase.ProductionUsecase,
 ) http.Handler {
 	return &ProductionHandler{
-		query: companyProductionQueryService,
-		uc:    uc,
+		query:    companyProductionQueryService,
+		progress: progressQuery,
+		uc:       uc,
 	}
 }
 
@@ -42,6 +46,9 @@ type createProductionRequest struct {
 	ProductBlueprintID string                   `json:"productBlueprintId"`
 	AssigneeID         string                   `json:"assigneeId"`
 	Models             []productionModelRequest `json:"models"`
+	TargetStartAt      *time.Time               `json:"targetStartAt,omitempty"`
+	TargetInspectedAt  *time.Time               `json:"targetInspectedAt,omitempty"`
+	TargetCompletedAt  *time.Time               `json:"targetCompletedAt,omitempty"`
 }
 
 type updateProductionRequest struct {
@@ -53,6 +60,16 @@ type updateProductionRequest struct {
 	UpdatedBy  *string                  `json:"updatedBy,omitempty"`
 }
 
+type changeProductionStatusRequest struct {
+	Status string `json:"status"`
+}
+
+type setProductionTargetsRequest struct {
+	TargetStartAt     *time.Time `json:"targetStartAt"`
+	TargetInspectedAt *time.Time `json:"targetInspectedAt"`
+	TargetCompletedAt *time.Time `json:"targetCompletedAt"`
+}
+
 func (m productionModelRequest) toCommand() usecase.ModelQuantityCommand {
 	return usecase.ModelQuantityCommand{
 		ModelID:  m.ModelID,
@@ -73,6 +90,9 @@ func (req createProductionRequest) toCommand(createdBy string) usecase.CreatePro
 		ProductBlueprintID: req.ProductBlueprintID,
 		AssigneeID:         req.AssigneeID,
 		Models:             productionModelRequestsToCommands(req.Models),
+		TargetStartAt:      req.TargetStartAt,
+		TargetInspectedAt:  req.TargetInspectedAt,
+		TargetCompletedAt:  req.TargetCompletedAt,
 		CreatedBy:          &createdBy,
 	}
 }
@@ -106,6 +126,21 @@ func (h *ProductionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case r.Method == http.MethodPost && r.URL.Path == "/productions":
 		h.postProduction(w, r)
 
+	case r.Method == http.MethodGet && r.URL.Path == "/productions/overdue":
+		h.listOverdueProductions(w, r)
+
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/progress"):
+		id := productionSubresourceID(r.URL.Path, "/progress")
+		h.getProductionProgress(w, r, id)
+
+	case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/status"):
+		id := productionSubresourceID(r.URL.Path, "/status")
+		h.changeProductionStatus(w, r, id)
+
+	case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/targets"):
+		id := productionSubresourceID(r.URL.Path, "/targets")
+		h.setProductionTargets(w, r, id)
+
 	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/productions/"):
 		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/productions/"), "/")
 		h.getProduction(w, r, id)
@@ -304,6 +339,164 @@ func (h *ProductionHandler) deleteProduction(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ========================================
+// GET /productions/overdue
+// ========================================
+
+func (h *ProductionHandler) listOverdueProductions(w http.ResponseWriter, r *http.Request) {
+	if h.progress == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "progress query is nil"})
+		return
+	}
+
+	rows, err := h.progress.ListOverdueProductions(r.Context())
+	if err != nil {
+		writeProductionErr(w, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(rows)
+}
+
+// ========================================
+// GET /productions/{id}/progress
+// ========================================
+
+func (h *ProductionHandler) getProductionProgress(w http.ResponseWriter, r *http.Request, id string) {
+	if h.progress == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "progress query is nil"})
+		return
+	}
+
+	if id == "" || strings.Contains(id, "/") {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid id"})
+		return
+	}
+
+	progress, err := h.progress.GetProductionProgress(r.Context(), id)
+	if err != nil {
+		writeProductionErr(w, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(progress)
+}
+
+// ========================================
+// PATCH /productions/{id}/status
+// ========================================
+
+func (h *ProductionHandler) changeProductionStatus(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	if h.uc == nil || h.query == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "usecase is nil"})
+		return
+	}
+
+	if id == "" || strings.Contains(id, "/") {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid id"})
+		return
+	}
+
+	var req changeProductionStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid json"})
+		return
+	}
+
+	uid, _, ok := middleware.CurrentUIDAndEmail(r)
+	if !ok || uid == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	// 他社の production は not found
+	if _, err := h.query.GetProductionDetailByID(ctx, id); err != nil {
+		writeProductionErr(w, err)
+		return
+	}
+
+	updated, err := h.uc.ChangeStatus(ctx, usecase.ChangeProductionStatusCommand{
+		ID:        id,
+		Status:    productiondom.Status(req.Status),
+		UpdatedBy: &uid,
+	})
+	if err != nil {
+		writeProductionErr(w, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(updated)
+}
+
+// ========================================
+// PUT /productions/{id}/targets
+// ========================================
+
+func (h *ProductionHandler) setProductionTargets(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	if h.uc == nil || h.query == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "usecase is nil"})
+		return
+	}
+
+	if id == "" || strings.Contains(id, "/") {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid id"})
+		return
+	}
+
+	var req setProductionTargetsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid json"})
+		return
+	}
+
+	uid, _, ok := middleware.CurrentUIDAndEmail(r)
+	if !ok || uid == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
+		return
+	}
+
+	// 他社の production は not found
+	if _, err := h.query.GetProductionDetailByID(ctx, id); err != nil {
+		writeProductionErr(w, err)
+		return
+	}
+
+	updated, err := h.uc.SetTargets(ctx, usecase.SetProductionTargetsCommand{
+		ID:                id,
+		TargetStartAt:     req.TargetStartAt,
+		TargetInspectedAt: req.TargetInspectedAt,
+		TargetCompletedAt: req.TargetCompletedAt,
+		UpdatedBy:         &uid,
+	})
+	if err != nil {
+		writeProductionErr(w, err)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(updated)
+}
+
+func productionSubresourceID(path, suffix string) string {
+	return strings.Trim(strings.TrimSuffix(strings.TrimPrefix(path, "/productions/"), suffix), "/")
+}
+
 // ========================================
 // Error
 // ========================================
@@ -320,13 +513,17 @@ func writeProductionErr(w http.ResponseWriter, err error) {
 		errors.Is(err, productiondom.ErrInvalidQuantity),
 		errors.Is(err, productiondom.ErrInvalidPrintedAt),
 		errors.Is(err, productiondom.ErrInvalidPrintedBy),
-		errors.Is(err, productiondom.ErrInvalidCreatedAt):
+		errors.Is(err, productiondom.ErrInvalidCreatedAt),
+		errors.Is(err, productiondom.ErrInvalidStatus),
+		errors.Is(err, productiondom.ErrInvalidTargetDates),
+		errors.Is(err, productbpdom.ErrInvalidCompanyID):
 		code = http.StatusBadRequest
 
 	case errors.Is(err, productiondom.ErrNotFound):
 		code = http.StatusNotFound
 
-	case errors.Is(err, productiondom.ErrConflict):
+	case errors.Is(err, productiondom.ErrConflict),
+		errors.Is(err, productiondom.ErrInvalidStatusTransition):
 		code = http.StatusConflict
 	}
 
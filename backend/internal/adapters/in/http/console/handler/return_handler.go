@@ -0,0 +1,270 @@
+// backend/internal/adapters/in/http/console/handler/return_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	returndom "narratives/internal/domain/orderReturn"
+	refunddom "narratives/internal/domain/refund"
+	transportationdom "narratives/internal/domain/transportation"
+)
+
+// ReturnHandler handles brand-side return requests:
+//   - GET  /returns?status=
+//   - GET  /returns/{id}
+//   - POST /returns/{id}/approve
+//   - POST /returns/{id}/reject
+//   - POST /returns/{id}/receive
+//   - POST /returns/{id}/complete
+type ReturnHandler struct {
+	uc *usecase.ReturnUsecase
+}
+
+func NewReturnHandler(uc *usecase.ReturnUsecase) http.Handler {
+	return &ReturnHandler{uc: uc}
+}
+
+const returnsPath = "/returns"
+
+func (h *ReturnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "return_usecase_not_wired")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	if path == returnsPath {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.list(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(path, returnsPath+"/") {
+		writeNotFound(w)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, returnsPath+"/"), "/")
+	id := strings.TrimSpace(parts[0])
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	if len(parts) == 1 {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.get(w, r, id)
+		return
+	}
+
+	if len(parts) != 2 {
+		writeNotFound(w)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	switch parts[1] {
+	case "approve":
+		h.approve(w, r, id)
+	case "reject":
+		h.reject(w, r, id)
+	case "receive":
+		h.receive(w, r, id)
+	case "complete":
+		h.complete(w, r, id)
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (h *ReturnHandler) list(w http.ResponseWriter, r *http.Request) {
+	status := returndom.Status(strings.TrimSpace(r.URL.Query().Get("status")))
+
+	items, err := h.uc.ListForCompany(r.Context(), status)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	if items == nil {
+		items = []returndom.Return{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// get returns the request together with the buyer's photos.
+func (h *ReturnHandler) get(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	item, err := h.uc.GetForCompany(ctx, id)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	images, err := h.uc.ListImagesForCompany(ctx, id)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	if images == nil {
+		images = []returndom.ReturnImage{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"return": item,
+		"images": images,
+	})
+}
+
+type approveReturnRequest struct {
+	Resolution string `json:"resolution"`
+	Carrier    string `json:"carrier"`
+	Note       string `json:"note"`
+}
+
+type returnNoteRequest struct {
+	Note string `json:"note"`
+}
+
+func (h *ReturnHandler) approve(w http.ResponseWriter, r *http.Request, id string) {
+	var req approveReturnRequest
+	if !decodeOptionalReturnBody(w, r, &req) {
+		return
+	}
+
+	item, err := h.uc.Approve(r.Context(), usecase.ApproveReturnInput{
+		ID:         id,
+		Resolution: returndom.Resolution(strings.TrimSpace(req.Resolution)),
+		Carrier:    transportationdom.Carrier(strings.TrimSpace(req.Carrier)),
+		Note:       req.Note,
+	})
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (h *ReturnHandler) reject(w http.ResponseWriter, r *http.Request, id string) {
+	var req returnNoteRequest
+	if !decodeOptionalReturnBody(w, r, &req) {
+		return
+	}
+
+	item, err := h.uc.Reject(r.Context(), id, req.Note)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+// receive records that the item arrived at the brand: returned NFTs are sent
+// back to the brand wallet and the products are restocked.
+func (h *ReturnHandler) receive(w http.ResponseWriter, r *http.Request, id string) {
+	item, err := h.uc.Receive(r.Context(), id)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+// complete issues the refund or creates the replacement order.
+func (h *ReturnHandler) complete(w http.ResponseWriter, r *http.Request, id string) {
+	var req returnNoteRequest
+	if !decodeOptionalReturnBody(w, r, &req) {
+		return
+	}
+
+	item, err := h.uc.Complete(r.Context(), id, req.Note)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+// decodeOptionalReturnBody は空 body を許容する（note なしの操作のため）。
+func decodeOptionalReturnBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if r.Body == nil || r.ContentLength == 0 {
+		return true
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return false
+	}
+
+	return true
+}
+
+func writeReturnErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+
+	case errors.Is(err, returndom.ErrInvalidID),
+		errors.Is(err, returndom.ErrInvalidStatus),
+		errors.Is(err, returndom.ErrInvalidResolution),
+		errors.Is(err, returndom.ErrInvalidComment),
+		errors.Is(err, transportationdom.ErrInvalidCarrier),
+		errors.Is(err, refunddom.ErrInvalidItems),
+		errors.Is(err, refunddom.ErrNothingToRefund),
+		errors.Is(err, refunddom.ErrQtyExceedsRemaining),
+		errors.Is(err, refunddom.ErrAmountExceedsPaid):
+		code = http.StatusBadRequest
+
+	case errors.Is(err, returndom.ErrNotFound):
+		code = http.StatusNotFound
+
+	case errors.Is(err, returndom.ErrConflict),
+		errors.Is(err, returndom.ErrInvalidTransition),
+		errors.Is(err, returndom.ErrNotRestocked),
+		errors.Is(err, returndom.ErrResolutionPending),
+		errors.Is(err, usecase.ErrReturnAlreadyRefunded),
+		errors.Is(err, usecase.ErrReturnTokenMismatch),
+		errors.Is(err, usecase.ErrRefundOrderNotPaid),
+		errors.Is(err, usecase.ErrRefundPaymentNotSucceeded),
+		errors.Is(err, refunddom.ErrItemNotRefundable),
+		errors.Is(err, refunddom.ErrConflict):
+		code = http.StatusConflict
+
+	case errors.Is(err, usecase.ErrReturnNotConfigured),
+		errors.Is(err, usecase.ErrReturnTokenNotConfigured):
+		code = http.StatusNotImplemented
+
+	case errors.Is(err, usecase.ErrRefundStripeFailed):
+		code = http.StatusBadGateway
+	}
+
+	writeError(w, code, err.Error())
+}
@@ -0,0 +1,100 @@
+// backend/internal/adapters/in/http/console/handler/royalty_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	royaltydom "narratives/internal/domain/royalty"
+)
+
+// RoyaltyHandler returns the company's resale royalty ledger:
+//   - GET /royalties?brandId=&from=&to=
+//
+// from / to are RFC3339 and filter accruedAt in [from, to).
+type RoyaltyHandler struct {
+	uc *usecase.RoyaltyUsecase
+}
+
+func NewRoyaltyHandler(uc *usecase.RoyaltyUsecase) http.Handler {
+	return &RoyaltyHandler{uc: uc}
+}
+
+const royaltiesPath = "/royalties"
+
+func (h *RoyaltyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "royalty_usecase_not_wired")
+		return
+	}
+
+	if strings.TrimSuffix(r.URL.Path, "/") != royaltiesPath {
+		writeNotFound(w)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	h.report(w, r)
+}
+
+func (h *RoyaltyHandler) report(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := royaltydom.ListFilter{
+		BrandID: strings.TrimSpace(q.Get("brandId")),
+	}
+
+	if v := strings.TrimSpace(q.Get("from")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from (expected RFC3339)")
+			return
+		}
+		filter.From = &t
+	}
+	if v := strings.TrimSpace(q.Get("to")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to (expected RFC3339)")
+			return
+		}
+		filter.To = &t
+	}
+
+	report, err := h.uc.ReportForCompany(r.Context(), filter)
+	if err != nil {
+		writeRoyaltyErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+func writeRoyaltyErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+
+	case errors.Is(err, royaltydom.ErrInvalidCompanyID),
+		errors.Is(err, royaltydom.ErrInvalidPeriod):
+		code = http.StatusBadRequest
+
+	case errors.Is(err, usecase.ErrRoyaltyNotConfigured):
+		code = http.StatusNotImplemented
+	}
+
+	writeError(w, code, err.Error())
+}
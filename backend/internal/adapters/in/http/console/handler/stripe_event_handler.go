@@ -0,0 +1,179 @@
+// backend/internal/adapters/in/http/console/handler/stripe_event_handler.go
+package consoleHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	stripeeventdom "narratives/internal/domain/stripeEvent"
+)
+
+// StripeEventHandler handles the stored Stripe webhook events（運営のみ）:
+//   - GET  /stripe-events?type=&status=&limit=
+//   - GET  /stripe-events/{eventId}
+//   - POST /stripe-events/{eventId}/replay
+type StripeEventHandler struct {
+	uc *usecase.StripeWebhookEventUsecase
+}
+
+func NewStripeEventHandler(uc *usecase.StripeWebhookEventUsecase) http.Handler {
+	return &StripeEventHandler{uc: uc}
+}
+
+const stripeEventsPath = "/stripe-events"
+
+// stripeEventResponse は payload を JSON としてそのまま返します。
+type stripeEventResponse struct {
+	stripeeventdom.Event
+
+	Payload rawJSON `json:"payload,omitempty"`
+}
+
+type rawJSON []byte
+
+func (j rawJSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+func (h *StripeEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if h == nil || h.uc == nil {
+		writeError(w, http.StatusInternalServerError, "stripe_event_usecase_not_wired")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	if path == stripeEventsPath {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.list(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(path, stripeEventsPath+"/") {
+		writeNotFound(w)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, stripeEventsPath+"/"), "/")
+	eventID := strings.TrimSpace(parts[0])
+
+	switch {
+	case len(parts) == 1:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+
+		e, err := h.uc.Get(r.Context(), eventID)
+		if err != nil {
+			writeStripeEventErr(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, toStripeEventResponse(e, true))
+
+	case len(parts) == 2 && parts[1] == "replay":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+
+		e, err := h.uc.Replay(r.Context(), eventID)
+		if err != nil {
+			// 再処理の失敗は failed として保存済み。結果と理由を返す。
+			if e.ID != "" && e.Status == stripeeventdom.StatusFailed {
+				writeJSON(w, http.StatusBadGateway, map[string]any{
+					"error": err.Error(),
+					"event": toStripeEventResponse(e, false),
+				})
+				return
+			}
+
+			writeStripeEventErr(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, toStripeEventResponse(e, false))
+
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (h *StripeEventHandler) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := stripeeventdom.ListFilter{
+		Type:   strings.TrimSpace(q.Get("type")),
+		Status: stripeeventdom.Status(strings.TrimSpace(q.Get("status"))),
+	}
+
+	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		filter.Limit = n
+	}
+
+	items, err := h.uc.List(r.Context(), filter)
+	if err != nil {
+		writeStripeEventErr(w, err)
+		return
+	}
+
+	out := make([]stripeEventResponse, 0, len(items))
+	for _, e := range items {
+		out = append(out, toStripeEventResponse(e, false))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": out})
+}
+
+func toStripeEventResponse(
+	e stripeeventdom.Event,
+	withPayload bool,
+) stripeEventResponse {
+	out := stripeEventResponse{Event: e}
+	if withPayload {
+		out.Payload = rawJSON(e.Payload)
+	}
+	return out
+}
+
+func writeStripeEventErr(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusRequestTimeout
+
+	case errors.Is(err, stripeeventdom.ErrInvalidStatus):
+		code = http.StatusBadRequest
+
+	case errors.Is(err, stripeeventdom.ErrNotFound):
+		code = http.StatusNotFound
+
+	case errors.Is(err, usecase.ErrStripeEventForbidden):
+		code = http.StatusForbidden
+
+	case errors.Is(err, usecase.ErrStripeEventNotConfigured):
+		code = http.StatusNotImplemented
+	}
+
+	writeError(w, code, err.Error())
+}
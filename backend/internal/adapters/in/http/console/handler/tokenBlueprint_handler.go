@@ -78,6 +78,8 @@ type createTokenBlueprintRequest struct {
 	IconSize        int64  `json:"iconSize,omitempty"`
 
 	ContentFiles []tbdom.ContentFile `json:"contentFiles,omitempty"`
+
+	Royalty tbdom.RoyaltyPolicy `json:"royalty"`
 }
 
 type updateTokenBlueprintRequest struct {
@@ -96,6 +98,8 @@ type updateTokenBlueprintRequest struct {
 	ContentFiles *[]tbdom.ContentFile `json:"contentFiles,omitempty"`
 	MetadataURI  *string              `json:"metadataUri,omitempty"`
 	Minted       *bool                `json:"minted,omitempty"`
+
+	Royalty *tbdom.RoyaltyPolicy `json:"royalty,omitempty"`
 }
 
 type contentFileResponse struct {
@@ -135,6 +139,8 @@ type tokenBlueprintResponse struct {
 	IconContentType string `json:"iconContentType,omitempty"`
 	IconSize        int64  `json:"iconSize,omitempty"`
 
+	Royalty tbdom.RoyaltyPolicy `json:"royalty"`
+
 	// Deprecated: content files are returned via contentFiles[].url.
 	ContentsURL string `json:"contentsUrl,omitempty"`
 }
@@ -234,6 +240,8 @@ func (h *TokenBlueprintHandler) toResponse(
 		IconContentType: tb.IconContentType,
 		IconSize:        tb.IconSize,
 
+		Royalty: tb.Royalty,
+
 		ContentsURL: "",
 	}
 }
@@ -338,6 +346,8 @@ func (h *TokenBlueprintHandler) create(w http.ResponseWriter, r *http.Request) {
 		IconSize:        req.IconSize,
 
 		ContentFiles: req.ContentFiles,
+
+		Royalty: req.Royalty,
 	})
 	if err != nil {
 		writeTokenBlueprintErr(w, err)
@@ -486,6 +496,7 @@ func (h *TokenBlueprintHandler) update(w http.ResponseWriter, r *http.Request, i
 		ContentFiles: req.ContentFiles,
 		MetadataURI:  req.MetadataURI,
 		Minted:       req.Minted,
+		Royalty:      req.Royalty,
 		UpdatedBy:    actorMemberID,
 	})
 	if err != nil {
@@ -538,7 +549,8 @@ func writeTokenBlueprintErr(w http.ResponseWriter, err error) {
 		_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
 
-	case errors.Is(err, tbdom.ErrConflict):
+	case errors.Is(err, tbdom.ErrConflict),
+		errors.Is(err, tbdom.ErrAlreadyMinted):
 		w.WriteHeader(http.StatusConflict)
 		_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
@@ -557,7 +569,8 @@ func writeTokenBlueprintErr(w http.ResponseWriter, err error) {
 		errors.Is(err, tbdom.ErrInvalidIconContentType),
 		errors.Is(err, tbdom.ErrInvalidIconSize),
 		errors.Is(err, tbdom.ErrInvalidContentFile),
-		errors.Is(err, tbdom.ErrInvalidContentType):
+		errors.Is(err, tbdom.ErrInvalidContentType),
+		errors.Is(err, tbdom.ErrInvalidRoyalty):
 		w.WriteHeader(http.StatusBadRequest)
 		_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
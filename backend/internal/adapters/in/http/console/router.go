@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"narratives/internal/adapters/in/http/middleware"
+	permissiondom "narratives/internal/domain/permission"
 )
 
 // RouterDeps は「ルーティングに必要なもの」だけを受け取る。
@@ -12,8 +13,9 @@ import (
 // 生成済みの http.Handler と Middleware だけを渡す。
 type RouterDeps struct {
 	// Middlewares（生成はDI側）
-	AuthMw      *middleware.AuthMiddleware
-	BootstrapMw *middleware.BootstrapAuthMiddleware
+	AuthMw       *middleware.AuthMiddleware
+	BootstrapMw  *middleware.BootstrapAuthMiddleware
+	PermissionMw *middleware.PermissionMiddleware
 
 	// Handlers（生成はDI側）
 	AuthBootstrap            http.Handler
@@ -34,6 +36,8 @@ type RouterDeps struct {
 	TokenBP                  http.Handler
 	Messages                 http.Handler
 	Orders                   http.Handler
+	Returns                  http.Handler
+	Coupons                  http.Handler
 	Wallets                  http.Handler
 	Members                  http.Handler
 	Productions              http.Handler
@@ -67,6 +71,10 @@ type RouterDeps struct {
 	// endpoint:
 	//   POST /internal/order-dispatch-notifications/dispatch-due
 	//
+	// Cloud Scheduler等から呼ばれる期限切れ在庫引当の解放用です。
+	// endpoint:
+	//   POST /internal/inventory-reservations/release-expired
+	//
 	// 注意:
 	// - 通常のConsole Firebase Authではなく、Cloud Tasks OIDC / Cloud Run Invoker
 	//   または各internal handlerの認証処理で保護します。
@@ -76,12 +84,55 @@ type RouterDeps struct {
 	InternalInvitationDeliveryDispatch        http.Handler
 	InternalOrderDispatchNotificationProcess  http.Handler
 	InternalOrderDispatchNotificationDispatch http.Handler
+	InternalInventoryReservationRelease       http.Handler
 
 	OwnerResolve    http.Handler
 	Invitation      http.Handler
 	Sales           http.Handler
 	TokenBPReview   http.Handler
 	ProductBPReview http.Handler
+
+	Royalties http.Handler
+
+	// Cloud Scheduler等から呼ばれる期限切れofferの失効用です（internal handlerで認証）。
+	// endpoint:
+	//   POST /internal/offers/expire-due
+	InternalOfferExpire http.Handler
+
+	// resale escrow の紛争解決（brand 側）
+	Escrows http.Handler
+
+	// Cloud Scheduler等から呼ばれるescrowの自動受取確認・支払い確定用です（internal handlerで認証）。
+	// endpoint:
+	//   POST /internal/escrows/auto-confirm-due
+	InternalEscrowAutoConfirm http.Handler
+
+	// 支払い台帳・支払いバッチ・全銀協フォーマットの振込ファイル
+	Payouts http.Handler
+
+	// 手数料プラン・月次の請求明細書（CSV / PDF）
+	Billing http.Handler
+
+	// console からの書き込みの監査ログ（検索・CSV 出力）
+	AuditLogs http.Handler
+
+	// Cloud Scheduler等から呼ばれるoutboxイベントの再配信用です（internal handlerで認証）。
+	// endpoint:
+	//   POST /internal/outbox/relay
+	InternalOutboxRelay http.Handler
+
+	// 保存済みの Stripe webhook イベントの一覧・再処理（運営のみ）
+	StripeEvents http.Handler
+
+	// 製品ごとの NFC タグの鍵発行・UID 登録・無効化（/products/nfc-tags）
+	NFCTags http.Handler
+
+	// QR ラベルシート PDF・QR コード画像・ラベルテンプレート
+	// （/products/print-labels, /products/qr/{productId}, /products/print-label-templates）
+	PrintLabels http.Handler
+
+	// 検品の不良率・歩留まりレポート（/products/inspection-reports/{defects,yield}）
+	InspectionReports http.Handler
 }
 
 func NewRouter(deps RouterDeps) http.Handler {
@@ -95,6 +146,14 @@ func NewRouter(deps RouterDeps) http.Handler {
 		return deps.AuthMw.Handler(h)
 	}
 
+	// withPerm は AuthMiddleware の内側で route ごとの権限を強制する。
+	withPerm := func(h http.Handler, rules ...middleware.PermissionRule) http.Handler {
+		if deps.PermissionMw != nil {
+			h = deps.PermissionMw.Handler(rules...)(h)
+		}
+		return withAuth(h)
+	}
+
 	withBootstrap := func(h http.Handler) http.Handler {
 		h = middleware.CORS(h)
 		if deps.BootstrapMw == nil {
@@ -112,7 +171,10 @@ func NewRouter(deps RouterDeps) http.Handler {
 	}
 
 	if deps.Invitation != nil {
-		authInvitation := withAuth(deps.Invitation)
+		authInvitation := withPerm(
+			deps.Invitation,
+			writeRule("/invitations", permissiondom.NameMemberInvite),
+		)
 		mux.Handle("/invitations", authInvitation)
 
 		publicInvitation := withPublic(deps.Invitation)
@@ -121,49 +183,82 @@ func NewRouter(deps RouterDeps) http.Handler {
 	}
 
 	if deps.Accounts != nil {
-		h := withAuth(deps.Accounts)
+		h := withPerm(
+			deps.Accounts,
+			middleware.PermissionRule{
+				Pattern:    "/accounts/**",
+				Permission: permissiondom.NameOrganizationAccountView,
+			},
+		)
 		mux.Handle("/accounts", h)
 		mux.Handle("/accounts/", h)
 	}
 
 	if deps.Announcements != nil {
-		h := withAuth(deps.Announcements)
+		h := withPerm(
+			deps.Announcements,
+			writeRule("/announcements/**", permissiondom.NameCampaignAnnouncementUpdate),
+		)
 		mux.Handle("/announcements", h)
 		mux.Handle("/announcements/", h)
 	}
 
 	if deps.Permissions != nil {
-		h := withAuth(deps.Permissions)
+		h := withPerm(
+			deps.Permissions,
+			writeRule("/permissions/roles/**", permissiondom.NameMemberRolesAssign),
+			writeRule("/permissions/members/**", permissiondom.NameMemberRolesAssign),
+		)
 		mux.Handle("/permissions", h)
 		mux.Handle("/permissions/", h)
 	}
 
 	if deps.Brands != nil {
-		h := withAuth(deps.Brands)
+		h := withPerm(
+			deps.Brands,
+			writeRule("/brands/**", permissiondom.NameBrandUpdate),
+		)
 		mux.Handle("/brands", h)
 		mux.Handle("/brands/", h)
 	}
 
 	if deps.CompanyShippingAddresses != nil {
-		h := withAuth(deps.CompanyShippingAddresses)
+		h := withPerm(
+			deps.CompanyShippingAddresses,
+			writeRule("/companies/me/shipping-addresses/**", permissiondom.NameOrganizationSettingsUpdate),
+		)
 		mux.Handle("/companies/me/shipping-addresses", h)
 		mux.Handle("/companies/me/shipping-addresses/", h)
 	}
 
 	if deps.Companies != nil {
-		h := withAuth(deps.Companies)
+		h := withPerm(
+			deps.Companies,
+			middleware.PermissionRule{
+				Methods:    []string{http.MethodPatch, http.MethodDelete},
+				Pattern:    "/companies/**",
+				Permission: permissiondom.NameOrganizationSettingsUpdate,
+			},
+		)
 		mux.Handle("/companies", h)
 		mux.Handle("/companies/", h)
 	}
 
 	if deps.Inquiries != nil {
-		h := withAuth(deps.Inquiries)
+		h := withPerm(
+			deps.Inquiries,
+			writeRule("/inquiries/**", permissiondom.NameInquiryUpdate),
+		)
 		mux.Handle("/inquiries", h)
 		mux.Handle("/inquiries/", h)
 	}
 
 	if deps.Inventories != nil {
-		h := withAuth(deps.Inventories)
+		h := withPerm(
+			deps.Inventories,
+			writeRule("/inventories/**", permissiondom.NameInventoryUpdate),
+			writeRule("/inventory/**", permissiondom.NameInventoryUpdate),
+		)
 		mux.Handle("/inventories", h)
 		mux.Handle("/inventories/", h)
 		mux.Handle("/inventory", h)
@@ -171,108 +266,302 @@ func NewRouter(deps RouterDeps) http.Handler {
 	}
 
 	if deps.ListSaveOperations != nil {
-		h := withAuth(deps.ListSaveOperations)
+		h := withPerm(
+			deps.ListSaveOperations,
+			writeRule("/lists/save-operations/**", permissiondom.NameListPublish),
+		)
 		mux.Handle("/lists/save-operations", h)
 		mux.Handle("/lists/save-operations/", h)
 	}
 
 	if deps.Lists != nil {
-		h := withAuth(deps.Lists)
+		h := withPerm(
+			deps.Lists,
+			writeRule("/lists/**", permissiondom.NameListPublish),
+		)
 		mux.Handle("/lists", h)
 		mux.Handle("/lists/", h)
 	}
 
 	if deps.Transportation != nil {
-		h := withAuth(deps.Transportation)
+		h := withPerm(
+			deps.Transportation,
+			writeRule("/transportation/**", permissiondom.NameOrderShippingUpdate),
+		)
 		mux.Handle("/transportation", h)
 		mux.Handle("/transportation/", h)
 	}
 
 	if deps.ProductsPrint != nil {
-		h := withAuth(deps.ProductsPrint)
+		h := withPerm(
+			deps.ProductsPrint,
+			writeRule("/products/**", permissiondom.NameProductionUpdate),
+		)
 		mux.Handle("/products", h)
 		mux.Handle("/products/", h)
 	}
 
+	if deps.NFCTags != nil {
+		h := withPerm(
+			deps.NFCTags,
+			writeRule("/products/nfc-tags/**", permissiondom.NameProductionUpdate),
+		)
+		mux.Handle("/products/nfc-tags", h)
+		mux.Handle("/products/nfc-tags/", h)
+	}
+
+	if deps.PrintLabels != nil {
+		h := withPerm(
+			deps.PrintLabels,
+			writeRule("/products/print-label-templates/**", permissiondom.NameProductionUpdate),
+		)
+		mux.Handle("/products/print-labels", h)
+		mux.Handle("/products/qr/", h)
+		mux.Handle("/products/print-label-templates", h)
+		mux.Handle("/products/print-label-templates/", h)
+	}
+
+	if deps.InspectionReports != nil {
+		h := withPerm(
+			deps.InspectionReports,
+			middleware.PermissionRule{
+				Pattern:    "/products/inspection-reports/**",
+				Permission: permissiondom.NameProductionReportView,
+			},
+		)
+		mux.Handle("/products/inspection-reports/", h)
+	}
+
 	if deps.ProductBP != nil {
-		h := withAuth(deps.ProductBP)
+		h := withPerm(
+			deps.ProductBP,
+			writeRule("/product-blueprints/**", permissiondom.NameProductUpdate),
+		)
 		mux.Handle("/product-blueprints", h)
 		mux.Handle("/product-blueprints/", h)
 	}
 
 	if deps.ProductBPCategories != nil {
-		h := withAuth(deps.ProductBPCategories)
+		h := withPerm(
+			deps.ProductBPCategories,
+			writeRule("/console/product-blueprint-categories/**", permissiondom.NameProductUpdate),
+		)
 		mux.Handle("/console/product-blueprint-categories", h)
 		mux.Handle("/console/product-blueprint-categories/", h)
 	}
 
 	if deps.TokenBP != nil {
-		h := withAuth(deps.TokenBP)
+		h := withPerm(
+			deps.TokenBP,
+			writeRule("/token-blueprints/**", permissiondom.NameTokenUpdate),
+		)
 		mux.Handle("/token-blueprints", h)
 		mux.Handle("/token-blueprints/", h)
 	}
 
 	if deps.TokenBPReview != nil {
-		h := withAuth(deps.TokenBPReview)
+		h := withPerm(
+			deps.TokenBPReview,
+			writeRule("/token-blueprint-reviews/**", permissiondom.NameTokenReviewUpdate),
+		)
 		mux.Handle("/token-blueprint-reviews", h)
 		mux.Handle("/token-blueprint-reviews/", h)
 	}
 
 	if deps.ProductBPReview != nil {
-		h := withAuth(deps.ProductBPReview)
+		h := withPerm(
+			deps.ProductBPReview,
+			middleware.PermissionRule{
+				Pattern:    "/product-blueprint-reviews/**",
+				Permission: permissiondom.NameProductReviewView,
+			},
+		)
 		mux.Handle("/product-blueprint-reviews", h)
 		mux.Handle("/product-blueprint-reviews/", h)
 	}
 
 	if deps.Messages != nil {
-		h := withAuth(deps.Messages)
+		h := withPerm(
+			deps.Messages,
+			writeRule("/messages/**", permissiondom.NameMemberMessageUpdate),
+		)
 		mux.Handle("/messages", h)
 		mux.Handle("/messages/", h)
 	}
 
 	if deps.Orders != nil {
-		h := withAuth(deps.Orders)
+		h := withPerm(
+			deps.Orders,
+			middleware.PermissionRule{
+				Methods:    []string{http.MethodPatch},
+				Pattern:    "/orders/*/dispatch",
+				Permission: permissiondom.NameOrderDispatch,
+			},
+			middleware.PermissionRule{
+				Methods:    []string{http.MethodPatch},
+				Pattern:    "/orders/*/status",
+				Permission: permissiondom.NameOrderDispatch,
+			},
+			middleware.PermissionRule{
+				Methods:    []string{http.MethodPost},
+				Pattern:    "/orders/*/refunds",
+				Permission: permissiondom.NameOrderRefund,
+			},
+		)
 		mux.Handle("/orders", h)
 		mux.Handle("/orders/", h)
 	}
 
+	if deps.Returns != nil {
+		h := withPerm(
+			deps.Returns,
+			middleware.PermissionRule{
+				Methods:    []string{http.MethodPost},
+				Pattern:    "/returns/*/*",
+				Permission: permissiondom.NameOrderReturnApprove,
+			},
+		)
+		mux.Handle("/returns", h)
+		mux.Handle("/returns/", h)
+	}
+
+	if deps.Escrows != nil {
+		h := withPerm(
+			deps.Escrows,
+			middleware.PermissionRule{
+				Methods:    []string{http.MethodPost},
+				Pattern:    "/escrows/*/resolve",
+				Permission: permissiondom.NameOrderEscrowUpdate,
+			},
+		)
+		mux.Handle("/escrows", h)
+		mux.Handle("/escrows/", h)
+	}
+
+	if deps.Payouts != nil {
+		h := withPerm(
+			deps.Payouts,
+			middleware.PermissionRule{
+				Methods:    []string{http.MethodPost},
+				Pattern:    "/payouts/batches/*/approve",
+				Permission: permissiondom.NameOrderPayoutApprove,
+			},
+			writeRule("/payouts/**", permissiondom.NameOrderPayoutUpdate),
+		)
+		mux.Handle("/payouts/", h)
+	}
+
+	if deps.Billing != nil {
+		h := withPerm(
+			deps.Billing,
+			// 手数料プランは閲覧も運営のみ
+			middleware.PermissionRule{
+				Pattern:    "/billing/plans/**",
+				Permission: permissiondom.NameSystemBillingUpdate,
+			},
+			writeRule("/billing/**", permissiondom.NameOrganizationBillingUpdate),
+		)
+		mux.Handle("/billing/", h)
+	}
+
+	if deps.AuditLogs != nil {
+		h := withPerm(
+			deps.AuditLogs,
+			middleware.PermissionRule{
+				Pattern:    "/audit-logs/**",
+				Permission: permissiondom.NameOrganizationAuditView,
+			},
+		)
+		mux.Handle("/audit-logs", h)
+		mux.Handle("/audit-logs/", h)
+	}
+
+	if deps.StripeEvents != nil {
+		h := withPerm(
+			deps.StripeEvents,
+			middleware.PermissionRule{
+				Pattern:    "/stripe-events/**",
+				Permission: permissiondom.NameSystemPaymentUpdate,
+			},
+		)
+		mux.Handle("/stripe-events", h)
+		mux.Handle("/stripe-events/", h)
+	}
+
+	if deps.Coupons != nil {
+		h := withPerm(
+			deps.Coupons,
+			writeRule("/coupons/**", permissiondom.NameCampaignCouponUpdate),
+		)
+		mux.Handle("/coupons", h)
+		mux.Handle("/coupons/", h)
+	}
+
 	if deps.Wallets != nil {
-		h := withAuth(deps.Wallets)
+		h := withPerm(
+			deps.Wallets,
+			middleware.PermissionRule{
+				Pattern:    "/wallets/**",
+				Permission: permissiondom.NameWalletView,
+			},
+		)
 		mux.Handle("/wallets", h)
 		mux.Handle("/wallets/", h)
 	}
 
 	if deps.Members != nil {
-		mux.Handle("/members", withAuth(deps.Members))
+		memberRules := []middleware.PermissionRule{
+			{
+				Methods:    []string{http.MethodPost},
+				Pattern:    "/members",
+				Permission: permissiondom.NameMemberInvite,
+			},
+			writeRule("/members/*", permissiondom.NameMemberUpdate),
+		}
+
+		mux.Handle("/members", withPerm(deps.Members, memberRules...))
 
 		// /members/me は初回ログイン直後にも呼ばれるため、
 		// 通常AuthMiddlewareではなくBootstrapAuthMiddlewareを通す。
 		// member未作成時はhandler側で404を返す。
 		mux.Handle("/members/me", withBootstrap(deps.Members))
-		mux.Handle("/members/", withAuth(deps.Members))
+		mux.Handle("/members/", withPerm(deps.Members, memberRules...))
 	}
 
 	if deps.Productions != nil {
-		h := withAuth(deps.Productions)
+		h := withPerm(
+			deps.Productions,
+			writeRule("/productions/**", permissiondom.NameProductionUpdate),
+		)
 		mux.Handle("/productions", h)
 		mux.Handle("/productions/", h)
 	}
 
 	if deps.Models != nil {
-		h := withAuth(deps.Models)
+		h := withPerm(
+			deps.Models,
+			writeRule("/models/**", permissiondom.NameProductUpdate),
+		)
 		mux.Handle("/models", h)
 		mux.Handle("/models/", h)
 	}
 
 	if deps.Inspector != nil {
-		h := withAuth(deps.Inspector)
+		h := withPerm(
+			deps.Inspector,
+			writeRule("/inspector/**", permissiondom.NameProductionUpdate),
+			writeRule("/products/inspections/**", permissiondom.NameProductionUpdate),
+		)
 		mux.Handle("/inspector/products/", h)
 		mux.Handle("/products/inspections", h)
 		mux.Handle("/products/inspections/", h)
 	}
 
 	if deps.Mint != nil {
-		h := withAuth(deps.Mint)
+		h := withPerm(
+			deps.Mint,
+			writeRule("/mint/**", permissiondom.NameMintRequest),
+		)
 		mux.Handle("/mint", h)
 		mux.Handle("/mint/", h)
 	}
@@ -307,17 +596,70 @@ func NewRouter(deps RouterDeps) http.Handler {
 		mux.Handle("/internal/order-dispatch-notifications/dispatch-due", h)
 	}
 
+	if deps.InternalInventoryReservationRelease != nil {
+		h := withPublic(deps.InternalInventoryReservationRelease)
+		mux.Handle("/internal/inventory-reservations/release-expired", h)
+	}
+
+	if deps.InternalOfferExpire != nil {
+		h := withPublic(deps.InternalOfferExpire)
+		mux.Handle("/internal/offers/expire-due", h)
+	}
+
+	if deps.InternalEscrowAutoConfirm != nil {
+		h := withPublic(deps.InternalEscrowAutoConfirm)
+		mux.Handle("/internal/escrows/auto-confirm-due", h)
+	}
+
+	if deps.InternalOutboxRelay != nil {
+		h := withPublic(deps.InternalOutboxRelay)
+		mux.Handle("/internal/outbox/relay", h)
+	}
+
 	if deps.OwnerResolve != nil {
-		h := withAuth(deps.OwnerResolve)
+		h := withPerm(
+			deps.OwnerResolve,
+			middleware.PermissionRule{
+				Pattern:    "/owners/resolve/**",
+				Permission: permissiondom.NameWalletView,
+			},
+		)
 		mux.Handle("/owners/resolve", h)
 		mux.Handle("/owners/resolve/", h)
 	}
 
 	if deps.Sales != nil {
-		h := withAuth(deps.Sales)
+		h := withPerm(
+			deps.Sales,
+			middleware.PermissionRule{
+				Pattern:    "/sales/**",
+				Permission: permissiondom.NameAnalyticsSalesView,
+			},
+		)
 		mux.Handle("/sales", h)
 		mux.Handle("/sales/", h)
 	}
 
+	if deps.Royalties != nil {
+		h := withPerm(
+			deps.Royalties,
+			middleware.PermissionRule{
+				Pattern:    "/royalties/**",
+				Permission: permissiondom.NameTokenRoyaltyView,
+			},
+		)
+		mux.Handle("/royalties", h)
+		mux.Handle("/royalties/", h)
+	}
+
 	return mux
 }
+
+// writeRule は pattern への書き込み系メソッドに permission を要求する rule を返す。
+func writeRule(pattern, permission string) middleware.PermissionRule {
+	return middleware.PermissionRule{
+		Methods:    middleware.WriteMethods,
+		Pattern:    pattern,
+		Permission: permission,
+	}
+}
@@ -0,0 +1,371 @@
+// backend/internal/adapters/in/http/handler/escrow_auto_confirm_handler.go
+package internalHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/api/idtoken"
+
+	uc "narratives/internal/application/usecase"
+)
+
+const (
+	envEscrowAutoConfirmCloudTasksAudience       = "CLOUD_TASKS_AUDIENCE"
+	envEscrowAutoConfirmCloudTasksServiceAccount = "CLOUD_TASKS_SERVICE_ACCOUNT"
+	envEscrowAutoConfirmInternalBaseURL          = "INTERNAL_BASE_URL"
+	envEscrowAutoConfirmSelfBaseURL              = "SELF_BASE_URL"
+
+	maxEscrowAutoConfirmRequestBodyBytes int64 = 64 * 1024
+)
+
+var (
+	errEscrowAutoConfirmAuthNotConfigured = errors.New(
+		"escrow auto-confirm authentication is not configured",
+	)
+	errEscrowAutoConfirmUnauthorized = errors.New(
+		"escrow auto-confirm request is unauthorized",
+	)
+	errEscrowAutoConfirmForbidden = errors.New(
+		"escrow auto-confirm request is forbidden",
+	)
+)
+
+// EscrowAutoConfirmSweeper は escrow の自動受取確認・支払い確定の処理です。
+type EscrowAutoConfirmSweeper interface {
+	AutoConfirmDue(
+		ctx context.Context,
+		limit int,
+	) (uc.AutoConfirmDueEscrowsResult, error)
+}
+
+type EscrowAutoConfirmHandler struct {
+	sweeper             EscrowAutoConfirmSweeper
+	audience            string
+	serviceAccountEmail string
+}
+
+type autoConfirmDueEscrowsRequest struct {
+	Limit int `json:"limit"`
+}
+
+type escrowAutoConfirmErrorResponse struct {
+	Error  string                          `json:"error"`
+	Result *uc.AutoConfirmDueEscrowsResult `json:"result,omitempty"`
+}
+
+func NewEscrowAutoConfirmHandler(
+	sweeper EscrowAutoConfirmSweeper,
+) *EscrowAutoConfirmHandler {
+	audience := firstNonEmptyEscrowAutoConfirmEnvironmentValue(
+		envEscrowAutoConfirmCloudTasksAudience,
+		envEscrowAutoConfirmInternalBaseURL,
+		envEscrowAutoConfirmSelfBaseURL,
+	)
+
+	serviceAccountEmail := firstNonEmptyEscrowAutoConfirmEnvironmentValue(
+		envEscrowAutoConfirmCloudTasksServiceAccount,
+	)
+
+	return &EscrowAutoConfirmHandler{
+		sweeper:  sweeper,
+		audience: strings.TrimRight(audience, "/"),
+		serviceAccountEmail: strings.ToLower(
+			strings.TrimSpace(serviceAccountEmail),
+		),
+	}
+}
+
+func (h *EscrowAutoConfirmHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	h.AutoConfirmDue(w, r)
+}
+
+// AutoConfirmDueは自動受取確認の期限を過ぎたescrowを受取確認済みにし、
+// NFTの移転と受取確認が揃ったescrowの出品者への支払いを確定します。
+// Cloud SchedulerなどからOIDC付きで呼び出すことを想定しています。
+// bodyは省略可能です。
+//
+//	{
+//	  "limit": 100
+//	}
+func (h *EscrowAutoConfirmHandler) AutoConfirmDue(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeEscrowAutoConfirmError(
+			w,
+			http.StatusMethodNotAllowed,
+			"method_not_allowed",
+			nil,
+		)
+		return
+	}
+
+	if h == nil || h.sweeper == nil {
+		writeEscrowAutoConfirmError(
+			w,
+			http.StatusServiceUnavailable,
+			"escrow_usecase_unavailable",
+			nil,
+		)
+		return
+	}
+
+	if err := h.authorizeInternalRequest(r); err != nil {
+		h.writeAuthorizationError(w, err)
+		return
+	}
+
+	var request autoConfirmDueEscrowsRequest
+
+	if err := decodeOptionalEscrowAutoConfirmJSON(
+		w,
+		r,
+		&request,
+	); err != nil {
+		writeEscrowAutoConfirmError(
+			w,
+			http.StatusBadRequest,
+			"invalid_json_body",
+			nil,
+		)
+		return
+	}
+
+	if request.Limit < 0 {
+		writeEscrowAutoConfirmError(
+			w,
+			http.StatusBadRequest,
+			"limit_must_not_be_negative",
+			nil,
+		)
+		return
+	}
+
+	result, err := h.sweeper.AutoConfirmDue(
+		r.Context(),
+		request.Limit,
+	)
+	if err != nil {
+		writeEscrowAutoConfirmError(
+			w,
+			http.StatusInternalServerError,
+			"escrow_auto_confirm_failed",
+			&result,
+		)
+		return
+	}
+
+	writeEscrowAutoConfirmJSON(
+		w,
+		http.StatusOK,
+		result,
+	)
+}
+
+func (h *EscrowAutoConfirmHandler) authorizeInternalRequest(
+	r *http.Request,
+) error {
+	audience := strings.TrimSpace(h.audience)
+	serviceAccountEmail := strings.ToLower(
+		strings.TrimSpace(h.serviceAccountEmail),
+	)
+
+	if audience == "" || serviceAccountEmail == "" {
+		return errEscrowAutoConfirmAuthNotConfigured
+	}
+
+	rawToken, ok := escrowAutoConfirmBearerToken(
+		r.Header.Get("Authorization"),
+	)
+	if !ok {
+		return errEscrowAutoConfirmUnauthorized
+	}
+
+	payload, err := idtoken.Validate(
+		r.Context(),
+		rawToken,
+		audience,
+	)
+	if err != nil || payload == nil {
+		return errEscrowAutoConfirmUnauthorized
+	}
+
+	tokenEmail, _ := payload.Claims["email"].(string)
+	tokenEmail = strings.ToLower(
+		strings.TrimSpace(tokenEmail),
+	)
+
+	if tokenEmail == "" || tokenEmail != serviceAccountEmail {
+		return errEscrowAutoConfirmForbidden
+	}
+
+	if !escrowAutoConfirmEmailVerified(
+		payload.Claims["email_verified"],
+	) {
+		return errEscrowAutoConfirmForbidden
+	}
+
+	return nil
+}
+
+func (h *EscrowAutoConfirmHandler) writeAuthorizationError(
+	w http.ResponseWriter,
+	err error,
+) {
+	switch {
+	case errors.Is(err, errEscrowAutoConfirmAuthNotConfigured):
+		writeEscrowAutoConfirmError(
+			w,
+			http.StatusServiceUnavailable,
+			"escrow_auto_confirm_auth_unavailable",
+			nil,
+		)
+
+	case errors.Is(err, errEscrowAutoConfirmForbidden):
+		writeEscrowAutoConfirmError(
+			w,
+			http.StatusForbidden,
+			"forbidden",
+			nil,
+		)
+
+	default:
+		writeEscrowAutoConfirmError(
+			w,
+			http.StatusUnauthorized,
+			"unauthorized",
+			nil,
+		)
+	}
+}
+
+func escrowAutoConfirmBearerToken(
+	authorizationHeader string,
+) (string, bool) {
+	parts := strings.Fields(
+		strings.TrimSpace(authorizationHeader),
+	)
+
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(parts[1])
+	if token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+func escrowAutoConfirmEmailVerified(
+	value any,
+) bool {
+	switch verified := value.(type) {
+	case bool:
+		return verified
+
+	case string:
+		return strings.EqualFold(
+			strings.TrimSpace(verified),
+			"true",
+		)
+
+	default:
+		return false
+	}
+}
+
+func decodeOptionalEscrowAutoConfirmJSON(
+	w http.ResponseWriter,
+	r *http.Request,
+	destination any,
+) error {
+	if r.Body == nil {
+		return nil
+	}
+
+	r.Body = http.MaxBytesReader(
+		w,
+		r.Body,
+		maxEscrowAutoConfirmRequestBodyBytes,
+	)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(destination); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return err
+	}
+
+	var extra any
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return errors.New("multiple JSON values are not allowed")
+	}
+
+	return nil
+}
+
+func writeEscrowAutoConfirmError(
+	w http.ResponseWriter,
+	statusCode int,
+	message string,
+	result *uc.AutoConfirmDueEscrowsResult,
+) {
+	writeEscrowAutoConfirmJSON(
+		w,
+		statusCode,
+		escrowAutoConfirmErrorResponse{
+			Error:  message,
+			Result: result,
+		},
+	)
+}
+
+func writeEscrowAutoConfirmJSON(
+	w http.ResponseWriter,
+	statusCode int,
+	value any,
+) {
+	w.Header().Set(
+		"Content-Type",
+		"application/json; charset=utf-8",
+	)
+	w.Header().Set(
+		"Cache-Control",
+		"no-store",
+	)
+
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func firstNonEmptyEscrowAutoConfirmEnvironmentValue(
+	keys ...string,
+) string {
+	for _, key := range keys {
+		value := strings.TrimSpace(
+			os.Getenv(strings.TrimSpace(key)),
+		)
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,370 @@
+// backend/internal/adapters/in/http/handler/inventory_reservation_handler.go
+package internalHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/api/idtoken"
+
+	uc "narratives/internal/application/usecase"
+)
+
+const (
+	envInventoryReservationCloudTasksAudience       = "CLOUD_TASKS_AUDIENCE"
+	envInventoryReservationCloudTasksServiceAccount = "CLOUD_TASKS_SERVICE_ACCOUNT"
+	envInventoryReservationInternalBaseURL          = "INTERNAL_BASE_URL"
+	envInventoryReservationSelfBaseURL              = "SELF_BASE_URL"
+
+	maxInventoryReservationRequestBodyBytes int64 = 64 * 1024
+)
+
+var (
+	errInventoryReservationAuthNotConfigured = errors.New(
+		"inventory reservation authentication is not configured",
+	)
+	errInventoryReservationUnauthorized = errors.New(
+		"inventory reservation request is unauthorized",
+	)
+	errInventoryReservationForbidden = errors.New(
+		"inventory reservation request is forbidden",
+	)
+)
+
+// InventoryReservationSweeper は期限切れ引当の解放処理です。
+type InventoryReservationSweeper interface {
+	ReleaseExpired(
+		ctx context.Context,
+		limit int,
+	) (uc.ReleaseExpiredReservationsResult, error)
+}
+
+type InventoryReservationHandler struct {
+	sweeper             InventoryReservationSweeper
+	audience            string
+	serviceAccountEmail string
+}
+
+type releaseExpiredInventoryReservationsRequest struct {
+	Limit int `json:"limit"`
+}
+
+type inventoryReservationErrorResponse struct {
+	Error  string                               `json:"error"`
+	Result *uc.ReleaseExpiredReservationsResult `json:"result,omitempty"`
+}
+
+func NewInventoryReservationHandler(
+	sweeper InventoryReservationSweeper,
+) *InventoryReservationHandler {
+	audience := firstNonEmptyInventoryReservationEnvironmentValue(
+		envInventoryReservationCloudTasksAudience,
+		envInventoryReservationInternalBaseURL,
+		envInventoryReservationSelfBaseURL,
+	)
+
+	serviceAccountEmail := firstNonEmptyInventoryReservationEnvironmentValue(
+		envInventoryReservationCloudTasksServiceAccount,
+	)
+
+	return &InventoryReservationHandler{
+		sweeper:  sweeper,
+		audience: strings.TrimRight(audience, "/"),
+		serviceAccountEmail: strings.ToLower(
+			strings.TrimSpace(serviceAccountEmail),
+		),
+	}
+}
+
+func (h *InventoryReservationHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	h.ReleaseExpired(w, r)
+}
+
+// ReleaseExpiredは期限切れかつ未決済の在庫引当を解放します。
+// Cloud SchedulerなどからOIDC付きで呼び出すことを想定しています。
+// bodyは省略可能です。
+//
+//	{
+//	  "limit": 100
+//	}
+func (h *InventoryReservationHandler) ReleaseExpired(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeInventoryReservationError(
+			w,
+			http.StatusMethodNotAllowed,
+			"method_not_allowed",
+			nil,
+		)
+		return
+	}
+
+	if h == nil || h.sweeper == nil {
+		writeInventoryReservationError(
+			w,
+			http.StatusServiceUnavailable,
+			"inventory_reservation_usecase_unavailable",
+			nil,
+		)
+		return
+	}
+
+	if err := h.authorizeInternalRequest(r); err != nil {
+		h.writeAuthorizationError(w, err)
+		return
+	}
+
+	var request releaseExpiredInventoryReservationsRequest
+
+	if err := decodeOptionalInventoryReservationJSON(
+		w,
+		r,
+		&request,
+	); err != nil {
+		writeInventoryReservationError(
+			w,
+			http.StatusBadRequest,
+			"invalid_json_body",
+			nil,
+		)
+		return
+	}
+
+	if request.Limit < 0 {
+		writeInventoryReservationError(
+			w,
+			http.StatusBadRequest,
+			"limit_must_not_be_negative",
+			nil,
+		)
+		return
+	}
+
+	result, err := h.sweeper.ReleaseExpired(
+		r.Context(),
+		request.Limit,
+	)
+	if err != nil {
+		writeInventoryReservationError(
+			w,
+			http.StatusInternalServerError,
+			"inventory_reservation_release_failed",
+			&result,
+		)
+		return
+	}
+
+	writeInventoryReservationJSON(
+		w,
+		http.StatusOK,
+		result,
+	)
+}
+
+func (h *InventoryReservationHandler) authorizeInternalRequest(
+	r *http.Request,
+) error {
+	audience := strings.TrimSpace(h.audience)
+	serviceAccountEmail := strings.ToLower(
+		strings.TrimSpace(h.serviceAccountEmail),
+	)
+
+	if audience == "" || serviceAccountEmail == "" {
+		return errInventoryReservationAuthNotConfigured
+	}
+
+	rawToken, ok := inventoryReservationBearerToken(
+		r.Header.Get("Authorization"),
+	)
+	if !ok {
+		return errInventoryReservationUnauthorized
+	}
+
+	payload, err := idtoken.Validate(
+		r.Context(),
+		rawToken,
+		audience,
+	)
+	if err != nil || payload == nil {
+		return errInventoryReservationUnauthorized
+	}
+
+	tokenEmail, _ := payload.Claims["email"].(string)
+	tokenEmail = strings.ToLower(
+		strings.TrimSpace(tokenEmail),
+	)
+
+	if tokenEmail == "" || tokenEmail != serviceAccountEmail {
+		return errInventoryReservationForbidden
+	}
+
+	if !inventoryReservationEmailVerified(
+		payload.Claims["email_verified"],
+	) {
+		return errInventoryReservationForbidden
+	}
+
+	return nil
+}
+
+func (h *InventoryReservationHandler) writeAuthorizationError(
+	w http.ResponseWriter,
+	err error,
+) {
+	switch {
+	case errors.Is(err, errInventoryReservationAuthNotConfigured):
+		writeInventoryReservationError(
+			w,
+			http.StatusServiceUnavailable,
+			"inventory_reservation_auth_unavailable",
+			nil,
+		)
+
+	case errors.Is(err, errInventoryReservationForbidden):
+		writeInventoryReservationError(
+			w,
+			http.StatusForbidden,
+			"forbidden",
+			nil,
+		)
+
+	default:
+		writeInventoryReservationError(
+			w,
+			http.StatusUnauthorized,
+			"unauthorized",
+			nil,
+		)
+	}
+}
+
+func inventoryReservationBearerToken(
+	authorizationHeader string,
+) (string, bool) {
+	parts := strings.Fields(
+		strings.TrimSpace(authorizationHeader),
+	)
+
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(parts[1])
+	if token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+func inventoryReservationEmailVerified(
+	value any,
+) bool {
+	switch verified := value.(type) {
+	case bool:
+		return verified
+
+	case string:
+		return strings.EqualFold(
+			strings.TrimSpace(verified),
+			"true",
+		)
+
+	default:
+		return false
+	}
+}
+
+func decodeOptionalInventoryReservationJSON(
+	w http.ResponseWriter,
+	r *http.Request,
+	destination any,
+) error {
+	if r.Body == nil {
+		return nil
+	}
+
+	r.Body = http.MaxBytesReader(
+		w,
+		r.Body,
+		maxInventoryReservationRequestBodyBytes,
+	)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(destination); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return err
+	}
+
+	var extra any
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return errors.New("multiple JSON values are not allowed")
+	}
+
+	return nil
+}
+
+func writeInventoryReservationError(
+	w http.ResponseWriter,
+	statusCode int,
+	message string,
+	result *uc.ReleaseExpiredReservationsResult,
+) {
+	writeInventoryReservationJSON(
+		w,
+		statusCode,
+		inventoryReservationErrorResponse{
+			Error:  message,
+			Result: result,
+		},
+	)
+}
+
+func writeInventoryReservationJSON(
+	w http.ResponseWriter,
+	statusCode int,
+	value any,
+) {
+	w.Header().Set(
+		"Content-Type",
+		"application/json; charset=utf-8",
+	)
+	w.Header().Set(
+		"Cache-Control",
+		"no-store",
+	)
+
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func firstNonEmptyInventoryReservationEnvironmentValue(
+	keys ...string,
+) string {
+	for _, key := range keys {
+		value := strings.TrimSpace(
+			os.Getenv(strings.TrimSpace(key)),
+		)
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,371 @@
+// backend/internal/adapters/in/http/handler/offer_expiry_handler.go
+package internalHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/api/idtoken"
+
+	uc "narratives/internal/application/usecase"
+)
+
+const (
+	envOfferExpiryCloudTasksAudience       = "CLOUD_TASKS_AUDIENCE"
+	envOfferExpiryCloudTasksServiceAccount = "CLOUD_TASKS_SERVICE_ACCOUNT"
+	envOfferExpiryInternalBaseURL          = "INTERNAL_BASE_URL"
+	envOfferExpirySelfBaseURL              = "SELF_BASE_URL"
+
+	maxOfferExpiryRequestBodyBytes int64 = 64 * 1024
+)
+
+var (
+	errOfferExpiryAuthNotConfigured = errors.New(
+		"offer expiry authentication is not configured",
+	)
+	errOfferExpiryUnauthorized = errors.New(
+		"offer expiry request is unauthorized",
+	)
+	errOfferExpiryForbidden = errors.New(
+		"offer expiry request is forbidden",
+	)
+)
+
+// OfferExpirySweeper は期限切れ offer の失効処理です。
+type OfferExpirySweeper interface {
+	ExpireDue(
+		ctx context.Context,
+		limit int,
+	) (uc.ExpireDueOffersResult, error)
+}
+
+type OfferExpiryHandler struct {
+	sweeper             OfferExpirySweeper
+	audience            string
+	serviceAccountEmail string
+}
+
+type expireDueOffersRequest struct {
+	Limit int `json:"limit"`
+}
+
+type offerExpiryErrorResponse struct {
+	Error  string                    `json:"error"`
+	Result *uc.ExpireDueOffersResult `json:"result,omitempty"`
+}
+
+func NewOfferExpiryHandler(
+	sweeper OfferExpirySweeper,
+) *OfferExpiryHandler {
+	audience := firstNonEmptyOfferExpiryEnvironmentValue(
+		envOfferExpiryCloudTasksAudience,
+		envOfferExpiryInternalBaseURL,
+		envOfferExpirySelfBaseURL,
+	)
+
+	serviceAccountEmail := firstNonEmptyOfferExpiryEnvironmentValue(
+		envOfferExpiryCloudTasksServiceAccount,
+	)
+
+	return &OfferExpiryHandler{
+		sweeper:  sweeper,
+		audience: strings.TrimRight(audience, "/"),
+		serviceAccountEmail: strings.ToLower(
+			strings.TrimSpace(serviceAccountEmail),
+		),
+	}
+}
+
+func (h *OfferExpiryHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	h.ExpireDue(w, r)
+}
+
+// ExpireDueは期限切れのofferを失効させ、注文されなかったofferで確保していた
+// resaleを出品中に戻します。
+// Cloud SchedulerなどからOIDC付きで呼び出すことを想定しています。
+// bodyは省略可能です。
+//
+//	{
+//	  "limit": 100
+//	}
+func (h *OfferExpiryHandler) ExpireDue(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeOfferExpiryError(
+			w,
+			http.StatusMethodNotAllowed,
+			"method_not_allowed",
+			nil,
+		)
+		return
+	}
+
+	if h == nil || h.sweeper == nil {
+		writeOfferExpiryError(
+			w,
+			http.StatusServiceUnavailable,
+			"offer_usecase_unavailable",
+			nil,
+		)
+		return
+	}
+
+	if err := h.authorizeInternalRequest(r); err != nil {
+		h.writeAuthorizationError(w, err)
+		return
+	}
+
+	var request expireDueOffersRequest
+
+	if err := decodeOptionalOfferExpiryJSON(
+		w,
+		r,
+		&request,
+	); err != nil {
+		writeOfferExpiryError(
+			w,
+			http.StatusBadRequest,
+			"invalid_json_body",
+			nil,
+		)
+		return
+	}
+
+	if request.Limit < 0 {
+		writeOfferExpiryError(
+			w,
+			http.StatusBadRequest,
+			"limit_must_not_be_negative",
+			nil,
+		)
+		return
+	}
+
+	result, err := h.sweeper.ExpireDue(
+		r.Context(),
+		request.Limit,
+	)
+	if err != nil {
+		writeOfferExpiryError(
+			w,
+			http.StatusInternalServerError,
+			"offer_expire_failed",
+			&result,
+		)
+		return
+	}
+
+	writeOfferExpiryJSON(
+		w,
+		http.StatusOK,
+		result,
+	)
+}
+
+func (h *OfferExpiryHandler) authorizeInternalRequest(
+	r *http.Request,
+) error {
+	audience := strings.TrimSpace(h.audience)
+	serviceAccountEmail := strings.ToLower(
+		strings.TrimSpace(h.serviceAccountEmail),
+	)
+
+	if audience == "" || serviceAccountEmail == "" {
+		return errOfferExpiryAuthNotConfigured
+	}
+
+	rawToken, ok := offerExpiryBearerToken(
+		r.Header.Get("Authorization"),
+	)
+	if !ok {
+		return errOfferExpiryUnauthorized
+	}
+
+	payload, err := idtoken.Validate(
+		r.Context(),
+		rawToken,
+		audience,
+	)
+	if err != nil || payload == nil {
+		return errOfferExpiryUnauthorized
+	}
+
+	tokenEmail, _ := payload.Claims["email"].(string)
+	tokenEmail = strings.ToLower(
+		strings.TrimSpace(tokenEmail),
+	)
+
+	if tokenEmail == "" || tokenEmail != serviceAccountEmail {
+		return errOfferExpiryForbidden
+	}
+
+	if !offerExpiryEmailVerified(
+		payload.Claims["email_verified"],
+	) {
+		return errOfferExpiryForbidden
+	}
+
+	return nil
+}
+
+func (h *OfferExpiryHandler) writeAuthorizationError(
+	w http.ResponseWriter,
+	err error,
+) {
+	switch {
+	case errors.Is(err, errOfferExpiryAuthNotConfigured):
+		writeOfferExpiryError(
+			w,
+			http.StatusServiceUnavailable,
+			"offer_expiry_auth_unavailable",
+			nil,
+		)
+
+	case errors.Is(err, errOfferExpiryForbidden):
+		writeOfferExpiryError(
+			w,
+			http.StatusForbidden,
+			"forbidden",
+			nil,
+		)
+
+	default:
+		writeOfferExpiryError(
+			w,
+			http.StatusUnauthorized,
+			"unauthorized",
+			nil,
+		)
+	}
+}
+
+func offerExpiryBearerToken(
+	authorizationHeader string,
+) (string, bool) {
+	parts := strings.Fields(
+		strings.TrimSpace(authorizationHeader),
+	)
+
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(parts[1])
+	if token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+func offerExpiryEmailVerified(
+	value any,
+) bool {
+	switch verified := value.(type) {
+	case bool:
+		return verified
+
+	case string:
+		return strings.EqualFold(
+			strings.TrimSpace(verified),
+			"true",
+		)
+
+	default:
+		return false
+	}
+}
+
+func decodeOptionalOfferExpiryJSON(
+	w http.ResponseWriter,
+	r *http.Request,
+	destination any,
+) error {
+	if r.Body == nil {
+		return nil
+	}
+
+	r.Body = http.MaxBytesReader(
+		w,
+		r.Body,
+		maxOfferExpiryRequestBodyBytes,
+	)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(destination); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return err
+	}
+
+	var extra any
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return errors.New("multiple JSON values are not allowed")
+	}
+
+	return nil
+}
+
+func writeOfferExpiryError(
+	w http.ResponseWriter,
+	statusCode int,
+	message string,
+	result *uc.ExpireDueOffersResult,
+) {
+	writeOfferExpiryJSON(
+		w,
+		statusCode,
+		offerExpiryErrorResponse{
+			Error:  message,
+			Result: result,
+		},
+	)
+}
+
+func writeOfferExpiryJSON(
+	w http.ResponseWriter,
+	statusCode int,
+	value any,
+) {
+	w.Header().Set(
+		"Content-Type",
+		"application/json; charset=utf-8",
+	)
+	w.Header().Set(
+		"Cache-Control",
+		"no-store",
+	)
+
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func firstNonEmptyOfferExpiryEnvironmentValue(
+	keys ...string,
+) string {
+	for _, key := range keys {
+		value := strings.TrimSpace(
+			os.Getenv(strings.TrimSpace(key)),
+		)
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
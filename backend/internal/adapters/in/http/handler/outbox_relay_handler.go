@@ -0,0 +1,371 @@
+// backend/internal/adapters/in/http/handler/outbox_relay_handler.go
+package internalHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/api/idtoken"
+
+	uc "narratives/internal/application/usecase"
+)
+
+const (
+	envOutboxRelayCloudTasksAudience       = "CLOUD_TASKS_AUDIENCE"
+	envOutboxRelayCloudTasksServiceAccount = "CLOUD_TASKS_SERVICE_ACCOUNT"
+	envOutboxRelayInternalBaseURL          = "INTERNAL_BASE_URL"
+	envOutboxRelaySelfBaseURL              = "SELF_BASE_URL"
+
+	maxOutboxRelayRequestBodyBytes int64 = 64 * 1024
+)
+
+var (
+	errOutboxRelayAuthNotConfigured = errors.New(
+		"outbox relay authentication is not configured",
+	)
+	errOutboxRelayUnauthorized = errors.New(
+		"outbox relay request is unauthorized",
+	)
+	errOutboxRelayForbidden = errors.New(
+		"outbox relay request is forbidden",
+	)
+)
+
+// OutboxRelaySweeper は配信対象の outbox イベントを購読ハンドラへ配信する処理です。
+type OutboxRelaySweeper interface {
+	RelayDue(
+		ctx context.Context,
+		limit int,
+	) (uc.RelayDueOutboxEventsResult, error)
+}
+
+type OutboxRelayHandler struct {
+	sweeper             OutboxRelaySweeper
+	audience            string
+	serviceAccountEmail string
+}
+
+type relayDueOutboxEventsRequest struct {
+	Limit int `json:"limit"`
+}
+
+type outboxRelayErrorResponse struct {
+	Error  string                         `json:"error"`
+	Result *uc.RelayDueOutboxEventsResult `json:"result,omitempty"`
+}
+
+func NewOutboxRelayHandler(
+	sweeper OutboxRelaySweeper,
+) *OutboxRelayHandler {
+	audience := firstNonEmptyOutboxRelayEnvironmentValue(
+		envOutboxRelayCloudTasksAudience,
+		envOutboxRelayInternalBaseURL,
+		envOutboxRelaySelfBaseURL,
+	)
+
+	serviceAccountEmail := firstNonEmptyOutboxRelayEnvironmentValue(
+		envOutboxRelayCloudTasksServiceAccount,
+	)
+
+	return &OutboxRelayHandler{
+		sweeper:  sweeper,
+		audience: strings.TrimRight(audience, "/"),
+		serviceAccountEmail: strings.ToLower(
+			strings.TrimSpace(serviceAccountEmail),
+		),
+	}
+}
+
+func (h *OutboxRelayHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	h.RelayDue(w, r)
+}
+
+// RelayDueは未配信・再試行待ち・リース切れのoutboxイベントを配信します。
+// 書き込み直後の配信に失敗したイベントを回収するためのsweeperです。
+// Cloud SchedulerなどからOIDC付きで呼び出すことを想定しています。
+// bodyは省略可能です。
+//
+//	{
+//	  "limit": 100
+//	}
+func (h *OutboxRelayHandler) RelayDue(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeOutboxRelayError(
+			w,
+			http.StatusMethodNotAllowed,
+			"method_not_allowed",
+			nil,
+		)
+		return
+	}
+
+	if h == nil || h.sweeper == nil {
+		writeOutboxRelayError(
+			w,
+			http.StatusServiceUnavailable,
+			"outbox_relay_unavailable",
+			nil,
+		)
+		return
+	}
+
+	if err := h.authorizeInternalRequest(r); err != nil {
+		h.writeAuthorizationError(w, err)
+		return
+	}
+
+	var request relayDueOutboxEventsRequest
+
+	if err := decodeOptionalOutboxRelayJSON(
+		w,
+		r,
+		&request,
+	); err != nil {
+		writeOutboxRelayError(
+			w,
+			http.StatusBadRequest,
+			"invalid_json_body",
+			nil,
+		)
+		return
+	}
+
+	if request.Limit < 0 {
+		writeOutboxRelayError(
+			w,
+			http.StatusBadRequest,
+			"limit_must_not_be_negative",
+			nil,
+		)
+		return
+	}
+
+	result, err := h.sweeper.RelayDue(
+		r.Context(),
+		request.Limit,
+	)
+	if err != nil {
+		writeOutboxRelayError(
+			w,
+			http.StatusInternalServerError,
+			"outbox_relay_failed",
+			&result,
+		)
+		return
+	}
+
+	writeOutboxRelayJSON(
+		w,
+		http.StatusOK,
+		result,
+	)
+}
+
+func (h *OutboxRelayHandler) authorizeInternalRequest(
+	r *http.Request,
+) error {
+	audience := strings.TrimSpace(h.audience)
+	serviceAccountEmail := strings.ToLower(
+		strings.TrimSpace(h.serviceAccountEmail),
+	)
+
+	if audience == "" || serviceAccountEmail == "" {
+		return errOutboxRelayAuthNotConfigured
+	}
+
+	rawToken, ok := outboxRelayBearerToken(
+		r.Header.Get("Authorization"),
+	)
+	if !ok {
+		return errOutboxRelayUnauthorized
+	}
+
+	payload, err := idtoken.Validate(
+		r.Context(),
+		rawToken,
+		audience,
+	)
+	if err != nil || payload == nil {
+		return errOutboxRelayUnauthorized
+	}
+
+	tokenEmail, _ := payload.Claims["email"].(string)
+	tokenEmail = strings.ToLower(
+		strings.TrimSpace(tokenEmail),
+	)
+
+	if tokenEmail == "" || tokenEmail != serviceAccountEmail {
+		return errOutboxRelayForbidden
+	}
+
+	if !outboxRelayEmailVerified(
+		payload.Claims["email_verified"],
+	) {
+		return errOutboxRelayForbidden
+	}
+
+	return nil
+}
+
+func (h *OutboxRelayHandler) writeAuthorizationError(
+	w http.ResponseWriter,
+	err error,
+) {
+	switch {
+	case errors.Is(err, errOutboxRelayAuthNotConfigured):
+		writeOutboxRelayError(
+			w,
+			http.StatusServiceUnavailable,
+			"outbox_relay_auth_unavailable",
+			nil,
+		)
+
+	case errors.Is(err, errOutboxRelayForbidden):
+		writeOutboxRelayError(
+			w,
+			http.StatusForbidden,
+			"forbidden",
+			nil,
+		)
+
+	default:
+		writeOutboxRelayError(
+			w,
+			http.StatusUnauthorized,
+			"unauthorized",
+			nil,
+		)
+	}
+}
+
+func outboxRelayBearerToken(
+	authorizationHeader string,
+) (string, bool) {
+	parts := strings.Fields(
+		strings.TrimSpace(authorizationHeader),
+	)
+
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(parts[1])
+	if token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+func outboxRelayEmailVerified(
+	value any,
+) bool {
+	switch verified := value.(type) {
+	case bool:
+		return verified
+
+	case string:
+		return strings.EqualFold(
+			strings.TrimSpace(verified),
+			"true",
+		)
+
+	default:
+		return false
+	}
+}
+
+func decodeOptionalOutboxRelayJSON(
+	w http.ResponseWriter,
+	r *http.Request,
+	destination any,
+) error {
+	if r.Body == nil {
+		return nil
+	}
+
+	r.Body = http.MaxBytesReader(
+		w,
+		r.Body,
+		maxOutboxRelayRequestBodyBytes,
+	)
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(destination); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		return err
+	}
+
+	var extra any
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return errors.New("multiple JSON values are not allowed")
+	}
+
+	return nil
+}
+
+func writeOutboxRelayError(
+	w http.ResponseWriter,
+	statusCode int,
+	message string,
+	result *uc.RelayDueOutboxEventsResult,
+) {
+	writeOutboxRelayJSON(
+		w,
+		statusCode,
+		outboxRelayErrorResponse{
+			Error:  message,
+			Result: result,
+		},
+	)
+}
+
+func writeOutboxRelayJSON(
+	w http.ResponseWriter,
+	statusCode int,
+	value any,
+) {
+	w.Header().Set(
+		"Content-Type",
+		"application/json; charset=utf-8",
+	)
+	w.Header().Set(
+		"Cache-Control",
+		"no-store",
+	)
+
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func firstNonEmptyOutboxRelayEnvironmentValue(
+	keys ...string,
+) string {
+	for _, key := range keys {
+		value := strings.TrimSpace(
+			os.Getenv(strings.TrimSpace(key)),
+		)
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
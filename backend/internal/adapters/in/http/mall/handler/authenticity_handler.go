@@ -0,0 +1,284 @@
+// backend/internal/adapters/in/http/mall/handler/authenticity_handler.go
+package mallHandler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	authdom "narratives/internal/domain/authenticity"
+	nfcdom "narratives/internal/domain/nfc"
+)
+
+// ClientGeoLocationHeader はロードバランサが付与するスキャン元の地域です。
+// 形式: "{country},{region},{city}"
+// （Cloud Load Balancing の custom request header
+// "X-Client-Geo-Location:{client_region},{client_region_subdivision},{client_city}"）
+const ClientGeoLocationHeader = "X-Client-Geo-Location"
+
+type AuthenticityVerifier interface {
+	Verify(
+		ctx context.Context,
+		in usecase.VerifyAuthenticityInput,
+	) (usecase.AuthenticityResult, error)
+}
+
+// NFCSUNVerifier は NFC タグの SUN メッセージを検証します（usecase.NFCTagUsecase）。
+type NFCSUNVerifier interface {
+	// RequiresSUN は product に登録済みの NFC タグがあるかを返します。
+	RequiresSUN(
+		ctx context.Context,
+		productID string,
+	) (bool, error)
+
+	VerifySUN(
+		ctx context.Context,
+		productID string,
+		msg nfcdom.SUNMessage,
+	) (nfcdom.Tag, error)
+}
+
+// AuthenticityHandler は QR コード / NFC タグの真贋確認 API です（public）。
+//
+// GET /mall/authenticity/{productId}?lat=..&lng=..
+// GET /mall/authenticity/{productId}/nfc?picc=..&cmac=..&lat=..&lng=..
+//   - lat / lng は任意（ブラウザの位置情報の利用に同意した場合のみ送信される）
+//   - NFC は SUN メッセージの検証に失敗した場合（複製タグ・リプレイ）、
+//     真贋確認の結果を返さずに 403 を返す
+//   - 登録済みの NFC タグがある製品は /nfc でのみ確認できる。
+//     SUN メッセージのない URL（コピーされた URL など）は 403 を返す
+//   - スキャンごとに記録されるため、レスポンスはキャッシュさせない
+type AuthenticityHandler struct {
+	uc  AuthenticityVerifier
+	nfc NFCSUNVerifier
+}
+
+type AuthenticityHandlerOption func(*AuthenticityHandler)
+
+func WithNFCSUNVerifier(nfc NFCSUNVerifier) AuthenticityHandlerOption {
+	return func(h *AuthenticityHandler) {
+		h.nfc = nfc
+	}
+}
+
+func NewAuthenticityHandler(
+	uc AuthenticityVerifier,
+	opts ...AuthenticityHandlerOption,
+) http.Handler {
+	h := &AuthenticityHandler{uc: uc}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+
+	return h
+}
+
+func (h *AuthenticityHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if !validatePreviewGETRequest(w, r) {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	if h == nil || h.uc == nil {
+		internalError(w, "authenticity usecase not configured")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(
+		strings.TrimPrefix(r.URL.Path, "/mall/authenticity"),
+		"/",
+	), "/")
+
+	productID := strings.TrimSpace(parts[0])
+	if productID == "" {
+		productID = strings.TrimSpace(r.URL.Query().Get("productId"))
+	}
+	if productID == "" {
+		badRequest(w, "productId is required")
+		return
+	}
+
+	var nfcTag *nfcdom.Tag
+
+	switch {
+	case len(parts) == 1:
+		if !h.allowWithoutNFC(w, r, productID) {
+			return
+		}
+	case len(parts) == 2 && parts[1] == "nfc":
+		tag, ok := h.verifyNFC(w, r, productID)
+		if !ok {
+			return
+		}
+		nfcTag = &tag
+	default:
+		notFound(w)
+		return
+	}
+
+	res, err := h.uc.Verify(r.Context(), usecase.VerifyAuthenticityInput{
+		ProductID: productID,
+		Location:  authenticityLocationFromRequest(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrAuthenticityInvalidProductID):
+			badRequest(w, "invalid productId")
+		case errors.Is(err, context.Canceled),
+			errors.Is(err, context.DeadlineExceeded):
+			writeJSON(w, http.StatusRequestTimeout, map[string]string{
+				"error": "request canceled",
+			})
+		default:
+			log.Printf("[mall.authenticity] verify failed productId=%q err=%v", productID, err)
+			internalError(w, "verify failed")
+		}
+		return
+	}
+
+	body := map[string]any{
+		"data": res,
+	}
+	if nfcTag != nil {
+		body["nfc"] = map[string]any{
+			"verified": true,
+			"counter":  nfcTag.LastCounter,
+		}
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+// allowWithoutNFC は SUN メッセージなしで真贋確認してよい製品かを確認します。
+// false を返した場合は、この関数内でレスポンスを書き込み済みです。
+func (h *AuthenticityHandler) allowWithoutNFC(
+	w http.ResponseWriter,
+	r *http.Request,
+	productID string,
+) bool {
+	if h.nfc == nil {
+		return true
+	}
+
+	required, err := h.nfc.RequiresSUN(r.Context(), productID)
+	if err != nil {
+		log.Printf("[mall.authenticity] nfc tag lookup failed productId=%q err=%v", productID, err)
+		internalError(w, "nfc tag lookup failed")
+		return false
+	}
+
+	if required {
+		writeJSON(w, http.StatusForbidden, map[string]string{
+			"error":  "nfc_tag_rejected",
+			"reason": "nfc_required",
+		})
+		return false
+	}
+
+	return true
+}
+
+// verifyNFC は SUN メッセージを検証します。
+// false を返した場合は、この関数内でレスポンスを書き込み済みです。
+func (h *AuthenticityHandler) verifyNFC(
+	w http.ResponseWriter,
+	r *http.Request,
+	productID string,
+) (nfcdom.Tag, bool) {
+	if h.nfc == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{
+			"error": "nfc verification not configured",
+		})
+		return nfcdom.Tag{}, false
+	}
+
+	q := r.URL.Query()
+
+	tag, err := h.nfc.VerifySUN(r.Context(), productID, nfcdom.SUNMessage{
+		PICCData: q.Get(nfcdom.SUNPICCDataParam),
+		CMAC:     q.Get(nfcdom.SUNCMACParam),
+	})
+	if err == nil {
+		return tag, true
+	}
+
+	reason := ""
+	switch {
+	case errors.Is(err, nfcdom.ErrInvalidSUN):
+		reason = "invalid_signature"
+	case errors.Is(err, nfcdom.ErrUIDMismatch):
+		reason = "uid_mismatch"
+	case errors.Is(err, nfcdom.ErrCounterReplayed):
+		reason = "counter_replayed"
+	case errors.Is(err, nfcdom.ErrNotProvisioned),
+		errors.Is(err, nfcdom.ErrNotFound),
+		errors.Is(err, nfcdom.ErrInvalidProductID):
+		reason = "unknown_tag"
+	}
+
+	if reason != "" {
+		log.Printf("[mall.authenticity] nfc tag rejected productId=%q reason=%s", productID, reason)
+		writeJSON(w, http.StatusForbidden, map[string]string{
+			"error":  "nfc_tag_rejected",
+			"reason": reason,
+		})
+		return nfcdom.Tag{}, false
+	}
+
+	if errors.Is(err, usecase.ErrNFCKeysUnavailable) {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{
+			"error": "nfc verification not configured",
+		})
+		return nfcdom.Tag{}, false
+	}
+
+	log.Printf("[mall.authenticity] nfc verify failed productId=%q err=%v", productID, err)
+	internalError(w, "nfc verify failed")
+	return nfcdom.Tag{}, false
+}
+
+// authenticityLocationFromRequest は lat / lng クエリと geo ヘッダからスキャン位置を組み立てます。
+// 不正な座標は無視します。
+func authenticityLocationFromRequest(r *http.Request) authdom.Location {
+	var loc authdom.Location
+
+	q := r.URL.Query()
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(q.Get("lat")), 64)
+	lng, lngErr := strconv.ParseFloat(strings.TrimSpace(q.Get("lng")), 64)
+	if latErr == nil && lngErr == nil {
+		loc.Latitude = &lat
+		loc.Longitude = &lng
+		if loc.Validate() != nil {
+			loc.Latitude = nil
+			loc.Longitude = nil
+		}
+	}
+
+	if geo := strings.TrimSpace(r.Header.Get(ClientGeoLocationHeader)); geo != "" {
+		parts := strings.SplitN(geo, ",", 3)
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		loc.Country = parts[0]
+		if len(parts) > 1 {
+			loc.Region = parts[1]
+		}
+		if len(parts) > 2 {
+			loc.City = parts[2]
+		}
+	}
+
+	return loc
+}
@@ -58,6 +58,12 @@ func (h *CartHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case r.Method == http.MethodDelete && path == "/mall/me/cart/resales":
 		h.handleRemoveResaleItem(w, r)
 
+	case r.Method == http.MethodPut && path == "/mall/me/cart/coupon":
+		h.handleApplyCoupon(w, r)
+
+	case r.Method == http.MethodDelete && path == "/mall/me/cart/coupon":
+		h.handleRemoveCoupon(w, r)
+
 	default:
 		writeErr(w, http.StatusNotFound, "not found")
 	}
@@ -244,6 +250,63 @@ func (h *CartHandler) handleRemoveResaleItem(w http.ResponseWriter, r *http.Requ
 	h.respondCartDTO(w, r, avatarID)
 }
 
+// handleApplyCoupon validates the coupon against the cart and stores it.
+// The response carries the item discount; free shipping is priced by
+// POST /mall/me/shipping-quotes with couponCode.
+func (h *CartHandler) handleApplyCoupon(w http.ResponseWriter, r *http.Request) {
+	avatarID, ok := currentCartAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	var request cartCouponReq
+	if err := readJSON(r, &request); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid json body")
+		return
+	}
+
+	if request.CouponCode == "" {
+		writeErr(w, http.StatusBadRequest, "couponCode is required")
+		return
+	}
+
+	_, discount, err := h.uc.ApplyCoupon(r.Context(), avatarID, request.CouponCode)
+	if err != nil {
+		h.writeCartErr(w, err)
+		return
+	}
+
+	if h.cartQuery == nil {
+		writeErr(w, http.StatusInternalServerError, "cart query is not configured")
+		return
+	}
+
+	result, err := h.cartQuery.GetByAvatarID(r.Context(), avatarID)
+	if err != nil {
+		h.writeCartErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"cart":     result,
+		"discount": discount,
+	})
+}
+
+func (h *CartHandler) handleRemoveCoupon(w http.ResponseWriter, r *http.Request) {
+	avatarID, ok := currentCartAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.uc.RemoveCoupon(r.Context(), avatarID); err != nil {
+		h.writeCartErr(w, err)
+		return
+	}
+
+	h.respondCartDTO(w, r, avatarID)
+}
+
 func (h *CartHandler) handleClear(w http.ResponseWriter, r *http.Request) {
 	avatarID, ok := currentCartAvatarID(w, r)
 	if !ok {
@@ -299,6 +362,17 @@ func (h *CartHandler) writeCartErr(w http.ResponseWriter, err error) {
 		return
 	}
 
+	if status, ok := couponHTTPStatus(err); ok {
+		writeErr(w, status, err.Error())
+		return
+	}
+
+	// 値引き計算で解決した list / inventory の不整合は注文作成と同じ扱い。
+	if status := orderHTTPStatus(err); status != http.StatusInternalServerError {
+		writeErr(w, status, err.Error())
+		return
+	}
+
 	writeErr(w, http.StatusInternalServerError, err.Error())
 }
 
@@ -311,6 +385,10 @@ type cartItemReq struct {
 	Qty         int    `json:"qty"`
 }
 
+type cartCouponReq struct {
+	CouponCode string `json:"couponCode"`
+}
+
 func writeErr(w http.ResponseWriter, status int, message string) {
 	writeJSON(w, status, map[string]any{
 		"error": message,
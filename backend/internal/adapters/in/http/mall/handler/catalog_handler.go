@@ -14,6 +14,7 @@ import (
 //
 // Routes:
 // - GET /mall/catalog/{listId}
+// - GET /mall/me/catalog/{listId} (holder-only list の判定結果 access 付き)
 type MallCatalogHandler struct {
 	Q *mallquery.CatalogQuery
 }
@@ -34,6 +35,33 @@ func (h *MallCatalogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	path := strings.TrimSuffix(r.URL.Path, "/")
 
+	// detail (me): /mall/me/catalog/{listId}
+	if strings.HasPrefix(path, "/mall/me/catalog/") {
+		listID := strings.TrimPrefix(path, "/mall/me/catalog/")
+		if listID == "" || strings.Contains(listID, "/") {
+			notFound(w)
+			return
+		}
+
+		avatarID, ok := requireAvatarID(w, r)
+		if !ok {
+			return
+		}
+
+		dto, err := h.Q.GetByListIDForAvatar(r.Context(), listID, avatarID)
+		if err != nil {
+			if errors.Is(err, ldom.ErrNotFound) {
+				notFound(w)
+				return
+			}
+			internalError(w, err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusOK, dto)
+		return
+	}
+
 	// detail: /mall/catalog/{listId}
 	if strings.HasPrefix(path, "/mall/catalog/") {
 		listID := strings.TrimPrefix(path, "/mall/catalog/")
@@ -0,0 +1,42 @@
+// backend/internal/adapters/in/http/mall/handler/coupon_error.go
+package mallHandler
+
+import (
+	"errors"
+	"net/http"
+
+	usecase "narratives/internal/application/usecase"
+	coupondom "narratives/internal/domain/coupon"
+)
+
+// couponHTTPStatus maps coupon errors shared by the cart, shipping quote and
+// order handlers. ok is false when err is not a coupon error.
+func couponHTTPStatus(err error) (int, bool) {
+	switch {
+	case err == nil:
+		return 0, false
+
+	case errors.Is(err, coupondom.ErrInvalidCode):
+		return http.StatusBadRequest, true
+
+	case errors.Is(err, coupondom.ErrNotFound):
+		return http.StatusNotFound, true
+
+	// コードは正しいが、この注文には使えない。
+	case errors.Is(err, coupondom.ErrInactive),
+		errors.Is(err, coupondom.ErrNotStarted),
+		errors.Is(err, coupondom.ErrExpired),
+		errors.Is(err, coupondom.ErrUsageLimitReached),
+		errors.Is(err, coupondom.ErrPerAvatarLimit),
+		errors.Is(err, coupondom.ErrNotApplicable),
+		errors.Is(err, coupondom.ErrMinSubtotalNotMet):
+		return http.StatusUnprocessableEntity, true
+
+	case errors.Is(err, usecase.ErrOrderCouponNotConfigured),
+		errors.Is(err, usecase.ErrCouponNotConfigured):
+		return http.StatusServiceUnavailable, true
+
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,248 @@
+// backend/internal/adapters/in/http/mall/handler/escrow_handler.go
+package mallHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	escrowdom "narratives/internal/domain/escrow"
+)
+
+// EscrowHandler serves the buyer and seller side of resale escrows.
+//
+// Routes:
+// - GET  /mall/me/escrows?role=buyer|seller
+// - GET  /mall/me/escrows/{escrowId}
+// - POST /mall/me/escrows/{escrowId}/confirm
+// - POST /mall/me/escrows/{escrowId}/dispute
+//
+// confirm / dispute are buyer-only.
+type EscrowHandler struct {
+	uc *usecase.EscrowUsecase
+}
+
+func NewEscrowHandler(uc *usecase.EscrowUsecase) http.Handler {
+	return &EscrowHandler{uc: uc}
+}
+
+const meEscrowsPath = "/mall/me/escrows"
+
+func (h *EscrowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if h == nil || h.uc == nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "escrow usecase is nil",
+		})
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path == "" {
+		path = r.URL.Path
+	}
+
+	if path == meEscrowsPath {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.list(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(path, meEscrowsPath+"/") {
+		notFound(w)
+		return
+	}
+
+	rest := strings.TrimPrefix(path, meEscrowsPath+"/")
+	parts := strings.Split(rest, "/")
+	escrowID := strings.TrimSpace(parts[0])
+
+	if escrowID == "" {
+		badRequest(w, "invalid escrowId")
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.get(w, r, escrowID)
+		return
+
+	case len(parts) == 2 && parts[1] == "confirm":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+		h.confirm(w, r, escrowID)
+		return
+
+	case len(parts) == 2 && parts[1] == "dispute":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+		h.dispute(w, r, escrowID)
+		return
+
+	default:
+		notFound(w)
+		return
+	}
+}
+
+func (h *EscrowHandler) list(w http.ResponseWriter, r *http.Request) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	role := usecase.EscrowRole(strings.TrimSpace(r.URL.Query().Get("role")))
+	if role == "" {
+		role = usecase.EscrowRoleBuyer
+	}
+
+	items, err := h.uc.ListForAvatar(r.Context(), avatarID, role)
+	if err != nil {
+		writeEscrowErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": items,
+	})
+}
+
+func (h *EscrowHandler) get(
+	w http.ResponseWriter,
+	r *http.Request,
+	escrowID string,
+) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.uc.GetForAvatar(r.Context(), avatarID, escrowID)
+	if err != nil {
+		writeEscrowErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": item,
+	})
+}
+
+func (h *EscrowHandler) confirm(
+	w http.ResponseWriter,
+	r *http.Request,
+	escrowID string,
+) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.uc.ConfirmReceipt(r.Context(), avatarID, escrowID)
+	if err != nil {
+		writeEscrowErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": item,
+	})
+}
+
+func (h *EscrowHandler) dispute(
+	w http.ResponseWriter,
+	r *http.Request,
+	escrowID string,
+) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Reason  string `json:"reason"`
+		Comment string `json:"comment"`
+	}
+
+	if err := readJSON(r, &req); err != nil {
+		badRequest(w, "invalid json")
+		return
+	}
+
+	item, err := h.uc.OpenDispute(r.Context(), usecase.OpenEscrowDisputeInput{
+		AvatarID: avatarID,
+		EscrowID: escrowID,
+		Reason:   escrowdom.DisputeReason(strings.TrimSpace(req.Reason)),
+		Comment:  req.Comment,
+	})
+	if err != nil {
+		writeEscrowErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": item,
+	})
+}
+
+func writeEscrowErr(w http.ResponseWriter, err error) {
+	msg := "internal error"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	writeJSON(w, escrowHTTPStatus(err), map[string]string{
+		"error": msg,
+	})
+}
+
+func escrowHTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusInternalServerError
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return http.StatusRequestTimeout
+
+	case errors.Is(err, escrowdom.ErrNotFound):
+		return http.StatusNotFound
+
+	case errors.Is(err, escrowdom.ErrConflict),
+		errors.Is(err, escrowdom.ErrNotHeld),
+		errors.Is(err, escrowdom.ErrAlreadyConfirmed):
+		return http.StatusConflict
+
+	case errors.Is(err, escrowdom.ErrInvalidID),
+		errors.Is(err, escrowdom.ErrInvalidDisputeReason),
+		errors.Is(err, escrowdom.ErrInvalidDisputeComment),
+		errors.Is(err, usecase.ErrEscrowInvalidRole):
+		return http.StatusBadRequest
+
+	case errors.Is(err, usecase.ErrEscrowNotConfigured):
+		return http.StatusNotImplemented
+
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,310 @@
+// backend/internal/adapters/in/http/mall/handler/market_offer_handler.go
+package mallHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	offerdom "narratives/internal/domain/offer"
+)
+
+// MarketOfferHandler serves offers (price negotiation) on market resales.
+//
+// Routes:
+// - GET  /mall/market/offers?role=buyer|seller
+// - POST /mall/market/offers
+// - GET  /mall/market/offers/{offerId}
+// - POST /mall/market/offers/{offerId}/accept
+// - POST /mall/market/offers/{offerId}/counter
+// - POST /mall/market/offers/{offerId}/decline
+// - POST /mall/market/offers/{offerId}/withdraw
+//
+// The accepted offer is checked out through POST /mall/me/orders with
+// items[].offerId.
+type MarketOfferHandler struct {
+	uc *usecase.OfferUsecase
+}
+
+func NewMarketOfferHandler(uc *usecase.OfferUsecase) http.Handler {
+	return &MarketOfferHandler{uc: uc}
+}
+
+const marketOffersPath = "/mall/market/offers"
+
+func (h *MarketOfferHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if h == nil || h.uc == nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "offer usecase is nil",
+		})
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	if path == marketOffersPath {
+		switch r.Method {
+		case http.MethodGet:
+			h.list(w, r)
+			return
+
+		case http.MethodPost:
+			h.place(w, r)
+			return
+
+		default:
+			methodNotAllowed(w)
+			return
+		}
+	}
+
+	if !strings.HasPrefix(path, marketOffersPath+"/") {
+		notFound(w)
+		return
+	}
+
+	rest := strings.TrimPrefix(path, marketOffersPath+"/")
+	parts := strings.Split(rest, "/")
+	offerID := strings.TrimSpace(parts[0])
+
+	if offerID == "" {
+		badRequest(w, "invalid offerId")
+		return
+	}
+
+	if len(parts) == 1 {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.get(w, r, offerID)
+		return
+	}
+
+	if len(parts) != 2 {
+		notFound(w)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	switch parts[1] {
+	case "accept":
+		h.respond(w, r, offerID, h.uc.Accept)
+	case "decline":
+		h.respond(w, r, offerID, h.uc.Decline)
+	case "withdraw":
+		h.respond(w, r, offerID, h.uc.Withdraw)
+	case "counter":
+		h.counter(w, r, offerID)
+	default:
+		notFound(w)
+	}
+}
+
+func (h *MarketOfferHandler) list(w http.ResponseWriter, r *http.Request) {
+	avatarID, ok := currentMarketAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	role := offerdom.Party(strings.TrimSpace(r.URL.Query().Get("role")))
+	if role == "" {
+		role = offerdom.PartyBuyer
+	}
+
+	items, err := h.uc.ListForAvatar(r.Context(), avatarID, role)
+	if err != nil {
+		writeOfferErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items": items,
+	})
+}
+
+func (h *MarketOfferHandler) place(w http.ResponseWriter, r *http.Request) {
+	avatarID, ok := currentMarketAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		ResaleID  string     `json:"resaleId"`
+		Amount    int        `json:"amount"`
+		ExpiresAt *time.Time `json:"expiresAt"`
+	}
+
+	if err := readJSON(r, &req); err != nil {
+		badRequest(w, "invalid json")
+		return
+	}
+
+	if strings.TrimSpace(req.ResaleID) == "" {
+		badRequest(w, "resaleId is required")
+		return
+	}
+
+	created, err := h.uc.Place(r.Context(), usecase.PlaceOfferInput{
+		ResaleID:      req.ResaleID,
+		BuyerAvatarID: avatarID,
+		Amount:        req.Amount,
+		ExpiresAt:     req.ExpiresAt,
+	})
+	if err != nil {
+		writeOfferErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"data": created,
+	})
+}
+
+func (h *MarketOfferHandler) get(
+	w http.ResponseWriter,
+	r *http.Request,
+	offerID string,
+) {
+	avatarID, ok := currentMarketAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.uc.GetForAvatar(r.Context(), offerID, avatarID)
+	if err != nil {
+		writeOfferErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": item,
+	})
+}
+
+func (h *MarketOfferHandler) counter(
+	w http.ResponseWriter,
+	r *http.Request,
+	offerID string,
+) {
+	avatarID, ok := currentMarketAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Amount    int        `json:"amount"`
+		ExpiresAt *time.Time `json:"expiresAt"`
+	}
+
+	if err := readJSON(r, &req); err != nil {
+		badRequest(w, "invalid json")
+		return
+	}
+
+	item, err := h.uc.Counter(r.Context(), usecase.CounterOfferInput{
+		OfferID:   offerID,
+		AvatarID:  avatarID,
+		Amount:    req.Amount,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		writeOfferErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": item,
+	})
+}
+
+func (h *MarketOfferHandler) respond(
+	w http.ResponseWriter,
+	r *http.Request,
+	offerID string,
+	action func(ctx context.Context, offerID string, avatarID string) (offerdom.Offer, error),
+) {
+	avatarID, ok := currentMarketAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := action(r.Context(), offerID, avatarID)
+	if err != nil {
+		writeOfferErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": item,
+	})
+}
+
+func writeOfferErr(w http.ResponseWriter, err error) {
+	msg := "internal error"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	// resale が出品中でない（reserved / sold）場合は resale 側のエラーで返す。
+	status, ok := offerHTTPStatus(err)
+	if !ok {
+		status = resaleHTTPStatus(err)
+	}
+
+	writeJSON(w, status, map[string]string{
+		"error": msg,
+	})
+}
+
+// offerHTTPStatus maps offer errors shared by the offer and order handlers.
+// ok is false when err is not an offer error.
+func offerHTTPStatus(err error) (int, bool) {
+	switch {
+	case err == nil:
+		return 0, false
+
+	case errors.Is(err, offerdom.ErrNotFound):
+		return http.StatusNotFound, true
+
+	case errors.Is(err, offerdom.ErrConflict),
+		errors.Is(err, offerdom.ErrNotYourTurn),
+		errors.Is(err, offerdom.ErrNotOpen),
+		errors.Is(err, offerdom.ErrNotAccepted),
+		errors.Is(err, offerdom.ErrOfferExpired),
+		errors.Is(err, offerdom.ErrAlreadyOrdered):
+		return http.StatusConflict, true
+
+	case errors.Is(err, offerdom.ErrNotParty):
+		return http.StatusForbidden, true
+
+	case errors.Is(err, offerdom.ErrInvalidID),
+		errors.Is(err, offerdom.ErrInvalidResaleID),
+		errors.Is(err, offerdom.ErrInvalidAmount),
+		errors.Is(err, offerdom.ErrInvalidParty),
+		errors.Is(err, offerdom.ErrInvalidExpiresAt),
+		errors.Is(err, offerdom.ErrSelfOffer):
+		return http.StatusBadRequest, true
+
+	case errors.Is(err, usecase.ErrOfferNotConfigured),
+		errors.Is(err, usecase.ErrOrderOfferNotConfigured):
+		return http.StatusServiceUnavailable, true
+
+	default:
+		return 0, false
+	}
+}
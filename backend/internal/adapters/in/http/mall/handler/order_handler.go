@@ -26,11 +26,13 @@ import (
 //   - POST  /mall/me/orders
 //   - GET   /mall/me/orders
 //   - GET   /mall/me/orders/{orderId}
+//   - GET   /mall/me/orders/{orderId}/invoice (receipt PDF)
 //   - PATCH /mall/me/orders/{orderId}/items/{itemIndex}/cancel
 type OrderHandler struct {
 	uc               *usecase.OrderUsecase
 	historyQuery     OrderHistoryQuery
 	orderDetailQuery OrderDetailQuery
+	invoiceRenderer  OrderInvoiceRenderer
 }
 
 type OrderHistoryQuery interface {
@@ -44,16 +46,44 @@ type OrderDetailQuery interface {
 	) (historydto.OrderDetail, error)
 }
 
+// OrderInvoiceRenderer renders the receipt (qualified invoice) PDF of an order.
+type OrderInvoiceRenderer interface {
+	RenderPDF(
+		ctx context.Context,
+		order orderdom.Order,
+	) ([]byte, error)
+}
+
+type OrderHandlerOption func(*OrderHandler)
+
+// WithOrderInvoiceRenderer enables GET /mall/me/orders/{orderId}/invoice.
+func WithOrderInvoiceRenderer(
+	renderer OrderInvoiceRenderer,
+) OrderHandlerOption {
+	return func(h *OrderHandler) {
+		h.invoiceRenderer = renderer
+	}
+}
+
 func NewOrderHandler(
 	uc *usecase.OrderUsecase,
 	historyQuery OrderHistoryQuery,
 	orderDetailQuery OrderDetailQuery,
+	opts ...OrderHandlerOption,
 ) http.Handler {
-	return &OrderHandler{
+	h := &OrderHandler{
 		uc:               uc,
 		historyQuery:     historyQuery,
 		orderDetailQuery: orderDetailQuery,
 	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+
+	return h
 }
 
 func (h *OrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -78,6 +108,11 @@ func (h *OrderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		strings.HasSuffix(path, "/cancel"):
 		h.cancelMe(w, r, path)
 		return
+	case r.Method == http.MethodGet &&
+		strings.HasPrefix(path, "/mall/me/orders/") &&
+		strings.HasSuffix(path, "/invoice"):
+		h.invoiceMe(w, r, path)
+		return
 	case r.Method == http.MethodGet &&
 		strings.HasPrefix(path, "/mall/me/orders/"):
 		h.getMe(w, r, path)
@@ -99,6 +134,9 @@ type orderItemRequest struct {
 	// resale item identifier
 	ResaleID string `json:"resaleId"`
 
+	// accepted market offer (optional)
+	OfferID string `json:"offerId"`
+
 	Qty int `json:"qty"`
 
 	// Reserved for future order creation behavior.
@@ -112,6 +150,9 @@ type createOrderRequest struct {
 	ShippingAddressID string             `json:"shippingAddressId"`
 	PaymentMethodID   string             `json:"paymentMethodId"`
 	Items             []orderItemRequest `json:"items"`
+
+	// CouponCode is optional.
+	CouponCode string `json:"couponCode"`
 }
 
 func (h *OrderHandler) post(w http.ResponseWriter, r *http.Request) {
@@ -183,6 +224,7 @@ func (h *OrderHandler) post(w http.ResponseWriter, r *http.Request) {
 		ShippingAddressID: req.ShippingAddressID,
 		PaymentMethodID:   req.PaymentMethodID,
 		Items:             items,
+		CouponCode:        req.CouponCode,
 	}
 
 	out, err := h.uc.Create(ctx, in)
@@ -221,6 +263,7 @@ func orderItemRequestToInput(item orderItemRequest) (usecase.CreateOrderItemInpu
 		return usecase.CreateOrderItemInput{
 			Type:         orderdom.OrderItemTypeResale,
 			ResaleID:     item.ResaleID,
+			OfferID:      item.OfferID,
 			Qty:          1,
 			IsCancelled:  item.IsCancelled,
 			IsDispatched: item.IsDispatched,
@@ -292,6 +335,76 @@ func (h *OrderHandler) getMe(
 	_ = json.NewEncoder(w).Encode(detail)
 }
 
+// invoiceMe returns the receipt PDF. One page is issued per seller company.
+func (h *OrderHandler) invoiceMe(
+	w http.ResponseWriter,
+	r *http.Request,
+	path string,
+) {
+	ctx := r.Context()
+
+	avatarID, ok := middleware.CurrentAvatarID(r)
+	if !ok || avatarID == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized: missing avatarId"})
+		return
+	}
+
+	orderID := strings.TrimSpace(
+		strings.TrimSuffix(
+			strings.TrimPrefix(
+				path,
+				"/mall/me/orders/",
+			),
+			"/invoice",
+		),
+	)
+
+	if orderID == "" || strings.Contains(orderID, "/") {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "not_found"})
+		return
+	}
+
+	if h.invoiceRenderer == nil {
+		writeOrderErr(w, usecase.ErrInvoiceNotConfigured)
+		return
+	}
+
+	out, err := h.uc.GetByID(ctx, orderID)
+	if err != nil {
+		writeOrderErr(w, err)
+		return
+	}
+
+	if out.AvatarID != avatarID {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "not_found"})
+		return
+	}
+
+	// 領収書は決済完了後にのみ発行する。
+	if !out.Paid {
+		writeOrderErr(w, usecase.ErrInvoiceOrderNotPaid)
+		return
+	}
+
+	body, err := h.invoiceRenderer.RenderPDF(ctx, out)
+	if err != nil {
+		writeOrderErr(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set(
+		"Content-Disposition",
+		`attachment; filename="receipt-`+out.ID+`.pdf"`,
+	)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
 func (h *OrderHandler) cancelMe(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -477,6 +590,13 @@ func writeOrderErr(w http.ResponseWriter, err error) {
 }
 
 func orderHTTPStatus(err error) int {
+	if status, ok := couponHTTPStatus(err); ok {
+		return status
+	}
+	if status, ok := offerHTTPStatus(err); ok {
+		return status
+	}
+
 	switch {
 	case err == nil:
 		return http.StatusInternalServerError
@@ -493,7 +613,10 @@ func orderHTTPStatus(err error) int {
 		return http.StatusNotFound
 
 	case errors.Is(err, orderdom.ErrConflict),
-		errors.Is(err, shippingaddressdom.ErrConflict):
+		errors.Is(err, inventorydom.ErrInsufficientStock),
+		errors.Is(err, shippingaddressdom.ErrConflict),
+		errors.Is(err, usecase.ErrInvoiceNothingToIssue),
+		errors.Is(err, usecase.ErrInvoiceOrderNotPaid):
 		return http.StatusConflict
 
 	case isInvalidOrderError(err),
@@ -501,10 +624,16 @@ func orderHTTPStatus(err error) int {
 		isInvalidShippingQuoteError(err):
 		return http.StatusBadRequest
 
+	case errors.Is(err, listdom.ErrHolderOnly):
+		return http.StatusForbidden
+
 	case isUnprocessableShippingQuoteError(err):
 		return http.StatusUnprocessableEntity
 
-	case isUnavailableShippingQuoteError(err):
+	case isUnavailableShippingQuoteError(err),
+		errors.Is(err, usecase.ErrInvoiceNotConfigured),
+		errors.Is(err, usecase.ErrOrderListAccessNotConfigured),
+		errors.Is(err, usecase.ErrListAccessNotConfigured):
 		return http.StatusServiceUnavailable
 
 	default:
@@ -523,7 +652,8 @@ func isInvalidOrderError(err error) bool {
 		errors.Is(err, orderdom.ErrInvalidPaymentMethod) ||
 		errors.Is(err, orderdom.ErrInvalidItems) ||
 		errors.Is(err, orderdom.ErrInvalidItemSnapshot) ||
-		errors.Is(err, orderdom.ErrInvalidCreatedAt)
+		errors.Is(err, orderdom.ErrInvalidCreatedAt) ||
+		errors.Is(err, orderdom.ErrInvalidDiscount)
 }
 
 func isInvalidShippingQuoteError(err error) bool {
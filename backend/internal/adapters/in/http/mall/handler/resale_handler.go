@@ -1021,7 +1021,9 @@ func resaleHTTPStatus(err error) int {
 
 	case errors.Is(err, resaledom.ErrConflict),
 		errors.Is(err, resaledom.ErrConditionImageConflict),
-		errors.Is(err, resaledom.ErrSoldResaleCannotBeDeleted):
+		errors.Is(err, resaledom.ErrSoldResaleCannotBeDeleted),
+		errors.Is(err, resaledom.ErrNotListing),
+		errors.Is(err, resaledom.ErrReserved):
 		return http.StatusConflict
 
 	case errors.Is(err, resaledom.ErrInvalidID),
@@ -0,0 +1,404 @@
+// backend/internal/adapters/in/http/mall/handler/return_handler.go
+package mallHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	returndom "narratives/internal/domain/orderReturn"
+)
+
+// ReturnHandler serves the buyer side of return requests.
+//
+// Routes:
+// - GET    /mall/me/returns
+// - POST   /mall/me/returns
+// - GET    /mall/me/returns/{returnId}
+// - POST   /mall/me/returns/{returnId}/cancel
+// - GET    /mall/me/returns/{returnId}/images
+// - POST   /mall/me/returns/{returnId}/images
+// - DELETE /mall/me/returns/{returnId}/images/{imageId}
+type ReturnHandler struct {
+	uc *usecase.ReturnUsecase
+}
+
+func NewReturnHandler(uc *usecase.ReturnUsecase) http.Handler {
+	return &ReturnHandler{uc: uc}
+}
+
+const meReturnsPath = "/mall/me/returns"
+
+func (h *ReturnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if h == nil || h.uc == nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "return usecase is nil",
+		})
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path == "" {
+		path = r.URL.Path
+	}
+
+	if path == meReturnsPath {
+		switch r.Method {
+		case http.MethodGet:
+			h.list(w, r)
+			return
+
+		case http.MethodPost:
+			h.create(w, r)
+			return
+
+		default:
+			methodNotAllowed(w)
+			return
+		}
+	}
+
+	if !strings.HasPrefix(path, meReturnsPath+"/") {
+		notFound(w)
+		return
+	}
+
+	rest := strings.TrimPrefix(path, meReturnsPath+"/")
+	parts := strings.Split(rest, "/")
+	returnID := strings.TrimSpace(parts[0])
+
+	if returnID == "" {
+		badRequest(w, "invalid returnId")
+		return
+	}
+
+	switch {
+	case len(parts) == 1:
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w)
+			return
+		}
+		h.get(w, r, returnID)
+		return
+
+	case len(parts) == 2 && parts[1] == "cancel":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w)
+			return
+		}
+		h.cancel(w, r, returnID)
+		return
+
+	case len(parts) == 2 && parts[1] == "images":
+		switch r.Method {
+		case http.MethodGet:
+			h.listImages(w, r, returnID)
+			return
+
+		case http.MethodPost:
+			h.createImage(w, r, returnID)
+			return
+
+		default:
+			methodNotAllowed(w)
+			return
+		}
+
+	case len(parts) == 3 && parts[1] == "images" && parts[2] != "":
+		if r.Method != http.MethodDelete {
+			methodNotAllowed(w)
+			return
+		}
+		h.deleteImage(w, r, returnID, parts[2])
+		return
+
+	default:
+		notFound(w)
+		return
+	}
+}
+
+func (h *ReturnHandler) list(w http.ResponseWriter, r *http.Request) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := h.uc.ListForAvatar(r.Context(), avatarID)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": items,
+	})
+}
+
+func (h *ReturnHandler) create(w http.ResponseWriter, r *http.Request) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		OrderID    string   `json:"orderId"`
+		ItemIndex  *int     `json:"itemIndex"`
+		ProductIDs []string `json:"productIds"`
+		Reasons    []string `json:"reasons"`
+		Comment    string   `json:"comment"`
+		Resolution string   `json:"resolution"`
+	}
+
+	if err := readJSON(r, &req); err != nil {
+		badRequest(w, "invalid json")
+		return
+	}
+
+	if strings.TrimSpace(req.OrderID) == "" {
+		badRequest(w, "orderId is required")
+		return
+	}
+
+	if req.ItemIndex == nil {
+		badRequest(w, "itemIndex is required")
+		return
+	}
+
+	reasons := make([]returndom.Reason, 0, len(req.Reasons))
+	for _, reason := range req.Reasons {
+		reasons = append(reasons, returndom.Reason(strings.TrimSpace(reason)))
+	}
+
+	created, err := h.uc.RequestReturn(r.Context(), usecase.RequestReturnInput{
+		AvatarID:   avatarID,
+		OrderID:    strings.TrimSpace(req.OrderID),
+		ItemIndex:  *req.ItemIndex,
+		ProductIDs: req.ProductIDs,
+		Reasons:    reasons,
+		Comment:    req.Comment,
+		Resolution: returndom.Resolution(strings.TrimSpace(req.Resolution)),
+	})
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"data": created,
+	})
+}
+
+func (h *ReturnHandler) get(
+	w http.ResponseWriter,
+	r *http.Request,
+	returnID string,
+) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.uc.GetForAvatar(r.Context(), avatarID, returnID)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": item,
+	})
+}
+
+func (h *ReturnHandler) cancel(
+	w http.ResponseWriter,
+	r *http.Request,
+	returnID string,
+) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.uc.Cancel(r.Context(), avatarID, returnID)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": item,
+	})
+}
+
+func (h *ReturnHandler) listImages(
+	w http.ResponseWriter,
+	r *http.Request,
+	returnID string,
+) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	images, err := h.uc.ListImages(r.Context(), avatarID, returnID)
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": images,
+	})
+}
+
+// createImage stores a return photo record.
+// 画像本体は frontend が Firebase Storage に直接アップロードし、
+// backend は download URL のみを保存する（resale の状態画像と同じ方針）。
+func (h *ReturnHandler) createImage(
+	w http.ResponseWriter,
+	r *http.Request,
+	returnID string,
+) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		ID           string `json:"id"`
+		URL          string `json:"url"`
+		DisplayOrder int    `json:"displayOrder"`
+	}
+
+	if err := readJSON(r, &req); err != nil {
+		badRequest(w, "invalid json")
+		return
+	}
+
+	if strings.TrimSpace(req.ID) == "" {
+		badRequest(w, "id is required")
+		return
+	}
+
+	if strings.TrimSpace(req.URL) == "" {
+		badRequest(w, "url is required")
+		return
+	}
+
+	if req.DisplayOrder < 0 {
+		badRequest(w, "displayOrder must be >= 0")
+		return
+	}
+
+	img, err := h.uc.AddImage(r.Context(), avatarID, returndom.ReturnImage{
+		ID:           req.ID,
+		ReturnID:     returnID,
+		URL:          req.URL,
+		DisplayOrder: req.DisplayOrder,
+	})
+	if err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"data": img,
+	})
+}
+
+func (h *ReturnHandler) deleteImage(
+	w http.ResponseWriter,
+	r *http.Request,
+	returnID string,
+	imageID string,
+) {
+	avatarID, ok := requireAvatarID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.DeleteImage(
+		r.Context(),
+		avatarID,
+		returnID,
+		imageID,
+	); err != nil {
+		writeReturnErr(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"ok":       true,
+		"returnId": returnID,
+		"imageId":  imageID,
+	})
+}
+
+func writeReturnErr(w http.ResponseWriter, err error) {
+	msg := "internal error"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	writeJSON(w, returnHTTPStatus(err), map[string]string{
+		"error": msg,
+	})
+}
+
+func returnHTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusInternalServerError
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return http.StatusRequestTimeout
+
+	case errors.Is(err, returndom.ErrNotFound),
+		errors.Is(err, returndom.ErrImageNotFound):
+		return http.StatusNotFound
+
+	case errors.Is(err, returndom.ErrConflict),
+		errors.Is(err, returndom.ErrImageConflict),
+		errors.Is(err, returndom.ErrInvalidTransition),
+		errors.Is(err, returndom.ErrTooManyImages),
+		errors.Is(err, usecase.ErrReturnNotEditable),
+		errors.Is(err, usecase.ErrReturnOrderNotPaid),
+		errors.Is(err, usecase.ErrReturnItemNotReturnable),
+		errors.Is(err, usecase.ErrReturnAlreadyRefunded):
+		return http.StatusConflict
+
+	case errors.Is(err, returndom.ErrInvalidID),
+		errors.Is(err, returndom.ErrInvalidOrderID),
+		errors.Is(err, returndom.ErrInvalidItemIndex),
+		errors.Is(err, returndom.ErrInvalidProductIDs),
+		errors.Is(err, returndom.ErrInvalidReason),
+		errors.Is(err, returndom.ErrInvalidComment),
+		errors.Is(err, returndom.ErrInvalidResolution),
+		errors.Is(err, returndom.ErrInvalidImageID),
+		errors.Is(err, returndom.ErrInvalidImageURL),
+		errors.Is(err, returndom.ErrInvalidImageOrder),
+		errors.Is(err, usecase.ErrReturnProductIDsRequired),
+		errors.Is(err, usecase.ErrReturnProductIDsNotAllowed),
+		errors.Is(err, usecase.ErrReturnProductNotTransferred),
+		errors.Is(err, usecase.ErrReturnTokenMismatch):
+		return http.StatusBadRequest
+
+	case errors.Is(err, usecase.ErrReturnNotConfigured):
+		return http.StatusNotImplemented
+
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -2,6 +2,7 @@
 package mallHandler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -13,6 +14,7 @@ import (
 	inventorydom "narratives/internal/domain/inventory"
 	listdom "narratives/internal/domain/list"
 	modeldom "narratives/internal/domain/model"
+	orderdom "narratives/internal/domain/order"
 	shippingaddressdom "narratives/internal/domain/shippingAddress"
 	transportationdom "narratives/internal/domain/transportation"
 )
@@ -21,14 +23,44 @@ const maxShippingQuoteBodyBytes int64 = 1 << 20 // 1 MiB
 
 type ShippingQuoteHandler struct {
 	uc *usecase.ShippingQuoteUsecase
+
+	discountPreviewer ShippingQuoteDiscountPreviewer
+}
+
+// ShippingQuoteDiscountPreviewer prices a coupon against the quoted items.
+type ShippingQuoteDiscountPreviewer interface {
+	PreviewDiscount(
+		ctx context.Context,
+		in usecase.PreviewOrderDiscountInput,
+	) (orderdom.DiscountSnapshot, error)
+}
+
+type ShippingQuoteHandlerOption func(*ShippingQuoteHandler)
+
+// WithShippingQuoteDiscountPreviewer enables couponCode in the request.
+func WithShippingQuoteDiscountPreviewer(
+	previewer ShippingQuoteDiscountPreviewer,
+) ShippingQuoteHandlerOption {
+	return func(h *ShippingQuoteHandler) {
+		h.discountPreviewer = previewer
+	}
 }
 
 func NewShippingQuoteHandler(
 	uc *usecase.ShippingQuoteUsecase,
+	opts ...ShippingQuoteHandlerOption,
 ) http.Handler {
-	return &ShippingQuoteHandler{
+	h := &ShippingQuoteHandler{
 		uc: uc,
 	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+
+	return h
 }
 
 type shippingQuoteItemRequest struct {
@@ -41,6 +73,9 @@ type shippingQuoteRequest struct {
 	Items []shippingQuoteItemRequest `json:"items"`
 
 	ShippingAddressID string `json:"shippingAddressId"`
+
+	// CouponCode is optional. When set, the response carries the discount.
+	CouponCode string `json:"couponCode,omitempty"`
 }
 
 type shippingQuoteItemResponse struct {
@@ -58,6 +93,16 @@ type shippingQuoteItemResponse struct {
 	Amount     int64 `json:"amount"`
 
 	Currency string `json:"currency"`
+
+	Customs *shippingQuoteCustomsResponse `json:"customs,omitempty"`
+}
+
+type shippingQuoteCustomsResponse struct {
+	HSCode        string `json:"hsCode"`
+	Description   string `json:"description"`
+	OriginCountry string `json:"originCountry"`
+
+	DeclaredUnitValue int64 `json:"declaredUnitValue"`
 }
 
 type shippingQuoteResponse struct {
@@ -66,6 +111,8 @@ type shippingQuoteResponse struct {
 	ShippingAmount int64 `json:"shippingAmount"`
 
 	Currency string `json:"currency"`
+
+	Discount *orderdom.DiscountSnapshot `json:"discount,omitempty"`
 }
 
 func (h *ShippingQuoteHandler) ServeHTTP(
@@ -170,6 +217,13 @@ func (h *ShippingQuoteHandler) post(
 
 	var shippingAmount int64
 
+	quoteSnapshotItems :=
+		make(
+			[]orderdom.ShippingQuoteItemSnapshot,
+			0,
+			len(request.Items),
+		)
+
 	for _, item := range request.Items {
 		if item.ListID == "" {
 			writeJSON(
@@ -268,6 +322,31 @@ func (h *ShippingQuoteHandler) post(
 		shippingAmount =
 			nextShippingAmount
 
+		quoteSnapshotItems =
+			append(
+				quoteSnapshotItems,
+				orderdom.ShippingQuoteItemSnapshot{
+					ListID:      quote.ListID,
+					InventoryID: quote.InventoryID,
+					ModelID:     quote.ModelID,
+					Qty:         item.Qty,
+					UnitAmount:  int(quote.Amount),
+					Amount:      int(lineAmount),
+					Currency:    quote.Currency,
+				},
+			)
+
+		var customs *shippingQuoteCustomsResponse
+		if quote.Customs != nil {
+			customs = &shippingQuoteCustomsResponse{
+				HSCode:        quote.Customs.HSCode,
+				Description:   quote.Customs.Description,
+				OriginCountry: quote.Customs.OriginCountry,
+
+				DeclaredUnitValue: quote.Customs.DeclaredUnitValue,
+			}
+		}
+
 		items =
 			append(
 				items,
@@ -279,7 +358,7 @@ func (h *ShippingQuoteHandler) post(
 					Qty: item.Qty,
 
 					Carrier: string(
-						quote.TransportationOption,
+						quote.Carrier,
 					),
 
 					TransportationID: quote.TransportationID,
@@ -291,10 +370,33 @@ func (h *ShippingQuoteHandler) post(
 					Amount: lineAmount,
 
 					Currency: quote.Currency,
+
+					Customs: customs,
 				},
 			)
 	}
 
+	var discount *orderdom.DiscountSnapshot
+
+	if strings.TrimSpace(request.CouponCode) != "" {
+		preview, ok :=
+			h.previewDiscount(
+				w,
+				r,
+				request,
+				orderdom.ShippingQuoteSnapshot{
+					Items:    quoteSnapshotItems,
+					Amount:   int(shippingAmount),
+					Currency: orderdom.ShippingQuoteCurrencyJPY,
+				},
+			)
+		if !ok {
+			return
+		}
+
+		discount = &preview
+	}
+
 	writeJSON(
 		w,
 		http.StatusOK,
@@ -304,10 +406,76 @@ func (h *ShippingQuoteHandler) post(
 			ShippingAmount: shippingAmount,
 
 			Currency: "JPY",
+
+			Discount: discount,
 		},
 	)
 }
 
+func (h *ShippingQuoteHandler) previewDiscount(
+	w http.ResponseWriter,
+	r *http.Request,
+	request shippingQuoteRequest,
+	quote orderdom.ShippingQuoteSnapshot,
+) (orderdom.DiscountSnapshot, bool) {
+	if h.discountPreviewer == nil {
+		writeJSON(
+			w,
+			http.StatusServiceUnavailable,
+			map[string]string{
+				"error": "coupon_not_configured",
+			},
+		)
+		return orderdom.DiscountSnapshot{}, false
+	}
+
+	avatarID, _ :=
+		middleware.CurrentAvatarID(
+			r,
+		)
+
+	orderItems :=
+		make(
+			[]usecase.CreateOrderItemInput,
+			0,
+			len(request.Items),
+		)
+
+	for _, item := range request.Items {
+		orderItems =
+			append(
+				orderItems,
+				usecase.CreateOrderItemInput{
+					Type:    orderdom.OrderItemTypeList,
+					ListID:  item.ListID,
+					ModelID: item.ModelID,
+					Qty:     item.Qty,
+				},
+			)
+	}
+
+	discount, err :=
+		h.discountPreviewer.PreviewDiscount(
+			r.Context(),
+			usecase.PreviewOrderDiscountInput{
+				AvatarID:      avatarID,
+				CouponCode:    request.CouponCode,
+				Items:         orderItems,
+				ShippingQuote: quote,
+			},
+		)
+	if err != nil {
+		// coupon / list / inventory のエラーは注文作成と同じ status で返す。
+		writeOrderErr(
+			w,
+			err,
+		)
+		return orderdom.DiscountSnapshot{}, false
+	}
+
+	return discount, true
+}
+
 func (h *ShippingQuoteHandler) requireUsecase(
 	w http.ResponseWriter,
 ) bool {
@@ -471,6 +639,10 @@ func writeShippingQuoteErr(
 			err,
 			transportationdom.ErrUnsupportedCountry,
 		),
+		errors.Is(
+			err,
+			transportationdom.ErrInvalidPostalCode,
+		),
 		errors.Is(
 			err,
 			transportationdom.ErrInvalidPrefectureCode,
@@ -513,6 +685,18 @@ func writeShippingQuoteErr(
 		errors.Is(
 			err,
 			transportationdom.ErrPostRateNotFound,
+		),
+		errors.Is(
+			err,
+			transportationdom.ErrInternationalPackageTooLarge,
+		),
+		errors.Is(
+			err,
+			transportationdom.ErrInternationalPackageTooHeavy,
+		),
+		errors.Is(
+			err,
+			transportationdom.ErrInternationalRateNotFound,
 		):
 		statusCode =
 			http.StatusUnprocessableEntity
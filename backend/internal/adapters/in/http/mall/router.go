@@ -54,18 +54,37 @@ type Deps struct {
 	Preview   http.Handler
 	PreviewMe http.Handler
 
+	// public: QR code verification
+	// - GET /mall/authenticity/{productId}
+	// - GET /mall/authenticity?productId=...
+	Authenticity http.Handler
+
 	OrderScanTransfer http.Handler
 
 	OwnerResolve http.Handler
 
 	Order http.Handler
 
+	// returns (me)
+	// - GET/POST /mall/me/returns
+	// - GET  /mall/me/returns/{id}
+	// - POST /mall/me/returns/{id}/cancel
+	// - GET/POST /mall/me/returns/{id}/images
+	// - DELETE /mall/me/returns/{id}/images/{imageId}
+	Return http.Handler
+
 	// market resales (auth + avatar required)
 	// - GET /mall/market/resales
 	// - GET /mall/market/resales/cursor
 	// - GET /mall/market/resales/{id}
 	Market http.Handler
 
+	// market offers (auth + avatar required)
+	// - GET/POST /mall/market/offers
+	// - GET  /mall/market/offers/{id}
+	// - POST /mall/market/offers/{id}/{accept|counter|decline|withdraw}
+	MarketOffer http.Handler
+
 	// resales
 	// public:
 	// - GET /mall/resales/avatar/{avatarId}
@@ -96,6 +115,12 @@ type Deps struct {
 
 	// /mall/me/setup-status (existence checks for redirect)
 	SetupStatus http.Handler
+
+	// resale escrows (me)
+	// - GET  /mall/me/escrows?role=buyer|seller
+	// - GET  /mall/me/escrows/{id}
+	// - POST /mall/me/escrows/{id}/{confirm|dispute}
+	Escrow http.Handler
 }
 
 // handleSafe registers pattern with h.
@@ -276,10 +301,10 @@ func paymentReadOnlyHandler(
 // Register registers buyer-facing routes onto mux (mall only).
 //
 // auth:
-//   - /mall/market/resales** and /mall/me/** routes requiring user auth
+//   - /mall/market/resales**, /mall/market/offers** and /mall/me/** routes requiring user auth
 //
 // avatar:
-//   - /mall/market/resales** and /mall/me/** routes requiring avatar context
+//   - /mall/market/resales**, /mall/market/offers** and /mall/me/** routes requiring avatar context
 func Register(
 	mux *http.ServeMux,
 	deps Deps,
@@ -391,6 +416,10 @@ func Register(
 	handleSafe(mux, "/mall/preview", deps.Preview, "Preview")
 	handleSafe(mux, "/mall/preview/", deps.Preview, "Preview")
 
+	// authenticity (public)
+	handleSafe(mux, "/mall/authenticity", deps.Authenticity, "Authenticity")
+	handleSafe(mux, "/mall/authenticity/", deps.Authenticity, "Authenticity")
+
 	// resales by public avatar
 	handleSafe(
 		mux,
@@ -447,6 +476,24 @@ func Register(
 		avatar,
 	)
 
+	// market offers
+	handleSafeAuthAvatar(
+		mux,
+		"/mall/market/offers",
+		deps.MarketOffer,
+		"MarketOffer",
+		auth,
+		avatar,
+	)
+	handleSafeAuthAvatar(
+		mux,
+		"/mall/market/offers/",
+		deps.MarketOffer,
+		"MarketOffer",
+		auth,
+		avatar,
+	)
+
 	// ------------------------------------------------------------
 	// Auth-required routes (/mall/me/**)
 	// setup-status / users / shipping-addresses / payment-methods are auth-only.
@@ -741,6 +788,42 @@ func Register(
 		avatar,
 	)
 
+	// returns (me)
+	handleSafeAuthAvatar(
+		mux,
+		"/mall/me/returns",
+		deps.Return,
+		"Return(me)",
+		auth,
+		avatar,
+	)
+	handleSafeAuthAvatar(
+		mux,
+		"/mall/me/returns/",
+		deps.Return,
+		"Return(me)",
+		auth,
+		avatar,
+	)
+
+	// escrows (me)
+	handleSafeAuthAvatar(
+		mux,
+		"/mall/me/escrows",
+		deps.Escrow,
+		"Escrow(me)",
+		auth,
+		avatar,
+	)
+	handleSafeAuthAvatar(
+		mux,
+		"/mall/me/escrows/",
+		deps.Escrow,
+		"Escrow(me)",
+		auth,
+		avatar,
+	)
+
 	// resales (me)
 	handleSafeAuthAvatar(
 		mux,
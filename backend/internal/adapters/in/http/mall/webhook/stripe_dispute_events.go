@@ -0,0 +1,131 @@
+// backend/internal/adapters/in/http/mall/webhook/stripe_dispute_events.go
+package mallHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	paymentdom "narratives/internal/domain/payment"
+)
+
+// ============================================================
+// Stripe dispute event parsing
+// ============================================================
+
+type stripeDispute struct {
+	ID            string `json:"id"`
+	PaymentIntent string `json:"payment_intent"`
+	Status        string `json:"status"`
+	Reason        string `json:"reason"`
+	Amount        int    `json:"amount"`
+	Created       int64  `json:"created"`
+}
+
+// isStripeDisputeEventType reports whether the event embeds a Dispute.
+func isStripeDisputeEventType(
+	eventType string,
+) bool {
+	switch eventType {
+	case "charge.dispute.created",
+		"charge.dispute.updated",
+		"charge.dispute.closed":
+		return true
+
+	default:
+		return false
+	}
+}
+
+// extractStripeDisputeEventInput converts a verified charge.dispute.* event
+// into the application-level event input.
+//
+// Disputes without a PaymentIntent (e.g. charges created outside the
+// PaymentIntent flow) cannot belong to this application and are ignored.
+func extractStripeDisputeEventInput(
+	event stripeEvent,
+) (
+	input usecase.ApplyStripeDisputeEventInput,
+	supported bool,
+	err error,
+) {
+	eventID := strings.TrimSpace(event.ID)
+	if eventID == "" {
+		return usecase.ApplyStripeDisputeEventInput{},
+			false,
+			errors.New(
+				"Stripe event id is empty",
+			)
+	}
+
+	var dispute stripeDispute
+	if err := json.Unmarshal(
+		event.Data.Object,
+		&dispute,
+	); err != nil {
+		return usecase.ApplyStripeDisputeEventInput{},
+			false,
+			fmt.Errorf(
+				"decode Stripe Dispute: %w",
+				err,
+			)
+	}
+
+	stripePaymentIntentID := strings.TrimSpace(
+		dispute.PaymentIntent,
+	)
+	if stripePaymentIntentID == "" {
+		return usecase.ApplyStripeDisputeEventInput{},
+			false,
+			nil
+	}
+
+	status := paymentdom.DisputeStatus(
+		strings.TrimSpace(dispute.Status),
+	)
+	if !paymentdom.IsValidDisputeStatus(status) {
+		return usecase.ApplyStripeDisputeEventInput{},
+			false,
+			fmt.Errorf(
+				"unknown Stripe dispute status %q",
+				dispute.Status,
+			)
+	}
+
+	occurredUnix := event.Created
+	if occurredUnix <= 0 {
+		occurredUnix = dispute.Created
+	}
+	if occurredUnix <= 0 {
+		return usecase.ApplyStripeDisputeEventInput{},
+			false,
+			errors.New(
+				"Stripe event created timestamp is invalid",
+			)
+	}
+
+	var openedAt time.Time
+	if dispute.Created > 0 {
+		openedAt = time.Unix(dispute.Created, 0).UTC()
+	}
+
+	return usecase.ApplyStripeDisputeEventInput{
+		EventID: eventID,
+
+		StripePaymentIntentID: stripePaymentIntentID,
+		StripeDisputeID:       strings.TrimSpace(dispute.ID),
+
+		Status: status,
+		Reason: strings.TrimSpace(dispute.Reason),
+		Amount: dispute.Amount,
+
+		OpenedAt: openedAt,
+		OccurredAt: time.Unix(
+			occurredUnix,
+			0,
+		).UTC(),
+	}, true, nil
+}
@@ -0,0 +1,184 @@
+// backend/internal/adapters/in/http/mall/webhook/stripe_event_processor.go
+package mallHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	paymentdom "narratives/internal/domain/payment"
+)
+
+var (
+	errStripePaymentUsecaseMissing = errors.New(
+		"stripe webhook: payment usecase is not initialized",
+	)
+	errStripeRefundUsecaseMissing = errors.New(
+		"stripe webhook: refund usecase is not initialized",
+	)
+)
+
+// StripeEventProcessor applies a verified Stripe event (the raw event JSON) to
+// Payment / Refund. It is shared by the webhook and the console replay.
+//
+// Every apply path deduplicates the Stripe event ID inside its own Firestore
+// transaction, so processing the same event again is a successful no-op.
+type StripeEventProcessor struct {
+	paymentUC *usecase.PaymentUsecase
+	refundUC  *usecase.RefundUsecase
+}
+
+var _ usecase.StripeEventProcessor = (*StripeEventProcessor)(nil)
+
+func NewStripeEventProcessor(
+	paymentUC *usecase.PaymentUsecase,
+	refundUC *usecase.RefundUsecase,
+) *StripeEventProcessor {
+	return &StripeEventProcessor{
+		paymentUC: paymentUC,
+		refundUC:  refundUC,
+	}
+}
+
+// ProcessStripeEvent returns handled=false for event types and objects that
+// do not belong to this application. Malformed event data is returned as
+// usecase.ErrStripeEventInvalid; any other error should be retried.
+func (p *StripeEventProcessor) ProcessStripeEvent(
+	ctx context.Context,
+	eventType string,
+	payload []byte,
+) (bool, error) {
+	if p == nil || p.paymentUC == nil {
+		return false, errStripePaymentUsecaseMissing
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return false, invalidStripeEvent(err)
+	}
+
+	eventType = strings.TrimSpace(eventType)
+	if eventType == "" {
+		eventType = strings.TrimSpace(event.Type)
+	}
+
+	switch {
+	case isStripeRefundEventType(eventType):
+		return p.processRefundEvent(ctx, event)
+
+	case isStripeDisputeEventType(eventType):
+		return p.processDisputeEvent(ctx, event)
+	}
+
+	input, supported, err :=
+		extractStripePaymentEventInput(event)
+	if err != nil {
+		return false, invalidStripeEvent(err)
+	}
+
+	// Unsupported event types and PaymentIntents that do not belong to this
+	// application are acknowledged so Stripe does not retry them.
+	if !supported {
+		return false, nil
+	}
+
+	// ApplyStripeEvent performs the following operations through a Firestore
+	// Transaction:
+	//
+	// - event ID deduplication
+	// - PaymentIntent ID verification
+	// - status transition validation
+	// - status update
+	// - first-succeeded post-paid marker acquisition
+	//
+	// A duplicate event is a successful no-op.
+	//
+	// Errors are retried. This is also important when Stripe sends the webhook
+	// after creating the PaymentIntent but before the payments document has
+	// been created.
+	if _, err := p.paymentUC.ApplyStripeEvent(
+		ctx,
+		input,
+	); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// processRefundEvent applies charge.refunded / refund.* events.
+//
+// Each refund is applied through RefundUsecase.ApplyStripeEvent, which
+// deduplicates the event and reflects succeeded refunds into the Order.
+func (p *StripeEventProcessor) processRefundEvent(
+	ctx context.Context,
+	event stripeEvent,
+) (bool, error) {
+	inputs, err := extractStripeRefundEventInputs(event)
+	if err != nil {
+		return false, invalidStripeEvent(err)
+	}
+
+	if len(inputs) == 0 {
+		return false, nil
+	}
+
+	if p.refundUC == nil {
+		return false, errStripeRefundUsecaseMissing
+	}
+
+	for _, input := range inputs {
+		// A refund created by IssueRefund may not be persisted yet when
+		// Stripe sends the event. The error lets Stripe retry it.
+		if _, err := p.refundUC.ApplyStripeEvent(
+			ctx,
+			input,
+		); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// processDisputeEvent applies charge.dispute.* events to the Payment of the
+// disputed PaymentIntent.
+func (p *StripeEventProcessor) processDisputeEvent(
+	ctx context.Context,
+	event stripeEvent,
+) (bool, error) {
+	input, supported, err :=
+		extractStripeDisputeEventInput(event)
+	if err != nil {
+		return false, invalidStripeEvent(err)
+	}
+
+	if !supported {
+		return false, nil
+	}
+
+	if _, err := p.paymentUC.ApplyStripeDisputeEvent(
+		ctx,
+		input,
+	); err != nil {
+		// The Payment is created before the PaymentIntent can be charged,
+		// so an unknown PaymentIntent belongs to another application.
+		if errors.Is(err, paymentdom.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func invalidStripeEvent(err error) error {
+	return fmt.Errorf(
+		"%w: %v",
+		usecase.ErrStripeEventInvalid,
+		err,
+	)
+}
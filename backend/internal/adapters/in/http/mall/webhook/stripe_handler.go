@@ -16,12 +16,13 @@ import (
 
 	usecase "narratives/internal/application/usecase"
 	paymentdom "narratives/internal/domain/payment"
+	stripeeventdom "narratives/internal/domain/stripeEvent"
 )
 
 const stripeWebhookMaxBodyBytes int64 = 1 << 20 // 1 MiB
 
 type StripeWebhookHandler struct {
-	paymentUC *usecase.PaymentUsecase
+	eventUC *usecase.StripeWebhookEventUsecase
 
 	// Stripe webhook signing secret (whsec_...).
 	signingSecret string
@@ -34,11 +35,11 @@ type StripeWebhookHandler struct {
 }
 
 func NewStripeWebhookHandler(
-	paymentUC *usecase.PaymentUsecase,
+	eventUC *usecase.StripeWebhookEventUsecase,
 	signingSecret string,
 ) http.Handler {
 	return &StripeWebhookHandler{
-		paymentUC:     paymentUC,
+		eventUC:       eventUC,
 		signingSecret: signingSecret,
 		tolerance:     5 * time.Minute,
 		now:           time.Now,
@@ -72,12 +73,12 @@ func (h *StripeWebhookHandler) ServeHTTP(
 		return
 	}
 
-	if h == nil || h.paymentUC == nil {
+	if h == nil || h.eventUC == nil {
 		writeStripeWebhookJSON(
 			w,
 			http.StatusInternalServerError,
 			map[string]string{
-				"error": "payment_usecase_not_initialized",
+				"error": "stripe_event_usecase_not_initialized",
 			},
 		)
 		return
@@ -166,50 +167,37 @@ func (h *StripeWebhookHandler) ServeHTTP(
 		return
 	}
 
-	input, supported, err :=
-		extractStripePaymentEventInput(event)
-	if err != nil {
-		writeStripeWebhookJSON(
-			w,
-			http.StatusBadRequest,
-			map[string]string{
-				"error": "invalid_stripe_event",
-			},
-		)
-		return
-	}
-
-	// Unsupported event types and PaymentIntents that do not belong to this
-	// application are acknowledged so Stripe does not retry them.
-	if !supported {
-		writeStripeWebhookJSON(
-			w,
-			http.StatusOK,
-			map[string]string{
-				"status": "ignored",
-			},
-		)
-		return
+	occurredAt := now
+	if event.Created > 0 {
+		occurredAt = time.Unix(event.Created, 0).UTC()
 	}
 
-	// ApplyStripeEvent performs the following operations through a Firestore
-	// Transaction:
-	//
-	// - event ID deduplication
-	// - PaymentIntent ID verification
-	// - status transition validation
-	// - status update
-	// - first-succeeded post-paid marker acquisition
-	//
-	// A duplicate event is a successful no-op.
-	if _, err := h.paymentUC.ApplyStripeEvent(
+	// Receive stores the event by Stripe event ID before processing it, so a
+	// redelivered event that has already been processed is acknowledged
+	// without being applied again. Failed events can be replayed from the
+	// console.
+	result, err := h.eventUC.Receive(
 		r.Context(),
-		input,
-	); err != nil {
+		usecase.ReceiveStripeEventInput{
+			ID:         event.ID,
+			Type:       event.Type,
+			Payload:    body,
+			OccurredAt: occurredAt,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, usecase.ErrStripeEventInvalid) {
+			writeStripeWebhookJSON(
+				w,
+				http.StatusBadRequest,
+				map[string]string{
+					"error": "invalid_stripe_event",
+				},
+			)
+			return
+		}
+
 		// Return 500 so Stripe retries the event.
-		//
-		// This is also important when Stripe sends the webhook after creating
-		// the PaymentIntent but before the payments document has been created.
 		writeStripeWebhookJSON(
 			w,
 			http.StatusInternalServerError,
@@ -220,11 +208,19 @@ func (h *StripeWebhookHandler) ServeHTTP(
 		return
 	}
 
+	status := "ok"
+	switch {
+	case result.Duplicate:
+		status = "duplicate"
+	case result.Event.Status == stripeeventdom.StatusIgnored:
+		status = "ignored"
+	}
+
 	writeStripeWebhookJSON(
 		w,
 		http.StatusOK,
 		map[string]string{
-			"status": "ok",
+			"status": status,
 		},
 	)
 }
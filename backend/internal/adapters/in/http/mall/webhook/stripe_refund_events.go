@@ -0,0 +1,175 @@
+// backend/internal/adapters/in/http/mall/webhook/stripe_refund_events.go
+package mallHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	refunddom "narratives/internal/domain/refund"
+)
+
+// ============================================================
+// Stripe refund event parsing
+// ============================================================
+
+type stripeRefund struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	Created       int64  `json:"created"`
+	FailureReason string `json:"failure_reason"`
+
+	Metadata map[string]string `json:"metadata"`
+}
+
+type stripeCharge struct {
+	ID      string `json:"id"`
+	Created int64  `json:"created"`
+
+	Refunds struct {
+		Data []stripeRefund `json:"data"`
+	} `json:"refunds"`
+}
+
+// isStripeRefundEventType reports whether the event carries Refund state.
+//
+// charge.refunded embeds the Charge; refunds.data is only present on API
+// versions / endpoints that include it. refund.* and charge.refund.updated
+// embed the Refund itself.
+func isStripeRefundEventType(
+	eventType string,
+) bool {
+	switch eventType {
+	case "charge.refunded",
+		"charge.refund.updated",
+		"refund.created",
+		"refund.updated",
+		"refund.failed":
+		return true
+
+	default:
+		return false
+	}
+}
+
+// extractStripeRefundEventInputs converts a verified refund event into
+// refund event inputs.
+//
+// Refunds without metadata[refundId] were not created by this application
+// and are skipped.
+//
+// A single charge.refunded event may carry several refunds, so the dedup key
+// is "{eventId}:{stripeRefundId}" for that event type.
+func extractStripeRefundEventInputs(
+	event stripeEvent,
+) ([]refunddom.ApplyStripeEventInput, error) {
+	eventID := strings.TrimSpace(event.ID)
+	if eventID == "" {
+		return nil, errors.New(
+			"Stripe event id is empty",
+		)
+	}
+
+	eventType := strings.TrimSpace(event.Type)
+
+	var (
+		refunds  []stripeRefund
+		fallback int64
+		perItem  bool
+	)
+
+	switch eventType {
+	case "charge.refunded":
+		var charge stripeCharge
+		if err := json.Unmarshal(
+			event.Data.Object,
+			&charge,
+		); err != nil {
+			return nil, fmt.Errorf(
+				"decode Stripe Charge: %w",
+				err,
+			)
+		}
+
+		refunds = charge.Refunds.Data
+		fallback = charge.Created
+		perItem = true
+
+	default:
+		var refund stripeRefund
+		if err := json.Unmarshal(
+			event.Data.Object,
+			&refund,
+		); err != nil {
+			return nil, fmt.Errorf(
+				"decode Stripe Refund: %w",
+				err,
+			)
+		}
+
+		refunds = []stripeRefund{refund}
+		fallback = refund.Created
+	}
+
+	occurredUnix := event.Created
+	if occurredUnix <= 0 {
+		occurredUnix = fallback
+	}
+	if occurredUnix <= 0 {
+		return nil, errors.New(
+			"Stripe event created timestamp is invalid",
+		)
+	}
+
+	inputs := make([]refunddom.ApplyStripeEventInput, 0, len(refunds))
+
+	for _, refund := range refunds {
+		refundID := strings.TrimSpace(refund.Metadata["refundId"])
+		stripeRefundID := strings.TrimSpace(refund.ID)
+
+		if refundID == "" || stripeRefundID == "" {
+			continue
+		}
+
+		status := refunddom.RefundStatus(
+			strings.TrimSpace(refund.Status),
+		)
+		if !refunddom.IsValidStatus(status) {
+			continue
+		}
+
+		var errorMsg *string
+		if status == refunddom.StatusFailed ||
+			status == refunddom.StatusCanceled {
+			errorMsg = optionalNonEmptyString(refund.FailureReason)
+			if errorMsg == nil {
+				value := fmt.Sprintf(
+					"Stripe Refund was %s",
+					status,
+				)
+				errorMsg = &value
+			}
+		}
+
+		dedupID := eventID
+		if perItem {
+			dedupID = eventID + ":" + stripeRefundID
+		}
+
+		inputs = append(inputs, refunddom.ApplyStripeEventInput{
+			EventID:        dedupID,
+			RefundID:       refundID,
+			StripeRefundID: stripeRefundID,
+			Status:         status,
+			ErrorMsg:       errorMsg,
+			OccurredAt: time.Unix(
+				occurredUnix,
+				0,
+			).UTC(),
+		})
+	}
+
+	return inputs, nil
+}
@@ -0,0 +1,249 @@
+// backend/internal/adapters/in/http/middleware/idempotency.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	idemdom "narratives/internal/domain/idempotency"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	idempotencyMaxBodyBytes  = 1 << 20 // 1 MiB
+)
+
+// IdempotencyStore は Idempotency-Key の取得・レスポンスの保存を行う。
+// 実装は usecase.IdempotencyUsecase。
+type IdempotencyStore interface {
+	Begin(
+		ctx context.Context,
+		in usecase.BeginIdempotentRequestInput,
+	) (usecase.IdempotentRequest, error)
+
+	Complete(
+		ctx context.Context,
+		req usecase.IdempotentRequest,
+		status int,
+		contentType string,
+		body []byte,
+	) error
+
+	Release(ctx context.Context, req usecase.IdempotentRequest) error
+
+	Extend(ctx context.Context, req usecase.IdempotentRequest) error
+}
+
+// IdempotencyMiddleware は Idempotency-Key ヘッダ付きの書き込みリクエストを 1 回だけ実行する。
+// Intended to run AFTER UserAuthMiddleware (keys are scoped to the current user).
+//
+//   - ヘッダが無いリクエスト・GET などはそのまま通す。
+//   - 同じキー・同じリクエストの再送は保存済みのレスポンスを返す（Idempotent-Replayed: true）。
+//   - 同じキーを別のリクエストに使った場合は 422、処理中の場合は 409 を返す。
+//   - ハンドラの実行中はキーのリースを延長し続ける（遅いリクエストの再送を再実行しない）。
+type IdempotencyMiddleware struct {
+	Store IdempotencyStore
+}
+
+func (m *IdempotencyMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimSpace(r.Header.Get(IdempotencyKeyHeader))
+		if key == "" || !isIdempotentWriteMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// ✅ fail-closed: the client relies on the key, so never run twice silently
+		if m == nil || m.Store == nil {
+			writeJSONErrorIdempotency(w, http.StatusServiceUnavailable, "idempotency_not_initialized")
+			return
+		}
+
+		uid, ok := CurrentUserUID(r)
+		if !ok || uid == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, idempotencyMaxBodyBytes))
+		if err != nil {
+			writeJSONErrorIdempotency(w, http.StatusBadRequest, "invalid_body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		req, err := m.Store.Begin(r.Context(), usecase.BeginIdempotentRequestInput{
+			UserID: uid,
+			Key:    key,
+			Method: r.Method,
+			Path:   r.URL.RequestURI(),
+			Body:   body,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, idemdom.ErrInvalidKey):
+				writeJSONErrorIdempotency(w, http.StatusBadRequest, "invalid_idempotency_key")
+			case errors.Is(err, usecase.ErrIdempotencyKeyReused):
+				writeJSONErrorIdempotency(w, http.StatusUnprocessableEntity, "idempotency_key_reused")
+			case errors.Is(err, usecase.ErrIdempotencyRequestInProgress):
+				writeJSONErrorIdempotency(w, http.StatusConflict, "idempotency_request_in_progress")
+			default:
+				log.Printf("[idempotency] Begin failed uid=%q err=%v", uid, err)
+				writeJSONErrorIdempotency(w, http.StatusInternalServerError, "idempotency_failed")
+			}
+			return
+		}
+
+		if req.Replay {
+			if ct := req.Record.ResponseContentType; ct != "" {
+				w.Header().Set("Content-Type", ct)
+			}
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(req.Record.ResponseStatus)
+			_, _ = w.Write(req.Record.ResponseBody)
+			return
+		}
+
+		// レスポンスの保存・キーの解放はクライアントの切断後も行う。
+		storeCtx := context.WithoutCancel(r.Context())
+
+		rec := &idempotencyRecorder{ResponseWriter: w}
+
+		stopRenew := m.renewLease(storeCtx, req, uid)
+
+		completed := false
+		defer func() {
+			stopRenew()
+			if completed {
+				return
+			}
+			if err := m.Store.Release(storeCtx, req); err != nil {
+				log.Printf("[idempotency] Release failed uid=%q err=%v", uid, err)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+
+		stopRenew()
+		completed = true
+
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		var storeErr error
+		if rec.overflow {
+			storeErr = m.Store.Release(storeCtx, req)
+		} else {
+			storeErr = m.Store.Complete(
+				storeCtx,
+				req,
+				status,
+				w.Header().Get("Content-Type"),
+				rec.body.Bytes(),
+			)
+		}
+		if storeErr != nil {
+			log.Printf("[idempotency] store response failed uid=%q err=%v", uid, storeErr)
+		}
+	})
+}
+
+// renewLease はハンドラの実行中、キーのリースを定期的に延長する。
+// 返り値の関数で延長を止める（複数回呼び出してよい）。
+func (m *IdempotencyMiddleware) renewLease(
+	ctx context.Context,
+	req usecase.IdempotentRequest,
+	uid string,
+) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(usecase.IdempotencyLeaseRenewInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := m.Store.Extend(ctx, req); err != nil {
+					log.Printf("[idempotency] Extend failed uid=%q err=%v", uid, err)
+					if errors.Is(err, idemdom.ErrNotInProgress) {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+func isIdempotentWriteMethod(method string) bool {
+	for _, m := range WriteMethods {
+		if method == m {
+			return true
+		}
+	}
+	return false
+}
+
+// idempotencyRecorder はレスポンスをクライアントへ書き込みながら保存用に記録する。
+type idempotencyRecorder struct {
+	http.ResponseWriter
+
+	status   int
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (r *idempotencyRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *idempotencyRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	if !r.overflow {
+		if r.body.Len()+len(b) > idemdom.MaxResponseBodyBytes {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(b)
+		}
+	}
+
+	return r.ResponseWriter.Write(b)
+}
+
+func writeJSONErrorIdempotency(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
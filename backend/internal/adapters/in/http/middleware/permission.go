@@ -0,0 +1,186 @@
+// backend/internal/adapters/in/http/middleware/permission.go
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+	memdom "narratives/internal/domain/member"
+)
+
+// PermissionAuthorizer は member の実効権限（直接付与 + ロール）を解決する。
+// 実装は usecase.PermissionUsecase。
+type PermissionAuthorizer interface {
+	EffectivePermissions(
+		ctx context.Context,
+		memberID string,
+		member memdom.Member,
+		companyID string,
+	) ([]string, error)
+}
+
+// PermissionRule は route と必要な権限の対応。
+//
+//   - Methods が空の場合は全メソッドに適用する。
+//   - Pattern の "*" は 1 セグメントに一致する（例: "/orders/*/dispatch"）。
+//   - Pattern の末尾 "/**" は配下すべてに一致する（例: "/lists/**" は "/lists" も含む）。
+type PermissionRule struct {
+	Methods    []string
+	Pattern    string
+	Permission string
+}
+
+// WriteMethods は書き込み系 HTTP メソッド。
+var WriteMethods = []string{
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+var ctxKeyPermissions = ctxKey{name: "permissions"}
+
+// PermissionMiddleware は AuthMiddleware の内側で、CurrentMember が
+// route に必要な権限を持たない場合に 403 を返す。
+// どの rule にも一致しない request はそのまま通す。
+type PermissionMiddleware struct {
+	Authorizer PermissionAuthorizer
+}
+
+func (m *PermissionMiddleware) Handler(rules ...PermissionRule) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// CORS preflight は認証なしで通す
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rule, ok := matchPermissionRule(rules, r.Method, r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if m == nil || m.Authorizer == nil {
+				writePermissionJSON(w, http.StatusServiceUnavailable, map[string]string{
+					"error": "permission middleware not initialized",
+				})
+				return
+			}
+
+			member, ok := CurrentMember(r)
+			if !ok {
+				writePermissionJSON(w, http.StatusUnauthorized, map[string]string{
+					"error": "unauthorized",
+				})
+				return
+			}
+
+			companyID, _ := CompanyID(r)
+
+			perms, err := m.Authorizer.EffectivePermissions(
+				r.Context(),
+				usecase.MemberIDFromContext(r.Context()),
+				*member,
+				companyID,
+			)
+			if err != nil {
+				writePermissionJSON(w, http.StatusInternalServerError, map[string]string{
+					"error": "permission resolve failed",
+				})
+				return
+			}
+
+			if !containsPermission(perms, rule.Permission) {
+				writePermissionJSON(w, http.StatusForbidden, map[string]string{
+					"error":      "forbidden",
+					"permission": rule.Permission,
+				})
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ctxKeyPermissions, perms)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// HasPermission は PermissionMiddleware が解決した実効権限に name が
+// 含まれるかを返す。rule に一致しなかった request では常に false。
+func HasPermission(r *http.Request, name string) bool {
+	perms, ok := r.Context().Value(ctxKeyPermissions).([]string)
+	if !ok {
+		return false
+	}
+	return containsPermission(perms, name)
+}
+
+func matchPermissionRule(rules []PermissionRule, method, path string) (PermissionRule, bool) {
+	for _, rule := range rules {
+		if len(rule.Methods) > 0 && !containsMethod(rule.Methods, method) {
+			continue
+		}
+		if matchPermissionPattern(rule.Pattern, path) {
+			return rule, true
+		}
+	}
+	return PermissionRule{}, false
+}
+
+func matchPermissionPattern(pattern, path string) bool {
+	path = strings.TrimRight(path, "/")
+	if path == "" {
+		path = "/"
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+
+	ps := strings.Split(strings.Trim(pattern, "/"), "/")
+	xs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(ps) != len(xs) {
+		return false
+	}
+
+	for i := range ps {
+		if ps[i] == "*" {
+			if xs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if ps[i] != xs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPermission(perms []string, name string) bool {
+	for _, p := range perms {
+		if strings.EqualFold(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func writePermissionJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,95 @@
+// backend/internal/adapters/out/csv/audit_log_renderer.go
+package csv
+
+import (
+	"bytes"
+	encodingcsv "encoding/csv"
+	"encoding/json"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	auditdom "narratives/internal/domain/audit"
+)
+
+// AuditLogRenderer は監査ログを CSV（UTF-8 BOM 付き, CRLF）に変換します。
+//
+// 列: 日時, 操作者, 対象, 対象ID, 操作, フィールド, 変更前, 変更後
+// 1 フィールドの変更を 1 行にします。変更前後の値は JSON で出力します。
+type AuditLogRenderer struct {
+	location *time.Location
+}
+
+var _ usecase.AuditLogRenderer = (*AuditLogRenderer)(nil)
+
+func NewAuditLogRenderer() *AuditLogRenderer {
+	return &AuditLogRenderer{
+		location: time.FixedZone("JST", 9*60*60),
+	}
+}
+
+func (r *AuditLogRenderer) RenderAuditLogs(
+	entries []auditdom.Entry,
+) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+
+	w := encodingcsv.NewWriter(&buf)
+	w.UseCRLF = true
+
+	rows := [][]string{
+		{"日時", "操作者", "対象", "対象ID", "操作", "フィールド", "変更前", "変更後"},
+	}
+
+	for _, e := range entries {
+		base := []string{
+			e.CreatedAt.In(r.location).Format("2006-01-02 15:04:05"),
+			e.ActorMemberID,
+			string(e.EntityType),
+			e.EntityID,
+			string(e.Action),
+		}
+
+		if len(e.Changes) == 0 {
+			rows = append(rows, append(base, "", "", ""))
+			continue
+		}
+
+		for _, c := range e.Changes {
+			before, err := jsonValue(c.Before)
+			if err != nil {
+				return nil, err
+			}
+
+			after, err := jsonValue(c.After)
+			if err != nil {
+				return nil, err
+			}
+
+			row := append([]string(nil), base...)
+			rows = append(rows, append(row, c.Field, before, after))
+		}
+	}
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// jsonValue は文字列はそのまま、それ以外は JSON で返します（nil は空）。
+func jsonValue(v any) (string, error) {
+	switch x := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return x, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
@@ -0,0 +1,121 @@
+// backend/internal/adapters/out/csv/billing_statement_renderer.go
+package csv
+
+import (
+	"bytes"
+	encodingcsv "encoding/csv"
+	"strconv"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	billingdom "narratives/internal/domain/billing"
+)
+
+// utf8BOM は Excel で UTF-8 の CSV を文字化けせずに開くための BOM です。
+const utf8BOM = "\ufeff"
+
+// BillingStatementRenderer は company の月次の請求明細書を CSV（UTF-8 BOM 付き, CRLF）に変換します。
+//
+// 列: 区分, 内容, 数量, 単価, 金額, 請求対象
+// 請求対象が "0" の行（注文・移転の集計）は参考値で、請求額に含みません。
+type BillingStatementRenderer struct {
+	location *time.Location
+}
+
+var _ usecase.BillingStatementRenderer = (*BillingStatementRenderer)(nil)
+
+func NewBillingStatementRenderer() *BillingStatementRenderer {
+	return &BillingStatementRenderer{
+		location: time.FixedZone("JST", 9*60*60),
+	}
+}
+
+func (r *BillingStatementRenderer) RenderBillingStatement(
+	s billingdom.Statement,
+) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+
+	w := encodingcsv.NewWriter(&buf)
+	w.UseCRLF = true
+
+	rows := [][]string{
+		{"請求明細書", s.ID},
+		{"請求先", s.CompanyName, s.CompanyID},
+		{"対象月", s.Period},
+		{"発行日時", s.GeneratedAt.In(r.location).Format("2006-01-02 15:04")},
+		{},
+		{"区分", "内容", "数量", "単価", "金額", "請求対象"},
+	}
+
+	row := func(category, description string, quantity, unitPrice *int, amount int, billed bool) {
+		rows = append(rows, []string{
+			category,
+			description,
+			optionalInt(quantity),
+			optionalInt(unitPrice),
+			strconv.Itoa(amount),
+			boolFlag(billed),
+		})
+	}
+
+	for _, m := range s.Mints {
+		quantity, unitPrice := m.Quantity, m.MintFeePerItem
+		row("ミント", m.TokenBlueprintID+" ("+m.ID+")", &quantity, &unitPrice, m.ItemFeeAmount, true)
+		if m.NetworkCostAmount > 0 {
+			row(
+				"ミント",
+				"ネットワーク手数料 "+strconv.FormatInt(m.NetworkCostLamports, 10)+" lamports ("+m.ID+")",
+				nil,
+				nil,
+				m.NetworkCostAmount,
+				true,
+			)
+		}
+	}
+
+	if s.MonthlyFee > 0 {
+		one, fee := 1, s.MonthlyFee
+		row("月額料金", "プラットフォーム月額料金", &one, &fee, s.MonthlyFee, true)
+	}
+
+	row("小計", "税抜", nil, nil, s.Subtotal, true)
+	row("消費税", strconv.Itoa(s.TaxRate)+"%", nil, nil, s.TaxAmount, true)
+	row("請求額", "税込", nil, nil, s.TotalAmount, true)
+
+	orders := s.Sales.OrderCount
+	row("注文", "販売代金・ロイヤリティ（税抜）", &orders, nil, s.Sales.GrossAmount, false)
+	row("注文", "返金", nil, nil, -s.Sales.RefundAmount, false)
+	row(
+		"注文",
+		"販売手数料 "+strconv.FormatFloat(float64(s.Plan.SalesBasisPoints)/100, 'f', -1, 64)+"%（支払額から差引済み）",
+		nil,
+		nil,
+		s.Sales.CommissionAmount,
+		false,
+	)
+
+	succeeded, failed := s.Transfers.SucceededCount, s.Transfers.FailedCount
+	row("移転", "NFT の移転（成功）", &succeeded, nil, 0, false)
+	row("移転", "NFT の移転（失敗）", &failed, nil, 0, false)
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func optionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func boolFlag(v bool) string {
+	if v {
+		return "1"
+	}
+	return "0"
+}
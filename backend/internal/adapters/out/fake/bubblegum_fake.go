@@ -0,0 +1,150 @@
+// backend/internal/adapters/out/fake/bubblegum_fake.go
+package fake
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	usecase "narratives/internal/application/usecase"
+	tokendom "narratives/internal/domain/token"
+)
+
+var (
+	ErrBubblegumInvalidToAddress = errors.New("bubblegum_fake: toAddress is empty")
+	ErrBubblegumInvalidProducts  = errors.New("bubblegum_fake: productIds is empty")
+	ErrBubblegumUnknownAsset     = errors.New("bubblegum_fake: asset is not minted")
+	ErrBubblegumOwnerMismatch    = errors.New("bubblegum_fake: from wallet is not the asset owner")
+)
+
+// BubblegumFake は Bubblegum V2 cNFT の mint / transfer client の fake。
+//
+// mint した asset の owner を保持し、transfer は現在の owner からのみ許可する。
+// 同一 OperationID の transfer は同じ signature を返す。
+type BubblegumFake struct {
+	mu  sync.Mutex
+	ids sequence
+
+	Cluster     string
+	TreeAddress string
+
+	ErrMint     error
+	ErrTransfer error
+
+	Mints     []usecase.MintProductsInput
+	Transfers []usecase.ExecuteTransferInput
+
+	owners     map[string]string
+	leafIndex  uint64
+	operations map[string]string
+}
+
+var (
+	_ usecase.TokenMintPort         = (*BubblegumFake)(nil)
+	_ usecase.TokenTransferExecutor = (*BubblegumFake)(nil)
+)
+
+func NewBubblegumFake() *BubblegumFake {
+	return &BubblegumFake{
+		Cluster:     "fake",
+		TreeAddress: "tree_fake",
+		owners:      map[string]string{},
+		operations:  map[string]string{},
+	}
+}
+
+func (f *BubblegumFake) MintProducts(
+	_ context.Context,
+	input usecase.MintProductsInput,
+) ([]usecase.MintedTokenForUsecase, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Mints = append(f.Mints, input)
+
+	if f.ErrMint != nil {
+		return nil, f.ErrMint
+	}
+	if input.ToAddress == "" {
+		return nil, ErrBubblegumInvalidToAddress
+	}
+	if len(input.ProductIDs) == 0 {
+		return nil, ErrBubblegumInvalidProducts
+	}
+
+	out := make([]usecase.MintedTokenForUsecase, 0, len(input.ProductIDs))
+	for _, productID := range input.ProductIDs {
+		assetID := f.ids.next("asset")
+		f.owners[assetID] = input.ToAddress
+
+		result := &tokendom.MintResult{
+			Signature:     f.ids.next("sig"),
+			AssetStandard: tokendom.AssetStandardBubblegumV2,
+			Cluster:       f.Cluster,
+			AssetID:       assetID,
+			TreeAddress:   f.TreeAddress,
+			LeafIndex:     f.leafIndex,
+		}
+		f.leafIndex++
+
+		out = append(out, usecase.MintedTokenForUsecase{
+			ProductID: productID,
+			Result:    result,
+		})
+	}
+
+	return out, nil
+}
+
+func (f *BubblegumFake) ExecuteTransfer(
+	_ context.Context,
+	in usecase.ExecuteTransferInput,
+) (usecase.ExecuteTransferResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Transfers = append(f.Transfers, in)
+
+	if f.ErrTransfer != nil {
+		return usecase.ExecuteTransferResult{}, f.ErrTransfer
+	}
+
+	if in.OperationID != "" {
+		if sig, ok := f.operations[in.OperationID]; ok {
+			return usecase.ExecuteTransferResult{TxSignature: sig}, nil
+		}
+	}
+
+	owner, ok := f.owners[in.AssetID]
+	if !ok {
+		return usecase.ExecuteTransferResult{}, ErrBubblegumUnknownAsset
+	}
+	if in.FromWalletAddress != "" && owner != in.FromWalletAddress {
+		return usecase.ExecuteTransferResult{}, ErrBubblegumOwnerMismatch
+	}
+
+	sig := f.ids.next("sig")
+	f.owners[in.AssetID] = in.ToWalletAddress
+	if in.OperationID != "" {
+		f.operations[in.OperationID] = sig
+	}
+
+	return usecase.ExecuteTransferResult{TxSignature: sig}, nil
+}
+
+// SeedAsset は mint を経由せずに asset と owner を登録する。
+func (f *BubblegumFake) SeedAsset(assetID string, owner string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.owners[assetID] = owner
+}
+
+// OwnerOf は asset の現在の owner wallet を返す。
+func (f *BubblegumFake) OwnerOf(assetID string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	owner, ok := f.owners[assetID]
+	return owner, ok
+}
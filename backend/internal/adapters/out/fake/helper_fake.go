@@ -0,0 +1,27 @@
+// backend/internal/adapters/out/fake/helper_fake.go
+
+// Package fake は、外部サービス（Stripe / Solana Bubblegum / Resend）の
+// network を使わない fake 実装を提供する。
+//
+// memory パッケージの repository と組み合わせて、usecase を go test から
+// 実行するために使う。fake は呼び出し内容を記録し、返す ID は決定的に採番する。
+package fake
+
+import (
+	"fmt"
+	"sync"
+)
+
+// sequence は prefix 付きの決定的な ID を採番する。
+type sequence struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (s *sequence) next(prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.n++
+	return fmt.Sprintf("%s_fake%06d", prefix, s.n)
+}
@@ -0,0 +1,62 @@
+// backend/internal/adapters/out/fake/queue_fake.go
+package fake
+
+import (
+	"context"
+	"sync"
+
+	usecase "narratives/internal/application/usecase"
+	orderdom "narratives/internal/domain/order"
+)
+
+// TaskQueueFake は Cloud Tasks への投入を記録するだけの fake。
+// 投入された task は実行しないので、テスト側で usecase を直接呼び出す。
+type TaskQueueFake struct {
+	mu sync.Mutex
+
+	Err error
+
+	MintIDs               []string
+	DispatchNotifications []orderdom.DispatchNotificationDelivery
+}
+
+var (
+	_ usecase.MintTaskEnqueuer                   = (*TaskQueueFake)(nil)
+	_ usecase.OrderDispatchNotificationQueuePort = (*TaskQueueFake)(nil)
+)
+
+func NewTaskQueueFake() *TaskQueueFake {
+	return &TaskQueueFake{}
+}
+
+func (q *TaskQueueFake) EnqueueMintTask(
+	_ context.Context,
+	mintID string,
+) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.Err != nil {
+		return q.Err
+	}
+
+	q.MintIDs = append(q.MintIDs, mintID)
+
+	return nil
+}
+
+func (q *TaskQueueFake) EnqueueOrderDispatchNotification(
+	_ context.Context,
+	delivery orderdom.DispatchNotificationDelivery,
+) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.Err != nil {
+		return q.Err
+	}
+
+	q.DispatchNotifications = append(q.DispatchNotifications, delivery)
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// backend/internal/adapters/out/fake/resend_fake.go
+package fake
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	mailadp "narratives/internal/adapters/out/mail"
+	usecase "narratives/internal/application/usecase"
+	orderdom "narratives/internal/domain/order"
+)
+
+var ErrResendInvalidRecipient = errors.New("resend_fake: recipient is empty")
+
+// SentEmail は ResendClientFake が受け付けた 1 通のメール。
+type SentEmail struct {
+	ProviderMessageID string
+	From              string
+	To                string
+	Subject           string
+	Body              string
+	IdempotencyKey    string
+}
+
+// ResendClientFake は mail.ResendClient の fake。
+//
+// 同一 idempotencyKey の再送は新しいメールを記録せず、最初の message ID を返す。
+// Err を設定すると送信は失敗し、Retryable がそのまま結果に入る。
+type ResendClientFake struct {
+	mu  sync.Mutex
+	ids sequence
+
+	Err       error
+	Retryable bool
+
+	Sent []SentEmail
+
+	byIdempotencyKey map[string]string
+}
+
+var (
+	_ mailadp.AuthEmailClient                      = (*ResendClientFake)(nil)
+	_ mailadp.InvitationEmailClient                = (*ResendClientFake)(nil)
+	_ mailadp.OrderDispatchNotificationEmailClient = (*ResendClientFake)(nil)
+)
+
+func NewResendClientFake() *ResendClientFake {
+	return &ResendClientFake{
+		byIdempotencyKey: map[string]string{},
+	}
+}
+
+func (c *ResendClientFake) Send(
+	ctx context.Context,
+	from string,
+	to string,
+	subject string,
+	body string,
+) error {
+	_, err := c.SendWithResult(ctx, from, to, subject, body, "")
+	return err
+}
+
+func (c *ResendClientFake) SendWithResult(
+	_ context.Context,
+	from string,
+	to string,
+	subject string,
+	body string,
+	idempotencyKey string,
+) (mailadp.EmailSendResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Err != nil {
+		return mailadp.EmailSendResult{Retryable: c.Retryable}, c.Err
+	}
+	if to == "" {
+		return mailadp.EmailSendResult{}, ErrResendInvalidRecipient
+	}
+
+	if idempotencyKey != "" {
+		if id, ok := c.byIdempotencyKey[idempotencyKey]; ok {
+			return mailadp.EmailSendResult{ProviderMessageID: id}, nil
+		}
+	}
+
+	id := c.ids.next("email")
+	c.Sent = append(c.Sent, SentEmail{
+		ProviderMessageID: id,
+		From:              from,
+		To:                to,
+		Subject:           subject,
+		Body:              body,
+		IdempotencyKey:    idempotencyKey,
+	})
+
+	if idempotencyKey != "" {
+		c.byIdempotencyKey[idempotencyKey] = id
+	}
+
+	return mailadp.EmailSendResult{ProviderMessageID: id}, nil
+}
+
+// SentTo は to 宛てに記録されたメールを送信順に返す。
+func (c *ResendClientFake) SentTo(to string) []SentEmail {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]SentEmail, 0)
+	for _, m := range c.Sent {
+		if m.To == to {
+			out = append(out, m)
+		}
+	}
+
+	return out
+}
+
+// OrderConfirmationSenderFake は usecase.MailSenderForPayment の fake。
+type OrderConfirmationSenderFake struct {
+	mu sync.Mutex
+
+	Err error
+
+	Orders []orderdom.Order
+}
+
+var _ usecase.MailSenderForPayment = (*OrderConfirmationSenderFake)(nil)
+
+func NewOrderConfirmationSenderFake() *OrderConfirmationSenderFake {
+	return &OrderConfirmationSenderFake{}
+}
+
+func (s *OrderConfirmationSenderFake) SendOrderConfirmation(
+	_ context.Context,
+	_ string,
+	to string,
+	order orderdom.Order,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Err != nil {
+		return s.Err
+	}
+	if to == "" {
+		return ErrResendInvalidRecipient
+	}
+
+	s.Orders = append(s.Orders, order)
+
+	return nil
+}
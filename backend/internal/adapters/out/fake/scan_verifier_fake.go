@@ -0,0 +1,72 @@
+// backend/internal/adapters/out/fake/scan_verifier_fake.go
+package fake
+
+import (
+	"context"
+	"sync"
+
+	usecase "narratives/internal/application/usecase"
+)
+
+// ScanVerifierFake は usecase.ScanVerifier の fake。
+//
+// SetMatch で productID ごとの scan 結果を登録する。未登録の product は
+// Matched=false の結果になる。
+type ScanVerifierFake struct {
+	mu sync.Mutex
+
+	Err error
+
+	Calls []usecase.VerifyInput
+
+	matches map[string]usecase.ModelTokenPair
+}
+
+var _ usecase.ScanVerifier = (*ScanVerifierFake)(nil)
+
+func NewScanVerifierFake() *ScanVerifierFake {
+	return &ScanVerifierFake{
+		matches: map[string]usecase.ModelTokenPair{},
+	}
+}
+
+// SetMatch は productID を scan したときに一致する model / tokenBlueprint を登録する。
+func (f *ScanVerifierFake) SetMatch(productID string, pair usecase.ModelTokenPair) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.matches[productID] = pair
+}
+
+func (f *ScanVerifierFake) VerifyMatch(
+	_ context.Context,
+	in usecase.VerifyInput,
+) (usecase.VerifyResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.Calls = append(f.Calls, in)
+
+	if f.Err != nil {
+		return usecase.VerifyResult{}, f.Err
+	}
+
+	result := usecase.VerifyResult{
+		AvatarID:  in.AvatarID,
+		ProductID: in.ProductID,
+	}
+
+	pair, ok := f.matches[in.ProductID]
+	if !ok {
+		return result, nil
+	}
+
+	match := pair
+	result.ScannedModelID = pair.ModelID
+	result.ScannedTokenBlueprintID = pair.TokenBlueprintID
+	result.PurchasedPairs = []usecase.ModelTokenPair{pair}
+	result.Matched = true
+	result.Match = &match
+
+	return result, nil
+}
@@ -0,0 +1,193 @@
+// backend/internal/adapters/out/fake/stripe_fake.go
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	usecase "narratives/internal/application/usecase"
+)
+
+var (
+	ErrStripeInvalidAmount         = errors.New("stripe_fake: amount must be positive")
+	ErrStripeInvalidPaymentMethod  = errors.New("stripe_fake: stripePaymentMethodId is empty")
+	ErrStripeInvalidPaymentIntent  = errors.New("stripe_fake: stripePaymentIntentId is empty")
+	ErrStripeRefundExceedsCaptured = errors.New("stripe_fake: refund amount exceeds captured amount")
+)
+
+// StripeGatewayFake は Stripe PaymentIntent / Refund gateway の fake。
+//
+// - 同一 IdempotencyKey の PaymentIntent 作成は同じ結果を返す（Stripe と同じ冪等性）。
+// - PaymentIntentStatus / RefundStatus で返す status を切り替えられる。
+// - Err* を設定すると、その呼び出しはネットワークエラーとして失敗する。
+type StripeGatewayFake struct {
+	mu  sync.Mutex
+	ids sequence
+
+	// PaymentIntentStatus は CreateAndConfirmPaymentIntent が返す status。既定は "succeeded"。
+	PaymentIntentStatus string
+
+	// RefundStatus は CreateRefund が返す status。既定は "succeeded"。
+	RefundStatus string
+
+	ErrPaymentIntent error
+	ErrRefund        error
+	ErrCancel        error
+
+	PaymentIntents []usecase.CreateAndConfirmPaymentIntentInput
+	Refunds        []usecase.CreateStripeRefundInput
+	Cancels        []string
+
+	byIdempotencyKey map[string]usecase.CreateAndConfirmPaymentIntentResult
+	captured         map[string]int
+	refunded         map[string]int
+}
+
+var (
+	_ usecase.StripePaymentIntentGateway  = (*StripeGatewayFake)(nil)
+	_ usecase.StripeRefundGateway         = (*StripeGatewayFake)(nil)
+	_ usecase.StripePaymentIntentCanceler = (*StripeGatewayFake)(nil)
+)
+
+func NewStripeGatewayFake() *StripeGatewayFake {
+	return &StripeGatewayFake{
+		PaymentIntentStatus: "succeeded",
+		RefundStatus:        "succeeded",
+		byIdempotencyKey:    map[string]usecase.CreateAndConfirmPaymentIntentResult{},
+		captured:            map[string]int{},
+		refunded:            map[string]int{},
+	}
+}
+
+func (g *StripeGatewayFake) CreateAndConfirmPaymentIntent(
+	_ context.Context,
+	in usecase.CreateAndConfirmPaymentIntentInput,
+) (*usecase.CreateAndConfirmPaymentIntentResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.PaymentIntents = append(g.PaymentIntents, in)
+
+	if g.ErrPaymentIntent != nil {
+		return nil, g.ErrPaymentIntent
+	}
+	if in.Amount <= 0 {
+		return nil, ErrStripeInvalidAmount
+	}
+	if in.StripePaymentMethodID == "" {
+		return nil, ErrStripeInvalidPaymentMethod
+	}
+
+	if in.IdempotencyKey != "" {
+		if prev, ok := g.byIdempotencyKey[in.IdempotencyKey]; ok {
+			out := prev
+			return &out, nil
+		}
+	}
+
+	status := g.PaymentIntentStatus
+	if status == "" {
+		status = "succeeded"
+	}
+
+	id := g.ids.next("pi")
+	result := usecase.CreateAndConfirmPaymentIntentResult{
+		StripePaymentIntentID: id,
+		Status:                status,
+		ClientSecret:          fmt.Sprintf("%s_secret_fake", id),
+		RequiresAction:        status == "requires_action",
+	}
+
+	if status == "succeeded" {
+		g.captured[id] = in.Amount
+	}
+	if in.IdempotencyKey != "" {
+		g.byIdempotencyKey[in.IdempotencyKey] = result
+	}
+
+	return &result, nil
+}
+
+// CreateRefund は captured 金額を超える返金を拒否する。
+func (g *StripeGatewayFake) CreateRefund(
+	_ context.Context,
+	in usecase.CreateStripeRefundInput,
+) (*usecase.CreateStripeRefundResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Refunds = append(g.Refunds, in)
+
+	if g.ErrRefund != nil {
+		return nil, g.ErrRefund
+	}
+	if in.StripePaymentIntentID == "" {
+		return nil, ErrStripeInvalidPaymentIntent
+	}
+	if in.Amount <= 0 {
+		return nil, ErrStripeInvalidAmount
+	}
+
+	if captured, ok := g.captured[in.StripePaymentIntentID]; ok &&
+		g.refunded[in.StripePaymentIntentID]+in.Amount > captured {
+		return nil, ErrStripeRefundExceedsCaptured
+	}
+
+	status := g.RefundStatus
+	if status == "" {
+		status = "succeeded"
+	}
+
+	if status != "failed" && status != "canceled" {
+		g.refunded[in.StripePaymentIntentID] += in.Amount
+	}
+
+	result := usecase.CreateStripeRefundResult{
+		StripeRefundID: g.ids.next("re"),
+		Status:         status,
+	}
+	if status == "failed" {
+		result.FailureReason = "fake_failure"
+	}
+
+	return &result, nil
+}
+
+// CancelPaymentIntent は captured 済みの PaymentIntent に対して "succeeded" を返し、
+// それ以外を canceled にする（再実行しても "canceled"）。
+func (g *StripeGatewayFake) CancelPaymentIntent(
+	_ context.Context,
+	stripePaymentIntentID string,
+	_ string,
+) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Cancels = append(g.Cancels, stripePaymentIntentID)
+
+	if g.ErrCancel != nil {
+		return "", g.ErrCancel
+	}
+	if stripePaymentIntentID == "" {
+		return "", ErrStripeInvalidPaymentIntent
+	}
+
+	if _, ok := g.captured[stripePaymentIntentID]; ok {
+		return "succeeded", fmt.Errorf(
+			"stripe_fake: payment intent %s is already captured",
+			stripePaymentIntentID,
+		)
+	}
+
+	return "canceled", nil
+}
+
+// RefundedAmount は PaymentIntent ごとの返金済み金額を返す。
+func (g *StripeGatewayFake) RefundedAmount(stripePaymentIntentID string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.refunded[stripePaymentIntentID]
+}
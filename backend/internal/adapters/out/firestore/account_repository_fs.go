@@ -144,6 +144,15 @@ func (r *AccountRepositoryFS) Update(ctx context.Context, id string, patch accdo
 	if patch.Status != nil {
 		updates = append(updates, firestore.Update{Path: "status", Value: *patch.Status})
 	}
+	if patch.BankCode != nil {
+		updates = append(updates, firestore.Update{Path: "bankCode", Value: *patch.BankCode})
+	}
+	if patch.BranchCode != nil {
+		updates = append(updates, firestore.Update{Path: "branchCode", Value: *patch.BranchCode})
+	}
+	if patch.HolderNameKana != nil {
+		updates = append(updates, firestore.Update{Path: "holderNameKana", Value: *patch.HolderNameKana})
+	}
 	if patch.UpdatedBy != nil {
 		updates = append(updates, firestore.Update{Path: "updatedBy", Value: *patch.UpdatedBy})
 	}
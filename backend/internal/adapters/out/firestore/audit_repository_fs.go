@@ -0,0 +1,220 @@
+// backend/internal/adapters/out/firestore/audit_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	auditdom "narratives/internal/domain/audit"
+)
+
+const auditLogsCollectionName = "auditLogs"
+
+var ErrAuditRepositoryNotConfigured = errors.New(
+	"audit_repository_fs: not configured",
+)
+
+// AuditRepositoryFS is the Firestore implementation of audit.RepositoryPort.
+//
+// Firestore design:
+//
+//	auditLogs/{autoId}
+//
+// Entries are only ever created; Search queries by companyId and filters the
+// rest in memory so no composite index is required.
+type AuditRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ auditdom.RepositoryPort = (*AuditRepositoryFS)(nil)
+
+func NewAuditRepositoryFS(
+	client *firestore.Client,
+) *AuditRepositoryFS {
+	return &AuditRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *AuditRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(auditLogsCollectionName)
+}
+
+type auditEntryDocument struct {
+	CompanyID     string `firestore:"companyId"`
+	ActorMemberID string `firestore:"actorMemberId,omitempty"`
+
+	EntityType string `firestore:"entityType"`
+	EntityID   string `firestore:"entityId"`
+	Action     string `firestore:"action"`
+
+	Changes []auditChangeDocument `firestore:"changes"`
+
+	CreatedAt time.Time `firestore:"createdAt"`
+}
+
+type auditChangeDocument struct {
+	Field  string `firestore:"field"`
+	Before any    `firestore:"before"`
+	After  any    `firestore:"after"`
+}
+
+func (r *AuditRepositoryFS) Append(
+	ctx context.Context,
+	e auditdom.Entry,
+) (auditdom.Entry, error) {
+	if r == nil || r.Client == nil {
+		return auditdom.Entry{}, ErrAuditRepositoryNotConfigured
+	}
+
+	if err := e.Validate(); err != nil {
+		return auditdom.Entry{}, err
+	}
+
+	changes := make([]auditChangeDocument, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		changes = append(changes, auditChangeDocument{
+			Field:  c.Field,
+			Before: c.Before,
+			After:  c.After,
+		})
+	}
+
+	ref := r.col().NewDoc()
+	if _, err := ref.Create(ctx, auditEntryDocument{
+		CompanyID:     e.CompanyID,
+		ActorMemberID: e.ActorMemberID,
+
+		EntityType: string(e.EntityType),
+		EntityID:   e.EntityID,
+		Action:     string(e.Action),
+
+		Changes: changes,
+
+		CreatedAt: e.CreatedAt.UTC(),
+	}); err != nil {
+		return auditdom.Entry{}, err
+	}
+
+	e.ID = ref.ID
+	return e, nil
+}
+
+func (r *AuditRepositoryFS) Search(
+	ctx context.Context,
+	filter auditdom.Filter,
+) ([]auditdom.Entry, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrAuditRepositoryNotConfigured
+	}
+
+	companyID := strings.TrimSpace(filter.CompanyID)
+	if companyID == "" {
+		return nil, auditdom.ErrInvalidCompanyID
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = auditdom.DefaultSearchLimit
+	}
+	if limit > auditdom.MaxSearchLimit {
+		limit = auditdom.MaxSearchLimit
+	}
+
+	iter := r.col().Where("companyId", "==", companyID).Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]auditdom.Entry, 0)
+
+	// entity / member / 日時は composite index を増やさないようにメモリ上で絞り込む。
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var doc auditEntryDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf(
+				"decode audit log %q: %w",
+				snap.Ref.ID,
+				err,
+			)
+		}
+
+		e := docToAuditEntry(snap.Ref.ID, doc)
+		if !matchAuditFilter(e, filter) {
+			continue
+		}
+
+		out = append(out, e)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+func matchAuditFilter(e auditdom.Entry, f auditdom.Filter) bool {
+	if f.EntityType != "" && e.EntityType != f.EntityType {
+		return false
+	}
+	if f.EntityID != "" && e.EntityID != f.EntityID {
+		return false
+	}
+	if f.ActorMemberID != "" && e.ActorMemberID != f.ActorMemberID {
+		return false
+	}
+	if !f.From.IsZero() && e.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !e.CreatedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+func docToAuditEntry(
+	id string,
+	doc auditEntryDocument,
+) auditdom.Entry {
+	changes := make([]auditdom.Change, 0, len(doc.Changes))
+	for _, c := range doc.Changes {
+		changes = append(changes, auditdom.Change{
+			Field:  c.Field,
+			Before: c.Before,
+			After:  c.After,
+		})
+	}
+
+	return auditdom.Entry{
+		ID: id,
+
+		CompanyID:     doc.CompanyID,
+		ActorMemberID: doc.ActorMemberID,
+
+		EntityType: auditdom.EntityType(doc.EntityType),
+		EntityID:   doc.EntityID,
+		Action:     auditdom.Action(doc.Action),
+
+		Changes: changes,
+
+		CreatedAt: doc.CreatedAt.UTC(),
+	}
+}
@@ -0,0 +1,223 @@
+// backend/internal/adapters/out/firestore/authenticity_scan_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	authdom "narratives/internal/domain/authenticity"
+)
+
+const authenticityScansCollectionName = "authenticityScans"
+
+var ErrAuthenticityScanRepositoryNotConfigured = errors.New(
+	"authenticity_scan_repository_fs: not configured",
+)
+
+// AuthenticityScanRepositoryFS is the Firestore implementation of
+// authenticity.ScanRepositoryPort.
+//
+// Firestore design:
+//
+//	products/{productId}/authenticityScans/{autoId}
+//
+// Scans are stored under the product so that the per-product history query
+// (scannedAt >= since, newest first) needs only a single-field index.
+type AuthenticityScanRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ authdom.ScanRepositoryPort = (*AuthenticityScanRepositoryFS)(nil)
+
+func NewAuthenticityScanRepositoryFS(
+	client *firestore.Client,
+) *AuthenticityScanRepositoryFS {
+	return &AuthenticityScanRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *AuthenticityScanRepositoryFS) col(
+	productID string,
+) *firestore.CollectionRef {
+	return r.Client.
+		Collection("products").
+		Doc(productID).
+		Collection(authenticityScansCollectionName)
+}
+
+type authenticityScanDocument struct {
+	ProductID string `firestore:"productId"`
+
+	Verdict string   `firestore:"verdict"`
+	Reasons []string `firestore:"reasons,omitempty"`
+
+	Latitude  *float64 `firestore:"latitude,omitempty"`
+	Longitude *float64 `firestore:"longitude,omitempty"`
+	Country   string   `firestore:"country,omitempty"`
+	Region    string   `firestore:"region,omitempty"`
+	City      string   `firestore:"city,omitempty"`
+
+	UserAgent string `firestore:"userAgent,omitempty"`
+
+	Suspicious        bool     `firestore:"suspicious"`
+	SuspiciousReasons []string `firestore:"suspiciousReasons,omitempty"`
+
+	ScannedAt time.Time `firestore:"scannedAt"`
+}
+
+func (r *AuthenticityScanRepositoryFS) Create(
+	ctx context.Context,
+	s authdom.Scan,
+) (authdom.Scan, error) {
+	if r == nil || r.Client == nil {
+		return authdom.Scan{}, ErrAuthenticityScanRepositoryNotConfigured
+	}
+
+	productID := strings.TrimSpace(s.ProductID)
+	if productID == "" {
+		return authdom.Scan{}, authdom.ErrInvalidProductID
+	}
+
+	ref := r.col(productID).NewDoc()
+	if _, err := ref.Create(ctx, authenticityScanToDocument(s)); err != nil {
+		return authdom.Scan{}, err
+	}
+
+	s.ID = ref.ID
+	return s, nil
+}
+
+func (r *AuthenticityScanRepositoryFS) ListRecentByProductID(
+	ctx context.Context,
+	productID string,
+	since time.Time,
+	limit int,
+) ([]authdom.Scan, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrAuthenticityScanRepositoryNotConfigured
+	}
+
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return nil, authdom.ErrInvalidProductID
+	}
+
+	if limit <= 0 || limit > authdom.MaxRecentScans {
+		limit = authdom.MaxRecentScans
+	}
+
+	iter := r.col(productID).
+		Where("scannedAt", ">=", since.UTC()).
+		OrderBy("scannedAt", firestore.Desc).
+		Limit(limit).
+		Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]authdom.Scan, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := decodeAuthenticityScanSnapshot(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, s)
+	}
+
+	return out, nil
+}
+
+func authenticityScanToDocument(s authdom.Scan) authenticityScanDocument {
+	return authenticityScanDocument{
+		ProductID: s.ProductID,
+
+		Verdict: string(s.Verdict),
+		Reasons: authenticityReasonsToStrings(s.Reasons),
+
+		Latitude:  s.Location.Latitude,
+		Longitude: s.Location.Longitude,
+		Country:   s.Location.Country,
+		Region:    s.Location.Region,
+		City:      s.Location.City,
+
+		UserAgent: s.UserAgent,
+
+		Suspicious:        s.Suspicious,
+		SuspiciousReasons: authenticityReasonsToStrings(s.SuspiciousReasons),
+
+		ScannedAt: s.ScannedAt.UTC(),
+	}
+}
+
+func decodeAuthenticityScanSnapshot(
+	snap *firestore.DocumentSnapshot,
+) (authdom.Scan, error) {
+	var doc authenticityScanDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return authdom.Scan{}, fmt.Errorf(
+			"decode authenticity scan %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return authdom.Scan{
+		ID:        snap.Ref.ID,
+		ProductID: doc.ProductID,
+
+		Verdict: authdom.Verdict(doc.Verdict),
+		Reasons: authenticityReasonsFromStrings(doc.Reasons),
+
+		Location: authdom.Location{
+			Latitude:  doc.Latitude,
+			Longitude: doc.Longitude,
+			Country:   doc.Country,
+			Region:    doc.Region,
+			City:      doc.City,
+		},
+
+		UserAgent: doc.UserAgent,
+
+		Suspicious:        doc.Suspicious,
+		SuspiciousReasons: authenticityReasonsFromStrings(doc.SuspiciousReasons),
+
+		ScannedAt: doc.ScannedAt.UTC(),
+	}, nil
+}
+
+func authenticityReasonsToStrings(in []authdom.Reason) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(in))
+	for _, r := range in {
+		out = append(out, string(r))
+	}
+	return out
+}
+
+func authenticityReasonsFromStrings(in []string) []authdom.Reason {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]authdom.Reason, 0, len(in))
+	for _, r := range in {
+		out = append(out, authdom.Reason(r))
+	}
+	return out
+}
@@ -0,0 +1,611 @@
+// backend/internal/adapters/out/firestore/billing_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	billingdom "narratives/internal/domain/billing"
+)
+
+const (
+	billingPlansCollectionName       = "billingPlans"
+	billingMintChargesCollectionName = "billingMintCharges"
+	billingStatementsCollectionName  = "billingStatements"
+)
+
+var ErrBillingRepositoryNotConfigured = errors.New(
+	"billing_repository_fs: not configured",
+)
+
+// BillingRepositoryFS is the Firestore implementation of
+// billing.RepositoryPort.
+//
+// Firestore design:
+//
+//	billingPlans/{companyId}
+//	billingMintCharges/{mintId}
+//	billingStatements/{companyId}_{YYYY-MM}
+//
+// CreateMintCharge uses Firestore Create so each mint request is charged at
+// most once. Statements are overwritten when regenerated.
+type BillingRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ billingdom.RepositoryPort = (*BillingRepositoryFS)(nil)
+
+func NewBillingRepositoryFS(
+	client *firestore.Client,
+) *BillingRepositoryFS {
+	return &BillingRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *BillingRepositoryFS) plans() *firestore.CollectionRef {
+	return r.Client.Collection(billingPlansCollectionName)
+}
+
+func (r *BillingRepositoryFS) mintCharges() *firestore.CollectionRef {
+	return r.Client.Collection(billingMintChargesCollectionName)
+}
+
+func (r *BillingRepositoryFS) statements() *firestore.CollectionRef {
+	return r.Client.Collection(billingStatementsCollectionName)
+}
+
+type billingPlanDocument struct {
+	CompanyID string `firestore:"companyId"`
+
+	SalesBasisPoints       int `firestore:"salesBasisPoints"`
+	MintFeePerItem         int `firestore:"mintFeePerItem"`
+	NetworkCostBasisPoints int `firestore:"networkCostBasisPoints"`
+	MonthlyFee             int `firestore:"monthlyFee"`
+
+	UpdatedAt time.Time `firestore:"updatedAt"`
+	UpdatedBy string    `firestore:"updatedBy,omitempty"`
+}
+
+type billingMintChargeDocument struct {
+	MintID string `firestore:"mintId"`
+
+	CompanyID        string `firestore:"companyId"`
+	BrandID          string `firestore:"brandId"`
+	TokenBlueprintID string `firestore:"tokenBlueprintId"`
+
+	Quantity int `firestore:"quantity"`
+
+	MintFeePerItem int `firestore:"mintFeePerItem"`
+	ItemFeeAmount  int `firestore:"itemFeeAmount"`
+
+	NetworkCostLamports    int64 `firestore:"networkCostLamports"`
+	YenPerSOL              int   `firestore:"yenPerSol"`
+	NetworkCostBasisPoints int   `firestore:"networkCostBasisPoints"`
+	NetworkCostAmount      int   `firestore:"networkCostAmount"`
+
+	Amount int `firestore:"amount"`
+
+	ChargedAt time.Time `firestore:"chargedAt"`
+}
+
+type billingStatementDocument struct {
+	CompanyID   string `firestore:"companyId"`
+	CompanyName string `firestore:"companyName"`
+
+	Period      string    `firestore:"period"`
+	PeriodStart time.Time `firestore:"periodStart"`
+	PeriodEnd   time.Time `firestore:"periodEnd"`
+
+	Plan billingPlanDocument `firestore:"plan"`
+
+	Sales billingSalesSummaryDocument `firestore:"sales"`
+
+	Mints        []billingMintChargeDocument `firestore:"mints"`
+	MintQuantity int                         `firestore:"mintQuantity"`
+	MintAmount   int                         `firestore:"mintAmount"`
+
+	Transfers billingTransferSummaryDocument `firestore:"transfers"`
+
+	MonthlyFee int `firestore:"monthlyFee"`
+
+	Subtotal    int `firestore:"subtotal"`
+	TaxRate     int `firestore:"taxRate"`
+	TaxAmount   int `firestore:"taxAmount"`
+	TotalAmount int `firestore:"totalAmount"`
+
+	GeneratedAt time.Time `firestore:"generatedAt"`
+	GeneratedBy string    `firestore:"generatedBy,omitempty"`
+}
+
+type billingSalesSummaryDocument struct {
+	OrderCount       int `firestore:"orderCount"`
+	GrossAmount      int `firestore:"grossAmount"`
+	RefundAmount     int `firestore:"refundAmount"`
+	NetAmount        int `firestore:"netAmount"`
+	CommissionAmount int `firestore:"commissionAmount"`
+}
+
+type billingTransferSummaryDocument struct {
+	SucceededCount int `firestore:"succeededCount"`
+	FailedCount    int `firestore:"failedCount"`
+}
+
+// ============================================================
+// Plans
+// ============================================================
+
+func (r *BillingRepositoryFS) GetPlan(
+	ctx context.Context,
+	companyID string,
+) (billingdom.Plan, error) {
+	if r == nil || r.Client == nil {
+		return billingdom.Plan{}, ErrBillingRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" || strings.Contains(companyID, "/") {
+		return billingdom.Plan{}, billingdom.ErrInvalidCompanyID
+	}
+
+	snap, err := r.plans().Doc(companyID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return billingdom.Plan{}, billingdom.ErrNotFound
+		}
+		return billingdom.Plan{}, err
+	}
+
+	var doc billingPlanDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return billingdom.Plan{}, fmt.Errorf(
+			"decode billing plan %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	p := docToBillingPlan(doc)
+	p.CompanyID = snap.Ref.ID
+
+	return p, nil
+}
+
+func (r *BillingRepositoryFS) SetPlan(
+	ctx context.Context,
+	p billingdom.Plan,
+) (billingdom.Plan, error) {
+	if r == nil || r.Client == nil {
+		return billingdom.Plan{}, ErrBillingRepositoryNotConfigured
+	}
+
+	if err := p.Validate(); err != nil {
+		return billingdom.Plan{}, err
+	}
+
+	if _, err := r.plans().Doc(p.CompanyID).Set(
+		ctx,
+		billingPlanToDocument(p),
+	); err != nil {
+		return billingdom.Plan{}, err
+	}
+
+	return p, nil
+}
+
+func (r *BillingRepositoryFS) ListPlans(
+	ctx context.Context,
+) ([]billingdom.Plan, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrBillingRepositoryNotConfigured
+	}
+
+	iter := r.plans().Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]billingdom.Plan, 0)
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var doc billingPlanDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf(
+				"decode billing plan %q: %w",
+				snap.Ref.ID,
+				err,
+			)
+		}
+
+		p := docToBillingPlan(doc)
+		p.CompanyID = snap.Ref.ID
+		out = append(out, p)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CompanyID < out[j].CompanyID
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// Mint charges
+// ============================================================
+
+func (r *BillingRepositoryFS) CreateMintCharge(
+	ctx context.Context,
+	c billingdom.MintCharge,
+) (billingdom.MintCharge, error) {
+	if r == nil || r.Client == nil {
+		return billingdom.MintCharge{}, ErrBillingRepositoryNotConfigured
+	}
+
+	if err := c.Validate(); err != nil {
+		return billingdom.MintCharge{}, err
+	}
+
+	if _, err := r.mintCharges().Doc(c.ID).Create(
+		ctx,
+		billingMintChargeToDocument(c),
+	); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return billingdom.MintCharge{}, billingdom.ErrConflict
+		}
+
+		return billingdom.MintCharge{}, err
+	}
+
+	return c, nil
+}
+
+func (r *BillingRepositoryFS) ListMintChargesByCompanyID(
+	ctx context.Context,
+	companyID string,
+	from time.Time,
+	to time.Time,
+) ([]billingdom.MintCharge, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrBillingRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, billingdom.ErrInvalidCompanyID
+	}
+
+	iter := r.mintCharges().Where("companyId", "==", companyID).Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]billingdom.MintCharge, 0)
+
+	// 計上日時は composite index を増やさないようにメモリ上で絞り込む。
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var doc billingMintChargeDocument
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf(
+				"decode billing mint charge %q: %w",
+				snap.Ref.ID,
+				err,
+			)
+		}
+
+		c := docToBillingMintCharge(doc)
+		c.ID = snap.Ref.ID
+
+		if c.ChargedAt.Before(from) || !c.ChargedAt.Before(to) {
+			continue
+		}
+
+		out = append(out, c)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ChargedAt.Before(out[j].ChargedAt)
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// Statements
+// ============================================================
+
+func (r *BillingRepositoryFS) SaveStatement(
+	ctx context.Context,
+	s billingdom.Statement,
+) (billingdom.Statement, error) {
+	if r == nil || r.Client == nil {
+		return billingdom.Statement{}, ErrBillingRepositoryNotConfigured
+	}
+
+	if s.ID != billingdom.StatementID(s.CompanyID, s.Period) ||
+		s.CompanyID == "" ||
+		strings.Contains(s.ID, "/") {
+		return billingdom.Statement{}, billingdom.ErrInvalidStatementID
+	}
+
+	mints := make([]billingMintChargeDocument, 0, len(s.Mints))
+	for _, c := range s.Mints {
+		mints = append(mints, billingMintChargeToDocument(c))
+	}
+
+	if _, err := r.statements().Doc(s.ID).Set(ctx, billingStatementDocument{
+		CompanyID:   s.CompanyID,
+		CompanyName: s.CompanyName,
+
+		Period:      s.Period,
+		PeriodStart: s.PeriodStart.UTC(),
+		PeriodEnd:   s.PeriodEnd.UTC(),
+
+		Plan: billingPlanToDocument(s.Plan),
+
+		Sales: billingSalesSummaryDocument{
+			OrderCount:       s.Sales.OrderCount,
+			GrossAmount:      s.Sales.GrossAmount,
+			RefundAmount:     s.Sales.RefundAmount,
+			NetAmount:        s.Sales.NetAmount,
+			CommissionAmount: s.Sales.CommissionAmount,
+		},
+
+		Mints:        mints,
+		MintQuantity: s.MintQuantity,
+		MintAmount:   s.MintAmount,
+
+		Transfers: billingTransferSummaryDocument{
+			SucceededCount: s.Transfers.SucceededCount,
+			FailedCount:    s.Transfers.FailedCount,
+		},
+
+		MonthlyFee: s.MonthlyFee,
+
+		Subtotal:    s.Subtotal,
+		TaxRate:     s.TaxRate,
+		TaxAmount:   s.TaxAmount,
+		TotalAmount: s.TotalAmount,
+
+		GeneratedAt: s.GeneratedAt.UTC(),
+		GeneratedBy: s.GeneratedBy,
+	}); err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	return s, nil
+}
+
+func (r *BillingRepositoryFS) GetStatement(
+	ctx context.Context,
+	id string,
+) (billingdom.Statement, error) {
+	if r == nil || r.Client == nil {
+		return billingdom.Statement{}, ErrBillingRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return billingdom.Statement{}, billingdom.ErrInvalidStatementID
+	}
+
+	snap, err := r.statements().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return billingdom.Statement{}, billingdom.ErrNotFound
+		}
+		return billingdom.Statement{}, err
+	}
+
+	return docToBillingStatement(snap)
+}
+
+func (r *BillingRepositoryFS) ListStatementsByCompanyID(
+	ctx context.Context,
+	companyID string,
+) ([]billingdom.Statement, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrBillingRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, billingdom.ErrInvalidCompanyID
+	}
+
+	iter := r.statements().Where("companyId", "==", companyID).Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]billingdom.Statement, 0)
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := docToBillingStatement(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, s)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Period > out[j].Period
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// Mapping
+// ============================================================
+
+func billingPlanToDocument(
+	p billingdom.Plan,
+) billingPlanDocument {
+	return billingPlanDocument{
+		CompanyID: p.CompanyID,
+
+		SalesBasisPoints:       p.SalesBasisPoints,
+		MintFeePerItem:         p.MintFeePerItem,
+		NetworkCostBasisPoints: p.NetworkCostBasisPoints,
+		MonthlyFee:             p.MonthlyFee,
+
+		UpdatedAt: p.UpdatedAt.UTC(),
+		UpdatedBy: p.UpdatedBy,
+	}
+}
+
+func docToBillingPlan(
+	doc billingPlanDocument,
+) billingdom.Plan {
+	return billingdom.Plan{
+		CompanyID: doc.CompanyID,
+
+		SalesBasisPoints:       doc.SalesBasisPoints,
+		MintFeePerItem:         doc.MintFeePerItem,
+		NetworkCostBasisPoints: doc.NetworkCostBasisPoints,
+		MonthlyFee:             doc.MonthlyFee,
+
+		UpdatedAt: doc.UpdatedAt.UTC(),
+		UpdatedBy: doc.UpdatedBy,
+	}
+}
+
+func billingMintChargeToDocument(
+	c billingdom.MintCharge,
+) billingMintChargeDocument {
+	return billingMintChargeDocument{
+		MintID: c.ID,
+
+		CompanyID:        c.CompanyID,
+		BrandID:          c.BrandID,
+		TokenBlueprintID: c.TokenBlueprintID,
+
+		Quantity: c.Quantity,
+
+		MintFeePerItem: c.MintFeePerItem,
+		ItemFeeAmount:  c.ItemFeeAmount,
+
+		NetworkCostLamports:    c.NetworkCostLamports,
+		YenPerSOL:              c.YenPerSOL,
+		NetworkCostBasisPoints: c.NetworkCostBasisPoints,
+		NetworkCostAmount:      c.NetworkCostAmount,
+
+		Amount: c.Amount,
+
+		ChargedAt: c.ChargedAt.UTC(),
+	}
+}
+
+func docToBillingMintCharge(
+	doc billingMintChargeDocument,
+) billingdom.MintCharge {
+	return billingdom.MintCharge{
+		ID: doc.MintID,
+
+		CompanyID:        doc.CompanyID,
+		BrandID:          doc.BrandID,
+		TokenBlueprintID: doc.TokenBlueprintID,
+
+		Quantity: doc.Quantity,
+
+		MintFeePerItem: doc.MintFeePerItem,
+		ItemFeeAmount:  doc.ItemFeeAmount,
+
+		NetworkCostLamports:    doc.NetworkCostLamports,
+		YenPerSOL:              doc.YenPerSOL,
+		NetworkCostBasisPoints: doc.NetworkCostBasisPoints,
+		NetworkCostAmount:      doc.NetworkCostAmount,
+
+		Amount: doc.Amount,
+
+		ChargedAt: doc.ChargedAt.UTC(),
+	}
+}
+
+func docToBillingStatement(
+	snap *firestore.DocumentSnapshot,
+) (billingdom.Statement, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return billingdom.Statement{}, billingdom.ErrNotFound
+	}
+
+	var doc billingStatementDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return billingdom.Statement{}, fmt.Errorf(
+			"decode billing statement %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	mints := make([]billingdom.MintCharge, 0, len(doc.Mints))
+	for _, c := range doc.Mints {
+		mints = append(mints, docToBillingMintCharge(c))
+	}
+
+	return billingdom.Statement{
+		ID: snap.Ref.ID,
+
+		CompanyID:   doc.CompanyID,
+		CompanyName: doc.CompanyName,
+
+		Period:      doc.Period,
+		PeriodStart: doc.PeriodStart.UTC(),
+		PeriodEnd:   doc.PeriodEnd.UTC(),
+
+		Plan: docToBillingPlan(doc.Plan),
+
+		Sales: billingdom.SalesSummary{
+			OrderCount:       doc.Sales.OrderCount,
+			GrossAmount:      doc.Sales.GrossAmount,
+			RefundAmount:     doc.Sales.RefundAmount,
+			NetAmount:        doc.Sales.NetAmount,
+			CommissionAmount: doc.Sales.CommissionAmount,
+		},
+
+		Mints:        mints,
+		MintQuantity: doc.MintQuantity,
+		MintAmount:   doc.MintAmount,
+
+		Transfers: billingdom.TransferSummary{
+			SucceededCount: doc.Transfers.SucceededCount,
+			FailedCount:    doc.Transfers.FailedCount,
+		},
+
+		MonthlyFee: doc.MonthlyFee,
+
+		Subtotal:    doc.Subtotal,
+		TaxRate:     doc.TaxRate,
+		TaxAmount:   doc.TaxAmount,
+		TotalAmount: doc.TotalAmount,
+
+		GeneratedAt: doc.GeneratedAt.UTC(),
+		GeneratedBy: doc.GeneratedBy,
+	}, nil
+}
@@ -118,6 +118,8 @@ type cartDoc struct {
 	CreatedAt time.Time `firestore:"createdAt"`
 	UpdatedAt time.Time `firestore:"updatedAt"`
 	ExpiresAt time.Time `firestore:"expiresAt"`
+
+	CouponCode string `firestore:"couponCode,omitempty"`
 }
 
 type cartItemDoc struct {
@@ -171,6 +173,8 @@ func cartDocFromSnapshot(
 		}
 	}
 
+	out.CouponCode = asString(raw["couponCode"])
+
 	itemsValue, _ := raw["items"]
 	itemsMap, ok := itemsValue.(map[string]any)
 	if !ok || itemsMap == nil {
@@ -231,6 +235,8 @@ func cartDocFromDomain(cart *cartdom.Cart) cartDoc {
 		CreatedAt: cart.CreatedAt,
 		UpdatedAt: cart.UpdatedAt,
 		ExpiresAt: cart.ExpiresAt,
+
+		CouponCode: cart.CouponCode,
 	}
 }
 
@@ -257,6 +263,8 @@ func (doc cartDoc) toDomain() *cartdom.Cart {
 		CreatedAt: doc.CreatedAt,
 		UpdatedAt: doc.UpdatedAt,
 		ExpiresAt: doc.ExpiresAt,
+
+		CouponCode: doc.CouponCode,
 	}
 }
 
@@ -96,6 +96,10 @@ func (r *CompanyRepositoryFS) Create(ctx context.Context, c compdom.Company) (co
 		return compdom.Company{}, err
 	}
 
+	if err := setCompanyInvoiceRegistrationNumber(&validated, c.InvoiceRegistrationNumber); err != nil {
+		return compdom.Company{}, err
+	}
+
 	if _, err := docRef.Create(ctx, companyToDocData(validated)); err != nil {
 		if status.Code(err) == codes.AlreadyExists {
 			return compdom.Company{}, compdom.ErrConflict
@@ -145,6 +149,10 @@ func (r *CompanyRepositoryFS) Update(ctx context.Context, id string, patch compd
 		if patch.IsActive != nil {
 			current.IsActive = *patch.IsActive
 		}
+		invoiceRegistrationNumber := current.InvoiceRegistrationNumber
+		if patch.InvoiceRegistrationNumber != nil {
+			invoiceRegistrationNumber = *patch.InvoiceRegistrationNumber
+		}
 		if patch.UpdatedAt != nil {
 			if patch.UpdatedAt.IsZero() {
 				return compdom.ErrInvalidUpdatedAt
@@ -190,6 +198,10 @@ func (r *CompanyRepositoryFS) Update(ctx context.Context, id string, patch compd
 			return err
 		}
 
+		if err := setCompanyInvoiceRegistrationNumber(&validated, invoiceRegistrationNumber); err != nil {
+			return err
+		}
+
 		if err := tx.Set(docRef, companyToDocData(validated)); err != nil {
 			return err
 		}
@@ -238,6 +250,8 @@ type companyDoc struct {
 	UpdatedBy string     `firestore:"updatedBy"`
 	DeletedAt *time.Time `firestore:"deletedAt"`
 	DeletedBy *string    `firestore:"deletedBy"`
+
+	InvoiceRegistrationNumber string `firestore:"invoiceRegistrationNumber"`
 }
 
 // ==============================
@@ -255,6 +269,9 @@ func companyToDocData(c compdom.Company) map[string]any {
 		"updatedBy": c.UpdatedBy,
 	}
 
+	if c.InvoiceRegistrationNumber != "" {
+		m["invoiceRegistrationNumber"] = c.InvoiceRegistrationNumber
+	}
 	if c.DeletedAt != nil {
 		m["deletedAt"] = c.DeletedAt.UTC()
 	}
@@ -294,9 +311,23 @@ func docToCompany(doc *firestore.DocumentSnapshot) (compdom.Company, error) {
 		return compdom.Company{}, fmt.Errorf("invalid company document %q: %w", doc.Ref.ID, err)
 	}
 
+	if err := setCompanyInvoiceRegistrationNumber(&company, raw.InvoiceRegistrationNumber); err != nil {
+		return compdom.Company{}, fmt.Errorf("invalid company document %q: %w", doc.Ref.ID, err)
+	}
+
 	return company, nil
 }
 
+// setCompanyInvoiceRegistrationNumber は NewCompany の後に登録番号を正規化・検証して設定します。
+func setCompanyInvoiceRegistrationNumber(c *compdom.Company, number string) error {
+	number = compdom.NormalizeInvoiceRegistrationNumber(number)
+	if err := compdom.ValidateInvoiceRegistrationNumber(number); err != nil {
+		return err
+	}
+	c.InvoiceRegistrationNumber = number
+	return nil
+}
+
 // ==============================
 // compile-time interface checks
 // ==============================
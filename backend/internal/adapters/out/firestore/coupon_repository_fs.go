@@ -0,0 +1,774 @@
+// backend/internal/adapters/out/firestore/coupon_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	coupondom "narratives/internal/domain/coupon"
+)
+
+const (
+	couponsCollectionName           = "coupons"
+	couponCodesCollectionName       = "couponCodes"
+	couponRedemptionsCollectionName = "couponRedemptions"
+)
+
+var ErrCouponRepositoryNotConfigured = errors.New(
+	"coupon_repository_fs: not configured",
+)
+
+// CouponRepositoryFS is the Firestore implementation of coupon.RepositoryPort
+// and coupon.RedemptionRepository.
+//
+// Firestore design:
+//
+//	coupons/{couponId}
+//	couponCodes/{code}            { couponId }  (code の一意制約)
+//	couponRedemptions/{orderId}
+//
+// Reserve / Release update the redemption and coupons.redeemedCount in the
+// same Firestore Transaction so the usage limit cannot be exceeded by
+// concurrent checkouts.
+type CouponRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var (
+	_ coupondom.RepositoryPort       = (*CouponRepositoryFS)(nil)
+	_ coupondom.RedemptionRepository = (*CouponRepositoryFS)(nil)
+)
+
+func NewCouponRepositoryFS(
+	client *firestore.Client,
+) *CouponRepositoryFS {
+	return &CouponRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *CouponRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(couponsCollectionName)
+}
+
+func (r *CouponRepositoryFS) codeCol() *firestore.CollectionRef {
+	return r.Client.Collection(couponCodesCollectionName)
+}
+
+func (r *CouponRepositoryFS) redemptionCol() *firestore.CollectionRef {
+	return r.Client.Collection(couponRedemptionsCollectionName)
+}
+
+type couponDocument struct {
+	CompanyID string `firestore:"companyId"`
+
+	Code        string `firestore:"code"`
+	Name        string `firestore:"name"`
+	Description string `firestore:"description,omitempty"`
+
+	DiscountType      string `firestore:"discountType"`
+	Amount            int    `firestore:"amount"`
+	Percent           int    `firestore:"percent"`
+	MaxDiscountAmount int    `firestore:"maxDiscountAmount"`
+	MinSubtotal       int    `firestore:"minSubtotal"`
+
+	BrandIDs            []string `firestore:"brandIds"`
+	ProductBlueprintIDs []string `firestore:"productBlueprintIds"`
+
+	UsageLimit     int `firestore:"usageLimit"`
+	PerAvatarLimit int `firestore:"perAvatarLimit"`
+	RedeemedCount  int `firestore:"redeemedCount"`
+
+	ValidFrom  *time.Time `firestore:"validFrom,omitempty"`
+	ValidUntil *time.Time `firestore:"validUntil,omitempty"`
+
+	Active bool `firestore:"active"`
+
+	CreatedAt time.Time  `firestore:"createdAt"`
+	CreatedBy string     `firestore:"createdBy"`
+	UpdatedAt *time.Time `firestore:"updatedAt,omitempty"`
+	UpdatedBy *string    `firestore:"updatedBy,omitempty"`
+}
+
+type couponCodeDocument struct {
+	CouponID string `firestore:"couponId"`
+}
+
+type couponRedemptionDocument struct {
+	CouponID  string `firestore:"couponId"`
+	CompanyID string `firestore:"companyId"`
+	Code      string `firestore:"code"`
+
+	OrderID  string `firestore:"orderId"`
+	AvatarID string `firestore:"avatarId"`
+
+	DiscountAmount int `firestore:"discountAmount"`
+
+	Status string `firestore:"status"`
+
+	CreatedAt  time.Time  `firestore:"createdAt"`
+	RedeemedAt *time.Time `firestore:"redeemedAt,omitempty"`
+	ReleasedAt *time.Time `firestore:"releasedAt,omitempty"`
+}
+
+// ============================================================
+// coupon.RepositoryPort
+// ============================================================
+
+func (r *CouponRepositoryFS) GetByID(
+	ctx context.Context,
+	id string,
+) (coupondom.Coupon, error) {
+	if r == nil || r.Client == nil {
+		return coupondom.Coupon{}, ErrCouponRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return coupondom.Coupon{}, coupondom.ErrNotFound
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return coupondom.Coupon{}, coupondom.ErrNotFound
+		}
+
+		return coupondom.Coupon{}, err
+	}
+
+	return docToCoupon(snap)
+}
+
+func (r *CouponRepositoryFS) GetByCode(
+	ctx context.Context,
+	code string,
+) (coupondom.Coupon, error) {
+	if r == nil || r.Client == nil {
+		return coupondom.Coupon{}, ErrCouponRepositoryNotConfigured
+	}
+
+	code = coupondom.NormalizeCode(code)
+	if code == "" || strings.Contains(code, "/") {
+		return coupondom.Coupon{}, coupondom.ErrNotFound
+	}
+
+	snap, err := r.codeCol().Doc(code).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return coupondom.Coupon{}, coupondom.ErrNotFound
+		}
+
+		return coupondom.Coupon{}, err
+	}
+
+	var doc couponCodeDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return coupondom.Coupon{}, fmt.Errorf(
+			"decode coupon code %q: %w",
+			code,
+			err,
+		)
+	}
+
+	return r.GetByID(ctx, doc.CouponID)
+}
+
+func (r *CouponRepositoryFS) ListByCompanyID(
+	ctx context.Context,
+	companyID string,
+) ([]coupondom.Coupon, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrCouponRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, coupondom.ErrInvalidCompanyID
+	}
+
+	iter := r.col().Where("companyId", "==", companyID).Documents(ctx)
+	defer iter.Stop()
+
+	coupons := make([]coupondom.Coupon, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := docToCoupon(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		coupons = append(coupons, c)
+	}
+
+	sort.SliceStable(coupons, func(i, j int) bool {
+		return coupons[i].CreatedAt.After(coupons[j].CreatedAt)
+	})
+
+	return coupons, nil
+}
+
+func (r *CouponRepositoryFS) Create(
+	ctx context.Context,
+	c coupondom.Coupon,
+) (coupondom.Coupon, error) {
+	if r == nil || r.Client == nil {
+		return coupondom.Coupon{}, ErrCouponRepositoryNotConfigured
+	}
+
+	if err := c.Validate(); err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	ref := r.col().Doc(c.ID)
+	codeRef := r.codeCol().Doc(c.Code)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			if err := tx.Create(
+				codeRef,
+				couponCodeDocument{CouponID: c.ID},
+			); err != nil {
+				return couponCreateErr(err)
+			}
+
+			if err := tx.Create(ref, couponToDocument(c)); err != nil {
+				return couponCreateErr(err)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	return c, nil
+}
+
+func (r *CouponRepositoryFS) Update(
+	ctx context.Context,
+	c coupondom.Coupon,
+) (coupondom.Coupon, error) {
+	if r == nil || r.Client == nil {
+		return coupondom.Coupon{}, ErrCouponRepositoryNotConfigured
+	}
+
+	ref := r.col().Doc(c.ID)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			current, err := r.getInTx(tx, ref)
+			if err != nil {
+				return err
+			}
+
+			if current.Code != c.Code ||
+				current.CompanyID != c.CompanyID {
+				return coupondom.ErrConflict
+			}
+
+			// redeemedCount は Reserve / Release だけが更新する。
+			c.RedeemedCount = current.RedeemedCount
+
+			if err := c.Validate(); err != nil {
+				return err
+			}
+
+			return tx.Set(ref, couponToDocument(c))
+		},
+	)
+	if err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	return c, nil
+}
+
+// ============================================================
+// coupon.RedemptionRepository
+// ============================================================
+
+func (r *CouponRepositoryFS) Reserve(
+	ctx context.Context,
+	red coupondom.Redemption,
+) (coupondom.Redemption, error) {
+	if r == nil || r.Client == nil {
+		return coupondom.Redemption{}, ErrCouponRepositoryNotConfigured
+	}
+
+	if err := red.Validate(); err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	ref := r.redemptionCol().Doc(red.ID)
+	couponRef := r.col().Doc(red.CouponID)
+
+	result := red
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			existing, err := r.getRedemptionInTx(tx, ref)
+			switch {
+			case err == nil && existing.Counts():
+				if existing.CouponID != red.CouponID {
+					return coupondom.ErrConflict
+				}
+				result = existing
+				return nil
+
+			case err != nil && !errors.Is(err, coupondom.ErrNotFound):
+				return err
+			}
+
+			c, err := r.getInTx(tx, couponRef)
+			if err != nil {
+				return err
+			}
+
+			avatarCount := 0
+			if c.PerAvatarLimit > 0 {
+				iter := tx.Documents(
+					r.redemptionCol().
+						Where("couponId", "==", red.CouponID).
+						Where("avatarId", "==", red.AvatarID),
+				)
+				defer iter.Stop()
+
+				for {
+					snap, err := iter.Next()
+					if errors.Is(err, iterator.Done) {
+						break
+					}
+					if err != nil {
+						return err
+					}
+
+					other, err := docToCouponRedemption(snap)
+					if err != nil {
+						return err
+					}
+
+					if other.ID != red.ID && other.Counts() {
+						avatarCount++
+					}
+				}
+			}
+
+			if err := c.Reserve(red, avatarCount); err != nil {
+				return err
+			}
+
+			if err := tx.Update(couponRef, []firestore.Update{
+				{Path: "redeemedCount", Value: c.RedeemedCount},
+			}); err != nil {
+				return err
+			}
+
+			result = red
+			return tx.Set(ref, couponRedemptionToDocument(red))
+		},
+	)
+	if err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	return result, nil
+}
+
+func (r *CouponRepositoryFS) MarkRedeemed(
+	ctx context.Context,
+	orderID string,
+	at time.Time,
+) (coupondom.Redemption, error) {
+	if r == nil || r.Client == nil {
+		return coupondom.Redemption{}, ErrCouponRepositoryNotConfigured
+	}
+
+	ref, err := r.redemptionRef(orderID)
+	if err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	var result coupondom.Redemption
+
+	err = r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			red, err := r.getRedemptionInTx(tx, ref)
+			if err != nil {
+				return err
+			}
+
+			if err := red.MarkRedeemed(at); err != nil {
+				return err
+			}
+
+			result = red
+			return tx.Set(ref, couponRedemptionToDocument(red))
+		},
+	)
+	if err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	return result, nil
+}
+
+func (r *CouponRepositoryFS) Release(
+	ctx context.Context,
+	orderID string,
+	at time.Time,
+) (coupondom.Redemption, error) {
+	if r == nil || r.Client == nil {
+		return coupondom.Redemption{}, ErrCouponRepositoryNotConfigured
+	}
+
+	ref, err := r.redemptionRef(orderID)
+	if err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	var result coupondom.Redemption
+
+	err = r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			red, err := r.getRedemptionInTx(tx, ref)
+			if err != nil {
+				return err
+			}
+
+			result = red
+			if !red.Counts() {
+				return nil
+			}
+
+			couponRef := r.col().Doc(red.CouponID)
+
+			c, err := r.getInTx(tx, couponRef)
+			if err != nil && !errors.Is(err, coupondom.ErrNotFound) {
+				return err
+			}
+
+			red.Release(at)
+			result = red
+
+			if err == nil {
+				c.Unreserve()
+				if err := tx.Update(couponRef, []firestore.Update{
+					{Path: "redeemedCount", Value: c.RedeemedCount},
+				}); err != nil {
+					return err
+				}
+			}
+
+			return tx.Set(ref, couponRedemptionToDocument(red))
+		},
+	)
+	if err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	return result, nil
+}
+
+func (r *CouponRepositoryFS) ListByCouponID(
+	ctx context.Context,
+	couponID string,
+) ([]coupondom.Redemption, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrCouponRepositoryNotConfigured
+	}
+
+	couponID = strings.TrimSpace(couponID)
+	if couponID == "" {
+		return nil, coupondom.ErrInvalidID
+	}
+
+	iter := r.redemptionCol().Where("couponId", "==", couponID).Documents(ctx)
+	defer iter.Stop()
+
+	redemptions := make([]coupondom.Redemption, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		red, err := docToCouponRedemption(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		redemptions = append(redemptions, red)
+	}
+
+	sort.SliceStable(redemptions, func(i, j int) bool {
+		return redemptions[i].CreatedAt.After(redemptions[j].CreatedAt)
+	})
+
+	return redemptions, nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func (r *CouponRepositoryFS) redemptionRef(
+	orderID string,
+) (*firestore.DocumentRef, error) {
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" || strings.Contains(orderID, "/") {
+		return nil, coupondom.ErrNotFound
+	}
+
+	return r.redemptionCol().Doc(orderID), nil
+}
+
+func (r *CouponRepositoryFS) getInTx(
+	tx *firestore.Transaction,
+	ref *firestore.DocumentRef,
+) (coupondom.Coupon, error) {
+	snap, err := tx.Get(ref)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return coupondom.Coupon{}, coupondom.ErrNotFound
+		}
+
+		return coupondom.Coupon{}, err
+	}
+
+	return docToCoupon(snap)
+}
+
+func (r *CouponRepositoryFS) getRedemptionInTx(
+	tx *firestore.Transaction,
+	ref *firestore.DocumentRef,
+) (coupondom.Redemption, error) {
+	snap, err := tx.Get(ref)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return coupondom.Redemption{}, coupondom.ErrNotFound
+		}
+
+		return coupondom.Redemption{}, err
+	}
+
+	return docToCouponRedemption(snap)
+}
+
+func couponCreateErr(err error) error {
+	if status.Code(err) == codes.AlreadyExists {
+		return coupondom.ErrConflict
+	}
+
+	return err
+}
+
+func couponToDocument(c coupondom.Coupon) couponDocument {
+	brandIDs := c.BrandIDs
+	if brandIDs == nil {
+		brandIDs = []string{}
+	}
+
+	productBlueprintIDs := c.ProductBlueprintIDs
+	if productBlueprintIDs == nil {
+		productBlueprintIDs = []string{}
+	}
+
+	return couponDocument{
+		CompanyID: c.CompanyID,
+
+		Code:        c.Code,
+		Name:        c.Name,
+		Description: c.Description,
+
+		DiscountType:      string(c.DiscountType),
+		Amount:            c.Amount,
+		Percent:           c.Percent,
+		MaxDiscountAmount: c.MaxDiscountAmount,
+		MinSubtotal:       c.MinSubtotal,
+
+		BrandIDs:            brandIDs,
+		ProductBlueprintIDs: productBlueprintIDs,
+
+		UsageLimit:     c.UsageLimit,
+		PerAvatarLimit: c.PerAvatarLimit,
+		RedeemedCount:  c.RedeemedCount,
+
+		ValidFrom:  utcTimePtr(c.ValidFrom),
+		ValidUntil: utcTimePtr(c.ValidUntil),
+
+		Active: c.Active,
+
+		CreatedAt: c.CreatedAt.UTC(),
+		CreatedBy: c.CreatedBy,
+		UpdatedAt: utcTimePtr(c.UpdatedAt),
+		UpdatedBy: c.UpdatedBy,
+	}
+}
+
+func docToCoupon(
+	snap *firestore.DocumentSnapshot,
+) (coupondom.Coupon, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return coupondom.Coupon{}, coupondom.ErrNotFound
+	}
+
+	var doc couponDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return coupondom.Coupon{}, fmt.Errorf(
+			"decode coupon %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	brandIDs := doc.BrandIDs
+	if len(brandIDs) == 0 {
+		brandIDs = nil
+	}
+
+	productBlueprintIDs := doc.ProductBlueprintIDs
+	if len(productBlueprintIDs) == 0 {
+		productBlueprintIDs = nil
+	}
+
+	c := coupondom.Coupon{
+		ID:        snap.Ref.ID,
+		CompanyID: doc.CompanyID,
+
+		Code:        doc.Code,
+		Name:        doc.Name,
+		Description: doc.Description,
+
+		DiscountType:      coupondom.DiscountType(doc.DiscountType),
+		Amount:            doc.Amount,
+		Percent:           doc.Percent,
+		MaxDiscountAmount: doc.MaxDiscountAmount,
+		MinSubtotal:       doc.MinSubtotal,
+
+		BrandIDs:            brandIDs,
+		ProductBlueprintIDs: productBlueprintIDs,
+
+		UsageLimit:     doc.UsageLimit,
+		PerAvatarLimit: doc.PerAvatarLimit,
+		RedeemedCount:  doc.RedeemedCount,
+
+		ValidFrom:  utcTimePtr(doc.ValidFrom),
+		ValidUntil: utcTimePtr(doc.ValidUntil),
+
+		Active: doc.Active,
+
+		CreatedAt: doc.CreatedAt.UTC(),
+		CreatedBy: doc.CreatedBy,
+		UpdatedAt: utcTimePtr(doc.UpdatedAt),
+		UpdatedBy: doc.UpdatedBy,
+	}
+
+	if err := c.Validate(); err != nil {
+		return coupondom.Coupon{}, fmt.Errorf(
+			"coupon %s: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return c, nil
+}
+
+func couponRedemptionToDocument(
+	red coupondom.Redemption,
+) couponRedemptionDocument {
+	return couponRedemptionDocument{
+		CouponID:  red.CouponID,
+		CompanyID: red.CompanyID,
+		Code:      red.Code,
+
+		OrderID:  red.OrderID,
+		AvatarID: red.AvatarID,
+
+		DiscountAmount: red.DiscountAmount,
+
+		Status: string(red.Status),
+
+		CreatedAt:  red.CreatedAt.UTC(),
+		RedeemedAt: utcTimePtr(red.RedeemedAt),
+		ReleasedAt: utcTimePtr(red.ReleasedAt),
+	}
+}
+
+func docToCouponRedemption(
+	snap *firestore.DocumentSnapshot,
+) (coupondom.Redemption, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return coupondom.Redemption{}, coupondom.ErrNotFound
+	}
+
+	var doc couponRedemptionDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return coupondom.Redemption{}, fmt.Errorf(
+			"decode coupon redemption %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return coupondom.Redemption{
+		ID:        snap.Ref.ID,
+		CouponID:  doc.CouponID,
+		CompanyID: doc.CompanyID,
+		Code:      doc.Code,
+
+		OrderID:  doc.OrderID,
+		AvatarID: doc.AvatarID,
+
+		DiscountAmount: doc.DiscountAmount,
+
+		Status: coupondom.RedemptionStatus(doc.Status),
+
+		CreatedAt:  doc.CreatedAt.UTC(),
+		RedeemedAt: utcTimePtr(doc.RedeemedAt),
+		ReleasedAt: utcTimePtr(doc.ReleasedAt),
+	}, nil
+}
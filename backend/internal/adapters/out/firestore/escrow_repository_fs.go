@@ -0,0 +1,532 @@
+// backend/internal/adapters/out/firestore/escrow_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	escrowdom "narratives/internal/domain/escrow"
+)
+
+const (
+	escrowsCollectionName = "escrows"
+
+	defaultEscrowDueListLimit = 100
+)
+
+var ErrEscrowRepositoryNotConfigured = errors.New(
+	"escrow_repository_fs: not configured",
+)
+
+// EscrowRepositoryFS is the Firestore implementation of
+// escrow.RepositoryPort.
+//
+// Firestore design:
+//
+//	escrows/{orderId}_{itemIndex}
+//
+// Create uses Firestore Create so a resale item is held at most once even
+// when the post-paid step runs again.
+type EscrowRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ escrowdom.RepositoryPort = (*EscrowRepositoryFS)(nil)
+
+func NewEscrowRepositoryFS(
+	client *firestore.Client,
+) *EscrowRepositoryFS {
+	return &EscrowRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *EscrowRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(escrowsCollectionName)
+}
+
+type escrowDocument struct {
+	OrderID   string `firestore:"orderId"`
+	ItemIndex int    `firestore:"itemIndex"`
+
+	ResaleID  string `firestore:"resaleId"`
+	ProductID string `firestore:"productId"`
+
+	CompanyID string `firestore:"companyId"`
+	BrandID   string `firestore:"brandId"`
+
+	BuyerAvatarID  string `firestore:"buyerAvatarId"`
+	SellerAvatarID string `firestore:"sellerAvatarId"`
+
+	SaleAmount    int `firestore:"saleAmount"`
+	RoyaltyAmount int `firestore:"royaltyAmount"`
+	PayoutAmount  int `firestore:"payoutAmount"`
+
+	Status string `firestore:"status"`
+
+	HeldAt        time.Time  `firestore:"heldAt"`
+	TransferredAt *time.Time `firestore:"transferredAt,omitempty"`
+	AutoConfirmAt *time.Time `firestore:"autoConfirmAt,omitempty"`
+
+	ReceiptConfirmedAt *time.Time `firestore:"receiptConfirmedAt,omitempty"`
+	ReceiptConfirmedBy string     `firestore:"receiptConfirmedBy,omitempty"`
+
+	Dispute *escrowDisputeDocument `firestore:"dispute,omitempty"`
+
+	ReleasedAt *time.Time `firestore:"releasedAt,omitempty"`
+
+	// PayoutAccrued は ListPendingPayoutAccrual の等価クエリ用です。
+	// このフィールドが無い（導入前の）released は計上済みとみなします。
+	PayoutAccrued   bool       `firestore:"payoutAccrued"`
+	PayoutAccruedAt *time.Time `firestore:"payoutAccruedAt,omitempty"`
+
+	RefundID   string     `firestore:"refundId,omitempty"`
+	RefundedAt *time.Time `firestore:"refundedAt,omitempty"`
+
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+type escrowDisputeDocument struct {
+	Reason  string `firestore:"reason"`
+	Comment string `firestore:"comment,omitempty"`
+
+	OpenedAt time.Time `firestore:"openedAt"`
+
+	Resolution string     `firestore:"resolution,omitempty"`
+	Note       string     `firestore:"note,omitempty"`
+	ResolvedBy string     `firestore:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time `firestore:"resolvedAt,omitempty"`
+}
+
+func (r *EscrowRepositoryFS) GetByID(
+	ctx context.Context,
+	id string,
+) (escrowdom.Escrow, error) {
+	if r == nil || r.Client == nil {
+		return escrowdom.Escrow{}, ErrEscrowRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return escrowdom.Escrow{}, escrowdom.ErrInvalidID
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return escrowdom.Escrow{}, escrowdom.ErrNotFound
+		}
+		return escrowdom.Escrow{}, err
+	}
+
+	return docToEscrow(snap)
+}
+
+func (r *EscrowRepositoryFS) Create(
+	ctx context.Context,
+	e escrowdom.Escrow,
+) (escrowdom.Escrow, error) {
+	if r == nil || r.Client == nil {
+		return escrowdom.Escrow{}, ErrEscrowRepositoryNotConfigured
+	}
+
+	if err := e.Validate(); err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	if _, err := r.col().Doc(e.ID).Create(
+		ctx,
+		escrowToDocument(e),
+	); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return escrowdom.Escrow{}, escrowdom.ErrConflict
+		}
+
+		return escrowdom.Escrow{}, err
+	}
+
+	return e, nil
+}
+
+func (r *EscrowRepositoryFS) Update(
+	ctx context.Context,
+	e escrowdom.Escrow,
+	prevUpdatedAt time.Time,
+) (escrowdom.Escrow, error) {
+	if r == nil || r.Client == nil {
+		return escrowdom.Escrow{}, ErrEscrowRepositoryNotConfigured
+	}
+
+	if err := e.Validate(); err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	ref := r.col().Doc(e.ID)
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return escrowdom.ErrNotFound
+			}
+			return err
+		}
+
+		current, err := docToEscrow(snap)
+		if err != nil {
+			return err
+		}
+
+		// 受取確認・紛争・sweeper が同時に更新した場合、先に保存された変更を上書きしない。
+		// Firestore の timestamp はマイクロ秒精度のため揃えて比較する。
+		if !current.UpdatedAt.Truncate(time.Microsecond).Equal(
+			prevUpdatedAt.UTC().Truncate(time.Microsecond),
+		) {
+			return escrowdom.ErrConflict
+		}
+
+		return tx.Set(ref, escrowToDocument(e))
+	})
+	if err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	return e, nil
+}
+
+func (r *EscrowRepositoryFS) ListByBuyerAvatarID(
+	ctx context.Context,
+	avatarID string,
+) ([]escrowdom.Escrow, error) {
+	return r.listNewestFirst(ctx, "buyerAvatarId", avatarID)
+}
+
+func (r *EscrowRepositoryFS) ListBySellerAvatarID(
+	ctx context.Context,
+	avatarID string,
+) ([]escrowdom.Escrow, error) {
+	return r.listNewestFirst(ctx, "sellerAvatarId", avatarID)
+}
+
+func (r *EscrowRepositoryFS) listNewestFirst(
+	ctx context.Context,
+	field string,
+	value string,
+) ([]escrowdom.Escrow, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrEscrowRepositoryNotConfigured
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return []escrowdom.Escrow{}, nil
+	}
+
+	escrows, err := r.collect(
+		r.col().Where(field, "==", value).Documents(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sortEscrowsNewestFirst(escrows)
+
+	return escrows, nil
+}
+
+func (r *EscrowRepositoryFS) ListByCompanyID(
+	ctx context.Context,
+	companyID string,
+	st escrowdom.Status,
+) ([]escrowdom.Escrow, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrEscrowRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, escrowdom.ErrInvalidCompanyID
+	}
+
+	q := r.col().Where("companyId", "==", companyID)
+	if st != "" {
+		if !escrowdom.IsValidStatus(st) {
+			return nil, escrowdom.ErrInvalidStatus
+		}
+		q = q.Where("status", "==", string(st))
+	}
+
+	escrows, err := r.collect(q.Documents(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	sortEscrowsNewestFirst(escrows)
+
+	return escrows, nil
+}
+
+func (r *EscrowRepositoryFS) ListDue(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]escrowdom.Escrow, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrEscrowRepositoryNotConfigured
+	}
+
+	now = now.UTC()
+	if limit <= 0 {
+		limit = defaultEscrowDueListLimit
+	}
+
+	// 期限は composite index を増やさないようにメモリ上で判定する。
+	held, err := r.collect(
+		r.col().
+			Where("status", "==", string(escrowdom.StatusHeld)).
+			Documents(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list due escrows: %w", err)
+	}
+
+	due := make([]escrowdom.Escrow, 0, len(held))
+	untransferred := make([]escrowdom.Escrow, 0)
+
+	for _, e := range held {
+		switch {
+		case e.IsAutoConfirmDue(now), e.CanRelease():
+			due = append(due, e)
+		case e.TransferredAt == nil:
+			untransferred = append(untransferred, e)
+		}
+	}
+
+	sortEscrowsOldestFirst(due)
+	sortEscrowsOldestFirst(untransferred)
+
+	due = append(due, untransferred...)
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *EscrowRepositoryFS) ListPendingPayoutAccrual(
+	ctx context.Context,
+	limit int,
+) ([]escrowdom.Escrow, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrEscrowRepositoryNotConfigured
+	}
+
+	if limit <= 0 {
+		limit = defaultEscrowDueListLimit
+	}
+
+	pending, err := r.collect(
+		r.col().
+			Where("status", "==", string(escrowdom.StatusReleased)).
+			Where("payoutAccrued", "==", false).
+			Documents(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list escrows pending payout accrual: %w", err)
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return escrowReleasedAt(pending[i]).Before(escrowReleasedAt(pending[j]))
+	})
+
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	return pending, nil
+}
+
+func escrowReleasedAt(e escrowdom.Escrow) time.Time {
+	if e.ReleasedAt != nil {
+		return *e.ReleasedAt
+	}
+	return e.UpdatedAt
+}
+
+func (r *EscrowRepositoryFS) collect(
+	iter *firestore.DocumentIterator,
+) ([]escrowdom.Escrow, error) {
+	defer iter.Stop()
+
+	escrows := make([]escrowdom.Escrow, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := docToEscrow(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		escrows = append(escrows, e)
+	}
+
+	return escrows, nil
+}
+
+func sortEscrowsNewestFirst(escrows []escrowdom.Escrow) {
+	sort.SliceStable(escrows, func(i, j int) bool {
+		return escrows[i].HeldAt.After(escrows[j].HeldAt)
+	})
+}
+
+func sortEscrowsOldestFirst(escrows []escrowdom.Escrow) {
+	sort.SliceStable(escrows, func(i, j int) bool {
+		return escrows[i].HeldAt.Before(escrows[j].HeldAt)
+	})
+}
+
+func escrowToDocument(
+	e escrowdom.Escrow,
+) escrowDocument {
+	var dispute *escrowDisputeDocument
+	if e.Dispute != nil {
+		dispute = &escrowDisputeDocument{
+			Reason:  string(e.Dispute.Reason),
+			Comment: e.Dispute.Comment,
+
+			OpenedAt: e.Dispute.OpenedAt.UTC(),
+
+			Resolution: string(e.Dispute.Resolution),
+			Note:       e.Dispute.Note,
+			ResolvedBy: e.Dispute.ResolvedBy,
+			ResolvedAt: utcTimePtr(e.Dispute.ResolvedAt),
+		}
+	}
+
+	return escrowDocument{
+		OrderID:   e.OrderID,
+		ItemIndex: e.ItemIndex,
+
+		ResaleID:  e.ResaleID,
+		ProductID: e.ProductID,
+
+		CompanyID: e.CompanyID,
+		BrandID:   e.BrandID,
+
+		BuyerAvatarID:  e.BuyerAvatarID,
+		SellerAvatarID: e.SellerAvatarID,
+
+		SaleAmount:    e.SaleAmount,
+		RoyaltyAmount: e.RoyaltyAmount,
+		PayoutAmount:  e.PayoutAmount,
+
+		Status: string(e.Status),
+
+		HeldAt:        e.HeldAt.UTC(),
+		TransferredAt: utcTimePtr(e.TransferredAt),
+		AutoConfirmAt: utcTimePtr(e.AutoConfirmAt),
+
+		ReceiptConfirmedAt: utcTimePtr(e.ReceiptConfirmedAt),
+		ReceiptConfirmedBy: string(e.ReceiptConfirmedBy),
+
+		Dispute: dispute,
+
+		ReleasedAt: utcTimePtr(e.ReleasedAt),
+
+		PayoutAccrued:   e.PayoutAccruedAt != nil,
+		PayoutAccruedAt: utcTimePtr(e.PayoutAccruedAt),
+
+		RefundID:   e.RefundID,
+		RefundedAt: utcTimePtr(e.RefundedAt),
+
+		UpdatedAt: e.UpdatedAt.UTC(),
+	}
+}
+
+func docToEscrow(
+	snap *firestore.DocumentSnapshot,
+) (escrowdom.Escrow, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return escrowdom.Escrow{}, escrowdom.ErrNotFound
+	}
+
+	var doc escrowDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return escrowdom.Escrow{}, fmt.Errorf(
+			"decode escrow %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	var dispute *escrowdom.Dispute
+	if doc.Dispute != nil {
+		dispute = &escrowdom.Dispute{
+			Reason:  escrowdom.DisputeReason(doc.Dispute.Reason),
+			Comment: doc.Dispute.Comment,
+
+			OpenedAt: doc.Dispute.OpenedAt.UTC(),
+
+			Resolution: escrowdom.Resolution(doc.Dispute.Resolution),
+			Note:       doc.Dispute.Note,
+			ResolvedBy: doc.Dispute.ResolvedBy,
+			ResolvedAt: utcTimePtr(doc.Dispute.ResolvedAt),
+		}
+	}
+
+	return escrowdom.Escrow{
+		ID: snap.Ref.ID,
+
+		OrderID:   doc.OrderID,
+		ItemIndex: doc.ItemIndex,
+
+		ResaleID:  doc.ResaleID,
+		ProductID: doc.ProductID,
+
+		CompanyID: doc.CompanyID,
+		BrandID:   doc.BrandID,
+
+		BuyerAvatarID:  doc.BuyerAvatarID,
+		SellerAvatarID: doc.SellerAvatarID,
+
+		SaleAmount:    doc.SaleAmount,
+		RoyaltyAmount: doc.RoyaltyAmount,
+		PayoutAmount:  doc.PayoutAmount,
+
+		Status: escrowdom.Status(doc.Status),
+
+		HeldAt:        doc.HeldAt.UTC(),
+		TransferredAt: utcTimePtr(doc.TransferredAt),
+		AutoConfirmAt: utcTimePtr(doc.AutoConfirmAt),
+
+		ReceiptConfirmedAt: utcTimePtr(doc.ReceiptConfirmedAt),
+		ReceiptConfirmedBy: escrowdom.ConfirmedBy(doc.ReceiptConfirmedBy),
+
+		Dispute: dispute,
+
+		ReleasedAt: utcTimePtr(doc.ReleasedAt),
+
+		PayoutAccruedAt: utcTimePtr(doc.PayoutAccruedAt),
+
+		RefundID:   doc.RefundID,
+		RefundedAt: utcTimePtr(doc.RefundedAt),
+
+		UpdatedAt: doc.UpdatedAt.UTC(),
+	}, nil
+}
@@ -0,0 +1,279 @@
+// backend/internal/adapters/out/firestore/idempotency_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	idemdom "narratives/internal/domain/idempotency"
+)
+
+const idempotencyKeysCollectionName = "idempotencyKeys"
+
+var ErrIdempotencyRepositoryNotConfigured = errors.New(
+	"idempotency_repository_fs: not configured",
+)
+
+// IdempotencyRepositoryFS is the Firestore implementation of
+// idempotency.RepositoryPort.
+//
+// Firestore design:
+//
+//	idempotencyKeys/{sha256(userId + key)}
+//
+// expiresAt is intended for a Firestore TTL policy; expired documents that
+// have not been deleted yet are treated as absent by Acquire.
+type IdempotencyRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ idemdom.RepositoryPort = (*IdempotencyRepositoryFS)(nil)
+
+func NewIdempotencyRepositoryFS(
+	client *firestore.Client,
+) *IdempotencyRepositoryFS {
+	return &IdempotencyRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *IdempotencyRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(idempotencyKeysCollectionName)
+}
+
+type idempotencyDocument struct {
+	UserID string `firestore:"userId"`
+	Key    string `firestore:"key"`
+
+	Fingerprint string `firestore:"fingerprint"`
+	Status      string `firestore:"status"`
+
+	ResponseStatus      int    `firestore:"responseStatus,omitempty"`
+	ResponseContentType string `firestore:"responseContentType,omitempty"`
+	ResponseBody        []byte `firestore:"responseBody,omitempty"`
+
+	CreatedAt   time.Time  `firestore:"createdAt"`
+	LockedUntil time.Time  `firestore:"lockedUntil"`
+	ExpiresAt   time.Time  `firestore:"expiresAt"`
+	CompletedAt *time.Time `firestore:"completedAt,omitempty"`
+}
+
+func (r *IdempotencyRepositoryFS) Acquire(
+	ctx context.Context,
+	rec idemdom.Record,
+) (idemdom.Record, bool, error) {
+	if r == nil || r.Client == nil {
+		return idemdom.Record{}, false, ErrIdempotencyRepositoryNotConfigured
+	}
+
+	if strings.TrimSpace(rec.ID) == "" {
+		return idemdom.Record{}, false, idemdom.ErrInvalidKey
+	}
+
+	ref := r.col().Doc(rec.ID)
+
+	var (
+		stored   idemdom.Record
+		acquired bool
+	)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			acquired = false
+
+			snap, err := tx.Get(ref)
+			if err != nil && status.Code(err) != codes.NotFound {
+				return err
+			}
+
+			if err == nil {
+				existing, err := decodeIdempotencySnapshot(snap)
+				if err != nil {
+					return err
+				}
+				if !existing.Replaceable(rec.CreatedAt) {
+					stored = existing
+					return nil
+				}
+			}
+
+			if err := tx.Set(ref, idempotencyToDocument(rec)); err != nil {
+				return err
+			}
+
+			stored = rec
+			acquired = true
+			return nil
+		},
+	)
+	if err != nil {
+		return idemdom.Record{}, false, err
+	}
+
+	return stored, acquired, nil
+}
+
+func (r *IdempotencyRepositoryFS) Extend(
+	ctx context.Context,
+	rec idemdom.Record,
+) error {
+	if r == nil || r.Client == nil {
+		return ErrIdempotencyRepositoryNotConfigured
+	}
+
+	ref := r.col().Doc(rec.ID)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			if err := r.ensureOwned(tx, ref, rec); err != nil {
+				return err
+			}
+
+			return tx.Update(ref, []firestore.Update{
+				{Path: "lockedUntil", Value: rec.LockedUntil.UTC()},
+			})
+		},
+	)
+	if errors.Is(err, idemdom.ErrNotFound) {
+		return idemdom.ErrNotInProgress
+	}
+
+	return err
+}
+
+func (r *IdempotencyRepositoryFS) Complete(
+	ctx context.Context,
+	rec idemdom.Record,
+) error {
+	if r == nil || r.Client == nil {
+		return ErrIdempotencyRepositoryNotConfigured
+	}
+
+	ref := r.col().Doc(rec.ID)
+
+	return r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			if err := r.ensureOwned(tx, ref, rec); err != nil {
+				return err
+			}
+
+			return tx.Set(ref, idempotencyToDocument(rec))
+		},
+	)
+}
+
+func (r *IdempotencyRepositoryFS) Release(
+	ctx context.Context,
+	rec idemdom.Record,
+) error {
+	if r == nil || r.Client == nil {
+		return ErrIdempotencyRepositoryNotConfigured
+	}
+
+	ref := r.col().Doc(rec.ID)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			if err := r.ensureOwned(tx, ref, rec); err != nil {
+				return err
+			}
+
+			return tx.Delete(ref)
+		},
+	)
+	if errors.Is(err, idemdom.ErrNotInProgress) ||
+		errors.Is(err, idemdom.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// ensureOwned は保存済みのレコードが rec を取得したリクエストのものかを確認します。
+func (r *IdempotencyRepositoryFS) ensureOwned(
+	tx *firestore.Transaction,
+	ref *firestore.DocumentRef,
+	rec idemdom.Record,
+) error {
+	snap, err := tx.Get(ref)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return idemdom.ErrNotFound
+		}
+		return err
+	}
+
+	current, err := decodeIdempotencySnapshot(snap)
+	if err != nil {
+		return err
+	}
+
+	if current.Status != idemdom.StatusInProgress ||
+		!current.CreatedAt.Equal(rec.CreatedAt) ||
+		current.Fingerprint != rec.Fingerprint {
+		return idemdom.ErrNotInProgress
+	}
+
+	return nil
+}
+
+func idempotencyToDocument(rec idemdom.Record) idempotencyDocument {
+	return idempotencyDocument{
+		UserID: rec.UserID,
+		Key:    rec.Key,
+
+		Fingerprint: rec.Fingerprint,
+		Status:      string(rec.Status),
+
+		ResponseStatus:      rec.ResponseStatus,
+		ResponseContentType: rec.ResponseContentType,
+		ResponseBody:        rec.ResponseBody,
+
+		CreatedAt:   rec.CreatedAt.UTC(),
+		LockedUntil: rec.LockedUntil.UTC(),
+		ExpiresAt:   rec.ExpiresAt.UTC(),
+		CompletedAt: rec.CompletedAt,
+	}
+}
+
+func decodeIdempotencySnapshot(
+	snap *firestore.DocumentSnapshot,
+) (idemdom.Record, error) {
+	var doc idempotencyDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return idemdom.Record{}, fmt.Errorf(
+			"decode idempotency key %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return idemdom.Record{
+		ID:     snap.Ref.ID,
+		UserID: doc.UserID,
+		Key:    doc.Key,
+
+		Fingerprint: doc.Fingerprint,
+		Status:      idemdom.Status(doc.Status),
+
+		ResponseStatus:      doc.ResponseStatus,
+		ResponseContentType: doc.ResponseContentType,
+		ResponseBody:        doc.ResponseBody,
+
+		CreatedAt:   doc.CreatedAt.UTC(),
+		LockedUntil: doc.LockedUntil.UTC(),
+		ExpiresAt:   doc.ExpiresAt.UTC(),
+		CompletedAt: utcTimePtr(doc.CompletedAt),
+	}, nil
+}
@@ -47,6 +47,9 @@ type inspectionItemRecord struct {
 	InspectionResult *string    `firestore:"inspectionResult"`
 	InspectedBy      *string    `firestore:"inspectedBy"`
 	InspectedAt      *time.Time `firestore:"inspectedAt"`
+	DefectReasons    []string   `firestore:"defectReasons"`
+	DefectNote       *string    `firestore:"defectNote"`
+	DefectPhotoURLs  []string   `firestore:"defectPhotoUrls"`
 }
 
 type inspectionRecord struct {
@@ -194,6 +197,17 @@ func inspectionBatchToDoc(v inspectiondom.InspectionBatch) map[string]any {
 			item["inspectedAt"] = inspection.InspectedAt.UTC()
 		}
 
+		// 不良の詳細は failed の場合のみ記録する
+		if len(inspection.DefectReasons) > 0 {
+			item["defectReasons"] = inspection.DefectReasons
+		}
+		if inspection.DefectNote != nil {
+			item["defectNote"] = *inspection.DefectNote
+		}
+		if len(inspection.DefectPhotoURLs) > 0 {
+			item["defectPhotoUrls"] = inspection.DefectPhotoURLs
+		}
+
 		items = append(items, item)
 	}
 
@@ -249,6 +263,19 @@ func docToInspectionBatch(doc *firestore.DocumentSnapshot) (inspectiondom.Inspec
 			item.InspectedAt = &inspectedAt
 		}
 
+		if len(raw.DefectReasons) > 0 {
+			item.DefectReasons = append([]string(nil), raw.DefectReasons...)
+		}
+
+		if raw.DefectNote != nil {
+			defectNote := *raw.DefectNote
+			item.DefectNote = &defectNote
+		}
+
+		if len(raw.DefectPhotoURLs) > 0 {
+			item.DefectPhotoURLs = append([]string(nil), raw.DefectPhotoURLs...)
+		}
+
 		inspections = append(inspections, item)
 	}
 
@@ -680,7 +680,8 @@ func (r *InventoryRepositoryFS) ReserveByOrder(
 
 			if ms.ReservedCount > ms.Accumulation {
 				return fmt.Errorf(
-					"inventory repo: insufficient stock (modelId=%s accumulation=%d reservedCount=%d orderId=%s qty=%d)",
+					"%w (modelId=%s accumulation=%d reservedCount=%d orderId=%s qty=%d)",
+					invdom.ErrInsufficientStock,
 					modelID,
 					ms.Accumulation,
 					ms.ReservedCount,
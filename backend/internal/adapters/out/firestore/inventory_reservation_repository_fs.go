@@ -0,0 +1,437 @@
+// backend/internal/adapters/out/firestore/inventory_reservation_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	invdom "narratives/internal/domain/inventory"
+)
+
+const (
+	inventoryReservationsCollectionName = "inventoryReservations"
+
+	defaultInventoryReservationListLimit = 100
+)
+
+var ErrInventoryReservationRepositoryNotConfigured = errors.New(
+	"inventory_reservation_repository_fs: not configured",
+)
+
+// InventoryReservationRepositoryFS is the Firestore implementation of
+// inventory.ReservationRepository.
+//
+// Firestore design:
+//
+//	inventoryReservations/{orderId}
+//
+// inventoryIds は array-contains で inventory 単位の引当一覧を引くための
+// 補助 field です。status / expiresAt の複合条件は composite index を
+// 増やさないよう、status の等価条件で取得した後にメモリ上で絞り込みます。
+type InventoryReservationRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ invdom.ReservationRepository = (*InventoryReservationRepositoryFS)(nil)
+
+func NewInventoryReservationRepositoryFS(
+	client *firestore.Client,
+) *InventoryReservationRepositoryFS {
+	return &InventoryReservationRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *InventoryReservationRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(inventoryReservationsCollectionName)
+}
+
+type inventoryReservationItemDocument struct {
+	InventoryID string `firestore:"inventoryId"`
+	ModelID     string `firestore:"modelId"`
+	Qty         int    `firestore:"qty"`
+}
+
+type inventoryReservationDocument struct {
+	OrderID string `firestore:"orderId"`
+
+	Items        []inventoryReservationItemDocument `firestore:"items"`
+	InventoryIDs []string                           `firestore:"inventoryIds"`
+
+	Status        string `firestore:"status"`
+	ReleaseReason string `firestore:"releaseReason,omitempty"`
+
+	ExpiresAt time.Time `firestore:"expiresAt"`
+
+	CreatedAt   time.Time  `firestore:"createdAt"`
+	UpdatedAt   time.Time  `firestore:"updatedAt"`
+	ConfirmedAt *time.Time `firestore:"confirmedAt,omitempty"`
+	ReleasedAt  *time.Time `firestore:"releasedAt,omitempty"`
+}
+
+// ============================================================
+// inventory.ReservationRepository
+// ============================================================
+
+func (r *InventoryReservationRepositoryFS) CreateIfAbsent(
+	ctx context.Context,
+	reservation invdom.Reservation,
+) (invdom.Reservation, bool, error) {
+	if r == nil || r.Client == nil {
+		return invdom.Reservation{}, false, ErrInventoryReservationRepositoryNotConfigured
+	}
+
+	if err := reservation.Validate(); err != nil {
+		return invdom.Reservation{}, false, err
+	}
+
+	_, err := r.col().Doc(reservation.ID).Create(
+		ctx,
+		inventoryReservationToDocument(reservation),
+	)
+	if err == nil {
+		return reservation, true, nil
+	}
+
+	if status.Code(err) != codes.AlreadyExists {
+		return invdom.Reservation{}, false, fmt.Errorf(
+			"create inventory reservation %q: %w",
+			reservation.ID,
+			err,
+		)
+	}
+
+	existing, err := r.GetByOrderID(ctx, reservation.ID)
+	if err != nil {
+		return invdom.Reservation{}, false, err
+	}
+
+	return existing, false, nil
+}
+
+func (r *InventoryReservationRepositoryFS) GetByOrderID(
+	ctx context.Context,
+	orderID string,
+) (invdom.Reservation, error) {
+	if r == nil || r.Client == nil {
+		return invdom.Reservation{}, ErrInventoryReservationRepositoryNotConfigured
+	}
+
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return invdom.Reservation{}, invdom.ErrInvalidReservationOrderID
+	}
+
+	snap, err := r.col().Doc(orderID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return invdom.Reservation{}, invdom.ErrNotFound
+		}
+
+		return invdom.Reservation{}, err
+	}
+
+	return docToInventoryReservation(snap)
+}
+
+func (r *InventoryReservationRepositoryFS) ListExpired(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]invdom.Reservation, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrInventoryReservationRepositoryNotConfigured
+	}
+
+	now = now.UTC()
+	if limit <= 0 {
+		limit = defaultInventoryReservationListLimit
+	}
+
+	iter := r.col().
+		Where("status", "==", string(invdom.ReservationStatusActive)).
+		Documents(ctx)
+	defer iter.Stop()
+
+	reservations := make([]invdom.Reservation, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list inventory reservations: %w", err)
+		}
+
+		reservation, err := docToInventoryReservation(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if !reservation.IsExpired(now) {
+			continue
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	sortInventoryReservationsByExpiry(reservations)
+
+	if len(reservations) > limit {
+		reservations = reservations[:limit]
+	}
+
+	return reservations, nil
+}
+
+func (r *InventoryReservationRepositoryFS) ListActiveByInventoryID(
+	ctx context.Context,
+	inventoryID string,
+) ([]invdom.Reservation, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrInventoryReservationRepositoryNotConfigured
+	}
+
+	inventoryID = strings.TrimSpace(inventoryID)
+	if inventoryID == "" {
+		return nil, invdom.ErrInvalidMintID
+	}
+
+	iter := r.col().
+		Where("inventoryIds", "array-contains", inventoryID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	reservations := make([]invdom.Reservation, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf(
+				"list inventory reservations by inventory %q: %w",
+				inventoryID,
+				err,
+			)
+		}
+
+		reservation, err := docToInventoryReservation(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if reservation.Status == invdom.ReservationStatusReleased {
+			continue
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	sortInventoryReservationsByExpiry(reservations)
+
+	return reservations, nil
+}
+
+func (r *InventoryReservationRepositoryFS) Update(
+	ctx context.Context,
+	reservation invdom.Reservation,
+) (invdom.Reservation, error) {
+	if r == nil || r.Client == nil {
+		return invdom.Reservation{}, ErrInventoryReservationRepositoryNotConfigured
+	}
+
+	if err := reservation.Validate(); err != nil {
+		return invdom.Reservation{}, err
+	}
+
+	docRef := r.col().Doc(reservation.ID)
+
+	var result invdom.Reservation
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			snap, err := tx.Get(docRef)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return invdom.ErrNotFound
+				}
+
+				return err
+			}
+
+			current, err := docToInventoryReservation(snap)
+			if err != nil {
+				return err
+			}
+
+			if current.Status == invdom.ReservationStatusReleased {
+				if reservation.Status == invdom.ReservationStatusReleased {
+					result = current
+					return nil
+				}
+
+				return invdom.ErrReservationConflict
+			}
+
+			if err := tx.Set(
+				docRef,
+				inventoryReservationToDocument(reservation),
+			); err != nil {
+				return err
+			}
+
+			result = reservation
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.Is(err, invdom.ErrNotFound) ||
+			errors.Is(err, invdom.ErrReservationConflict) {
+			return invdom.Reservation{}, err
+		}
+
+		return invdom.Reservation{}, fmt.Errorf(
+			"update inventory reservation %q: %w",
+			reservation.ID,
+			err,
+		)
+	}
+
+	return result, nil
+}
+
+// ============================================================
+// mapping
+// ============================================================
+
+func inventoryReservationToDocument(
+	reservation invdom.Reservation,
+) inventoryReservationDocument {
+	items := make(
+		[]inventoryReservationItemDocument,
+		0,
+		len(reservation.Items),
+	)
+	inventoryIDs := make([]string, 0, len(reservation.Items))
+	seen := map[string]struct{}{}
+
+	for _, item := range reservation.Items {
+		items = append(
+			items,
+			inventoryReservationItemDocument{
+				InventoryID: item.InventoryID,
+				ModelID:     item.ModelID,
+				Qty:         item.Qty,
+			},
+		)
+
+		if _, ok := seen[item.InventoryID]; ok {
+			continue
+		}
+		seen[item.InventoryID] = struct{}{}
+		inventoryIDs = append(inventoryIDs, item.InventoryID)
+	}
+
+	sort.Strings(inventoryIDs)
+
+	return inventoryReservationDocument{
+		OrderID:       reservation.OrderID,
+		Items:         items,
+		InventoryIDs:  inventoryIDs,
+		Status:        string(reservation.Status),
+		ReleaseReason: string(reservation.ReleaseReason),
+		ExpiresAt:     reservation.ExpiresAt.UTC(),
+		CreatedAt:     reservation.CreatedAt.UTC(),
+		UpdatedAt:     reservation.UpdatedAt.UTC(),
+		ConfirmedAt:   utcTimePointer(reservation.ConfirmedAt),
+		ReleasedAt:    utcTimePointer(reservation.ReleasedAt),
+	}
+}
+
+func docToInventoryReservation(
+	snap *firestore.DocumentSnapshot,
+) (invdom.Reservation, error) {
+	if snap == nil {
+		return invdom.Reservation{}, errors.New(
+			"inventory reservation document snapshot is nil",
+		)
+	}
+
+	var doc inventoryReservationDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return invdom.Reservation{}, fmt.Errorf(
+			"decode inventory reservation %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	items := make([]invdom.ReservationItem, 0, len(doc.Items))
+	for _, item := range doc.Items {
+		items = append(
+			items,
+			invdom.ReservationItem{
+				InventoryID: item.InventoryID,
+				ModelID:     item.ModelID,
+				Qty:         item.Qty,
+			},
+		)
+	}
+
+	orderID := strings.TrimSpace(doc.OrderID)
+	if orderID == "" {
+		orderID = snap.Ref.ID
+	}
+
+	reservation := invdom.Reservation{
+		ID:            snap.Ref.ID,
+		OrderID:       orderID,
+		Items:         items,
+		Status:        invdom.ReservationStatus(doc.Status),
+		ReleaseReason: invdom.ReservationReleaseReason(doc.ReleaseReason),
+		ExpiresAt:     doc.ExpiresAt.UTC(),
+		CreatedAt:     doc.CreatedAt.UTC(),
+		UpdatedAt:     doc.UpdatedAt.UTC(),
+		ConfirmedAt:   utcTimePointer(doc.ConfirmedAt),
+		ReleasedAt:    utcTimePointer(doc.ReleasedAt),
+	}
+
+	if err := reservation.Validate(); err != nil {
+		return invdom.Reservation{}, fmt.Errorf(
+			"invalid inventory reservation %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return reservation, nil
+}
+
+func sortInventoryReservationsByExpiry(reservations []invdom.Reservation) {
+	sort.SliceStable(
+		reservations,
+		func(i int, j int) bool {
+			if reservations[i].ExpiresAt.Equal(reservations[j].ExpiresAt) {
+				return reservations[i].ID < reservations[j].ID
+			}
+
+			return reservations[i].ExpiresAt.Before(reservations[j].ExpiresAt)
+		},
+	)
+}
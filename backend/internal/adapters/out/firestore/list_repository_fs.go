@@ -361,6 +361,7 @@ func (r *ListRepositoryFS) Update(ctx context.Context, id string, l ldom.List) (
 		cur.ReadableID = l.ReadableID
 		cur.Description = l.Description
 		cur.Prices = l.Prices
+		cur.Gating = l.Gating
 
 		clearUpdatedBy := false
 		clearUpdatedAt := false
@@ -508,6 +509,8 @@ func decodeListDoc(doc *gfs.DocumentSnapshot) (ldom.List, error) {
 		UpdatedBy   *string    `firestore:"updated_by"`
 		UpdatedAt   *time.Time `firestore:"updated_at"`
 		InventoryID string     `firestore:"inventory_id"`
+
+		Gating []listGatingRuleDoc `firestore:"gating"`
 	}
 	if err := doc.DataTo(&raw); err != nil {
 		return ldom.List{}, err
@@ -527,6 +530,7 @@ func decodeListDoc(doc *gfs.DocumentSnapshot) (ldom.List, error) {
 		CreatedAt:   raw.CreatedAt,
 		UpdatedBy:   raw.UpdatedBy,
 		UpdatedAt:   raw.UpdatedAt,
+		Gating:      decodeListGating(raw.Gating),
 	}
 	if err := l.ValidateForPersist(); err != nil {
 		return ldom.List{}, err
@@ -545,6 +549,7 @@ func encodeListDoc(l ldom.List) map[string]any {
 		"description":  l.Description,
 		"created_by":   l.CreatedBy,
 		"created_at":   l.CreatedAt.UTC(),
+		"gating":       encodeListGating(l.Gating),
 	}
 	if l.UpdatedBy != nil {
 		m["updated_by"] = *l.UpdatedBy
@@ -555,6 +560,50 @@ func encodeListDoc(l ldom.List) map[string]any {
 	return m
 }
 
+// ============================================================
+// Helpers - gating
+// ============================================================
+
+type listGatingRuleDoc struct {
+	TargetType string     `firestore:"target_type"`
+	TargetID   string     `firestore:"target_id"`
+	MinCount   int        `firestore:"min_count"`
+	SnapshotAt *time.Time `firestore:"snapshot_at"`
+}
+
+func decodeListGating(raw []listGatingRuleDoc) []ldom.GatingRule {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	out := make([]ldom.GatingRule, 0, len(raw))
+	for _, r := range raw {
+		out = append(out, ldom.GatingRule{
+			TargetType: ldom.GateTargetType(r.TargetType),
+			TargetID:   r.TargetID,
+			MinCount:   r.MinCount,
+			SnapshotAt: r.SnapshotAt,
+		})
+	}
+	return ldom.NormalizeGatingRules(out)
+}
+
+func encodeListGating(rules []ldom.GatingRule) []map[string]any {
+	out := make([]map[string]any, 0, len(rules))
+	for _, r := range rules {
+		m := map[string]any{
+			"target_type": string(r.TargetType),
+			"target_id":   r.TargetID,
+			"min_count":   r.MinCount,
+		}
+		if r.SnapshotAt != nil {
+			m["snapshot_at"] = r.SnapshotAt.UTC()
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
 // ============================================================
 // Helpers - prices
 // ============================================================
@@ -482,6 +482,9 @@ func applyMemberPatch(m memdom.Member, patch memdom.MemberPatch, now time.Time)
 	if patch.Permissions != nil {
 		m.Permissions = dedupStrings(*patch.Permissions)
 	}
+	if patch.Roles != nil {
+		m.Roles = dedupStrings(*patch.Roles)
+	}
 	if patch.AssignedBrands != nil {
 		m.AssignedBrands = dedupStrings(*patch.AssignedBrands)
 	}
@@ -533,6 +536,7 @@ func normalizeMemberForCreate(m memdom.Member, now time.Time) memdom.Member {
 func normalizeMemberValues(m memdom.Member) memdom.Member {
 	m.Email = normalizeMemberEmail(m.Email)
 	m.Permissions = dedupStrings(m.Permissions)
+	m.Roles = dedupStrings(m.Roles)
 	m.AssignedBrands = dedupStrings(m.AssignedBrands)
 	return m
 }
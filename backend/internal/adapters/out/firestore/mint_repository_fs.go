@@ -110,9 +110,10 @@ func taskStatusFromRaw(raw map[string]any) mintdom.MintProductTaskStatus {
 }
 
 type tokenBlueprintDoc struct {
-	Name        string `firestore:"name"`
-	Symbol      string `firestore:"symbol"`
-	MetadataURI string `firestore:"metadataUri"`
+	Name        string                              `firestore:"name"`
+	Symbol      string                              `firestore:"symbol"`
+	MetadataURI string                              `firestore:"metadataUri"`
+	Royalty     *tokenBlueprintRepositoryRoyaltyDoc `firestore:"royalty"`
 }
 
 type brandDoc struct {
@@ -433,7 +434,17 @@ func (r *MintRepositoryFS) Update(ctx context.Context, m mintdom.Mint) (mintdom.
 		data["onChainTxSignature"] = firestore.Delete
 	}
 
-	_, err := docRef.Set(ctx, data, firestore.MergeAll)
+	// MintCompleted などのイベントは Mint と同じ transaction で outbox へ保存する。
+	err := r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			if err := tx.Set(docRef, data, firestore.MergeAll); err != nil {
+				return err
+			}
+
+			return createOutboxEventsTx(r.Client, tx, m.PendingEvents())
+		},
+	)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
 			return mintdom.Mint{}, mintdom.ErrNotFound
@@ -441,6 +452,7 @@ func (r *MintRepositoryFS) Update(ctx context.Context, m mintdom.Mint) (mintdom.
 		return mintdom.Mint{}, err
 	}
 
+	m.ClearEvents()
 	return m, nil
 }
 
@@ -1007,6 +1019,8 @@ func (r *MintRepositoryFS) LoadForMinting(
 		BlueprintName:    name,
 		BlueprintSymbol:  symbol,
 		MetadataURI:      metadataURI,
+
+		SellerFeeBasisPoints: fromFSRoyalty(tb.Royalty).BasisPoints,
 	}
 
 	return dto, nil
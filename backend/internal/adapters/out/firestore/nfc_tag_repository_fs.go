@@ -0,0 +1,260 @@
+// backend/internal/adapters/out/firestore/nfc_tag_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	nfcdom "narratives/internal/domain/nfc"
+)
+
+const nfcTagsCollectionName = "nfcTags"
+
+var ErrNFCTagRepositoryNotConfigured = errors.New(
+	"nfc_tag_repository_fs: not configured",
+)
+
+// NFCTagRepositoryFS is the Firestore implementation of nfc.RepositoryPort.
+//
+// Firestore design:
+//
+//	nfcTags/{productId}
+//
+// Tag keys are never stored; they are derived from the master key and
+// keyVersion on demand.
+type NFCTagRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ nfcdom.RepositoryPort = (*NFCTagRepositoryFS)(nil)
+
+func NewNFCTagRepositoryFS(client *firestore.Client) *NFCTagRepositoryFS {
+	return &NFCTagRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *NFCTagRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(nfcTagsCollectionName)
+}
+
+type nfcTagDocument struct {
+	ProductionID string `firestore:"productionId"`
+	CompanyID    string `firestore:"companyId"`
+
+	Status     string `firestore:"status"`
+	KeyVersion int    `firestore:"keyVersion"`
+	UID        string `firestore:"uid,omitempty"`
+
+	LastCounter    int64      `firestore:"lastCounter"`
+	LastVerifiedAt *time.Time `firestore:"lastVerifiedAt,omitempty"`
+
+	CreatedAt     time.Time  `firestore:"createdAt"`
+	UpdatedAt     time.Time  `firestore:"updatedAt"`
+	ProvisionedAt *time.Time `firestore:"provisionedAt,omitempty"`
+	RevokedAt     *time.Time `firestore:"revokedAt,omitempty"`
+}
+
+func (r *NFCTagRepositoryFS) GetByProductID(
+	ctx context.Context,
+	productID string,
+) (nfcdom.Tag, error) {
+	if r == nil || r.Client == nil {
+		return nfcdom.Tag{}, ErrNFCTagRepositoryNotConfigured
+	}
+
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return nfcdom.Tag{}, nfcdom.ErrInvalidProductID
+	}
+
+	snap, err := r.col().Doc(productID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nfcdom.Tag{}, nfcdom.ErrNotFound
+		}
+		return nfcdom.Tag{}, err
+	}
+
+	return decodeNFCTagSnapshot(snap)
+}
+
+func (r *NFCTagRepositoryFS) ListByProductionID(
+	ctx context.Context,
+	productionID string,
+) ([]nfcdom.Tag, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrNFCTagRepositoryNotConfigured
+	}
+
+	productionID = strings.TrimSpace(productionID)
+	if productionID == "" {
+		return nil, nfcdom.ErrInvalidProductionID
+	}
+
+	iter := r.col().
+		Where("productionId", "==", productionID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]nfcdom.Tag, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := decodeNFCTagSnapshot(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, t)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ProductID < out[j].ProductID
+	})
+
+	return out, nil
+}
+
+func (r *NFCTagRepositoryFS) CreateIfAbsent(
+	ctx context.Context,
+	t nfcdom.Tag,
+) (bool, error) {
+	if r == nil || r.Client == nil {
+		return false, ErrNFCTagRepositoryNotConfigured
+	}
+
+	productID := strings.TrimSpace(t.ProductID)
+	if productID == "" {
+		return false, nfcdom.ErrInvalidProductID
+	}
+
+	_, err := r.col().Doc(productID).Create(ctx, nfcTagToDocument(t))
+	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *NFCTagRepositoryFS) Update(
+	ctx context.Context,
+	productID string,
+	fn func(t *nfcdom.Tag) error,
+) (nfcdom.Tag, error) {
+	if r == nil || r.Client == nil {
+		return nfcdom.Tag{}, ErrNFCTagRepositoryNotConfigured
+	}
+
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return nfcdom.Tag{}, nfcdom.ErrInvalidProductID
+	}
+
+	ref := r.col().Doc(productID)
+
+	var updated nfcdom.Tag
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			snap, err := tx.Get(ref)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return nfcdom.ErrNotFound
+				}
+				return err
+			}
+
+			t, err := decodeNFCTagSnapshot(snap)
+			if err != nil {
+				return err
+			}
+
+			if err := fn(&t); err != nil {
+				return err
+			}
+
+			if err := tx.Set(ref, nfcTagToDocument(t)); err != nil {
+				return err
+			}
+
+			updated = t
+			return nil
+		},
+	)
+	if err != nil {
+		return nfcdom.Tag{}, err
+	}
+
+	return updated, nil
+}
+
+func nfcTagToDocument(t nfcdom.Tag) nfcTagDocument {
+	return nfcTagDocument{
+		ProductionID: t.ProductionID,
+		CompanyID:    t.CompanyID,
+
+		Status:     string(t.Status),
+		KeyVersion: t.KeyVersion,
+		UID:        t.UID,
+
+		LastCounter:    int64(t.LastCounter),
+		LastVerifiedAt: t.LastVerifiedAt,
+
+		CreatedAt:     t.CreatedAt.UTC(),
+		UpdatedAt:     t.UpdatedAt.UTC(),
+		ProvisionedAt: t.ProvisionedAt,
+		RevokedAt:     t.RevokedAt,
+	}
+}
+
+func decodeNFCTagSnapshot(
+	snap *firestore.DocumentSnapshot,
+) (nfcdom.Tag, error) {
+	var doc nfcTagDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return nfcdom.Tag{}, fmt.Errorf(
+			"decode nfc tag %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return nfcdom.Tag{
+		ProductID:    snap.Ref.ID,
+		ProductionID: doc.ProductionID,
+		CompanyID:    doc.CompanyID,
+
+		Status:     nfcdom.Status(doc.Status),
+		KeyVersion: doc.KeyVersion,
+		UID:        doc.UID,
+
+		LastCounter:    uint32(doc.LastCounter),
+		LastVerifiedAt: utcTimePtr(doc.LastVerifiedAt),
+
+		CreatedAt:     doc.CreatedAt.UTC(),
+		UpdatedAt:     doc.UpdatedAt.UTC(),
+		ProvisionedAt: utcTimePtr(doc.ProvisionedAt),
+		RevokedAt:     utcTimePtr(doc.RevokedAt),
+	}, nil
+}
@@ -0,0 +1,401 @@
+// backend/internal/adapters/out/firestore/offer_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	offerdom "narratives/internal/domain/offer"
+)
+
+const (
+	offersCollectionName = "offers"
+
+	defaultOfferDueListLimit = 100
+)
+
+var ErrOfferRepositoryNotConfigured = errors.New(
+	"offer_repository_fs: not configured",
+)
+
+// OfferRepositoryFS is the Firestore implementation of offer.RepositoryPort.
+//
+// Firestore design:
+//
+//	offers/{offerId}
+type OfferRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ offerdom.RepositoryPort = (*OfferRepositoryFS)(nil)
+
+func NewOfferRepositoryFS(
+	client *firestore.Client,
+) *OfferRepositoryFS {
+	return &OfferRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *OfferRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(offersCollectionName)
+}
+
+type offerDocument struct {
+	ResaleID string `firestore:"resaleId"`
+
+	BuyerAvatarID  string `firestore:"buyerAvatarId"`
+	SellerAvatarID string `firestore:"sellerAvatarId"`
+
+	ListPrice int `firestore:"listPrice"`
+	Amount    int `firestore:"amount"`
+
+	Status    string               `firestore:"status"`
+	LastActor string               `firestore:"lastActor"`
+	Rounds    []offerRoundDocument `firestore:"rounds"`
+
+	ExpiresAt         time.Time  `firestore:"expiresAt"`
+	CheckoutExpiresAt *time.Time `firestore:"checkoutExpiresAt,omitempty"`
+
+	OrderID string `firestore:"orderId,omitempty"`
+
+	CreatedAt time.Time `firestore:"createdAt"`
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+type offerRoundDocument struct {
+	By        string    `firestore:"by"`
+	Amount    int       `firestore:"amount"`
+	CreatedAt time.Time `firestore:"createdAt"`
+}
+
+func (r *OfferRepositoryFS) GetByID(
+	ctx context.Context,
+	id string,
+) (offerdom.Offer, error) {
+	if r == nil || r.Client == nil {
+		return offerdom.Offer{}, ErrOfferRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return offerdom.Offer{}, offerdom.ErrInvalidID
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return offerdom.Offer{}, offerdom.ErrNotFound
+		}
+		return offerdom.Offer{}, err
+	}
+
+	return docToOffer(snap)
+}
+
+func (r *OfferRepositoryFS) ListByResaleID(
+	ctx context.Context,
+	resaleID string,
+) ([]offerdom.Offer, error) {
+	return r.listWhere(ctx, "resaleId", resaleID)
+}
+
+func (r *OfferRepositoryFS) ListByBuyerAvatarID(
+	ctx context.Context,
+	avatarID string,
+) ([]offerdom.Offer, error) {
+	return r.listWhere(ctx, "buyerAvatarId", avatarID)
+}
+
+func (r *OfferRepositoryFS) ListBySellerAvatarID(
+	ctx context.Context,
+	avatarID string,
+) ([]offerdom.Offer, error) {
+	return r.listWhere(ctx, "sellerAvatarId", avatarID)
+}
+
+func (r *OfferRepositoryFS) listWhere(
+	ctx context.Context,
+	field string,
+	value string,
+) ([]offerdom.Offer, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrOfferRepositoryNotConfigured
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return []offerdom.Offer{}, nil
+	}
+
+	offers, err := r.collect(
+		r.col().Where(field, "==", value).Documents(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(offers, func(i, j int) bool {
+		return offers[i].UpdatedAt.After(offers[j].UpdatedAt)
+	})
+
+	return offers, nil
+}
+
+func (r *OfferRepositoryFS) ListDue(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]offerdom.Offer, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrOfferRepositoryNotConfigured
+	}
+
+	now = now.UTC()
+	if limit <= 0 {
+		limit = defaultOfferDueListLimit
+	}
+
+	// 期限は composite index を増やさないようにメモリ上で判定する。
+	offers, err := r.collect(
+		r.col().
+			Where("status", "in", []string{
+				string(offerdom.StatusPending),
+				string(offerdom.StatusCountered),
+				string(offerdom.StatusAccepted),
+			}).
+			Documents(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list due offers: %w", err)
+	}
+
+	due := make([]offerdom.Offer, 0, len(offers))
+	for _, o := range offers {
+		if o.IsExpired(now) {
+			due = append(due, o)
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].UpdatedAt.Before(due[j].UpdatedAt)
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *OfferRepositoryFS) Create(
+	ctx context.Context,
+	o offerdom.Offer,
+) (offerdom.Offer, error) {
+	if r == nil || r.Client == nil {
+		return offerdom.Offer{}, ErrOfferRepositoryNotConfigured
+	}
+
+	if err := o.Validate(); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	ref := r.col().NewDoc()
+	if id := strings.TrimSpace(o.ID); id != "" {
+		ref = r.col().Doc(id)
+	}
+
+	if _, err := ref.Create(ctx, offerToDocument(o)); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return offerdom.Offer{}, offerdom.ErrConflict
+		}
+		return offerdom.Offer{}, err
+	}
+
+	o.ID = ref.ID
+
+	return o, nil
+}
+
+func (r *OfferRepositoryFS) Update(
+	ctx context.Context,
+	o offerdom.Offer,
+	prevUpdatedAt time.Time,
+) (offerdom.Offer, error) {
+	if r == nil || r.Client == nil {
+		return offerdom.Offer{}, ErrOfferRepositoryNotConfigured
+	}
+
+	id := strings.TrimSpace(o.ID)
+	if id == "" || strings.Contains(id, "/") {
+		return offerdom.Offer{}, offerdom.ErrInvalidID
+	}
+
+	if err := o.Validate(); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	ref := r.col().Doc(id)
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return offerdom.ErrNotFound
+			}
+			return err
+		}
+
+		current, err := docToOffer(snap)
+		if err != nil {
+			return err
+		}
+
+		// 同時に返答された場合、先に保存された変更を上書きしない。
+		// Firestore の timestamp はマイクロ秒精度のため揃えて比較する。
+		if !current.UpdatedAt.Truncate(time.Microsecond).Equal(
+			prevUpdatedAt.UTC().Truncate(time.Microsecond),
+		) {
+			return offerdom.ErrConflict
+		}
+
+		return tx.Set(ref, offerToDocument(o))
+	})
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	return o, nil
+}
+
+func (r *OfferRepositoryFS) collect(
+	iter *firestore.DocumentIterator,
+) ([]offerdom.Offer, error) {
+	defer iter.Stop()
+
+	offers := make([]offerdom.Offer, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		o, err := docToOffer(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		offers = append(offers, o)
+	}
+
+	return offers, nil
+}
+
+func offerToDocument(
+	o offerdom.Offer,
+) offerDocument {
+	rounds := make([]offerRoundDocument, 0, len(o.Rounds))
+	for _, round := range o.Rounds {
+		rounds = append(rounds, offerRoundDocument{
+			By:        string(round.By),
+			Amount:    round.Amount,
+			CreatedAt: round.CreatedAt.UTC(),
+		})
+	}
+
+	var checkoutExpiresAt *time.Time
+	if o.CheckoutExpiresAt != nil {
+		t := o.CheckoutExpiresAt.UTC()
+		checkoutExpiresAt = &t
+	}
+
+	return offerDocument{
+		ResaleID: o.ResaleID,
+
+		BuyerAvatarID:  o.BuyerAvatarID,
+		SellerAvatarID: o.SellerAvatarID,
+
+		ListPrice: o.ListPrice,
+		Amount:    o.Amount,
+
+		Status:    string(o.Status),
+		LastActor: string(o.LastActor),
+		Rounds:    rounds,
+
+		ExpiresAt:         o.ExpiresAt.UTC(),
+		CheckoutExpiresAt: checkoutExpiresAt,
+
+		OrderID: o.OrderID,
+
+		CreatedAt: o.CreatedAt.UTC(),
+		UpdatedAt: o.UpdatedAt.UTC(),
+	}
+}
+
+func docToOffer(
+	snap *firestore.DocumentSnapshot,
+) (offerdom.Offer, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return offerdom.Offer{}, offerdom.ErrNotFound
+	}
+
+	var doc offerDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return offerdom.Offer{}, fmt.Errorf(
+			"decode offer %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	rounds := make([]offerdom.Round, 0, len(doc.Rounds))
+	for _, round := range doc.Rounds {
+		rounds = append(rounds, offerdom.Round{
+			By:        offerdom.Party(round.By),
+			Amount:    round.Amount,
+			CreatedAt: round.CreatedAt.UTC(),
+		})
+	}
+
+	var checkoutExpiresAt *time.Time
+	if doc.CheckoutExpiresAt != nil {
+		t := doc.CheckoutExpiresAt.UTC()
+		checkoutExpiresAt = &t
+	}
+
+	return offerdom.Offer{
+		ID:       snap.Ref.ID,
+		ResaleID: doc.ResaleID,
+
+		BuyerAvatarID:  doc.BuyerAvatarID,
+		SellerAvatarID: doc.SellerAvatarID,
+
+		ListPrice: doc.ListPrice,
+		Amount:    doc.Amount,
+
+		Status:    offerdom.Status(doc.Status),
+		LastActor: offerdom.Party(doc.LastActor),
+		Rounds:    rounds,
+
+		ExpiresAt:         doc.ExpiresAt.UTC(),
+		CheckoutExpiresAt: checkoutExpiresAt,
+
+		OrderID: doc.OrderID,
+
+		CreatedAt: doc.CreatedAt.UTC(),
+		UpdatedAt: doc.UpdatedAt.UTC(),
+	}, nil
+}
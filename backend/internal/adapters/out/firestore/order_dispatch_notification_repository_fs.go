@@ -15,6 +15,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	orderdom "narratives/internal/domain/order"
+	transportationdom "narratives/internal/domain/transportation"
 )
 
 const (
@@ -32,6 +33,9 @@ type orderDispatchNotificationItemDocument struct {
 	ProductBlueprintID string `firestore:"productBlueprintId"`
 	TokenBlueprintID   string `firestore:"tokenBlueprintId"`
 	Qty                int    `firestore:"qty"`
+
+	Carrier        string `firestore:"carrier,omitempty"`
+	TrackingNumber string `firestore:"trackingNumber,omitempty"`
 }
 
 type orderDispatchNotificationDeliveryDocument struct {
@@ -676,6 +680,8 @@ func orderDispatchNotificationDeliveryToDocument(
 				ProductBlueprintID: item.ProductBlueprintID,
 				TokenBlueprintID:   item.TokenBlueprintID,
 				Qty:                item.Qty,
+				Carrier:            string(item.Carrier),
+				TrackingNumber:     item.TrackingNumber,
 			},
 		)
 	}
@@ -745,6 +751,8 @@ func readOrderDispatchNotificationDeliverySnapshot(
 				ProductBlueprintID: item.ProductBlueprintID,
 				TokenBlueprintID:   item.TokenBlueprintID,
 				Qty:                item.Qty,
+				Carrier:            transportationdom.Carrier(item.Carrier),
+				TrackingNumber:     item.TrackingNumber,
 			},
 		)
 	}
@@ -18,6 +18,7 @@ import (
 	fscommon "narratives/internal/adapters/out/firestore/common"
 	common "narratives/internal/domain/common"
 	orderdom "narratives/internal/domain/order"
+	transportationdom "narratives/internal/domain/transportation"
 )
 
 var (
@@ -30,7 +31,8 @@ var (
 )
 
 // OrderRepositoryFS is the Firestore implementation of orderdom.Repository.
-// Order writes and orderTransferItems projection writes share one transaction.
+// Order writes, orderTransferItems projection writes and the Order's pending
+// outbox events share one transaction.
 type OrderRepositoryFS struct {
 	Client *firestore.Client
 }
@@ -192,7 +194,7 @@ func (r *OrderRepositoryFS) Create(
 				}
 			}
 
-			return nil
+			return createOutboxEventsTx(r.Client, tx, o.PendingEvents())
 		},
 	)
 	if err != nil {
@@ -202,6 +204,7 @@ func (r *OrderRepositoryFS) Create(
 		return orderdom.Order{}, err
 	}
 
+	o.ClearEvents()
 	return o, nil
 }
 
@@ -319,13 +322,14 @@ func (r *OrderRepositoryFS) Update(
 				}
 			}
 
-			return nil
+			return createOutboxEventsTx(r.Client, tx, o.PendingEvents())
 		},
 	)
 	if err != nil {
 		return orderdom.Order{}, err
 	}
 
+	o.ClearEvents()
 	return o, nil
 }
 
@@ -343,6 +347,42 @@ type orderDoc struct {
 	PaymentMethodSnapshot paymentMethodSnapshotDoc `firestore:"paymentMethodSnapshot"`
 
 	CreatedAt time.Time `firestore:"createdAt"`
+
+	Refunds []refundSnapshotDoc `firestore:"refunds,omitempty"`
+
+	Discount *discountSnapshotDoc `firestore:"discount,omitempty"`
+}
+
+type discountSnapshotDoc struct {
+	CouponID     string                    `firestore:"couponId"`
+	Code         string                    `firestore:"code"`
+	DiscountType string                    `firestore:"discountType"`
+	Items        []discountItemSnapshotDoc `firestore:"items"`
+
+	ItemAmount     int `firestore:"itemAmount"`
+	ShippingAmount int `firestore:"shippingAmount"`
+	Amount         int `firestore:"amount"`
+
+	AppliedAt time.Time `firestore:"appliedAt"`
+}
+
+type discountItemSnapshotDoc struct {
+	ItemIndex      int `firestore:"itemIndex"`
+	Amount         int `firestore:"amount"`
+	ShippingAmount int `firestore:"shippingAmount"`
+}
+
+type refundSnapshotDoc struct {
+	RefundID   string                  `firestore:"refundId"`
+	Amount     int                     `firestore:"amount"`
+	Items      []refundItemSnapshotDoc `firestore:"items"`
+	RefundedAt time.Time               `firestore:"refundedAt"`
+}
+
+type refundItemSnapshotDoc struct {
+	ItemIndex int `firestore:"itemIndex"`
+	Qty       int `firestore:"qty"`
+	Amount    int `firestore:"amount"`
 }
 
 type shippingSnapshotDoc struct {
@@ -380,6 +420,16 @@ type shippingQuoteItemSnapshotDoc struct {
 	Amount     int `firestore:"amount"`
 
 	Currency string `firestore:"currency"`
+
+	Customs *customsDeclarationSnapshotDoc `firestore:"customs,omitempty"`
+}
+
+type customsDeclarationSnapshotDoc struct {
+	HSCode            string `firestore:"hsCode"`
+	Description       string `firestore:"description"`
+	OriginCountry     string `firestore:"originCountry"`
+	DeclaredUnitValue int    `firestore:"declaredUnitValue"`
+	Currency          string `firestore:"currency"`
 }
 
 type paymentMethodSnapshotDoc struct {
@@ -418,8 +468,30 @@ type itemDoc struct {
 	IsCancelled  bool `firestore:"isCancelled"`
 	IsDispatched bool `firestore:"isDispatched"`
 
+	Status      string           `firestore:"status,omitempty"`
+	Dispatch    *itemDispatchDoc `firestore:"dispatch,omitempty"`
+	DeliveredAt *time.Time       `firestore:"deliveredAt,omitempty"`
+	ReturnedAt  *time.Time       `firestore:"returnedAt,omitempty"`
+
 	Transferred   bool       `firestore:"transferred"`
 	TransferredAt *time.Time `firestore:"transferredAt,omitempty"`
+
+	Royalty *itemRoyaltyDoc `firestore:"royalty,omitempty"`
+
+	OfferID string `firestore:"offerId,omitempty"`
+}
+
+type itemDispatchDoc struct {
+	Carrier        string    `firestore:"carrier"`
+	TrackingNumber string    `firestore:"trackingNumber,omitempty"`
+	DispatchedAt   time.Time `firestore:"dispatchedAt"`
+}
+
+type itemRoyaltyDoc struct {
+	CompanyID     string `firestore:"companyId"`
+	BasisPoints   int    `firestore:"basisPoints"`
+	MinimumAmount int    `firestore:"minimumAmount"`
+	Amount        int    `firestore:"amount"`
 }
 
 func docToOrder(
@@ -477,6 +549,8 @@ func docToOrder(
 				Amount:     item.Amount,
 
 				Currency: item.Currency,
+
+				Customs: customsDeclarationFromDoc(item.Customs),
 			},
 		)
 	}
@@ -517,8 +591,17 @@ func docToOrder(
 				IsCancelled:  item.IsCancelled,
 				IsDispatched: item.IsDispatched,
 
+				Status:      orderdom.Status(item.Status),
+				Dispatch:    itemDispatchFromDoc(item.Dispatch),
+				DeliveredAt: utcTimePtr(item.DeliveredAt),
+				ReturnedAt:  utcTimePtr(item.ReturnedAt),
+
 				Transferred:   item.Transferred,
 				TransferredAt: transferredAt,
+
+				Royalty: itemRoyaltyFromDoc(item.Royalty),
+
+				OfferID: item.OfferID,
 			},
 		)
 	}
@@ -561,6 +644,65 @@ func docToOrder(
 		CreatedAt: doc.CreatedAt.UTC(),
 	}
 
+	for _, r := range doc.Refunds {
+		refundItems := make(
+			[]orderdom.RefundItemSnapshot,
+			0,
+			len(r.Items),
+		)
+
+		for _, item := range r.Items {
+			refundItems = append(
+				refundItems,
+				orderdom.RefundItemSnapshot{
+					ItemIndex: item.ItemIndex,
+					Qty:       item.Qty,
+					Amount:    item.Amount,
+				},
+			)
+		}
+
+		order.Refunds = append(
+			order.Refunds,
+			orderdom.RefundSnapshot{
+				RefundID:   r.RefundID,
+				Amount:     r.Amount,
+				Items:      refundItems,
+				RefundedAt: r.RefundedAt.UTC(),
+			},
+		)
+	}
+
+	if d := doc.Discount; d != nil {
+		discountItems := make(
+			[]orderdom.DiscountItemSnapshot,
+			0,
+			len(d.Items),
+		)
+
+		for _, item := range d.Items {
+			discountItems = append(
+				discountItems,
+				orderdom.DiscountItemSnapshot{
+					ItemIndex:      item.ItemIndex,
+					Amount:         item.Amount,
+					ShippingAmount: item.ShippingAmount,
+				},
+			)
+		}
+
+		order.Discount = &orderdom.DiscountSnapshot{
+			CouponID:       d.CouponID,
+			Code:           d.Code,
+			DiscountType:   d.DiscountType,
+			Items:          discountItems,
+			ItemAmount:     d.ItemAmount,
+			ShippingAmount: d.ShippingAmount,
+			Amount:         d.Amount,
+			AppliedAt:      d.AppliedAt.UTC(),
+		}
+	}
+
 	if err := order.Validate(); err != nil {
 		return orderdom.Order{}, fmt.Errorf(
 			"order %s: %w",
@@ -591,7 +733,7 @@ func orderToDoc(o orderdom.Order) map[string]any {
 		)
 	}
 
-	return map[string]any{
+	doc := map[string]any{
 		"userId":   o.UserID,
 		"avatarId": o.AvatarID,
 		"cartId":   o.CartID,
@@ -627,6 +769,54 @@ func orderToDoc(o orderdom.Order) map[string]any {
 		"items":     items,
 		"createdAt": o.CreatedAt.UTC(),
 	}
+
+	if len(o.Refunds) > 0 {
+		refunds := make([]map[string]any, 0, len(o.Refunds))
+
+		for _, r := range o.Refunds {
+			refundItems := make([]map[string]any, 0, len(r.Items))
+			for _, item := range r.Items {
+				refundItems = append(refundItems, map[string]any{
+					"itemIndex": item.ItemIndex,
+					"qty":       item.Qty,
+					"amount":    item.Amount,
+				})
+			}
+
+			refunds = append(refunds, map[string]any{
+				"refundId":   r.RefundID,
+				"amount":     r.Amount,
+				"items":      refundItems,
+				"refundedAt": r.RefundedAt.UTC(),
+			})
+		}
+
+		doc["refunds"] = refunds
+	}
+
+	if d := o.Discount; d != nil {
+		discountItems := make([]map[string]any, 0, len(d.Items))
+		for _, item := range d.Items {
+			discountItems = append(discountItems, map[string]any{
+				"itemIndex":      item.ItemIndex,
+				"amount":         item.Amount,
+				"shippingAmount": item.ShippingAmount,
+			})
+		}
+
+		doc["discount"] = map[string]any{
+			"couponId":       d.CouponID,
+			"code":           d.Code,
+			"discountType":   d.DiscountType,
+			"items":          discountItems,
+			"itemAmount":     d.ItemAmount,
+			"shippingAmount": d.ShippingAmount,
+			"amount":         d.Amount,
+			"appliedAt":      d.AppliedAt.UTC(),
+		}
+	}
+
+	return doc
 }
 
 func shippingQuoteItemToDocMap(
@@ -651,9 +841,35 @@ func shippingQuoteItemToDocMap(
 			item.TransportationID
 	}
 
+	if item.Customs != nil {
+		doc["customs"] = map[string]any{
+			"hsCode":            item.Customs.HSCode,
+			"description":       item.Customs.Description,
+			"originCountry":     item.Customs.OriginCountry,
+			"declaredUnitValue": item.Customs.DeclaredUnitValue,
+			"currency":          item.Customs.Currency,
+		}
+	}
+
 	return doc
 }
 
+func customsDeclarationFromDoc(
+	doc *customsDeclarationSnapshotDoc,
+) *orderdom.CustomsDeclarationSnapshot {
+	if doc == nil {
+		return nil
+	}
+
+	return &orderdom.CustomsDeclarationSnapshot{
+		HSCode:            doc.HSCode,
+		Description:       doc.Description,
+		OriginCountry:     doc.OriginCountry,
+		DeclaredUnitValue: doc.DeclaredUnitValue,
+		Currency:          doc.Currency,
+	}
+}
+
 func orderItemToDocMap(
 	item orderdom.OrderItemSnapshot,
 ) map[string]any {
@@ -692,6 +908,19 @@ func orderItemToDocMap(
 		doc["tokenBlueprintId"] =
 			item.TokenBlueprintID
 		doc["brandId"] = item.BrandID
+
+		if item.Royalty != nil {
+			doc["royalty"] = map[string]any{
+				"companyId":     item.Royalty.CompanyID,
+				"basisPoints":   item.Royalty.BasisPoints,
+				"minimumAmount": item.Royalty.MinimumAmount,
+				"amount":        item.Royalty.Amount,
+			}
+		}
+
+		if item.OfferID != "" {
+			doc["offerId"] = item.OfferID
+		}
 	}
 
 	if item.Transferred && item.TransferredAt != nil {
@@ -699,9 +928,66 @@ func orderItemToDocMap(
 			item.TransferredAt.UTC()
 	}
 
+	if item.Status != "" {
+		doc["status"] = string(item.Status)
+	}
+
+	if item.Dispatch != nil {
+		dispatch := map[string]any{
+			"carrier":      string(item.Dispatch.Carrier),
+			"dispatchedAt": item.Dispatch.DispatchedAt.UTC(),
+		}
+
+		if item.Dispatch.TrackingNumber != "" {
+			dispatch["trackingNumber"] =
+				item.Dispatch.TrackingNumber
+		}
+
+		doc["dispatch"] = dispatch
+	}
+
+	if item.DeliveredAt != nil {
+		doc["deliveredAt"] =
+			item.DeliveredAt.UTC()
+	}
+
+	if item.ReturnedAt != nil {
+		doc["returnedAt"] =
+			item.ReturnedAt.UTC()
+	}
+
 	return doc
 }
 
+func itemDispatchFromDoc(
+	doc *itemDispatchDoc,
+) *orderdom.DispatchSnapshot {
+	if doc == nil {
+		return nil
+	}
+
+	return &orderdom.DispatchSnapshot{
+		Carrier:        transportationdom.Carrier(doc.Carrier),
+		TrackingNumber: doc.TrackingNumber,
+		DispatchedAt:   doc.DispatchedAt.UTC(),
+	}
+}
+
+func itemRoyaltyFromDoc(
+	doc *itemRoyaltyDoc,
+) *orderdom.RoyaltySnapshot {
+	if doc == nil {
+		return nil
+	}
+
+	return &orderdom.RoyaltySnapshot{
+		CompanyID:     doc.CompanyID,
+		BasisPoints:   doc.BasisPoints,
+		MinimumAmount: doc.MinimumAmount,
+		Amount:        doc.Amount,
+	}
+}
+
 func orderTransferItemDocuments(
 	o orderdom.Order,
 ) ([]map[string]any, error) {
@@ -996,7 +1282,9 @@ func validateShippingQuoteItemDocumentShape(
 	switch carrier {
 	case "yamato",
 		"sagawa",
-		"post":
+		"post",
+		"ems",
+		"epacket":
 		if size <= 0 {
 			return 0, ErrInvalidOrderDocumentData
 		}
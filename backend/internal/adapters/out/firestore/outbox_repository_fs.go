@@ -0,0 +1,399 @@
+// backend/internal/adapters/out/firestore/outbox_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	outboxdom "narratives/internal/domain/outbox"
+)
+
+const (
+	outboxEventsCollectionName = "outboxEvents"
+	defaultOutboxListDueLimit  = 50
+)
+
+var ErrOutboxRepositoryNotConfigured = errors.New(
+	"outbox_repository_fs: not configured",
+)
+
+// OutboxRepositoryFS is the Firestore implementation of outbox.RepositoryPort.
+//
+// Firestore design:
+//
+//	outboxEvents/{eventId}
+//
+// Events are created by the aggregate repositories through
+// createOutboxEventsTx inside the aggregate's own transaction. This
+// repository only moves them through the delivery states.
+type OutboxRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ outboxdom.RepositoryPort = (*OutboxRepositoryFS)(nil)
+
+func NewOutboxRepositoryFS(
+	client *firestore.Client,
+) *OutboxRepositoryFS {
+	return &OutboxRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *OutboxRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(outboxEventsCollectionName)
+}
+
+type outboxEventDocument struct {
+	Type string `firestore:"type"`
+
+	AggregateType string `firestore:"aggregateType"`
+	AggregateID   string `firestore:"aggregateId"`
+
+	Payload string `firestore:"payload"`
+
+	Status      string `firestore:"status"`
+	Attempts    int    `firestore:"attempts"`
+	MaxAttempts int    `firestore:"maxAttempts"`
+	LastError   string `firestore:"lastError,omitempty"`
+
+	OccurredAt time.Time `firestore:"occurredAt"`
+	UpdatedAt  time.Time `firestore:"updatedAt"`
+
+	NextAttemptAt   *time.Time `firestore:"nextAttemptAt,omitempty"`
+	ProcessingUntil *time.Time `firestore:"processingUntil,omitempty"`
+	PublishedAt     *time.Time `firestore:"publishedAt,omitempty"`
+	FailedAt        *time.Time `firestore:"failedAt,omitempty"`
+}
+
+// createOutboxEventsTx は集約の transaction 内でイベントを作成します。
+// 集約の repository は読み取りをすべて終えた後に呼び出してください。
+func createOutboxEventsTx(
+	client *firestore.Client,
+	tx *firestore.Transaction,
+	events []outboxdom.Event,
+) error {
+	for _, e := range events {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+
+		ref := client.Collection(outboxEventsCollectionName).Doc(e.ID)
+		if err := tx.Create(ref, outboxEventToDocument(e)); err != nil {
+			return fmt.Errorf("create outbox event %q: %w", e.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *OutboxRepositoryFS) GetByID(
+	ctx context.Context,
+	id string,
+) (outboxdom.Event, error) {
+	if r == nil || r.Client == nil {
+		return outboxdom.Event{}, ErrOutboxRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return outboxdom.Event{}, outboxdom.ErrInvalidID
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return outboxdom.Event{}, outboxdom.ErrNotFound
+		}
+		return outboxdom.Event{}, err
+	}
+
+	return readOutboxEventSnapshot(snap)
+}
+
+func (r *OutboxRepositoryFS) ListDue(
+	ctx context.Context,
+	types []outboxdom.EventType,
+	now time.Time,
+	limit int,
+) ([]outboxdom.Event, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrOutboxRepositoryNotConfigured
+	}
+
+	if len(types) == 0 {
+		return []outboxdom.Event{}, nil
+	}
+
+	if limit <= 0 {
+		limit = defaultOutboxListDueLimit
+	}
+
+	now = now.UTC()
+
+	wanted := make(map[outboxdom.EventType]struct{}, len(types))
+	for _, t := range types {
+		wanted[t] = struct{}{}
+	}
+
+	iter := r.col().Where(
+		"status",
+		"in",
+		[]string{
+			string(outboxdom.StatusPending),
+			string(outboxdom.StatusProcessing),
+		},
+	).Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]outboxdom.Event, 0)
+
+	// 型・期限は composite index を増やさないようにメモリ上で絞り込む。
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list due outbox events: %w", err)
+		}
+
+		e, err := readOutboxEventSnapshot(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := wanted[e.Type]; !ok {
+			continue
+		}
+		if e.Attempts >= e.MaxAttempts || !e.IsDue(now) {
+			continue
+		}
+
+		out = append(out, e)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].OccurredAt.Before(out[j].OccurredAt)
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+func (r *OutboxRepositoryFS) ListPendingByAggregate(
+	ctx context.Context,
+	aggregateType outboxdom.AggregateType,
+	aggregateID string,
+) ([]outboxdom.Event, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrOutboxRepositoryNotConfigured
+	}
+
+	aggregateID = strings.TrimSpace(aggregateID)
+	if aggregateID == "" {
+		return nil, outboxdom.ErrInvalidAggregateID
+	}
+
+	iter := r.col().Where("aggregateId", "==", aggregateID).Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]outboxdom.Event, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list outbox events by aggregate: %w", err)
+		}
+
+		e, err := readOutboxEventSnapshot(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.AggregateType != aggregateType ||
+			e.Status != outboxdom.StatusPending {
+			continue
+		}
+
+		out = append(out, e)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].OccurredAt.Before(out[j].OccurredAt)
+	})
+
+	return out, nil
+}
+
+func (r *OutboxRepositoryFS) Claim(
+	ctx context.Context,
+	id string,
+	now time.Time,
+	processingUntil time.Time,
+) (outboxdom.Event, error) {
+	var claimed outboxdom.Event
+
+	err := r.update(ctx, id, func(e outboxdom.Event) (outboxdom.Event, error) {
+		next, err := e.Claim(now, processingUntil)
+		if err != nil {
+			return outboxdom.Event{}, err
+		}
+
+		claimed = next
+		return next, nil
+	})
+	if err != nil {
+		return outboxdom.Event{}, err
+	}
+
+	return claimed, nil
+}
+
+func (r *OutboxRepositoryFS) MarkPublished(
+	ctx context.Context,
+	id string,
+	expectedAttempts int,
+	now time.Time,
+) error {
+	return r.update(ctx, id, func(e outboxdom.Event) (outboxdom.Event, error) {
+		if e.Attempts != expectedAttempts {
+			return outboxdom.Event{}, outboxdom.ErrNotClaimable
+		}
+		return e.MarkPublished(now)
+	})
+}
+
+func (r *OutboxRepositoryFS) MarkRetry(
+	ctx context.Context,
+	id string,
+	expectedAttempts int,
+	lastError string,
+	nextAttemptAt time.Time,
+	now time.Time,
+) error {
+	return r.update(ctx, id, func(e outboxdom.Event) (outboxdom.Event, error) {
+		if e.Attempts != expectedAttempts {
+			return outboxdom.Event{}, outboxdom.ErrNotClaimable
+		}
+		return e.MarkRetry(lastError, nextAttemptAt, now)
+	})
+}
+
+// update は 1 件のイベントを transaction 内で読み取り、fn の結果で置き換えます。
+func (r *OutboxRepositoryFS) update(
+	ctx context.Context,
+	id string,
+	fn func(outboxdom.Event) (outboxdom.Event, error),
+) error {
+	if r == nil || r.Client == nil {
+		return ErrOutboxRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return outboxdom.ErrInvalidID
+	}
+
+	ref := r.col().Doc(id)
+
+	return r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			snap, err := tx.Get(ref)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return outboxdom.ErrNotFound
+				}
+				return err
+			}
+
+			current, err := readOutboxEventSnapshot(snap)
+			if err != nil {
+				return err
+			}
+
+			next, err := fn(current)
+			if err != nil {
+				return err
+			}
+
+			return tx.Set(ref, outboxEventToDocument(next))
+		},
+	)
+}
+
+func outboxEventToDocument(e outboxdom.Event) outboxEventDocument {
+	return outboxEventDocument{
+		Type: string(e.Type),
+
+		AggregateType: string(e.AggregateType),
+		AggregateID:   e.AggregateID,
+
+		Payload: string(e.Payload),
+
+		Status:      string(e.Status),
+		Attempts:    e.Attempts,
+		MaxAttempts: e.MaxAttempts,
+		LastError:   e.LastError,
+
+		OccurredAt: e.OccurredAt.UTC(),
+		UpdatedAt:  e.UpdatedAt.UTC(),
+
+		NextAttemptAt:   utcTimePointer(e.NextAttemptAt),
+		ProcessingUntil: utcTimePointer(e.ProcessingUntil),
+		PublishedAt:     utcTimePointer(e.PublishedAt),
+		FailedAt:        utcTimePointer(e.FailedAt),
+	}
+}
+
+func readOutboxEventSnapshot(
+	snap *firestore.DocumentSnapshot,
+) (outboxdom.Event, error) {
+	var doc outboxEventDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return outboxdom.Event{}, fmt.Errorf(
+			"decode outbox event %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return outboxdom.Event{
+		ID:   snap.Ref.ID,
+		Type: outboxdom.EventType(doc.Type),
+
+		AggregateType: outboxdom.AggregateType(doc.AggregateType),
+		AggregateID:   doc.AggregateID,
+
+		Payload: []byte(doc.Payload),
+
+		Status:      outboxdom.Status(doc.Status),
+		Attempts:    doc.Attempts,
+		MaxAttempts: doc.MaxAttempts,
+		LastError:   doc.LastError,
+
+		OccurredAt: doc.OccurredAt.UTC(),
+		UpdatedAt:  doc.UpdatedAt.UTC(),
+
+		NextAttemptAt:   utcTimePointer(doc.NextAttemptAt),
+		ProcessingUntil: utcTimePointer(doc.ProcessingUntil),
+		PublishedAt:     utcTimePointer(doc.PublishedAt),
+		FailedAt:        utcTimePointer(doc.FailedAt),
+	}, nil
+}
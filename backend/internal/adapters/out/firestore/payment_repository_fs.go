@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	usecase "narratives/internal/application/usecase"
+	outboxdom "narratives/internal/domain/outbox"
 	paymentdom "narratives/internal/domain/payment"
 )
 
@@ -20,12 +21,14 @@ var (
 	_ paymentdom.RepositoryPort = (*PaymentRepositoryFS)(nil)
 
 	_ usecase.StripePaymentEventRepository = (*PaymentRepositoryFS)(nil)
+	_ usecase.StripeDisputeEventRepository = (*PaymentRepositoryFS)(nil)
 )
 
 // PaymentRepositoryFS is the Firestore-based implementation of:
 //
 // - payment.RepositoryPort
 // - usecase.StripePaymentEventRepository
+// - usecase.StripeDisputeEventRepository
 //
 // Firestore design:
 //
@@ -46,6 +49,10 @@ var (
 //   - duplicate event IDs are successful no-ops
 //   - event marker creation, Payment status update, and post-paid marker
 //     acquisition occur in one Firestore Transaction
+//   - acquiring the post-paid marker also creates the PaymentSucceeded
+//     outbox event in the same Transaction
+//   - dispute events find the Payment by stripePaymentIntentId and store the
+//     latest dispute in the "dispute" map field
 type PaymentRepositoryFS struct {
 	Client *firestore.Client
 }
@@ -156,12 +163,19 @@ func (r *PaymentRepositoryFS) Create(
 
 	data := paymentToCreateData(payment)
 
-	// When a Payment is initially created as succeeded, PaymentUsecase.Create
-	// executes the post-paid processing. Store the claim marker in the same
-	// transaction as the Payment creation so that a later succeeded webhook
-	// does not execute it again.
+	// When a Payment is initially created as succeeded, the post-paid
+	// processing runs from the PaymentSucceeded outbox event. Store the claim
+	// marker and the event in the same transaction as the Payment creation so
+	// that a later succeeded webhook does not execute it again.
+	var events []outboxdom.Event
 	if payment.Status == paymentdom.StatusSucceeded {
 		data["postPaidTriggeredAt"] = createdAt
+
+		event, err := newPaymentSucceededEvent(payment.PaymentID, createdAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
 	}
 
 	err = r.Client.RunTransaction(
@@ -170,10 +184,14 @@ func (r *PaymentRepositoryFS) Create(
 			ctx context.Context,
 			transaction *firestore.Transaction,
 		) error {
-			return transaction.Create(
+			if err := transaction.Create(
 				documentReference,
 				data,
-			)
+			); err != nil {
+				return err
+			}
+
+			return createOutboxEventsTx(r.Client, transaction, events)
 		},
 	)
 	if err != nil {
@@ -496,56 +514,23 @@ func (r *PaymentRepositoryFS) ApplyStripePaymentEvent(
 					ErrInvalidStripePaymentIntent
 			}
 
-			transitionAllowed :=
-				paymentStatusTransitionAllowed(
-					current.Status,
-					in.Status,
-				)
-
+			// Stale / out-of-order events are recorded as processed but do
+			// not update the Payment (see paymentdom.CanTransition).
 			next := current
-			statusChanged := false
-
-			if transitionAllowed {
-				statusChanged =
-					current.Status != in.Status
-
-				next.Status = in.Status
-
-				switch in.Status {
-				case paymentdom.StatusFailed,
-					paymentdom.StatusCanceled:
-					next.ErrorType = in.ErrorType
-					next.ErrorCode = in.ErrorCode
-					next.ErrorMsg = in.ErrorMsg
-
-				default:
-					// A non-error Stripe state clears stale error metadata.
-					next.ErrorType = nil
-					next.ErrorCode = nil
-					next.ErrorMsg = nil
-				}
 
-				validated, validationErr :=
-					paymentdom.New(
-						next.PaymentID,
-						next.PaymentMethodID,
-						next.StripeCustomerID,
-						next.StripePaymentMethodID,
-						next.StripePaymentIntentID,
-						next.Amount,
-						next.Status,
-						next.ErrorType,
-						next.ErrorCode,
-						next.ErrorMsg,
-						next.CreatedAt,
-					)
-				if validationErr != nil {
-					return validationErr
-				}
-
-				next = validated
+			statusChanged, transitionErr := next.TransitionTo(
+				in.Status,
+				in.ErrorType,
+				in.ErrorCode,
+				in.ErrorMsg,
+			)
+			if transitionErr != nil &&
+				!errors.Is(transitionErr, paymentdom.ErrStatusTransition) {
+				return transitionErr
 			}
 
+			transitionAllowed := transitionErr == nil
+
 			postPaidMarkerExists, markerErr :=
 				paymentPostPaidMarkerExists(
 					paymentSnapshot.Data(),
@@ -653,6 +638,24 @@ func (r *PaymentRepositoryFS) ApplyStripePaymentEvent(
 				return createErr
 			}
 
+			if postPaidRequired {
+				succeeded, eventErr := newPaymentSucceededEvent(
+					in.PaymentID,
+					processedAt,
+				)
+				if eventErr != nil {
+					return eventErr
+				}
+
+				if eventErr := createOutboxEventsTx(
+					r.Client,
+					transaction,
+					[]outboxdom.Event{succeeded},
+				); eventErr != nil {
+					return eventErr
+				}
+			}
+
 			result =
 				&usecase.ApplyStripePaymentEventResult{
 					Payment:          &next,
@@ -680,75 +683,171 @@ func (r *PaymentRepositoryFS) ApplyStripePaymentEvent(
 	return result, nil
 }
 
-// ============================================================
-// Stripe status transition policy
-// ============================================================
+// ApplyStripeDisputeEvent applies a charge.dispute.* event in one Firestore
+// Transaction. The event marker shares paymentStripeEvents with the
+// PaymentIntent events.
+func (r *PaymentRepositoryFS) ApplyStripeDisputeEvent(
+	ctx context.Context,
+	in usecase.ApplyStripeDisputeEventInput,
+) (*usecase.ApplyStripeDisputeEventResult, error) {
+	if r == nil || r.Client == nil {
+		return nil, errors.New(
+			"firestore client is nil",
+		)
+	}
 
-// paymentStatusTransitionAllowed prevents stale or out-of-order Stripe
-// webhook events from regressing a terminal Payment state.
-//
-// Invalid/stale transitions are recorded as processed events but do not
-// update the Payment.
-func paymentStatusTransitionAllowed(
-	current paymentdom.PaymentStatus,
-	next paymentdom.PaymentStatus,
-) bool {
-	if current == next {
-		return true
-	}
-
-	switch current {
-	case paymentdom.StatusPending:
-		switch next {
-		case paymentdom.StatusRequiresAction,
-			paymentdom.StatusProcessing,
-			paymentdom.StatusSucceeded,
-			paymentdom.StatusFailed,
-			paymentdom.StatusCanceled:
-			return true
-		}
+	in.EventID = strings.TrimSpace(in.EventID)
+	in.StripePaymentIntentID = strings.TrimSpace(in.StripePaymentIntentID)
 
-	case paymentdom.StatusRequiresAction:
-		switch next {
-		case paymentdom.StatusPending,
-			paymentdom.StatusProcessing,
-			paymentdom.StatusSucceeded,
-			paymentdom.StatusFailed,
-			paymentdom.StatusCanceled:
-			return true
-		}
+	if in.EventID == "" {
+		return nil, usecase.ErrPaymentStripeEventIDEmpty
+	}
 
-	case paymentdom.StatusProcessing:
-		switch next {
-		case paymentdom.StatusRequiresAction,
-			paymentdom.StatusSucceeded,
-			paymentdom.StatusFailed,
-			paymentdom.StatusCanceled:
-			return true
-		}
+	if strings.Contains(in.EventID, "/") {
+		return nil, fmt.Errorf(
+			"payment: invalid Stripe event id %q",
+			in.EventID,
+		)
+	}
 
-	case paymentdom.StatusFailed:
-		// A PaymentIntent can recover after a new payment method or another
-		// confirmation attempt.
-		switch next {
-		case paymentdom.StatusPending,
-			paymentdom.StatusRequiresAction,
-			paymentdom.StatusProcessing,
-			paymentdom.StatusSucceeded,
-			paymentdom.StatusCanceled:
-			return true
-		}
+	if in.StripePaymentIntentID == "" {
+		return nil, paymentdom.ErrInvalidStripePaymentIntent
+	}
+
+	eventReference := r.stripeEventCol().Doc(in.EventID)
+	paymentQuery := r.col().
+		Where("stripePaymentIntentId", "==", in.StripePaymentIntentID).
+		Limit(1)
+
+	processedAt := time.Now().UTC()
+
+	var result *usecase.ApplyStripeDisputeEventResult
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			transaction *firestore.Transaction,
+		) error {
+			// Read all required documents before any write.
+			eventSnapshot, eventErr := transaction.Get(eventReference)
+			if eventErr != nil &&
+				status.Code(eventErr) != codes.NotFound {
+				return eventErr
+			}
+
+			paymentSnapshots, queryErr :=
+				transaction.Documents(paymentQuery).GetAll()
+			if queryErr != nil {
+				return queryErr
+			}
+			if len(paymentSnapshots) == 0 {
+				return paymentdom.ErrNotFound
+			}
+
+			current, decodeErr := docToPayment(paymentSnapshots[0])
+			if decodeErr != nil {
+				return decodeErr
+			}
+
+			// Duplicate Stripe event: successful no-op.
+			if eventErr == nil &&
+				eventSnapshot != nil &&
+				eventSnapshot.Exists() {
+				result = &usecase.ApplyStripeDisputeEventResult{
+					Payment:      &current,
+					EventApplied: false,
+				}
+
+				return nil
+			}
+
+			next := current
+
+			changed, applyErr := next.ApplyDispute(
+				paymentdom.Dispute{
+					StripeDisputeID: in.StripeDisputeID,
+					Status:          in.Status,
+					Reason:          in.Reason,
+					Amount:          in.Amount,
+					OpenedAt:        in.OpenedAt,
+					UpdatedAt:       in.OccurredAt,
+				},
+			)
+			if applyErr != nil {
+				return applyErr
+			}
+
+			if changed {
+				if updateErr := transaction.Update(
+					paymentSnapshots[0].Ref,
+					[]firestore.Update{
+						{
+							Path:  "dispute",
+							Value: paymentDisputeToData(*next.Dispute),
+						},
+						{
+							Path:  "updatedAt",
+							Value: processedAt,
+						},
+					},
+				); updateErr != nil {
+					return updateErr
+				}
+			}
+
+			if createErr := transaction.Create(
+				eventReference,
+				map[string]any{
+					"eventId":               in.EventID,
+					"paymentId":             current.PaymentID,
+					"stripePaymentIntentId": in.StripePaymentIntentID,
+					"stripeDisputeId":       in.StripeDisputeID,
+					"disputeStatus":         string(in.Status),
+					"disputeChanged":        changed,
+					"occurredAt":            in.OccurredAt,
+					"processedAt":           processedAt,
+				},
+			); createErr != nil {
+				return createErr
+			}
 
-	case paymentdom.StatusSucceeded:
-		// succeeded is terminal.
-		return false
+			result = &usecase.ApplyStripeDisputeEventResult{
+				Payment:        &next,
+				EventApplied:   true,
+				DisputeChanged: changed,
+			}
 
-	case paymentdom.StatusCanceled:
-		// canceled is terminal.
-		return false
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return false
+	if result == nil || result.Payment == nil {
+		return nil,
+			usecase.ErrPaymentStripeEventResultEmpty
+	}
+
+	return result, nil
+}
+
+// newPaymentSucceededEvent は post-paid marker と一緒に保存する
+// PaymentSucceeded イベントを作成します。
+func newPaymentSucceededEvent(
+	paymentID string,
+	at time.Time,
+) (outboxdom.Event, error) {
+	return outboxdom.NewEvent(
+		outboxdom.EventPaymentSucceeded,
+		outboxdom.AggregatePayment,
+		paymentID,
+		outboxdom.PaymentSucceededPayload{
+			PaymentID: paymentID,
+		},
+		at,
+	)
 }
 
 // ============================================================
@@ -895,9 +994,75 @@ func docToPayment(
 		return paymentdom.Payment{}, err
 	}
 
+	payment.Dispute, err = paymentDisputeFromData(data)
+	if err != nil {
+		return paymentdom.Payment{}, err
+	}
+
 	return payment, nil
 }
 
+func paymentDisputeToData(
+	dispute paymentdom.Dispute,
+) map[string]any {
+	return map[string]any{
+		"stripeDisputeId": dispute.StripeDisputeID,
+		"status":          string(dispute.Status),
+		"reason":          dispute.Reason,
+		"amount":          dispute.Amount,
+		"openedAt":        dispute.OpenedAt.UTC(),
+		"updatedAt":       dispute.UpdatedAt.UTC(),
+	}
+}
+
+func paymentDisputeFromData(
+	values map[string]any,
+) (*paymentdom.Dispute, error) {
+	raw, ok := values["dispute"].(map[string]any)
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	disputeID, err := paymentRequiredString(raw, "stripeDisputeId")
+	if err != nil {
+		return nil, err
+	}
+
+	statusText, err := paymentRequiredString(raw, "status")
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := paymentRequiredInt(raw, "amount")
+	if err != nil {
+		return nil, err
+	}
+
+	openedAt, err := paymentRequiredTime(raw, "openedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt, err := paymentRequiredTime(raw, "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	reason := ""
+	if value := paymentOptionalString(raw, "reason"); value != nil {
+		reason = *value
+	}
+
+	return &paymentdom.Dispute{
+		StripeDisputeID: disputeID,
+		Status:          paymentdom.DisputeStatus(statusText),
+		Reason:          reason,
+		Amount:          amount,
+		OpenedAt:        openedAt,
+		UpdatedAt:       updatedAt,
+	}, nil
+}
+
 // ============================================================
 // Firestore field helpers
 // ============================================================
@@ -0,0 +1,779 @@
+// backend/internal/adapters/out/firestore/payout_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	payoutdom "narratives/internal/domain/payout"
+)
+
+const (
+	payoutEntriesCollectionName    = "payoutEntries"
+	payoutBatchesCollectionName    = "payoutBatches"
+	payoutRecipientsCollectionName = "payoutRecipients"
+)
+
+var ErrPayoutRepositoryNotConfigured = errors.New(
+	"payout_repository_fs: not configured",
+)
+
+// PayoutRepositoryFS is the Firestore implementation of
+// payout.RepositoryPort.
+//
+// Firestore design:
+//
+//	payoutEntries/{source}_{sourceId}
+//	payoutBatches/{batchId}
+//	payoutRecipients/{companyId}_{recipientType}_{recipientId}
+//
+// CreateEntry uses Firestore Create so each source is accrued at most once
+// even when the post-paid / release step runs again. Batches claim their
+// entries (batchId) in the same transaction as the batch itself.
+type PayoutRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ payoutdom.RepositoryPort = (*PayoutRepositoryFS)(nil)
+
+func NewPayoutRepositoryFS(
+	client *firestore.Client,
+) *PayoutRepositoryFS {
+	return &PayoutRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *PayoutRepositoryFS) entries() *firestore.CollectionRef {
+	return r.Client.Collection(payoutEntriesCollectionName)
+}
+
+func (r *PayoutRepositoryFS) batches() *firestore.CollectionRef {
+	return r.Client.Collection(payoutBatchesCollectionName)
+}
+
+func (r *PayoutRepositoryFS) recipients() *firestore.CollectionRef {
+	return r.Client.Collection(payoutRecipientsCollectionName)
+}
+
+type payoutEntryDocument struct {
+	CompanyID string `firestore:"companyId"`
+
+	RecipientType string `firestore:"recipientType"`
+	RecipientID   string `firestore:"recipientId"`
+
+	Source   string `firestore:"source"`
+	SourceID string `firestore:"sourceId"`
+
+	OrderID   string `firestore:"orderId,omitempty"`
+	ItemIndex int    `firestore:"itemIndex"`
+
+	GrossAmount    int `firestore:"grossAmount"`
+	FeeBasisPoints int `firestore:"feeBasisPoints"`
+	FeeAmount      int `firestore:"feeAmount"`
+	NetAmount      int `firestore:"netAmount"`
+
+	AccruedAt time.Time `firestore:"accruedAt"`
+
+	BatchID string `firestore:"batchId"`
+}
+
+type payoutBatchDocument struct {
+	CompanyID string `firestore:"companyId"`
+
+	CutoffAt     time.Time `firestore:"cutoffAt"`
+	TransferDate time.Time `firestore:"transferDate"`
+
+	Status string `firestore:"status"`
+
+	Lines []payoutLineDocument `firestore:"lines"`
+
+	TotalAmount int `firestore:"totalAmount"`
+	EntryCount  int `firestore:"entryCount"`
+
+	CreatedBy string    `firestore:"createdBy"`
+	CreatedAt time.Time `firestore:"createdAt"`
+
+	ApprovedBy string     `firestore:"approvedBy,omitempty"`
+	ApprovedAt *time.Time `firestore:"approvedAt,omitempty"`
+
+	ExportedAt  *time.Time `firestore:"exportedAt,omitempty"`
+	PaidAt      *time.Time `firestore:"paidAt,omitempty"`
+	CancelledAt *time.Time `firestore:"cancelledAt,omitempty"`
+
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+type payoutLineDocument struct {
+	RecipientType string `firestore:"recipientType"`
+	RecipientID   string `firestore:"recipientId"`
+
+	AccountID      string `firestore:"accountId"`
+	BankCode       string `firestore:"bankCode"`
+	BankName       string `firestore:"bankName"`
+	BranchCode     string `firestore:"branchCode"`
+	BranchName     string `firestore:"branchName"`
+	AccountType    string `firestore:"accountType"`
+	AccountNumber  string `firestore:"accountNumber"`
+	HolderNameKana string `firestore:"holderNameKana"`
+
+	Amount int `firestore:"amount"`
+
+	EntryIDs []string `firestore:"entryIds"`
+}
+
+type payoutRecipientDocument struct {
+	CompanyID string `firestore:"companyId"`
+
+	RecipientType string `firestore:"recipientType"`
+	RecipientID   string `firestore:"recipientId"`
+
+	AccountID string `firestore:"accountId"`
+
+	UpdatedAt time.Time `firestore:"updatedAt"`
+	UpdatedBy string    `firestore:"updatedBy,omitempty"`
+}
+
+// ============================================================
+// Entries
+// ============================================================
+
+func (r *PayoutRepositoryFS) CreateEntry(
+	ctx context.Context,
+	e payoutdom.Entry,
+) (payoutdom.Entry, error) {
+	if r == nil || r.Client == nil {
+		return payoutdom.Entry{}, ErrPayoutRepositoryNotConfigured
+	}
+
+	if err := e.Validate(); err != nil {
+		return payoutdom.Entry{}, err
+	}
+
+	if _, err := r.entries().Doc(e.ID).Create(
+		ctx,
+		payoutEntryToDocument(e),
+	); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return payoutdom.Entry{}, payoutdom.ErrConflict
+		}
+
+		return payoutdom.Entry{}, err
+	}
+
+	return e, nil
+}
+
+func (r *PayoutRepositoryFS) GetEntry(
+	ctx context.Context,
+	id string,
+) (payoutdom.Entry, error) {
+	if r == nil || r.Client == nil {
+		return payoutdom.Entry{}, ErrPayoutRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return payoutdom.Entry{}, payoutdom.ErrInvalidID
+	}
+
+	snap, err := r.entries().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return payoutdom.Entry{}, payoutdom.ErrNotFound
+		}
+		return payoutdom.Entry{}, err
+	}
+
+	return docToPayoutEntry(snap)
+}
+
+func (r *PayoutRepositoryFS) ListEntriesByCompanyID(
+	ctx context.Context,
+	companyID string,
+	filter payoutdom.EntryFilter,
+) ([]payoutdom.Entry, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrPayoutRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, payoutdom.ErrInvalidCompanyID
+	}
+
+	iter := r.entries().Where("companyId", "==", companyID).Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]payoutdom.Entry, 0)
+
+	// 受取先・バッチ・計上日時は composite index を増やさないようにメモリ上で絞り込む。
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := docToPayoutEntry(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.RecipientType != "" && e.RecipientType != filter.RecipientType {
+			continue
+		}
+		if filter.RecipientID != "" && e.RecipientID != filter.RecipientID {
+			continue
+		}
+		if filter.Unbatched && e.IsBatched() {
+			continue
+		}
+		if filter.BatchID != "" && e.BatchID != filter.BatchID {
+			continue
+		}
+		if filter.AccruedBefore != nil && !e.AccruedAt.Before(*filter.AccruedBefore) {
+			continue
+		}
+
+		out = append(out, e)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].AccruedAt.Before(out[j].AccruedAt)
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// Batches
+// ============================================================
+
+func (r *PayoutRepositoryFS) CreateBatch(
+	ctx context.Context,
+	b payoutdom.Batch,
+) (payoutdom.Batch, error) {
+	if r == nil || r.Client == nil {
+		return payoutdom.Batch{}, ErrPayoutRepositoryNotConfigured
+	}
+
+	if err := b.Validate(); err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	batchRef := r.batches().Doc(b.ID)
+
+	entryRefs := make([]*firestore.DocumentRef, 0, b.EntryCount)
+	for _, id := range b.EntryIDs() {
+		entryRefs = append(entryRefs, r.entries().Doc(id))
+	}
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snaps, err := tx.GetAll(entryRefs)
+		if err != nil {
+			return err
+		}
+
+		// 別のバッチ作成と同時に実行された場合、同じ台帳を二重に支払わない。
+		for _, snap := range snaps {
+			e, err := docToPayoutEntry(snap)
+			if err != nil {
+				if errors.Is(err, payoutdom.ErrNotFound) {
+					return payoutdom.ErrConflict
+				}
+				return err
+			}
+			if e.IsBatched() || e.CompanyID != b.CompanyID {
+				return payoutdom.ErrConflict
+			}
+		}
+
+		if err := tx.Create(batchRef, payoutBatchToDocument(b)); err != nil {
+			return err
+		}
+
+		for _, ref := range entryRefs {
+			if err := tx.Update(ref, []firestore.Update{
+				{Path: "batchId", Value: b.ID},
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return payoutdom.Batch{}, payoutdom.ErrConflict
+		}
+		return payoutdom.Batch{}, err
+	}
+
+	return b, nil
+}
+
+func (r *PayoutRepositoryFS) GetBatch(
+	ctx context.Context,
+	id string,
+) (payoutdom.Batch, error) {
+	if r == nil || r.Client == nil {
+		return payoutdom.Batch{}, ErrPayoutRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return payoutdom.Batch{}, payoutdom.ErrInvalidBatchID
+	}
+
+	snap, err := r.batches().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return payoutdom.Batch{}, payoutdom.ErrNotFound
+		}
+		return payoutdom.Batch{}, err
+	}
+
+	return docToPayoutBatch(snap)
+}
+
+func (r *PayoutRepositoryFS) UpdateBatch(
+	ctx context.Context,
+	b payoutdom.Batch,
+	prevUpdatedAt time.Time,
+) (payoutdom.Batch, error) {
+	if r == nil || r.Client == nil {
+		return payoutdom.Batch{}, ErrPayoutRepositoryNotConfigured
+	}
+
+	if err := b.Validate(); err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	ref := r.batches().Doc(b.ID)
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return payoutdom.ErrNotFound
+			}
+			return err
+		}
+
+		current, err := docToPayoutBatch(snap)
+		if err != nil {
+			return err
+		}
+
+		// Firestore の timestamp はマイクロ秒精度のため揃えて比較する。
+		if !current.UpdatedAt.Truncate(time.Microsecond).Equal(
+			prevUpdatedAt.UTC().Truncate(time.Microsecond),
+		) {
+			return payoutdom.ErrConflict
+		}
+
+		if err := tx.Set(ref, payoutBatchToDocument(b)); err != nil {
+			return err
+		}
+
+		// 取り消したバッチの台帳は次のバッチの対象に戻す。
+		if b.Status == payoutdom.BatchStatusCancelled &&
+			current.Status != payoutdom.BatchStatusCancelled {
+			for _, id := range current.EntryIDs() {
+				if err := tx.Update(r.entries().Doc(id), []firestore.Update{
+					{Path: "batchId", Value: ""},
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	return b, nil
+}
+
+func (r *PayoutRepositoryFS) ListBatchesByCompanyID(
+	ctx context.Context,
+	companyID string,
+	st payoutdom.BatchStatus,
+) ([]payoutdom.Batch, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrPayoutRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, payoutdom.ErrInvalidCompanyID
+	}
+
+	q := r.batches().Where("companyId", "==", companyID)
+	if st != "" {
+		if !payoutdom.IsValidBatchStatus(st) {
+			return nil, payoutdom.ErrInvalidBatchStatus
+		}
+		q = q.Where("status", "==", string(st))
+	}
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]payoutdom.Batch, 0)
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := docToPayoutBatch(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, b)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// Recipients
+// ============================================================
+
+func (r *PayoutRepositoryFS) GetRecipient(
+	ctx context.Context,
+	id string,
+) (payoutdom.Recipient, error) {
+	if r == nil || r.Client == nil {
+		return payoutdom.Recipient{}, ErrPayoutRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return payoutdom.Recipient{}, payoutdom.ErrInvalidID
+	}
+
+	snap, err := r.recipients().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return payoutdom.Recipient{}, payoutdom.ErrNotFound
+		}
+		return payoutdom.Recipient{}, err
+	}
+
+	return docToPayoutRecipient(snap)
+}
+
+func (r *PayoutRepositoryFS) SetRecipient(
+	ctx context.Context,
+	rec payoutdom.Recipient,
+) (payoutdom.Recipient, error) {
+	if r == nil || r.Client == nil {
+		return payoutdom.Recipient{}, ErrPayoutRepositoryNotConfigured
+	}
+
+	if err := rec.Validate(); err != nil {
+		return payoutdom.Recipient{}, err
+	}
+
+	if _, err := r.recipients().Doc(rec.ID).Set(ctx, payoutRecipientDocument{
+		CompanyID: rec.CompanyID,
+
+		RecipientType: string(rec.RecipientType),
+		RecipientID:   rec.RecipientID,
+
+		AccountID: rec.AccountID,
+
+		UpdatedAt: rec.UpdatedAt.UTC(),
+		UpdatedBy: rec.UpdatedBy,
+	}); err != nil {
+		return payoutdom.Recipient{}, err
+	}
+
+	return rec, nil
+}
+
+func (r *PayoutRepositoryFS) ListRecipientsByCompanyID(
+	ctx context.Context,
+	companyID string,
+) ([]payoutdom.Recipient, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrPayoutRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, payoutdom.ErrInvalidCompanyID
+	}
+
+	iter := r.recipients().Where("companyId", "==", companyID).Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]payoutdom.Recipient, 0)
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rec, err := docToPayoutRecipient(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, rec)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// Mapping
+// ============================================================
+
+func payoutEntryToDocument(
+	e payoutdom.Entry,
+) payoutEntryDocument {
+	return payoutEntryDocument{
+		CompanyID: e.CompanyID,
+
+		RecipientType: string(e.RecipientType),
+		RecipientID:   e.RecipientID,
+
+		Source:   string(e.Source),
+		SourceID: e.SourceID,
+
+		OrderID:   e.OrderID,
+		ItemIndex: e.ItemIndex,
+
+		GrossAmount:    e.GrossAmount,
+		FeeBasisPoints: e.FeeBasisPoints,
+		FeeAmount:      e.FeeAmount,
+		NetAmount:      e.NetAmount,
+
+		AccruedAt: e.AccruedAt.UTC(),
+
+		BatchID: e.BatchID,
+	}
+}
+
+func docToPayoutEntry(
+	snap *firestore.DocumentSnapshot,
+) (payoutdom.Entry, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return payoutdom.Entry{}, payoutdom.ErrNotFound
+	}
+
+	var doc payoutEntryDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return payoutdom.Entry{}, fmt.Errorf(
+			"decode payout entry %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return payoutdom.Entry{
+		ID: snap.Ref.ID,
+
+		CompanyID: doc.CompanyID,
+
+		RecipientType: payoutdom.RecipientType(doc.RecipientType),
+		RecipientID:   doc.RecipientID,
+
+		Source:   payoutdom.Source(doc.Source),
+		SourceID: doc.SourceID,
+
+		OrderID:   doc.OrderID,
+		ItemIndex: doc.ItemIndex,
+
+		GrossAmount:    doc.GrossAmount,
+		FeeBasisPoints: doc.FeeBasisPoints,
+		FeeAmount:      doc.FeeAmount,
+		NetAmount:      doc.NetAmount,
+
+		AccruedAt: doc.AccruedAt.UTC(),
+
+		BatchID: doc.BatchID,
+	}, nil
+}
+
+func payoutBatchToDocument(
+	b payoutdom.Batch,
+) payoutBatchDocument {
+	lines := make([]payoutLineDocument, 0, len(b.Lines))
+	for _, line := range b.Lines {
+		lines = append(lines, payoutLineDocument{
+			RecipientType: string(line.RecipientType),
+			RecipientID:   line.RecipientID,
+
+			AccountID:      line.Account.AccountID,
+			BankCode:       line.Account.BankCode,
+			BankName:       line.Account.BankName,
+			BranchCode:     line.Account.BranchCode,
+			BranchName:     line.Account.BranchName,
+			AccountType:    line.Account.AccountType,
+			AccountNumber:  line.Account.AccountNumber,
+			HolderNameKana: line.Account.HolderNameKana,
+
+			Amount: line.Amount,
+
+			EntryIDs: line.EntryIDs,
+		})
+	}
+
+	return payoutBatchDocument{
+		CompanyID: b.CompanyID,
+
+		CutoffAt:     b.CutoffAt.UTC(),
+		TransferDate: b.TransferDate.UTC(),
+
+		Status: string(b.Status),
+
+		Lines: lines,
+
+		TotalAmount: b.TotalAmount,
+		EntryCount:  b.EntryCount,
+
+		CreatedBy: b.CreatedBy,
+		CreatedAt: b.CreatedAt.UTC(),
+
+		ApprovedBy: b.ApprovedBy,
+		ApprovedAt: utcTimePtr(b.ApprovedAt),
+
+		ExportedAt:  utcTimePtr(b.ExportedAt),
+		PaidAt:      utcTimePtr(b.PaidAt),
+		CancelledAt: utcTimePtr(b.CancelledAt),
+
+		UpdatedAt: b.UpdatedAt.UTC(),
+	}
+}
+
+func docToPayoutBatch(
+	snap *firestore.DocumentSnapshot,
+) (payoutdom.Batch, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return payoutdom.Batch{}, payoutdom.ErrNotFound
+	}
+
+	var doc payoutBatchDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return payoutdom.Batch{}, fmt.Errorf(
+			"decode payout batch %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	lines := make([]payoutdom.Line, 0, len(doc.Lines))
+	for _, line := range doc.Lines {
+		lines = append(lines, payoutdom.Line{
+			RecipientType: payoutdom.RecipientType(line.RecipientType),
+			RecipientID:   line.RecipientID,
+
+			Account: payoutdom.BankAccount{
+				AccountID:      line.AccountID,
+				BankCode:       line.BankCode,
+				BankName:       line.BankName,
+				BranchCode:     line.BranchCode,
+				BranchName:     line.BranchName,
+				AccountType:    line.AccountType,
+				AccountNumber:  line.AccountNumber,
+				HolderNameKana: line.HolderNameKana,
+			},
+
+			Amount: line.Amount,
+
+			EntryIDs: line.EntryIDs,
+		})
+	}
+
+	return payoutdom.Batch{
+		ID: snap.Ref.ID,
+
+		CompanyID: doc.CompanyID,
+
+		CutoffAt:     doc.CutoffAt.UTC(),
+		TransferDate: doc.TransferDate.UTC(),
+
+		Status: payoutdom.BatchStatus(doc.Status),
+
+		Lines: lines,
+
+		TotalAmount: doc.TotalAmount,
+		EntryCount:  doc.EntryCount,
+
+		CreatedBy: doc.CreatedBy,
+		CreatedAt: doc.CreatedAt.UTC(),
+
+		ApprovedBy: doc.ApprovedBy,
+		ApprovedAt: utcTimePtr(doc.ApprovedAt),
+
+		ExportedAt:  utcTimePtr(doc.ExportedAt),
+		PaidAt:      utcTimePtr(doc.PaidAt),
+		CancelledAt: utcTimePtr(doc.CancelledAt),
+
+		UpdatedAt: doc.UpdatedAt.UTC(),
+	}, nil
+}
+
+func docToPayoutRecipient(
+	snap *firestore.DocumentSnapshot,
+) (payoutdom.Recipient, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return payoutdom.Recipient{}, payoutdom.ErrNotFound
+	}
+
+	var doc payoutRecipientDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return payoutdom.Recipient{}, fmt.Errorf(
+			"decode payout recipient %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return payoutdom.Recipient{
+		ID: snap.Ref.ID,
+
+		CompanyID: doc.CompanyID,
+
+		RecipientType: payoutdom.RecipientType(doc.RecipientType),
+		RecipientID:   doc.RecipientID,
+
+		AccountID: doc.AccountID,
+
+		UpdatedAt: doc.UpdatedAt.UTC(),
+		UpdatedBy: doc.UpdatedBy,
+	}, nil
+}
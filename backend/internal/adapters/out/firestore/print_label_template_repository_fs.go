@@ -0,0 +1,289 @@
+// backend/internal/adapters/out/firestore/print_label_template_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	printdom "narratives/internal/domain/print"
+)
+
+const printLabelTemplatesCollectionName = "printLabelTemplates"
+
+var ErrPrintLabelTemplateRepositoryNotConfigured = errors.New(
+	"print_label_template_repository_fs: not configured",
+)
+
+// PrintLabelTemplateRepositoryFS is the Firestore implementation of
+// print.LabelTemplateRepositoryPort.
+//
+// Firestore design:
+//
+//	printLabelTemplates/{templateId}  (companyId で絞り込み)
+type PrintLabelTemplateRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ printdom.LabelTemplateRepositoryPort = (*PrintLabelTemplateRepositoryFS)(nil)
+
+func NewPrintLabelTemplateRepositoryFS(client *firestore.Client) *PrintLabelTemplateRepositoryFS {
+	return &PrintLabelTemplateRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *PrintLabelTemplateRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(printLabelTemplatesCollectionName)
+}
+
+type printLabelTemplateDocument struct {
+	CompanyID string `firestore:"companyId"`
+	Name      string `firestore:"name"`
+
+	LabelWidthMM  float64 `firestore:"labelWidthMm"`
+	LabelHeightMM float64 `firestore:"labelHeightMm"`
+	Columns       int     `firestore:"columns"`
+	Rows          int     `firestore:"rows"`
+
+	MarginTopMM  float64 `firestore:"marginTopMm"`
+	MarginLeftMM float64 `firestore:"marginLeftMm"`
+	GapXMM       float64 `firestore:"gapXMm"`
+	GapYMM       float64 `firestore:"gapYMm"`
+	PaddingMM    float64 `firestore:"paddingMm"`
+	QRSizeMM     float64 `firestore:"qrSizeMm"`
+
+	ShowBrandLogo   bool `firestore:"showBrandLogo"`
+	ShowProductName bool `firestore:"showProductName"`
+	ShowModelSize   bool `firestore:"showModelSize"`
+	ShowModelColor  bool `firestore:"showModelColor"`
+	ShowProductID   bool `firestore:"showProductId"`
+	ShowBorder      bool `firestore:"showBorder"`
+
+	CreatedAt time.Time `firestore:"createdAt"`
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+func (r *PrintLabelTemplateRepositoryFS) ListByCompanyID(
+	ctx context.Context,
+	companyID string,
+) ([]printdom.LabelTemplate, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrPrintLabelTemplateRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return []printdom.LabelTemplate{}, nil
+	}
+
+	iter := r.col().
+		Where("companyId", "==", companyID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]printdom.LabelTemplate, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := decodePrintLabelTemplateSnapshot(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, t)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].ID < out[j].ID
+	})
+
+	return out, nil
+}
+
+func (r *PrintLabelTemplateRepositoryFS) GetByID(
+	ctx context.Context,
+	id string,
+) (printdom.LabelTemplate, error) {
+	if r == nil || r.Client == nil {
+		return printdom.LabelTemplate{}, ErrPrintLabelTemplateRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return printdom.LabelTemplate{}, printdom.ErrInvalidLabelTemplateID
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return printdom.LabelTemplate{}, printdom.ErrLabelTemplateNotFound
+		}
+		return printdom.LabelTemplate{}, err
+	}
+
+	return decodePrintLabelTemplateSnapshot(snap)
+}
+
+func (r *PrintLabelTemplateRepositoryFS) Create(
+	ctx context.Context,
+	t printdom.LabelTemplate,
+) (printdom.LabelTemplate, error) {
+	if r == nil || r.Client == nil {
+		return printdom.LabelTemplate{}, ErrPrintLabelTemplateRepositoryNotConfigured
+	}
+
+	ref := r.col().NewDoc()
+	if _, err := ref.Create(ctx, printLabelTemplateToDocument(t)); err != nil {
+		return printdom.LabelTemplate{}, err
+	}
+
+	t.ID = ref.ID
+	return t, nil
+}
+
+func (r *PrintLabelTemplateRepositoryFS) Update(
+	ctx context.Context,
+	t printdom.LabelTemplate,
+) (printdom.LabelTemplate, error) {
+	if r == nil || r.Client == nil {
+		return printdom.LabelTemplate{}, ErrPrintLabelTemplateRepositoryNotConfigured
+	}
+
+	id := strings.TrimSpace(t.ID)
+	if id == "" {
+		return printdom.LabelTemplate{}, printdom.ErrInvalidLabelTemplateID
+	}
+
+	ref := r.col().Doc(id)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			if _, err := tx.Get(ref); err != nil {
+				if status.Code(err) == codes.NotFound {
+					return printdom.ErrLabelTemplateNotFound
+				}
+				return err
+			}
+
+			return tx.Set(ref, printLabelTemplateToDocument(t))
+		},
+	)
+	if err != nil {
+		return printdom.LabelTemplate{}, err
+	}
+
+	return t, nil
+}
+
+func (r *PrintLabelTemplateRepositoryFS) Delete(
+	ctx context.Context,
+	id string,
+) error {
+	if r == nil || r.Client == nil {
+		return ErrPrintLabelTemplateRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return printdom.ErrInvalidLabelTemplateID
+	}
+
+	if _, err := r.col().Doc(id).Delete(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return printdom.ErrLabelTemplateNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func printLabelTemplateToDocument(t printdom.LabelTemplate) printLabelTemplateDocument {
+	return printLabelTemplateDocument{
+		CompanyID: t.CompanyID,
+		Name:      t.Name,
+
+		LabelWidthMM:  t.LabelWidthMM,
+		LabelHeightMM: t.LabelHeightMM,
+		Columns:       t.Columns,
+		Rows:          t.Rows,
+
+		MarginTopMM:  t.MarginTopMM,
+		MarginLeftMM: t.MarginLeftMM,
+		GapXMM:       t.GapXMM,
+		GapYMM:       t.GapYMM,
+		PaddingMM:    t.PaddingMM,
+		QRSizeMM:     t.QRSizeMM,
+
+		ShowBrandLogo:   t.ShowBrandLogo,
+		ShowProductName: t.ShowProductName,
+		ShowModelSize:   t.ShowModelSize,
+		ShowModelColor:  t.ShowModelColor,
+		ShowProductID:   t.ShowProductID,
+		ShowBorder:      t.ShowBorder,
+
+		CreatedAt: t.CreatedAt.UTC(),
+		UpdatedAt: t.UpdatedAt.UTC(),
+	}
+}
+
+func decodePrintLabelTemplateSnapshot(
+	snap *firestore.DocumentSnapshot,
+) (printdom.LabelTemplate, error) {
+	var doc printLabelTemplateDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return printdom.LabelTemplate{}, fmt.Errorf(
+			"decode print label template %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return printdom.LabelTemplate{
+		ID:        snap.Ref.ID,
+		CompanyID: doc.CompanyID,
+		Name:      doc.Name,
+
+		LabelWidthMM:  doc.LabelWidthMM,
+		LabelHeightMM: doc.LabelHeightMM,
+		Columns:       doc.Columns,
+		Rows:          doc.Rows,
+
+		MarginTopMM:  doc.MarginTopMM,
+		MarginLeftMM: doc.MarginLeftMM,
+		GapXMM:       doc.GapXMM,
+		GapYMM:       doc.GapYMM,
+		PaddingMM:    doc.PaddingMM,
+		QRSizeMM:     doc.QRSizeMM,
+
+		ShowBrandLogo:   doc.ShowBrandLogo,
+		ShowProductName: doc.ShowProductName,
+		ShowModelSize:   doc.ShowModelSize,
+		ShowModelColor:  doc.ShowModelColor,
+		ShowProductID:   doc.ShowProductID,
+		ShowBorder:      doc.ShowBorder,
+
+		CreatedAt: doc.CreatedAt.UTC(),
+		UpdatedAt: doc.UpdatedAt.UTC(),
+	}, nil
+}
@@ -315,7 +315,8 @@ func (r *ProductBlueprintRepositoryFS) Update(ctx context.Context, id string, pa
 		if err != nil {
 			return err
 		}
-		if !productBlueprint.CanModify() {
+		// 税関情報だけの更新は印刷済みでも許可する。
+		if !productBlueprint.CanModify() && !patch.IsCustomsOnly() {
 			return pbdom.ErrForbidden
 		}
 
@@ -385,6 +386,11 @@ func (r *ProductBlueprintRepositoryFS) Update(ctx context.Context, id string, pa
 				return err
 			}
 		}
+		if patch.Customs != nil {
+			if err := productBlueprint.UpdateCustoms(*patch.Customs, now, patch.UpdatedBy); err != nil {
+				return err
+			}
+		}
 
 		if err := productBlueprint.ValidateCategoryFields(validateProductBlueprintCategoryFields); err != nil {
 			return err
@@ -554,6 +560,7 @@ type productBlueprintDoc struct {
 	ProductIDTagType             string                        `firestore:"productIdTagType"`
 	AssigneeID                   string                        `firestore:"assigneeId"`
 	ModelRefs                    []productBlueprintModelRefDoc `firestore:"modelRefs"`
+	Customs                      *productBlueprintCustomsDoc   `firestore:"customs"`
 	Printed                      *bool                         `firestore:"printed"`
 	CreatedBy                    *string                       `firestore:"createdBy"`
 	CreatedAt                    time.Time                     `firestore:"createdAt"`
@@ -561,6 +568,13 @@ type productBlueprintDoc struct {
 	UpdatedAt                    time.Time                     `firestore:"updatedAt"`
 }
 
+type productBlueprintCustomsDoc struct {
+	HSCode        string `firestore:"hsCode"`
+	Description   string `firestore:"description"`
+	OriginCountry string `firestore:"originCountry"`
+	DeclaredValue int64  `firestore:"declaredValue"`
+}
+
 type productBlueprintModelRefDoc struct {
 	ModelID      string `firestore:"modelId"`
 	DisplayOrder int64  `firestore:"displayOrder"`
@@ -601,6 +615,7 @@ func docToProductBlueprint(document *firestore.DocumentSnapshot) (pbdom.ProductB
 		ProductIdTag:                 pbdom.ProductIDTag{Type: pbdom.ProductIDTagType(stored.ProductIDTagType)},
 		AssigneeID:                   stored.AssigneeID,
 		ModelRefs:                    modelRefs,
+		Customs:                      productBlueprintCustomsFromDoc(stored.Customs),
 		Printed:                      *stored.Printed,
 		CreatedBy:                    stored.CreatedBy,
 		CreatedAt:                    stored.CreatedAt,
@@ -618,6 +633,18 @@ func docToProductBlueprint(document *firestore.DocumentSnapshot) (pbdom.ProductB
 	return productBlueprint, nil
 }
 
+func productBlueprintCustomsFromDoc(stored *productBlueprintCustomsDoc) pbdom.CustomsInfo {
+	if stored == nil {
+		return pbdom.CustomsInfo{}
+	}
+	return pbdom.CustomsInfo{
+		HSCode:        stored.HSCode,
+		Description:   stored.Description,
+		OriginCountry: stored.OriginCountry,
+		DeclaredValue: stored.DeclaredValue,
+	}
+}
+
 func productBlueprintModelRefsFromDoc(stored []productBlueprintModelRefDoc) ([]pbdom.ModelRef, error) {
 	if stored == nil {
 		return nil, nil
@@ -669,6 +696,14 @@ func productBlueprintToDoc(productBlueprint pbdom.ProductBlueprint) (map[string]
 	if productBlueprint.ModelRefs != nil {
 		document["modelRefs"] = modelRefsToDoc(productBlueprint.ModelRefs)
 	}
+	if !productBlueprint.Customs.IsZero() {
+		document["customs"] = map[string]any{
+			"hsCode":        productBlueprint.Customs.HSCode,
+			"description":   productBlueprint.Customs.Description,
+			"originCountry": productBlueprint.Customs.OriginCountry,
+			"declaredValue": productBlueprint.Customs.DeclaredValue,
+		}
+	}
 	if productBlueprint.CreatedBy != nil && *productBlueprint.CreatedBy != "" {
 		document["createdBy"] = *productBlueprint.CreatedBy
 	}
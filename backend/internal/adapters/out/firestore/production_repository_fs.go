@@ -64,6 +64,7 @@ func (r *ProductionRepositoryFS) GetByID(ctx context.Context, id string) (*prodd
 // Create creates a new Production document from CreateProductionInput.
 // - ID は CreateProductionInput には含まれないため、常に Firestore の auto ID を採番
 // - Printed が nil の場合は false 扱い
+// - Status は Printed から導出（printed=true なら manufacturing）
 // - CreatedAt/UpdatedAt は省略時 now(UTC)
 func (r *ProductionRepositoryFS) Create(ctx context.Context, in proddom.CreateProductionInput) (*proddom.Production, error) {
 	if r.Client == nil {
@@ -104,11 +105,19 @@ func (r *ProductionRepositoryFS) Create(ctx context.Context, in proddom.CreatePr
 		CreatedAt:          createdAt,
 		UpdatedAt:          createdAt,
 		UpdatedBy:          nil,
+		Status:             proddom.DeriveStatus(printed),
+	}
+	if printed {
+		p.StartedAt = printedAt
 	}
 
 	ref := r.col().NewDoc()
 	p.ID = ref.ID
 
+	if err := p.SetTargets(in.TargetStartAt, in.TargetInspectedAt, in.TargetCompletedAt); err != nil {
+		return nil, err
+	}
+
 	if err := p.Validate(); err != nil {
 		return nil, err
 	}
@@ -364,6 +373,15 @@ func docToProduction(doc *firestore.DocumentSnapshot) (proddom.Production, error
 		CreatedAt          time.Time               `firestore:"createdAt"`
 		UpdatedBy          *string                 `firestore:"updatedBy"`
 		UpdatedAt          *time.Time              `firestore:"updatedAt"`
+
+		Status            string     `firestore:"status"`
+		StartedAt         *time.Time `firestore:"startedAt"`
+		InspectedAt       *time.Time `firestore:"inspectedAt"`
+		CompletedAt       *time.Time `firestore:"completedAt"`
+		CancelledAt       *time.Time `firestore:"cancelledAt"`
+		TargetStartAt     *time.Time `firestore:"targetStartAt"`
+		TargetInspectedAt *time.Time `firestore:"targetInspectedAt"`
+		TargetCompletedAt *time.Time `firestore:"targetCompletedAt"`
 	}
 
 	if err := doc.DataTo(&raw); err != nil {
@@ -385,12 +403,28 @@ func docToProduction(doc *firestore.DocumentSnapshot) (proddom.Production, error
 		CreatedBy:          raw.CreatedBy,
 		CreatedAt:          raw.CreatedAt,
 		UpdatedBy:          raw.UpdatedBy,
+		Status:             proddom.Status(raw.Status),
+		StartedAt:          raw.StartedAt,
+		InspectedAt:        raw.InspectedAt,
+		CompletedAt:        raw.CompletedAt,
+		CancelledAt:        raw.CancelledAt,
+		TargetStartAt:      raw.TargetStartAt,
+		TargetInspectedAt:  raw.TargetInspectedAt,
+		TargetCompletedAt:  raw.TargetCompletedAt,
 	}
 
 	if raw.UpdatedAt != nil {
 		out.UpdatedAt = *raw.UpdatedAt
 	}
 
+	// status 導入前の document は printed から導出する
+	if raw.Status == "" {
+		out.Status = proddom.DeriveStatus(out.Printed)
+		if out.Printed && out.StartedAt == nil {
+			out.StartedAt = out.PrintedAt
+		}
+	}
+
 	if err := out.Validate(); err != nil {
 		return proddom.Production{}, fmt.Errorf("invalid production document %q: %w", doc.Ref.ID, err)
 	}
@@ -428,5 +462,23 @@ func productionToDoc(p proddom.Production) map[string]any {
 		m["updatedBy"] = *p.UpdatedBy
 	}
 
+	m["status"] = string(p.Status)
+
+	for key, t := range map[string]*time.Time{
+		"startedAt":         p.StartedAt,
+		"inspectedAt":       p.InspectedAt,
+		"completedAt":       p.CompletedAt,
+		"cancelledAt":       p.CancelledAt,
+		"targetStartAt":     p.TargetStartAt,
+		"targetInspectedAt": p.TargetInspectedAt,
+		"targetCompletedAt": p.TargetCompletedAt,
+	} {
+		if t != nil && !t.IsZero() {
+			m[key] = t.UTC()
+		} else {
+			m[key] = nil
+		}
+	}
+
 	return m
 }
@@ -0,0 +1,594 @@
+// backend/internal/adapters/out/firestore/refund_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	refunddom "narratives/internal/domain/refund"
+)
+
+const (
+	refundsCollectionName            = "refunds"
+	refundStripeEventsCollectionName = "refundStripeEvents"
+	refundLocksCollectionName        = "refundLocks"
+)
+
+var ErrRefundRepositoryNotConfigured = errors.New(
+	"refund_repository_fs: not configured",
+)
+
+// RefundRepositoryFS is the Firestore implementation of
+// refund.RepositoryPort.
+//
+// Firestore design:
+//
+//	refunds/{refundId}
+//	refundStripeEvents/{stripeEventId}
+//	refundLocks/{paymentId}
+//
+// refundLocks/{paymentId} is read and written by every CreateForPayment of
+// the payment. A query alone does not conflict with a concurrently created
+// refund document, so the lock document serializes the transactions.
+//
+// Stripe event rules:
+//
+//   - Stripe event ID is used as the event document ID
+//   - duplicate event IDs are successful no-ops
+//   - event marker creation and Refund status update occur in one
+//     Firestore Transaction
+type RefundRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ refunddom.RepositoryPort = (*RefundRepositoryFS)(nil)
+
+func NewRefundRepositoryFS(
+	client *firestore.Client,
+) *RefundRepositoryFS {
+	return &RefundRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *RefundRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(refundsCollectionName)
+}
+
+func (r *RefundRepositoryFS) stripeEventCol() *firestore.CollectionRef {
+	return r.Client.Collection(refundStripeEventsCollectionName)
+}
+
+func (r *RefundRepositoryFS) lockCol() *firestore.CollectionRef {
+	return r.Client.Collection(refundLocksCollectionName)
+}
+
+type refundItemDocument struct {
+	ItemIndex      int `firestore:"itemIndex"`
+	Qty            int `firestore:"qty"`
+	ItemAmount     int `firestore:"itemAmount"`
+	ShippingAmount int `firestore:"shippingAmount"`
+	TaxAmount      int `firestore:"taxAmount"`
+	Amount         int `firestore:"amount"`
+}
+
+type refundDocument struct {
+	PaymentID string `firestore:"paymentId"`
+
+	StripePaymentIntentID string `firestore:"stripePaymentIntentId"`
+	StripeRefundID        string `firestore:"stripeRefundId,omitempty"`
+
+	Items []refundItemDocument `firestore:"items"`
+
+	Amount   int    `firestore:"amount"`
+	Currency string `firestore:"currency"`
+
+	Reason string `firestore:"reason"`
+	Note   string `firestore:"note,omitempty"`
+
+	Status   string  `firestore:"status"`
+	ErrorMsg *string `firestore:"errorMsg,omitempty"`
+
+	RequestedBy string `firestore:"requestedBy"`
+
+	CreatedAt time.Time `firestore:"createdAt"`
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+// ============================================================
+// refund.RepositoryPort
+// ============================================================
+
+func (r *RefundRepositoryFS) GetByID(
+	ctx context.Context,
+	id string,
+) (refunddom.Refund, error) {
+	if r == nil || r.Client == nil {
+		return refunddom.Refund{}, ErrRefundRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return refunddom.Refund{}, refunddom.ErrNotFound
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return refunddom.Refund{}, refunddom.ErrNotFound
+		}
+
+		return refunddom.Refund{}, err
+	}
+
+	return docToRefund(snap)
+}
+
+func (r *RefundRepositoryFS) ListByPaymentID(
+	ctx context.Context,
+	paymentID string,
+) ([]refunddom.Refund, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrRefundRepositoryNotConfigured
+	}
+
+	paymentID = strings.TrimSpace(paymentID)
+	if paymentID == "" {
+		return nil, refunddom.ErrInvalidPaymentID
+	}
+
+	iter := r.col().
+		Where("paymentId", "==", paymentID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	refunds := make([]refunddom.Refund, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		refund, err := docToRefund(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		refunds = append(refunds, refund)
+	}
+
+	sort.SliceStable(refunds, func(i, j int) bool {
+		return refunds[i].CreatedAt.Before(refunds[j].CreatedAt)
+	})
+
+	return refunds, nil
+}
+
+func (r *RefundRepositoryFS) Create(
+	ctx context.Context,
+	refund refunddom.Refund,
+) (refunddom.Refund, error) {
+	if r == nil || r.Client == nil {
+		return refunddom.Refund{}, ErrRefundRepositoryNotConfigured
+	}
+
+	if err := refund.Validate(); err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	_, err := r.col().Doc(refund.ID).Create(
+		ctx,
+		refundToDocument(refund),
+	)
+	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return refunddom.Refund{}, refunddom.ErrConflict
+		}
+
+		return refunddom.Refund{}, err
+	}
+
+	return refund, nil
+}
+
+// CreateForPayment atomically:
+//
+//  1. Reads the per-payment lock document.
+//  2. Lists the refunds of the payment.
+//  3. Builds the new refund from them.
+//  4. Creates the refund and rewrites the lock document.
+func (r *RefundRepositoryFS) CreateForPayment(
+	ctx context.Context,
+	paymentID string,
+	build func(existing []refunddom.Refund) (refunddom.Refund, error),
+) (refunddom.Refund, error) {
+	if r == nil || r.Client == nil {
+		return refunddom.Refund{}, ErrRefundRepositoryNotConfigured
+	}
+
+	paymentID = strings.TrimSpace(paymentID)
+	if paymentID == "" || strings.Contains(paymentID, "/") {
+		return refunddom.Refund{}, refunddom.ErrInvalidPaymentID
+	}
+
+	lockRef := r.lockCol().Doc(paymentID)
+
+	var created refunddom.Refund
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			if _, err := tx.Get(lockRef); err != nil &&
+				status.Code(err) != codes.NotFound {
+				return err
+			}
+
+			snaps, err := tx.Documents(
+				r.col().Where("paymentId", "==", paymentID),
+			).GetAll()
+			if err != nil {
+				return err
+			}
+
+			existing := make([]refunddom.Refund, 0, len(snaps))
+			for _, snap := range snaps {
+				refund, err := docToRefund(snap)
+				if err != nil {
+					return err
+				}
+				existing = append(existing, refund)
+			}
+
+			sort.SliceStable(existing, func(i, j int) bool {
+				return existing[i].CreatedAt.Before(existing[j].CreatedAt)
+			})
+
+			refund, err := build(existing)
+			if err != nil {
+				return err
+			}
+
+			if refund.PaymentID != paymentID {
+				return refunddom.ErrConflict
+			}
+			if err := refund.Validate(); err != nil {
+				return err
+			}
+
+			if err := tx.Create(
+				r.col().Doc(refund.ID),
+				refundToDocument(refund),
+			); err != nil {
+				return err
+			}
+
+			if err := tx.Set(lockRef, map[string]any{
+				"paymentId":    paymentID,
+				"lastRefundId": refund.ID,
+				"updatedAt":    refund.CreatedAt.UTC(),
+			}); err != nil {
+				return err
+			}
+
+			created = refund
+			return nil
+		},
+	)
+	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return refunddom.Refund{}, refunddom.ErrConflict
+		}
+
+		return refunddom.Refund{}, err
+	}
+
+	return created, nil
+}
+
+func (r *RefundRepositoryFS) Update(
+	ctx context.Context,
+	refund refunddom.Refund,
+) (refunddom.Refund, error) {
+	if r == nil || r.Client == nil {
+		return refunddom.Refund{}, ErrRefundRepositoryNotConfigured
+	}
+
+	if err := refund.Validate(); err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	ref := r.col().Doc(refund.ID)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			snap, err := tx.Get(ref)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return refunddom.ErrNotFound
+				}
+
+				return err
+			}
+
+			current, err := docToRefund(snap)
+			if err != nil {
+				return err
+			}
+
+			if current.PaymentID != refund.PaymentID {
+				return refunddom.ErrConflict
+			}
+
+			if !refunddom.CanTransition(current.Status, refund.Status) {
+				return refunddom.ErrInvalidTransition
+			}
+
+			return tx.Set(ref, refundToDocument(refund))
+		},
+	)
+	if err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	return refund, nil
+}
+
+// ApplyStripeEvent atomically:
+//
+//  1. Deduplicates the Stripe event.
+//  2. Reads the current Refund.
+//  3. Verifies the Stripe Refund ID when one is already recorded.
+//  4. Applies a valid status transition.
+//  5. Records the Stripe event as processed.
+func (r *RefundRepositoryFS) ApplyStripeEvent(
+	ctx context.Context,
+	in refunddom.ApplyStripeEventInput,
+) (refunddom.ApplyStripeEventResult, error) {
+	if r == nil || r.Client == nil {
+		return refunddom.ApplyStripeEventResult{},
+			ErrRefundRepositoryNotConfigured
+	}
+
+	in.EventID = strings.TrimSpace(in.EventID)
+	in.RefundID = strings.TrimSpace(in.RefundID)
+	in.StripeRefundID = strings.TrimSpace(in.StripeRefundID)
+
+	if in.EventID == "" || strings.Contains(in.EventID, "/") {
+		return refunddom.ApplyStripeEventResult{}, fmt.Errorf(
+			"refund: invalid Stripe event id %q",
+			in.EventID,
+		)
+	}
+
+	if in.RefundID == "" {
+		return refunddom.ApplyStripeEventResult{}, refunddom.ErrInvalidID
+	}
+
+	if !refunddom.IsValidStatus(in.Status) {
+		return refunddom.ApplyStripeEventResult{}, refunddom.ErrInvalidStatus
+	}
+
+	refundRef := r.col().Doc(in.RefundID)
+	eventRef := r.stripeEventCol().Doc(in.EventID)
+	processedAt := time.Now().UTC()
+
+	var result refunddom.ApplyStripeEventResult
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			eventSnap, eventErr := tx.Get(eventRef)
+			if eventErr != nil &&
+				status.Code(eventErr) != codes.NotFound {
+				return eventErr
+			}
+
+			refundSnap, err := tx.Get(refundRef)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return refunddom.ErrNotFound
+				}
+
+				return err
+			}
+
+			current, err := docToRefund(refundSnap)
+			if err != nil {
+				return err
+			}
+
+			// Duplicate Stripe event: successful no-op.
+			if eventErr == nil &&
+				eventSnap != nil &&
+				eventSnap.Exists() {
+				result = refunddom.ApplyStripeEventResult{
+					Refund: current,
+				}
+
+				return nil
+			}
+
+			if current.StripeRefundID != "" &&
+				in.StripeRefundID != "" &&
+				current.StripeRefundID != in.StripeRefundID {
+				return refunddom.ErrConflict
+			}
+
+			next := current
+			transitionApplied :=
+				next.ApplyStripeResult(
+					in.StripeRefundID,
+					in.Status,
+					in.ErrorMsg,
+					processedAt,
+				) == nil
+
+			statusChanged :=
+				transitionApplied &&
+					current.Status != next.Status
+
+			if transitionApplied {
+				if err := next.Validate(); err != nil {
+					return err
+				}
+
+				if err := tx.Set(
+					refundRef,
+					refundToDocument(next),
+				); err != nil {
+					return err
+				}
+			} else {
+				next = current
+			}
+
+			eventData := map[string]any{
+				"eventId":           in.EventID,
+				"refundId":          in.RefundID,
+				"stripeRefundId":    in.StripeRefundID,
+				"requestedStatus":   string(in.Status),
+				"appliedStatus":     string(next.Status),
+				"transitionApplied": transitionApplied,
+				"statusChanged":     statusChanged,
+				"occurredAt":        in.OccurredAt.UTC(),
+				"processedAt":       processedAt,
+			}
+
+			if in.ErrorMsg != nil {
+				eventData["errorMsg"] = *in.ErrorMsg
+			}
+
+			if err := tx.Create(eventRef, eventData); err != nil {
+				return err
+			}
+
+			result = refunddom.ApplyStripeEventResult{
+				Refund:        next,
+				EventApplied:  true,
+				StatusChanged: statusChanged,
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return refunddom.ApplyStripeEventResult{}, err
+	}
+
+	return result, nil
+}
+
+// ============================================================
+// Document conversion
+// ============================================================
+
+func refundToDocument(
+	refund refunddom.Refund,
+) refundDocument {
+	items := make([]refundItemDocument, 0, len(refund.Items))
+	for _, item := range refund.Items {
+		items = append(items, refundItemDocument{
+			ItemIndex:      item.ItemIndex,
+			Qty:            item.Qty,
+			ItemAmount:     item.ItemAmount,
+			ShippingAmount: item.ShippingAmount,
+			TaxAmount:      item.TaxAmount,
+			Amount:         item.Amount,
+		})
+	}
+
+	return refundDocument{
+		PaymentID:             refund.PaymentID,
+		StripePaymentIntentID: refund.StripePaymentIntentID,
+		StripeRefundID:        refund.StripeRefundID,
+		Items:                 items,
+		Amount:                refund.Amount,
+		Currency:              refund.Currency,
+		Reason:                string(refund.Reason),
+		Note:                  refund.Note,
+		Status:                string(refund.Status),
+		ErrorMsg:              refund.ErrorMsg,
+		RequestedBy:           refund.RequestedBy,
+		CreatedAt:             refund.CreatedAt.UTC(),
+		UpdatedAt:             refund.UpdatedAt.UTC(),
+	}
+}
+
+func docToRefund(
+	snap *firestore.DocumentSnapshot,
+) (refunddom.Refund, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return refunddom.Refund{}, refunddom.ErrNotFound
+	}
+
+	var doc refundDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return refunddom.Refund{}, fmt.Errorf(
+			"decode refund %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	items := make([]refunddom.RefundItem, 0, len(doc.Items))
+	for _, item := range doc.Items {
+		items = append(items, refunddom.RefundItem{
+			ItemIndex:      item.ItemIndex,
+			Qty:            item.Qty,
+			ItemAmount:     item.ItemAmount,
+			ShippingAmount: item.ShippingAmount,
+			TaxAmount:      item.TaxAmount,
+			Amount:         item.Amount,
+		})
+	}
+
+	refund := refunddom.Refund{
+		ID:                    snap.Ref.ID,
+		PaymentID:             doc.PaymentID,
+		StripePaymentIntentID: doc.StripePaymentIntentID,
+		StripeRefundID:        doc.StripeRefundID,
+		Items:                 items,
+		Amount:                doc.Amount,
+		Currency:              doc.Currency,
+		Reason:                refunddom.Reason(doc.Reason),
+		Note:                  doc.Note,
+		Status:                refunddom.RefundStatus(doc.Status),
+		ErrorMsg:              doc.ErrorMsg,
+		RequestedBy:           doc.RequestedBy,
+		CreatedAt:             doc.CreatedAt.UTC(),
+		UpdatedAt:             doc.UpdatedAt.UTC(),
+	}
+
+	if err := refund.Validate(); err != nil {
+		return refunddom.Refund{}, fmt.Errorf(
+			"refund %s: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return refund, nil
+}
@@ -0,0 +1,248 @@
+// backend/internal/adapters/out/firestore/return_image_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	gfs "cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	returndom "narratives/internal/domain/orderReturn"
+)
+
+const returnImagesSub = "images"
+
+// ReturnImageRepositoryFS implements orderReturn.ImageRepository using Firestore.
+//
+// Collection:
+// - returns/{returnId}/images/{imageId}
+//
+// Image policy (same as list / resale images):
+// - Backend stores only Firebase Storage download URL.
+// - Image record is scoped by returnId.
+type ReturnImageRepositoryFS struct {
+	Client *gfs.Client
+}
+
+func NewReturnImageRepositoryFS(
+	client *gfs.Client,
+) *ReturnImageRepositoryFS {
+	return &ReturnImageRepositoryFS{
+		Client: client,
+	}
+}
+
+var _ returndom.ImageRepository = (*ReturnImageRepositoryFS)(nil)
+
+func (r *ReturnImageRepositoryFS) imagesCol(
+	returnID string,
+) *gfs.CollectionRef {
+	return r.Client.
+		Collection(returnsCollectionName).
+		Doc(returnID).
+		Collection(returnImagesSub)
+}
+
+// ============================================================
+// Return image query
+// ============================================================
+
+func (r *ReturnImageRepositoryFS) ListByReturnID(
+	ctx context.Context,
+	returnID string,
+) ([]returndom.ReturnImage, error) {
+	if r == nil || r.Client == nil {
+		return nil,
+			errors.New("firestore client is nil")
+	}
+
+	returnID = strings.TrimSpace(returnID)
+	if returnID == "" {
+		return []returndom.ReturnImage{}, nil
+	}
+
+	return listOrderedImageDocuments(
+		ctx,
+		r.imagesCol(returnID),
+		func(
+			doc *gfs.DocumentSnapshot,
+		) (returndom.ReturnImage, bool) {
+			return decodeReturnImageDoc(
+				doc,
+				returnID,
+			)
+		},
+	)
+}
+
+// ============================================================
+// Return image write
+// ============================================================
+
+func (r *ReturnImageRepositoryFS) Create(
+	ctx context.Context,
+	img returndom.ReturnImage,
+) (returndom.ReturnImage, error) {
+	if r == nil || r.Client == nil {
+		return returndom.ReturnImage{},
+			errors.New("firestore client is nil")
+	}
+
+	img.ReturnID = strings.TrimSpace(img.ReturnID)
+	img.URL = strings.TrimSpace(img.URL)
+	img.CreatedBy = strings.TrimSpace(img.CreatedBy)
+
+	if img.ReturnID == "" {
+		return returndom.ReturnImage{},
+			returndom.ErrInvalidImageReturnID
+	}
+
+	normalizedImageID, ok :=
+		normalizeImageDocumentID(img.ID)
+	if !ok {
+		return returndom.ReturnImage{},
+			returndom.ErrInvalidImageID
+	}
+
+	img.ID = normalizedImageID
+
+	img.CreatedAt =
+		normalizeImageCreatedAt(img.CreatedAt)
+
+	img.UpdatedAt =
+		normalizeImageUpdatedAt(img.UpdatedAt)
+
+	img.UpdatedBy =
+		normalizeImageUpdatedBy(img.UpdatedBy)
+
+	if err := img.Validate(); err != nil {
+		return returndom.ReturnImage{}, err
+	}
+
+	ref := r.imagesCol(img.ReturnID).
+		Doc(img.ID)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *gfs.Transaction,
+		) error {
+			_, err := tx.Get(ref)
+			if err == nil {
+				return returndom.ErrImageConflict
+			}
+
+			if status.Code(err) != codes.NotFound {
+				return err
+			}
+
+			if err := tx.Create(
+				ref,
+				encodeReturnImageDoc(img),
+			); err != nil {
+				if status.Code(err) ==
+					codes.AlreadyExists {
+					return returndom.ErrImageConflict
+				}
+
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return returndom.ReturnImage{}, err
+	}
+
+	return img, nil
+}
+
+func (r *ReturnImageRepositoryFS) Delete(
+	ctx context.Context,
+	returnID string,
+	imageID string,
+) error {
+	if r == nil || r.Client == nil {
+		return errors.New("firestore client is nil")
+	}
+
+	returnID = strings.TrimSpace(returnID)
+	if returnID == "" {
+		return returndom.ErrInvalidImageReturnID
+	}
+
+	normalizedImageID, ok :=
+		normalizeImageDocumentID(imageID)
+	if !ok {
+		return returndom.ErrInvalidImageID
+	}
+
+	return deleteImageDocument(
+		ctx,
+		r.Client,
+		r.imagesCol(returnID).Doc(normalizedImageID),
+		returndom.ErrImageNotFound,
+	)
+}
+
+// ============================================================
+// Domain and Firestore conversion
+// ============================================================
+
+func encodeReturnImageDoc(
+	img returndom.ReturnImage,
+) map[string]any {
+	return encodeImageDocument(
+		"return_id",
+		imageDocument{
+			ID:           img.ID,
+			OwnerID:      img.ReturnID,
+			URL:          img.URL,
+			DisplayOrder: img.DisplayOrder,
+			CreatedAt:    img.CreatedAt,
+			CreatedBy:    img.CreatedBy,
+			UpdatedAt:    img.UpdatedAt,
+			UpdatedBy:    img.UpdatedBy,
+		},
+	)
+}
+
+func decodeReturnImageDoc(
+	doc *gfs.DocumentSnapshot,
+	returnID string,
+) (returndom.ReturnImage, bool) {
+	raw, ok := decodeImageDocument(
+		doc,
+		returnID,
+		"return_id",
+	)
+	if !ok {
+		return returndom.ReturnImage{}, false
+	}
+
+	img, err := returndom.NewReturnImage(
+		raw.ID,
+		raw.OwnerID,
+		raw.URL,
+		raw.DisplayOrder,
+		raw.CreatedAt,
+		raw.CreatedBy,
+	)
+	if err != nil {
+		return returndom.ReturnImage{}, false
+	}
+
+	img.UpdatedAt = raw.UpdatedAt
+	img.UpdatedBy = raw.UpdatedBy
+
+	if err := img.Validate(); err != nil {
+		return returndom.ReturnImage{}, false
+	}
+
+	return img, true
+}
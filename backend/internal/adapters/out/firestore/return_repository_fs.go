@@ -0,0 +1,581 @@
+// backend/internal/adapters/out/firestore/return_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	returndom "narratives/internal/domain/orderReturn"
+	transportationdom "narratives/internal/domain/transportation"
+)
+
+const returnsCollectionName = "returns"
+
+var ErrReturnRepositoryNotConfigured = errors.New(
+	"return_repository_fs: not configured",
+)
+
+// ReturnRepositoryFS is the Firestore implementation of
+// orderReturn.RepositoryPort.
+//
+// Firestore design:
+//
+//	returns/{returnId}
+//	returns/{returnId}/images/{imageId}   (ReturnImageRepositoryFS)
+//
+// Create checks for another open return of the same order item inside the
+// same Firestore Transaction that creates the document.
+type ReturnRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ returndom.RepositoryPort = (*ReturnRepositoryFS)(nil)
+
+func NewReturnRepositoryFS(
+	client *firestore.Client,
+) *ReturnRepositoryFS {
+	return &ReturnRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *ReturnRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(returnsCollectionName)
+}
+
+type returnShippingQuoteDocument struct {
+	Carrier  string    `firestore:"carrier"`
+	Size     int       `firestore:"size"`
+	Amount   int       `firestore:"amount"`
+	Currency string    `firestore:"currency"`
+	QuotedAt time.Time `firestore:"quotedAt"`
+}
+
+type returnTokenDocument struct {
+	ProductID   string    `firestore:"productId"`
+	AssetID     string    `firestore:"assetId"`
+	TxSignature string    `firestore:"txSignature"`
+	ReturnedAt  time.Time `firestore:"returnedAt"`
+}
+
+type returnDocument struct {
+	OrderID   string `firestore:"orderId"`
+	ItemIndex int    `firestore:"itemIndex"`
+
+	UserID   string `firestore:"userId"`
+	AvatarID string `firestore:"avatarId"`
+
+	CompanyID string `firestore:"companyId"`
+	BrandID   string `firestore:"brandId,omitempty"`
+
+	InventoryID        string `firestore:"inventoryId"`
+	ModelID            string `firestore:"modelId"`
+	ListID             string `firestore:"listId"`
+	ProductBlueprintID string `firestore:"productBlueprintId"`
+	TokenBlueprintID   string `firestore:"tokenBlueprintId"`
+
+	Qty        int      `firestore:"qty"`
+	ProductIDs []string `firestore:"productIds"`
+
+	Reasons []string `firestore:"reasons"`
+	Comment string   `firestore:"comment,omitempty"`
+
+	Resolution string `firestore:"resolution"`
+	Status     string `firestore:"status"`
+
+	ReturnShippingQuote *returnShippingQuoteDocument `firestore:"returnShippingQuote,omitempty"`
+
+	ReviewNote string     `firestore:"reviewNote,omitempty"`
+	ReviewedBy string     `firestore:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time `firestore:"reviewedAt,omitempty"`
+
+	ReceivedBy string     `firestore:"receivedBy,omitempty"`
+	ReceivedAt *time.Time `firestore:"receivedAt,omitempty"`
+
+	Restocked bool `firestore:"restocked"`
+
+	TokenReturns []returnTokenDocument `firestore:"tokenReturns"`
+
+	RefundID           string `firestore:"refundId,omitempty"`
+	ReplacementOrderID string `firestore:"replacementOrderId,omitempty"`
+
+	CompletedBy string     `firestore:"completedBy,omitempty"`
+	CompletedAt *time.Time `firestore:"completedAt,omitempty"`
+	CancelledAt *time.Time `firestore:"cancelledAt,omitempty"`
+
+	CreatedAt time.Time `firestore:"createdAt"`
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+// ============================================================
+// orderReturn.RepositoryPort
+// ============================================================
+
+func (r *ReturnRepositoryFS) GetByID(
+	ctx context.Context,
+	id string,
+) (returndom.Return, error) {
+	if r == nil || r.Client == nil {
+		return returndom.Return{}, ErrReturnRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return returndom.Return{}, returndom.ErrNotFound
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return returndom.Return{}, returndom.ErrNotFound
+		}
+
+		return returndom.Return{}, err
+	}
+
+	return docToReturn(snap)
+}
+
+func (r *ReturnRepositoryFS) ListByOrderID(
+	ctx context.Context,
+	orderID string,
+) ([]returndom.Return, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrReturnRepositoryNotConfigured
+	}
+
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return nil, returndom.ErrInvalidOrderID
+	}
+
+	returns, err := r.list(
+		ctx,
+		r.col().Where("orderId", "==", orderID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(returns, func(i, j int) bool {
+		return returns[i].CreatedAt.Before(returns[j].CreatedAt)
+	})
+
+	return returns, nil
+}
+
+func (r *ReturnRepositoryFS) ListByAvatarID(
+	ctx context.Context,
+	avatarID string,
+) ([]returndom.Return, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrReturnRepositoryNotConfigured
+	}
+
+	avatarID = strings.TrimSpace(avatarID)
+	if avatarID == "" {
+		return nil, returndom.ErrInvalidAvatarID
+	}
+
+	returns, err := r.list(
+		ctx,
+		r.col().Where("avatarId", "==", avatarID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sortReturnsNewestFirst(returns)
+
+	return returns, nil
+}
+
+func (r *ReturnRepositoryFS) ListByCompanyID(
+	ctx context.Context,
+	companyID string,
+	st returndom.Status,
+) ([]returndom.Return, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrReturnRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, returndom.ErrInvalidCompanyID
+	}
+
+	q := r.col().Where("companyId", "==", companyID)
+	if st != "" {
+		if !returndom.IsValidStatus(st) {
+			return nil, returndom.ErrInvalidStatus
+		}
+		q = q.Where("status", "==", string(st))
+	}
+
+	returns, err := r.list(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	sortReturnsNewestFirst(returns)
+
+	return returns, nil
+}
+
+func (r *ReturnRepositoryFS) Create(
+	ctx context.Context,
+	ret returndom.Return,
+) (returndom.Return, error) {
+	if r == nil || r.Client == nil {
+		return returndom.Return{}, ErrReturnRepositoryNotConfigured
+	}
+
+	if err := ret.Validate(); err != nil {
+		return returndom.Return{}, err
+	}
+
+	ref := r.col().Doc(ret.ID)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			iter := tx.Documents(
+				r.col().
+					Where("orderId", "==", ret.OrderID).
+					Where("itemIndex", "==", ret.ItemIndex),
+			)
+			defer iter.Stop()
+
+			for {
+				snap, err := iter.Next()
+				if errors.Is(err, iterator.Done) {
+					break
+				}
+				if err != nil {
+					return err
+				}
+
+				existing, err := docToReturn(snap)
+				if err != nil {
+					return err
+				}
+
+				if existing.Status.IsOpen() {
+					return returndom.ErrConflict
+				}
+			}
+
+			if err := tx.Create(ref, returnToDocument(ret)); err != nil {
+				if status.Code(err) == codes.AlreadyExists {
+					return returndom.ErrConflict
+				}
+
+				return err
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	return ret, nil
+}
+
+func (r *ReturnRepositoryFS) Update(
+	ctx context.Context,
+	ret returndom.Return,
+) (returndom.Return, error) {
+	if r == nil || r.Client == nil {
+		return returndom.Return{}, ErrReturnRepositoryNotConfigured
+	}
+
+	if err := ret.Validate(); err != nil {
+		return returndom.Return{}, err
+	}
+
+	ref := r.col().Doc(ret.ID)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			snap, err := tx.Get(ref)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return returndom.ErrNotFound
+				}
+
+				return err
+			}
+
+			current, err := docToReturn(snap)
+			if err != nil {
+				return err
+			}
+
+			if current.OrderID != ret.OrderID ||
+				current.ItemIndex != ret.ItemIndex {
+				return returndom.ErrConflict
+			}
+
+			if current.Status != ret.Status &&
+				!returndom.CanTransition(current.Status, ret.Status) {
+				return returndom.ErrInvalidTransition
+			}
+
+			return tx.Set(ref, returnToDocument(ret))
+		},
+	)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	return ret, nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func (r *ReturnRepositoryFS) list(
+	ctx context.Context,
+	q firestore.Query,
+) ([]returndom.Return, error) {
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	returns := make([]returndom.Return, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err := docToReturn(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		returns = append(returns, ret)
+	}
+
+	return returns, nil
+}
+
+func sortReturnsNewestFirst(returns []returndom.Return) {
+	sort.SliceStable(returns, func(i, j int) bool {
+		return returns[i].CreatedAt.After(returns[j].CreatedAt)
+	})
+}
+
+func returnToDocument(
+	ret returndom.Return,
+) returnDocument {
+	productIDs := ret.ProductIDs
+	if productIDs == nil {
+		productIDs = []string{}
+	}
+
+	reasons := make([]string, 0, len(ret.Reasons))
+	for _, reason := range ret.Reasons {
+		reasons = append(reasons, string(reason))
+	}
+
+	tokenReturns := make([]returnTokenDocument, 0, len(ret.TokenReturns))
+	for _, tr := range ret.TokenReturns {
+		tokenReturns = append(tokenReturns, returnTokenDocument{
+			ProductID:   tr.ProductID,
+			AssetID:     tr.AssetID,
+			TxSignature: tr.TxSignature,
+			ReturnedAt:  tr.ReturnedAt.UTC(),
+		})
+	}
+
+	var quote *returnShippingQuoteDocument
+	if q := ret.ReturnShippingQuote; q != nil {
+		quote = &returnShippingQuoteDocument{
+			Carrier:  string(q.Carrier),
+			Size:     q.Size,
+			Amount:   q.Amount,
+			Currency: q.Currency,
+			QuotedAt: q.QuotedAt.UTC(),
+		}
+	}
+
+	return returnDocument{
+		OrderID:   ret.OrderID,
+		ItemIndex: ret.ItemIndex,
+
+		UserID:   ret.UserID,
+		AvatarID: ret.AvatarID,
+
+		CompanyID: ret.CompanyID,
+		BrandID:   ret.BrandID,
+
+		InventoryID:        ret.InventoryID,
+		ModelID:            ret.ModelID,
+		ListID:             ret.ListID,
+		ProductBlueprintID: ret.ProductBlueprintID,
+		TokenBlueprintID:   ret.TokenBlueprintID,
+
+		Qty:        ret.Qty,
+		ProductIDs: productIDs,
+
+		Reasons: reasons,
+		Comment: ret.Comment,
+
+		Resolution: string(ret.Resolution),
+		Status:     string(ret.Status),
+
+		ReturnShippingQuote: quote,
+
+		ReviewNote: ret.ReviewNote,
+		ReviewedBy: ret.ReviewedBy,
+		ReviewedAt: utcTimePtr(ret.ReviewedAt),
+
+		ReceivedBy: ret.ReceivedBy,
+		ReceivedAt: utcTimePtr(ret.ReceivedAt),
+
+		Restocked: ret.Restocked,
+
+		TokenReturns: tokenReturns,
+
+		RefundID:           ret.RefundID,
+		ReplacementOrderID: ret.ReplacementOrderID,
+
+		CompletedBy: ret.CompletedBy,
+		CompletedAt: utcTimePtr(ret.CompletedAt),
+		CancelledAt: utcTimePtr(ret.CancelledAt),
+
+		CreatedAt: ret.CreatedAt.UTC(),
+		UpdatedAt: ret.UpdatedAt.UTC(),
+	}
+}
+
+func docToReturn(
+	snap *firestore.DocumentSnapshot,
+) (returndom.Return, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return returndom.Return{}, returndom.ErrNotFound
+	}
+
+	var doc returnDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return returndom.Return{}, fmt.Errorf(
+			"decode return %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	reasons := make([]returndom.Reason, 0, len(doc.Reasons))
+	for _, reason := range doc.Reasons {
+		reasons = append(reasons, returndom.Reason(reason))
+	}
+
+	var tokenReturns []returndom.TokenReturn
+	for _, tr := range doc.TokenReturns {
+		tokenReturns = append(tokenReturns, returndom.TokenReturn{
+			ProductID:   tr.ProductID,
+			AssetID:     tr.AssetID,
+			TxSignature: tr.TxSignature,
+			ReturnedAt:  tr.ReturnedAt.UTC(),
+		})
+	}
+
+	var quote *returndom.ShippingQuote
+	if q := doc.ReturnShippingQuote; q != nil {
+		quote = &returndom.ShippingQuote{
+			Carrier:  transportationdom.Carrier(q.Carrier),
+			Size:     q.Size,
+			Amount:   q.Amount,
+			Currency: q.Currency,
+			QuotedAt: q.QuotedAt.UTC(),
+		}
+	}
+
+	productIDs := doc.ProductIDs
+	if productIDs == nil {
+		productIDs = []string{}
+	}
+
+	ret := returndom.Return{
+		ID:        snap.Ref.ID,
+		OrderID:   doc.OrderID,
+		ItemIndex: doc.ItemIndex,
+
+		UserID:   doc.UserID,
+		AvatarID: doc.AvatarID,
+
+		CompanyID: doc.CompanyID,
+		BrandID:   doc.BrandID,
+
+		InventoryID:        doc.InventoryID,
+		ModelID:            doc.ModelID,
+		ListID:             doc.ListID,
+		ProductBlueprintID: doc.ProductBlueprintID,
+		TokenBlueprintID:   doc.TokenBlueprintID,
+
+		Qty:        doc.Qty,
+		ProductIDs: productIDs,
+
+		Reasons: reasons,
+		Comment: doc.Comment,
+
+		Resolution: returndom.Resolution(doc.Resolution),
+		Status:     returndom.Status(doc.Status),
+
+		ReturnShippingQuote: quote,
+
+		ReviewNote: doc.ReviewNote,
+		ReviewedBy: doc.ReviewedBy,
+		ReviewedAt: utcTimePtr(doc.ReviewedAt),
+
+		ReceivedBy: doc.ReceivedBy,
+		ReceivedAt: utcTimePtr(doc.ReceivedAt),
+
+		Restocked: doc.Restocked,
+
+		TokenReturns: tokenReturns,
+
+		RefundID:           doc.RefundID,
+		ReplacementOrderID: doc.ReplacementOrderID,
+
+		CompletedBy: doc.CompletedBy,
+		CompletedAt: utcTimePtr(doc.CompletedAt),
+		CancelledAt: utcTimePtr(doc.CancelledAt),
+
+		CreatedAt: doc.CreatedAt.UTC(),
+		UpdatedAt: doc.UpdatedAt.UTC(),
+	}
+
+	if err := ret.Validate(); err != nil {
+		return returndom.Return{}, fmt.Errorf(
+			"return %s: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return ret, nil
+}
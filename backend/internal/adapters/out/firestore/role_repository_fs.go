@@ -0,0 +1,195 @@
+// backend/internal/adapters/out/firestore/role_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	permission "narratives/internal/domain/permission"
+)
+
+// Firestore-based implementation of permission.RoleRepository.
+//
+// Collection:
+//
+//	roles/{roleId}
+//
+// 組み込みロール（permission.BuiltinRoles）は保存しない。
+type RoleRepositoryFS struct {
+	Client *firestore.Client
+}
+
+func NewRoleRepositoryFS(client *firestore.Client) *RoleRepositoryFS {
+	return &RoleRepositoryFS{Client: client}
+}
+
+func (r *RoleRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection("roles")
+}
+
+type roleDocument struct {
+	CompanyID   string    `firestore:"companyId"`
+	Name        string    `firestore:"name"`
+	Description string    `firestore:"description"`
+	Permissions []string  `firestore:"permissions"`
+	CreatedAt   time.Time `firestore:"createdAt"`
+	UpdatedAt   time.Time `firestore:"updatedAt"`
+}
+
+// ListByCompanyID returns the company's roles ordered by name.
+func (r *RoleRepositoryFS) ListByCompanyID(ctx context.Context, companyID string) ([]permission.Role, error) {
+	if r.Client == nil {
+		return nil, errors.New("firestore client is nil")
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, permission.ErrInvalidRoleCompanyID
+	}
+
+	it := r.col().Where("companyId", "==", companyID).Documents(ctx)
+	defer it.Stop()
+
+	var out []permission.Role
+	for {
+		doc, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		role, err := docToRole(doc)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, role)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out, nil
+}
+
+// GetByID implements permission.RoleRepository.GetByID.
+func (r *RoleRepositoryFS) GetByID(ctx context.Context, id string) (permission.Role, error) {
+	if r.Client == nil {
+		return permission.Role{}, errors.New("firestore client is nil")
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return permission.Role{}, permission.ErrRoleNotFound
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return permission.Role{}, permission.ErrRoleNotFound
+		}
+		return permission.Role{}, err
+	}
+
+	return docToRole(snap)
+}
+
+// Save upserts a company role. CreatedAt of an existing role is preserved.
+func (r *RoleRepositoryFS) Save(ctx context.Context, role permission.Role) (permission.Role, error) {
+	if r.Client == nil {
+		return permission.Role{}, errors.New("firestore client is nil")
+	}
+
+	if err := role.Validate(); err != nil {
+		return permission.Role{}, err
+	}
+
+	ref := r.col().Doc(role.ID)
+
+	err := r.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("get role %q in transaction: %w", role.ID, err)
+		}
+
+		if err == nil && snap.Exists() {
+			current, err := docToRole(snap)
+			if err != nil {
+				return err
+			}
+			if current.CompanyID != role.CompanyID {
+				return permission.ErrConflict
+			}
+			if !current.CreatedAt.IsZero() {
+				role.CreatedAt = current.CreatedAt
+			}
+		}
+
+		return tx.Set(ref, roleToDocument(role))
+	})
+	if err != nil {
+		return permission.Role{}, err
+	}
+
+	return role, nil
+}
+
+// Delete removes a company role.
+func (r *RoleRepositoryFS) Delete(ctx context.Context, id string) error {
+	if r.Client == nil {
+		return errors.New("firestore client is nil")
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return permission.ErrRoleNotFound
+	}
+
+	if _, err := r.col().Doc(id).Delete(ctx, firestore.Exists); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return permission.ErrRoleNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func roleToDocument(role permission.Role) roleDocument {
+	return roleDocument{
+		CompanyID:   role.CompanyID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: append([]string{}, role.Permissions...),
+		CreatedAt:   role.CreatedAt.UTC(),
+		UpdatedAt:   role.UpdatedAt.UTC(),
+	}
+}
+
+func docToRole(doc *firestore.DocumentSnapshot) (permission.Role, error) {
+	var d roleDocument
+	if err := doc.DataTo(&d); err != nil {
+		return permission.Role{}, fmt.Errorf("decode role %q: %w", doc.Ref.ID, err)
+	}
+
+	return permission.Role{
+		ID:          doc.Ref.ID,
+		CompanyID:   d.CompanyID,
+		Name:        d.Name,
+		Description: d.Description,
+		Permissions: append([]string(nil), d.Permissions...),
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,216 @@
+// backend/internal/adapters/out/firestore/royalty_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	royaltydom "narratives/internal/domain/royalty"
+)
+
+const royaltyEntriesCollectionName = "royaltyEntries"
+
+var ErrRoyaltyRepositoryNotConfigured = errors.New(
+	"royalty_repository_fs: not configured",
+)
+
+// RoyaltyRepositoryFS is the Firestore implementation of
+// royalty.RepositoryPort.
+//
+// Firestore design:
+//
+//	royaltyEntries/{orderId}_{itemIndex}
+//
+// Create uses Firestore Create so a resale item is accrued at most once even
+// when the post-paid step runs again.
+type RoyaltyRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ royaltydom.RepositoryPort = (*RoyaltyRepositoryFS)(nil)
+
+func NewRoyaltyRepositoryFS(
+	client *firestore.Client,
+) *RoyaltyRepositoryFS {
+	return &RoyaltyRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *RoyaltyRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(royaltyEntriesCollectionName)
+}
+
+type royaltyEntryDocument struct {
+	CompanyID        string `firestore:"companyId"`
+	BrandID          string `firestore:"brandId"`
+	TokenBlueprintID string `firestore:"tokenBlueprintId"`
+
+	OrderID   string `firestore:"orderId"`
+	ItemIndex int    `firestore:"itemIndex"`
+
+	ResaleID       string `firestore:"resaleId"`
+	ProductID      string `firestore:"productId"`
+	SellerAvatarID string `firestore:"sellerAvatarId,omitempty"`
+
+	SaleAmount    int `firestore:"saleAmount"`
+	BasisPoints   int `firestore:"basisPoints"`
+	MinimumAmount int `firestore:"minimumAmount"`
+	Amount        int `firestore:"amount"`
+
+	AccruedAt time.Time `firestore:"accruedAt"`
+}
+
+func (r *RoyaltyRepositoryFS) Create(
+	ctx context.Context,
+	e royaltydom.Entry,
+) (royaltydom.Entry, error) {
+	if r == nil || r.Client == nil {
+		return royaltydom.Entry{}, ErrRoyaltyRepositoryNotConfigured
+	}
+
+	if err := e.Validate(); err != nil {
+		return royaltydom.Entry{}, err
+	}
+
+	if _, err := r.col().Doc(e.ID).Create(
+		ctx,
+		royaltyEntryToDocument(e),
+	); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return royaltydom.Entry{}, royaltydom.ErrConflict
+		}
+
+		return royaltydom.Entry{}, err
+	}
+
+	return e, nil
+}
+
+func (r *RoyaltyRepositoryFS) ListByCompanyID(
+	ctx context.Context,
+	companyID string,
+	filter royaltydom.ListFilter,
+) ([]royaltydom.Entry, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrRoyaltyRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, royaltydom.ErrInvalidCompanyID
+	}
+
+	q := r.col().Where("companyId", "==", companyID)
+	if brandID := strings.TrimSpace(filter.BrandID); brandID != "" {
+		q = q.Where("brandId", "==", brandID)
+	}
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	entries := make([]royaltydom.Entry, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := docToRoyaltyEntry(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		// 期間は composite index を増やさないようにメモリ上で絞り込む。
+		if filter.From != nil && e.AccruedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !e.AccruedAt.Before(*filter.To) {
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].AccruedAt.After(entries[j].AccruedAt)
+	})
+
+	return entries, nil
+}
+
+func royaltyEntryToDocument(
+	e royaltydom.Entry,
+) royaltyEntryDocument {
+	return royaltyEntryDocument{
+		CompanyID:        e.CompanyID,
+		BrandID:          e.BrandID,
+		TokenBlueprintID: e.TokenBlueprintID,
+
+		OrderID:   e.OrderID,
+		ItemIndex: e.ItemIndex,
+
+		ResaleID:       e.ResaleID,
+		ProductID:      e.ProductID,
+		SellerAvatarID: e.SellerAvatarID,
+
+		SaleAmount:    e.SaleAmount,
+		BasisPoints:   e.BasisPoints,
+		MinimumAmount: e.MinimumAmount,
+		Amount:        e.Amount,
+
+		AccruedAt: e.AccruedAt.UTC(),
+	}
+}
+
+func docToRoyaltyEntry(
+	snap *firestore.DocumentSnapshot,
+) (royaltydom.Entry, error) {
+	if snap == nil || snap.Ref == nil || !snap.Exists() {
+		return royaltydom.Entry{}, royaltydom.ErrNotFound
+	}
+
+	var doc royaltyEntryDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return royaltydom.Entry{}, fmt.Errorf(
+			"decode royalty entry %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return royaltydom.Entry{
+		ID: snap.Ref.ID,
+
+		CompanyID:        doc.CompanyID,
+		BrandID:          doc.BrandID,
+		TokenBlueprintID: doc.TokenBlueprintID,
+
+		OrderID:   doc.OrderID,
+		ItemIndex: doc.ItemIndex,
+
+		ResaleID:       doc.ResaleID,
+		ProductID:      doc.ProductID,
+		SellerAvatarID: doc.SellerAvatarID,
+
+		SaleAmount:    doc.SaleAmount,
+		BasisPoints:   doc.BasisPoints,
+		MinimumAmount: doc.MinimumAmount,
+		Amount:        doc.Amount,
+
+		AccruedAt: doc.AccruedAt.UTC(),
+	}, nil
+}
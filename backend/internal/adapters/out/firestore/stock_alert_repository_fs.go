@@ -0,0 +1,498 @@
+// backend/internal/adapters/out/firestore/stock_alert_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	invdom "narratives/internal/domain/inventory"
+)
+
+const (
+	stockAlertsCollectionName         = "stockAlerts"
+	stockAlertOpenLocksCollectionName = "stockAlertOpenLocks"
+	defaultStockAlertListLimit        = 50
+	stockAlertOpenLockDocIDSeparator  = "__"
+)
+
+var ErrStockAlertRepositoryNotConfigured = errors.New(
+	"stock_alert_repository_fs: not configured",
+)
+
+// StockAlertRepositoryFS is the Firestore implementation of
+// inventory.StockAlertRepository.
+//
+// Firestore design:
+//
+//	stockAlerts/{alertId}
+//	stockAlertOpenLocks/{inventoryId}__{modelId}  { alertId }
+//
+// stockAlertOpenLocks は inventory / model ごとに open なアラートを 1 件に
+// 保つための lock document です。発火と解消は lock と alert を同じ
+// transaction で更新します。
+// 一覧は composite index を増やさないよう、等価条件 1 つで取得した後に
+// メモリ上で絞り込み・並び替えを行います。
+type StockAlertRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ invdom.StockAlertRepository = (*StockAlertRepositoryFS)(nil)
+
+func NewStockAlertRepositoryFS(
+	client *firestore.Client,
+) *StockAlertRepositoryFS {
+	return &StockAlertRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *StockAlertRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(stockAlertsCollectionName)
+}
+
+func (r *StockAlertRepositoryFS) lockRef(
+	inventoryID string,
+	modelID string,
+) *firestore.DocumentRef {
+	return r.Client.
+		Collection(stockAlertOpenLocksCollectionName).
+		Doc(inventoryID + stockAlertOpenLockDocIDSeparator + modelID)
+}
+
+type stockAlertOpenLockDocument struct {
+	AlertID string `firestore:"alertId"`
+}
+
+type stockAlertDocument struct {
+	InventoryID string `firestore:"inventoryId"`
+	ModelID     string `firestore:"modelId"`
+	CompanyID   string `firestore:"companyId"`
+
+	Status string `firestore:"status"`
+
+	Threshold     int `firestore:"threshold"`
+	Available     int `firestore:"available"`
+	Accumulation  int `firestore:"accumulation"`
+	ReservedCount int `firestore:"reservedCount"`
+
+	FiredAt           time.Time  `firestore:"firedAt"`
+	ResolvedAt        *time.Time `firestore:"resolvedAt,omitempty"`
+	ResolvedAvailable *int       `firestore:"resolvedAvailable,omitempty"`
+
+	NotifiedEmails    []string   `firestore:"notifiedEmails,omitempty"`
+	NotifiedAt        *time.Time `firestore:"notifiedAt,omitempty"`
+	NotificationError string     `firestore:"notificationError,omitempty"`
+}
+
+// ============================================================
+// inventory.StockAlertRepository
+// ============================================================
+
+func (r *StockAlertRepositoryFS) OpenIfAbsent(
+	ctx context.Context,
+	alert invdom.StockAlert,
+) (invdom.StockAlert, bool, error) {
+	if r == nil || r.Client == nil {
+		return invdom.StockAlert{}, false, ErrStockAlertRepositoryNotConfigured
+	}
+
+	if err := alert.Validate(); err != nil {
+		return invdom.StockAlert{}, false, err
+	}
+	if alert.Status != invdom.StockAlertStatusOpen {
+		return invdom.StockAlert{}, false, invdom.ErrInvalidStockAlertStatus
+	}
+
+	lockRef := r.lockRef(alert.InventoryID, alert.ModelID)
+	alertRef := r.col().Doc(alert.ID)
+
+	var (
+		result  invdom.StockAlert
+		created bool
+	)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			created = false
+
+			lockSnap, err := tx.Get(lockRef)
+			if err != nil && status.Code(err) != codes.NotFound {
+				return err
+			}
+
+			if err == nil {
+				var lock stockAlertOpenLockDocument
+				if err := lockSnap.DataTo(&lock); err != nil {
+					return err
+				}
+
+				existingSnap, err := tx.Get(r.col().Doc(lock.AlertID))
+				if err != nil {
+					return err
+				}
+
+				existing, err := docToStockAlert(existingSnap)
+				if err != nil {
+					return err
+				}
+
+				result = existing
+				return nil
+			}
+
+			if err := tx.Create(
+				lockRef,
+				stockAlertOpenLockDocument{AlertID: alert.ID},
+			); err != nil {
+				return err
+			}
+
+			if err := tx.Create(
+				alertRef,
+				stockAlertToDocument(alert),
+			); err != nil {
+				return err
+			}
+
+			result = alert
+			created = true
+			return nil
+		},
+	)
+	if err != nil {
+		return invdom.StockAlert{}, false, fmt.Errorf(
+			"open stock alert %q: %w",
+			alert.ID,
+			err,
+		)
+	}
+
+	return result, created, nil
+}
+
+func (r *StockAlertRepositoryFS) ResolveOpen(
+	ctx context.Context,
+	inventoryID string,
+	modelID string,
+	available int,
+	now time.Time,
+) (invdom.StockAlert, error) {
+	if r == nil || r.Client == nil {
+		return invdom.StockAlert{}, ErrStockAlertRepositoryNotConfigured
+	}
+
+	inventoryID = strings.TrimSpace(inventoryID)
+	modelID = strings.TrimSpace(modelID)
+	if inventoryID == "" {
+		return invdom.StockAlert{}, invdom.ErrInvalidMintID
+	}
+	if modelID == "" {
+		return invdom.StockAlert{}, invdom.ErrInvalidModelID
+	}
+
+	lockRef := r.lockRef(inventoryID, modelID)
+
+	var result invdom.StockAlert
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(
+			ctx context.Context,
+			tx *firestore.Transaction,
+		) error {
+			lockSnap, err := tx.Get(lockRef)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return invdom.ErrNotFound
+				}
+				return err
+			}
+
+			var lock stockAlertOpenLockDocument
+			if err := lockSnap.DataTo(&lock); err != nil {
+				return err
+			}
+
+			alertRef := r.col().Doc(lock.AlertID)
+
+			alertSnap, err := tx.Get(alertRef)
+			if err != nil && status.Code(err) != codes.NotFound {
+				return err
+			}
+
+			// lock だけが残っている場合は lock を削除して解消扱いにする。
+			if err != nil {
+				return tx.Delete(lockRef)
+			}
+
+			alert, err := docToStockAlert(alertSnap)
+			if err != nil {
+				return err
+			}
+
+			alert.Resolve(available, now)
+
+			if err := tx.Set(
+				alertRef,
+				stockAlertToDocument(alert),
+			); err != nil {
+				return err
+			}
+
+			if err := tx.Delete(lockRef); err != nil {
+				return err
+			}
+
+			result = alert
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.Is(err, invdom.ErrNotFound) {
+			return invdom.StockAlert{}, err
+		}
+
+		return invdom.StockAlert{}, fmt.Errorf(
+			"resolve stock alert inventory=%q model=%q: %w",
+			inventoryID,
+			modelID,
+			err,
+		)
+	}
+
+	return result, nil
+}
+
+func (r *StockAlertRepositoryFS) RecordNotification(
+	ctx context.Context,
+	alertID string,
+	notifiedEmails []string,
+	notificationError string,
+	now time.Time,
+) error {
+	if r == nil || r.Client == nil {
+		return ErrStockAlertRepositoryNotConfigured
+	}
+
+	alertID = strings.TrimSpace(alertID)
+	if alertID == "" {
+		return invdom.ErrInvalidStockAlert
+	}
+
+	if notifiedEmails == nil {
+		notifiedEmails = []string{}
+	}
+
+	_, err := r.col().Doc(alertID).Update(
+		ctx,
+		[]firestore.Update{
+			{Path: "notifiedEmails", Value: notifiedEmails},
+			{Path: "notifiedAt", Value: now.UTC()},
+			{Path: "notificationError", Value: notificationError},
+		},
+	)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return invdom.ErrNotFound
+		}
+
+		return fmt.Errorf(
+			"record stock alert notification %q: %w",
+			alertID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (r *StockAlertRepositoryFS) ListByInventoryID(
+	ctx context.Context,
+	inventoryID string,
+	limit int,
+) ([]invdom.StockAlert, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrStockAlertRepositoryNotConfigured
+	}
+
+	inventoryID = strings.TrimSpace(inventoryID)
+	if inventoryID == "" {
+		return nil, invdom.ErrInvalidMintID
+	}
+
+	if limit <= 0 {
+		limit = defaultStockAlertListLimit
+	}
+
+	alerts, err := r.listWhere(ctx, "inventoryId", inventoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(alerts) > limit {
+		alerts = alerts[:limit]
+	}
+
+	return alerts, nil
+}
+
+func (r *StockAlertRepositoryFS) ListOpenByCompanyID(
+	ctx context.Context,
+	companyID string,
+) ([]invdom.StockAlert, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrStockAlertRepositoryNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, invdom.ErrInvalidStockAlertCompanyID
+	}
+
+	alerts, err := r.listWhere(ctx, "companyId", companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	open := make([]invdom.StockAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		if alert.Status == invdom.StockAlertStatusOpen {
+			open = append(open, alert)
+		}
+	}
+
+	return open, nil
+}
+
+func (r *StockAlertRepositoryFS) listWhere(
+	ctx context.Context,
+	field string,
+	value string,
+) ([]invdom.StockAlert, error) {
+	iter := r.col().
+		Where(field, "==", value).
+		Documents(ctx)
+	defer iter.Stop()
+
+	alerts := make([]invdom.StockAlert, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf(
+				"list stock alerts by %s %q: %w",
+				field,
+				value,
+				err,
+			)
+		}
+
+		alert, err := docToStockAlert(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	sort.SliceStable(
+		alerts,
+		func(i int, j int) bool {
+			if alerts[i].FiredAt.Equal(alerts[j].FiredAt) {
+				return alerts[i].ID > alerts[j].ID
+			}
+
+			return alerts[i].FiredAt.After(alerts[j].FiredAt)
+		},
+	)
+
+	return alerts, nil
+}
+
+// ============================================================
+// mapping
+// ============================================================
+
+func stockAlertToDocument(
+	alert invdom.StockAlert,
+) stockAlertDocument {
+	return stockAlertDocument{
+		InventoryID:       alert.InventoryID,
+		ModelID:           alert.ModelID,
+		CompanyID:         alert.CompanyID,
+		Status:            string(alert.Status),
+		Threshold:         alert.Threshold,
+		Available:         alert.Available,
+		Accumulation:      alert.Accumulation,
+		ReservedCount:     alert.ReservedCount,
+		FiredAt:           alert.FiredAt.UTC(),
+		ResolvedAt:        utcTimePointer(alert.ResolvedAt),
+		ResolvedAvailable: alert.ResolvedAvailable,
+		NotifiedEmails:    alert.NotifiedEmails,
+		NotifiedAt:        utcTimePointer(alert.NotifiedAt),
+		NotificationError: alert.NotificationError,
+	}
+}
+
+func docToStockAlert(
+	snap *firestore.DocumentSnapshot,
+) (invdom.StockAlert, error) {
+	if snap == nil {
+		return invdom.StockAlert{}, errors.New(
+			"stock alert document snapshot is nil",
+		)
+	}
+
+	var doc stockAlertDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return invdom.StockAlert{}, fmt.Errorf(
+			"decode stock alert %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	alert := invdom.StockAlert{
+		ID:                snap.Ref.ID,
+		InventoryID:       doc.InventoryID,
+		ModelID:           doc.ModelID,
+		CompanyID:         doc.CompanyID,
+		Status:            invdom.StockAlertStatus(doc.Status),
+		Threshold:         doc.Threshold,
+		Available:         doc.Available,
+		Accumulation:      doc.Accumulation,
+		ReservedCount:     doc.ReservedCount,
+		FiredAt:           doc.FiredAt.UTC(),
+		ResolvedAt:        utcTimePointer(doc.ResolvedAt),
+		ResolvedAvailable: doc.ResolvedAvailable,
+		NotifiedEmails:    doc.NotifiedEmails,
+		NotifiedAt:        utcTimePointer(doc.NotifiedAt),
+		NotificationError: doc.NotificationError,
+	}
+
+	if err := alert.Validate(); err != nil {
+		return invdom.StockAlert{}, fmt.Errorf(
+			"invalid stock alert %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return alert, nil
+}
@@ -0,0 +1,154 @@
+// backend/internal/adapters/out/firestore/stock_alert_setting_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	invdom "narratives/internal/domain/inventory"
+)
+
+const stockAlertSettingsCollectionName = "stockAlertSettings"
+
+var ErrStockAlertSettingRepositoryNotConfigured = errors.New(
+	"stock_alert_setting_repository_fs: not configured",
+)
+
+// StockAlertSettingRepositoryFS is the Firestore implementation of
+// inventory.StockAlertSettingRepository.
+//
+// Firestore design:
+//
+//	stockAlertSettings/{inventoryId}
+type StockAlertSettingRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ invdom.StockAlertSettingRepository = (*StockAlertSettingRepositoryFS)(nil)
+
+func NewStockAlertSettingRepositoryFS(
+	client *firestore.Client,
+) *StockAlertSettingRepositoryFS {
+	return &StockAlertSettingRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *StockAlertSettingRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(stockAlertSettingsCollectionName)
+}
+
+type stockAlertSettingDocument struct {
+	InventoryID string `firestore:"inventoryId"`
+	CompanyID   string `firestore:"companyId"`
+
+	Enabled bool `firestore:"enabled"`
+
+	DefaultThreshold *int           `firestore:"defaultThreshold,omitempty"`
+	ModelThresholds  map[string]int `firestore:"modelThresholds"`
+
+	NotifyEmails []string `firestore:"notifyEmails"`
+
+	UpdatedAt time.Time `firestore:"updatedAt"`
+	UpdatedBy string    `firestore:"updatedBy,omitempty"`
+}
+
+func (r *StockAlertSettingRepositoryFS) GetByInventoryID(
+	ctx context.Context,
+	inventoryID string,
+) (invdom.StockAlertSetting, error) {
+	if r == nil || r.Client == nil {
+		return invdom.StockAlertSetting{}, ErrStockAlertSettingRepositoryNotConfigured
+	}
+
+	inventoryID = strings.TrimSpace(inventoryID)
+	if inventoryID == "" {
+		return invdom.StockAlertSetting{}, invdom.ErrInvalidMintID
+	}
+
+	snap, err := r.col().Doc(inventoryID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return invdom.StockAlertSetting{}, invdom.ErrNotFound
+		}
+
+		return invdom.StockAlertSetting{}, err
+	}
+
+	var doc stockAlertSettingDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return invdom.StockAlertSetting{}, fmt.Errorf(
+			"decode stock alert setting %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	setting := invdom.StockAlertSetting{
+		InventoryID:      snap.Ref.ID,
+		CompanyID:        doc.CompanyID,
+		Enabled:          doc.Enabled,
+		DefaultThreshold: doc.DefaultThreshold,
+		ModelThresholds:  doc.ModelThresholds,
+		NotifyEmails:     doc.NotifyEmails,
+		UpdatedAt:        doc.UpdatedAt.UTC(),
+		UpdatedBy:        doc.UpdatedBy,
+	}
+	if setting.ModelThresholds == nil {
+		setting.ModelThresholds = map[string]int{}
+	}
+
+	return setting, nil
+}
+
+func (r *StockAlertSettingRepositoryFS) Save(
+	ctx context.Context,
+	setting invdom.StockAlertSetting,
+) (invdom.StockAlertSetting, error) {
+	if r == nil || r.Client == nil {
+		return invdom.StockAlertSetting{}, ErrStockAlertSettingRepositoryNotConfigured
+	}
+
+	if err := setting.Validate(); err != nil {
+		return invdom.StockAlertSetting{}, err
+	}
+
+	modelThresholds := setting.ModelThresholds
+	if modelThresholds == nil {
+		modelThresholds = map[string]int{}
+	}
+
+	notifyEmails := setting.NotifyEmails
+	if notifyEmails == nil {
+		notifyEmails = []string{}
+	}
+
+	if _, err := r.col().Doc(setting.InventoryID).Set(
+		ctx,
+		stockAlertSettingDocument{
+			InventoryID:      setting.InventoryID,
+			CompanyID:        setting.CompanyID,
+			Enabled:          setting.Enabled,
+			DefaultThreshold: setting.DefaultThreshold,
+			ModelThresholds:  modelThresholds,
+			NotifyEmails:     notifyEmails,
+			UpdatedAt:        setting.UpdatedAt.UTC(),
+			UpdatedBy:        setting.UpdatedBy,
+		},
+	); err != nil {
+		return invdom.StockAlertSetting{}, fmt.Errorf(
+			"save stock alert setting %q: %w",
+			setting.InventoryID,
+			err,
+		)
+	}
+
+	return setting, nil
+}
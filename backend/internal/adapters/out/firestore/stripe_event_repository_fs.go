@@ -0,0 +1,300 @@
+// backend/internal/adapters/out/firestore/stripe_event_repository_fs.go
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	stripeeventdom "narratives/internal/domain/stripeEvent"
+)
+
+const stripeWebhookEventsCollectionName = "stripeWebhookEvents"
+
+var ErrStripeEventRepositoryNotConfigured = errors.New(
+	"stripe_event_repository_fs: not configured",
+)
+
+// StripeEventRepositoryFS is the Firestore implementation of
+// stripeEvent.RepositoryPort.
+//
+// Firestore design:
+//
+//	stripeWebhookEvents/{stripeEventId}
+//
+// The document ID is the Stripe event ID, so a redelivered event resolves to
+// the same document. payload keeps the raw JSON for console replay.
+// paymentStripeEvents / refundStripeEvents remain the per-aggregate
+// deduplication markers written in the same transaction as each change.
+type StripeEventRepositoryFS struct {
+	Client *firestore.Client
+}
+
+var _ stripeeventdom.RepositoryPort = (*StripeEventRepositoryFS)(nil)
+
+func NewStripeEventRepositoryFS(
+	client *firestore.Client,
+) *StripeEventRepositoryFS {
+	return &StripeEventRepositoryFS{
+		Client: client,
+	}
+}
+
+func (r *StripeEventRepositoryFS) col() *firestore.CollectionRef {
+	return r.Client.Collection(stripeWebhookEventsCollectionName)
+}
+
+type stripeEventDocument struct {
+	Type    string `firestore:"type"`
+	Payload string `firestore:"payload"`
+
+	Status    string `firestore:"status"`
+	Attempts  int    `firestore:"attempts"`
+	LastError string `firestore:"lastError,omitempty"`
+
+	OccurredAt time.Time `firestore:"occurredAt"`
+	ReceivedAt time.Time `firestore:"receivedAt"`
+	UpdatedAt  time.Time `firestore:"updatedAt"`
+
+	ProcessedAt *time.Time `firestore:"processedAt,omitempty"`
+
+	LastReplayedAt *time.Time `firestore:"lastReplayedAt,omitempty"`
+	LastReplayedBy string     `firestore:"lastReplayedBy,omitempty"`
+}
+
+func (r *StripeEventRepositoryFS) GetByID(
+	ctx context.Context,
+	id string,
+) (stripeeventdom.Event, error) {
+	if r == nil || r.Client == nil {
+		return stripeeventdom.Event{}, ErrStripeEventRepositoryNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return stripeeventdom.Event{}, stripeeventdom.ErrNotFound
+	}
+
+	snap, err := r.col().Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return stripeeventdom.Event{}, stripeeventdom.ErrNotFound
+		}
+		return stripeeventdom.Event{}, err
+	}
+
+	return decodeStripeEventSnapshot(snap)
+}
+
+func (r *StripeEventRepositoryFS) Record(
+	ctx context.Context,
+	e stripeeventdom.Event,
+) (stripeeventdom.Event, bool, error) {
+	if r == nil || r.Client == nil {
+		return stripeeventdom.Event{}, false,
+			ErrStripeEventRepositoryNotConfigured
+	}
+
+	if err := e.Validate(); err != nil {
+		return stripeeventdom.Event{}, false, err
+	}
+
+	ref := r.col().Doc(e.ID)
+
+	var (
+		stored  stripeeventdom.Event
+		created bool
+	)
+
+	err := r.Client.RunTransaction(
+		ctx,
+		func(ctx context.Context, tx *firestore.Transaction) error {
+			created = false
+
+			snap, err := tx.Get(ref)
+			if err == nil {
+				stored, err = decodeStripeEventSnapshot(snap)
+				return err
+			}
+			if status.Code(err) != codes.NotFound {
+				return err
+			}
+
+			if err := tx.Create(ref, stripeEventToDocument(e)); err != nil {
+				return err
+			}
+
+			stored = e
+			created = true
+			return nil
+		},
+	)
+	if err != nil {
+		return stripeeventdom.Event{}, false, err
+	}
+
+	return stored, created, nil
+}
+
+func (r *StripeEventRepositoryFS) Save(
+	ctx context.Context,
+	e stripeeventdom.Event,
+) error {
+	if r == nil || r.Client == nil {
+		return ErrStripeEventRepositoryNotConfigured
+	}
+
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	updates := []firestore.Update{
+		{Path: "status", Value: string(e.Status)},
+		{Path: "attempts", Value: e.Attempts},
+		{Path: "lastError", Value: e.LastError},
+		{Path: "updatedAt", Value: e.UpdatedAt.UTC()},
+	}
+
+	if e.ProcessedAt != nil {
+		updates = append(updates, firestore.Update{
+			Path:  "processedAt",
+			Value: e.ProcessedAt.UTC(),
+		})
+	}
+	if e.LastReplayedAt != nil {
+		updates = append(updates,
+			firestore.Update{
+				Path:  "lastReplayedAt",
+				Value: e.LastReplayedAt.UTC(),
+			},
+			firestore.Update{
+				Path:  "lastReplayedBy",
+				Value: e.LastReplayedBy,
+			},
+		)
+	}
+
+	if _, err := r.col().Doc(e.ID).Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return stripeeventdom.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *StripeEventRepositoryFS) List(
+	ctx context.Context,
+	filter stripeeventdom.ListFilter,
+) ([]stripeeventdom.Event, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrStripeEventRepositoryNotConfigured
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = stripeeventdom.DefaultListLimit
+	}
+	if limit > stripeeventdom.MaxListLimit {
+		limit = stripeeventdom.MaxListLimit
+	}
+
+	eventType := strings.TrimSpace(filter.Type)
+
+	q := r.col().OrderBy("receivedAt", firestore.Desc)
+	if eventType == "" && filter.Status == "" {
+		q = q.Limit(limit)
+	}
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]stripeeventdom.Event, 0)
+
+	// type / status は composite index を増やさないようにメモリ上で絞り込む。
+	for len(out) < limit {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeStripeEventSnapshot(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if eventType != "" && e.Type != eventType {
+			continue
+		}
+		if filter.Status != "" && e.Status != filter.Status {
+			continue
+		}
+
+		out = append(out, e)
+	}
+
+	return out, nil
+}
+
+func stripeEventToDocument(e stripeeventdom.Event) stripeEventDocument {
+	return stripeEventDocument{
+		Type:    e.Type,
+		Payload: string(e.Payload),
+
+		Status:    string(e.Status),
+		Attempts:  e.Attempts,
+		LastError: e.LastError,
+
+		OccurredAt: e.OccurredAt.UTC(),
+		ReceivedAt: e.ReceivedAt.UTC(),
+		UpdatedAt:  e.UpdatedAt.UTC(),
+
+		ProcessedAt: e.ProcessedAt,
+
+		LastReplayedAt: e.LastReplayedAt,
+		LastReplayedBy: e.LastReplayedBy,
+	}
+}
+
+func decodeStripeEventSnapshot(
+	snap *firestore.DocumentSnapshot,
+) (stripeeventdom.Event, error) {
+	var doc stripeEventDocument
+	if err := snap.DataTo(&doc); err != nil {
+		return stripeeventdom.Event{}, fmt.Errorf(
+			"decode stripe event %q: %w",
+			snap.Ref.ID,
+			err,
+		)
+	}
+
+	return stripeeventdom.Event{
+		ID:      snap.Ref.ID,
+		Type:    doc.Type,
+		Payload: []byte(doc.Payload),
+
+		Status:    stripeeventdom.Status(doc.Status),
+		Attempts:  doc.Attempts,
+		LastError: doc.LastError,
+
+		OccurredAt: doc.OccurredAt.UTC(),
+		ReceivedAt: doc.ReceivedAt.UTC(),
+		UpdatedAt:  doc.UpdatedAt.UTC(),
+
+		ProcessedAt: utcTimePtr(doc.ProcessedAt),
+
+		LastReplayedAt: utcTimePtr(doc.LastReplayedAt),
+		LastReplayedBy: doc.LastReplayedBy,
+	}, nil
+}
@@ -188,6 +188,7 @@ func (r *TokenBlueprintRepositoryFS) Create(ctx context.Context, in tbdom.Create
 		UpdatedAt:       updatedAt,
 		UpdatedBy:       in.UpdatedBy,
 		MetadataURI:     in.MetadataURI,
+		Royalty:         in.Royalty,
 	}
 	if err := validatePersistedTokenBlueprint(candidate); err != nil {
 		return nil, err
@@ -212,6 +213,7 @@ func (r *TokenBlueprintRepositoryFS) Create(ctx context.Context, in tbdom.Create
 		"updatedAt":       updatedAt,
 		"updatedBy":       in.UpdatedBy,
 		"metadataUri":     in.MetadataURI,
+		"royalty":         toFSRoyalty(in.Royalty),
 	}
 
 	if _, err := docRef.Create(ctx, data); err != nil {
@@ -292,6 +294,12 @@ func (r *TokenBlueprintRepositoryFS) Update(ctx context.Context, id string, in t
 	if in.ContentFiles != nil {
 		candidate.ContentFiles = *in.ContentFiles
 	}
+	if in.Royalty != nil {
+		// mint 済みかどうかは更新前の状態で判定する。
+		if err := candidate.SetRoyalty(*in.Royalty); err != nil {
+			return nil, err
+		}
+	}
 	if in.AssigneeID != nil {
 		candidate.AssigneeID = *in.AssigneeID
 	}
@@ -335,6 +343,9 @@ func (r *TokenBlueprintRepositoryFS) Update(ctx context.Context, id string, in t
 	if in.Minted != nil {
 		updates = append(updates, firestore.Update{Path: "minted", Value: *in.Minted})
 	}
+	if in.Royalty != nil {
+		updates = append(updates, firestore.Update{Path: "royalty", Value: toFSRoyalty(*in.Royalty)})
+	}
 	if in.ContentFiles != nil {
 		if err := tbdom.ValidateContentFiles(*in.ContentFiles); err != nil {
 			return nil, err
@@ -468,6 +479,13 @@ type tokenBlueprintRepositoryDoc struct {
 	UpdatedAt       time.Time                                `firestore:"updatedAt"`
 	UpdatedBy       string                                   `firestore:"updatedBy"`
 	MetadataURI     string                                   `firestore:"metadataUri"`
+	Royalty         *tokenBlueprintRepositoryRoyaltyDoc      `firestore:"royalty"`
+}
+
+// royalty を持たない既存 document はロイヤリティなしとして読む。
+type tokenBlueprintRepositoryRoyaltyDoc struct {
+	BasisPoints   int `firestore:"basisPoints"`
+	MinimumAmount int `firestore:"minimumAmount"`
 }
 
 type tokenBlueprintRepositoryContentFileDoc struct {
@@ -526,6 +544,7 @@ func docToTokenBlueprint(doc *firestore.DocumentSnapshot) (tbdom.TokenBlueprint,
 		UpdatedAt:       raw.UpdatedAt,
 		UpdatedBy:       raw.UpdatedBy,
 		MetadataURI:     raw.MetadataURI,
+		Royalty:         fromFSRoyalty(raw.Royalty),
 	}
 
 	if err := validatePersistedTokenBlueprint(tb); err != nil {
@@ -555,6 +574,23 @@ func toFSContentFiles(xs []tbdom.ContentFile) []map[string]any {
 	return out
 }
 
+func toFSRoyalty(p tbdom.RoyaltyPolicy) map[string]any {
+	return map[string]any{
+		"basisPoints":   p.BasisPoints,
+		"minimumAmount": p.MinimumAmount,
+	}
+}
+
+func fromFSRoyalty(raw *tokenBlueprintRepositoryRoyaltyDoc) tbdom.RoyaltyPolicy {
+	if raw == nil {
+		return tbdom.RoyaltyPolicy{}
+	}
+	return tbdom.RoyaltyPolicy{
+		BasisPoints:   raw.BasisPoints,
+		MinimumAmount: raw.MinimumAmount,
+	}
+}
+
 func fromFSContentFiles(xs []tokenBlueprintRepositoryContentFileDoc) ([]tbdom.ContentFile, error) {
 	out := make([]tbdom.ContentFile, 0, len(xs))
 	for i, raw := range xs {
@@ -609,6 +645,9 @@ func validatePersistedTokenBlueprint(tb tbdom.TokenBlueprint) error {
 	if tb.IconSize < 0 {
 		return tbdom.ErrInvalidIconSize
 	}
+	if err := tb.Royalty.Validate(); err != nil {
+		return err
+	}
 
 	hasAnyIconField := tb.IconURL != "" || tb.IconObjectPath != "" || tb.IconFileName != "" || tb.IconContentType != "" || tb.IconSize != 0
 	if hasAnyIconField {
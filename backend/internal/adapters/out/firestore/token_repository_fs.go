@@ -279,6 +279,9 @@ func (r *TokenReaderFS) GetTokenByProductID(ctx context.Context, productID strin
 		TreeAddress:           d.TreeAddress,
 		LeafIndex:             uint64(*d.LeafIndex),
 		CoreCollectionAddress: d.CoreCollectionAddress,
+		ToAddress:             d.ToAddress,
+		MintedAt:              d.MintedAt.UTC(),
+		OnChainTxSignature:    d.OnChainTxSignature,
 	}, nil
 }
 
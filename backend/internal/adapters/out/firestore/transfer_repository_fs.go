@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	outboxdom "narratives/internal/domain/outbox"
 	transferdom "narratives/internal/domain/transfer"
 )
 
@@ -244,6 +246,58 @@ func (r *TransferRepositoryFS) ListByProductID(
 	return out, nil
 }
 
+// ListByFromBrandID returns the Transfer attempts sent from brandID that were
+// created in [from, to), in ascending createdAt order.
+//
+// createdAt is filtered in memory to avoid a composite index.
+func (r *TransferRepositoryFS) ListByFromBrandID(
+	ctx context.Context,
+	brandID string,
+	from time.Time,
+	to time.Time,
+) ([]transferdom.Transfer, error) {
+	if r == nil || r.Client == nil {
+		return nil, ErrTransferRepoNotConfigured
+	}
+	if brandID == "" {
+		return nil, ErrInvalidTransferData
+	}
+
+	iter := r.transfersCol().
+		Where("fromBrandId", "==", brandID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	out := make([]transferdom.Transfer, 0)
+
+	for {
+		snap, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := transferFromSnapshot(snap)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.CreatedAt.Before(from) || !t.CreatedAt.Before(to) {
+			continue
+		}
+
+		out = append(out, *t)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+
+	return out, nil
+}
+
 func (r *TransferRepositoryFS) ResolveTransferredAtByAssetID(
 	ctx context.Context,
 	assetID string,
@@ -561,6 +615,7 @@ func (r *TransferRepositoryFS) Patch(
 			if err != nil {
 				return err
 			}
+			wasSucceeded := t.Status == transferdom.StatusSucceeded
 			if err := t.ApplyPatch(patch); err != nil {
 				return err
 			}
@@ -596,6 +651,21 @@ func (r *TransferRepositoryFS) Patch(
 				return err
 			}
 
+			// succeeded への遷移時だけ TransferSucceeded を同じ transaction で保存する。
+			if !wasSucceeded && t.Status == transferdom.StatusSucceeded {
+				event, err := newTransferSucceededEvent(*t, now)
+				if err != nil {
+					return err
+				}
+				if err := createOutboxEventsTx(
+					r.Client,
+					tx,
+					[]outboxdom.Event{event},
+				); err != nil {
+					return err
+				}
+			}
+
 			updated = *t
 			return nil
 		},
@@ -611,6 +681,34 @@ func (r *TransferRepositoryFS) Patch(
 // Transfer helpers
 // ============================================================
 
+// newTransferSucceededEvent は succeeded に遷移した Transfer の
+// TransferSucceeded イベントを作成します。集約 ID は productId です。
+func newTransferSucceededEvent(
+	t transferdom.Transfer,
+	at time.Time,
+) (outboxdom.Event, error) {
+	txSignature := ""
+	if t.TxSignature != nil {
+		txSignature = *t.TxSignature
+	}
+
+	return outboxdom.NewEvent(
+		outboxdom.EventTransferSucceeded,
+		outboxdom.AggregateTransfer,
+		t.ProductID,
+		outboxdom.TransferSucceededPayload{
+			ProductID:   t.ProductID,
+			Attempt:     t.Attempt,
+			OperationID: t.OperationID,
+			OrderID:     t.OrderID,
+			AvatarID:    t.AvatarID,
+			AssetID:     t.AssetID,
+			TxSignature: txSignature,
+		},
+		at,
+	)
+}
+
 func transferFromSnapshot(
 	snap *firestore.DocumentSnapshot,
 ) (*transferdom.Transfer, error) {
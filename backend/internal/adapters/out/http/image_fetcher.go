@@ -0,0 +1,78 @@
+// backend/internal/adapters/out/http/image_fetcher.go
+package httpout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxFetchedImageBytes はブランドロゴとして取得する画像の上限です。
+const maxFetchedImageBytes = 5 << 20
+
+var (
+	ErrImageURLNotAllowed = errors.New("image fetcher: url is not allowed")
+	ErrImageTooLarge      = errors.New("image fetcher: image is too large")
+)
+
+// ImageFetcher はブランドアイコンなどの画像を取得します（usecase.LabelImageFetcher）。
+//
+// 任意の URL へのリクエスト（SSRF）を避けるため、https かつ許可したホストのみ取得します。
+type ImageFetcher struct {
+	client       *http.Client
+	allowedHosts map[string]struct{}
+}
+
+func NewImageFetcher() *ImageFetcher {
+	return &ImageFetcher{
+		client: &http.Client{Timeout: 10 * time.Second},
+		allowedHosts: map[string]struct{}{
+			"storage.googleapis.com":         {},
+			"firebasestorage.googleapis.com": {},
+		},
+	}
+}
+
+func (f *ImageFetcher) FetchImage(ctx context.Context, rawURL string) ([]byte, error) {
+	if f == nil {
+		return nil, fmt.Errorf("image fetcher is nil")
+	}
+
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Scheme != "https" {
+		return nil, ErrImageURLNotAllowed
+	}
+	if _, ok := f.allowedHosts[strings.ToLower(u.Hostname())]; !ok {
+		return nil, ErrImageURLNotAllowed
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image fetcher: unexpected status %d", resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchedImageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxFetchedImageBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	return b, nil
+}
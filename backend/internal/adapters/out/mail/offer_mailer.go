@@ -0,0 +1,195 @@
+// backend/internal/adapters/out/mail/offer_mailer.go
+package mail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	offeruc "narratives/internal/application/usecase"
+	offerdom "narratives/internal/domain/offer"
+)
+
+const offerSubjectPrefix = "【AMOL】"
+
+// 期限は日本時間で表示する（コンテナに tzdata がなくても動くよう固定オフセット）。
+var offerMailJST = time.FixedZone("JST", 9*60*60)
+
+type OfferEmailClient interface {
+	SendWithResult(
+		ctx context.Context,
+		from string,
+		to string,
+		subject string,
+		body string,
+		idempotencyKey string,
+	) (EmailSendResult, error)
+}
+
+// OfferMailerは、marketのoffer（価格交渉）の状況を当事者へ通知します。
+type OfferMailer struct {
+	client      OfferEmailClient
+	fromAddress string
+}
+
+var _ offeruc.OfferMailerPort = (*OfferMailer)(nil)
+
+func NewOfferMailer(
+	client OfferEmailClient,
+	fromAddress string,
+) *OfferMailer {
+	return &OfferMailer{
+		client:      client,
+		fromAddress: strings.TrimSpace(fromAddress),
+	}
+}
+
+func (m *OfferMailer) SendOfferNotification(
+	ctx context.Context,
+	message offeruc.OfferMailMessage,
+) error {
+	if m == nil {
+		return fmt.Errorf("offer mailer is nil")
+	}
+
+	if m.client == nil {
+		return fmt.Errorf("offer email client is not configured")
+	}
+
+	fromAddress := strings.TrimSpace(m.fromAddress)
+	if fromAddress == "" {
+		return fmt.Errorf("from address is empty")
+	}
+
+	toEmail := strings.ToLower(
+		strings.TrimSpace(message.ToEmail),
+	)
+	if toEmail == "" {
+		return fmt.Errorf("to email is empty")
+	}
+
+	if strings.TrimSpace(message.OfferID) == "" {
+		return fmt.Errorf("offerId is required")
+	}
+
+	title, lead := offerMailTitleAndLead(message)
+	if title == "" {
+		return fmt.Errorf("unsupported offer mail event: %s", message.Event)
+	}
+
+	productName := strings.TrimSpace(message.ProductName)
+	if productName == "" {
+		productName = strings.TrimSpace(message.ResaleID)
+	}
+
+	subject := fmt.Sprintf(
+		"%s%s: %s",
+		offerSubjectPrefix,
+		title,
+		productName,
+	)
+
+	_, err := m.client.SendWithResult(
+		ctx,
+		fromAddress,
+		toEmail,
+		subject,
+		buildOfferMailBody(lead, productName, message),
+		strings.TrimSpace(message.IdempotencyKey),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"send offer notification failed: to=%s: %w",
+			toEmail,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// offerMailTitleAndLeadは、イベントと受信者の立場に応じた件名と本文の冒頭を返します。
+func offerMailTitleAndLead(
+	message offeruc.OfferMailMessage,
+) (string, string) {
+	toSeller := message.Recipient == offerdom.PartySeller
+
+	switch message.Event {
+	case offeruc.OfferMailEventPlaced:
+		return "オファーが届きました",
+			"出品中の商品にオファーが届きました。期限までに承諾・金額の提示・辞退のいずれかを選択してください。"
+
+	case offeruc.OfferMailEventCountered:
+		return "オファーの金額が提示されました",
+			"相手から新しい金額が提示されました。期限までに承諾・金額の提示・辞退のいずれかを選択してください。"
+
+	case offeruc.OfferMailEventAccepted:
+		if toSeller {
+			return "オファーが成立しました",
+				"オファーが成立しました。購入者の決済まで商品は確保されます。"
+		}
+		return "オファーが成立しました",
+			"オファーが成立しました。決済期限までに合意価格でご購入ください。"
+
+	case offeruc.OfferMailEventDeclined:
+		return "オファーが辞退されました",
+			"相手がオファーを辞退しました。"
+
+	case offeruc.OfferMailEventWithdrawn:
+		return "オファーが取り下げられました",
+			"購入者がオファーを取り下げました。"
+
+	case offeruc.OfferMailEventExpired:
+		if toSeller {
+			return "オファーの期限が切れました",
+				"オファーの期限が切れました。確保されていた商品は出品中に戻ります。"
+		}
+		return "オファーの期限が切れました",
+			"オファーの期限が切れました。"
+
+	case offeruc.OfferMailEventSuperseded:
+		return "オファーが終了しました",
+			"この商品は別のオファーで成立したため、オファーは終了しました。"
+
+	default:
+		return "", ""
+	}
+}
+
+func buildOfferMailBody(
+	lead string,
+	productName string,
+	message offeruc.OfferMailMessage,
+) string {
+	var builder strings.Builder
+
+	builder.WriteString(lead)
+	builder.WriteString("\n\n")
+	builder.WriteString("商品:\n")
+	builder.WriteString(productName)
+	builder.WriteString("\n\n")
+	builder.WriteString(fmt.Sprintf("オファーID: %s\n", message.OfferID))
+	builder.WriteString(fmt.Sprintf("出品価格: %d円\n", message.ListPrice))
+	builder.WriteString(fmt.Sprintf("提示金額: %d円\n", message.Amount))
+
+	if message.ExpiresAt != nil && !message.ExpiresAt.IsZero() {
+		label := "返答期限"
+		if message.Event == offeruc.OfferMailEventAccepted {
+			label = "決済期限"
+		}
+
+		builder.WriteString(fmt.Sprintf(
+			"%s: %s\n",
+			label,
+			message.ExpiresAt.In(offerMailJST).Format("2006/01/02 15:04 JST"),
+		))
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString("オファーの状況はAMOLのマイページからご確認ください。\n\n")
+	builder.WriteString("--\n")
+	builder.WriteString("AMOL")
+
+	return builder.String()
+}
@@ -8,6 +8,7 @@ import (
 
 	orderdispatchuc "narratives/internal/application/usecase"
 	orderdom "narratives/internal/domain/order"
+	transportationdom "narratives/internal/domain/transportation"
 )
 
 const orderDispatchNotificationSubject = "【AMOL】ご注文の商品を発送しました"
@@ -151,8 +152,11 @@ func normalizeOrderDispatchNotificationMailItems(
 		normalized = append(
 			normalized,
 			orderdispatchuc.OrderDispatchNotificationMailItem{
-				ProductName: productName,
-				Qty:         item.Qty,
+				ProductName:    productName,
+				Qty:            item.Qty,
+				Carrier:        item.Carrier,
+				TrackingNumber: strings.TrimSpace(item.TrackingNumber),
+				TrackingURL:    strings.TrimSpace(item.TrackingURL),
 			},
 		)
 	}
@@ -180,6 +184,35 @@ func buildOrderDispatchNotificationMailBody(
 				item.Qty,
 			),
 		)
+
+		if carrierName := orderDispatchCarrierDisplayName(
+			item.Carrier,
+		); carrierName != "" {
+			builder.WriteString(
+				fmt.Sprintf(
+					"  配送業者: %s\n",
+					carrierName,
+				),
+			)
+		}
+
+		if item.TrackingNumber != "" {
+			builder.WriteString(
+				fmt.Sprintf(
+					"  お問い合わせ番号: %s\n",
+					item.TrackingNumber,
+				),
+			)
+		}
+
+		if item.TrackingURL != "" {
+			builder.WriteString(
+				fmt.Sprintf(
+					"  配送状況: %s\n",
+					item.TrackingURL,
+				),
+			)
+		}
 	}
 
 	builder.WriteString("\n")
@@ -189,3 +222,24 @@ func buildOrderDispatchNotificationMailBody(
 
 	return builder.String()
 }
+
+func orderDispatchCarrierDisplayName(
+	carrier transportationdom.Carrier,
+) string {
+	switch carrier {
+	case transportationdom.CarrierYamato:
+		return "ヤマト運輸"
+	case transportationdom.CarrierSagawa:
+		return "佐川急便"
+	case transportationdom.CarrierPost:
+		return "日本郵便"
+	case transportationdom.CarrierEMS:
+		return "日本郵便（EMS）"
+	case transportationdom.CarrierEPacket:
+		return "日本郵便（国際eパケット）"
+	case transportationdom.CarrierCustom:
+		return "自社配送"
+	default:
+		return ""
+	}
+}
@@ -4,6 +4,7 @@ package mail
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 
 	branddom "narratives/internal/domain/brand"
@@ -39,6 +40,11 @@ type OrderCompanyGetter interface {
 	GetByID(ctx context.Context, id string) (companydom.Company, error)
 }
 
+// OrderInvoicePDFRenderer renders the receipt PDF attached to the confirmation mail.
+type OrderInvoicePDFRenderer interface {
+	RenderPDF(ctx context.Context, order orderdom.Order) ([]byte, error)
+}
+
 type OrderMailer struct {
 	client               *ResendClient
 	modelRepo            OrderModelGetter
@@ -47,6 +53,7 @@ type OrderMailer struct {
 	tokenBlueprintRepo   OrderTokenBlueprintGetter
 	brandRepo            OrderBrandGetter
 	companyRepo          OrderCompanyGetter
+	invoiceRenderer      OrderInvoicePDFRenderer
 }
 
 func NewOrderMailer(
@@ -69,6 +76,16 @@ func NewOrderMailer(
 	}
 }
 
+// WithInvoiceAttachment attaches the receipt PDF to the confirmation mail.
+func (m *OrderMailer) WithInvoiceAttachment(renderer OrderInvoicePDFRenderer) *OrderMailer {
+	if m == nil {
+		return nil
+	}
+
+	m.invoiceRenderer = renderer
+	return m
+}
+
 func (m *OrderMailer) SendOrderConfirmation(ctx context.Context, from, to string, ord orderdom.Order) error {
 	if m == nil || m.client == nil {
 		return fmt.Errorf("order mailer is nil")
@@ -110,7 +127,31 @@ func (m *OrderMailer) SendOrderConfirmation(ctx context.Context, from, to string
 		companyErrorsByID,
 	)
 
-	return m.client.Send(ctx, from, to, subject, body)
+	if m.invoiceRenderer == nil {
+		return m.client.Send(ctx, from, to, subject, body)
+	}
+
+	// 領収書の生成に失敗しても注文確認メールは送る。
+	pdf, err := m.invoiceRenderer.RenderPDF(ctx, ord)
+	if err != nil {
+		log.Printf("[order_mailer] render receipt failed: orderId=%s err=%v", ord.ID, err)
+		return m.client.Send(ctx, from, to, subject, body)
+	}
+
+	return m.client.SendWithAttachments(
+		ctx,
+		from,
+		to,
+		subject,
+		body,
+		[]MailAttachment{
+			{
+				Filename:    fmt.Sprintf("receipt-%s.pdf", ord.ID),
+				ContentType: "application/pdf",
+				Content:     pdf,
+			},
+		},
+	)
 }
 
 func (m *OrderMailer) loadOrderModels(
@@ -367,11 +408,16 @@ func buildOrderConfirmationMailBody(
 
 	totalQty := 0
 	productSubtotal := 0
+	discountAmount := ord.DiscountAmount()
+	shippingAmount :=
+		ord.ShippingQuoteSnapshot.Amount
+
 	taxableAmount8 := 0
 	taxableAmount10 :=
-		ord.ShippingQuoteSnapshot.Amount
+		shippingAmount -
+			ord.ShippingDiscountAmount()
 
-	for _, it := range ord.Items {
+	for i, it := range ord.Items {
 		lineAmount :=
 			it.Price *
 				it.Qty
@@ -379,6 +425,8 @@ func buildOrderConfirmationMailBody(
 		totalQty += it.Qty
 		productSubtotal += lineAmount
 
+		lineAmount -= ord.ItemDiscountAmount(i)
+
 		switch it.ConsumptionTaxRate {
 		case orderdom.ConsumptionTaxRateReduced:
 			taxableAmount8 +=
@@ -390,9 +438,6 @@ func buildOrderConfirmationMailBody(
 		}
 	}
 
-	shippingAmount :=
-		ord.ShippingQuoteSnapshot.Amount
-
 	taxAmount8 :=
 		taxableAmount8 *
 			orderdom.ConsumptionTaxRateReduced /
@@ -409,7 +454,8 @@ func buildOrderConfirmationMailBody(
 
 	totalAmount :=
 		productSubtotal +
-			shippingAmount +
+			shippingAmount -
+			discountAmount +
 			taxAmount
 
 	b.WriteString("ご注文ありがとうございます。\n")
@@ -420,7 +466,16 @@ func buildOrderConfirmationMailBody(
 	b.WriteString(fmt.Sprintf("商品点数: %d点\n", totalQty))
 	b.WriteString(fmt.Sprintf("商品小計（税抜）: %d円\n", productSubtotal))
 	b.WriteString(fmt.Sprintf("配送料（税抜）: %d円\n", shippingAmount))
+	if discountAmount > 0 {
+		b.WriteString(fmt.Sprintf("クーポン値引き: -%d円\n", discountAmount))
+	}
 	b.WriteString(fmt.Sprintf("消費税: %d円\n", taxAmount))
+	if taxableAmount10 > 0 {
+		b.WriteString(fmt.Sprintf("  10%%対象: %d円（消費税 %d円）\n", taxableAmount10, taxAmount10))
+	}
+	if taxableAmount8 > 0 {
+		b.WriteString(fmt.Sprintf("  8%%対象: %d円（消費税 %d円）\n", taxableAmount8, taxAmount8))
+	}
 	b.WriteString(fmt.Sprintf("合計金額（税込）: %d円\n", totalAmount))
 	b.WriteString("\n")
 
@@ -52,6 +52,55 @@ func (c *ResendClient) SendWithResult(
 	subject string,
 	body string,
 	idempotencyKey string,
+) (EmailSendResult, error) {
+	return c.send(
+		ctx,
+		from,
+		to,
+		subject,
+		body,
+		idempotencyKey,
+		nil,
+	)
+}
+
+// MailAttachmentはメールに添付するファイルです。
+type MailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// SendWithAttachmentsは添付ファイル付きで送信します（領収書PDFなど）。
+func (c *ResendClient) SendWithAttachments(
+	ctx context.Context,
+	from string,
+	to string,
+	subject string,
+	body string,
+	attachments []MailAttachment,
+) error {
+	_, err := c.send(
+		ctx,
+		from,
+		to,
+		subject,
+		body,
+		"",
+		attachments,
+	)
+
+	return err
+}
+
+func (c *ResendClient) send(
+	ctx context.Context,
+	from string,
+	to string,
+	subject string,
+	body string,
+	idempotencyKey string,
+	attachments []MailAttachment,
 ) (EmailSendResult, error) {
 	if c == nil {
 		return EmailSendResult{
@@ -99,6 +148,18 @@ func (c *ResendClient) SendWithResult(
 		),
 	}
 
+	for _, attachment := range attachments {
+		if len(attachment.Content) == 0 {
+			continue
+		}
+
+		params.Attachments = append(params.Attachments, &resend.Attachment{
+			Content:     attachment.Content,
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+		})
+	}
+
 	resp, err := c.client.Emails.SendWithContext(
 		ctx,
 		params,
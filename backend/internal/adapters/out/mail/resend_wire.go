@@ -73,3 +73,35 @@ func NewAuthMailerWithResend() *AuthMailer {
 		fromAddress,
 	)
 }
+
+// NewStockAlertMailerWithResendは、Resendを使ったStockAlertMailerを生成します。
+//
+// - RESEND_API_KEY: ResendのAPIキー
+// - RESEND_FROM  : 送信元メールアドレス
+func NewStockAlertMailerWithResend() *StockAlertMailer {
+	apiKey := strings.TrimSpace(os.Getenv(envResendAPIKey))
+	fromAddress := strings.TrimSpace(os.Getenv(envResendFrom))
+
+	client := NewResendClient(apiKey)
+
+	return NewStockAlertMailer(
+		client,
+		fromAddress,
+	)
+}
+
+// NewOfferMailerWithResendは、Resendを使ったOfferMailerを生成します。
+//
+// - RESEND_API_KEY: ResendのAPIキー
+// - RESEND_FROM  : 送信元メールアドレス
+func NewOfferMailerWithResend() *OfferMailer {
+	apiKey := strings.TrimSpace(os.Getenv(envResendAPIKey))
+	fromAddress := strings.TrimSpace(os.Getenv(envResendFrom))
+
+	client := NewResendClient(apiKey)
+
+	return NewOfferMailer(
+		client,
+		fromAddress,
+	)
+}
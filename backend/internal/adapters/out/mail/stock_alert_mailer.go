@@ -0,0 +1,125 @@
+// backend/internal/adapters/out/mail/stock_alert_mailer.go
+package mail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	stockalertuc "narratives/internal/application/usecase"
+)
+
+const stockAlertSubjectPrefix = "【AMOL】在庫アラート"
+
+type StockAlertEmailClient interface {
+	SendWithResult(
+		ctx context.Context,
+		from string,
+		to string,
+		subject string,
+		body string,
+		idempotencyKey string,
+	) (EmailSendResult, error)
+}
+
+// StockAlertMailerは、在庫が閾値以下になったことをブランド担当者へ通知します。
+type StockAlertMailer struct {
+	client      StockAlertEmailClient
+	fromAddress string
+}
+
+var _ stockalertuc.StockAlertMailerPort = (*StockAlertMailer)(nil)
+
+func NewStockAlertMailer(
+	client StockAlertEmailClient,
+	fromAddress string,
+) *StockAlertMailer {
+	return &StockAlertMailer{
+		client:      client,
+		fromAddress: strings.TrimSpace(fromAddress),
+	}
+}
+
+func (m *StockAlertMailer) SendStockAlert(
+	ctx context.Context,
+	message stockalertuc.StockAlertMailMessage,
+) error {
+	if m == nil {
+		return fmt.Errorf("stock alert mailer is nil")
+	}
+
+	if m.client == nil {
+		return fmt.Errorf("stock alert email client is not configured")
+	}
+
+	fromAddress := strings.TrimSpace(m.fromAddress)
+	if fromAddress == "" {
+		return fmt.Errorf("from address is empty")
+	}
+
+	toEmail := strings.ToLower(
+		strings.TrimSpace(message.ToEmail),
+	)
+	if toEmail == "" {
+		return fmt.Errorf("to email is empty")
+	}
+
+	inventoryID := strings.TrimSpace(message.InventoryID)
+	modelID := strings.TrimSpace(message.ModelID)
+	if inventoryID == "" || modelID == "" {
+		return fmt.Errorf("inventoryId and modelId are required")
+	}
+
+	productName := strings.TrimSpace(message.ProductName)
+	if productName == "" {
+		productName = inventoryID
+	}
+
+	subject := fmt.Sprintf(
+		"%s: %s",
+		stockAlertSubjectPrefix,
+		productName,
+	)
+
+	_, err := m.client.SendWithResult(
+		ctx,
+		fromAddress,
+		toEmail,
+		subject,
+		buildStockAlertMailBody(productName, message),
+		strings.TrimSpace(message.IdempotencyKey),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"send stock alert failed: to=%s: %w",
+			toEmail,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func buildStockAlertMailBody(
+	productName string,
+	message stockalertuc.StockAlertMailMessage,
+) string {
+	var builder strings.Builder
+
+	builder.WriteString("販売可能な在庫数が設定した閾値以下になりました。\n\n")
+	builder.WriteString("商品:\n")
+	builder.WriteString(productName)
+	builder.WriteString("\n\n")
+	builder.WriteString(fmt.Sprintf("在庫ID: %s\n", message.InventoryID))
+	builder.WriteString(fmt.Sprintf("モデルID: %s\n\n", message.ModelID))
+	builder.WriteString(fmt.Sprintf("販売可能数: %d\n", message.Available))
+	builder.WriteString(fmt.Sprintf("閾値: %d\n", message.Threshold))
+	builder.WriteString(fmt.Sprintf("在庫数: %d\n", message.Accumulation))
+	builder.WriteString(fmt.Sprintf("引当数: %d\n", message.ReservedCount))
+	builder.WriteString("\n")
+	builder.WriteString("在庫の状況はAMOL Consoleからご確認ください。\n\n")
+	builder.WriteString("--\n")
+	builder.WriteString("AMOL")
+
+	return builder.String()
+}
@@ -0,0 +1,346 @@
+// backend/internal/adapters/out/memory/account_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fscommon "narratives/internal/adapters/out/firestore/common"
+	accdom "narratives/internal/domain/account"
+)
+
+// AccountRepositoryMem は account.Repository の in-memory 実装。
+//
+// Firestore adapter の List / Count は filter を無視するが、
+// テストで絞り込みを確認できるよう、ここでは主要な filter を適用する。
+type AccountRepositoryMem struct {
+	mu  sync.Mutex
+	ids idSequence
+
+	accounts map[string]accdom.Account
+
+	now func() time.Time
+}
+
+var _ accdom.Repository = (*AccountRepositoryMem)(nil)
+
+func NewAccountRepositoryMem() *AccountRepositoryMem {
+	return &AccountRepositoryMem{
+		accounts: map[string]accdom.Account{},
+		now:      time.Now,
+	}
+}
+
+// List は createdAt desc, id desc の順で返す。
+func (r *AccountRepositoryMem) List(
+	_ context.Context,
+	filter accdom.Filter,
+	_ accdom.Sort,
+	page accdom.Page,
+) (accdom.PageResult[accdom.Account], error) {
+	pageNum, perPage, offset := fscommon.NormalizePage(
+		page.Number,
+		page.PerPage,
+		50,
+		200,
+	)
+
+	r.mu.Lock()
+	matched := r.filterLocked(filter)
+	r.mu.Unlock()
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+
+	return accdom.PageResult[accdom.Account]{
+		Items:      paginate(matched, offset, perPage),
+		TotalCount: len(matched),
+		TotalPages: fscommon.ComputeTotalPages(len(matched), perPage),
+		Page:       pageNum,
+		PerPage:    perPage,
+	}, nil
+}
+
+// ListByCursor は id 昇順で cursor paging する。
+func (r *AccountRepositoryMem) ListByCursor(
+	_ context.Context,
+	filter accdom.Filter,
+	_ accdom.Sort,
+	cpage accdom.CursorPage,
+) (accdom.CursorPageResult[accdom.Account], error) {
+	limit := cpage.Limit
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	r.mu.Lock()
+	matched := r.filterLocked(filter)
+	r.mu.Unlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].ID < matched[j].ID
+	})
+
+	items := make([]accdom.Account, 0, limit)
+	for _, a := range matched {
+		if cpage.After != "" && a.ID <= cpage.After {
+			continue
+		}
+		items = append(items, a)
+	}
+
+	var next *string
+	if len(items) > limit {
+		cursor := items[limit-1].ID
+		items = items[:limit]
+		next = &cursor
+	}
+
+	return accdom.CursorPageResult[accdom.Account]{
+		Items:      items,
+		NextCursor: next,
+		Limit:      limit,
+	}, nil
+}
+
+func (r *AccountRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (accdom.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.accounts[id]
+	if !ok {
+		return accdom.Account{}, accdom.ErrNotFound
+	}
+
+	return cloneAccount(a), nil
+}
+
+func (r *AccountRepositoryMem) Exists(
+	_ context.Context,
+	id string,
+) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.accounts[id]
+
+	return ok, nil
+}
+
+func (r *AccountRepositoryMem) Count(
+	_ context.Context,
+	filter accdom.Filter,
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.filterLocked(filter)), nil
+}
+
+func (r *AccountRepositoryMem) Create(
+	_ context.Context,
+	a accdom.Account,
+) (accdom.Account, error) {
+	now := r.now().UTC()
+	a.CreatedAt = now
+	a.UpdatedAt = now
+
+	if a.ID == "" {
+		a.ID = r.ids.newID("account")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.accounts[a.ID]; exists {
+		return accdom.Account{}, accdom.ErrConflict
+	}
+
+	r.accounts[a.ID] = cloneAccount(a)
+
+	return cloneAccount(a), nil
+}
+
+// Update は nil でない field だけを反映し、常に updatedAt を更新する。
+func (r *AccountRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	patch accdom.AccountPatch,
+) (accdom.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.accounts[id]
+	if !ok {
+		return accdom.Account{}, accdom.ErrNotFound
+	}
+
+	a := cloneAccount(existing)
+
+	if patch.BankName != nil {
+		a.BankName = *patch.BankName
+	}
+	if patch.BranchName != nil {
+		a.BranchName = *patch.BranchName
+	}
+	if patch.AccountNumber != nil {
+		a.AccountNumber = *patch.AccountNumber
+	}
+	if patch.AccountType != nil {
+		a.AccountType = *patch.AccountType
+	}
+	if patch.Currency != nil {
+		a.Currency = *patch.Currency
+	}
+	if patch.Status != nil {
+		a.Status = *patch.Status
+	}
+	if patch.BankCode != nil {
+		a.BankCode = *patch.BankCode
+	}
+	if patch.BranchCode != nil {
+		a.BranchCode = *patch.BranchCode
+	}
+	if patch.HolderNameKana != nil {
+		a.HolderNameKana = *patch.HolderNameKana
+	}
+	if patch.UpdatedBy != nil {
+		a.UpdatedBy = cloneStringPtr(patch.UpdatedBy)
+	}
+	if patch.DeletedAt != nil {
+		a.DeletedAt = cloneTimePtr(patch.DeletedAt)
+	}
+	if patch.DeletedBy != nil {
+		a.DeletedBy = cloneStringPtr(patch.DeletedBy)
+	}
+
+	a.UpdatedAt = r.now().UTC()
+
+	r.accounts[id] = cloneAccount(a)
+
+	return cloneAccount(a), nil
+}
+
+func (r *AccountRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.accounts[id]; !ok {
+		return accdom.ErrNotFound
+	}
+
+	delete(r.accounts, id)
+
+	return nil
+}
+
+// Save は AccountRepositoryFS と同じく upsert する。
+func (r *AccountRepositoryMem) Save(
+	_ context.Context,
+	a accdom.Account,
+	_ *accdom.SaveOptions,
+) (accdom.Account, error) {
+	now := r.now().UTC()
+	if a.ID == "" {
+		a.ID = r.ids.newID("account")
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = now
+	}
+	a.UpdatedAt = now
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.accounts[a.ID] = cloneAccount(a)
+
+	return cloneAccount(a), nil
+}
+
+// filterLocked は r.mu を保持した状態で呼び出す。
+func (r *AccountRepositoryMem) filterLocked(f accdom.Filter) []accdom.Account {
+	q := strings.ToLower(strings.TrimSpace(f.SearchQuery))
+
+	out := make([]accdom.Account, 0)
+	for _, id := range sortedKeys(r.accounts) {
+		a := r.accounts[id]
+
+		if f.MemberID != nil && a.MemberID != *f.MemberID {
+			continue
+		}
+		if f.Currency != nil && a.Currency != *f.Currency {
+			continue
+		}
+		if len(f.Statuses) > 0 && !containsAccountStatus(f.Statuses, a.Status) {
+			continue
+		}
+		if len(f.Types) > 0 && !containsAccountType(f.Types, a.AccountType) {
+			continue
+		}
+		if f.AccountNumberMin != nil && a.AccountNumber < *f.AccountNumberMin {
+			continue
+		}
+		if f.AccountNumberMax != nil && a.AccountNumber > *f.AccountNumberMax {
+			continue
+		}
+		if f.Deleted != nil && *f.Deleted != (a.DeletedAt != nil) {
+			continue
+		}
+		if q != "" && !accountMatchesQuery(a, q) {
+			continue
+		}
+
+		out = append(out, cloneAccount(a))
+	}
+
+	return out
+}
+
+func accountMatchesQuery(a accdom.Account, q string) bool {
+	for _, v := range []string{a.ID, a.BankName, a.BranchName, a.Currency} {
+		if strings.Contains(strings.ToLower(v), q) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAccountStatus(values []accdom.AccountStatus, target accdom.AccountStatus) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAccountType(values []accdom.AccountType, target accdom.AccountType) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneAccount(a accdom.Account) accdom.Account {
+	a.CreatedBy = cloneStringPtr(a.CreatedBy)
+	a.UpdatedBy = cloneStringPtr(a.UpdatedBy)
+	a.DeletedAt = cloneTimePtr(a.DeletedAt)
+	a.DeletedBy = cloneStringPtr(a.DeletedBy)
+	return a
+}
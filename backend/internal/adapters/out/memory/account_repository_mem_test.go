@@ -0,0 +1,131 @@
+// backend/internal/adapters/out/memory/account_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"narratives/internal/adapters/out/memory"
+	accdom "narratives/internal/domain/account"
+)
+
+func TestAccountRepositoryMem_CreateAndUpdate(t *testing.T) {
+	bankName := "new bank"
+
+	tests := []struct {
+		name    string
+		run     func(ctx context.Context, repo *memory.AccountRepositoryMem) error
+		wantErr error
+	}{
+		{
+			name: "create does not overwrite an existing account",
+			run: func(ctx context.Context, repo *memory.AccountRepositoryMem) error {
+				_, err := repo.Create(ctx, accdom.Account{ID: "account_1", MemberID: "member_2"})
+				return err
+			},
+			wantErr: accdom.ErrConflict,
+		},
+		{
+			name: "update does not create a missing account",
+			run: func(ctx context.Context, repo *memory.AccountRepositoryMem) error {
+				_, err := repo.Update(ctx, "account_missing", accdom.AccountPatch{BankName: &bankName})
+				return err
+			},
+			wantErr: accdom.ErrNotFound,
+		},
+		{
+			name: "update applies the patch",
+			run: func(ctx context.Context, repo *memory.AccountRepositoryMem) error {
+				got, err := repo.Update(ctx, "account_1", accdom.AccountPatch{BankName: &bankName})
+				if err != nil {
+					return err
+				}
+				if got.BankName != bankName || got.MemberID != "member_1" {
+					t.Fatalf("updated account = %+v", got)
+				}
+				return nil
+			},
+		},
+		{
+			name: "delete of a missing account",
+			run: func(ctx context.Context, repo *memory.AccountRepositoryMem) error {
+				return repo.Delete(ctx, "account_missing")
+			},
+			wantErr: accdom.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewAccountRepositoryMem()
+
+			if _, err := repo.Create(ctx, accdom.Account{
+				ID:       "account_1",
+				MemberID: "member_1",
+				BankName: "bank",
+			}); err != nil {
+				t.Fatalf("seed account: %v", err)
+			}
+
+			err := tt.run(ctx, repo)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repo.GetByID(ctx, "account_1")
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if stored.MemberID != "member_1" {
+				t.Fatalf("stored memberID = %q, want member_1", stored.MemberID)
+			}
+		})
+	}
+}
+
+func TestAccountRepositoryMem_ListFilter(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAccountRepositoryMem()
+
+	for _, a := range []accdom.Account{
+		{ID: "account_1", MemberID: "member_1"},
+		{ID: "account_2", MemberID: "member_1"},
+		{ID: "account_3", MemberID: "member_2"},
+	} {
+		if _, err := repo.Create(ctx, a); err != nil {
+			t.Fatalf("seed %s: %v", a.ID, err)
+		}
+	}
+
+	memberID := "member_1"
+	tests := []struct {
+		name   string
+		filter accdom.Filter
+		want   int
+	}{
+		{name: "all", filter: accdom.Filter{}, want: 3},
+		{name: "by member", filter: accdom.Filter{MemberID: &memberID}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.List(ctx, tt.filter, accdom.Sort{}, accdom.Page{})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if got.TotalCount != tt.want || len(got.Items) != tt.want {
+				t.Fatalf("List = %d items (total %d), want %d", len(got.Items), got.TotalCount, tt.want)
+			}
+
+			count, err := repo.Count(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Count: %v", err)
+			}
+			if count != tt.want {
+				t.Fatalf("Count = %d, want %d", count, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// backend/internal/adapters/out/memory/audit_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	auditdom "narratives/internal/domain/audit"
+)
+
+// AuditRepositoryMem は audit.RepositoryPort の in-memory 実装。
+// 監査ログは追記のみで、Append のたびに新しい ID を払い出す。
+type AuditRepositoryMem struct {
+	mu      sync.Mutex
+	ids     idSequence
+	entries []auditdom.Entry
+}
+
+var _ auditdom.RepositoryPort = (*AuditRepositoryMem)(nil)
+
+func NewAuditRepositoryMem() *AuditRepositoryMem {
+	return &AuditRepositoryMem{}
+}
+
+func (r *AuditRepositoryMem) Append(
+	_ context.Context,
+	e auditdom.Entry,
+) (auditdom.Entry, error) {
+	if err := e.Validate(); err != nil {
+		return auditdom.Entry{}, err
+	}
+
+	e.ID = r.ids.newID("audit")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, cloneAuditEntry(e))
+
+	return cloneAuditEntry(e), nil
+}
+
+// Search は createdAt desc の順で最大 filter.Limit 件返す。
+func (r *AuditRepositoryMem) Search(
+	_ context.Context,
+	filter auditdom.Filter,
+) ([]auditdom.Entry, error) {
+	companyID := strings.TrimSpace(filter.CompanyID)
+	if companyID == "" {
+		return nil, auditdom.ErrInvalidCompanyID
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = auditdom.DefaultSearchLimit
+	}
+	if limit > auditdom.MaxSearchLimit {
+		limit = auditdom.MaxSearchLimit
+	}
+
+	out := make([]auditdom.Entry, 0)
+
+	r.mu.Lock()
+	for _, e := range r.entries {
+		if e.CompanyID != companyID || !matchAuditFilter(e, filter) {
+			continue
+		}
+		out = append(out, cloneAuditEntry(e))
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+func matchAuditFilter(e auditdom.Entry, f auditdom.Filter) bool {
+	if f.EntityType != "" && e.EntityType != f.EntityType {
+		return false
+	}
+	if f.EntityID != "" && e.EntityID != f.EntityID {
+		return false
+	}
+	if f.ActorMemberID != "" && e.ActorMemberID != f.ActorMemberID {
+		return false
+	}
+	if !f.From.IsZero() && e.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !e.CreatedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+func cloneAuditEntry(e auditdom.Entry) auditdom.Entry {
+	out := e
+
+	if e.Changes != nil {
+		out.Changes = make([]auditdom.Change, len(e.Changes))
+		copy(out.Changes, e.Changes)
+	}
+
+	return out
+}
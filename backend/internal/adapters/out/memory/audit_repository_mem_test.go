@@ -0,0 +1,64 @@
+// backend/internal/adapters/out/memory/audit_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	auditdom "narratives/internal/domain/audit"
+)
+
+func TestAuditRepositoryMem_Search(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAuditRepositoryMem()
+	base := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	ids := make([]string, 0, 3)
+	for i, in := range []auditdom.NewEntryInput{
+		{CompanyID: "company_1", ActorMemberID: "member_1", EntityType: auditdom.EntityBrand, EntityID: "brand_1", Action: auditdom.ActionCreate},
+		{CompanyID: "company_1", ActorMemberID: "member_2", EntityType: auditdom.EntityBrand, EntityID: "brand_1", Action: auditdom.ActionUpdate},
+		{CompanyID: "company_2", ActorMemberID: "member_3", EntityType: auditdom.EntityMember, EntityID: "member_1", Action: auditdom.ActionDelete},
+	} {
+		e, err := auditdom.NewEntry(in, base.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatalf("NewEntry: %v", err)
+		}
+		stored, err := repo.Append(ctx, e)
+		if err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+		ids = append(ids, stored.ID)
+	}
+
+	tests := []struct {
+		name    string
+		filter  auditdom.Filter
+		want    []string
+		wantErr error
+	}{
+		{name: "company scope is required", filter: auditdom.Filter{}, wantErr: auditdom.ErrInvalidCompanyID},
+		{name: "newest first", filter: auditdom.Filter{CompanyID: "company_1"}, want: []string{ids[1], ids[0]}},
+		{name: "actor", filter: auditdom.Filter{CompanyID: "company_1", ActorMemberID: "member_1"}, want: []string{ids[0]}},
+		{name: "limit", filter: auditdom.Filter{CompanyID: "company_1", Limit: 1}, want: []string{ids[1]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.Search(ctx, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("len = %d, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i].ID != tt.want[i] {
+					t.Fatalf("got[%d] = %q, want %q", i, got[i].ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
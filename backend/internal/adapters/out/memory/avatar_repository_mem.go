@@ -0,0 +1,197 @@
+// backend/internal/adapters/out/memory/avatar_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	avdom "narratives/internal/domain/avatar"
+)
+
+var (
+	ErrAvatarNotFound = errors.New(
+		"avatar_repository_mem: not found",
+	)
+	ErrAvatarConflict = errors.New(
+		"avatar_repository_mem: conflict",
+	)
+)
+
+// AvatarRepositoryMem は avatar.Repository の in-memory 実装。
+//
+// Firestore adapter と同じく、walletAddress は一度だけ設定できる。
+// 設定済みの avatar に walletAddress を渡した Update は ErrAvatarConflict を返す。
+type AvatarRepositoryMem struct {
+	mu  sync.Mutex
+	ids idSequence
+
+	avatars map[string]avdom.Avatar
+
+	now func() time.Time
+}
+
+var _ avdom.Repository = (*AvatarRepositoryMem)(nil)
+
+func NewAvatarRepositoryMem() *AvatarRepositoryMem {
+	return &AvatarRepositoryMem{
+		avatars: map[string]avdom.Avatar{},
+		now:     time.Now,
+	}
+}
+
+func (r *AvatarRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (avdom.Avatar, error) {
+	if id == "" {
+		return avdom.Avatar{}, ErrAvatarNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.avatars[id]
+	if !ok {
+		return avdom.Avatar{}, ErrAvatarNotFound
+	}
+
+	return cloneAvatar(a), nil
+}
+
+func (r *AvatarRepositoryMem) GetByUserID(
+	_ context.Context,
+	userID string,
+) (avdom.Avatar, error) {
+	if userID == "" {
+		return avdom.Avatar{}, ErrAvatarNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sortedKeys(r.avatars) {
+		a := r.avatars[id]
+		if a.UserID == userID {
+			return cloneAvatar(a), nil
+		}
+	}
+
+	return avdom.Avatar{}, ErrAvatarNotFound
+}
+
+func (r *AvatarRepositoryMem) Create(
+	_ context.Context,
+	a avdom.Avatar,
+) (avdom.Avatar, error) {
+	now := r.now().UTC()
+
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = now
+	}
+	if a.UpdatedAt.IsZero() {
+		a.UpdatedAt = now
+	}
+	if a.ID == "" {
+		a.ID = r.ids.newID("avatar")
+	}
+
+	if err := a.Validate(); err != nil {
+		return avdom.Avatar{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.avatars[a.ID]; exists {
+		return avdom.Avatar{}, ErrAvatarConflict
+	}
+
+	r.avatars[a.ID] = cloneAvatar(a)
+
+	return cloneAvatar(a), nil
+}
+
+func (r *AvatarRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	patch avdom.AvatarPatch,
+) (avdom.Avatar, error) {
+	if id == "" {
+		return avdom.Avatar{}, ErrAvatarNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.avatars[id]
+	if !ok {
+		return avdom.Avatar{}, ErrAvatarNotFound
+	}
+
+	if patch.WalletAddress != nil &&
+		current.WalletAddress != nil &&
+		*current.WalletAddress != "" {
+		return avdom.Avatar{}, ErrAvatarConflict
+	}
+
+	if patch.AvatarName == nil &&
+		patch.AvatarIcon == nil &&
+		patch.WalletAddress == nil &&
+		patch.Profile == nil &&
+		patch.ExternalLink == nil {
+		return cloneAvatar(current), nil
+	}
+
+	next, err := current.ApplyPatch(patch, r.now())
+	if err != nil {
+		return avdom.Avatar{}, err
+	}
+
+	r.avatars[id] = cloneAvatar(next)
+
+	return cloneAvatar(next), nil
+}
+
+func (r *AvatarRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if id == "" {
+		return ErrAvatarNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.avatars[id]; !ok {
+		return ErrAvatarNotFound
+	}
+
+	delete(r.avatars, id)
+
+	return nil
+}
+
+func (r *AvatarRepositoryMem) ExistsByUserID(
+	ctx context.Context,
+	userID string,
+) (bool, error) {
+	if _, err := r.GetByUserID(ctx, userID); err != nil {
+		if errors.Is(err, ErrAvatarNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func cloneAvatar(a avdom.Avatar) avdom.Avatar {
+	a.AvatarIcon = cloneStringPtr(a.AvatarIcon)
+	a.WalletAddress = cloneStringPtr(a.WalletAddress)
+	a.Profile = cloneStringPtr(a.Profile)
+	a.ExternalLink = cloneStringPtr(a.ExternalLink)
+	return a
+}
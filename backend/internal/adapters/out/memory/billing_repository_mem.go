@@ -0,0 +1,215 @@
+// backend/internal/adapters/out/memory/billing_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	billingdom "narratives/internal/domain/billing"
+)
+
+// BillingRepositoryMem は billing.RepositoryPort の in-memory 実装。
+//
+// Firestore と同じく、プランは docId = companyId、明細書は docId = StatementID で保存する。
+// ミント手数料は同じ mintId を二重に計上しない（ErrConflict）。
+type BillingRepositoryMem struct {
+	mu          sync.Mutex
+	plans       map[string]billingdom.Plan
+	mintCharges map[string]billingdom.MintCharge
+	statements  map[string]billingdom.Statement
+}
+
+var _ billingdom.RepositoryPort = (*BillingRepositoryMem)(nil)
+
+func NewBillingRepositoryMem() *BillingRepositoryMem {
+	return &BillingRepositoryMem{
+		plans:       map[string]billingdom.Plan{},
+		mintCharges: map[string]billingdom.MintCharge{},
+		statements:  map[string]billingdom.Statement{},
+	}
+}
+
+func (r *BillingRepositoryMem) GetPlan(
+	_ context.Context,
+	companyID string,
+) (billingdom.Plan, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" || strings.Contains(companyID, "/") {
+		return billingdom.Plan{}, billingdom.ErrInvalidCompanyID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.plans[companyID]
+	if !ok {
+		return billingdom.Plan{}, billingdom.ErrNotFound
+	}
+
+	return p, nil
+}
+
+func (r *BillingRepositoryMem) SetPlan(
+	_ context.Context,
+	p billingdom.Plan,
+) (billingdom.Plan, error) {
+	if err := p.Validate(); err != nil {
+		return billingdom.Plan{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.plans[p.CompanyID] = p
+
+	return p, nil
+}
+
+// ListPlans は companyId asc の順で返す。
+func (r *BillingRepositoryMem) ListPlans(
+	_ context.Context,
+) ([]billingdom.Plan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]billingdom.Plan, 0, len(r.plans))
+	for _, id := range sortedKeys(r.plans) {
+		out = append(out, r.plans[id])
+	}
+
+	return out, nil
+}
+
+func (r *BillingRepositoryMem) CreateMintCharge(
+	_ context.Context,
+	c billingdom.MintCharge,
+) (billingdom.MintCharge, error) {
+	if err := c.Validate(); err != nil {
+		return billingdom.MintCharge{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.mintCharges[c.ID]; exists {
+		return billingdom.MintCharge{}, billingdom.ErrConflict
+	}
+
+	r.mintCharges[c.ID] = c
+
+	return c, nil
+}
+
+// ListMintChargesByCompanyID は [from, to) に計上したミント手数料を chargedAt asc で返す。
+func (r *BillingRepositoryMem) ListMintChargesByCompanyID(
+	_ context.Context,
+	companyID string,
+	from time.Time,
+	to time.Time,
+) ([]billingdom.MintCharge, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, billingdom.ErrInvalidCompanyID
+	}
+
+	out := make([]billingdom.MintCharge, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.mintCharges) {
+		c := r.mintCharges[id]
+		if c.CompanyID != companyID {
+			continue
+		}
+		if c.ChargedAt.Before(from) || !c.ChargedAt.Before(to) {
+			continue
+		}
+		out = append(out, c)
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ChargedAt.Before(out[j].ChargedAt)
+	})
+
+	return out, nil
+}
+
+// SaveStatement は同じ ID の明細書を上書きする。
+func (r *BillingRepositoryMem) SaveStatement(
+	_ context.Context,
+	s billingdom.Statement,
+) (billingdom.Statement, error) {
+	if s.ID != billingdom.StatementID(s.CompanyID, s.Period) ||
+		s.CompanyID == "" ||
+		strings.Contains(s.ID, "/") {
+		return billingdom.Statement{}, billingdom.ErrInvalidStatementID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statements[s.ID] = cloneBillingStatement(s)
+
+	return cloneBillingStatement(s), nil
+}
+
+func (r *BillingRepositoryMem) GetStatement(
+	_ context.Context,
+	id string,
+) (billingdom.Statement, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return billingdom.Statement{}, billingdom.ErrInvalidStatementID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.statements[id]
+	if !ok {
+		return billingdom.Statement{}, billingdom.ErrNotFound
+	}
+
+	return cloneBillingStatement(s), nil
+}
+
+// ListStatementsByCompanyID は period desc の順で返す。
+func (r *BillingRepositoryMem) ListStatementsByCompanyID(
+	_ context.Context,
+	companyID string,
+) ([]billingdom.Statement, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, billingdom.ErrInvalidCompanyID
+	}
+
+	out := make([]billingdom.Statement, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.statements) {
+		if s := r.statements[id]; s.CompanyID == companyID {
+			out = append(out, cloneBillingStatement(s))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Period > out[j].Period
+	})
+
+	return out, nil
+}
+
+func cloneBillingStatement(s billingdom.Statement) billingdom.Statement {
+	out := s
+
+	if s.Mints != nil {
+		out.Mints = make([]billingdom.MintCharge, len(s.Mints))
+		copy(out.Mints, s.Mints)
+	}
+
+	return out
+}
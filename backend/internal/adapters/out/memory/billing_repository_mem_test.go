@@ -0,0 +1,78 @@
+// backend/internal/adapters/out/memory/billing_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	billingdom "narratives/internal/domain/billing"
+)
+
+func TestBillingRepositoryMem_MintCharges(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewBillingRepositoryMem()
+	from := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	if _, err := repo.GetPlan(ctx, "company_1"); !errors.Is(err, billingdom.ErrNotFound) {
+		t.Fatalf("GetPlan err = %v, want ErrNotFound", err)
+	}
+
+	plan, err := billingdom.NewPlan(billingdom.NewPlanInput{
+		CompanyID:      "company_1",
+		MintFeePerItem: 10,
+		UpdatedBy:      "operator_1",
+	}, from)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if _, err := repo.SetPlan(ctx, plan); err != nil {
+		t.Fatalf("SetPlan: %v", err)
+	}
+
+	charge := func(mintID string, chargedAt time.Time) billingdom.MintCharge {
+		c, err := billingdom.NewMintCharge(plan, billingdom.NewMintChargeInput{
+			MintID:    mintID,
+			Quantity:  3,
+			ChargedAt: chargedAt,
+		})
+		if err != nil {
+			t.Fatalf("NewMintCharge: %v", err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		charge  billingdom.MintCharge
+		wantErr error
+	}{
+		{name: "first charge", charge: charge("mint_2", from.Add(48*time.Hour))},
+		{name: "earlier charge", charge: charge("mint_1", from)},
+		{name: "charge outside the period", charge: charge("mint_3", to)},
+		{name: "duplicate charge", charge: charge("mint_1", from.Add(time.Hour)), wantErr: billingdom.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.CreateMintCharge(ctx, tt.charge)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	got, err := repo.ListMintChargesByCompanyID(ctx, "company_1", from, to)
+	if err != nil {
+		t.Fatalf("ListMintChargesByCompanyID: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "mint_1" || got[1].ID != "mint_2" {
+		t.Fatalf("charges = %+v, want mint_1, mint_2", got)
+	}
+	if !got[0].ChargedAt.Equal(from) {
+		t.Fatalf("mint_1 chargedAt = %v, want %v (not overwritten)", got[0].ChargedAt, from)
+	}
+}
@@ -0,0 +1,244 @@
+// backend/internal/adapters/out/memory/brand_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	fscommon "narratives/internal/adapters/out/firestore/common"
+	usecase "narratives/internal/application/usecase"
+	branddom "narratives/internal/domain/brand"
+)
+
+// BrandRepositoryMem は brand.Repository の in-memory 実装。
+//
+// transfer で使う usecase.BrandWalletResolver（brands/{brandId}.walletAddress）も
+// 同じ map で実装する。
+type BrandRepositoryMem struct {
+	mu  sync.Mutex
+	ids idSequence
+
+	brands map[string]branddom.Brand
+
+	now func() time.Time
+}
+
+var (
+	_ branddom.Repository         = (*BrandRepositoryMem)(nil)
+	_ usecase.BrandWalletResolver = (*BrandRepositoryMem)(nil)
+)
+
+func NewBrandRepositoryMem() *BrandRepositoryMem {
+	return &BrandRepositoryMem{
+		brands: map[string]branddom.Brand{},
+		now:    time.Now,
+	}
+}
+
+// ListByCompanyID は createdAt desc, id desc の順で返す。
+func (r *BrandRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+	page branddom.Page,
+) (branddom.PageResult[branddom.Brand], error) {
+	if companyID == "" {
+		return branddom.PageResult[branddom.Brand]{}, branddom.ErrInvalidID
+	}
+
+	pageNum, perPage, offset := fscommon.NormalizePage(
+		page.Number,
+		page.PerPage,
+		50,
+		200,
+	)
+
+	r.mu.Lock()
+	matched := make([]branddom.Brand, 0)
+	for _, b := range r.brands {
+		if b.CompanyID == companyID {
+			matched = append(matched, cloneBrand(b))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+
+	return branddom.PageResult[branddom.Brand]{
+		Items:      paginate(matched, offset, perPage),
+		TotalCount: len(matched),
+		TotalPages: fscommon.ComputeTotalPages(len(matched), perPage),
+		Page:       pageNum,
+		PerPage:    perPage,
+	}, nil
+}
+
+func (r *BrandRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (branddom.Brand, error) {
+	if id == "" {
+		return branddom.Brand{}, branddom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.brands[id]
+	if !ok {
+		return branddom.Brand{}, branddom.ErrNotFound
+	}
+
+	return cloneBrand(b), nil
+}
+
+func (r *BrandRepositoryMem) Create(
+	_ context.Context,
+	b branddom.Brand,
+) (branddom.Brand, error) {
+	now := r.now().UTC()
+
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = now
+	}
+	if b.UpdatedAt == nil || b.UpdatedAt.IsZero() {
+		t := b.CreatedAt
+		b.UpdatedAt = &t
+	}
+	if b.ID == "" {
+		b.ID = r.ids.newID("brand")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.brands[b.ID]; exists {
+		return branddom.Brand{}, branddom.ErrConflict
+	}
+
+	r.brands[b.ID] = cloneBrand(b)
+
+	return cloneBrand(b), nil
+}
+
+// Update は BrandRepositoryFS と同じく、空文字の optional field を clear し、
+// UpdatedAt が指定されていなければ現在時刻を設定する。
+func (r *BrandRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	patch branddom.BrandPatch,
+) (branddom.Brand, error) {
+	if id == "" {
+		return branddom.Brand{}, branddom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.brands[id]
+	if !ok {
+		return branddom.Brand{}, branddom.ErrNotFound
+	}
+
+	b := cloneBrand(existing)
+	changed := false
+
+	setString := func(dst *string, v *string) {
+		if v != nil {
+			*dst = *v
+			changed = true
+		}
+	}
+	setOptional := func(dst **string, v *string) {
+		if v != nil {
+			*dst = nil
+			if *v != "" {
+				*dst = cloneStringPtr(v)
+			}
+			changed = true
+		}
+	}
+
+	setString(&b.CompanyID, patch.CompanyID)
+	setString(&b.Name, patch.Name)
+	setString(&b.Description, patch.Description)
+	setString(&b.URL, patch.URL)
+	setString(&b.BrandIcon, patch.BrandIcon)
+	setString(&b.BrandBackgroundImage, patch.BrandBackgroundImage)
+	setString(&b.WalletAddress, patch.WalletAddress)
+	setOptional(&b.ManagerID, patch.ManagerID)
+	setOptional(&b.CreatedBy, patch.CreatedBy)
+	setOptional(&b.UpdatedBy, patch.UpdatedBy)
+
+	if patch.IsActive != nil {
+		b.IsActive = *patch.IsActive
+		changed = true
+	}
+
+	if !changed && patch.UpdatedAt == nil {
+		return cloneBrand(existing), nil
+	}
+
+	switch {
+	case patch.UpdatedAt == nil:
+		t := r.now().UTC()
+		b.UpdatedAt = &t
+	case patch.UpdatedAt.IsZero():
+		b.UpdatedAt = nil
+	default:
+		t := patch.UpdatedAt.UTC()
+		b.UpdatedAt = &t
+	}
+
+	r.brands[id] = cloneBrand(b)
+
+	return cloneBrand(b), nil
+}
+
+func (r *BrandRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if id == "" {
+		return branddom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.brands[id]; !ok {
+		return branddom.ErrNotFound
+	}
+
+	delete(r.brands, id)
+
+	return nil
+}
+
+// ResolveBrandWalletAddress は brands/{brandId}.walletAddress を返す。
+func (r *BrandRepositoryMem) ResolveBrandWalletAddress(
+	ctx context.Context,
+	brandID string,
+) (string, error) {
+	b, err := r.GetByID(ctx, brandID)
+	if err != nil {
+		return "", err
+	}
+
+	return b.WalletAddress, nil
+}
+
+func cloneBrand(b branddom.Brand) branddom.Brand {
+	b.ManagerID = cloneStringPtr(b.ManagerID)
+	b.CreatedBy = cloneStringPtr(b.CreatedBy)
+	b.UpdatedAt = cloneTimePtr(b.UpdatedAt)
+	b.UpdatedBy = cloneStringPtr(b.UpdatedBy)
+	return b
+}
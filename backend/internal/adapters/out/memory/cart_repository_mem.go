@@ -0,0 +1,98 @@
+// backend/internal/adapters/out/memory/cart_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	cartdom "narratives/internal/domain/cart"
+)
+
+// CartRepositoryMem は cart.Repository の in-memory 実装。
+// cart は avatarId を ID とする upsert 型の集約なので、Create / Update の区別は持たない。
+type CartRepositoryMem struct {
+	mu    sync.Mutex
+	carts map[string]cartdom.Cart
+}
+
+var _ cartdom.Repository = (*CartRepositoryMem)(nil)
+
+func NewCartRepositoryMem() *CartRepositoryMem {
+	return &CartRepositoryMem{
+		carts: map[string]cartdom.Cart{},
+	}
+}
+
+// GetByAvatarID returns (nil, nil) if not found.
+func (r *CartRepositoryMem) GetByAvatarID(
+	_ context.Context,
+	avatarID string,
+) (*cartdom.Cart, error) {
+	if avatarID == "" {
+		return nil, errors.New("cart_repository_mem: avatarID is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.carts[avatarID]
+	if !ok {
+		return nil, nil
+	}
+
+	out := cloneCart(c)
+	return &out, nil
+}
+
+// Upsert saves cart by cart.ID (= avatarId).
+func (r *CartRepositoryMem) Upsert(
+	_ context.Context,
+	cart *cartdom.Cart,
+) error {
+	if cart == nil {
+		return errors.New("cart_repository_mem: cart is nil")
+	}
+	if cart.ID == "" {
+		return errors.New(
+			"cart_repository_mem: Upsert requires cart.ID (= avatarId)",
+		)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.carts[cart.ID] = cloneCart(*cart)
+
+	return nil
+}
+
+// DeleteByAvatarID は存在しない cart の削除を成功として扱う（Firestore Delete と同じ）。
+func (r *CartRepositoryMem) DeleteByAvatarID(
+	_ context.Context,
+	avatarID string,
+) error {
+	if avatarID == "" {
+		return errors.New("cart_repository_mem: avatarID is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.carts, avatarID)
+
+	return nil
+}
+
+func cloneCart(c cartdom.Cart) cartdom.Cart {
+	out := c
+
+	if c.Items != nil {
+		out.Items = make(map[string]cartdom.CartItem, len(c.Items))
+		for k, v := range c.Items {
+			out.Items[k] = v
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,199 @@
+// backend/internal/adapters/out/memory/company_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sync"
+
+	compdom "narratives/internal/domain/company"
+)
+
+// CompanyRepositoryMem は company.Repository の in-memory 実装。
+type CompanyRepositoryMem struct {
+	mu  sync.Mutex
+	ids idSequence
+
+	companies map[string]compdom.Company
+}
+
+var _ compdom.Repository = (*CompanyRepositoryMem)(nil)
+
+func NewCompanyRepositoryMem() *CompanyRepositoryMem {
+	return &CompanyRepositoryMem{
+		companies: map[string]compdom.Company{},
+	}
+}
+
+func (r *CompanyRepositoryMem) NewID(_ context.Context) (string, error) {
+	return r.ids.newID("company"), nil
+}
+
+func (r *CompanyRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (compdom.Company, error) {
+	if id == "" {
+		return compdom.Company{}, compdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.companies[id]
+	if !ok {
+		return compdom.Company{}, compdom.ErrNotFound
+	}
+
+	return cloneCompany(c), nil
+}
+
+func (r *CompanyRepositoryMem) Create(
+	_ context.Context,
+	c compdom.Company,
+) (compdom.Company, error) {
+	if c.ID == "" {
+		c.ID = r.ids.newID("company")
+	}
+
+	validated, err := validateCompany(c, c.InvoiceRegistrationNumber)
+	if err != nil {
+		return compdom.Company{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.companies[validated.ID]; exists {
+		return compdom.Company{}, compdom.ErrConflict
+	}
+
+	r.companies[validated.ID] = cloneCompany(validated)
+
+	return cloneCompany(validated), nil
+}
+
+func (r *CompanyRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	patch compdom.CompanyPatch,
+) (compdom.Company, error) {
+	if id == "" {
+		return compdom.Company{}, compdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.companies[id]
+	if !ok {
+		return compdom.Company{}, compdom.ErrNotFound
+	}
+
+	current := cloneCompany(existing)
+
+	if patch.Name != nil {
+		current.Name = *patch.Name
+	}
+	if patch.Admin != nil {
+		current.Admin = *patch.Admin
+	}
+	if patch.IsActive != nil {
+		current.IsActive = *patch.IsActive
+	}
+	invoiceRegistrationNumber := current.InvoiceRegistrationNumber
+	if patch.InvoiceRegistrationNumber != nil {
+		invoiceRegistrationNumber = *patch.InvoiceRegistrationNumber
+	}
+	if patch.UpdatedAt != nil {
+		if patch.UpdatedAt.IsZero() {
+			return compdom.Company{}, compdom.ErrInvalidUpdatedAt
+		}
+		current.UpdatedAt = *patch.UpdatedAt
+	}
+	if patch.UpdatedBy != nil {
+		if *patch.UpdatedBy == "" {
+			return compdom.Company{}, compdom.ErrInvalidUpdatedBy
+		}
+		current.UpdatedBy = *patch.UpdatedBy
+	}
+	if patch.DeletedAt != nil {
+		if patch.DeletedAt.IsZero() {
+			current.DeletedAt = nil
+		} else {
+			current.DeletedAt = cloneTimePtr(patch.DeletedAt)
+		}
+	}
+	if patch.DeletedBy != nil {
+		if *patch.DeletedBy == "" {
+			current.DeletedBy = nil
+		} else {
+			current.DeletedBy = cloneStringPtr(patch.DeletedBy)
+		}
+	}
+
+	validated, err := validateCompany(current, invoiceRegistrationNumber)
+	if err != nil {
+		return compdom.Company{}, err
+	}
+
+	r.companies[id] = cloneCompany(validated)
+
+	return cloneCompany(validated), nil
+}
+
+func (r *CompanyRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if id == "" {
+		return compdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.companies[id]; !ok {
+		return compdom.ErrNotFound
+	}
+
+	delete(r.companies, id)
+
+	return nil
+}
+
+// validateCompany は Firestore adapter と同じく NewCompany で検証し、
+// 登録番号を正規化して設定する。
+func validateCompany(
+	c compdom.Company,
+	invoiceRegistrationNumber string,
+) (compdom.Company, error) {
+	validated, err := compdom.NewCompany(
+		c.ID,
+		c.Name,
+		c.Admin,
+		c.CreatedBy,
+		c.UpdatedBy,
+		c.CreatedAt,
+		c.UpdatedAt,
+		c.IsActive,
+		c.DeletedAt,
+		c.DeletedBy,
+	)
+	if err != nil {
+		return compdom.Company{}, err
+	}
+
+	number := compdom.NormalizeInvoiceRegistrationNumber(invoiceRegistrationNumber)
+	if err := compdom.ValidateInvoiceRegistrationNumber(number); err != nil {
+		return compdom.Company{}, err
+	}
+	validated.InvoiceRegistrationNumber = number
+
+	return validated, nil
+}
+
+func cloneCompany(c compdom.Company) compdom.Company {
+	c.DeletedAt = cloneTimePtr(c.DeletedAt)
+	c.DeletedBy = cloneStringPtr(c.DeletedBy)
+	return c
+}
@@ -0,0 +1,288 @@
+// backend/internal/adapters/out/memory/coupon_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coupondom "narratives/internal/domain/coupon"
+)
+
+// CouponRepositoryMem は coupon.RepositoryPort と coupon.RedemptionRepository の
+// in-memory 実装。利用回数の更新は Firestore の transaction と同じく
+// 1 つの lock の中で coupon と redemption をまとめて更新する。
+type CouponRepositoryMem struct {
+	mu sync.Mutex
+
+	coupons     map[string]coupondom.Coupon
+	redemptions map[string]coupondom.Redemption
+}
+
+var (
+	_ coupondom.RepositoryPort       = (*CouponRepositoryMem)(nil)
+	_ coupondom.RedemptionRepository = (*CouponRepositoryMem)(nil)
+)
+
+func NewCouponRepositoryMem() *CouponRepositoryMem {
+	return &CouponRepositoryMem{
+		coupons:     map[string]coupondom.Coupon{},
+		redemptions: map[string]coupondom.Redemption{},
+	}
+}
+
+func (r *CouponRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (coupondom.Coupon, error) {
+	id = strings.TrimSpace(id)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.coupons[id]
+	if !ok {
+		return coupondom.Coupon{}, coupondom.ErrNotFound
+	}
+
+	return cloneCoupon(c), nil
+}
+
+func (r *CouponRepositoryMem) GetByCode(
+	_ context.Context,
+	code string,
+) (coupondom.Coupon, error) {
+	code = coupondom.NormalizeCode(code)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sortedKeys(r.coupons) {
+		if c := r.coupons[id]; c.Code == code {
+			return cloneCoupon(c), nil
+		}
+	}
+
+	return coupondom.Coupon{}, coupondom.ErrNotFound
+}
+
+func (r *CouponRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+) ([]coupondom.Coupon, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, coupondom.ErrInvalidCompanyID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]coupondom.Coupon, 0)
+	for _, id := range sortedKeys(r.coupons) {
+		if c := r.coupons[id]; c.CompanyID == companyID {
+			out = append(out, cloneCoupon(c))
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+
+	return out, nil
+}
+
+func (r *CouponRepositoryMem) Create(
+	_ context.Context,
+	c coupondom.Coupon,
+) (coupondom.Coupon, error) {
+	if err := c.Validate(); err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.coupons[c.ID]; exists {
+		return coupondom.Coupon{}, coupondom.ErrConflict
+	}
+
+	for _, existing := range r.coupons {
+		if existing.Code == c.Code {
+			return coupondom.Coupon{}, coupondom.ErrConflict
+		}
+	}
+
+	r.coupons[c.ID] = cloneCoupon(c)
+
+	return cloneCoupon(c), nil
+}
+
+func (r *CouponRepositoryMem) Update(
+	_ context.Context,
+	c coupondom.Coupon,
+) (coupondom.Coupon, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.coupons[c.ID]
+	if !ok {
+		return coupondom.Coupon{}, coupondom.ErrNotFound
+	}
+
+	if current.Code != c.Code ||
+		current.CompanyID != c.CompanyID {
+		return coupondom.Coupon{}, coupondom.ErrConflict
+	}
+
+	c.RedeemedCount = current.RedeemedCount
+
+	if err := c.Validate(); err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	r.coupons[c.ID] = cloneCoupon(c)
+
+	return cloneCoupon(c), nil
+}
+
+func (r *CouponRepositoryMem) Reserve(
+	_ context.Context,
+	red coupondom.Redemption,
+) (coupondom.Redemption, error) {
+	if err := red.Validate(); err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.redemptions[red.ID]; ok && existing.Counts() {
+		if existing.CouponID != red.CouponID {
+			return coupondom.Redemption{}, coupondom.ErrConflict
+		}
+		return existing, nil
+	}
+
+	c, ok := r.coupons[red.CouponID]
+	if !ok {
+		return coupondom.Redemption{}, coupondom.ErrNotFound
+	}
+
+	avatarCount := 0
+	for _, existing := range r.redemptions {
+		if existing.CouponID == red.CouponID &&
+			existing.AvatarID == red.AvatarID &&
+			existing.Counts() {
+			avatarCount++
+		}
+	}
+
+	if err := c.Reserve(red, avatarCount); err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	r.coupons[c.ID] = c
+	r.redemptions[red.ID] = cloneRedemption(red)
+
+	return cloneRedemption(red), nil
+}
+
+func (r *CouponRepositoryMem) MarkRedeemed(
+	_ context.Context,
+	orderID string,
+	at time.Time,
+) (coupondom.Redemption, error) {
+	orderID = strings.TrimSpace(orderID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	red, ok := r.redemptions[orderID]
+	if !ok {
+		return coupondom.Redemption{}, coupondom.ErrNotFound
+	}
+
+	if err := red.MarkRedeemed(at); err != nil {
+		return coupondom.Redemption{}, err
+	}
+
+	r.redemptions[orderID] = red
+
+	return cloneRedemption(red), nil
+}
+
+func (r *CouponRepositoryMem) Release(
+	_ context.Context,
+	orderID string,
+	at time.Time,
+) (coupondom.Redemption, error) {
+	orderID = strings.TrimSpace(orderID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	red, ok := r.redemptions[orderID]
+	if !ok {
+		return coupondom.Redemption{}, coupondom.ErrNotFound
+	}
+
+	if !red.Counts() {
+		return cloneRedemption(red), nil
+	}
+
+	red.Release(at)
+	r.redemptions[orderID] = red
+
+	if c, ok := r.coupons[red.CouponID]; ok {
+		c.Unreserve()
+		r.coupons[c.ID] = c
+	}
+
+	return cloneRedemption(red), nil
+}
+
+func (r *CouponRepositoryMem) ListByCouponID(
+	_ context.Context,
+	couponID string,
+) ([]coupondom.Redemption, error) {
+	couponID = strings.TrimSpace(couponID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]coupondom.Redemption, 0)
+	for _, id := range sortedKeys(r.redemptions) {
+		if red := r.redemptions[id]; red.CouponID == couponID {
+			out = append(out, cloneRedemption(red))
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+
+	return out, nil
+}
+
+func cloneCoupon(c coupondom.Coupon) coupondom.Coupon {
+	out := c
+	out.BrandIDs = cloneStrings(c.BrandIDs)
+	out.ProductBlueprintIDs = cloneStrings(c.ProductBlueprintIDs)
+	out.ValidFrom = cloneTimePtr(c.ValidFrom)
+	out.ValidUntil = cloneTimePtr(c.ValidUntil)
+	out.UpdatedAt = cloneTimePtr(c.UpdatedAt)
+	out.UpdatedBy = cloneStringPtr(c.UpdatedBy)
+
+	return out
+}
+
+func cloneRedemption(red coupondom.Redemption) coupondom.Redemption {
+	out := red
+	out.RedeemedAt = cloneTimePtr(red.RedeemedAt)
+	out.ReleasedAt = cloneTimePtr(red.ReleasedAt)
+
+	return out
+}
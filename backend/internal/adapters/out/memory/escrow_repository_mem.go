@@ -0,0 +1,277 @@
+// backend/internal/adapters/out/memory/escrow_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	escrowdom "narratives/internal/domain/escrow"
+)
+
+// EscrowRepositoryMem は escrow.RepositoryPort の in-memory 実装。
+// Firestore adapter と同様に、Update は保存済みの UpdatedAt を比較して競合を検出する。
+type EscrowRepositoryMem struct {
+	mu      sync.Mutex
+	escrows map[string]escrowdom.Escrow
+}
+
+var _ escrowdom.RepositoryPort = (*EscrowRepositoryMem)(nil)
+
+func NewEscrowRepositoryMem() *EscrowRepositoryMem {
+	return &EscrowRepositoryMem{
+		escrows: map[string]escrowdom.Escrow{},
+	}
+}
+
+func (r *EscrowRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (escrowdom.Escrow, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return escrowdom.Escrow{}, escrowdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.escrows[id]
+	if !ok {
+		return escrowdom.Escrow{}, escrowdom.ErrNotFound
+	}
+
+	return cloneEscrow(e), nil
+}
+
+func (r *EscrowRepositoryMem) Create(
+	_ context.Context,
+	e escrowdom.Escrow,
+) (escrowdom.Escrow, error) {
+	if err := e.Validate(); err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.escrows[e.ID]; exists {
+		return escrowdom.Escrow{}, escrowdom.ErrConflict
+	}
+
+	r.escrows[e.ID] = cloneEscrow(e)
+
+	return cloneEscrow(e), nil
+}
+
+func (r *EscrowRepositoryMem) Update(
+	_ context.Context,
+	e escrowdom.Escrow,
+	prevUpdatedAt time.Time,
+) (escrowdom.Escrow, error) {
+	if err := e.Validate(); err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.escrows[e.ID]
+	if !ok {
+		return escrowdom.Escrow{}, escrowdom.ErrNotFound
+	}
+
+	// Firestore の timestamp 精度に合わせて比較する。
+	if !current.UpdatedAt.Truncate(time.Microsecond).Equal(
+		prevUpdatedAt.UTC().Truncate(time.Microsecond),
+	) {
+		return escrowdom.Escrow{}, escrowdom.ErrConflict
+	}
+
+	r.escrows[e.ID] = cloneEscrow(e)
+
+	return cloneEscrow(e), nil
+}
+
+func (r *EscrowRepositoryMem) ListByBuyerAvatarID(
+	_ context.Context,
+	avatarID string,
+) ([]escrowdom.Escrow, error) {
+	return r.listNewestFirst(avatarID, func(e escrowdom.Escrow) string { return e.BuyerAvatarID }), nil
+}
+
+func (r *EscrowRepositoryMem) ListBySellerAvatarID(
+	_ context.Context,
+	avatarID string,
+) ([]escrowdom.Escrow, error) {
+	return r.listNewestFirst(avatarID, func(e escrowdom.Escrow) string { return e.SellerAvatarID }), nil
+}
+
+func (r *EscrowRepositoryMem) listNewestFirst(
+	value string,
+	field func(escrowdom.Escrow) string,
+) []escrowdom.Escrow {
+	out := make([]escrowdom.Escrow, 0)
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return out
+	}
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.escrows) {
+		if e := r.escrows[id]; field(e) == value {
+			out = append(out, cloneEscrow(e))
+		}
+	}
+	r.mu.Unlock()
+
+	sortEscrowsNewestFirst(out)
+
+	return out
+}
+
+func (r *EscrowRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+	st escrowdom.Status,
+) ([]escrowdom.Escrow, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, escrowdom.ErrInvalidCompanyID
+	}
+	if st != "" && !escrowdom.IsValidStatus(st) {
+		return nil, escrowdom.ErrInvalidStatus
+	}
+
+	out := make([]escrowdom.Escrow, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.escrows) {
+		e := r.escrows[id]
+		if e.CompanyID != companyID {
+			continue
+		}
+		if st != "" && e.Status != st {
+			continue
+		}
+		out = append(out, cloneEscrow(e))
+	}
+	r.mu.Unlock()
+
+	sortEscrowsNewestFirst(out)
+
+	return out, nil
+}
+
+// ListDue は自動受取確認の期限切れ・支払い可能な held の escrow を先に、
+// NFT 移転が未記録の held の escrow を後に、それぞれ heldAt asc で返す。
+func (r *EscrowRepositoryMem) ListDue(
+	_ context.Context,
+	now time.Time,
+	limit int,
+) ([]escrowdom.Escrow, error) {
+	now = now.UTC()
+	if limit <= 0 {
+		limit = 100
+	}
+
+	due := make([]escrowdom.Escrow, 0)
+	untransferred := make([]escrowdom.Escrow, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.escrows) {
+		e := r.escrows[id]
+		if e.Status != escrowdom.StatusHeld {
+			continue
+		}
+
+		switch {
+		case e.IsAutoConfirmDue(now), e.CanRelease():
+			due = append(due, cloneEscrow(e))
+		case e.TransferredAt == nil:
+			untransferred = append(untransferred, cloneEscrow(e))
+		}
+	}
+	r.mu.Unlock()
+
+	sortEscrowsOldestFirst(due)
+	sortEscrowsOldestFirst(untransferred)
+
+	due = append(due, untransferred...)
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+// ListPendingPayoutAccrual は released かつ未計上の escrow を releasedAt asc で返す。
+func (r *EscrowRepositoryMem) ListPendingPayoutAccrual(
+	_ context.Context,
+	limit int,
+) ([]escrowdom.Escrow, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	pending := make([]escrowdom.Escrow, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.escrows) {
+		if e := r.escrows[id]; e.NeedsPayoutAccrual() {
+			pending = append(pending, cloneEscrow(e))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return escrowReleasedAt(pending[i]).Before(escrowReleasedAt(pending[j]))
+	})
+
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	return pending, nil
+}
+
+func escrowReleasedAt(e escrowdom.Escrow) time.Time {
+	if e.ReleasedAt != nil {
+		return *e.ReleasedAt
+	}
+	return e.UpdatedAt
+}
+
+func sortEscrowsNewestFirst(escrows []escrowdom.Escrow) {
+	sort.SliceStable(escrows, func(i, j int) bool {
+		return escrows[i].HeldAt.After(escrows[j].HeldAt)
+	})
+}
+
+func sortEscrowsOldestFirst(escrows []escrowdom.Escrow) {
+	sort.SliceStable(escrows, func(i, j int) bool {
+		return escrows[i].HeldAt.Before(escrows[j].HeldAt)
+	})
+}
+
+func cloneEscrow(e escrowdom.Escrow) escrowdom.Escrow {
+	out := e
+
+	out.TransferredAt = cloneTimePtr(e.TransferredAt)
+	out.AutoConfirmAt = cloneTimePtr(e.AutoConfirmAt)
+	out.ReceiptConfirmedAt = cloneTimePtr(e.ReceiptConfirmedAt)
+	out.ReleasedAt = cloneTimePtr(e.ReleasedAt)
+	out.PayoutAccruedAt = cloneTimePtr(e.PayoutAccruedAt)
+	out.RefundedAt = cloneTimePtr(e.RefundedAt)
+
+	if e.Dispute != nil {
+		d := *e.Dispute
+		d.ResolvedAt = cloneTimePtr(e.Dispute.ResolvedAt)
+		out.Dispute = &d
+	}
+
+	return out
+}
@@ -0,0 +1,134 @@
+// backend/internal/adapters/out/memory/escrow_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	escrowdom "narratives/internal/domain/escrow"
+)
+
+func newTestEscrow(t *testing.T, orderID string, heldAt time.Time) escrowdom.Escrow {
+	t.Helper()
+
+	e, err := escrowdom.New(escrowdom.NewEscrowInput{
+		OrderID:        orderID,
+		ResaleID:       "resale_1",
+		ProductID:      "product_1",
+		CompanyID:      "company_1",
+		BrandID:        "brand_1",
+		BuyerAvatarID:  "avatar_buyer",
+		SellerAvatarID: "avatar_seller",
+		SaleAmount:     1000,
+		RoyaltyAmount:  100,
+		HeldAt:         heldAt,
+	})
+	if err != nil {
+		t.Fatalf("escrow.New: %v", err)
+	}
+
+	return e
+}
+
+func TestEscrowRepositoryMem_CreateAndUpdate(t *testing.T) {
+	heldAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		run     func(ctx context.Context, repo *memory.EscrowRepositoryMem) error
+		wantErr error
+	}{
+		{
+			name: "create does not overwrite",
+			run: func(ctx context.Context, repo *memory.EscrowRepositoryMem) error {
+				_, err := repo.Create(ctx, newTestEscrow(t, "order_1", heldAt.Add(time.Hour)))
+				return err
+			},
+			wantErr: escrowdom.ErrConflict,
+		},
+		{
+			name: "update does not create",
+			run: func(ctx context.Context, repo *memory.EscrowRepositoryMem) error {
+				_, err := repo.Update(ctx, newTestEscrow(t, "order_missing", heldAt), heldAt)
+				return err
+			},
+			wantErr: escrowdom.ErrNotFound,
+		},
+		{
+			name: "update with a stale updatedAt",
+			run: func(ctx context.Context, repo *memory.EscrowRepositoryMem) error {
+				e := newTestEscrow(t, "order_1", heldAt)
+				e.UpdatedAt = heldAt.Add(time.Hour)
+				_, err := repo.Update(ctx, e, heldAt.Add(-time.Second))
+				return err
+			},
+			wantErr: escrowdom.ErrConflict,
+		},
+		{
+			name: "update ignores sub-microsecond differences",
+			run: func(ctx context.Context, repo *memory.EscrowRepositoryMem) error {
+				e := newTestEscrow(t, "order_1", heldAt)
+				_, err := repo.Update(ctx, e, heldAt.Add(500*time.Nanosecond))
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewEscrowRepositoryMem()
+
+			seed := newTestEscrow(t, "order_1", heldAt)
+			if _, err := repo.Create(ctx, seed); err != nil {
+				t.Fatalf("seed escrow: %v", err)
+			}
+
+			err := tt.run(ctx, repo)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if _, err := repo.GetByID(ctx, "order_missing_0"); !errors.Is(err, escrowdom.ErrNotFound) {
+				t.Fatalf("GetByID(order_missing_0) err = %v, want ErrNotFound", err)
+			}
+			stored, err := repo.GetByID(ctx, seed.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if !stored.HeldAt.Equal(heldAt) {
+				t.Fatalf("stored heldAt = %v, want %v", stored.HeldAt, heldAt)
+			}
+		})
+	}
+}
+
+func TestEscrowRepositoryMem_ListByBuyerAvatarID(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewEscrowRepositoryMem()
+	heldAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	for i, orderID := range []string{"order_1", "order_2", "order_3"} {
+		if _, err := repo.Create(ctx, newTestEscrow(t, orderID, heldAt.Add(time.Duration(i)*time.Hour))); err != nil {
+			t.Fatalf("seed %s: %v", orderID, err)
+		}
+	}
+
+	got, err := repo.ListByBuyerAvatarID(ctx, "avatar_buyer")
+	if err != nil {
+		t.Fatalf("ListByBuyerAvatarID: %v", err)
+	}
+
+	want := []string{"order_3_0", "order_2_0", "order_1_0"}
+	if len(got) != len(want) {
+		t.Fatalf("len = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i] {
+			t.Fatalf("got[%d] = %q, want %q", i, got[i].ID, want[i])
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// backend/internal/adapters/out/memory/helper_mem.go
+//
+// memory パッケージは、各 domain RepositoryPort の in-memory 実装を提供する。
+//
+// 目的:
+//   - Firestore / Stripe / Solana / Resend に依存せず、usecase を go test で
+//     end-to-end に動かせるようにする。
+//   - Firestore adapter と同じ永続化契約を守る。
+//     Create は既存データを上書きしない（ErrConflict）。
+//     Update は存在しないデータを作成しない（ErrNotFound）。
+//
+// 方針:
+//   - 各 repository は sync.Mutex で保護した map を持つ。
+//   - 保存時・返却時に値をコピーし、呼び出し側の変更が保存値へ漏れないようにする。
+//   - ID 採番は連番で行い、テストで結果を再現できるようにする。
+//
+// 対象範囲:
+//   - usecase が書き込む集約（order / inventory / cart / payment / mint / transfer /
+//     list / resale / offer / escrow / payout / billing / royalty / audit / outbox /
+//     idempotency / stripeEvent / nfc / account / member / role / product など）は
+//     このパッケージに実装を持つ。
+//   - 以下は Firestore 専用とし、memory 実装を持たない。
+//     CMS・問い合わせ系（announcement / inquiry / message / contact / invitation）、
+//     レビュー（productBlueprintReview / tokenBlueprint review）、
+//     画像・印刷（list image / resale image / print）、
+//     参照専用のデータ（permission catalog / productBlueprintCategory / systemconfig /
+//     production / inspection / authenticity scan / user / mint の cross-domain read port）。
+//     これらを使う usecase のテストでは、必要な port だけを stub で差し替える。
+//   - memory 実装で DI コンテナを組み立てる経路はない。di パッケージは常に
+//     Firestore adapter を配線する。
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// idSequence はテストで再現可能な連番 ID を払い出す。
+type idSequence struct {
+	mu   sync.Mutex
+	next int
+}
+
+func (s *idSequence) newID(prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.next++
+
+	return fmt.Sprintf("%s_%06d", prefix, s.next)
+}
+
+func cloneStringPtr(p *string) *string {
+	if p == nil {
+		return nil
+	}
+
+	v := *p
+	return &v
+}
+
+func cloneTimePtr(p *time.Time) *time.Time {
+	if p == nil {
+		return nil
+	}
+
+	v := *p
+	return &v
+}
+
+func cloneStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]string, len(in))
+	copy(out, in)
+
+	return out
+}
+
+func cloneIntMap(in map[string]int) map[string]int {
+	if in == nil {
+		return nil
+	}
+
+	out := make(map[string]int, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+
+	return out
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// paginate は NormalizePage 済みの offset / limit で items を切り出す。
+func paginate[T any](items []T, offset int, limit int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	out := make([]T, end-offset)
+	copy(out, items[offset:end])
+
+	return out
+}
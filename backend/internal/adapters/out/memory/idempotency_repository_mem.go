@@ -0,0 +1,129 @@
+// backend/internal/adapters/out/memory/idempotency_repository_mem.go
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	idemdom "narratives/internal/domain/idempotency"
+)
+
+// IdempotencyRepositoryMem は idempotency.RepositoryPort の in-memory 実装。
+//
+// Firestore adapter と同じく、Extend / Complete / Release は保存済みのレコードが
+// 同じリクエスト（CreatedAt と Fingerprint が一致する in_progress）の場合だけ反映する。
+type IdempotencyRepositoryMem struct {
+	mu      sync.Mutex
+	records map[string]idemdom.Record
+}
+
+var _ idemdom.RepositoryPort = (*IdempotencyRepositoryMem)(nil)
+
+func NewIdempotencyRepositoryMem() *IdempotencyRepositoryMem {
+	return &IdempotencyRepositoryMem{
+		records: map[string]idemdom.Record{},
+	}
+}
+
+func (r *IdempotencyRepositoryMem) Acquire(
+	_ context.Context,
+	rec idemdom.Record,
+) (idemdom.Record, bool, error) {
+	if strings.TrimSpace(rec.ID) == "" {
+		return idemdom.Record{}, false, idemdom.ErrInvalidKey
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.records[rec.ID]; ok && !existing.Replaceable(rec.CreatedAt) {
+		return cloneIdempotencyRecord(existing), false, nil
+	}
+
+	r.records[rec.ID] = cloneIdempotencyRecord(rec)
+
+	return cloneIdempotencyRecord(rec), true, nil
+}
+
+func (r *IdempotencyRepositoryMem) Extend(
+	_ context.Context,
+	rec idemdom.Record,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, err := r.owned(rec)
+	if err != nil {
+		if err == idemdom.ErrNotFound {
+			return idemdom.ErrNotInProgress
+		}
+		return err
+	}
+
+	current.LockedUntil = rec.LockedUntil.UTC()
+	r.records[rec.ID] = current
+
+	return nil
+}
+
+func (r *IdempotencyRepositoryMem) Complete(
+	_ context.Context,
+	rec idemdom.Record,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.owned(rec); err != nil {
+		return err
+	}
+
+	r.records[rec.ID] = cloneIdempotencyRecord(rec)
+
+	return nil
+}
+
+// Release は別のリクエストが取得し直していた場合、何もしない。
+func (r *IdempotencyRepositoryMem) Release(
+	_ context.Context,
+	rec idemdom.Record,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.owned(rec); err != nil {
+		return nil
+	}
+
+	delete(r.records, rec.ID)
+
+	return nil
+}
+
+// owned は r.mu を保持した状態で呼び出す。
+func (r *IdempotencyRepositoryMem) owned(rec idemdom.Record) (idemdom.Record, error) {
+	current, ok := r.records[rec.ID]
+	if !ok {
+		return idemdom.Record{}, idemdom.ErrNotFound
+	}
+
+	if current.Status != idemdom.StatusInProgress ||
+		!current.CreatedAt.Equal(rec.CreatedAt) ||
+		current.Fingerprint != rec.Fingerprint {
+		return idemdom.Record{}, idemdom.ErrNotInProgress
+	}
+
+	return current, nil
+}
+
+func cloneIdempotencyRecord(rec idemdom.Record) idemdom.Record {
+	out := rec
+
+	if rec.ResponseBody != nil {
+		out.ResponseBody = make([]byte, len(rec.ResponseBody))
+		copy(out.ResponseBody, rec.ResponseBody)
+	}
+	out.CompletedAt = cloneTimePtr(rec.CompletedAt)
+
+	return out
+}
@@ -0,0 +1,108 @@
+// backend/internal/adapters/out/memory/idempotency_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	idemdom "narratives/internal/domain/idempotency"
+)
+
+func TestIdempotencyRepositoryMem_Ownership(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	lease := time.Minute
+	ttl := 24 * time.Hour
+
+	tests := []struct {
+		name       string
+		run        func(ctx context.Context, repo *memory.IdempotencyRepositoryMem, owner idemdom.Record) error
+		wantErr    error
+		wantStatus idemdom.Status
+	}{
+		{
+			name: "second acquire while in progress",
+			run: func(ctx context.Context, repo *memory.IdempotencyRepositoryMem, _ idemdom.Record) error {
+				rec, _ := idemdom.NewInProgress("user_1", "key_1", "fp", now.Add(time.Second), lease, ttl)
+				if _, acquired, err := repo.Acquire(ctx, rec); err != nil || acquired {
+					t.Fatalf("Acquire = (%v, %v), want not acquired", acquired, err)
+				}
+				return nil
+			},
+			wantStatus: idemdom.StatusInProgress,
+		},
+		{
+			name: "owner completes",
+			run: func(ctx context.Context, repo *memory.IdempotencyRepositoryMem, owner idemdom.Record) error {
+				if err := owner.Complete(200, "application/json", []byte(`{}`), now); err != nil {
+					return err
+				}
+				return repo.Complete(ctx, owner)
+			},
+			wantStatus: idemdom.StatusCompleted,
+		},
+		{
+			name: "another request cannot complete",
+			run: func(ctx context.Context, repo *memory.IdempotencyRepositoryMem, owner idemdom.Record) error {
+				other := owner
+				other.Fingerprint = "other"
+				if err := other.Complete(200, "application/json", []byte(`{}`), now); err != nil {
+					return err
+				}
+				return repo.Complete(ctx, other)
+			},
+			wantErr:    idemdom.ErrNotInProgress,
+			wantStatus: idemdom.StatusInProgress,
+		},
+		{
+			name: "another request cannot release",
+			run: func(ctx context.Context, repo *memory.IdempotencyRepositoryMem, owner idemdom.Record) error {
+				other := owner
+				other.CreatedAt = now.Add(time.Second)
+				return repo.Release(ctx, other)
+			},
+			wantStatus: idemdom.StatusInProgress,
+		},
+		{
+			name: "extend of a missing record",
+			run: func(ctx context.Context, repo *memory.IdempotencyRepositoryMem, owner idemdom.Record) error {
+				missing := owner
+				missing.ID = "missing"
+				return repo.Extend(ctx, missing)
+			},
+			wantErr:    idemdom.ErrNotInProgress,
+			wantStatus: idemdom.StatusInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewIdempotencyRepositoryMem()
+
+			owner, err := idemdom.NewInProgress("user_1", "key_1", "fp", now, lease, ttl)
+			if err != nil {
+				t.Fatalf("NewInProgress: %v", err)
+			}
+			if _, acquired, err := repo.Acquire(ctx, owner); err != nil || !acquired {
+				t.Fatalf("Acquire = (%v, %v), want acquired", acquired, err)
+			}
+
+			err = tt.run(ctx, repo, owner)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			// 同じ CreatedAt の Acquire は期限切れでない限り保存済みのレコードを返す。
+			stored, acquired, err := repo.Acquire(ctx, owner)
+			if err != nil || acquired {
+				t.Fatalf("re-Acquire = (%v, %v), want stored record", acquired, err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Fatalf("status = %q, want %q", stored.Status, tt.wantStatus)
+			}
+		})
+	}
+}
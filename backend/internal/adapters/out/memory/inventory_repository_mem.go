@@ -0,0 +1,541 @@
+// backend/internal/adapters/out/memory/inventory_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	invdom "narratives/internal/domain/inventory"
+)
+
+// InventoryRepositoryMem は inventory.RepositoryPort の in-memory 実装。
+// stock の正規化（products のソート・重複排除、accumulation / reservedCount の再計算）は
+// Firestore adapter と同じ規則で行う。
+type InventoryRepositoryMem struct {
+	mu    sync.Mutex
+	mints map[string]invdom.Mint
+}
+
+var _ invdom.RepositoryPort = (*InventoryRepositoryMem)(nil)
+
+func NewInventoryRepositoryMem() *InventoryRepositoryMem {
+	return &InventoryRepositoryMem{
+		mints: map[string]invdom.Mint{},
+	}
+}
+
+// ============================================================
+// Read
+// ============================================================
+
+func (r *InventoryRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (invdom.Mint, error) {
+	if id == "" {
+		return invdom.Mint{}, invdom.ErrInvalidMintID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.mints[id]
+	if !ok {
+		return invdom.Mint{}, invdom.ErrNotFound
+	}
+
+	return cloneInventory(m), nil
+}
+
+func (r *InventoryRepositoryMem) ListByProductBlueprintID(
+	_ context.Context,
+	productBlueprintID string,
+) ([]invdom.Mint, error) {
+	if productBlueprintID == "" {
+		return nil, invdom.ErrInvalidProductBlueprintID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]invdom.Mint, 0)
+	for _, id := range sortedKeys(r.mints) {
+		m := r.mints[id]
+		if m.ProductBlueprintID == productBlueprintID {
+			out = append(out, cloneInventory(m))
+		}
+	}
+
+	return out, nil
+}
+
+func (r *InventoryRepositoryMem) ResolveBlueprintIDsByInventoryID(
+	ctx context.Context,
+	inventoryID string,
+) (productBlueprintID string, tokenBlueprintID string, err error) {
+	m, err := r.GetByID(ctx, inventoryID)
+	if err != nil {
+		return "", "", err
+	}
+
+	return m.ProductBlueprintID, m.TokenBlueprintID, nil
+}
+
+// ============================================================
+// ShippingAddress / Transportation assignment
+// ============================================================
+
+func (r *InventoryRepositoryMem) SetShippingAddressID(
+	_ context.Context,
+	inventoryID string,
+	shippingAddressID string,
+	now time.Time,
+) error {
+	if inventoryID == "" {
+		return invdom.ErrInvalidMintID
+	}
+	if shippingAddressID == "" {
+		return errors.New("inventory repo: shippingAddressID is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.mints[inventoryID]
+	if !ok {
+		return invdom.ErrNotFound
+	}
+
+	m.ShippingAddressID = shippingAddressID
+	m.UpdatedAt = normalizeNow(now)
+	r.mints[inventoryID] = m
+
+	return nil
+}
+
+func (r *InventoryRepositoryMem) ClearShippingAddressIDByShippingAddressID(
+	_ context.Context,
+	shippingAddressID string,
+	now time.Time,
+) error {
+	if shippingAddressID == "" {
+		return errors.New("inventory repo: shippingAddressID is empty")
+	}
+
+	now = normalizeNow(now)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, m := range r.mints {
+		if m.ShippingAddressID != shippingAddressID {
+			continue
+		}
+
+		m.ShippingAddressID = ""
+		m.UpdatedAt = now
+		r.mints[id] = m
+	}
+
+	return nil
+}
+
+func (r *InventoryRepositoryMem) SetTransportation(
+	_ context.Context,
+	inventoryID string,
+	transportationOption invdom.TransportationOption,
+	transportationID string,
+	now time.Time,
+) error {
+	if inventoryID == "" {
+		return invdom.ErrInvalidMintID
+	}
+	if !invdom.IsValidTransportationOption(transportationOption) {
+		return invdom.ErrInvalidTransportationOption
+	}
+	if transportationOption == invdom.TransportationOptionCustom {
+		if transportationID == "" {
+			return invdom.ErrTransportationIDRequired
+		}
+	} else if transportationID != "" {
+		return invdom.ErrTransportationIDNotAllowed
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.mints[inventoryID]
+	if !ok {
+		return invdom.ErrNotFound
+	}
+
+	m.TransportationOption = transportationOption
+	m.TransportationID = transportationID
+	m.UpdatedAt = normalizeNow(now)
+	r.mints[inventoryID] = m
+
+	return nil
+}
+
+// ============================================================
+// Upsert
+// ============================================================
+
+func (r *InventoryRepositoryMem) UpsertByModelAndToken(
+	_ context.Context,
+	tokenBlueprintID string,
+	productBlueprintID string,
+	modelID string,
+	productIDs []string,
+) (invdom.Mint, error) {
+	if tokenBlueprintID == "" {
+		return invdom.Mint{}, invdom.ErrInvalidTokenBlueprintID
+	}
+	if productBlueprintID == "" {
+		return invdom.Mint{}, invdom.ErrInvalidProductBlueprintID
+	}
+	if modelID == "" {
+		return invdom.Mint{}, invdom.ErrInvalidModelID
+	}
+
+	ids := normalizeProductIDs(productIDs)
+	if len(ids) == 0 {
+		return invdom.Mint{}, invdom.ErrInvalidProducts
+	}
+
+	id := invdom.BuildMintID(productBlueprintID, tokenBlueprintID)
+	now := time.Now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.mints[id]
+	if !ok {
+		m = invdom.Mint{
+			ID:                 id,
+			TokenBlueprintID:   tokenBlueprintID,
+			ProductBlueprintID: productBlueprintID,
+			CreatedAt:          now,
+		}
+	} else {
+		m = cloneInventory(m)
+	}
+
+	if m.Stock == nil {
+		m.Stock = map[string]invdom.ModelStock{}
+	}
+
+	ms := m.Stock[modelID]
+	ms.Products = normalizeProductIDs(append(ms.Products, ids...))
+	m.Stock[modelID] = ms
+
+	m.Stock = normalizeInventoryStock(m.Stock)
+	m.ModelIDs = sortedKeys(m.Stock)
+	m.UpdatedAt = now
+
+	if err := m.Validate(); err != nil {
+		return invdom.Mint{}, err
+	}
+
+	r.mints[id] = m
+
+	return cloneInventory(m), nil
+}
+
+// ============================================================
+// Reservation operations
+// ============================================================
+
+func (r *InventoryRepositoryMem) ReserveByOrder(
+	_ context.Context,
+	inventoryID string,
+	modelID string,
+	orderID string,
+	qty int,
+) error {
+	if inventoryID == "" {
+		return invdom.ErrInvalidMintID
+	}
+	if modelID == "" {
+		return invdom.ErrInvalidModelID
+	}
+	if orderID == "" {
+		return errors.New("inventory repo: orderID is empty")
+	}
+	if qty <= 0 {
+		return errors.New("inventory repo: qty must be > 0")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.mints[inventoryID]
+	if !ok {
+		return invdom.ErrNotFound
+	}
+
+	m := cloneInventory(stored)
+
+	ms, ok := m.Stock[modelID]
+	if !ok {
+		return fmt.Errorf(
+			"inventory repo: model stock not found modelId=%s",
+			modelID,
+		)
+	}
+
+	if existing, ok := ms.ReservedByOrder[orderID]; ok &&
+		existing == qty {
+		return nil
+	}
+
+	if ms.ReservedByOrder == nil {
+		ms.ReservedByOrder = map[string]int{}
+	}
+
+	ms.ReservedByOrder[orderID] = qty
+	ms = normalizeInventoryModelStock(ms)
+
+	if ms.ReservedCount > ms.Accumulation {
+		return fmt.Errorf(
+			"%w (modelId=%s accumulation=%d reservedCount=%d orderId=%s qty=%d)",
+			invdom.ErrInsufficientStock,
+			modelID,
+			ms.Accumulation,
+			ms.ReservedCount,
+			orderID,
+			qty,
+		)
+	}
+
+	m.Stock[modelID] = ms
+	m.Stock = normalizeInventoryStock(m.Stock)
+	m.ModelIDs = sortedKeys(m.Stock)
+	m.UpdatedAt = time.Now().UTC()
+
+	r.mints[inventoryID] = m
+
+	return nil
+}
+
+func (r *InventoryRepositoryMem) ReleaseReservationByOrder(
+	_ context.Context,
+	inventoryID string,
+	modelID string,
+	orderID string,
+	now time.Time,
+) error {
+	if inventoryID == "" {
+		return invdom.ErrInvalidMintID
+	}
+	if modelID == "" {
+		return invdom.ErrInvalidModelID
+	}
+	if orderID == "" {
+		return errors.New("inventory repo: orderID is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.mints[inventoryID]
+	if !ok {
+		return invdom.ErrNotFound
+	}
+
+	m := cloneInventory(stored)
+
+	ms, ok := m.Stock[modelID]
+	if !ok {
+		return nil
+	}
+	if _, ok := ms.ReservedByOrder[orderID]; !ok {
+		return nil
+	}
+
+	delete(ms.ReservedByOrder, orderID)
+
+	m.Stock[modelID] = normalizeInventoryModelStock(ms)
+	m.Stock = normalizeInventoryStock(m.Stock)
+	m.ModelIDs = sortedKeys(m.Stock)
+	m.UpdatedAt = normalizeNow(now)
+
+	r.mints[inventoryID] = m
+
+	return nil
+}
+
+func (r *InventoryRepositoryMem) ReleaseReservationAfterTransfer(
+	_ context.Context,
+	inventoryID string,
+	modelID string,
+	productID string,
+	orderID string,
+	now time.Time,
+) (removedCount int, err error) {
+	if inventoryID == "" {
+		return 0, invdom.ErrInvalidMintID
+	}
+	if modelID == "" {
+		return 0, invdom.ErrInvalidModelID
+	}
+	if productID == "" {
+		return 0, errors.New("inventory repo: productID is empty")
+	}
+	if orderID == "" {
+		return 0, errors.New("inventory repo: orderID is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.mints[inventoryID]
+	if !ok {
+		return 0, invdom.ErrNotFound
+	}
+
+	m := cloneInventory(stored)
+
+	ms, ok := m.Stock[modelID]
+	if !ok {
+		return 0, nil
+	}
+
+	products := make([]string, 0, len(ms.Products))
+	for _, p := range ms.Products {
+		if p == productID {
+			removedCount++
+			continue
+		}
+		products = append(products, p)
+	}
+
+	if removedCount == 0 {
+		return 0, nil
+	}
+
+	ms.Products = products
+
+	if cur := ms.ReservedByOrder[orderID] - removedCount; cur <= 0 {
+		delete(ms.ReservedByOrder, orderID)
+	} else {
+		ms.ReservedByOrder[orderID] = cur
+	}
+
+	m.Stock[modelID] = normalizeInventoryModelStock(ms)
+	m.Stock = normalizeInventoryStock(m.Stock)
+	m.ModelIDs = sortedKeys(m.Stock)
+	m.UpdatedAt = normalizeNow(now)
+
+	r.mints[inventoryID] = m
+
+	return removedCount, nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func normalizeNow(now time.Time) time.Time {
+	if now.IsZero() {
+		return time.Now().UTC()
+	}
+
+	return now.UTC()
+}
+
+func normalizeProductIDs(raw []string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(raw))
+
+	for _, s := range raw {
+		if s == "" {
+			continue
+		}
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+
+	sort.Strings(out)
+
+	return out
+}
+
+func normalizeInventoryModelStock(ms invdom.ModelStock) invdom.ModelStock {
+	ms.Products = normalizeProductIDs(ms.Products)
+	if len(ms.Products) == 0 {
+		ms.Products = nil
+	}
+
+	ms.Accumulation = len(ms.Products)
+
+	reserved := map[string]int{}
+	sum := 0
+
+	for oid, n := range ms.ReservedByOrder {
+		if oid == "" || n <= 0 {
+			continue
+		}
+
+		reserved[oid] = n
+		sum += n
+	}
+
+	if len(reserved) == 0 {
+		reserved = nil
+	}
+
+	ms.ReservedByOrder = reserved
+	ms.ReservedCount = sum
+
+	return ms
+}
+
+// normalizeInventoryStock は products も予約も持たない model を取り除く。
+func normalizeInventoryStock(
+	raw map[string]invdom.ModelStock,
+) map[string]invdom.ModelStock {
+	out := map[string]invdom.ModelStock{}
+
+	for modelID, ms := range raw {
+		if modelID == "" {
+			continue
+		}
+
+		ms = normalizeInventoryModelStock(ms)
+		if len(ms.Products) == 0 && len(ms.ReservedByOrder) == 0 {
+			continue
+		}
+
+		out[modelID] = ms
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+func cloneInventory(m invdom.Mint) invdom.Mint {
+	out := m
+	out.ModelIDs = cloneStrings(m.ModelIDs)
+
+	if m.Stock != nil {
+		out.Stock = make(map[string]invdom.ModelStock, len(m.Stock))
+		for modelID, ms := range m.Stock {
+			ms.Products = cloneStrings(ms.Products)
+			ms.ReservedByOrder = cloneIntMap(ms.ReservedByOrder)
+			out.Stock[modelID] = ms
+		}
+	}
+
+	return out
+}
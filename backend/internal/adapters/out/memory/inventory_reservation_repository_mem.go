@@ -0,0 +1,175 @@
+// backend/internal/adapters/out/memory/inventory_reservation_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	invdom "narratives/internal/domain/inventory"
+)
+
+// InventoryReservationRepositoryMem は inventory.ReservationRepository の in-memory 実装。
+type InventoryReservationRepositoryMem struct {
+	mu           sync.Mutex
+	reservations map[string]invdom.Reservation
+}
+
+var _ invdom.ReservationRepository = (*InventoryReservationRepositoryMem)(nil)
+
+func NewInventoryReservationRepositoryMem() *InventoryReservationRepositoryMem {
+	return &InventoryReservationRepositoryMem{
+		reservations: map[string]invdom.Reservation{},
+	}
+}
+
+// CreateIfAbsent は既存の引当レコードがあれば上書きせずにそれを返す。
+func (r *InventoryReservationRepositoryMem) CreateIfAbsent(
+	_ context.Context,
+	reservation invdom.Reservation,
+) (invdom.Reservation, bool, error) {
+	if err := reservation.Validate(); err != nil {
+		return invdom.Reservation{}, false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.reservations[reservation.ID]; ok {
+		return cloneReservation(existing), false, nil
+	}
+
+	r.reservations[reservation.ID] = cloneReservation(reservation)
+
+	return cloneReservation(reservation), true, nil
+}
+
+func (r *InventoryReservationRepositoryMem) GetByOrderID(
+	_ context.Context,
+	orderID string,
+) (invdom.Reservation, error) {
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return invdom.Reservation{}, invdom.ErrInvalidReservationOrderID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservations[orderID]
+	if !ok {
+		return invdom.Reservation{}, invdom.ErrNotFound
+	}
+
+	return cloneReservation(reservation), nil
+}
+
+func (r *InventoryReservationRepositoryMem) ListExpired(
+	_ context.Context,
+	now time.Time,
+	limit int,
+) ([]invdom.Reservation, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	r.mu.Lock()
+	out := make([]invdom.Reservation, 0)
+	for _, reservation := range r.reservations {
+		if reservation.IsExpired(now) {
+			out = append(out, cloneReservation(reservation))
+		}
+	}
+	r.mu.Unlock()
+
+	sortReservationsByExpiryMem(out)
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+func (r *InventoryReservationRepositoryMem) ListActiveByInventoryID(
+	_ context.Context,
+	inventoryID string,
+) ([]invdom.Reservation, error) {
+	inventoryID = strings.TrimSpace(inventoryID)
+	if inventoryID == "" {
+		return nil, invdom.ErrInvalidMintID
+	}
+
+	r.mu.Lock()
+	out := make([]invdom.Reservation, 0)
+	for _, reservation := range r.reservations {
+		if reservation.Status == invdom.ReservationStatusReleased {
+			continue
+		}
+		for _, item := range reservation.Items {
+			if item.InventoryID == inventoryID {
+				out = append(out, cloneReservation(reservation))
+				break
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	sortReservationsByExpiryMem(out)
+
+	return out, nil
+}
+
+// Update は released 済みのレコードを released 以外に戻さない。
+func (r *InventoryReservationRepositoryMem) Update(
+	_ context.Context,
+	reservation invdom.Reservation,
+) (invdom.Reservation, error) {
+	if err := reservation.Validate(); err != nil {
+		return invdom.Reservation{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.reservations[reservation.ID]
+	if !ok {
+		return invdom.Reservation{}, invdom.ErrNotFound
+	}
+
+	if current.Status == invdom.ReservationStatusReleased {
+		if reservation.Status == invdom.ReservationStatusReleased {
+			return cloneReservation(current), nil
+		}
+		return invdom.Reservation{}, invdom.ErrReservationConflict
+	}
+
+	r.reservations[reservation.ID] = cloneReservation(reservation)
+
+	return cloneReservation(reservation), nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func sortReservationsByExpiryMem(reservations []invdom.Reservation) {
+	sort.SliceStable(reservations, func(i, j int) bool {
+		if reservations[i].ExpiresAt.Equal(reservations[j].ExpiresAt) {
+			return reservations[i].ID < reservations[j].ID
+		}
+		return reservations[i].ExpiresAt.Before(reservations[j].ExpiresAt)
+	})
+}
+
+func cloneReservation(r invdom.Reservation) invdom.Reservation {
+	if r.Items != nil {
+		r.Items = append([]invdom.ReservationItem(nil), r.Items...)
+	}
+	r.ConfirmedAt = cloneTimePtr(r.ConfirmedAt)
+	r.ReleasedAt = cloneTimePtr(r.ReleasedAt)
+
+	return r
+}
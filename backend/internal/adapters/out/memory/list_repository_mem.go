@@ -0,0 +1,406 @@
+// backend/internal/adapters/out/memory/list_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fscommon "narratives/internal/adapters/out/firestore/common"
+	ldom "narratives/internal/domain/list"
+)
+
+// ListRepositoryMem は list.Repository の in-memory 実装。
+// prices は Firestore では subcollection だが、memory では List に含めて保持する。
+type ListRepositoryMem struct {
+	mu    sync.Mutex
+	ids   idSequence
+	lists map[string]ldom.List
+}
+
+var _ ldom.Repository = (*ListRepositoryMem)(nil)
+
+func NewListRepositoryMem() *ListRepositoryMem {
+	return &ListRepositoryMem{
+		lists: map[string]ldom.List{},
+	}
+}
+
+func (r *ListRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (ldom.List, error) {
+	if id == "" {
+		return ldom.List{}, ldom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.lists[id]
+	if !ok {
+		return ldom.List{}, ldom.ErrNotFound
+	}
+
+	return cloneList(l), nil
+}
+
+func (r *ListRepositoryMem) ListByInventoryID(
+	_ context.Context,
+	inventoryID string,
+) ([]ldom.List, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]ldom.List, 0)
+	if inventoryID == "" {
+		return out, nil
+	}
+
+	for _, id := range sortedKeys(r.lists) {
+		if l := r.lists[id]; l.InventoryID == inventoryID {
+			out = append(out, cloneList(l))
+		}
+	}
+
+	return out, nil
+}
+
+// List は updatedAt desc, createdAt desc, id desc の順で返す。
+func (r *ListRepositoryMem) List(
+	_ context.Context,
+	filter ldom.Filter,
+	_ ldom.Sort,
+	page ldom.Page,
+) (ldom.PageResult[ldom.List], error) {
+	pageNum, perPage, offset := fscommon.NormalizePage(
+		page.Number,
+		page.PerPage,
+		50,
+		0,
+	)
+
+	r.mu.Lock()
+	matched := r.filterLocked(filter)
+	r.mu.Unlock()
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+
+		au, bu := timeOrZero(a.UpdatedAt), timeOrZero(b.UpdatedAt)
+		if !au.Equal(bu) {
+			return au.After(bu)
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+
+	return ldom.PageResult[ldom.List]{
+		Items:      paginate(matched, offset, perPage),
+		TotalCount: len(matched),
+		TotalPages: fscommon.ComputeTotalPages(len(matched), perPage),
+		Page:       pageNum,
+		PerPage:    perPage,
+	}, nil
+}
+
+// ListByCursor は id 昇順で cursor paging する。
+func (r *ListRepositoryMem) ListByCursor(
+	_ context.Context,
+	filter ldom.Filter,
+	_ ldom.Sort,
+	cpage ldom.CursorPage,
+) (ldom.CursorPageResult[ldom.List], error) {
+	limit := cpage.Limit
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	r.mu.Lock()
+	matched := r.filterLocked(filter)
+	r.mu.Unlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].ID < matched[j].ID
+	})
+
+	items := make([]ldom.List, 0, limit)
+	for _, l := range matched {
+		if cpage.After != "" && l.ID <= cpage.After {
+			continue
+		}
+		items = append(items, l)
+	}
+
+	var next *string
+	if len(items) > limit {
+		cursor := items[limit-1].ID
+		items = items[:limit]
+		next = &cursor
+	}
+
+	return ldom.CursorPageResult[ldom.List]{
+		Items:      items,
+		NextCursor: next,
+		Limit:      limit,
+	}, nil
+}
+
+func (r *ListRepositoryMem) Create(
+	_ context.Context,
+	l ldom.List,
+) (ldom.List, error) {
+	now := time.Now().UTC()
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = now
+	}
+	if l.UpdatedAt == nil {
+		t := now
+		l.UpdatedAt = &t
+	}
+	if l.ID == "" {
+		l.ID = r.ids.newID("list")
+	}
+
+	if err := l.ValidateForPersist(); err != nil {
+		return ldom.List{}, err
+	}
+	if err := validateUniqueListPriceModelIDs(l.Prices); err != nil {
+		return ldom.List{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.lists[l.ID]; exists {
+		return ldom.List{}, ldom.ErrConflict
+	}
+
+	r.lists[l.ID] = cloneList(l)
+
+	return cloneList(l), nil
+}
+
+// Update は ListRepositoryFS と同じ可変 field だけを更新する。
+// UpdatedBy の空文字 / UpdatedAt の zero 値は clear、UpdatedAt nil は現在時刻を表す。
+func (r *ListRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	l ldom.List,
+) (ldom.List, error) {
+	if id == "" {
+		return ldom.List{}, ldom.ErrNotFound
+	}
+	if l.ID != "" && l.ID != id {
+		return ldom.List{}, ldom.ErrInvalidID
+	}
+	if err := validateUniqueListPriceModelIDs(l.Prices); err != nil {
+		return ldom.List{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.lists[id]
+	if !ok {
+		return ldom.List{}, ldom.ErrNotFound
+	}
+
+	cur := cloneList(stored)
+
+	cur.Status = l.Status
+	cur.AssigneeID = l.AssigneeID
+	cur.Title = l.Title
+	cur.ImageID = l.ImageID
+	cur.InventoryID = l.InventoryID
+	cur.ReadableID = l.ReadableID
+	cur.Description = l.Description
+	cur.Prices = cloneListPrices(l.Prices)
+
+	cur.UpdatedBy, cur.UpdatedAt = applyUpdatedMeta(
+		cur.UpdatedBy,
+		l.UpdatedBy,
+		l.UpdatedAt,
+	)
+
+	if err := cur.ValidateForPersist(); err != nil {
+		return ldom.List{}, err
+	}
+
+	r.lists[id] = cur
+
+	return cloneList(cur), nil
+}
+
+func (r *ListRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if id == "" {
+		return ldom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.lists[id]; !ok {
+		return ldom.ErrNotFound
+	}
+
+	delete(r.lists, id)
+
+	return nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func (r *ListRepositoryMem) filterLocked(filter ldom.Filter) []ldom.List {
+	out := make([]ldom.List, 0, len(r.lists))
+	for _, l := range r.lists {
+		if listMatchesFilter(l, filter) {
+			out = append(out, cloneList(l))
+		}
+	}
+
+	return out
+}
+
+func listMatchesFilter(l ldom.List, f ldom.Filter) bool {
+	if q := strings.ToLower(strings.TrimSpace(f.SearchQuery)); q != "" {
+		if !strings.Contains(strings.ToLower(l.Title), q) &&
+			!strings.Contains(strings.ToLower(l.Description), q) {
+			return false
+		}
+	}
+	if len(f.IDs) > 0 && !containsString(f.IDs, l.ID) {
+		return false
+	}
+	if len(f.ReadableIDs) > 0 && !containsString(f.ReadableIDs, l.ReadableID) {
+		return false
+	}
+	if f.AssigneeID != nil && l.AssigneeID != *f.AssigneeID {
+		return false
+	}
+	if f.Status != nil && l.Status != *f.Status {
+		return false
+	}
+	if len(f.Statuses) > 0 {
+		found := false
+		for _, st := range f.Statuses {
+			if l.Status == st {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.InventoryIDs) > 0 && !containsString(f.InventoryIDs, l.InventoryID) {
+		return false
+	}
+
+	if len(f.ModelIDs) > 0 || f.MinPrice != nil || f.MaxPrice != nil {
+		found := false
+		for _, row := range l.Prices {
+			if len(f.ModelIDs) > 0 && !containsString(f.ModelIDs, row.ModelID) {
+				continue
+			}
+			if f.MinPrice != nil && row.Price < *f.MinPrice {
+				continue
+			}
+			if f.MaxPrice != nil && row.Price > *f.MaxPrice {
+				continue
+			}
+			found = true
+			break
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateUniqueListPriceModelIDs(prices []ldom.ListPriceRow) error {
+	seen := make(map[string]struct{}, len(prices))
+	for _, row := range prices {
+		if row.ModelID == "" {
+			return ldom.ErrInvalidPriceModelID
+		}
+		if row.Price < ldom.MinPrice || row.Price > ldom.MaxPrice {
+			return ldom.ErrInvalidPrice
+		}
+		if _, exists := seen[row.ModelID]; exists {
+			return ldom.ErrInvalidPrices
+		}
+		seen[row.ModelID] = struct{}{}
+	}
+
+	return nil
+}
+
+// applyUpdatedMeta は list / resale の Update で共通の UpdatedBy / UpdatedAt 規則を適用する。
+func applyUpdatedMeta(
+	current *string,
+	updatedBy *string,
+	updatedAt *time.Time,
+) (*string, *time.Time) {
+	nextBy := cloneStringPtr(current)
+	if updatedBy != nil {
+		if *updatedBy == "" {
+			nextBy = nil
+		} else {
+			nextBy = cloneStringPtr(updatedBy)
+		}
+	}
+
+	var nextAt *time.Time
+	switch {
+	case updatedAt == nil:
+		t := time.Now().UTC()
+		nextAt = &t
+	case updatedAt.IsZero():
+		nextAt = nil
+	default:
+		t := updatedAt.UTC()
+		nextAt = &t
+	}
+
+	return nextBy, nextAt
+}
+
+func timeOrZero(p *time.Time) time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+
+	return *p
+}
+
+func cloneListPrices(in []ldom.ListPriceRow) []ldom.ListPriceRow {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]ldom.ListPriceRow, len(in))
+	copy(out, in)
+
+	return out
+}
+
+func cloneList(l ldom.List) ldom.List {
+	out := l
+	out.Prices = cloneListPrices(l.Prices)
+	out.UpdatedBy = cloneStringPtr(l.UpdatedBy)
+	out.UpdatedAt = cloneTimePtr(l.UpdatedAt)
+
+	return out
+}
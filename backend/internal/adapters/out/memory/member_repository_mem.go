@@ -0,0 +1,352 @@
+// backend/internal/adapters/out/memory/member_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fscommon "narratives/internal/adapters/out/firestore/common"
+	memdom "narratives/internal/domain/member"
+)
+
+// MemberRepositoryMem は member.Repository の in-memory 実装。
+//
+// Firestore adapter の memberUIDs/{uid} と同じく、uid → docId の索引を持ち、
+// 同じ UID を 2 つの member に割り当てる Create / Update は ErrConflict にする。
+type MemberRepositoryMem struct {
+	mu  sync.Mutex
+	ids idSequence
+
+	members map[string]memdom.Member
+	byUID   map[string]string
+
+	now func() time.Time
+}
+
+var _ memdom.Repository = (*MemberRepositoryMem)(nil)
+
+func NewMemberRepositoryMem() *MemberRepositoryMem {
+	return &MemberRepositoryMem{
+		members: map[string]memdom.Member{},
+		byUID:   map[string]string{},
+		now:     time.Now,
+	}
+}
+
+func (r *MemberRepositoryMem) Create(
+	_ context.Context,
+	m memdom.Member,
+) (memdom.Record, error) {
+	now := r.now().UTC()
+
+	m = normalizeMemMember(m)
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	} else {
+		m.CreatedAt = m.CreatedAt.UTC()
+	}
+	m.UpdatedAt = &now
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if m.UID != "" {
+		if _, exists := r.byUID[m.UID]; exists {
+			return memdom.Record{}, memdom.ErrConflict
+		}
+	}
+
+	id := r.ids.newID("member")
+
+	r.members[id] = cloneMember(m)
+	if m.UID != "" {
+		r.byUID[m.UID] = id
+	}
+
+	return memdom.Record{DocID: id, Member: cloneMember(m)}, nil
+}
+
+func (r *MemberRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (memdom.Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.members[id]
+	if !ok {
+		return memdom.Record{}, memdom.ErrNotFound
+	}
+
+	return memdom.Record{DocID: id, Member: cloneMember(m)}, nil
+}
+
+func (r *MemberRepositoryMem) GetByUID(
+	_ context.Context,
+	uid string,
+) (memdom.Record, error) {
+	if uid == "" {
+		return memdom.Record{}, memdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byUID[uid]
+	if !ok {
+		return memdom.Record{}, memdom.ErrNotFound
+	}
+
+	m, ok := r.members[id]
+	if !ok {
+		return memdom.Record{}, memdom.ErrNotFound
+	}
+
+	return memdom.Record{DocID: id, Member: cloneMember(m)}, nil
+}
+
+// Update は MemberRepositoryFS と同じく patch を反映し、UID の索引も付け替える。
+func (r *MemberRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	patch memdom.MemberPatch,
+) (memdom.Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.members[id]
+	if !ok {
+		return memdom.Record{}, memdom.ErrNotFound
+	}
+
+	updated, err := applyMemMemberPatch(cloneMember(current), patch, r.now())
+	if err != nil {
+		return memdom.Record{}, err
+	}
+
+	if updated.UID != "" {
+		if owner, exists := r.byUID[updated.UID]; exists && owner != id {
+			return memdom.Record{}, memdom.ErrConflict
+		}
+	}
+
+	if current.UID != "" && current.UID != updated.UID && r.byUID[current.UID] == id {
+		delete(r.byUID, current.UID)
+	}
+	if updated.UID != "" {
+		r.byUID[updated.UID] = id
+	}
+
+	r.members[id] = cloneMember(updated)
+
+	return memdom.Record{DocID: id, Member: cloneMember(updated)}, nil
+}
+
+func (r *MemberRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.members[id]
+	if !ok {
+		return memdom.ErrNotFound
+	}
+
+	if m.UID != "" && r.byUID[m.UID] == id {
+		delete(r.byUID, m.UID)
+	}
+	delete(r.members, id)
+
+	return nil
+}
+
+// ListByCompanyID は updatedAt desc, docId desc の順で返す。
+func (r *MemberRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+	filter memdom.Filter,
+	page memdom.Page,
+) (memdom.RecordPageResult, error) {
+	if companyID == "" {
+		return memdom.RecordPageResult{}, errors.New("member: companyID is empty")
+	}
+
+	pageNum, perPage, offset := fscommon.NormalizePage(
+		page.Number,
+		page.PerPage,
+		50,
+		200,
+	)
+
+	r.mu.Lock()
+	matched := make([]memdom.Record, 0)
+	for _, id := range sortedKeys(r.members) {
+		m := r.members[id]
+		if m.CompanyID != companyID || !matchMemberFilter(m, filter) {
+			continue
+		}
+		matched = append(matched, memdom.Record{DocID: id, Member: cloneMember(m)})
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+
+		au, bu := timeOrZero(a.Member.UpdatedAt), timeOrZero(b.Member.UpdatedAt)
+		if !au.Equal(bu) {
+			return au.After(bu)
+		}
+		return a.DocID > b.DocID
+	})
+
+	return memdom.RecordPageResult{
+		Items:      paginate(matched, offset, perPage),
+		TotalCount: len(matched),
+		TotalPages: fscommon.ComputeTotalPages(len(matched), perPage),
+		Page:       pageNum,
+		PerPage:    perPage,
+	}, nil
+}
+
+func matchMemberFilter(m memdom.Member, f memdom.Filter) bool {
+	if f.UID != "" && m.UID != f.UID {
+		return false
+	}
+	if f.Status != "" && m.Status != f.Status {
+		return false
+	}
+	for _, b := range f.BrandIDs {
+		if !containsString(m.AssignedBrands, b) {
+			return false
+		}
+	}
+	for _, p := range f.Permissions {
+		if !containsString(m.Permissions, p) {
+			return false
+		}
+	}
+
+	if q := strings.ToLower(strings.TrimSpace(f.SearchQuery)); q != "" {
+		found := false
+		for _, v := range []string{m.FirstName, m.LastName, m.FirstNameKana, m.LastNameKana, m.Email} {
+			if strings.Contains(strings.ToLower(v), q) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func applyMemMemberPatch(
+	m memdom.Member,
+	patch memdom.MemberPatch,
+	now time.Time,
+) (memdom.Member, error) {
+	now = now.UTC()
+
+	if patch.UID != nil {
+		m.UID = *patch.UID
+	}
+	if patch.FirstName != nil {
+		m.FirstName = *patch.FirstName
+	}
+	if patch.LastName != nil {
+		m.LastName = *patch.LastName
+	}
+	if patch.FirstNameKana != nil {
+		m.FirstNameKana = *patch.FirstNameKana
+	}
+	if patch.LastNameKana != nil {
+		m.LastNameKana = *patch.LastNameKana
+	}
+	if patch.Email != nil {
+		m.Email = *patch.Email
+	}
+	if patch.Permissions != nil {
+		m.Permissions = cloneStrings(*patch.Permissions)
+	}
+	if patch.Roles != nil {
+		m.Roles = cloneStrings(*patch.Roles)
+	}
+	if patch.AssignedBrands != nil {
+		m.AssignedBrands = cloneStrings(*patch.AssignedBrands)
+	}
+	if patch.CompanyID != nil {
+		m.CompanyID = *patch.CompanyID
+	}
+	if patch.Status != nil {
+		m.Status = *patch.Status
+	}
+	if patch.CreatedAt != nil {
+		m.CreatedAt = patch.CreatedAt.UTC()
+	}
+	if patch.UpdatedBy != nil {
+		if *patch.UpdatedBy == "" {
+			return memdom.Member{}, memdom.ErrInvalidUpdatedBy
+		}
+		m.UpdatedBy = cloneStringPtr(patch.UpdatedBy)
+	}
+	if patch.UpdatedAt != nil {
+		now = patch.UpdatedAt.UTC()
+	}
+
+	m = normalizeMemMember(m)
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	m.UpdatedAt = &now
+
+	return m, nil
+}
+
+// normalizeMemMember は MemberRepositoryFS と同じく email を小文字化し、
+// 権限・ロール・ブランドの空文字と重複を取り除く。
+func normalizeMemMember(m memdom.Member) memdom.Member {
+	m.Email = strings.ToLower(m.Email)
+	m.Permissions = dedupMemStrings(m.Permissions)
+	m.Roles = dedupMemStrings(m.Roles)
+	m.AssignedBrands = dedupMemStrings(m.AssignedBrands)
+	return m
+}
+
+func dedupMemStrings(in []string) []string {
+	seen := make(map[string]struct{}, len(in))
+	out := make([]string, 0, len(in))
+
+	for _, v := range in {
+		if v == "" {
+			continue
+		}
+		if _, exists := seen[v]; exists {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+func cloneMember(m memdom.Member) memdom.Member {
+	m.Permissions = cloneStrings(m.Permissions)
+	m.Roles = cloneStrings(m.Roles)
+	m.AssignedBrands = cloneStrings(m.AssignedBrands)
+	m.UpdatedAt = cloneTimePtr(m.UpdatedAt)
+	m.UpdatedBy = cloneStringPtr(m.UpdatedBy)
+	return m
+}
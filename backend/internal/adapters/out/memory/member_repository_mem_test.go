@@ -0,0 +1,141 @@
+// backend/internal/adapters/out/memory/member_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"narratives/internal/adapters/out/memory"
+	memdom "narratives/internal/domain/member"
+)
+
+func TestMemberRepositoryMem_UIDIndex(t *testing.T) {
+	takenUID := "uid_1"
+	newUID := "uid_3"
+	firstName := "Hanako"
+
+	tests := []struct {
+		name    string
+		run     func(ctx context.Context, repo *memory.MemberRepositoryMem, secondID string) error
+		wantErr error
+		wantUID map[string]string // uid -> 期待する docId（"" は見つからない）
+	}{
+		{
+			name: "create with a taken uid",
+			run: func(ctx context.Context, repo *memory.MemberRepositoryMem, _ string) error {
+				_, err := repo.Create(ctx, memdom.Member{UID: takenUID, CompanyID: "company_1"})
+				return err
+			},
+			wantErr: memdom.ErrConflict,
+			wantUID: map[string]string{takenUID: "first"},
+		},
+		{
+			name: "update to a taken uid",
+			run: func(ctx context.Context, repo *memory.MemberRepositoryMem, secondID string) error {
+				_, err := repo.Update(ctx, secondID, memdom.MemberPatch{UID: &takenUID})
+				return err
+			},
+			wantErr: memdom.ErrConflict,
+			wantUID: map[string]string{takenUID: "first", "uid_2": "second"},
+		},
+		{
+			name: "update moves the uid index",
+			run: func(ctx context.Context, repo *memory.MemberRepositoryMem, secondID string) error {
+				_, err := repo.Update(ctx, secondID, memdom.MemberPatch{UID: &newUID})
+				return err
+			},
+			wantUID: map[string]string{"uid_2": "", newUID: "second"},
+		},
+		{
+			name: "update does not create a missing member",
+			run: func(ctx context.Context, repo *memory.MemberRepositoryMem, _ string) error {
+				_, err := repo.Update(ctx, "member_missing", memdom.MemberPatch{FirstName: &firstName})
+				return err
+			},
+			wantErr: memdom.ErrNotFound,
+		},
+		{
+			name: "delete removes the uid index",
+			run: func(ctx context.Context, repo *memory.MemberRepositoryMem, secondID string) error {
+				return repo.Delete(ctx, secondID)
+			},
+			wantUID: map[string]string{"uid_2": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewMemberRepositoryMem()
+
+			first, err := repo.Create(ctx, memdom.Member{UID: takenUID, CompanyID: "company_1"})
+			if err != nil {
+				t.Fatalf("seed first: %v", err)
+			}
+			second, err := repo.Create(ctx, memdom.Member{UID: "uid_2", CompanyID: "company_1"})
+			if err != nil {
+				t.Fatalf("seed second: %v", err)
+			}
+			docIDs := map[string]string{"first": first.DocID, "second": second.DocID}
+
+			err = tt.run(ctx, repo, second.DocID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			for uid, want := range tt.wantUID {
+				rec, err := repo.GetByUID(ctx, uid)
+				if want == "" {
+					if !errors.Is(err, memdom.ErrNotFound) {
+						t.Fatalf("GetByUID(%q) err = %v, want ErrNotFound", uid, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("GetByUID(%q): %v", uid, err)
+				}
+				if rec.DocID != docIDs[want] {
+					t.Fatalf("GetByUID(%q) = %q, want %q", uid, rec.DocID, docIDs[want])
+				}
+			}
+		})
+	}
+}
+
+func TestMemberRepositoryMem_ListByCompanyID(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemberRepositoryMem()
+
+	for _, m := range []memdom.Member{
+		{CompanyID: "company_1", Status: "active", Email: "A@example.com"},
+		{CompanyID: "company_1", Status: "inactive", Email: "b@example.com"},
+		{CompanyID: "company_2", Status: "active", Email: "c@example.com"},
+	} {
+		if _, err := repo.Create(ctx, m); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter memdom.Filter
+		want   int
+	}{
+		{name: "company scope", filter: memdom.Filter{}, want: 2},
+		{name: "status", filter: memdom.Filter{Status: "active"}, want: 1},
+		{name: "normalized email", filter: memdom.Filter{SearchQuery: "a@example"}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListByCompanyID(ctx, "company_1", tt.filter, memdom.Page{})
+			if err != nil {
+				t.Fatalf("ListByCompanyID: %v", err)
+			}
+			if got.TotalCount != tt.want {
+				t.Fatalf("TotalCount = %d, want %d", got.TotalCount, tt.want)
+			}
+		})
+	}
+}
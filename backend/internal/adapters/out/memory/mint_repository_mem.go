@@ -0,0 +1,418 @@
+// backend/internal/adapters/out/memory/mint_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	mintdom "narratives/internal/domain/mint"
+)
+
+// MintRepositoryMem は mint.MintRepository と mint.MintProductTaskRepository の
+// in-memory 実装。
+//
+// Firestore adapter の Create は merge 書き込みだが、memory では永続化契約を
+// 明確にするため Create は既存 mint を上書きせず mintdom.ErrConflict を返す。
+type MintRepositoryMem struct {
+	mu sync.Mutex
+
+	mints map[string]mintdom.Mint
+
+	// tasks は mintID -> productID -> task。
+	tasks map[string]map[string]mintdom.MintProductTask
+}
+
+var (
+	_ mintdom.MintRepository            = (*MintRepositoryMem)(nil)
+	_ mintdom.MintProductTaskRepository = (*MintRepositoryMem)(nil)
+)
+
+func NewMintRepositoryMem() *MintRepositoryMem {
+	return &MintRepositoryMem{
+		mints: map[string]mintdom.Mint{},
+		tasks: map[string]map[string]mintdom.MintProductTask{},
+	}
+}
+
+// ============================================================
+// mintdom.MintRepository
+// ============================================================
+
+func (r *MintRepositoryMem) Create(
+	_ context.Context,
+	m mintdom.Mint,
+) (mintdom.Mint, error) {
+	if m.ID == "" {
+		return mintdom.Mint{}, errors.New("mint.ID is empty")
+	}
+
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+	if m.Status == "" {
+		m.Status = mintdom.MintStatusCreated
+	}
+
+	if err := m.Validate(); err != nil {
+		return mintdom.Mint{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.mints[m.ID]; exists {
+		return mintdom.Mint{}, mintdom.ErrConflict
+	}
+
+	r.mints[m.ID] = cloneMint(m)
+
+	return cloneMint(m), nil
+}
+
+func (r *MintRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (mintdom.Mint, error) {
+	if id == "" {
+		return mintdom.Mint{}, mintdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.mints[id]
+	if !ok {
+		return mintdom.Mint{}, mintdom.ErrNotFound
+	}
+
+	return cloneMint(m), nil
+}
+
+// Update は MintRepositoryFS と同様に、未指定の不変 field を既存値で補完してから保存する。
+func (r *MintRepositoryMem) Update(
+	_ context.Context,
+	m mintdom.Mint,
+) (mintdom.Mint, error) {
+	if m.ID == "" {
+		return mintdom.Mint{}, errors.New("mint.ID is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.mints[m.ID]
+	if !ok {
+		return mintdom.Mint{}, mintdom.ErrNotFound
+	}
+
+	if m.Status == "" {
+		m.Status = mintdom.MintStatusCreated
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = existing.CreatedAt
+	}
+	if m.CreatedBy == "" {
+		m.CreatedBy = existing.CreatedBy
+	}
+	if m.RequestedBy == "" {
+		m.RequestedBy = existing.RequestedBy
+	}
+	if m.BrandID == "" {
+		m.BrandID = existing.BrandID
+	}
+	if m.TokenBlueprintID == "" {
+		m.TokenBlueprintID = existing.TokenBlueprintID
+	}
+	if len(m.Products) == 0 {
+		m.Products = cloneStrings(existing.Products)
+	}
+
+	if err := m.Validate(); err != nil {
+		return mintdom.Mint{}, err
+	}
+
+	r.mints[m.ID] = cloneMint(m)
+
+	return cloneMint(m), nil
+}
+
+// ============================================================
+// mintdom.MintProductTaskRepository
+// ============================================================
+
+// CreateTasks は冪等。既存 task はそのまま返し、存在しない product の task だけ作成する。
+func (r *MintRepositoryMem) CreateTasks(
+	_ context.Context,
+	mintID string,
+	productIDs []string,
+) ([]mintdom.MintProductTask, error) {
+	if mintID == "" {
+		return nil, errors.New("mint id is empty")
+	}
+	if len(productIDs) == 0 {
+		return nil, mintdom.ErrInvalidProducts
+	}
+
+	now := time.Now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byProduct := r.tasks[mintID]
+	if byProduct == nil {
+		byProduct = map[string]mintdom.MintProductTask{}
+	}
+
+	created := map[string]mintdom.MintProductTask{}
+	out := make([]mintdom.MintProductTask, 0, len(productIDs))
+
+	for _, productID := range productIDs {
+		if productID == "" {
+			return nil, mintdom.ErrInvalidProducts
+		}
+
+		if existing, ok := byProduct[productID]; ok {
+			out = append(out, cloneMintProductTask(existing))
+			continue
+		}
+		if pending, ok := created[productID]; ok {
+			out = append(out, cloneMintProductTask(pending))
+			continue
+		}
+
+		task, err := mintdom.NewMintProductTask(mintID, productID, now)
+		if err != nil {
+			return nil, err
+		}
+
+		created[productID] = task
+		out = append(out, cloneMintProductTask(task))
+	}
+
+	for productID, task := range created {
+		byProduct[productID] = task
+	}
+	r.tasks[mintID] = byProduct
+
+	return out, nil
+}
+
+func (r *MintRepositoryMem) GetByProductID(
+	_ context.Context,
+	mintID string,
+	productID string,
+) (mintdom.MintProductTask, error) {
+	if mintID == "" {
+		return mintdom.MintProductTask{}, errors.New("mint id is empty")
+	}
+	if productID == "" {
+		return mintdom.MintProductTask{}, errors.New("product id is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[mintID][productID]
+	if !ok {
+		return mintdom.MintProductTask{}, mintdom.ErrMintProductTaskNotFound
+	}
+
+	return cloneMintProductTask(task), nil
+}
+
+func (r *MintRepositoryMem) ListByMintID(
+	_ context.Context,
+	mintID string,
+) ([]mintdom.MintProductTask, error) {
+	if mintID == "" {
+		return nil, errors.New("mint id is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]mintdom.MintProductTask, 0, len(r.tasks[mintID]))
+	for _, productID := range sortedKeys(r.tasks[mintID]) {
+		out = append(out, cloneMintProductTask(r.tasks[mintID][productID]))
+	}
+
+	return out, nil
+}
+
+// GetNextExecutableTask は PENDING、次に FAILED_RETRYABLE の順で、
+// createdAt, productId 昇順の先頭 task を返す。
+func (r *MintRepositoryMem) GetNextExecutableTask(
+	_ context.Context,
+	mintID string,
+) (mintdom.MintProductTask, error) {
+	if mintID == "" {
+		return mintdom.MintProductTask{}, errors.New("mint id is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := []mintdom.MintProductTaskStatus{
+		mintdom.MintProductTaskStatusPending,
+		mintdom.MintProductTaskStatusFailedRetryable,
+	}
+
+	for _, st := range statuses {
+		candidates := make([]mintdom.MintProductTask, 0)
+		for _, task := range r.tasks[mintID] {
+			if task.Status == st {
+				candidates = append(candidates, task)
+			}
+		}
+
+		if len(candidates) == 0 {
+			continue
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			if !candidates[i].CreatedAt.Equal(candidates[j].CreatedAt) {
+				return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+			}
+			return candidates[i].ProductID < candidates[j].ProductID
+		})
+
+		return cloneMintProductTask(candidates[0]), nil
+	}
+
+	return mintdom.MintProductTask{}, mintdom.ErrMintProductTaskNotFound
+}
+
+func (r *MintRepositoryMem) MarkMinting(
+	_ context.Context,
+	mintID string,
+	productID string,
+) (mintdom.MintProductTask, error) {
+	return r.updateTask(
+		mintID,
+		productID,
+		func(task *mintdom.MintProductTask) error {
+			return task.MarkMinting(time.Now().UTC())
+		},
+	)
+}
+
+func (r *MintRepositoryMem) MarkMinted(
+	_ context.Context,
+	mintID string,
+	productID string,
+	assetID string,
+	treeAddress string,
+	leafIndex uint64,
+	signature string,
+) (mintdom.MintProductTask, error) {
+	return r.updateTask(
+		mintID,
+		productID,
+		func(task *mintdom.MintProductTask) error {
+			return task.MarkMinted(
+				time.Now().UTC(),
+				assetID,
+				treeAddress,
+				leafIndex,
+				signature,
+			)
+		},
+	)
+}
+
+func (r *MintRepositoryMem) MarkFailedRetryable(
+	_ context.Context,
+	mintID string,
+	productID string,
+	message string,
+) (mintdom.MintProductTask, error) {
+	return r.updateTask(
+		mintID,
+		productID,
+		func(task *mintdom.MintProductTask) error {
+			return task.MarkFailedRetryable(time.Now().UTC(), message)
+		},
+	)
+}
+
+func (r *MintRepositoryMem) MarkFailedFatal(
+	_ context.Context,
+	mintID string,
+	productID string,
+	message string,
+) (mintdom.MintProductTask, error) {
+	return r.updateTask(
+		mintID,
+		productID,
+		func(task *mintdom.MintProductTask) error {
+			return task.MarkFailedFatal(time.Now().UTC(), message)
+		},
+	)
+}
+
+func (r *MintRepositoryMem) ResetRetryableToPending(
+	_ context.Context,
+	mintID string,
+	productID string,
+) (mintdom.MintProductTask, error) {
+	return r.updateTask(
+		mintID,
+		productID,
+		func(task *mintdom.MintProductTask) error {
+			return task.ResetToPending(time.Now().UTC())
+		},
+	)
+}
+
+// updateTask は既存 task に entity の状態遷移を適用して保存する。
+// 遷移が失敗した場合は保存値を変更しない。
+func (r *MintRepositoryMem) updateTask(
+	mintID string,
+	productID string,
+	apply func(task *mintdom.MintProductTask) error,
+) (mintdom.MintProductTask, error) {
+	if mintID == "" {
+		return mintdom.MintProductTask{}, errors.New("mint id is empty")
+	}
+	if productID == "" {
+		return mintdom.MintProductTask{}, errors.New("product id is empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tasks[mintID][productID]
+	if !ok {
+		return mintdom.MintProductTask{}, mintdom.ErrMintProductTaskNotFound
+	}
+
+	task := cloneMintProductTask(stored)
+	if err := apply(&task); err != nil {
+		return mintdom.MintProductTask{}, err
+	}
+
+	r.tasks[mintID][productID] = task
+
+	return cloneMintProductTask(task), nil
+}
+
+func cloneMint(m mintdom.Mint) mintdom.Mint {
+	out := m
+	out.Products = cloneStrings(m.Products)
+	out.MintedAt = cloneTimePtr(m.MintedAt)
+	out.ScheduledBurnDate = cloneTimePtr(m.ScheduledBurnDate)
+
+	return out
+}
+
+func cloneMintProductTask(t mintdom.MintProductTask) mintdom.MintProductTask {
+	out := t
+	out.MintingStartedAt = cloneTimePtr(t.MintingStartedAt)
+	out.MintedAt = cloneTimePtr(t.MintedAt)
+	out.LastFailedAt = cloneTimePtr(t.LastFailedAt)
+
+	return out
+}
@@ -0,0 +1,322 @@
+// backend/internal/adapters/out/memory/model_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	modeldom "narratives/internal/domain/model"
+)
+
+// ModelRepositoryMem は model.RepositoryPort の in-memory 実装。
+// variation は ApparelModelVariation / AlcoholModelVariation の値として保持する。
+type ModelRepositoryMem struct {
+	mu         sync.Mutex
+	ids        idSequence
+	variations map[string]modeldom.ModelVariation
+}
+
+var _ modeldom.RepositoryPort = (*ModelRepositoryMem)(nil)
+
+func NewModelRepositoryMem() *ModelRepositoryMem {
+	return &ModelRepositoryMem{
+		variations: map[string]modeldom.ModelVariation{},
+	}
+}
+
+// ListByProductBlueprintID は updatedAt desc, createdAt desc, id asc の順で返す。
+func (r *ModelRepositoryMem) ListByProductBlueprintID(
+	_ context.Context,
+	productBlueprintID string,
+) ([]modeldom.ModelVariation, error) {
+	if productBlueprintID == "" {
+		return nil, modeldom.ErrInvalidBlueprintID
+	}
+
+	r.mu.Lock()
+	out := make([]modeldom.ModelVariation, 0)
+	for _, v := range r.variations {
+		if v.GetProductBlueprintID() == productBlueprintID {
+			out = append(out, cloneModelVariation(v))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		au, ac, aid := modelVariationSortKey(out[i])
+		bu, bc, bid := modelVariationSortKey(out[j])
+
+		if !au.Equal(bu) {
+			return au.After(bu)
+		}
+		if !ac.Equal(bc) {
+			return ac.After(bc)
+		}
+		return aid < bid
+	})
+
+	return out, nil
+}
+
+func (r *ModelRepositoryMem) GetByID(
+	_ context.Context,
+	variationID string,
+) (modeldom.ModelVariation, error) {
+	if variationID == "" {
+		return nil, modeldom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.variations[variationID]
+	if !ok {
+		return nil, modeldom.ErrNotFound
+	}
+
+	return cloneModelVariation(v), nil
+}
+
+func (r *ModelRepositoryMem) Create(
+	_ context.Context,
+	variation modeldom.NewModelVariation,
+) (modeldom.ModelVariation, error) {
+	if err := variation.Validate(); err != nil {
+		return nil, err
+	}
+
+	v, err := newModelVariationMem(
+		r.ids.newID("model"),
+		variation,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.variations[v.GetID()]; exists {
+		return nil, modeldom.ErrConflict
+	}
+
+	r.variations[v.GetID()] = cloneModelVariation(v)
+
+	return cloneModelVariation(v), nil
+}
+
+// Update は指定された field だけを更新し、updatedAt を現在時刻にする。
+func (r *ModelRepositoryMem) Update(
+	_ context.Context,
+	variationID string,
+	updates modeldom.ModelVariationUpdate,
+) (modeldom.ModelVariation, error) {
+	if variationID == "" {
+		return nil, modeldom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.variations[variationID]
+	if !ok {
+		return nil, modeldom.ErrNotFound
+	}
+	if err := updates.Validate(current.GetKind()); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	var next modeldom.ModelVariation
+	switch v := cloneModelVariation(current).(type) {
+	case modeldom.ApparelModelVariation:
+		if updates.Size != nil {
+			v.Size = *updates.Size
+		}
+		if updates.Color != nil {
+			v.Color = *updates.Color
+		}
+		if updates.ModelNumber != nil {
+			v.ModelNumber = *updates.ModelNumber
+		}
+		if updates.Measurements != nil {
+			v.Measurements = updates.Measurements.Clone()
+		}
+		if updates.ShippingPackage != nil {
+			v.ShippingPackage = *updates.ShippingPackage
+		}
+		v.UpdatedAt = now
+		next = v
+
+	case modeldom.AlcoholModelVariation:
+		if updates.ModelNumber != nil {
+			v.ModelNumber = *updates.ModelNumber
+		}
+		if updates.Volume != nil {
+			v.Volume = *updates.Volume
+		}
+		if updates.ShippingPackage != nil {
+			v.ShippingPackage = *updates.ShippingPackage
+		}
+		v.UpdatedAt = now
+		next = v
+
+	default:
+		return nil, modeldom.ErrInvalidKind
+	}
+
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+
+	r.variations[variationID] = cloneModelVariation(next)
+
+	return cloneModelVariation(next), nil
+}
+
+func (r *ModelRepositoryMem) Delete(
+	_ context.Context,
+	variationID string,
+) error {
+	if variationID == "" {
+		return modeldom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.variations[variationID]; !ok {
+		return modeldom.ErrNotFound
+	}
+
+	delete(r.variations, variationID)
+
+	return nil
+}
+
+// ReplaceByProductBlueprintID は既存 variation の削除と新規作成を 1 つの lock 内で行う。
+// 戻り値は request の順序を維持する。
+func (r *ModelRepositoryMem) ReplaceByProductBlueprintID(
+	_ context.Context,
+	productBlueprintID string,
+	variations []modeldom.NewModelVariation,
+) ([]modeldom.ModelVariation, error) {
+	if productBlueprintID == "" {
+		return nil, modeldom.ErrInvalidBlueprintID
+	}
+
+	now := time.Now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prepared := make([]modeldom.ModelVariation, 0, len(variations))
+	for _, variation := range variations {
+		if err := variation.Validate(); err != nil {
+			return nil, err
+		}
+		if variation.ProductBlueprintID() != productBlueprintID {
+			return nil, modeldom.ErrProductMismatch
+		}
+
+		v, err := newModelVariationMem(r.ids.newID("model"), variation, now)
+		if err != nil {
+			return nil, err
+		}
+		prepared = append(prepared, v)
+	}
+
+	for id, v := range r.variations {
+		if v.GetProductBlueprintID() == productBlueprintID {
+			delete(r.variations, id)
+		}
+	}
+
+	out := make([]modeldom.ModelVariation, 0, len(prepared))
+	for _, v := range prepared {
+		r.variations[v.GetID()] = cloneModelVariation(v)
+		out = append(out, cloneModelVariation(v))
+	}
+
+	return out, nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func newModelVariationMem(
+	id string,
+	input modeldom.NewModelVariation,
+	now time.Time,
+) (modeldom.ModelVariation, error) {
+	var v modeldom.ModelVariation
+
+	switch input.Kind {
+	case modeldom.ModelVariationKindApparel:
+		v = modeldom.ApparelModelVariation{
+			ID:                 id,
+			ProductBlueprintID: input.Apparel.ProductBlueprintID,
+			ModelNumber:        input.Apparel.ModelNumber,
+			Size:               input.Apparel.Size,
+			Color:              input.Apparel.Color,
+			Measurements:       input.Apparel.Measurements.Clone(),
+			ShippingPackage:    input.Apparel.ShippingPackage,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+
+	case modeldom.ModelVariationKindAlcohol:
+		v = modeldom.AlcoholModelVariation{
+			ID:                 id,
+			ProductBlueprintID: input.Alcohol.ProductBlueprintID,
+			ModelNumber:        input.Alcohol.ModelNumber,
+			Volume:             input.Alcohol.Volume,
+			ShippingPackage:    input.Alcohol.ShippingPackage,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+
+	default:
+		return nil, modeldom.ErrInvalidKind
+	}
+
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func modelVariationSortKey(
+	v modeldom.ModelVariation,
+) (time.Time, time.Time, string) {
+	switch typed := v.(type) {
+	case modeldom.ApparelModelVariation:
+		return typed.UpdatedAt, typed.CreatedAt, typed.ID
+	case modeldom.AlcoholModelVariation:
+		return typed.UpdatedAt, typed.CreatedAt, typed.ID
+	default:
+		return time.Time{}, time.Time{}, v.GetID()
+	}
+}
+
+func cloneModelVariation(v modeldom.ModelVariation) modeldom.ModelVariation {
+	switch typed := v.(type) {
+	case modeldom.ApparelModelVariation:
+		typed.Measurements = typed.Measurements.Clone()
+		typed.CreatedBy = cloneStringPtr(typed.CreatedBy)
+		typed.UpdatedBy = cloneStringPtr(typed.UpdatedBy)
+		return typed
+	case modeldom.AlcoholModelVariation:
+		typed.CreatedBy = cloneStringPtr(typed.CreatedBy)
+		typed.UpdatedBy = cloneStringPtr(typed.UpdatedBy)
+		return typed
+	default:
+		return v
+	}
+}
@@ -0,0 +1,129 @@
+// backend/internal/adapters/out/memory/nfc_tag_repository_mem.go
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	nfcdom "narratives/internal/domain/nfc"
+)
+
+// NFCTagRepositoryMem は nfc.RepositoryPort の in-memory 実装。
+// Firestore と同じく docId = productId で保存する。
+type NFCTagRepositoryMem struct {
+	mu   sync.Mutex
+	tags map[string]nfcdom.Tag
+}
+
+var _ nfcdom.RepositoryPort = (*NFCTagRepositoryMem)(nil)
+
+func NewNFCTagRepositoryMem() *NFCTagRepositoryMem {
+	return &NFCTagRepositoryMem{
+		tags: map[string]nfcdom.Tag{},
+	}
+}
+
+func (r *NFCTagRepositoryMem) GetByProductID(
+	_ context.Context,
+	productID string,
+) (nfcdom.Tag, error) {
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return nfcdom.Tag{}, nfcdom.ErrInvalidProductID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tags[productID]
+	if !ok {
+		return nfcdom.Tag{}, nfcdom.ErrNotFound
+	}
+
+	return cloneNFCTag(t), nil
+}
+
+// ListByProductionID は productId asc の順で返す。
+func (r *NFCTagRepositoryMem) ListByProductionID(
+	_ context.Context,
+	productionID string,
+) ([]nfcdom.Tag, error) {
+	productionID = strings.TrimSpace(productionID)
+	if productionID == "" {
+		return nil, nfcdom.ErrInvalidProductionID
+	}
+
+	out := make([]nfcdom.Tag, 0)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sortedKeys(r.tags) {
+		if t := r.tags[id]; t.ProductionID == productionID {
+			out = append(out, cloneNFCTag(t))
+		}
+	}
+
+	return out, nil
+}
+
+func (r *NFCTagRepositoryMem) CreateIfAbsent(
+	_ context.Context,
+	t nfcdom.Tag,
+) (bool, error) {
+	productID := strings.TrimSpace(t.ProductID)
+	if productID == "" {
+		return false, nfcdom.ErrInvalidProductID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tags[productID]; exists {
+		return false, nil
+	}
+
+	r.tags[productID] = cloneNFCTag(t)
+
+	return true, nil
+}
+
+// Update は fn がエラーを返した場合、保存せずにそのエラーを返す。
+func (r *NFCTagRepositoryMem) Update(
+	_ context.Context,
+	productID string,
+	fn func(t *nfcdom.Tag) error,
+) (nfcdom.Tag, error) {
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return nfcdom.Tag{}, nfcdom.ErrInvalidProductID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.tags[productID]
+	if !ok {
+		return nfcdom.Tag{}, nfcdom.ErrNotFound
+	}
+
+	t := cloneNFCTag(current)
+	if err := fn(&t); err != nil {
+		return nfcdom.Tag{}, err
+	}
+
+	r.tags[productID] = cloneNFCTag(t)
+
+	return cloneNFCTag(t), nil
+}
+
+func cloneNFCTag(t nfcdom.Tag) nfcdom.Tag {
+	out := t
+
+	out.LastVerifiedAt = cloneTimePtr(t.LastVerifiedAt)
+	out.ProvisionedAt = cloneTimePtr(t.ProvisionedAt)
+	out.RevokedAt = cloneTimePtr(t.RevokedAt)
+
+	return out
+}
@@ -0,0 +1,83 @@
+// backend/internal/adapters/out/memory/nfc_tag_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	nfcdom "narratives/internal/domain/nfc"
+)
+
+func TestNFCTagRepositoryMem_Update(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	errRejected := errors.New("rejected")
+
+	tests := []struct {
+		name       string
+		productID  string
+		fn         func(t *nfcdom.Tag) error
+		wantErr    error
+		wantStatus nfcdom.Status
+	}{
+		{
+			name:       "update of an assigned tag",
+			productID:  "product_1",
+			fn:         func(tag *nfcdom.Tag) error { return tag.Provision("04A1B2C3D4E5F6", now) },
+			wantStatus: nfcdom.StatusProvisioned,
+		},
+		{
+			name:      "fn error is not saved",
+			productID: "product_1",
+			fn: func(tag *nfcdom.Tag) error {
+				tag.Status = nfcdom.StatusRevoked
+				return errRejected
+			},
+			wantErr:    errRejected,
+			wantStatus: nfcdom.StatusAssigned,
+		},
+		{
+			name:       "update does not create",
+			productID:  "product_missing",
+			fn:         func(*nfcdom.Tag) error { return nil },
+			wantErr:    nfcdom.ErrNotFound,
+			wantStatus: nfcdom.StatusAssigned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewNFCTagRepositoryMem()
+
+			tag, err := nfcdom.NewAssigned("product_1", "production_1", "company_1", now)
+			if err != nil {
+				t.Fatalf("NewAssigned: %v", err)
+			}
+			if created, err := repo.CreateIfAbsent(ctx, tag); err != nil || !created {
+				t.Fatalf("CreateIfAbsent = (%v, %v), want created", created, err)
+			}
+			if created, err := repo.CreateIfAbsent(ctx, tag); err != nil || created {
+				t.Fatalf("second CreateIfAbsent = (%v, %v), want not created", created, err)
+			}
+
+			_, err = repo.Update(ctx, tt.productID, tt.fn)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repo.GetByProductID(ctx, "product_1")
+			if err != nil {
+				t.Fatalf("GetByProductID: %v", err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Fatalf("status = %q, want %q", stored.Status, tt.wantStatus)
+			}
+			if _, err := repo.GetByProductID(ctx, "product_missing"); !errors.Is(err, nfcdom.ErrNotFound) {
+				t.Fatalf("GetByProductID(product_missing) err = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// backend/internal/adapters/out/memory/offer_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	offerdom "narratives/internal/domain/offer"
+)
+
+// OfferRepositoryMem は offer.RepositoryPort の in-memory 実装。
+// Firestore adapter と同様に、Update は保存済みの UpdatedAt を比較して競合を検出する。
+type OfferRepositoryMem struct {
+	mu     sync.Mutex
+	ids    idSequence
+	offers map[string]offerdom.Offer
+}
+
+var _ offerdom.RepositoryPort = (*OfferRepositoryMem)(nil)
+
+func NewOfferRepositoryMem() *OfferRepositoryMem {
+	return &OfferRepositoryMem{
+		offers: map[string]offerdom.Offer{},
+	}
+}
+
+func (r *OfferRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (offerdom.Offer, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return offerdom.Offer{}, offerdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	o, ok := r.offers[id]
+	if !ok {
+		return offerdom.Offer{}, offerdom.ErrNotFound
+	}
+
+	return cloneOffer(o), nil
+}
+
+func (r *OfferRepositoryMem) ListByResaleID(
+	_ context.Context,
+	resaleID string,
+) ([]offerdom.Offer, error) {
+	return r.listWhere(resaleID, func(o offerdom.Offer) string { return o.ResaleID }), nil
+}
+
+func (r *OfferRepositoryMem) ListByBuyerAvatarID(
+	_ context.Context,
+	avatarID string,
+) ([]offerdom.Offer, error) {
+	return r.listWhere(avatarID, func(o offerdom.Offer) string { return o.BuyerAvatarID }), nil
+}
+
+func (r *OfferRepositoryMem) ListBySellerAvatarID(
+	_ context.Context,
+	avatarID string,
+) ([]offerdom.Offer, error) {
+	return r.listWhere(avatarID, func(o offerdom.Offer) string { return o.SellerAvatarID }), nil
+}
+
+// listWhere は updatedAt desc の順で返す。
+func (r *OfferRepositoryMem) listWhere(
+	value string,
+	field func(offerdom.Offer) string,
+) []offerdom.Offer {
+	out := make([]offerdom.Offer, 0)
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return out
+	}
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.offers) {
+		if o := r.offers[id]; field(o) == value {
+			out = append(out, cloneOffer(o))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].UpdatedAt.After(out[j].UpdatedAt)
+	})
+
+	return out
+}
+
+// ListDue は updatedAt asc の順で、期限切れの offer を limit 件まで返す。
+func (r *OfferRepositoryMem) ListDue(
+	_ context.Context,
+	now time.Time,
+	limit int,
+) ([]offerdom.Offer, error) {
+	now = now.UTC()
+	if limit <= 0 {
+		limit = 100
+	}
+
+	due := make([]offerdom.Offer, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.offers) {
+		if o := r.offers[id]; o.IsExpired(now) {
+			due = append(due, cloneOffer(o))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].UpdatedAt.Before(due[j].UpdatedAt)
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *OfferRepositoryMem) Create(
+	_ context.Context,
+	o offerdom.Offer,
+) (offerdom.Offer, error) {
+	if err := o.Validate(); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	o.ID = strings.TrimSpace(o.ID)
+	if o.ID == "" {
+		o.ID = r.ids.newID("offer")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.offers[o.ID]; exists {
+		return offerdom.Offer{}, offerdom.ErrConflict
+	}
+
+	r.offers[o.ID] = cloneOffer(o)
+
+	return cloneOffer(o), nil
+}
+
+func (r *OfferRepositoryMem) Update(
+	_ context.Context,
+	o offerdom.Offer,
+	prevUpdatedAt time.Time,
+) (offerdom.Offer, error) {
+	id := strings.TrimSpace(o.ID)
+	if id == "" || strings.Contains(id, "/") {
+		return offerdom.Offer{}, offerdom.ErrInvalidID
+	}
+
+	if err := o.Validate(); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.offers[id]
+	if !ok {
+		return offerdom.Offer{}, offerdom.ErrNotFound
+	}
+
+	// Firestore の timestamp 精度に合わせて比較する。
+	if !current.UpdatedAt.Truncate(time.Microsecond).Equal(
+		prevUpdatedAt.UTC().Truncate(time.Microsecond),
+	) {
+		return offerdom.Offer{}, offerdom.ErrConflict
+	}
+
+	r.offers[id] = cloneOffer(o)
+
+	return cloneOffer(o), nil
+}
+
+func cloneOffer(o offerdom.Offer) offerdom.Offer {
+	out := o
+
+	if o.Rounds != nil {
+		out.Rounds = make([]offerdom.Round, len(o.Rounds))
+		copy(out.Rounds, o.Rounds)
+	}
+	out.CheckoutExpiresAt = cloneTimePtr(o.CheckoutExpiresAt)
+
+	return out
+}
@@ -0,0 +1,329 @@
+// backend/internal/adapters/out/memory/order_dispatch_notification_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	orderdom "narratives/internal/domain/order"
+)
+
+const orderDispatchNotificationAttemptErrorMem = "maximum dispatch notification delivery attempts reached"
+
+// OrderDispatchNotificationRepositoryMem は order.DispatchNotificationRepository の in-memory 実装。
+// expectedAttemptCount による楽観的な状態遷移は Firestore 実装と同じ規則に従う。
+type OrderDispatchNotificationRepositoryMem struct {
+	mu         sync.Mutex
+	deliveries map[string]orderdom.DispatchNotificationDelivery
+}
+
+var _ orderdom.DispatchNotificationRepository = (*OrderDispatchNotificationRepositoryMem)(nil)
+
+func NewOrderDispatchNotificationRepositoryMem() *OrderDispatchNotificationRepositoryMem {
+	return &OrderDispatchNotificationRepositoryMem{
+		deliveries: map[string]orderdom.DispatchNotificationDelivery{},
+	}
+}
+
+// CreateIfAbsent は既存の delivery があれば上書きせずにそれを返す。
+func (r *OrderDispatchNotificationRepositoryMem) CreateIfAbsent(
+	_ context.Context,
+	delivery orderdom.DispatchNotificationDelivery,
+) (orderdom.DispatchNotificationDelivery, bool, error) {
+	normalized, err := delivery.Normalize()
+	if err != nil {
+		return orderdom.DispatchNotificationDelivery{}, false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.deliveries[normalized.ID]; ok {
+		return cloneDispatchNotificationDelivery(existing), false, nil
+	}
+
+	r.deliveries[normalized.ID] = cloneDispatchNotificationDelivery(normalized)
+
+	return cloneDispatchNotificationDelivery(normalized), true, nil
+}
+
+func (r *OrderDispatchNotificationRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (orderdom.DispatchNotificationDelivery, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return orderdom.DispatchNotificationDelivery{},
+			orderdom.ErrDispatchNotificationDeliveryIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return orderdom.DispatchNotificationDelivery{}, orderdom.ErrNotFound
+	}
+
+	return cloneDispatchNotificationDelivery(delivery), nil
+}
+
+// ListDue は attempt 上限に達していない due な delivery を due 時刻の昇順で返す。
+func (r *OrderDispatchNotificationRepositoryMem) ListDue(
+	_ context.Context,
+	now time.Time,
+	limit int,
+) ([]orderdom.DispatchNotificationDelivery, error) {
+	now = now.UTC()
+	if limit <= 0 {
+		limit = 50
+	}
+
+	r.mu.Lock()
+	out := make([]orderdom.DispatchNotificationDelivery, 0)
+	for _, delivery := range r.deliveries {
+		switch delivery.Status {
+		case orderdom.DispatchNotificationStatusPending,
+			orderdom.DispatchNotificationStatusProcessing,
+			orderdom.DispatchNotificationStatusRetryableFailed:
+		default:
+			continue
+		}
+		if delivery.AttemptCount >= delivery.MaxAttempts {
+			continue
+		}
+		if !delivery.IsDue(now) {
+			continue
+		}
+		out = append(out, cloneDispatchNotificationDelivery(delivery))
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		left := dispatchNotificationDueTimeMem(out[i])
+		right := dispatchNotificationDueTimeMem(out[j])
+		if left.Equal(right) {
+			if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+				return out[i].ID < out[j].ID
+			}
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return left.Before(right)
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+// Claim は attempt 上限に達した delivery を failed にして ErrDispatchNotificationAttemptLimit を返す。
+func (r *OrderDispatchNotificationRepositoryMem) Claim(
+	_ context.Context,
+	id string,
+	now time.Time,
+	processingUntil time.Time,
+) (orderdom.DispatchNotificationDelivery, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return orderdom.DispatchNotificationDelivery{},
+			orderdom.ErrDispatchNotificationDeliveryIDRequired
+	}
+
+	now = now.UTC()
+	processingUntil = processingUntil.UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return orderdom.DispatchNotificationDelivery{}, orderdom.ErrNotFound
+	}
+	if delivery.IsTerminal() {
+		return orderdom.DispatchNotificationDelivery{},
+			orderdom.ErrDispatchNotificationNotClaimable
+	}
+
+	if delivery.AttemptCount >= delivery.MaxAttempts {
+		failed, err := delivery.MarkFailed(orderDispatchNotificationAttemptErrorMem, now)
+		if err != nil {
+			return orderdom.DispatchNotificationDelivery{}, err
+		}
+		r.deliveries[id] = cloneDispatchNotificationDelivery(failed)
+
+		return orderdom.DispatchNotificationDelivery{},
+			orderdom.ErrDispatchNotificationAttemptLimit
+	}
+
+	claimed, err := delivery.Claim(now, processingUntil)
+	if err != nil {
+		return orderdom.DispatchNotificationDelivery{}, err
+	}
+
+	r.deliveries[id] = cloneDispatchNotificationDelivery(claimed)
+
+	return cloneDispatchNotificationDelivery(claimed), nil
+}
+
+// MarkDelivered は delivered 済みなら no-op。
+func (r *OrderDispatchNotificationRepositoryMem) MarkDelivered(
+	_ context.Context,
+	id string,
+	expectedAttemptCount int,
+	providerMessageID string,
+	deliveredAt time.Time,
+) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return orderdom.ErrDispatchNotificationDeliveryIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return orderdom.ErrNotFound
+	}
+	if delivery.Status == orderdom.DispatchNotificationStatusDelivered {
+		return nil
+	}
+	if delivery.AttemptCount != expectedAttemptCount ||
+		delivery.Status != orderdom.DispatchNotificationStatusProcessing {
+		return orderdom.ErrDispatchNotificationNotClaimable
+	}
+
+	delivered, err := delivery.MarkDelivered(providerMessageID, deliveredAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	r.deliveries[id] = cloneDispatchNotificationDelivery(delivered)
+
+	return nil
+}
+
+// MarkRetryableFailed は同じ attempt で retryable_failed 済みなら no-op。
+func (r *OrderDispatchNotificationRepositoryMem) MarkRetryableFailed(
+	_ context.Context,
+	id string,
+	expectedAttemptCount int,
+	lastError string,
+	nextAttemptAt time.Time,
+	failedAt time.Time,
+) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return orderdom.ErrDispatchNotificationDeliveryIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return orderdom.ErrNotFound
+	}
+	if delivery.Status == orderdom.DispatchNotificationStatusRetryableFailed &&
+		delivery.AttemptCount == expectedAttemptCount {
+		return nil
+	}
+	if delivery.AttemptCount != expectedAttemptCount ||
+		delivery.Status != orderdom.DispatchNotificationStatusProcessing {
+		return orderdom.ErrDispatchNotificationNotClaimable
+	}
+
+	retryable, err := delivery.MarkRetryableFailed(
+		lastError,
+		nextAttemptAt.UTC(),
+		failedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	r.deliveries[id] = cloneDispatchNotificationDelivery(retryable)
+
+	return nil
+}
+
+// MarkFailed は failed 済みなら no-op、delivered 済みなら ErrDispatchNotificationStatusInvalid。
+func (r *OrderDispatchNotificationRepositoryMem) MarkFailed(
+	_ context.Context,
+	id string,
+	expectedAttemptCount int,
+	lastError string,
+	failedAt time.Time,
+) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return orderdom.ErrDispatchNotificationDeliveryIDRequired
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return orderdom.ErrNotFound
+	}
+	if delivery.Status == orderdom.DispatchNotificationStatusDelivered {
+		return orderdom.ErrDispatchNotificationStatusInvalid
+	}
+	if delivery.Status == orderdom.DispatchNotificationStatusFailed {
+		return nil
+	}
+	if delivery.AttemptCount != expectedAttemptCount {
+		return orderdom.ErrDispatchNotificationNotClaimable
+	}
+
+	failed, err := delivery.MarkFailed(lastError, failedAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	r.deliveries[id] = cloneDispatchNotificationDelivery(failed)
+
+	return nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func dispatchNotificationDueTimeMem(
+	delivery orderdom.DispatchNotificationDelivery,
+) time.Time {
+	switch delivery.Status {
+	case orderdom.DispatchNotificationStatusProcessing:
+		if delivery.ProcessingUntil != nil {
+			return delivery.ProcessingUntil.UTC()
+		}
+	default:
+		if delivery.NextAttemptAt != nil {
+			return delivery.NextAttemptAt.UTC()
+		}
+	}
+
+	return delivery.CreatedAt.UTC()
+}
+
+func cloneDispatchNotificationDelivery(
+	d orderdom.DispatchNotificationDelivery,
+) orderdom.DispatchNotificationDelivery {
+	if d.Items != nil {
+		d.Items = append([]orderdom.DispatchNotificationItem(nil), d.Items...)
+	}
+	d.NextAttemptAt = cloneTimePtr(d.NextAttemptAt)
+	d.ProcessingStartedAt = cloneTimePtr(d.ProcessingStartedAt)
+	d.ProcessingUntil = cloneTimePtr(d.ProcessingUntil)
+	d.DeliveredAt = cloneTimePtr(d.DeliveredAt)
+	d.FailedAt = cloneTimePtr(d.FailedAt)
+
+	return d
+}
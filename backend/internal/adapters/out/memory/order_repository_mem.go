@@ -0,0 +1,628 @@
+// backend/internal/adapters/out/memory/order_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fscommon "narratives/internal/adapters/out/firestore/common"
+	usecase "narratives/internal/application/usecase"
+	common "narratives/internal/domain/common"
+	orderdom "narratives/internal/domain/order"
+	transferdom "narratives/internal/domain/transfer"
+)
+
+var (
+	ErrInvalidTransferOrderID = errors.New(
+		"order_repository_mem: orderId is empty",
+	)
+	ErrInvalidTransferItemIndex = errors.New(
+		"order_repository_mem: itemIndex is invalid",
+	)
+	ErrInvalidTransferAvatarID = errors.New(
+		"order_repository_mem: avatarId is empty",
+	)
+	ErrInvalidTransferProductID = errors.New(
+		"order_repository_mem: productId is empty",
+	)
+	ErrInvalidTransferTokenBlueprintID = errors.New(
+		"order_repository_mem: tokenBlueprintId is empty",
+	)
+	ErrOrderNotPaid = errors.New(
+		"order_repository_mem: order is not paid",
+	)
+	ErrTransferItemTransferred = errors.New(
+		"order_repository_mem: item already transferred",
+	)
+	ErrTransferItemLocked = errors.New(
+		"order_repository_mem: item is locked",
+	)
+)
+
+const defaultTransferLockTTL = 10 * time.Minute
+
+// transferLock は orderTransferItems projection の lock 状態に相当する。
+type transferLock struct {
+	LockedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// OrderRepositoryMem は orderdom.Repository と usecase.OrderRepoForTransfer を
+// 1 つの map で実装する。
+//
+// Firestore では orders と orderTransferItems projection を transaction で
+// 同期しているが、memory では Order 自体から projection を導出し、
+// transfer lock だけを別 map で保持する。
+type OrderRepositoryMem struct {
+	mu sync.Mutex
+
+	orders map[string]orderdom.Order
+	locks  map[string]transferLock
+
+	LockTTL time.Duration
+	Now     func() time.Time
+}
+
+var (
+	_ orderdom.Repository               = (*OrderRepositoryMem)(nil)
+	_ usecase.OrderRepoForTransfer      = (*OrderRepositoryMem)(nil)
+	_ usecase.OrderReaderForPaymentFlow = (*OrderRepositoryMem)(nil)
+	_ usecase.OrderWriterForPaymentFlow = (*OrderRepositoryMem)(nil)
+)
+
+func NewOrderRepositoryMem() *OrderRepositoryMem {
+	return &OrderRepositoryMem{
+		orders: map[string]orderdom.Order{},
+		locks:  map[string]transferLock{},
+		Now:    time.Now,
+	}
+}
+
+func (r *OrderRepositoryMem) lockTTL() time.Duration {
+	if r.LockTTL > 0 {
+		return r.LockTTL
+	}
+
+	return defaultTransferLockTTL
+}
+
+func (r *OrderRepositoryMem) now() time.Time {
+	if r.Now != nil {
+		return r.Now().UTC()
+	}
+
+	return time.Now().UTC()
+}
+
+func transferLockKey(orderID string, itemIndex int) string {
+	return fmt.Sprintf("%s__%d", orderID, itemIndex)
+}
+
+// ============================================================
+// orderdom.Repository
+// ============================================================
+
+func (r *OrderRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (orderdom.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return orderdom.Order{}, orderdom.ErrNotFound
+	}
+
+	o, ok := r.orders[id]
+	if !ok {
+		return orderdom.Order{}, orderdom.ErrNotFound
+	}
+
+	return cloneOrder(o), nil
+}
+
+func (r *OrderRepositoryMem) ListByAvatarID(
+	_ context.Context,
+	avatarID string,
+	sort common.Sort,
+	page common.Page,
+) (common.PageResult[orderdom.Order], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pageNum, perPage, offset := fscommon.NormalizePage(
+		page.Number,
+		page.PerPage,
+		50,
+		200,
+	)
+
+	avatarID = strings.TrimSpace(avatarID)
+
+	matched := make([]orderdom.Order, 0)
+	if avatarID != "" {
+		for _, o := range r.orders {
+			if o.AvatarID == avatarID {
+				matched = append(matched, cloneOrder(o))
+			}
+		}
+	}
+
+	sortOrders(matched, sort)
+
+	return common.PageResult[orderdom.Order]{
+		Items:      paginate(matched, offset, perPage),
+		TotalCount: len(matched),
+		TotalPages: fscommon.ComputeTotalPages(len(matched), perPage),
+		Page:       pageNum,
+		PerPage:    perPage,
+	}, nil
+}
+
+func (r *OrderRepositoryMem) Create(
+	_ context.Context,
+	o orderdom.Order,
+) (orderdom.Order, error) {
+	if err := o.Validate(); err != nil {
+		return orderdom.Order{}, err
+	}
+	if err := validateOrderTransferItems(o); err != nil {
+		return orderdom.Order{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.orders[o.ID]; exists {
+		return orderdom.Order{}, orderdom.ErrConflict
+	}
+
+	r.orders[o.ID] = cloneOrder(o)
+
+	return cloneOrder(o), nil
+}
+
+func (r *OrderRepositoryMem) Update(
+	_ context.Context,
+	o orderdom.Order,
+	_ *common.SaveOptions,
+) (orderdom.Order, error) {
+	if o.ID == "" {
+		return orderdom.Order{}, orderdom.ErrNotFound
+	}
+	if err := o.Validate(); err != nil {
+		return orderdom.Order{}, err
+	}
+	if err := validateOrderTransferItems(o); err != nil {
+		return orderdom.Order{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.orders[o.ID]
+	if !ok {
+		return orderdom.Order{}, orderdom.ErrNotFound
+	}
+
+	now := r.now()
+
+	// 有効な transfer lock を持つ item は、transfer 実行中に内容が変わらないよう保護する。
+	for itemIndex := range existing.Items {
+		lock, locked := r.locks[transferLockKey(o.ID, itemIndex)]
+		if !locked || !lock.ExpiresAt.After(now) {
+			continue
+		}
+
+		if itemIndex >= len(o.Items) ||
+			o.AvatarID != existing.AvatarID ||
+			!o.Paid ||
+			o.Items[itemIndex].IsCancelled ||
+			o.Items[itemIndex].Transferred ||
+			!orderItemTransferIdentityEqual(
+				existing.Items[itemIndex],
+				o.Items[itemIndex],
+			) {
+			return orderdom.Order{}, ErrTransferItemLocked
+		}
+	}
+
+	for itemIndex := len(o.Items); itemIndex < len(existing.Items); itemIndex++ {
+		delete(r.locks, transferLockKey(o.ID, itemIndex))
+	}
+
+	r.orders[o.ID] = cloneOrder(o)
+
+	return cloneOrder(o), nil
+}
+
+// ============================================================
+// usecase.OrderRepoForTransfer
+// ============================================================
+
+func (r *OrderRepositoryMem) FindEligibleTransferItem(
+	_ context.Context,
+	in usecase.FindEligibleTransferItemInput,
+) (usecase.TransferTargetItem, error) {
+	if in.AvatarID == "" {
+		return usecase.TransferTargetItem{},
+			ErrInvalidTransferAvatarID
+	}
+	if in.ProductID == "" {
+		return usecase.TransferTargetItem{},
+			ErrInvalidTransferProductID
+	}
+	if in.TokenBlueprintID == "" {
+		return usecase.TransferTargetItem{},
+			ErrInvalidTransferTokenBlueprintID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	eligible := r.eligibleItemsLocked(in.AvatarID)
+
+	// Resale item は productId で個体を特定できるため先に解決する。
+	for _, item := range eligible {
+		if item.ItemType == orderdom.OrderItemTypeResale &&
+			item.ProductID == in.ProductID &&
+			item.TokenBlueprintID == in.TokenBlueprintID {
+			return eligibleToTransferTarget(item), nil
+		}
+	}
+
+	if in.ModelID == "" {
+		return usecase.TransferTargetItem{},
+			orderdom.ErrNotFound
+	}
+
+	for _, item := range eligible {
+		if item.ItemType == orderdom.OrderItemTypeList &&
+			item.ModelID == in.ModelID &&
+			item.TokenBlueprintID == in.TokenBlueprintID {
+			return eligibleToTransferTarget(item), nil
+		}
+	}
+
+	return usecase.TransferTargetItem{},
+		orderdom.ErrNotFound
+}
+
+func (r *OrderRepositoryMem) ListEligibleTransferItemsByAvatarID(
+	_ context.Context,
+	avatarID string,
+) ([]orderdom.EligibleTransferItem, error) {
+	if avatarID == "" {
+		return nil, ErrInvalidTransferAvatarID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.eligibleItemsLocked(avatarID), nil
+}
+
+func (r *OrderRepositoryMem) LockTransferItem(
+	_ context.Context,
+	orderID string,
+	itemIndex int,
+	now time.Time,
+) error {
+	if orderID == "" {
+		return ErrInvalidTransferOrderID
+	}
+	if itemIndex < 0 {
+		return ErrInvalidTransferItemIndex
+	}
+	if now.IsZero() {
+		return transferdom.ErrInvalidCreatedAt
+	}
+
+	now = now.UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	o, ok := r.orders[orderID]
+	if !ok || itemIndex >= len(o.Items) {
+		return orderdom.ErrNotFound
+	}
+	if !o.Paid {
+		return ErrOrderNotPaid
+	}
+	if o.Items[itemIndex].Transferred {
+		return ErrTransferItemTransferred
+	}
+
+	key := transferLockKey(orderID, itemIndex)
+	if lock, locked := r.locks[key]; locked &&
+		lock.ExpiresAt.After(now) {
+		return ErrTransferItemLocked
+	}
+
+	r.locks[key] = transferLock{
+		LockedAt:  now,
+		ExpiresAt: now.Add(r.lockTTL()),
+	}
+
+	return nil
+}
+
+func (r *OrderRepositoryMem) UnlockTransferItem(
+	_ context.Context,
+	orderID string,
+	itemIndex int,
+) error {
+	if orderID == "" {
+		return ErrInvalidTransferOrderID
+	}
+	if itemIndex < 0 {
+		return ErrInvalidTransferItemIndex
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	o, ok := r.orders[orderID]
+	if !ok || itemIndex >= len(o.Items) {
+		return orderdom.ErrNotFound
+	}
+
+	delete(r.locks, transferLockKey(orderID, itemIndex))
+
+	return nil
+}
+
+func (r *OrderRepositoryMem) MarkTransferredItem(
+	_ context.Context,
+	orderID string,
+	itemIndex int,
+	at time.Time,
+) error {
+	if orderID == "" {
+		return ErrInvalidTransferOrderID
+	}
+	if itemIndex < 0 {
+		return ErrInvalidTransferItemIndex
+	}
+	if at.IsZero() {
+		return transferdom.ErrInvalidTransferredAt
+	}
+
+	at = at.UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.orders[orderID]
+	if !ok || itemIndex >= len(stored.Items) {
+		return orderdom.ErrNotFound
+	}
+	if !stored.Paid {
+		return ErrOrderNotPaid
+	}
+	if stored.Items[itemIndex].Transferred {
+		return ErrTransferItemTransferred
+	}
+
+	o := cloneOrder(stored)
+	if err := o.UpdateItemTransferred(
+		itemIndex,
+		true,
+		at,
+	); err != nil {
+		return err
+	}
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	r.orders[orderID] = o
+	delete(r.locks, transferLockKey(orderID, itemIndex))
+
+	return nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+// eligibleItemsLocked は paid かつ未 transfer の item を createdAt, itemIndex 昇順で返す。
+// 呼び出し側で mu を保持していること。
+func (r *OrderRepositoryMem) eligibleItemsLocked(
+	avatarID string,
+) []orderdom.EligibleTransferItem {
+	orders := make([]orderdom.Order, 0)
+	for _, o := range r.orders {
+		if o.AvatarID == avatarID && o.Paid {
+			orders = append(orders, o)
+		}
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+
+	items := make([]orderdom.EligibleTransferItem, 0)
+	for _, o := range orders {
+		for itemIndex, item := range o.Items {
+			if item.Transferred {
+				continue
+			}
+
+			items = append(
+				items,
+				orderItemToEligible(o.ID, itemIndex, item),
+			)
+		}
+	}
+
+	return items
+}
+
+func validateOrderTransferItems(o orderdom.Order) error {
+	for itemIndex, item := range o.Items {
+		if err := orderItemToEligible(
+			o.ID,
+			itemIndex,
+			item,
+		).Validate(); err != nil {
+			return fmt.Errorf(
+				"order %s item %d: %w",
+				o.ID,
+				itemIndex,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func orderItemToEligible(
+	orderID string,
+	itemIndex int,
+	item orderdom.OrderItemSnapshot,
+) orderdom.EligibleTransferItem {
+	eligible := orderdom.EligibleTransferItem{
+		OrderID:   orderID,
+		ItemType:  item.Type,
+		ItemIndex: itemIndex,
+
+		ProductBlueprintID: item.ProductBlueprintID,
+		TokenBlueprintID:   item.TokenBlueprintID,
+	}
+
+	switch item.Type {
+	case orderdom.OrderItemTypeList:
+		eligible.ModelID = item.ModelID
+		eligible.InventoryID = item.InventoryID
+		eligible.ListID = item.ListID
+
+	case orderdom.OrderItemTypeResale:
+		eligible.ResaleID = item.ResaleID
+		eligible.ProductID = item.ProductID
+		eligible.BrandID = item.BrandID
+	}
+
+	return eligible
+}
+
+func eligibleToTransferTarget(
+	item orderdom.EligibleTransferItem,
+) usecase.TransferTargetItem {
+	return usecase.TransferTargetItem{
+		OrderID:   item.OrderID,
+		ItemIndex: item.ItemIndex,
+		ItemType:  item.ItemType,
+
+		InventoryID: item.InventoryID,
+		ModelID:     item.ModelID,
+		ResaleID:    item.ResaleID,
+
+		ProductID:          item.ProductID,
+		ProductBlueprintID: item.ProductBlueprintID,
+		TokenBlueprintID:   item.TokenBlueprintID,
+		BrandID:            item.BrandID,
+	}
+}
+
+func orderItemTransferIdentityEqual(
+	a orderdom.OrderItemSnapshot,
+	b orderdom.OrderItemSnapshot,
+) bool {
+	return orderItemToEligible("", 0, a) ==
+		orderItemToEligible("", 0, b)
+}
+
+func sortOrders(orders []orderdom.Order, s common.Sort) {
+	desc := true
+	if s.Order == common.SortAsc &&
+		(s.Column == "" || s.Column == orderdom.SortByCreatedAt) {
+		desc = false
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		a, b := orders[i], orders[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			if desc {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if desc {
+			return a.ID > b.ID
+		}
+		return a.ID < b.ID
+	})
+}
+
+func cloneOrder(o orderdom.Order) orderdom.Order {
+	out := o
+
+	if o.ShippingQuoteSnapshot.Items != nil {
+		out.ShippingQuoteSnapshot.Items = make(
+			[]orderdom.ShippingQuoteItemSnapshot,
+			len(o.ShippingQuoteSnapshot.Items),
+		)
+		for i, item := range o.ShippingQuoteSnapshot.Items {
+			if item.Customs != nil {
+				customs := *item.Customs
+				item.Customs = &customs
+			}
+			out.ShippingQuoteSnapshot.Items[i] = item
+		}
+	}
+
+	if o.Items != nil {
+		out.Items = make([]orderdom.OrderItemSnapshot, len(o.Items))
+		for i, item := range o.Items {
+			item.ProductBlueprintCategoryPath = cloneStrings(
+				item.ProductBlueprintCategoryPath,
+			)
+			item.TransferredAt = cloneTimePtr(item.TransferredAt)
+			item.DeliveredAt = cloneTimePtr(item.DeliveredAt)
+			item.ReturnedAt = cloneTimePtr(item.ReturnedAt)
+			if item.Dispatch != nil {
+				dispatch := *item.Dispatch
+				item.Dispatch = &dispatch
+			}
+			out.Items[i] = item
+		}
+	}
+
+	if o.Refunds != nil {
+		out.Refunds = make([]orderdom.RefundSnapshot, len(o.Refunds))
+		for i, refund := range o.Refunds {
+			if refund.Items != nil {
+				items := make(
+					[]orderdom.RefundItemSnapshot,
+					len(refund.Items),
+				)
+				copy(items, refund.Items)
+				refund.Items = items
+			}
+			out.Refunds[i] = refund
+		}
+	}
+
+	if o.Discount != nil {
+		discount := *o.Discount
+		discount.Items = append(
+			[]orderdom.DiscountItemSnapshot(nil),
+			o.Discount.Items...,
+		)
+		out.Discount = &discount
+	}
+
+	return out
+}
@@ -0,0 +1,253 @@
+// backend/internal/adapters/out/memory/outbox_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	outboxdom "narratives/internal/domain/outbox"
+)
+
+var ErrOutboxEventConflict = errors.New(
+	"outbox_repository_mem: conflict",
+)
+
+// OutboxRepositoryMem は outbox.RepositoryPort の in-memory 実装。
+//
+// Firestore ではイベントを集約の repository が集約と同じ transaction で作成するが、
+// memory の集約 repository はイベントを書かないため、テストでは Add で登録する。
+type OutboxRepositoryMem struct {
+	mu     sync.Mutex
+	events map[string]outboxdom.Event
+}
+
+var _ outboxdom.RepositoryPort = (*OutboxRepositoryMem)(nil)
+
+func NewOutboxRepositoryMem() *OutboxRepositoryMem {
+	return &OutboxRepositoryMem{
+		events: map[string]outboxdom.Event{},
+	}
+}
+
+// Add はイベントを登録する。既存の ID は上書きしない。
+func (r *OutboxRepositoryMem) Add(e outboxdom.Event) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.events[e.ID]; exists {
+		return ErrOutboxEventConflict
+	}
+
+	r.events[e.ID] = cloneOutboxEvent(e)
+
+	return nil
+}
+
+func (r *OutboxRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (outboxdom.Event, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return outboxdom.Event{}, outboxdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return outboxdom.Event{}, outboxdom.ErrNotFound
+	}
+
+	return cloneOutboxEvent(e), nil
+}
+
+// ListDue は occurredAt asc の順で最大 limit 件返す。
+func (r *OutboxRepositoryMem) ListDue(
+	_ context.Context,
+	types []outboxdom.EventType,
+	now time.Time,
+	limit int,
+) ([]outboxdom.Event, error) {
+	if len(types) == 0 {
+		return []outboxdom.Event{}, nil
+	}
+
+	if limit <= 0 {
+		limit = 50
+	}
+
+	now = now.UTC()
+
+	wanted := make(map[outboxdom.EventType]struct{}, len(types))
+	for _, t := range types {
+		wanted[t] = struct{}{}
+	}
+
+	out := make([]outboxdom.Event, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.events) {
+		e := r.events[id]
+
+		if e.Status != outboxdom.StatusPending && e.Status != outboxdom.StatusProcessing {
+			continue
+		}
+		if _, ok := wanted[e.Type]; !ok {
+			continue
+		}
+		if e.Attempts >= e.MaxAttempts || !e.IsDue(now) {
+			continue
+		}
+
+		out = append(out, cloneOutboxEvent(e))
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].OccurredAt.Before(out[j].OccurredAt)
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+// ListPendingByAggregate は occurredAt asc の順で返す。
+func (r *OutboxRepositoryMem) ListPendingByAggregate(
+	_ context.Context,
+	aggregateType outboxdom.AggregateType,
+	aggregateID string,
+) ([]outboxdom.Event, error) {
+	aggregateID = strings.TrimSpace(aggregateID)
+	if aggregateID == "" {
+		return nil, outboxdom.ErrInvalidAggregateID
+	}
+
+	out := make([]outboxdom.Event, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.events) {
+		e := r.events[id]
+		if e.AggregateID != aggregateID ||
+			e.AggregateType != aggregateType ||
+			e.Status != outboxdom.StatusPending {
+			continue
+		}
+		out = append(out, cloneOutboxEvent(e))
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].OccurredAt.Before(out[j].OccurredAt)
+	})
+
+	return out, nil
+}
+
+func (r *OutboxRepositoryMem) Claim(
+	_ context.Context,
+	id string,
+	now time.Time,
+	processingUntil time.Time,
+) (outboxdom.Event, error) {
+	var claimed outboxdom.Event
+
+	err := r.update(id, func(e outboxdom.Event) (outboxdom.Event, error) {
+		next, err := e.Claim(now, processingUntil)
+		if err != nil {
+			return outboxdom.Event{}, err
+		}
+
+		claimed = next
+		return next, nil
+	})
+	if err != nil {
+		return outboxdom.Event{}, err
+	}
+
+	return cloneOutboxEvent(claimed), nil
+}
+
+func (r *OutboxRepositoryMem) MarkPublished(
+	_ context.Context,
+	id string,
+	expectedAttempts int,
+	now time.Time,
+) error {
+	return r.update(id, func(e outboxdom.Event) (outboxdom.Event, error) {
+		if e.Attempts != expectedAttempts {
+			return outboxdom.Event{}, outboxdom.ErrNotClaimable
+		}
+		return e.MarkPublished(now)
+	})
+}
+
+func (r *OutboxRepositoryMem) MarkRetry(
+	_ context.Context,
+	id string,
+	expectedAttempts int,
+	lastError string,
+	nextAttemptAt time.Time,
+	now time.Time,
+) error {
+	return r.update(id, func(e outboxdom.Event) (outboxdom.Event, error) {
+		if e.Attempts != expectedAttempts {
+			return outboxdom.Event{}, outboxdom.ErrNotClaimable
+		}
+		return e.MarkRetry(lastError, nextAttemptAt, now)
+	})
+}
+
+func (r *OutboxRepositoryMem) update(
+	id string,
+	fn func(outboxdom.Event) (outboxdom.Event, error),
+) error {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return outboxdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.events[id]
+	if !ok {
+		return outboxdom.ErrNotFound
+	}
+
+	next, err := fn(cloneOutboxEvent(current))
+	if err != nil {
+		return err
+	}
+
+	r.events[id] = cloneOutboxEvent(next)
+
+	return nil
+}
+
+func cloneOutboxEvent(e outboxdom.Event) outboxdom.Event {
+	out := e
+
+	if e.Payload != nil {
+		out.Payload = make([]byte, len(e.Payload))
+		copy(out.Payload, e.Payload)
+	}
+	out.NextAttemptAt = cloneTimePtr(e.NextAttemptAt)
+	out.ProcessingUntil = cloneTimePtr(e.ProcessingUntil)
+	out.PublishedAt = cloneTimePtr(e.PublishedAt)
+	out.FailedAt = cloneTimePtr(e.FailedAt)
+
+	return out
+}
@@ -0,0 +1,165 @@
+// backend/internal/adapters/out/memory/outbox_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	outboxdom "narratives/internal/domain/outbox"
+)
+
+func TestOutboxRepositoryMem_Delivery(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		run        func(ctx context.Context, repo *memory.OutboxRepositoryMem, id string) error
+		wantErr    error
+		wantStatus outboxdom.Status
+	}{
+		{
+			name: "claim then publish",
+			run: func(ctx context.Context, repo *memory.OutboxRepositoryMem, id string) error {
+				claimed, err := repo.Claim(ctx, id, now, now.Add(time.Minute))
+				if err != nil {
+					return err
+				}
+				return repo.MarkPublished(ctx, id, claimed.Attempts, now)
+			},
+			wantStatus: outboxdom.StatusPublished,
+		},
+		{
+			name: "publish after another worker reclaimed the event",
+			run: func(ctx context.Context, repo *memory.OutboxRepositoryMem, id string) error {
+				if _, err := repo.Claim(ctx, id, now, now.Add(time.Minute)); err != nil {
+					return err
+				}
+				if _, err := repo.Claim(ctx, id, now.Add(2*time.Minute), now.Add(3*time.Minute)); err != nil {
+					return err
+				}
+				return repo.MarkPublished(ctx, id, 1, now.Add(2*time.Minute))
+			},
+			wantErr:    outboxdom.ErrNotClaimable,
+			wantStatus: outboxdom.StatusProcessing,
+		},
+		{
+			name: "claim of a leased event",
+			run: func(ctx context.Context, repo *memory.OutboxRepositoryMem, id string) error {
+				if _, err := repo.Claim(ctx, id, now, now.Add(time.Minute)); err != nil {
+					return err
+				}
+				_, err := repo.Claim(ctx, id, now.Add(time.Second), now.Add(time.Minute))
+				return err
+			},
+			wantErr:    outboxdom.ErrNotClaimable,
+			wantStatus: outboxdom.StatusProcessing,
+		},
+		{
+			name: "claim of a missing event",
+			run: func(ctx context.Context, repo *memory.OutboxRepositoryMem, _ string) error {
+				_, err := repo.Claim(ctx, "event_missing", now, now.Add(time.Minute))
+				return err
+			},
+			wantErr:    outboxdom.ErrNotFound,
+			wantStatus: outboxdom.StatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewOutboxRepositoryMem()
+
+			e, err := outboxdom.NewEvent(
+				outboxdom.EventOrderCreated,
+				outboxdom.AggregateOrder,
+				"order_1",
+				map[string]string{"orderId": "order_1"},
+				now,
+			)
+			if err != nil {
+				t.Fatalf("NewEvent: %v", err)
+			}
+			if err := repo.Add(e); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+			if err := repo.Add(e); !errors.Is(err, memory.ErrOutboxEventConflict) {
+				t.Fatalf("Add twice err = %v, want ErrOutboxEventConflict", err)
+			}
+
+			err = tt.run(ctx, repo, e.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repo.GetByID(ctx, e.ID)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Fatalf("status = %q, want %q", stored.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestOutboxRepositoryMem_ListDue(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewOutboxRepositoryMem()
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	add := func(eventType outboxdom.EventType, occurredAt time.Time) outboxdom.Event {
+		e, err := outboxdom.NewEvent(eventType, outboxdom.AggregateOrder, "order_1", map[string]string{}, occurredAt)
+		if err != nil {
+			t.Fatalf("NewEvent: %v", err)
+		}
+		if err := repo.Add(e); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		return e
+	}
+
+	later := add(outboxdom.EventOrderCreated, now.Add(-time.Minute))
+	earlier := add(outboxdom.EventOrderCreated, now.Add(-time.Hour))
+	add(outboxdom.EventMintCompleted, now.Add(-2*time.Hour))
+	published := add(outboxdom.EventOrderCreated, now.Add(-3*time.Hour))
+
+	claimed, err := repo.Claim(ctx, published.ID, now, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if err := repo.MarkPublished(ctx, published.ID, claimed.Attempts, now); err != nil {
+		t.Fatalf("MarkPublished: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		types []outboxdom.EventType
+		limit int
+		want  []string
+	}{
+		{name: "no types", want: []string{}},
+		{name: "oldest first", types: []outboxdom.EventType{outboxdom.EventOrderCreated}, want: []string{earlier.ID, later.ID}},
+		{name: "limit", types: []outboxdom.EventType{outboxdom.EventOrderCreated}, limit: 1, want: []string{earlier.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListDue(ctx, tt.types, now, tt.limit)
+			if err != nil {
+				t.Fatalf("ListDue: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("len = %d, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i].ID != tt.want[i] {
+					t.Fatalf("got[%d] = %q, want %q", i, got[i].ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
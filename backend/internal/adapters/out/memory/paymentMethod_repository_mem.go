@@ -0,0 +1,311 @@
+// backend/internal/adapters/out/memory/paymentMethod_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	pm "narratives/internal/domain/paymentMethod"
+)
+
+// PaymentMethodRepositoryMem は paymentMethod.RepositoryPort の in-memory 実装。
+// Stripe Customer ID の対応表（stripe adapter の customerStore）も保持する。
+type PaymentMethodRepositoryMem struct {
+	mu  sync.Mutex
+	ids idSequence
+
+	methods map[string]pm.PaymentMethod
+
+	// customers は userID -> Stripe Customer ID。
+	customers map[string]string
+}
+
+var _ pm.RepositoryPort = (*PaymentMethodRepositoryMem)(nil)
+
+func NewPaymentMethodRepositoryMem() *PaymentMethodRepositoryMem {
+	return &PaymentMethodRepositoryMem{
+		methods:   map[string]pm.PaymentMethod{},
+		customers: map[string]string{},
+	}
+}
+
+func (r *PaymentMethodRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (*pm.PaymentMethod, error) {
+	if id == "" {
+		return nil, pm.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.methods[id]
+	if !ok {
+		return nil, pm.ErrNotFound
+	}
+
+	return &item, nil
+}
+
+// GetByUser は isDefault desc, updatedAt desc, id desc の順で返す。
+func (r *PaymentMethodRepositoryMem) GetByUser(
+	_ context.Context,
+	userID string,
+) ([]pm.PaymentMethod, error) {
+	if userID == "" {
+		return []pm.PaymentMethod{}, nil
+	}
+
+	r.mu.Lock()
+	items := make([]pm.PaymentMethod, 0)
+	for _, item := range r.methods {
+		if item.UserID == userID {
+			items = append(items, item)
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.IsDefault != b.IsDefault {
+			return a.IsDefault
+		}
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+		return a.ID > b.ID
+	})
+
+	return items, nil
+}
+
+func (r *PaymentMethodRepositoryMem) GetDefaultByUser(
+	_ context.Context,
+	userID string,
+) (*pm.PaymentMethod, error) {
+	if userID == "" {
+		return nil, pm.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sortedKeys(r.methods) {
+		item := r.methods[id]
+		if item.UserID == userID && item.IsDefault {
+			return &item, nil
+		}
+	}
+
+	return nil, pm.ErrNotFound
+}
+
+func (r *PaymentMethodRepositoryMem) GetByStripePaymentMethodID(
+	_ context.Context,
+	stripePaymentMethodID string,
+) (*pm.PaymentMethod, error) {
+	if stripePaymentMethodID == "" {
+		return nil, pm.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sortedKeys(r.methods) {
+		item := r.methods[id]
+		if item.StripePaymentMethodID == stripePaymentMethodID {
+			return &item, nil
+		}
+	}
+
+	return nil, pm.ErrNotFound
+}
+
+// GetStripeCustomerIDByUser は、ユーザーに対応する Stripe Customer ID を返します。
+func (r *PaymentMethodRepositoryMem) GetStripeCustomerIDByUser(
+	_ context.Context,
+	userID string,
+) (string, error) {
+	if userID == "" {
+		return "", pm.ErrInvalidUserID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	customerID, ok := r.customers[userID]
+	if !ok || customerID == "" {
+		return "", pm.ErrNotFound
+	}
+
+	return customerID, nil
+}
+
+// SaveStripeCustomerIDByUser は、ユーザーと Stripe Customer ID の対応関係を作成または更新します。
+func (r *PaymentMethodRepositoryMem) SaveStripeCustomerIDByUser(
+	_ context.Context,
+	userID string,
+	stripeCustomerID string,
+) error {
+	if userID == "" {
+		return pm.ErrInvalidUserID
+	}
+	if stripeCustomerID == "" {
+		return pm.ErrInvalidStripeCustomerID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.customers[userID] = stripeCustomerID
+
+	return nil
+}
+
+// Create は PaymentMethodRepositoryFS と同様に、in.IsDefault が true の場合は
+// 既存の既定カードの解除と新規作成を 1 つの lock 内で行う。
+func (r *PaymentMethodRepositoryMem) Create(
+	_ context.Context,
+	in pm.CreatePaymentMethodInput,
+) (*pm.PaymentMethod, error) {
+	if in.UserID == "" {
+		return nil, pm.ErrInvalidUserID
+	}
+
+	now := time.Now().UTC()
+
+	createdAt := now
+	if in.CreatedAt != nil && !in.CreatedAt.IsZero() {
+		createdAt = in.CreatedAt.UTC()
+	}
+
+	updatedAt := createdAt
+	if in.UpdatedAt != nil && !in.UpdatedAt.IsZero() {
+		updatedAt = in.UpdatedAt.UTC()
+	}
+
+	item, err := pm.New(
+		r.ids.newID("pm"),
+		in.UserID,
+		in.StripeCustomerID,
+		in.StripePaymentMethodID,
+		in.Brand,
+		in.Last4,
+		in.ExpMonth,
+		in.ExpYear,
+		in.CardholderName,
+		in.IsDefault,
+		createdAt,
+		updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.methods[item.ID]; exists {
+		return nil, pm.ErrConflict
+	}
+
+	if in.IsDefault {
+		r.unsetDefaultsLocked(in.UserID, "", now)
+	}
+
+	r.methods[item.ID] = item
+	r.customers[in.UserID] = in.StripeCustomerID
+
+	return &item, nil
+}
+
+func (r *PaymentMethodRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if id == "" {
+		return pm.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.methods[id]; !ok {
+		return pm.ErrNotFound
+	}
+
+	delete(r.methods, id)
+
+	return nil
+}
+
+// SetDefault は所有者確認、既存の既定カードの解除、対象の既定化を 1 つの lock 内で行う。
+func (r *PaymentMethodRepositoryMem) SetDefault(
+	_ context.Context,
+	id string,
+	userID string,
+	updatedAt time.Time,
+) (*pm.PaymentMethod, error) {
+	if id == "" {
+		return nil, pm.ErrNotFound
+	}
+	if userID == "" {
+		return nil, pm.ErrInvalidUserID
+	}
+
+	now := updatedAt.UTC()
+	if updatedAt.IsZero() {
+		now = time.Now().UTC()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.methods[id]
+	if !ok || current.UserID != userID {
+		return nil, pm.ErrNotFound
+	}
+
+	candidate, err := pm.New(
+		current.ID,
+		current.UserID,
+		current.StripeCustomerID,
+		current.StripePaymentMethodID,
+		current.Brand,
+		current.Last4,
+		current.ExpMonth,
+		current.ExpYear,
+		current.CardholderName,
+		true,
+		current.CreatedAt,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.unsetDefaultsLocked(userID, id, now)
+	r.methods[id] = candidate
+
+	return &candidate, nil
+}
+
+// unsetDefaultsLocked は userID の既定カードを解除する。exceptID は対象外とする。
+func (r *PaymentMethodRepositoryMem) unsetDefaultsLocked(
+	userID string,
+	exceptID string,
+	now time.Time,
+) {
+	for id, item := range r.methods {
+		if id == exceptID || item.UserID != userID || !item.IsDefault {
+			continue
+		}
+
+		item.IsDefault = false
+		item.UpdatedAt = now
+		r.methods[id] = item
+	}
+}
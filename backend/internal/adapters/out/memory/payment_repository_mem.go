@@ -0,0 +1,346 @@
+// backend/internal/adapters/out/memory/payment_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	paymentdom "narratives/internal/domain/payment"
+)
+
+var (
+	_ paymentdom.RepositoryPort = (*PaymentRepositoryMem)(nil)
+
+	_ usecase.StripePaymentEventRepository = (*PaymentRepositoryMem)(nil)
+)
+
+// paymentRecord は Payment と、Firestore document 上の内部 field
+// （postPaidTriggeredAt）に相当する marker をまとめて保持する。
+type paymentRecord struct {
+	Payment paymentdom.Payment
+
+	PostPaidTriggeredAt *time.Time
+}
+
+// PaymentRepositoryMem is the in-memory implementation of:
+//
+// - payment.RepositoryPort
+// - usecase.StripePaymentEventRepository
+//
+// Stripe event の重複排除、status 遷移の検証、post-paid marker の取得は
+// PaymentRepositoryFS と同じ規則で 1 つの lock 内で行う。
+type PaymentRepositoryMem struct {
+	mu sync.Mutex
+
+	payments map[string]paymentRecord
+
+	// stripeEvents は処理済み Stripe event ID の集合。
+	stripeEvents map[string]struct{}
+}
+
+func NewPaymentRepositoryMem() *PaymentRepositoryMem {
+	return &PaymentRepositoryMem{
+		payments:     map[string]paymentRecord{},
+		stripeEvents: map[string]struct{}{},
+	}
+}
+
+func (r *PaymentRepositoryMem) GetByPaymentID(
+	_ context.Context,
+	paymentID string,
+) (*paymentdom.Payment, error) {
+	paymentID = strings.TrimSpace(paymentID)
+	if paymentID == "" {
+		return nil, paymentdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.payments[paymentID]
+	if !ok {
+		return nil, paymentdom.ErrNotFound
+	}
+
+	p := clonePayment(rec.Payment)
+	return &p, nil
+}
+
+func (r *PaymentRepositoryMem) Create(
+	_ context.Context,
+	in paymentdom.CreatePaymentInput,
+) (*paymentdom.Payment, error) {
+	in.PaymentID = strings.TrimSpace(in.PaymentID)
+	in.PaymentMethodID = strings.TrimSpace(in.PaymentMethodID)
+	in.StripeCustomerID = strings.TrimSpace(in.StripeCustomerID)
+	in.StripePaymentMethodID = strings.TrimSpace(in.StripePaymentMethodID)
+	in.StripePaymentIntentID = strings.TrimSpace(in.StripePaymentIntentID)
+
+	if in.PaymentID == "" {
+		return nil, paymentdom.ErrInvalidPaymentID
+	}
+
+	createdAt := time.Now().UTC()
+
+	payment, err := paymentdom.New(
+		in.PaymentID,
+		in.PaymentMethodID,
+		in.StripeCustomerID,
+		in.StripePaymentMethodID,
+		in.StripePaymentIntentID,
+		in.Amount,
+		in.Status,
+		normalizeOptionalString(in.ErrorType),
+		normalizeOptionalString(in.ErrorCode),
+		normalizeOptionalString(in.ErrorMsg),
+		createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.payments[payment.PaymentID]; exists {
+		return nil, paymentdom.ErrConflict
+	}
+
+	rec := paymentRecord{
+		Payment: clonePayment(payment),
+	}
+
+	// succeeded で作成された Payment は PaymentUsecase.Create が post-paid 処理を行うため、
+	// 後続の succeeded webhook で再実行されないよう marker を同時に立てる。
+	if payment.Status == paymentdom.StatusSucceeded {
+		rec.PostPaidTriggeredAt = &createdAt
+	}
+
+	r.payments[payment.PaymentID] = rec
+
+	return &payment, nil
+}
+
+func (r *PaymentRepositoryMem) UpdateByPaymentID(
+	_ context.Context,
+	paymentID string,
+	patch paymentdom.UpdatePaymentInput,
+) (*paymentdom.Payment, error) {
+	paymentID = strings.TrimSpace(paymentID)
+	if paymentID == "" {
+		return nil, paymentdom.ErrNotFound
+	}
+
+	// Stripe 由来の status 更新は ApplyStripePaymentEvent を通す。
+	if patch.Status != nil {
+		return nil,
+			usecase.ErrPaymentStatusUpdateRequiresStripeEvent
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.payments[paymentID]
+	if !ok {
+		return nil, paymentdom.ErrNotFound
+	}
+
+	next := clonePayment(rec.Payment)
+
+	if patch.PaymentMethodID != nil {
+		value := strings.TrimSpace(*patch.PaymentMethodID)
+		if value == "" {
+			return nil, paymentdom.ErrInvalidPaymentMethodID
+		}
+		next.PaymentMethodID = value
+	}
+
+	if patch.StripeCustomerID != nil {
+		value := strings.TrimSpace(*patch.StripeCustomerID)
+		if value == "" {
+			return nil, paymentdom.ErrInvalidStripeCustomerID
+		}
+		next.StripeCustomerID = value
+	}
+
+	if patch.StripePaymentMethodID != nil {
+		value := strings.TrimSpace(*patch.StripePaymentMethodID)
+		if value == "" {
+			return nil, paymentdom.ErrInvalidStripePaymentMethod
+		}
+		next.StripePaymentMethodID = value
+	}
+
+	if patch.StripePaymentIntentID != nil {
+		value := strings.TrimSpace(*patch.StripePaymentIntentID)
+		if value == "" {
+			return nil, paymentdom.ErrInvalidStripePaymentIntent
+		}
+		next.StripePaymentIntentID = value
+	}
+
+	if patch.Amount != nil {
+		if *patch.Amount < paymentdom.MinAmount ||
+			(paymentdom.MaxAmount > 0 &&
+				*patch.Amount > paymentdom.MaxAmount) {
+			return nil, paymentdom.ErrInvalidAmount
+		}
+		next.Amount = *patch.Amount
+	}
+
+	if patch.ErrorType != nil {
+		next.ErrorType = normalizeOptionalString(patch.ErrorType)
+	}
+	if patch.ErrorCode != nil {
+		next.ErrorCode = normalizeOptionalString(patch.ErrorCode)
+	}
+	if patch.ErrorMsg != nil {
+		next.ErrorMsg = normalizeOptionalString(patch.ErrorMsg)
+	}
+
+	rec.Payment = next
+	r.payments[paymentID] = rec
+
+	out := clonePayment(next)
+	return &out, nil
+}
+
+// ============================================================
+// usecase.StripePaymentEventRepository
+// ============================================================
+
+// ApplyStripePaymentEvent は PaymentRepositoryFS と同じ手順を 1 つの lock 内で行う。
+//
+//  1. Deduplicates the Stripe event.
+//  2. Reads and validates the current Payment.
+//  3. Verifies the Stripe PaymentIntent ID.
+//  4. Applies a valid status transition.
+//  5. Acquires the post-paid marker if this is the first succeeded state.
+//  6. Records the Stripe event as processed.
+func (r *PaymentRepositoryMem) ApplyStripePaymentEvent(
+	_ context.Context,
+	in usecase.ApplyStripePaymentEventInput,
+) (*usecase.ApplyStripePaymentEventResult, error) {
+	in.EventID = strings.TrimSpace(in.EventID)
+	in.PaymentID = strings.TrimSpace(in.PaymentID)
+	in.StripePaymentIntentID = strings.TrimSpace(in.StripePaymentIntentID)
+
+	if in.EventID == "" {
+		return nil, usecase.ErrPaymentStripeEventIDEmpty
+	}
+	if strings.Contains(in.EventID, "/") {
+		return nil, fmt.Errorf(
+			"payment: invalid Stripe event id %q",
+			in.EventID,
+		)
+	}
+	if in.PaymentID == "" {
+		return nil, paymentdom.ErrInvalidPaymentID
+	}
+	if in.StripePaymentIntentID == "" {
+		return nil, paymentdom.ErrInvalidStripePaymentIntent
+	}
+	if !paymentdom.IsValidStatus(in.Status) {
+		return nil, paymentdom.ErrInvalidStatus
+	}
+	if in.OccurredAt.IsZero() {
+		return nil, usecase.ErrPaymentStripeEventOccurredAtInvalid
+	}
+
+	in.ErrorType = normalizeOptionalString(in.ErrorType)
+	in.ErrorCode = normalizeOptionalString(in.ErrorCode)
+	in.ErrorMsg = normalizeOptionalString(in.ErrorMsg)
+
+	processedAt := time.Now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.payments[in.PaymentID]
+	if !ok {
+		return nil, paymentdom.ErrNotFound
+	}
+
+	current := clonePayment(rec.Payment)
+
+	// Duplicate Stripe event: successful no-op.
+	if _, processed := r.stripeEvents[in.EventID]; processed {
+		return &usecase.ApplyStripePaymentEventResult{
+			Payment:      &current,
+			EventApplied: false,
+		}, nil
+	}
+
+	if current.StripePaymentIntentID != in.StripePaymentIntentID {
+		return nil, paymentdom.ErrInvalidStripePaymentIntent
+	}
+
+	// Stale / out-of-order events are recorded but do not update the Payment.
+	next := current
+
+	statusChanged, transitionErr := next.TransitionTo(
+		in.Status,
+		in.ErrorType,
+		in.ErrorCode,
+		in.ErrorMsg,
+	)
+	if transitionErr != nil &&
+		!errors.Is(transitionErr, paymentdom.ErrStatusTransition) {
+		return nil, transitionErr
+	}
+
+	transitionAllowed := transitionErr == nil
+
+	postPaidRequired := transitionAllowed &&
+		next.Status == paymentdom.StatusSucceeded &&
+		rec.PostPaidTriggeredAt == nil
+
+	if transitionAllowed {
+		rec.Payment = clonePayment(next)
+	}
+	if postPaidRequired {
+		rec.PostPaidTriggeredAt = &processedAt
+	}
+
+	r.payments[in.PaymentID] = rec
+	r.stripeEvents[in.EventID] = struct{}{}
+
+	return &usecase.ApplyStripePaymentEventResult{
+		Payment:          &next,
+		EventApplied:     true,
+		StatusChanged:    statusChanged,
+		PostPaidRequired: postPaidRequired,
+	}, nil
+}
+
+func normalizeOptionalString(p *string) *string {
+	if p == nil {
+		return nil
+	}
+
+	v := strings.TrimSpace(*p)
+	if v == "" {
+		return nil
+	}
+
+	return &v
+}
+
+func clonePayment(p paymentdom.Payment) paymentdom.Payment {
+	out := p
+	out.ErrorType = cloneStringPtr(p.ErrorType)
+	out.ErrorCode = cloneStringPtr(p.ErrorCode)
+	out.ErrorMsg = cloneStringPtr(p.ErrorMsg)
+
+	if p.Dispute != nil {
+		d := *p.Dispute
+		out.Dispute = &d
+	}
+
+	return out
+}
@@ -0,0 +1,344 @@
+// backend/internal/adapters/out/memory/payout_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	payoutdom "narratives/internal/domain/payout"
+)
+
+// PayoutRepositoryMem は payout.RepositoryPort の in-memory 実装。
+//
+// 支払い台帳・支払いバッチ・受取先を 1 つの lock で保護し、
+// Firestore の transaction と同じく、バッチ作成・取り消しと台帳の BatchID の
+// 更新をまとめて行う。
+type PayoutRepositoryMem struct {
+	mu         sync.Mutex
+	entries    map[string]payoutdom.Entry
+	batches    map[string]payoutdom.Batch
+	recipients map[string]payoutdom.Recipient
+}
+
+var _ payoutdom.RepositoryPort = (*PayoutRepositoryMem)(nil)
+
+func NewPayoutRepositoryMem() *PayoutRepositoryMem {
+	return &PayoutRepositoryMem{
+		entries:    map[string]payoutdom.Entry{},
+		batches:    map[string]payoutdom.Batch{},
+		recipients: map[string]payoutdom.Recipient{},
+	}
+}
+
+// ============================================================
+// 支払い台帳
+// ============================================================
+
+func (r *PayoutRepositoryMem) CreateEntry(
+	_ context.Context,
+	e payoutdom.Entry,
+) (payoutdom.Entry, error) {
+	if err := e.Validate(); err != nil {
+		return payoutdom.Entry{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.entries[e.ID]; exists {
+		return payoutdom.Entry{}, payoutdom.ErrConflict
+	}
+
+	r.entries[e.ID] = e
+
+	return e, nil
+}
+
+func (r *PayoutRepositoryMem) GetEntry(
+	_ context.Context,
+	id string,
+) (payoutdom.Entry, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return payoutdom.Entry{}, payoutdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[id]
+	if !ok {
+		return payoutdom.Entry{}, payoutdom.ErrNotFound
+	}
+
+	return e, nil
+}
+
+// ListEntriesByCompanyID は accruedAt asc の順で返す。
+func (r *PayoutRepositoryMem) ListEntriesByCompanyID(
+	_ context.Context,
+	companyID string,
+	filter payoutdom.EntryFilter,
+) ([]payoutdom.Entry, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, payoutdom.ErrInvalidCompanyID
+	}
+
+	out := make([]payoutdom.Entry, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.entries) {
+		e := r.entries[id]
+
+		if e.CompanyID != companyID {
+			continue
+		}
+		if filter.RecipientType != "" && e.RecipientType != filter.RecipientType {
+			continue
+		}
+		if filter.RecipientID != "" && e.RecipientID != filter.RecipientID {
+			continue
+		}
+		if filter.Unbatched && e.IsBatched() {
+			continue
+		}
+		if filter.BatchID != "" && e.BatchID != filter.BatchID {
+			continue
+		}
+		if filter.AccruedBefore != nil && !e.AccruedAt.Before(*filter.AccruedBefore) {
+			continue
+		}
+
+		out = append(out, e)
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].AccruedAt.Before(out[j].AccruedAt)
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// 支払いバッチ
+// ============================================================
+
+// CreateBatch は含まれる台帳が未計上・別バッチ・別 company の場合 ErrConflict を返す。
+func (r *PayoutRepositoryMem) CreateBatch(
+	_ context.Context,
+	b payoutdom.Batch,
+) (payoutdom.Batch, error) {
+	if err := b.Validate(); err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.batches[b.ID]; exists {
+		return payoutdom.Batch{}, payoutdom.ErrConflict
+	}
+
+	entryIDs := b.EntryIDs()
+	for _, id := range entryIDs {
+		e, ok := r.entries[id]
+		if !ok || e.IsBatched() || e.CompanyID != b.CompanyID {
+			return payoutdom.Batch{}, payoutdom.ErrConflict
+		}
+	}
+
+	r.batches[b.ID] = clonePayoutBatch(b)
+
+	for _, id := range entryIDs {
+		e := r.entries[id]
+		e.BatchID = b.ID
+		r.entries[id] = e
+	}
+
+	return clonePayoutBatch(b), nil
+}
+
+func (r *PayoutRepositoryMem) GetBatch(
+	_ context.Context,
+	id string,
+) (payoutdom.Batch, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return payoutdom.Batch{}, payoutdom.ErrInvalidBatchID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.batches[id]
+	if !ok {
+		return payoutdom.Batch{}, payoutdom.ErrNotFound
+	}
+
+	return clonePayoutBatch(b), nil
+}
+
+// UpdateBatch は cancelled に更新する場合、含まれる台帳の BatchID を外す。
+func (r *PayoutRepositoryMem) UpdateBatch(
+	_ context.Context,
+	b payoutdom.Batch,
+	prevUpdatedAt time.Time,
+) (payoutdom.Batch, error) {
+	if err := b.Validate(); err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.batches[b.ID]
+	if !ok {
+		return payoutdom.Batch{}, payoutdom.ErrNotFound
+	}
+
+	// Firestore の timestamp 精度に合わせて比較する。
+	if !current.UpdatedAt.Truncate(time.Microsecond).Equal(
+		prevUpdatedAt.UTC().Truncate(time.Microsecond),
+	) {
+		return payoutdom.Batch{}, payoutdom.ErrConflict
+	}
+
+	r.batches[b.ID] = clonePayoutBatch(b)
+
+	if b.Status == payoutdom.BatchStatusCancelled &&
+		current.Status != payoutdom.BatchStatusCancelled {
+		for _, id := range current.EntryIDs() {
+			if e, ok := r.entries[id]; ok {
+				e.BatchID = ""
+				r.entries[id] = e
+			}
+		}
+	}
+
+	return clonePayoutBatch(b), nil
+}
+
+// ListBatchesByCompanyID は createdAt desc の順で返す。
+func (r *PayoutRepositoryMem) ListBatchesByCompanyID(
+	_ context.Context,
+	companyID string,
+	st payoutdom.BatchStatus,
+) ([]payoutdom.Batch, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, payoutdom.ErrInvalidCompanyID
+	}
+	if st != "" && !payoutdom.IsValidBatchStatus(st) {
+		return nil, payoutdom.ErrInvalidBatchStatus
+	}
+
+	out := make([]payoutdom.Batch, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.batches) {
+		b := r.batches[id]
+		if b.CompanyID != companyID {
+			continue
+		}
+		if st != "" && b.Status != st {
+			continue
+		}
+		out = append(out, clonePayoutBatch(b))
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// 受取先
+// ============================================================
+
+func (r *PayoutRepositoryMem) GetRecipient(
+	_ context.Context,
+	id string,
+) (payoutdom.Recipient, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.Contains(id, "/") {
+		return payoutdom.Recipient{}, payoutdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.recipients[id]
+	if !ok {
+		return payoutdom.Recipient{}, payoutdom.ErrNotFound
+	}
+
+	return rec, nil
+}
+
+// SetRecipient は Firestore の Set と同じく、既存の受取先を上書きする。
+func (r *PayoutRepositoryMem) SetRecipient(
+	_ context.Context,
+	rec payoutdom.Recipient,
+) (payoutdom.Recipient, error) {
+	if err := rec.Validate(); err != nil {
+		return payoutdom.Recipient{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recipients[rec.ID] = rec
+
+	return rec, nil
+}
+
+// ListRecipientsByCompanyID は id asc の順で返す。
+func (r *PayoutRepositoryMem) ListRecipientsByCompanyID(
+	_ context.Context,
+	companyID string,
+) ([]payoutdom.Recipient, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, payoutdom.ErrInvalidCompanyID
+	}
+
+	out := make([]payoutdom.Recipient, 0)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sortedKeys(r.recipients) {
+		if rec := r.recipients[id]; rec.CompanyID == companyID {
+			out = append(out, rec)
+		}
+	}
+
+	return out, nil
+}
+
+func clonePayoutBatch(b payoutdom.Batch) payoutdom.Batch {
+	out := b
+
+	if b.Lines != nil {
+		out.Lines = make([]payoutdom.Line, len(b.Lines))
+		for i, line := range b.Lines {
+			line.EntryIDs = cloneStrings(line.EntryIDs)
+			out.Lines[i] = line
+		}
+	}
+
+	out.ApprovedAt = cloneTimePtr(b.ApprovedAt)
+	out.ExportedAt = cloneTimePtr(b.ExportedAt)
+	out.PaidAt = cloneTimePtr(b.PaidAt)
+	out.CancelledAt = cloneTimePtr(b.CancelledAt)
+
+	return out
+}
@@ -0,0 +1,203 @@
+// backend/internal/adapters/out/memory/payout_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	payoutdom "narratives/internal/domain/payout"
+)
+
+func newTestPayoutEntry(t *testing.T, companyID string, sourceID string, accruedAt time.Time) payoutdom.Entry {
+	t.Helper()
+
+	e, err := payoutdom.NewEntry(payoutdom.NewEntryInput{
+		CompanyID:     companyID,
+		RecipientType: payoutdom.RecipientTypeCompany,
+		RecipientID:   companyID,
+		Source:        payoutdom.SourceBrandSale,
+		SourceID:      sourceID,
+		GrossAmount:   1000,
+		AccruedAt:     accruedAt,
+	})
+	if err != nil {
+		t.Fatalf("NewEntry: %v", err)
+	}
+
+	return e
+}
+
+func newTestPayoutBatch(t *testing.T, id string, companyID string, entries []payoutdom.Entry, now time.Time) payoutdom.Batch {
+	t.Helper()
+
+	line := payoutdom.Line{
+		RecipientType: payoutdom.RecipientTypeCompany,
+		RecipientID:   companyID,
+		Account:       payoutdom.BankAccount{AccountID: "account_1"},
+	}
+	for _, e := range entries {
+		line.Amount += e.NetAmount
+		line.EntryIDs = append(line.EntryIDs, e.ID)
+	}
+
+	b, err := payoutdom.NewBatch(payoutdom.NewBatchInput{
+		ID:           id,
+		CompanyID:    companyID,
+		CutoffAt:     now,
+		TransferDate: now.Add(72 * time.Hour),
+		Lines:        []payoutdom.Line{line},
+		CreatedBy:    "member_1",
+		CreatedAt:    now,
+	})
+	if err != nil {
+		t.Fatalf("NewBatch: %v", err)
+	}
+
+	return b
+}
+
+func TestPayoutRepositoryMem_CreateBatch(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		entries func(t *testing.T, own, batched, other payoutdom.Entry) []payoutdom.Entry
+		wantErr error
+	}{
+		{
+			name: "unbatched entries of the company",
+			entries: func(_ *testing.T, own, _, _ payoutdom.Entry) []payoutdom.Entry {
+				return []payoutdom.Entry{own}
+			},
+		},
+		{
+			name: "entry already in another batch",
+			entries: func(_ *testing.T, own, batched, _ payoutdom.Entry) []payoutdom.Entry {
+				return []payoutdom.Entry{own, batched}
+			},
+			wantErr: payoutdom.ErrConflict,
+		},
+		{
+			name: "entry of another company",
+			entries: func(_ *testing.T, own, _, other payoutdom.Entry) []payoutdom.Entry {
+				return []payoutdom.Entry{own, other}
+			},
+			wantErr: payoutdom.ErrConflict,
+		},
+		{
+			name: "entry that was never accrued",
+			entries: func(t *testing.T, own, _, _ payoutdom.Entry) []payoutdom.Entry {
+				return []payoutdom.Entry{own, newTestPayoutEntry(t, "company_1", "order_missing_0", now)}
+			},
+			wantErr: payoutdom.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewPayoutRepositoryMem()
+
+			own := newTestPayoutEntry(t, "company_1", "order_1_0", now)
+			batched := newTestPayoutEntry(t, "company_1", "order_2_0", now)
+			other := newTestPayoutEntry(t, "company_2", "order_3_0", now)
+			for _, e := range []payoutdom.Entry{own, batched, other} {
+				if _, err := repo.CreateEntry(ctx, e); err != nil {
+					t.Fatalf("seed entry %s: %v", e.ID, err)
+				}
+			}
+			if _, err := repo.CreateBatch(ctx, newTestPayoutBatch(t, "batch_0", "company_1", []payoutdom.Entry{batched}, now)); err != nil {
+				t.Fatalf("seed batch: %v", err)
+			}
+
+			_, err := repo.CreateBatch(ctx, newTestPayoutBatch(t, "batch_1", "company_1", tt.entries(t, own, batched, other), now))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repo.GetEntry(ctx, own.ID)
+			if err != nil {
+				t.Fatalf("GetEntry: %v", err)
+			}
+			wantBatchID := "batch_1"
+			if tt.wantErr != nil {
+				wantBatchID = ""
+			}
+			if stored.BatchID != wantBatchID {
+				t.Fatalf("entry batchID = %q, want %q", stored.BatchID, wantBatchID)
+			}
+		})
+	}
+}
+
+func TestPayoutRepositoryMem_UpdateBatch(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		batchID     string
+		prevUpdated time.Time
+		wantErr     error
+		wantBatchID string
+	}{
+		{
+			name:        "cancel returns entries to the pool",
+			batchID:     "batch_1",
+			prevUpdated: now,
+			wantBatchID: "",
+		},
+		{
+			name:        "stale updatedAt",
+			batchID:     "batch_1",
+			prevUpdated: now.Add(-time.Minute),
+			wantErr:     payoutdom.ErrConflict,
+			wantBatchID: "batch_1",
+		},
+		{
+			name:        "missing batch is not created",
+			batchID:     "batch_missing",
+			prevUpdated: now,
+			wantErr:     payoutdom.ErrNotFound,
+			wantBatchID: "batch_1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewPayoutRepositoryMem()
+
+			entry := newTestPayoutEntry(t, "company_1", "order_1_0", now)
+			if _, err := repo.CreateEntry(ctx, entry); err != nil {
+				t.Fatalf("seed entry: %v", err)
+			}
+			if _, err := repo.CreateBatch(ctx, newTestPayoutBatch(t, "batch_1", "company_1", []payoutdom.Entry{entry}, now)); err != nil {
+				t.Fatalf("seed batch: %v", err)
+			}
+
+			b := newTestPayoutBatch(t, tt.batchID, "company_1", []payoutdom.Entry{entry}, now)
+			if err := b.Cancel(now.Add(time.Hour)); err != nil {
+				t.Fatalf("Cancel: %v", err)
+			}
+
+			_, err := repo.UpdateBatch(ctx, b, tt.prevUpdated)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repo.GetEntry(ctx, entry.ID)
+			if err != nil {
+				t.Fatalf("GetEntry: %v", err)
+			}
+			if stored.BatchID != tt.wantBatchID {
+				t.Fatalf("entry batchID = %q, want %q", stored.BatchID, tt.wantBatchID)
+			}
+			if _, err := repo.GetBatch(ctx, "batch_missing"); !errors.Is(err, payoutdom.ErrNotFound) {
+				t.Fatalf("GetBatch(batch_missing) err = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
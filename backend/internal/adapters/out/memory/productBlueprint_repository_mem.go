@@ -0,0 +1,469 @@
+// backend/internal/adapters/out/memory/productBlueprint_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	pbdom "narratives/internal/domain/productBlueprint"
+)
+
+// ProductBlueprintRepositoryMem は productBlueprint.Repository の in-memory 実装。
+//
+// Firestore adapter は models collection を正として GetIDByModelID を解決するが、
+// memory では ProductBlueprint.ModelRefs を正とする。
+// CategoryFieldsValidator の既定値はすべての categoryFields を許可する。
+type ProductBlueprintRepositoryMem struct {
+	mu         sync.Mutex
+	blueprints map[string]pbdom.ProductBlueprint
+
+	CategoryFieldsValidator pbdom.CategoryFieldsValidator
+}
+
+var _ pbdom.Repository = (*ProductBlueprintRepositoryMem)(nil)
+
+func NewProductBlueprintRepositoryMem() *ProductBlueprintRepositoryMem {
+	return &ProductBlueprintRepositoryMem{
+		blueprints:              map[string]pbdom.ProductBlueprint{},
+		CategoryFieldsValidator: acceptAnyCategoryFields,
+	}
+}
+
+// WithCategoryFieldsValidator は categoryFields の schema 検証を差し替える。
+func (r *ProductBlueprintRepositoryMem) WithCategoryFieldsValidator(
+	v pbdom.CategoryFieldsValidator,
+) *ProductBlueprintRepositoryMem {
+	if v != nil {
+		r.CategoryFieldsValidator = v
+	}
+	return r
+}
+
+func (r *ProductBlueprintRepositoryMem) validator() pbdom.CategoryFieldsValidator {
+	if r.CategoryFieldsValidator != nil {
+		return r.CategoryFieldsValidator
+	}
+	return acceptAnyCategoryFields
+}
+
+func (r *ProductBlueprintRepositoryMem) Create(
+	_ context.Context,
+	in pbdom.CreateInput,
+) (pbdom.ProductBlueprint, error) {
+	if in.ID == "" {
+		return pbdom.ProductBlueprint{}, pbdom.ErrInvalidID
+	}
+
+	createdAt := time.Now().UTC()
+	if in.CreatedAt != nil && !in.CreatedAt.IsZero() {
+		createdAt = in.CreatedAt.UTC()
+	}
+
+	pb, err := pbdom.New(
+		in.ID,
+		in.ProductName,
+		in.Description,
+		in.BrandID,
+		in.ProductBlueprintCategoryPath,
+		in.CategoryFields,
+		in.ProductIdTag,
+		in.AssigneeID,
+		in.CreatedBy,
+		createdAt,
+		in.CompanyID,
+		r.validator(),
+	)
+	if err != nil {
+		return pbdom.ProductBlueprint{}, err
+	}
+
+	if len(in.ModelRefs) > 0 {
+		pb.ModelRefs = sanitizeModelRefsMem(in.ModelRefs)
+	}
+
+	if err := pb.ValidateCategoryFields(r.validator()); err != nil {
+		return pbdom.ProductBlueprint{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.blueprints[pb.ID]; exists {
+		return pbdom.ProductBlueprint{}, pbdom.ErrConflict
+	}
+
+	r.blueprints[pb.ID] = cloneProductBlueprint(pb)
+
+	return cloneProductBlueprint(pb), nil
+}
+
+func (r *ProductBlueprintRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (pbdom.ProductBlueprint, error) {
+	if id == "" {
+		return pbdom.ProductBlueprint{}, pbdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pb, ok := r.blueprints[id]
+	if !ok {
+		return pbdom.ProductBlueprint{}, pbdom.ErrNotFound
+	}
+
+	return cloneProductBlueprint(pb), nil
+}
+
+func (r *ProductBlueprintRepositoryMem) GetIDByModelID(
+	_ context.Context,
+	modelID string,
+) (string, []pbdom.ModelRef, error) {
+	if modelID == "" {
+		return "", nil, pbdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sortedKeys(r.blueprints) {
+		pb := r.blueprints[id]
+		for _, ref := range pb.ModelRefs {
+			if ref.ModelID == modelID {
+				return pb.ID, cloneModelRefsMem(pb.ModelRefs), nil
+			}
+		}
+	}
+
+	return "", nil, pbdom.ErrNotFound
+}
+
+func (r *ProductBlueprintRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+) ([]pbdom.ProductBlueprint, error) {
+	if companyID == "" {
+		return nil, pbdom.ErrInvalidCompanyID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]pbdom.ProductBlueprint, 0)
+	for _, id := range sortedKeys(r.blueprints) {
+		if pb := r.blueprints[id]; pb.CompanyID == companyID {
+			out = append(out, cloneProductBlueprint(pb))
+		}
+	}
+
+	return out, nil
+}
+
+func (r *ProductBlueprintRepositoryMem) ListIDsByBrandID(
+	_ context.Context,
+	brandID string,
+) ([]string, error) {
+	if brandID == "" {
+		return nil, pbdom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0)
+	for _, id := range sortedKeys(r.blueprints) {
+		if r.blueprints[id].BrandID == brandID {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// ReplaceModelRefsWithoutTouch は printed=false の場合だけ modelRefs を置換し、
+// updatedAt / updatedBy は変更しない。
+func (r *ProductBlueprintRepositoryMem) ReplaceModelRefsWithoutTouch(
+	_ context.Context,
+	id string,
+	refs []pbdom.ModelRef,
+) (pbdom.ProductBlueprint, error) {
+	if id == "" {
+		return pbdom.ProductBlueprint{}, pbdom.ErrInvalidID
+	}
+
+	normalized := sanitizeModelRefsMem(refs)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.blueprints[id]
+	if !ok {
+		return pbdom.ProductBlueprint{}, pbdom.ErrNotFound
+	}
+
+	pb := cloneProductBlueprint(stored)
+	if !pb.CanModify() {
+		return pbdom.ProductBlueprint{}, pbdom.ErrForbidden
+	}
+	if err := pb.ReplaceModelRefsWithoutTouch(normalized); err != nil {
+		return pbdom.ProductBlueprint{}, err
+	}
+
+	r.blueprints[id] = cloneProductBlueprint(pb)
+
+	return pb, nil
+}
+
+// Update は ProductBlueprintRepositoryFS と同じ順序で patch を適用する。
+// 印刷済みの場合は税関情報だけの更新を許可する。
+func (r *ProductBlueprintRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	patch pbdom.Patch,
+) (pbdom.ProductBlueprint, error) {
+	if id == "" {
+		return pbdom.ProductBlueprint{}, pbdom.ErrInvalidID
+	}
+
+	validator := r.validator()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.blueprints[id]
+	if !ok {
+		return pbdom.ProductBlueprint{}, pbdom.ErrNotFound
+	}
+
+	pb := cloneProductBlueprint(stored)
+	if !pb.CanModify() && !patch.IsCustomsOnly() {
+		return pbdom.ProductBlueprint{}, pbdom.ErrForbidden
+	}
+
+	now := time.Now().UTC()
+
+	if patch.ProductName != nil {
+		if err := pb.UpdateProductName(*patch.ProductName, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	}
+	if patch.Description != nil {
+		if err := pb.UpdateDescription(*patch.Description, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	}
+	if patch.BrandID != nil {
+		if err := pb.UpdateBrand(*patch.BrandID, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	}
+	if patch.CompanyID != nil {
+		if *patch.CompanyID == "" {
+			return pbdom.ProductBlueprint{}, pbdom.ErrInvalidCompanyID
+		}
+		pb.CompanyID = *patch.CompanyID
+		pb.UpdatedAt = now
+		pb.UpdatedBy = patch.UpdatedBy
+	}
+
+	switch {
+	case patch.ProductBlueprintCategoryPath != nil && patch.CategoryFields != nil:
+		if err := pb.UpdateCategoryAndFields(*patch.ProductBlueprintCategoryPath, *patch.CategoryFields, validator, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	case patch.ProductBlueprintCategoryPath != nil:
+		if err := pb.UpdateCategory(*patch.ProductBlueprintCategoryPath, validator, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	case patch.CategoryFields != nil:
+		if err := pb.UpdateCategoryFields(*patch.CategoryFields, validator, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	}
+
+	if patch.ProductIdTag != nil {
+		if err := pb.UpdateTag(*patch.ProductIdTag, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	}
+	if patch.AssigneeID != nil {
+		if err := pb.UpdateAssignee(*patch.AssigneeID, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	}
+	if patch.ModelRefs != nil {
+		refs := sanitizeModelRefsMem(*patch.ModelRefs)
+
+		modelIDs := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			modelIDs = append(modelIDs, ref.ModelID)
+		}
+
+		if err := pb.UpdateModelIDs(modelIDs, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	}
+	if patch.Customs != nil {
+		if err := pb.UpdateCustoms(*patch.Customs, now, patch.UpdatedBy); err != nil {
+			return pbdom.ProductBlueprint{}, err
+		}
+	}
+
+	if err := pb.ValidateCategoryFields(validator); err != nil {
+		return pbdom.ProductBlueprint{}, err
+	}
+
+	r.blueprints[id] = cloneProductBlueprint(pb)
+
+	return pb, nil
+}
+
+// MarkPrinted は冪等。すでに printed=true の場合はそのまま返す。
+func (r *ProductBlueprintRepositoryMem) MarkPrinted(
+	_ context.Context,
+	id string,
+) (pbdom.ProductBlueprint, error) {
+	if id == "" {
+		return pbdom.ProductBlueprint{}, pbdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.blueprints[id]
+	if !ok {
+		return pbdom.ProductBlueprint{}, pbdom.ErrNotFound
+	}
+
+	pb := cloneProductBlueprint(stored)
+	if pb.Printed {
+		return pb, nil
+	}
+
+	if err := pb.MarkPrinted(time.Now().UTC(), pb.UpdatedBy, r.validator()); err != nil {
+		return pbdom.ProductBlueprint{}, err
+	}
+
+	r.blueprints[id] = cloneProductBlueprint(pb)
+
+	return pb, nil
+}
+
+// Delete は companyID が一致し、printed=false の ProductBlueprint だけを削除する。
+// 配下 Model の削除は ModelRepositoryMem 側の責務とする。
+func (r *ProductBlueprintRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+	companyID string,
+) error {
+	if id == "" {
+		return pbdom.ErrInvalidID
+	}
+	if companyID == "" {
+		return pbdom.ErrInvalidCompanyID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pb, ok := r.blueprints[id]
+	if !ok {
+		return pbdom.ErrNotFound
+	}
+	if pb.CompanyID == "" || pb.CompanyID != companyID {
+		return pbdom.ErrForbidden
+	}
+	if pb.Printed {
+		return pbdom.ErrForbidden
+	}
+
+	delete(r.blueprints, id)
+
+	return nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func acceptAnyCategoryFields(_ []string, _ pbdom.CategoryFields) error {
+	return nil
+}
+
+// sanitizeModelRefsMem は displayOrder 昇順（同順位は入力順）に並べ、
+// 空 ID と重複 ID を除外して 1..N に再採番する。
+func sanitizeModelRefsMem(in []pbdom.ModelRef) []pbdom.ModelRef {
+	sorted := cloneModelRefsMem(in)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].DisplayOrder < sorted[j].DisplayOrder
+	})
+
+	seen := make(map[string]struct{}, len(sorted))
+	out := make([]pbdom.ModelRef, 0, len(sorted))
+	for _, ref := range sorted {
+		if ref.ModelID == "" {
+			continue
+		}
+		if _, exists := seen[ref.ModelID]; exists {
+			continue
+		}
+		seen[ref.ModelID] = struct{}{}
+		out = append(out, pbdom.ModelRef{
+			ModelID:      ref.ModelID,
+			DisplayOrder: len(out) + 1,
+		})
+	}
+
+	return out
+}
+
+func cloneModelRefsMem(in []pbdom.ModelRef) []pbdom.ModelRef {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]pbdom.ModelRef, len(in))
+	copy(out, in)
+
+	return out
+}
+
+func cloneCategoryFieldsMem(in pbdom.CategoryFields) pbdom.CategoryFields {
+	if in == nil {
+		return nil
+	}
+
+	out := make(pbdom.CategoryFields, len(in))
+	for k, v := range in {
+		switch typed := v.(type) {
+		case []string:
+			out[k] = cloneStrings(typed)
+		case []any:
+			out[k] = append([]any(nil), typed...)
+		case map[string]any:
+			m := make(map[string]any, len(typed))
+			for mk, mv := range typed {
+				m[mk] = mv
+			}
+			out[k] = m
+		default:
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+func cloneProductBlueprint(pb pbdom.ProductBlueprint) pbdom.ProductBlueprint {
+	out := pb
+	out.ProductBlueprintCategoryPath = cloneStrings(pb.ProductBlueprintCategoryPath)
+	out.CategoryFields = cloneCategoryFieldsMem(pb.CategoryFields)
+	out.ModelRefs = cloneModelRefsMem(pb.ModelRefs)
+	out.CreatedBy = cloneStringPtr(pb.CreatedBy)
+	out.UpdatedBy = cloneStringPtr(pb.UpdatedBy)
+
+	return out
+}
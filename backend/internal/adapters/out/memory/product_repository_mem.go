@@ -0,0 +1,90 @@
+// backend/internal/adapters/out/memory/product_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sync"
+
+	productdom "narratives/internal/domain/product"
+)
+
+// ProductRepositoryMem は product.Repository の in-memory 実装。
+type ProductRepositoryMem struct {
+	mu  sync.Mutex
+	ids idSequence
+
+	products map[string]productdom.Product
+}
+
+var _ productdom.Repository = (*ProductRepositoryMem)(nil)
+
+func NewProductRepositoryMem() *ProductRepositoryMem {
+	return &ProductRepositoryMem{
+		products: map[string]productdom.Product{},
+	}
+}
+
+func (r *ProductRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (productdom.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.products[id]
+	if !ok {
+		return productdom.Product{}, productdom.ErrNotFound
+	}
+
+	return cloneProduct(p), nil
+}
+
+// Create は ID が空の場合に採番し、既存の ID は上書きしない。
+func (r *ProductRepositoryMem) Create(
+	_ context.Context,
+	p productdom.Product,
+) (productdom.Product, error) {
+	if p.ID == "" {
+		p.ID = r.ids.newID("product")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.products[p.ID]; exists {
+		return productdom.Product{}, productdom.ErrConflict
+	}
+
+	r.products[p.ID] = cloneProduct(p)
+
+	return cloneProduct(p), nil
+}
+
+// ListByProductionID は id asc の順で返す。
+func (r *ProductRepositoryMem) ListByProductionID(
+	_ context.Context,
+	productionID string,
+) ([]productdom.Product, error) {
+	out := make([]productdom.Product, 0)
+	if productionID == "" {
+		return out, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range sortedKeys(r.products) {
+		if p := r.products[id]; p.ProductionID == productionID {
+			out = append(out, cloneProduct(p))
+		}
+	}
+
+	return out, nil
+}
+
+func cloneProduct(p productdom.Product) productdom.Product {
+	p.PrintedAt = cloneTimePtr(p.PrintedAt)
+	p.InspectedAt = cloneTimePtr(p.InspectedAt)
+	p.InspectedBy = cloneStringPtr(p.InspectedBy)
+	return p
+}
@@ -0,0 +1,262 @@
+// backend/internal/adapters/out/memory/refund_repository_mem.go
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	refunddom "narratives/internal/domain/refund"
+)
+
+// RefundRepositoryMem は refund.RepositoryPort の in-memory 実装。
+// Stripe event の重複排除も RefundRepositoryFS と同じ規則で行う。
+type RefundRepositoryMem struct {
+	mu sync.Mutex
+
+	refunds      map[string]refunddom.Refund
+	stripeEvents map[string]struct{}
+}
+
+var _ refunddom.RepositoryPort = (*RefundRepositoryMem)(nil)
+
+func NewRefundRepositoryMem() *RefundRepositoryMem {
+	return &RefundRepositoryMem{
+		refunds:      map[string]refunddom.Refund{},
+		stripeEvents: map[string]struct{}{},
+	}
+}
+
+func (r *RefundRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (refunddom.Refund, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return refunddom.Refund{}, refunddom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	refund, ok := r.refunds[id]
+	if !ok {
+		return refunddom.Refund{}, refunddom.ErrNotFound
+	}
+
+	return cloneRefund(refund), nil
+}
+
+func (r *RefundRepositoryMem) ListByPaymentID(
+	_ context.Context,
+	paymentID string,
+) ([]refunddom.Refund, error) {
+	paymentID = strings.TrimSpace(paymentID)
+	if paymentID == "" {
+		return nil, refunddom.ErrInvalidPaymentID
+	}
+
+	r.mu.Lock()
+	out := make([]refunddom.Refund, 0)
+	for _, id := range sortedKeys(r.refunds) {
+		if refund := r.refunds[id]; refund.PaymentID == paymentID {
+			out = append(out, cloneRefund(refund))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+
+	return out, nil
+}
+
+func (r *RefundRepositoryMem) Create(
+	_ context.Context,
+	refund refunddom.Refund,
+) (refunddom.Refund, error) {
+	if err := refund.Validate(); err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.refunds[refund.ID]; exists {
+		return refunddom.Refund{}, refunddom.ErrConflict
+	}
+
+	r.refunds[refund.ID] = cloneRefund(refund)
+
+	return cloneRefund(refund), nil
+}
+
+// CreateForPayment は一覧・build・作成を 1 つの lock 内で行う。
+func (r *RefundRepositoryMem) CreateForPayment(
+	_ context.Context,
+	paymentID string,
+	build func(existing []refunddom.Refund) (refunddom.Refund, error),
+) (refunddom.Refund, error) {
+	paymentID = strings.TrimSpace(paymentID)
+	if paymentID == "" {
+		return refunddom.Refund{}, refunddom.ErrInvalidPaymentID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := make([]refunddom.Refund, 0)
+	for _, id := range sortedKeys(r.refunds) {
+		if refund := r.refunds[id]; refund.PaymentID == paymentID {
+			existing = append(existing, cloneRefund(refund))
+		}
+	}
+	sort.SliceStable(existing, func(i, j int) bool {
+		return existing[i].CreatedAt.Before(existing[j].CreatedAt)
+	})
+
+	refund, err := build(existing)
+	if err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	if refund.PaymentID != paymentID {
+		return refunddom.Refund{}, refunddom.ErrConflict
+	}
+	if err := refund.Validate(); err != nil {
+		return refunddom.Refund{}, err
+	}
+	if _, exists := r.refunds[refund.ID]; exists {
+		return refunddom.Refund{}, refunddom.ErrConflict
+	}
+
+	r.refunds[refund.ID] = cloneRefund(refund)
+
+	return cloneRefund(refund), nil
+}
+
+func (r *RefundRepositoryMem) Update(
+	_ context.Context,
+	refund refunddom.Refund,
+) (refunddom.Refund, error) {
+	if err := refund.Validate(); err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.refunds[refund.ID]
+	if !ok {
+		return refunddom.Refund{}, refunddom.ErrNotFound
+	}
+
+	if current.PaymentID != refund.PaymentID {
+		return refunddom.Refund{}, refunddom.ErrConflict
+	}
+
+	if !refunddom.CanTransition(current.Status, refund.Status) {
+		return refunddom.Refund{}, refunddom.ErrInvalidTransition
+	}
+
+	r.refunds[refund.ID] = cloneRefund(refund)
+
+	return cloneRefund(refund), nil
+}
+
+// ApplyStripeEvent は RefundRepositoryFS と同様に、event の重複排除、
+// Stripe Refund ID の照合、状態遷移の適用、event の記録を 1 つの lock 内で行う。
+func (r *RefundRepositoryMem) ApplyStripeEvent(
+	_ context.Context,
+	in refunddom.ApplyStripeEventInput,
+) (refunddom.ApplyStripeEventResult, error) {
+	in.EventID = strings.TrimSpace(in.EventID)
+	in.RefundID = strings.TrimSpace(in.RefundID)
+	in.StripeRefundID = strings.TrimSpace(in.StripeRefundID)
+
+	if in.EventID == "" || strings.Contains(in.EventID, "/") {
+		return refunddom.ApplyStripeEventResult{}, fmt.Errorf(
+			"refund: invalid Stripe event id %q",
+			in.EventID,
+		)
+	}
+
+	if in.RefundID == "" {
+		return refunddom.ApplyStripeEventResult{}, refunddom.ErrInvalidID
+	}
+
+	if !refunddom.IsValidStatus(in.Status) {
+		return refunddom.ApplyStripeEventResult{}, refunddom.ErrInvalidStatus
+	}
+
+	processedAt := time.Now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.refunds[in.RefundID]
+	if !ok {
+		return refunddom.ApplyStripeEventResult{}, refunddom.ErrNotFound
+	}
+	current := cloneRefund(stored)
+
+	// Duplicate Stripe event: successful no-op.
+	if _, seen := r.stripeEvents[in.EventID]; seen {
+		return refunddom.ApplyStripeEventResult{
+			Refund: current,
+		}, nil
+	}
+
+	if current.StripeRefundID != "" &&
+		in.StripeRefundID != "" &&
+		current.StripeRefundID != in.StripeRefundID {
+		return refunddom.ApplyStripeEventResult{}, refunddom.ErrConflict
+	}
+
+	next := cloneRefund(current)
+	transitionApplied :=
+		next.ApplyStripeResult(
+			in.StripeRefundID,
+			in.Status,
+			in.ErrorMsg,
+			processedAt,
+		) == nil
+
+	statusChanged :=
+		transitionApplied &&
+			current.Status != next.Status
+
+	if transitionApplied {
+		if err := next.Validate(); err != nil {
+			return refunddom.ApplyStripeEventResult{}, err
+		}
+
+		r.refunds[next.ID] = cloneRefund(next)
+	} else {
+		next = current
+	}
+
+	r.stripeEvents[in.EventID] = struct{}{}
+
+	return refunddom.ApplyStripeEventResult{
+		Refund:        cloneRefund(next),
+		EventApplied:  true,
+		StatusChanged: statusChanged,
+	}, nil
+}
+
+func cloneRefund(refund refunddom.Refund) refunddom.Refund {
+	out := refund
+	out.ErrorMsg = cloneStringPtr(refund.ErrorMsg)
+
+	if refund.Items != nil {
+		out.Items = make([]refunddom.RefundItem, len(refund.Items))
+		copy(out.Items, refund.Items)
+	}
+
+	return out
+}
@@ -0,0 +1,455 @@
+// backend/internal/adapters/out/memory/resale_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	fscommon "narratives/internal/adapters/out/firestore/common"
+	resaledom "narratives/internal/domain/resale"
+)
+
+// ResaleRepositoryMem は resale.Repository の in-memory 実装。
+// Firestore adapter と同様に、1 product に対して resale は 1 件までとする。
+type ResaleRepositoryMem struct {
+	mu      sync.Mutex
+	ids     idSequence
+	resales map[string]resaledom.Resale
+}
+
+var _ resaledom.Repository = (*ResaleRepositoryMem)(nil)
+
+func NewResaleRepositoryMem() *ResaleRepositoryMem {
+	return &ResaleRepositoryMem{
+		resales: map[string]resaledom.Resale{},
+	}
+}
+
+func (r *ResaleRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (resaledom.Resale, error) {
+	if id == "" {
+		return resaledom.Resale{}, resaledom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.resales[id]
+	if !ok {
+		return resaledom.Resale{}, resaledom.ErrNotFound
+	}
+
+	return cloneResale(item), nil
+}
+
+// ListByAvatarID は updatedAt desc, createdAt desc, id desc の順で返す。
+func (r *ResaleRepositoryMem) ListByAvatarID(
+	_ context.Context,
+	avatarID string,
+) ([]resaledom.Resale, error) {
+	out := make([]resaledom.Resale, 0)
+	if avatarID == "" {
+		return out, nil
+	}
+
+	r.mu.Lock()
+	for _, item := range r.resales {
+		if item.AvatarID == avatarID {
+			out = append(out, cloneResale(item))
+		}
+	}
+	r.mu.Unlock()
+
+	sortResalesMem(out, resaledom.Sort{})
+
+	return out, nil
+}
+
+func (r *ResaleRepositoryMem) List(
+	_ context.Context,
+	filter resaledom.Filter,
+	sortSpec resaledom.Sort,
+	page resaledom.Page,
+) (resaledom.PageResult[resaledom.Resale], error) {
+	pageNum, perPage, offset := fscommon.NormalizePage(
+		page.Number,
+		page.PerPage,
+		50,
+		0,
+	)
+
+	r.mu.Lock()
+	matched := r.filterLocked(filter)
+	r.mu.Unlock()
+
+	sortResalesMem(matched, sortSpec)
+
+	return resaledom.PageResult[resaledom.Resale]{
+		Items:      paginate(matched, offset, perPage),
+		TotalCount: len(matched),
+		TotalPages: fscommon.ComputeTotalPages(len(matched), perPage),
+		Page:       pageNum,
+		PerPage:    perPage,
+	}, nil
+}
+
+// ListByCursor は ResaleRepositoryFS と同様に、sort 後の並びで id > After の要素から返す。
+func (r *ResaleRepositoryMem) ListByCursor(
+	_ context.Context,
+	filter resaledom.Filter,
+	sortSpec resaledom.Sort,
+	cpage resaledom.CursorPage,
+) (resaledom.CursorPageResult[resaledom.Resale], error) {
+	limit := cpage.Limit
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	r.mu.Lock()
+	matched := r.filterLocked(filter)
+	r.mu.Unlock()
+
+	sortResalesMem(matched, sortSpec)
+
+	items := make([]resaledom.Resale, 0, limit+1)
+	skipping := cpage.After != ""
+	last := ""
+
+	for _, item := range matched {
+		if skipping {
+			if item.ID <= cpage.After {
+				continue
+			}
+			skipping = false
+		}
+
+		items = append(items, item)
+		last = item.ID
+
+		if len(items) >= limit+1 {
+			break
+		}
+	}
+
+	var next *string
+	if len(items) > limit {
+		items = items[:limit]
+		next = &last
+	}
+
+	return resaledom.CursorPageResult[resaledom.Resale]{
+		Items:      items,
+		NextCursor: next,
+		Limit:      limit,
+	}, nil
+}
+
+func (r *ResaleRepositoryMem) Create(
+	_ context.Context,
+	item resaledom.Resale,
+) (resaledom.Resale, error) {
+	now := time.Now().UTC()
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = now
+	} else {
+		item.CreatedAt = item.CreatedAt.UTC()
+	}
+	if item.UpdatedAt == nil {
+		t := now
+		item.UpdatedAt = &t
+	}
+	if item.UpdatedBy != nil && *item.UpdatedBy == "" {
+		item.UpdatedBy = nil
+	}
+	if item.Status == "" {
+		item.Status = resaledom.StatusListing
+	}
+	if item.ID == "" {
+		item.ID = r.ids.newID("resale")
+	}
+	if !isValidResaleDocID(item.ID) {
+		return resaledom.Resale{}, resaledom.ErrInvalidID
+	}
+
+	if err := item.ValidateForPersist(); err != nil {
+		return resaledom.Resale{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.resales[item.ID]; exists {
+		return resaledom.Resale{}, resaledom.ErrConflict
+	}
+	for _, existing := range r.resales {
+		if existing.ProductID == item.ProductID {
+			return resaledom.Resale{}, resaledom.ErrConflict
+		}
+	}
+
+	r.resales[item.ID] = cloneResale(item)
+
+	return cloneResale(item), nil
+}
+
+// Update は ResaleRepositoryFS と同じ可変 field だけを更新する。
+func (r *ResaleRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	item resaledom.Resale,
+) (resaledom.Resale, error) {
+	if id == "" {
+		return resaledom.Resale{}, resaledom.ErrNotFound
+	}
+	if !isValidResaleDocID(id) || (item.ID != "" && item.ID != id) {
+		return resaledom.Resale{}, resaledom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.resales[id]
+	if !ok {
+		return resaledom.Resale{}, resaledom.ErrNotFound
+	}
+
+	cur := cloneResale(stored)
+
+	if item.Status != "" {
+		cur.Status = item.Status
+	}
+	cur.Price = item.Price
+	if item.Condition != "" {
+		cur.Condition = item.Condition
+	}
+	cur.Description = item.Description
+	cur.ImageID = item.ImageID
+
+	if cur.CreatedBy == "" {
+		cur.CreatedBy = item.CreatedBy
+	}
+	if cur.CreatedAt.IsZero() {
+		cur.CreatedAt = item.CreatedAt.UTC()
+	}
+
+	cur.UpdatedBy, cur.UpdatedAt = applyUpdatedMeta(
+		cur.UpdatedBy,
+		item.UpdatedBy,
+		item.UpdatedAt,
+	)
+
+	if err := cur.ValidateForPersist(); err != nil {
+		return resaledom.Resale{}, err
+	}
+
+	r.resales[id] = cur
+
+	return cloneResale(cur), nil
+}
+
+// Delete は sold の resale を削除しない（Resale.ValidateDelete）。
+func (r *ResaleRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if id == "" {
+		return resaledom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.resales[id]
+	if !ok {
+		return resaledom.ErrNotFound
+	}
+
+	if err := item.ValidateDelete(); err != nil {
+		return err
+	}
+
+	delete(r.resales, id)
+
+	return nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func (r *ResaleRepositoryMem) filterLocked(
+	filter resaledom.Filter,
+) []resaledom.Resale {
+	out := make([]resaledom.Resale, 0, len(r.resales))
+	for _, item := range r.resales {
+		if resaleMatchesFilter(item, filter) {
+			out = append(out, cloneResale(item))
+		}
+	}
+
+	return out
+}
+
+func isValidResaleDocID(id string) bool {
+	return id != "" &&
+		!strings.Contains(id, "/") &&
+		!strings.Contains(id, "://")
+}
+
+func resaleMatchesFilter(item resaledom.Resale, f resaledom.Filter) bool {
+	if len(f.IDs) > 0 && !containsString(f.IDs, item.ID) {
+		return false
+	}
+	if len(f.AssetIDs) > 0 && !containsString(f.AssetIDs, item.AssetID) {
+		return false
+	}
+	if len(f.TokenBlueprintIDs) > 0 && !containsString(f.TokenBlueprintIDs, item.TokenBlueprintID) {
+		return false
+	}
+	if len(f.ProductIDs) > 0 && !containsString(f.ProductIDs, item.ProductID) {
+		return false
+	}
+	if len(f.BrandIDs) > 0 && !containsString(f.BrandIDs, item.BrandID) {
+		return false
+	}
+	if len(f.ProductBlueprintIDs) > 0 && !containsString(f.ProductBlueprintIDs, item.ProductBlueprintID) {
+		return false
+	}
+	if len(f.AvatarIDs) > 0 && !containsString(f.AvatarIDs, item.AvatarID) {
+		return false
+	}
+	if len(f.ExcludeAvatarIDs) > 0 && containsString(f.ExcludeAvatarIDs, item.AvatarID) {
+		return false
+	}
+	if f.Status != nil && item.Status != *f.Status {
+		return false
+	}
+	if len(f.Statuses) > 0 {
+		found := false
+		for _, st := range f.Statuses {
+			if item.Status == st {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Condition != nil && item.Condition != *f.Condition {
+		return false
+	}
+	if len(f.Conditions) > 0 {
+		found := false
+		for _, c := range f.Conditions {
+			if item.Condition == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.MinPrice != nil && item.Price < *f.MinPrice {
+		return false
+	}
+	if f.MaxPrice != nil && item.Price > *f.MaxPrice {
+		return false
+	}
+
+	if q := strings.ToLower(f.SearchQuery); q != "" {
+		haystack := strings.ToLower(strings.Join([]string{
+			item.ID,
+			item.AssetID,
+			item.TokenBlueprintID,
+			item.ProductID,
+			item.BrandID,
+			item.ProductBlueprintID,
+			item.AvatarID,
+			item.Description,
+			string(item.Status),
+			string(item.Condition),
+		}, " "))
+
+		if !strings.Contains(haystack, q) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortResalesMem は ResaleRepositoryFS の sortResales と同じ並び順を返す。
+// 既定は updatedAt desc。
+func sortResalesMem(items []resaledom.Resale, sortSpec resaledom.Sort) {
+	column := sortSpec.Column
+	if column == "" {
+		column = "updatedAt"
+	}
+
+	order := sortSpec.Order
+	if order == "" {
+		order = resaledom.SortDesc
+	}
+
+	less := func(a, b resaledom.Resale) bool {
+		switch column {
+		case "id":
+			return a.ID < b.ID
+
+		case "price":
+			if a.Price == b.Price {
+				return a.ID < b.ID
+			}
+			return a.Price < b.Price
+
+		case "createdAt", "created_at":
+			if a.CreatedAt.Equal(b.CreatedAt) {
+				return a.ID < b.ID
+			}
+			return a.CreatedAt.Before(b.CreatedAt)
+
+		default:
+			at, bt := timeOrZero(a.UpdatedAt), timeOrZero(b.UpdatedAt)
+			if at.Equal(bt) {
+				if a.CreatedAt.Equal(b.CreatedAt) {
+					return a.ID < b.ID
+				}
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+			return at.Before(bt)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if order == resaledom.SortDesc {
+			return less(items[j], items[i])
+		}
+		return less(items[i], items[j])
+	})
+}
+
+func cloneResale(item resaledom.Resale) resaledom.Resale {
+	out := item
+	out.UpdatedBy = cloneStringPtr(item.UpdatedBy)
+	out.UpdatedAt = cloneTimePtr(item.UpdatedAt)
+	out.Measurements = cloneIntMap(item.Measurements)
+
+	if item.Color != nil {
+		c := *item.Color
+		out.Color = &c
+	}
+	if item.Volume != nil {
+		v := *item.Volume
+		out.Volume = &v
+	}
+
+	return out
+}
@@ -0,0 +1,308 @@
+// backend/internal/adapters/out/memory/return_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	returndom "narratives/internal/domain/orderReturn"
+)
+
+// ReturnRepositoryMem は orderReturn.RepositoryPort の in-memory 実装。
+// 同一注文明細の open な返品の重複チェックも ReturnRepositoryFS と同じ規則で行う。
+type ReturnRepositoryMem struct {
+	mu sync.Mutex
+
+	returns map[string]returndom.Return
+}
+
+var _ returndom.RepositoryPort = (*ReturnRepositoryMem)(nil)
+
+func NewReturnRepositoryMem() *ReturnRepositoryMem {
+	return &ReturnRepositoryMem{
+		returns: map[string]returndom.Return{},
+	}
+}
+
+func (r *ReturnRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (returndom.Return, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return returndom.Return{}, returndom.ErrNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret, ok := r.returns[id]
+	if !ok {
+		return returndom.Return{}, returndom.ErrNotFound
+	}
+
+	return cloneReturn(ret), nil
+}
+
+func (r *ReturnRepositoryMem) ListByOrderID(
+	_ context.Context,
+	orderID string,
+) ([]returndom.Return, error) {
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return nil, returndom.ErrInvalidOrderID
+	}
+
+	out := r.filter(func(ret returndom.Return) bool {
+		return ret.OrderID == orderID
+	})
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+
+	return out, nil
+}
+
+func (r *ReturnRepositoryMem) ListByAvatarID(
+	_ context.Context,
+	avatarID string,
+) ([]returndom.Return, error) {
+	avatarID = strings.TrimSpace(avatarID)
+	if avatarID == "" {
+		return nil, returndom.ErrInvalidAvatarID
+	}
+
+	out := r.filter(func(ret returndom.Return) bool {
+		return ret.AvatarID == avatarID
+	})
+
+	sortReturnsNewestFirst(out)
+
+	return out, nil
+}
+
+func (r *ReturnRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+	status returndom.Status,
+) ([]returndom.Return, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, returndom.ErrInvalidCompanyID
+	}
+
+	if status != "" && !returndom.IsValidStatus(status) {
+		return nil, returndom.ErrInvalidStatus
+	}
+
+	out := r.filter(func(ret returndom.Return) bool {
+		return ret.CompanyID == companyID &&
+			(status == "" || ret.Status == status)
+	})
+
+	sortReturnsNewestFirst(out)
+
+	return out, nil
+}
+
+func (r *ReturnRepositoryMem) Create(
+	_ context.Context,
+	ret returndom.Return,
+) (returndom.Return, error) {
+	if err := ret.Validate(); err != nil {
+		return returndom.Return{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.returns[ret.ID]; exists {
+		return returndom.Return{}, returndom.ErrConflict
+	}
+
+	for _, existing := range r.returns {
+		if existing.OrderID == ret.OrderID &&
+			existing.ItemIndex == ret.ItemIndex &&
+			existing.Status.IsOpen() {
+			return returndom.Return{}, returndom.ErrConflict
+		}
+	}
+
+	r.returns[ret.ID] = cloneReturn(ret)
+
+	return cloneReturn(ret), nil
+}
+
+func (r *ReturnRepositoryMem) Update(
+	_ context.Context,
+	ret returndom.Return,
+) (returndom.Return, error) {
+	if err := ret.Validate(); err != nil {
+		return returndom.Return{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.returns[ret.ID]
+	if !ok {
+		return returndom.Return{}, returndom.ErrNotFound
+	}
+
+	if current.OrderID != ret.OrderID ||
+		current.ItemIndex != ret.ItemIndex {
+		return returndom.Return{}, returndom.ErrConflict
+	}
+
+	if current.Status != ret.Status &&
+		!returndom.CanTransition(current.Status, ret.Status) {
+		return returndom.Return{}, returndom.ErrInvalidTransition
+	}
+
+	r.returns[ret.ID] = cloneReturn(ret)
+
+	return cloneReturn(ret), nil
+}
+
+func (r *ReturnRepositoryMem) filter(
+	match func(returndom.Return) bool,
+) []returndom.Return {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]returndom.Return, 0)
+	for _, id := range sortedKeys(r.returns) {
+		if ret := r.returns[id]; match(ret) {
+			out = append(out, cloneReturn(ret))
+		}
+	}
+
+	return out
+}
+
+func sortReturnsNewestFirst(returns []returndom.Return) {
+	sort.SliceStable(returns, func(i, j int) bool {
+		return returns[i].CreatedAt.After(returns[j].CreatedAt)
+	})
+}
+
+func cloneReturn(ret returndom.Return) returndom.Return {
+	out := ret
+	out.ProductIDs = cloneStrings(ret.ProductIDs)
+	out.ReviewedAt = cloneTimePtr(ret.ReviewedAt)
+	out.ReceivedAt = cloneTimePtr(ret.ReceivedAt)
+	out.CompletedAt = cloneTimePtr(ret.CompletedAt)
+	out.CancelledAt = cloneTimePtr(ret.CancelledAt)
+
+	if ret.Reasons != nil {
+		out.Reasons = make([]returndom.Reason, len(ret.Reasons))
+		copy(out.Reasons, ret.Reasons)
+	}
+
+	if ret.TokenReturns != nil {
+		out.TokenReturns = make([]returndom.TokenReturn, len(ret.TokenReturns))
+		copy(out.TokenReturns, ret.TokenReturns)
+	}
+
+	if ret.ReturnShippingQuote != nil {
+		quote := *ret.ReturnShippingQuote
+		out.ReturnShippingQuote = &quote
+	}
+
+	return out
+}
+
+// ReturnImageRepositoryMem は orderReturn.ImageRepository の in-memory 実装。
+type ReturnImageRepositoryMem struct {
+	mu sync.Mutex
+
+	// returnID -> imageID -> image
+	images map[string]map[string]returndom.ReturnImage
+}
+
+var _ returndom.ImageRepository = (*ReturnImageRepositoryMem)(nil)
+
+func NewReturnImageRepositoryMem() *ReturnImageRepositoryMem {
+	return &ReturnImageRepositoryMem{
+		images: map[string]map[string]returndom.ReturnImage{},
+	}
+}
+
+func (r *ReturnImageRepositoryMem) ListByReturnID(
+	_ context.Context,
+	returnID string,
+) ([]returndom.ReturnImage, error) {
+	returnID = strings.TrimSpace(returnID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byID := r.images[returnID]
+	out := make([]returndom.ReturnImage, 0, len(byID))
+	for _, id := range sortedKeys(byID) {
+		out = append(out, cloneReturnImage(byID[id]))
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].DisplayOrder < out[j].DisplayOrder
+	})
+
+	return out, nil
+}
+
+func (r *ReturnImageRepositoryMem) Create(
+	_ context.Context,
+	img returndom.ReturnImage,
+) (returndom.ReturnImage, error) {
+	if err := img.Validate(); err != nil {
+		return returndom.ReturnImage{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byID, ok := r.images[img.ReturnID]
+	if !ok {
+		byID = map[string]returndom.ReturnImage{}
+		r.images[img.ReturnID] = byID
+	}
+
+	if _, exists := byID[img.ID]; exists {
+		return returndom.ReturnImage{}, returndom.ErrImageConflict
+	}
+
+	byID[img.ID] = cloneReturnImage(img)
+
+	return cloneReturnImage(img), nil
+}
+
+func (r *ReturnImageRepositoryMem) Delete(
+	_ context.Context,
+	returnID string,
+	imageID string,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	byID := r.images[strings.TrimSpace(returnID)]
+	imageID = strings.TrimSpace(imageID)
+
+	if _, ok := byID[imageID]; !ok {
+		return returndom.ErrImageNotFound
+	}
+
+	delete(byID, imageID)
+
+	return nil
+}
+
+func cloneReturnImage(img returndom.ReturnImage) returndom.ReturnImage {
+	out := img
+	out.UpdatedAt = cloneTimePtr(img.UpdatedAt)
+	out.UpdatedBy = cloneStringPtr(img.UpdatedBy)
+
+	return out
+}
@@ -0,0 +1,120 @@
+// backend/internal/adapters/out/memory/role_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	permission "narratives/internal/domain/permission"
+)
+
+// RoleRepositoryMem は permission.RoleRepository の in-memory 実装。
+// RoleRepositoryFS と同じく、組み込みロールは保存しない前提で使う。
+type RoleRepositoryMem struct {
+	mu    sync.Mutex
+	roles map[string]permission.Role
+}
+
+var _ permission.RoleRepository = (*RoleRepositoryMem)(nil)
+
+func NewRoleRepositoryMem() *RoleRepositoryMem {
+	return &RoleRepositoryMem{
+		roles: map[string]permission.Role{},
+	}
+}
+
+// ListByCompanyID は name asc の順で返す。
+func (r *RoleRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+) ([]permission.Role, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, permission.ErrInvalidRoleCompanyID
+	}
+
+	var out []permission.Role
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.roles) {
+		if role := r.roles[id]; role.CompanyID == companyID {
+			out = append(out, cloneRole(role))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out, nil
+}
+
+func (r *RoleRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (permission.Role, error) {
+	id = strings.TrimSpace(id)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	role, ok := r.roles[id]
+	if !ok {
+		return permission.Role{}, permission.ErrRoleNotFound
+	}
+
+	return cloneRole(role), nil
+}
+
+// Save は upsert する。既存ロールの CreatedAt は保持し、
+// 別の company のロールを上書きする場合は ErrConflict を返す。
+func (r *RoleRepositoryMem) Save(
+	_ context.Context,
+	role permission.Role,
+) (permission.Role, error) {
+	if err := role.Validate(); err != nil {
+		return permission.Role{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, exists := r.roles[role.ID]; exists {
+		if current.CompanyID != role.CompanyID {
+			return permission.Role{}, permission.ErrConflict
+		}
+		if !current.CreatedAt.IsZero() {
+			role.CreatedAt = current.CreatedAt
+		}
+	}
+
+	r.roles[role.ID] = cloneRole(role)
+
+	return cloneRole(role), nil
+}
+
+func (r *RoleRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	id = strings.TrimSpace(id)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.roles[id]; !ok {
+		return permission.ErrRoleNotFound
+	}
+
+	delete(r.roles, id)
+
+	return nil
+}
+
+func cloneRole(role permission.Role) permission.Role {
+	role.Permissions = cloneStrings(role.Permissions)
+	return role
+}
@@ -0,0 +1,81 @@
+// backend/internal/adapters/out/memory/role_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	permission "narratives/internal/domain/permission"
+)
+
+func TestRoleRepositoryMem_Save(t *testing.T) {
+	created := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	later := created.Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		role          permission.Role
+		wantErr       error
+		wantCreatedAt time.Time
+	}{
+		{
+			name: "update keeps createdAt",
+			role: permission.Role{
+				ID:          "role_1",
+				CompanyID:   "company_1",
+				Name:        "renamed",
+				Permissions: []string{permission.NameOrderDispatch},
+				CreatedAt:   later,
+				UpdatedAt:   later,
+			},
+			wantCreatedAt: created,
+		},
+		{
+			name: "role of another company",
+			role: permission.Role{
+				ID:          "role_1",
+				CompanyID:   "company_2",
+				Name:        "stolen",
+				Permissions: []string{permission.NameOrderDispatch},
+				CreatedAt:   later,
+				UpdatedAt:   later,
+			},
+			wantErr:       permission.ErrConflict,
+			wantCreatedAt: created,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewRoleRepositoryMem()
+
+			if _, err := repo.Save(ctx, permission.Role{
+				ID:          "role_1",
+				CompanyID:   "company_1",
+				Name:        "staff",
+				Permissions: []string{permission.NameOrderDispatch},
+				CreatedAt:   created,
+				UpdatedAt:   created,
+			}); err != nil {
+				t.Fatalf("seed role: %v", err)
+			}
+
+			_, err := repo.Save(ctx, tt.role)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repo.GetByID(ctx, "role_1")
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if stored.CompanyID != "company_1" || !stored.CreatedAt.Equal(tt.wantCreatedAt) {
+				t.Fatalf("stored role = %+v", stored)
+			}
+		})
+	}
+}
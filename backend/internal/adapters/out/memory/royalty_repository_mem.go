@@ -0,0 +1,89 @@
+// backend/internal/adapters/out/memory/royalty_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	royaltydom "narratives/internal/domain/royalty"
+)
+
+// RoyaltyRepositoryMem は royalty.RepositoryPort の in-memory 実装。
+// Firestore と同じく ID（{orderId}_{itemIndex}）ごとに 1 件だけ計上する。
+type RoyaltyRepositoryMem struct {
+	mu      sync.Mutex
+	entries map[string]royaltydom.Entry
+}
+
+var _ royaltydom.RepositoryPort = (*RoyaltyRepositoryMem)(nil)
+
+func NewRoyaltyRepositoryMem() *RoyaltyRepositoryMem {
+	return &RoyaltyRepositoryMem{
+		entries: map[string]royaltydom.Entry{},
+	}
+}
+
+func (r *RoyaltyRepositoryMem) Create(
+	_ context.Context,
+	e royaltydom.Entry,
+) (royaltydom.Entry, error) {
+	if err := e.Validate(); err != nil {
+		return royaltydom.Entry{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.entries[e.ID]; exists {
+		return royaltydom.Entry{}, royaltydom.ErrConflict
+	}
+
+	r.entries[e.ID] = e
+
+	return e, nil
+}
+
+// ListByCompanyID は accruedAt desc の順で返す。
+func (r *RoyaltyRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+	filter royaltydom.ListFilter,
+) ([]royaltydom.Entry, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, royaltydom.ErrInvalidCompanyID
+	}
+
+	brandID := strings.TrimSpace(filter.BrandID)
+
+	out := make([]royaltydom.Entry, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.entries) {
+		e := r.entries[id]
+
+		if e.CompanyID != companyID {
+			continue
+		}
+		if brandID != "" && e.BrandID != brandID {
+			continue
+		}
+		if filter.From != nil && e.AccruedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !e.AccruedAt.Before(*filter.To) {
+			continue
+		}
+
+		out = append(out, e)
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].AccruedAt.After(out[j].AccruedAt)
+	})
+
+	return out, nil
+}
@@ -0,0 +1,77 @@
+// backend/internal/adapters/out/memory/royalty_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	royaltydom "narratives/internal/domain/royalty"
+)
+
+func TestRoyaltyRepositoryMem_CreateAndList(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRoyaltyRepositoryMem()
+	base := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	entry := func(orderID string, brandID string, accruedAt time.Time) royaltydom.Entry {
+		e, err := royaltydom.NewEntry(royaltydom.NewEntryInput{
+			CompanyID:        "company_1",
+			BrandID:          brandID,
+			TokenBlueprintID: "tb_1",
+			OrderID:          orderID,
+			ResaleID:         "resale_1",
+			SaleAmount:       1000,
+			Amount:           100,
+			AccruedAt:        accruedAt,
+		})
+		if err != nil {
+			t.Fatalf("NewEntry: %v", err)
+		}
+		return e
+	}
+
+	for _, e := range []royaltydom.Entry{
+		entry("order_1", "brand_1", base),
+		entry("order_2", "brand_1", base.Add(time.Hour)),
+		entry("order_3", "brand_2", base.Add(2*time.Hour)),
+	} {
+		if _, err := repo.Create(ctx, e); err != nil {
+			t.Fatalf("seed %s: %v", e.ID, err)
+		}
+	}
+
+	if _, err := repo.Create(ctx, entry("order_1", "brand_2", base)); !errors.Is(err, royaltydom.ErrConflict) {
+		t.Fatalf("duplicate Create err = %v, want ErrConflict", err)
+	}
+
+	from := base.Add(time.Hour)
+	tests := []struct {
+		name   string
+		filter royaltydom.ListFilter
+		want   []string
+	}{
+		{name: "newest first", filter: royaltydom.ListFilter{}, want: []string{"order_3_0", "order_2_0", "order_1_0"}},
+		{name: "brand", filter: royaltydom.ListFilter{BrandID: "brand_1"}, want: []string{"order_2_0", "order_1_0"}},
+		{name: "from", filter: royaltydom.ListFilter{From: &from}, want: []string{"order_3_0", "order_2_0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListByCompanyID(ctx, "company_1", tt.filter)
+			if err != nil {
+				t.Fatalf("ListByCompanyID: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("len = %d, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i].ID != tt.want[i] {
+					t.Fatalf("got[%d] = %q, want %q", i, got[i].ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
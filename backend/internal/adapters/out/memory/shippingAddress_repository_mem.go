@@ -0,0 +1,286 @@
+// backend/internal/adapters/out/memory/shippingAddress_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	shipaddrdom "narratives/internal/domain/shippingAddress"
+)
+
+// ShippingAddressRepositoryMem は shippingAddress.RepositoryPort の in-memory 実装。
+// ID は ShippingAddressRepositoryFS と同じく UUID 形式のみ受け付ける。
+type ShippingAddressRepositoryMem struct {
+	mu        sync.Mutex
+	addresses map[string]shipaddrdom.ShippingAddress
+}
+
+var _ shipaddrdom.RepositoryPort = (*ShippingAddressRepositoryMem)(nil)
+
+func NewShippingAddressRepositoryMem() *ShippingAddressRepositoryMem {
+	return &ShippingAddressRepositoryMem{
+		addresses: map[string]shipaddrdom.ShippingAddress{},
+	}
+}
+
+func (r *ShippingAddressRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (*shipaddrdom.ShippingAddress, error) {
+	if err := validateShippingAddressMemID(id); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	a, ok := r.addresses[id]
+	if !ok {
+		return nil, shipaddrdom.ErrNotFound
+	}
+
+	return &a, nil
+}
+
+// GetByUser は、対象が存在しない場合と userID の所有物でない場合のいずれも ErrNotFound を返す。
+func (r *ShippingAddressRepositoryMem) GetByUser(
+	ctx context.Context,
+	id string,
+	userID string,
+) (*shipaddrdom.ShippingAddress, error) {
+	if userID == "" || len([]rune(userID)) > shipaddrdom.MaxUserIDLength {
+		return nil, shipaddrdom.ErrInvalidUserID
+	}
+
+	a, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.UserID != userID {
+		return nil, shipaddrdom.ErrNotFound
+	}
+
+	return a, nil
+}
+
+// GetByCompany は、対象が存在しない場合と companyID に所属しない場合のいずれも ErrNotFound を返す。
+func (r *ShippingAddressRepositoryMem) GetByCompany(
+	ctx context.Context,
+	id string,
+	companyID string,
+) (*shipaddrdom.ShippingAddress, error) {
+	if companyID == "" || len([]rune(companyID)) > shipaddrdom.MaxCompanyIDLength {
+		return nil, shipaddrdom.ErrInvalidCompanyID
+	}
+
+	a, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.CompanyID != companyID {
+		return nil, shipaddrdom.ErrNotFound
+	}
+
+	return a, nil
+}
+
+func (r *ShippingAddressRepositoryMem) Exists(
+	_ context.Context,
+	id string,
+) (bool, error) {
+	if err := validateShippingAddressMemID(id); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.addresses[id]
+	return ok, nil
+}
+
+// ListByUserID は updatedAt の降順で返す。
+func (r *ShippingAddressRepositoryMem) ListByUserID(
+	_ context.Context,
+	userID string,
+) ([]shipaddrdom.ShippingAddress, error) {
+	if userID == "" || len([]rune(userID)) > shipaddrdom.MaxUserIDLength {
+		return nil, shipaddrdom.ErrInvalidUserID
+	}
+
+	return r.listBy(func(a shipaddrdom.ShippingAddress) bool {
+		return a.UserID == userID
+	}), nil
+}
+
+// ListByCompanyID は updatedAt の降順で返す。
+func (r *ShippingAddressRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+) ([]shipaddrdom.ShippingAddress, error) {
+	if companyID == "" || len([]rune(companyID)) > shipaddrdom.MaxCompanyIDLength {
+		return nil, shipaddrdom.ErrInvalidCompanyID
+	}
+
+	return r.listBy(func(a shipaddrdom.ShippingAddress) bool {
+		return a.CompanyID == companyID
+	}), nil
+}
+
+// Create は同一 ID が存在する場合 ErrConflict を返し、上書きしない。
+func (r *ShippingAddressRepositoryMem) Create(
+	_ context.Context,
+	value shipaddrdom.ShippingAddress,
+) (*shipaddrdom.ShippingAddress, error) {
+	if err := validateShippingAddressMemID(value.ID); err != nil {
+		return nil, err
+	}
+
+	validated, err := shipaddrdom.NewWithAudit(
+		value.ID,
+		value.UserID,
+		value.CompanyID,
+		value.Name,
+		value.ZipCode,
+		value.State,
+		value.City,
+		value.Street,
+		value.Street2,
+		value.Country,
+		value.CreatedAt,
+		value.CreatedBy,
+		value.UpdatedAt,
+		value.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.addresses[validated.ID]; exists {
+		return nil, shipaddrdom.ErrConflict
+	}
+
+	r.addresses[validated.ID] = validated
+
+	return &validated, nil
+}
+
+// Update は ShippingAddressRepositoryFS と同様に、所有者・作成監査 field の変更を拒否し、
+// 住所 field と updatedAt / updatedBy だけを更新する。存在しない場合は作成しない。
+func (r *ShippingAddressRepositoryMem) Update(
+	_ context.Context,
+	value shipaddrdom.ShippingAddress,
+) (*shipaddrdom.ShippingAddress, error) {
+	if err := validateShippingAddressMemID(value.ID); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.addresses[value.ID]
+	if !ok {
+		return nil, shipaddrdom.ErrNotFound
+	}
+
+	if value.UserID != current.UserID {
+		return nil, shipaddrdom.ErrInvalidUserID
+	}
+	if value.CompanyID != current.CompanyID {
+		return nil, shipaddrdom.ErrInvalidCompanyID
+	}
+	if !value.CreatedAt.Equal(current.CreatedAt) {
+		return nil, shipaddrdom.ErrInvalidCreatedAt
+	}
+	if value.CreatedBy != current.CreatedBy {
+		return nil, shipaddrdom.ErrInvalidCreatedBy
+	}
+
+	updatedBy := value.UpdatedBy
+	if updatedBy == "" {
+		updatedBy = current.UpdatedBy
+	}
+
+	next, err := shipaddrdom.NewWithAudit(
+		current.ID,
+		current.UserID,
+		current.CompanyID,
+		value.Name,
+		value.ZipCode,
+		value.State,
+		value.City,
+		value.Street,
+		value.Street2,
+		value.Country,
+		current.CreatedAt,
+		current.CreatedBy,
+		value.UpdatedAt,
+		updatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.addresses[next.ID] = next
+
+	return &next, nil
+}
+
+func (r *ShippingAddressRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if err := validateShippingAddressMemID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.addresses[id]; !ok {
+		return shipaddrdom.ErrNotFound
+	}
+
+	delete(r.addresses, id)
+
+	return nil
+}
+
+func (r *ShippingAddressRepositoryMem) listBy(
+	match func(a shipaddrdom.ShippingAddress) bool,
+) []shipaddrdom.ShippingAddress {
+	r.mu.Lock()
+	out := make([]shipaddrdom.ShippingAddress, 0)
+	for _, a := range r.addresses {
+		if match(a) {
+			out = append(out, a)
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].UpdatedAt.Equal(out[j].UpdatedAt) {
+			return out[i].UpdatedAt.After(out[j].UpdatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+
+	return out
+}
+
+func validateShippingAddressMemID(id string) error {
+	if id == "" {
+		return shipaddrdom.ErrInvalidID
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return shipaddrdom.ErrInvalidID
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+// backend/internal/adapters/out/memory/stock_alert_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	invdom "narratives/internal/domain/inventory"
+)
+
+// StockAlertSettingRepositoryMem は inventory.StockAlertSettingRepository の in-memory 実装。
+type StockAlertSettingRepositoryMem struct {
+	mu       sync.Mutex
+	settings map[string]invdom.StockAlertSetting
+}
+
+var _ invdom.StockAlertSettingRepository = (*StockAlertSettingRepositoryMem)(nil)
+
+func NewStockAlertSettingRepositoryMem() *StockAlertSettingRepositoryMem {
+	return &StockAlertSettingRepositoryMem{
+		settings: map[string]invdom.StockAlertSetting{},
+	}
+}
+
+func (r *StockAlertSettingRepositoryMem) GetByInventoryID(
+	_ context.Context,
+	inventoryID string,
+) (invdom.StockAlertSetting, error) {
+	inventoryID = strings.TrimSpace(inventoryID)
+	if inventoryID == "" {
+		return invdom.StockAlertSetting{}, invdom.ErrInvalidMintID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	setting, ok := r.settings[inventoryID]
+	if !ok {
+		return invdom.StockAlertSetting{}, invdom.ErrNotFound
+	}
+
+	return cloneStockAlertSetting(setting), nil
+}
+
+func (r *StockAlertSettingRepositoryMem) Save(
+	_ context.Context,
+	setting invdom.StockAlertSetting,
+) (invdom.StockAlertSetting, error) {
+	if err := setting.Validate(); err != nil {
+		return invdom.StockAlertSetting{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.settings[setting.InventoryID] = cloneStockAlertSetting(setting)
+
+	return cloneStockAlertSetting(setting), nil
+}
+
+// StockAlertRepositoryMem は inventory.StockAlertRepository の in-memory 実装。
+type StockAlertRepositoryMem struct {
+	mu     sync.Mutex
+	alerts map[string]invdom.StockAlert
+
+	// inventoryId__modelId -> open alertId
+	openAlertIDs map[string]string
+}
+
+var _ invdom.StockAlertRepository = (*StockAlertRepositoryMem)(nil)
+
+func NewStockAlertRepositoryMem() *StockAlertRepositoryMem {
+	return &StockAlertRepositoryMem{
+		alerts:       map[string]invdom.StockAlert{},
+		openAlertIDs: map[string]string{},
+	}
+}
+
+func (r *StockAlertRepositoryMem) OpenIfAbsent(
+	_ context.Context,
+	alert invdom.StockAlert,
+) (invdom.StockAlert, bool, error) {
+	if err := alert.Validate(); err != nil {
+		return invdom.StockAlert{}, false, err
+	}
+	if alert.Status != invdom.StockAlertStatusOpen {
+		return invdom.StockAlert{}, false, invdom.ErrInvalidStockAlertStatus
+	}
+
+	key := stockAlertOpenKeyMem(alert.InventoryID, alert.ModelID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if alertID, ok := r.openAlertIDs[key]; ok {
+		if existing, ok := r.alerts[alertID]; ok {
+			return cloneStockAlert(existing), false, nil
+		}
+	}
+
+	r.alerts[alert.ID] = cloneStockAlert(alert)
+	r.openAlertIDs[key] = alert.ID
+
+	return cloneStockAlert(alert), true, nil
+}
+
+func (r *StockAlertRepositoryMem) ResolveOpen(
+	_ context.Context,
+	inventoryID string,
+	modelID string,
+	available int,
+	now time.Time,
+) (invdom.StockAlert, error) {
+	key := stockAlertOpenKeyMem(
+		strings.TrimSpace(inventoryID),
+		strings.TrimSpace(modelID),
+	)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alertID, ok := r.openAlertIDs[key]
+	if !ok {
+		return invdom.StockAlert{}, invdom.ErrNotFound
+	}
+	delete(r.openAlertIDs, key)
+
+	alert, ok := r.alerts[alertID]
+	if !ok {
+		return invdom.StockAlert{}, nil
+	}
+
+	alert.Resolve(available, now)
+	r.alerts[alertID] = alert
+
+	return cloneStockAlert(alert), nil
+}
+
+func (r *StockAlertRepositoryMem) RecordNotification(
+	_ context.Context,
+	alertID string,
+	notifiedEmails []string,
+	notificationError string,
+	now time.Time,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alert, ok := r.alerts[strings.TrimSpace(alertID)]
+	if !ok {
+		return invdom.ErrNotFound
+	}
+
+	at := now.UTC()
+	alert.NotifiedEmails = cloneStrings(notifiedEmails)
+	alert.NotifiedAt = &at
+	alert.NotificationError = notificationError
+	r.alerts[alert.ID] = alert
+
+	return nil
+}
+
+func (r *StockAlertRepositoryMem) ListByInventoryID(
+	_ context.Context,
+	inventoryID string,
+	limit int,
+) ([]invdom.StockAlert, error) {
+	inventoryID = strings.TrimSpace(inventoryID)
+	if inventoryID == "" {
+		return nil, invdom.ErrInvalidMintID
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	out := r.filter(func(alert invdom.StockAlert) bool {
+		return alert.InventoryID == inventoryID
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+func (r *StockAlertRepositoryMem) ListOpenByCompanyID(
+	_ context.Context,
+	companyID string,
+) ([]invdom.StockAlert, error) {
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, invdom.ErrInvalidStockAlertCompanyID
+	}
+
+	return r.filter(func(alert invdom.StockAlert) bool {
+		return alert.CompanyID == companyID &&
+			alert.Status == invdom.StockAlertStatusOpen
+	}), nil
+}
+
+func (r *StockAlertRepositoryMem) filter(
+	match func(invdom.StockAlert) bool,
+) []invdom.StockAlert {
+	r.mu.Lock()
+	out := make([]invdom.StockAlert, 0)
+	for _, alert := range r.alerts {
+		if match(alert) {
+			out = append(out, cloneStockAlert(alert))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].FiredAt.Equal(out[j].FiredAt) {
+			return out[i].ID > out[j].ID
+		}
+		return out[i].FiredAt.After(out[j].FiredAt)
+	})
+
+	return out
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func stockAlertOpenKeyMem(inventoryID string, modelID string) string {
+	return inventoryID + "__" + modelID
+}
+
+func cloneStockAlertSetting(s invdom.StockAlertSetting) invdom.StockAlertSetting {
+	if s.DefaultThreshold != nil {
+		v := *s.DefaultThreshold
+		s.DefaultThreshold = &v
+	}
+	s.ModelThresholds = cloneIntMap(s.ModelThresholds)
+	s.NotifyEmails = cloneStrings(s.NotifyEmails)
+
+	return s
+}
+
+func cloneStockAlert(a invdom.StockAlert) invdom.StockAlert {
+	a.ResolvedAt = cloneTimePtr(a.ResolvedAt)
+	a.NotifiedAt = cloneTimePtr(a.NotifiedAt)
+	a.NotifiedEmails = cloneStrings(a.NotifiedEmails)
+	if a.ResolvedAvailable != nil {
+		v := *a.ResolvedAvailable
+		a.ResolvedAvailable = &v
+	}
+
+	return a
+}
@@ -0,0 +1,153 @@
+// backend/internal/adapters/out/memory/stripe_event_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	stripeeventdom "narratives/internal/domain/stripeEvent"
+)
+
+// StripeEventRepositoryMem は stripeEvent.RepositoryPort の in-memory 実装。
+//
+// Firestore adapter と同じく、Save は処理結果の field だけを更新し、
+// 受信時の Type / Payload / 日時は変更しない。
+type StripeEventRepositoryMem struct {
+	mu     sync.Mutex
+	events map[string]stripeeventdom.Event
+}
+
+var _ stripeeventdom.RepositoryPort = (*StripeEventRepositoryMem)(nil)
+
+func NewStripeEventRepositoryMem() *StripeEventRepositoryMem {
+	return &StripeEventRepositoryMem{
+		events: map[string]stripeeventdom.Event{},
+	}
+}
+
+func (r *StripeEventRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (stripeeventdom.Event, error) {
+	id = strings.TrimSpace(id)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return stripeeventdom.Event{}, stripeeventdom.ErrNotFound
+	}
+
+	return cloneStripeEvent(e), nil
+}
+
+func (r *StripeEventRepositoryMem) Record(
+	_ context.Context,
+	e stripeeventdom.Event,
+) (stripeeventdom.Event, bool, error) {
+	if err := e.Validate(); err != nil {
+		return stripeeventdom.Event{}, false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, exists := r.events[e.ID]; exists {
+		return cloneStripeEvent(stored), false, nil
+	}
+
+	r.events[e.ID] = cloneStripeEvent(e)
+
+	return cloneStripeEvent(e), true, nil
+}
+
+func (r *StripeEventRepositoryMem) Save(
+	_ context.Context,
+	e stripeeventdom.Event,
+) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.events[e.ID]
+	if !ok {
+		return stripeeventdom.ErrNotFound
+	}
+
+	current.Status = e.Status
+	current.Attempts = e.Attempts
+	current.LastError = e.LastError
+	current.UpdatedAt = e.UpdatedAt.UTC()
+
+	if e.ProcessedAt != nil {
+		current.ProcessedAt = cloneTimePtr(e.ProcessedAt)
+	}
+	if e.LastReplayedAt != nil {
+		current.LastReplayedAt = cloneTimePtr(e.LastReplayedAt)
+		current.LastReplayedBy = e.LastReplayedBy
+	}
+
+	r.events[e.ID] = current
+
+	return nil
+}
+
+// List は receivedAt desc の順で返す。
+func (r *StripeEventRepositoryMem) List(
+	_ context.Context,
+	filter stripeeventdom.ListFilter,
+) ([]stripeeventdom.Event, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = stripeeventdom.DefaultListLimit
+	}
+	if limit > stripeeventdom.MaxListLimit {
+		limit = stripeeventdom.MaxListLimit
+	}
+
+	eventType := strings.TrimSpace(filter.Type)
+
+	out := make([]stripeeventdom.Event, 0)
+
+	r.mu.Lock()
+	for _, id := range sortedKeys(r.events) {
+		e := r.events[id]
+		if eventType != "" && e.Type != eventType {
+			continue
+		}
+		if filter.Status != "" && e.Status != filter.Status {
+			continue
+		}
+		out = append(out, cloneStripeEvent(e))
+	}
+	r.mu.Unlock()
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ReceivedAt.After(out[j].ReceivedAt)
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+
+	return out, nil
+}
+
+func cloneStripeEvent(e stripeeventdom.Event) stripeeventdom.Event {
+	out := e
+
+	if e.Payload != nil {
+		out.Payload = make([]byte, len(e.Payload))
+		copy(out.Payload, e.Payload)
+	}
+	out.ProcessedAt = cloneTimePtr(e.ProcessedAt)
+	out.LastReplayedAt = cloneTimePtr(e.LastReplayedAt)
+
+	return out
+}
@@ -0,0 +1,92 @@
+// backend/internal/adapters/out/memory/stripe_event_repository_mem_test.go
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	stripeeventdom "narratives/internal/domain/stripeEvent"
+)
+
+func TestStripeEventRepositoryMem_RecordAndSave(t *testing.T) {
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id":"evt_1"}`)
+
+	tests := []struct {
+		name       string
+		run        func(ctx context.Context, repo *memory.StripeEventRepositoryMem, e stripeeventdom.Event) error
+		wantErr    error
+		wantStatus stripeeventdom.Status
+	}{
+		{
+			name: "record of a redelivered event keeps the stored one",
+			run: func(ctx context.Context, repo *memory.StripeEventRepositoryMem, e stripeeventdom.Event) error {
+				e.Type = "changed"
+				stored, created, err := repo.Record(ctx, e)
+				if err != nil {
+					return err
+				}
+				if created || stored.Type != "payment_intent.succeeded" {
+					t.Fatalf("Record = (%+v, %v), want stored event", stored, created)
+				}
+				return nil
+			},
+			wantStatus: stripeeventdom.StatusReceived,
+		},
+		{
+			name: "save updates only the processing result",
+			run: func(ctx context.Context, repo *memory.StripeEventRepositoryMem, e stripeeventdom.Event) error {
+				e.MarkProcessed(now.Add(time.Minute))
+				e.Payload = []byte(`{"id":"changed"}`)
+				return repo.Save(ctx, e)
+			},
+			wantStatus: stripeeventdom.StatusProcessed,
+		},
+		{
+			name: "save does not create",
+			run: func(ctx context.Context, repo *memory.StripeEventRepositoryMem, e stripeeventdom.Event) error {
+				e.ID = "evt_missing"
+				return repo.Save(ctx, e)
+			},
+			wantErr:    stripeeventdom.ErrNotFound,
+			wantStatus: stripeeventdom.StatusReceived,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := memory.NewStripeEventRepositoryMem()
+
+			e, err := stripeeventdom.NewReceived("evt_1", "payment_intent.succeeded", payload, now, now)
+			if err != nil {
+				t.Fatalf("NewReceived: %v", err)
+			}
+			if _, created, err := repo.Record(ctx, e); err != nil || !created {
+				t.Fatalf("Record = (%v, %v), want created", created, err)
+			}
+
+			err = tt.run(ctx, repo, e)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := repo.GetByID(ctx, "evt_1")
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Fatalf("status = %q, want %q", stored.Status, tt.wantStatus)
+			}
+			if string(stored.Payload) != string(payload) {
+				t.Fatalf("payload = %s, want %s", stored.Payload, payload)
+			}
+			if _, err := repo.GetByID(ctx, "evt_missing"); !errors.Is(err, stripeeventdom.ErrNotFound) {
+				t.Fatalf("GetByID(evt_missing) err = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+// backend/internal/adapters/out/memory/tokenBlueprint_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	fscommon "narratives/internal/adapters/out/firestore/common"
+	common "narratives/internal/domain/common"
+	tbdom "narratives/internal/domain/tokenBlueprint"
+)
+
+// TokenBlueprintRepositoryMem は tokenBlueprint.RepositoryPort の in-memory 実装。
+type TokenBlueprintRepositoryMem struct {
+	mu  sync.Mutex
+	ids idSequence
+
+	blueprints map[string]tbdom.TokenBlueprint
+
+	now func() time.Time
+}
+
+var _ tbdom.RepositoryPort = (*TokenBlueprintRepositoryMem)(nil)
+
+func NewTokenBlueprintRepositoryMem() *TokenBlueprintRepositoryMem {
+	return &TokenBlueprintRepositoryMem{
+		blueprints: map[string]tbdom.TokenBlueprint{},
+		now:        time.Now,
+	}
+}
+
+func (r *TokenBlueprintRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (*tbdom.TokenBlueprint, error) {
+	if id == "" {
+		return nil, tbdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tb, ok := r.blueprints[id]
+	if !ok {
+		return nil, tbdom.ErrNotFound
+	}
+
+	out := cloneTokenBlueprint(tb)
+	return &out, nil
+}
+
+func (r *TokenBlueprintRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+	page common.Page,
+) (common.PageResult[tbdom.TokenBlueprint], error) {
+	return r.list(page, companyID == "", func(tb tbdom.TokenBlueprint) bool {
+		return tb.CompanyID == companyID
+	}), nil
+}
+
+func (r *TokenBlueprintRepositoryMem) ListByBrandID(
+	_ context.Context,
+	brandID string,
+	page common.Page,
+) (common.PageResult[tbdom.TokenBlueprint], error) {
+	return r.list(page, brandID == "", func(tb tbdom.TokenBlueprint) bool {
+		return tb.BrandID == brandID
+	}), nil
+}
+
+func (r *TokenBlueprintRepositoryMem) Create(
+	_ context.Context,
+	in tbdom.CreateTokenBlueprintInput,
+) (*tbdom.TokenBlueprint, error) {
+	if in.CreatedBy == "" {
+		return nil, tbdom.ErrInvalidCreatedBy
+	}
+	if in.UpdatedBy == "" {
+		return nil, tbdom.ErrInvalidUpdatedBy
+	}
+	if err := tbdom.ValidateContentFiles(in.ContentFiles); err != nil {
+		return nil, err
+	}
+
+	now := r.now().UTC()
+	createdAt := now
+	if in.CreatedAt != nil {
+		if in.CreatedAt.IsZero() {
+			return nil, tbdom.ErrInvalidCreatedAt
+		}
+		createdAt = in.CreatedAt.UTC()
+	}
+
+	updatedAt := now
+	if in.UpdatedAt != nil {
+		if in.UpdatedAt.IsZero() {
+			return nil, tbdom.ErrInvalidUpdatedAt
+		}
+		updatedAt = in.UpdatedAt.UTC()
+	}
+
+	tb := tbdom.TokenBlueprint{
+		ID:              r.ids.newID("tokenBlueprint"),
+		Name:            in.Name,
+		Symbol:          in.Symbol,
+		BrandID:         in.BrandID,
+		CompanyID:       in.CompanyID,
+		Description:     in.Description,
+		IconURL:         in.IconURL,
+		IconObjectPath:  in.IconObjectPath,
+		IconFileName:    in.IconFileName,
+		IconContentType: in.IconContentType,
+		IconSize:        in.IconSize,
+		ContentFiles:    cloneContentFiles(in.ContentFiles),
+		AssigneeID:      in.AssigneeID,
+		Minted:          false,
+		CreatedAt:       createdAt,
+		CreatedBy:       in.CreatedBy,
+		UpdatedAt:       updatedAt,
+		UpdatedBy:       in.UpdatedBy,
+		MetadataURI:     in.MetadataURI,
+		Royalty:         in.Royalty,
+	}
+	if err := validatePersistedTokenBlueprint(tb); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.blueprints[tb.ID]; exists {
+		return nil, tbdom.ErrConflict
+	}
+
+	r.blueprints[tb.ID] = cloneTokenBlueprint(tb)
+
+	out := cloneTokenBlueprint(tb)
+	return &out, nil
+}
+
+// Update は TokenBlueprintRepositoryFS と同じく UpdatedAt / UpdatedBy を必須とし、
+// royalty は更新前の minted で変更可否を判定する。
+func (r *TokenBlueprintRepositoryMem) Update(
+	_ context.Context,
+	id string,
+	in tbdom.UpdateTokenBlueprintInput,
+) (*tbdom.TokenBlueprint, error) {
+	if id == "" {
+		return nil, tbdom.ErrInvalidID
+	}
+	if in.UpdatedAt == nil || in.UpdatedAt.IsZero() {
+		return nil, tbdom.ErrInvalidUpdatedAt
+	}
+	if in.UpdatedBy == nil || *in.UpdatedBy == "" {
+		return nil, tbdom.ErrInvalidUpdatedBy
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.blueprints[id]
+	if !ok {
+		return nil, tbdom.ErrNotFound
+	}
+
+	candidate := cloneTokenBlueprint(current)
+
+	setString := func(dst *string, v *string) {
+		if v != nil {
+			*dst = *v
+		}
+	}
+
+	setString(&candidate.Name, in.Name)
+	setString(&candidate.Symbol, in.Symbol)
+	setString(&candidate.BrandID, in.BrandID)
+	setString(&candidate.Description, in.Description)
+	setString(&candidate.IconURL, in.IconURL)
+	setString(&candidate.IconObjectPath, in.IconObjectPath)
+	setString(&candidate.IconFileName, in.IconFileName)
+	setString(&candidate.IconContentType, in.IconContentType)
+	setString(&candidate.AssigneeID, in.AssigneeID)
+	setString(&candidate.MetadataURI, in.MetadataURI)
+
+	if in.IconSize != nil {
+		candidate.IconSize = *in.IconSize
+	}
+	if in.ContentFiles != nil {
+		if err := tbdom.ValidateContentFiles(*in.ContentFiles); err != nil {
+			return nil, err
+		}
+		candidate.ContentFiles = cloneContentFiles(*in.ContentFiles)
+	}
+	if in.Royalty != nil {
+		if err := candidate.SetRoyalty(*in.Royalty); err != nil {
+			return nil, err
+		}
+	}
+	if in.Minted != nil {
+		candidate.Minted = *in.Minted
+	}
+
+	candidate.UpdatedAt = in.UpdatedAt.UTC()
+	candidate.UpdatedBy = *in.UpdatedBy
+	if err := validatePersistedTokenBlueprint(candidate); err != nil {
+		return nil, err
+	}
+
+	r.blueprints[id] = cloneTokenBlueprint(candidate)
+
+	out := cloneTokenBlueprint(candidate)
+	return &out, nil
+}
+
+func (r *TokenBlueprintRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if id == "" {
+		return tbdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.blueprints[id]; !ok {
+		return tbdom.ErrNotFound
+	}
+
+	delete(r.blueprints, id)
+
+	return nil
+}
+
+func (r *TokenBlueprintRepositoryMem) IsSymbolUnique(
+	_ context.Context,
+	symbol string,
+	excludeID string,
+) (bool, error) {
+	if symbol == "" {
+		return false, tbdom.ErrInvalidSymbol
+	}
+
+	return r.isUnique(excludeID, func(tb tbdom.TokenBlueprint) bool {
+		return tb.Symbol == symbol
+	}), nil
+}
+
+func (r *TokenBlueprintRepositoryMem) IsNameUnique(
+	_ context.Context,
+	name string,
+	excludeID string,
+) (bool, error) {
+	if name == "" {
+		return false, tbdom.ErrInvalidName
+	}
+
+	return r.isUnique(excludeID, func(tb tbdom.TokenBlueprint) bool {
+		return tb.Name == name
+	}), nil
+}
+
+// list は createdAt desc, id desc の順で返す。
+// Firestore adapter と同じく TotalCount / TotalPages は返さない。
+func (r *TokenBlueprintRepositoryMem) list(
+	page common.Page,
+	empty bool,
+	match func(tb tbdom.TokenBlueprint) bool,
+) common.PageResult[tbdom.TokenBlueprint] {
+	pageNum, perPage, offset := fscommon.NormalizePage(
+		page.Number,
+		page.PerPage,
+		50,
+		200,
+	)
+
+	if empty {
+		return common.PageResult[tbdom.TokenBlueprint]{
+			Items:   []tbdom.TokenBlueprint{},
+			Page:    pageNum,
+			PerPage: perPage,
+		}
+	}
+
+	r.mu.Lock()
+	matched := make([]tbdom.TokenBlueprint, 0)
+	for _, tb := range r.blueprints {
+		if match(tb) {
+			matched = append(matched, cloneTokenBlueprint(tb))
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+
+	return common.PageResult[tbdom.TokenBlueprint]{
+		Items:   paginate(matched, offset, perPage),
+		Page:    pageNum,
+		PerPage: perPage,
+	}
+}
+
+func (r *TokenBlueprintRepositoryMem) isUnique(
+	excludeID string,
+	match func(tb tbdom.TokenBlueprint) bool,
+) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, tb := range r.blueprints {
+		if excludeID != "" && id == excludeID {
+			continue
+		}
+		if match(tb) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validatePersistedTokenBlueprint(tb tbdom.TokenBlueprint) error {
+	if _, err := tbdom.New(
+		tb.ID,
+		tb.Name,
+		tb.Symbol,
+		tb.BrandID,
+		tb.CompanyID,
+		tb.Description,
+		tb.ContentFiles,
+		tb.AssigneeID,
+		tb.CreatedAt,
+		tb.CreatedBy,
+		tb.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	if tb.UpdatedBy == "" {
+		return tbdom.ErrInvalidUpdatedBy
+	}
+	if tb.IconSize < 0 {
+		return tbdom.ErrInvalidIconSize
+	}
+	if err := tb.Royalty.Validate(); err != nil {
+		return err
+	}
+
+	hasAnyIconField := tb.IconURL != "" ||
+		tb.IconObjectPath != "" ||
+		tb.IconFileName != "" ||
+		tb.IconContentType != "" ||
+		tb.IconSize != 0
+	if hasAnyIconField {
+		if tb.IconURL == "" {
+			return tbdom.ErrInvalidIconURL
+		}
+		if tb.IconObjectPath == "" {
+			return tbdom.ErrInvalidIconObjectPath
+		}
+		if tb.IconFileName == "" {
+			return tbdom.ErrInvalidIconFileName
+		}
+	}
+
+	return nil
+}
+
+func cloneContentFiles(in []tbdom.ContentFile) []tbdom.ContentFile {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]tbdom.ContentFile, len(in))
+	copy(out, in)
+
+	return out
+}
+
+func cloneTokenBlueprint(tb tbdom.TokenBlueprint) tbdom.TokenBlueprint {
+	tb.ContentFiles = cloneContentFiles(tb.ContentFiles)
+	return tb
+}
@@ -0,0 +1,187 @@
+// backend/internal/adapters/out/memory/token_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	tokendom "narratives/internal/domain/token"
+)
+
+var ErrTokenConflict = errors.New(
+	"token_repository_mem: token already exists",
+)
+
+// TokenRepositoryMem は tokens/{productId} の in-memory 実装。
+//
+// token.TokenQueryPort と、transfer で使う usecase.TokenResolver /
+// usecase.TokenOwnerUpdater を同じ map で実装する。
+// Firestore では mint 成功時に token document が作られるため、
+// テストでは Create で mint 済みの token を登録する。
+type TokenRepositoryMem struct {
+	mu sync.Mutex
+
+	tokens map[string]tokendom.GetTokenByProductIDResult
+}
+
+var (
+	_ tokendom.TokenQueryPort   = (*TokenRepositoryMem)(nil)
+	_ usecase.TokenResolver     = (*TokenRepositoryMem)(nil)
+	_ usecase.TokenOwnerUpdater = (*TokenRepositoryMem)(nil)
+)
+
+func NewTokenRepositoryMem() *TokenRepositoryMem {
+	return &TokenRepositoryMem{
+		tokens: map[string]tokendom.GetTokenByProductIDResult{},
+	}
+}
+
+// Create は mint 済みの token を登録する。既存の productId は上書きしない。
+func (r *TokenRepositoryMem) Create(
+	_ context.Context,
+	t tokendom.GetTokenByProductIDResult,
+) error {
+	if t.ProductID == "" {
+		return tokendom.ErrInvalidProductID
+	}
+	if t.AssetID == "" {
+		return tokendom.ErrInvalidAssetID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tokens[t.ProductID]; exists {
+		return ErrTokenConflict
+	}
+	for _, existing := range r.tokens {
+		if existing.AssetID == t.AssetID {
+			return ErrTokenConflict
+		}
+	}
+
+	r.tokens[t.ProductID] = t
+
+	return nil
+}
+
+func (r *TokenRepositoryMem) GetTokenByProductID(
+	_ context.Context,
+	productID string,
+) (tokendom.GetTokenByProductIDResult, error) {
+	if productID == "" {
+		return tokendom.GetTokenByProductIDResult{}, tokendom.ErrInvalidProductID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tokens[productID]
+	if !ok {
+		return tokendom.GetTokenByProductIDResult{}, tokendom.ErrNotFound
+	}
+
+	return t, nil
+}
+
+func (r *TokenRepositoryMem) ResolveTokenByAssetID(
+	_ context.Context,
+	assetID string,
+) (tokendom.ResolveTokenByAssetIDResult, error) {
+	if assetID == "" {
+		return tokendom.ResolveTokenByAssetIDResult{}, tokendom.ErrInvalidAssetID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, productID := range sortedKeys(r.tokens) {
+		t := r.tokens[productID]
+		if t.AssetID == assetID {
+			return tokendom.ResolveTokenByAssetIDResult{
+				ProductID:   t.ProductID,
+				BrandID:     t.BrandID,
+				MetadataURI: t.MetadataURI,
+				AssetID:     t.AssetID,
+			}, nil
+		}
+	}
+
+	return tokendom.ResolveTokenByAssetIDResult{}, tokendom.ErrNotFound
+}
+
+// ListAssetIDsByTokenBlueprintID は productId 昇順で assetId を返す。
+func (r *TokenRepositoryMem) ListAssetIDsByTokenBlueprintID(
+	_ context.Context,
+	tokenBlueprintID string,
+) (tokendom.ListAssetIDsByTokenBlueprintIDResult, error) {
+	if tokenBlueprintID == "" {
+		return tokendom.ListAssetIDsByTokenBlueprintIDResult{}, tokendom.ErrInvalidTokenBlueprintID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	assetIDs := make([]string, 0)
+	for _, productID := range sortedKeys(r.tokens) {
+		t := r.tokens[productID]
+		if t.TokenBlueprintID == tokenBlueprintID {
+			assetIDs = append(assetIDs, t.AssetID)
+		}
+	}
+
+	return tokendom.ListAssetIDsByTokenBlueprintIDResult{
+		TokenBlueprintID: tokenBlueprintID,
+		AssetIDs:         assetIDs,
+	}, nil
+}
+
+func (r *TokenRepositoryMem) ResolveTokenByProductID(
+	ctx context.Context,
+	productID string,
+) (usecase.TokenForTransfer, error) {
+	t, err := r.GetTokenByProductID(ctx, productID)
+	if err != nil {
+		return usecase.TokenForTransfer{}, err
+	}
+
+	return usecase.TokenForTransfer{
+		ProductID:        t.ProductID,
+		BrandID:          t.BrandID,
+		AssetID:          t.AssetID,
+		TokenBlueprintID: t.TokenBlueprintID,
+	}, nil
+}
+
+// UpdateToAddressByProductID は Firestore の Update と同じく、存在しない token を作成しない。
+func (r *TokenRepositoryMem) UpdateToAddressByProductID(
+	_ context.Context,
+	productID string,
+	newToAddress string,
+	_ time.Time,
+	txSignature string,
+) error {
+	if productID == "" {
+		return tokendom.ErrInvalidProductID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tokens[productID]
+	if !ok {
+		return tokendom.ErrNotFound
+	}
+
+	t.ToAddress = newToAddress
+	if txSignature != "" {
+		t.OnChainTxSignature = txSignature
+	}
+
+	r.tokens[productID] = t
+
+	return nil
+}
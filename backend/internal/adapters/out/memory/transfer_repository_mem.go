@@ -0,0 +1,345 @@
+// backend/internal/adapters/out/memory/transfer_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	transferdom "narratives/internal/domain/transfer"
+)
+
+// transferRecord は Transfer と、Transfer 自体には持たせない transferredAt をまとめて保持する。
+type transferRecord struct {
+	Transfer      transferdom.Transfer
+	TransferredAt *time.Time
+}
+
+// TransferRepositoryMem は transfer.RepositoryPort の in-memory 実装。
+//
+// - CreateAttempt は operationId 単位で冪等（既存 attempt を返す）。
+// - Save / Patch は既存 attempt のみ更新し、存在しない attempt は作成しない。
+type TransferRepositoryMem struct {
+	mu sync.Mutex
+
+	// transfers は productID -> attempt -> record。
+	transfers map[string]map[int]transferRecord
+
+	// operations は operationID -> productID / attempt の mapping。
+	operations map[string]transferKey
+
+	Now func() time.Time
+}
+
+type transferKey struct {
+	ProductID string
+	Attempt   int
+}
+
+var _ transferdom.RepositoryPort = (*TransferRepositoryMem)(nil)
+
+func NewTransferRepositoryMem() *TransferRepositoryMem {
+	return &TransferRepositoryMem{
+		transfers:  map[string]map[int]transferRecord{},
+		operations: map[string]transferKey{},
+		Now:        time.Now,
+	}
+}
+
+func (r *TransferRepositoryMem) now() time.Time {
+	if r.Now != nil {
+		return r.Now().UTC()
+	}
+
+	return time.Now().UTC()
+}
+
+func (r *TransferRepositoryMem) GetLatestByProductID(
+	_ context.Context,
+	productID string,
+) (*transferdom.Transfer, error) {
+	if productID == "" {
+		return nil, transferdom.ErrInvalidProductID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts := r.transfers[productID]
+	latest := 0
+	for attempt := range attempts {
+		if attempt > latest {
+			latest = attempt
+		}
+	}
+
+	if latest == 0 {
+		return nil, transferdom.ErrNotFound
+	}
+
+	t := cloneTransfer(attempts[latest].Transfer)
+	return &t, nil
+}
+
+func (r *TransferRepositoryMem) GetByProductIDAndAttempt(
+	_ context.Context,
+	productID string,
+	attempt int,
+) (*transferdom.Transfer, error) {
+	if productID == "" {
+		return nil, transferdom.ErrInvalidProductID
+	}
+	if attempt <= 0 {
+		return nil, transferdom.ErrInvalidAttempt
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.transfers[productID][attempt]
+	if !ok {
+		return nil, transferdom.ErrNotFound
+	}
+
+	t := cloneTransfer(rec.Transfer)
+	return &t, nil
+}
+
+func (r *TransferRepositoryMem) GetByOperationID(
+	_ context.Context,
+	operationID string,
+) (*transferdom.Transfer, error) {
+	if operationID == "" {
+		return nil, transferdom.ErrInvalidOperationID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.operations[operationID]
+	if !ok {
+		return nil, transferdom.ErrNotFound
+	}
+
+	rec, ok := r.transfers[key.ProductID][key.Attempt]
+	if !ok {
+		return nil, transferdom.ErrNotFound
+	}
+
+	t := cloneTransfer(rec.Transfer)
+	return &t, nil
+}
+
+// ListByProductID は attempt 昇順で返す。該当がない場合は空 slice。
+func (r *TransferRepositoryMem) ListByProductID(
+	_ context.Context,
+	productID string,
+) ([]transferdom.Transfer, error) {
+	if productID == "" {
+		return nil, transferdom.ErrInvalidProductID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts := make([]int, 0, len(r.transfers[productID]))
+	for attempt := range r.transfers[productID] {
+		attempts = append(attempts, attempt)
+	}
+	sort.Ints(attempts)
+
+	out := make([]transferdom.Transfer, 0, len(attempts))
+	for _, attempt := range attempts {
+		out = append(
+			out,
+			cloneTransfer(r.transfers[productID][attempt].Transfer),
+		)
+	}
+
+	return out, nil
+}
+
+// ResolveTransferredAtByAssetID は assetId に対する最新の成功 transfer を返す。
+func (r *TransferRepositoryMem) ResolveTransferredAtByAssetID(
+	_ context.Context,
+	assetID string,
+) (transferdom.ResolveTransferredAtByAssetIDResult, error) {
+	if assetID == "" {
+		return transferdom.ResolveTransferredAtByAssetIDResult{},
+			transferdom.ErrInvalidAssetID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		found  bool
+		result transferdom.ResolveTransferredAtByAssetIDResult
+	)
+
+	for _, attempts := range r.transfers {
+		for _, rec := range attempts {
+			t := rec.Transfer
+			if t.AssetID != assetID ||
+				t.Status != transferdom.StatusSucceeded ||
+				rec.TransferredAt == nil {
+				continue
+			}
+
+			if found && !rec.TransferredAt.After(result.TransferredAt) {
+				continue
+			}
+
+			found = true
+			result = transferdom.ResolveTransferredAtByAssetIDResult{
+				ProductID:     t.ProductID,
+				Attempt:       t.Attempt,
+				AvatarID:      t.AvatarID,
+				AssetID:       t.AssetID,
+				TransferredAt: *rec.TransferredAt,
+			}
+		}
+	}
+
+	if !found {
+		return transferdom.ResolveTransferredAtByAssetIDResult{},
+			transferdom.ErrNotFound
+	}
+
+	return result, nil
+}
+
+// CreateAttempt は次の attempt 採番と pending Transfer 作成を原子的に行う。
+// 同一 operationId の再実行では既存 Transfer を返す。
+func (r *TransferRepositoryMem) CreateAttempt(
+	_ context.Context,
+	in transferdom.CreateAttemptInput,
+) (*transferdom.Transfer, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key, ok := r.operations[in.OperationID]; ok {
+		if key.ProductID != in.ProductID {
+			return nil, transferdom.ErrInvalidProductID
+		}
+
+		rec, ok := r.transfers[key.ProductID][key.Attempt]
+		if !ok {
+			return nil, transferdom.ErrNotFound
+		}
+
+		t := cloneTransfer(rec.Transfer)
+		return &t, nil
+	}
+
+	next := 1
+	for attempt := range r.transfers[in.ProductID] {
+		if attempt >= next {
+			next = attempt + 1
+		}
+	}
+
+	t, err := in.NewTransfer(next)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.transfers[in.ProductID] == nil {
+		r.transfers[in.ProductID] = map[int]transferRecord{}
+	}
+
+	r.transfers[in.ProductID][next] = transferRecord{
+		Transfer: cloneTransfer(t),
+	}
+	r.operations[in.OperationID] = transferKey{
+		ProductID: in.ProductID,
+		Attempt:   next,
+	}
+
+	out := cloneTransfer(t)
+	return &out, nil
+}
+
+func (r *TransferRepositoryMem) Save(
+	_ context.Context,
+	t transferdom.Transfer,
+) (*transferdom.Transfer, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.transfers[t.ProductID][t.Attempt]
+	if !ok {
+		return nil, transferdom.ErrNotFound
+	}
+
+	rec.Transfer = cloneTransfer(t)
+	if t.Status == transferdom.StatusSucceeded {
+		now := r.now()
+		rec.TransferredAt = &now
+	}
+
+	r.transfers[t.ProductID][t.Attempt] = rec
+
+	out := cloneTransfer(t)
+	return &out, nil
+}
+
+func (r *TransferRepositoryMem) Patch(
+	_ context.Context,
+	productID string,
+	attempt int,
+	patch transferdom.TransferPatch,
+) (*transferdom.Transfer, error) {
+	if productID == "" {
+		return nil, transferdom.ErrInvalidProductID
+	}
+	if attempt <= 0 {
+		return nil, transferdom.ErrInvalidAttempt
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.transfers[productID][attempt]
+	if !ok {
+		return nil, transferdom.ErrNotFound
+	}
+
+	t := cloneTransfer(rec.Transfer)
+	if err := t.ApplyPatch(patch); err != nil {
+		return nil, err
+	}
+
+	rec.Transfer = t
+	if patch.Status != nil && t.Status == transferdom.StatusSucceeded {
+		now := r.now()
+		rec.TransferredAt = &now
+	}
+
+	r.transfers[productID][attempt] = rec
+
+	out := cloneTransfer(t)
+	return &out, nil
+}
+
+func cloneTransfer(t transferdom.Transfer) transferdom.Transfer {
+	out := t
+	out.TxSignature = cloneStringPtr(t.TxSignature)
+	out.ErrorMsg = cloneStringPtr(t.ErrorMsg)
+
+	if t.ErrorType != nil {
+		v := *t.ErrorType
+		out.ErrorType = &v
+	}
+
+	return out
+}
@@ -0,0 +1,199 @@
+// backend/internal/adapters/out/memory/transportation_repository_mem.go
+package memory
+
+import (
+	"context"
+	"sync"
+
+	transportationdom "narratives/internal/domain/transportation"
+)
+
+// TransportationRepositoryMem は transportation.RepositoryPort の in-memory 実装。
+// 保存前に transportationdom.New で Entity 全体を再構築・検証する。
+type TransportationRepositoryMem struct {
+	mu       sync.Mutex
+	settings map[string]transportationdom.TransportationFeeSetting
+}
+
+var _ transportationdom.RepositoryPort = (*TransportationRepositoryMem)(nil)
+
+func NewTransportationRepositoryMem() *TransportationRepositoryMem {
+	return &TransportationRepositoryMem{
+		settings: map[string]transportationdom.TransportationFeeSetting{},
+	}
+}
+
+func (r *TransportationRepositoryMem) GetByID(
+	_ context.Context,
+	id string,
+) (*transportationdom.TransportationFeeSetting, error) {
+	if id == "" || len([]rune(id)) > transportationdom.MaxTransportationIDLength {
+		return nil, transportationdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.settings[id]
+	if !ok {
+		return nil, transportationdom.ErrNotFound
+	}
+
+	out := cloneTransportation(s)
+	return &out, nil
+}
+
+func (r *TransportationRepositoryMem) ListByCompanyID(
+	_ context.Context,
+	companyID string,
+) ([]transportationdom.TransportationFeeSetting, error) {
+	if err := validateTransportationMemCompanyID(companyID); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]transportationdom.TransportationFeeSetting, 0)
+	for _, id := range sortedKeys(r.settings) {
+		if s := r.settings[id]; s.CompanyID == companyID {
+			out = append(out, cloneTransportation(s))
+		}
+	}
+
+	return out, nil
+}
+
+// Create は既存の設定を上書きせず、同一 ID が存在する場合は ErrConflict を返す。
+func (r *TransportationRepositoryMem) Create(
+	_ context.Context,
+	value transportationdom.TransportationFeeSetting,
+) (*transportationdom.TransportationFeeSetting, error) {
+	if value.ID == "" || len([]rune(value.ID)) > transportationdom.MaxTransportationIDLength {
+		return nil, transportationdom.ErrInvalidID
+	}
+	if err := validateTransportationMemCompanyID(value.CompanyID); err != nil {
+		return nil, err
+	}
+
+	validated, err := transportationdom.New(
+		value.ID,
+		value.CompanyID,
+		value.Name,
+		value.PrefectureRates,
+		value.IslandRates,
+		value.CreatedAt,
+		value.CreatedBy,
+		value.UpdatedAt,
+		value.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.settings[validated.ID]; exists {
+		return nil, transportationdom.ErrConflict
+	}
+
+	r.settings[validated.ID] = cloneTransportation(validated)
+
+	return &validated, nil
+}
+
+// Update は upsert ではない。ID、CompanyID、CreatedAt、CreatedBy は変更できない。
+func (r *TransportationRepositoryMem) Update(
+	_ context.Context,
+	value transportationdom.TransportationFeeSetting,
+) (*transportationdom.TransportationFeeSetting, error) {
+	if value.ID == "" || len([]rune(value.ID)) > transportationdom.MaxTransportationIDLength {
+		return nil, transportationdom.ErrInvalidID
+	}
+	if err := validateTransportationMemCompanyID(value.CompanyID); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.settings[value.ID]
+	if !ok {
+		return nil, transportationdom.ErrNotFound
+	}
+
+	if value.CompanyID != current.CompanyID {
+		return nil, transportationdom.ErrInvalidCompanyID
+	}
+	if !value.CreatedAt.Equal(current.CreatedAt) {
+		return nil, transportationdom.ErrInvalidCreatedAt
+	}
+	if value.CreatedBy != current.CreatedBy {
+		return nil, transportationdom.ErrInvalidCreatedBy
+	}
+
+	next, err := transportationdom.New(
+		current.ID,
+		current.CompanyID,
+		value.Name,
+		value.PrefectureRates,
+		value.IslandRates,
+		current.CreatedAt,
+		current.CreatedBy,
+		value.UpdatedAt,
+		value.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r.settings[next.ID] = cloneTransportation(next)
+
+	return &next, nil
+}
+
+func (r *TransportationRepositoryMem) Delete(
+	_ context.Context,
+	id string,
+) error {
+	if id == "" || len([]rune(id)) > transportationdom.MaxTransportationIDLength {
+		return transportationdom.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.settings[id]; !ok {
+		return transportationdom.ErrNotFound
+	}
+
+	delete(r.settings, id)
+
+	return nil
+}
+
+func validateTransportationMemCompanyID(companyID string) error {
+	if companyID == "" || len([]rune(companyID)) > transportationdom.MaxCompanyIDLength {
+		return transportationdom.ErrInvalidCompanyID
+	}
+
+	return nil
+}
+
+func cloneTransportation(
+	s transportationdom.TransportationFeeSetting,
+) transportationdom.TransportationFeeSetting {
+	out := s
+
+	if s.PrefectureRates != nil {
+		out.PrefectureRates = make([]transportationdom.PrefectureRate, len(s.PrefectureRates))
+		copy(out.PrefectureRates, s.PrefectureRates)
+	}
+	if s.IslandRates != nil {
+		out.IslandRates = make([]transportationdom.IslandRate, len(s.IslandRates))
+		copy(out.IslandRates, s.IslandRates)
+	}
+
+	return out
+}
@@ -0,0 +1,194 @@
+// backend/internal/adapters/out/memory/wallet_repository_mem.go
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	walletdom "narratives/internal/domain/wallet"
+)
+
+var (
+	ErrInvalidWalletAvatarID = errors.New(
+		"wallet_repository_mem: invalid avatarId",
+	)
+	ErrInvalidWalletAssetID = errors.New(
+		"wallet_repository_mem: invalid assetId",
+	)
+)
+
+// WalletRepositoryMem は wallet.Repository の in-memory 実装。
+//
+// transfer で使う usecase.AvatarWalletItemTransferUpdater と
+// usecase.AvatarWalletResolver も同じ map で実装する。
+// Firestore と同じく docId = avatarId で保存する。
+type WalletRepositoryMem struct {
+	mu sync.Mutex
+
+	wallets map[string]walletdom.Wallet
+}
+
+var (
+	_ walletdom.Repository                    = (*WalletRepositoryMem)(nil)
+	_ usecase.AvatarWalletItemTransferUpdater = (*WalletRepositoryMem)(nil)
+	_ usecase.AvatarWalletResolver            = (*WalletRepositoryMem)(nil)
+)
+
+func NewWalletRepositoryMem() *WalletRepositoryMem {
+	return &WalletRepositoryMem{
+		wallets: map[string]walletdom.Wallet{},
+	}
+}
+
+func (r *WalletRepositoryMem) GetByAvatarID(
+	_ context.Context,
+	avatarID string,
+) (walletdom.Wallet, error) {
+	if avatarID == "" {
+		return walletdom.Wallet{}, ErrInvalidWalletAvatarID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.wallets[avatarID]
+	if !ok {
+		return walletdom.Wallet{}, walletdom.ErrNotFound
+	}
+
+	return cloneWallet(w), nil
+}
+
+// Save は Firestore と同じく upsert する。
+func (r *WalletRepositoryMem) Save(
+	_ context.Context,
+	avatarID string,
+	w walletdom.Wallet,
+) error {
+	if avatarID == "" {
+		return ErrInvalidWalletAvatarID
+	}
+	if err := validateCanonicalWallet(w); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.wallets[avatarID] = cloneWallet(w)
+
+	return nil
+}
+
+// AddAssetIDToAvatarWalletItems は assetIds に assetId を冪等追加する。
+func (r *WalletRepositoryMem) AddAssetIDToAvatarWalletItems(
+	_ context.Context,
+	avatarID string,
+	assetID string,
+	now time.Time,
+) error {
+	return r.updateAssetIDs(avatarID, assetID, now, func(w *walletdom.Wallet) error {
+		return w.AddAssetID(assetID, now)
+	})
+}
+
+// RemoveAssetIDFromAvatarWalletItems は assetIds から assetId を冪等削除する。
+func (r *WalletRepositoryMem) RemoveAssetIDFromAvatarWalletItems(
+	_ context.Context,
+	avatarID string,
+	assetID string,
+	now time.Time,
+) error {
+	return r.updateAssetIDs(avatarID, assetID, now, func(w *walletdom.Wallet) error {
+		// RemoveAssetID は形式検証を行わないため、AddAssetID で検証する。
+		probe := cloneWallet(*w)
+		if err := probe.AddAssetID(assetID, now); err != nil {
+			return err
+		}
+
+		w.RemoveAssetID(assetID, now)
+		return nil
+	})
+}
+
+// ResolveAvatarWalletAddress は wallets/{avatarId}.walletAddress を返す。
+func (r *WalletRepositoryMem) ResolveAvatarWalletAddress(
+	ctx context.Context,
+	avatarID string,
+) (string, error) {
+	w, err := r.GetByAvatarID(ctx, avatarID)
+	if err != nil {
+		return "", err
+	}
+	if w.WalletAddress == "" {
+		return "", walletdom.ErrInvalidWalletAddress
+	}
+
+	return w.WalletAddress, nil
+}
+
+func (r *WalletRepositoryMem) updateAssetIDs(
+	avatarID string,
+	assetID string,
+	now time.Time,
+	apply func(w *walletdom.Wallet) error,
+) error {
+	if avatarID == "" {
+		return ErrInvalidWalletAvatarID
+	}
+	if assetID == "" {
+		return ErrInvalidWalletAssetID
+	}
+	if now.IsZero() {
+		return walletdom.ErrInvalidLastUpdatedAt
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.wallets[avatarID]
+	if !ok {
+		return walletdom.ErrNotFound
+	}
+
+	next := cloneWallet(current)
+	if err := apply(&next); err != nil {
+		return err
+	}
+	next.LastUpdatedAt = now
+
+	r.wallets[avatarID] = next
+
+	return nil
+}
+
+func validateCanonicalWallet(w walletdom.Wallet) error {
+	validated, err := walletdom.NewFull(
+		w.WalletAddress,
+		w.AssetIDs,
+		w.LastUpdatedAt,
+		w.Status,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(validated.AssetIDs) != len(w.AssetIDs) {
+		return walletdom.ErrInvalidAssetID
+	}
+	for i := range w.AssetIDs {
+		if validated.AssetIDs[i] != w.AssetIDs[i] {
+			return walletdom.ErrInvalidAssetID
+		}
+	}
+
+	return nil
+}
+
+func cloneWallet(w walletdom.Wallet) walletdom.Wallet {
+	w.AssetIDs = cloneStrings(w.AssetIDs)
+	return w
+}
@@ -0,0 +1,202 @@
+// backend/internal/adapters/out/pdf/billing_statement_renderer.go
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	billingdom "narratives/internal/domain/billing"
+)
+
+// BillingStatementRenderer は company の月次の請求明細書を A4 の PDF に描画します。
+// ミント申請が 1 ページに収まらない場合は続きのページに描画します。
+type BillingStatementRenderer struct {
+	location *time.Location
+}
+
+var _ usecase.BillingStatementRenderer = (*BillingStatementRenderer)(nil)
+
+func NewBillingStatementRenderer() *BillingStatementRenderer {
+	return &BillingStatementRenderer{
+		location: time.FixedZone("JST", 9*60*60),
+	}
+}
+
+// layout（pt, 左上原点）
+const (
+	billingMarginX = 48.0
+	billingRight   = A4Width - billingMarginX
+	billingBottom  = A4Height - 72.0
+
+	billingRowHeight = 18.0
+
+	colBillingCategory    = billingMarginX + 4
+	colBillingDescription = 120.0
+	colBillingQuantity    = 380.0
+	colBillingUnitPrice   = 450.0
+	colBillingAmount      = billingRight - 4
+)
+
+func (r *BillingStatementRenderer) RenderBillingStatement(
+	s billingdom.Statement,
+) ([]byte, error) {
+	doc := NewDocument()
+	page := doc.AddPage()
+
+	page.TextCenter(A4Width/2, 72, 20, "請求明細書")
+
+	// 右上: 明細書番号・日付
+	page.TextRight(billingRight, 104, 9, "No. "+s.ID)
+	page.TextRight(billingRight, 118, 9, "発行日: "+r.date(s.GeneratedAt))
+	page.TextRight(billingRight, 132, 9, "対象期間: "+r.period(s))
+
+	// 請求先
+	page.Text(billingMarginX, 112, 12, s.CompanyName+" 御中")
+	page.Text(billingMarginX, 130, 9, "companyId: "+s.CompanyID)
+
+	// 請求額
+	page.SetGray(0.93)
+	page.Rect(billingMarginX, 156, billingRight-billingMarginX, 36, true)
+	page.SetGray(0)
+	page.Text(billingMarginX+12, 180, 12, "ご請求金額（税込）")
+	page.TextRight(billingRight-12, 181, 16, yen(s.TotalAmount)+"-")
+
+	// 参考: 注文・移転の集計（請求額に含まない）
+	y := 216.0
+	page.Text(billingMarginX, y, 10, "当月の取引（参考）")
+	y += billingRowHeight
+
+	summary := []struct {
+		label string
+		value string
+	}{
+		{"注文件数", strconv.Itoa(s.Sales.OrderCount) + " 件"},
+		{"販売手数料率", basisPointsRate(s.Plan.SalesBasisPoints)},
+		{"販売代金・ロイヤリティ（税抜）", yen(s.Sales.GrossAmount)},
+		{"返金", "-" + yen(s.Sales.RefundAmount)},
+		{"販売手数料（支払額から差引済み）", yen(s.Sales.CommissionAmount)},
+		{"NFT の移転（成功）", strconv.Itoa(s.Transfers.SucceededCount) + " 件"},
+		{"NFT の移転（失敗）", strconv.Itoa(s.Transfers.FailedCount) + " 件"},
+	}
+	for _, item := range summary {
+		page.Text(colBillingCategory, y, 9, item.label)
+		page.TextRight(colBillingAmount, y, 9, item.value)
+		y += billingRowHeight - 4
+	}
+
+	y = r.renderTableHeader(page, y+billingRowHeight)
+
+	row := func(category, description, quantity, unitPrice string, amount int) {
+		page, y = r.ensureSpace(doc, page, y, 1)
+
+		page.Text(colBillingCategory, y, 9, category)
+		page.Text(colBillingDescription, y, 9, Truncate(
+			description,
+			9,
+			colBillingQuantity-colBillingDescription-40,
+		))
+		page.TextRight(colBillingQuantity, y, 9, quantity)
+		page.TextRight(colBillingUnitPrice, y, 9, unitPrice)
+		page.TextRight(colBillingAmount, y, 9, yen(amount))
+		y += billingRowHeight
+	}
+
+	for _, m := range s.Mints {
+		row(
+			"ミント",
+			fmt.Sprintf("%s（%s）", m.TokenBlueprintID, m.ID),
+			strconv.Itoa(m.Quantity),
+			yen(m.MintFeePerItem),
+			m.ItemFeeAmount,
+		)
+		if m.NetworkCostAmount > 0 {
+			row(
+				"ミント",
+				fmt.Sprintf(
+					"ネットワーク手数料 %s lamports × %s",
+					strconv.FormatInt(m.NetworkCostLamports, 10),
+					basisPointsRate(m.NetworkCostBasisPoints),
+				),
+				"",
+				"",
+				m.NetworkCostAmount,
+			)
+		}
+	}
+
+	if s.MonthlyFee > 0 {
+		row("月額料金", "プラットフォーム月額料金", "1", yen(s.MonthlyFee), s.MonthlyFee)
+	}
+
+	page.Line(billingMarginX, y-12, billingRight, y-12, 0.5)
+
+	page, y = r.ensureSpace(doc, page, y+4, 4)
+
+	labelX := 300.0
+	page.Text(labelX, y, 9, "小計（税抜）")
+	page.TextRight(colBillingAmount, y, 9, yen(s.Subtotal))
+	y += billingRowHeight - 4
+	page.Text(labelX, y, 9, "消費税（"+rate(s.TaxRate)+"）")
+	page.TextRight(colBillingAmount, y, 9, yen(s.TaxAmount))
+	y += billingRowHeight - 4
+	page.Text(labelX, y, 10, "ご請求金額")
+	page.TextRight(colBillingAmount, y, 10, yen(s.TotalAmount))
+	y += billingRowHeight * 2
+
+	page.Text(billingMarginX, y, 8, "販売手数料は支払い（振込）の際に差し引いているため、ご請求金額には含みません。")
+	y += 12
+	page.Text(billingMarginX, y, 8, "ミント手数料はミント申請時点のプランと見積で計算し、1円未満を切り捨てています。")
+
+	return doc.Bytes()
+}
+
+// renderTableHeader は明細の見出しを描画し、最初の行の y を返します。
+func (r *BillingStatementRenderer) renderTableHeader(page *Page, y float64) float64 {
+	page.SetGray(0.93)
+	page.Rect(billingMarginX, y-12, billingRight-billingMarginX, 18, true)
+	page.SetGray(0)
+
+	page.Text(colBillingCategory, y, 9, "区分")
+	page.Text(colBillingDescription, y, 9, "内容")
+	page.TextRight(colBillingQuantity, y, 9, "数量")
+	page.TextRight(colBillingUnitPrice, y, 9, "単価")
+	page.TextRight(colBillingAmount, y, 9, "金額")
+
+	return y + billingRowHeight + 4
+}
+
+// ensureSpace は rows 行が収まらない場合に改ページし、描画先と y を返します。
+func (r *BillingStatementRenderer) ensureSpace(
+	doc *Document,
+	page *Page,
+	y float64,
+	rows int,
+) (*Page, float64) {
+	if y+float64(rows)*billingRowHeight <= billingBottom {
+		return page, y
+	}
+
+	next := doc.AddPage()
+	next.TextRight(billingRight, 48, 8, "（続き）")
+
+	return next, r.renderTableHeader(next, 72)
+}
+
+func (r *BillingStatementRenderer) date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(r.location).Format("2006年1月2日")
+}
+
+// period は対象期間（月初〜月末, JST）を返します。
+func (r *BillingStatementRenderer) period(s billingdom.Statement) string {
+	return r.date(s.PeriodStart) + "〜" + r.date(s.PeriodEnd.Add(-time.Nanosecond))
+}
+
+// basisPointsRate は 250 を "2.5%" にします。
+func basisPointsRate(bp int) string {
+	return strconv.FormatFloat(float64(bp)/100, 'f', -1, 64) + "%"
+}
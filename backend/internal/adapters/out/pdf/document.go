@@ -0,0 +1,470 @@
+// backend/internal/adapters/out/pdf/document.go
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+	"unicode/utf16"
+)
+
+// Document は帳票用の最小限の PDF writer です。
+//
+// 外部ライブラリを使わず、テキスト・罫線・矩形・画像だけを出力します。
+// 日本語は PDF viewer 標準の CJK フォント（平成角ゴシック, 埋め込みなし）を
+// UniJIS-UCS2-HW-H で参照するため、ASCII は半角幅・それ以外は全角幅で描画されます。
+//
+// 座標は pt 単位で、利用側の扱いやすさのため左上を原点（y は下向き）とします。
+type Document struct {
+	width  float64
+	height float64
+
+	pages  []*Page
+	images []*Image
+}
+
+// Image は文書に埋め込む画像（XObject）です。同じ Image は何度描画しても 1 回だけ埋め込まれます。
+type Image struct {
+	name   string
+	width  int
+	height int
+
+	rgb   []byte // DeviceRGB, 8bit
+	alpha []byte // DeviceGray, 8bit（不透明の場合は nil）
+}
+
+func (img *Image) Width() int  { return img.width }
+func (img *Image) Height() int { return img.height }
+
+// Page は 1 ページ分の描画命令です。
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+}
+
+const (
+	// A4 portrait
+	A4Width  = 595.28
+	A4Height = 841.89
+
+	fontResourceName = "F1"
+)
+
+var ErrEmptyDocument = errors.New("pdf: document has no page")
+
+func NewDocument() *Document {
+	return NewDocumentWithSize(A4Width, A4Height)
+}
+
+func NewDocumentWithSize(width, height float64) *Document {
+	return &Document{
+		width:  width,
+		height: height,
+	}
+}
+
+func (d *Document) Width() float64  { return d.width }
+func (d *Document) Height() float64 { return d.height }
+
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// AddImage は img を文書に登録します。透明度はソフトマスクとして保持します。
+func (d *Document) AddImage(img image.Image) *Image {
+	b := img.Bounds()
+
+	out := &Image{
+		name:   fmt.Sprintf("Im%d", len(d.images)+1),
+		width:  b.Dx(),
+		height: b.Dy(),
+		rgb:    make([]byte, 0, b.Dx()*b.Dy()*3),
+	}
+
+	alpha := make([]byte, 0, b.Dx()*b.Dy())
+	opaque := true
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := img.At(x, y).RGBA()
+
+			// 乗算済みアルファを戻す
+			if a > 0 && a < 0xFFFF {
+				r = r * 0xFFFF / a
+				g = g * 0xFFFF / a
+				bl = bl * 0xFFFF / a
+			}
+
+			out.rgb = append(out.rgb, byte(r>>8), byte(g>>8), byte(bl>>8))
+			alpha = append(alpha, byte(a>>8))
+			if a != 0xFFFF {
+				opaque = false
+			}
+		}
+	}
+
+	if !opaque {
+		out.alpha = alpha
+	}
+
+	d.images = append(d.images, out)
+	return out
+}
+
+// ============================================================
+// Drawing
+// ============================================================
+
+// Text は (x, y) を左端・ベースラインとして s を描画します。
+func (p *Page) Text(x, y, size float64, s string) {
+	if s == "" {
+		return
+	}
+
+	fmt.Fprintf(
+		&p.content,
+		"BT /%s %s Tf %s %s Td <%s> Tj ET\n",
+		fontResourceName,
+		num(size),
+		num(x),
+		num(p.doc.height-y),
+		encodeText(s),
+	)
+}
+
+// TextRight は xRight を右端として s を描画します。
+func (p *Page) TextRight(xRight, y, size float64, s string) {
+	p.Text(xRight-TextWidth(s, size), y, size, s)
+}
+
+// TextCenter は xCenter を中央として s を描画します。
+func (p *Page) TextCenter(xCenter, y, size float64, s string) {
+	p.Text(xCenter-TextWidth(s, size)/2, y, size, s)
+}
+
+// Line は (x1, y1) から (x2, y2) へ線を引きます。
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(
+		&p.content,
+		"%s w %s %s m %s %s l S\n",
+		num(width),
+		num(x1),
+		num(p.doc.height-y1),
+		num(x2),
+		num(p.doc.height-y2),
+	)
+}
+
+// Rect は左上 (x, y)・幅 w・高さ h の矩形を描画します。fill=false は枠線のみ。
+func (p *Page) Rect(x, y, w, h float64, fill bool) {
+	op := "S"
+	if fill {
+		op = "f"
+	}
+
+	fmt.Fprintf(
+		&p.content,
+		"%s %s %s %s re %s\n",
+		num(x),
+		num(p.doc.height-y-h),
+		num(w),
+		num(h),
+		op,
+	)
+}
+
+// Image は左上 (x, y)・幅 w・高さ h に img を描画します（縦横比は呼び出し側で調整）。
+func (p *Page) Image(img *Image, x, y, w, h float64) {
+	if img == nil {
+		return
+	}
+
+	fmt.Fprintf(
+		&p.content,
+		"q %s 0 0 %s %s %s cm /%s Do Q\n",
+		num(w),
+		num(h),
+		num(x),
+		num(p.doc.height-y-h),
+		img.name,
+	)
+}
+
+// SetGray は以降の線・塗りの色をグレースケール（0=黒, 1=白）で指定します。
+func (p *Page) SetGray(level float64) {
+	fmt.Fprintf(&p.content, "%s G %s g\n", num(level), num(level))
+}
+
+// TextWidth は s を size で描画したときの幅を返します。
+func TextWidth(s string, size float64) float64 {
+	w := 0.0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w * size
+}
+
+// Truncate は幅が maxWidth に収まるように s を切り詰めます（末尾に "…"）。
+func Truncate(s string, size, maxWidth float64) string {
+	if TextWidth(s, size) <= maxWidth {
+		return s
+	}
+
+	const ellipsis = "…"
+	limit := maxWidth - TextWidth(ellipsis, size)
+
+	var b strings.Builder
+	w := 0.0
+	for _, r := range s {
+		rw := runeWidth(r) * size
+		if w+rw > limit {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+
+	return b.String() + ellipsis
+}
+
+// ============================================================
+// Output
+// ============================================================
+
+// Bytes は PDF を出力します。
+func (d *Document) Bytes() ([]byte, error) {
+	if d == nil || len(d.pages) == 0 {
+		return nil, ErrEmptyDocument
+	}
+
+	w := &writer{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: catalog, 2: pages, 3-5: font, 6..: page + content, 以降: image (+ soft mask)
+	const (
+		catalogID    = 1
+		pagesID      = 2
+		fontID       = 3
+		cidFontID    = 4
+		descriptorID = 5
+		firstPageID  = 6
+	)
+
+	imageIDs := make([]int, len(d.images))
+	nextID := firstPageID + len(d.pages)*2
+	xobjects := make([]string, 0, len(d.images))
+	for i, img := range d.images {
+		imageIDs[i] = nextID
+		xobjects = append(xobjects, fmt.Sprintf("/%s %d 0 R", img.name, nextID))
+
+		nextID++
+		if img.alpha != nil {
+			nextID++
+		}
+	}
+
+	resources := fmt.Sprintf("/Font << /%s %d 0 R >>", fontResourceName, fontID)
+	if len(xobjects) > 0 {
+		resources += " /XObject << " + strings.Join(xobjects, " ") + " >>"
+	}
+
+	kids := make([]string, 0, len(d.pages))
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPageID+i*2))
+	}
+
+	w.object(catalogID, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+	w.object(pagesID, fmt.Sprintf(
+		"<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "),
+		len(d.pages),
+	))
+	w.object(fontID, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5-UniJIS-UCS2-HW-H"+
+			" /Encoding /UniJIS-UCS2-HW-H /DescendantFonts [%d 0 R] >>",
+		cidFontID,
+	))
+	w.object(cidFontID, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5"+
+			" /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >>"+
+			" /FontDescriptor %d 0 R /DW 1000 /W [231 325 500 327 389 500] >>",
+		descriptorID,
+	))
+	w.object(descriptorID,
+		"<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4"+
+			" /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 752 /Descent -221"+
+			" /CapHeight 737 /StemV 114 >>",
+	)
+
+	for i, page := range d.pages {
+		pageID := firstPageID + i*2
+		contentID := pageID + 1
+
+		w.object(pageID, fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s]"+
+				" /Resources << %s >> /Contents %d 0 R >>",
+			pagesID,
+			num(d.width),
+			num(d.height),
+			resources,
+			contentID,
+		))
+
+		stream, err := deflate(page.content.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		w.stream(contentID, stream)
+	}
+
+	for i, img := range d.images {
+		if err := w.image(imageIDs[i], img); err != nil {
+			return nil, err
+		}
+	}
+
+	w.trailer(catalogID)
+
+	return w.buf.Bytes(), nil
+}
+
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) begin(id int) {
+	for len(w.offsets) < id {
+		w.offsets = append(w.offsets, 0)
+	}
+	w.offsets[id-1] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n", id)
+}
+
+func (w *writer) object(id int, body string) {
+	w.begin(id)
+	w.buf.WriteString(body)
+	w.buf.WriteString("\nendobj\n")
+}
+
+func (w *writer) stream(id int, data []byte) {
+	w.streamWithDict(id, "", data)
+}
+
+func (w *writer) streamWithDict(id int, dict string, data []byte) {
+	w.begin(id)
+	fmt.Fprintf(&w.buf, "<< %s/Length %d /Filter /FlateDecode >>\nstream\n", dict, len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+// image は img を id に、ソフトマスクがある場合は id+1 に書き込みます。
+func (w *writer) image(id int, img *Image) error {
+	rgb, err := deflate(img.rgb)
+	if err != nil {
+		return err
+	}
+
+	dict := fmt.Sprintf(
+		"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 ",
+		img.width,
+		img.height,
+	)
+	if img.alpha != nil {
+		dict += fmt.Sprintf("/SMask %d 0 R ", id+1)
+	}
+	w.streamWithDict(id, dict, rgb)
+
+	if img.alpha == nil {
+		return nil
+	}
+
+	alpha, err := deflate(img.alpha)
+	if err != nil {
+		return err
+	}
+
+	w.streamWithDict(id+1, fmt.Sprintf(
+		"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 ",
+		img.width,
+		img.height,
+	), alpha)
+
+	return nil
+}
+
+func (w *writer) trailer(rootID int) {
+	xref := w.buf.Len()
+
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n", len(w.offsets)+1)
+	w.buf.WriteString("0000000000 65535 f \n")
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(
+		&w.buf,
+		"trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(w.offsets)+1,
+		rootID,
+		xref,
+	)
+}
+
+// ============================================================
+// Helpers
+// ============================================================
+
+func deflate(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+
+	zw := zlib.NewWriter(&out)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// encodeText は s を UCS-2（UTF-16BE）の hex 文字列にします。
+// BMP 外の文字は CMap が扱えないため "?" に置き換えます。
+func encodeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r > 0xFFFF || utf16.IsSurrogate(r) {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+// runeWidth は em 単位の文字幅です（ASCII と半角カナは半角）。
+func runeWidth(r rune) float64 {
+	switch {
+	case r >= 0x20 && r <= 0x7E:
+		return 0.5
+	case r >= 0xFF61 && r <= 0xFF9F:
+		return 0.5
+	default:
+		return 1
+	}
+}
+
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
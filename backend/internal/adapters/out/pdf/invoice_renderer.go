@@ -0,0 +1,289 @@
+// backend/internal/adapters/out/pdf/invoice_renderer.go
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	invoicedom "narratives/internal/domain/invoice"
+)
+
+// InvoiceRenderer は領収書（適格請求書）を A4 の PDF に描画します。
+// 発行事業者ごとに改ページし、明細が 1 ページに収まらない場合は続きのページに描画します。
+type InvoiceRenderer struct {
+	location *time.Location
+}
+
+var _ usecase.InvoiceRenderer = (*InvoiceRenderer)(nil)
+
+func NewInvoiceRenderer() *InvoiceRenderer {
+	return &InvoiceRenderer{
+		location: time.FixedZone("JST", 9*60*60),
+	}
+}
+
+// layout（pt, 左上原点）
+const (
+	invoiceMarginX = 48.0
+	invoiceRight   = A4Width - invoiceMarginX
+	invoiceBottom  = A4Height - 72.0
+
+	invoiceRowHeight = 18.0
+
+	colDescription = invoiceMarginX + 4
+	colQtyRight    = 340.0
+	colPriceRight  = 420.0
+	colAmountRight = 500.0
+	colRateRight   = invoiceRight - 4
+)
+
+func (r *InvoiceRenderer) RenderInvoices(
+	invoices []invoicedom.Invoice,
+) ([]byte, error) {
+	doc := NewDocument()
+
+	for _, inv := range invoices {
+		r.renderInvoice(doc, inv)
+	}
+
+	return doc.Bytes()
+}
+
+func (r *InvoiceRenderer) renderInvoice(
+	doc *Document,
+	inv invoicedom.Invoice,
+) {
+	page := doc.AddPage()
+
+	title := "領収書"
+	if inv.Issuer.Qualified() {
+		title = "領収書（適格請求書）"
+	}
+	page.TextCenter(A4Width/2, 72, 20, title)
+
+	// 右上: 番号・日付
+	page.TextRight(invoiceRight, 104, 9, "No. "+inv.Number)
+	page.TextRight(invoiceRight, 118, 9, "発行日: "+r.date(inv.IssuedAt))
+	page.TextRight(invoiceRight, 132, 9, "取引日: "+r.date(inv.TransactionDate))
+	page.TextRight(invoiceRight, 146, 9, "注文番号: "+inv.OrderID)
+
+	// 発行事業者
+	page.Text(invoiceMarginX, 112, 12, inv.Issuer.Name)
+	if inv.Issuer.Qualified() {
+		page.Text(invoiceMarginX, 130, 9, "登録番号: "+inv.Issuer.RegistrationNumber)
+	}
+
+	// 合計金額
+	page.SetGray(0.93)
+	page.Rect(invoiceMarginX, 170, invoiceRight-invoiceMarginX, 36, true)
+	page.SetGray(0)
+	page.Text(invoiceMarginX+12, 194, 12, "合計金額（税込）")
+	page.TextRight(invoiceRight-12, 195, 16, yen(inv.Total)+"-")
+	page.Text(invoiceMarginX, 222, 9, "上記の金額を正に領収いたしました。")
+
+	y := r.renderTableHeader(page, 246)
+
+	for _, line := range inv.Lines {
+		rows := 1
+		if line.DiscountAmount > 0 {
+			rows = 2
+		}
+		page, y = r.ensureSpace(doc, page, y, rows)
+
+		description := line.Description
+		if line.Reduced() {
+			description += " ※"
+		}
+
+		page.Text(colDescription, y, 9, Truncate(description, 9, colQtyRight-colDescription-40))
+		page.TextRight(colQtyRight, y, 9, strconv.Itoa(line.Qty))
+		page.TextRight(colPriceRight, y, 9, yen(line.UnitPrice))
+		page.TextRight(colAmountRight, y, 9, yen(line.Amount))
+		page.TextRight(colRateRight, y, 9, rate(line.TaxRate))
+		y += invoiceRowHeight
+
+		if line.DiscountAmount > 0 {
+			page.Text(colDescription+12, y, 9, "クーポン値引き")
+			page.TextRight(colAmountRight, y, 9, "-"+yen(line.DiscountAmount))
+			page.TextRight(colRateRight, y, 9, rate(line.TaxRate))
+			y += invoiceRowHeight
+		}
+	}
+
+	if inv.ShippingAmount > 0 {
+		rows := 1
+		if inv.ShippingDiscountAmount > 0 {
+			rows = 2
+		}
+		page, y = r.ensureSpace(doc, page, y, rows)
+
+		page.Text(colDescription, y, 9, "送料")
+		page.TextRight(colAmountRight, y, 9, yen(inv.ShippingAmount))
+		page.TextRight(colRateRight, y, 9, rate(invoicedom.TaxRateStandard))
+		y += invoiceRowHeight
+
+		if inv.ShippingDiscountAmount > 0 {
+			page.Text(colDescription+12, y, 9, "送料値引き")
+			page.TextRight(colAmountRight, y, 9, "-"+yen(inv.ShippingDiscountAmount))
+			page.TextRight(colRateRight, y, 9, rate(invoicedom.TaxRateStandard))
+			y += invoiceRowHeight
+		}
+	}
+
+	page.Line(invoiceMarginX, y-12, invoiceRight, y-12, 0.5)
+
+	// 税率ごとの合計
+	page, y = r.ensureSpace(doc, page, y+4, len(inv.Rates)+4)
+
+	labelX := 300.0
+	for _, summary := range inv.Rates {
+		page.Text(labelX, y, 9, fmt.Sprintf("%s対象（税抜）", rate(summary.Rate)))
+		page.TextRight(colAmountRight, y, 9, yen(summary.TaxableAmount))
+		y += invoiceRowHeight - 4
+
+		page.Text(labelX+12, y, 9, "消費税")
+		page.TextRight(colAmountRight, y, 9, yen(summary.TaxAmount))
+		y += invoiceRowHeight
+	}
+
+	page.Line(labelX, y-12, invoiceRight, y-12, 0.5)
+	page.Text(labelX, y, 9, "小計（税抜）")
+	page.TextRight(colAmountRight, y, 9, yen(inv.Subtotal))
+	y += invoiceRowHeight - 4
+	page.Text(labelX, y, 9, "消費税合計")
+	page.TextRight(colAmountRight, y, 9, yen(inv.TaxAmount))
+	y += invoiceRowHeight - 4
+	page.Text(labelX, y, 10, "合計（税込）")
+	page.TextRight(colAmountRight, y, 10, yen(inv.Total))
+	y += invoiceRowHeight * 2
+
+	if len(inv.Refunds) > 0 {
+		page, y = r.renderRefunds(doc, page, y, inv)
+	}
+
+	// 注記
+	page, y = r.ensureSpace(doc, page, y, 3)
+	if inv.HasReducedRate() {
+		page.Text(invoiceMarginX, y, 8, "※は軽減税率（8%）対象です。")
+		y += 12
+	}
+	page.Text(invoiceMarginX, y, 8, "消費税額は税率ごとの合計金額に対して計算し、1円未満を切り捨てています。")
+	y += 12
+	if !inv.Issuer.Qualified() {
+		page.Text(invoiceMarginX, y, 8, "発行者は適格請求書発行事業者ではないため、本書は適格請求書ではありません。")
+	}
+}
+
+// renderRefunds は返金を返還インボイス（適格返還請求書）として描画し、次の y を返します。
+func (r *InvoiceRenderer) renderRefunds(
+	doc *Document,
+	page *Page,
+	y float64,
+	inv invoicedom.Invoice,
+) (*Page, float64) {
+	title := "返金"
+	if inv.Issuer.Qualified() {
+		title = "返還インボイス（適格返還請求書）"
+	}
+
+	page, y = r.ensureSpace(doc, page, y, 2)
+	page.Text(invoiceMarginX, y, 11, title)
+	y += invoiceRowHeight
+
+	labelX := 300.0
+	for _, refund := range inv.Refunds {
+		page, y = r.ensureSpace(doc, page, y, len(refund.Rates)*2+2)
+
+		page.Text(invoiceMarginX, y, 9, "返金日: "+r.date(refund.RefundedAt))
+		page.TextRight(invoiceRight, y, 9, "返金番号: "+refund.RefundID)
+		y += invoiceRowHeight
+
+		for _, summary := range refund.Rates {
+			page.Text(labelX, y, 9, fmt.Sprintf("%s対象（税抜）", rate(summary.Rate)))
+			page.TextRight(colAmountRight, y, 9, "-"+yen(summary.TaxableAmount))
+			y += invoiceRowHeight - 4
+
+			page.Text(labelX+12, y, 9, "消費税")
+			page.TextRight(colAmountRight, y, 9, "-"+yen(summary.TaxAmount))
+			y += invoiceRowHeight
+		}
+
+		page.Text(labelX, y, 9, "返金額（税込）")
+		page.TextRight(colAmountRight, y, 9, "-"+yen(refund.Amount))
+		y += invoiceRowHeight
+	}
+
+	page, y = r.ensureSpace(doc, page, y, 2)
+	page.Line(labelX, y-12, invoiceRight, y-12, 0.5)
+	page.Text(labelX, y, 10, "返金後の合計（税込）")
+	page.TextRight(colAmountRight, y, 10, yen(inv.NetTotal()))
+	y += invoiceRowHeight * 2
+
+	return page, y
+}
+
+// renderTableHeader は明細の見出しを描画し、最初の行の y を返します。
+func (r *InvoiceRenderer) renderTableHeader(page *Page, y float64) float64 {
+	page.SetGray(0.93)
+	page.Rect(invoiceMarginX, y-12, invoiceRight-invoiceMarginX, 18, true)
+	page.SetGray(0)
+
+	page.Text(colDescription, y, 9, "品目")
+	page.TextRight(colQtyRight, y, 9, "数量")
+	page.TextRight(colPriceRight, y, 9, "単価（税抜）")
+	page.TextRight(colAmountRight, y, 9, "金額（税抜）")
+	page.TextRight(colRateRight, y, 9, "税率")
+
+	return y + invoiceRowHeight + 4
+}
+
+// ensureSpace は rows 行が収まらない場合に改ページし、描画先と y を返します。
+func (r *InvoiceRenderer) ensureSpace(
+	doc *Document,
+	page *Page,
+	y float64,
+	rows int,
+) (*Page, float64) {
+	if y+float64(rows)*invoiceRowHeight <= invoiceBottom {
+		return page, y
+	}
+
+	next := doc.AddPage()
+	next.TextRight(invoiceRight, 48, 8, "（続き）")
+
+	return next, r.renderTableHeader(next, 72)
+}
+
+func (r *InvoiceRenderer) date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(r.location).Format("2006年1月2日")
+}
+
+func rate(r int) string {
+	return strconv.Itoa(r) + "%"
+}
+
+// yen は 1234567 を "¥1,234,567" にします。
+func yen(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.Itoa(amount)
+
+	out := make([]byte, 0, len(digits)+len(digits)/3)
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, digits[i])
+	}
+
+	return sign + "¥" + string(out)
+}
@@ -0,0 +1,254 @@
+// backend/internal/adapters/out/pdf/label_sheet_renderer.go
+package pdf
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"strings"
+
+	"narratives/internal/adapters/out/qrcode"
+	usecase "narratives/internal/application/usecase"
+	printdom "narratives/internal/domain/print"
+)
+
+// LabelSheetRenderer は PrintLog の QR ラベルを A4 のラベル用紙に面付けして描画します。
+// 1 ページに収まらないラベルは続きのページに描画します。
+type LabelSheetRenderer struct {
+	level qrcode.Level
+}
+
+var _ usecase.LabelSheetRenderer = (*LabelSheetRenderer)(nil)
+
+// NewLabelSheetRenderer は誤り訂正レベル M の QR コードで描画する Renderer を返します。
+func NewLabelSheetRenderer() *LabelSheetRenderer {
+	return &LabelSheetRenderer{level: qrcode.LevelM}
+}
+
+const (
+	ptPerMM = 72 / 25.4
+
+	// テキスト領域がこれより狭い場合は QR コードの下にテキストを置く
+	labelMinSideTextWidth = 18 * ptPerMM
+
+	labelMinFontSize = 5.0
+	labelMaxFontSize = 9.0
+	labelLineSpacing = 1.35
+
+	// ブランドロゴの埋め込み解像度の上限（長辺, px）
+	labelLogoMaxPixels = 256
+
+	// 隣接する矩形の間に viewer のアンチエイリアスで白い線が出ないよう、わずかに重ねる
+	labelQROverlap = 0.05
+)
+
+func (r *LabelSheetRenderer) RenderLabelSheet(
+	sheet printdom.LabelSheet,
+) ([]byte, error) {
+	tpl := sheet.Template
+	if err := tpl.Validate(); err != nil {
+		return nil, err
+	}
+
+	doc := NewDocument()
+
+	var logo *Image
+	if tpl.ShowBrandLogo && len(sheet.BrandLogo) > 0 {
+		if img, _, err := image.Decode(bytes.NewReader(sheet.BrandLogo)); err == nil {
+			logo = doc.AddImage(downscale(img, labelLogoMaxPixels))
+		}
+	}
+
+	perSheet := tpl.LabelsPerSheet()
+
+	var page *Page
+	for i, label := range sheet.Labels {
+		slot := i % perSheet
+		if slot == 0 {
+			page = doc.AddPage()
+		}
+
+		col := slot % tpl.Columns
+		row := slot / tpl.Columns
+
+		x := (tpl.MarginLeftMM + float64(col)*(tpl.LabelWidthMM+tpl.GapXMM)) * ptPerMM
+		y := (tpl.MarginTopMM + float64(row)*(tpl.LabelHeightMM+tpl.GapYMM)) * ptPerMM
+
+		if err := r.drawLabel(page, sheet, logo, label, x, y); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc.Bytes()
+}
+
+// drawLabel は左上 (x, y) に 1 枚分のラベルを描画します。
+func (r *LabelSheetRenderer) drawLabel(
+	page *Page,
+	sheet printdom.LabelSheet,
+	logo *Image,
+	label printdom.Label,
+	x, y float64,
+) error {
+	tpl := sheet.Template
+
+	w := tpl.LabelWidthMM * ptPerMM
+	h := tpl.LabelHeightMM * ptPerMM
+	pad := tpl.PaddingMM * ptPerMM
+	qr := tpl.EffectiveQRSizeMM() * ptPerMM
+
+	if tpl.ShowBorder {
+		page.SetGray(0.6)
+		page.Line(x, y, x+w, y, 0.25)
+		page.Line(x+w, y, x+w, y+h, 0.25)
+		page.Line(x+w, y+h, x, y+h, 0.25)
+		page.Line(x, y+h, x, y, 0.25)
+		page.SetGray(0)
+	}
+
+	// QR コードは左（横長のラベル）または上（縦長・幅が足りない場合）
+	var qrX, qrY, tx, ty, tw, th float64
+	if w-2*pad-qr >= labelMinSideTextWidth {
+		qrX, qrY = x+pad, y+(h-qr)/2
+		tx, ty = x+pad+qr, y+pad
+		tw, th = x+w-pad-tx, h-2*pad
+	} else {
+		qrX, qrY = x+(w-qr)/2, y+pad
+		tx, ty = x+pad, y+pad+qr
+		tw, th = w-2*pad, y+h-pad-ty
+	}
+
+	if err := r.drawQRCode(page, label.QRPayload, qrX, qrY, qr); err != nil {
+		return err
+	}
+
+	fontSize := math.Max(labelMinFontSize, math.Min(labelMaxFontSize, h/12))
+	lineHeight := fontSize * labelLineSpacing
+	bottom := ty + th
+
+	// productId はテキスト領域の下端に固定する
+	if tpl.ShowProductID {
+		size := math.Max(labelMinFontSize, fontSize*0.8)
+		if th >= size*labelLineSpacing {
+			page.Text(tx, bottom-size*0.25, size, Truncate(label.ProductID, size, tw))
+			bottom -= size * labelLineSpacing
+		}
+	}
+
+	cursor := ty
+
+	if tpl.ShowBrandLogo {
+		logoH := math.Min(lineHeight*2, (bottom-cursor)/2)
+		if logo != nil && logoH > 0 {
+			lw, lh := fitImage(logo, tw, logoH)
+			page.Image(logo, tx, cursor, lw, lh)
+			cursor += lh + fontSize*0.5
+		} else if sheet.BrandName != "" && cursor+lineHeight <= bottom {
+			page.Text(tx, cursor+fontSize, fontSize, Truncate(sheet.BrandName, fontSize, tw))
+			cursor += lineHeight
+		}
+	}
+
+	lines := make([]string, 0, 2)
+	if label.ProductName != "" {
+		lines = append(lines, label.ProductName)
+	}
+
+	variant := make([]string, 0, 2)
+	if label.ModelSize != "" {
+		variant = append(variant, label.ModelSize)
+	}
+	if label.ModelColor != "" {
+		variant = append(variant, label.ModelColor)
+	}
+	if len(variant) > 0 {
+		lines = append(lines, strings.Join(variant, " / "))
+	}
+
+	for _, line := range lines {
+		if cursor+lineHeight > bottom {
+			break
+		}
+		page.Text(tx, cursor+fontSize, fontSize, Truncate(line, fontSize, tw))
+		cursor += lineHeight
+	}
+
+	return nil
+}
+
+// drawQRCode は左上 (x, y)・一辺 size（クワイエットゾーンを含む）に QR コードを描画します。
+func (r *LabelSheetRenderer) drawQRCode(
+	page *Page,
+	payload string,
+	x, y, size float64,
+) error {
+	code, err := qrcode.Encode(payload, r.level)
+	if err != nil {
+		return err
+	}
+
+	n := code.Size()
+	m := size / float64(n+2*qrcode.QuietZone)
+	x0 := x + float64(qrcode.QuietZone)*m
+	y0 := y + float64(qrcode.QuietZone)*m
+
+	// 横方向に連続する暗モジュールを 1 つの矩形にまとめる
+	for my := 0; my < n; my++ {
+		for mx := 0; mx < n; mx++ {
+			if !code.Dark(mx, my) {
+				continue
+			}
+			run := 1
+			for code.Dark(mx+run, my) {
+				run++
+			}
+			page.Rect(
+				x0+float64(mx)*m,
+				y0+float64(my)*m,
+				float64(run)*m,
+				m+labelQROverlap,
+				true,
+			)
+			mx += run - 1
+		}
+	}
+
+	return nil
+}
+
+// fitImage は縦横比を保って maxW × maxH に収まる大きさを返します。
+func fitImage(img *Image, maxW, maxH float64) (float64, float64) {
+	if img.Width() == 0 || img.Height() == 0 {
+		return 0, 0
+	}
+
+	scale := math.Min(maxW/float64(img.Width()), maxH/float64(img.Height()))
+	return float64(img.Width()) * scale, float64(img.Height()) * scale
+}
+
+// downscale は長辺が maxSide を超える画像を縮小します（最近傍）。
+func downscale(img image.Image, maxSide int) image.Image {
+	b := img.Bounds()
+	side := max(b.Dx(), b.Dy())
+	if side <= maxSide {
+		return img
+	}
+
+	w := max(b.Dx()*maxSide/side, 1)
+	h := max(b.Dy()*maxSide/side, 1)
+
+	out := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			out.Set(x, y, img.At(
+				b.Min.X+x*b.Dx()/w,
+				b.Min.Y+y*b.Dy()/h,
+			))
+		}
+	}
+
+	return out
+}
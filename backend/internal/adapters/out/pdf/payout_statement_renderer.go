@@ -0,0 +1,185 @@
+// backend/internal/adapters/out/pdf/payout_statement_renderer.go
+package pdf
+
+import (
+	"fmt"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	payoutdom "narratives/internal/domain/payout"
+)
+
+// PayoutStatementRenderer は支払明細書を A4 の PDF に描画します。
+// 明細が 1 ページに収まらない場合は続きのページに描画します。
+type PayoutStatementRenderer struct {
+	location *time.Location
+}
+
+var _ usecase.PayoutStatementRenderer = (*PayoutStatementRenderer)(nil)
+
+func NewPayoutStatementRenderer() *PayoutStatementRenderer {
+	return &PayoutStatementRenderer{
+		location: time.FixedZone("JST", 9*60*60),
+	}
+}
+
+// layout（pt, 左上原点）
+const (
+	statementMarginX = 48.0
+	statementRight   = A4Width - statementMarginX
+	statementBottom  = A4Height - 72.0
+
+	statementRowHeight = 18.0
+
+	colStatementDate   = statementMarginX + 4
+	colStatementSource = 120.0
+	colStatementOrder  = 200.0
+	colStatementGross  = 400.0
+	colStatementFee    = 460.0
+	colStatementNet    = statementRight - 4
+)
+
+func (r *PayoutStatementRenderer) RenderPayoutStatement(
+	s payoutdom.Statement,
+) ([]byte, error) {
+	doc := NewDocument()
+	page := doc.AddPage()
+
+	page.TextCenter(A4Width/2, 72, 20, "支払明細書")
+
+	// 右上: バッチ・日付
+	page.TextRight(statementRight, 104, 9, "No. "+s.BatchID)
+	page.TextRight(statementRight, 118, 9, "発行日: "+r.date(s.IssuedAt))
+	page.TextRight(statementRight, 132, 9, "締め日時: "+r.datetime(s.CutoffAt))
+	page.TextRight(statementRight, 146, 9, "振込予定日: "+r.date(s.TransferDate))
+
+	// 受取先・支払元
+	page.Text(statementMarginX, 112, 12, s.Account.HolderNameKana+" 様")
+	page.Text(statementMarginX, 130, 9, fmt.Sprintf("受取先: %s %s", s.RecipientType, s.RecipientID))
+	page.Text(statementMarginX, 144, 9, "支払元: "+s.CompanyName)
+
+	// 振込金額
+	page.SetGray(0.93)
+	page.Rect(statementMarginX, 170, statementRight-statementMarginX, 36, true)
+	page.SetGray(0)
+	page.Text(statementMarginX+12, 194, 12, "お振込金額")
+	page.TextRight(statementRight-12, 195, 16, yen(s.NetAmount)+"-")
+
+	page.Text(statementMarginX, 222, 9, fmt.Sprintf(
+		"振込先: %s（%s） %s（%s） %s %s",
+		s.Account.BankName,
+		s.Account.BankCode,
+		s.Account.BranchName,
+		s.Account.BranchCode,
+		accountTypeLabel(s.Account.AccountType),
+		s.Account.AccountNumber,
+	))
+
+	y := r.renderTableHeader(page, 246)
+
+	for _, e := range s.Entries {
+		page, y = r.ensureSpace(doc, page, y, 1)
+
+		page.Text(colStatementDate, y, 9, r.date(e.AccruedAt))
+		page.Text(colStatementSource, y, 9, sourceLabel(e.Source))
+		page.Text(colStatementOrder, y, 9, Truncate(
+			fmt.Sprintf("%s #%d", e.OrderID, e.ItemIndex+1),
+			9,
+			colStatementGross-colStatementOrder-60,
+		))
+		page.TextRight(colStatementGross, y, 9, yen(e.GrossAmount))
+		page.TextRight(colStatementFee, y, 9, yen(e.FeeAmount))
+		page.TextRight(colStatementNet, y, 9, yen(e.NetAmount))
+		y += statementRowHeight
+	}
+
+	page.Line(statementMarginX, y-12, statementRight, y-12, 0.5)
+
+	page, y = r.ensureSpace(doc, page, y+4, 5)
+
+	labelX := 300.0
+	page.Text(labelX, y, 9, "売上・受取額合計")
+	page.TextRight(colStatementNet, y, 9, yen(s.GrossAmount))
+	y += statementRowHeight - 4
+	page.Text(labelX, y, 9, "プラットフォーム手数料")
+	page.TextRight(colStatementNet, y, 9, "-"+yen(s.FeeAmount))
+	y += statementRowHeight - 4
+	page.Text(labelX, y, 10, "お振込金額")
+	page.TextRight(colStatementNet, y, 10, yen(s.NetAmount))
+	y += statementRowHeight * 2
+
+	page.Text(statementMarginX, y, 8, "手数料は計上時点の料率で計算し、1円未満を切り捨てています。")
+	y += 12
+	page.Text(statementMarginX, y, 8, "返金調整は返金された数量の割合で計上済みの金額・手数料を差し引いたものです。")
+
+	return doc.Bytes()
+}
+
+// renderTableHeader は明細の見出しを描画し、最初の行の y を返します。
+func (r *PayoutStatementRenderer) renderTableHeader(page *Page, y float64) float64 {
+	page.SetGray(0.93)
+	page.Rect(statementMarginX, y-12, statementRight-statementMarginX, 18, true)
+	page.SetGray(0)
+
+	page.Text(colStatementDate, y, 9, "計上日")
+	page.Text(colStatementSource, y, 9, "区分")
+	page.Text(colStatementOrder, y, 9, "注文番号")
+	page.TextRight(colStatementGross, y, 9, "金額")
+	page.TextRight(colStatementFee, y, 9, "手数料")
+	page.TextRight(colStatementNet, y, 9, "支払額")
+
+	return y + statementRowHeight + 4
+}
+
+// ensureSpace は rows 行が収まらない場合に改ページし、描画先と y を返します。
+func (r *PayoutStatementRenderer) ensureSpace(
+	doc *Document,
+	page *Page,
+	y float64,
+	rows int,
+) (*Page, float64) {
+	if y+float64(rows)*statementRowHeight <= statementBottom {
+		return page, y
+	}
+
+	next := doc.AddPage()
+	next.TextRight(statementRight, 48, 8, "（続き）")
+
+	return next, r.renderTableHeader(next, 72)
+}
+
+func (r *PayoutStatementRenderer) date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(r.location).Format("2006年1月2日")
+}
+
+func (r *PayoutStatementRenderer) datetime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(r.location).Format("2006年1月2日 15:04")
+}
+
+func sourceLabel(s payoutdom.Source) string {
+	switch s {
+	case payoutdom.SourceBrandSale:
+		return "販売代金"
+	case payoutdom.SourceRoyalty:
+		return "ロイヤリティ"
+	case payoutdom.SourceResaleProceeds:
+		return "二次流通売上"
+	case payoutdom.SourceRefundAdjustment:
+		return "返金調整"
+	default:
+		return string(s)
+	}
+}
+
+func accountTypeLabel(zenginType string) string {
+	if zenginType == "2" {
+		return "当座"
+	}
+	return "普通"
+}
@@ -0,0 +1,501 @@
+// backend/internal/adapters/out/qrcode/encoder.go
+package qrcode
+
+/*
+QR コード（JIS X 0510 / ISO/IEC 18004, Model 2）の最小限のエンコーダ。
+
+外部ライブラリを使わず、次の範囲だけを扱います:
+  - 8bit byte モード（製品 URL を UTF-8 のまま格納）
+  - 誤り訂正レベル L / M / Q / H、型番 1〜40
+  - 8 種類のマスクから失点が最小のものを選択
+
+型番は収まる最小のものを選び、同じ型番に収まる範囲で誤り訂正レベルを引き上げます。
+*/
+
+import (
+	"errors"
+)
+
+// Level は誤り訂正レベルです。
+type Level int
+
+const (
+	LevelL Level = iota // 約 7%
+	LevelM              // 約 15%
+	LevelQ              // 約 25%
+	LevelH              // 約 30%
+)
+
+const (
+	minVersion = 1
+	maxVersion = 40
+
+	modeByte = 0x4
+)
+
+var (
+	ErrDataTooLong  = errors.New("qrcode: data too long")
+	ErrInvalidLevel = errors.New("qrcode: invalid error correction level")
+)
+
+// Code はエンコード済みの QR コード（モジュールの行列）です。
+type Code struct {
+	Version int
+	Level   Level
+	Mask    int
+
+	size       int
+	modules    []bool
+	isFunction []bool
+}
+
+// Size は 1 辺のモジュール数です（クワイエットゾーンを含まない）。
+func (c *Code) Size() int { return c.size }
+
+// Dark は (x, y) のモジュールが暗（黒）かどうかを返します。範囲外は明です。
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.size || y >= c.size {
+		return false
+	}
+	return c.modules[y*c.size+x]
+}
+
+// Encode は text を byte モードでエンコードします。
+func Encode(text string, level Level) (*Code, error) {
+	if level < LevelL || level > LevelH {
+		return nil, ErrInvalidLevel
+	}
+
+	data := []byte(text)
+
+	version := 0
+	for v := minVersion; v <= maxVersion; v++ {
+		if dataBitsNeeded(v, len(data)) <= numDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrDataTooLong
+	}
+
+	for l := level + 1; l <= LevelH; l++ {
+		if dataBitsNeeded(version, len(data)) <= numDataCodewords(version, l)*8 {
+			level = l
+		}
+	}
+
+	c := newCode(data, version, level)
+
+	best, minPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if p := c.penalty(); minPenalty < 0 || p < minPenalty {
+			best, minPenalty = mask, p
+		}
+		c.applyMask(mask) // XOR なので同じマスクで元に戻る
+	}
+
+	c.Mask = best
+	c.applyMask(best)
+	c.drawFormatBits(best)
+	c.isFunction = nil
+
+	return c, nil
+}
+
+// newCode は機能パターンとデータを配置した（マスク前の）Code を返します。
+func newCode(data []byte, version int, level Level) *Code {
+	// データビット列
+	var bb bitBuffer
+	bb.append(modeByte, 4)
+	bb.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	capacity := numDataCodewords(version, level) * 8
+	bb.append(0, min(4, capacity-bb.len()))
+	bb.append(0, (8-bb.len()%8)%8)
+	for pad := 0xEC; bb.len() < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	c := &Code{
+		Version: version,
+		Level:   level,
+	}
+	c.size = version*4 + 17
+	c.modules = make([]bool, c.size*c.size)
+	c.isFunction = make([]bool, c.size*c.size)
+
+	c.drawFunctionPatterns()
+	c.drawCodewords(addECCAndInterleave(bb.bytes(), version, level))
+
+	return c
+}
+
+func dataBitsNeeded(version, n int) int {
+	return 4 + charCountBits(version) + n*8
+}
+
+// charCountBits は byte モードの文字数指示子のビット数です。
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// ============================================================
+// Function patterns
+// ============================================================
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y*c.size+x] = dark
+	c.isFunction[y*c.size+x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	// タイミングパターン
+	for i := 0; i < c.size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	// 位置検出パターン（分離パターンを含む）
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(c.size-4, 3)
+	c.drawFinderPattern(3, c.size-4)
+
+	// 位置合わせパターン（位置検出パターンと重なる 3 箇所を除く）
+	positions := alignmentPatternPositions(c.Version)
+	n := len(positions)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			c.drawAlignmentPattern(positions[i], positions[j])
+		}
+	}
+
+	// 形式情報は領域だけ確保し、マスク決定後に書き込む
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+func (c *Code) drawFinderPattern(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= c.size || y >= c.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignmentPattern(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// alignmentPatternPositions は位置合わせパターンの中心座標（行・列共通）です。
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+
+	num := version/7 + 2
+	step := (version*8 + num*3 + 5) / (num*4 - 4) * 2
+
+	out := make([]int, num)
+	out[0] = 6
+	for i, pos := num-1, version*4+17-7; i >= 1; i, pos = i-1, pos-step {
+		out[i] = pos
+	}
+
+	return out
+}
+
+// drawFormatBits は誤り訂正レベルとマスクの形式情報（BCH(15,5)）を 2 箇所に書き込みます。
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatInfo(c.Level, mask)
+
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.size-8, true) // 常に暗のモジュール
+}
+
+// drawVersion は型番情報（BCH(18,6)）を書き込みます（型番 7 以上）。
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+
+	bits := versionInfo(c.Version)
+
+	for i := 0; i < 18; i++ {
+		a := c.size - 11 + i%3
+		b := i / 3
+		c.setFunction(a, b, bit(bits, i))
+		c.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// formatInfo は形式情報の 15 bit（マスク 101010000010010 適用済み）です。
+func formatInfo(level Level, mask int) int {
+	data := level.formatBits()<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionInfo は型番情報の 18 bit です。
+func versionInfo(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+func (l Level) formatBits() int {
+	switch l {
+	case LevelL:
+		return 1
+	case LevelM:
+		return 0
+	case LevelQ:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// ============================================================
+// Codewords
+// ============================================================
+
+// drawCodewords は右下から 2 列ずつジグザグにデータを配置します。
+func (c *Code) drawCodewords(data []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // 縦のタイミングパターンを飛ばす
+		}
+		upward := (right+1)&2 == 0
+
+		for vert := 0; vert < c.size; vert++ {
+			y := vert
+			if upward {
+				y = c.size - 1 - vert
+			}
+
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.isFunction[y*c.size+x] || i >= len(data)*8 {
+					continue
+				}
+				c.modules[y*c.size+x] = bit(int(data[i>>3]), 7-(i&7))
+				i++
+			}
+		}
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.isFunction[y*c.size+x] {
+				continue
+			}
+
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			default:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+
+			if invert {
+				c.modules[y*c.size+x] = !c.modules[y*c.size+x]
+			}
+		}
+	}
+}
+
+// addECCAndInterleave はブロックごとに誤り訂正符号語を付け、インターリーブします。
+func addECCAndInterleave(data []byte, version int, level Level) []byte {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(eccLen)
+
+	blocks := make([][]byte, 0, numBlocks)
+	k := 0
+	for i := 0; i < numBlocks; i++ {
+		datLen := shortBlockLen - eccLen
+		if i >= numShortBlocks {
+			datLen++
+		}
+
+		dat := data[k : k+datLen]
+		k += datLen
+
+		// 短いブロックは長さを揃えるため、データの末尾に読み飛ばす 1 byte を置く
+		block := make([]byte, shortBlockLen+1)
+		copy(block, dat)
+		copy(block[len(block)-eccLen:], reedSolomonRemainder(dat, divisor))
+		blocks = append(blocks, block)
+	}
+
+	out := make([]byte, 0, rawCodewords)
+	for i := 0; i < shortBlockLen+1; i++ {
+		for j, block := range blocks {
+			if i == shortBlockLen-eccLen && j < numShortBlocks {
+				continue
+			}
+			out = append(out, block[i])
+		}
+	}
+
+	return out
+}
+
+func numRawDataModules(version int) int {
+	n := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		n -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 -
+		eccCodewordsPerBlock[level][version]*numErrorCorrectionBlocks[level][version]
+}
+
+// ============================================================
+// Reed-Solomon (GF(2^8), x^8 + x^4 + x^3 + x^2 + 1)
+// ============================================================
+
+func reedSolomonDivisor(degree int) []byte {
+	out := make([]byte, degree)
+	out[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range out {
+			out[j] = gfMultiply(out[j], root)
+			if j+1 < len(out) {
+				out[j] ^= out[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return out
+}
+
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	out := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ out[0]
+		copy(out, out[1:])
+		out[len(out)-1] = 0
+		for i := range out {
+			out[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return out
+}
+
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// ============================================================
+// Helpers
+// ============================================================
+
+type bitBuffer struct {
+	bits []bool
+}
+
+func (b *bitBuffer) len() int { return len(b.bits) }
+
+func (b *bitBuffer) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		b.bits = append(b.bits, (v>>i)&1 == 1)
+	}
+}
+
+func (b *bitBuffer) bytes() []byte {
+	out := make([]byte, (len(b.bits)+7)/8)
+	for i, v := range b.bits {
+		if v {
+			out[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return out
+}
+
+func bit(v, i int) bool {
+	return (v>>i)&1 != 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,432 @@
+// backend/internal/adapters/out/qrcode/encoder_test.go
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// ISO/IEC 18004 Annex I の例（"HELLO WORLD", 1-M）の符号語。
+var (
+	helloWorld1MData = []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	helloWorld1MECC  = []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+)
+
+func TestReedSolomonRemainder_KnownAnswer(t *testing.T) {
+	got := reedSolomonRemainder(
+		helloWorld1MData,
+		reedSolomonDivisor(eccCodewordsPerBlock[LevelM][1]),
+	)
+
+	if !bytes.Equal(got, helloWorld1MECC) {
+		t.Fatalf("ecc = %v, want %v", got, helloWorld1MECC)
+	}
+}
+
+func TestAddECCAndInterleave_SingleBlock(t *testing.T) {
+	got := addECCAndInterleave(helloWorld1MData, 1, LevelM)
+
+	want := append(append([]byte(nil), helloWorld1MData...), helloWorld1MECC...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("codewords = %v, want %v", got, want)
+	}
+}
+
+func TestFormatInfo_KnownAnswer(t *testing.T) {
+	tests := []struct {
+		level Level
+		mask  int
+		want  int
+	}{
+		{LevelM, 0, 0b101010000010010},
+		{LevelL, 0, 0b111011111000100},
+		{LevelL, 7, 0b110100101110110},
+		{LevelQ, 0, 0b011010101011111},
+		{LevelH, 0, 0b001011010001001},
+		{LevelH, 7, 0b000100000111011},
+	}
+
+	for _, tt := range tests {
+		if got := formatInfo(tt.level, tt.mask); got != tt.want {
+			t.Errorf("formatInfo(%d, %d) = %015b, want %015b", tt.level, tt.mask, got, tt.want)
+		}
+	}
+}
+
+func TestVersionInfo_KnownAnswer(t *testing.T) {
+	tests := []struct {
+		version int
+		want    int
+	}{
+		{7, 0x07C94},
+		{8, 0x085BC},
+		{21, 0x15683},
+		{40, 0x28C69},
+	}
+
+	for _, tt := range tests {
+		if got := versionInfo(tt.version); got != tt.want {
+			t.Errorf("versionInfo(%d) = %#05x, want %#05x", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestEncode_ChoosesSmallestVersion(t *testing.T) {
+	// byte モードの容量（JIS X 0510 表 7）
+	tests := []struct {
+		version  int
+		level    Level
+		capacity int
+	}{
+		{1, LevelL, 17},
+		{1, LevelM, 14},
+		{1, LevelQ, 11},
+		{1, LevelH, 7},
+		{10, LevelL, 271},
+		{10, LevelH, 119},
+		{10, LevelM, 213},
+		{40, LevelM, 2331},
+		{40, LevelL, 2953},
+		{40, LevelH, 1273},
+	}
+
+	for _, tt := range tests {
+		c, err := Encode(strings.Repeat("a", tt.capacity), tt.level)
+		if err != nil {
+			t.Fatalf("Encode(%d bytes, %d): %v", tt.capacity, tt.level, err)
+		}
+		if c.Version != tt.version || c.Level != tt.level {
+			t.Errorf("Encode(%d bytes, %d) = %d-%d, want %d-%d",
+				tt.capacity, tt.level, c.Version, c.Level, tt.version, tt.level)
+		}
+
+		c, err = Encode(strings.Repeat("a", tt.capacity+1), tt.level)
+		if tt.version == maxVersion {
+			if !errors.Is(err, ErrDataTooLong) {
+				t.Errorf("Encode(%d bytes, %d) err = %v, want %v",
+					tt.capacity+1, tt.level, err, ErrDataTooLong)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Encode(%d bytes, %d): %v", tt.capacity+1, tt.level, err)
+		}
+		if c.Version != tt.version+1 {
+			t.Errorf("Encode(%d bytes, %d) version = %d, want %d",
+				tt.capacity+1, tt.level, c.Version, tt.version+1)
+		}
+	}
+}
+
+func TestEncode_RaisesLevelWithinVersion(t *testing.T) {
+	c, err := Encode("abc", LevelL)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if c.Version != 1 || c.Level != LevelH {
+		t.Fatalf("Encode = %d-%d, want 1-%d", c.Version, c.Level, LevelH)
+	}
+}
+
+func TestEncode_InvalidLevel(t *testing.T) {
+	for _, level := range []Level{LevelL - 1, LevelH + 1} {
+		if _, err := Encode("abc", level); !errors.Is(err, ErrInvalidLevel) {
+			t.Errorf("Encode(level %d) err = %v, want %v", level, err, ErrInvalidLevel)
+		}
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	texts := []string{
+		"",
+		"https://example.com/p/製品-0001",
+		strings.Repeat("0123456789", 3),
+		strings.Repeat("narratives", 15),
+		strings.Repeat("abcdefghijklmnopqrstuvwxyz", 20),
+		strings.Repeat("ABCDEFGHIJKLMNOP", 70),
+		strings.Repeat("z", 1273),
+	}
+
+	for _, text := range texts {
+		for _, level := range []Level{LevelL, LevelM, LevelQ, LevelH} {
+			c, err := Encode(text, level)
+			if err != nil {
+				t.Fatalf("Encode(%d bytes, %d): %v", len(text), level, err)
+			}
+			if c.Level < level {
+				t.Fatalf("Encode(%d bytes, %d) lowered level to %d", len(text), level, c.Level)
+			}
+
+			got, err := decodeForTest(c)
+			if err != nil {
+				t.Fatalf("decode %d-%d (%d bytes): %v", c.Version, c.Level, len(text), err)
+			}
+			if got != text {
+				t.Fatalf("decode %d-%d = %q, want %q", c.Version, c.Level, got, text)
+			}
+		}
+	}
+}
+
+func TestEncode_RoundTripEveryMask(t *testing.T) {
+	for _, version := range []int{1, 7, 20} {
+		text := strings.Repeat("m", numDataCodewords(version, LevelQ)-3)
+
+		for mask := 0; mask < 8; mask++ {
+			c := newCode([]byte(text), version, LevelQ)
+			c.applyMask(mask)
+			c.drawFormatBits(mask)
+			c.Mask = mask
+			c.isFunction = nil
+
+			got, err := decodeForTest(c)
+			if err != nil {
+				t.Fatalf("decode version %d mask %d: %v", version, mask, err)
+			}
+			if got != text {
+				t.Fatalf("decode version %d mask %d = %q, want %q", version, mask, got, text)
+			}
+		}
+	}
+}
+
+func TestDecodeForTest_DetectsCorruption(t *testing.T) {
+	c, err := Encode("https://example.com/p/0001", LevelM)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	// 右下のモジュールは常にデータ領域
+	last := c.size*c.size - 1
+	c.modules[last] = !c.modules[last]
+
+	if _, err := decodeForTest(c); err == nil {
+		t.Fatal("decode of corrupted code succeeded")
+	}
+}
+
+// ============================================================
+// Test decoder
+// ============================================================
+
+// testMasks は JIS X 0510 表 10 のマスク条件です（x は列、y は行）。
+var testMasks = [8]func(x, y int) bool{
+	func(x, y int) bool { return (y+x)%2 == 0 },
+	func(x, y int) bool { return y%2 == 0 },
+	func(x, y int) bool { return x%3 == 0 },
+	func(x, y int) bool { return (y+x)%3 == 0 },
+	func(x, y int) bool { return (y/2+x/3)%2 == 0 },
+	func(x, y int) bool { return (y*x)%2+(y*x)%3 == 0 },
+	func(x, y int) bool { return ((y*x)%2+(y*x)%3)%2 == 0 },
+	func(x, y int) bool { return ((y+x)%2+(y*x)%3)%2 == 0 },
+}
+
+// decodeForTest は誤りのない Code を読み取り、byte モードのデータを返します。
+func decodeForTest(c *Code) (string, error) {
+	size := c.Size()
+	if (size-17)%4 != 0 {
+		return "", fmt.Errorf("invalid size %d", size)
+	}
+	version := (size - 17) / 4
+
+	level, mask, err := readFormatForTest(c)
+	if err != nil {
+		return "", err
+	}
+	if level != c.Level || mask != c.Mask {
+		return "", fmt.Errorf("format = %d/%d, code = %d/%d", level, mask, c.Level, c.Mask)
+	}
+
+	if version >= 7 {
+		if got := readVersionForTest(c); got != versionInfo(version) {
+			return "", fmt.Errorf("version info = %#x, want %#x", got, versionInfo(version))
+		}
+	}
+
+	layout := &Code{
+		Version:    version,
+		Level:      level,
+		size:       size,
+		modules:    make([]bool, size*size),
+		isFunction: make([]bool, size*size),
+	}
+	layout.drawFunctionPatterns()
+
+	var bits []bool
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+
+		for vert := 0; vert < size; vert++ {
+			y := vert
+			if upward {
+				y = size - 1 - vert
+			}
+			for x := right; x >= right-1; x-- {
+				if layout.isFunction[y*size+x] {
+					continue
+				}
+				bits = append(bits, c.Dark(x, y) != testMasks[mask](x, y))
+			}
+		}
+	}
+
+	rawCodewords := len(bits) / 8
+	if rawCodewords != numRawDataModules(version)/8 {
+		return "", fmt.Errorf("raw codewords = %d, want %d", rawCodewords, numRawDataModules(version)/8)
+	}
+
+	codewords := make([]byte, rawCodewords)
+	for i := range codewords {
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				codewords[i] |= 1 << (7 - j)
+			}
+		}
+	}
+
+	data, err := deinterleaveForTest(codewords, version, level)
+	if err != nil {
+		return "", err
+	}
+
+	return parseByteModeForTest(data, version)
+}
+
+func readFormatForTest(c *Code) (Level, int, error) {
+	size := c.Size()
+
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first |= boolBit(c.Dark(8, i)) << i
+	}
+	first |= boolBit(c.Dark(8, 7)) << 6
+	first |= boolBit(c.Dark(8, 8)) << 7
+	first |= boolBit(c.Dark(7, 8)) << 8
+	for i := 9; i < 15; i++ {
+		first |= boolBit(c.Dark(14-i, 8)) << i
+	}
+
+	for i := 0; i < 8; i++ {
+		second |= boolBit(c.Dark(size-1-i, 8)) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= boolBit(c.Dark(8, size-15+i)) << i
+	}
+
+	if first != second {
+		return 0, 0, fmt.Errorf("format copies differ: %015b / %015b", first, second)
+	}
+	if !c.Dark(8, size-8) {
+		return 0, 0, errors.New("dark module is light")
+	}
+
+	for _, level := range []Level{LevelL, LevelM, LevelQ, LevelH} {
+		for mask := 0; mask < 8; mask++ {
+			if formatInfo(level, mask) == first {
+				return level, mask, nil
+			}
+		}
+	}
+
+	return 0, 0, fmt.Errorf("unknown format %015b", first)
+}
+
+func readVersionForTest(c *Code) int {
+	size := c.Size()
+
+	bits := 0
+	for i := 0; i < 18; i++ {
+		if c.Dark(size-11+i%3, i/3) {
+			bits |= 1 << i
+		}
+	}
+	return bits
+}
+
+// deinterleaveForTest はブロックに分け、シンドロームが 0 であることを確認してデータを返します。
+func deinterleaveForTest(codewords []byte, version int, level Level) ([]byte, error) {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	numShortBlocks := numBlocks - len(codewords)%numBlocks
+	shortBlockLen := len(codewords) / numBlocks
+
+	// 短いブロックはデータが 1 byte 少ない
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortBlockLen+1; i++ {
+		for j := range blocks {
+			if i == shortBlockLen-eccLen && j < numShortBlocks {
+				continue
+			}
+			blocks[j] = append(blocks[j], codewords[k])
+			k++
+		}
+	}
+
+	var data []byte
+	for j, block := range blocks {
+		for i := 0; i < eccLen; i++ {
+			if s := syndromeForTest(block, i); s != 0 {
+				return nil, fmt.Errorf("block %d syndrome %d = %d", j, i, s)
+			}
+		}
+		data = append(data, block[:len(block)-eccLen]...)
+	}
+
+	return data, nil
+}
+
+// syndromeForTest は符号語多項式を α^i で評価します。
+func syndromeForTest(block []byte, i int) byte {
+	alpha := byte(1)
+	for n := 0; n < i; n++ {
+		alpha = gfMultiply(alpha, 0x02)
+	}
+
+	var s byte
+	for _, b := range block {
+		s = gfMultiply(s, alpha) ^ b
+	}
+	return s
+}
+
+func parseByteModeForTest(data []byte, version int) (string, error) {
+	pos := 0
+	read := func(n int) int {
+		v := 0
+		for i := 0; i < n; i++ {
+			v = v<<1 | int(data[pos>>3]>>(7-pos&7)&1)
+			pos++
+		}
+		return v
+	}
+
+	if mode := read(4); mode != modeByte {
+		return "", fmt.Errorf("mode = %#x, want %#x", mode, modeByte)
+	}
+
+	n := read(charCountBits(version))
+	if 4+charCountBits(version)+n*8 > len(data)*8 {
+		return "", fmt.Errorf("char count %d exceeds capacity", n)
+	}
+
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = byte(read(8))
+	}
+
+	return string(out), nil
+}
+
+func boolBit(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
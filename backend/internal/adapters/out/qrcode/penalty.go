@@ -0,0 +1,90 @@
+// backend/internal/adapters/out/qrcode/penalty.go
+package qrcode
+
+// マスク評価の失点（JIS X 0510 8.8.2）
+const (
+	penaltyN1 = 3
+	penaltyN2 = 3
+	penaltyN3 = 40
+	penaltyN4 = 10
+)
+
+// finderLikePatterns は 1:1:3:1:1 の暗明パターン（前後の明 4 モジュールを含む）です。
+var finderLikePatterns = [2][11]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func (c *Code) penalty() int {
+	total := 0
+
+	// N1: 同色の 5 モジュール以上の連続（行・列）
+	for y := 0; y < c.size; y++ {
+		total += runPenalty(c.size, func(i int) bool { return c.Dark(i, y) })
+	}
+	for x := 0; x < c.size; x++ {
+		total += runPenalty(c.size, func(i int) bool { return c.Dark(x, i) })
+	}
+
+	// N2: 同色の 2x2 ブロック
+	for y := 0; y < c.size-1; y++ {
+		for x := 0; x < c.size-1; x++ {
+			d := c.Dark(x, y)
+			if d == c.Dark(x+1, y) && d == c.Dark(x, y+1) && d == c.Dark(x+1, y+1) {
+				total += penaltyN2
+			}
+		}
+	}
+
+	// N3: 位置検出パターンに似たパターン（行・列）
+	for y := 0; y < c.size; y++ {
+		for x := 0; x+11 <= c.size; x++ {
+			for _, p := range finderLikePatterns {
+				if matchPattern(p, func(i int) bool { return c.Dark(x+i, y) }) {
+					total += penaltyN3
+				}
+				if matchPattern(p, func(i int) bool { return c.Dark(y, x+i) }) {
+					total += penaltyN3
+				}
+			}
+		}
+	}
+
+	// N4: 暗モジュールの比率の 50% からの偏り（5% ごと）
+	dark := 0
+	for _, m := range c.modules {
+		if m {
+			dark++
+		}
+	}
+	n := len(c.modules)
+	k := (abs(dark*20-n*10)+n-1)/n - 1
+	total += max(k, 0) * penaltyN4
+
+	return total
+}
+
+func runPenalty(n int, dark func(i int) bool) int {
+	total := 0
+	run := 1
+	for i := 1; i <= n; i++ {
+		if i < n && dark(i) == dark(i-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			total += penaltyN1 + run - 5
+		}
+		run = 1
+	}
+	return total
+}
+
+func matchPattern(p [11]bool, dark func(i int) bool) bool {
+	for i, v := range p {
+		if dark(i) != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+// backend/internal/adapters/out/qrcode/renderer.go
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	usecase "narratives/internal/application/usecase"
+)
+
+// QuietZone は QR コードの周囲に必要な余白（モジュール数）です。
+const QuietZone = 4
+
+// Renderer は QR コードを PNG / SVG に変換します。
+type Renderer struct {
+	level Level
+}
+
+var _ usecase.QRCodeRenderer = (*Renderer)(nil)
+
+// NewRenderer は誤り訂正レベル M（汚れ・かすれに対して約 15%）の Renderer を返します。
+func NewRenderer() *Renderer {
+	return &Renderer{level: LevelM}
+}
+
+// RenderQRCodePNG は一辺がおよそ sizePx（モジュールの整数倍に切り下げ）の PNG を返します。
+func (r *Renderer) RenderQRCodePNG(payload string, sizePx int) ([]byte, error) {
+	code, err := Encode(payload, r.level)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := code.Size() + 2*QuietZone
+	scale := max(sizePx/modules, 1)
+
+	img := image.NewPaletted(
+		image.Rect(0, 0, modules*scale, modules*scale),
+		color.Palette{color.White, color.Black},
+	)
+	for y := 0; y < code.Size(); y++ {
+		for x := 0; x < code.Size(); x++ {
+			if !code.Dark(x, y) {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				row := img.Pix[((y+QuietZone)*scale+dy)*img.Stride:]
+				for dx := 0; dx < scale; dx++ {
+					row[(x+QuietZone)*scale+dx] = 1
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RenderQRCodeSVG はモジュール単位の viewBox を持つ SVG を返します（印刷時に任意の大きさへ拡大できる）。
+func (r *Renderer) RenderQRCodeSVG(payload string) ([]byte, error) {
+	code, err := Encode(payload, r.level)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := code.Size() + 2*QuietZone
+
+	var buf bytes.Buffer
+	fmt.Fprintf(
+		&buf,
+		`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+			`<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n"+
+			`<rect width="100%%" height="100%%" fill="#FFFFFF"/>`+"\n"+
+			`<path fill="#000000" d="`,
+		modules,
+		modules,
+	)
+
+	// 横方向に連続する暗モジュールを 1 つの矩形にまとめる
+	for y := 0; y < code.Size(); y++ {
+		for x := 0; x < code.Size(); x++ {
+			if !code.Dark(x, y) {
+				continue
+			}
+			run := 1
+			for code.Dark(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&buf, "M%d,%dh%dv1h-%dz", x+QuietZone, y+QuietZone, run, run)
+			x += run - 1
+		}
+	}
+
+	buf.WriteString(`"/>` + "\n</svg>\n")
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+// backend/internal/adapters/out/stripe/payment_intent_cancel_gateway.go
+package stripe
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+)
+
+var _ usecase.StripePaymentIntentCanceler = (*PaymentMethodGateway)(nil)
+
+// CancelPaymentIntent cancels a Stripe PaymentIntent that has not been
+// captured yet and returns its resulting status.
+//
+// Stripe rejects cancelling a PaymentIntent that is already canceled or has
+// succeeded, but includes the PaymentIntent in the error. An already canceled
+// PaymentIntent is reported as "canceled" without error so that retries are
+// idempotent; any other rejected state is returned together with the error.
+func (g *PaymentMethodGateway) CancelPaymentIntent(
+	ctx context.Context,
+	stripePaymentIntentID string,
+	reason string,
+) (string, error) {
+	if err := g.validateReady(); err != nil {
+		return "", err
+	}
+
+	stripePaymentIntentID = strings.TrimSpace(stripePaymentIntentID)
+	if stripePaymentIntentID == "" {
+		return "", errors.New("stripe payment intent id is empty")
+	}
+
+	form := url.Values{}
+	if reason = strings.TrimSpace(reason); reason != "" {
+		form.Set("cancellation_reason", reason)
+	}
+
+	var out stripePaymentIntentResponse
+	requestErr := g.postFormWithIdempotencyKey(
+		ctx,
+		"/payment_intents/"+url.PathEscape(stripePaymentIntentID)+"/cancel",
+		form,
+		"cancel_"+stripePaymentIntentID,
+		&out,
+	)
+
+	status := strings.TrimSpace(out.Status)
+
+	if requestErr != nil {
+		if status == "canceled" {
+			return status, nil
+		}
+		return status, requestErr
+	}
+
+	return status, nil
+}
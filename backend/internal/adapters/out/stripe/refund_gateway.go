@@ -0,0 +1,82 @@
+// backend/internal/adapters/out/stripe/refund_gateway.go
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	usecase "narratives/internal/application/usecase"
+)
+
+var _ usecase.StripeRefundGateway = (*PaymentMethodGateway)(nil)
+
+// CreateRefund creates a Stripe Refund against a PaymentIntent.
+//
+// The refund document ID is sent as the Stripe Idempotency-Key and as
+// metadata[refundId], so a retried request never refunds twice and the
+// charge.refunded webhook can be mapped back to refunds/{refundId}.
+func (g *PaymentMethodGateway) CreateRefund(
+	ctx context.Context,
+	in usecase.CreateStripeRefundInput,
+) (*usecase.CreateStripeRefundResult, error) {
+	if err := g.validateReady(); err != nil {
+		return nil, err
+	}
+
+	paymentIntentID := strings.TrimSpace(in.StripePaymentIntentID)
+	refundID := strings.TrimSpace(in.RefundID)
+
+	if paymentIntentID == "" {
+		return nil, errors.New("stripe refund payment intent id is empty")
+	}
+	if refundID == "" {
+		return nil, errors.New("stripe refund id is empty")
+	}
+	if in.Amount <= 0 {
+		return nil, errors.New("stripe refund amount is invalid")
+	}
+
+	form := url.Values{}
+	form.Set("payment_intent", paymentIntentID)
+	form.Set("amount", fmt.Sprintf("%d", in.Amount))
+	form.Set("metadata[refundId]", refundID)
+
+	if paymentID := strings.TrimSpace(in.PaymentID); paymentID != "" {
+		form.Set("metadata[paymentId]", paymentID)
+	}
+
+	if reason := strings.TrimSpace(in.Reason); reason != "" {
+		form.Set("reason", reason)
+	}
+
+	var out stripeRefundResponse
+	if err := g.postFormWithIdempotencyKey(
+		ctx,
+		"/refunds",
+		form,
+		"refund_"+refundID,
+		&out,
+	); err != nil {
+		return nil, err
+	}
+
+	stripeRefundID := strings.TrimSpace(out.ID)
+	if stripeRefundID == "" {
+		return nil, errors.New("stripe refund id is empty")
+	}
+
+	return &usecase.CreateStripeRefundResult{
+		StripeRefundID: stripeRefundID,
+		Status:         strings.TrimSpace(out.Status),
+		FailureReason:  strings.TrimSpace(out.FailureReason),
+	}, nil
+}
+
+type stripeRefundResponse struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason"`
+}
@@ -0,0 +1,278 @@
+// backend/internal/adapters/out/zengin/transfer_file.go
+package zengin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	accdom "narratives/internal/domain/account"
+	payoutdom "narratives/internal/domain/payout"
+)
+
+const (
+	envRequesterCode   = "PAYOUT_ZENGIN_REQUESTER_CODE"
+	envRequesterName   = "PAYOUT_ZENGIN_REQUESTER_NAME"
+	envBankCode        = "PAYOUT_ZENGIN_BANK_CODE"
+	envBankName        = "PAYOUT_ZENGIN_BANK_NAME"
+	envBranchCode      = "PAYOUT_ZENGIN_BRANCH_CODE"
+	envBranchName      = "PAYOUT_ZENGIN_BRANCH_NAME"
+	envAccountType     = "PAYOUT_ZENGIN_ACCOUNT_TYPE"
+	envAccountNumber   = "PAYOUT_ZENGIN_ACCOUNT_NUMBER"
+	defaultAccountType = "1"
+
+	// recordLength は全銀協フォーマットの 1 レコードのバイト数です。
+	recordLength = 120
+
+	// maxAmount は 1 件あたりの振込金額の上限（10 桁）です。
+	maxAmount = 9_999_999_999
+)
+
+var (
+	ErrInvalidConfig    = errors.New("zengin: invalid requester config")
+	ErrInvalidCharacter = errors.New("zengin: text contains a character not allowed in zengin files")
+	ErrInvalidLine      = errors.New("zengin: invalid transfer line")
+)
+
+// Config は振込依頼人（支払元）の情報です。名称は半角カナで指定します。
+type Config struct {
+	// RequesterCode は銀行から付与された委託者コード（10 桁）です。
+	RequesterCode string
+	RequesterName string
+
+	BankCode   string
+	BankName   string
+	BranchCode string
+	BranchName string
+
+	// AccountType は預金種目（"1" 普通 / "2" 当座）です。
+	AccountType   string
+	AccountNumber string
+}
+
+// TransferFileWriter は支払いバッチを全銀協フォーマット（総合振込, 120 バイト固定長,
+// Shift_JIS, CRLF 区切り）の振込ファイルに変換します。
+//
+// レコード構成: ヘッダー（1）/ データ（2, 受取先ごと）/ トレーラー（8）/ エンド（9）
+type TransferFileWriter struct {
+	config   Config
+	location *time.Location
+}
+
+var _ usecase.PayoutTransferFileRenderer = (*TransferFileWriter)(nil)
+
+func NewTransferFileWriterFromEnv() (*TransferFileWriter, error) {
+	accountType := strings.TrimSpace(os.Getenv(envAccountType))
+	if accountType == "" {
+		accountType = defaultAccountType
+	}
+
+	return NewTransferFileWriter(Config{
+		RequesterCode: strings.TrimSpace(os.Getenv(envRequesterCode)),
+		RequesterName: strings.TrimSpace(os.Getenv(envRequesterName)),
+		BankCode:      strings.TrimSpace(os.Getenv(envBankCode)),
+		BankName:      strings.TrimSpace(os.Getenv(envBankName)),
+		BranchCode:    strings.TrimSpace(os.Getenv(envBranchCode)),
+		BranchName:    strings.TrimSpace(os.Getenv(envBranchName)),
+		AccountType:   accountType,
+		AccountNumber: strings.TrimSpace(os.Getenv(envAccountNumber)),
+	})
+}
+
+func NewTransferFileWriter(config Config) (*TransferFileWriter, error) {
+	switch {
+	case !isDigits(config.RequesterCode, 10),
+		!accdom.IsZenginKana(config.RequesterName),
+		!isDigits(config.BankCode, 4),
+		!isDigits(config.BranchCode, 3),
+		config.AccountType != "1" && config.AccountType != "2",
+		!isDigits(config.AccountNumber, 7):
+		return nil, ErrInvalidConfig
+	}
+
+	// 銀行名・支店名は任意（空の場合はスペース）。
+	for _, name := range []string{config.BankName, config.BranchName} {
+		if name != "" && !accdom.IsZenginKana(name) {
+			return nil, ErrInvalidConfig
+		}
+	}
+
+	return &TransferFileWriter{
+		config:   config,
+		location: time.FixedZone("JST", 9*60*60),
+	}, nil
+}
+
+func (w *TransferFileWriter) RenderTransferFile(
+	b payoutdom.Batch,
+) ([]byte, error) {
+	if w == nil {
+		return nil, ErrInvalidConfig
+	}
+
+	var buf bytes.Buffer
+
+	records := make([]*record, 0, len(b.Lines)+3)
+
+	header := newRecord()
+	header.digits("1", 1)
+	header.digits("21", 2) // 種別コード: 総合振込
+	header.digits("0", 1)  // コード区分: JIS（Shift_JIS）
+	header.digits(w.config.RequesterCode, 10)
+	header.text(w.config.RequesterName, 40)
+	header.digits(b.TransferDate.In(w.location).Format("0102"), 4)
+	header.digits(w.config.BankCode, 4)
+	header.text(w.config.BankName, 15)
+	header.digits(w.config.BranchCode, 3)
+	header.text(w.config.BranchName, 15)
+	header.digits(w.config.AccountType, 1)
+	header.digits(w.config.AccountNumber, 7)
+	header.text("", 17)
+	records = append(records, header)
+
+	total := 0
+	for _, line := range b.Lines {
+		a := line.Account
+		if line.Amount <= 0 || line.Amount > maxAmount ||
+			!isDigits(a.BankCode, 4) ||
+			!isDigits(a.BranchCode, 3) ||
+			(a.AccountType != "1" && a.AccountType != "2") ||
+			!isDigits(a.AccountNumber, 7) {
+			return nil, fmt.Errorf("%w: %s %s", ErrInvalidLine, line.RecipientType, line.RecipientID)
+		}
+
+		data := newRecord()
+		data.digits("2", 1)
+		data.digits(a.BankCode, 4)
+		data.text(kanaOrBlank(a.BankName), 15)
+		data.digits(a.BranchCode, 3)
+		data.text(kanaOrBlank(a.BranchName), 15)
+		data.text("", 4) // 手形交換所番号
+		data.digits(a.AccountType, 1)
+		data.digits(a.AccountNumber, 7)
+		data.text(a.HolderNameKana, 30)
+		data.number(line.Amount, 10)
+		data.digits("0", 1) // 新規コード
+		data.text("", 10)   // 顧客コード1
+		data.text("", 10)   // 顧客コード2
+		data.digits("7", 1) // 振込指定区分: テレ振込
+		data.text("", 1)    // 識別表示
+		data.text("", 7)
+		records = append(records, data)
+
+		total += line.Amount
+	}
+
+	trailer := newRecord()
+	trailer.digits("8", 1)
+	trailer.number(len(b.Lines), 6)
+	trailer.number(total, 12)
+	trailer.text("", 101)
+	records = append(records, trailer)
+
+	end := newRecord()
+	end.digits("9", 1)
+	end.text("", 119)
+	records = append(records, end)
+
+	for _, rec := range records {
+		if rec.err != nil {
+			return nil, rec.err
+		}
+		if rec.buf.Len() != recordLength {
+			return nil, fmt.Errorf("zengin: record length %d", rec.buf.Len())
+		}
+
+		buf.Write(rec.buf.Bytes())
+		buf.WriteString("\r\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// record は 1 レコード分の固定長フィールドを組み立てます。
+type record struct {
+	buf bytes.Buffer
+	err error
+}
+
+func newRecord() *record {
+	return &record{}
+}
+
+// text は左詰め・スペース埋めで書き込みます（長い場合は切り詰め）。
+func (r *record) text(s string, width int) {
+	encoded, err := encodeShiftJIS(s)
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+
+	if len(encoded) > width {
+		encoded = encoded[:width]
+	}
+
+	r.buf.Write(encoded)
+	r.buf.WriteString(strings.Repeat(" ", width-len(encoded)))
+}
+
+// digits は検証済みの数字列を右詰め・ゼロ埋めで書き込みます。
+func (r *record) digits(s string, width int) {
+	if len(s) > width || !isDigits(s, len(s)) {
+		if r.err == nil {
+			r.err = fmt.Errorf("%w: %q", ErrInvalidLine, s)
+		}
+		s = ""
+	}
+
+	r.buf.WriteString(strings.Repeat("0", width-len(s)))
+	r.buf.WriteString(s)
+}
+
+func (r *record) number(n int, width int) {
+	r.digits(strconv.Itoa(n), width)
+}
+
+// encodeShiftJIS は全銀協で使える文字（ASCII と半角カナ）を Shift_JIS に変換します。
+// 半角カナ（U+FF61〜U+FF9F）は Shift_JIS の 0xA1〜0xDF に対応します。
+func encodeShiftJIS(s string) ([]byte, error) {
+	out := make([]byte, 0, len(s))
+
+	for _, r := range s {
+		switch {
+		case r >= 0x20 && r < 0x7F:
+			out = append(out, byte(r))
+		case r >= 0xFF61 && r <= 0xFF9F:
+			out = append(out, byte(r-0xFF61+0xA1))
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCharacter, r)
+		}
+	}
+
+	return out, nil
+}
+
+// kanaOrBlank は銀行名・支店名が半角カナでない場合（漢字の登録など）に空にします。
+// 名称は任意項目のため、コードだけで振込先を特定できます。
+func kanaOrBlank(s string) string {
+	if accdom.IsZenginKana(s) {
+		return s
+	}
+	return ""
+}
+
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,261 @@
+// backend/internal/adapters/out/zengin/transfer_file_test.go
+package zengin
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	payoutdom "narratives/internal/domain/payout"
+)
+
+func testConfig() Config {
+	return Config{
+		RequesterCode: "0123456789",
+		RequesterName: "ｶ)ﾃｽﾄ",
+		BankCode:      "0001",
+		BankName:      "ﾐｽﾞﾎ",
+		BranchCode:    "100",
+		BranchName:    "",
+		AccountType:   "1",
+		AccountNumber: "1234567",
+	}
+}
+
+func testAccount() payoutdom.BankAccount {
+	return payoutdom.BankAccount{
+		AccountID:      "account_1",
+		BankCode:       "0005",
+		BankName:       "みずほ銀行",
+		BranchCode:     "123",
+		BranchName:     "ｼﾌﾞﾔ",
+		AccountType:    "2",
+		AccountNumber:  "0012345",
+		HolderNameKana: "ﾀﾅｶ ﾀﾛｳ",
+	}
+}
+
+func testBatch() payoutdom.Batch {
+	second := testAccount()
+	second.AccountID = "account_2"
+	second.AccountType = "1"
+
+	return payoutdom.Batch{
+		ID: "batch_1",
+		// UTC 15:00 は JST の翌日 0:00。振込指定日は JST で書き出す。
+		TransferDate: time.Date(2026, 10, 24, 15, 0, 0, 0, time.UTC),
+		Lines: []payoutdom.Line{
+			{RecipientType: payoutdom.RecipientTypeCompany, RecipientID: "company_1", Account: testAccount(), Amount: 9500, EntryIDs: []string{"a"}},
+			{RecipientType: payoutdom.RecipientTypeAvatar, RecipientID: "avatar_1", Account: second, Amount: 1234567890, EntryIDs: []string{"b"}},
+		},
+	}
+}
+
+func render(t *testing.T) [][]byte {
+	t.Helper()
+
+	w, err := NewTransferFileWriter(testConfig())
+	if err != nil {
+		t.Fatalf("NewTransferFileWriter: %v", err)
+	}
+
+	out, err := w.RenderTransferFile(testBatch())
+	if err != nil {
+		t.Fatalf("RenderTransferFile: %v", err)
+	}
+
+	if !bytes.HasSuffix(out, []byte("\r\n")) {
+		t.Fatalf("file does not end with CRLF")
+	}
+	records := bytes.Split(bytes.TrimSuffix(out, []byte("\r\n")), []byte("\r\n"))
+	for i, rec := range records {
+		if len(rec) != recordLength {
+			t.Fatalf("record %d length = %d, want %d", i, len(rec), recordLength)
+		}
+	}
+
+	return records
+}
+
+type field struct {
+	name  string
+	start int
+	width int
+	want  string
+}
+
+func checkFields(t *testing.T, rec []byte, fields []field) {
+	t.Helper()
+
+	next := 0
+	for _, f := range fields {
+		// フィールド定義がレコードを隙間なく覆っていることも確認する。
+		if f.start != next {
+			t.Fatalf("field %s starts at %d, want %d", f.name, f.start, next)
+		}
+		next = f.start + f.width
+
+		if len(f.want) != f.width {
+			t.Fatalf("field %s: want has %d bytes, width is %d", f.name, len(f.want), f.width)
+		}
+		if got := string(rec[f.start:next]); got != f.want {
+			t.Errorf("field %s = %q, want %q", f.name, got, f.want)
+		}
+	}
+	if next != recordLength {
+		t.Fatalf("fields cover %d bytes, want %d", next, recordLength)
+	}
+}
+
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+func TestRenderTransferFile_Layout(t *testing.T) {
+	records := render(t)
+	if len(records) != 5 {
+		t.Fatalf("records = %d, want 5 (header, 2 data, trailer, end)", len(records))
+	}
+
+	tests := []struct {
+		name   string
+		record []byte
+		fields []field
+	}{
+		{
+			name:   "header",
+			record: records[0],
+			fields: []field{
+				{"record type", 0, 1, "1"},
+				{"kind", 1, 2, "21"},
+				{"code type", 3, 1, "0"},
+				{"requester code", 4, 10, "0123456789"},
+				// ｶ)ﾃｽﾄ → 0xB6 ')' 0xC3 0xBD 0xC4
+				{"requester name", 14, 40, pad("\xb6)\xc3\xbd\xc4", 40)},
+				{"transfer date", 54, 4, "1025"},
+				{"bank code", 58, 4, "0001"},
+				// ﾐｽﾞﾎ → 0xD0 0xBD 0xDE 0xCE
+				{"bank name", 62, 15, pad("\xd0\xbd\xde\xce", 15)},
+				{"branch code", 77, 3, "100"},
+				{"branch name", 80, 15, pad("", 15)},
+				{"account type", 95, 1, "1"},
+				{"account number", 96, 7, "1234567"},
+				{"dummy", 103, 17, pad("", 17)},
+			},
+		},
+		{
+			name:   "data",
+			record: records[1],
+			fields: []field{
+				{"record type", 0, 1, "2"},
+				{"bank code", 1, 4, "0005"},
+				// 漢字の銀行名はスペースにする。
+				{"bank name", 5, 15, pad("", 15)},
+				{"branch code", 20, 3, "123"},
+				// ｼﾌﾞﾔ → 0xBC 0xCC 0xDE 0xD4
+				{"branch name", 23, 15, pad("\xbc\xcc\xde\xd4", 15)},
+				{"clearing house", 38, 4, pad("", 4)},
+				{"account type", 42, 1, "2"},
+				{"account number", 43, 7, "0012345"},
+				// ﾀﾅｶ ﾀﾛｳ → 0xC0 0xC5 0xB6 ' ' 0xC0 0xDB 0xB3
+				{"holder name", 50, 30, pad("\xc0\xc5\xb6 \xc0\xdb\xb3", 30)},
+				{"amount", 80, 10, "0000009500"},
+				{"new code", 90, 1, "0"},
+				{"customer code 1", 91, 10, pad("", 10)},
+				{"customer code 2", 101, 10, pad("", 10)},
+				{"transfer type", 111, 1, "7"},
+				{"identifier", 112, 1, " "},
+				{"dummy", 113, 7, pad("", 7)},
+			},
+		},
+		{
+			name:   "trailer",
+			record: records[3],
+			fields: []field{
+				{"record type", 0, 1, "8"},
+				{"count", 1, 6, "000002"},
+				{"total", 7, 12, "001234577390"},
+				{"dummy", 19, 101, pad("", 101)},
+			},
+		},
+		{
+			name:   "end",
+			record: records[4],
+			fields: []field{
+				{"record type", 0, 1, "9"},
+				{"dummy", 1, 119, pad("", 119)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFields(t, tt.record, tt.fields)
+		})
+	}
+
+	if got := string(records[2][80:90]); got != "1234567890" {
+		t.Fatalf("second data amount = %q, want %q", got, "1234567890")
+	}
+}
+
+func TestNewTransferFileWriter_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+	}{
+		{name: "short requester code", modify: func(c *Config) { c.RequesterCode = "123456789" }},
+		{name: "kanji requester name", modify: func(c *Config) { c.RequesterName = "株式会社テスト" }},
+		{name: "empty requester name", modify: func(c *Config) { c.RequesterName = "" }},
+		{name: "bank code with letters", modify: func(c *Config) { c.BankCode = "00A1" }},
+		{name: "short branch code", modify: func(c *Config) { c.BranchCode = "10" }},
+		{name: "account type 3", modify: func(c *Config) { c.AccountType = "3" }},
+		{name: "short account number", modify: func(c *Config) { c.AccountNumber = "123456" }},
+		{name: "kanji bank name", modify: func(c *Config) { c.BankName = "みずほ銀行" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testConfig()
+			tt.modify(&c)
+
+			if _, err := NewTransferFileWriter(c); !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("NewTransferFileWriter err = %v, want %v", err, ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestRenderTransferFile_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(l *payoutdom.Line)
+		want   error
+	}{
+		{name: "zero amount", modify: func(l *payoutdom.Line) { l.Amount = 0 }, want: ErrInvalidLine},
+		{name: "amount over 10 digits", modify: func(l *payoutdom.Line) { l.Amount = maxAmount + 1 }, want: ErrInvalidLine},
+		{name: "short bank code", modify: func(l *payoutdom.Line) { l.Account.BankCode = "005" }, want: ErrInvalidLine},
+		{name: "branch code with letters", modify: func(l *payoutdom.Line) { l.Account.BranchCode = "12A" }, want: ErrInvalidLine},
+		{name: "unknown account type", modify: func(l *payoutdom.Line) { l.Account.AccountType = "" }, want: ErrInvalidLine},
+		{name: "unpadded account number", modify: func(l *payoutdom.Line) { l.Account.AccountNumber = "12345" }, want: ErrInvalidLine},
+		{name: "kanji holder name", modify: func(l *payoutdom.Line) { l.Account.HolderNameKana = "田中太郎" }, want: ErrInvalidCharacter},
+	}
+
+	w, err := NewTransferFileWriter(testConfig())
+	if err != nil {
+		t.Fatalf("NewTransferFileWriter: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testBatch()
+			tt.modify(&b.Lines[1])
+
+			if _, err := w.RenderTransferFile(b); !errors.Is(err, tt.want) {
+				t.Fatalf("RenderTransferFile err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
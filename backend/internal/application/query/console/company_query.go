@@ -15,6 +15,8 @@ type CompanyDetail struct {
 	Admin    string `json:"admin"`
 	IsActive bool   `json:"isActive"`
 
+	InvoiceRegistrationNumber string `json:"invoiceRegistrationNumber,omitempty"`
+
 	CreatedAt     time.Time `json:"createdAt"`
 	CreatedBy     string    `json:"createdBy"`
 	CreatedByName string    `json:"createdByName"`
@@ -68,6 +70,8 @@ func (q *CompanyQuery) GetByID(
 		Admin:    company.Admin,
 		IsActive: company.IsActive,
 
+		InvoiceRegistrationNumber: company.InvoiceRegistrationNumber,
+
 		CreatedAt: company.CreatedAt,
 		CreatedBy: company.CreatedBy,
 		CreatedByName: q.resolveMemberName(
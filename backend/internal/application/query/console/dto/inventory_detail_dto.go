@@ -76,12 +76,19 @@ type InventoryTransportationOptionDTO struct {
 // InventoryDetailRowDTO は Inventory Detail 画面向けの在庫行 DTO。
 // GET /inventory/{inventoryId} の rows として返す。
 // frontend 側では /models/by-blueprint/{productBlueprintId}/variations を追加取得せず、この rows を正とする。
+//
+// stock は引当を差し引いた販売可能数（= accumulation - reservedCount）。
 type InventoryDetailRowDTO struct {
 	ModelID     string `json:"modelId"`
 	Kind        string `json:"kind,omitempty"`
 	ModelNumber string `json:"modelNumber"`
 	Stock       int    `json:"stock"`
 
+	// 物理在庫数と注文による引当数
+	Accumulation  int                       `json:"accumulation"`
+	ReservedCount int                       `json:"reservedCount"`
+	Reservations  []InventoryReservationDTO `json:"reservations"`
+
 	// apparel 系 model 用
 	Size  string `json:"size,omitempty"`
 	Color string `json:"color,omitempty"`
@@ -92,6 +99,15 @@ type InventoryDetailRowDTO struct {
 	VolumeUnit  string `json:"volumeUnit,omitempty"`
 }
 
+// InventoryReservationDTO は Inventory Detail 画面向けの注文別引当 read model。
+// status / expiresAt は引当レコードがある場合のみ返す。
+type InventoryReservationDTO struct {
+	OrderID   string `json:"orderId"`
+	Qty       int    `json:"qty"`
+	Status    string `json:"status,omitempty"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+}
+
 // InventoryDetailDTO は GET /inventory/{inventoryId} の Inventory Detail 画面専用 BFF response。
 // frontend 側で別 API の response を merge せず、この DTO を唯一の正とする。
 type InventoryDetailDTO struct {
@@ -113,7 +129,8 @@ type InventoryDetailDTO struct {
 
 	TransportationOptions []InventoryTransportationOptionDTO `json:"transportationOptions"`
 
-	Rows       []InventoryDetailRowDTO `json:"rows"`
-	TotalStock int                     `json:"totalStock"`
-	UpdatedAt  string                  `json:"updatedAt,omitempty"`
+	Rows          []InventoryDetailRowDTO `json:"rows"`
+	TotalStock    int                     `json:"totalStock"`
+	TotalReserved int                     `json:"totalReserved"`
+	UpdatedAt     string                  `json:"updatedAt,omitempty"`
 }
@@ -0,0 +1,451 @@
+// backend/internal/application/query/console/inspection_analytics_query.go
+package query
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	resolver "narratives/internal/application/resolver"
+	inspectiondom "narratives/internal/domain/inspection"
+	pbcdom "narratives/internal/domain/productBlueprintCategory"
+)
+
+var ErrInspectionAnalyticsQueryNotConfigured = errors.New("inspection analytics query is not configured")
+
+// InspectionAnalyticsRepo は検品結果を productionID キーで取得する最小ポートです。
+type InspectionAnalyticsRepo interface {
+	GetByProductionID(ctx context.Context, productionID string) (inspectiondom.InspectionBatch, error)
+}
+
+// ============================================================
+// DTO
+// ============================================================
+//
+// 不良率・歩留まりの定義（ネガティブ制）:
+//   - manufactured = quantity - notManufactured
+//   - passed       = manufactured - failed（検品中は notYet を passed 見込みとして数える）
+//   - defectRate   = failed / manufactured
+//   - yield        = passed / manufactured
+// manufactured が 0 の場合、rate は null です。
+
+type InspectionCountsDTO struct {
+	Quantity        int      `json:"quantity"`
+	Manufactured    int      `json:"manufactured"`
+	Passed          int      `json:"passed"`
+	Failed          int      `json:"failed"`
+	NotManufactured int      `json:"notManufactured"`
+	DefectRate      *float64 `json:"defectRate"`
+	Yield           *float64 `json:"yield"`
+}
+
+type DefectReasonCountDTO struct {
+	Code  string   `json:"code"`
+	Label string   `json:"label"`
+	Count int      `json:"count"`
+	Share *float64 `json:"share"` // failed に占める割合（1 件に複数理由がある場合は合計が 1 を超える）
+}
+
+type ModelDefectReportDTO struct {
+	ModelID     string `json:"modelId"`
+	ModelNumber string `json:"modelNumber,omitempty"`
+	InspectionCountsDTO
+	DefectReasons []DefectReasonCountDTO `json:"defectReasons"`
+}
+
+type ProductionDefectReportDTO struct {
+	ProductionID       string `json:"productionId"`
+	ProductBlueprintID string `json:"productBlueprintId"`
+	ProductName        string `json:"productName"`
+	AssigneeID         string `json:"assigneeId"`
+	AssigneeName       string `json:"assigneeName"`
+	Status             string `json:"status"`
+	InspectionCountsDTO
+	DefectReasons []DefectReasonCountDTO `json:"defectReasons"`
+	Models        []ModelDefectReportDTO `json:"models"`
+
+	// 理由未入力の failed 件数
+	UnclassifiedFailed int `json:"unclassifiedFailed"`
+	// 写真付きの failed 件数
+	FailedWithPhotos int `json:"failedWithPhotos"`
+}
+
+type InspectionYieldFilter struct {
+	ProductBlueprintID string
+	AssigneeID         string
+	// production の createdAt で絞り込む（From 以上 To 未満）
+	From *time.Time
+	To   *time.Time
+	// false の場合は完了した検品のみを対象にします
+	IncludeInspecting bool
+}
+
+type ProductionYieldRowDTO struct {
+	ProductionID       string     `json:"productionId"`
+	ProductBlueprintID string     `json:"productBlueprintId"`
+	ProductName        string     `json:"productName"`
+	AssigneeID         string     `json:"assigneeId"`
+	AssigneeName       string     `json:"assigneeName"`
+	Status             string     `json:"status"`
+	CreatedAt          *time.Time `json:"createdAt,omitempty"`
+	InspectionCountsDTO
+	TopDefectReasons []DefectReasonCountDTO `json:"topDefectReasons"`
+}
+
+type AssigneeYieldDTO struct {
+	AssigneeID   string `json:"assigneeId"`
+	AssigneeName string `json:"assigneeName"`
+	Productions  int    `json:"productions"`
+	InspectionCountsDTO
+	DefectReasons []DefectReasonCountDTO `json:"defectReasons"`
+}
+
+type InspectionYieldComparisonDTO struct {
+	Total       InspectionCountsDTO     `json:"total"`
+	Productions []ProductionYieldRowDTO `json:"productions"`
+	Assignees   []AssigneeYieldDTO      `json:"assignees"`
+}
+
+// topDefectReasonCount は production 行に含める不良理由の件数です。
+const topDefectReasonCount = 3
+
+// ============================================================
+// Query
+// ============================================================
+
+type InspectionAnalyticsQuery struct {
+	productionQuery *CompanyProductionQueryService
+	inspRepo        InspectionAnalyticsRepo
+	nameResolver    *resolver.NameResolver
+}
+
+func NewInspectionAnalyticsQuery(
+	productionQuery *CompanyProductionQueryService,
+	inspRepo InspectionAnalyticsRepo,
+	nameResolver *resolver.NameResolver,
+) *InspectionAnalyticsQuery {
+	return &InspectionAnalyticsQuery{
+		productionQuery: productionQuery,
+		inspRepo:        inspRepo,
+		nameResolver:    nameResolver,
+	}
+}
+
+// GetProductionDefectReport は 1 production の不良率（全体・model 別・不良理由別）を返します。
+func (q *InspectionAnalyticsQuery) GetProductionDefectReport(
+	ctx context.Context,
+	productionID string,
+) (ProductionDefectReportDTO, error) {
+	if q == nil || q.productionQuery == nil || q.inspRepo == nil {
+		return ProductionDefectReportDTO{}, ErrInspectionAnalyticsQueryNotConfigured
+	}
+
+	if productionID == "" {
+		return ProductionDefectReportDTO{}, inspectiondom.ErrInvalidInspectionProductionID
+	}
+
+	production, pb, err := q.productionQuery.getProductionByIDForCurrentCompany(ctx, productionID)
+	if err != nil {
+		return ProductionDefectReportDTO{}, err
+	}
+
+	batch, err := q.inspRepo.GetByProductionID(ctx, production.ID)
+	if err != nil {
+		return ProductionDefectReportDTO{}, err
+	}
+
+	total := newDefectTally()
+	byModel := make(map[string]*defectTally)
+	modelIDs := make([]string, 0)
+
+	unclassified := 0
+	withPhotos := 0
+
+	for _, item := range batch.Inspections {
+		total.add(item)
+
+		t, ok := byModel[item.ModelID]
+		if !ok {
+			t = newDefectTally()
+			byModel[item.ModelID] = t
+			modelIDs = append(modelIDs, item.ModelID)
+		}
+		t.add(item)
+
+		if isFailedInspection(item) {
+			if len(item.DefectReasons) == 0 {
+				unclassified++
+			}
+			if len(item.DefectPhotoURLs) > 0 {
+				withPhotos++
+			}
+		}
+	}
+
+	sort.Strings(modelIDs)
+
+	models := make([]ModelDefectReportDTO, 0, len(modelIDs))
+	for _, modelID := range modelIDs {
+		t := byModel[modelID]
+
+		modelNumber := ""
+		if q.nameResolver != nil && modelID != "" {
+			modelNumber = q.nameResolver.ResolveModelNumber(ctx, modelID)
+		}
+
+		models = append(models, ModelDefectReportDTO{
+			ModelID:             modelID,
+			ModelNumber:         modelNumber,
+			InspectionCountsDTO: t.counts(),
+			DefectReasons:       t.reasons(0),
+		})
+	}
+
+	// 不良率の高い model を先頭に
+	sort.SliceStable(models, func(i, j int) bool {
+		return rateValue(models[i].DefectRate) > rateValue(models[j].DefectRate)
+	})
+
+	return ProductionDefectReportDTO{
+		ProductionID:        production.ID,
+		ProductBlueprintID:  production.ProductBlueprintID,
+		ProductName:         pb.ProductName,
+		AssigneeID:          production.AssigneeID,
+		AssigneeName:        q.resolveAssigneeName(ctx, production.AssigneeID),
+		Status:              string(batch.Status),
+		InspectionCountsDTO: total.counts(),
+		DefectReasons:       total.reasons(0),
+		Models:              models,
+		UnclassifiedFailed:  unclassified,
+		FailedWithPhotos:    withPhotos,
+	}, nil
+}
+
+// CompareInspectionYield は current company の production ごと・担当者（assignee）ごとの
+// 歩留まりを比較できる形で返します。検品が未作成の production は含みません。
+func (q *InspectionAnalyticsQuery) CompareInspectionYield(
+	ctx context.Context,
+	filter InspectionYieldFilter,
+) (InspectionYieldComparisonDTO, error) {
+	if q == nil || q.productionQuery == nil || q.inspRepo == nil {
+		return InspectionYieldComparisonDTO{}, ErrInspectionAnalyticsQueryNotConfigured
+	}
+
+	productions, pbByID, err := q.productionQuery.listProductionsByCurrentCompany(ctx)
+	if err != nil {
+		return InspectionYieldComparisonDTO{}, err
+	}
+
+	total := newDefectTally()
+	rows := make([]ProductionYieldRowDTO, 0, len(productions))
+
+	byAssignee := make(map[string]*defectTally)
+	productionsByAssignee := make(map[string]int)
+
+	for _, p := range productions {
+		if filter.ProductBlueprintID != "" && p.ProductBlueprintID != filter.ProductBlueprintID {
+			continue
+		}
+		if filter.AssigneeID != "" && p.AssigneeID != filter.AssigneeID {
+			continue
+		}
+		if filter.From != nil && p.CreatedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !p.CreatedAt.Before(*filter.To) {
+			continue
+		}
+
+		batch, err := q.inspRepo.GetByProductionID(ctx, p.ID)
+		if err != nil {
+			if errors.Is(err, inspectiondom.ErrNotFound) {
+				continue
+			}
+			return InspectionYieldComparisonDTO{}, err
+		}
+
+		if batch.Status != inspectiondom.InspectionStatusCompleted && !filter.IncludeInspecting {
+			continue
+		}
+
+		t := newDefectTally()
+		for _, item := range batch.Inspections {
+			t.add(item)
+		}
+
+		total.merge(t)
+
+		a, ok := byAssignee[p.AssigneeID]
+		if !ok {
+			a = newDefectTally()
+			byAssignee[p.AssigneeID] = a
+		}
+		a.merge(t)
+		productionsByAssignee[p.AssigneeID]++
+
+		var createdAt *time.Time
+		if !p.CreatedAt.IsZero() {
+			v := p.CreatedAt.UTC()
+			createdAt = &v
+		}
+
+		rows = append(rows, ProductionYieldRowDTO{
+			ProductionID:        p.ID,
+			ProductBlueprintID:  p.ProductBlueprintID,
+			ProductName:         pbByID[p.ProductBlueprintID].ProductName,
+			AssigneeID:          p.AssigneeID,
+			AssigneeName:        q.resolveAssigneeName(ctx, p.AssigneeID),
+			Status:              string(batch.Status),
+			CreatedAt:           createdAt,
+			InspectionCountsDTO: t.counts(),
+			TopDefectReasons:    t.reasons(topDefectReasonCount),
+		})
+	}
+
+	// 歩留まりの低い production を先頭に
+	sort.SliceStable(rows, func(i, j int) bool {
+		yi, yj := rateValueOr(rows[i].Yield, 2), rateValueOr(rows[j].Yield, 2)
+		if yi != yj {
+			return yi < yj
+		}
+		return rows[i].ProductionID < rows[j].ProductionID
+	})
+
+	assignees := make([]AssigneeYieldDTO, 0, len(byAssignee))
+	for assigneeID, t := range byAssignee {
+		assignees = append(assignees, AssigneeYieldDTO{
+			AssigneeID:          assigneeID,
+			AssigneeName:        q.resolveAssigneeName(ctx, assigneeID),
+			Productions:         productionsByAssignee[assigneeID],
+			InspectionCountsDTO: t.counts(),
+			DefectReasons:       t.reasons(0),
+		})
+	}
+
+	sort.SliceStable(assignees, func(i, j int) bool {
+		yi, yj := rateValueOr(assignees[i].Yield, 2), rateValueOr(assignees[j].Yield, 2)
+		if yi != yj {
+			return yi < yj
+		}
+		return assignees[i].AssigneeID < assignees[j].AssigneeID
+	})
+
+	return InspectionYieldComparisonDTO{
+		Total:       total.counts(),
+		Productions: rows,
+		Assignees:   assignees,
+	}, nil
+}
+
+func (q *InspectionAnalyticsQuery) resolveAssigneeName(ctx context.Context, assigneeID string) string {
+	if q.nameResolver == nil || assigneeID == "" {
+		return ""
+	}
+	return q.nameResolver.ResolveAssigneeName(ctx, assigneeID)
+}
+
+// ============================================================
+// Tally
+// ============================================================
+
+type defectTally struct {
+	quantity        int
+	failed          int
+	notManufactured int
+	reasonCounts    map[string]int
+}
+
+func newDefectTally() *defectTally {
+	return &defectTally{reasonCounts: make(map[string]int)}
+}
+
+func (t *defectTally) add(item inspectiondom.InspectionItem) {
+	t.quantity++
+
+	if item.InspectionResult == nil {
+		return
+	}
+
+	switch *item.InspectionResult {
+	case inspectiondom.InspectionFailed:
+		t.failed++
+		for _, code := range item.DefectReasons {
+			t.reasonCounts[code]++
+		}
+	case inspectiondom.InspectionNotManufactured:
+		t.notManufactured++
+	}
+}
+
+func (t *defectTally) merge(o *defectTally) {
+	t.quantity += o.quantity
+	t.failed += o.failed
+	t.notManufactured += o.notManufactured
+	for code, n := range o.reasonCounts {
+		t.reasonCounts[code] += n
+	}
+}
+
+func (t *defectTally) counts() InspectionCountsDTO {
+	manufactured := t.quantity - t.notManufactured
+	passed := manufactured - t.failed
+
+	return InspectionCountsDTO{
+		Quantity:        t.quantity,
+		Manufactured:    manufactured,
+		Passed:          passed,
+		Failed:          t.failed,
+		NotManufactured: t.notManufactured,
+		DefectRate:      inspectionRatio(t.failed, manufactured),
+		Yield:           inspectionRatio(passed, manufactured),
+	}
+}
+
+// reasons は件数の多い順に不良理由を返します（limit <= 0 の場合は全件）。
+func (t *defectTally) reasons(limit int) []DefectReasonCountDTO {
+	out := make([]DefectReasonCountDTO, 0, len(t.reasonCounts))
+	for code, n := range t.reasonCounts {
+		out = append(out, DefectReasonCountDTO{
+			Code:  code,
+			Label: pbcdom.DefectReasonLabel(code),
+			Count: n,
+			Share: inspectionRatio(n, t.failed),
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Code < out[j].Code
+	})
+
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func isFailedInspection(item inspectiondom.InspectionItem) bool {
+	return item.InspectionResult != nil && *item.InspectionResult == inspectiondom.InspectionFailed
+}
+
+func inspectionRatio(n, d int) *float64 {
+	if d <= 0 {
+		return nil
+	}
+	v := float64(n) / float64(d)
+	return &v
+}
+
+func rateValue(v *float64) float64 {
+	return rateValueOr(v, -1)
+}
+
+func rateValueOr(v *float64, fallback float64) float64 {
+	if v == nil {
+		return fallback
+	}
+	return *v
+}
@@ -0,0 +1,132 @@
+// backend/internal/application/query/console/inspection_analytics_query_test.go
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	inspectiondom "narratives/internal/domain/inspection"
+)
+
+func inspected(result inspectiondom.InspectionResult, reasons ...string) inspectiondom.InspectionItem {
+	return inspectiondom.InspectionItem{InspectionResult: &result, DefectReasons: reasons}
+}
+
+func ratio(v float64) *float64 {
+	return &v
+}
+
+func TestDefectTally_Counts(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []inspectiondom.InspectionItem
+		want  InspectionCountsDTO
+	}{
+		{
+			// 8 個製造（2 個は未製造）、うち 2 個が不良 → 不良率 0.25, 歩留まり 0.75。
+			// notYet は Complete 前の未入力で、passed と同じに数える。
+			name: "defect rate and yield exclude not manufactured",
+			items: []inspectiondom.InspectionItem{
+				inspected(inspectiondom.InspectionPassed),
+				inspected(inspectiondom.InspectionPassed),
+				inspected(inspectiondom.InspectionPassed),
+				inspected(inspectiondom.InspectionPassed),
+				inspected(inspectiondom.InspectionPassed),
+				inspected(inspectiondom.InspectionNotYet),
+				inspected(inspectiondom.InspectionFailed, "stain"),
+				inspected(inspectiondom.InspectionFailed),
+				inspected(inspectiondom.InspectionNotManufactured),
+				inspected(inspectiondom.InspectionNotManufactured),
+			},
+			want: InspectionCountsDTO{
+				Quantity:        10,
+				Manufactured:    8,
+				Passed:          6,
+				Failed:          2,
+				NotManufactured: 2,
+				DefectRate:      ratio(0.25),
+				Yield:           ratio(0.75),
+			},
+		},
+		{
+			name: "nothing manufactured",
+			items: []inspectiondom.InspectionItem{
+				inspected(inspectiondom.InspectionNotManufactured),
+			},
+			want: InspectionCountsDTO{Quantity: 1, NotManufactured: 1},
+		},
+		{
+			name: "no result counts as passed",
+			items: []inspectiondom.InspectionItem{
+				{},
+			},
+			want: InspectionCountsDTO{Quantity: 1, Manufactured: 1, Passed: 1, DefectRate: ratio(0), Yield: ratio(1)},
+		},
+		{name: "empty", want: InspectionCountsDTO{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tally := newDefectTally()
+			for _, it := range tt.items {
+				tally.add(it)
+			}
+
+			if got := tally.counts(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("counts = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefectTally_Reasons(t *testing.T) {
+	a := newDefectTally()
+	a.add(inspected(inspectiondom.InspectionFailed, "stain", "sewing"))
+	a.add(inspected(inspectiondom.InspectionFailed, "stain"))
+	a.add(inspected(inspectiondom.InspectionPassed))
+
+	b := newDefectTally()
+	b.add(inspected(inspectiondom.InspectionFailed, "fabric"))
+	b.add(inspected(inspectiondom.InspectionFailed, "scratch"))
+
+	total := newDefectTally()
+	total.merge(a)
+	total.merge(b)
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []DefectReasonCountDTO
+	}{
+		{
+			// 件数の多い順、同数は code 順。share は failed 4 件に対する割合。
+			name: "all",
+			want: []DefectReasonCountDTO{
+				{Code: "stain", Label: "汚れ", Count: 2, Share: ratio(0.5)},
+				{Code: "fabric", Label: "生地不良", Count: 1, Share: ratio(0.25)},
+				{Code: "scratch", Label: "scratch", Count: 1, Share: ratio(0.25)},
+				{Code: "sewing", Label: "縫製不良", Count: 1, Share: ratio(0.25)},
+			},
+		},
+		{
+			name:  "top 2",
+			limit: 2,
+			want: []DefectReasonCountDTO{
+				{Code: "stain", Label: "汚れ", Count: 2, Share: ratio(0.5)},
+				{Code: "fabric", Label: "生地不良", Count: 1, Share: ratio(0.25)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := total.reasons(tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("reasons = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := total.counts(); got.Quantity != 5 || got.Failed != 4 || got.Passed != 1 {
+		t.Fatalf("merged counts = %+v", got)
+	}
+}
@@ -25,6 +25,7 @@ type InventoryDetailQuery struct {
 	transportationRepo   transportationdom.RepositoryPort
 	nameResolver         *resolver.NameResolver
 	companyIDFromContext applicationport.CompanyIDResolver
+	reservationRepo      invdom.ReservationRepository
 }
 
 func NewInventoryDetailQuery(
@@ -47,6 +48,20 @@ func NewInventoryDetailQuery(
 	}
 }
 
+// WithReservationRepository は rows に引当レコードの status / expiresAt を載せる。
+// 未設定の場合は inv.Stock[modelId].ReservedByOrder のみから引当を返す。
+func (q *InventoryDetailQuery) WithReservationRepository(
+	reservationRepo invdom.ReservationRepository,
+) *InventoryDetailQuery {
+	if q == nil {
+		return q
+	}
+
+	q.reservationRepo = reservationRepo
+
+	return q
+}
+
 // ============================================================
 // TokenBlueprint: tbId -> Inventory Detail DTO
 // - GetByID で取得した TokenBlueprint から
@@ -283,8 +298,11 @@ func (q *InventoryDetailQuery) GetDetailByID(
 		return nil, errors.New("productBlueprint.modelRefs has no valid modelId")
 	}
 
+	reservationsByOrderID := q.loadReservationsByOrderID(ctx, inventoryID)
+
 	rows := make([]querydto.InventoryDetailRowDTO, 0, len(orderedModelIDs))
 	total := 0
+	totalReserved := 0
 
 	for _, modelID := range orderedModelIDs {
 		modelStock, ok := inv.Stock[modelID]
@@ -316,6 +334,13 @@ func (q *InventoryDetailQuery) GetDetailByID(
 			Kind:        attr.Kind,
 			ModelNumber: modelNumber,
 			Stock:       available,
+
+			Accumulation:  modelStock.Accumulation,
+			ReservedCount: modelStock.ReservedCount,
+			Reservations: buildInventoryReservationDTOs(
+				modelStock.ReservedByOrder,
+				reservationsByOrderID,
+			),
 		}
 
 		if attr.Kind == "alcohol" {
@@ -340,6 +365,7 @@ func (q *InventoryDetailQuery) GetDetailByID(
 
 		rows = append(rows, row)
 		total += available
+		totalReserved += modelStock.ReservedCount
 	}
 
 	updated := inv.UpdatedAt
@@ -366,10 +392,81 @@ func (q *InventoryDetailQuery) GetDetailByID(
 		TransportationOptions:  transportationOptions,
 		Rows:                   rows,
 		TotalStock:             total,
+		TotalReserved:          totalReserved,
 		UpdatedAt:              updatedAt,
 	}, nil
 }
 
+// loadReservationsByOrderID は inventory に紐づく未解放の引当レコードを orderId で引けるようにする。
+// 引当レコードは表示補助のため、取得に失敗しても detail 全体は失敗させない。
+func (q *InventoryDetailQuery) loadReservationsByOrderID(
+	ctx context.Context,
+	inventoryID string,
+) map[string]invdom.Reservation {
+	out := map[string]invdom.Reservation{}
+	if q == nil || q.reservationRepo == nil {
+		return out
+	}
+
+	reservations, err := q.reservationRepo.ListActiveByInventoryID(ctx, inventoryID)
+	if err != nil {
+		return out
+	}
+
+	for _, reservation := range reservations {
+		out[reservation.OrderID] = reservation
+	}
+
+	return out
+}
+
+// buildInventoryReservationDTOs は ReservedByOrder を正として注文別引当を組み立てる。
+// 期限が近い順（レコードがない引当は末尾、orderId 昇順）に並べる。
+func buildInventoryReservationDTOs(
+	reservedByOrder map[string]int,
+	reservationsByOrderID map[string]invdom.Reservation,
+) []querydto.InventoryReservationDTO {
+	out := make([]querydto.InventoryReservationDTO, 0, len(reservedByOrder))
+	expiresAt := map[string]time.Time{}
+
+	for orderID, qty := range reservedByOrder {
+		if qty <= 0 {
+			continue
+		}
+
+		dto := querydto.InventoryReservationDTO{
+			OrderID: orderID,
+			Qty:     qty,
+		}
+
+		if reservation, ok := reservationsByOrderID[orderID]; ok {
+			dto.Status = string(reservation.Status)
+			if !reservation.ExpiresAt.IsZero() {
+				dto.ExpiresAt = reservation.ExpiresAt.UTC().Format(time.RFC3339)
+				expiresAt[orderID] = reservation.ExpiresAt
+			}
+		}
+
+		out = append(out, dto)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		ei, iok := expiresAt[out[i].OrderID]
+		ej, jok := expiresAt[out[j].OrderID]
+
+		switch {
+		case iok && jok && !ei.Equal(ej):
+			return ei.Before(ej)
+		case iok != jok:
+			return iok
+		default:
+			return out[i].OrderID < out[j].OrderID
+		}
+	})
+
+	return out
+}
+
 // ============================================================
 // ProductBlueprint -> Inventory Detail DTO
 // ============================================================
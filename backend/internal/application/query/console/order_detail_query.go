@@ -39,11 +39,15 @@ type OrderDetailDTO struct {
 	Email    string `json:"email"`
 
 	Paid      bool   `json:"paid"`
+	Status    string `json:"status"`
 	CreatedAt string `json:"createdAt"`
 
 	ShippingAmount int `json:"shippingAmount"`
 	ConsumptionTax int `json:"consumptionTax"`
 
+	// RefundedAmount is the total of succeeded refunds.
+	RefundedAmount int `json:"refundedAmount"`
+
 	ShippingSnapshot orderdom.ShippingSnapshot `json:"shippingSnapshot"`
 	Items            []OrderDetailItemDTO      `json:"items"`
 }
@@ -84,6 +88,16 @@ type OrderDetailItemDTO struct {
 	IsCancelled  bool `json:"isCancelled"`
 	IsDispatched bool `json:"isDispatched"`
 
+	Status         string `json:"status"`
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"trackingNumber,omitempty"`
+	TrackingURL    string `json:"trackingUrl,omitempty"`
+	DispatchedAt   string `json:"dispatchedAt,omitempty"`
+	DeliveredAt    string `json:"deliveredAt,omitempty"`
+
+	RefundedQty    int `json:"refundedQty"`
+	RefundedAmount int `json:"refundedAmount"`
+
 	Transferred   bool   `json:"transferred"`
 	TransferredAt string `json:"transferredAt,omitempty"`
 }
@@ -214,8 +228,10 @@ func (q *OrderDetailQuery) toDTO(ctx context.Context, o orderdom.Order) (OrderDe
 		UserName:         q.userName.ResolveUserName(ctx, o.UserID),
 		Email:            email,
 		Paid:             o.Paid,
+		Status:           string(o.Status()),
 		ShippingAmount:   o.ShippingQuoteSnapshot.Amount,
 		ConsumptionTax:   consumptionTax,
+		RefundedAmount:   o.RefundedAmount(),
 		ShippingSnapshot: o.ShippingSnapshot,
 		Items:            make([]OrderDetailItemDTO, 0, len(o.Items)),
 	}
@@ -301,7 +317,7 @@ func (q *OrderDetailQuery) toDTO(ctx context.Context, o orderdom.Order) (OrderDe
 		return readableID, nil
 	}
 
-	for _, it := range o.Items {
+	for itemIndex, it := range o.Items {
 		pbID := it.ProductBlueprintID
 		tbID := it.TokenBlueprintID
 
@@ -388,6 +404,11 @@ func (q *OrderDetailQuery) toDTO(ctx context.Context, o orderdom.Order) (OrderDe
 			IsCancelled:  it.IsCancelled,
 			IsDispatched: it.IsDispatched,
 
+			Status: string(o.ItemStatus(itemIndex)),
+
+			RefundedQty:    o.ItemRefundedQty(itemIndex),
+			RefundedAmount: o.ItemRefundedAmount(itemIndex),
+
 			Transferred: it.Transferred,
 		}
 
@@ -395,6 +416,17 @@ func (q *OrderDetailQuery) toDTO(ctx context.Context, o orderdom.Order) (OrderDe
 			item.TransferredAt = it.TransferredAt.UTC().Format(time.RFC3339)
 		}
 
+		if it.Dispatch != nil {
+			item.Carrier = string(it.Dispatch.Carrier)
+			item.TrackingNumber = it.Dispatch.TrackingNumber
+			item.TrackingURL = it.Dispatch.TrackingURL()
+			item.DispatchedAt = it.Dispatch.DispatchedAt.UTC().Format(time.RFC3339)
+		}
+
+		if it.DeliveredAt != nil && !it.DeliveredAt.IsZero() {
+			item.DeliveredAt = it.DeliveredAt.UTC().Format(time.RFC3339)
+		}
+
 		dto.Items = append(dto.Items, item)
 	}
 
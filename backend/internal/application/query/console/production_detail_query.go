@@ -63,6 +63,16 @@ type ProductionDetailDTO struct {
 	UpdatedBy     *string    `json:"updatedBy,omitempty"`
 	UpdatedByName string     `json:"updatedByName,omitempty"`
 	UpdatedAt     *time.Time `json:"updatedAt,omitempty"`
+
+	Status            string                  `json:"status"`
+	StartedAt         *time.Time              `json:"startedAt,omitempty"`
+	InspectedAt       *time.Time              `json:"inspectedAt,omitempty"`
+	CompletedAt       *time.Time              `json:"completedAt,omitempty"`
+	CancelledAt       *time.Time              `json:"cancelledAt,omitempty"`
+	TargetStartAt     *time.Time              `json:"targetStartAt,omitempty"`
+	TargetInspectedAt *time.Time              `json:"targetInspectedAt,omitempty"`
+	TargetCompletedAt *time.Time              `json:"targetCompletedAt,omitempty"`
+	Overdue           []productiondom.Overdue `json:"overdue"`
 }
 
 // ============================================================
@@ -215,6 +225,15 @@ func (s *CompanyProductionQueryService) GetProductionDetailByID(
 		UpdatedBy:                    p.UpdatedBy,
 		UpdatedByName:                updatedByName,
 		UpdatedAt:                    productionTimePointer(p.UpdatedAt),
+		Status:                       string(p.Status),
+		StartedAt:                    p.StartedAt,
+		InspectedAt:                  p.InspectedAt,
+		CompletedAt:                  p.CompletedAt,
+		CancelledAt:                  p.CancelledAt,
+		TargetStartAt:                p.TargetStartAt,
+		TargetInspectedAt:            p.TargetInspectedAt,
+		TargetCompletedAt:            p.TargetCompletedAt,
+		Overdue:                      p.OverdueMilestones(time.Now().UTC()),
 	}, nil
 }
 
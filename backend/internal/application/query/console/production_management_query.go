@@ -37,6 +37,9 @@ type ProductionListItemDTO struct {
 	UpdatedByName      string                   `json:"updatedByName"`
 	UpdatedAt          *time.Time               `json:"updatedAt"`
 	TotalQuantity      int                      `json:"totalQuantity"`
+	Status             string                   `json:"status"`
+	TargetCompletedAt  *time.Time               `json:"targetCompletedAt"`
+	Overdue            bool                     `json:"overdue"`
 }
 
 // ============================================================
@@ -195,5 +198,8 @@ func (s *CompanyProductionQueryService) toProductionListItemDTO(ctx context.Cont
 		UpdatedByName:      updatedByName,
 		UpdatedAt:          productionTimePointer(production.UpdatedAt),
 		TotalQuantity:      totalQuantity,
+		Status:             string(production.Status),
+		TargetCompletedAt:  production.TargetCompletedAt,
+		Overdue:            production.IsOverdue(time.Now().UTC()),
 	}
 }
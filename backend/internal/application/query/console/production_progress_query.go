@@ -0,0 +1,454 @@
+// backend/internal/application/query/console/production_progress_query.go
+package query
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	resolver "narratives/internal/application/resolver"
+	inspectiondom "narratives/internal/domain/inspection"
+	mintdom "narratives/internal/domain/mint"
+	printdom "narratives/internal/domain/print"
+	productiondom "narratives/internal/domain/production"
+)
+
+var ErrProductionProgressQueryNotConfigured = errors.New("production progress query is not configured")
+
+// ProductionProgressPrintLogRepo は印刷履歴を productionID キーで取得する最小ポートです。
+type ProductionProgressPrintLogRepo interface {
+	GetByProductionID(ctx context.Context, productionID string) (printdom.PrintLog, error)
+}
+
+// ProductionProgressMintRepo は mint を取得する最小ポートです（mintID = productionID）。
+type ProductionProgressMintRepo interface {
+	GetByID(ctx context.Context, id string) (mintdom.Mint, error)
+}
+
+// ============================================================
+// DTO
+// ============================================================
+//
+// 進捗数量の定義（検品バッチから集計）:
+//   - planned   = production.models の quantity
+//   - produced  = 検品対象 item 数 - notManufactured
+//   - inspected = passed + failed（notYet は未検品として数えない）
+// 検品バッチが未作成の場合、planned 以外は 0 です。
+
+type ProductionProgressCountsDTO struct {
+	Planned         int `json:"planned"`
+	Produced        int `json:"produced"`
+	Inspected       int `json:"inspected"`
+	Passed          int `json:"passed"`
+	Failed          int `json:"failed"`
+	NotManufactured int `json:"notManufactured"`
+}
+
+type ProductionModelProgressDTO struct {
+	ModelID     string `json:"modelId"`
+	ModelNumber string `json:"modelNumber,omitempty"`
+	ProductionProgressCountsDTO
+}
+
+// Timeline event types
+const (
+	ProductionTimelineCreated             = "production.created"
+	ProductionTimelineStarted             = "production.started"
+	ProductionTimelinePrinted             = "print.printed"
+	ProductionTimelineInspectionStarted   = "inspection.started"
+	ProductionTimelineInspectionCompleted = "inspection.completed"
+	ProductionTimelineMintRequested       = "mint.requested"
+	ProductionTimelineMinted              = "mint.minted"
+	ProductionTimelineCompleted           = "production.completed"
+	ProductionTimelineCancelled           = "production.cancelled"
+)
+
+type ProductionTimelineEventDTO struct {
+	Type      string    `json:"type"`
+	At        time.Time `json:"at"`
+	ActorID   string    `json:"actorId,omitempty"`
+	ActorName string    `json:"actorName,omitempty"`
+	// 印刷枚数・検品件数など（イベントによっては null）
+	Count *int `json:"count,omitempty"`
+}
+
+type ProductionProgressDTO struct {
+	ProductionID       string `json:"productionId"`
+	ProductBlueprintID string `json:"productBlueprintId"`
+	ProductName        string `json:"productName"`
+	AssigneeID         string `json:"assigneeId"`
+	AssigneeName       string `json:"assigneeName"`
+
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	InspectedAt *time.Time `json:"inspectedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CancelledAt *time.Time `json:"cancelledAt,omitempty"`
+
+	TargetStartAt     *time.Time              `json:"targetStartAt,omitempty"`
+	TargetInspectedAt *time.Time              `json:"targetInspectedAt,omitempty"`
+	TargetCompletedAt *time.Time              `json:"targetCompletedAt,omitempty"`
+	Overdue           []productiondom.Overdue `json:"overdue"`
+
+	// 検品バッチの status（未作成なら空）
+	InspectionStatus string `json:"inspectionStatus,omitempty"`
+	// mint の status（未申請なら空）
+	MintStatus string `json:"mintStatus,omitempty"`
+
+	Total  ProductionProgressCountsDTO  `json:"total"`
+	Models []ProductionModelProgressDTO `json:"models"`
+
+	Timeline []ProductionTimelineEventDTO `json:"timeline"`
+}
+
+type OverdueProductionDTO struct {
+	ProductionID       string                  `json:"productionId"`
+	ProductBlueprintID string                  `json:"productBlueprintId"`
+	ProductName        string                  `json:"productName"`
+	AssigneeID         string                  `json:"assigneeId"`
+	AssigneeName       string                  `json:"assigneeName"`
+	Status             string                  `json:"status"`
+	Overdue            []productiondom.Overdue `json:"overdue"`
+	// 最も古い未達 milestone の目標日からの経過日数
+	DaysOverdue int `json:"daysOverdue"`
+}
+
+// ============================================================
+// Query
+// ============================================================
+
+type ProductionProgressQuery struct {
+	productionQuery *CompanyProductionQueryService
+	inspRepo        InspectionAnalyticsRepo
+	printLogRepo    ProductionProgressPrintLogRepo
+	mintRepo        ProductionProgressMintRepo
+	nameResolver    *resolver.NameResolver
+	now             func() time.Time
+}
+
+func NewProductionProgressQuery(
+	productionQuery *CompanyProductionQueryService,
+	inspRepo InspectionAnalyticsRepo,
+	printLogRepo ProductionProgressPrintLogRepo,
+	mintRepo ProductionProgressMintRepo,
+	nameResolver *resolver.NameResolver,
+) *ProductionProgressQuery {
+	return &ProductionProgressQuery{
+		productionQuery: productionQuery,
+		inspRepo:        inspRepo,
+		printLogRepo:    printLogRepo,
+		mintRepo:        mintRepo,
+		nameResolver:    nameResolver,
+		now:             time.Now,
+	}
+}
+
+// GetProductionProgress は 1 production の status・目標日・遅延・model 別の進捗数量と、
+// 印刷・検品・ミントを合わせたタイムラインを返します。
+func (q *ProductionProgressQuery) GetProductionProgress(
+	ctx context.Context,
+	productionID string,
+) (ProductionProgressDTO, error) {
+	if q == nil || q.productionQuery == nil || q.inspRepo == nil {
+		return ProductionProgressDTO{}, ErrProductionProgressQueryNotConfigured
+	}
+
+	p, pb, err := q.productionQuery.getProductionByIDForCurrentCompany(ctx, productionID)
+	if err != nil {
+		return ProductionProgressDTO{}, err
+	}
+
+	var batch *inspectiondom.InspectionBatch
+	if b, err := q.inspRepo.GetByProductionID(ctx, p.ID); err == nil {
+		batch = &b
+	} else if !errors.Is(err, inspectiondom.ErrNotFound) {
+		return ProductionProgressDTO{}, err
+	}
+
+	var printLog *printdom.PrintLog
+	if q.printLogRepo != nil && p.Printed {
+		if pl, err := q.printLogRepo.GetByProductionID(ctx, p.ID); err == nil {
+			printLog = &pl
+		} else if !errors.Is(err, printdom.ErrNotFound) {
+			return ProductionProgressDTO{}, err
+		}
+	}
+
+	var mint *mintdom.Mint
+	if q.mintRepo != nil && batch != nil && batch.MintID != nil {
+		if m, err := q.mintRepo.GetByID(ctx, *batch.MintID); err == nil {
+			mint = &m
+		} else if !errors.Is(err, mintdom.ErrNotFound) {
+			return ProductionProgressDTO{}, err
+		}
+	}
+
+	total, models := q.rollUpProgress(ctx, p, batch)
+
+	out := ProductionProgressDTO{
+		ProductionID:       p.ID,
+		ProductBlueprintID: p.ProductBlueprintID,
+		ProductName:        pb.ProductName,
+		AssigneeID:         p.AssigneeID,
+		AssigneeName:       q.resolveMemberName(ctx, p.AssigneeID),
+		Status:             string(p.Status),
+		StartedAt:          p.StartedAt,
+		InspectedAt:        p.InspectedAt,
+		CompletedAt:        p.CompletedAt,
+		CancelledAt:        p.CancelledAt,
+		TargetStartAt:      p.TargetStartAt,
+		TargetInspectedAt:  p.TargetInspectedAt,
+		TargetCompletedAt:  p.TargetCompletedAt,
+		Overdue:            p.OverdueMilestones(q.now().UTC()),
+		Total:              total,
+		Models:             models,
+		Timeline:           q.buildTimeline(ctx, p, printLog, batch, mint),
+	}
+
+	if batch != nil {
+		out.InspectionStatus = string(batch.Status)
+	}
+	if mint != nil {
+		out.MintStatus = string(mint.Status)
+	}
+
+	return out, nil
+}
+
+// ListOverdueProductions は current company の遅延している production を、
+// 最も古い未達 milestone の目標日が早い順に返します。
+func (q *ProductionProgressQuery) ListOverdueProductions(
+	ctx context.Context,
+) ([]OverdueProductionDTO, error) {
+	if q == nil || q.productionQuery == nil {
+		return nil, ErrProductionProgressQueryNotConfigured
+	}
+
+	productions, pbByID, err := q.productionQuery.listProductionsByCurrentCompany(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := q.now().UTC()
+	out := make([]OverdueProductionDTO, 0)
+
+	for _, p := range productions {
+		overdue := p.OverdueMilestones(now)
+		if len(overdue) == 0 {
+			continue
+		}
+
+		out = append(out, OverdueProductionDTO{
+			ProductionID:       p.ID,
+			ProductBlueprintID: p.ProductBlueprintID,
+			ProductName:        pbByID[p.ProductBlueprintID].ProductName,
+			AssigneeID:         p.AssigneeID,
+			AssigneeName:       q.resolveMemberName(ctx, p.AssigneeID),
+			Status:             string(p.Status),
+			Overdue:            overdue,
+			DaysOverdue:        int(now.Sub(overdue[0].TargetAt).Hours() / 24),
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		ti, tj := out[i].Overdue[0].TargetAt, out[j].Overdue[0].TargetAt
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return out[i].ProductionID < out[j].ProductionID
+	})
+
+	return out, nil
+}
+
+// ============================================================
+// Roll-up
+// ============================================================
+
+func (q *ProductionProgressQuery) rollUpProgress(
+	ctx context.Context,
+	p productiondom.Production,
+	batch *inspectiondom.InspectionBatch,
+) (ProductionProgressCountsDTO, []ProductionModelProgressDTO) {
+	byModel := make(map[string]*ProductionProgressCountsDTO, len(p.Models))
+	modelIDs := make([]string, 0, len(p.Models))
+
+	get := func(modelID string) *ProductionProgressCountsDTO {
+		c, ok := byModel[modelID]
+		if !ok {
+			c = &ProductionProgressCountsDTO{}
+			byModel[modelID] = c
+			modelIDs = append(modelIDs, modelID)
+		}
+		return c
+	}
+
+	for _, m := range p.Models {
+		get(m.ModelID).Planned += m.Quantity
+	}
+
+	if batch != nil {
+		for _, item := range batch.Inspections {
+			c := get(item.ModelID)
+			c.Produced++
+
+			if item.InspectionResult == nil {
+				continue
+			}
+
+			switch *item.InspectionResult {
+			case inspectiondom.InspectionPassed:
+				c.Inspected++
+				c.Passed++
+			case inspectiondom.InspectionFailed:
+				c.Inspected++
+				c.Failed++
+			case inspectiondom.InspectionNotManufactured:
+				c.Produced--
+				c.NotManufactured++
+			}
+		}
+	}
+
+	total := ProductionProgressCountsDTO{}
+	models := make([]ProductionModelProgressDTO, 0, len(modelIDs))
+
+	for _, modelID := range modelIDs {
+		c := *byModel[modelID]
+
+		total.Planned += c.Planned
+		total.Produced += c.Produced
+		total.Inspected += c.Inspected
+		total.Passed += c.Passed
+		total.Failed += c.Failed
+		total.NotManufactured += c.NotManufactured
+
+		modelNumber := ""
+		if q.nameResolver != nil && modelID != "" {
+			modelNumber = q.nameResolver.ResolveModelNumber(ctx, modelID)
+		}
+
+		models = append(models, ProductionModelProgressDTO{
+			ModelID:                     modelID,
+			ModelNumber:                 modelNumber,
+			ProductionProgressCountsDTO: c,
+		})
+	}
+
+	return total, models
+}
+
+// ============================================================
+// Timeline
+// ============================================================
+
+func (q *ProductionProgressQuery) buildTimeline(
+	ctx context.Context,
+	p productiondom.Production,
+	printLog *printdom.PrintLog,
+	batch *inspectiondom.InspectionBatch,
+	mint *mintdom.Mint,
+) []ProductionTimelineEventDTO {
+	events := make([]ProductionTimelineEventDTO, 0, 8)
+
+	add := func(eventType string, at *time.Time, actorID string, count *int) {
+		if at == nil || at.IsZero() {
+			return
+		}
+		events = append(events, ProductionTimelineEventDTO{
+			Type:      eventType,
+			At:        at.UTC(),
+			ActorID:   actorID,
+			ActorName: q.resolveMemberName(ctx, actorID),
+			Count:     count,
+		})
+	}
+
+	add(ProductionTimelineCreated, productionTimePointer(p.CreatedAt), progressActorID(p.CreatedBy), nil)
+
+	// 印刷で製造開始した場合は print.printed と同時刻になるため、started は手動開始のときのみ
+	if p.StartedAt != nil && (p.PrintedAt == nil || !p.StartedAt.Equal(*p.PrintedAt)) {
+		add(ProductionTimelineStarted, p.StartedAt, "", nil)
+	}
+
+	if p.Printed {
+		var count *int
+		if printLog != nil {
+			n := len(printLog.Items)
+			count = &n
+		}
+		add(ProductionTimelinePrinted, p.PrintedAt, progressActorID(p.PrintedBy), count)
+	}
+
+	if batch != nil {
+		var first, last *inspectiondom.InspectionItem
+		inspected := 0
+
+		for i := range batch.Inspections {
+			item := &batch.Inspections[i]
+			if item.InspectedAt == nil || item.InspectedAt.IsZero() {
+				continue
+			}
+			inspected++
+
+			if first == nil || item.InspectedAt.Before(*first.InspectedAt) {
+				first = item
+			}
+			if last == nil || item.InspectedAt.After(*last.InspectedAt) {
+				last = item
+			}
+		}
+
+		if first != nil {
+			add(ProductionTimelineInspectionStarted, first.InspectedAt, progressActorID(first.InspectedBy), nil)
+		}
+
+		if batch.Status == inspectiondom.InspectionStatusCompleted {
+			completedAt := p.InspectedAt
+			actorID := ""
+			if last != nil {
+				if completedAt == nil {
+					completedAt = last.InspectedAt
+				}
+				actorID = progressActorID(last.InspectedBy)
+			}
+			add(ProductionTimelineInspectionCompleted, completedAt, actorID, &inspected)
+		}
+	}
+
+	if mint != nil {
+		actorID := mint.RequestedBy
+		if actorID == "" {
+			actorID = mint.CreatedBy
+		}
+		requestedAt := mint.CreatedAt
+		add(ProductionTimelineMintRequested, &requestedAt, actorID, nil)
+
+		n := len(mint.Products)
+		add(ProductionTimelineMinted, mint.MintedAt, "", &n)
+	}
+
+	add(ProductionTimelineCompleted, p.CompletedAt, "", nil)
+	add(ProductionTimelineCancelled, p.CancelledAt, "", nil)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+
+	return events
+}
+
+func (q *ProductionProgressQuery) resolveMemberName(ctx context.Context, memberID string) string {
+	if q.nameResolver == nil || memberID == "" {
+		return ""
+	}
+	return q.nameResolver.ResolveMemberNameByUID(ctx, memberID)
+}
+
+func progressActorID(id *string) string {
+	if id == nil {
+		return ""
+	}
+	return *id
+}
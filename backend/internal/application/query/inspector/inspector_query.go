@@ -305,6 +305,11 @@ type InspectionItemDTO struct {
 	InspectionResult *string `json:"inspectionResult,omitempty"`
 	InspectedBy      *string `json:"inspectedBy,omitempty"`
 	InspectedAt      *string `json:"inspectedAt,omitempty"` // RFC3339
+
+	// failed の場合のみ
+	DefectReasons   []string `json:"defectReasons,omitempty"`
+	DefectNote      *string  `json:"defectNote,omitempty"`
+	DefectPhotoURLs []string `json:"defectPhotoUrls,omitempty"`
 }
 
 type InspectionBatchForScreenDTO struct {
@@ -340,6 +345,10 @@ func NewInspectionBatchForScreenDTO(
 			InspectionResult: res,
 			InspectedBy:      it.InspectedBy,
 			InspectedAt:      inspectedAt,
+
+			DefectReasons:   it.DefectReasons,
+			DefectNote:      it.DefectNote,
+			DefectPhotoURLs: it.DefectPhotoURLs,
 		})
 	}
 
@@ -228,6 +228,8 @@ func toCartDTO(
 		CreatedAt: toRFC3339Ptr(cart.CreatedAt),
 		UpdatedAt: toRFC3339Ptr(cart.UpdatedAt),
 		ExpiresAt: toRFC3339Ptr(cart.ExpiresAt),
+
+		CouponCode: cart.CouponCode,
 	}
 
 	if cart.Items == nil {
@@ -43,6 +43,16 @@ type ProductBlueprintReviewRepository interface {
 	)
 }
 
+// ListAccessEvaluator は holder-only list に対する avatar の保有条件を判定します。
+// ListAccessUsecase が実装します。
+type ListAccessEvaluator interface {
+	Evaluate(
+		ctx context.Context,
+		avatarID string,
+		list ldom.List,
+	) (ldom.GatingResult, error)
+}
+
 // ============================================================
 // Query
 // ============================================================
@@ -55,6 +65,7 @@ type CatalogQuery struct {
 	ProductBlueprintReviewRepo ProductBlueprintReviewRepository
 	ListImageRepo              applicationport.ListImageLister
 	NameResolver               *appresolver.NameResolver
+	ListAccess                 ListAccessEvaluator
 }
 
 // ============================================================
@@ -71,6 +82,7 @@ func NewCatalogQuery(
 	tokenRepo applicationport.TokenBlueprintGetter,
 	productBlueprintReviewRepo ProductBlueprintReviewRepository,
 	nameResolver *appresolver.NameResolver,
+	listAccess ListAccessEvaluator,
 ) *CatalogQuery {
 	return &CatalogQuery{
 		ListRepo:                   listRepo,
@@ -81,6 +93,7 @@ func NewCatalogQuery(
 		ProductBlueprintReviewRepo: productBlueprintReviewRepo,
 		ListImageRepo:              listImageRepo,
 		NameResolver:               nameResolver,
+		ListAccess:                 listAccess,
 	}
 }
 
@@ -399,6 +412,49 @@ func (
 	return output, nil
 }
 
+// GetByListIDForAvatar は GetByListID に avatar の holder-only 判定結果を付けて返します.
+//
+// gating rule の無い list では Access は nil のままです。
+func (
+	q *CatalogQuery,
+) GetByListIDForAvatar(
+	ctx context.Context,
+	listID string,
+	avatarID string,
+) (dto.CatalogDTO, error) {
+	output, err :=
+		q.GetByListID(
+			ctx,
+			listID,
+		)
+	if err != nil {
+		return dto.CatalogDTO{}, err
+	}
+	if !output.List.HolderOnly {
+		return output, nil
+	}
+	if q.ListAccess == nil {
+		return dto.CatalogDTO{},
+			errors.New(
+				"catalog query: list access evaluator is nil",
+			)
+	}
+	access, err :=
+		q.ListAccess.Evaluate(
+			ctx,
+			avatarID,
+			ldom.List{
+				ID:     output.List.ID,
+				Gating: output.List.Gating,
+			},
+		)
+	if err != nil {
+		return dto.CatalogDTO{}, err
+	}
+	output.Access = &access
+	return output, nil
+}
+
 // ============================================================
 // ListImages
 // ============================================================
@@ -484,6 +540,8 @@ func toCatalogListDTO(
 		Image:       listItem.ImageID,
 		Prices:      listItem.Prices,
 		InventoryID: listItem.InventoryID,
+		HolderOnly:  listItem.IsHolderOnly(),
+		Gating:      listItem.Gating,
 	}
 }
 
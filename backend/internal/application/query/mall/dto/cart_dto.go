@@ -10,6 +10,8 @@ type CartDTO struct {
 	CreatedAt *string `json:"createdAt,omitempty"`
 	UpdatedAt *string `json:"updatedAt,omitempty"`
 	ExpiresAt *string `json:"expiresAt,omitempty"`
+
+	CouponCode string `json:"couponCode,omitempty"`
 }
 
 type CartItemDTO struct {
@@ -24,6 +24,9 @@ type CatalogDTO struct {
 	ModelVariations []CatalogModelVariationDTO `json:"modelVariations,omitempty"`
 
 	ProductReviewSummary *CatalogProductReviewSummaryDTO `json:"productReviewSummary,omitempty"`
+
+	// holder-only list の閲覧 avatar に対する判定結果（/mall/me/catalog のみ）
+	Access *ldom.GatingResult `json:"access,omitempty"`
 }
 
 type CatalogListDTO struct {
@@ -34,6 +37,9 @@ type CatalogListDTO struct {
 	Prices      []ldom.ListPriceRow `json:"prices"`
 
 	InventoryID string `json:"inventoryId,omitempty"`
+
+	HolderOnly bool              `json:"holderOnly"`
+	Gating     []ldom.GatingRule `json:"gating,omitempty"`
 }
 
 // ============================================================
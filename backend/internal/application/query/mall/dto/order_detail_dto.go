@@ -13,6 +13,7 @@ type OrderDetail struct {
 
 	Paid        bool              `json:"paid"`
 	IsCancelled bool              `json:"isCancelled"`
+	Status      string            `json:"status"`
 	Items       []OrderDetailItem `json:"items"`
 
 	CreatedAt string `json:"createdAt,omitempty"`
@@ -60,6 +61,13 @@ type OrderDetailItem struct {
 	IsCanceled   bool `json:"isCanceled"`
 	IsDispatched bool `json:"isDispatched"`
 
+	Status         string `json:"status"`
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"trackingNumber,omitempty"`
+	TrackingURL    string `json:"trackingUrl,omitempty"`
+	DispatchedAt   string `json:"dispatchedAt,omitempty"`
+	DeliveredAt    string `json:"deliveredAt,omitempty"`
+
 	Transferred   bool   `json:"transferred"`
 	TransferredAt string `json:"transferredAt,omitempty"`
 }
@@ -36,6 +36,10 @@ type ListItemDTO struct {
 	InventoryID        string `json:"inventoryId,omitempty"`
 	ProductBlueprintID string `json:"productBlueprintId,omitempty"`
 	TokenBlueprintID   string `json:"tokenBlueprintId,omitempty"`
+
+	// holder-only list の場合 true。購入には gating のいずれかの保有条件を満たす必要がある。
+	HolderOnly bool              `json:"holderOnly"`
+	Gating     []ldom.GatingRule `json:"gating,omitempty"`
 }
 
 type ListIndexResponseDTO struct {
@@ -147,6 +151,8 @@ func (q *ListQuery) toListItemDTO(
 		InventoryID:        inventoryID,
 		ProductBlueprintID: productBlueprintID,
 		TokenBlueprintID:   tokenBlueprintID,
+		HolderOnly:         l.IsHolderOnly(),
+		Gating:             l.Gating,
 	}, nil
 }
 
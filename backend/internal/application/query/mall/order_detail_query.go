@@ -53,7 +53,8 @@ func (q *OrderDetailQuery) EnrichOrderDetail(
 			in.ShippingQuoteSnapshot,
 		),
 
-		Paid: in.Paid,
+		Paid:   in.Paid,
+		Status: string(in.Status()),
 
 		Items: make(
 			[]orderdetaildto.OrderDetailItem,
@@ -114,6 +115,8 @@ func (q *OrderDetailQuery) EnrichOrderDetail(
 			IsCanceled:   sourceItem.IsCancelled,
 			IsDispatched: sourceItem.IsDispatched,
 
+			Status: string(sourceItem.EffectiveStatus(in.Paid)),
+
 			Transferred: sourceItem.Transferred,
 		}
 
@@ -124,6 +127,22 @@ func (q *OrderDetailQuery) EnrichOrderDetail(
 				Format(time.RFC3339Nano)
 		}
 
+		if sourceItem.Dispatch != nil {
+			item.Carrier = string(sourceItem.Dispatch.Carrier)
+			item.TrackingNumber = sourceItem.Dispatch.TrackingNumber
+			item.TrackingURL = sourceItem.Dispatch.TrackingURL()
+			item.DispatchedAt = sourceItem.Dispatch.DispatchedAt.
+				UTC().
+				Format(time.RFC3339Nano)
+		}
+
+		if sourceItem.DeliveredAt != nil &&
+			!sourceItem.DeliveredAt.IsZero() {
+			item.DeliveredAt = sourceItem.DeliveredAt.
+				UTC().
+				Format(time.RFC3339Nano)
+		}
+
 		blueprintIDs := historyBlueprintIDs{
 			ProductBlueprintID: sourceItem.ProductBlueprintID,
 			TokenBlueprintID:   sourceItem.TokenBlueprintID,
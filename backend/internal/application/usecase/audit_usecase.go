@@ -0,0 +1,212 @@
+// backend/internal/application/usecase/audit_usecase.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	auditdom "narratives/internal/domain/audit"
+)
+
+// AuditRecorder は usecase の書き込みを監査ログに記録します。
+// 各 usecase は変更前後のエンティティを渡し、差分は AuditUsecase が計算します。
+type AuditRecorder interface {
+	Record(ctx context.Context, in RecordAuditInput) error
+}
+
+// AuditLogRenderer は監査ログを CSV に変換します。
+type AuditLogRenderer interface {
+	RenderAuditLogs(entries []auditdom.Entry) ([]byte, error)
+}
+
+// AuditLogFile はダウンロード用のファイルです。
+type AuditLogFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+var ErrAuditNotConfigured = errors.New(
+	"audit: usecase is not configured",
+)
+
+type AuditUsecase struct {
+	repo     auditdom.RepositoryPort
+	renderer AuditLogRenderer
+	now      func() time.Time
+}
+
+var _ AuditRecorder = (*AuditUsecase)(nil)
+
+func NewAuditUsecase(repo auditdom.RepositoryPort) *AuditUsecase {
+	return &AuditUsecase{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// WithRenderer は CSV 出力を有効にします。
+func (u *AuditUsecase) WithRenderer(renderer AuditLogRenderer) *AuditUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.renderer = renderer
+	return u
+}
+
+// ============================================================
+// Record
+// ============================================================
+
+type RecordAuditInput struct {
+	// CompanyID は context に company がない場合（内部処理など）にだけ使います。
+	CompanyID string
+
+	EntityType auditdom.EntityType
+	EntityID   string
+	Action     auditdom.Action
+
+	Before any
+	After  any
+}
+
+// Record は current member の操作として監査ログを追記します。
+// 更新で差分がない場合は記録しません。
+func (u *AuditUsecase) Record(
+	ctx context.Context,
+	in RecordAuditInput,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrAuditNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		companyID = in.CompanyID
+	}
+
+	e, err := auditdom.NewEntry(auditdom.NewEntryInput{
+		CompanyID:     companyID,
+		ActorMemberID: MemberIDFromContext(ctx),
+		EntityType:    in.EntityType,
+		EntityID:      in.EntityID,
+		Action:        in.Action,
+		Before:        in.Before,
+		After:         in.After,
+	}, u.now())
+	if err != nil {
+		return err
+	}
+
+	if e.Action == auditdom.ActionUpdate && len(e.Changes) == 0 {
+		return nil
+	}
+
+	_, err = u.repo.Append(ctx, e)
+	return err
+}
+
+// recordAudit は監査ログを best-effort で記録します。
+// 記録に失敗しても元の書き込みは成功のまま返します。
+func recordAudit(
+	ctx context.Context,
+	recorder AuditRecorder,
+	in RecordAuditInput,
+) {
+	if recorder == nil {
+		return
+	}
+
+	if err := recorder.Record(ctx, in); err != nil {
+		log.Printf(
+			"audit: record %s %s id=%q err=%v",
+			in.EntityType,
+			in.Action,
+			in.EntityID,
+			err,
+		)
+	}
+}
+
+// ============================================================
+// Search / Export
+// ============================================================
+
+type SearchAuditLogsInput struct {
+	EntityType    auditdom.EntityType
+	EntityID      string
+	ActorMemberID string
+
+	From time.Time
+	To   time.Time
+
+	Limit int
+}
+
+// Search は current company の監査ログを新しい順で返します。
+func (u *AuditUsecase) Search(
+	ctx context.Context,
+	in SearchAuditLogsInput,
+) ([]auditdom.Entry, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrAuditNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nil, auditdom.ErrInvalidCompanyID
+	}
+
+	if !in.From.IsZero() && !in.To.IsZero() && !in.From.Before(in.To) {
+		return nil, auditdom.ErrInvalidRange
+	}
+
+	return u.repo.Search(ctx, auditdom.Filter{
+		CompanyID:     companyID,
+		EntityType:    auditdom.EntityType(strings.TrimSpace(string(in.EntityType))),
+		EntityID:      strings.TrimSpace(in.EntityID),
+		ActorMemberID: strings.TrimSpace(in.ActorMemberID),
+		From:          in.From,
+		To:            in.To,
+		Limit:         in.Limit,
+	})
+}
+
+// Export は Search の結果を CSV で返します。
+// 件数の上限は MaxSearchLimit です。
+func (u *AuditUsecase) Export(
+	ctx context.Context,
+	in SearchAuditLogsInput,
+) (AuditLogFile, error) {
+	if u == nil || u.renderer == nil {
+		return AuditLogFile{}, ErrAuditNotConfigured
+	}
+
+	if in.Limit <= 0 {
+		in.Limit = auditdom.MaxSearchLimit
+	}
+
+	entries, err := u.Search(ctx, in)
+	if err != nil {
+		return AuditLogFile{}, err
+	}
+
+	content, err := u.renderer.RenderAuditLogs(entries)
+	if err != nil {
+		return AuditLogFile{}, err
+	}
+
+	return AuditLogFile{
+		FileName: fmt.Sprintf(
+			"audit-logs-%s.csv",
+			u.now().UTC().Format("20060102-150405"),
+		),
+		ContentType: "text/csv; charset=utf-8",
+		Content:     content,
+	}, nil
+}
@@ -0,0 +1,414 @@
+// backend/internal/application/usecase/authenticity_usecase.go
+package usecase
+
+/*
+責務:
+- QR コード（https://amol.jp/{productId}）のスキャン時に、製品が本物かを判定する（公開 API）。
+  productId → 検査結果 → mint 済みトークン（assetId） → 現在の保有者（tokens.toAddress）
+  → オンチェーンで保有者が assetId を保有しているか、の順に確認する。
+- ブランド・トークン設計（tokenBlueprint）の公開情報を返す。
+- スキャン（日時・位置・User-Agent）を記録し、同じ productId が多数の場所で読まれている
+  などの不審なパターンを検出する。
+
+前提:
+- 存在しない productId のスキャンは記録しない（任意の ID でドキュメントが作られるのを防ぐ）。
+- スキャンの記録・ブランド等の付加情報の取得に失敗しても、判定結果は返す。
+*/
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	applicationport "narratives/internal/application/port"
+	authdom "narratives/internal/domain/authenticity"
+	productdom "narratives/internal/domain/product"
+	tokendom "narratives/internal/domain/token"
+	walletdom "narratives/internal/domain/wallet"
+)
+
+var (
+	ErrAuthenticityNotConfigured    = errors.New("authenticity usecase: not configured")
+	ErrAuthenticityInvalidProductID = errors.New("authenticity usecase: invalid productId")
+)
+
+// AuthenticityTokenReader は productId から mint 済みトークンを取得します。
+// 実装は firestore.TokenReaderFS（未 mint の場合は tokendom.ErrNotFound）。
+type AuthenticityTokenReader interface {
+	GetTokenByProductID(
+		ctx context.Context,
+		productID string,
+	) (tokendom.GetTokenByProductIDResult, error)
+}
+
+type AuthenticityUsecase struct {
+	products        applicationport.ProductGetter
+	tokens          AuthenticityTokenReader
+	onchain         walletdom.OnchainReader
+	brands          applicationport.BrandGetter
+	tokenBlueprints applicationport.TokenBlueprintGetter
+	scans           authdom.ScanRepositoryPort
+
+	policy authdom.SuspicionPolicy
+
+	now func() time.Time
+}
+
+func NewAuthenticityUsecase(
+	products applicationport.ProductGetter,
+	tokens AuthenticityTokenReader,
+	onchain walletdom.OnchainReader,
+	brands applicationport.BrandGetter,
+	tokenBlueprints applicationport.TokenBlueprintGetter,
+	scans authdom.ScanRepositoryPort,
+) *AuthenticityUsecase {
+	return &AuthenticityUsecase{
+		products:        products,
+		tokens:          tokens,
+		onchain:         onchain,
+		brands:          brands,
+		tokenBlueprints: tokenBlueprints,
+		scans:           scans,
+		policy:          authdom.DefaultSuspicionPolicy(),
+		now:             time.Now,
+	}
+}
+
+// WithSuspicionPolicy は不審なスキャンの判定基準を差し替えます。
+func (u *AuthenticityUsecase) WithSuspicionPolicy(
+	policy authdom.SuspicionPolicy,
+) *AuthenticityUsecase {
+	if u == nil || policy.Window <= 0 {
+		return u
+	}
+
+	u.policy = policy
+
+	return u
+}
+
+// ============================================================
+// Input / Result
+// ============================================================
+
+type VerifyAuthenticityInput struct {
+	ProductID string
+
+	Location  authdom.Location
+	UserAgent string
+}
+
+type AuthenticityToken struct {
+	AssetID            string    `json:"assetId"`
+	AssetStandard      string    `json:"assetStandard,omitempty"`
+	Cluster            string    `json:"cluster,omitempty"`
+	MetadataURI        string    `json:"metadataUri,omitempty"`
+	MintedAt           time.Time `json:"mintedAt"`
+	OnChainTxSignature string    `json:"onChainTxSignature,omitempty"`
+}
+
+type AuthenticityOwner struct {
+	WalletAddress string `json:"walletAddress"`
+
+	// IsBrand は保有者がブランドのウォレット（未販売）かです。
+	IsBrand bool `json:"isBrand"`
+
+	// OnchainVerified はオンチェーンで assetId の保有を確認できたかです。
+	OnchainVerified bool `json:"onchainVerified"`
+}
+
+type AuthenticityBrand struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Icon       string `json:"icon,omitempty"`
+	WebsiteURL string `json:"websiteUrl,omitempty"`
+}
+
+type AuthenticityTokenBlueprint struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Symbol  string `json:"symbol"`
+	IconURL string `json:"iconUrl,omitempty"`
+}
+
+type AuthenticityScan struct {
+	ID                string           `json:"id,omitempty"`
+	ScannedAt         time.Time        `json:"scannedAt"`
+	Suspicious        bool             `json:"suspicious"`
+	SuspiciousReasons []authdom.Reason `json:"suspiciousReasons,omitempty"`
+}
+
+type AuthenticityResult struct {
+	ProductID string           `json:"productId"`
+	Verdict   authdom.Verdict  `json:"verdict"`
+	Reasons   []authdom.Reason `json:"reasons,omitempty"`
+
+	InspectionResult productdom.InspectionResult `json:"inspectionResult,omitempty"`
+	InspectedAt      *time.Time                  `json:"inspectedAt,omitempty"`
+
+	Token          *AuthenticityToken          `json:"token,omitempty"`
+	Owner          *AuthenticityOwner          `json:"owner,omitempty"`
+	Brand          *AuthenticityBrand          `json:"brand,omitempty"`
+	TokenBlueprint *AuthenticityTokenBlueprint `json:"tokenBlueprint,omitempty"`
+
+	Scan *AuthenticityScan `json:"scan,omitempty"`
+}
+
+// ============================================================
+// Verify
+// ============================================================
+
+// Verify は productId の真贋を判定し、スキャンを記録します。
+func (u *AuthenticityUsecase) Verify(
+	ctx context.Context,
+	in VerifyAuthenticityInput,
+) (AuthenticityResult, error) {
+	if u == nil || u.products == nil || u.tokens == nil {
+		return AuthenticityResult{}, ErrAuthenticityNotConfigured
+	}
+
+	productID := strings.TrimSpace(in.ProductID)
+	if productID == "" || strings.Contains(productID, "/") {
+		return AuthenticityResult{}, ErrAuthenticityInvalidProductID
+	}
+
+	now := u.now().UTC()
+
+	res := AuthenticityResult{
+		ProductID: productID,
+	}
+
+	p, err := u.products.GetByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, productdom.ErrNotFound) {
+			res.Verdict = authdom.VerdictNotAuthentic
+			res.Reasons = []authdom.Reason{authdom.ReasonProductNotFound}
+			return res, nil
+		}
+		return AuthenticityResult{}, err
+	}
+
+	res.InspectionResult = p.InspectionResult
+	res.InspectedAt = p.InspectedAt
+
+	if err := u.resolveVerdict(ctx, &res); err != nil {
+		return AuthenticityResult{}, err
+	}
+
+	res.Scan = u.recordScan(ctx, res, in, now)
+
+	return res, nil
+}
+
+// resolveVerdict は検査結果・トークン・オンチェーンの保有者から Verdict を決めます。
+func (u *AuthenticityUsecase) resolveVerdict(
+	ctx context.Context,
+	res *AuthenticityResult,
+) error {
+	switch res.InspectionResult {
+	case productdom.InspectionPassed:
+	case productdom.InspectionFailed:
+		res.Verdict = authdom.VerdictNotAuthentic
+		res.Reasons = []authdom.Reason{authdom.ReasonInspectionFailed}
+		return nil
+	case productdom.InspectionNotManufactured:
+		res.Verdict = authdom.VerdictNotAuthentic
+		res.Reasons = []authdom.Reason{authdom.ReasonNotManufactured}
+		return nil
+	default:
+		res.Verdict = authdom.VerdictUnverified
+		res.Reasons = []authdom.Reason{authdom.ReasonInspectionPending}
+		return nil
+	}
+
+	tok, err := u.tokens.GetTokenByProductID(ctx, res.ProductID)
+	if err != nil {
+		if errors.Is(err, tokendom.ErrNotFound) {
+			res.Verdict = authdom.VerdictUnverified
+			res.Reasons = []authdom.Reason{authdom.ReasonNotMinted}
+			return nil
+		}
+		return err
+	}
+
+	res.Token = &AuthenticityToken{
+		AssetID:            tok.AssetID,
+		AssetStandard:      string(tok.AssetStandard),
+		Cluster:            tok.Cluster,
+		MetadataURI:        tok.MetadataURI,
+		MintedAt:           tok.MintedAt,
+		OnChainTxSignature: tok.OnChainTxSignature,
+	}
+
+	brandWallet := u.attachBrand(ctx, res, tok.BrandID)
+	u.attachTokenBlueprint(ctx, res, tok.TokenBlueprintID)
+
+	owner := strings.TrimSpace(tok.ToAddress)
+	if owner == "" {
+		res.Verdict = authdom.VerdictUnverified
+		res.Reasons = []authdom.Reason{authdom.ReasonOwnerUnknown}
+		return nil
+	}
+
+	res.Owner = &AuthenticityOwner{
+		WalletAddress: owner,
+		IsBrand:       brandWallet != "" && brandWallet == owner,
+	}
+
+	if u.onchain == nil {
+		res.Verdict = authdom.VerdictUnverified
+		res.Reasons = []authdom.Reason{authdom.ReasonOnchainUnavailable}
+		return nil
+	}
+
+	assetIDs, err := u.onchain.ListOwnedAssetIDs(ctx, owner)
+	if err != nil {
+		log.Printf(
+			"[authenticity] onchain lookup failed productId=%q wallet=%q err=%v",
+			res.ProductID,
+			owner,
+			err,
+		)
+		res.Verdict = authdom.VerdictUnverified
+		res.Reasons = []authdom.Reason{authdom.ReasonOnchainUnavailable}
+		return nil
+	}
+
+	for _, id := range assetIDs {
+		if id == tok.AssetID {
+			res.Owner.OnchainVerified = true
+			break
+		}
+	}
+
+	if !res.Owner.OnchainVerified {
+		res.Verdict = authdom.VerdictUnverified
+		res.Reasons = []authdom.Reason{authdom.ReasonOnchainOwnerMismatch}
+		return nil
+	}
+
+	res.Verdict = authdom.VerdictAuthentic
+	return nil
+}
+
+// attachBrand はブランドの公開情報を設定し、ブランドのウォレットアドレスを返します。
+func (u *AuthenticityUsecase) attachBrand(
+	ctx context.Context,
+	res *AuthenticityResult,
+	brandID string,
+) string {
+	brandID = strings.TrimSpace(brandID)
+	if u.brands == nil || brandID == "" {
+		return ""
+	}
+
+	b, err := u.brands.GetByID(ctx, brandID)
+	if err != nil {
+		log.Printf("[authenticity] brand lookup failed brandId=%q err=%v", brandID, err)
+		return ""
+	}
+
+	res.Brand = &AuthenticityBrand{
+		ID:         b.ID,
+		Name:       b.Name,
+		Icon:       b.BrandIcon,
+		WebsiteURL: b.URL,
+	}
+
+	return strings.TrimSpace(b.WalletAddress)
+}
+
+func (u *AuthenticityUsecase) attachTokenBlueprint(
+	ctx context.Context,
+	res *AuthenticityResult,
+	tokenBlueprintID string,
+) {
+	tokenBlueprintID = strings.TrimSpace(tokenBlueprintID)
+	if u.tokenBlueprints == nil || tokenBlueprintID == "" {
+		return
+	}
+
+	tb, err := u.tokenBlueprints.GetByID(ctx, tokenBlueprintID)
+	if err != nil || tb == nil {
+		if err != nil {
+			log.Printf(
+				"[authenticity] tokenBlueprint lookup failed tokenBlueprintId=%q err=%v",
+				tokenBlueprintID,
+				err,
+			)
+		}
+		return
+	}
+
+	res.TokenBlueprint = &AuthenticityTokenBlueprint{
+		ID:      tb.ID,
+		Name:    tb.Name,
+		Symbol:  tb.Symbol,
+		IconURL: tb.IconURL,
+	}
+}
+
+// recordScan は過去のスキャンと照合して不審なパターンを判定し、スキャンを保存します。
+func (u *AuthenticityUsecase) recordScan(
+	ctx context.Context,
+	res AuthenticityResult,
+	in VerifyAuthenticityInput,
+	now time.Time,
+) *AuthenticityScan {
+	loc := in.Location
+	if loc.Validate() != nil {
+		loc = authdom.Location{}
+	}
+
+	scan, err := authdom.NewScan(
+		res.ProductID,
+		res.Verdict,
+		res.Reasons,
+		loc,
+		in.UserAgent,
+		now,
+	)
+	if err != nil {
+		log.Printf("[authenticity] build scan failed productId=%q err=%v", res.ProductID, err)
+		return nil
+	}
+
+	if u.scans == nil {
+		return &AuthenticityScan{ScannedAt: scan.ScannedAt}
+	}
+
+	recent, err := u.scans.ListRecentByProductID(
+		ctx,
+		res.ProductID,
+		now.Add(-u.policy.Window),
+		authdom.MaxRecentScans,
+	)
+	if err != nil {
+		log.Printf("[authenticity] list scans failed productId=%q err=%v", res.ProductID, err)
+	} else {
+		scan.Flag(authdom.DetectSuspicious(scan, recent, u.policy))
+	}
+
+	if scan.Suspicious {
+		log.Printf(
+			"[authenticity] suspicious scan productId=%q reasons=%v",
+			res.ProductID,
+			scan.SuspiciousReasons,
+		)
+	}
+
+	saved, err := u.scans.Create(ctx, scan)
+	if err != nil {
+		log.Printf("[authenticity] save scan failed productId=%q err=%v", res.ProductID, err)
+		saved = scan
+	}
+
+	return &AuthenticityScan{
+		ID:                saved.ID,
+		ScannedAt:         saved.ScannedAt,
+		Suspicious:        saved.Suspicious,
+		SuspiciousReasons: saved.SuspiciousReasons,
+	}
+}
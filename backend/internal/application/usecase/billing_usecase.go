@@ -0,0 +1,684 @@
+// backend/internal/application/usecase/billing_usecase.go
+package usecase
+
+/*
+責務:
+- company ごとのプラットフォーム手数料プラン（販売手数料率・ミント手数料・月額料金）の管理
+- ミント申請時のミント手数料の計上（ミント見積のネットワーク手数料を含む）
+- 月次の請求明細書（注文・ミント・NFT の移転の集計）の作成と CSV / PDF の出力
+
+前提:
+- プランの登録・変更はプラットフォーム運営の company（operatorCompanyID）のメンバーだけが行う。
+  プラン未登録の company には DefaultPlan（プラットフォーム共通の販売手数料率）を使う。
+- 販売手数料は支払い台帳（payout）の計上時点のプランの料率で差し引く。
+  明細書の注文の集計は、company が受取先の brand_sale / royalty / refund_adjustment の台帳から行う。
+- ミント手数料はミント申請ごとに 1 回だけ、申請時点のプランと見積で計上する。
+- 対象月は JST の暦月。締め済みの月だけ明細書を作成でき、再作成すると上書きする。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	billingdom "narratives/internal/domain/billing"
+	branddom "narratives/internal/domain/brand"
+	companydom "narratives/internal/domain/company"
+	mintdom "narratives/internal/domain/mint"
+	payoutdom "narratives/internal/domain/payout"
+	transferdom "narratives/internal/domain/transfer"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+type BillingPayoutEntryLister interface {
+	ListEntriesByCompanyID(
+		ctx context.Context,
+		companyID string,
+		filter payoutdom.EntryFilter,
+	) ([]payoutdom.Entry, error)
+}
+
+type BillingBrandReader interface {
+	GetByID(ctx context.Context, id string) (branddom.Brand, error)
+	ListByCompanyID(
+		ctx context.Context,
+		companyID string,
+		page branddom.Page,
+	) (branddom.PageResult[branddom.Brand], error)
+}
+
+type BillingCompanyGetter interface {
+	GetByID(ctx context.Context, id string) (companydom.Company, error)
+}
+
+// BillingTransferLister はブランドから送った NFT の移転（[from, to) に作成した試行）を返します。
+type BillingTransferLister interface {
+	ListByFromBrandID(
+		ctx context.Context,
+		brandID string,
+		from time.Time,
+		to time.Time,
+	) ([]transferdom.Transfer, error)
+}
+
+// BillingMintCostEstimate はミントのトランザクション手数料の見積合計（lamports）を返します。
+// application/query の MintFundingEstimateQuery を DI 層でラップして注入します。
+type BillingMintCostEstimate func(
+	ctx context.Context,
+	productionID string,
+	tokenBlueprintID string,
+) (int64, error)
+
+// BillingStatementRenderer は請求明細書を CSV / PDF に変換します。
+type BillingStatementRenderer interface {
+	RenderBillingStatement(s billingdom.Statement) ([]byte, error)
+}
+
+// BillingStatementFormat は請求明細書の出力形式です。
+type BillingStatementFormat string
+
+const (
+	BillingStatementFormatCSV BillingStatementFormat = "csv"
+	BillingStatementFormatPDF BillingStatementFormat = "pdf"
+)
+
+// BillingFile はダウンロード用のファイルです。
+type BillingFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+var (
+	ErrBillingNotConfigured = errors.New(
+		"billing: usecase is not configured",
+	)
+	ErrBillingForbidden = errors.New(
+		"billing: only the platform operator can manage commission plans",
+	)
+	ErrBillingInvalidFormat = errors.New(
+		"billing: invalid statement format",
+	)
+)
+
+// billingBrandPageSize は明細書の作成時にブランドを読み込む 1 ページの件数です。
+const billingBrandPageSize = 200
+
+type BillingUsecase struct {
+	repo        billingdom.RepositoryPort
+	entryLister BillingPayoutEntryLister
+	brandRepo   BillingBrandReader
+	companyRepo BillingCompanyGetter
+
+	transferLister   BillingTransferLister
+	mintCostEstimate BillingMintCostEstimate
+
+	csvRenderer BillingStatementRenderer
+	pdfRenderer BillingStatementRenderer
+
+	defaultSalesBasisPoints int
+	yenPerSOL               int
+	operatorCompanyID       string
+
+	location *time.Location
+	now      func() time.Time
+}
+
+func NewBillingUsecase(
+	repo billingdom.RepositoryPort,
+	entryLister BillingPayoutEntryLister,
+	brandRepo BillingBrandReader,
+	companyRepo BillingCompanyGetter,
+) *BillingUsecase {
+	return &BillingUsecase{
+		repo:        repo,
+		entryLister: entryLister,
+		brandRepo:   brandRepo,
+		companyRepo: companyRepo,
+		location:    time.FixedZone("JST", 9*60*60),
+		now:         time.Now,
+	}
+}
+
+// WithDefaultSalesFee はプラン未登録の company の販売手数料率（basis points）を設定します。
+// 範囲外の値は無視します。
+func (u *BillingUsecase) WithDefaultSalesFee(
+	basisPoints int,
+) *BillingUsecase {
+	if u == nil {
+		return u
+	}
+
+	if basisPoints >= 0 && basisPoints <= billingdom.MaxSalesBasisPoints {
+		u.defaultSalesBasisPoints = basisPoints
+	}
+
+	return u
+}
+
+// WithSOLRate はネットワーク手数料の円換算レート（1 SOL あたりの円）を設定します。
+// 0 の場合、ネットワーク手数料は請求しません。
+func (u *BillingUsecase) WithSOLRate(
+	yenPerSOL int,
+) *BillingUsecase {
+	if u == nil {
+		return u
+	}
+
+	if yenPerSOL >= 0 {
+		u.yenPerSOL = yenPerSOL
+	}
+
+	return u
+}
+
+// WithOperatorCompany はプランを管理できるプラットフォーム運営の companyId を設定します。
+func (u *BillingUsecase) WithOperatorCompany(
+	companyID string,
+) *BillingUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.operatorCompanyID = strings.TrimSpace(companyID)
+
+	return u
+}
+
+func (u *BillingUsecase) WithTransferLister(
+	lister BillingTransferLister,
+) *BillingUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.transferLister = lister
+
+	return u
+}
+
+func (u *BillingUsecase) WithMintCostEstimate(
+	estimate BillingMintCostEstimate,
+) *BillingUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.mintCostEstimate = estimate
+
+	return u
+}
+
+func (u *BillingUsecase) WithStatementRenderers(
+	csvRenderer BillingStatementRenderer,
+	pdfRenderer BillingStatementRenderer,
+) *BillingUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.csvRenderer = csvRenderer
+	u.pdfRenderer = pdfRenderer
+
+	return u
+}
+
+// ============================================================
+// Plans
+// ============================================================
+
+// PlanFor は company のプランを返します。未登録の場合は DefaultPlan です。
+func (u *BillingUsecase) PlanFor(
+	ctx context.Context,
+	companyID string,
+) (billingdom.Plan, error) {
+	if u == nil || u.repo == nil {
+		return billingdom.Plan{}, ErrBillingNotConfigured
+	}
+
+	p, err := u.repo.GetPlan(ctx, companyID)
+	if errors.Is(err, billingdom.ErrNotFound) {
+		return billingdom.DefaultPlan(companyID, u.defaultSalesBasisPoints), nil
+	}
+	if err != nil {
+		return billingdom.Plan{}, err
+	}
+
+	return p, nil
+}
+
+// SalesFeeBasisPoints は支払い台帳の計上に使う company の販売手数料率です。
+func (u *BillingUsecase) SalesFeeBasisPoints(
+	ctx context.Context,
+	companyID string,
+) (int, error) {
+	p, err := u.PlanFor(ctx, companyID)
+	if err != nil {
+		return 0, err
+	}
+
+	return p.SalesBasisPoints, nil
+}
+
+// CurrentPlan は current company のプランを返します。
+func (u *BillingUsecase) CurrentPlan(
+	ctx context.Context,
+) (billingdom.Plan, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return billingdom.Plan{}, err
+	}
+
+	return u.PlanFor(ctx, companyID)
+}
+
+// ListPlans は登録済みのプランを返します（運営のみ）。
+func (u *BillingUsecase) ListPlans(
+	ctx context.Context,
+) ([]billingdom.Plan, error) {
+	if err := u.operatorScope(ctx); err != nil {
+		return nil, err
+	}
+
+	return u.repo.ListPlans(ctx)
+}
+
+// GetPlan は company のプランを返します（運営のみ）。
+func (u *BillingUsecase) GetPlan(
+	ctx context.Context,
+	companyID string,
+) (billingdom.Plan, error) {
+	if err := u.operatorScope(ctx); err != nil {
+		return billingdom.Plan{}, err
+	}
+
+	return u.PlanFor(ctx, strings.TrimSpace(companyID))
+}
+
+type SetBillingPlanInput struct {
+	CompanyID string
+
+	SalesBasisPoints       int
+	MintFeePerItem         int
+	NetworkCostBasisPoints int
+	MonthlyFee             int
+}
+
+// SetPlan は company のプランを登録・変更します（運営のみ）。
+// 変更は以後の計上に適用し、計上済みの台帳・ミント手数料には影響しません。
+func (u *BillingUsecase) SetPlan(
+	ctx context.Context,
+	in SetBillingPlanInput,
+) (billingdom.Plan, error) {
+	if err := u.operatorScope(ctx); err != nil {
+		return billingdom.Plan{}, err
+	}
+
+	companyID := strings.TrimSpace(in.CompanyID)
+
+	if u.companyRepo != nil {
+		if _, err := u.companyRepo.GetByID(ctx, companyID); err != nil {
+			return billingdom.Plan{}, err
+		}
+	}
+
+	p, err := billingdom.NewPlan(billingdom.NewPlanInput{
+		CompanyID:              companyID,
+		SalesBasisPoints:       in.SalesBasisPoints,
+		MintFeePerItem:         in.MintFeePerItem,
+		NetworkCostBasisPoints: in.NetworkCostBasisPoints,
+		MonthlyFee:             in.MonthlyFee,
+		UpdatedBy:              MemberIDFromContext(ctx),
+	}, u.now())
+	if err != nil {
+		return billingdom.Plan{}, err
+	}
+
+	return u.repo.SetPlan(ctx, p)
+}
+
+// ============================================================
+// Mint charges
+// ============================================================
+
+// RecordMintCharge はミント申請の手数料を計上します。
+// 計上済みのミント申請はスキップするため、何度呼び出しても結果は同じです。
+// ネットワーク手数料の見積が取得できない場合は、ミント 1 点あたりの手数料だけを計上します。
+func (u *BillingUsecase) RecordMintCharge(
+	ctx context.Context,
+	m mintdom.Mint,
+) error {
+	if u == nil || u.repo == nil || u.brandRepo == nil {
+		return ErrBillingNotConfigured
+	}
+
+	brand, err := u.brandRepo.GetByID(ctx, m.BrandID)
+	if err != nil {
+		return fmt.Errorf("brand %s: %w", m.BrandID, err)
+	}
+
+	plan, err := u.PlanFor(ctx, brand.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		lamports    int64
+		estimateErr error
+	)
+	if u.mintCostEstimate != nil &&
+		plan.NetworkCostBasisPoints > 0 &&
+		u.yenPerSOL > 0 {
+		lamports, estimateErr = u.mintCostEstimate(ctx, m.ID, m.TokenBlueprintID)
+		if estimateErr != nil || lamports < 0 {
+			lamports = 0
+		}
+	}
+
+	c, err := billingdom.NewMintCharge(plan, billingdom.NewMintChargeInput{
+		MintID:              m.ID,
+		BrandID:             m.BrandID,
+		TokenBlueprintID:    m.TokenBlueprintID,
+		Quantity:            len(m.Products),
+		NetworkCostLamports: lamports,
+		YenPerSOL:           u.yenPerSOL,
+		ChargedAt:           u.now(),
+	})
+	if err != nil {
+		return fmt.Errorf("mint charge %s: %w", m.ID, err)
+	}
+
+	if _, err := u.repo.CreateMintCharge(ctx, c); err != nil &&
+		!errors.Is(err, billingdom.ErrConflict) {
+		return err
+	}
+
+	if estimateErr != nil {
+		return fmt.Errorf("mint charge %s: network cost estimate: %w", m.ID, estimateErr)
+	}
+
+	return nil
+}
+
+// ============================================================
+// Statements
+// ============================================================
+
+// GenerateStatement は current company の period（YYYY-MM, JST）の請求明細書を作成します。
+func (u *BillingUsecase) GenerateStatement(
+	ctx context.Context,
+	period string,
+) (billingdom.Statement, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	if u.entryLister == nil {
+		return billingdom.Statement{}, ErrBillingNotConfigured
+	}
+
+	start, end, err := billingdom.PeriodRange(period, u.location)
+	if err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	plan, err := u.PlanFor(ctx, companyID)
+	if err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	sales, err := u.salesSummary(ctx, companyID, start, end)
+	if err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	mints, err := u.repo.ListMintChargesByCompanyID(ctx, companyID, start, end)
+	if err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	transfers, err := u.transferSummary(ctx, companyID, start, end)
+	if err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	companyName := companyID
+	if u.companyRepo != nil {
+		if company, err := u.companyRepo.GetByID(ctx, companyID); err == nil &&
+			strings.TrimSpace(company.Name) != "" {
+			companyName = company.Name
+		}
+	}
+
+	s, err := billingdom.NewStatement(billingdom.NewStatementInput{
+		CompanyName: companyName,
+		Period:      billingdom.PeriodOf(start, u.location),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Plan:        plan,
+		Sales:       sales,
+		Mints:       mints,
+		Transfers:   transfers,
+		GeneratedBy: MemberIDFromContext(ctx),
+	}, u.now())
+	if err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	return u.repo.SaveStatement(ctx, s)
+}
+
+func (u *BillingUsecase) ListStatements(
+	ctx context.Context,
+) ([]billingdom.Statement, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.ListStatementsByCompanyID(ctx, companyID)
+}
+
+// GetStatement は current company の period の請求明細書を返します。
+func (u *BillingUsecase) GetStatement(
+	ctx context.Context,
+	period string,
+) (billingdom.Statement, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	if _, _, err := billingdom.PeriodRange(period, u.location); err != nil {
+		return billingdom.Statement{}, err
+	}
+
+	return u.repo.GetStatement(ctx, billingdom.StatementID(companyID, period))
+}
+
+// ExportStatement は作成済みの請求明細書を CSV / PDF で出力します。
+func (u *BillingUsecase) ExportStatement(
+	ctx context.Context,
+	period string,
+	format BillingStatementFormat,
+) (BillingFile, error) {
+	if u == nil {
+		return BillingFile{}, ErrBillingNotConfigured
+	}
+
+	var (
+		renderer    BillingStatementRenderer
+		contentType string
+	)
+
+	switch format {
+	case BillingStatementFormatCSV:
+		renderer, contentType = u.csvRenderer, "text/csv; charset=utf-8"
+	case BillingStatementFormatPDF:
+		renderer, contentType = u.pdfRenderer, "application/pdf"
+	default:
+		return BillingFile{}, ErrBillingInvalidFormat
+	}
+
+	if renderer == nil {
+		return BillingFile{}, ErrBillingNotConfigured
+	}
+
+	s, err := u.GetStatement(ctx, period)
+	if err != nil {
+		return BillingFile{}, err
+	}
+
+	content, err := renderer.RenderBillingStatement(s)
+	if err != nil {
+		return BillingFile{}, err
+	}
+
+	return BillingFile{
+		FileName:    fmt.Sprintf("billing-statement-%s.%s", s.Period, format),
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+// salesSummary は company が受取先の台帳を [start, end) の計上日時で集計します。
+func (u *BillingUsecase) salesSummary(
+	ctx context.Context,
+	companyID string,
+	start time.Time,
+	end time.Time,
+) (billingdom.SalesSummary, error) {
+	entries, err := u.entryLister.ListEntriesByCompanyID(ctx, companyID, payoutdom.EntryFilter{
+		RecipientType: payoutdom.RecipientTypeCompany,
+		RecipientID:   companyID,
+		AccruedBefore: &end,
+	})
+	if err != nil {
+		return billingdom.SalesSummary{}, err
+	}
+
+	var s billingdom.SalesSummary
+	orders := make(map[string]struct{})
+
+	for _, e := range entries {
+		if e.AccruedAt.Before(start) {
+			continue
+		}
+
+		switch e.Source {
+		case payoutdom.SourceBrandSale, payoutdom.SourceRoyalty:
+			s.GrossAmount += e.GrossAmount
+			s.CommissionAmount += e.FeeAmount
+			if e.OrderID != "" {
+				orders[e.OrderID] = struct{}{}
+			}
+		case payoutdom.SourceRefundAdjustment:
+			s.RefundAmount -= e.GrossAmount
+			s.CommissionAmount += e.FeeAmount
+		}
+	}
+
+	s.OrderCount = len(orders)
+
+	return s, nil
+}
+
+// transferSummary は company のブランドから送った NFT の移転を論理 transfer（operationId）ごとに集計します。
+// 成功した試行がある transfer を成功、最後の試行が失敗した transfer を失敗として数えます。
+func (u *BillingUsecase) transferSummary(
+	ctx context.Context,
+	companyID string,
+	start time.Time,
+	end time.Time,
+) (billingdom.TransferSummary, error) {
+	if u.transferLister == nil || u.brandRepo == nil {
+		return billingdom.TransferSummary{}, nil
+	}
+
+	var brandIDs []string
+	for page := 1; ; page++ {
+		res, err := u.brandRepo.ListByCompanyID(ctx, companyID, branddom.Page{
+			Number:  page,
+			PerPage: billingBrandPageSize,
+		})
+		if err != nil {
+			return billingdom.TransferSummary{}, err
+		}
+
+		for _, b := range res.Items {
+			brandIDs = append(brandIDs, b.ID)
+		}
+
+		if page >= res.TotalPages || len(res.Items) == 0 {
+			break
+		}
+	}
+
+	latest := make(map[string]transferdom.Transfer)
+	succeeded := make(map[string]bool)
+
+	for _, brandID := range brandIDs {
+		transfers, err := u.transferLister.ListByFromBrandID(ctx, brandID, start, end)
+		if err != nil {
+			return billingdom.TransferSummary{}, err
+		}
+
+		for _, t := range transfers {
+			key := t.OperationID
+			if key == "" {
+				key = fmt.Sprintf("%s_%d", t.ProductID, t.Attempt)
+			}
+
+			if t.Status == transferdom.StatusSucceeded {
+				succeeded[key] = true
+			}
+			if prev, ok := latest[key]; !ok || t.Attempt > prev.Attempt {
+				latest[key] = t
+			}
+		}
+	}
+
+	var s billingdom.TransferSummary
+	for key, t := range latest {
+		switch {
+		case succeeded[key]:
+			s.SucceededCount++
+		case t.Status == transferdom.StatusFailed:
+			s.FailedCount++
+		}
+	}
+
+	return s, nil
+}
+
+func (u *BillingUsecase) companyScope(ctx context.Context) (string, error) {
+	if u == nil || u.repo == nil {
+		return "", ErrBillingNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return "", billingdom.ErrInvalidCompanyID
+	}
+
+	return companyID, nil
+}
+
+func (u *BillingUsecase) operatorScope(ctx context.Context) error {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	if u.operatorCompanyID == "" || companyID != u.operatorCompanyID {
+		return ErrBillingForbidden
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	auditdom "narratives/internal/domain/audit"
 	branddom "narratives/internal/domain/brand"
 	memberdom "narratives/internal/domain/member"
 )
@@ -13,6 +14,7 @@ type BrandUsecase struct {
 	brandRepo  branddom.Repository
 	memberRepo memberdom.Repository
 	walletSvc  branddom.SolanaBrandWalletService
+	audit      AuditRecorder
 	now        func() time.Time
 }
 
@@ -26,6 +28,15 @@ func WithBrandWalletService(
 	}
 }
 
+// WithBrandAuditRecorder はブランドの作成・更新・削除を監査ログに記録します。
+func WithBrandAuditRecorder(
+	recorder AuditRecorder,
+) BrandUsecaseOption {
+	return func(u *BrandUsecase) {
+		u.audit = recorder
+	}
+}
+
 func WithNow(now func() time.Time) BrandUsecaseOption {
 	return func(u *BrandUsecase) {
 		if now != nil {
@@ -172,6 +183,14 @@ func (u *BrandUsecase) Create(
 		}
 	}
 
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		CompanyID:  created.CompanyID,
+		EntityType: auditdom.EntityBrand,
+		EntityID:   created.ID,
+		Action:     auditdom.ActionCreate,
+		After:      created,
+	})
+
 	return created, nil
 }
 
@@ -198,7 +217,23 @@ func (u *BrandUsecase) Update(
 		patch.UpdatedAt = &t
 	}
 
-	return u.brandRepo.Update(ctx, id, patch)
+	before := u.auditSnapshot(ctx, id)
+
+	updated, err := u.brandRepo.Update(ctx, id, patch)
+	if err != nil {
+		return updated, err
+	}
+
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		CompanyID:  updated.CompanyID,
+		EntityType: auditdom.EntityBrand,
+		EntityID:   id,
+		Action:     auditdom.ActionUpdate,
+		Before:     before,
+		After:      updated,
+	})
+
+	return updated, nil
 }
 
 func (u *BrandUsecase) Delete(
@@ -209,5 +244,36 @@ func (u *BrandUsecase) Delete(
 		return branddom.ErrInvalidID
 	}
 
-	return u.brandRepo.Delete(ctx, id)
+	before := u.auditSnapshot(ctx, id)
+
+	if err := u.brandRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		EntityType: auditdom.EntityBrand,
+		EntityID:   id,
+		Action:     auditdom.ActionDelete,
+		Before:     before,
+	})
+
+	return nil
+}
+
+// auditSnapshot は監査ログ用に変更前のブランドを返します。
+// 監査ログが無効な場合や取得できない場合は nil です。
+func (u *BrandUsecase) auditSnapshot(
+	ctx context.Context,
+	id string,
+) any {
+	if u.audit == nil {
+		return nil
+	}
+
+	b, err := u.brandRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+
+	return b
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	cartdom "narratives/internal/domain/cart"
+	coupondom "narratives/internal/domain/coupon"
+	orderdom "narratives/internal/domain/order"
 )
 
 var (
@@ -17,6 +19,17 @@ var (
 // CartUsecase coordinates cart operations.
 type CartUsecase struct {
 	repo cartdom.Repository
+
+	couponPreviewer CartCouponPreviewer
+	listAccess      ListAccessGuardByID
+}
+
+// CartCouponPreviewer prices a coupon against the cart items.
+type CartCouponPreviewer interface {
+	PreviewDiscount(
+		ctx context.Context,
+		in PreviewOrderDiscountInput,
+	) (orderdom.DiscountSnapshot, error)
 }
 
 func NewCartUsecase(repo cartdom.Repository) *CartUsecase {
@@ -25,6 +38,43 @@ func NewCartUsecase(repo cartdom.Repository) *CartUsecase {
 	}
 }
 
+// WithCouponPreviewer enables coupon codes in the cart.
+func (uc *CartUsecase) WithCouponPreviewer(
+	previewer CartCouponPreviewer,
+) *CartUsecase {
+	if uc == nil {
+		return uc
+	}
+
+	uc.couponPreviewer = previewer
+
+	return uc
+}
+
+// WithListAccessGuard rejects holder-only list items for avatars that do not
+// hold the required tokens.
+func (uc *CartUsecase) WithListAccessGuard(
+	guard ListAccessGuardByID,
+) *CartUsecase {
+	if uc == nil {
+		return uc
+	}
+
+	uc.listAccess = guard
+
+	return uc
+}
+
+func (uc *CartUsecase) ensureListAccess(
+	ctx context.Context,
+	avatarID, listID string,
+) error {
+	if uc.listAccess == nil {
+		return nil
+	}
+	return uc.listAccess.EnsureListAccessByID(ctx, avatarID, listID)
+}
+
 // Get returns the cart for avatarID.
 // If cart does not exist, returns (nil, ErrCartNotFound).
 func (uc *CartUsecase) Get(ctx context.Context, avatarID string) (*cartdom.Cart, error) {
@@ -84,6 +134,10 @@ func (uc *CartUsecase) AddItem(
 		return nil, ErrCartInvalidArgument
 	}
 
+	if err := uc.ensureListAccess(ctx, aid, lid); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 
 	c, err := uc.repo.GetByAvatarID(ctx, aid)
@@ -156,6 +210,13 @@ func (uc *CartUsecase) SetItemQty(
 		return nil, ErrCartInvalidArgument
 	}
 
+	// 数量を減らす・削除する操作は holder でなくなった後も許可する。
+	if qty > 0 {
+		if err := uc.ensureListAccess(ctx, aid, lid); err != nil {
+			return nil, err
+		}
+	}
+
 	c, err := uc.repo.GetByAvatarID(ctx, aid)
 	if err != nil {
 		return nil, err
@@ -260,3 +321,95 @@ func (uc *CartUsecase) EmptyItems(ctx context.Context, avatarID string) error {
 
 	return uc.repo.Upsert(ctx, c)
 }
+
+// ApplyCoupon validates code against the cart's list items and stores it.
+// The returned discount excludes free shipping, which needs a shipping quote.
+func (uc *CartUsecase) ApplyCoupon(
+	ctx context.Context,
+	avatarID string,
+	code string,
+) (*cartdom.Cart, orderdom.DiscountSnapshot, error) {
+	code = coupondom.NormalizeCode(code)
+	if avatarID == "" || code == "" {
+		return nil, orderdom.DiscountSnapshot{}, ErrCartInvalidArgument
+	}
+
+	if uc.couponPreviewer == nil {
+		return nil, orderdom.DiscountSnapshot{}, ErrOrderCouponNotConfigured
+	}
+
+	c, err := uc.repo.GetByAvatarID(ctx, avatarID)
+	if err != nil {
+		return nil, orderdom.DiscountSnapshot{}, err
+	}
+	if c == nil {
+		return nil, orderdom.DiscountSnapshot{}, coupondom.ErrNotApplicable
+	}
+
+	items := make([]CreateOrderItemInput, 0, len(c.Items))
+	for _, item := range c.Items {
+		if item.Type == cartdom.CartItemTypeResale ||
+			item.ListID == "" {
+			continue
+		}
+
+		items = append(items, CreateOrderItemInput{
+			Type:    orderdom.OrderItemTypeList,
+			ListID:  item.ListID,
+			ModelID: item.ModelID,
+			Qty:     item.Qty,
+		})
+	}
+
+	if len(items) == 0 {
+		return nil, orderdom.DiscountSnapshot{}, coupondom.ErrNotApplicable
+	}
+
+	discount, err := uc.couponPreviewer.PreviewDiscount(
+		ctx,
+		PreviewOrderDiscountInput{
+			AvatarID:   avatarID,
+			CouponCode: code,
+			Items:      items,
+		},
+	)
+	if err != nil {
+		return nil, orderdom.DiscountSnapshot{}, err
+	}
+
+	if err := c.SetCouponCode(code, time.Now()); err != nil {
+		return nil, orderdom.DiscountSnapshot{}, err
+	}
+	if err := uc.repo.Upsert(ctx, c); err != nil {
+		return nil, orderdom.DiscountSnapshot{}, err
+	}
+
+	return c, discount, nil
+}
+
+// RemoveCoupon clears the cart's coupon code.
+func (uc *CartUsecase) RemoveCoupon(
+	ctx context.Context,
+	avatarID string,
+) (*cartdom.Cart, error) {
+	if avatarID == "" {
+		return nil, ErrCartInvalidArgument
+	}
+
+	c, err := uc.repo.GetByAvatarID(ctx, avatarID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil || c.CouponCode == "" {
+		return c, nil
+	}
+
+	if err := c.SetCouponCode("", time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Upsert(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,500 @@
+// backend/internal/application/usecase/checkout_flow_test.go
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/fake"
+	"narratives/internal/adapters/out/memory"
+	usecase "narratives/internal/application/usecase"
+	avdom "narratives/internal/domain/avatar"
+	branddom "narratives/internal/domain/brand"
+	invdom "narratives/internal/domain/inventory"
+	ldom "narratives/internal/domain/list"
+	modeldom "narratives/internal/domain/model"
+	orderdom "narratives/internal/domain/order"
+	outboxdom "narratives/internal/domain/outbox"
+	paymentdom "narratives/internal/domain/payment"
+	pm "narratives/internal/domain/paymentMethod"
+	pbdom "narratives/internal/domain/productBlueprint"
+	shipaddrdom "narratives/internal/domain/shippingAddress"
+	tokendom "narratives/internal/domain/token"
+	transportationdom "narratives/internal/domain/transportation"
+	walletdom "narratives/internal/domain/wallet"
+)
+
+const (
+	checkoutUserID    = "user_checkout"
+	checkoutCompanyID = "company_checkout"
+	checkoutProductID = "product_checkout"
+
+	checkoutOriginAddressID      = "6f1c1e0a-6a53-4f0e-9a57-0d2b0c7a1001"
+	checkoutDestinationAddressID = "6f1c1e0a-6a53-4f0e-9a57-0d2b0c7a1002"
+
+	// wallet address と assetId は base58 で検証されるため、形式の正しい値を使う。
+	checkoutBrandWallet  = "So11111111111111111111111111111111111111112"
+	checkoutAvatarWallet = "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T"
+	checkoutAssetID      = "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"
+)
+
+// checkoutFlow は checkout → payment → dispatch → transfer を
+// in-memory repository と fake adapter だけで組み立てる。
+type checkoutFlow struct {
+	orders           *memory.OrderRepositoryMem
+	lists            *memory.ListRepositoryMem
+	inventories      *memory.InventoryRepositoryMem
+	models           *memory.ModelRepositoryMem
+	productBlueprint *memory.ProductBlueprintRepositoryMem
+	addresses        *memory.ShippingAddressRepositoryMem
+	paymentMethods   *memory.PaymentMethodRepositoryMem
+	payments         *memory.PaymentRepositoryMem
+	brands           *memory.BrandRepositoryMem
+	avatars          *memory.AvatarRepositoryMem
+	wallets          *memory.WalletRepositoryMem
+	tokens           *memory.TokenRepositoryMem
+	transfers        *memory.TransferRepositoryMem
+	resales          *memory.ResaleRepositoryMem
+	offers           *memory.OfferRepositoryMem
+
+	bubblegum *fake.BubblegumFake
+	scanner   *fake.ScanVerifierFake
+
+	offerUC    *usecase.OfferUsecase
+	orderUC    *usecase.OrderUsecase
+	paymentUC  *usecase.PaymentUsecase
+	transferUC *usecase.TransferUsecase
+}
+
+func newCheckoutFlow() *checkoutFlow {
+	f := &checkoutFlow{
+		orders:           memory.NewOrderRepositoryMem(),
+		lists:            memory.NewListRepositoryMem(),
+		inventories:      memory.NewInventoryRepositoryMem(),
+		models:           memory.NewModelRepositoryMem(),
+		productBlueprint: memory.NewProductBlueprintRepositoryMem(),
+		addresses:        memory.NewShippingAddressRepositoryMem(),
+		paymentMethods:   memory.NewPaymentMethodRepositoryMem(),
+		payments:         memory.NewPaymentRepositoryMem(),
+		brands:           memory.NewBrandRepositoryMem(),
+		avatars:          memory.NewAvatarRepositoryMem(),
+		wallets:          memory.NewWalletRepositoryMem(),
+		tokens:           memory.NewTokenRepositoryMem(),
+		transfers:        memory.NewTransferRepositoryMem(),
+		resales:          memory.NewResaleRepositoryMem(),
+		offers:           memory.NewOfferRepositoryMem(),
+
+		bubblegum: fake.NewBubblegumFake(),
+		scanner:   fake.NewScanVerifierFake(),
+	}
+
+	shippingQuoteUC := usecase.NewShippingQuoteUsecase(
+		f.lists,
+		f.inventories,
+		f.models,
+		f.addresses,
+		transportationdom.NewService(memory.NewTransportationRepositoryMem()),
+	)
+
+	f.offerUC = usecase.NewOfferUsecase(f.offers, f.resales)
+
+	f.orderUC = usecase.NewOrderUsecase(
+		f.orders,
+		f.lists,
+		f.inventories,
+		f.productBlueprint,
+		f.resales,
+		f.paymentMethods,
+		f.addresses,
+		shippingQuoteUC,
+	).WithOfferCheckout(f.offerUC)
+
+	f.paymentUC = usecase.NewPaymentUsecase(usecase.NewPaymentUsecaseInput{
+		PaymentRepo: f.payments,
+		OrderRepo:   f.orders,
+		Offers:      f.offerUC,
+	})
+
+	executionUC := usecase.NewTokenTransferExecutionUsecase(
+		f.tokens,
+		f.wallets,
+		nil,
+		f.transfers,
+		f.bubblegum,
+		nil,
+	)
+
+	f.transferUC = usecase.NewTransferUsecase(
+		f.scanner,
+		f.orders,
+		f.tokens,
+		f.brands,
+		f.wallets,
+		f.brands,
+		f.avatars,
+		executionUC,
+		nil,
+	)
+
+	return f
+}
+
+// checkoutSeed は出品から購入者までの初期データ。
+type checkoutSeed struct {
+	avatarID         string
+	brandID          string
+	listID           string
+	modelID          string
+	inventoryID      string
+	tokenBlueprintID string
+	paymentMethodID  string
+}
+
+func (f *checkoutFlow) seed(t *testing.T, ctx context.Context) checkoutSeed {
+	t.Helper()
+
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	const tokenBlueprintID = "tokenBlueprint_checkout"
+
+	brand, err := f.brands.Create(ctx, branddom.Brand{
+		CompanyID:     checkoutCompanyID,
+		Name:          "Checkout Brand",
+		IsActive:      true,
+		WalletAddress: checkoutBrandWallet,
+	})
+	if err != nil {
+		t.Fatalf("create brand: %v", err)
+	}
+
+	avatar, err := f.avatars.Create(ctx, avdom.Avatar{
+		UserID:     checkoutUserID,
+		AvatarName: "buyer",
+	})
+	if err != nil {
+		t.Fatalf("create avatar: %v", err)
+	}
+
+	wallet, err := walletdom.New(checkoutAvatarWallet, nil, now)
+	if err != nil {
+		t.Fatalf("new wallet: %v", err)
+	}
+	if err := f.wallets.Save(ctx, avatar.ID, wallet); err != nil {
+		t.Fatalf("save wallet: %v", err)
+	}
+
+	pb, err := f.productBlueprint.Create(ctx, pbdom.CreateInput{
+		ID:                           "productBlueprint_checkout",
+		ProductName:                  "Checkout Tee",
+		BrandID:                      brand.ID,
+		CompanyID:                    checkoutCompanyID,
+		ProductBlueprintCategoryPath: []string{"apparel", "tops"},
+		ProductIdTag:                 pbdom.ProductIDTag{Type: pbdom.TagQR},
+		AssigneeID:                   "member_checkout",
+	})
+	if err != nil {
+		t.Fatalf("create product blueprint: %v", err)
+	}
+
+	model, err := f.models.Create(ctx, modeldom.NewModelVariationFromApparel(
+		modeldom.NewApparelModelVariation{
+			ProductBlueprintID: pb.ID,
+			ModelNumber:        "TEE-M",
+			Size:               "M",
+			Color:              modeldom.Color{Name: "black", RGB: 0},
+			ShippingPackage: modeldom.ShippingPackage{
+				WeightGrams: 300,
+				WidthMM:     250,
+				LengthMM:    300,
+				HeightMM:    30,
+			},
+		},
+	))
+	if err != nil {
+		t.Fatalf("create model: %v", err)
+	}
+
+	inventory, err := f.inventories.UpsertByModelAndToken(
+		ctx,
+		tokenBlueprintID,
+		pb.ID,
+		model.GetID(),
+		[]string{checkoutProductID},
+	)
+	if err != nil {
+		t.Fatalf("upsert inventory: %v", err)
+	}
+	if err := f.inventories.SetTransportation(
+		ctx,
+		inventory.ID,
+		invdom.TransportationOption(transportationdom.CarrierYamato),
+		"",
+		now,
+	); err != nil {
+		t.Fatalf("set transportation: %v", err)
+	}
+
+	if _, err := f.addresses.Create(ctx, shipaddrdom.ShippingAddress{
+		ID:        checkoutOriginAddressID,
+		UserID:    "member_checkout",
+		CompanyID: checkoutCompanyID,
+		Name:      "warehouse",
+		ZipCode:   "100-0001",
+		State:     "東京都",
+		City:      "千代田区",
+		Street:    "千代田1-1",
+		Country:   shipaddrdom.DefaultCountry,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("create origin address: %v", err)
+	}
+	if err := f.inventories.SetShippingAddressID(
+		ctx,
+		inventory.ID,
+		checkoutOriginAddressID,
+		now,
+	); err != nil {
+		t.Fatalf("set inventory shipping address: %v", err)
+	}
+
+	if _, err := f.addresses.Create(ctx, shipaddrdom.ShippingAddress{
+		ID:        checkoutDestinationAddressID,
+		UserID:    checkoutUserID,
+		ZipCode:   "530-0001",
+		State:     "大阪府",
+		City:      "大阪市北区",
+		Street:    "梅田1-1",
+		Country:   shipaddrdom.DefaultCountry,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("create destination address: %v", err)
+	}
+
+	method, err := f.paymentMethods.Create(ctx, pm.CreatePaymentMethodInput{
+		UserID:                checkoutUserID,
+		StripeCustomerID:      "cus_checkout",
+		StripePaymentMethodID: "pm_checkout",
+		Brand:                 "visa",
+		Last4:                 "4242",
+		ExpMonth:              12,
+		ExpYear:               2030,
+		CardholderName:        "TARO YAMADA",
+		IsDefault:             true,
+	})
+	if err != nil {
+		t.Fatalf("create payment method: %v", err)
+	}
+
+	list, err := f.lists.Create(ctx, ldom.List{
+		Status:      ldom.StatusListing,
+		AssigneeID:  "member_checkout",
+		Title:       "Checkout Tee",
+		InventoryID: inventory.ID,
+		Description: "black tee",
+		Prices: []ldom.ListPriceRow{
+			{ModelID: model.GetID(), Price: 5000},
+		},
+		CreatedBy: "member_checkout",
+	})
+	if err != nil {
+		t.Fatalf("create list: %v", err)
+	}
+
+	if err := f.tokens.Create(ctx, tokendom.GetTokenByProductIDResult{
+		ProductID:        checkoutProductID,
+		BrandID:          brand.ID,
+		TokenBlueprintID: tokenBlueprintID,
+		AssetID:          checkoutAssetID,
+		ToAddress:        checkoutBrandWallet,
+	}); err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+	f.bubblegum.SeedAsset(checkoutAssetID, checkoutBrandWallet)
+
+	return checkoutSeed{
+		avatarID:         avatar.ID,
+		brandID:          brand.ID,
+		listID:           list.ID,
+		modelID:          model.GetID(),
+		inventoryID:      inventory.ID,
+		tokenBlueprintID: tokenBlueprintID,
+		paymentMethodID:  method.ID,
+	}
+}
+
+// pay は Stripe webhook（succeeded）と PaymentSucceeded の outbox 配信を再現する。
+func (f *checkoutFlow) pay(t *testing.T, ctx context.Context, order orderdom.Order) {
+	t.Helper()
+
+	now := time.Now().UTC()
+
+	if _, err := f.paymentUC.Create(ctx, paymentdom.Payment{
+		PaymentID:             order.ID,
+		PaymentMethodID:       order.PaymentMethodSnapshot.PaymentMethodID,
+		StripeCustomerID:      order.PaymentMethodSnapshot.CustomerID,
+		StripePaymentMethodID: order.PaymentMethodSnapshot.StripePaymentMethodID,
+		StripePaymentIntentID: "pi_checkout",
+		Amount:                5000,
+		Status:                paymentdom.StatusProcessing,
+	}); err != nil {
+		t.Fatalf("create payment: %v", err)
+	}
+
+	payment, err := f.paymentUC.ApplyStripeEvent(ctx, usecase.ApplyStripePaymentEventInput{
+		EventID:               "evt_checkout",
+		PaymentID:             order.ID,
+		StripePaymentIntentID: "pi_checkout",
+		Status:                paymentdom.StatusSucceeded,
+		OccurredAt:            now,
+	})
+	if err != nil {
+		t.Fatalf("apply stripe event: %v", err)
+	}
+	if payment.Status != paymentdom.StatusSucceeded {
+		t.Fatalf("payment status = %q, want %q", payment.Status, paymentdom.StatusSucceeded)
+	}
+
+	event, err := outboxdom.NewEvent(
+		outboxdom.EventPaymentSucceeded,
+		outboxdom.AggregatePayment,
+		payment.PaymentID,
+		outboxdom.PaymentSucceededPayload{PaymentID: payment.PaymentID},
+		now,
+	)
+	if err != nil {
+		t.Fatalf("new outbox event: %v", err)
+	}
+	if err := f.paymentUC.HandleOutboxEvent(ctx, event); err != nil {
+		t.Fatalf("handle payment succeeded: %v", err)
+	}
+}
+
+func TestCheckoutFlow_ListItemIsTransferredToBuyerAfterDispatch(t *testing.T) {
+	ctx := context.Background()
+	f := newCheckoutFlow()
+	s := f.seed(t, ctx)
+
+	// checkout
+	order, err := f.orderUC.Create(ctx, usecase.CreateOrderInput{
+		UserID:            checkoutUserID,
+		AvatarID:          s.avatarID,
+		CartID:            "cart_checkout",
+		ShippingAddressID: checkoutDestinationAddressID,
+		PaymentMethodID:   s.paymentMethodID,
+		Items: []usecase.CreateOrderItemInput{
+			{
+				Type:    orderdom.OrderItemTypeList,
+				ListID:  s.listID,
+				ModelID: s.modelID,
+				Qty:     1,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if order.Paid {
+		t.Fatal("order is paid before payment")
+	}
+
+	// 発送は出品企業の console から行う。
+	companyCtx := usecase.WithCompanyID(ctx, checkoutCompanyID)
+	allowed := map[string]struct{}{s.inventoryID: {}}
+
+	// 未払いの注文は発送できない。
+	if _, err := f.orderUC.DispatchItems(companyCtx, usecase.DispatchOrderItemsInput{
+		ID:                  order.ID,
+		AllowedInventoryIDs: allowed,
+		TrackingNumber:      "123456789012",
+	}); !errors.Is(err, orderdom.ErrConflict) {
+		t.Fatalf("dispatch unpaid order: err = %v, want %v", err, orderdom.ErrConflict)
+	}
+
+	// payment
+	f.pay(t, ctx, order)
+
+	paid, err := f.orders.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("get paid order: %v", err)
+	}
+	if !paid.Paid {
+		t.Fatal("order is not paid after payment succeeded")
+	}
+
+	// dispatch
+	dispatched, err := f.orderUC.DispatchItems(companyCtx, usecase.DispatchOrderItemsInput{
+		ID:                  order.ID,
+		AllowedInventoryIDs: allowed,
+		TrackingNumber:      "123456789012",
+	})
+	if err != nil {
+		t.Fatalf("dispatch items: %v", err)
+	}
+	if !dispatched.Changed {
+		t.Fatal("dispatch did not change the order")
+	}
+	if status := dispatched.Order.ItemStatus(0); status != orderdom.StatusDispatched {
+		t.Fatalf("item status = %q, want %q", status, orderdom.StatusDispatched)
+	}
+
+	// transfer
+	f.scanner.SetMatch(checkoutProductID, usecase.ModelTokenPair{
+		ModelID:          s.modelID,
+		TokenBlueprintID: s.tokenBlueprintID,
+	})
+
+	result, err := f.transferUC.TransferToAvatarByVerifiedScan(ctx, usecase.TransferByVerifiedScanInput{
+		AvatarID:    s.avatarID,
+		ProductID:   checkoutProductID,
+		OperationID: "op_checkout",
+	})
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	if result.MatchedOrderID != order.ID {
+		t.Fatalf("matched order = %q, want %q", result.MatchedOrderID, order.ID)
+	}
+	if result.FromWallet != checkoutBrandWallet || result.ToWallet != checkoutAvatarWallet {
+		t.Fatalf("transfer wallets = %q -> %q", result.FromWallet, result.ToWallet)
+	}
+
+	if owner, _ := f.bubblegum.OwnerOf(checkoutAssetID); owner != checkoutAvatarWallet {
+		t.Fatalf("on-chain owner = %q, want %q", owner, checkoutAvatarWallet)
+	}
+
+	token, err := f.tokens.GetTokenByProductID(ctx, checkoutProductID)
+	if err != nil {
+		t.Fatalf("get token: %v", err)
+	}
+	if token.ToAddress != checkoutAvatarWallet {
+		t.Fatalf("token owner = %q, want %q", token.ToAddress, checkoutAvatarWallet)
+	}
+
+	wallet, err := f.wallets.GetByAvatarID(ctx, s.avatarID)
+	if err != nil {
+		t.Fatalf("get wallet: %v", err)
+	}
+	if len(wallet.AssetIDs) != 1 || wallet.AssetIDs[0] != checkoutAssetID {
+		t.Fatalf("wallet assets = %v, want [%s]", wallet.AssetIDs, checkoutAssetID)
+	}
+
+	transferred, err := f.orders.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("get transferred order: %v", err)
+	}
+	if !transferred.Items[0].Transferred {
+		t.Fatal("order item is not marked transferred")
+	}
+
+	// 同じ商品を再度 scan しても二重に transfer しない。
+	if _, err := f.transferUC.TransferToAvatarByVerifiedScan(ctx, usecase.TransferByVerifiedScanInput{
+		AvatarID:    s.avatarID,
+		ProductID:   checkoutProductID,
+		OperationID: "op_checkout_again",
+	}); !errors.Is(err, usecase.ErrTransferNoEligibleOrder) {
+		t.Fatalf("second transfer: err = %v, want %v", err, usecase.ErrTransferNoEligibleOrder)
+	}
+}
@@ -0,0 +1,493 @@
+// backend/internal/application/usecase/coupon_usecase.go
+package usecase
+
+/*
+責務:
+- ブランド（console）によるクーポンの発行・編集・一覧・利用状況レポート
+- 購入時のクーポン値引き計算（cart / 送料見積もり / 注文作成）
+- 注文に紐づく利用回数の確保・確定・解放
+
+前提:
+- クーポンは company 単位で発行し、対象は発行 company の list item に限る。
+  resale item は出品者が異なるため対象外。
+- 値引きは注文作成時に Order.Discount に保存し、決済額・返金額はそれを使う。
+- 利用回数は注文作成時に確保（reserved）し、決済完了で確定（redeemed）、
+  決済失敗・注文取消・引当期限切れで解放（released）する。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	applicationport "narratives/internal/application/port"
+	coupondom "narratives/internal/domain/coupon"
+	orderdom "narratives/internal/domain/order"
+)
+
+var (
+	ErrCouponNotConfigured = errors.New(
+		"coupon: usecase is not configured",
+	)
+)
+
+type CouponUsecase struct {
+	repo                 coupondom.RepositoryPort
+	redemptionRepo       coupondom.RedemptionRepository
+	productBlueprintRepo applicationport.ProductBlueprintGetter
+
+	now func() time.Time
+}
+
+func NewCouponUsecase(
+	repo coupondom.RepositoryPort,
+	redemptionRepo coupondom.RedemptionRepository,
+	productBlueprintRepo applicationport.ProductBlueprintGetter,
+) *CouponUsecase {
+	return &CouponUsecase{
+		repo:                 repo,
+		redemptionRepo:       redemptionRepo,
+		productBlueprintRepo: productBlueprintRepo,
+		now:                  time.Now,
+	}
+}
+
+// ============================================================
+// Brand side (console)
+// ============================================================
+
+func (u *CouponUsecase) ListForCompany(
+	ctx context.Context,
+) ([]coupondom.Coupon, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrCouponNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nil, coupondom.ErrInvalidCompanyID
+	}
+
+	return u.repo.ListByCompanyID(ctx, companyID)
+}
+
+func (u *CouponUsecase) GetForCompany(
+	ctx context.Context,
+	id string,
+) (coupondom.Coupon, error) {
+	if u == nil || u.repo == nil {
+		return coupondom.Coupon{}, ErrCouponNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return coupondom.Coupon{}, coupondom.ErrInvalidCompanyID
+	}
+
+	c, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	// 他社のクーポンは存在しないものとして扱う。
+	if c.CompanyID != companyID {
+		return coupondom.Coupon{}, coupondom.ErrNotFound
+	}
+
+	return c, nil
+}
+
+type CreateCouponInput struct {
+	Code        string
+	Name        string
+	Description string
+
+	DiscountType      coupondom.DiscountType
+	Amount            int
+	Percent           int
+	MaxDiscountAmount int
+	MinSubtotal       int
+
+	BrandIDs            []string
+	ProductBlueprintIDs []string
+
+	UsageLimit     int
+	PerAvatarLimit int
+
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+
+	Active bool
+}
+
+func (u *CouponUsecase) CreateForCompany(
+	ctx context.Context,
+	in CreateCouponInput,
+) (coupondom.Coupon, error) {
+	if u == nil || u.repo == nil {
+		return coupondom.Coupon{}, ErrCouponNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return coupondom.Coupon{}, coupondom.ErrInvalidCompanyID
+	}
+
+	now := u.now().UTC()
+
+	c, err := coupondom.New(coupondom.NewInput{
+		ID:        u.newCouponID(now),
+		CompanyID: companyID,
+
+		Code:        in.Code,
+		Name:        in.Name,
+		Description: in.Description,
+
+		DiscountType:      in.DiscountType,
+		Amount:            in.Amount,
+		Percent:           in.Percent,
+		MaxDiscountAmount: in.MaxDiscountAmount,
+		MinSubtotal:       in.MinSubtotal,
+
+		BrandIDs:            in.BrandIDs,
+		ProductBlueprintIDs: in.ProductBlueprintIDs,
+
+		UsageLimit:     in.UsageLimit,
+		PerAvatarLimit: in.PerAvatarLimit,
+
+		ValidFrom:  in.ValidFrom,
+		ValidUntil: in.ValidUntil,
+
+		Active: in.Active,
+
+		CreatedAt: now,
+		CreatedBy: MemberIDFromContext(ctx),
+	})
+	if err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	return u.repo.Create(ctx, c)
+}
+
+func (u *CouponUsecase) UpdateForCompany(
+	ctx context.Context,
+	id string,
+	in coupondom.UpdateInput,
+) (coupondom.Coupon, error) {
+	c, err := u.GetForCompany(ctx, id)
+	if err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	if err := c.Update(
+		in,
+		u.now().UTC(),
+		MemberIDFromContext(ctx),
+	); err != nil {
+		return coupondom.Coupon{}, err
+	}
+
+	return u.repo.Update(ctx, c)
+}
+
+// CouponRedemptionReport は console の利用状況レポートです。
+//
+// DiscountAmount は redeemed（決済完了）の値引き額の合計です。
+type CouponRedemptionReport struct {
+	Coupon      coupondom.Coupon       `json:"coupon"`
+	Redemptions []coupondom.Redemption `json:"redemptions"`
+
+	ReservedCount int `json:"reservedCount"`
+	RedeemedCount int `json:"redeemedCount"`
+	ReleasedCount int `json:"releasedCount"`
+
+	DiscountAmount int `json:"discountAmount"`
+}
+
+func (u *CouponUsecase) RedemptionReport(
+	ctx context.Context,
+	id string,
+) (CouponRedemptionReport, error) {
+	if u == nil || u.redemptionRepo == nil {
+		return CouponRedemptionReport{}, ErrCouponNotConfigured
+	}
+
+	c, err := u.GetForCompany(ctx, id)
+	if err != nil {
+		return CouponRedemptionReport{}, err
+	}
+
+	redemptions, err := u.redemptionRepo.ListByCouponID(ctx, c.ID)
+	if err != nil {
+		return CouponRedemptionReport{}, err
+	}
+
+	report := CouponRedemptionReport{
+		Coupon:      c,
+		Redemptions: redemptions,
+	}
+
+	if report.Redemptions == nil {
+		report.Redemptions = []coupondom.Redemption{}
+	}
+
+	for _, r := range redemptions {
+		switch r.Status {
+		case coupondom.RedemptionStatusReserved:
+			report.ReservedCount++
+
+		case coupondom.RedemptionStatusRedeemed:
+			report.RedeemedCount++
+			report.DiscountAmount += r.DiscountAmount
+
+		case coupondom.RedemptionStatusReleased:
+			report.ReleasedCount++
+		}
+	}
+
+	return report, nil
+}
+
+// ============================================================
+// Checkout
+// ============================================================
+
+// CouponDiscountInput は値引き計算の入力です。
+// Items / ShippingQuote は注文作成時と同じく server 側で解決済みの値を渡します。
+type CouponDiscountInput struct {
+	Code     string
+	AvatarID string
+
+	Items         []orderdom.OrderItemSnapshot
+	ShippingQuote orderdom.ShippingQuoteSnapshot
+}
+
+// QuoteDiscount は code のクーポンを Items に適用した値引きを返します。
+// 利用者ごとの上限は ReserveForOrder で確認します。
+func (u *CouponUsecase) QuoteDiscount(
+	ctx context.Context,
+	in CouponDiscountInput,
+) (orderdom.DiscountSnapshot, error) {
+	if u == nil || u.repo == nil || u.productBlueprintRepo == nil {
+		return orderdom.DiscountSnapshot{}, ErrCouponNotConfigured
+	}
+
+	code := coupondom.NormalizeCode(in.Code)
+	if code == "" {
+		return orderdom.DiscountSnapshot{}, coupondom.ErrInvalidCode
+	}
+
+	c, err := u.repo.GetByCode(ctx, code)
+	if err != nil {
+		return orderdom.DiscountSnapshot{}, err
+	}
+
+	now := u.now().UTC()
+
+	if err := c.CheckUsable(now); err != nil {
+		return orderdom.DiscountSnapshot{}, err
+	}
+
+	lines, err := u.discountLines(ctx, in.Items, in.ShippingQuote)
+	if err != nil {
+		return orderdom.DiscountSnapshot{}, err
+	}
+
+	discount, err := c.Calculate(lines)
+	if err != nil {
+		return orderdom.DiscountSnapshot{}, err
+	}
+
+	items := make([]orderdom.DiscountItemSnapshot, 0, len(discount.Lines))
+	for _, line := range discount.Lines {
+		items = append(items, orderdom.DiscountItemSnapshot{
+			ItemIndex:      line.Index,
+			Amount:         line.Amount,
+			ShippingAmount: line.ShippingAmount,
+		})
+	}
+
+	return orderdom.DiscountSnapshot{
+		CouponID:     c.ID,
+		Code:         c.Code,
+		DiscountType: string(c.DiscountType),
+
+		Items: items,
+
+		ItemAmount:     discount.ItemAmount,
+		ShippingAmount: discount.ShippingAmount,
+		Amount:         discount.Amount,
+
+		AppliedAt: now,
+	}, nil
+}
+
+// ReserveForOrder は order の値引きに使ったクーポンの利用回数を確保します。
+// 値引きのない注文では何もしません。
+func (u *CouponUsecase) ReserveForOrder(
+	ctx context.Context,
+	order orderdom.Order,
+) error {
+	if order.Discount == nil {
+		return nil
+	}
+
+	if u == nil || u.repo == nil || u.redemptionRepo == nil {
+		return ErrCouponNotConfigured
+	}
+
+	c, err := u.repo.GetByID(ctx, order.Discount.CouponID)
+	if err != nil {
+		return err
+	}
+
+	redemption, err := coupondom.NewRedemption(
+		c,
+		order.ID,
+		order.AvatarID,
+		order.Discount.Amount,
+		u.now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.redemptionRepo.Reserve(ctx, redemption)
+	return err
+}
+
+// ConfirmForOrder は決済完了した注文のクーポン利用を確定します。
+// クーポンを使っていない注文では何もしません。
+func (u *CouponUsecase) ConfirmForOrder(
+	ctx context.Context,
+	orderID string,
+) error {
+	if u == nil || u.redemptionRepo == nil {
+		return ErrCouponNotConfigured
+	}
+
+	_, err := u.redemptionRepo.MarkRedeemed(
+		ctx,
+		orderID,
+		u.now().UTC(),
+	)
+	if errors.Is(err, coupondom.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// ReleaseForOrder は注文のクーポン利用を解放し、利用回数を戻します。
+// クーポンを使っていない注文・解放済みの注文では何もしません。
+func (u *CouponUsecase) ReleaseForOrder(
+	ctx context.Context,
+	orderID string,
+) error {
+	if u == nil || u.redemptionRepo == nil {
+		return ErrCouponNotConfigured
+	}
+
+	_, err := u.redemptionRepo.Release(
+		ctx,
+		orderID,
+		u.now().UTC(),
+	)
+	if errors.Is(err, coupondom.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// discountLines は注文明細を値引き計算の明細に変換します。
+//
+// company / brand は product blueprint から解決し、送料は shipping quote の
+// 明細と listId/inventoryId/modelId で突き合わせます（返金計算と同じ規則）。
+func (u *CouponUsecase) discountLines(
+	ctx context.Context,
+	items []orderdom.OrderItemSnapshot,
+	shippingQuote orderdom.ShippingQuoteSnapshot,
+) ([]coupondom.Line, error) {
+	type scope struct {
+		companyID string
+		brandID   string
+	}
+
+	scopes := make(map[string]scope, len(items))
+	used := make([]bool, len(shippingQuote.Items))
+	lines := make([]coupondom.Line, 0, len(items))
+
+	for index, item := range items {
+		if item.Type != orderdom.OrderItemTypeList ||
+			item.IsCancelled {
+			continue
+		}
+
+		s, ok := scopes[item.ProductBlueprintID]
+		if !ok {
+			pb, err := u.productBlueprintRepo.GetByID(
+				ctx,
+				item.ProductBlueprintID,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"coupon: resolve productBlueprint %q: %w",
+					item.ProductBlueprintID,
+					err,
+				)
+			}
+
+			s = scope{
+				companyID: strings.TrimSpace(pb.CompanyID),
+				brandID:   strings.TrimSpace(pb.BrandID),
+			}
+			scopes[item.ProductBlueprintID] = s
+		}
+
+		brandID := strings.TrimSpace(item.BrandID)
+		if brandID == "" {
+			brandID = s.brandID
+		}
+
+		shippingAmount := 0
+		for quoteIndex, quote := range shippingQuote.Items {
+			if used[quoteIndex] {
+				continue
+			}
+
+			if quote.ListID != item.ListID ||
+				quote.InventoryID != item.InventoryID ||
+				quote.ModelID != item.ModelID {
+				continue
+			}
+
+			used[quoteIndex] = true
+			shippingAmount = quote.Amount
+			break
+		}
+
+		lines = append(lines, coupondom.Line{
+			Index: index,
+
+			CompanyID:          s.companyID,
+			BrandID:            brandID,
+			ProductBlueprintID: item.ProductBlueprintID,
+
+			Amount:         item.Price * item.Qty,
+			ShippingAmount: shippingAmount,
+		})
+	}
+
+	return lines, nil
+}
+
+func (u *CouponUsecase) newCouponID(t time.Time) string {
+	return fmt.Sprintf(
+		"cpn_%d",
+		t.UTC().UnixNano(),
+	)
+}
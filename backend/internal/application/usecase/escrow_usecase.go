@@ -0,0 +1,791 @@
+// backend/internal/application/usecase/escrow_usecase.go
+package usecase
+
+/*
+責務:
+- 決済完了時の resale item の代金の預かり（escrow）の作成
+- NFT 移転の記録、購入者の受取確認、自動受取確認、出品者への支払いの確定（released）
+- 購入者の紛争申し立てと、ブランド（console）による紛争の解決（release / refund）
+
+前提:
+- escrow は {orderId}_{itemIndex} 単位で 1 回だけ作成する（決済後処理の再実行に備える）。取消済みの明細は対象外。
+- 出品者への支払いは、受取確認（購入者 / 自動 / ブランド）と NFT の移転の両方が揃った時点で確定する。
+- 自動受取確認は NFT の移転から autoConfirmAfter 経過後。期限の処理は AutoConfirmDue
+  （internal endpoint から定期実行）で行い、移転の記録漏れも order から補正する。
+- 紛争中は支払いを凍結する。解決はブランド（resale 対象商品のブランドの company）が行う。
+- released の escrow が出品者への支払い記録（PayoutAmount = 販売価格 - ロイヤリティ）になる。
+  payoutLedger が設定されている場合は released になった時点で支払い台帳に計上する（best-effort）。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	applicationport "narratives/internal/application/port"
+	escrowdom "narratives/internal/domain/escrow"
+	orderdom "narratives/internal/domain/order"
+	outboxdom "narratives/internal/domain/outbox"
+	refunddom "narratives/internal/domain/refund"
+)
+
+// DefaultEscrowAutoConfirmAfter は NFT の移転から自動受取確認までの期間。
+const DefaultEscrowAutoConfirmAfter = 7 * 24 * time.Hour
+
+// ============================================================
+// Ports
+// ============================================================
+
+type EscrowOrderGetter interface {
+	GetByID(ctx context.Context, id string) (orderdom.Order, error)
+}
+
+// EscrowPayoutLedger は released になった escrow を出品者への支払い台帳に計上します。
+type EscrowPayoutLedger interface {
+	AccrueForEscrow(ctx context.Context, e escrowdom.Escrow) error
+}
+
+var ErrEscrowNotConfigured = errors.New(
+	"escrow: usecase is not configured",
+)
+
+type EscrowUsecase struct {
+	repo       escrowdom.RepositoryPort
+	orderRepo  EscrowOrderGetter
+	resaleRepo applicationport.ResaleGetter
+	brandRepo  applicationport.BrandGetter
+
+	refundIssuer OrderRefundIssuer
+	payoutLedger EscrowPayoutLedger
+
+	autoConfirmAfter time.Duration
+	now              func() time.Time
+}
+
+func NewEscrowUsecase(
+	repo escrowdom.RepositoryPort,
+	orderRepo EscrowOrderGetter,
+	resaleRepo applicationport.ResaleGetter,
+	brandRepo applicationport.BrandGetter,
+) *EscrowUsecase {
+	return &EscrowUsecase{
+		repo:             repo,
+		orderRepo:        orderRepo,
+		resaleRepo:       resaleRepo,
+		brandRepo:        brandRepo,
+		autoConfirmAfter: DefaultEscrowAutoConfirmAfter,
+		now:              time.Now,
+	}
+}
+
+// WithRefundIssuer は紛争の返金による解決を有効にします。
+func (u *EscrowUsecase) WithRefundIssuer(
+	refundIssuer OrderRefundIssuer,
+) *EscrowUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.refundIssuer = refundIssuer
+
+	return u
+}
+
+// WithPayoutLedger は released の escrow の支払い台帳への計上を有効にします。
+func (u *EscrowUsecase) WithPayoutLedger(
+	payoutLedger EscrowPayoutLedger,
+) *EscrowUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.payoutLedger = payoutLedger
+
+	return u
+}
+
+func (u *EscrowUsecase) WithAutoConfirmAfter(
+	d time.Duration,
+) *EscrowUsecase {
+	if u == nil {
+		return u
+	}
+
+	if d > 0 {
+		u.autoConfirmAfter = d
+	}
+
+	return u
+}
+
+// ============================================================
+// Payment (post-paid)
+// ============================================================
+
+// HoldForOrder は決済済み order の resale item の代金を escrow に預かります。
+// 作成済みの明細はスキップするため、何度呼び出しても結果は同じです。
+func (u *EscrowUsecase) HoldForOrder(
+	ctx context.Context,
+	order orderdom.Order,
+) error {
+	if u == nil || u.repo == nil || u.resaleRepo == nil || u.brandRepo == nil {
+		return ErrEscrowNotConfigured
+	}
+
+	heldAt := u.now().UTC()
+
+	var errs []error
+
+	for index, item := range order.Items {
+		if item.Type != orderdom.OrderItemTypeResale || item.IsCancelled {
+			continue
+		}
+
+		resale, err := u.resaleRepo.GetByID(ctx, item.ResaleID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("resale %s: %w", item.ResaleID, err))
+			continue
+		}
+
+		brand, err := u.brandRepo.GetByID(ctx, item.BrandID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("brand %s: %w", item.BrandID, err))
+			continue
+		}
+
+		e, err := escrowdom.New(escrowdom.NewEscrowInput{
+			OrderID:   order.ID,
+			ItemIndex: index,
+
+			ResaleID:  item.ResaleID,
+			ProductID: item.ProductID,
+
+			CompanyID: brand.CompanyID,
+			BrandID:   item.BrandID,
+
+			BuyerAvatarID:  order.AvatarID,
+			SellerAvatarID: resale.AvatarID,
+
+			SaleAmount:    item.Price * item.Qty,
+			RoyaltyAmount: order.ItemRoyaltyAmount(index),
+
+			HeldAt: heldAt,
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if _, err := u.repo.Create(ctx, e); err != nil &&
+			!errors.Is(err, escrowdom.ErrConflict) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ============================================================
+// Transfer
+// ============================================================
+
+// RecordTransfer は NFT の移転を記録します。受取確認済みであれば支払いを確定します。
+// escrow のない明細（list item や escrow 導入前の注文）は何もしません。
+func (u *EscrowUsecase) RecordTransfer(
+	ctx context.Context,
+	orderID string,
+	itemIndex int,
+	at time.Time,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrEscrowNotConfigured
+	}
+
+	e, err := u.repo.GetByID(ctx, escrowdom.EscrowID(orderID, itemIndex))
+	if err != nil {
+		if errors.Is(err, escrowdom.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = u.recordTransfer(ctx, e, at)
+	return err
+}
+
+var _ OutboxEventHandler = (*EscrowUsecase)(nil)
+
+// HandleOutboxEvent は TransferSucceeded を受けて resale item の NFT の移転を記録します。
+// 注文に紐づかない移転（share 等）や resale 以外の明細は何もしません。
+// 記録済みの escrow は MarkTransferred が変更なしを返すため、再配信されても安全です。
+func (u *EscrowUsecase) HandleOutboxEvent(
+	ctx context.Context,
+	e outboxdom.Event,
+) error {
+	if e.Type != outboxdom.EventTransferSucceeded {
+		return nil
+	}
+
+	if u == nil || u.repo == nil || u.orderRepo == nil {
+		return ErrEscrowNotConfigured
+	}
+
+	var payload outboxdom.TransferSucceededPayload
+	if err := e.DecodePayload(&payload); err != nil {
+		return err
+	}
+
+	orderID := strings.TrimSpace(payload.OrderID)
+	productID := strings.TrimSpace(payload.ProductID)
+	if orderID == "" || productID == "" {
+		return nil
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, orderdom.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	for i, item := range order.Items {
+		if item.Type != orderdom.OrderItemTypeResale ||
+			item.ProductID != productID {
+			continue
+		}
+
+		if err := u.RecordTransfer(ctx, order.ID, i, e.OccurredAt); err != nil {
+			return fmt.Errorf(
+				"record escrow transfer orderId=%s itemIndex=%d: %w",
+				order.ID,
+				i,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (u *EscrowUsecase) recordTransfer(
+	ctx context.Context,
+	e escrowdom.Escrow,
+	at time.Time,
+) (escrowdom.Escrow, error) {
+	now := u.now().UTC()
+	prevUpdatedAt := e.UpdatedAt
+
+	changed, err := e.MarkTransferred(at, u.autoConfirmAfter, now)
+	if err != nil || !changed {
+		return e, err
+	}
+
+	if e.CanRelease() {
+		if err := e.Release(now); err != nil {
+			return e, err
+		}
+	}
+
+	return u.update(ctx, e, prevUpdatedAt)
+}
+
+// ============================================================
+// Buyer / seller side (mall)
+// ============================================================
+
+// EscrowRole は一覧の対象です。
+type EscrowRole string
+
+const (
+	EscrowRoleBuyer  EscrowRole = "buyer"
+	EscrowRoleSeller EscrowRole = "seller"
+)
+
+var ErrEscrowInvalidRole = errors.New("escrow: invalid role")
+
+// ListForAvatar は avatar が購入者（role=buyer）または出品者（role=seller）の escrow を返します。
+func (u *EscrowUsecase) ListForAvatar(
+	ctx context.Context,
+	avatarID string,
+	role EscrowRole,
+) ([]escrowdom.Escrow, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrEscrowNotConfigured
+	}
+
+	avatarID = strings.TrimSpace(avatarID)
+	if avatarID == "" {
+		return nil, escrowdom.ErrInvalidBuyerAvatarID
+	}
+
+	switch role {
+	case EscrowRoleBuyer:
+		return u.repo.ListByBuyerAvatarID(ctx, avatarID)
+	case EscrowRoleSeller:
+		return u.repo.ListBySellerAvatarID(ctx, avatarID)
+	default:
+		return nil, ErrEscrowInvalidRole
+	}
+}
+
+// GetForAvatar は avatar が当事者の escrow を返します。
+func (u *EscrowUsecase) GetForAvatar(
+	ctx context.Context,
+	avatarID string,
+	id string,
+) (escrowdom.Escrow, error) {
+	if u == nil || u.repo == nil {
+		return escrowdom.Escrow{}, ErrEscrowNotConfigured
+	}
+
+	avatarID = strings.TrimSpace(avatarID)
+
+	e, err := u.repo.GetByID(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	// 当事者以外には存在を明かさない。
+	if avatarID == "" ||
+		(e.BuyerAvatarID != avatarID && e.SellerAvatarID != avatarID) {
+		return escrowdom.Escrow{}, escrowdom.ErrNotFound
+	}
+
+	return e, nil
+}
+
+// ConfirmReceipt は購入者の受取確認を記録します。NFT が移転済みであれば支払いを確定します。
+func (u *EscrowUsecase) ConfirmReceipt(
+	ctx context.Context,
+	avatarID string,
+	id string,
+) (escrowdom.Escrow, error) {
+	e, err := u.getForBuyer(ctx, avatarID, id)
+	if err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	now := u.now().UTC()
+	prevUpdatedAt := e.UpdatedAt
+
+	if err := e.ConfirmReceipt(escrowdom.ConfirmedByBuyer, now); err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	if e.CanRelease() {
+		if err := e.Release(now); err != nil {
+			return escrowdom.Escrow{}, err
+		}
+	}
+
+	return u.update(ctx, e, prevUpdatedAt)
+}
+
+type OpenEscrowDisputeInput struct {
+	AvatarID string
+	EscrowID string
+
+	Reason  escrowdom.DisputeReason
+	Comment string
+}
+
+// OpenDispute は購入者の紛争申し立てを記録し、出品者への支払いを凍結します。
+func (u *EscrowUsecase) OpenDispute(
+	ctx context.Context,
+	in OpenEscrowDisputeInput,
+) (escrowdom.Escrow, error) {
+	e, err := u.getForBuyer(ctx, in.AvatarID, in.EscrowID)
+	if err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	prevUpdatedAt := e.UpdatedAt
+
+	if err := e.OpenDispute(in.Reason, in.Comment, u.now()); err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	return u.repo.Update(ctx, e, prevUpdatedAt)
+}
+
+// getForBuyer は受取確認・紛争の操作対象を返します（購入者以外は ErrNotFound）。
+func (u *EscrowUsecase) getForBuyer(
+	ctx context.Context,
+	avatarID string,
+	id string,
+) (escrowdom.Escrow, error) {
+	e, err := u.GetForAvatar(ctx, avatarID, id)
+	if err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	if e.BuyerAvatarID != strings.TrimSpace(avatarID) {
+		return escrowdom.Escrow{}, escrowdom.ErrNotFound
+	}
+
+	return e, nil
+}
+
+// ============================================================
+// Brand side (console)
+// ============================================================
+
+func (u *EscrowUsecase) ListForCompany(
+	ctx context.Context,
+	status escrowdom.Status,
+) ([]escrowdom.Escrow, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrEscrowNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nil, escrowdom.ErrInvalidCompanyID
+	}
+
+	escrows, err := u.repo.ListByCompanyID(ctx, companyID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if escrows == nil {
+		escrows = []escrowdom.Escrow{}
+	}
+
+	return escrows, nil
+}
+
+func (u *EscrowUsecase) GetForCompany(
+	ctx context.Context,
+	id string,
+) (escrowdom.Escrow, error) {
+	if u == nil || u.repo == nil {
+		return escrowdom.Escrow{}, ErrEscrowNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return escrowdom.Escrow{}, escrowdom.ErrInvalidCompanyID
+	}
+
+	e, err := u.repo.GetByID(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	// 他社の escrow は存在しないものとして扱う。
+	if e.CompanyID != companyID {
+		return escrowdom.Escrow{}, escrowdom.ErrNotFound
+	}
+
+	return e, nil
+}
+
+type ResolveEscrowDisputeInput struct {
+	EscrowID string
+
+	Resolution escrowdom.Resolution
+	Note       string
+}
+
+// ResolveDispute は紛争を出品者への支払い（release）または購入者への返金（refund）で解決します。
+func (u *EscrowUsecase) ResolveDispute(
+	ctx context.Context,
+	in ResolveEscrowDisputeInput,
+) (escrowdom.Escrow, error) {
+	e, err := u.GetForCompany(ctx, in.EscrowID)
+	if err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	if e.Status != escrowdom.StatusDisputed {
+		return escrowdom.Escrow{}, escrowdom.ErrNotDisputed
+	}
+
+	memberID := strings.TrimSpace(MemberIDFromContext(ctx))
+	prevUpdatedAt := e.UpdatedAt
+
+	switch in.Resolution {
+	case escrowdom.ResolutionRelease:
+		// 移転が記録漏れの場合は order から補正してから判定する。
+		if e.TransferredAt == nil {
+			if at := u.lookupTransferredAt(ctx, e); at != nil {
+				if _, err := e.MarkTransferred(*at, u.autoConfirmAfter, u.now()); err != nil {
+					return escrowdom.Escrow{}, err
+				}
+			}
+		}
+
+		if err := e.ResolveRelease(in.Note, memberID, u.now()); err != nil {
+			return escrowdom.Escrow{}, err
+		}
+
+	case escrowdom.ResolutionRefund:
+		refundID, err := u.refundForEscrow(ctx, e, in.Note, memberID)
+		if err != nil {
+			return escrowdom.Escrow{}, err
+		}
+
+		if err := e.ResolveRefund(refundID, in.Note, memberID, u.now()); err != nil {
+			return escrowdom.Escrow{}, err
+		}
+
+	default:
+		return escrowdom.Escrow{}, escrowdom.ErrInvalidResolution
+	}
+
+	return u.update(ctx, e, prevUpdatedAt)
+}
+
+// refundForEscrow は escrow の明細を返金し、refund ID を返します。
+// 返金済みの明細（escrow の保存に失敗した再実行など）は既存の refund ID を返します。
+func (u *EscrowUsecase) refundForEscrow(
+	ctx context.Context,
+	e escrowdom.Escrow,
+	note string,
+	memberID string,
+) (string, error) {
+	if u.refundIssuer == nil || u.orderRepo == nil {
+		return "", ErrEscrowNotConfigured
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, e.OrderID)
+	if err != nil {
+		return "", err
+	}
+	if e.ItemIndex >= len(order.Items) {
+		return "", escrowdom.ErrInvalidItemIndex
+	}
+
+	qty := order.Items[e.ItemIndex].Qty - order.ItemRefundedQty(e.ItemIndex)
+	if qty <= 0 {
+		for i := len(order.Refunds) - 1; i >= 0; i-- {
+			for _, item := range order.Refunds[i].Items {
+				if item.ItemIndex == e.ItemIndex {
+					return order.Refunds[i].RefundID, nil
+				}
+			}
+		}
+		return "", escrowdom.ErrInvalidRefundID
+	}
+
+	refund, err := u.refundIssuer.IssueRefund(ctx, IssueRefundInput{
+		OrderID: e.OrderID,
+		Items: []IssueRefundItemInput{
+			{ItemIndex: e.ItemIndex, Qty: qty},
+		},
+		Reason:      refunddom.ReasonRequestedByCustomer,
+		Note:        strings.TrimSpace(note),
+		RequestedBy: memberID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return refund.ID, nil
+}
+
+// ============================================================
+// Auto confirm (sweeper)
+// ============================================================
+
+// AutoConfirmDueEscrowsResult は sweeper 1 回分の処理結果。
+type AutoConfirmDueEscrowsResult struct {
+	Scanned     int `json:"scanned"`
+	Transferred int `json:"transferred"`
+	Confirmed   int `json:"confirmed"`
+	Released    int `json:"released"`
+	Accrued     int `json:"accrued"`
+	Failed      int `json:"failed"`
+}
+
+// AutoConfirmDue は held の escrow を処理します。
+//
+//   - NFT の移転が未記録の escrow: order の明細が移転済みであれば記録する
+//   - 自動受取確認の期限を過ぎた escrow: 受取確認済み（auto）にする
+//   - 受取確認済みかつ移転済みの escrow: released にする
+//   - released だが支払い台帳に未計上の escrow: 計上し直す
+//
+// 1 件の失敗で残りの処理を止めず、最初のエラーを結果と一緒に返す。
+func (u *EscrowUsecase) AutoConfirmDue(
+	ctx context.Context,
+	limit int,
+) (AutoConfirmDueEscrowsResult, error) {
+	var result AutoConfirmDueEscrowsResult
+
+	if u == nil || u.repo == nil {
+		return result, ErrEscrowNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	due, err := u.repo.ListDue(ctx, now, limit)
+	if err != nil {
+		return result, err
+	}
+
+	var firstErr error
+
+	for _, e := range due {
+		result.Scanned++
+
+		if err := u.sweepOne(ctx, e, now, &result); err != nil {
+			result.Failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if u.payoutLedger == nil {
+		return result, firstErr
+	}
+
+	pending, err := u.repo.ListPendingPayoutAccrual(ctx, limit)
+	if err != nil {
+		if firstErr == nil {
+			firstErr = err
+		}
+		return result, firstErr
+	}
+
+	for _, e := range pending {
+		result.Scanned++
+
+		if _, err := u.accruePayout(ctx, e); err != nil {
+			result.Failed++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("escrow %s: %w", e.ID, err)
+			}
+			continue
+		}
+
+		result.Accrued++
+	}
+
+	return result, firstErr
+}
+
+func (u *EscrowUsecase) sweepOne(
+	ctx context.Context,
+	e escrowdom.Escrow,
+	now time.Time,
+	result *AutoConfirmDueEscrowsResult,
+) error {
+	prevUpdatedAt := e.UpdatedAt
+	changed := false
+
+	if e.TransferredAt == nil {
+		at := u.lookupTransferredAt(ctx, e)
+		if at == nil {
+			return nil
+		}
+
+		if _, err := e.MarkTransferred(*at, u.autoConfirmAfter, now); err != nil {
+			return err
+		}
+		changed = true
+		result.Transferred++
+	}
+
+	if e.IsAutoConfirmDue(now) {
+		if err := e.ConfirmReceipt(escrowdom.ConfirmedByAuto, now); err != nil {
+			return err
+		}
+		changed = true
+		result.Confirmed++
+	}
+
+	released := false
+	if e.CanRelease() {
+		if err := e.Release(now); err != nil {
+			return err
+		}
+		changed = true
+		released = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if _, err := u.update(ctx, e, prevUpdatedAt); err != nil {
+		return fmt.Errorf("escrow %s: %w", e.ID, err)
+	}
+
+	if released {
+		result.Released++
+	}
+
+	return nil
+}
+
+// update は escrow を保存し、released になった escrow を支払い台帳に計上します。
+// 計上は発生源ごとに 1 回だけ行われるため、保存済みの released を再度渡しても重複しません。
+//
+// 計上に失敗しても保存済みの状態遷移は取り消さず、計上済みの記録も残しません。
+// 未計上の released は AutoConfirmDue が計上し直します。
+func (u *EscrowUsecase) update(
+	ctx context.Context,
+	e escrowdom.Escrow,
+	prevUpdatedAt time.Time,
+) (escrowdom.Escrow, error) {
+	saved, err := u.repo.Update(ctx, e, prevUpdatedAt)
+	if err != nil {
+		return escrowdom.Escrow{}, err
+	}
+
+	if saved.NeedsPayoutAccrual() && u.payoutLedger != nil {
+		accrued, err := u.accruePayout(ctx, saved)
+		if err != nil {
+			log.Printf("[escrow] payout accrual failed escrowId=%s err=%v", saved.ID, err)
+			return saved, nil
+		}
+		saved = accrued
+	}
+
+	return saved, nil
+}
+
+// accruePayout は released の escrow を支払い台帳に計上し、計上済みを記録します。
+func (u *EscrowUsecase) accruePayout(
+	ctx context.Context,
+	e escrowdom.Escrow,
+) (escrowdom.Escrow, error) {
+	if err := u.payoutLedger.AccrueForEscrow(ctx, e); err != nil {
+		return e, err
+	}
+
+	prevUpdatedAt := e.UpdatedAt
+	if err := e.MarkPayoutAccrued(u.now()); err != nil {
+		return e, err
+	}
+
+	return u.repo.Update(ctx, e, prevUpdatedAt)
+}
+
+// lookupTransferredAt は order の明細の移転日時を返します（未移転・解決できない場合は nil）。
+func (u *EscrowUsecase) lookupTransferredAt(
+	ctx context.Context,
+	e escrowdom.Escrow,
+) *time.Time {
+	if u.orderRepo == nil {
+		return nil
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, e.OrderID)
+	if err != nil || e.ItemIndex >= len(order.Items) {
+		return nil
+	}
+
+	item := order.Items[e.ItemIndex]
+	if !item.Transferred || item.TransferredAt == nil {
+		return nil
+	}
+
+	return item.TransferredAt
+}
@@ -47,3 +47,13 @@ func dedupStrings(xs []string) []string {
 	}
 	return out
 }
+
+// 共通ヘルパー: 空白除去後に最初の空でない値を返す
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,207 @@
+// backend/internal/application/usecase/idempotency_usecase.go
+package usecase
+
+/*
+責務:
+- Idempotency-Key 付きの書き込みリクエストの重複実行を防ぐ。
+  - 初回: キーを処理中として取得し、ハンドラの実行後にレスポンスを保存する。
+  - 同じリクエストの再送: 保存済みのレスポンスを返す（ハンドラを実行しない）。
+  - 別のリクエストでのキーの再利用・処理中の並行リクエスト: エラー。
+
+前提:
+- キーはユーザー単位で、TTL（既定 24 時間）を過ぎると再利用できる。
+- 5xx などレスポンスを保存しない結果の場合は Release でキーを解放し、同じキーで再試行できる。
+- リース（既定 2 分）はプロセス停止時にキーを取り戻すためのもの。処理中のリクエストは
+  IdempotencyLeaseRenewInterval ごとに Extend でリースを延長し、再送を処理中として拒否させる。
+*/
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	idemdom "narratives/internal/domain/idempotency"
+)
+
+const (
+	defaultIdempotencyKeyTTL   = 24 * time.Hour
+	defaultIdempotencyKeyLease = 2 * time.Minute
+
+	// IdempotencyLeaseRenewInterval は処理中のリクエストがリースを延長する間隔です。
+	// 延長が 1 回遅れてもリースが切れないよう、リースより十分短くします。
+	IdempotencyLeaseRenewInterval = defaultIdempotencyKeyLease / 4
+)
+
+var (
+	ErrIdempotencyNotConfigured = errors.New(
+		"idempotency: usecase is not configured",
+	)
+	ErrIdempotencyKeyReused = errors.New(
+		"idempotency: key was already used for a different request",
+	)
+	ErrIdempotencyRequestInProgress = errors.New(
+		"idempotency: a request with the same key is in progress",
+	)
+)
+
+type IdempotencyUsecase struct {
+	repo idemdom.RepositoryPort
+
+	ttl   time.Duration
+	lease time.Duration
+
+	now func() time.Time
+}
+
+func NewIdempotencyUsecase(repo idemdom.RepositoryPort) *IdempotencyUsecase {
+	return &IdempotencyUsecase{
+		repo:  repo,
+		ttl:   defaultIdempotencyKeyTTL,
+		lease: defaultIdempotencyKeyLease,
+		now:   time.Now,
+	}
+}
+
+// WithTTL はキーの保持期間を設定します（0 以下は既定値）。
+func (u *IdempotencyUsecase) WithTTL(ttl time.Duration) *IdempotencyUsecase {
+	if u == nil || ttl <= 0 {
+		return u
+	}
+
+	u.ttl = ttl
+
+	return u
+}
+
+type BeginIdempotentRequestInput struct {
+	UserID string
+	Key    string
+
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// IdempotentRequest は Begin の結果です。
+//
+// Replay が true の場合は Record のレスポンスをそのまま返します。
+// それ以外はハンドラを実行し、Complete または Release を呼び出します。
+type IdempotentRequest struct {
+	Record idemdom.Record
+	Replay bool
+}
+
+// Begin はキーを取得するか、保存済みのレスポンスを返します。
+func (u *IdempotencyUsecase) Begin(
+	ctx context.Context,
+	in BeginIdempotentRequestInput,
+) (IdempotentRequest, error) {
+	if u == nil || u.repo == nil {
+		return IdempotentRequest{}, ErrIdempotencyNotConfigured
+	}
+
+	fingerprint := idemdom.Fingerprint(in.Method, in.Path, in.Body)
+
+	rec, err := idemdom.NewInProgress(
+		in.UserID,
+		strings.TrimSpace(in.Key),
+		fingerprint,
+		u.now(),
+		u.lease,
+		u.ttl,
+	)
+	if err != nil {
+		return IdempotentRequest{}, err
+	}
+
+	stored, acquired, err := u.repo.Acquire(ctx, rec)
+	if err != nil {
+		return IdempotentRequest{}, err
+	}
+
+	if acquired {
+		return IdempotentRequest{Record: stored}, nil
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return IdempotentRequest{}, ErrIdempotencyKeyReused
+	}
+
+	if stored.Status != idemdom.StatusCompleted {
+		return IdempotentRequest{}, ErrIdempotencyRequestInProgress
+	}
+
+	return IdempotentRequest{
+		Record: stored,
+		Replay: true,
+	}, nil
+}
+
+// Extend は処理中のキーのリースを延長します。
+// 別のリクエストがキーを取得し直していた場合は idemdom.ErrNotInProgress を返します。
+func (u *IdempotencyUsecase) Extend(
+	ctx context.Context,
+	req IdempotentRequest,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrIdempotencyNotConfigured
+	}
+	if req.Replay {
+		return nil
+	}
+
+	rec := req.Record
+	if err := rec.ExtendLease(u.now(), u.lease); err != nil {
+		return err
+	}
+
+	return u.repo.Extend(ctx, rec)
+}
+
+// Complete はハンドラのレスポンスを保存します。
+// 保存できないレスポンス（5xx・サイズ超過）の場合はキーを解放します。
+func (u *IdempotencyUsecase) Complete(
+	ctx context.Context,
+	req IdempotentRequest,
+	status int,
+	contentType string,
+	body []byte,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrIdempotencyNotConfigured
+	}
+	if req.Replay {
+		return nil
+	}
+
+	rec := req.Record
+
+	if status >= 500 {
+		return u.repo.Release(ctx, rec)
+	}
+
+	if err := rec.Complete(status, contentType, body, u.now()); err != nil {
+		if errors.Is(err, idemdom.ErrResponseTooLarge) {
+			return u.repo.Release(ctx, rec)
+		}
+		return err
+	}
+
+	return u.repo.Complete(ctx, rec)
+}
+
+// Release はキーを解放します（ハンドラが panic した場合など）。
+func (u *IdempotencyUsecase) Release(
+	ctx context.Context,
+	req IdempotentRequest,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrIdempotencyNotConfigured
+	}
+	if req.Replay {
+		return nil
+	}
+
+	return u.repo.Release(ctx, req.Record)
+}
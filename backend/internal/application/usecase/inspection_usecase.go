@@ -3,10 +3,16 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	applicationport "narratives/internal/application/port"
 	inspectiondom "narratives/internal/domain/inspection"
+	pbcdom "narratives/internal/domain/productBlueprintCategory"
+	productiondom "narratives/internal/domain/production"
 )
 
 // ------------------------------------------------------------
@@ -26,6 +32,19 @@ type ProductInspectionRepo interface {
 	) error
 }
 
+// InspectionProductionGetter は不良理由の category を解決するための production 取得ポートです。
+type InspectionProductionGetter interface {
+	GetByID(ctx context.Context, id string) (*productiondom.Production, error)
+}
+
+// InspectionProductionProgress は検品完了を production の status に反映するポートです。
+type InspectionProductionProgress interface {
+	MarkInspected(ctx context.Context, productionID string, at time.Time) error
+}
+
+// ErrInspectionDefectReasonsNotConfigured は不良理由の category を解決する依存が未設定の場合のエラーです。
+var ErrInspectionDefectReasonsNotConfigured = errors.New("inspection: defect reason catalog is not configured")
+
 // ------------------------------------------------------------
 // Usecase
 // ------------------------------------------------------------
@@ -36,6 +55,13 @@ type ProductInspectionRepo interface {
 type InspectionUsecase struct {
 	inspectionRepo inspectiondom.Repository
 	productRepo    ProductInspectionRepo
+
+	// 不良理由 code の検証用（production → productBlueprint → category）
+	productionRepo    InspectionProductionGetter
+	productBlueprints applicationport.ProductBlueprintGetter
+
+	// 検品完了時に production を inspected にする（optional）
+	productionProgress InspectionProductionProgress
 }
 
 // NewInspectionUsecase を唯一の出入り口にするため、必要な依存はすべてここで受け取る。
@@ -49,6 +75,75 @@ func NewInspectionUsecase(
 	}
 }
 
+// WithDefectReasonCatalog は不良理由 code を production の category で検証するための依存を設定します。
+func (u *InspectionUsecase) WithDefectReasonCatalog(
+	productionRepo InspectionProductionGetter,
+	productBlueprints applicationport.ProductBlueprintGetter,
+) *InspectionUsecase {
+	if u == nil {
+		return nil
+	}
+	u.productionRepo = productionRepo
+	u.productBlueprints = productBlueprints
+	return u
+}
+
+// WithProductionProgress は検品完了時に production の status を進めるための依存を設定します。
+func (u *InspectionUsecase) WithProductionProgress(
+	progress InspectionProductionProgress,
+) *InspectionUsecase {
+	if u == nil {
+		return nil
+	}
+	u.productionProgress = progress
+	return u
+}
+
+// ------------------------------------------------------------
+// Queries
+// ------------------------------------------------------------
+
+// ListDefectReasons は production の category で選択できる不良理由を返します。
+func (u *InspectionUsecase) ListDefectReasons(
+	ctx context.Context,
+	productionID string,
+) ([]pbcdom.DefectReason, error) {
+	path, err := u.categoryPathForProduction(ctx, productionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pbcdom.ListDefectReasons(path), nil
+}
+
+func (u *InspectionUsecase) categoryPathForProduction(
+	ctx context.Context,
+	productionID string,
+) ([]string, error) {
+	if u == nil || u.productionRepo == nil || u.productBlueprints == nil {
+		return nil, ErrInspectionDefectReasonsNotConfigured
+	}
+
+	if productionID == "" {
+		return nil, inspectiondom.ErrInvalidInspectionProductionID
+	}
+
+	production, err := u.productionRepo.GetByID(ctx, productionID)
+	if err != nil {
+		return nil, err
+	}
+	if production == nil {
+		return nil, productiondom.ErrNotFound
+	}
+
+	pb, err := u.productBlueprints.GetByID(ctx, production.ProductBlueprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ProductBlueprintCategoryPath, nil
+}
+
 // ------------------------------------------------------------
 // Commands
 // ------------------------------------------------------------
@@ -108,6 +203,13 @@ func (u *InspectionUsecase) CompleteInspectionForProduction(
 		}
 	}
 
+	// production の status 反映は best-effort（検品完了自体は確定済み）
+	if u.productionProgress != nil {
+		if err := u.productionProgress.MarkInspected(ctx, pid, at); err != nil {
+			log.Printf("inspection usecase: mark production inspected failed productionId=%s err=%v", pid, err)
+		}
+	}
+
 	return updated, nil
 }
 
@@ -116,6 +218,9 @@ func (u *InspectionUsecase) CompleteInspectionForProduction(
 // ネガティブ制では、通常は failed / notManufactured を明示的に入力します。
 // ただし、誤って failed / notManufactured にした productId を戻すため、
 // 修正操作として passed への更新も許可します。
+//
+// defect は failed の場合のみ指定できます（不良理由・メモ・写真 URL）。
+// 不良理由 code は production の category で選択可能なものに限ります。
 func (u *InspectionUsecase) UpdateInspectionForProduct(
 	ctx context.Context,
 	productionID string,
@@ -123,6 +228,7 @@ func (u *InspectionUsecase) UpdateInspectionForProduct(
 	result *inspectiondom.InspectionResult,
 	inspectedBy *string,
 	inspectedAt *time.Time,
+	defect *inspectiondom.DefectDetail,
 ) (inspectiondom.InspectionBatch, error) {
 	if u.inspectionRepo == nil {
 		return inspectiondom.InspectionBatch{}, fmt.Errorf("inspectionRepo is nil")
@@ -161,6 +267,32 @@ func (u *InspectionUsecase) UpdateInspectionForProduct(
 		return inspectiondom.InspectionBatch{}, inspectiondom.ErrInvalidInspectedAt
 	}
 
+	if defect != nil && *result != inspectiondom.InspectionFailed {
+		if len(defect.Reasons) > 0 {
+			return inspectiondom.InspectionBatch{}, inspectiondom.ErrInvalidDefectReasons
+		}
+		if defect.Note != nil {
+			return inspectiondom.InspectionBatch{}, inspectiondom.ErrInvalidDefectNote
+		}
+		if len(defect.PhotoURLs) > 0 {
+			return inspectiondom.InspectionBatch{}, inspectiondom.ErrInvalidDefectPhotoURLs
+		}
+	}
+
+	if defect != nil && len(defect.Reasons) > 0 {
+		path, err := u.categoryPathForProduction(ctx, pid)
+		if err != nil {
+			return inspectiondom.InspectionBatch{}, err
+		}
+
+		for _, code := range defect.Reasons {
+			code = strings.TrimSpace(code)
+			if code != "" && !pbcdom.IsValidDefectReason(path, code) {
+				return inspectiondom.InspectionBatch{}, inspectiondom.ErrInvalidDefectReasons
+			}
+		}
+	}
+
 	batch, err := u.inspectionRepo.GetByProductionID(ctx, pid)
 	if err != nil {
 		return inspectiondom.InspectionBatch{}, err
@@ -173,7 +305,11 @@ func (u *InspectionUsecase) UpdateInspectionForProduct(
 		}
 
 	case inspectiondom.InspectionFailed:
-		if err := batch.MarkFailed(pdID, *inspectedBy, atUTC); err != nil {
+		var d inspectiondom.DefectDetail
+		if defect != nil {
+			d = *defect
+		}
+		if err := batch.MarkFailedWithDefect(pdID, *inspectedBy, atUTC, d); err != nil {
 			return inspectiondom.InspectionBatch{}, err
 		}
 
@@ -0,0 +1,752 @@
+// backend/internal/application/usecase/inventory_reservation_usecase.go
+package usecase
+
+/*
+責務:
+- 注文作成時の在庫引当（inventory.ReservedByOrder）と引当レコードの作成
+- 決済完了時の引当確定（期限切れ解放の対象外にする）
+- 決済失敗・決済キャンセル時の引当解放
+- 期限切れかつ未決済の引当解放（sweeper）
+
+前提:
+- 引当レコード ID = orderId
+- Stock[modelId].ReservedByOrder の更新は inventory repository の transaction に委ねる
+- ReserveByOrder / ReleaseReservationByOrder はどちらも冪等
+- 決済は発送時（EnsureOrderPaidForDispatch）に行うため、payment の無い未決済注文は放棄とみなさない
+- 期限切れで解放するのは決済が失敗・キャンセル・未完了の注文だけで、未発送の list item をキャンセルし、
+  クーポン利用と offer（resale の確保）も戻す
+- 解放前に未確定の PaymentIntent をキャンセルし、遅れて成功した決済で解放済みの在庫を課金しない
+*/
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	invdom "narratives/internal/domain/inventory"
+	orderdom "narratives/internal/domain/order"
+	paymentdom "narratives/internal/domain/payment"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+// InventoryStockReservationPort is the inventory stock mutation subset used
+// by InventoryReservationUsecase.
+type InventoryStockReservationPort interface {
+	ReserveByOrder(
+		ctx context.Context,
+		inventoryID string,
+		modelID string,
+		orderID string,
+		qty int,
+	) error
+
+	ReleaseReservationByOrder(
+		ctx context.Context,
+		inventoryID string,
+		modelID string,
+		orderID string,
+		now time.Time,
+	) error
+}
+
+// OrderRepoForReservation reads the order to decide whether an expired
+// reservation belongs to an abandoned checkout, and cancels its items.
+type OrderRepoForReservation interface {
+	GetByID(
+		ctx context.Context,
+		id string,
+	) (orderdom.Order, error)
+
+	Update(
+		ctx context.Context,
+		order orderdom.Order,
+		opts *orderdom.UpdateOptions,
+	) (orderdom.Order, error)
+}
+
+// CouponReleaserForReservation returns the coupon usage of an abandoned
+// checkout. It must be a no-op for orders without a coupon.
+type CouponReleaserForReservation interface {
+	ReleaseForOrder(
+		ctx context.Context,
+		orderID string,
+	) error
+}
+
+// OfferReleaserForReservation ends the accepted offers of an abandoned
+// checkout and frees their reserved resales. It must be a no-op for orders
+// without offers.
+type OfferReleaserForReservation interface {
+	ReleaseForOrder(
+		ctx context.Context,
+		order orderdom.Order,
+	) error
+}
+
+// PaymentRepoForReservation reads the payment of an expired order and marks
+// it canceled after its PaymentIntent is cancelled.
+//
+// The status change goes through ApplyStripePaymentEvent because the
+// repositories reject status updates outside Stripe event application.
+type PaymentRepoForReservation interface {
+	GetByPaymentID(
+		ctx context.Context,
+		paymentID string,
+	) (*paymentdom.Payment, error)
+
+	StripePaymentEventRepository
+}
+
+// StripePaymentIntentCanceler cancels an uncaptured Stripe PaymentIntent and
+// returns its resulting status. An already canceled PaymentIntent must be
+// reported as "canceled" without error.
+type StripePaymentIntentCanceler interface {
+	CancelPaymentIntent(
+		ctx context.Context,
+		stripePaymentIntentID string,
+		reason string,
+	) (string, error)
+}
+
+var (
+	ErrInventoryReservationRepositoryMissing = errors.New(
+		"inventory reservation: repository is not configured",
+	)
+	ErrInventoryReservationStockMissing = errors.New(
+		"inventory reservation: inventory repository is not configured",
+	)
+	ErrInventoryReservationOrderRepoMissing = errors.New(
+		"inventory reservation: order repository is not configured",
+	)
+)
+
+// ============================================================
+// Usecase
+// ============================================================
+
+type InventoryReservationUsecase struct {
+	repo      invdom.ReservationRepository
+	stock     InventoryStockReservationPort
+	orderRepo OrderRepoForReservation
+
+	stockLevelEvaluator StockLevelEvaluator
+	couponReleaser      CouponReleaserForReservation
+	offerReleaser       OfferReleaserForReservation
+
+	paymentRepo     PaymentRepoForReservation
+	paymentCanceler StripePaymentIntentCanceler
+
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewInventoryReservationUsecase(
+	repo invdom.ReservationRepository,
+	stock InventoryStockReservationPort,
+	orderRepo OrderRepoForReservation,
+) *InventoryReservationUsecase {
+	return &InventoryReservationUsecase{
+		repo:      repo,
+		stock:     stock,
+		orderRepo: orderRepo,
+		ttl:       invdom.DefaultReservationTTL,
+		now:       time.Now,
+	}
+}
+
+// WithTTL は注文作成から、決済が失敗・未完了の引当を解放するまでの時間を設定する。
+// 0 以下の場合は既定値を使う。
+func (u *InventoryReservationUsecase) WithTTL(
+	ttl time.Duration,
+) *InventoryReservationUsecase {
+	if u == nil {
+		return u
+	}
+
+	if ttl <= 0 {
+		ttl = invdom.DefaultReservationTTL
+	}
+
+	u.ttl = ttl
+
+	return u
+}
+
+// WithStockLevelEvaluator は引当・解放後の在庫アラート評価を有効にする。
+func (u *InventoryReservationUsecase) WithStockLevelEvaluator(
+	evaluator StockLevelEvaluator,
+) *InventoryReservationUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.stockLevelEvaluator = evaluator
+
+	return u
+}
+
+// WithCouponReleaser は期限切れで放棄された注文のクーポン利用を解放する。
+func (u *InventoryReservationUsecase) WithCouponReleaser(
+	releaser CouponReleaserForReservation,
+) *InventoryReservationUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.couponReleaser = releaser
+
+	return u
+}
+
+// WithOfferReleaser は期限切れで放棄された注文が購入に使った offer を終了し、
+// resale の確保を戻す。
+func (u *InventoryReservationUsecase) WithOfferReleaser(
+	releaser OfferReleaserForReservation,
+) *InventoryReservationUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.offerReleaser = releaser
+
+	return u
+}
+
+// WithPaymentCanceler は期限切れで解放する前に未確定の PaymentIntent を
+// キャンセルし、payment を canceled にする。
+// 未設定の場合、sweeper は決済の試行を確認できないため未決済の注文を解放しない。
+func (u *InventoryReservationUsecase) WithPaymentCanceler(
+	repo PaymentRepoForReservation,
+	canceler StripePaymentIntentCanceler,
+) *InventoryReservationUsecase {
+	if u == nil || repo == nil || canceler == nil {
+		return u
+	}
+
+	u.paymentRepo = repo
+	u.paymentCanceler = canceler
+
+	return u
+}
+
+func (u *InventoryReservationUsecase) WithNow(
+	now func() time.Time,
+) *InventoryReservationUsecase {
+	if u == nil || now == nil {
+		return u
+	}
+
+	u.now = now
+
+	return u
+}
+
+// ReserveForOrder は order の未キャンセル list item を在庫に引き当て、
+// 期限付きの引当レコードを作成する。
+//
+// レコードを先に作成するため、在庫更新の途中で失敗しても sweeper が
+// 期限到来時に解放できる。再実行時は active なレコードに対して
+// 在庫引当だけを冪等に再適用する。
+// list item を含まない注文は何もしない。
+func (u *InventoryReservationUsecase) ReserveForOrder(
+	ctx context.Context,
+	order orderdom.Order,
+) (invdom.Reservation, error) {
+	if u == nil || u.repo == nil {
+		return invdom.Reservation{}, ErrInventoryReservationRepositoryMissing
+	}
+	if u.stock == nil {
+		return invdom.Reservation{}, ErrInventoryReservationStockMissing
+	}
+
+	items := reservationItemsFromOrder(order)
+	if len(items) == 0 {
+		return invdom.Reservation{}, nil
+	}
+
+	reservation, err := invdom.NewReservation(
+		order.ID,
+		items,
+		u.now().UTC(),
+		u.ttl,
+	)
+	if err != nil {
+		return invdom.Reservation{}, err
+	}
+
+	stored, _, err := u.repo.CreateIfAbsent(ctx, reservation)
+	if err != nil {
+		return invdom.Reservation{}, err
+	}
+
+	if stored.Status != invdom.ReservationStatusActive {
+		return stored, nil
+	}
+
+	for _, item := range stored.Items {
+		if err := u.stock.ReserveByOrder(
+			ctx,
+			item.InventoryID,
+			item.ModelID,
+			stored.OrderID,
+			item.Qty,
+		); err != nil {
+			return invdom.Reservation{}, err
+		}
+	}
+
+	u.evaluateStockLevels(ctx, stored.Items)
+
+	return stored, nil
+}
+
+// ConfirmForOrder は決済完了した注文の引当を期限切れ解放の対象外にする。
+// 引当レコードがない注文（resale のみの注文など）は何もしない。
+func (u *InventoryReservationUsecase) ConfirmForOrder(
+	ctx context.Context,
+	orderID string,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrInventoryReservationRepositoryMissing
+	}
+
+	reservation, err := u.repo.GetByOrderID(ctx, strings.TrimSpace(orderID))
+	if err != nil {
+		if errors.Is(err, invdom.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	switch reservation.Status {
+	case invdom.ReservationStatusConfirmed:
+		return nil
+
+	case invdom.ReservationStatusReleased:
+		// 解放後に決済が成功した場合、在庫は既に他の注文へ引き当て可能になっている。
+		// 自動で再引当はせず、発送時の在庫確認に委ねる。
+		log.Printf(
+			"inventory reservation: payment succeeded after release orderId=%q reason=%q",
+			reservation.OrderID,
+			reservation.ReleaseReason,
+		)
+		return nil
+	}
+
+	if err := reservation.Confirm(u.now()); err != nil {
+		return err
+	}
+
+	_, err = u.repo.Update(ctx, reservation)
+	return err
+}
+
+// ReleaseForOrder は注文の引当を解放する。
+//
+// 決済失敗・決済キャンセルでは confirmed の引当を解放しない。
+// 注文キャンセルは confirmed でも解放する。
+// 解放済み、または引当レコードがない場合は何もしない。
+func (u *InventoryReservationUsecase) ReleaseForOrder(
+	ctx context.Context,
+	orderID string,
+	reason invdom.ReservationReleaseReason,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrInventoryReservationRepositoryMissing
+	}
+	if u.stock == nil {
+		return ErrInventoryReservationStockMissing
+	}
+	if !invdom.IsValidReservationReleaseReason(reason) {
+		return invdom.ErrInvalidReleaseReason
+	}
+
+	reservation, err := u.repo.GetByOrderID(ctx, strings.TrimSpace(orderID))
+	if err != nil {
+		if errors.Is(err, invdom.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if reservation.Status == invdom.ReservationStatusReleased {
+		return nil
+	}
+
+	if reservation.Status == invdom.ReservationStatusConfirmed &&
+		reason != invdom.ReservationReleaseReasonOrderCanceled {
+		return nil
+	}
+
+	return u.release(ctx, reservation, reason)
+}
+
+// ReleaseExpiredReservationsResult は sweeper 1 回分の処理結果。
+type ReleaseExpiredReservationsResult struct {
+	Scanned   int `json:"scanned"`
+	Released  int `json:"released"`
+	Confirmed int `json:"confirmed"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+}
+
+type expiredReservationOutcome int
+
+const (
+	expiredReservationReleased expiredReservationOutcome = iota
+	expiredReservationConfirmed
+	expiredReservationSkipped
+)
+
+// ReleaseExpired は期限切れの active な引当を処理する。
+//
+//   - 決済済みの注文: 引当を confirmed にする（webhook 取りこぼしの補正）
+//   - 発送済み・transfer 済みの item を含む注文: 引当を confirmed にする
+//   - payment の無い注文: 引当を confirmed にする（発送時に決済する注文で、放棄ではない）
+//   - 決済処理中（processing）の注文: 何もしない（webhook の結果を待つ）
+//   - 決済が失敗・キャンセル・未完了の注文: PaymentIntent をキャンセルしてから
+//     引当を解放し、未発送の list item をキャンセルする
+//   - 注文が存在しない: 引当を解放する
+//
+// payment の無い注文を active のまま残すと、期限切れの一覧に毎回残って
+// 後続の引当が処理されなくなるため confirmed にする。confirmed の引当は
+// 注文キャンセルまたは transfer で解放される。
+//
+// PaymentIntent のキャンセルに失敗した注文は解放せず Failed として数え、
+// 次回の sweep で再試行する。
+// 1 件の失敗で残りの処理を止めず、最初のエラーを結果と一緒に返す。
+func (u *InventoryReservationUsecase) ReleaseExpired(
+	ctx context.Context,
+	limit int,
+) (ReleaseExpiredReservationsResult, error) {
+	var result ReleaseExpiredReservationsResult
+
+	if u == nil || u.repo == nil {
+		return result, ErrInventoryReservationRepositoryMissing
+	}
+	if u.stock == nil {
+		return result, ErrInventoryReservationStockMissing
+	}
+	if u.orderRepo == nil {
+		return result, ErrInventoryReservationOrderRepoMissing
+	}
+
+	now := u.now().UTC()
+
+	expired, err := u.repo.ListExpired(ctx, now, limit)
+	if err != nil {
+		return result, err
+	}
+
+	var firstErr error
+
+	for _, reservation := range expired {
+		result.Scanned++
+
+		outcome, err := u.releaseExpiredOne(ctx, reservation, now)
+		if err != nil {
+			result.Failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		switch outcome {
+		case expiredReservationReleased:
+			result.Released++
+		case expiredReservationConfirmed:
+			result.Confirmed++
+		case expiredReservationSkipped:
+			result.Skipped++
+		}
+	}
+
+	return result, firstErr
+}
+
+func (u *InventoryReservationUsecase) releaseExpiredOne(
+	ctx context.Context,
+	reservation invdom.Reservation,
+	now time.Time,
+) (expiredReservationOutcome, error) {
+	order, err := u.orderRepo.GetByID(ctx, reservation.OrderID)
+	if err != nil {
+		if errors.Is(err, orderdom.ErrNotFound) {
+			if err := u.release(
+				ctx,
+				reservation,
+				invdom.ReservationReleaseReasonExpired,
+			); err != nil {
+				return expiredReservationReleased, err
+			}
+			return expiredReservationReleased, nil
+		}
+		return expiredReservationReleased, err
+	}
+
+	if order.Paid || orderHasShippedItems(order) {
+		return u.confirmExpired(ctx, reservation, now)
+	}
+
+	paymentStatus, err := u.cancelAbandonedPayment(ctx, order.ID)
+	if err != nil {
+		return expiredReservationReleased, err
+	}
+
+	switch paymentStatus {
+	case "", paymentdom.StatusSucceeded:
+		// payment が無い注文は発送時に決済する。
+		// succeeded は order.Paid の反映（webhook）が遅れている。
+		return u.confirmExpired(ctx, reservation, now)
+	case paymentdom.StatusCanceled:
+	default:
+		// processing などは webhook の結果を待つ。
+		return expiredReservationSkipped, nil
+	}
+
+	if err := u.release(
+		ctx,
+		reservation,
+		invdom.ReservationReleaseReasonExpired,
+	); err != nil {
+		return expiredReservationReleased, err
+	}
+
+	if err := u.cancelAbandonedListItems(ctx, order); err != nil {
+		return expiredReservationReleased, err
+	}
+
+	if u.couponReleaser != nil && order.Discount != nil {
+		if err := u.couponReleaser.ReleaseForOrder(ctx, order.ID); err != nil {
+			return expiredReservationReleased, err
+		}
+	}
+
+	if u.offerReleaser != nil {
+		if err := u.offerReleaser.ReleaseForOrder(ctx, order); err != nil {
+			return expiredReservationReleased, err
+		}
+	}
+
+	return expiredReservationReleased, nil
+}
+
+func (u *InventoryReservationUsecase) confirmExpired(
+	ctx context.Context,
+	reservation invdom.Reservation,
+	now time.Time,
+) (expiredReservationOutcome, error) {
+	if err := reservation.Confirm(now); err != nil {
+		return expiredReservationConfirmed, err
+	}
+	_, err := u.repo.Update(ctx, reservation)
+	return expiredReservationConfirmed, err
+}
+
+// cancelAbandonedPayment は放棄された注文の PaymentIntent をキャンセルし、
+// payment を canceled にする。戻り値は payment の最終的な status
+// （payment が無い・未設定の場合は空文字）。
+//
+// pending / requires_action / failed の payment だけをキャンセルする。
+// Stripe 側で既に succeeded / processing の場合はキャンセルできないため、
+// その status をそのまま返し、呼び出し側で解放を見送る。
+func (u *InventoryReservationUsecase) cancelAbandonedPayment(
+	ctx context.Context,
+	orderID string,
+) (paymentdom.PaymentStatus, error) {
+	if u.paymentRepo == nil || u.paymentCanceler == nil {
+		return "", nil
+	}
+
+	payment, err := u.paymentRepo.GetByPaymentID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, paymentdom.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	if payment == nil {
+		return "", nil
+	}
+
+	switch payment.Status {
+	case paymentdom.StatusPending,
+		paymentdom.StatusRequiresAction,
+		paymentdom.StatusFailed:
+	default:
+		return payment.Status, nil
+	}
+
+	stripeStatus, err := u.paymentCanceler.CancelPaymentIntent(
+		ctx,
+		payment.StripePaymentIntentID,
+		"abandoned",
+	)
+	switch paymentdom.PaymentStatus(stripeStatus) {
+	case paymentdom.StatusSucceeded, paymentdom.StatusProcessing:
+		log.Printf(
+			"inventory reservation: payment intent not cancelable orderId=%q status=%q",
+			orderID,
+			stripeStatus,
+		)
+		return paymentdom.PaymentStatus(stripeStatus), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// payment_intent.canceled の webhook を待たずに反映する。
+	// 同じ注文の再実行では同じ EventID になり、二重に適用しない。
+	errMsg := "checkout abandoned: inventory reservation expired"
+	result, err := u.paymentRepo.ApplyStripePaymentEvent(
+		ctx,
+		ApplyStripePaymentEventInput{
+			EventID:               "reservation_expired_" + orderID,
+			PaymentID:             orderID,
+			StripePaymentIntentID: payment.StripePaymentIntentID,
+			Status:                paymentdom.StatusCanceled,
+			ErrorMsg:              &errMsg,
+			OccurredAt:            u.now().UTC(),
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	if result == nil || result.Payment == nil {
+		return "", ErrPaymentStripeEventResultEmpty
+	}
+
+	return result.Payment.Status, nil
+}
+
+func (u *InventoryReservationUsecase) release(
+	ctx context.Context,
+	reservation invdom.Reservation,
+	reason invdom.ReservationReleaseReason,
+) error {
+	now := u.now().UTC()
+
+	for _, item := range reservation.Items {
+		if err := u.stock.ReleaseReservationByOrder(
+			ctx,
+			item.InventoryID,
+			item.ModelID,
+			reservation.OrderID,
+			now,
+		); err != nil && !errors.Is(err, invdom.ErrNotFound) {
+			return err
+		}
+	}
+
+	if err := reservation.Release(reason, now); err != nil {
+		return err
+	}
+
+	if _, err := u.repo.Update(ctx, reservation); err != nil {
+		return err
+	}
+
+	u.evaluateStockLevels(ctx, reservation.Items)
+
+	return nil
+}
+
+// evaluateStockLevels は引当数が変わった inventory / model の在庫アラートを評価する。
+func (u *InventoryReservationUsecase) evaluateStockLevels(
+	ctx context.Context,
+	items []invdom.ReservationItem,
+) {
+	if u.stockLevelEvaluator == nil {
+		return
+	}
+
+	modelIDsByInventory := map[string][]string{}
+	inventoryIDs := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if _, ok := modelIDsByInventory[item.InventoryID]; !ok {
+			inventoryIDs = append(inventoryIDs, item.InventoryID)
+		}
+		modelIDsByInventory[item.InventoryID] = append(
+			modelIDsByInventory[item.InventoryID],
+			item.ModelID,
+		)
+	}
+
+	for _, inventoryID := range inventoryIDs {
+		EvaluateStockLevelBestEffort(
+			ctx,
+			u.stockLevelEvaluator,
+			inventoryID,
+			modelIDsByInventory[inventoryID]...,
+		)
+	}
+}
+
+func (u *InventoryReservationUsecase) cancelAbandonedListItems(
+	ctx context.Context,
+	order orderdom.Order,
+) error {
+	changed := false
+
+	for i, item := range order.Items {
+		if item.Type != orderdom.OrderItemTypeList ||
+			item.IsCancelled ||
+			item.IsDispatched ||
+			item.Transferred {
+			continue
+		}
+
+		if err := order.CancelItem(i); err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	_, err := u.orderRepo.Update(ctx, order, nil)
+	return err
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func reservationItemsFromOrder(
+	order orderdom.Order,
+) []invdom.ReservationItem {
+	items := make([]invdom.ReservationItem, 0, len(order.Items))
+
+	for _, item := range order.Items {
+		if item.Type != orderdom.OrderItemTypeList || item.IsCancelled {
+			continue
+		}
+
+		items = append(items, invdom.ReservationItem{
+			InventoryID: item.InventoryID,
+			ModelID:     item.ModelID,
+			Qty:         item.Qty,
+		})
+	}
+
+	return items
+}
+
+func orderHasShippedItems(order orderdom.Order) bool {
+	for _, item := range order.Items {
+		if item.IsDispatched || item.Transferred {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,181 @@
+// backend/internal/application/usecase/inventory_reservation_usecase_test.go
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/fake"
+	"narratives/internal/adapters/out/memory"
+	usecase "narratives/internal/application/usecase"
+	invdom "narratives/internal/domain/inventory"
+	orderdom "narratives/internal/domain/order"
+	paymentdom "narratives/internal/domain/payment"
+)
+
+func TestInventoryReservation_ReleaseExpired(t *testing.T) {
+	createdAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	afterTTL := createdAt.Add(invdom.DefaultReservationTTL + time.Hour)
+
+	tests := []struct {
+		name string
+		// payment は nil なら payment を作らない（発送時に決済する通常の注文）。
+		payment         *paymentdom.PaymentStatus
+		withoutCanceler bool
+		want            usecase.ReleaseExpiredReservationsResult
+		wantStatus      invdom.ReservationStatus
+		wantCancels     int
+	}{
+		{
+			// 発送待ちの未決済注文は放棄ではない。在庫と注文に触れない。
+			name:       "undispatched order without payment is left untouched",
+			want:       usecase.ReleaseExpiredReservationsResult{Scanned: 1, Confirmed: 1},
+			wantStatus: invdom.ReservationStatusConfirmed,
+		},
+		{
+			name:            "no payment repository configured",
+			withoutCanceler: true,
+			want:            usecase.ReleaseExpiredReservationsResult{Scanned: 1, Confirmed: 1},
+			wantStatus:      invdom.ReservationStatusConfirmed,
+		},
+		{
+			name:        "failed payment is released",
+			payment:     paymentStatusPtr(paymentdom.StatusFailed),
+			want:        usecase.ReleaseExpiredReservationsResult{Scanned: 1, Released: 1},
+			wantStatus:  invdom.ReservationStatusReleased,
+			wantCancels: 1,
+		},
+		{
+			name:        "payment requiring action is released",
+			payment:     paymentStatusPtr(paymentdom.StatusRequiresAction),
+			want:        usecase.ReleaseExpiredReservationsResult{Scanned: 1, Released: 1},
+			wantStatus:  invdom.ReservationStatusReleased,
+			wantCancels: 1,
+		},
+		{
+			name:       "canceled payment is released",
+			payment:    paymentStatusPtr(paymentdom.StatusCanceled),
+			want:       usecase.ReleaseExpiredReservationsResult{Scanned: 1, Released: 1},
+			wantStatus: invdom.ReservationStatusReleased,
+		},
+		{
+			name:       "processing payment waits for the webhook",
+			payment:    paymentStatusPtr(paymentdom.StatusProcessing),
+			want:       usecase.ReleaseExpiredReservationsResult{Scanned: 1, Skipped: 1},
+			wantStatus: invdom.ReservationStatusActive,
+		},
+		{
+			name:       "succeeded payment is confirmed",
+			payment:    paymentStatusPtr(paymentdom.StatusSucceeded),
+			want:       usecase.ReleaseExpiredReservationsResult{Scanned: 1, Confirmed: 1},
+			wantStatus: invdom.ReservationStatusConfirmed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newCheckoutFlow()
+			s := f.seed(t, ctx)
+
+			now := createdAt
+			reservations := memory.NewInventoryReservationRepositoryMem()
+			stripe := fake.NewStripeGatewayFake()
+
+			reservationUC := usecase.NewInventoryReservationUsecase(
+				reservations,
+				f.inventories,
+				f.orders,
+			).WithNow(func() time.Time { return now })
+			if !tt.withoutCanceler {
+				reservationUC.WithPaymentCanceler(f.payments, stripe)
+			}
+			f.orderUC.WithInventoryReserver(reservationUC)
+
+			order := f.createOrder(t, ctx, s)
+
+			if tt.payment != nil {
+				if _, err := f.paymentUC.Create(ctx, paymentdom.Payment{
+					PaymentID:             order.ID,
+					PaymentMethodID:       order.PaymentMethodSnapshot.PaymentMethodID,
+					StripeCustomerID:      order.PaymentMethodSnapshot.CustomerID,
+					StripePaymentMethodID: order.PaymentMethodSnapshot.StripePaymentMethodID,
+					StripePaymentIntentID: "pi_sweeper",
+					Amount:                5000,
+					Status:                *tt.payment,
+				}); err != nil {
+					t.Fatalf("create payment: %v", err)
+				}
+			}
+
+			now = afterTTL
+			got, err := reservationUC.ReleaseExpired(ctx, 10)
+			if err != nil {
+				t.Fatalf("ReleaseExpired: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("ReleaseExpired = %+v, want %+v", got, tt.want)
+			}
+
+			reservation, err := reservations.GetByOrderID(ctx, order.ID)
+			if err != nil {
+				t.Fatalf("get reservation: %v", err)
+			}
+			if reservation.Status != tt.wantStatus {
+				t.Fatalf("reservation status = %q, want %q", reservation.Status, tt.wantStatus)
+			}
+			if len(stripe.Cancels) != tt.wantCancels {
+				t.Fatalf("payment intent cancels = %v, want %d", stripe.Cancels, tt.wantCancels)
+			}
+
+			released := tt.wantStatus == invdom.ReservationStatusReleased
+
+			inventory, err := f.inventories.GetByID(ctx, s.inventoryID)
+			if err != nil {
+				t.Fatalf("get inventory: %v", err)
+			}
+			if _, reserved := inventory.Stock[s.modelID].ReservedByOrder[order.ID]; reserved == released {
+				t.Fatalf("stock reserved = %v, want %v", reserved, !released)
+			}
+
+			stored, err := f.orders.GetByID(ctx, order.ID)
+			if err != nil {
+				t.Fatalf("get order: %v", err)
+			}
+			if stored.Items[0].IsCancelled != released {
+				t.Fatalf("item cancelled = %v, want %v", stored.Items[0].IsCancelled, released)
+			}
+		})
+	}
+}
+
+func paymentStatusPtr(s paymentdom.PaymentStatus) *paymentdom.PaymentStatus {
+	return &s
+}
+
+// createOrder は seed した出品 1 点の注文を作成する。
+func (f *checkoutFlow) createOrder(t *testing.T, ctx context.Context, s checkoutSeed) orderdom.Order {
+	t.Helper()
+
+	order, err := f.orderUC.Create(ctx, usecase.CreateOrderInput{
+		UserID:            checkoutUserID,
+		AvatarID:          s.avatarID,
+		CartID:            "cart_checkout",
+		ShippingAddressID: checkoutDestinationAddressID,
+		PaymentMethodID:   s.paymentMethodID,
+		Items: []usecase.CreateOrderItemInput{
+			{
+				Type:    orderdom.OrderItemTypeList,
+				ListID:  s.listID,
+				ModelID: s.modelID,
+				Qty:     1,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	return order
+}
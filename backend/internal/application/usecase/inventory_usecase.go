@@ -8,6 +8,7 @@ import (
 	"time"
 
 	applicationport "narratives/internal/application/port"
+	auditdom "narratives/internal/domain/audit"
 	invdom "narratives/internal/domain/inventory"
 	shadom "narratives/internal/domain/shippingAddress"
 	transportationdom "narratives/internal/domain/transportation"
@@ -25,6 +26,10 @@ type InventoryUsecase struct {
 	shippingAddressRepo  shadom.RepositoryPort
 	transportationRepo   transportationdom.RepositoryPort
 	productBlueprintRepo applicationport.ProductBlueprintGetter
+
+	stockLevelEvaluator StockLevelEvaluator
+
+	audit AuditRecorder
 }
 
 func NewInventoryUsecase(repo invdom.RepositoryPort) *InventoryUsecase {
@@ -49,6 +54,31 @@ func (uc *InventoryUsecase) WithProductModelResolver(
 	return uc
 }
 
+// WithStockLevelEvaluator は Products / ReservedByOrder 更新後の
+// 在庫アラート評価を有効にする。
+func (uc *InventoryUsecase) WithStockLevelEvaluator(
+	evaluator StockLevelEvaluator,
+) *InventoryUsecase {
+	if uc == nil {
+		return uc
+	}
+
+	uc.stockLevelEvaluator = evaluator
+	return uc
+}
+
+// WithAuditRecorder は配送元住所・配送方法の変更を監査ログに記録します。
+func (uc *InventoryUsecase) WithAuditRecorder(
+	recorder AuditRecorder,
+) *InventoryUsecase {
+	if uc == nil {
+		return uc
+	}
+
+	uc.audit = recorder
+	return uc
+}
+
 func (uc *InventoryUsecase) WithShippingAddressAssignment(
 	shippingAddressRepo shadom.RepositoryPort,
 	productBlueprintRepo applicationport.ProductBlueprintGetter,
@@ -163,12 +193,29 @@ func (uc *InventoryUsecase) SetShippingAddress(
 		return err
 	}
 
-	return uc.repo.SetShippingAddressID(
+	if err := uc.repo.SetShippingAddressID(
 		ctx,
 		invID,
 		shippingAddressIDValue,
 		time.Now().UTC(),
-	)
+	); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, RecordAuditInput{
+		CompanyID:  companyIDValue,
+		EntityType: auditdom.EntityInventory,
+		EntityID:   invID,
+		Action:     auditdom.ActionUpdate,
+		Before: map[string]any{
+			"shippingAddressId": inventory.ShippingAddressID,
+		},
+		After: map[string]any{
+			"shippingAddressId": shippingAddressIDValue,
+		},
+	})
+
+	return nil
 }
 
 // ============================================================
@@ -294,13 +341,32 @@ func (uc *InventoryUsecase) SetTransportation(
 		}
 	}
 
-	return uc.repo.SetTransportation(
+	if err := uc.repo.SetTransportation(
 		ctx,
 		invID,
 		option,
 		transportationIDValue,
 		time.Now().UTC(),
-	)
+	); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, RecordAuditInput{
+		CompanyID:  companyIDValue,
+		EntityType: auditdom.EntityInventory,
+		EntityID:   invID,
+		Action:     auditdom.ActionUpdate,
+		Before: map[string]any{
+			"transportationOption": inventory.TransportationOption,
+			"transportationId":     inventory.TransportationID,
+		},
+		After: map[string]any{
+			"transportationOption": option,
+			"transportationId":     transportationIDValue,
+		},
+	})
+
+	return nil
 }
 
 // ============================================================
@@ -431,7 +497,14 @@ func (uc *InventoryUsecase) UpsertFromMintByModel(
 		}
 	}
 
-	return uc.repo.UpsertByModelAndToken(ctx, tbID, pbID, mID, productIDs)
+	inv, err := uc.repo.UpsertByModelAndToken(ctx, tbID, pbID, mID, productIDs)
+	if err != nil {
+		return invdom.Mint{}, err
+	}
+
+	EvaluateStockLevelBestEffort(ctx, uc.stockLevelEvaluator, inv.ID, mID)
+
+	return inv, nil
 }
 
 // ============================================================
@@ -471,6 +544,8 @@ func (uc *InventoryUsecase) ReserveByOrder(ctx context.Context, orderID string,
 		if err := uc.repo.ReserveByOrder(ctx, invID, mid, oid, qty); err != nil {
 			return err
 		}
+
+		EvaluateStockLevelBestEffort(ctx, uc.stockLevelEvaluator, invID, mid)
 	}
 
 	return nil
@@ -516,7 +591,7 @@ func (uc *InventoryUsecase) ReleaseAfterTransfer(
 		now = time.Now().UTC()
 	}
 
-	_, err := uc.repo.ReleaseReservationAfterTransfer(
+	removed, err := uc.repo.ReleaseReservationAfterTransfer(
 		ctx,
 		invID,
 		mid,
@@ -524,5 +599,13 @@ func (uc *InventoryUsecase) ReleaseAfterTransfer(
 		oid,
 		now,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if removed > 0 {
+		EvaluateStockLevelBestEffort(ctx, uc.stockLevelEvaluator, invID, mid)
+	}
+
+	return nil
 }
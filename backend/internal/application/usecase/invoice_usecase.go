@@ -0,0 +1,399 @@
+// backend/internal/application/usecase/invoice_usecase.go
+package usecase
+
+/*
+責務:
+- 注文の領収書（適格請求書）を発行事業者ごとに組み立てる
+- 領収書 PDF を生成する（mall の注文詳細からのダウンロード / 注文確認メールの添付）
+
+前提:
+- 発行事業者は list item の product blueprint の company。
+  resale item は個人の出品者の取引のため、登録番号のない領収書にまとめる。
+- 取消済みの明細は記載しない。
+- 送料は shipping quote の明細と listId/inventoryId/modelId で突き合わせ、
+  突き合わせられない送料は最初の発行事業者に計上する。
+- 値引きは Order.Discount の明細ごとの値引き額を使う。
+- 領収書は保存せず、注文 snapshot から都度生成する。
+- 未決済の注文の領収書は発行しない。
+- succeeded の返金は、明細を記載した領収書に返還インボイスとして記載する。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	applicationport "narratives/internal/application/port"
+	companydom "narratives/internal/domain/company"
+	invoicedom "narratives/internal/domain/invoice"
+	orderdom "narratives/internal/domain/order"
+	refunddom "narratives/internal/domain/refund"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+type InvoiceCompanyGetter interface {
+	GetByID(ctx context.Context, id string) (companydom.Company, error)
+}
+
+// InvoiceRenderer renders invoices into one PDF document (one page per invoice).
+type InvoiceRenderer interface {
+	RenderInvoices(invoices []invoicedom.Invoice) ([]byte, error)
+}
+
+// InvoiceRefundLister returns the refunds of a payment (payment ID = order ID).
+type InvoiceRefundLister interface {
+	ListByPaymentID(ctx context.Context, paymentID string) ([]refunddom.Refund, error)
+}
+
+var (
+	ErrInvoiceNotConfigured = errors.New(
+		"invoice: usecase is not configured",
+	)
+	ErrInvoiceNothingToIssue = errors.New(
+		"invoice: order has no item to issue",
+	)
+	ErrInvoiceOrderNotPaid = errors.New(
+		"invoice: order is not paid",
+	)
+)
+
+// resaleIssuerName は resale item の領収書の発行者表記です。
+const resaleIssuerName = "マーケット出品者（個人）"
+
+type InvoiceUsecase struct {
+	productBlueprintRepo applicationport.ProductBlueprintGetter
+	companyRepo          InvoiceCompanyGetter
+	renderer             InvoiceRenderer
+	refundLister         InvoiceRefundLister
+
+	now func() time.Time
+}
+
+func NewInvoiceUsecase(
+	productBlueprintRepo applicationport.ProductBlueprintGetter,
+	companyRepo InvoiceCompanyGetter,
+	renderer InvoiceRenderer,
+) *InvoiceUsecase {
+	return &InvoiceUsecase{
+		productBlueprintRepo: productBlueprintRepo,
+		companyRepo:          companyRepo,
+		renderer:             renderer,
+		now:                  time.Now,
+	}
+}
+
+// WithRefundLister は返金の返還インボイスとしての記載を有効にする。
+func (u *InvoiceUsecase) WithRefundLister(
+	lister InvoiceRefundLister,
+) *InvoiceUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.refundLister = lister
+
+	return u
+}
+
+// ============================================================
+// Build
+// ============================================================
+
+type invoiceGroup struct {
+	issuer invoicedom.Issuer
+
+	lines []invoicedom.Line
+
+	shippingAmount         int
+	shippingDiscountAmount int
+
+	refunds []invoicedom.RefundInput
+}
+
+// BuildForOrder は order の領収書を発行事業者ごとに返します。
+func (u *InvoiceUsecase) BuildForOrder(
+	ctx context.Context,
+	order orderdom.Order,
+) ([]invoicedom.Invoice, error) {
+	if u == nil ||
+		u.productBlueprintRepo == nil ||
+		u.companyRepo == nil {
+		return nil, ErrInvoiceNotConfigured
+	}
+
+	if !order.Paid {
+		return nil, ErrInvoiceOrderNotPaid
+	}
+
+	shippingByItem, unmatchedShipping := invoiceShippingAmounts(order)
+
+	groups := make([]*invoiceGroup, 0, 1)
+	groupsByKey := map[string]*invoiceGroup{}
+	groupsByItem := map[int]*invoiceGroup{}
+	productNames := map[string]string{}
+
+	for index, item := range order.Items {
+		if item.IsCancelled {
+			continue
+		}
+
+		pb, err := u.productBlueprintRepo.GetByID(
+			ctx,
+			item.ProductBlueprintID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invoice: resolve productBlueprint %q: %w",
+				item.ProductBlueprintID,
+				err,
+			)
+		}
+		productNames[item.ProductBlueprintID] = strings.TrimSpace(pb.ProductName)
+
+		key := "resale"
+		if item.Type == orderdom.OrderItemTypeList {
+			key = "company:" + strings.TrimSpace(pb.CompanyID)
+		}
+
+		group, ok := groupsByKey[key]
+		if !ok {
+			issuer, err := u.resolveIssuer(ctx, item, pb.CompanyID)
+			if err != nil {
+				return nil, err
+			}
+
+			group = &invoiceGroup{issuer: issuer}
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+
+		description := productNames[item.ProductBlueprintID]
+		if description == "" {
+			description = "商品"
+		}
+
+		group.lines = append(group.lines, invoicedom.Line{
+			ItemIndex:   index,
+			Description: description,
+
+			Qty:       item.Qty,
+			UnitPrice: item.Price,
+
+			Amount:         item.Price * item.Qty,
+			DiscountAmount: order.ItemDiscountAmount(index),
+
+			TaxRate: item.ConsumptionTaxRate,
+		})
+
+		groupsByItem[index] = group
+
+		group.shippingAmount += shippingByItem[index]
+		group.shippingDiscountAmount += order.ItemShippingDiscountAmount(index)
+	}
+
+	if len(groups) == 0 {
+		return nil, ErrInvoiceNothingToIssue
+	}
+
+	groups[0].shippingAmount += unmatchedShipping
+
+	if err := u.attachRefunds(ctx, order, groupsByItem); err != nil {
+		return nil, err
+	}
+
+	issuedAt := u.now().UTC()
+	out := make([]invoicedom.Invoice, 0, len(groups))
+
+	for i, group := range groups {
+		inv, err := invoicedom.Build(invoicedom.BuildInput{
+			Number:  fmt.Sprintf("%s-%02d", order.ID, i+1),
+			OrderID: order.ID,
+
+			Issuer: group.issuer,
+
+			TransactionDate: order.CreatedAt,
+			IssuedAt:        issuedAt,
+
+			Lines: group.lines,
+
+			ShippingAmount:         group.shippingAmount,
+			ShippingDiscountAmount: group.shippingDiscountAmount,
+
+			Refunds: group.refunds,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, inv)
+	}
+
+	return out, nil
+}
+
+// attachRefunds は succeeded の返金を、返金した明細を記載した領収書に振り分けます。
+//
+// 返金の消費税は明細分（明細の税率）と送料分（標準税率）の合計なので、
+// 送料分を返金時と同じ計算で取り出し、残りを明細の税率に計上します。
+func (u *InvoiceUsecase) attachRefunds(
+	ctx context.Context,
+	order orderdom.Order,
+	groupsByItem map[int]*invoiceGroup,
+) error {
+	if u.refundLister == nil {
+		return nil
+	}
+
+	refunds, err := u.refundLister.ListByPaymentID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("invoice: list refunds: %w", err)
+	}
+
+	for _, refund := range refunds {
+		if refund.Status != refunddom.StatusSucceeded {
+			continue
+		}
+
+		inputs := map[*invoiceGroup]*invoicedom.RefundInput{}
+		touched := make([]*invoiceGroup, 0, 1)
+
+		for _, item := range refund.Items {
+			group, ok := groupsByItem[item.ItemIndex]
+			if !ok {
+				continue
+			}
+
+			input, ok := inputs[group]
+			if !ok {
+				input = &invoicedom.RefundInput{
+					RefundID:   refund.ID,
+					RefundedAt: refund.UpdatedAt,
+				}
+				inputs[group] = input
+				touched = append(touched, group)
+			}
+
+			shippingTax := item.ShippingAmount * invoicedom.TaxRateStandard / 100
+			if shippingTax > item.TaxAmount {
+				shippingTax = item.TaxAmount
+			}
+
+			rate := group.lineTaxRate(item.ItemIndex)
+
+			input.Lines = append(input.Lines, invoicedom.RefundLine{
+				TaxRate:       rate,
+				TaxableAmount: item.ItemAmount,
+				TaxAmount:     item.TaxAmount - shippingTax,
+			})
+			if item.ShippingAmount > 0 {
+				input.Lines = append(input.Lines, invoicedom.RefundLine{
+					TaxRate:       invoicedom.TaxRateStandard,
+					TaxableAmount: item.ShippingAmount,
+					TaxAmount:     shippingTax,
+				})
+			}
+		}
+
+		for _, group := range touched {
+			group.refunds = append(group.refunds, *inputs[group])
+		}
+	}
+
+	return nil
+}
+
+func (g *invoiceGroup) lineTaxRate(itemIndex int) int {
+	for _, line := range g.lines {
+		if line.ItemIndex == itemIndex {
+			return line.TaxRate
+		}
+	}
+	return invoicedom.TaxRateStandard
+}
+
+// RenderPDF は order の領収書 PDF を返します。
+func (u *InvoiceUsecase) RenderPDF(
+	ctx context.Context,
+	order orderdom.Order,
+) ([]byte, error) {
+	if u == nil || u.renderer == nil {
+		return nil, ErrInvoiceNotConfigured
+	}
+
+	invoices, err := u.BuildForOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.renderer.RenderInvoices(invoices)
+}
+
+func (u *InvoiceUsecase) resolveIssuer(
+	ctx context.Context,
+	item orderdom.OrderItemSnapshot,
+	companyID string,
+) (invoicedom.Issuer, error) {
+	if item.Type != orderdom.OrderItemTypeList {
+		return invoicedom.Issuer{Name: resaleIssuerName}, nil
+	}
+
+	companyID = strings.TrimSpace(companyID)
+
+	company, err := u.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return invoicedom.Issuer{}, fmt.Errorf(
+			"invoice: resolve company %q: %w",
+			companyID,
+			err,
+		)
+	}
+
+	return invoicedom.Issuer{
+		CompanyID:          company.ID,
+		Name:               company.Name,
+		RegistrationNumber: company.InvoiceRegistrationNumber,
+	}, nil
+}
+
+// invoiceShippingAmounts は明細ごとの送料と、どの list item にも
+// 突き合わせられなかった送料の合計を返します。
+func invoiceShippingAmounts(order orderdom.Order) (map[int]int, int) {
+	used := make([]bool, len(order.ShippingQuoteSnapshot.Items))
+	result := make(map[int]int, len(order.Items))
+	matched := 0
+
+	for index, item := range order.Items {
+		if item.Type != orderdom.OrderItemTypeList {
+			continue
+		}
+
+		for quoteIndex, quote := range order.ShippingQuoteSnapshot.Items {
+			if used[quoteIndex] {
+				continue
+			}
+
+			if quote.ListID != item.ListID ||
+				quote.InventoryID != item.InventoryID ||
+				quote.ModelID != item.ModelID {
+				continue
+			}
+
+			used[quoteIndex] = true
+			result[index] = quote.Amount
+			matched += quote.Amount
+			break
+		}
+	}
+
+	unmatched := order.ShippingQuoteSnapshot.Amount - matched
+	if unmatched < 0 {
+		unmatched = 0
+	}
+
+	return result, unmatched
+}
@@ -0,0 +1,136 @@
+// backend/internal/application/usecase/list_access_usecase.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	listdom "narratives/internal/domain/list"
+	walletdom "narratives/internal/domain/wallet"
+)
+
+// ListHoldingReader は avatar の on-chain 保有 token の明細を返します。
+// WalletUsecase.ListHoldings が実装します。
+type ListHoldingReader interface {
+	ListHoldings(ctx context.Context, avatarID string) ([]WalletHolding, error)
+}
+
+// ListAccessGuard は holder-only list の購入可否を判定します。
+// list をすでに読み込んでいる caller（OrderUsecase）向けです。
+type ListAccessGuard interface {
+	EnsureListAccess(ctx context.Context, avatarID string, list listdom.List) error
+}
+
+// ListAccessGuardByID は listId から holder-only list の購入可否を判定します（CartUsecase 向け）。
+type ListAccessGuardByID interface {
+	EnsureListAccessByID(ctx context.Context, avatarID string, listID string) error
+}
+
+var ErrListAccessNotConfigured = errors.New("list access usecase: not configured")
+
+// ListAccessUsecase は list の token gating（holder-only）を判定します.
+//
+// gating rule の無い list は常に購入できます。gating rule のある list は
+// avatar の on-chain 保有 token がいずれかの rule を満たす場合のみ購入できます。
+type ListAccessUsecase struct {
+	listRepo listdom.Repository
+	holdings ListHoldingReader
+}
+
+func NewListAccessUsecase(
+	listRepo listdom.Repository,
+	holdings ListHoldingReader,
+) *ListAccessUsecase {
+	return &ListAccessUsecase{
+		listRepo: listRepo,
+		holdings: holdings,
+	}
+}
+
+var (
+	_ ListAccessGuard     = (*ListAccessUsecase)(nil)
+	_ ListAccessGuardByID = (*ListAccessUsecase)(nil)
+)
+
+// Evaluate は avatar の保有状況で list の gating rule を判定します。
+//
+// avatarID が空の場合（未ログイン）や wallet が未作成の場合は保有 0 として判定します。
+func (uc *ListAccessUsecase) Evaluate(
+	ctx context.Context,
+	avatarID string,
+	list listdom.List,
+) (listdom.GatingResult, error) {
+	if !list.IsHolderOnly() {
+		return listdom.EvaluateGating(nil, nil), nil
+	}
+	if uc == nil || uc.holdings == nil {
+		return listdom.GatingResult{}, ErrListAccessNotConfigured
+	}
+
+	if avatarID == "" {
+		return listdom.EvaluateGating(list.Gating, nil), nil
+	}
+
+	walletHoldings, err := uc.holdings.ListHoldings(ctx, avatarID)
+	if err != nil {
+		if errors.Is(err, walletdom.ErrNotFound) ||
+			errors.Is(err, ErrWalletSyncWalletAddressEmpty) {
+			return listdom.EvaluateGating(list.Gating, nil), nil
+		}
+		return listdom.GatingResult{}, err
+	}
+
+	holdings := make([]listdom.Holding, 0, len(walletHoldings))
+	for _, h := range walletHoldings {
+		holdings = append(holdings, listdom.Holding{
+			TokenBlueprintID:   h.TokenBlueprintID,
+			ProductBlueprintID: h.ProductBlueprintID,
+			AcquiredAt:         h.AcquiredAt,
+		})
+	}
+
+	return listdom.EvaluateGating(list.Gating, holdings), nil
+}
+
+// EnsureListAccess は holder でない場合 listdom.ErrHolderOnly を返します。
+func (uc *ListAccessUsecase) EnsureListAccess(
+	ctx context.Context,
+	avatarID string,
+	list listdom.List,
+) error {
+	if !list.IsHolderOnly() {
+		return nil
+	}
+
+	result, err := uc.Evaluate(ctx, avatarID, list)
+	if err != nil {
+		return err
+	}
+	if !result.Eligible {
+		return fmt.Errorf("%w: listId=%s", listdom.ErrHolderOnly, list.ID)
+	}
+
+	return nil
+}
+
+// EnsureListAccessByID は listId の list を読み込んで EnsureListAccess を行います。
+func (uc *ListAccessUsecase) EnsureListAccessByID(
+	ctx context.Context,
+	avatarID string,
+	listID string,
+) error {
+	if uc == nil || uc.listRepo == nil {
+		return ErrListAccessNotConfigured
+	}
+	if listID == "" {
+		return listdom.ErrInvalidID
+	}
+
+	list, err := uc.listRepo.GetByID(ctx, listID)
+	if err != nil {
+		return err
+	}
+
+	return uc.EnsureListAccess(ctx, avatarID, list)
+}
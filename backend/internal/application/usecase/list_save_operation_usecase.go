@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	auditdom "narratives/internal/domain/audit"
 	listdom "narratives/internal/domain/list"
 	"strings"
 	"time"
@@ -27,6 +28,7 @@ type ListSaveOperationUsecase struct {
 	retryQueue       ListSaveOperationRetryQueue
 	now              func() time.Time
 	isRetryableError func(error) bool
+	audit            AuditRecorder
 }
 type NewListSaveOperationUsecaseParams struct {
 	ListRepository      listdom.Repository
@@ -70,6 +72,15 @@ func NewListSaveOperationUsecase(p NewListSaveOperationUsecaseParams) *ListSaveO
 		isRetryableError: isRetryableError,
 	}
 }
+
+// WithAuditRecorder は保存操作による出品と出品画像の作成・更新・削除を監査ログに記録します。
+func (uc *ListSaveOperationUsecase) WithAuditRecorder(recorder AuditRecorder) *ListSaveOperationUsecase {
+	if uc == nil {
+		return uc
+	}
+	uc.audit = recorder
+	return uc
+}
 func (uc *ListSaveOperationUsecase) Start(ctx context.Context, input StartListSaveOperationInput) (listdom.SaveOperation, error) {
 	if err := uc.validateDependencies(); err != nil {
 		return listdom.SaveOperation{}, err
@@ -272,10 +283,11 @@ func (uc *ListSaveOperationUsecase) executeLoaded(ctx context.Context, operation
 			}
 		case listdom.SaveOperationStatusUpdatingList:
 			if !operation.Progress.ListUpdated {
-				if err := uc.applyTargetList(ctx, operation); err != nil {
+				saved, created, err := uc.applyTargetList(ctx, operation)
+				if err != nil {
 					return uc.failExecution(ctx, operation, err)
 				}
-				var err error
+				uc.recordListAudit(ctx, operation, saved, created)
 				operation, err = uc.persistMutation(ctx, operation, func(value *listdom.SaveOperation) error {
 					return value.MarkListUpdated(uc.currentTime())
 				})
@@ -350,7 +362,7 @@ func (uc *ListSaveOperationUsecase) registerImages(ctx context.Context, operatio
 		if operation.IsImageRegistered(image.ImageID) {
 			continue
 		}
-		_, err := uc.imageRepo.Create(ctx, listdom.ListImage{
+		created, err := uc.imageRepo.Create(ctx, listdom.ListImage{
 			ID:           image.ImageID,
 			ListID:       operation.ListID,
 			URL:          image.URL,
@@ -361,6 +373,12 @@ func (uc *ListSaveOperationUsecase) registerImages(ctx context.Context, operatio
 		if err != nil {
 			return uc.failExecution(ctx, operation, err)
 		}
+		recordAudit(ctx, uc.audit, RecordAuditInput{
+			EntityType: auditdom.EntityListImage,
+			EntityID:   created.ID,
+			Action:     auditdom.ActionCreate,
+			After:      created,
+		})
 		operation, err = uc.persistMutation(ctx, operation, func(value *listdom.SaveOperation) error {
 			return value.MarkImageRegistered(image.ImageID, uc.currentTime())
 		})
@@ -379,6 +397,14 @@ func (uc *ListSaveOperationUsecase) deleteImages(ctx context.Context, operation
 		if err != nil && !errors.Is(err, listdom.ErrNotFound) {
 			return uc.failExecution(ctx, operation, err)
 		}
+		if err == nil {
+			recordAudit(ctx, uc.audit, RecordAuditInput{
+				EntityType: auditdom.EntityListImage,
+				EntityID:   imageID,
+				Action:     auditdom.ActionDelete,
+				Before:     previousSaveOperationImage(operation, imageID),
+			})
+		}
 		operation, err = uc.persistMutation(ctx, operation, func(value *listdom.SaveOperation) error {
 			return value.MarkImageDeleted(imageID, uc.currentTime())
 		})
@@ -388,43 +414,43 @@ func (uc *ListSaveOperationUsecase) deleteImages(ctx context.Context, operation
 	}
 	return operation, nil
 }
-func (uc *ListSaveOperationUsecase) applyTargetList(ctx context.Context, operation listdom.SaveOperation) error {
+func (uc *ListSaveOperationUsecase) applyTargetList(ctx context.Context, operation listdom.SaveOperation) (listdom.List, bool, error) {
 	target := operation.Payload.TargetList
 	target.ID = operation.ListID
 	target.ImageID = operation.Payload.PreviousPrimaryImageID
 	now := uc.currentTime()
 	target.UpdatedAt = &now
 	if operation.Type == listdom.SaveOperationTypeUpdate {
-		_, err := uc.listRepo.Update(ctx, operation.ListID, target)
-		return err
+		saved, err := uc.listRepo.Update(ctx, operation.ListID, target)
+		return saved, false, err
 	}
 	existing, err := uc.listRepo.GetByID(ctx, operation.ListID)
 	if err == nil {
 		if !sameCreatedListIdentity(existing, target) {
-			return listdom.ErrConflict
+			return listdom.List{}, false, listdom.ErrConflict
 		}
-		_, err = uc.listRepo.Update(ctx, operation.ListID, target)
-		return err
+		saved, err := uc.listRepo.Update(ctx, operation.ListID, target)
+		return saved, false, err
 	}
 	if !errors.Is(err, listdom.ErrNotFound) {
-		return err
+		return listdom.List{}, false, err
 	}
-	_, err = uc.listRepo.Create(ctx, target)
+	saved, err := uc.listRepo.Create(ctx, target)
 	if err == nil {
-		return nil
+		return saved, true, nil
 	}
 	if !errors.Is(err, listdom.ErrConflict) {
-		return err
+		return listdom.List{}, false, err
 	}
 	existing, getErr := uc.listRepo.GetByID(ctx, operation.ListID)
 	if getErr != nil {
-		return err
+		return listdom.List{}, false, err
 	}
 	if !sameCreatedListIdentity(existing, target) {
-		return listdom.ErrConflict
+		return listdom.List{}, false, listdom.ErrConflict
 	}
-	_, updateErr := uc.listRepo.Update(ctx, operation.ListID, target)
-	return updateErr
+	saved, updateErr := uc.listRepo.Update(ctx, operation.ListID, target)
+	return saved, false, updateErr
 }
 func (uc *ListSaveOperationUsecase) applyPrimaryImage(ctx context.Context, operation listdom.SaveOperation) error {
 	primaryImageID := strings.TrimSpace(operation.Payload.PrimaryImageID)
@@ -441,6 +467,7 @@ func (uc *ListSaveOperationUsecase) applyPrimaryImage(ctx context.Context, opera
 	if err != nil {
 		return err
 	}
+	before := item
 	item.ImageID = primaryImageID
 	now := uc.currentTime()
 	item.UpdatedAt = &now
@@ -450,8 +477,45 @@ func (uc *ListSaveOperationUsecase) applyPrimaryImage(ctx context.Context, opera
 			item.UpdatedBy = &updatedBy
 		}
 	}
-	_, err = uc.listRepo.Update(ctx, operation.ListID, item)
-	return err
+	updated, err := uc.listRepo.Update(ctx, operation.ListID, item)
+	if err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.audit, RecordAuditInput{
+		EntityType: auditdom.EntityList,
+		EntityID:   operation.ListID,
+		Action:     auditdom.ActionUpdate,
+		Before:     before,
+		After:      updated,
+	})
+	return nil
+}
+
+// recordListAudit は対象の出品の保存を監査ログに記録します。
+// 作成の操作でも、再試行で既存の出品を更新した場合は更新として記録します。
+func (uc *ListSaveOperationUsecase) recordListAudit(ctx context.Context, operation listdom.SaveOperation, saved listdom.List, created bool) {
+	in := RecordAuditInput{
+		EntityType: auditdom.EntityList,
+		EntityID:   operation.ListID,
+		Action:     auditdom.ActionUpdate,
+		After:      saved,
+	}
+	if created {
+		in.Action = auditdom.ActionCreate
+	} else if operation.Payload.PreviousList != nil {
+		in.Before = *operation.Payload.PreviousList
+	}
+	recordAudit(ctx, uc.audit, in)
+}
+
+// previousSaveOperationImage は操作開始時の画像を返します。見つからない場合は nil です。
+func previousSaveOperationImage(operation listdom.SaveOperation, imageID string) any {
+	for _, image := range operation.Payload.PreviousImages {
+		if image.ID == imageID {
+			return image
+		}
+	}
+	return nil
 }
 func (uc *ListSaveOperationUsecase) compensateLoaded(ctx context.Context, operation listdom.SaveOperation) (listdom.SaveOperation, error) {
 	if operation.Status == listdom.SaveOperationStatusCompensated {
@@ -589,6 +653,7 @@ func (uc *ListSaveOperationUsecase) persistMutation(ctx context.Context, operati
 func (uc *ListSaveOperationUsecase) preparePayloadState(ctx context.Context, input StartListSaveOperationInput, listID string, now time.Time) (listdom.List, *listdom.List, []listdom.ListImage, string, error) {
 	target := input.TargetList
 	target.ID = listID
+	target.Gating = listdom.NormalizeGatingRules(target.Gating)
 	switch input.Type {
 	case listdom.SaveOperationTypeCreate:
 		if target.CreatedAt.IsZero() {
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	auditdom "narratives/internal/domain/audit"
 	listdom "narratives/internal/domain/list"
 )
 
@@ -21,6 +22,7 @@ type ListUsecase struct {
 	listRepo  listdom.Repository
 	imageRepo listdom.ImageRepository
 	storage   ListAssetStorage
+	audit     AuditRecorder
 }
 
 func NewListUsecase(
@@ -35,6 +37,16 @@ func NewListUsecase(
 	}
 }
 
+// WithAuditRecorder は出品の作成・更新・削除を監査ログに記録します。
+func (uc *ListUsecase) WithAuditRecorder(recorder AuditRecorder) *ListUsecase {
+	if uc == nil {
+		return uc
+	}
+
+	uc.audit = recorder
+	return uc
+}
+
 func generateReadableID(listID string, createdAt time.Time) string {
 	t := createdAt
 	if t.IsZero() {
@@ -65,6 +77,8 @@ func (uc *ListUsecase) Create(
 		return listdom.List{}, ErrNotSupported("List.Create")
 	}
 
+	item.Gating = listdom.NormalizeGatingRules(item.Gating)
+
 	created, err := uc.listRepo.Create(ctx, item)
 	if err != nil {
 		return listdom.List{}, err
@@ -83,6 +97,14 @@ func (uc *ListUsecase) Create(
 		}
 	}
 
+	recordAudit(ctx, uc.audit, RecordAuditInput{
+		CompanyID:  companyID,
+		EntityType: auditdom.EntityList,
+		EntityID:   created.ID,
+		Action:     auditdom.ActionCreate,
+		After:      created,
+	})
+
 	return created, nil
 }
 
@@ -101,7 +123,25 @@ func (uc *ListUsecase) Update(
 	}
 
 	item.ID = id
-	return uc.listRepo.Update(ctx, id, item)
+	item.Gating = listdom.NormalizeGatingRules(item.Gating)
+
+	before := uc.auditSnapshot(ctx, id)
+
+	updated, err := uc.listRepo.Update(ctx, id, item)
+	if err != nil {
+		return updated, err
+	}
+
+	recordAudit(ctx, uc.audit, RecordAuditInput{
+		CompanyID:  companyID,
+		EntityType: auditdom.EntityList,
+		EntityID:   id,
+		Action:     auditdom.ActionUpdate,
+		Before:     before,
+		After:      updated,
+	})
+
+	return updated, nil
 }
 
 func (uc *ListUsecase) Delete(
@@ -120,11 +160,42 @@ func (uc *ListUsecase) Delete(
 		return ErrNotSupported("List.Delete.Storage")
 	}
 
+	before := uc.auditSnapshot(ctx, id)
+
 	if err := uc.storage.DeleteAll(ctx, id); err != nil {
 		return err
 	}
 
-	return uc.listRepo.Delete(ctx, id)
+	if err := uc.listRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, RecordAuditInput{
+		EntityType: auditdom.EntityList,
+		EntityID:   id,
+		Action:     auditdom.ActionDelete,
+		Before:     before,
+	})
+
+	return nil
+}
+
+// auditSnapshot は監査ログ用に変更前の出品を返します。
+// 監査ログが無効な場合や取得できない場合は nil です。
+func (uc *ListUsecase) auditSnapshot(
+	ctx context.Context,
+	id string,
+) any {
+	if uc.audit == nil {
+		return nil
+	}
+
+	l, err := uc.listRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+
+	return l
 }
 
 func (uc *ListUsecase) CreateImage(
@@ -170,6 +241,13 @@ func (uc *ListUsecase) CreateImage(
 		return listdom.ListImage{}, err
 	}
 
+	recordAudit(ctx, uc.audit, RecordAuditInput{
+		EntityType: auditdom.EntityListImage,
+		EntityID:   created.ID,
+		Action:     auditdom.ActionCreate,
+		After:      created,
+	})
+
 	return created, nil
 }
 
@@ -198,19 +276,44 @@ func (uc *ListUsecase) DeleteImage(
 		return ErrInvalidArgument("invalid_image_id")
 	}
 
+	var before any
+	if uc.audit != nil {
+		if img, err := uc.imageRepo.GetByID(ctx, listID, imageID); err == nil {
+			before = img
+		}
+	}
+
 	if err := uc.imageRepo.Delete(ctx, listID, imageID); err != nil {
 		if !errors.Is(err, listdom.ErrNotFound) {
 			return err
 		}
 	}
 
+	if before != nil {
+		recordAudit(ctx, uc.audit, RecordAuditInput{
+			EntityType: auditdom.EntityListImage,
+			EntityID:   imageID,
+			Action:     auditdom.ActionDelete,
+			Before:     before,
+		})
+	}
+
 	if uc.listRepo != nil {
 		l, err := uc.listRepo.GetByID(ctx, listID)
 		if err == nil && l.ImageID == imageID {
+			prev := l
 			now := time.Now().UTC()
 			l.ImageID = ""
 			l.UpdatedAt = &now
-			_, _ = uc.listRepo.Update(ctx, listID, l)
+			if updated, err := uc.listRepo.Update(ctx, listID, l); err == nil {
+				recordAudit(ctx, uc.audit, RecordAuditInput{
+					EntityType: auditdom.EntityList,
+					EntityID:   listID,
+					Action:     auditdom.ActionUpdate,
+					Before:     prev,
+					After:      updated,
+				})
+			}
 		}
 	}
 
@@ -294,6 +397,8 @@ func (uc *ListUsecase) SetPrimaryImage(
 		return listdom.List{}, err
 	}
 
+	before := l
+
 	updatedAt := now.UTC()
 	l.ImageID = iid
 	l.UpdatedAt = &updatedAt
@@ -307,5 +412,13 @@ func (uc *ListUsecase) SetPrimaryImage(
 		return listdom.List{}, err
 	}
 
+	recordAudit(ctx, uc.audit, RecordAuditInput{
+		EntityType: auditdom.EntityList,
+		EntityID:   lid,
+		Action:     auditdom.ActionUpdate,
+		Before:     before,
+		After:      updated,
+	})
+
 	return updated, nil
 }
@@ -5,7 +5,9 @@ import (
 	"context"
 	"time"
 
+	auditdom "narratives/internal/domain/audit"
 	memdom "narratives/internal/domain/member"
+	permissiondom "narratives/internal/domain/permission"
 )
 
 // -----------------------------------------------------------------------------
@@ -22,8 +24,9 @@ type MemberRecord struct {
 // -----------------------------------------------------------------------------
 
 type MemberUsecase struct {
-	repo memdom.Repository
-	now  func() time.Time
+	repo  memdom.Repository
+	audit AuditRecorder
+	now   func() time.Time
 }
 
 func NewMemberUsecase(
@@ -35,6 +38,16 @@ func NewMemberUsecase(
 	}
 }
 
+// WithAuditRecorder はメンバーの作成・更新・削除を監査ログに記録します。
+func (u *MemberUsecase) WithAuditRecorder(recorder AuditRecorder) *MemberUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.audit = recorder
+	return u
+}
+
 // -----------------------------------------------------------------------------
 // Commands
 // -----------------------------------------------------------------------------
@@ -75,6 +88,10 @@ func (u *MemberUsecase) Create(
 		companyID = cid
 	}
 
+	if err := ensureNoNewSystemPermissions(in.Permissions, nil); err != nil {
+		return MemberRecord{}, err
+	}
+
 	m := memdom.Member{
 		UID:            in.UID,
 		FirstName:      in.FirstName,
@@ -95,6 +112,14 @@ func (u *MemberUsecase) Create(
 		return MemberRecord{}, err
 	}
 
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		CompanyID:  rec.Member.CompanyID,
+		EntityType: auditdom.EntityMember,
+		EntityID:   rec.DocID,
+		Action:     auditdom.ActionCreate,
+		After:      rec.Member,
+	})
+
 	return MemberRecord{
 		DocID:  rec.DocID,
 		Member: rec.Member,
@@ -144,6 +169,12 @@ func (u *MemberUsecase) Update(
 		return MemberRecord{}, memdom.ErrNotFound
 	}
 
+	if in.Permissions != nil {
+		if err := ensureNoNewSystemPermissions(*in.Permissions, current.Member.Permissions); err != nil {
+			return MemberRecord{}, err
+		}
+	}
+
 	patch := memdom.MemberPatch{
 		FirstName:      in.FirstName,
 		LastName:       in.LastName,
@@ -163,12 +194,41 @@ func (u *MemberUsecase) Update(
 		return MemberRecord{}, err
 	}
 
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		CompanyID:  companyID,
+		EntityType: auditdom.EntityMember,
+		EntityID:   memberID,
+		Action:     auditdom.ActionUpdate,
+		Before:     current.Member,
+		After:      rec.Member,
+	})
+
 	return MemberRecord{
 		DocID:  rec.DocID,
 		Member: rec.Member,
 	}, nil
 }
 
+// ensureNoNewSystemPermissions は console から system.*（運営向け）を新たに付与させない。
+// 運営が直接付与済みのものは、そのまま残す更新を許可する。
+func ensureNoNewSystemPermissions(names []string, current []string) error {
+	granted := make(map[string]struct{}, len(current))
+	for _, n := range current {
+		granted[n] = struct{}{}
+	}
+
+	for _, n := range names {
+		if !permissiondom.IsSystemPermission(n) {
+			continue
+		}
+		if _, ok := granted[n]; !ok {
+			return permissiondom.ErrSystemPermission
+		}
+	}
+
+	return nil
+}
+
 type GetCurrentMemberInput struct {
 	FirebaseUID string
 }
@@ -201,5 +261,23 @@ func (u *MemberUsecase) Delete(
 		return memdom.ErrNotFound
 	}
 
-	return u.repo.Delete(ctx, memberID)
+	var before any
+	if u.audit != nil {
+		if current, err := u.repo.GetByID(ctx, memberID); err == nil {
+			before = current.Member
+		}
+	}
+
+	if err := u.repo.Delete(ctx, memberID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		EntityType: auditdom.EntityMember,
+		EntityID:   memberID,
+		Action:     auditdom.ActionDelete,
+		Before:     before,
+	})
+
+	return nil
 }
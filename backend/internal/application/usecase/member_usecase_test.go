@@ -0,0 +1,91 @@
+// backend/internal/application/usecase/member_usecase_test.go
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	usecase "narratives/internal/application/usecase"
+	memdom "narratives/internal/domain/member"
+	permissiondom "narratives/internal/domain/permission"
+)
+
+// console からのメンバー更新では system.* を新たに付与できない。
+func TestMember_UpdateRejectsNewSystemPermissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		next    []string
+		want    error
+	}{
+		{
+			name: "tenant permissions",
+			next: []string{permissiondom.NameOrderDispatch},
+		},
+		{
+			name: "new system permission",
+			next: []string{permissiondom.NameOrderDispatch, permissiondom.NameSystemBillingUpdate},
+			want: permissiondom.ErrSystemPermission,
+		},
+		{
+			name:    "system permission already granted by the platform",
+			current: []string{permissiondom.NameSystemBillingUpdate},
+			next:    []string{permissiondom.NameOrderDispatch, permissiondom.NameSystemBillingUpdate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := usecase.WithCompanyID(context.Background(), "company_1")
+
+			repo := &memberRepoStub{
+				rec: memdom.Record{
+					DocID:  "member_1",
+					Member: memdom.Member{CompanyID: "company_1", Permissions: tt.current},
+				},
+			}
+
+			next := tt.next
+			_, err := usecase.NewMemberUsecase(repo).Update(ctx, usecase.UpdateMemberInput{
+				MemberID:    "member_1",
+				Permissions: &next,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Update error = %v, want %v", err, tt.want)
+			}
+			if got := repo.updated; got != (tt.want == nil) {
+				t.Fatalf("updated = %v, want %v", got, tt.want == nil)
+			}
+		})
+	}
+}
+
+// memberRepoStub は Update が使う GetByID / Update だけを実装する。
+type memberRepoStub struct {
+	memdom.Repository
+
+	rec     memdom.Record
+	updated bool
+}
+
+func (r *memberRepoStub) GetByID(_ context.Context, id string) (memdom.Record, error) {
+	if id != r.rec.DocID {
+		return memdom.Record{}, memdom.ErrNotFound
+	}
+
+	return r.rec, nil
+}
+
+func (r *memberRepoStub) Update(
+	_ context.Context,
+	id string,
+	patch memdom.MemberPatch,
+) (memdom.Record, error) {
+	r.updated = true
+	if patch.Permissions != nil {
+		r.rec.Member.Permissions = *patch.Permissions
+	}
+
+	return r.rec, nil
+}
@@ -5,11 +5,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	invdom "narratives/internal/domain/inventory"
 	mintdom "narratives/internal/domain/mint"
+	outboxdom "narratives/internal/domain/outbox"
 	tokendom "narratives/internal/domain/token"
 	tbdom "narratives/internal/domain/tokenBlueprint"
 )
@@ -44,6 +46,9 @@ type MintRequestForUsecase struct {
 	BlueprintSymbol string
 
 	MetadataURI string
+
+	// SellerFeeBasisPoints は TokenBlueprint.Royalty.BasisPoints。
+	SellerFeeBasisPoints int
 }
 
 // MintRequestPort は、MintUsecase から見た「ミント対象 MintRequest」の
@@ -105,6 +110,15 @@ type TokenBlueprintMintMarker interface {
 	) (*tbdom.TokenBlueprint, error)
 }
 
+// ============================================================
+// Billing dependency
+// ============================================================
+
+// MintChargeRecorder は、ミント申請の手数料を計上するためのポートです（BillingUsecase）。
+type MintChargeRecorder interface {
+	RecordMintCharge(ctx context.Context, m mintdom.Mint) error
+}
+
 // ============================================================
 // Inventory dependency
 // ============================================================
@@ -184,6 +198,10 @@ type MintUsecase struct {
 
 	tbMetadataEnsurer TokenBlueprintMetadataEnsurer
 	tbMintMarker      TokenBlueprintMintMarker
+
+	mintChargeRecorder MintChargeRecorder
+
+	outbox OutboxPublisher
 }
 
 func NewMintUsecase(
@@ -277,12 +295,33 @@ func (u *MintUsecase) SetTokenBlueprintMintMarker(
 	u.tbMintMarker = marker
 }
 
+// SetOutbox は MintCompleted を Mint の保存直後に配信します。
+// tokenBlueprint の minted 反映は MintCompleted のハンドラ（HandleOutboxEvent）で行います。
+func (u *MintUsecase) SetOutbox(publisher OutboxPublisher) {
+	if u == nil {
+		return
+	}
+
+	u.outbox = publisher
+}
+
+func (u *MintUsecase) SetMintChargeRecorder(
+	recorder MintChargeRecorder,
+) {
+	if u == nil {
+		return
+	}
+
+	u.mintChargeRecorder = recorder
+}
+
 // UpdateRequestInfo は mint request を起票し、productId 単位の mint task を作成します.
 //
 // 処理:
 // - mint request 作成
 // - productId 単位の MintProductTask を作成
 // - 最初の worker task を enqueue
+// - ミント手数料を計上（best-effort）
 // - HTTP には即時返却
 func (u *MintUsecase) UpdateRequestInfo(
 	ctx context.Context,
@@ -402,6 +441,12 @@ func (u *MintUsecase) UpdateRequestInfo(
 		}
 	}
 
+	if u.mintChargeRecorder != nil {
+		if err := u.mintChargeRecorder.RecordMintCharge(ctx, mintEntity); err != nil {
+			log.Printf("mint usecase: record mint charge mintId=%q err=%v", pid, err)
+		}
+	}
+
 	// handler 側を 202 Accepted + queued DTO に変更するのが理想です。
 	return nil
 }
@@ -829,6 +874,8 @@ func (u *MintUsecase) ExecuteNextMintTask(
 			BlueprintName:    name,
 			BlueprintSymbol:  symbol,
 			MetadataURI:      metadataURI,
+
+			SellerFeeBasisPoints: req.SellerFeeBasisPoints,
 		},
 	)
 	if err != nil {
@@ -1091,14 +1138,9 @@ func (u *MintUsecase) updateParentAndMaybeEnqueueNext(
 				err,
 			)
 		}
+		mintEnt.ClearEvents()
 
-		if u.tbMintMarker != nil && reqTBID != "" {
-			_, _ = u.tbMintMarker.MarkTokenBlueprintMinted(
-				ctx,
-				reqTBID,
-				actorID,
-			)
-		}
+		publishOutbox(ctx, u.outbox, outboxdom.AggregateMint, mintEnt.ID)
 
 		return nil
 	}
@@ -1210,14 +1252,9 @@ func (u *MintUsecase) finalizeMintIfAllTasksCompleted(
 			err,
 		)
 	}
+	mintEnt.ClearEvents()
 
-	if u.tbMintMarker != nil && reqTBID != "" {
-		_, _ = u.tbMintMarker.MarkTokenBlueprintMinted(
-			ctx,
-			reqTBID,
-			actorID,
-		)
-	}
+	publishOutbox(ctx, u.outbox, outboxdom.AggregateMint, mintEnt.ID)
 
 	return u.mintResultMapper.FromMint(*mintEnt), nil
 }
@@ -1258,3 +1295,46 @@ func isRetryableMintError(err error) bool {
 
 	return false
 }
+
+// ============================================================
+// Outbox handler
+// ============================================================
+
+var _ OutboxEventHandler = (*MintUsecase)(nil)
+
+// HandleOutboxEvent は MintCompleted を受けて tokenBlueprint を minted にします。
+// MarkTokenBlueprintMinted は minted 済みでも成功するため、再配信されても安全です。
+func (u *MintUsecase) HandleOutboxEvent(
+	ctx context.Context,
+	e outboxdom.Event,
+) error {
+	if e.Type != outboxdom.EventMintCompleted {
+		return nil
+	}
+
+	if u == nil || u.tbMintMarker == nil {
+		return nil
+	}
+
+	var payload outboxdom.MintCompletedPayload
+	if err := e.DecodePayload(&payload); err != nil {
+		return err
+	}
+
+	// 実行者が無い mint は minted 化できないため、再試行せずに終える。
+	tbID := strings.TrimSpace(payload.TokenBlueprintID)
+	actorID := strings.TrimSpace(payload.ActorID)
+	if tbID == "" || actorID == "" {
+		return nil
+	}
+
+	if _, err := u.tbMintMarker.MarkTokenBlueprintMinted(
+		ctx,
+		tbID,
+		actorID,
+	); err != nil {
+		return fmt.Errorf("mark tokenBlueprint %q minted: %w", tbID, err)
+	}
+
+	return nil
+}
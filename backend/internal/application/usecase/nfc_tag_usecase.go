@@ -0,0 +1,325 @@
+// backend/internal/application/usecase/nfc_tag_usecase.go
+package usecase
+
+/*
+責務:
+- productIdTag が NFC の ProductBlueprint の製品に NFC タグ（NTAG 424 DNA）を割り当てる。
+  - 割り当て: 印刷（PrintUsecase）時に製品ごとに assigned で作成する。
+  - 鍵の発行: タグ書き込み用に SDM の鍵と NDEF URL テンプレートを返す（console）。
+  - 登録:     書き込んだタグの UID を登録する（provisioned）。
+  - 無効化 / 再発行: 紛失・交換時。再発行すると鍵バージョンが変わる。
+- SUN メッセージを検証し、複製タグ・リプレイを拒否する（mall の真贋確認）。
+
+前提:
+- 鍵はマスター鍵（Secret Manager）から導出し、保存しない。
+  マスター鍵が未設定の場合、割り当て・一覧以外は ErrNFCKeysUnavailable を返す。
+- console の操作は companyId 境界で制限する（他社のタグは not found）。
+*/
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	nfcdom "narratives/internal/domain/nfc"
+)
+
+var (
+	ErrNFCNotConfigured   = errors.New("nfc usecase: not configured")
+	ErrNFCKeysUnavailable = errors.New("nfc usecase: master key is not configured")
+)
+
+type NFCTagUsecase struct {
+	repo nfcdom.RepositoryPort
+
+	masterKey []byte
+	baseURL   string
+
+	now func() time.Time
+}
+
+func NewNFCTagUsecase(repo nfcdom.RepositoryPort) *NFCTagUsecase {
+	return &NFCTagUsecase{
+		repo:    repo,
+		baseURL: publicQRBaseURL,
+		now:     time.Now,
+	}
+}
+
+// WithMasterKey は鍵の導出に使うマスター鍵を設定します。
+func (u *NFCTagUsecase) WithMasterKey(masterKey []byte) *NFCTagUsecase {
+	if u == nil || len(masterKey) < nfcdom.MinMasterKeyLength {
+		return u
+	}
+
+	u.masterKey = append([]byte(nil), masterKey...)
+
+	return u
+}
+
+// ============================================================
+// Assignment (PrintUsecase)
+// ============================================================
+
+// AssignForProduction は productIDs のタグを割り当てます。既に割り当て済みの製品は変更しません。
+func (u *NFCTagUsecase) AssignForProduction(
+	ctx context.Context,
+	productionID string,
+	companyID string,
+	productIDs []string,
+) (int, error) {
+	if u == nil || u.repo == nil {
+		return 0, ErrNFCNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	assigned := 0
+	for _, productID := range productIDs {
+		t, err := nfcdom.NewAssigned(productID, productionID, companyID, now)
+		if err != nil {
+			return assigned, err
+		}
+
+		created, err := u.repo.CreateIfAbsent(ctx, t)
+		if err != nil {
+			return assigned, err
+		}
+		if created {
+			assigned++
+		}
+	}
+
+	return assigned, nil
+}
+
+// ============================================================
+// Console
+// ============================================================
+
+func (u *NFCTagUsecase) ListByProductionID(
+	ctx context.Context,
+	productionID string,
+) ([]nfcdom.Tag, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrNFCNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nil, nfcdom.ErrInvalidCompanyID
+	}
+
+	tags, err := u.repo.ListByProductionID(ctx, productionID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]nfcdom.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t.CompanyID == companyID {
+			out = append(out, t)
+		}
+	}
+
+	return out, nil
+}
+
+// NFCTagKeyMaterial はタグ書き込み用の鍵と NDEF URL テンプレートです。
+type NFCTagKeyMaterial struct {
+	ProductID  string `json:"productId"`
+	KeyVersion int    `json:"keyVersion"`
+
+	// SDMMetaReadKey / SDMFileReadKey（AES-128, hex）
+	MetaReadKey string `json:"metaReadKey"`
+	FileReadKey string `json:"fileReadKey"`
+
+	Template nfcdom.SUNURLTemplate `json:"template"`
+}
+
+// IssueKeys は assigned のタグの鍵を返します（UID 登録後は再発行が必要）。
+func (u *NFCTagUsecase) IssueKeys(
+	ctx context.Context,
+	productID string,
+) (NFCTagKeyMaterial, error) {
+	if u == nil || u.repo == nil {
+		return NFCTagKeyMaterial{}, ErrNFCNotConfigured
+	}
+	if len(u.masterKey) == 0 {
+		return NFCTagKeyMaterial{}, ErrNFCKeysUnavailable
+	}
+
+	t, err := u.getOwned(ctx, productID)
+	if err != nil {
+		return NFCTagKeyMaterial{}, err
+	}
+	if t.Status != nfcdom.StatusAssigned {
+		return NFCTagKeyMaterial{}, nfcdom.ErrInvalidStatus
+	}
+
+	keys, err := nfcdom.DeriveTagKeys(u.masterKey, t.ProductID, t.KeyVersion)
+	if err != nil {
+		return NFCTagKeyMaterial{}, err
+	}
+
+	tpl, err := nfcdom.BuildSUNURLTemplate(u.baseURL, t.ProductID)
+	if err != nil {
+		return NFCTagKeyMaterial{}, err
+	}
+
+	return NFCTagKeyMaterial{
+		ProductID:   t.ProductID,
+		KeyVersion:  t.KeyVersion,
+		MetaReadKey: strings.ToUpper(hex.EncodeToString(keys.MetaReadKey[:])),
+		FileReadKey: strings.ToUpper(hex.EncodeToString(keys.FileReadKey[:])),
+		Template:    tpl,
+	}, nil
+}
+
+// Provision は書き込んだタグの UID を登録します。
+func (u *NFCTagUsecase) Provision(
+	ctx context.Context,
+	productID string,
+	uid string,
+) (nfcdom.Tag, error) {
+	return u.updateOwned(ctx, productID, func(t *nfcdom.Tag, now time.Time) error {
+		return t.Provision(uid, now)
+	})
+}
+
+// Revoke はタグを無効化します。
+func (u *NFCTagUsecase) Revoke(
+	ctx context.Context,
+	productID string,
+) (nfcdom.Tag, error) {
+	return u.updateOwned(ctx, productID, func(t *nfcdom.Tag, now time.Time) error {
+		return t.Revoke(now)
+	})
+}
+
+// Reissue は新しい鍵バージョンでタグを割り当て直します。
+func (u *NFCTagUsecase) Reissue(
+	ctx context.Context,
+	productID string,
+) (nfcdom.Tag, error) {
+	return u.updateOwned(ctx, productID, func(t *nfcdom.Tag, now time.Time) error {
+		return t.Reissue(now)
+	})
+}
+
+func (u *NFCTagUsecase) getOwned(
+	ctx context.Context,
+	productID string,
+) (nfcdom.Tag, error) {
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nfcdom.Tag{}, nfcdom.ErrInvalidCompanyID
+	}
+
+	t, err := u.repo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nfcdom.Tag{}, err
+	}
+	if t.CompanyID != companyID {
+		return nfcdom.Tag{}, nfcdom.ErrNotFound
+	}
+
+	return t, nil
+}
+
+func (u *NFCTagUsecase) updateOwned(
+	ctx context.Context,
+	productID string,
+	fn func(t *nfcdom.Tag, now time.Time) error,
+) (nfcdom.Tag, error) {
+	if u == nil || u.repo == nil {
+		return nfcdom.Tag{}, ErrNFCNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nfcdom.Tag{}, nfcdom.ErrInvalidCompanyID
+	}
+
+	now := u.now().UTC()
+
+	return u.repo.Update(ctx, productID, func(t *nfcdom.Tag) error {
+		if t.CompanyID != companyID {
+			return nfcdom.ErrNotFound
+		}
+		return fn(t, now)
+	})
+}
+
+// ============================================================
+// Verification (mall)
+// ============================================================
+
+// RequiresSUN は product に登録済み（provisioned）の NFC タグがあるかを返します。
+// 登録済みのタグがある製品は、SUN メッセージなしの真贋確認を受け付けません。
+func (u *NFCTagUsecase) RequiresSUN(
+	ctx context.Context,
+	productID string,
+) (bool, error) {
+	if u == nil || u.repo == nil {
+		return false, ErrNFCNotConfigured
+	}
+
+	t, err := u.repo.GetByProductID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, nfcdom.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return t.Status == nfcdom.StatusProvisioned, nil
+}
+
+// VerifySUN は SUN メッセージを検証し、カウンタを進めます。
+//
+// MAC 不一致（ErrInvalidSUN）・UID 不一致・カウンタの再利用・未登録のタグはエラーです。
+// 呼び出し側はエラーの場合に真贋確認の結果を返さないでください。
+func (u *NFCTagUsecase) VerifySUN(
+	ctx context.Context,
+	productID string,
+	msg nfcdom.SUNMessage,
+) (nfcdom.Tag, error) {
+	if u == nil || u.repo == nil {
+		return nfcdom.Tag{}, ErrNFCNotConfigured
+	}
+	if len(u.masterKey) == 0 {
+		return nfcdom.Tag{}, ErrNFCKeysUnavailable
+	}
+
+	t, err := u.repo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nfcdom.Tag{}, err
+	}
+	if t.Status != nfcdom.StatusProvisioned {
+		return nfcdom.Tag{}, nfcdom.ErrNotProvisioned
+	}
+
+	keys, err := nfcdom.DeriveTagKeys(u.masterKey, t.ProductID, t.KeyVersion)
+	if err != nil {
+		return nfcdom.Tag{}, err
+	}
+
+	data, err := nfcdom.VerifySUN(keys, msg)
+	if err != nil {
+		return nfcdom.Tag{}, err
+	}
+
+	now := u.now().UTC()
+	keyVersion := t.KeyVersion
+
+	return u.repo.Update(ctx, t.ProductID, func(t *nfcdom.Tag) error {
+		// 検証中に再発行された場合は古い鍵の SUN として扱う
+		if t.KeyVersion != keyVersion {
+			return nfcdom.ErrInvalidSUN
+		}
+		return t.AcceptCounter(data, now)
+	})
+}
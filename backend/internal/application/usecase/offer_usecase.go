@@ -0,0 +1,936 @@
+// backend/internal/application/usecase/offer_usecase.go
+package usecase
+
+/*
+責務:
+- market の resale に対する offer（価格交渉）の作成・返答（accept / counter / decline / withdraw）
+- accept 時の resale の確保（reserved）と、合意価格での注文の検証
+- 決済完了時の offer の完了、期限切れ offer の失効と resale の確保解除
+- 決済されなかった注文（決済失敗・引当の期限切れ・明細キャンセル）の offer の終了と resale の確保解除
+- 交渉の相手側へのメール通知（best-effort）
+
+前提:
+- offer を出せるのは出品者以外の avatar。金額は出品価格未満。
+- 返答できるのは最後に金額を提示していない側。
+- accept されると resale は reserved になり、購入者は checkout 期限までに合意価格で注文する。
+  同じ resale の他の交渉中 offer は decline する。注文済みの offer は決済（発送時）まで失効しない。
+- 注文済みの offer は、その注文の決済失敗・決済キャンセル・引当の期限切れ・明細キャンセルで
+  expired になり、resale は出品中に戻る（CancelCheckout）。
+- 期限切れの処理は ExpireDue（internal endpoint から定期実行）で行う。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	applicationport "narratives/internal/application/port"
+	avatardom "narratives/internal/domain/avatar"
+	offerdom "narratives/internal/domain/offer"
+	orderdom "narratives/internal/domain/order"
+	resaledom "narratives/internal/domain/resale"
+)
+
+const (
+	// DefaultOfferTTL は期限を指定しない場合の返答期限。
+	DefaultOfferTTL = 72 * time.Hour
+
+	// MaxOfferTTL は返答期限の上限。
+	MaxOfferTTL = 7 * 24 * time.Hour
+
+	// DefaultOfferCheckoutTTL は accept 後に合意価格で決済できる期間。
+	DefaultOfferCheckoutTTL = 48 * time.Hour
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+type OfferAvatarGetter interface {
+	GetByID(ctx context.Context, id string) (avatardom.Avatar, error)
+}
+
+// OfferMailEvent は通知の種類です。
+type OfferMailEvent string
+
+const (
+	OfferMailEventPlaced     OfferMailEvent = "placed"
+	OfferMailEventCountered  OfferMailEvent = "countered"
+	OfferMailEventAccepted   OfferMailEvent = "accepted"
+	OfferMailEventDeclined   OfferMailEvent = "declined"
+	OfferMailEventWithdrawn  OfferMailEvent = "withdrawn"
+	OfferMailEventExpired    OfferMailEvent = "expired"
+	OfferMailEventSuperseded OfferMailEvent = "superseded"
+)
+
+type OfferMailMessage struct {
+	IdempotencyKey string
+	ToEmail        string
+
+	// Recipient は受信者が offer のどちら側かです。
+	Recipient offerdom.Party
+	Event     OfferMailEvent
+
+	OfferID     string
+	ResaleID    string
+	ProductName string
+
+	ListPrice int
+	Amount    int
+
+	// ExpiresAt は返答期限（交渉中）または決済期限（accepted）です。
+	ExpiresAt *time.Time
+}
+
+type OfferMailerPort interface {
+	SendOfferNotification(
+		ctx context.Context,
+		message OfferMailMessage,
+	) error
+}
+
+var ErrOfferNotConfigured = errors.New(
+	"offer: usecase is not configured",
+)
+
+type OfferUsecase struct {
+	repo       offerdom.RepositoryPort
+	resaleRepo resaledom.Repository
+
+	avatarRepo     OfferAvatarGetter
+	authUserReader applicationport.AuthUserReader
+	mailer         OfferMailerPort
+
+	checkoutTTL time.Duration
+	now         func() time.Time
+}
+
+var _ OrderOfferCheckout = (*OfferUsecase)(nil)
+
+func NewOfferUsecase(
+	repo offerdom.RepositoryPort,
+	resaleRepo resaledom.Repository,
+) *OfferUsecase {
+	return &OfferUsecase{
+		repo:        repo,
+		resaleRepo:  resaleRepo,
+		checkoutTTL: DefaultOfferCheckoutTTL,
+		now:         time.Now,
+	}
+}
+
+// WithMailer は offer の通知を有効にします。
+// 宛先は avatar の user に紐づくメールアドレスです。
+func (u *OfferUsecase) WithMailer(
+	mailer OfferMailerPort,
+	avatarRepo OfferAvatarGetter,
+	authUserReader applicationport.AuthUserReader,
+) *OfferUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.mailer = mailer
+	u.avatarRepo = avatarRepo
+	u.authUserReader = authUserReader
+
+	return u
+}
+
+func (u *OfferUsecase) WithCheckoutTTL(
+	ttl time.Duration,
+) *OfferUsecase {
+	if u == nil {
+		return u
+	}
+
+	if ttl > 0 {
+		u.checkoutTTL = ttl
+	}
+
+	return u
+}
+
+// ============================================================
+// Queries
+// ============================================================
+
+// GetForAvatar は avatar が当事者の offer を返します。
+func (u *OfferUsecase) GetForAvatar(
+	ctx context.Context,
+	id string,
+	avatarID string,
+) (offerdom.Offer, error) {
+	if u == nil || u.repo == nil {
+		return offerdom.Offer{}, ErrOfferNotConfigured
+	}
+
+	o, err := u.repo.GetByID(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	// 当事者以外には存在を明かさない。
+	if _, err := o.PartyOf(avatarID); err != nil {
+		return offerdom.Offer{}, offerdom.ErrNotFound
+	}
+
+	return o, nil
+}
+
+// ListForAvatar は avatar が購入者（role=buyer）または出品者（role=seller）の offer を返します。
+func (u *OfferUsecase) ListForAvatar(
+	ctx context.Context,
+	avatarID string,
+	role offerdom.Party,
+) ([]offerdom.Offer, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrOfferNotConfigured
+	}
+
+	avatarID = strings.TrimSpace(avatarID)
+	if avatarID == "" {
+		return nil, offerdom.ErrNotParty
+	}
+
+	switch role {
+	case offerdom.PartyBuyer:
+		return u.repo.ListByBuyerAvatarID(ctx, avatarID)
+	case offerdom.PartySeller:
+		return u.repo.ListBySellerAvatarID(ctx, avatarID)
+	default:
+		return nil, offerdom.ErrInvalidParty
+	}
+}
+
+// ============================================================
+// Negotiation
+// ============================================================
+
+type PlaceOfferInput struct {
+	ResaleID      string
+	BuyerAvatarID string
+	Amount        int
+
+	// ExpiresAt は省略可能（DefaultOfferTTL）。
+	ExpiresAt *time.Time
+}
+
+func (u *OfferUsecase) Place(
+	ctx context.Context,
+	in PlaceOfferInput,
+) (offerdom.Offer, error) {
+	if u == nil || u.repo == nil || u.resaleRepo == nil {
+		return offerdom.Offer{}, ErrOfferNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	expiresAt, err := resolveOfferExpiresAt(in.ExpiresAt, now)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	resale, err := u.resaleRepo.GetByID(ctx, strings.TrimSpace(in.ResaleID))
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	if resale.Status != resaledom.StatusListing {
+		return offerdom.Offer{}, resaledom.ErrNotListing
+	}
+
+	buyerAvatarID := strings.TrimSpace(in.BuyerAvatarID)
+
+	// 同じ resale への交渉中の offer は 1 件まで。
+	existing, err := u.repo.ListByResaleID(ctx, resale.ID)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+	for _, o := range existing {
+		if o.BuyerAvatarID == buyerAvatarID &&
+			o.Status.IsOpen() &&
+			!o.IsExpired(now) {
+			return offerdom.Offer{}, offerdom.ErrConflict
+		}
+	}
+
+	o, err := offerdom.New(offerdom.NewOfferInput{
+		ResaleID:       resale.ID,
+		BuyerAvatarID:  buyerAvatarID,
+		SellerAvatarID: resale.AvatarID,
+		ListPrice:      resale.Price,
+		Amount:         in.Amount,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      now,
+	})
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	created, err := u.repo.Create(ctx, o)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	u.notify(ctx, created, resale, OfferMailEventPlaced, offerdom.PartySeller)
+
+	return created, nil
+}
+
+type CounterOfferInput struct {
+	OfferID  string
+	AvatarID string
+	Amount   int
+
+	// ExpiresAt は省略可能（DefaultOfferTTL）。
+	ExpiresAt *time.Time
+}
+
+func (u *OfferUsecase) Counter(
+	ctx context.Context,
+	in CounterOfferInput,
+) (offerdom.Offer, error) {
+	if u == nil || u.repo == nil || u.resaleRepo == nil {
+		return offerdom.Offer{}, ErrOfferNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	expiresAt, err := resolveOfferExpiresAt(in.ExpiresAt, now)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	o, by, err := u.loadForParty(ctx, in.OfferID, in.AvatarID)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+	prevUpdatedAt := o.UpdatedAt
+
+	resale, err := u.resaleRepo.GetByID(ctx, o.ResaleID)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+	if resale.Status != resaledom.StatusListing {
+		return offerdom.Offer{}, resaledom.ErrNotListing
+	}
+
+	if err := o.Counter(by, in.Amount, expiresAt, now); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	updated, err := u.repo.Update(ctx, o, prevUpdatedAt)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	u.notify(ctx, updated, resale, OfferMailEventCountered, offerdom.Counterparty(by))
+
+	return updated, nil
+}
+
+// Accept は相手側の提示額で合意し、resale を確保します。
+func (u *OfferUsecase) Accept(
+	ctx context.Context,
+	offerID string,
+	avatarID string,
+) (offerdom.Offer, error) {
+	if u == nil || u.repo == nil || u.resaleRepo == nil {
+		return offerdom.Offer{}, ErrOfferNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	o, by, err := u.loadForParty(ctx, offerID, avatarID)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+	prevUpdatedAt := o.UpdatedAt
+
+	if err := o.Accept(by, now.Add(u.checkoutTTL), now); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	resale, err := u.resaleRepo.GetByID(ctx, o.ResaleID)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	if err := resale.Reserve(now); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	if _, err := u.resaleRepo.Update(ctx, resale.ID, resale); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	updated, err := u.repo.Update(ctx, o, prevUpdatedAt)
+	if err != nil {
+		// offer を保存できなかった場合は確保を戻す。
+		u.releaseResale(ctx, resale.ID, now)
+		return offerdom.Offer{}, err
+	}
+
+	u.declineOthers(ctx, updated, resale, now)
+
+	u.notify(ctx, updated, resale, OfferMailEventAccepted, offerdom.PartyBuyer)
+	u.notify(ctx, updated, resale, OfferMailEventAccepted, offerdom.PartySeller)
+
+	return updated, nil
+}
+
+func (u *OfferUsecase) Decline(
+	ctx context.Context,
+	offerID string,
+	avatarID string,
+) (offerdom.Offer, error) {
+	if u == nil || u.repo == nil {
+		return offerdom.Offer{}, ErrOfferNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	o, by, err := u.loadForParty(ctx, offerID, avatarID)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+	prevUpdatedAt := o.UpdatedAt
+
+	if err := o.Decline(by, now); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	updated, err := u.repo.Update(ctx, o, prevUpdatedAt)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	u.notify(ctx, updated, u.lookupResale(ctx, updated.ResaleID), OfferMailEventDeclined, offerdom.Counterparty(by))
+
+	return updated, nil
+}
+
+// Withdraw は購入者が交渉中の offer を取り下げます。
+func (u *OfferUsecase) Withdraw(
+	ctx context.Context,
+	offerID string,
+	avatarID string,
+) (offerdom.Offer, error) {
+	if u == nil || u.repo == nil {
+		return offerdom.Offer{}, ErrOfferNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	o, by, err := u.loadForParty(ctx, offerID, avatarID)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+	prevUpdatedAt := o.UpdatedAt
+
+	if err := o.Withdraw(by, now); err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	updated, err := u.repo.Update(ctx, o, prevUpdatedAt)
+	if err != nil {
+		return offerdom.Offer{}, err
+	}
+
+	u.notify(ctx, updated, u.lookupResale(ctx, updated.ResaleID), OfferMailEventWithdrawn, offerdom.PartySeller)
+
+	return updated, nil
+}
+
+// ============================================================
+// Checkout / payment
+// ============================================================
+
+// ResolveCheckoutPrice は accept 済み offer の合意価格を返します（注文作成時）。
+func (u *OfferUsecase) ResolveCheckoutPrice(
+	ctx context.Context,
+	in OfferCheckoutInput,
+) (int, error) {
+	if u == nil || u.repo == nil {
+		return 0, ErrOfferNotConfigured
+	}
+
+	o, err := u.repo.GetByID(ctx, strings.TrimSpace(in.OfferID))
+	if err != nil {
+		return 0, err
+	}
+
+	if o.ResaleID != strings.TrimSpace(in.ResaleID) {
+		return 0, offerdom.ErrInvalidResaleID
+	}
+
+	if party, err := o.PartyOf(in.BuyerAvatarID); err != nil || party != offerdom.PartyBuyer {
+		return 0, offerdom.ErrNotParty
+	}
+
+	if o.Status != offerdom.StatusAccepted {
+		return 0, offerdom.ErrNotAccepted
+	}
+
+	if o.IsExpired(u.now().UTC()) {
+		return 0, offerdom.ErrOfferExpired
+	}
+
+	if o.OrderID != "" {
+		return 0, offerdom.ErrAlreadyOrdered
+	}
+
+	return o.Amount, nil
+}
+
+// AttachOrder は合意価格の注文を offer に紐づけます（注文の保存前）。
+// 紐づけ後の offer は決済まで期限切れにならず、resale も reserved のままです。
+func (u *OfferUsecase) AttachOrder(
+	ctx context.Context,
+	offerID string,
+	orderID string,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrOfferNotConfigured
+	}
+
+	o, err := u.repo.GetByID(ctx, strings.TrimSpace(offerID))
+	if err != nil {
+		return err
+	}
+	prevUpdatedAt := o.UpdatedAt
+
+	if err := o.AttachOrder(orderID, u.now().UTC()); err != nil {
+		return err
+	}
+
+	_, err = u.repo.Update(ctx, o, prevUpdatedAt)
+	return err
+}
+
+// ReleaseOrder は注文を保存できなかった場合に AttachOrder を取り消します。
+func (u *OfferUsecase) ReleaseOrder(
+	ctx context.Context,
+	offerID string,
+	orderID string,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrOfferNotConfigured
+	}
+
+	o, err := u.repo.GetByID(ctx, strings.TrimSpace(offerID))
+	if err != nil {
+		return err
+	}
+	prevUpdatedAt := o.UpdatedAt
+
+	if err := o.DetachOrder(orderID, u.now().UTC()); err != nil {
+		return err
+	}
+
+	_, err = u.repo.Update(ctx, o, prevUpdatedAt)
+	return err
+}
+
+// CompleteForOrder は決済済み order の offer を completed にします。
+// 完了済みの offer はスキップするため、何度呼び出しても結果は同じです。
+func (u *OfferUsecase) CompleteForOrder(
+	ctx context.Context,
+	order orderdom.Order,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrOfferNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	var errs []error
+
+	for _, item := range order.Items {
+		if item.Type != orderdom.OrderItemTypeResale || item.OfferID == "" {
+			continue
+		}
+
+		o, err := u.repo.GetByID(ctx, item.OfferID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if o.Status == offerdom.StatusCompleted {
+			continue
+		}
+		prevUpdatedAt := o.UpdatedAt
+
+		if err := o.Complete(order.ID, now); err != nil {
+			errs = append(errs, fmt.Errorf("offer %s: %w", o.ID, err))
+			continue
+		}
+
+		if _, err := u.repo.Update(ctx, o, prevUpdatedAt); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CancelCheckout は決済されなかった注文（決済失敗・決済キャンセル・引当の期限切れ・
+// 明細キャンセル）の offer を expired にし、reserved の resale を出品中に戻します。
+// 決済済み（completed）の offer は何もしません。何度呼び出しても結果は同じです。
+func (u *OfferUsecase) CancelCheckout(
+	ctx context.Context,
+	offerID string,
+	orderID string,
+) error {
+	if u == nil || u.repo == nil || u.resaleRepo == nil {
+		return ErrOfferNotConfigured
+	}
+
+	o, err := u.repo.GetByID(ctx, strings.TrimSpace(offerID))
+	if err != nil {
+		return err
+	}
+
+	if o.Status == offerdom.StatusCompleted {
+		return nil
+	}
+
+	now := u.now().UTC()
+	wasAccepted := o.Status == offerdom.StatusAccepted
+	prevUpdatedAt := o.UpdatedAt
+
+	if err := o.CancelCheckout(orderID, now); err != nil {
+		return err
+	}
+
+	if wasAccepted {
+		if _, err := u.repo.Update(ctx, o, prevUpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	// offer の保存後に resale の更新が失敗した場合も、再実行で戻せるようにする。
+	return u.releaseUnclaimedResale(ctx, o, now)
+}
+
+// ReleaseForOrder は注文の resale item が購入に使った offer を CancelCheckout します。
+// offer を使っていない注文は何もしません。
+func (u *OfferUsecase) ReleaseForOrder(
+	ctx context.Context,
+	order orderdom.Order,
+) error {
+	var errs []error
+
+	for _, item := range order.Items {
+		if item.Type != orderdom.OrderItemTypeResale || item.OfferID == "" {
+			continue
+		}
+
+		if err := u.CancelCheckout(ctx, item.OfferID, order.ID); err != nil {
+			errs = append(errs, fmt.Errorf("offer %s: %w", item.OfferID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ============================================================
+// Expiry (sweeper)
+// ============================================================
+
+// ExpireDueOffersResult は sweeper 1 回分の処理結果。
+type ExpireDueOffersResult struct {
+	Scanned  int `json:"scanned"`
+	Expired  int `json:"expired"`
+	Released int `json:"released"`
+	Failed   int `json:"failed"`
+}
+
+// ExpireDue は期限切れの offer を expired にします。
+//
+//   - 交渉中の offer: expired にする
+//   - 注文のない accepted の offer: expired にし、reserved の resale を出品中に戻す
+//     （既に sold の場合は決済済みのため戻さない）
+//
+// 1 件の失敗で残りの処理を止めず、最初のエラーを結果と一緒に返す。
+func (u *OfferUsecase) ExpireDue(
+	ctx context.Context,
+	limit int,
+) (ExpireDueOffersResult, error) {
+	var result ExpireDueOffersResult
+
+	if u == nil || u.repo == nil || u.resaleRepo == nil {
+		return result, ErrOfferNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	due, err := u.repo.ListDue(ctx, now, limit)
+	if err != nil {
+		return result, err
+	}
+
+	var firstErr error
+
+	for _, o := range due {
+		result.Scanned++
+
+		released, err := u.expireOne(ctx, o, now)
+		if err != nil {
+			result.Failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		result.Expired++
+		if released {
+			result.Released++
+		}
+	}
+
+	return result, firstErr
+}
+
+func (u *OfferUsecase) expireOne(
+	ctx context.Context,
+	o offerdom.Offer,
+	now time.Time,
+) (bool, error) {
+	wasAccepted := o.Status == offerdom.StatusAccepted
+	prevUpdatedAt := o.UpdatedAt
+
+	if err := o.Expire(now); err != nil {
+		return false, err
+	}
+
+	updated, err := u.repo.Update(ctx, o, prevUpdatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	resale := u.lookupResale(ctx, updated.ResaleID)
+
+	released := false
+	if wasAccepted && resale.Status == resaledom.StatusReserved {
+		if err := resale.Release(now); err != nil {
+			return false, err
+		}
+		if _, err := u.resaleRepo.Update(ctx, resale.ID, resale); err != nil {
+			return false, err
+		}
+		released = true
+	}
+
+	u.notify(ctx, updated, resale, OfferMailEventExpired, offerdom.PartyBuyer)
+	u.notify(ctx, updated, resale, OfferMailEventExpired, offerdom.PartySeller)
+
+	return released, nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func (u *OfferUsecase) loadForParty(
+	ctx context.Context,
+	offerID string,
+	avatarID string,
+) (offerdom.Offer, offerdom.Party, error) {
+	o, err := u.repo.GetByID(ctx, strings.TrimSpace(offerID))
+	if err != nil {
+		return offerdom.Offer{}, "", err
+	}
+
+	by, err := o.PartyOf(avatarID)
+	if err != nil {
+		return offerdom.Offer{}, "", offerdom.ErrNotFound
+	}
+
+	return o, by, nil
+}
+
+// declineOthers は accept された offer 以外の交渉中 offer を decline します（best-effort）。
+func (u *OfferUsecase) declineOthers(
+	ctx context.Context,
+	accepted offerdom.Offer,
+	resale resaledom.Resale,
+	now time.Time,
+) {
+	others, err := u.repo.ListByResaleID(ctx, accepted.ResaleID)
+	if err != nil {
+		log.Printf("[offer] list offers for decline failed resaleId=%s err=%v", accepted.ResaleID, err)
+		return
+	}
+
+	for _, o := range others {
+		if o.ID == accepted.ID || !o.Status.IsOpen() {
+			continue
+		}
+		prevUpdatedAt := o.UpdatedAt
+
+		if err := o.Supersede(now); err != nil {
+			continue
+		}
+
+		updated, err := u.repo.Update(ctx, o, prevUpdatedAt)
+		if err != nil {
+			log.Printf("[offer] decline superseded offer failed offerId=%s err=%v", o.ID, err)
+			continue
+		}
+
+		u.notify(ctx, updated, resale, OfferMailEventSuperseded, offerdom.PartyBuyer)
+	}
+}
+
+func (u *OfferUsecase) releaseResale(
+	ctx context.Context,
+	resaleID string,
+	now time.Time,
+) {
+	resale, err := u.resaleRepo.GetByID(ctx, resaleID)
+	if err == nil {
+		if err = resale.Release(now); err == nil {
+			_, err = u.resaleRepo.Update(ctx, resaleID, resale)
+		}
+	}
+	if err != nil {
+		log.Printf("[offer] release resale failed resaleId=%s err=%v", resaleID, err)
+	}
+}
+
+// releaseUnclaimedResale は終了した offer の resale が reserved のままなら出品中に戻します。
+// 別の offer が accept 済みで resale を確保している場合は戻しません。
+func (u *OfferUsecase) releaseUnclaimedResale(
+	ctx context.Context,
+	ended offerdom.Offer,
+	now time.Time,
+) error {
+	resale, err := u.resaleRepo.GetByID(ctx, ended.ResaleID)
+	if err != nil {
+		return err
+	}
+	if resale.Status != resaledom.StatusReserved {
+		return nil
+	}
+
+	others, err := u.repo.ListByResaleID(ctx, ended.ResaleID)
+	if err != nil {
+		return err
+	}
+	for _, o := range others {
+		if o.ID != ended.ID && o.Status == offerdom.StatusAccepted {
+			return nil
+		}
+	}
+
+	if err := resale.Release(now); err != nil {
+		return err
+	}
+
+	_, err = u.resaleRepo.Update(ctx, resale.ID, resale)
+	return err
+}
+
+// lookupResale は通知用に resale を取得します（取得できない場合は ID のみ）。
+func (u *OfferUsecase) lookupResale(
+	ctx context.Context,
+	resaleID string,
+) resaledom.Resale {
+	if u.resaleRepo != nil {
+		if resale, err := u.resaleRepo.GetByID(ctx, resaleID); err == nil {
+			return resale
+		}
+	}
+
+	return resaledom.Resale{ID: resaleID}
+}
+
+// notify は to 側の当事者にメールを送ります。失敗はログのみ。
+func (u *OfferUsecase) notify(
+	ctx context.Context,
+	o offerdom.Offer,
+	resale resaledom.Resale,
+	event OfferMailEvent,
+	to offerdom.Party,
+) {
+	if u.mailer == nil || u.avatarRepo == nil || u.authUserReader == nil {
+		return
+	}
+
+	avatarID := o.BuyerAvatarID
+	if to == offerdom.PartySeller {
+		avatarID = o.SellerAvatarID
+	}
+
+	email, err := u.resolveEmail(ctx, avatarID)
+	if err != nil {
+		log.Printf("[offer] resolve email failed offerId=%s avatarId=%s err=%v", o.ID, avatarID, err)
+		return
+	}
+
+	var expiresAt *time.Time
+	switch {
+	case o.Status.IsOpen():
+		t := o.ExpiresAt
+		expiresAt = &t
+	case o.Status == offerdom.StatusAccepted:
+		expiresAt = o.CheckoutExpiresAt
+	}
+
+	message := OfferMailMessage{
+		IdempotencyKey: fmt.Sprintf("offer:%s:%s:%d:%s", o.ID, event, len(o.Rounds), to),
+		ToEmail:        email,
+		Recipient:      to,
+		Event:          event,
+		OfferID:        o.ID,
+		ResaleID:       o.ResaleID,
+		ProductName:    resale.ProductName,
+		ListPrice:      o.ListPrice,
+		Amount:         o.Amount,
+		ExpiresAt:      expiresAt,
+	}
+
+	if err := u.mailer.SendOfferNotification(ctx, message); err != nil {
+		log.Printf("[offer] send notification failed offerId=%s event=%s err=%v", o.ID, event, err)
+	}
+}
+
+func (u *OfferUsecase) resolveEmail(
+	ctx context.Context,
+	avatarID string,
+) (string, error) {
+	avatar, err := u.avatarRepo.GetByID(ctx, avatarID)
+	if err != nil {
+		return "", err
+	}
+
+	uid := strings.TrimSpace(avatar.UserID)
+	if uid == "" {
+		return "", avatardom.ErrInvalidUserID
+	}
+
+	return u.authUserReader.GetEmailByUID(ctx, uid)
+}
+
+func resolveOfferExpiresAt(
+	expiresAt *time.Time,
+	now time.Time,
+) (time.Time, error) {
+	if expiresAt == nil || expiresAt.IsZero() {
+		return now.Add(DefaultOfferTTL), nil
+	}
+
+	t := expiresAt.UTC()
+	if !t.After(now) || t.After(now.Add(MaxOfferTTL)) {
+		return time.Time{}, offerdom.ErrInvalidExpiresAt
+	}
+
+	return t, nil
+}
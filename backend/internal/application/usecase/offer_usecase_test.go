@@ -0,0 +1,258 @@
+// backend/internal/application/usecase/offer_usecase_test.go
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/fake"
+	"narratives/internal/adapters/out/memory"
+	usecase "narratives/internal/application/usecase"
+	avdom "narratives/internal/domain/avatar"
+	invdom "narratives/internal/domain/inventory"
+	offerdom "narratives/internal/domain/offer"
+	orderdom "narratives/internal/domain/order"
+	paymentdom "narratives/internal/domain/payment"
+	resaledom "narratives/internal/domain/resale"
+)
+
+const checkoutResaleAssetID = "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+
+// 合意価格で注文した offer は、注文が決済されずに終わった場合に expired になり、
+// resale は出品中に戻る。
+func TestOfferCheckout_EndsWhenOrderIsNotPaid(t *testing.T) {
+	createdAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		end  func(t *testing.T, ctx context.Context, f *checkoutFlow, reservationUC *usecase.InventoryReservationUsecase, order orderdom.Order)
+	}{
+		{
+			name: "payment failed",
+			end: func(t *testing.T, ctx context.Context, f *checkoutFlow, _ *usecase.InventoryReservationUsecase, order orderdom.Order) {
+				f.createPayment(t, ctx, order, paymentdom.StatusPending)
+
+				if _, err := f.paymentUC.ApplyStripeEvent(ctx, usecase.ApplyStripePaymentEventInput{
+					EventID:               "evt_offer_failed",
+					PaymentID:             order.ID,
+					StripePaymentIntentID: "pi_offer",
+					Status:                paymentdom.StatusFailed,
+					OccurredAt:            createdAt.Add(time.Hour),
+				}); err != nil {
+					t.Fatalf("ApplyStripeEvent: %v", err)
+				}
+			},
+		},
+		{
+			name: "reservation expired",
+			end: func(t *testing.T, ctx context.Context, f *checkoutFlow, reservationUC *usecase.InventoryReservationUsecase, order orderdom.Order) {
+				f.createPayment(t, ctx, order, paymentdom.StatusFailed)
+
+				reservationUC.WithNow(func() time.Time {
+					return createdAt.Add(invdom.DefaultReservationTTL + time.Hour)
+				})
+
+				got, err := reservationUC.ReleaseExpired(ctx, 10)
+				if err != nil {
+					t.Fatalf("ReleaseExpired: %v", err)
+				}
+				if got.Released != 1 {
+					t.Fatalf("ReleaseExpired = %+v, want 1 released", got)
+				}
+			},
+		},
+		{
+			name: "item cancelled",
+			end: func(t *testing.T, ctx context.Context, f *checkoutFlow, _ *usecase.InventoryReservationUsecase, order orderdom.Order) {
+				if _, err := f.orderUC.CancelItem(ctx, usecase.CancelOrderItemInput{
+					ID:        order.ID,
+					AvatarID:  order.AvatarID,
+					ItemIndex: resaleItemIndex(t, order),
+				}); err != nil {
+					t.Fatalf("CancelItem: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newCheckoutFlow()
+			s := f.seed(t, ctx)
+
+			reservationUC := usecase.NewInventoryReservationUsecase(
+				memory.NewInventoryReservationRepositoryMem(),
+				f.inventories,
+				f.orders,
+			).
+				WithNow(func() time.Time { return createdAt }).
+				WithPaymentCanceler(f.payments, fake.NewStripeGatewayFake()).
+				WithOfferReleaser(f.offerUC)
+			f.orderUC.WithInventoryReserver(reservationUC)
+
+			offer := f.acceptOffer(t, ctx, s)
+			order := f.createOfferOrder(t, ctx, s, offer)
+
+			ordered, err := f.offers.GetByID(ctx, offer.ID)
+			if err != nil {
+				t.Fatalf("get offer: %v", err)
+			}
+			if ordered.OrderID != order.ID || ordered.IsExpired(createdAt.Add(30*24*time.Hour)) {
+				t.Fatalf("ordered offer = %+v, want attached to %s and waiting for payment", ordered, order.ID)
+			}
+
+			tt.end(t, ctx, f, reservationUC, order)
+
+			ended, err := f.offers.GetByID(ctx, offer.ID)
+			if err != nil {
+				t.Fatalf("get offer: %v", err)
+			}
+			if ended.Status != offerdom.StatusExpired {
+				t.Fatalf("offer status = %q, want %q", ended.Status, offerdom.StatusExpired)
+			}
+			if ended.OrderID != order.ID {
+				t.Fatalf("offer orderId = %q, want %q", ended.OrderID, order.ID)
+			}
+
+			resale, err := f.resales.GetByID(ctx, offer.ResaleID)
+			if err != nil {
+				t.Fatalf("get resale: %v", err)
+			}
+			if resale.Status != resaledom.StatusListing {
+				t.Fatalf("resale status = %q, want %q", resale.Status, resaledom.StatusListing)
+			}
+		})
+	}
+}
+
+// acceptOffer は別の avatar の resale に seed の avatar が offer を出し、出品者が accept した状態を作る。
+func (f *checkoutFlow) acceptOffer(t *testing.T, ctx context.Context, s checkoutSeed) offerdom.Offer {
+	t.Helper()
+
+	seller, err := f.avatars.Create(ctx, avdom.Avatar{
+		UserID:     "user_seller",
+		AvatarName: "seller",
+	})
+	if err != nil {
+		t.Fatalf("create seller avatar: %v", err)
+	}
+
+	r, err := resaledom.NewForCreate(
+		resaledom.StatusListing,
+		checkoutResaleAssetID,
+		s.tokenBlueprintID,
+		"product_resale",
+		s.brandID,
+		"productBlueprint_checkout",
+		seller.ID,
+		4500,
+		resaledom.ConditionLikeNew,
+		"",
+		seller.ID,
+	)
+	if err != nil {
+		t.Fatalf("new resale: %v", err)
+	}
+
+	resale, err := f.resales.Create(ctx, r)
+	if err != nil {
+		t.Fatalf("create resale: %v", err)
+	}
+
+	placed, err := f.offerUC.Place(ctx, usecase.PlaceOfferInput{
+		ResaleID:      resale.ID,
+		BuyerAvatarID: s.avatarID,
+		Amount:        4000,
+	})
+	if err != nil {
+		t.Fatalf("place offer: %v", err)
+	}
+
+	accepted, err := f.offerUC.Accept(ctx, placed.ID, seller.ID)
+	if err != nil {
+		t.Fatalf("accept offer: %v", err)
+	}
+
+	return accepted
+}
+
+// createOfferOrder は seed の出品 1 点の注文に、offer の合意価格の resale を加える。
+// 注文作成の送料見積もりは list item のみに対応しているため、保存済みの注文に
+// resale item を追加し、AttachOrder で offer に紐づける。
+func (f *checkoutFlow) createOfferOrder(
+	t *testing.T,
+	ctx context.Context,
+	s checkoutSeed,
+	offer offerdom.Offer,
+) orderdom.Order {
+	t.Helper()
+
+	order := f.createOrder(t, ctx, s)
+
+	resale, err := f.resales.GetByID(ctx, offer.ResaleID)
+	if err != nil {
+		t.Fatalf("get resale: %v", err)
+	}
+
+	order.Items = append(order.Items, orderdom.OrderItemSnapshot{
+		Type:               orderdom.OrderItemTypeResale,
+		ResaleID:           resale.ID,
+		ProductID:          resale.ProductID,
+		ProductBlueprintID: resale.ProductBlueprintID,
+		TokenBlueprintID:   resale.TokenBlueprintID,
+		BrandID:            resale.BrandID,
+		ConsumptionTaxRate: order.Items[0].ConsumptionTaxRate,
+		Qty:                1,
+		Price:              offer.Amount,
+		OfferID:            offer.ID,
+
+		ProductBlueprintCategoryPath: order.Items[0].ProductBlueprintCategoryPath,
+	})
+
+	if err := f.offerUC.AttachOrder(ctx, offer.ID, order.ID); err != nil {
+		t.Fatalf("attach order: %v", err)
+	}
+
+	updated, err := f.orders.Update(ctx, order, nil)
+	if err != nil {
+		t.Fatalf("add resale item: %v", err)
+	}
+
+	return updated
+}
+
+func (f *checkoutFlow) createPayment(
+	t *testing.T,
+	ctx context.Context,
+	order orderdom.Order,
+	status paymentdom.PaymentStatus,
+) {
+	t.Helper()
+
+	if _, err := f.paymentUC.Create(ctx, paymentdom.Payment{
+		PaymentID:             order.ID,
+		PaymentMethodID:       order.PaymentMethodSnapshot.PaymentMethodID,
+		StripeCustomerID:      order.PaymentMethodSnapshot.CustomerID,
+		StripePaymentMethodID: order.PaymentMethodSnapshot.StripePaymentMethodID,
+		StripePaymentIntentID: "pi_offer",
+		Amount:                9000,
+		Status:                status,
+	}); err != nil {
+		t.Fatalf("create payment: %v", err)
+	}
+}
+
+func resaleItemIndex(t *testing.T, order orderdom.Order) int {
+	t.Helper()
+
+	for i, item := range order.Items {
+		if item.Type == orderdom.OrderItemTypeResale {
+			return i
+		}
+	}
+
+	t.Fatalf("order %s has no resale item", order.ID)
+	return -1
+}
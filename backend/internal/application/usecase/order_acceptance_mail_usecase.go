@@ -0,0 +1,123 @@
+// backend/internal/application/usecase/order_acceptance_mail_usecase.go
+package usecase
+
+/*
+責務:
+- 注文の作成（OrderCreated）を受けて、購入者に注文受付メールを送る。
+
+前提:
+- 購入者のメールアドレスは Firestore には保存せず、Firebase Auth から取得する。
+- outbox の配信は at-least-once のため、送信後の配信記録に失敗すると
+  同じメールが再送される可能性がある（取りこぼしより重複を許容する）。
+- 送信元（from）や送信手段が未設定の場合は何もしない（ローカル開発向け）。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	applicationport "narratives/internal/application/port"
+	orderdom "narratives/internal/domain/order"
+	outboxdom "narratives/internal/domain/outbox"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+type OrderReaderForAcceptanceMail interface {
+	GetByID(ctx context.Context, id string) (orderdom.Order, error)
+}
+
+type OrderAcceptanceMailSender interface {
+	SendOrderConfirmation(
+		ctx context.Context,
+		from string,
+		to string,
+		order orderdom.Order,
+	) error
+}
+
+var ErrOrderAcceptanceMailNotConfigured = errors.New(
+	"order_acceptance_mail: usecase is not configured",
+)
+
+type OrderAcceptanceMailUsecase struct {
+	orderRepo OrderReaderForAcceptanceMail
+	authUser  applicationport.AuthUserReader
+	sender    OrderAcceptanceMailSender
+	from      string
+}
+
+var _ OutboxEventHandler = (*OrderAcceptanceMailUsecase)(nil)
+
+func NewOrderAcceptanceMailUsecase(
+	orderRepo OrderReaderForAcceptanceMail,
+	authUser applicationport.AuthUserReader,
+	sender OrderAcceptanceMailSender,
+	from string,
+) *OrderAcceptanceMailUsecase {
+	return &OrderAcceptanceMailUsecase{
+		orderRepo: orderRepo,
+		authUser:  authUser,
+		sender:    sender,
+		from:      strings.TrimSpace(from),
+	}
+}
+
+// HandleOutboxEvent は OrderCreated を受けて注文受付メールを送ります。
+func (u *OrderAcceptanceMailUsecase) HandleOutboxEvent(
+	ctx context.Context,
+	e outboxdom.Event,
+) error {
+	if e.Type != outboxdom.EventOrderCreated {
+		return nil
+	}
+
+	if u == nil || u.orderRepo == nil || u.authUser == nil {
+		return ErrOrderAcceptanceMailNotConfigured
+	}
+
+	if u.sender == nil || u.from == "" {
+		return nil
+	}
+
+	var payload outboxdom.OrderCreatedPayload
+	if err := e.DecodePayload(&payload); err != nil {
+		return err
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, payload.OrderID)
+	if err != nil {
+		if errors.Is(err, orderdom.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	to, err := u.authUser.GetEmailByUID(ctx, order.UserID)
+	if err != nil {
+		return fmt.Errorf(
+			"resolve buyer email for user %q: %w",
+			order.UserID,
+			err,
+		)
+	}
+
+	to = strings.TrimSpace(to)
+	if to == "" {
+		return nil
+	}
+
+	if err := u.sender.SendOrderConfirmation(ctx, u.from, to, order); err != nil {
+		return fmt.Errorf(
+			"send order acceptance mail orderId=%s: %w",
+			order.ID,
+			err,
+		)
+	}
+
+	return nil
+}
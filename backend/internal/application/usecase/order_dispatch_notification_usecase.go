@@ -10,6 +10,8 @@ import (
 
 	applicationport "narratives/internal/application/port"
 	orderdom "narratives/internal/domain/order"
+	outboxdom "narratives/internal/domain/outbox"
+	transportationdom "narratives/internal/domain/transportation"
 )
 
 const (
@@ -52,6 +54,11 @@ type OrderDispatchNotificationUsecasePort interface {
 type OrderDispatchNotificationMailItem struct {
 	ProductName string
 	Qty         int
+
+	// 伝票情報。TrackingURL は carrier が問い合わせページを持つ場合のみ設定する。
+	Carrier        transportationdom.Carrier
+	TrackingNumber string
+	TrackingURL    string
 }
 
 type OrderDispatchNotificationMailMessage struct {
@@ -74,6 +81,14 @@ type OrderDispatchNotificationMailerPort interface {
 	) (OrderDispatchNotificationMailSendResult, error)
 }
 
+// OrderReaderForDispatchNotification は ItemDispatched の注文を読み込みます。
+type OrderReaderForDispatchNotification interface {
+	GetByID(
+		ctx context.Context,
+		id string,
+	) (orderdom.Order, error)
+}
+
 type OrderDispatchNotificationQueuePort interface {
 	EnqueueOrderDispatchNotification(
 		ctx context.Context,
@@ -87,6 +102,7 @@ type OrderDispatchNotificationQueuePort interface {
 
 type OrderDispatchNotificationUsecase struct {
 	deliveryRepo orderdom.DispatchNotificationRepository
+	orderReader  OrderReaderForDispatchNotification
 
 	authUser applicationport.AuthUserReader
 
@@ -126,6 +142,19 @@ func NewOrderDispatchNotificationUsecase(
 	}
 }
 
+// WithOrderReader は ItemDispatched イベントからの通知作成を有効にします。
+func (u *OrderDispatchNotificationUsecase) WithOrderReader(
+	orderReader OrderReaderForDispatchNotification,
+) *OrderDispatchNotificationUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.orderReader = orderReader
+
+	return u
+}
+
 // ==============================
 // Ensure Delivery
 // ==============================
@@ -207,6 +236,11 @@ func (u *OrderDispatchNotificationUsecase) EnsureDelivery(
 			return orderdom.DispatchNotificationDelivery{}, err
 		}
 
+		if targetItem.Dispatch != nil {
+			item.Carrier = targetItem.Dispatch.Carrier
+			item.TrackingNumber = targetItem.Dispatch.TrackingNumber
+		}
+
 		items = append(items, item)
 	}
 
@@ -295,6 +329,71 @@ func (u *OrderDispatchNotificationUsecase) EnsureDelivery(
 	return delivery, nil
 }
 
+// ==============================
+// Outbox
+// ==============================
+
+var _ OutboxEventHandler = (*OrderDispatchNotificationUsecase)(nil)
+
+// HandleOutboxEvent は ItemDispatched から発送通知を作成し、送信を予約します。
+// EnsureDelivery は delivery ID で冪等なため、同じイベントの再配信でも通知は 1 通です。
+func (u *OrderDispatchNotificationUsecase) HandleOutboxEvent(
+	ctx context.Context,
+	e outboxdom.Event,
+) error {
+	if e.Type != outboxdom.EventItemDispatched {
+		return nil
+	}
+
+	if u == nil || u.orderReader == nil {
+		return fmt.Errorf("order dispatch notification order reader is not configured")
+	}
+
+	var payload outboxdom.ItemDispatchedPayload
+	if err := e.DecodePayload(&payload); err != nil {
+		return err
+	}
+
+	order, err := u.orderReader.GetByID(ctx, payload.OrderID)
+	if err != nil {
+		return fmt.Errorf(
+			"get order %q for dispatch notification: %w",
+			payload.OrderID,
+			err,
+		)
+	}
+
+	inventoryIDs := make(map[string]struct{}, len(payload.InventoryIDs))
+	for _, id := range payload.InventoryIDs {
+		inventoryIDs[id] = struct{}{}
+	}
+
+	targetItems := make([]orderdom.OrderItemSnapshot, 0, len(order.Items))
+	for _, item := range order.Items {
+		if _, ok := inventoryIDs[item.InventoryID]; !ok {
+			continue
+		}
+
+		// 発送後にキャンセルされた明細は通知しない。
+		if item.IsCancelled || !item.IsDispatched {
+			continue
+		}
+
+		targetItems = append(targetItems, item)
+	}
+
+	if len(targetItems) == 0 {
+		return nil
+	}
+
+	_, err = u.EnsureDelivery(
+		WithCompanyID(ctx, payload.CompanyID),
+		order,
+		targetItems,
+	)
+	return err
+}
+
 // ==============================
 // Dispatch Due
 // ==============================
@@ -648,6 +747,13 @@ func (
 			OrderDispatchNotificationMailItem{
 				ProductName: productName,
 				Qty:         item.Qty,
+
+				Carrier:        item.Carrier,
+				TrackingNumber: item.TrackingNumber,
+				TrackingURL: transportationdom.TrackingURL(
+					item.Carrier,
+					item.TrackingNumber,
+				),
 			},
 		)
 	}
@@ -3,21 +3,26 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	auditdom "narratives/internal/domain/audit"
 	cartdom "narratives/internal/domain/cart"
 	common "narratives/internal/domain/common"
 	inventorydom "narratives/internal/domain/inventory"
 	listdom "narratives/internal/domain/list"
 	orderdom "narratives/internal/domain/order"
+	outboxdom "narratives/internal/domain/outbox"
 	paymentmethoddom "narratives/internal/domain/paymentMethod"
 	productblueprintdom "narratives/internal/domain/productBlueprint"
 	productblueprintcategorydom "narratives/internal/domain/productBlueprintCategory"
+	refunddom "narratives/internal/domain/refund"
 	resaledom "narratives/internal/domain/resale"
 	shippingaddressdom "narratives/internal/domain/shippingAddress"
+	transportationdom "narratives/internal/domain/transportation"
 )
 
 // OrderUsecase orchestrates order operations.
@@ -35,6 +40,15 @@ type OrderUsecase struct {
 	paymentMethodRepo    paymentmethoddom.RepositoryPort
 	shippingAddressRepo  shippingaddressdom.RepositoryPort
 	shippingQuoteUC      *ShippingQuoteUsecase
+	refundIssuer         OrderRefundIssuer
+	inventoryReserver    OrderInventoryReserver
+	stockLevelEvaluator  StockLevelEvaluator
+	couponApplier        OrderCouponApplier
+	royaltyQuoter        OrderRoyaltyQuoter
+	offerCheckout        OrderOfferCheckout
+	listAccess           ListAccessGuard
+	audit                AuditRecorder
+	outbox               OutboxPublisher
 	now                  func() time.Time
 }
 
@@ -73,6 +87,233 @@ func (u *OrderUsecase) WithCartRepository(
 	return u
 }
 
+// OrderRefundIssuer refunds cancelled items of an already paid Order.
+type OrderRefundIssuer interface {
+	IssueRefund(
+		ctx context.Context,
+		in IssueRefundInput,
+	) (refunddom.Refund, error)
+}
+
+func (u *OrderUsecase) WithRefundIssuer(
+	refundIssuer OrderRefundIssuer,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.refundIssuer = refundIssuer
+
+	return u
+}
+
+// OrderInventoryReserver manages the expiring inventory reservation record of
+// an Order.
+type OrderInventoryReserver interface {
+	ReserveForOrder(
+		ctx context.Context,
+		order orderdom.Order,
+	) (inventorydom.Reservation, error)
+
+	ConfirmForOrder(
+		ctx context.Context,
+		orderID string,
+	) error
+
+	ReleaseForOrder(
+		ctx context.Context,
+		orderID string,
+		reason inventorydom.ReservationReleaseReason,
+	) error
+}
+
+func (u *OrderUsecase) WithInventoryReserver(
+	inventoryReserver OrderInventoryReserver,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.inventoryReserver = inventoryReserver
+
+	return u
+}
+
+// WithStockLevelEvaluator は明細キャンセルによる引当解放後の
+// 在庫アラート評価を有効にする。
+func (u *OrderUsecase) WithStockLevelEvaluator(
+	evaluator StockLevelEvaluator,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.stockLevelEvaluator = evaluator
+
+	return u
+}
+
+// OrderCouponApplier prices a coupon for an Order and manages its usage count.
+type OrderCouponApplier interface {
+	QuoteDiscount(
+		ctx context.Context,
+		in CouponDiscountInput,
+	) (orderdom.DiscountSnapshot, error)
+
+	ReserveForOrder(
+		ctx context.Context,
+		order orderdom.Order,
+	) error
+
+	ReleaseForOrder(
+		ctx context.Context,
+		orderID string,
+	) error
+}
+
+// WithCouponApplier は注文作成時のクーポン値引きを有効にする。
+// 未設定の場合、couponCode 付きの注文は ErrOrderCouponNotConfigured になる。
+func (u *OrderUsecase) WithCouponApplier(
+	applier OrderCouponApplier,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.couponApplier = applier
+
+	return u
+}
+
+var ErrOrderCouponNotConfigured = errors.New(
+	"order usecase: coupon is not configured",
+)
+
+// OrderRoyaltyQuoter computes the brand royalty of a resale item.
+// It returns nil when the token blueprint has no royalty policy.
+type OrderRoyaltyQuoter interface {
+	QuoteRoyalty(
+		ctx context.Context,
+		item orderdom.OrderItemSnapshot,
+	) (*orderdom.RoyaltySnapshot, error)
+}
+
+// WithRoyaltyQuoter は resale item のロイヤリティ計算を有効にする。
+// 未設定の場合、resale item にロイヤリティは記録されない。
+func (u *OrderUsecase) WithRoyaltyQuoter(
+	quoter OrderRoyaltyQuoter,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.royaltyQuoter = quoter
+
+	return u
+}
+
+// OfferCheckoutInput identifies the accepted offer a resale item is bought
+// through.
+type OfferCheckoutInput struct {
+	OfferID       string
+	ResaleID      string
+	BuyerAvatarID string
+}
+
+// OrderOfferCheckout resolves the agreed price of an accepted market offer.
+//
+// AttachOrder is called before the Order is persisted so that an accepted
+// offer is checked out at most once; ReleaseOrder undoes it when the
+// Order could not be saved. CancelCheckout ends the offer and frees the
+// reserved resale when an item bought through it is cancelled.
+type OrderOfferCheckout interface {
+	ResolveCheckoutPrice(
+		ctx context.Context,
+		in OfferCheckoutInput,
+	) (int, error)
+
+	AttachOrder(
+		ctx context.Context,
+		offerID string,
+		orderID string,
+	) error
+
+	ReleaseOrder(
+		ctx context.Context,
+		offerID string,
+		orderID string,
+	) error
+
+	CancelCheckout(
+		ctx context.Context,
+		offerID string,
+		orderID string,
+	) error
+}
+
+// WithOfferCheckout は offer で合意した価格での resale 購入を有効にする。
+// 未設定の場合、offerId を指定した注文は作成できない。
+func (u *OrderUsecase) WithOfferCheckout(
+	checkout OrderOfferCheckout,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.offerCheckout = checkout
+
+	return u
+}
+
+// WithAuditRecorder は console からの発送・ステータス変更を監査ログに記録する。
+func (u *OrderUsecase) WithAuditRecorder(
+	recorder AuditRecorder,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.audit = recorder
+
+	return u
+}
+
+// WithOutbox は注文の作成・発送のイベントを書き込み直後に配信します。
+// 配信できなかったイベントは outbox の relay が再試行します。
+func (u *OrderUsecase) WithOutbox(
+	publisher OutboxPublisher,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.outbox = publisher
+
+	return u
+}
+
+var ErrOrderOfferNotConfigured = errors.New(
+	"order usecase: offer checkout is not configured",
+)
+
+// WithListAccessGuard は holder-only list の購入者判定を有効にする。
+// 未設定の場合、gating rule のある list の注文は ErrOrderListAccessNotConfigured になる。
+func (u *OrderUsecase) WithListAccessGuard(
+	guard ListAccessGuard,
+) *OrderUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.listAccess = guard
+
+	return u
+}
+
+var ErrOrderListAccessNotConfigured = errors.New(
+	"order usecase: list access guard is not configured",
+)
+
 // =======================
 // Queries
 // =======================
@@ -123,6 +364,10 @@ type CreateOrderItemInput struct {
 	// resale item identifier
 	ResaleID string
 
+	// OfferID is optional. When set, the resale is bought at the price agreed
+	// in the accepted offer.
+	OfferID string
+
 	Qty int
 
 	// Reserved for future order creation behavior.
@@ -141,6 +386,9 @@ type CreateOrderInput struct {
 	PaymentMethodID   string
 	Items             []CreateOrderItemInput
 
+	// CouponCode is optional. The discount is priced on the server.
+	CouponCode string
+
 	CreatedAt *time.Time
 }
 
@@ -186,6 +434,7 @@ func (u *OrderUsecase) Create(
 
 	items, err := u.resolveOrderItems(
 		ctx,
+		in.AvatarID,
 		in.Items,
 	)
 	if err != nil {
@@ -220,10 +469,77 @@ func (u *OrderUsecase) Create(
 
 	order.Paid = false
 
-	// Repository.Create must persist the Order and replace its canonical
-	// orderTransferItems projection in the same Firestore transaction.
+	// 注文受付メールなどは OrderCreated から outbox 経由で行う。
+	if err := order.RecordCreated(createdAt); err != nil {
+		return orderdom.Order{}, err
+	}
+
+	couponCode := strings.TrimSpace(in.CouponCode)
+	if couponCode != "" {
+		if u.couponApplier == nil {
+			return orderdom.Order{}, ErrOrderCouponNotConfigured
+		}
+
+		discount, err := u.couponApplier.QuoteDiscount(
+			ctx,
+			CouponDiscountInput{
+				Code:          couponCode,
+				AvatarID:      order.AvatarID,
+				Items:         order.Items,
+				ShippingQuote: order.ShippingQuoteSnapshot,
+			},
+		)
+		if err != nil {
+			return orderdom.Order{}, err
+		}
+
+		if err := order.ApplyDiscount(discount); err != nil {
+			return orderdom.Order{}, err
+		}
+
+		// 利用上限を超えないよう、注文の保存前に利用回数を確保する。
+		if err := u.couponApplier.ReserveForOrder(
+			ctx,
+			order,
+		); err != nil {
+			return orderdom.Order{}, err
+		}
+	}
+
+	// offer の合意価格で購入する resale は、同じ offer で二重に注文されないよう
+	// 注文の保存前に offer へ注文を紐づける。
+	attachedOfferIDs, err := u.attachOfferOrders(ctx, order)
+	if err != nil {
+		u.rollbackOrderCreate(ctx, order, nil, false)
+
+		return orderdom.Order{}, err
+	}
+
+	// list item の在庫は注文の保存前に期限付きで引き当てる。
+	//
+	// 在庫不足（inventorydom.ErrInsufficientStock）などで引き当てられない場合は
+	// 注文を作成せず、確保済みのクーポン・offer・一部の引当を巻き戻す。
+	// 引当後に注文の保存が失敗した場合も同様に巻き戻す。
+	reserved := false
+	if u.inventoryReserver != nil {
+		if _, err := u.inventoryReserver.ReserveForOrder(
+			ctx,
+			order,
+		); err != nil {
+			u.rollbackOrderCreate(ctx, order, attachedOfferIDs, true)
+
+			return orderdom.Order{}, err
+		}
+		reserved = true
+	}
+
+	// Repository.Create must persist the Order, its pending events and
+	// replace its canonical orderTransferItems projection in the same
+	// Firestore transaction.
 	created, err := u.repo.Create(ctx, order)
 	if err != nil {
+		u.rollbackOrderCreate(ctx, order, attachedOfferIDs, reserved)
+
 		return orderdom.Order{}, err
 	}
 
@@ -252,9 +568,94 @@ func (u *OrderUsecase) Create(
 		}
 	}
 
+	publishOutbox(ctx, u.outbox, outboxdom.AggregateOrder, created.ID)
+
 	return created, nil
 }
 
+// rollbackOrderCreate は保存できなかった注文のために確保した
+// 在庫引当・offer・クーポン利用を解放する。
+//
+// 解放の失敗は元のエラーを優先して返すためログに残す。
+// 在庫引当の残りは期限到来時に sweeper が解放する（注文が存在しないため）。
+func (u *OrderUsecase) rollbackOrderCreate(
+	ctx context.Context,
+	order orderdom.Order,
+	attachedOfferIDs []string,
+	releaseReservation bool,
+) {
+	if releaseReservation && u.inventoryReserver != nil {
+		if err := u.inventoryReserver.ReleaseForOrder(
+			ctx,
+			order.ID,
+			inventorydom.ReservationReleaseReasonOrderFailed,
+		); err != nil {
+			log.Printf(
+				"order usecase: release inventory after failed order create orderId=%q err=%v",
+				order.ID,
+				err,
+			)
+		}
+	}
+
+	u.releaseOfferOrders(ctx, order.ID, attachedOfferIDs)
+
+	if order.Discount != nil && u.couponApplier != nil {
+		if err := u.couponApplier.ReleaseForOrder(
+			ctx,
+			order.ID,
+		); err != nil {
+			log.Printf(
+				"order usecase: release coupon after failed order create orderId=%q err=%v",
+				order.ID,
+				err,
+			)
+		}
+	}
+}
+
+// PreviewOrderDiscountInput prices a coupon before the Order exists
+// (cart / shipping quote).
+type PreviewOrderDiscountInput struct {
+	AvatarID   string
+	CouponCode string
+	Items      []CreateOrderItemInput
+
+	// ShippingQuote is optional. Without it free_shipping coupons are
+	// validated but discount nothing yet.
+	ShippingQuote orderdom.ShippingQuoteSnapshot
+}
+
+// PreviewDiscount returns the discount the coupon would give if the Order
+// were created now. The usage count is not reserved.
+func (u *OrderUsecase) PreviewDiscount(
+	ctx context.Context,
+	in PreviewOrderDiscountInput,
+) (orderdom.DiscountSnapshot, error) {
+	if u.couponApplier == nil {
+		return orderdom.DiscountSnapshot{}, ErrOrderCouponNotConfigured
+	}
+
+	items, err := u.resolveOrderItems(
+		ctx,
+		in.AvatarID,
+		in.Items,
+	)
+	if err != nil {
+		return orderdom.DiscountSnapshot{}, err
+	}
+
+	return u.couponApplier.QuoteDiscount(
+		ctx,
+		CouponDiscountInput{
+			Code:          in.CouponCode,
+			AvatarID:      in.AvatarID,
+			Items:         items,
+			ShippingQuote: in.ShippingQuote,
+		},
+	)
+}
+
 type UpdateOrderInput struct {
 	ID string
 
@@ -277,6 +678,8 @@ func (u *OrderUsecase) Update(
 		return orderdom.Order{}, err
 	}
 
+	before := orderAuditSnapshot(order)
+
 	if in.UserID != nil {
 		order.UserID = *in.UserID
 	}
@@ -354,6 +757,7 @@ func (u *OrderUsecase) Update(
 	if in.ReplaceItems != nil {
 		items, err := u.resolveOrderItems(
 			ctx,
+			order.AvatarID,
 			*in.ReplaceItems,
 		)
 		if err != nil {
@@ -421,11 +825,25 @@ func (u *OrderUsecase) Update(
 		return orderdom.Order{}, err
 	}
 
-	checked.Paid = order.Paid
+	checked.UpdatePaid(order.Paid)
+	checked.Refunds = order.Refunds
 
 	// Repository.Update must persist the Order and replace its canonical
 	// orderTransferItems projection in the same Firestore transaction.
-	return u.repo.Update(ctx, checked, nil)
+	updated, err := u.repo.Update(ctx, checked, nil)
+	if err != nil {
+		return updated, err
+	}
+
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		EntityType: auditdom.EntityOrder,
+		EntityID:   updated.ID,
+		Action:     auditdom.ActionUpdate,
+		Before:     before,
+		After:      updated,
+	})
+
+	return updated, nil
 }
 
 type CancelOrderItemInput struct {
@@ -494,6 +912,8 @@ func (u *OrderUsecase) CancelItem(
 				orderdom.ErrConflict
 		}
 
+		before := orderAuditSnapshot(order)
+
 		if err :=
 			order.CancelItem(
 				in.ItemIndex,
@@ -512,6 +932,14 @@ func (u *OrderUsecase) CancelItem(
 		}
 
 		order = updated
+
+		recordAudit(ctx, u.audit, RecordAuditInput{
+			EntityType: auditdom.EntityOrder,
+			EntityID:   order.ID,
+			Action:     auditdom.ActionUpdate,
+			Before:     before,
+			After:      order,
+		})
 		targetItem =
 			order.Items[in.ItemIndex]
 	}
@@ -532,15 +960,124 @@ func (u *OrderUsecase) CancelItem(
 				); err != nil {
 			return orderdom.Order{}, err
 		}
+
+		EvaluateStockLevelBestEffort(
+			ctx,
+			u.stockLevelEvaluator,
+			targetItem.InventoryID,
+			targetItem.ModelID,
+		)
+
+		// 全てのlist itemがキャンセルされた場合は、引当レコードも解放済みにする。
+		if u.inventoryReserver != nil &&
+			allListItemsCancelled(order) {
+			if err :=
+				u.inventoryReserver.ReleaseForOrder(
+					ctx,
+					order.ID,
+					inventorydom.ReservationReleaseReasonOrderCanceled,
+				); err != nil {
+				return orderdom.Order{}, err
+			}
+		}
+
+		// クーポンの利用回数も戻す。
+		if u.couponApplier != nil &&
+			order.Discount != nil &&
+			allListItemsCancelled(order) {
+			if err :=
+				u.couponApplier.ReleaseForOrder(
+					ctx,
+					order.ID,
+				); err != nil {
+				return orderdom.Order{}, err
+			}
+		}
+	}
+
+	// offer の合意価格で購入した resale item は、offer を終了して resale の確保を戻す。
+	if targetItem.Type ==
+		orderdom.OrderItemTypeResale &&
+		targetItem.OfferID != "" &&
+		u.offerCheckout != nil {
+		if err :=
+			u.offerCheckout.CancelCheckout(
+				ctx,
+				targetItem.OfferID,
+				order.ID,
+			); err != nil {
+			return orderdom.Order{}, err
+		}
+	}
+
+	// 決済済みOrder（他の明細が発送済み）の明細キャンセルは返金する。
+	// 再実行時は返金済み数量を差し引くため、二重返金しない。
+	if order.Paid &&
+		u.refundIssuer != nil {
+		remaining :=
+			targetItem.Qty -
+				order.ItemRefundedQty(
+					in.ItemIndex,
+				)
+
+		if remaining > 0 {
+			if _, err :=
+				u.refundIssuer.IssueRefund(
+					ctx,
+					IssueRefundInput{
+						OrderID: order.ID,
+						Items: []IssueRefundItemInput{
+							{
+								ItemIndex: in.ItemIndex,
+								Qty:       remaining,
+							},
+						},
+						Reason:      refunddom.ReasonRequestedByCustomer,
+						Note:        "order item cancelled",
+						RequestedBy: avatarID,
+					},
+				); err != nil &&
+				!errors.Is(err, refunddom.ErrItemNotRefundable) &&
+				!errors.Is(err, refunddom.ErrQtyExceedsRemaining) &&
+				!errors.Is(err, refunddom.ErrNothingToRefund) {
+				return orderdom.Order{}, err
+			}
+
+			if refreshed, err :=
+				u.repo.GetByID(
+					ctx,
+					order.ID,
+				); err == nil {
+				order = refreshed
+			}
+		}
 	}
 
 	return order, nil
 }
 
+func allListItemsCancelled(
+	order orderdom.Order,
+) bool {
+	for _, item := range order.Items {
+		if item.Type == orderdom.OrderItemTypeList &&
+			!item.IsCancelled {
+			return false
+		}
+	}
+
+	return true
+}
+
 type DispatchOrderItemsInput struct {
 	ID string
 
 	AllowedInventoryIDs map[string]struct{}
+
+	// Carrier and TrackingNumber identify the parcel.
+	// An empty Carrier falls back to the carrier quoted at checkout.
+	Carrier        transportationdom.Carrier
+	TrackingNumber string
 }
 
 type DispatchOrderItemsResult struct {
@@ -559,6 +1096,20 @@ func (u *OrderUsecase) PrepareDispatchItems(
 			orderdom.ErrInvalidID
 	}
 
+	// 発送時決済の前に伝票情報を検証する。
+	if in.Carrier != "" &&
+		!transportationdom.IsValidCarrier(in.Carrier) {
+		return DispatchOrderItemsResult{},
+			orderdom.ErrInvalidDispatch
+	}
+
+	if _, err :=
+		transportationdom.NormalizeTrackingNumber(
+			in.TrackingNumber,
+		); err != nil {
+		return DispatchOrderItemsResult{}, err
+	}
+
 	order, err :=
 		u.repo.GetByID(
 			ctx,
@@ -627,6 +1178,8 @@ func (u *OrderUsecase) DispatchItems(
 			orderdom.ErrConflict
 	}
 
+	before := orderAuditSnapshot(order)
+
 	targetItems := make(
 		[]orderdom.OrderItemSnapshot,
 		0,
@@ -646,18 +1199,42 @@ func (u *OrderUsecase) DispatchItems(
 			continue
 		}
 
-		if !item.IsDispatched {
-			if err :=
-				order.UpdateItemDispatched(
-					index,
-					true,
-				); err != nil {
-				return DispatchOrderItemsResult{}, err
-			}
+		// 配達済み・返品済みの明細は発送し直さない。
+		if status := order.ItemStatus(index); status == orderdom.StatusDelivered ||
+			status == orderdom.StatusReturned {
+			targetItems =
+				append(
+					targetItems,
+					item,
+				)
+			continue
+		}
+
+		dispatch, err :=
+			orderdom.NewDispatchSnapshot(
+				resolveDispatchCarrier(
+					order,
+					item,
+					in.Carrier,
+				),
+				in.TrackingNumber,
+				u.now(),
+			)
+		if err != nil {
+			return DispatchOrderItemsResult{}, err
+		}
 
-			changed = true
+		itemChanged, err :=
+			order.DispatchItem(
+				index,
+				dispatch,
+			)
+		if err != nil {
+			return DispatchOrderItemsResult{}, err
 		}
 
+		changed = changed || itemChanged
+
 		targetItems =
 			append(
 				targetItems,
@@ -671,6 +1248,20 @@ func (u *OrderUsecase) DispatchItems(
 	}
 
 	if changed {
+		// 発送通知は ItemDispatched から outbox 経由で行う。
+		inventoryIDs := make([]string, 0, len(targetItems))
+		for _, item := range targetItems {
+			inventoryIDs = append(inventoryIDs, item.InventoryID)
+		}
+
+		if err := order.RecordItemsDispatched(
+			CompanyIDFromContext(ctx),
+			inventoryIDs,
+			u.now(),
+		); err != nil {
+			return DispatchOrderItemsResult{}, err
+		}
+
 		updated, err :=
 			u.repo.Update(
 				ctx,
@@ -682,6 +1273,16 @@ func (u *OrderUsecase) DispatchItems(
 		}
 
 		order = updated
+
+		publishOutbox(ctx, u.outbox, outboxdom.AggregateOrder, order.ID)
+
+		recordAudit(ctx, u.audit, RecordAuditInput{
+			EntityType: auditdom.EntityOrder,
+			EntityID:   order.ID,
+			Action:     auditdom.ActionDispatch,
+			Before:     before,
+			After:      order,
+		})
 	}
 
 	return DispatchOrderItemsResult{
@@ -692,6 +1293,295 @@ func (u *OrderUsecase) DispatchItems(
 	}, nil
 }
 
+// orderAuditSnapshot copies the items so that in-place item transitions do
+// not leak into the audit "before" value.
+func orderAuditSnapshot(order orderdom.Order) orderdom.Order {
+	order.Items = append([]orderdom.OrderItemSnapshot(nil), order.Items...)
+	return order
+}
+
+// resolveDispatchCarrier returns the requested carrier, or the carrier
+// quoted at checkout for the item's inventory/model.
+func resolveDispatchCarrier(
+	order orderdom.Order,
+	item orderdom.OrderItemSnapshot,
+	requested transportationdom.Carrier,
+) transportationdom.Carrier {
+	if requested != "" {
+		return requested
+	}
+
+	for _, quoted := range order.ShippingQuoteSnapshot.Items {
+		if quoted.InventoryID == item.InventoryID &&
+			quoted.ModelID == item.ModelID {
+			return transportationdom.Carrier(quoted.Carrier)
+		}
+	}
+
+	return ""
+}
+
+type UpdateOrderItemsStatusInput struct {
+	ID string
+
+	AllowedInventoryIDs map[string]struct{}
+
+	// Status is preparing or delivered.
+	// Dispatch goes through DispatchItems so the parcel is recorded.
+	Status orderdom.Status
+}
+
+// UpdateItemsStatus moves the items the company owns to Status.
+// Items already in Status are left unchanged.
+func (u *OrderUsecase) UpdateItemsStatus(
+	ctx context.Context,
+	in UpdateOrderItemsStatusInput,
+) (DispatchOrderItemsResult, error) {
+	if in.ID == "" {
+		return DispatchOrderItemsResult{},
+			orderdom.ErrInvalidID
+	}
+
+	if in.Status != orderdom.StatusPreparing &&
+		in.Status != orderdom.StatusDelivered {
+		return DispatchOrderItemsResult{},
+			orderdom.ErrInvalidStatus
+	}
+
+	order, err :=
+		u.repo.GetByID(
+			ctx,
+			in.ID,
+		)
+	if err != nil {
+		return DispatchOrderItemsResult{}, err
+	}
+
+	before := orderAuditSnapshot(order)
+
+	targetItems := make(
+		[]orderdom.OrderItemSnapshot,
+		0,
+		len(order.Items),
+	)
+	changed := false
+	now := u.now()
+
+	for index := range order.Items {
+		item := order.Items[index]
+
+		if _, ok :=
+			in.AllowedInventoryIDs[item.InventoryID]; !ok {
+			continue
+		}
+
+		if item.IsCancelled {
+			continue
+		}
+
+		var itemChanged bool
+
+		switch in.Status {
+		case orderdom.StatusPreparing:
+			itemChanged, err = order.MarkItemPreparing(index)
+		case orderdom.StatusDelivered:
+			itemChanged, err = order.MarkItemDelivered(index, now)
+		}
+		if err != nil {
+			return DispatchOrderItemsResult{}, err
+		}
+
+		changed = changed || itemChanged
+
+		targetItems =
+			append(
+				targetItems,
+				order.Items[index],
+			)
+	}
+
+	if len(targetItems) == 0 {
+		return DispatchOrderItemsResult{},
+			orderdom.ErrNotFound
+	}
+
+	if changed {
+		updated, err :=
+			u.repo.Update(
+				ctx,
+				order,
+				nil,
+			)
+		if err != nil {
+			return DispatchOrderItemsResult{}, err
+		}
+
+		order = updated
+
+		recordAudit(ctx, u.audit, RecordAuditInput{
+			EntityType: auditdom.EntityOrder,
+			EntityID:   order.ID,
+			Action:     auditdom.ActionUpdate,
+			Before:     before,
+			After:      order,
+		})
+	}
+
+	return DispatchOrderItemsResult{
+		Order: order,
+
+		TargetItems: targetItems,
+		Changed:     changed,
+	}, nil
+}
+
+// CreateReplacementOrderInput identifies the returned item to re-ship.
+//
+// ID must be deterministic per return so that retries return the same order
+// instead of shipping the item twice.
+type CreateReplacementOrderInput struct {
+	ID string
+
+	SourceOrderID string
+	ItemIndex     int
+}
+
+// CreateReplacementOrder creates a paid, zero-priced order that re-ships one
+// list item of SourceOrderID to the original shipping address.
+//
+// The shipping quote is copied with amount 0 because the brand bears the
+// replacement shipping. Inventory is reserved before the order is saved and
+// confirmed immediately since no payment follows.
+func (u *OrderUsecase) CreateReplacementOrder(
+	ctx context.Context,
+	in CreateReplacementOrderInput,
+) (orderdom.Order, error) {
+	id := strings.TrimSpace(in.ID)
+	if id == "" {
+		return orderdom.Order{}, orderdom.ErrInvalidID
+	}
+
+	existing, err := u.repo.GetByID(ctx, id)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, orderdom.ErrNotFound) {
+		return orderdom.Order{}, err
+	}
+
+	source, err := u.repo.GetByID(
+		ctx,
+		strings.TrimSpace(in.SourceOrderID),
+	)
+	if err != nil {
+		return orderdom.Order{}, err
+	}
+
+	if in.ItemIndex < 0 || in.ItemIndex >= len(source.Items) {
+		return orderdom.Order{}, orderdom.ErrInvalidItems
+	}
+
+	sourceItem := source.Items[in.ItemIndex]
+	if sourceItem.Type != orderdom.OrderItemTypeList {
+		return orderdom.Order{}, orderdom.ErrInvalidItems
+	}
+
+	item := orderdom.OrderItemSnapshot{
+		Type: orderdom.OrderItemTypeList,
+
+		ModelID:     sourceItem.ModelID,
+		InventoryID: sourceItem.InventoryID,
+		ListID:      sourceItem.ListID,
+
+		ProductBlueprintID: sourceItem.ProductBlueprintID,
+		TokenBlueprintID:   sourceItem.TokenBlueprintID,
+		BrandID:            sourceItem.BrandID,
+
+		ProductBlueprintCategoryPath: append(
+			[]string(nil),
+			sourceItem.ProductBlueprintCategoryPath...,
+		),
+
+		ConsumptionTaxRate: sourceItem.ConsumptionTaxRate,
+
+		Qty:   sourceItem.Qty,
+		Price: 0,
+	}
+
+	var quoteItem *orderdom.ShippingQuoteItemSnapshot
+	for _, q := range source.ShippingQuoteSnapshot.Items {
+		if q.ListID == sourceItem.ListID &&
+			q.ModelID == sourceItem.ModelID {
+			q := q
+			quoteItem = &q
+			break
+		}
+	}
+	if quoteItem == nil {
+		return orderdom.Order{}, orderdom.ErrInvalidShippingQuote
+	}
+
+	quoteItem.Qty = sourceItem.Qty
+	quoteItem.UnitAmount = 0
+	quoteItem.Amount = 0
+
+	order, err := orderdom.New(
+		id,
+		source.UserID,
+		source.AvatarID,
+		source.CartID,
+		source.ShippingSnapshot,
+		orderdom.ShippingQuoteSnapshot{
+			Items:    []orderdom.ShippingQuoteItemSnapshot{*quoteItem},
+			Amount:   0,
+			Currency: orderdom.ShippingQuoteCurrencyJPY,
+		},
+		source.PaymentMethodSnapshot,
+		[]orderdom.OrderItemSnapshot{item},
+		u.now().UTC(),
+	)
+	if err != nil {
+		return orderdom.Order{}, err
+	}
+
+	order.UpdatePaid(true)
+
+	// 通常の注文と同じく、在庫を引き当ててから保存する（在庫不足なら作成しない）。
+	if u.inventoryReserver != nil {
+		if _, err := u.inventoryReserver.ReserveForOrder(
+			ctx,
+			order,
+		); err != nil {
+			u.rollbackOrderCreate(ctx, order, nil, true)
+			return orderdom.Order{}, err
+		}
+	}
+
+	created, err := u.repo.Create(ctx, order)
+	if err != nil {
+		u.rollbackOrderCreate(ctx, order, nil, u.inventoryReserver != nil)
+		return orderdom.Order{}, err
+	}
+
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		EntityType: auditdom.EntityOrder,
+		EntityID:   created.ID,
+		Action:     auditdom.ActionCreate,
+		After:      created,
+	})
+
+	if u.inventoryReserver != nil {
+		if err := u.inventoryReserver.ConfirmForOrder(
+			ctx,
+			created.ID,
+		); err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
 // =======================
 // Shipping snapshot
 // =======================
@@ -883,7 +1773,7 @@ func (u *OrderUsecase) resolveShippingQuoteSnapshot(
 					DestinationShippingAddressID: quote.DestinationShippingAddressID,
 
 					Carrier: string(
-						quote.TransportationOption,
+						quote.Carrier,
 					),
 
 					TransportationID: quote.TransportationID,
@@ -897,6 +1787,10 @@ func (u *OrderUsecase) resolveShippingQuoteSnapshot(
 					Amount: lineAmount,
 
 					Currency: quote.Currency,
+
+					Customs: customsDeclarationSnapshot(
+						quote.Customs,
+					),
 				},
 			)
 	}
@@ -910,6 +1804,26 @@ func (u *OrderUsecase) resolveShippingQuoteSnapshot(
 	}, nil
 }
 
+func customsDeclarationSnapshot(
+	customs *ShippingQuoteCustoms,
+) *orderdom.CustomsDeclarationSnapshot {
+	if customs == nil {
+		return nil
+	}
+
+	return &orderdom.CustomsDeclarationSnapshot{
+		HSCode:        customs.HSCode,
+		Description:   customs.Description,
+		OriginCountry: customs.OriginCountry,
+
+		DeclaredUnitValue: int(
+			customs.DeclaredUnitValue,
+		),
+
+		Currency: orderdom.ShippingQuoteCurrencyJPY,
+	}
+}
+
 func resolveOrderDestinationShippingAddressID(
 	snapshot orderdom.ShippingQuoteSnapshot,
 ) (string, error) {
@@ -1031,6 +1945,7 @@ func (u *OrderUsecase) resolvePaymentMethodSnapshot(
 
 func (u *OrderUsecase) resolveOrderItems(
 	ctx context.Context,
+	avatarID string,
 	input []CreateOrderItemInput,
 ) ([]orderdom.OrderItemSnapshot, error) {
 	items := make(
@@ -1044,6 +1959,7 @@ func (u *OrderUsecase) resolveOrderItems(
 		case orderdom.OrderItemTypeList:
 			resolved, err := u.resolveListOrderItem(
 				ctx,
+				avatarID,
 				item,
 			)
 			if err != nil {
@@ -1055,6 +1971,7 @@ func (u *OrderUsecase) resolveOrderItems(
 		case orderdom.OrderItemTypeResale:
 			resolved, err := u.resolveResaleOrderItem(
 				ctx,
+				avatarID,
 				item,
 			)
 			if err != nil {
@@ -1140,6 +2057,7 @@ func (u *OrderUsecase) resolveProductBlueprintTaxSnapshot(
 
 func (u *OrderUsecase) resolveListOrderItem(
 	ctx context.Context,
+	avatarID string,
 	item CreateOrderItemInput,
 ) (orderdom.OrderItemSnapshot, error) {
 	if item.ListID == "" ||
@@ -1162,6 +2080,21 @@ func (u *OrderUsecase) resolveListOrderItem(
 			orderdom.ErrInvalidItemSnapshot
 	}
 
+	if list.IsHolderOnly() {
+		if u.listAccess == nil {
+			return orderdom.OrderItemSnapshot{},
+				ErrOrderListAccessNotConfigured
+		}
+
+		if err := u.listAccess.EnsureListAccess(
+			ctx,
+			avatarID,
+			list,
+		); err != nil {
+			return orderdom.OrderItemSnapshot{}, err
+		}
+	}
+
 	inventory, err := u.inventoryRepo.GetByID(
 		ctx,
 		list.InventoryID,
@@ -1244,6 +2177,7 @@ func resolveListModelPrice(
 
 func (u *OrderUsecase) resolveResaleOrderItem(
 	ctx context.Context,
+	avatarID string,
 	item CreateOrderItemInput,
 ) (orderdom.OrderItemSnapshot, error) {
 	if item.ResaleID == "" {
@@ -1259,7 +2193,36 @@ func (u *OrderUsecase) resolveResaleOrderItem(
 		return orderdom.OrderItemSnapshot{}, err
 	}
 
-	if resale.Status != resaledom.StatusListing {
+	price := resale.Price
+	offerID := strings.TrimSpace(item.OfferID)
+
+	// accept された offer の resale は reserved になり、
+	// offer の購入者だけが合意価格で購入できる。
+	switch {
+	case offerID != "":
+		if u.offerCheckout == nil {
+			return orderdom.OrderItemSnapshot{},
+				ErrOrderOfferNotConfigured
+		}
+
+		if resale.Status != resaledom.StatusReserved {
+			return orderdom.OrderItemSnapshot{},
+				orderdom.ErrInvalidItemSnapshot
+		}
+
+		price, err = u.offerCheckout.ResolveCheckoutPrice(
+			ctx,
+			OfferCheckoutInput{
+				OfferID:       offerID,
+				ResaleID:      resale.ID,
+				BuyerAvatarID: avatarID,
+			},
+		)
+		if err != nil {
+			return orderdom.OrderItemSnapshot{}, err
+		}
+
+	case resale.Status != resaledom.StatusListing:
 		return orderdom.OrderItemSnapshot{},
 			orderdom.ErrInvalidItemSnapshot
 	}
@@ -1276,7 +2239,7 @@ func (u *OrderUsecase) resolveResaleOrderItem(
 			err
 	}
 
-	return orderdom.OrderItemSnapshot{
+	snapshot := orderdom.OrderItemSnapshot{
 		Type:               orderdom.OrderItemTypeResale,
 		ResaleID:           resale.ID,
 		ProductID:          resale.ProductID,
@@ -1289,12 +2252,81 @@ func (u *OrderUsecase) resolveResaleOrderItem(
 		ConsumptionTaxRate: consumptionTaxRate,
 
 		Qty:           1,
-		Price:         resale.Price,
+		Price:         price,
 		IsCancelled:   false,
 		IsDispatched:  false,
 		Transferred:   false,
 		TransferredAt: nil,
-	}, nil
+
+		OfferID: offerID,
+	}
+
+	if u.royaltyQuoter != nil {
+		royalty, err := u.royaltyQuoter.QuoteRoyalty(
+			ctx,
+			snapshot,
+		)
+		if err != nil {
+			return orderdom.OrderItemSnapshot{}, err
+		}
+
+		snapshot.Royalty = royalty
+	}
+
+	return snapshot, nil
+}
+
+// attachOfferOrders は合意価格で購入する offer に注文を紐づけ、
+// 紐づけた offerId を返す。途中で失敗した場合は紐づけ済みの分を戻す。
+func (u *OrderUsecase) attachOfferOrders(
+	ctx context.Context,
+	order orderdom.Order,
+) ([]string, error) {
+	attached := make([]string, 0)
+
+	for _, item := range order.Items {
+		if item.OfferID == "" {
+			continue
+		}
+
+		if u.offerCheckout == nil {
+			return nil, ErrOrderOfferNotConfigured
+		}
+
+		if err := u.offerCheckout.AttachOrder(
+			ctx,
+			item.OfferID,
+			order.ID,
+		); err != nil {
+			u.releaseOfferOrders(ctx, order.ID, attached)
+			return nil, err
+		}
+
+		attached = append(attached, item.OfferID)
+	}
+
+	return attached, nil
+}
+
+func (u *OrderUsecase) releaseOfferOrders(
+	ctx context.Context,
+	orderID string,
+	offerIDs []string,
+) {
+	for _, offerID := range offerIDs {
+		if err := u.offerCheckout.ReleaseOrder(
+			ctx,
+			offerID,
+			orderID,
+		); err != nil {
+			log.Printf(
+				"order usecase: release offer after failed order create orderId=%q offerId=%q err=%v",
+				orderID,
+				offerID,
+				err,
+			)
+		}
+	}
 }
 
 // =======================
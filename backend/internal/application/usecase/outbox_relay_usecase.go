@@ -0,0 +1,289 @@
+// backend/internal/application/usecase/outbox_relay_usecase.go
+package usecase
+
+/*
+責務:
+- 集約と同じ transaction で outbox に保存されたドメインイベントを、購読ハンドラへ配信する。
+- 配信は at-least-once。イベントのリースを取得してからハンドラを呼び、
+  全ハンドラが成功したら published、失敗したら backoff 付きで再試行する。
+
+前提:
+- 書き込み直後の usecase は PublishPending で集約のイベントをその場で配信する（低遅延）。
+  失敗・停止したイベントは RelayDue（internal endpoint から定期実行）が回収する。
+- 1 イベントに複数のハンドラがある場合、1 つの失敗で全ハンドラが再実行されるため、
+  ハンドラは冪等である必要がある。
+- 購読していない型のイベントは配信しない（購読している別のプロセスが配信する）。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	outboxdom "narratives/internal/domain/outbox"
+)
+
+const (
+	defaultOutboxLeaseDuration = 2 * time.Minute
+	defaultOutboxRetryDelay    = 30 * time.Second
+	maxOutboxRetryDelay        = 1 * time.Hour
+)
+
+// OutboxEventHandler はドメインイベントを処理します。冪等である必要があります。
+type OutboxEventHandler interface {
+	HandleOutboxEvent(ctx context.Context, e outboxdom.Event) error
+}
+
+// OutboxEventHandlerFunc は関数を OutboxEventHandler として扱います。
+type OutboxEventHandlerFunc func(ctx context.Context, e outboxdom.Event) error
+
+func (f OutboxEventHandlerFunc) HandleOutboxEvent(
+	ctx context.Context,
+	e outboxdom.Event,
+) error {
+	return f(ctx, e)
+}
+
+// OutboxPublisher は書き込み直後に集約の未配信イベントを配信します。
+type OutboxPublisher interface {
+	PublishPending(
+		ctx context.Context,
+		aggregateType outboxdom.AggregateType,
+		aggregateID string,
+	)
+}
+
+var ErrOutboxNotConfigured = errors.New(
+	"outbox: relay is not configured",
+)
+
+type OutboxRelayUsecase struct {
+	repo     outboxdom.RepositoryPort
+	handlers map[outboxdom.EventType][]OutboxEventHandler
+
+	now           func() time.Time
+	leaseDuration time.Duration
+	retryDelay    time.Duration
+}
+
+var _ OutboxPublisher = (*OutboxRelayUsecase)(nil)
+
+func NewOutboxRelayUsecase(repo outboxdom.RepositoryPort) *OutboxRelayUsecase {
+	return &OutboxRelayUsecase{
+		repo:     repo,
+		handlers: map[outboxdom.EventType][]OutboxEventHandler{},
+
+		now:           time.Now,
+		leaseDuration: defaultOutboxLeaseDuration,
+		retryDelay:    defaultOutboxRetryDelay,
+	}
+}
+
+// Subscribe は eventType のハンドラを追加します。nil のハンドラは無視します。
+func (u *OutboxRelayUsecase) Subscribe(
+	eventType outboxdom.EventType,
+	handler OutboxEventHandler,
+) *OutboxRelayUsecase {
+	if u == nil || handler == nil {
+		return u
+	}
+
+	u.handlers[eventType] = append(u.handlers[eventType], handler)
+	return u
+}
+
+// ============================================================
+// Publish (inline)
+// ============================================================
+
+// PublishPending は集約の未配信イベントをその場で配信します（best-effort）。
+// 失敗したイベントは outbox に残り、RelayDue が再試行します。
+func (u *OutboxRelayUsecase) PublishPending(
+	ctx context.Context,
+	aggregateType outboxdom.AggregateType,
+	aggregateID string,
+) {
+	if u == nil || u.repo == nil {
+		return
+	}
+
+	events, err := u.repo.ListPendingByAggregate(ctx, aggregateType, aggregateID)
+	if err != nil {
+		log.Printf(
+			"outbox: list pending %s id=%q err=%v",
+			aggregateType,
+			aggregateID,
+			err,
+		)
+		return
+	}
+
+	for _, e := range events {
+		if len(u.handlers[e.Type]) == 0 {
+			continue
+		}
+
+		if _, err := u.relay(ctx, e); err != nil {
+			log.Printf(
+				"outbox: publish %s eventId=%q err=%v",
+				e.Type,
+				e.ID,
+				err,
+			)
+		}
+	}
+}
+
+// publishOutbox は publisher が設定されていれば集約のイベントを配信します。
+func publishOutbox(
+	ctx context.Context,
+	publisher OutboxPublisher,
+	aggregateType outboxdom.AggregateType,
+	aggregateID string,
+) {
+	if publisher == nil {
+		return
+	}
+
+	publisher.PublishPending(ctx, aggregateType, aggregateID)
+}
+
+// ============================================================
+// Relay due (sweeper)
+// ============================================================
+
+// RelayDueOutboxEventsResult は sweeper 1 回分の処理結果。
+type RelayDueOutboxEventsResult struct {
+	Scanned   int `json:"scanned"`
+	Published int `json:"published"`
+	Retried   int `json:"retried"`
+	Skipped   int `json:"skipped"`
+	Failed    int `json:"failed"`
+}
+
+// RelayDue は購読している型の配信対象イベントを配信します。
+// 1 件の失敗で残りの処理を止めず、最初のエラーを結果と一緒に返す。
+func (u *OutboxRelayUsecase) RelayDue(
+	ctx context.Context,
+	limit int,
+) (RelayDueOutboxEventsResult, error) {
+	var result RelayDueOutboxEventsResult
+
+	if u == nil || u.repo == nil {
+		return result, ErrOutboxNotConfigured
+	}
+
+	types := make([]outboxdom.EventType, 0, len(u.handlers))
+	for t := range u.handlers {
+		types = append(types, t)
+	}
+
+	due, err := u.repo.ListDue(ctx, types, u.now().UTC(), limit)
+	if err != nil {
+		return result, err
+	}
+
+	var firstErr error
+
+	for _, e := range due {
+		result.Scanned++
+
+		published, err := u.relay(ctx, e)
+		switch {
+		case errors.Is(err, outboxdom.ErrNotClaimable):
+			// 他の worker が処理中・処理済み
+			result.Skipped++
+
+		case err != nil:
+			result.Failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+
+		case published:
+			result.Published++
+
+		default:
+			result.Retried++
+		}
+	}
+
+	return result, firstErr
+}
+
+// relay はイベントのリースを取得してハンドラを呼び出します。
+// ハンドラの失敗は再試行として記録し、published=false, err=nil を返します。
+func (u *OutboxRelayUsecase) relay(
+	ctx context.Context,
+	e outboxdom.Event,
+) (bool, error) {
+	now := u.now().UTC()
+
+	claimed, err := u.repo.Claim(ctx, e.ID, now, now.Add(u.leaseDuration))
+	if err != nil {
+		return false, err
+	}
+
+	if handleErr := u.handle(ctx, claimed); handleErr != nil {
+		failedAt := u.now().UTC()
+
+		log.Printf(
+			"outbox: handle %s eventId=%q attempt=%d err=%v",
+			claimed.Type,
+			claimed.ID,
+			claimed.Attempts,
+			handleErr,
+		)
+
+		if err := u.repo.MarkRetry(
+			ctx,
+			claimed.ID,
+			claimed.Attempts,
+			handleErr.Error(),
+			failedAt.Add(u.retryDelayForAttempt(claimed.Attempts)),
+			failedAt,
+		); err != nil {
+			return false, fmt.Errorf("mark outbox event %q retry: %w", claimed.ID, err)
+		}
+
+		return false, nil
+	}
+
+	if err := u.repo.MarkPublished(
+		ctx,
+		claimed.ID,
+		claimed.Attempts,
+		u.now().UTC(),
+	); err != nil {
+		return false, fmt.Errorf("mark outbox event %q published: %w", claimed.ID, err)
+	}
+
+	return true, nil
+}
+
+func (u *OutboxRelayUsecase) handle(
+	ctx context.Context,
+	e outboxdom.Event,
+) error {
+	for _, h := range u.handlers[e.Type] {
+		if err := h.HandleOutboxEvent(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retryDelayForAttempt は試行回数に応じて倍々に増やした待ち時間を返します。
+func (u *OutboxRelayUsecase) retryDelayForAttempt(attempt int) time.Duration {
+	delay := u.retryDelay
+	for i := 1; i < attempt && delay < maxOutboxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxOutboxRetryDelay {
+		return maxOutboxRetryDelay
+	}
+	return delay
+}
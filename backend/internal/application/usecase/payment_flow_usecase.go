@@ -932,8 +932,10 @@ func calculatePaymentOrderAmount(
 
 	maxInt := int(^uint(0) >> 1)
 
+	// coupon の送料値引きは送料から差し引く（値引き後の金額に課税）。
 	shippingAmount :=
-		order.ShippingQuoteSnapshot.Amount
+		order.ShippingQuoteSnapshot.Amount -
+			order.ShippingDiscountAmount()
 
 	if shippingAmount < 0 {
 		return 0, ErrPaymentFlowOrderAmountInvalid
@@ -944,7 +946,7 @@ func calculatePaymentOrderAmount(
 	taxableAmount10 :=
 		shippingAmount
 
-	for _, item := range order.Items {
+	for i, item := range order.Items {
 		if item.Price < 0 || item.Qty <= 0 {
 			return 0, ErrPaymentFlowOrderAmountInvalid
 		}
@@ -954,8 +956,13 @@ func calculatePaymentOrderAmount(
 		}
 
 		lineAmount :=
-			item.Price *
-				item.Qty
+			item.Price*
+				item.Qty -
+				order.ItemDiscountAmount(i)
+
+		if lineAmount < 0 {
+			return 0, ErrPaymentFlowOrderAmountInvalid
+		}
 
 		switch item.ConsumptionTaxRate {
 		case orderdom.ConsumptionTaxRateReduced:
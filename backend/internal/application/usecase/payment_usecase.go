@@ -22,10 +22,24 @@ Stripe状態同期:
   Firestore Transaction内で原子的に処理する。
 - PostPaidRequiredはPaymentが初めてsucceededへ遷移した
   1回だけtrueになる。
+- RepositoryはPostPaidRequiredの取得と同じTransactionで
+  PaymentSucceededをoutboxへ保存する。支払い後処理はその配信
+  （HandleOutboxEvent）で実行する。
 
 支払い成功後の処理:
 0) order.Paid=true更新
 1) resale status=sold更新（best-effort）
+2) 在庫引当の確定（best-effort）
+3) クーポン利用の確定（best-effort）
+4) resale itemのロイヤリティ台帳計上（best-effort）
+5) 合意価格で購入されたofferの完了（best-effort）
+6) resale itemの代金のescrowへの預かり（best-effort）
+7) list itemの販売代金・resale itemのロイヤリティの支払い台帳への計上（best-effort）
+
+決済失敗・決済キャンセル時の処理（失敗時はwebhookを再試行させる）:
+- 在庫引当の解放
+- クーポン利用の解放
+- 合意価格で注文されたofferの終了とresaleの確保解除
 
 注文受付時に行うべき処理:
 - inventory reserve
@@ -38,6 +52,7 @@ Stripe状態同期:
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -45,7 +60,9 @@ import (
 	applicationport "narratives/internal/application/port"
 	cartdom "narratives/internal/domain/cart"
 	common "narratives/internal/domain/common"
+	invdom "narratives/internal/domain/inventory"
 	orderdom "narratives/internal/domain/order"
+	outboxdom "narratives/internal/domain/outbox"
 	paymentdom "narratives/internal/domain/payment"
 	resaledom "narratives/internal/domain/resale"
 )
@@ -108,6 +125,45 @@ type ApplyStripePaymentEventResult struct {
 	PostPaidRequired bool
 }
 
+// StripeDisputeEventRepository applies Stripe dispute events atomically.
+//
+// Implementations must, in one transaction, check whether EventID has
+// already been processed, find the Payment by StripePaymentIntentID, apply
+// the dispute through Payment.ApplyDispute and record EventID as processed.
+type StripeDisputeEventRepository interface {
+	ApplyStripeDisputeEvent(
+		ctx context.Context,
+		in ApplyStripeDisputeEventInput,
+	) (*ApplyStripeDisputeEventResult, error)
+}
+
+// ApplyStripeDisputeEventInput is generated from a verified
+// charge.dispute.* webhook event.
+type ApplyStripeDisputeEventInput struct {
+	EventID string
+
+	StripePaymentIntentID string
+	StripeDisputeID       string
+
+	Status paymentdom.DisputeStatus
+	Reason string
+	Amount int
+
+	// OpenedAt is Dispute.created; OccurredAt is the event time.
+	OpenedAt   time.Time
+	OccurredAt time.Time
+}
+
+type ApplyStripeDisputeEventResult struct {
+	Payment *paymentdom.Payment
+
+	// EventApplied is false when EventID has already been processed.
+	EventApplied bool
+
+	// DisputeChanged is false for stale or repeated dispute states.
+	DisputeChanged bool
+}
+
 // InventoryRepoForPayment is retained as a wiring-compatible port while
 // inventory reservation is moved to the order-placement flow.
 //
@@ -159,8 +215,87 @@ type ResaleRepoForPayment interface {
 	) (resaledom.Resale, error)
 }
 
-// MailSenderForPayment is retained as a wiring-compatible port while
-// order-acceptance mail is moved to the order-placement flow.
+// InventoryReservationForPayment confirms or releases the expiring inventory
+// reservation of the Order paid by a Payment.
+//
+// Both operations must be idempotent because Stripe may deliver an event
+// more than once.
+type InventoryReservationForPayment interface {
+	ConfirmForOrder(
+		ctx context.Context,
+		orderID string,
+	) error
+
+	ReleaseForOrder(
+		ctx context.Context,
+		orderID string,
+		reason invdom.ReservationReleaseReason,
+	) error
+}
+
+// CouponRedemptionForPayment confirms or releases the coupon usage of the
+// Order paid by a Payment. Both operations must be idempotent and must be
+// no-ops for orders without a coupon.
+type CouponRedemptionForPayment interface {
+	ConfirmForOrder(
+		ctx context.Context,
+		orderID string,
+	) error
+
+	ReleaseForOrder(
+		ctx context.Context,
+		orderID string,
+	) error
+}
+
+// RoyaltyLedgerForPayment accrues the brand royalties of the resale items of
+// a paid Order. It must be idempotent and a no-op for orders without
+// royalties.
+type RoyaltyLedgerForPayment interface {
+	AccrueForOrder(
+		ctx context.Context,
+		order orderdom.Order,
+	) error
+}
+
+// OfferCheckoutForPayment completes the accepted market offers the resale
+// items of a paid Order were bought through, or ends them and frees the
+// reserved resales when the payment failed or was canceled. Both operations
+// must be idempotent and no-ops for orders without offers.
+type OfferCheckoutForPayment interface {
+	CompleteForOrder(
+		ctx context.Context,
+		order orderdom.Order,
+	) error
+
+	ReleaseForOrder(
+		ctx context.Context,
+		order orderdom.Order,
+	) error
+}
+
+// EscrowLedgerForPayment holds the proceeds of the resale items of a paid
+// Order in escrow until the buyer confirms receipt. It must be idempotent
+// and a no-op for orders without resale items.
+type EscrowLedgerForPayment interface {
+	HoldForOrder(
+		ctx context.Context,
+		order orderdom.Order,
+	) error
+}
+
+// PayoutLedgerForPayment accrues the brand sales and royalties of a paid
+// Order into the payout ledger. It must be idempotent.
+type PayoutLedgerForPayment interface {
+	AccrueForOrder(
+		ctx context.Context,
+		order orderdom.Order,
+	) error
+}
+
+// MailSenderForPayment is retained as a wiring-compatible port. The
+// order-acceptance mail is sent from the OrderCreated outbox event by
+// OrderAcceptanceMailUsecase.
 //
 // PaymentUsecase does not send order confirmation mail after payment.
 type MailSenderForPayment interface {
@@ -192,6 +327,9 @@ var (
 	ErrPaymentStripeEventResultEmpty = errors.New(
 		"payment: stripe event application result is empty",
 	)
+	ErrPaymentStripeDisputeRepositoryMissing = errors.New(
+		"payment: stripe dispute event repository is not configured",
+	)
 )
 
 // ============================================================
@@ -202,19 +340,29 @@ var (
 type PaymentUsecase struct {
 	repo paymentdom.RepositoryPort
 
-	stripeEventRepo StripePaymentEventRepository
+	stripeEventRepo   StripePaymentEventRepository
+	stripeDisputeRepo StripeDisputeEventRepository
 
 	cartRepo      cartdom.Repository
 	inventoryRepo InventoryRepoForPayment
 	orderRepo     OrderRepoForPayment
 	resaleRepo    ResaleRepoForPayment
 
+	inventoryReservations InventoryReservationForPayment
+	couponRedemptions     CouponRedemptionForPayment
+	royaltyLedger         RoyaltyLedgerForPayment
+	offers                OfferCheckoutForPayment
+	escrowLedger          EscrowLedgerForPayment
+	payoutLedger          PayoutLedgerForPayment
+
 	// authUserGetter gets the email associated with a UID from Firebase
 	// Authentication. Email is not stored in the Firestore users collection.
 	authUserGetter applicationport.AuthUserReader
 	mailSender     MailSenderForPayment
 	mailFrom       string
 
+	outbox OutboxPublisher
+
 	now func() time.Time
 }
 
@@ -230,10 +378,41 @@ type NewPaymentUsecaseInput struct {
 	OrderRepo     OrderRepoForPayment
 	ResaleRepo    ResaleRepoForPayment
 
+	// InventoryReservations may be omitted. When set, failed/canceled
+	// payments release the Order's inventory reservation and the first
+	// succeeded payment confirms it.
+	InventoryReservations InventoryReservationForPayment
+
+	// CouponRedemptions may be omitted. When set, the coupon usage of the
+	// Order is released together with the inventory reservation and
+	// confirmed by the first succeeded payment.
+	CouponRedemptions CouponRedemptionForPayment
+
+	// RoyaltyLedger may be omitted. When set, the first succeeded payment
+	// accrues the royalties recorded on the Order's resale items.
+	RoyaltyLedger RoyaltyLedgerForPayment
+
+	// Offers may be omitted. When set, the first succeeded payment completes
+	// the accepted offers referenced by the Order's resale items, and a
+	// failed/canceled payment ends them and frees their resales.
+	Offers OfferCheckoutForPayment
+
+	// Escrows may be omitted. When set, the first succeeded payment holds
+	// the proceeds of the Order's resale items until receipt is confirmed.
+	Escrows EscrowLedgerForPayment
+
+	// Payouts may be omitted. When set, the first succeeded payment accrues
+	// the Order's brand sales and royalties into the payout ledger.
+	Payouts PayoutLedgerForPayment
+
 	AuthUserGetter applicationport.AuthUserReader
 	MailSender     MailSenderForPayment
 	MailFrom       string
 
+	// Outbox publishes the PaymentSucceeded event right after it is stored.
+	// When omitted the event is delivered by the outbox relay sweeper only.
+	Outbox OutboxPublisher
+
 	Now func() time.Time
 }
 
@@ -253,19 +432,33 @@ func NewPaymentUsecase(
 		}
 	}
 
+	// Dispute events are applied by PaymentRepo when it supports them.
+	stripeDisputeRepo, _ :=
+		in.PaymentRepo.(StripeDisputeEventRepository)
+
 	return &PaymentUsecase{
-		repo:            in.PaymentRepo,
-		stripeEventRepo: stripeEventRepo,
+		repo:              in.PaymentRepo,
+		stripeEventRepo:   stripeEventRepo,
+		stripeDisputeRepo: stripeDisputeRepo,
 
 		cartRepo:      in.CartRepo,
 		inventoryRepo: in.InventoryRepo,
 		orderRepo:     in.OrderRepo,
 		resaleRepo:    in.ResaleRepo,
 
+		inventoryReservations: in.InventoryReservations,
+		couponRedemptions:     in.CouponRedemptions,
+		royaltyLedger:         in.RoyaltyLedger,
+		offers:                in.Offers,
+		escrowLedger:          in.Escrows,
+		payoutLedger:          in.Payouts,
+
 		authUserGetter: in.AuthUserGetter,
 		mailSender:     in.MailSender,
 		mailFrom:       in.MailFrom,
 
+		outbox: in.Outbox,
+
 		now: now,
 	}
 }
@@ -304,7 +497,8 @@ func (u *PaymentUsecase) GetByPaymentID(
 // for an already-succeeded Payment.
 //
 // The repository implementation must persist the post-paid execution marker
-// when it creates a Payment whose initial status is succeeded.
+// and the PaymentSucceeded outbox event when it creates a Payment whose
+// initial status is succeeded.
 func (u *PaymentUsecase) Create(
 	ctx context.Context,
 	payment paymentdom.Payment,
@@ -343,7 +537,12 @@ func (u *PaymentUsecase) Create(
 
 	if created != nil &&
 		created.Status == paymentdom.StatusSucceeded {
-		u.handlePostPaidBestEffort(ctx, created)
+		publishOutbox(
+			ctx,
+			u.outbox,
+			outboxdom.AggregatePayment,
+			created.PaymentID,
+		)
 	}
 
 	return created, nil
@@ -390,7 +589,8 @@ func (u *PaymentUsecase) Update(
 // StripePaymentEventRepository.
 //
 // A duplicate event is returned as a successful no-op.
-// Post-paid processing is executed only when PostPaidRequired is true.
+// Post-paid processing is executed only when PostPaidRequired is true, from
+// the PaymentSucceeded outbox event stored with the marker.
 func (u *PaymentUsecase) ApplyStripeEvent(
 	ctx context.Context,
 	in ApplyStripePaymentEventInput,
@@ -445,46 +645,269 @@ func (u *PaymentUsecase) ApplyStripeEvent(
 	}
 
 	if result.PostPaidRequired {
-		u.handlePostPaidBestEffort(
+		publishOutbox(
+			ctx,
+			u.outbox,
+			outboxdom.AggregatePayment,
+			result.Payment.PaymentID,
+		)
+	}
+
+	// Duplicate events also reach here so that a release which failed on a
+	// previous delivery is retried. ReleaseForOrder is idempotent.
+	if err := u.releaseInventoryReservationOnFailure(
+		ctx,
+		result.Payment,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := u.releaseCouponOnFailure(
+		ctx,
+		result.Payment,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := u.releaseOffersOnFailure(
+		ctx,
+		result.Payment,
+	); err != nil {
+		return nil, err
+	}
+
+	return result.Payment, nil
+}
+
+// ApplyStripeDisputeEvent records a verified charge.dispute.* event on the
+// Payment of the disputed PaymentIntent.
+//
+// A duplicate event is returned as a successful no-op. A PaymentIntent that
+// does not belong to this application returns paymentdom.ErrNotFound.
+// The dispute does not change Payment.Status.
+func (u *PaymentUsecase) ApplyStripeDisputeEvent(
+	ctx context.Context,
+	in ApplyStripeDisputeEventInput,
+) (*paymentdom.Payment, error) {
+	if u == nil || u.repo == nil {
+		return nil, paymentdom.ErrNotFound
+	}
+
+	if u.stripeDisputeRepo == nil {
+		return nil, ErrPaymentStripeDisputeRepositoryMissing
+	}
+
+	in.EventID = strings.TrimSpace(in.EventID)
+	in.StripePaymentIntentID = strings.TrimSpace(in.StripePaymentIntentID)
+	in.StripeDisputeID = strings.TrimSpace(in.StripeDisputeID)
+	in.Reason = strings.TrimSpace(in.Reason)
+
+	if in.EventID == "" {
+		return nil, ErrPaymentStripeEventIDEmpty
+	}
+
+	if in.StripePaymentIntentID == "" {
+		return nil, paymentdom.ErrInvalidStripePaymentIntent
+	}
+
+	if in.StripeDisputeID == "" {
+		return nil, paymentdom.ErrInvalidStripeDisputeID
+	}
+
+	if !paymentdom.IsValidDisputeStatus(in.Status) {
+		return nil, paymentdom.ErrInvalidDisputeStatus
+	}
+
+	if in.OccurredAt.IsZero() {
+		return nil, ErrPaymentStripeEventOccurredAtInvalid
+	}
+
+	in.OccurredAt = in.OccurredAt.UTC()
+	if in.OpenedAt.IsZero() {
+		in.OpenedAt = in.OccurredAt
+	}
+	in.OpenedAt = in.OpenedAt.UTC()
+
+	result, err :=
+		u.stripeDisputeRepo.ApplyStripeDisputeEvent(
 			ctx,
-			result.Payment,
+			in,
 		)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil || result.Payment == nil {
+		return nil, ErrPaymentStripeEventResultEmpty
 	}
 
 	return result.Payment, nil
 }
 
+// releaseInventoryReservationOnFailure releases the inventory reservation
+// when the Payment ended as failed or canceled.
+func (u *PaymentUsecase) releaseInventoryReservationOnFailure(
+	ctx context.Context,
+	payment *paymentdom.Payment,
+) error {
+	if u == nil ||
+		u.inventoryReservations == nil ||
+		payment == nil {
+		return nil
+	}
+
+	var reason invdom.ReservationReleaseReason
+
+	switch payment.Status {
+	case paymentdom.StatusFailed:
+		reason = invdom.ReservationReleaseReasonPaymentFailed
+	case paymentdom.StatusCanceled:
+		reason = invdom.ReservationReleaseReasonPaymentCanceled
+	default:
+		return nil
+	}
+
+	return u.inventoryReservations.ReleaseForOrder(
+		ctx,
+		payment.PaymentID,
+		reason,
+	)
+}
+
+// releaseCouponOnFailure returns the coupon usage when the Payment ended as
+// failed or canceled.
+func (u *PaymentUsecase) releaseCouponOnFailure(
+	ctx context.Context,
+	payment *paymentdom.Payment,
+) error {
+	if u == nil ||
+		u.couponRedemptions == nil ||
+		payment == nil {
+		return nil
+	}
+
+	switch payment.Status {
+	case paymentdom.StatusFailed,
+		paymentdom.StatusCanceled:
+		return u.couponRedemptions.ReleaseForOrder(
+			ctx,
+			payment.PaymentID,
+		)
+
+	default:
+		return nil
+	}
+}
+
+// releaseOffersOnFailure ends the accepted offers of the Order and frees
+// their reserved resales when the Payment ended as failed or canceled.
+func (u *PaymentUsecase) releaseOffersOnFailure(
+	ctx context.Context,
+	payment *paymentdom.Payment,
+) error {
+	if u == nil ||
+		u.offers == nil ||
+		u.orderRepo == nil ||
+		payment == nil {
+		return nil
+	}
+
+	switch payment.Status {
+	case paymentdom.StatusFailed,
+		paymentdom.StatusCanceled:
+	default:
+		return nil
+	}
+
+	order, err := u.orderRepo.GetByID(
+		ctx,
+		payment.PaymentID,
+	)
+	if err != nil {
+		if errors.Is(err, orderdom.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return u.offers.ReleaseForOrder(
+		ctx,
+		order,
+	)
+}
+
 // ============================================================
 // Post-paid flow
 // ============================================================
 
-// handlePostPaidBestEffort runs post-paid side effects.
+var _ OutboxEventHandler = (*PaymentUsecase)(nil)
+
+// HandleOutboxEvent runs the post-paid processing for PaymentSucceeded.
 //
-// This method may only be called from:
+// The Repository stores PaymentSucceeded once, together with the post-paid
+// execution marker. The relay may deliver it more than once; every post-paid
+// step is idempotent. The failed steps are returned together so that the
+// relay retries the event; steps that already succeeded are no-ops on retry.
+func (u *PaymentUsecase) HandleOutboxEvent(
+	ctx context.Context,
+	e outboxdom.Event,
+) error {
+	if e.Type != outboxdom.EventPaymentSucceeded {
+		return nil
+	}
+
+	if u == nil || u.repo == nil {
+		return paymentdom.ErrNotFound
+	}
+
+	var payload outboxdom.PaymentSucceededPayload
+	if err := e.DecodePayload(&payload); err != nil {
+		return err
+	}
+
+	payment, err := u.repo.GetByPaymentID(ctx, payload.PaymentID)
+	if err != nil {
+		return err
+	}
+
+	return u.handlePostPaid(ctx, payment)
+}
+
+// handlePostPaid runs post-paid side effects.
 //
-//  1. A successful initial Create whose Repository transaction also stores
-//     the post-paid execution marker.
-//  2. ApplyStripeEvent when PostPaidRequired is true.
+// This method may only be called from HandleOutboxEvent for the
+// PaymentSucceeded event that the Repository stores with the post-paid
+// execution marker (initial Create as succeeded, or ApplyStripeEvent when
+// PostPaidRequired is true).
 //
-// The Repository guarantees that PostPaidRequired is acquired once.
-func (u *PaymentUsecase) handlePostPaidBestEffort(
+// A failed step does not stop the later ones; all step errors are joined.
+func (u *PaymentUsecase) handlePostPaid(
 	ctx context.Context,
 	payment *paymentdom.Payment,
-) {
+) error {
 	if u == nil || payment == nil {
-		return
+		return nil
 	}
 
 	if payment.Status != paymentdom.StatusSucceeded {
-		return
+		return nil
 	}
 
 	rootID := strings.TrimSpace(payment.PaymentID)
 	if rootID == "" {
-		return
+		return nil
 	}
 
-	var order *orderdom.Order
+	var (
+		order *orderdom.Order
+		errs  []error
+	)
+
+	step := func(name string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("post-paid %s: %w", name, err))
+		}
+	}
 
 	if u.orderRepo != nil {
 		foundOrder, err := u.orderRepo.GetByID(
@@ -493,16 +916,19 @@ func (u *PaymentUsecase) handlePostPaidBestEffort(
 		)
 		if err == nil {
 			order = &foundOrder
+		} else if !errors.Is(err, orderdom.ErrNotFound) {
+			step("get order", err)
 		}
 	}
 
 	// 0) order.Paid=true
-	if u.orderRepo != nil {
+	if u.orderRepo != nil && order != nil {
 		updatedOrder, err := u.markOrderPaidTrue(
 			ctx,
 			rootID,
 			order,
 		)
+		step("mark order paid", err)
 		if err == nil && updatedOrder != nil {
 			order = updatedOrder
 		}
@@ -510,15 +936,67 @@ func (u *PaymentUsecase) handlePostPaidBestEffort(
 
 	// 1) resale status=sold
 	if u.resaleRepo != nil && order != nil {
-		_ = u.markResalesSoldByOrder(
+		step("mark resales sold", u.markResalesSoldByOrder(
 			ctx,
 			*order,
-		)
+		))
+	}
+
+	// 2) inventory reservation confirmed
+	if u.inventoryReservations != nil {
+		step("confirm inventory reservation", u.inventoryReservations.ConfirmForOrder(
+			ctx,
+			rootID,
+		))
+	}
+
+	// 3) coupon redemption confirmed
+	if u.couponRedemptions != nil {
+		step("confirm coupon redemption", u.couponRedemptions.ConfirmForOrder(
+			ctx,
+			rootID,
+		))
+	}
+
+	// 4) resale royalties accrued
+	if u.royaltyLedger != nil && order != nil {
+		step("accrue royalties", u.royaltyLedger.AccrueForOrder(
+			ctx,
+			*order,
+		))
+	}
+
+	// 5) accepted offers completed
+	if u.offers != nil && order != nil {
+		step("complete offers", u.offers.CompleteForOrder(
+			ctx,
+			*order,
+		))
+	}
+
+	// 6) resale proceeds held in escrow
+	if u.escrowLedger != nil && order != nil {
+		step("hold escrow", u.escrowLedger.HoldForOrder(
+			ctx,
+			*order,
+		))
+	}
+
+	// 7) brand sales and royalties accrued for payout
+	//
+	// Resale proceeds are accrued when the escrow is released.
+	if u.payoutLedger != nil && order != nil {
+		step("accrue payout", u.payoutLedger.AccrueForOrder(
+			ctx,
+			*order,
+		))
 	}
 
 	// Inventory reservation, cart deletion, and order-acceptance mail are
 	// intentionally not executed here. With payment deferred until dispatch,
 	// those operations must belong to the order-placement flow.
+
+	return errors.Join(errs...)
 }
 
 // ============================================================
@@ -559,7 +1037,7 @@ func (u *PaymentUsecase) markOrderPaidTrue(
 		return &current, nil
 	}
 
-	current.Paid = true
+	current.UpdatePaid(true)
 
 	updated, err := u.orderRepo.Update(
 		ctx,
@@ -0,0 +1,928 @@
+// backend/internal/application/usecase/payout_usecase.go
+package usecase
+
+/*
+責務:
+- ブランドの販売代金・ロイヤリティ・resale の出品者受取額の支払い台帳への計上
+- 返金による計上済みの明細の減額
+- 振込先口座の登録、支払いバッチの作成・承認・取消・支払い完了の記録
+- 全銀協フォーマットの振込ファイルと受取先ごとの支払明細書（PDF）の出力
+
+前提:
+- 台帳は発生源ごとに 1 回だけ計上する（決済後処理・escrow の release の再実行に備える）。
+  - brand_sale: 決済時の list item（販売価格 × 数量 - クーポン値引き, 税抜）。受取先はブランドの company
+  - royalty: 決済時の resale item のロイヤリティ。受取先は token blueprint の company
+  - resale_proceeds: released になった escrow の PayoutAmount。受取先は出品者の avatar
+- プラットフォーム手数料は計上時点の料率で計算し、台帳に保存する。
+  company への支払い（brand_sale / royalty）は company の手数料プラン（feePlans）の料率、
+  avatar への支払い（resale_proceeds）と feePlans が未設定の場合は feeBasisPoints を使う。
+- 返金は計上済みの明細を返金数量の割合で減額する（refund_adjustment, 手数料も同じ割合で戻す）。
+- 振込先口座は受取先の口座に限る。company は自社（company scope）の口座（自社または所属メンバーの口座）、
+  avatar は自社に台帳のある avatar 本人の口座だけを登録できる。
+- バッチは締め日時より前の未払いの台帳を受取先ごとに集計する。合計が 0 以下の受取先、
+  振込先口座が未登録・全銀協フォーマットに必要な項目が揃っていない受取先は次回以降に繰り越す。
+- バッチの承認は作成者以外のメンバーが行う。振込ファイルは承認後に出力でき、出力後は取り消せない。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	applicationport "narratives/internal/application/port"
+	accdom "narratives/internal/domain/account"
+	companydom "narratives/internal/domain/company"
+	escrowdom "narratives/internal/domain/escrow"
+	memberdom "narratives/internal/domain/member"
+	orderdom "narratives/internal/domain/order"
+	payoutdom "narratives/internal/domain/payout"
+	refunddom "narratives/internal/domain/refund"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+type PayoutAccountGetter interface {
+	GetByID(ctx context.Context, id string) (accdom.Account, error)
+}
+
+// PayoutMemberGetter は company の口座の名義メンバーの所属を確認します。
+type PayoutMemberGetter interface {
+	GetByID(ctx context.Context, id string) (memberdom.Record, error)
+}
+
+type PayoutCompanyGetter interface {
+	GetByID(ctx context.Context, id string) (companydom.Company, error)
+}
+
+// PayoutFeePlanResolver は company の手数料プランの販売手数料率を返します（BillingUsecase）。
+type PayoutFeePlanResolver interface {
+	SalesFeeBasisPoints(ctx context.Context, companyID string) (int, error)
+}
+
+// PayoutTransferFileRenderer は承認済みのバッチを振込ファイル（全銀協フォーマット）に変換します。
+type PayoutTransferFileRenderer interface {
+	RenderTransferFile(b payoutdom.Batch) ([]byte, error)
+}
+
+// PayoutStatementRenderer は支払明細書を PDF に変換します。
+type PayoutStatementRenderer interface {
+	RenderPayoutStatement(s payoutdom.Statement) ([]byte, error)
+}
+
+var (
+	ErrPayoutNotConfigured = errors.New(
+		"payout: usecase is not configured",
+	)
+	ErrPayoutAccountNotReady = errors.New(
+		"payout: account is missing zengin transfer fields or is not active",
+	)
+	ErrPayoutRecipientNotInBatch = errors.New(
+		"payout: recipient is not in the batch",
+	)
+	ErrPayoutAccountNotOwned = errors.New(
+		"payout: account does not belong to the recipient",
+	)
+)
+
+type PayoutUsecase struct {
+	repo        payoutdom.RepositoryPort
+	accountRepo PayoutAccountGetter
+	memberRepo  PayoutMemberGetter
+	brandRepo   applicationport.BrandGetter
+	companyRepo PayoutCompanyGetter
+
+	transferFileRenderer PayoutTransferFileRenderer
+	statementRenderer    PayoutStatementRenderer
+
+	feePlans       PayoutFeePlanResolver
+	feeBasisPoints int
+
+	newID func() string
+	now   func() time.Time
+}
+
+func NewPayoutUsecase(
+	repo payoutdom.RepositoryPort,
+	accountRepo PayoutAccountGetter,
+	brandRepo applicationport.BrandGetter,
+	companyRepo PayoutCompanyGetter,
+) *PayoutUsecase {
+	return &PayoutUsecase{
+		repo:        repo,
+		accountRepo: accountRepo,
+		brandRepo:   brandRepo,
+		companyRepo: companyRepo,
+		newID:       uuid.NewString,
+		now:         time.Now,
+	}
+}
+
+// WithPlatformFee はプラットフォーム手数料率（basis points, 100 = 1%）を設定します。
+// 範囲外の値は無視します。
+func (u *PayoutUsecase) WithPlatformFee(
+	basisPoints int,
+) *PayoutUsecase {
+	if u == nil {
+		return u
+	}
+
+	if basisPoints >= 0 && basisPoints <= payoutdom.MaxFeeBasisPoints {
+		u.feeBasisPoints = basisPoints
+	}
+
+	return u
+}
+
+// WithMemberRepo は company の受取先に、所属メンバー名義の口座を登録できるようにします。
+// 未設定の場合、company の受取先には company 名義の口座だけを登録できます。
+func (u *PayoutUsecase) WithMemberRepo(
+	memberRepo PayoutMemberGetter,
+) *PayoutUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.memberRepo = memberRepo
+
+	return u
+}
+
+// WithFeePlans は company への支払いの手数料率を company の手数料プランから解決します。
+func (u *PayoutUsecase) WithFeePlans(
+	plans PayoutFeePlanResolver,
+) *PayoutUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.feePlans = plans
+
+	return u
+}
+
+func (u *PayoutUsecase) WithTransferFileRenderer(
+	renderer PayoutTransferFileRenderer,
+) *PayoutUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.transferFileRenderer = renderer
+
+	return u
+}
+
+func (u *PayoutUsecase) WithStatementRenderer(
+	renderer PayoutStatementRenderer,
+) *PayoutUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.statementRenderer = renderer
+
+	return u
+}
+
+// ============================================================
+// Accrual
+// ============================================================
+
+// AccrueForOrder は決済済み order の list item の販売代金と resale item のロイヤリティを計上します。
+// 計上済みの明細はスキップするため、何度呼び出しても結果は同じです。
+func (u *PayoutUsecase) AccrueForOrder(
+	ctx context.Context,
+	order orderdom.Order,
+) error {
+	if u == nil || u.repo == nil || u.brandRepo == nil {
+		return ErrPayoutNotConfigured
+	}
+
+	accruedAt := u.now().UTC()
+	sourceID := func(index int) string {
+		return fmt.Sprintf("%s_%d", order.ID, index)
+	}
+
+	var errs []error
+
+	for index, item := range order.Items {
+		if item.IsCancelled {
+			continue
+		}
+
+		var in payoutdom.NewEntryInput
+
+		switch item.Type {
+		case orderdom.OrderItemTypeResale:
+			amount := order.ItemRoyaltyAmount(index)
+			if amount <= 0 {
+				continue
+			}
+
+			in = payoutdom.NewEntryInput{
+				CompanyID:     item.Royalty.CompanyID,
+				RecipientType: payoutdom.RecipientTypeCompany,
+				RecipientID:   item.Royalty.CompanyID,
+				Source:        payoutdom.SourceRoyalty,
+				GrossAmount:   amount,
+			}
+
+		default:
+			amount := item.Price*item.Qty - order.ItemDiscountAmount(index)
+			if amount <= 0 {
+				continue
+			}
+
+			brand, err := u.brandRepo.GetByID(ctx, item.BrandID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("brand %s: %w", item.BrandID, err))
+				continue
+			}
+
+			in = payoutdom.NewEntryInput{
+				CompanyID:     brand.CompanyID,
+				RecipientType: payoutdom.RecipientTypeCompany,
+				RecipientID:   brand.CompanyID,
+				Source:        payoutdom.SourceBrandSale,
+				GrossAmount:   amount,
+			}
+		}
+
+		fee, err := u.companyFeeBasisPoints(ctx, in.CompanyID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fee plan %s: %w", in.CompanyID, err))
+			continue
+		}
+
+		in.SourceID = sourceID(index)
+		in.OrderID = order.ID
+		in.ItemIndex = index
+		in.FeeBasisPoints = fee
+		in.AccruedAt = accruedAt
+
+		if err := u.createEntry(ctx, in); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// AccrueForEscrow は released の escrow の出品者受取額を計上します。
+func (u *PayoutUsecase) AccrueForEscrow(
+	ctx context.Context,
+	e escrowdom.Escrow,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrPayoutNotConfigured
+	}
+
+	if e.Status != escrowdom.StatusReleased || e.PayoutAmount <= 0 {
+		return nil
+	}
+
+	accruedAt := u.now().UTC()
+	if e.ReleasedAt != nil {
+		accruedAt = e.ReleasedAt.UTC()
+	}
+
+	return u.createEntry(ctx, payoutdom.NewEntryInput{
+		CompanyID:      e.CompanyID,
+		RecipientType:  payoutdom.RecipientTypeAvatar,
+		RecipientID:    e.SellerAvatarID,
+		Source:         payoutdom.SourceResaleProceeds,
+		SourceID:       e.ID,
+		OrderID:        e.OrderID,
+		ItemIndex:      e.ItemIndex,
+		GrossAmount:    e.PayoutAmount,
+		FeeBasisPoints: u.feeBasisPoints,
+		AccruedAt:      accruedAt,
+	})
+}
+
+// AdjustForRefund は succeeded の refund の明細について、計上済みの台帳を返金数量の割合で減額します。
+// 台帳ごと・refund ごとに 1 回だけ計上するため、何度呼び出しても結果は同じです。
+func (u *PayoutUsecase) AdjustForRefund(
+	ctx context.Context,
+	order orderdom.Order,
+	refund refunddom.Refund,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrPayoutNotConfigured
+	}
+
+	if refund.Status != refunddom.StatusSucceeded {
+		return nil
+	}
+
+	accruedAt := u.now().UTC()
+
+	var errs []error
+
+	for _, item := range refund.Items {
+		if item.ItemIndex < 0 || item.ItemIndex >= len(order.Items) || item.Qty <= 0 {
+			continue
+		}
+
+		qty := order.Items[item.ItemIndex].Qty
+		if qty <= 0 {
+			continue
+		}
+
+		sourceID := fmt.Sprintf("%s_%d", order.ID, item.ItemIndex)
+
+		for _, source := range []payoutdom.Source{
+			payoutdom.SourceBrandSale,
+			payoutdom.SourceRoyalty,
+			payoutdom.SourceResaleProceeds,
+		} {
+			accrued, err := u.repo.GetEntry(ctx, payoutdom.EntryID(source, sourceID))
+			if errors.Is(err, payoutdom.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			amount := accrued.GrossAmount * min(item.Qty, qty) / qty
+			if amount <= 0 {
+				continue
+			}
+
+			if err := u.createEntry(ctx, payoutdom.NewEntryInput{
+				CompanyID:      accrued.CompanyID,
+				RecipientType:  accrued.RecipientType,
+				RecipientID:    accrued.RecipientID,
+				Source:         payoutdom.SourceRefundAdjustment,
+				SourceID:       fmt.Sprintf("%s_%d_%s", refund.ID, item.ItemIndex, source),
+				OrderID:        order.ID,
+				ItemIndex:      item.ItemIndex,
+				GrossAmount:    -amount,
+				FeeBasisPoints: accrued.FeeBasisPoints,
+				AccruedAt:      accruedAt,
+			}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// companyFeeBasisPoints は company への支払いの手数料率です。
+func (u *PayoutUsecase) companyFeeBasisPoints(
+	ctx context.Context,
+	companyID string,
+) (int, error) {
+	if u.feePlans == nil {
+		return u.feeBasisPoints, nil
+	}
+
+	return u.feePlans.SalesFeeBasisPoints(ctx, companyID)
+}
+
+func (u *PayoutUsecase) createEntry(
+	ctx context.Context,
+	in payoutdom.NewEntryInput,
+) error {
+	entry, err := payoutdom.NewEntry(in)
+	if err != nil {
+		return fmt.Errorf("payout entry %s: %w", payoutdom.EntryID(in.Source, in.SourceID), err)
+	}
+
+	if _, err := u.repo.CreateEntry(ctx, entry); err != nil &&
+		!errors.Is(err, payoutdom.ErrConflict) {
+		return err
+	}
+
+	return nil
+}
+
+// ============================================================
+// Console: ledger / recipients
+// ============================================================
+
+func (u *PayoutUsecase) ListEntries(
+	ctx context.Context,
+	filter payoutdom.EntryFilter,
+) ([]payoutdom.Entry, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.ListEntriesByCompanyID(ctx, companyID, filter)
+}
+
+func (u *PayoutUsecase) ListRecipients(
+	ctx context.Context,
+) ([]payoutdom.Recipient, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.ListRecipientsByCompanyID(ctx, companyID)
+}
+
+type SetPayoutRecipientInput struct {
+	RecipientType payoutdom.RecipientType
+	RecipientID   string
+	AccountID     string
+}
+
+// SetRecipientAccount は受取先の振込先口座を登録します。
+// 口座は受取先が所有する、全銀協フォーマットの振込に必要な項目が揃った有効な口座に限ります。
+// 受取先の口座でない場合は ErrPayoutAccountNotOwned（口座の有無は区別しない）。
+func (u *PayoutUsecase) SetRecipientAccount(
+	ctx context.Context,
+	in SetPayoutRecipientInput,
+) (payoutdom.Recipient, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return payoutdom.Recipient{}, err
+	}
+
+	if u.accountRepo == nil {
+		return payoutdom.Recipient{}, ErrPayoutNotConfigured
+	}
+
+	account, err := u.accountRepo.GetByID(ctx, strings.TrimSpace(in.AccountID))
+	if err != nil {
+		return payoutdom.Recipient{}, err
+	}
+
+	if err := u.ensureRecipientOwnsAccount(ctx, companyID, in, account); err != nil {
+		return payoutdom.Recipient{}, err
+	}
+
+	if !account.ZenginReady() {
+		return payoutdom.Recipient{}, ErrPayoutAccountNotReady
+	}
+
+	rec, err := payoutdom.NewRecipient(
+		companyID,
+		in.RecipientType,
+		in.RecipientID,
+		account.ID,
+		MemberIDFromContext(ctx),
+		u.now(),
+	)
+	if err != nil {
+		return payoutdom.Recipient{}, err
+	}
+
+	return u.repo.SetRecipient(ctx, rec)
+}
+
+// ============================================================
+// Console: batches
+// ============================================================
+
+type CreatePayoutBatchInput struct {
+	CutoffAt     time.Time
+	TransferDate time.Time
+}
+
+// PayoutSkipReason は受取先をバッチに含めなかった理由です。
+type PayoutSkipReason string
+
+const (
+	PayoutSkipNonPositive     PayoutSkipReason = "non_positive_amount"
+	PayoutSkipNoAccount       PayoutSkipReason = "no_account"
+	PayoutSkipAccountNotReady PayoutSkipReason = "account_not_ready"
+	PayoutSkipBatchFull       PayoutSkipReason = "batch_full"
+)
+
+type PayoutSkippedRecipient struct {
+	RecipientType payoutdom.RecipientType `json:"recipientType"`
+	RecipientID   string                  `json:"recipientId"`
+	Amount        int                     `json:"amount"`
+	Reason        PayoutSkipReason        `json:"reason"`
+}
+
+type CreatePayoutBatchResult struct {
+	Batch   payoutdom.Batch          `json:"batch"`
+	Skipped []PayoutSkippedRecipient `json:"skipped"`
+}
+
+// CreateBatch は締め日時より前の未払いの台帳を受取先ごとに集計し、draft のバッチを作成します。
+func (u *PayoutUsecase) CreateBatch(
+	ctx context.Context,
+	in CreatePayoutBatchInput,
+) (CreatePayoutBatchResult, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return CreatePayoutBatchResult{}, err
+	}
+
+	if u.accountRepo == nil {
+		return CreatePayoutBatchResult{}, ErrPayoutNotConfigured
+	}
+
+	now := u.now().UTC()
+
+	cutoffAt := in.CutoffAt.UTC()
+	if cutoffAt.IsZero() || cutoffAt.After(now) {
+		cutoffAt = now
+	}
+
+	entries, err := u.repo.ListEntriesByCompanyID(ctx, companyID, payoutdom.EntryFilter{
+		Unbatched:     true,
+		AccruedBefore: &cutoffAt,
+	})
+	if err != nil {
+		return CreatePayoutBatchResult{}, err
+	}
+
+	type group struct {
+		recipientType payoutdom.RecipientType
+		recipientID   string
+		amount        int
+		entryIDs      []string
+	}
+
+	groups := make(map[string]*group)
+	keys := make([]string, 0)
+
+	for _, e := range entries {
+		key := payoutdom.RecipientKey(companyID, e.RecipientType, e.RecipientID)
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{recipientType: e.RecipientType, recipientID: e.RecipientID}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+
+		g.amount += e.NetAmount
+		g.entryIDs = append(g.entryIDs, e.ID)
+	}
+
+	sort.Strings(keys)
+
+	result := CreatePayoutBatchResult{Skipped: []PayoutSkippedRecipient{}}
+	lines := make([]payoutdom.Line, 0, len(keys))
+	entryCount := 0
+
+	for _, key := range keys {
+		g := groups[key]
+
+		skip := func(reason PayoutSkipReason) {
+			result.Skipped = append(result.Skipped, PayoutSkippedRecipient{
+				RecipientType: g.recipientType,
+				RecipientID:   g.recipientID,
+				Amount:        g.amount,
+				Reason:        reason,
+			})
+		}
+
+		if g.amount <= 0 {
+			skip(PayoutSkipNonPositive)
+			continue
+		}
+
+		if entryCount+len(g.entryIDs) > payoutdom.MaxBatchEntries {
+			skip(PayoutSkipBatchFull)
+			continue
+		}
+
+		rec, err := u.repo.GetRecipient(ctx, key)
+		if errors.Is(err, payoutdom.ErrNotFound) {
+			skip(PayoutSkipNoAccount)
+			continue
+		}
+		if err != nil {
+			return CreatePayoutBatchResult{}, err
+		}
+
+		account, err := u.accountRepo.GetByID(ctx, rec.AccountID)
+		if errors.Is(err, accdom.ErrNotFound) {
+			skip(PayoutSkipNoAccount)
+			continue
+		}
+		if err != nil {
+			return CreatePayoutBatchResult{}, err
+		}
+
+		if !account.ZenginReady() {
+			skip(PayoutSkipAccountNotReady)
+			continue
+		}
+
+		lines = append(lines, payoutdom.Line{
+			RecipientType: g.recipientType,
+			RecipientID:   g.recipientID,
+
+			Account: payoutdom.BankAccount{
+				AccountID: account.ID,
+
+				BankCode:   account.BankCode,
+				BankName:   account.BankName,
+				BranchCode: account.BranchCode,
+				BranchName: account.BranchName,
+
+				AccountType:   account.ZenginAccountType(),
+				AccountNumber: account.ZenginAccountNumber(),
+
+				HolderNameKana: account.HolderNameKana,
+			},
+
+			Amount: g.amount,
+
+			EntryIDs: g.entryIDs,
+		})
+		entryCount += len(g.entryIDs)
+	}
+
+	batch, err := payoutdom.NewBatch(payoutdom.NewBatchInput{
+		ID:        u.newID(),
+		CompanyID: companyID,
+
+		CutoffAt:     cutoffAt,
+		TransferDate: in.TransferDate,
+
+		Lines: lines,
+
+		CreatedBy: MemberIDFromContext(ctx),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return CreatePayoutBatchResult{}, err
+	}
+
+	result.Batch, err = u.repo.CreateBatch(ctx, batch)
+	if err != nil {
+		return CreatePayoutBatchResult{}, err
+	}
+
+	return result, nil
+}
+
+func (u *PayoutUsecase) ListBatches(
+	ctx context.Context,
+	status payoutdom.BatchStatus,
+) ([]payoutdom.Batch, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.ListBatchesByCompanyID(ctx, companyID, status)
+}
+
+func (u *PayoutUsecase) GetBatch(
+	ctx context.Context,
+	id string,
+) (payoutdom.Batch, error) {
+	companyID, err := u.companyScope(ctx)
+	if err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	b, err := u.repo.GetBatch(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	// 他社のバッチは存在しないものとして扱う。
+	if b.CompanyID != companyID {
+		return payoutdom.Batch{}, payoutdom.ErrNotFound
+	}
+
+	return b, nil
+}
+
+// ApproveBatch は作成者以外のメンバーによる承認を記録します。
+func (u *PayoutUsecase) ApproveBatch(
+	ctx context.Context,
+	id string,
+) (payoutdom.Batch, error) {
+	return u.transition(ctx, id, func(b *payoutdom.Batch, now time.Time) error {
+		return b.Approve(MemberIDFromContext(ctx), now)
+	})
+}
+
+// CancelBatch はバッチを取り消し、含まれる台帳を次のバッチの対象に戻します。
+func (u *PayoutUsecase) CancelBatch(
+	ctx context.Context,
+	id string,
+) (payoutdom.Batch, error) {
+	return u.transition(ctx, id, func(b *payoutdom.Batch, now time.Time) error {
+		return b.Cancel(now)
+	})
+}
+
+// MarkBatchPaid は振込の完了を記録します。
+func (u *PayoutUsecase) MarkBatchPaid(
+	ctx context.Context,
+	id string,
+) (payoutdom.Batch, error) {
+	return u.transition(ctx, id, func(b *payoutdom.Batch, now time.Time) error {
+		return b.MarkPaid(now)
+	})
+}
+
+func (u *PayoutUsecase) transition(
+	ctx context.Context,
+	id string,
+	apply func(b *payoutdom.Batch, now time.Time) error,
+) (payoutdom.Batch, error) {
+	b, err := u.GetBatch(ctx, id)
+	if err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	prevUpdatedAt := b.UpdatedAt
+
+	if err := apply(&b, u.now()); err != nil {
+		return payoutdom.Batch{}, err
+	}
+
+	return u.repo.UpdateBatch(ctx, b, prevUpdatedAt)
+}
+
+// PayoutFile は出力したファイルです。
+type PayoutFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ExportTransferFile は承認済みのバッチの振込ファイル（全銀協フォーマット）を出力し、出力済みにします。
+// 出力済みのバッチは同じ内容を再出力できます。
+func (u *PayoutUsecase) ExportTransferFile(
+	ctx context.Context,
+	id string,
+) (PayoutFile, error) {
+	if u == nil || u.transferFileRenderer == nil {
+		return PayoutFile{}, ErrPayoutNotConfigured
+	}
+
+	b, err := u.GetBatch(ctx, id)
+	if err != nil {
+		return PayoutFile{}, err
+	}
+
+	if b.Status != payoutdom.BatchStatusApproved &&
+		b.Status != payoutdom.BatchStatusExported {
+		return PayoutFile{}, payoutdom.ErrInvalidTransition
+	}
+
+	content, err := u.transferFileRenderer.RenderTransferFile(b)
+	if err != nil {
+		return PayoutFile{}, err
+	}
+
+	if b.Status == payoutdom.BatchStatusApproved {
+		prevUpdatedAt := b.UpdatedAt
+
+		if err := b.MarkExported(u.now()); err != nil {
+			return PayoutFile{}, err
+		}
+
+		if _, err := u.repo.UpdateBatch(ctx, b, prevUpdatedAt); err != nil {
+			return PayoutFile{}, err
+		}
+	}
+
+	return PayoutFile{
+		FileName:    "zengin-" + b.ID + ".txt",
+		ContentType: "text/plain; charset=Shift_JIS",
+		Content:     content,
+	}, nil
+}
+
+// Statement は受取先の支払明細書を PDF で出力します。
+func (u *PayoutUsecase) Statement(
+	ctx context.Context,
+	batchID string,
+	recipientType payoutdom.RecipientType,
+	recipientID string,
+) (PayoutFile, error) {
+	if u == nil || u.statementRenderer == nil {
+		return PayoutFile{}, ErrPayoutNotConfigured
+	}
+
+	b, err := u.GetBatch(ctx, batchID)
+	if err != nil {
+		return PayoutFile{}, err
+	}
+
+	line, ok := b.LineFor(recipientType, strings.TrimSpace(recipientID))
+	if !ok {
+		return PayoutFile{}, ErrPayoutRecipientNotInBatch
+	}
+
+	entries := make([]payoutdom.Entry, 0, len(line.EntryIDs))
+	for _, entryID := range line.EntryIDs {
+		e, err := u.repo.GetEntry(ctx, entryID)
+		if err != nil {
+			return PayoutFile{}, err
+		}
+		entries = append(entries, e)
+	}
+
+	companyName := b.CompanyID
+	if u.companyRepo != nil {
+		if company, err := u.companyRepo.GetByID(ctx, b.CompanyID); err == nil &&
+			strings.TrimSpace(company.Name) != "" {
+			companyName = company.Name
+		}
+	}
+
+	content, err := u.statementRenderer.RenderPayoutStatement(
+		payoutdom.NewStatement(b, line, entries, companyName, u.now()),
+	)
+	if err != nil {
+		return PayoutFile{}, err
+	}
+
+	return PayoutFile{
+		FileName:    fmt.Sprintf("payout-statement-%s-%s.pdf", b.ID, line.RecipientID),
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// ensureRecipientOwnsAccount は口座が company scope の受取先のものかを確認します。
+//
+//   - company: 受取先は自社に限る。口座は自社名義、または自社に所属するメンバー名義
+//   - avatar: 自社に台帳のある avatar に限る。口座は avatar 本人の名義
+func (u *PayoutUsecase) ensureRecipientOwnsAccount(
+	ctx context.Context,
+	companyID string,
+	in SetPayoutRecipientInput,
+	account accdom.Account,
+) error {
+	recipientID := strings.TrimSpace(in.RecipientID)
+	ownerID := strings.TrimSpace(account.MemberID)
+
+	switch in.RecipientType {
+	case payoutdom.RecipientTypeCompany:
+		if recipientID != companyID {
+			return ErrPayoutAccountNotOwned
+		}
+		if ownerID == companyID {
+			return nil
+		}
+		if u.memberRepo == nil || ownerID == "" {
+			return ErrPayoutAccountNotOwned
+		}
+
+		owner, err := u.memberRepo.GetByID(ctx, ownerID)
+		if errors.Is(err, memberdom.ErrNotFound) {
+			return ErrPayoutAccountNotOwned
+		}
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(owner.Member.CompanyID) != companyID {
+			return ErrPayoutAccountNotOwned
+		}
+
+		return nil
+
+	case payoutdom.RecipientTypeAvatar:
+		if recipientID == "" || ownerID != recipientID {
+			return ErrPayoutAccountNotOwned
+		}
+
+		entries, err := u.repo.ListEntriesByCompanyID(ctx, companyID, payoutdom.EntryFilter{
+			RecipientType: payoutdom.RecipientTypeAvatar,
+			RecipientID:   recipientID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return ErrPayoutAccountNotOwned
+		}
+
+		return nil
+
+	default:
+		return payoutdom.ErrInvalidRecipientType
+	}
+}
+
+func (u *PayoutUsecase) companyScope(ctx context.Context) (string, error) {
+	if u == nil || u.repo == nil {
+		return "", ErrPayoutNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return "", payoutdom.ErrInvalidCompanyID
+	}
+
+	return companyID, nil
+}
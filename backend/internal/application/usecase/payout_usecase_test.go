@@ -0,0 +1,207 @@
+// backend/internal/application/usecase/payout_usecase_test.go
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	usecase "narratives/internal/application/usecase"
+	accdom "narratives/internal/domain/account"
+	memberdom "narratives/internal/domain/member"
+	payoutdom "narratives/internal/domain/payout"
+)
+
+const (
+	payoutCompanyID = "company_payout"
+	payoutAvatarID  = "avatar_seller"
+)
+
+func TestPayout_SetRecipientAccount(t *testing.T) {
+	tests := []struct {
+		name          string
+		recipientType payoutdom.RecipientType
+		recipientID   string
+		accountOwner  string
+		withoutMember bool
+		want          error
+	}{
+		{
+			name:          "company account",
+			recipientType: payoutdom.RecipientTypeCompany,
+			recipientID:   payoutCompanyID,
+			accountOwner:  payoutCompanyID,
+		},
+		{
+			name:          "account of a member of the company",
+			recipientType: payoutdom.RecipientTypeCompany,
+			recipientID:   payoutCompanyID,
+			accountOwner:  "member_payout",
+		},
+		{
+			name:          "account of a member of another company",
+			recipientType: payoutdom.RecipientTypeCompany,
+			recipientID:   payoutCompanyID,
+			accountOwner:  "member_other",
+			want:          usecase.ErrPayoutAccountNotOwned,
+		},
+		{
+			name:          "member account without a member repository",
+			recipientType: payoutdom.RecipientTypeCompany,
+			recipientID:   payoutCompanyID,
+			accountOwner:  "member_payout",
+			withoutMember: true,
+			want:          usecase.ErrPayoutAccountNotOwned,
+		},
+		{
+			name:          "another company as recipient",
+			recipientType: payoutdom.RecipientTypeCompany,
+			recipientID:   "company_other",
+			accountOwner:  "company_other",
+			want:          usecase.ErrPayoutAccountNotOwned,
+		},
+		{
+			name:          "avatar's own account",
+			recipientType: payoutdom.RecipientTypeAvatar,
+			recipientID:   payoutAvatarID,
+			accountOwner:  payoutAvatarID,
+		},
+		{
+			name:          "someone else's account for an avatar",
+			recipientType: payoutdom.RecipientTypeAvatar,
+			recipientID:   payoutAvatarID,
+			accountOwner:  "member_payout",
+			want:          usecase.ErrPayoutAccountNotOwned,
+		},
+		{
+			name:          "avatar without entries in the company",
+			recipientType: payoutdom.RecipientTypeAvatar,
+			recipientID:   "avatar_stranger",
+			accountOwner:  "avatar_stranger",
+			want:          usecase.ErrPayoutAccountNotOwned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := usecase.WithMemberID(
+				usecase.WithCompanyID(context.Background(), payoutCompanyID),
+				"member_operator",
+			)
+
+			repo := &payoutRepoStub{
+				entries: []payoutdom.Entry{
+					{
+						CompanyID:     payoutCompanyID,
+						RecipientType: payoutdom.RecipientTypeAvatar,
+						RecipientID:   payoutAvatarID,
+					},
+				},
+			}
+			accounts := payoutAccountsStub{
+				"account_1": zenginReadyAccount("account_1", tt.accountOwner),
+			}
+
+			uc := usecase.NewPayoutUsecase(repo, accounts, nil, nil)
+			if !tt.withoutMember {
+				uc.WithMemberRepo(payoutMembersStub{
+					"member_payout": {CompanyID: payoutCompanyID},
+					"member_other":  {CompanyID: "company_other"},
+				})
+			}
+
+			got, err := uc.SetRecipientAccount(ctx, usecase.SetPayoutRecipientInput{
+				RecipientType: tt.recipientType,
+				RecipientID:   tt.recipientID,
+				AccountID:     "account_1",
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("SetRecipientAccount error = %v, want %v", err, tt.want)
+			}
+
+			if tt.want != nil {
+				if len(repo.recipients) != 0 {
+					t.Fatalf("recipients = %+v, want none saved", repo.recipients)
+				}
+				return
+			}
+
+			if got.AccountID != "account_1" || got.CompanyID != payoutCompanyID || got.RecipientID != tt.recipientID {
+				t.Fatalf("recipient = %+v", got)
+			}
+		})
+	}
+}
+
+func zenginReadyAccount(id, owner string) accdom.Account {
+	return accdom.Account{
+		ID:             id,
+		MemberID:       owner,
+		BankName:       "テスト銀行",
+		BranchName:     "本店",
+		AccountNumber:  1234567,
+		AccountType:    accdom.TypeFutsu,
+		Currency:       "JPY",
+		Status:         accdom.StatusActive,
+		BankCode:       "0001",
+		BranchCode:     "001",
+		HolderNameKana: "ﾃｽﾄ",
+		CreatedAt:      time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+// payoutRepoStub は SetRecipientAccount が使う台帳の一覧と受取先の保存だけを実装する。
+type payoutRepoStub struct {
+	payoutdom.RepositoryPort
+
+	entries    []payoutdom.Entry
+	recipients []payoutdom.Recipient
+}
+
+func (r *payoutRepoStub) ListEntriesByCompanyID(
+	_ context.Context,
+	companyID string,
+	filter payoutdom.EntryFilter,
+) ([]payoutdom.Entry, error) {
+	out := make([]payoutdom.Entry, 0)
+	for _, e := range r.entries {
+		if e.CompanyID == companyID &&
+			e.RecipientType == filter.RecipientType &&
+			e.RecipientID == filter.RecipientID {
+			out = append(out, e)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *payoutRepoStub) SetRecipient(
+	_ context.Context,
+	rec payoutdom.Recipient,
+) (payoutdom.Recipient, error) {
+	r.recipients = append(r.recipients, rec)
+	return rec, nil
+}
+
+type payoutAccountsStub map[string]accdom.Account
+
+func (s payoutAccountsStub) GetByID(_ context.Context, id string) (accdom.Account, error) {
+	a, ok := s[id]
+	if !ok {
+		return accdom.Account{}, accdom.ErrNotFound
+	}
+
+	return a, nil
+}
+
+type payoutMembersStub map[string]memberdom.Member
+
+func (s payoutMembersStub) GetByID(_ context.Context, id string) (memberdom.Record, error) {
+	m, ok := s[id]
+	if !ok {
+		return memberdom.Record{}, memberdom.ErrNotFound
+	}
+
+	return memberdom.Record{DocID: id, Member: m}, nil
+}
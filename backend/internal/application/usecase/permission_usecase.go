@@ -3,29 +3,68 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	companydom "narratives/internal/domain/company"
+	memdom "narratives/internal/domain/member"
 	permissiondom "narratives/internal/domain/permission"
 )
 
 // PermissionRepo defines the persistence port used by PermissionUsecase.
 // backend/internal/adapters/out/firestore/permission_repository_fs.go を正とし、
-// 読み取り専用の Repository（List / GetByID）のみを前提とする。
+// Permission 自体は読み取り専用の Repository（List / GetByID）のみを前提とする。
 type PermissionRepo interface {
 	List(ctx context.Context, filter permissiondom.Filter, sort permissiondom.Sort, page permissiondom.Page) (permissiondom.PageResult[permissiondom.Permission], error)
 	GetByID(ctx context.Context, id string) (permissiondom.Permission, error)
 }
 
-// PermissionUsecase orchestrates permission read operations.
+// PermissionCompanyReader は company.Admin（root 権限 member）の解決に使う。
+type PermissionCompanyReader interface {
+	GetByID(ctx context.Context, id string) (companydom.Company, error)
+}
+
+var (
+	ErrPermissionRolesNotConfigured = errors.New("permission: roles are not configured")
+	ErrPermissionCompanyRequired    = errors.New("permission: companyId is required")
+)
+
+// PermissionUsecase orchestrates permission read operations, company role
+// bundles and permission checks for console routes.
 type PermissionUsecase struct {
-	repo PermissionRepo
+	repo        PermissionRepo
+	roleRepo    permissiondom.RoleRepository
+	memberRepo  memdom.Repository
+	companyRepo PermissionCompanyReader
+	now         func() time.Time
 }
 
 func NewPermissionUsecase(repo PermissionRepo) *PermissionUsecase {
-	return &PermissionUsecase{repo: repo}
+	return &PermissionUsecase{repo: repo, now: time.Now}
+}
+
+// WithRoles enables role bundles, role assignment and Authorize.
+func (u *PermissionUsecase) WithRoles(
+	roleRepo permissiondom.RoleRepository,
+	memberRepo memdom.Repository,
+	companyRepo PermissionCompanyReader,
+) *PermissionUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.roleRepo = roleRepo
+	u.memberRepo = memberRepo
+	u.companyRepo = companyRepo
+
+	return u
 }
 
 // ========================================
-// Queries（閲覧のみ）
+// Queries
 // ========================================
 
 // List はフィルタ + ソート + ページング付きで権限一覧を取得する。
@@ -43,5 +82,234 @@ func (u *PermissionUsecase) GetByID(ctx context.Context, id string) (permissiond
 	return u.repo.GetByID(ctx, id)
 }
 
-// ※ Permission は「閲覧のみ」の前提のため、
-//    Exists / Create / Save / Delete といった変更系ユースケースは定義しない。
+// ListRoles は組み込みロール + context の company 独自ロールを返す。
+func (u *PermissionUsecase) ListRoles(ctx context.Context) ([]permissiondom.Role, error) {
+	if u == nil || u.roleRepo == nil {
+		return nil, ErrPermissionRolesNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nil, ErrPermissionCompanyRequired
+	}
+
+	return u.rolesForCompany(ctx, companyID)
+}
+
+// ========================================
+// Commands
+// ========================================
+
+type SaveRoleInput struct {
+	// ID が空の場合は新規作成する。
+	ID          string
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// SaveRole は context の company に独自ロールを作成・更新する。
+// 組み込みロールは変更できない。
+func (u *PermissionUsecase) SaveRole(ctx context.Context, in SaveRoleInput) (permissiondom.Role, error) {
+	if u == nil || u.roleRepo == nil {
+		return permissiondom.Role{}, ErrPermissionRolesNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return permissiondom.Role{}, ErrPermissionCompanyRequired
+	}
+
+	now := u.now().UTC()
+
+	id := strings.TrimSpace(in.ID)
+	if id == "" {
+		id = fmt.Sprintf("role_%d", now.UnixNano())
+	} else if permissiondom.IsBuiltinRoleID(id) {
+		return permissiondom.Role{}, permissiondom.ErrBuiltinRole
+	} else {
+		current, err := u.roleRepo.GetByID(ctx, id)
+		if err != nil && !errors.Is(err, permissiondom.ErrRoleNotFound) {
+			return permissiondom.Role{}, err
+		}
+		if err == nil && current.CompanyID != companyID {
+			return permissiondom.Role{}, permissiondom.ErrRoleNotFound
+		}
+	}
+
+	role, err := permissiondom.NewRole(
+		id,
+		companyID,
+		in.Name,
+		in.Description,
+		in.Permissions,
+		now,
+	)
+	if err != nil {
+		return permissiondom.Role{}, err
+	}
+
+	return u.roleRepo.Save(ctx, role)
+}
+
+// DeleteRole は context の company の独自ロールを削除する。
+// 既に割り当て済みの member からは解決時に無視される。
+func (u *PermissionUsecase) DeleteRole(ctx context.Context, id string) error {
+	if u == nil || u.roleRepo == nil {
+		return ErrPermissionRolesNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return ErrPermissionCompanyRequired
+	}
+
+	id = strings.TrimSpace(id)
+	if permissiondom.IsBuiltinRoleID(id) {
+		return permissiondom.ErrBuiltinRole
+	}
+
+	current, err := u.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current.CompanyID != companyID {
+		return permissiondom.ErrRoleNotFound
+	}
+
+	return u.roleRepo.Delete(ctx, id)
+}
+
+// AssignMemberRoles は context の company に所属する member のロールを置き換える。
+func (u *PermissionUsecase) AssignMemberRoles(
+	ctx context.Context,
+	memberID string,
+	roleIDs []string,
+) (MemberRecord, error) {
+	if u == nil || u.roleRepo == nil || u.memberRepo == nil {
+		return MemberRecord{}, ErrPermissionRolesNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return MemberRecord{}, ErrPermissionCompanyRequired
+	}
+
+	memberID = strings.TrimSpace(memberID)
+	if memberID == "" {
+		return MemberRecord{}, memdom.ErrNotFound
+	}
+
+	current, err := u.memberRepo.GetByID(ctx, memberID)
+	if err != nil {
+		return MemberRecord{}, err
+	}
+	if current.Member.CompanyID != companyID {
+		return MemberRecord{}, memdom.ErrNotFound
+	}
+
+	roles, err := u.rolesForCompany(ctx, companyID)
+	if err != nil {
+		return MemberRecord{}, err
+	}
+
+	known := make(map[string]struct{}, len(roles))
+	for _, r := range roles {
+		known[r.ID] = struct{}{}
+	}
+
+	ids := make([]string, 0, len(roleIDs))
+	for _, id := range roleIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := known[id]; !ok {
+			return MemberRecord{}, permissiondom.ErrRoleNotFound
+		}
+		ids = append(ids, id)
+	}
+
+	now := u.now().UTC()
+	patch := memdom.MemberPatch{
+		Roles:     &ids,
+		UpdatedAt: &now,
+	}
+	if by := strings.TrimSpace(MemberIDFromContext(ctx)); by != "" {
+		patch.UpdatedBy = &by
+	}
+
+	rec, err := u.memberRepo.Update(ctx, memberID, patch)
+	if err != nil {
+		return MemberRecord{}, err
+	}
+
+	return MemberRecord{
+		DocID:  rec.DocID,
+		Member: rec.Member,
+	}, nil
+}
+
+// ========================================
+// Authorization
+// ========================================
+
+// EffectivePermissions は member の実効権限（直接付与 + ロール）を返す。
+// company.Admin の member はカタログの全権限（system.* を除く）を持つ。
+// system.* は運営向けの権限のため、member に直接付与されている場合のみ含める。
+func (u *PermissionUsecase) EffectivePermissions(
+	ctx context.Context,
+	memberID string,
+	member memdom.Member,
+	companyID string,
+) ([]string, error) {
+	if u == nil {
+		return nil, ErrPermissionRolesNotConfigured
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, ErrPermissionCompanyRequired
+	}
+
+	if u.companyRepo != nil && strings.TrimSpace(memberID) != "" {
+		company, err := u.companyRepo.GetByID(ctx, companyID)
+		if err != nil && !errors.Is(err, companydom.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil && company.Admin == memberID {
+			names := dedupStrings(append(
+				permissiondom.TenantPermissionNames(),
+				member.EffectivePermissions(nil)...,
+			))
+			sort.Strings(names)
+			return names, nil
+		}
+	}
+
+	if len(member.Roles) == 0 || u.roleRepo == nil {
+		return member.EffectivePermissions(nil), nil
+	}
+
+	roles, err := u.rolesForCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return member.EffectivePermissions(roles), nil
+}
+
+func (u *PermissionUsecase) rolesForCompany(
+	ctx context.Context,
+	companyID string,
+) ([]permissiondom.Role, error) {
+	companyRoles, err := u.roleRepo.ListByCompanyID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := permissiondom.BuiltinRoles()
+	roles = append(roles, companyRoles...)
+
+	return roles, nil
+}
@@ -0,0 +1,89 @@
+// backend/internal/application/usecase/permission_usecase_test.go
+package usecase_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"narratives/internal/adapters/out/memory"
+	usecase "narratives/internal/application/usecase"
+	companydom "narratives/internal/domain/company"
+	memdom "narratives/internal/domain/member"
+	permissiondom "narratives/internal/domain/permission"
+)
+
+// company.Admin はテナントの全権限を持つが、運営向けの system.* は直接付与された場合のみ持つ。
+func TestPermission_EffectivePermissionsForCompanyAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		memberID   string
+		direct     []string
+		wantHas    []string
+		wantHasNot []string
+	}{
+		{
+			name:       "company admin",
+			memberID:   "member_admin",
+			wantHas:    []string{permissiondom.NameOrderPayoutApprove, permissiondom.NameInquiryUpdate},
+			wantHasNot: []string{permissiondom.NameSystemBillingUpdate, permissiondom.NameSystemPaymentUpdate},
+		},
+		{
+			name:       "company admin with a direct system grant",
+			memberID:   "member_admin",
+			direct:     []string{permissiondom.NameSystemPaymentUpdate},
+			wantHas:    []string{permissiondom.NameOrderPayoutApprove, permissiondom.NameSystemPaymentUpdate},
+			wantHasNot: []string{permissiondom.NameSystemBillingUpdate},
+		},
+		{
+			name:       "other member",
+			memberID:   "member_staff",
+			direct:     []string{permissiondom.NameOrderDispatch},
+			wantHas:    []string{permissiondom.NameOrderDispatch},
+			wantHasNot: []string{permissiondom.NameOrderPayoutApprove, permissiondom.NameSystemBillingUpdate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+			companies := memory.NewCompanyRepositoryMem()
+			company, err := companies.Create(ctx, companydom.Company{
+				Name:      "company",
+				Admin:     "member_admin",
+				CreatedBy: "member_admin",
+				UpdatedBy: "member_admin",
+				CreatedAt: now,
+				UpdatedAt: now,
+				IsActive:  true,
+			})
+			if err != nil {
+				t.Fatalf("create company: %v", err)
+			}
+
+			uc := usecase.NewPermissionUsecase(nil).WithRoles(nil, nil, companies)
+
+			got, err := uc.EffectivePermissions(ctx, tt.memberID, memdom.Member{
+				CompanyID:   company.ID,
+				Permissions: tt.direct,
+			}, company.ID)
+			if err != nil {
+				t.Fatalf("EffectivePermissions: %v", err)
+			}
+
+			for _, n := range tt.wantHas {
+				if !slices.Contains(got, n) {
+					t.Errorf("permissions %v, want %q", got, n)
+				}
+			}
+			for _, n := range tt.wantHasNot {
+				if slices.Contains(got, n) {
+					t.Errorf("permissions %v, want no %q", got, n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,553 @@
+// backend/internal/application/usecase/print_label_usecase.go
+package usecase
+
+/*
+責務:
+- PrintLog 1 件分の QR ラベルシート（A4, PDF）を生成する。
+  - 面付け・表示項目はラベルテンプレート（company ごと）に従う。
+  - テンプレート未指定の場合は組み込みの DefaultLabelTemplate を使う。
+- 製品ごとの QR コード画像（PNG / SVG）を生成する。
+- ラベルテンプレートの CRUD。
+
+前提:
+- console の操作は companyId 境界で制限する（他社の製品・テンプレートは not found）。
+- QR ペイロードは PrintUsecase と同じ {publicQRBaseURL}/{productId}。
+- ブランドロゴが取得できない場合はブランド名の表示で代替し、生成は失敗させない。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	applicationport "narratives/internal/application/port"
+	modeldom "narratives/internal/domain/model"
+	printdom "narratives/internal/domain/print"
+	productdom "narratives/internal/domain/product"
+	productiondom "narratives/internal/domain/production"
+)
+
+var (
+	ErrPrintLabelNotConfigured  = errors.New("print label usecase: not configured")
+	ErrPrintLabelInvalidFormat  = errors.New("print label usecase: invalid qr code format")
+	ErrPrintLabelInvalidProduct = errors.New("print label usecase: invalid productId")
+)
+
+// QRCodeFormat は QR コード画像の形式です。
+type QRCodeFormat string
+
+const (
+	QRCodeFormatPNG QRCodeFormat = "png"
+	QRCodeFormatSVG QRCodeFormat = "svg"
+
+	// QR コード PNG の一辺（px）
+	DefaultQRCodePNGSize = 512
+	MaxQRCodePNGSize     = 2048
+)
+
+// LabelSheetRenderer は QR ラベルシートを PDF に描画します。
+type LabelSheetRenderer interface {
+	RenderLabelSheet(sheet printdom.LabelSheet) ([]byte, error)
+}
+
+// QRCodeRenderer は QR コードの画像を生成します。
+type QRCodeRenderer interface {
+	RenderQRCodePNG(payload string, sizePx int) ([]byte, error)
+	RenderQRCodeSVG(payload string) ([]byte, error)
+}
+
+// LabelImageFetcher はブランドロゴの画像を URL から取得します。
+type LabelImageFetcher interface {
+	FetchImage(ctx context.Context, url string) ([]byte, error)
+}
+
+type PrintLabelProductRepo interface {
+	GetByID(ctx context.Context, id string) (productdom.Product, error)
+	ListByProductionID(ctx context.Context, productionID string) ([]productdom.Product, error)
+}
+
+type PrintLabelModelGetter interface {
+	GetByID(ctx context.Context, variationID string) (modeldom.ModelVariation, error)
+}
+
+type PrintLabelUsecase struct {
+	productionRepo    PrintProductionRepo
+	productRepo       PrintLabelProductRepo
+	printLogRepo      PrintLogRepo
+	productBlueprints applicationport.ProductBlueprintGetter
+	models            PrintLabelModelGetter
+	brands            applicationport.BrandGetter
+	templates         printdom.LabelTemplateRepositoryPort
+
+	sheetRenderer LabelSheetRenderer
+	qrRenderer    QRCodeRenderer
+	images        LabelImageFetcher
+
+	now func() time.Time
+}
+
+func NewPrintLabelUsecase(
+	productionRepo PrintProductionRepo,
+	productRepo PrintLabelProductRepo,
+	printLogRepo PrintLogRepo,
+	productBlueprints applicationport.ProductBlueprintGetter,
+	models PrintLabelModelGetter,
+	brands applicationport.BrandGetter,
+	templates printdom.LabelTemplateRepositoryPort,
+	sheetRenderer LabelSheetRenderer,
+	qrRenderer QRCodeRenderer,
+) *PrintLabelUsecase {
+	return &PrintLabelUsecase{
+		productionRepo:    productionRepo,
+		productRepo:       productRepo,
+		printLogRepo:      printLogRepo,
+		productBlueprints: productBlueprints,
+		models:            models,
+		brands:            brands,
+		templates:         templates,
+		sheetRenderer:     sheetRenderer,
+		qrRenderer:        qrRenderer,
+		now:               time.Now,
+	}
+}
+
+// WithImageFetcher はラベルへのブランドロゴの描画を有効にします。
+func (u *PrintLabelUsecase) WithImageFetcher(images LabelImageFetcher) *PrintLabelUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.images = images
+
+	return u
+}
+
+// ============================================================
+// Label sheet
+// ============================================================
+
+// RenderLabelSheet は productionId の PrintLog のラベルシート（PDF）を生成します。
+// templateID が空の場合は DefaultLabelTemplate を使います。
+func (u *PrintLabelUsecase) RenderLabelSheet(
+	ctx context.Context,
+	productionID string,
+	templateID string,
+) ([]byte, error) {
+	if u == nil ||
+		u.productionRepo == nil ||
+		u.productRepo == nil ||
+		u.printLogRepo == nil ||
+		u.productBlueprints == nil ||
+		u.sheetRenderer == nil {
+		return nil, ErrPrintLabelNotConfigured
+	}
+
+	pid := strings.Trim(productionID, " \t\r\n/")
+	if pid == "" {
+		return nil, printdom.ErrInvalidPrintLogProductionID
+	}
+
+	tpl, err := u.GetLabelTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	production, err := u.productionRepo.GetByID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if production == nil {
+		return nil, productiondom.ErrNotFound
+	}
+
+	pb, err := u.productBlueprints.GetByID(ctx, production.ProductBlueprintID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"get productBlueprint failed: productBlueprintId=%s: %w",
+			production.ProductBlueprintID,
+			err,
+		)
+	}
+	if pb.CompanyID != strings.TrimSpace(CompanyIDFromContext(ctx)) {
+		return nil, productiondom.ErrNotFound
+	}
+
+	printLog, err := u.printLogRepo.GetByProductionID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := u.productRepo.ListByProductionID(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	modelIDByProductID := make(map[string]string, len(products))
+	for _, p := range products {
+		modelIDByProductID[p.ID] = p.ModelID
+	}
+
+	sheet := printdom.LabelSheet{
+		ProductionID: pid,
+		Template:     tpl,
+		Labels:       make([]printdom.Label, 0, len(printLog.Items)),
+	}
+
+	if tpl.ShowBrandLogo {
+		sheet.BrandName, sheet.BrandLogo = u.resolveBrand(ctx, pb.BrandID)
+	}
+
+	modelCache := make(map[string]labelModel)
+
+	for _, item := range printLog.Items {
+		if item.ProductID == "" {
+			continue
+		}
+
+		label := printdom.Label{
+			ProductID:    item.ProductID,
+			DisplayOrder: item.DisplayOrder,
+			QRPayload:    productQRPayload(item.ProductID),
+		}
+		if tpl.ShowProductName {
+			label.ProductName = pb.ProductName
+		}
+
+		if tpl.ShowModelSize || tpl.ShowModelColor {
+			m, err := u.resolveModel(ctx, modelCache, modelIDByProductID[item.ProductID])
+			if err != nil {
+				return nil, err
+			}
+			if tpl.ShowModelSize {
+				label.ModelSize = m.size
+			}
+			if tpl.ShowModelColor {
+				label.ModelColor = m.color
+			}
+		}
+
+		sheet.Labels = append(sheet.Labels, label)
+	}
+
+	if len(sheet.Labels) == 0 {
+		return nil, printdom.ErrInvalidPrintLogItems
+	}
+
+	return u.sheetRenderer.RenderLabelSheet(sheet)
+}
+
+// resolveBrand はブランド名とロゴ画像を返します。ロゴが取得できない場合は nil です。
+func (u *PrintLabelUsecase) resolveBrand(
+	ctx context.Context,
+	brandID string,
+) (string, []byte) {
+	if u.brands == nil || strings.TrimSpace(brandID) == "" {
+		return "", nil
+	}
+
+	b, err := u.brands.GetByID(ctx, brandID)
+	if err != nil {
+		log.Printf("[print_label] get brand failed brandId=%q err=%v", brandID, err)
+		return "", nil
+	}
+
+	if u.images == nil || strings.TrimSpace(b.BrandIcon) == "" {
+		return b.Name, nil
+	}
+
+	logo, err := u.images.FetchImage(ctx, b.BrandIcon)
+	if err != nil {
+		log.Printf("[print_label] fetch brand logo failed brandId=%q err=%v", brandID, err)
+		return b.Name, nil
+	}
+
+	return b.Name, logo
+}
+
+type labelModel struct {
+	size  string
+	color string
+}
+
+func (u *PrintLabelUsecase) resolveModel(
+	ctx context.Context,
+	cache map[string]labelModel,
+	modelID string,
+) (labelModel, error) {
+	if modelID == "" || u.models == nil {
+		return labelModel{}, nil
+	}
+	if m, ok := cache[modelID]; ok {
+		return m, nil
+	}
+
+	variation, err := u.models.GetByID(ctx, modelID)
+	if err != nil {
+		return labelModel{}, fmt.Errorf(
+			"get model variation failed: modelId=%s: %w",
+			modelID,
+			err,
+		)
+	}
+
+	var m labelModel
+	switch mv := variation.(type) {
+	case modeldom.ApparelModelVariation:
+		m = labelModel{size: mv.Size, color: mv.Color.Name}
+	case *modeldom.ApparelModelVariation:
+		if mv != nil {
+			m = labelModel{size: mv.Size, color: mv.Color.Name}
+		}
+	case modeldom.AlcoholModelVariation:
+		m = labelModel{size: volumeLabel(mv.Volume)}
+	case *modeldom.AlcoholModelVariation:
+		if mv != nil {
+			m = labelModel{size: volumeLabel(mv.Volume)}
+		}
+	}
+
+	cache[modelID] = m
+	return m, nil
+}
+
+func volumeLabel(v modeldom.Volume) string {
+	if v.Value <= 0 {
+		return ""
+	}
+	return strconv.Itoa(v.Value) + v.Unit
+}
+
+// ============================================================
+// QR code
+// ============================================================
+
+// RenderQRCode は製品の QR コード画像を生成し、Content-Type とともに返します。
+// sizePx は PNG の一辺（0 の場合は DefaultQRCodePNGSize）で、SVG では無視します。
+func (u *PrintLabelUsecase) RenderQRCode(
+	ctx context.Context,
+	productID string,
+	format QRCodeFormat,
+	sizePx int,
+) ([]byte, string, error) {
+	if u == nil ||
+		u.productRepo == nil ||
+		u.productionRepo == nil ||
+		u.productBlueprints == nil ||
+		u.qrRenderer == nil {
+		return nil, "", ErrPrintLabelNotConfigured
+	}
+
+	productID = strings.TrimSpace(productID)
+	if productID == "" || strings.ContainsAny(productID, "/?#") {
+		return nil, "", ErrPrintLabelInvalidProduct
+	}
+
+	if err := u.ensureProductOwned(ctx, productID); err != nil {
+		return nil, "", err
+	}
+
+	payload := productQRPayload(productID)
+
+	switch format {
+	case QRCodeFormatPNG, "":
+		if sizePx <= 0 {
+			sizePx = DefaultQRCodePNGSize
+		}
+		if sizePx > MaxQRCodePNGSize {
+			sizePx = MaxQRCodePNGSize
+		}
+
+		b, err := u.qrRenderer.RenderQRCodePNG(payload, sizePx)
+		return b, "image/png", err
+
+	case QRCodeFormatSVG:
+		b, err := u.qrRenderer.RenderQRCodeSVG(payload)
+		return b, "image/svg+xml", err
+
+	default:
+		return nil, "", ErrPrintLabelInvalidFormat
+	}
+}
+
+func (u *PrintLabelUsecase) ensureProductOwned(
+	ctx context.Context,
+	productID string,
+) error {
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return productdom.ErrNotFound
+	}
+
+	p, err := u.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	production, err := u.productionRepo.GetByID(ctx, p.ProductionID)
+	if err != nil {
+		return err
+	}
+	if production == nil {
+		return productdom.ErrNotFound
+	}
+
+	pb, err := u.productBlueprints.GetByID(ctx, production.ProductBlueprintID)
+	if err != nil {
+		return err
+	}
+	if pb.CompanyID != companyID {
+		return productdom.ErrNotFound
+	}
+
+	return nil
+}
+
+func productQRPayload(productID string) string {
+	return publicQRBaseURL + "/" + productID
+}
+
+// ============================================================
+// Label templates
+// ============================================================
+
+// ListLabelTemplates は自社のテンプレートを返します（DefaultLabelTemplate は含みません）。
+func (u *PrintLabelUsecase) ListLabelTemplates(
+	ctx context.Context,
+) ([]printdom.LabelTemplate, error) {
+	if u == nil || u.templates == nil {
+		return nil, ErrPrintLabelNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nil, printdom.ErrLabelTemplateNotFound
+	}
+
+	return u.templates.ListByCompanyID(ctx, companyID)
+}
+
+// GetLabelTemplate は id のテンプレートを返します。空または "default" の場合は DefaultLabelTemplate です。
+func (u *PrintLabelUsecase) GetLabelTemplate(
+	ctx context.Context,
+	id string,
+) (printdom.LabelTemplate, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || id == printdom.DefaultLabelTemplateID {
+		return printdom.DefaultLabelTemplate(), nil
+	}
+
+	if u == nil || u.templates == nil {
+		return printdom.LabelTemplate{}, ErrPrintLabelNotConfigured
+	}
+
+	return u.getOwnedTemplate(ctx, id)
+}
+
+func (u *PrintLabelUsecase) CreateLabelTemplate(
+	ctx context.Context,
+	t printdom.LabelTemplate,
+) (printdom.LabelTemplate, error) {
+	if u == nil || u.templates == nil {
+		return printdom.LabelTemplate{}, ErrPrintLabelNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return printdom.LabelTemplate{}, printdom.ErrLabelTemplateNotFound
+	}
+
+	now := u.now().UTC()
+
+	t.ID = ""
+	t.CompanyID = companyID
+	t.CreatedAt = now
+	t.UpdatedAt = now
+	t.Normalize()
+
+	if err := t.Validate(); err != nil {
+		return printdom.LabelTemplate{}, err
+	}
+
+	return u.templates.Create(ctx, t)
+}
+
+// UpdateLabelTemplate は id のテンプレートの設定を t で置き換えます。
+func (u *PrintLabelUsecase) UpdateLabelTemplate(
+	ctx context.Context,
+	id string,
+	t printdom.LabelTemplate,
+) (printdom.LabelTemplate, error) {
+	if u == nil || u.templates == nil {
+		return printdom.LabelTemplate{}, ErrPrintLabelNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == printdom.DefaultLabelTemplateID {
+		return printdom.LabelTemplate{}, printdom.ErrLabelTemplateReadOnly
+	}
+
+	current, err := u.getOwnedTemplate(ctx, id)
+	if err != nil {
+		return printdom.LabelTemplate{}, err
+	}
+
+	t.ID = current.ID
+	t.CompanyID = current.CompanyID
+	t.CreatedAt = current.CreatedAt
+	t.UpdatedAt = u.now().UTC()
+	t.Normalize()
+
+	if err := t.Validate(); err != nil {
+		return printdom.LabelTemplate{}, err
+	}
+
+	return u.templates.Update(ctx, t)
+}
+
+func (u *PrintLabelUsecase) DeleteLabelTemplate(
+	ctx context.Context,
+	id string,
+) error {
+	if u == nil || u.templates == nil {
+		return ErrPrintLabelNotConfigured
+	}
+
+	id = strings.TrimSpace(id)
+	if id == printdom.DefaultLabelTemplateID {
+		return printdom.ErrLabelTemplateReadOnly
+	}
+
+	if _, err := u.getOwnedTemplate(ctx, id); err != nil {
+		return err
+	}
+
+	return u.templates.Delete(ctx, id)
+}
+
+func (u *PrintLabelUsecase) getOwnedTemplate(
+	ctx context.Context,
+	id string,
+) (printdom.LabelTemplate, error) {
+	if id == "" {
+		return printdom.LabelTemplate{}, printdom.ErrInvalidLabelTemplateID
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return printdom.LabelTemplate{}, printdom.ErrLabelTemplateNotFound
+	}
+
+	t, err := u.templates.GetByID(ctx, id)
+	if err != nil {
+		return printdom.LabelTemplate{}, err
+	}
+	if t.CompanyID != companyID {
+		return printdom.LabelTemplate{}, printdom.ErrLabelTemplateNotFound
+	}
+
+	return t, nil
+}
@@ -36,12 +36,23 @@ type PrintProductionRepo interface {
 	Update(ctx context.Context, production productiondom.Production) (*productiondom.Production, error)
 }
 
+// NFCTagAssigner は productIdTag が NFC の製品にタグを割り当てます（NFCTagUsecase）。
+type NFCTagAssigner interface {
+	AssignForProduction(
+		ctx context.Context,
+		productionID string,
+		companyID string,
+		productIDs []string,
+	) (int, error)
+}
+
 type PrintUsecase struct {
 	productionRepo       PrintProductionRepo
 	repo                 ProductRepo
 	printLogRepo         PrintLogRepo
 	inspectionRepo       InspectionRepo
 	productBlueprintRepo productblueprintdom.Repository
+	nfcTags              NFCTagAssigner
 	now                  func() time.Time
 }
 
@@ -62,6 +73,17 @@ func NewPrintUsecase(
 	}
 }
 
+// WithNFCTagAssigner は印刷時の NFC タグの割り当てを有効にします。
+func (u *PrintUsecase) WithNFCTagAssigner(nfcTags NFCTagAssigner) *PrintUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.nfcTags = nfcTags
+
+	return u
+}
+
 func (u *PrintUsecase) CreatePrintLogForProduction(
 	ctx context.Context,
 	productionID string,
@@ -114,6 +136,14 @@ func (u *PrintUsecase) CreatePrintLogForProduction(
 			return printdom.PrintLog{}, err
 		}
 
+		if err := u.assignNFCTags(
+			ctx,
+			*production,
+			printedItemProductIDs(existing.Items),
+		); err != nil {
+			return printdom.PrintLog{}, err
+		}
+
 		existing.QrPayloads = buildQrPayloads(existing.Items)
 
 		return existing, nil
@@ -322,6 +352,14 @@ func (u *PrintUsecase) CreatePrintLogForProduction(
 		return printdom.PrintLog{}, err
 	}
 
+	if err := u.assignNFCTags(
+		ctx,
+		*production,
+		productIDs,
+	); err != nil {
+		return printdom.PrintLog{}, err
+	}
+
 	created.QrPayloads = buildQrPayloads(created.Items)
 
 	return created, nil
@@ -495,6 +533,56 @@ func (u *PrintUsecase) markProductBlueprintsPrintedByProduction(
 	return nil
 }
 
+// assignNFCTags は productIdTag が NFC の場合に製品ごとのタグを割り当てます。
+// 割り当て済みの製品は変更しないため、印刷の再実行でも安全です。
+func (u *PrintUsecase) assignNFCTags(
+	ctx context.Context,
+	production productiondom.Production,
+	productIDs []string,
+) error {
+	if u.nfcTags == nil || production.ProductBlueprintID == "" || len(productIDs) == 0 {
+		return nil
+	}
+
+	pb, err := u.productBlueprintRepo.GetByID(ctx, production.ProductBlueprintID)
+	if err != nil {
+		return fmt.Errorf(
+			"get productBlueprint failed: productBlueprintId=%s: %w",
+			production.ProductBlueprintID,
+			err,
+		)
+	}
+
+	if pb.ProductIdTag.Type != productblueprintdom.TagNFC {
+		return nil
+	}
+
+	if _, err := u.nfcTags.AssignForProduction(
+		ctx,
+		production.ID,
+		pb.CompanyID,
+		productIDs,
+	); err != nil {
+		return fmt.Errorf(
+			"assign nfc tags failed: productionId=%s: %w",
+			production.ID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func printedItemProductIDs(items []printdom.PrintedItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.ProductID != "" {
+			ids = append(ids, item.ProductID)
+		}
+	}
+	return ids
+}
+
 func (u *PrintUsecase) Create(
 	ctx context.Context,
 	p productdom.Product,
@@ -517,14 +605,7 @@ func buildQrPayloads(
 			continue
 		}
 
-		payloads = append(
-			payloads,
-			fmt.Sprintf(
-				"%s/%s",
-				publicQRBaseURL,
-				item.ProductID,
-			),
-		)
+		payloads = append(payloads, productQRPayload(item.ProductID))
 	}
 
 	return payloads
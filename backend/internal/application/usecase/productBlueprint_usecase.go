@@ -379,6 +379,67 @@ func (
 	return updated, nil
 }
 
+// UpdateCustomsは国外発送用の税関情報を更新します。
+//
+//   - companyId境界はUsecaseで確認する。
+//   - 税関情報はタグへ印刷されないため、印刷済みでも更新する。
+func (
+	u *ProductBlueprintUsecase,
+) UpdateCustoms(
+	ctx context.Context,
+	id string,
+	customs productbpdom.CustomsInfo,
+	updatedBy *string,
+) (productbpdom.ProductBlueprint, error) {
+	if u == nil || u.repo == nil {
+		return productbpdom.ProductBlueprint{},
+			productbpdom.ErrInternal
+	}
+
+	if id == "" {
+		return productbpdom.ProductBlueprint{},
+			productbpdom.ErrInvalidID
+	}
+
+	companyID := CompanyIDFromContext(ctx)
+	if companyID == "" {
+		return productbpdom.ProductBlueprint{},
+			productbpdom.ErrInvalidCompanyID
+	}
+
+	current, err := u.repo.GetByID(
+		ctx,
+		id,
+	)
+	if err != nil {
+		return productbpdom.ProductBlueprint{}, err
+	}
+
+	if current.CompanyID == "" ||
+		current.CompanyID != companyID {
+		return productbpdom.ProductBlueprint{},
+			productbpdom.ErrForbidden
+	}
+
+	customs = productbpdom.NormalizeCustomsInfo(customs)
+	if err := customs.Validate(); err != nil {
+		return productbpdom.ProductBlueprint{}, err
+	}
+
+	if updatedBy == nil {
+		updatedBy = current.UpdatedBy
+	}
+
+	return u.repo.Update(
+		ctx,
+		id,
+		productbpdom.Patch{
+			Customs:   &customs,
+			UpdatedBy: updatedBy,
+		},
+	)
+}
+
 // DeleteはProductBlueprintと配下Modelを物理削除します。
 //
 // 削除条件:
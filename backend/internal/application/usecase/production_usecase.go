@@ -3,8 +3,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"log"
+	"strings"
 	"time"
 
+	outboxdom "narratives/internal/domain/outbox"
 	productiondom "narratives/internal/domain/production"
 )
 
@@ -18,10 +22,14 @@ type CreateProductionCommand struct {
 	AssigneeID         string                 `json:"assigneeId"`
 	Models             []ModelQuantityCommand `json:"models"`
 
-	// Status は廃止。Printed(boolean) に統一。
+	// 印刷状態は Printed(boolean)。ライフサイクルの status は Printed から導出される。
 	Printed   *bool      `json:"printed,omitempty"`
 	PrintedAt *time.Time `json:"printedAt,omitempty"`
 
+	TargetStartAt     *time.Time `json:"targetStartAt,omitempty"`
+	TargetInspectedAt *time.Time `json:"targetInspectedAt,omitempty"`
+	TargetCompletedAt *time.Time `json:"targetCompletedAt,omitempty"`
+
 	CreatedBy *string `json:"createdBy,omitempty"`
 }
 
@@ -30,7 +38,7 @@ type UpdateProductionCommand struct {
 	AssigneeID string                 `json:"assigneeId"`
 	Models     []ModelQuantityCommand `json:"models"`
 
-	// Status は廃止。Printed(boolean) に統一。
+	// 印刷状態は Printed(boolean)。ライフサイクルの status は ChangeStatus で更新する。
 	Printed   *bool      `json:"printed,omitempty"`
 	PrintedAt *time.Time `json:"printedAt,omitempty"`
 	PrintedBy *string    `json:"printedBy,omitempty"`
@@ -38,6 +46,22 @@ type UpdateProductionCommand struct {
 	UpdatedBy *string `json:"updatedBy,omitempty"`
 }
 
+// ChangeProductionStatusCommand は status の手動遷移です（製造開始・完了・キャンセルなど）。
+type ChangeProductionStatusCommand struct {
+	ID        string               `json:"id"`
+	Status    productiondom.Status `json:"status"`
+	UpdatedBy *string              `json:"updatedBy,omitempty"`
+}
+
+// SetProductionTargetsCommand は目標日を置き換えます。nil の目標日は未設定になります。
+type SetProductionTargetsCommand struct {
+	ID                string     `json:"id"`
+	TargetStartAt     *time.Time `json:"targetStartAt,omitempty"`
+	TargetInspectedAt *time.Time `json:"targetInspectedAt,omitempty"`
+	TargetCompletedAt *time.Time `json:"targetCompletedAt,omitempty"`
+	UpdatedBy         *string    `json:"updatedBy,omitempty"`
+}
+
 type ProductionRepo interface {
 	productiondom.RepositoryPort
 }
@@ -96,12 +120,23 @@ func (u *ProductionUsecase) Create(
 		return productiondom.Production{}, err
 	}
 
+	if err := p.SetTargets(
+		cmd.TargetStartAt,
+		cmd.TargetInspectedAt,
+		cmd.TargetCompletedAt,
+	); err != nil {
+		return productiondom.Production{}, err
+	}
+
 	in := productiondom.CreateProductionInput{
 		ProductBlueprintID: p.ProductBlueprintID,
 		AssigneeID:         p.AssigneeID,
 		Models:             p.Models,
 		Printed:            &p.Printed,
 		PrintedAt:          p.PrintedAt,
+		TargetStartAt:      p.TargetStartAt,
+		TargetInspectedAt:  p.TargetInspectedAt,
+		TargetCompletedAt:  p.TargetCompletedAt,
 		CreatedBy:          p.CreatedBy,
 		CreatedAt:          &p.CreatedAt,
 	}
@@ -182,6 +217,168 @@ func (u *ProductionUsecase) Delete(ctx context.Context, id string) error {
 	return u.repo.Delete(ctx, id)
 }
 
+// ChangeStatus は status を手動で遷移させます。
+// 遷移できない組み合わせ（例: planned -> completed）は ErrInvalidStatusTransition を返します。
+func (u *ProductionUsecase) ChangeStatus(
+	ctx context.Context,
+	cmd ChangeProductionStatusCommand,
+) (productiondom.Production, error) {
+	next := productiondom.Status(strings.TrimSpace(string(cmd.Status)))
+	if !next.IsValid() {
+		return productiondom.Production{}, productiondom.ErrInvalidStatus
+	}
+
+	return u.modify(ctx, cmd.ID, cmd.UpdatedBy, func(p *productiondom.Production, now time.Time) error {
+		return p.TransitionTo(next, now)
+	})
+}
+
+// SetTargets は目標日を置き換えます。
+func (u *ProductionUsecase) SetTargets(
+	ctx context.Context,
+	cmd SetProductionTargetsCommand,
+) (productiondom.Production, error) {
+	return u.modify(ctx, cmd.ID, cmd.UpdatedBy, func(p *productiondom.Production, _ time.Time) error {
+		return p.SetTargets(cmd.TargetStartAt, cmd.TargetInspectedAt, cmd.TargetCompletedAt)
+	})
+}
+
+// MarkInspected は検品完了を production に反映します（manufacturing -> inspected）。
+// 印刷を経ずに検品が完了した場合は manufacturing を経由します。
+// 到達済み・キャンセル済みの場合は何もしません。
+func (u *ProductionUsecase) MarkInspected(
+	ctx context.Context,
+	productionID string,
+	at time.Time,
+) error {
+	return u.advance(ctx, productionID, productiondom.StatusInspected, at)
+}
+
+// MarkCompleted は production を completed にします。到達済み・キャンセル済みの場合は何もしません。
+func (u *ProductionUsecase) MarkCompleted(
+	ctx context.Context,
+	productionID string,
+	at time.Time,
+) error {
+	return u.advance(ctx, productionID, productiondom.StatusCompleted, at)
+}
+
+var _ OutboxEventHandler = (*ProductionUsecase)(nil)
+
+// HandleOutboxEvent は MintCompleted を受けて production を completed にします。
+// mint の docId は productionId と同じです。completed 済みなら何もしないため再配信されても安全です。
+func (u *ProductionUsecase) HandleOutboxEvent(
+	ctx context.Context,
+	e outboxdom.Event,
+) error {
+	if e.Type != outboxdom.EventMintCompleted {
+		return nil
+	}
+
+	var payload outboxdom.MintCompletedPayload
+	if err := e.DecodePayload(&payload); err != nil {
+		return err
+	}
+
+	productionID := strings.TrimSpace(payload.MintID)
+	if productionID == "" {
+		return nil
+	}
+
+	at := e.OccurredAt
+	if at.IsZero() {
+		at = u.now()
+	}
+
+	err := u.MarkCompleted(ctx, productionID, at)
+	if errors.Is(err, productiondom.ErrNotFound) {
+		log.Printf("production usecase: production for mint not found mintId=%s", productionID)
+		return nil
+	}
+	return err
+}
+
+func (u *ProductionUsecase) advance(
+	ctx context.Context,
+	productionID string,
+	milestone productiondom.Status,
+	at time.Time,
+) error {
+	productionID = strings.TrimSpace(productionID)
+	if productionID == "" {
+		return productiondom.ErrInvalidID
+	}
+
+	current, err := u.repo.GetByID(ctx, productionID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return productiondom.ErrNotFound
+	}
+
+	p := *current
+	if p.Status == productiondom.StatusCancelled || p.Status.Reached(milestone) {
+		return nil
+	}
+
+	if at.IsZero() {
+		at = u.now()
+	}
+	at = at.UTC()
+
+	if err := p.AdvanceTo(milestone, at); err != nil {
+		return err
+	}
+	p.UpdatedAt = at
+
+	_, err = u.repo.Update(ctx, p)
+	return err
+}
+
+func (u *ProductionUsecase) modify(
+	ctx context.Context,
+	id string,
+	updatedBy *string,
+	apply func(p *productiondom.Production, now time.Time) error,
+) (productiondom.Production, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return productiondom.Production{}, productiondom.ErrInvalidID
+	}
+
+	current, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return productiondom.Production{}, err
+	}
+	if current == nil {
+		return productiondom.Production{}, productiondom.ErrNotFound
+	}
+
+	p := *current
+	now := u.now().UTC()
+
+	if err := apply(&p, now); err != nil {
+		return productiondom.Production{}, err
+	}
+
+	p.UpdatedAt = now
+	if updatedBy != nil && *updatedBy != "" {
+		v := *updatedBy
+		p.UpdatedBy = &v
+	}
+
+	updated, err := u.repo.Update(ctx, p)
+	if err != nil {
+		return productiondom.Production{}, err
+	}
+	if updated == nil {
+		return productiondom.Production{}, productiondom.ErrNotFound
+	}
+
+	return *updated, nil
+}
+
 func modelQuantityCommandsToDomain(
 	models []ModelQuantityCommand,
 ) []productiondom.ModelQuantity {
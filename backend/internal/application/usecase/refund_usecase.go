@@ -0,0 +1,480 @@
+// backend/internal/application/usecase/refund_usecase.go
+package usecase
+
+/*
+責務:
+- 支払い済みOrderの明細単位（数量単位）の返金を発行する。
+- Stripe Refundの状態をRefundへ同期する。
+- succeededになったRefundをOrder.Refundsへ反映する。
+
+前提:
+- refund.PaymentID = payment.PaymentID = order.ID
+- 返金額はサーバ側のOrder snapshotから計算する（クライアント金額は使わない）
+- 有効なRefund（pending / requires_action / succeeded）の合計は
+  Payment.Amountを超えない
+- Order.Refundsへの反映はRefundID単位で冪等
+- payoutLedgerが設定されている場合、succeededのRefundで支払い台帳を減額する（best-effort, 冪等）
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	common "narratives/internal/domain/common"
+	orderdom "narratives/internal/domain/order"
+	paymentdom "narratives/internal/domain/payment"
+	refunddom "narratives/internal/domain/refund"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+// StripeRefundGateway is an outbound port for Stripe Refund creation.
+type StripeRefundGateway interface {
+	CreateRefund(
+		ctx context.Context,
+		in CreateStripeRefundInput,
+	) (*CreateStripeRefundResult, error)
+}
+
+type CreateStripeRefundInput struct {
+	RefundID  string
+	PaymentID string
+
+	StripePaymentIntentID string
+
+	Amount int
+	Reason string
+}
+
+type CreateStripeRefundResult struct {
+	StripeRefundID string
+	Status         string
+	FailureReason  string
+}
+
+// OrderRepoForRefund reads and updates the refunded Order.
+type OrderRepoForRefund interface {
+	GetByID(
+		ctx context.Context,
+		id string,
+	) (orderdom.Order, error)
+
+	Update(
+		ctx context.Context,
+		order orderdom.Order,
+		opts *common.SaveOptions,
+	) (orderdom.Order, error)
+}
+
+// PaymentReaderForRefund reads the Payment being refunded.
+type PaymentReaderForRefund interface {
+	GetByPaymentID(
+		ctx context.Context,
+		paymentID string,
+	) (*paymentdom.Payment, error)
+}
+
+// PayoutLedgerForRefund reduces the payout ledger entries of refunded items.
+// It must be idempotent per refund.
+type PayoutLedgerForRefund interface {
+	AdjustForRefund(
+		ctx context.Context,
+		order orderdom.Order,
+		refund refunddom.Refund,
+	) error
+}
+
+// ============================================================
+// Errors
+// ============================================================
+
+var (
+	ErrRefundNotConfigured = errors.New(
+		"refund: usecase is not configured",
+	)
+	ErrRefundOrderNotPaid = errors.New(
+		"refund: order is not paid",
+	)
+	ErrRefundPaymentNotSucceeded = errors.New(
+		"refund: payment has not succeeded",
+	)
+	ErrRefundItemNotAllowed = errors.New(
+		"refund: order item is not refundable by this company",
+	)
+	ErrRefundStripeFailed = errors.New(
+		"refund: stripe refund failed",
+	)
+)
+
+// ============================================================
+// Usecase
+// ============================================================
+
+type RefundUsecase struct {
+	repo        refunddom.RepositoryPort
+	orderRepo   OrderRepoForRefund
+	paymentRepo PaymentReaderForRefund
+	gateway     StripeRefundGateway
+
+	payoutLedger PayoutLedgerForRefund
+
+	now func() time.Time
+}
+
+func NewRefundUsecase(
+	repo refunddom.RepositoryPort,
+	orderRepo OrderRepoForRefund,
+	paymentRepo PaymentReaderForRefund,
+	gateway StripeRefundGateway,
+) *RefundUsecase {
+	return &RefundUsecase{
+		repo:        repo,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		gateway:     gateway,
+		now:         time.Now,
+	}
+}
+
+// WithPayoutLedger enables payout ledger adjustments for succeeded refunds.
+func (u *RefundUsecase) WithPayoutLedger(
+	payoutLedger PayoutLedgerForRefund,
+) *RefundUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.payoutLedger = payoutLedger
+
+	return u
+}
+
+// ============================================================
+// Queries
+// ============================================================
+
+func (u *RefundUsecase) ListByOrderID(
+	ctx context.Context,
+	orderID string,
+) ([]refunddom.Refund, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrRefundNotConfigured
+	}
+
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return nil, orderdom.ErrInvalidID
+	}
+
+	return u.repo.ListByPaymentID(ctx, orderID)
+}
+
+// ============================================================
+// Commands
+// ============================================================
+
+type IssueRefundItemInput struct {
+	ItemIndex int
+	Qty       int
+}
+
+type IssueRefundInput struct {
+	OrderID string
+
+	Items []IssueRefundItemInput
+
+	Reason refunddom.Reason
+	Note   string
+
+	// RequestedBy is the console member ID, or "system" for refunds
+	// triggered by the order flow itself.
+	RequestedBy string
+
+	// AllowedInventoryIDs restricts list items to the caller company's
+	// inventories. nil means no restriction (trusted server-side callers).
+	AllowedInventoryIDs map[string]struct{}
+}
+
+// IssueRefund computes the refund from the server-side Order snapshot,
+// persists it as pending, asks Stripe to refund and records the result.
+//
+// The remaining amount is computed from the existing refunds and the new
+// refund is persisted as pending in one repository transaction that is
+// serialized per Payment (refund.RepositoryPort.CreateForPayment), so
+// concurrent requests cannot over-refund. The refund is persisted before
+// Stripe is called so that its amount stays reserved while Stripe runs.
+// The refund ID doubles as the Stripe idempotency key.
+func (u *RefundUsecase) IssueRefund(
+	ctx context.Context,
+	in IssueRefundInput,
+) (refunddom.Refund, error) {
+	if u == nil ||
+		u.repo == nil ||
+		u.orderRepo == nil ||
+		u.paymentRepo == nil ||
+		u.gateway == nil {
+		return refunddom.Refund{}, ErrRefundNotConfigured
+	}
+
+	orderID := strings.TrimSpace(in.OrderID)
+	if orderID == "" {
+		return refunddom.Refund{}, orderdom.ErrInvalidID
+	}
+
+	requestedBy := strings.TrimSpace(in.RequestedBy)
+	if requestedBy == "" {
+		requestedBy = strings.TrimSpace(MemberIDFromContext(ctx))
+	}
+	if requestedBy == "" {
+		return refunddom.Refund{}, refunddom.ErrInvalidRequestedBy
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	if !order.Paid {
+		return refunddom.Refund{}, ErrRefundOrderNotPaid
+	}
+
+	payment, err := u.paymentRepo.GetByPaymentID(ctx, orderID)
+	if err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	if payment == nil ||
+		payment.Status != paymentdom.StatusSucceeded {
+		return refunddom.Refund{}, ErrRefundPaymentNotSucceeded
+	}
+
+	requests := make([]refunddom.ItemRequest, 0, len(in.Items))
+	for _, item := range in.Items {
+		if item.ItemIndex < 0 || item.ItemIndex >= len(order.Items) {
+			return refunddom.Refund{}, refunddom.ErrInvalidItems
+		}
+
+		if in.AllowedInventoryIDs != nil {
+			if _, ok := in.AllowedInventoryIDs[order.Items[item.ItemIndex].InventoryID]; !ok {
+				return refunddom.Refund{}, ErrRefundItemNotAllowed
+			}
+		}
+
+		requests = append(requests, refunddom.ItemRequest{
+			ItemIndex: item.ItemIndex,
+			Qty:       item.Qty,
+		})
+	}
+
+	refund, err := u.repo.CreateForPayment(
+		ctx,
+		orderID,
+		func(existing []refunddom.Refund) (refunddom.Refund, error) {
+			refundedQty := make(map[int]int, len(order.Items))
+			refundedAmount := 0
+
+			for _, r := range existing {
+				if !r.Status.IsActive() {
+					continue
+				}
+
+				refundedAmount += r.Amount
+				for _, item := range r.Items {
+					refundedQty[item.ItemIndex] += item.Qty
+				}
+			}
+
+			lines, err := refunddom.Calculate(refunddom.CalculateInput{
+				Order:          order,
+				PaidAmount:     payment.Amount,
+				Requests:       requests,
+				RefundedQty:    refundedQty,
+				RefundedAmount: refundedAmount,
+			})
+			if err != nil {
+				return refunddom.Refund{}, err
+			}
+
+			now := u.now().UTC()
+
+			return refunddom.New(
+				u.newRefundID(now),
+				orderID,
+				payment.StripePaymentIntentID,
+				lines,
+				in.Reason,
+				in.Note,
+				requestedBy,
+				now,
+			)
+		},
+	)
+	if err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	result, stripeErr := u.gateway.CreateRefund(
+		ctx,
+		CreateStripeRefundInput{
+			RefundID:              refund.ID,
+			PaymentID:             refund.PaymentID,
+			StripePaymentIntentID: refund.StripePaymentIntentID,
+			Amount:                refund.Amount,
+			Reason:                string(refund.Reason),
+		},
+	)
+	if stripeErr != nil || result == nil {
+		message := "stripe refund request failed"
+		if stripeErr != nil {
+			message = stripeErr.Error()
+		}
+
+		// Release the reserved amount so the operator can retry.
+		if err := refund.ApplyStripeResult(
+			"",
+			refunddom.StatusFailed,
+			&message,
+			u.now(),
+		); err == nil {
+			if _, err := u.repo.Update(ctx, refund); err != nil {
+				log.Printf(
+					"refund usecase: mark failed refundId=%q err=%v",
+					refund.ID,
+					err,
+				)
+			}
+		}
+
+		return refund, fmt.Errorf("%w: %s", ErrRefundStripeFailed, message)
+	}
+
+	next := refunddom.RefundStatus(result.Status)
+	if !refunddom.IsValidStatus(next) {
+		next = refunddom.StatusPending
+	}
+
+	var errorMsg *string
+	if reason := strings.TrimSpace(result.FailureReason); reason != "" {
+		errorMsg = &reason
+	}
+
+	if err := refund.ApplyStripeResult(
+		result.StripeRefundID,
+		next,
+		errorMsg,
+		u.now(),
+	); err != nil {
+		return refund, err
+	}
+
+	refund, err = u.repo.Update(ctx, refund)
+	if err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	if refund.Status == refunddom.StatusSucceeded {
+		if err := u.applyToOrder(ctx, refund); err != nil {
+			return refund, err
+		}
+	}
+
+	return refund, nil
+}
+
+// ApplyStripeEvent applies a verified charge.refunded /
+// charge.refund.updated webhook event.
+//
+// A duplicate event is a successful no-op. A succeeded refund is reflected
+// into Order.Refunds; reflection is idempotent, so it is safe to retry.
+func (u *RefundUsecase) ApplyStripeEvent(
+	ctx context.Context,
+	in refunddom.ApplyStripeEventInput,
+) (refunddom.Refund, error) {
+	if u == nil || u.repo == nil || u.orderRepo == nil {
+		return refunddom.Refund{}, ErrRefundNotConfigured
+	}
+
+	if strings.TrimSpace(in.EventID) == "" {
+		return refunddom.Refund{}, ErrPaymentStripeEventIDEmpty
+	}
+
+	if in.OccurredAt.IsZero() {
+		return refunddom.Refund{}, ErrPaymentStripeEventOccurredAtInvalid
+	}
+
+	result, err := u.repo.ApplyStripeEvent(ctx, in)
+	if err != nil {
+		return refunddom.Refund{}, err
+	}
+
+	if result.Refund.Status == refunddom.StatusSucceeded {
+		if err := u.applyToOrder(ctx, result.Refund); err != nil {
+			return result.Refund, err
+		}
+	}
+
+	return result.Refund, nil
+}
+
+// applyToOrder records a succeeded refund on the Order.
+func (u *RefundUsecase) applyToOrder(
+	ctx context.Context,
+	refund refunddom.Refund,
+) error {
+	order, err := u.orderRepo.GetByID(ctx, refund.PaymentID)
+	if err != nil {
+		return err
+	}
+
+	items := make([]orderdom.RefundItemSnapshot, 0, len(refund.Items))
+	for _, item := range refund.Items {
+		items = append(items, orderdom.RefundItemSnapshot{
+			ItemIndex: item.ItemIndex,
+			Qty:       item.Qty,
+			Amount:    item.Amount,
+		})
+	}
+
+	changed, err := order.ApplyRefund(orderdom.RefundSnapshot{
+		RefundID:   refund.ID,
+		Amount:     refund.Amount,
+		Items:      items,
+		RefundedAt: refund.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		if _, err := u.orderRepo.Update(ctx, order, nil); err != nil {
+			return err
+		}
+	}
+
+	// The adjustment is idempotent, so it also runs for already reflected
+	// refunds to correct a previously failed attempt.
+	if u.payoutLedger != nil {
+		if err := u.payoutLedger.AdjustForRefund(ctx, order, refund); err != nil {
+			log.Printf(
+				"refund usecase: payout adjustment refundId=%q err=%v",
+				refund.ID,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (u *RefundUsecase) newRefundID(t time.Time) string {
+	return fmt.Sprintf(
+		"rf_%d",
+		t.UTC().UnixNano(),
+	)
+}
@@ -40,6 +40,11 @@ func (uc *ResaleUsecase) Create(
 		return resaledom.Resale{}, ErrNotSupported("Resale.Create")
 	}
 
+	// reserved は offer の合意によってのみ設定される。
+	if item.Status == resaledom.StatusReserved {
+		return resaledom.Resale{}, resaledom.ErrInvalidStatus
+	}
+
 	return uc.resaleRepo.Create(ctx, item)
 }
 
@@ -58,6 +63,23 @@ func (uc *ResaleUsecase) Update(
 
 	item.ID = id
 
+	// accept 済み offer の合意価格で決済待ちの間は、価格と状態を変更できない。
+	current, err := uc.resaleRepo.GetByID(ctx, id)
+	if err != nil {
+		return resaledom.Resale{}, err
+	}
+
+	if current.Status == resaledom.StatusReserved &&
+		((item.Status != "" && item.Status != resaledom.StatusReserved) ||
+			item.Price != current.Price) {
+		return resaledom.Resale{}, resaledom.ErrReserved
+	}
+
+	if current.Status != resaledom.StatusReserved &&
+		item.Status == resaledom.StatusReserved {
+		return resaledom.Resale{}, resaledom.ErrInvalidStatus
+	}
+
 	return uc.resaleRepo.Update(ctx, id, item)
 }
 
@@ -0,0 +1,1176 @@
+// backend/internal/application/usecase/return_usecase.go
+package usecase
+
+/*
+責務:
+- 購入者による注文明細（list item）の返品申請・取消・写真添付
+- ブランド（console）による承認・却下・受領・完了
+- 承認時の返品送料見積もり（購入者住所 -> inventory 保管場所）
+- 受領時の NFT 返送（avatar wallet -> brand wallet）と在庫への戻し入れ
+- 完了時の返金、または交換注文の作成
+
+前提:
+- 返品は明細単位（qty = 明細の qty）。resale item は返品対象外。
+- 明細が dispatched / delivered の場合のみ申請できる。
+- NFT 移転済みの明細は、購入者が返送する productId を指定する。
+  productId はその注文・明細へ移転に成功した transfer 記録があるものに限る。
+- 受領処理は途中で失敗しても再実行できる。NFT 返送は productId ごとに記録し、
+  在庫戻し入れ・注文明細の returned 遷移はいずれも冪等。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	applicationport "narratives/internal/application/port"
+	inventorydom "narratives/internal/domain/inventory"
+	orderdom "narratives/internal/domain/order"
+	returndom "narratives/internal/domain/orderReturn"
+	refunddom "narratives/internal/domain/refund"
+	transferdom "narratives/internal/domain/transfer"
+	transportationdom "narratives/internal/domain/transportation"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+// OrderRepoForReturn reads the returned Order and marks its item returned.
+type OrderRepoForReturn interface {
+	GetByID(
+		ctx context.Context,
+		id string,
+	) (orderdom.Order, error)
+
+	Update(
+		ctx context.Context,
+		order orderdom.Order,
+		opts *orderdom.UpdateOptions,
+	) (orderdom.Order, error)
+}
+
+// ReturnInventoryRestocker puts returned products back into ModelStock.
+type ReturnInventoryRestocker interface {
+	UpsertByModelAndToken(
+		ctx context.Context,
+		tokenBlueprintID string,
+		productBlueprintID string,
+		modelID string,
+		productIDs []string,
+	) (inventorydom.Mint, error)
+
+	ReleaseReservationByOrder(
+		ctx context.Context,
+		inventoryID string,
+		modelID string,
+		orderID string,
+		now time.Time,
+	) error
+}
+
+// ReturnShippingQuoter quotes the return shipping fee.
+type ReturnShippingQuoter interface {
+	QuoteReturn(
+		ctx context.Context,
+		input ReturnShippingQuoteInput,
+	) (ShippingQuoteResult, error)
+}
+
+// ReturnReplacementOrderCreator creates the order that re-ships a returned item.
+type ReturnReplacementOrderCreator interface {
+	CreateReplacementOrder(
+		ctx context.Context,
+		in CreateReplacementOrderInput,
+	) (orderdom.Order, error)
+}
+
+// ReturnTransferLister returns every transfer attempt of a product.
+// transfer.RepositoryPort implements it.
+type ReturnTransferLister interface {
+	ListByProductID(
+		ctx context.Context,
+		productID string,
+	) ([]transferdom.Transfer, error)
+}
+
+// ReturnTokenDependencies sends returned NFTs back to the brand wallet.
+type ReturnTokenDependencies struct {
+	Tokens       TokenResolver
+	AvatarWallet AvatarWalletResolver
+	BrandWallet  BrandWalletResolver
+	Executor     TokenTransferExecutor
+	TokenOwner   TokenOwnerUpdater
+
+	// WalletItems is optional. The avatar wallet cache is synced from
+	// on-chain later when it is nil.
+	WalletItems AvatarWalletItemTransferUpdater
+}
+
+func (d ReturnTokenDependencies) configured() bool {
+	return d.Tokens != nil &&
+		d.AvatarWallet != nil &&
+		d.BrandWallet != nil &&
+		d.Executor != nil &&
+		d.TokenOwner != nil
+}
+
+// ============================================================
+// Errors
+// ============================================================
+
+var (
+	ErrReturnNotConfigured = errors.New(
+		"return: usecase is not configured",
+	)
+	ErrReturnOrderNotPaid = errors.New(
+		"return: order is not paid",
+	)
+	ErrReturnItemNotReturnable = errors.New(
+		"return: order item is not returnable",
+	)
+	ErrReturnProductIDsRequired = errors.New(
+		"return: productIds are required for transferred items",
+	)
+	ErrReturnProductIDsNotAllowed = errors.New(
+		"return: productIds are only allowed for transferred items",
+	)
+	ErrReturnProductNotTransferred = errors.New(
+		"return: product was not transferred for the returned item",
+	)
+	ErrReturnTokenMismatch = errors.New(
+		"return: product token does not belong to the returned item",
+	)
+	ErrReturnTokenNotConfigured = errors.New(
+		"return: token return dependencies are not configured",
+	)
+	ErrReturnAlreadyRefunded = errors.New(
+		"return: order item is already refunded",
+	)
+	ErrReturnNotEditable = errors.New(
+		"return: return is not editable",
+	)
+)
+
+// ============================================================
+// Usecase
+// ============================================================
+
+type ReturnUsecase struct {
+	repo                 returndom.RepositoryPort
+	imageRepo            returndom.ImageRepository
+	orderRepo            OrderRepoForReturn
+	productBlueprintRepo applicationport.ProductBlueprintGetter
+	inventory            ReturnInventoryRestocker
+
+	transfers   ReturnTransferLister
+	productRepo applicationport.ProductGetter
+
+	shippingQuoter      ReturnShippingQuoter
+	tokens              ReturnTokenDependencies
+	refundIssuer        OrderRefundIssuer
+	replacementCreator  ReturnReplacementOrderCreator
+	stockLevelEvaluator StockLevelEvaluator
+
+	now func() time.Time
+}
+
+func NewReturnUsecase(
+	repo returndom.RepositoryPort,
+	imageRepo returndom.ImageRepository,
+	orderRepo OrderRepoForReturn,
+	productBlueprintRepo applicationport.ProductBlueprintGetter,
+	inventory ReturnInventoryRestocker,
+) *ReturnUsecase {
+	return &ReturnUsecase{
+		repo:                 repo,
+		imageRepo:            imageRepo,
+		orderRepo:            orderRepo,
+		productBlueprintRepo: productBlueprintRepo,
+		inventory:            inventory,
+		now:                  time.Now,
+	}
+}
+
+// WithShippingQuoter は承認時の返品送料見積もりを有効にする。
+func (u *ReturnUsecase) WithShippingQuoter(
+	quoter ReturnShippingQuoter,
+) *ReturnUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.shippingQuoter = quoter
+
+	return u
+}
+
+// WithTransferVerification は返品申請の productId を transfer 記録で検証する。
+// 未設定の場合、NFT 移転済み明細の返品は申請できない。
+func (u *ReturnUsecase) WithTransferVerification(
+	transfers ReturnTransferLister,
+	productRepo applicationport.ProductGetter,
+) *ReturnUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.transfers = transfers
+	u.productRepo = productRepo
+
+	return u
+}
+
+// WithTokenReturn は受領時の NFT 返送を有効にする。
+// 未設定の場合、NFT 移転済み明細の返品は受領できない。
+func (u *ReturnUsecase) WithTokenReturn(
+	deps ReturnTokenDependencies,
+) *ReturnUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.tokens = deps
+
+	return u
+}
+
+func (u *ReturnUsecase) WithRefundIssuer(
+	refundIssuer OrderRefundIssuer,
+) *ReturnUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.refundIssuer = refundIssuer
+
+	return u
+}
+
+func (u *ReturnUsecase) WithReplacementOrderCreator(
+	creator ReturnReplacementOrderCreator,
+) *ReturnUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.replacementCreator = creator
+
+	return u
+}
+
+// WithStockLevelEvaluator は在庫戻し入れ後の在庫アラート評価を有効にする。
+func (u *ReturnUsecase) WithStockLevelEvaluator(
+	evaluator StockLevelEvaluator,
+) *ReturnUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.stockLevelEvaluator = evaluator
+
+	return u
+}
+
+// ============================================================
+// Buyer side (mall)
+// ============================================================
+
+type RequestReturnInput struct {
+	AvatarID string
+
+	OrderID   string
+	ItemIndex int
+
+	// ProductIDs are required when the item's NFTs were transferred.
+	ProductIDs []string
+
+	Reasons    []returndom.Reason
+	Comment    string
+	Resolution returndom.Resolution
+}
+
+// RequestReturn creates a return request for one list item of the avatar's
+// order.
+func (u *ReturnUsecase) RequestReturn(
+	ctx context.Context,
+	in RequestReturnInput,
+) (returndom.Return, error) {
+	if u == nil ||
+		u.repo == nil ||
+		u.orderRepo == nil ||
+		u.productBlueprintRepo == nil {
+		return returndom.Return{}, ErrReturnNotConfigured
+	}
+
+	avatarID := strings.TrimSpace(in.AvatarID)
+	if avatarID == "" {
+		return returndom.Return{}, returndom.ErrInvalidAvatarID
+	}
+
+	orderID := strings.TrimSpace(in.OrderID)
+	if orderID == "" {
+		return returndom.Return{}, returndom.ErrInvalidOrderID
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, orderdom.ErrNotFound) {
+			return returndom.Return{}, returndom.ErrNotFound
+		}
+		return returndom.Return{}, err
+	}
+
+	// 他人の注文は存在しないものとして扱う。
+	if order.AvatarID != avatarID {
+		return returndom.Return{}, returndom.ErrNotFound
+	}
+
+	if !order.Paid {
+		return returndom.Return{}, ErrReturnOrderNotPaid
+	}
+
+	if in.ItemIndex < 0 || in.ItemIndex >= len(order.Items) {
+		return returndom.Return{}, returndom.ErrInvalidItemIndex
+	}
+
+	item := order.Items[in.ItemIndex]
+
+	if item.Type != orderdom.OrderItemTypeList ||
+		!orderdom.CanTransition(
+			order.ItemStatus(in.ItemIndex),
+			orderdom.StatusReturned,
+		) {
+		return returndom.Return{}, ErrReturnItemNotReturnable
+	}
+
+	if order.ItemRefundedQty(in.ItemIndex) >= item.Qty {
+		return returndom.Return{}, ErrReturnAlreadyRefunded
+	}
+
+	productIDs := in.ProductIDs
+	if item.Transferred {
+		if len(productIDs) == 0 {
+			return returndom.Return{}, ErrReturnProductIDsRequired
+		}
+		if err := u.verifyTransferredProducts(
+			ctx,
+			order,
+			in.ItemIndex,
+			productIDs,
+		); err != nil {
+			return returndom.Return{}, err
+		}
+	} else if len(productIDs) > 0 {
+		return returndom.Return{}, ErrReturnProductIDsNotAllowed
+	}
+
+	productBlueprint, err := u.productBlueprintRepo.GetByID(
+		ctx,
+		item.ProductBlueprintID,
+	)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	now := u.now().UTC()
+
+	ret, err := returndom.New(returndom.NewInput{
+		ID:        u.newReturnID(now),
+		OrderID:   order.ID,
+		ItemIndex: in.ItemIndex,
+
+		UserID:   order.UserID,
+		AvatarID: order.AvatarID,
+
+		CompanyID: productBlueprint.CompanyID,
+		BrandID:   firstNonEmpty(item.BrandID, productBlueprint.BrandID),
+
+		InventoryID:        item.InventoryID,
+		ModelID:            item.ModelID,
+		ListID:             item.ListID,
+		ProductBlueprintID: item.ProductBlueprintID,
+		TokenBlueprintID:   item.TokenBlueprintID,
+
+		Qty:        item.Qty,
+		ProductIDs: productIDs,
+
+		Reasons:    in.Reasons,
+		Comment:    in.Comment,
+		Resolution: in.Resolution,
+
+		CreatedAt: now,
+	})
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	return u.repo.Create(ctx, ret)
+}
+
+// verifyTransferredProducts は productIDs がいずれも order の itemIndex の明細として
+// 購入者へ移転されたものかを確認する。
+//
+// transfer 記録は明細番号を持たないため、成功した移転の orderId・受取 avatar と、
+// product の modelId が明細と一致することで判定する。
+func (u *ReturnUsecase) verifyTransferredProducts(
+	ctx context.Context,
+	order orderdom.Order,
+	itemIndex int,
+	productIDs []string,
+) error {
+	if u.transfers == nil || u.productRepo == nil {
+		return ErrReturnNotConfigured
+	}
+
+	item := order.Items[itemIndex]
+
+	for _, productID := range productIDs {
+		productID = strings.TrimSpace(productID)
+		if productID == "" {
+			return returndom.ErrInvalidProductIDs
+		}
+
+		transfers, err := u.transfers.ListByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		transferred := false
+		for _, t := range transfers {
+			if t.Status == transferdom.StatusSucceeded &&
+				t.OrderID == order.ID &&
+				t.AvatarID == order.AvatarID {
+				transferred = true
+				break
+			}
+		}
+		if !transferred {
+			return fmt.Errorf(
+				"%w: productId=%s",
+				ErrReturnProductNotTransferred,
+				productID,
+			)
+		}
+
+		product, err := u.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if product.ModelID != item.ModelID {
+			return fmt.Errorf(
+				"%w: productId=%s",
+				ErrReturnProductNotTransferred,
+				productID,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (u *ReturnUsecase) ListForAvatar(
+	ctx context.Context,
+	avatarID string,
+) ([]returndom.Return, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrReturnNotConfigured
+	}
+
+	return u.repo.ListByAvatarID(ctx, avatarID)
+}
+
+func (u *ReturnUsecase) GetForAvatar(
+	ctx context.Context,
+	avatarID string,
+	id string,
+) (returndom.Return, error) {
+	if u == nil || u.repo == nil {
+		return returndom.Return{}, ErrReturnNotConfigured
+	}
+
+	ret, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	if ret.AvatarID != strings.TrimSpace(avatarID) {
+		return returndom.Return{}, returndom.ErrNotFound
+	}
+
+	return ret, nil
+}
+
+// Cancel withdraws the avatar's request before the products are received.
+func (u *ReturnUsecase) Cancel(
+	ctx context.Context,
+	avatarID string,
+	id string,
+) (returndom.Return, error) {
+	ret, err := u.GetForAvatar(ctx, avatarID, id)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	if ret.Status == returndom.StatusCancelled {
+		return ret, nil
+	}
+
+	if err := ret.Cancel(u.now()); err != nil {
+		return returndom.Return{}, err
+	}
+
+	return u.repo.Update(ctx, ret)
+}
+
+func (u *ReturnUsecase) ListImages(
+	ctx context.Context,
+	avatarID string,
+	returnID string,
+) ([]returndom.ReturnImage, error) {
+	if u == nil || u.imageRepo == nil {
+		return nil, ErrReturnNotConfigured
+	}
+
+	ret, err := u.GetForAvatar(ctx, avatarID, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.imageRepo.ListByReturnID(ctx, ret.ID)
+}
+
+// AddImage attaches a photo to a request that has not been received yet.
+// img.URL must be a Firebase Storage download URL uploaded by the client.
+func (u *ReturnUsecase) AddImage(
+	ctx context.Context,
+	avatarID string,
+	img returndom.ReturnImage,
+) (returndom.ReturnImage, error) {
+	if u == nil || u.imageRepo == nil {
+		return returndom.ReturnImage{}, ErrReturnNotConfigured
+	}
+
+	ret, err := u.GetForAvatar(ctx, avatarID, img.ReturnID)
+	if err != nil {
+		return returndom.ReturnImage{}, err
+	}
+
+	if !isReturnEditable(ret) {
+		return returndom.ReturnImage{}, ErrReturnNotEditable
+	}
+
+	images, err := u.imageRepo.ListByReturnID(ctx, ret.ID)
+	if err != nil {
+		return returndom.ReturnImage{}, err
+	}
+
+	if len(images) >= returndom.MaxImages {
+		return returndom.ReturnImage{}, returndom.ErrTooManyImages
+	}
+
+	if img.DisplayOrder < 0 {
+		img.DisplayOrder = 0
+	}
+
+	created, err := returndom.NewReturnImage(
+		strings.TrimSpace(img.ID),
+		ret.ID,
+		strings.TrimSpace(img.URL),
+		img.DisplayOrder,
+		u.now(),
+		ret.AvatarID,
+	)
+	if err != nil {
+		return returndom.ReturnImage{}, err
+	}
+
+	return u.imageRepo.Create(ctx, created)
+}
+
+func (u *ReturnUsecase) DeleteImage(
+	ctx context.Context,
+	avatarID string,
+	returnID string,
+	imageID string,
+) error {
+	if u == nil || u.imageRepo == nil {
+		return ErrReturnNotConfigured
+	}
+
+	ret, err := u.GetForAvatar(ctx, avatarID, returnID)
+	if err != nil {
+		return err
+	}
+
+	if !isReturnEditable(ret) {
+		return ErrReturnNotEditable
+	}
+
+	return u.imageRepo.Delete(ctx, ret.ID, imageID)
+}
+
+// ============================================================
+// Brand side (console)
+// ============================================================
+
+func (u *ReturnUsecase) ListForCompany(
+	ctx context.Context,
+	status returndom.Status,
+) ([]returndom.Return, error) {
+	if u == nil || u.repo == nil {
+		return nil, ErrReturnNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return nil, returndom.ErrInvalidCompanyID
+	}
+
+	return u.repo.ListByCompanyID(ctx, companyID, status)
+}
+
+func (u *ReturnUsecase) GetForCompany(
+	ctx context.Context,
+	id string,
+) (returndom.Return, error) {
+	if u == nil || u.repo == nil {
+		return returndom.Return{}, ErrReturnNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return returndom.Return{}, returndom.ErrInvalidCompanyID
+	}
+
+	ret, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	// 他社の返品は存在しないものとして扱う。
+	if ret.CompanyID != companyID {
+		return returndom.Return{}, returndom.ErrNotFound
+	}
+
+	return ret, nil
+}
+
+func (u *ReturnUsecase) ListImagesForCompany(
+	ctx context.Context,
+	returnID string,
+) ([]returndom.ReturnImage, error) {
+	if u == nil || u.imageRepo == nil {
+		return nil, ErrReturnNotConfigured
+	}
+
+	ret, err := u.GetForCompany(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.imageRepo.ListByReturnID(ctx, ret.ID)
+}
+
+type ApproveReturnInput struct {
+	ID string
+
+	// Resolution overrides the buyer's choice when set.
+	Resolution returndom.Resolution
+
+	// Carrier overrides the inventory's transportation option for the
+	// return shipping quote.
+	Carrier transportationdom.Carrier
+
+	Note string
+}
+
+// Approve accepts the request and quotes the return shipping fee for
+// domestic buyers.
+func (u *ReturnUsecase) Approve(
+	ctx context.Context,
+	in ApproveReturnInput,
+) (returndom.Return, error) {
+	ret, err := u.GetForCompany(ctx, in.ID)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	memberID := strings.TrimSpace(MemberIDFromContext(ctx))
+	now := u.now().UTC()
+
+	var quote *returndom.ShippingQuote
+	if u.shippingQuoter != nil {
+		quote, err = u.quoteReturnShipping(ctx, ret, in.Carrier, now)
+		if err != nil {
+			return returndom.Return{}, err
+		}
+	}
+
+	if err := ret.Approve(
+		in.Resolution,
+		quote,
+		in.Note,
+		memberID,
+		now,
+	); err != nil {
+		return returndom.Return{}, err
+	}
+
+	return u.repo.Update(ctx, ret)
+}
+
+func (u *ReturnUsecase) Reject(
+	ctx context.Context,
+	id string,
+	note string,
+) (returndom.Return, error) {
+	ret, err := u.GetForCompany(ctx, id)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	if err := ret.Reject(
+		note,
+		MemberIDFromContext(ctx),
+		u.now(),
+	); err != nil {
+		return returndom.Return{}, err
+	}
+
+	return u.repo.Update(ctx, ret)
+}
+
+// Receive records that the returned products arrived at the warehouse.
+//
+// Steps (each persisted before the next, so a failed call can be retried):
+//  1. send every returned NFT back to the brand wallet
+//  2. put the products back into inventory
+//  3. move the order item to returned
+//  4. move the return to received
+func (u *ReturnUsecase) Receive(
+	ctx context.Context,
+	id string,
+) (returndom.Return, error) {
+	if u == nil || u.inventory == nil || u.orderRepo == nil {
+		return returndom.Return{}, ErrReturnNotConfigured
+	}
+
+	ret, err := u.GetForCompany(ctx, id)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	if ret.Status == returndom.StatusReceived {
+		return ret, nil
+	}
+
+	if ret.Status != returndom.StatusApproved {
+		return returndom.Return{}, returndom.ErrInvalidTransition
+	}
+
+	memberID := strings.TrimSpace(MemberIDFromContext(ctx))
+	if memberID == "" {
+		return returndom.Return{}, returndom.ErrInvalidActor
+	}
+
+	for _, productID := range ret.ProductIDs {
+		if ret.HasTokenReturned(productID) {
+			continue
+		}
+
+		if err := u.returnToken(ctx, &ret, productID); err != nil {
+			return returndom.Return{}, err
+		}
+
+		ret, err = u.repo.Update(ctx, ret)
+		if err != nil {
+			return returndom.Return{}, err
+		}
+	}
+
+	if !ret.Restocked {
+		if err := u.restock(ctx, ret); err != nil {
+			return returndom.Return{}, err
+		}
+
+		if err := ret.MarkRestocked(u.now()); err != nil {
+			return returndom.Return{}, err
+		}
+
+		ret, err = u.repo.Update(ctx, ret)
+		if err != nil {
+			return returndom.Return{}, err
+		}
+
+		EvaluateStockLevelBestEffort(
+			ctx,
+			u.stockLevelEvaluator,
+			ret.InventoryID,
+			ret.ModelID,
+		)
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, ret.OrderID)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	changed, err := order.MarkItemReturned(ret.ItemIndex, u.now())
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	if changed {
+		if _, err := u.orderRepo.Update(ctx, order, nil); err != nil {
+			return returndom.Return{}, err
+		}
+	}
+
+	if err := ret.MarkReceived(memberID, u.now()); err != nil {
+		return returndom.Return{}, err
+	}
+
+	return u.repo.Update(ctx, ret)
+}
+
+// Complete applies the resolution (refund or replacement order) and closes
+// the return. The refund / replacement is linked to the return before it is
+// closed, so a retry never issues it twice.
+func (u *ReturnUsecase) Complete(
+	ctx context.Context,
+	id string,
+	note string,
+) (returndom.Return, error) {
+	ret, err := u.GetForCompany(ctx, id)
+	if err != nil {
+		return returndom.Return{}, err
+	}
+
+	if ret.Status == returndom.StatusCompleted {
+		return ret, nil
+	}
+
+	if ret.Status != returndom.StatusReceived {
+		return returndom.Return{}, returndom.ErrInvalidTransition
+	}
+
+	memberID := strings.TrimSpace(MemberIDFromContext(ctx))
+	if memberID == "" {
+		return returndom.Return{}, returndom.ErrInvalidActor
+	}
+
+	switch ret.Resolution {
+	case returndom.ResolutionRefund:
+		if ret.RefundID == "" {
+			if err := u.issueRefund(ctx, &ret, memberID, note); err != nil {
+				return returndom.Return{}, err
+			}
+
+			ret, err = u.repo.Update(ctx, ret)
+			if err != nil {
+				return returndom.Return{}, err
+			}
+		}
+
+	case returndom.ResolutionReplacement:
+		if ret.ReplacementOrderID == "" {
+			if u.replacementCreator == nil {
+				return returndom.Return{}, ErrReturnNotConfigured
+			}
+
+			order, err := u.replacementCreator.CreateReplacementOrder(
+				ctx,
+				CreateReplacementOrderInput{
+					ID:            replacementOrderID(ret.ID),
+					SourceOrderID: ret.OrderID,
+					ItemIndex:     ret.ItemIndex,
+				},
+			)
+			if err != nil {
+				return returndom.Return{}, err
+			}
+
+			if err := ret.RecordReplacementOrder(order.ID, u.now()); err != nil {
+				return returndom.Return{}, err
+			}
+
+			ret, err = u.repo.Update(ctx, ret)
+			if err != nil {
+				return returndom.Return{}, err
+			}
+		}
+	}
+
+	if err := ret.Complete(memberID, u.now()); err != nil {
+		return returndom.Return{}, err
+	}
+
+	return u.repo.Update(ctx, ret)
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+func (u *ReturnUsecase) quoteReturnShipping(
+	ctx context.Context,
+	ret returndom.Return,
+	carrier transportationdom.Carrier,
+	now time.Time,
+) (*returndom.ShippingQuote, error) {
+	order, err := u.orderRepo.GetByID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 発送元は国内のみ対応のため、国外の購入者の送料は見積もらない。
+	shipping := order.ShippingSnapshot
+	if shipping.Country != orderdom.DomesticCountry {
+		return nil, nil
+	}
+
+	if carrier == "" {
+		for _, q := range order.ShippingQuoteSnapshot.Items {
+			if q.ListID == ret.ListID && q.ModelID == ret.ModelID {
+				carrier = transportationdom.Carrier(q.Carrier)
+				break
+			}
+		}
+	}
+
+	result, err := u.shippingQuoter.QuoteReturn(
+		ctx,
+		ReturnShippingQuoteInput{
+			InventoryID: ret.InventoryID,
+			ModelID:     ret.ModelID,
+			Carrier:     carrier,
+			Origin: transportationdom.Address{
+				Country: shipping.Country,
+				ZipCode: shipping.ZipCode,
+				State:   shipping.State,
+				City:    shipping.City,
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &returndom.ShippingQuote{
+		Carrier:  result.Carrier,
+		Size:     result.Size,
+		Amount:   int(result.Amount),
+		Currency: returndom.ShippingQuoteCurrencyJPY,
+		QuotedAt: now,
+	}, nil
+}
+
+// returnToken transfers the NFT of productID from the buyer's avatar wallet
+// back to the brand wallet and records it on ret.
+func (u *ReturnUsecase) returnToken(
+	ctx context.Context,
+	ret *returndom.Return,
+	productID string,
+) error {
+	if !u.tokens.configured() {
+		return ErrReturnTokenNotConfigured
+	}
+
+	token, err := u.tokens.Tokens.ResolveTokenByProductID(ctx, productID)
+	if err != nil {
+		return fmt.Errorf(
+			"return: resolve token failed productId=%s: %w",
+			productID,
+			err,
+		)
+	}
+
+	if token.AssetID == "" {
+		return ErrTransferAssetIDEmpty
+	}
+
+	if token.TokenBlueprintID != "" &&
+		token.TokenBlueprintID != ret.TokenBlueprintID {
+		return ErrReturnTokenMismatch
+	}
+
+	brandID := firstNonEmpty(token.BrandID, ret.BrandID)
+	if brandID == "" {
+		return ErrTransferBrandIDEmpty
+	}
+
+	if ret.BrandID != "" && brandID != ret.BrandID {
+		return ErrReturnTokenMismatch
+	}
+
+	fromWallet, err := u.tokens.AvatarWallet.ResolveAvatarWalletAddress(
+		ctx,
+		ret.AvatarID,
+	)
+	if err != nil {
+		return err
+	}
+	if fromWallet == "" {
+		return ErrTransferFromWalletEmpty
+	}
+
+	toWallet, err := u.tokens.BrandWallet.ResolveBrandWalletAddress(
+		ctx,
+		brandID,
+	)
+	if err != nil {
+		return err
+	}
+	if toWallet == "" {
+		return ErrTransferToWalletEmpty
+	}
+
+	result, err := u.tokens.Executor.ExecuteTransfer(
+		ctx,
+		ExecuteTransferInput{
+			ProductID:   productID,
+			OperationID: "return_" + ret.ID + "_" + productID,
+
+			FromAvatarID: ret.AvatarID,
+			ToBrandID:    brandID,
+
+			BrandID:          brandID,
+			ModelID:          ret.ModelID,
+			TokenBlueprintID: ret.TokenBlueprintID,
+
+			AssetID: token.AssetID,
+
+			FromWalletAddress: fromWallet,
+			ToWalletAddress:   toWallet,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	now := u.now().UTC()
+
+	if err := u.tokens.TokenOwner.UpdateToAddressByProductID(
+		ctx,
+		productID,
+		toWallet,
+		now,
+		result.TxSignature,
+	); err != nil {
+		return err
+	}
+
+	if u.tokens.WalletItems != nil {
+		if err := u.tokens.WalletItems.RemoveAssetIDFromAvatarWalletItems(
+			ctx,
+			ret.AvatarID,
+			token.AssetID,
+			now,
+		); err != nil {
+			log.Printf(
+				"return usecase: remove asset from avatar wallet failed returnId=%q assetId=%q err=%v",
+				ret.ID,
+				token.AssetID,
+				err,
+			)
+		}
+	}
+
+	_, err = ret.RecordTokenReturned(
+		productID,
+		token.AssetID,
+		result.TxSignature,
+		now,
+	)
+	return err
+}
+
+// restock puts the returned products back into ModelStock.
+//
+// Transferred products were removed from ModelStock.Products when their NFT
+// moved to the buyer, so they are added back. Products that never left
+// ModelStock are still reserved by the order; releasing the reservation
+// makes them available again.
+func (u *ReturnUsecase) restock(
+	ctx context.Context,
+	ret returndom.Return,
+) error {
+	if len(ret.ProductIDs) > 0 {
+		if _, err := u.inventory.UpsertByModelAndToken(
+			ctx,
+			ret.TokenBlueprintID,
+			ret.ProductBlueprintID,
+			ret.ModelID,
+			ret.ProductIDs,
+		); err != nil {
+			return err
+		}
+	}
+
+	return u.inventory.ReleaseReservationByOrder(
+		ctx,
+		ret.InventoryID,
+		ret.ModelID,
+		ret.OrderID,
+		u.now(),
+	)
+}
+
+func (u *ReturnUsecase) issueRefund(
+	ctx context.Context,
+	ret *returndom.Return,
+	memberID string,
+	note string,
+) error {
+	if u.refundIssuer == nil || u.orderRepo == nil {
+		return ErrReturnNotConfigured
+	}
+
+	order, err := u.orderRepo.GetByID(ctx, ret.OrderID)
+	if err != nil {
+		return err
+	}
+
+	qty := ret.Qty - order.ItemRefundedQty(ret.ItemIndex)
+	if qty <= 0 {
+		return ErrReturnAlreadyRefunded
+	}
+
+	refund, err := u.refundIssuer.IssueRefund(ctx, IssueRefundInput{
+		OrderID: ret.OrderID,
+		Items: []IssueRefundItemInput{
+			{ItemIndex: ret.ItemIndex, Qty: qty},
+		},
+		Reason:      refunddom.ReasonRequestedByCustomer,
+		Note:        strings.TrimSpace(note),
+		RequestedBy: memberID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ret.RecordRefund(refund.ID, u.now())
+}
+
+// isReturnEditable reports whether the buyer may still change the photos.
+func isReturnEditable(ret returndom.Return) bool {
+	return ret.Status == returndom.StatusRequested ||
+		ret.Status == returndom.StatusApproved
+}
+
+// replacementOrderID is deterministic so a retried completion finds the
+// already created order.
+func replacementOrderID(returnID string) string {
+	return "ord_rpl_" + returnID
+}
+
+func (u *ReturnUsecase) newReturnID(t time.Time) string {
+	return fmt.Sprintf(
+		"ret_%d",
+		t.UTC().UnixNano(),
+	)
+}
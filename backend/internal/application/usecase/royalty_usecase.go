@@ -0,0 +1,242 @@
+// backend/internal/application/usecase/royalty_usecase.go
+package usecase
+
+/*
+責務:
+- resale item のロイヤリティ計算（注文作成時に OrderItemSnapshot.Royalty へ保存）
+- 決済完了時のロイヤリティ台帳への計上
+- ブランド（console）向けの台帳一覧・brand ごとの集計
+
+前提:
+- ロイヤリティの設定は token blueprint の RoyaltyPolicy。受取先は token blueprint の company。
+- 計算の基準は resale item の販売価格（税抜, price * qty）。resale item はクーポン対象外。
+- ロイヤリティは出品者の受取額から差し引く。購入者の決済額は変わらない。
+- 台帳は {orderId}_{itemIndex} 単位で 1 回だけ計上する（決済後処理の再実行に備える）。
+- 取消済みの明細は計上しない。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	orderdom "narratives/internal/domain/order"
+	resaledom "narratives/internal/domain/resale"
+	royaltydom "narratives/internal/domain/royalty"
+	tbdom "narratives/internal/domain/tokenBlueprint"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+type RoyaltyTokenBlueprintGetter interface {
+	GetByID(ctx context.Context, id string) (*tbdom.TokenBlueprint, error)
+}
+
+// RoyaltyResaleGetter resolves the reselling avatar recorded on the ledger.
+type RoyaltyResaleGetter interface {
+	GetByID(ctx context.Context, id string) (resaledom.Resale, error)
+}
+
+var ErrRoyaltyNotConfigured = errors.New(
+	"royalty: usecase is not configured",
+)
+
+type RoyaltyUsecase struct {
+	repo               royaltydom.RepositoryPort
+	tokenBlueprintRepo RoyaltyTokenBlueprintGetter
+	resaleRepo         RoyaltyResaleGetter
+
+	now func() time.Time
+}
+
+func NewRoyaltyUsecase(
+	repo royaltydom.RepositoryPort,
+	tokenBlueprintRepo RoyaltyTokenBlueprintGetter,
+	resaleRepo RoyaltyResaleGetter,
+) *RoyaltyUsecase {
+	return &RoyaltyUsecase{
+		repo:               repo,
+		tokenBlueprintRepo: tokenBlueprintRepo,
+		resaleRepo:         resaleRepo,
+		now:                time.Now,
+	}
+}
+
+// ============================================================
+// Order placement
+// ============================================================
+
+// QuoteRoyalty は resale item のロイヤリティを計算します。
+// list item や、ロイヤリティが設定されていない token blueprint の場合は nil を返します。
+func (u *RoyaltyUsecase) QuoteRoyalty(
+	ctx context.Context,
+	item orderdom.OrderItemSnapshot,
+) (*orderdom.RoyaltySnapshot, error) {
+	if u == nil || u.tokenBlueprintRepo == nil {
+		return nil, ErrRoyaltyNotConfigured
+	}
+
+	if item.Type != orderdom.OrderItemTypeResale {
+		return nil, nil
+	}
+
+	tokenBlueprintID := strings.TrimSpace(item.TokenBlueprintID)
+	if tokenBlueprintID == "" {
+		return nil, orderdom.ErrInvalidItemSnapshot
+	}
+
+	tb, err := u.tokenBlueprintRepo.GetByID(ctx, tokenBlueprintID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"royalty: resolve tokenBlueprint %q: %w",
+			tokenBlueprintID,
+			err,
+		)
+	}
+	if tb == nil {
+		return nil, tbdom.ErrNotFound
+	}
+
+	amount := tb.Royalty.Calculate(item.Price * item.Qty)
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	return &orderdom.RoyaltySnapshot{
+		CompanyID:     tb.CompanyID,
+		BasisPoints:   tb.Royalty.BasisPoints,
+		MinimumAmount: tb.Royalty.MinimumAmount,
+		Amount:        amount,
+	}, nil
+}
+
+// ============================================================
+// Payment (post-paid)
+// ============================================================
+
+// AccrueForOrder は決済済み order の resale item のロイヤリティを台帳に計上します。
+// 計上済みの明細はスキップするため、何度呼び出しても結果は同じです。
+func (u *RoyaltyUsecase) AccrueForOrder(
+	ctx context.Context,
+	order orderdom.Order,
+) error {
+	if u == nil || u.repo == nil {
+		return ErrRoyaltyNotConfigured
+	}
+
+	accruedAt := u.now().UTC()
+
+	var errs []error
+
+	for index, item := range order.Items {
+		amount := order.ItemRoyaltyAmount(index)
+		if item.Type != orderdom.OrderItemTypeResale || amount <= 0 {
+			continue
+		}
+
+		entry, err := royaltydom.NewEntry(royaltydom.NewEntryInput{
+			CompanyID:        item.Royalty.CompanyID,
+			BrandID:          item.BrandID,
+			TokenBlueprintID: item.TokenBlueprintID,
+
+			OrderID:   order.ID,
+			ItemIndex: index,
+
+			ResaleID:       item.ResaleID,
+			ProductID:      item.ProductID,
+			SellerAvatarID: u.resolveSellerAvatarID(ctx, item.ResaleID),
+
+			SaleAmount:    item.Price * item.Qty,
+			BasisPoints:   item.Royalty.BasisPoints,
+			MinimumAmount: item.Royalty.MinimumAmount,
+			Amount:        amount,
+
+			AccruedAt: accruedAt,
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if _, err := u.repo.Create(ctx, entry); err != nil &&
+			!errors.Is(err, royaltydom.ErrConflict) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// resolveSellerAvatarID は出品者の avatarId を返します（解決できない場合は空）。
+func (u *RoyaltyUsecase) resolveSellerAvatarID(
+	ctx context.Context,
+	resaleID string,
+) string {
+	if u.resaleRepo == nil || strings.TrimSpace(resaleID) == "" {
+		return ""
+	}
+
+	resale, err := u.resaleRepo.GetByID(ctx, resaleID)
+	if err != nil {
+		return ""
+	}
+
+	return resale.AvatarID
+}
+
+// ============================================================
+// Brand side (console)
+// ============================================================
+
+// RoyaltyReport は台帳の一覧と集計です。
+type RoyaltyReport struct {
+	Items  []royaltydom.Entry        `json:"items"`
+	Brands []royaltydom.BrandSummary `json:"brands"`
+
+	Count  int `json:"count"`
+	Amount int `json:"amount"`
+}
+
+func (u *RoyaltyUsecase) ReportForCompany(
+	ctx context.Context,
+	filter royaltydom.ListFilter,
+) (RoyaltyReport, error) {
+	if u == nil || u.repo == nil {
+		return RoyaltyReport{}, ErrRoyaltyNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if companyID == "" {
+		return RoyaltyReport{}, royaltydom.ErrInvalidCompanyID
+	}
+
+	if filter.From != nil &&
+		filter.To != nil &&
+		!filter.From.Before(*filter.To) {
+		return RoyaltyReport{}, royaltydom.ErrInvalidPeriod
+	}
+
+	entries, err := u.repo.ListByCompanyID(ctx, companyID, filter)
+	if err != nil {
+		return RoyaltyReport{}, err
+	}
+
+	if entries == nil {
+		entries = []royaltydom.Entry{}
+	}
+
+	report := RoyaltyReport{
+		Items:  entries,
+		Brands: royaltydom.Summarize(entries),
+		Count:  len(entries),
+	}
+	for _, e := range entries {
+		report.Amount += e.Amount
+	}
+
+	return report, nil
+}
@@ -7,6 +7,7 @@ import (
 	inventorydom "narratives/internal/domain/inventory"
 	listdom "narratives/internal/domain/list"
 	modeldom "narratives/internal/domain/model"
+	productbpdom "narratives/internal/domain/productBlueprint"
 	shippingaddressdom "narratives/internal/domain/shippingAddress"
 	transportationdom "narratives/internal/domain/transportation"
 )
@@ -17,6 +18,18 @@ type ShippingQuoteUsecase struct {
 	modelRepo           modeldom.RepositoryPort
 	shippingAddressRepo shippingaddressdom.RepositoryPort
 	transportationSvc   *transportationdom.Service
+
+	// 国外宛ての税関情報（HS code / 内容品名）を読むための Port。
+	// nil の場合、国外宛ての見積もりは ErrNotSupported を返す。
+	productBlueprintRepo ProductBlueprintReaderForShippingQuote
+}
+
+// ProductBlueprintReaderForShippingQuote は国外宛ての税関情報を取得する最小 Port。
+type ProductBlueprintReaderForShippingQuote interface {
+	GetByID(
+		ctx context.Context,
+		id string,
+	) (productbpdom.ProductBlueprint, error)
 }
 
 type ShippingQuoteInput struct {
@@ -36,9 +49,30 @@ type ShippingQuoteResult struct {
 	TransportationOption inventorydom.TransportationOption
 	TransportationID     string
 
+	// Carrier は実際に使う配送便。
+	// 国外宛てでは国内キャリアが ems / epacket へ振り替わるため、
+	// TransportationOption と異なる場合がある。
+	Carrier transportationdom.Carrier
+
+	DestinationCountry string
+
 	Size     int
 	Amount   int64
 	Currency string
+
+	// Customs は国外宛ての場合だけ設定する。
+	Customs *ShippingQuoteCustoms
+}
+
+// ShippingQuoteCustoms は税関告知書に記載する内容品の情報。
+type ShippingQuoteCustoms struct {
+	HSCode        string
+	Description   string
+	OriginCountry string
+
+	// DeclaredUnitValue は 1 点あたりの申告価格（JPY）。
+	// product blueprint に申告価格がない場合は出品価格を使う。
+	DeclaredUnitValue int64
 }
 
 func NewShippingQuoteUsecase(
@@ -57,6 +91,18 @@ func NewShippingQuoteUsecase(
 	}
 }
 
+// WithProductBlueprintRepo は国外宛て見積もり用の product blueprint 読み取り Port を設定する。
+func (uc *ShippingQuoteUsecase) WithProductBlueprintRepo(
+	repo ProductBlueprintReaderForShippingQuote,
+) *ShippingQuoteUsecase {
+	if uc == nil {
+		return uc
+	}
+
+	uc.productBlueprintRepo = repo
+	return uc
+}
+
 func (uc *ShippingQuoteUsecase) Quote(
 	ctx context.Context,
 	input ShippingQuoteInput,
@@ -247,14 +293,34 @@ func (uc *ShippingQuoteUsecase) Quote(
 			)
 	}
 
-	if destinationAddress.Country !=
-		shippingaddressdom.DefaultCountry {
+	if !transportationdom.IsSupportedCountry(
+		destinationAddress.Country,
+	) {
 		return ShippingQuoteResult{},
 			ErrInvalidArgument(
 				"unsupported_destination_country",
 			)
 	}
 
+	international :=
+		destinationAddress.Country !=
+			shippingaddressdom.DefaultCountry
+
+	var customs *ShippingQuoteCustoms
+
+	if international {
+		customs, err =
+			uc.resolveCustoms(
+				ctx,
+				inventoryItem.ProductBlueprintID,
+				listItem.Prices,
+				input.ModelID,
+			)
+		if err != nil {
+			return ShippingQuoteResult{}, err
+		}
+	}
+
 	quote, err :=
 		uc.transportationSvc.Calculate(
 			ctx,
@@ -303,12 +369,223 @@ func (uc *ShippingQuoteUsecase) Quote(
 		TransportationOption: inventoryItem.TransportationOption,
 		TransportationID:     inventoryItem.TransportationID,
 
+		Carrier: quote.Carrier,
+
+		DestinationCountry: destinationAddress.Country,
+
+		Size:     quote.Size,
+		Amount:   quote.Amount,
+		Currency: "JPY",
+
+		Customs: customs,
+	}, nil
+}
+
+// ReturnShippingQuoteInput は返品（購入者 -> inventory 保管場所）の見積もり入力。
+type ReturnShippingQuoteInput struct {
+	InventoryID string
+	ModelID     string
+
+	// Carrier が空の場合は inventory の TransportationOption を使う。
+	Carrier transportationdom.Carrier
+
+	// Origin は購入者の住所（注文時の ShippingSnapshot）。
+	Origin transportationdom.Address
+}
+
+// QuoteReturn は返品送料を見積もる。
+//
+// 発送元は国内のみ対応のため、国外の購入者からの返品は
+// transportation.ErrUnsupportedCountry を返す。
+func (uc *ShippingQuoteUsecase) QuoteReturn(
+	ctx context.Context,
+	input ReturnShippingQuoteInput,
+) (ShippingQuoteResult, error) {
+	if uc == nil ||
+		uc.inventoryRepo == nil ||
+		uc.modelRepo == nil ||
+		uc.shippingAddressRepo == nil ||
+		uc.transportationSvc == nil {
+		return ShippingQuoteResult{},
+			ErrNotSupported(
+				"ShippingQuote.QuoteReturn",
+			)
+	}
+
+	if input.InventoryID == "" {
+		return ShippingQuoteResult{},
+			ErrInvalidArgument(
+				"inventory_id_required",
+			)
+	}
+
+	if input.ModelID == "" {
+		return ShippingQuoteResult{},
+			ErrInvalidArgument(
+				"model_id_required",
+			)
+	}
+
+	inventoryItem, err :=
+		uc.inventoryRepo.GetByID(
+			ctx,
+			input.InventoryID,
+		)
+	if err != nil {
+		return ShippingQuoteResult{}, err
+	}
+
+	if inventoryItem.ShippingAddressID == "" {
+		return ShippingQuoteResult{},
+			ErrInvalidArgument(
+				"inventory_shipping_address_id_required",
+			)
+	}
+
+	carrier := input.Carrier
+	if carrier == "" {
+		carrier = transportationdom.Carrier(
+			inventoryItem.TransportationOption,
+		)
+	}
+
+	modelItem, err :=
+		uc.modelRepo.GetByID(
+			ctx,
+			input.ModelID,
+		)
+	if err != nil {
+		return ShippingQuoteResult{}, err
+	}
+
+	if modelItem == nil {
+		return ShippingQuoteResult{},
+			modeldom.ErrNotFound
+	}
+
+	shippingPackage :=
+		modelItem.GetShippingPackage()
+
+	if err :=
+		shippingPackage.Validate(); err != nil {
+		return ShippingQuoteResult{}, err
+	}
+
+	warehouseAddress, err :=
+		uc.shippingAddressRepo.GetByID(
+			ctx,
+			inventoryItem.ShippingAddressID,
+		)
+	if err != nil {
+		return ShippingQuoteResult{}, err
+	}
+
+	if warehouseAddress == nil {
+		return ShippingQuoteResult{},
+			shippingaddressdom.ErrNotFound
+	}
+
+	quote, err :=
+		uc.transportationSvc.Calculate(
+			ctx,
+			transportationdom.CalculateInput{
+				Carrier: carrier,
+
+				Package: transportationdom.Package{
+					WeightGrams: shippingPackage.WeightGrams,
+					WidthMM:     shippingPackage.WidthMM,
+					LengthMM:    shippingPackage.LengthMM,
+					HeightMM:    shippingPackage.HeightMM,
+				},
+
+				Origin: input.Origin,
+
+				Destination: transportationdom.Address{
+					Country: warehouseAddress.Country,
+					ZipCode: warehouseAddress.ZipCode,
+					State:   warehouseAddress.State,
+					City:    warehouseAddress.City,
+				},
+
+				CompanyID: warehouseAddress.CompanyID,
+
+				TransportationID: inventoryItem.TransportationID,
+			},
+		)
+	if err != nil {
+		return ShippingQuoteResult{}, err
+	}
+
+	return ShippingQuoteResult{
+		InventoryID:                  inventoryItem.ID,
+		ModelID:                      input.ModelID,
+		DestinationShippingAddressID: warehouseAddress.ID,
+
+		TransportationOption: inventoryItem.TransportationOption,
+		TransportationID:     inventoryItem.TransportationID,
+
+		Carrier: quote.Carrier,
+
+		DestinationCountry: warehouseAddress.Country,
+
 		Size:     quote.Size,
 		Amount:   quote.Amount,
 		Currency: "JPY",
 	}, nil
 }
 
+// resolveCustoms は product blueprint の税関情報から内容品の申告内容を作る。
+func (uc *ShippingQuoteUsecase) resolveCustoms(
+	ctx context.Context,
+	productBlueprintID string,
+	prices []listdom.ListPriceRow,
+	modelID string,
+) (*ShippingQuoteCustoms, error) {
+	if uc.productBlueprintRepo == nil {
+		return nil,
+			ErrNotSupported(
+				"ShippingQuote.ProductBlueprintRepo",
+			)
+	}
+
+	productBlueprint, err :=
+		uc.productBlueprintRepo.GetByID(
+			ctx,
+			productBlueprintID,
+		)
+	if err != nil {
+		return nil, err
+	}
+
+	if !productBlueprint.Customs.IsComplete() {
+		return nil,
+			ErrInvalidArgument(
+				"customs_info_required",
+			)
+	}
+
+	declaredUnitValue :=
+		productBlueprint.Customs.DeclaredValue
+
+	if declaredUnitValue <= 0 {
+		for _, price := range prices {
+			if price.ModelID == modelID {
+				declaredUnitValue =
+					int64(price.Price)
+				break
+			}
+		}
+	}
+
+	return &ShippingQuoteCustoms{
+		HSCode:        productBlueprint.Customs.HSCode,
+		Description:   productBlueprint.Customs.Description,
+		OriginCountry: productBlueprint.Customs.ResolvedOriginCountry(),
+
+		DeclaredUnitValue: declaredUnitValue,
+	}, nil
+}
+
 func listContainsModel(
 	prices []listdom.ListPriceRow,
 	modelID string,
@@ -0,0 +1,643 @@
+// backend/internal/application/usecase/stock_alert_usecase.go
+package usecase
+
+/*
+責務:
+- inventory 単位の在庫アラート設定（model ごとの閾値・通知先）の取得と保存
+- Stock[modelId] の ReservedByOrder / Products が変わったときの閾値評価
+- 閾値以下になったときのアラート発火（履歴保存）とメール通知・console のお知らせ作成
+- console 向けのアラート履歴・発火中アラート一覧
+
+前提:
+- 販売可能数 = Accumulation - ReservedCount（0 未満は 0）
+- 同じ inventory / model の open なアラートは 1 件まで（再通知しない）
+- 閾値を上回ったら resolved にし、再び閾値以下になったら新しく発火する
+- 評価は在庫更新の後に best-effort で行い、在庫更新自体は失敗させない
+- console のお知らせは inventory の token blueprint 宛ての未公開のお知らせとして作る。
+  未公開なので mall の avatar には配信されず、console のお知らせ管理にだけ表示される。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	applicationport "narratives/internal/application/port"
+	ann "narratives/internal/domain/announcement"
+	invdom "narratives/internal/domain/inventory"
+)
+
+// ============================================================
+// Ports
+// ============================================================
+
+// StockLevelEvaluator は在庫数が変わった inventory / model の閾値評価を行う。
+// modelIDs を省略した場合は inventory の全 model を評価する。
+type StockLevelEvaluator interface {
+	EvaluateStockLevel(
+		ctx context.Context,
+		inventoryID string,
+		modelIDs ...string,
+	) error
+}
+
+type InventoryReaderForStockAlert interface {
+	GetByID(
+		ctx context.Context,
+		id string,
+	) (invdom.Mint, error)
+}
+
+type StockAlertMailMessage struct {
+	IdempotencyKey string
+	ToEmail        string
+
+	InventoryID string
+	ModelID     string
+	ProductName string
+
+	Threshold     int
+	Available     int
+	Accumulation  int
+	ReservedCount int
+}
+
+type StockAlertMailerPort interface {
+	SendStockAlert(
+		ctx context.Context,
+		message StockAlertMailMessage,
+	) error
+}
+
+// StockAlertAnnouncer はアラート発火時に console のお知らせを作成する。
+type StockAlertAnnouncer interface {
+	CreateAnnouncement(
+		ctx context.Context,
+		input CreateAnnouncementInput,
+	) (ann.Announcement, error)
+}
+
+// stockAlertAnnouncementCreatedBy はアラートのお知らせの作成者表記です。
+const stockAlertAnnouncementCreatedBy = "system:stock-alert"
+
+var (
+	ErrStockAlertRepositoryMissing = errors.New(
+		"stock alert: repository is not configured",
+	)
+	ErrStockAlertInventoryRepoMissing = errors.New(
+		"stock alert: inventory repository is not configured",
+	)
+	ErrStockAlertProductBlueprintRepoMissing = errors.New(
+		"stock alert: product blueprint repository is not configured",
+	)
+	ErrStockAlertCompanyIDRequired = errors.New(
+		"stock alert: companyId is required",
+	)
+)
+
+// ============================================================
+// Usecase
+// ============================================================
+
+type StockAlertUsecase struct {
+	settingRepo          invdom.StockAlertSettingRepository
+	alertRepo            invdom.StockAlertRepository
+	inventoryRepo        InventoryReaderForStockAlert
+	productBlueprintRepo applicationport.ProductBlueprintGetter
+
+	mailer    StockAlertMailerPort
+	announcer StockAlertAnnouncer
+
+	now func() time.Time
+}
+
+var _ StockLevelEvaluator = (*StockAlertUsecase)(nil)
+
+func NewStockAlertUsecase(
+	settingRepo invdom.StockAlertSettingRepository,
+	alertRepo invdom.StockAlertRepository,
+	inventoryRepo InventoryReaderForStockAlert,
+	productBlueprintRepo applicationport.ProductBlueprintGetter,
+) *StockAlertUsecase {
+	return &StockAlertUsecase{
+		settingRepo:          settingRepo,
+		alertRepo:            alertRepo,
+		inventoryRepo:        inventoryRepo,
+		productBlueprintRepo: productBlueprintRepo,
+		now:                  time.Now,
+	}
+}
+
+// WithMailer はアラート発火時のメール通知を有効にする。
+// 未設定の場合は履歴のみ保存する。
+func (u *StockAlertUsecase) WithMailer(
+	mailer StockAlertMailerPort,
+) *StockAlertUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.mailer = mailer
+
+	return u
+}
+
+// WithAnnouncer はアラート発火時の console のお知らせ作成を有効にする。
+func (u *StockAlertUsecase) WithAnnouncer(
+	announcer StockAlertAnnouncer,
+) *StockAlertUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.announcer = announcer
+
+	return u
+}
+
+func (u *StockAlertUsecase) WithNow(
+	now func() time.Time,
+) *StockAlertUsecase {
+	if u == nil || now == nil {
+		return u
+	}
+
+	u.now = now
+
+	return u
+}
+
+// ============================================================
+// Settings
+// ============================================================
+
+// GetSetting は inventory の在庫アラート設定を返す。
+// 未設定の場合は無効な空設定を返す。
+func (u *StockAlertUsecase) GetSetting(
+	ctx context.Context,
+	companyID string,
+	inventoryID string,
+) (invdom.StockAlertSetting, error) {
+	if u == nil || u.settingRepo == nil {
+		return invdom.StockAlertSetting{}, ErrStockAlertRepositoryMissing
+	}
+
+	companyID, inventoryID, err := u.authorizeInventory(
+		ctx,
+		companyID,
+		inventoryID,
+	)
+	if err != nil {
+		return invdom.StockAlertSetting{}, err
+	}
+
+	setting, err := u.settingRepo.GetByInventoryID(ctx, inventoryID)
+	if err != nil {
+		if errors.Is(err, invdom.ErrNotFound) {
+			return invdom.StockAlertSetting{
+				InventoryID:     inventoryID,
+				CompanyID:       companyID,
+				ModelThresholds: map[string]int{},
+				NotifyEmails:    []string{},
+			}, nil
+		}
+		return invdom.StockAlertSetting{}, err
+	}
+
+	return setting, nil
+}
+
+type SaveStockAlertSettingInput struct {
+	CompanyID   string
+	MemberID    string
+	InventoryID string
+
+	Enabled          bool
+	DefaultThreshold *int
+	ModelThresholds  map[string]int
+	NotifyEmails     []string
+}
+
+// SaveSetting は在庫アラート設定を保存し、現在の在庫で即時に評価する。
+func (u *StockAlertUsecase) SaveSetting(
+	ctx context.Context,
+	in SaveStockAlertSettingInput,
+) (invdom.StockAlertSetting, error) {
+	if u == nil || u.settingRepo == nil {
+		return invdom.StockAlertSetting{}, ErrStockAlertRepositoryMissing
+	}
+
+	companyID, inventoryID, err := u.authorizeInventory(
+		ctx,
+		in.CompanyID,
+		in.InventoryID,
+	)
+	if err != nil {
+		return invdom.StockAlertSetting{}, err
+	}
+
+	setting, err := invdom.NewStockAlertSetting(
+		inventoryID,
+		companyID,
+		in.Enabled,
+		in.DefaultThreshold,
+		in.ModelThresholds,
+		in.NotifyEmails,
+		u.now(),
+		in.MemberID,
+	)
+	if err != nil {
+		return invdom.StockAlertSetting{}, err
+	}
+
+	saved, err := u.settingRepo.Save(ctx, setting)
+	if err != nil {
+		return invdom.StockAlertSetting{}, err
+	}
+
+	EvaluateStockLevelBestEffort(ctx, u, inventoryID)
+
+	return saved, nil
+}
+
+// ============================================================
+// Evaluation
+// ============================================================
+
+// EvaluateStockLevel は inventory / model の販売可能数を閾値と比較し、
+// アラートの発火・解消を行う。
+// 設定がない、または無効な inventory は何もしない。
+func (u *StockAlertUsecase) EvaluateStockLevel(
+	ctx context.Context,
+	inventoryID string,
+	modelIDs ...string,
+) error {
+	if u == nil || u.settingRepo == nil || u.alertRepo == nil {
+		return ErrStockAlertRepositoryMissing
+	}
+	if u.inventoryRepo == nil {
+		return ErrStockAlertInventoryRepoMissing
+	}
+
+	inventoryID = strings.TrimSpace(inventoryID)
+	if inventoryID == "" {
+		return invdom.ErrInvalidMintID
+	}
+
+	setting, err := u.settingRepo.GetByInventoryID(ctx, inventoryID)
+	if err != nil {
+		if errors.Is(err, invdom.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	inventory, err := u.inventoryRepo.GetByID(ctx, inventoryID)
+	if err != nil {
+		return err
+	}
+
+	targets := stockAlertTargetModelIDs(inventory, modelIDs)
+	now := u.now().UTC()
+
+	var firstErr error
+
+	for _, modelID := range targets {
+		if err := u.evaluateModel(
+			ctx,
+			setting,
+			inventory,
+			modelID,
+			now,
+		); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (u *StockAlertUsecase) evaluateModel(
+	ctx context.Context,
+	setting invdom.StockAlertSetting,
+	inventory invdom.Mint,
+	modelID string,
+	now time.Time,
+) error {
+	stock := inventory.Stock[modelID]
+	threshold, ok := setting.ThresholdFor(modelID)
+	available := stock.Available()
+
+	// 閾値が無くなった model の open なアラートも解消する。
+	if !ok || available > threshold {
+		_, err := u.alertRepo.ResolveOpen(
+			ctx,
+			setting.InventoryID,
+			modelID,
+			available,
+			now,
+		)
+		if err != nil && !errors.Is(err, invdom.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	alert, err := invdom.NewStockAlert(
+		setting,
+		modelID,
+		stock,
+		threshold,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	stored, created, err := u.alertRepo.OpenIfAbsent(ctx, alert)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+
+	productName := u.productName(ctx, inventory.ProductBlueprintID)
+
+	u.notify(ctx, setting, productName, stored)
+	u.announce(ctx, inventory.TokenBlueprintID, productName, stored)
+
+	return nil
+}
+
+func (u *StockAlertUsecase) productName(
+	ctx context.Context,
+	productBlueprintID string,
+) string {
+	if u.productBlueprintRepo == nil || productBlueprintID == "" {
+		return ""
+	}
+
+	pb, err := u.productBlueprintRepo.GetByID(ctx, productBlueprintID)
+	if err != nil {
+		return ""
+	}
+
+	return pb.ProductName
+}
+
+// notify は発火したアラートを通知先へメール送信し、結果を履歴に残す。
+// 送信失敗はアラートの発火自体を失敗にしない。
+func (u *StockAlertUsecase) notify(
+	ctx context.Context,
+	setting invdom.StockAlertSetting,
+	productName string,
+	alert invdom.StockAlert,
+) {
+	if u.mailer == nil || len(setting.NotifyEmails) == 0 {
+		return
+	}
+
+	notified := make([]string, 0, len(setting.NotifyEmails))
+	var errs []string
+
+	for _, email := range setting.NotifyEmails {
+		err := u.mailer.SendStockAlert(
+			ctx,
+			StockAlertMailMessage{
+				IdempotencyKey: alert.ID + "__" + email,
+				ToEmail:        email,
+				InventoryID:    alert.InventoryID,
+				ModelID:        alert.ModelID,
+				ProductName:    productName,
+				Threshold:      alert.Threshold,
+				Available:      alert.Available,
+				Accumulation:   alert.Accumulation,
+				ReservedCount:  alert.ReservedCount,
+			},
+		)
+		if err != nil {
+			errs = append(errs, email+": "+err.Error())
+			continue
+		}
+
+		notified = append(notified, email)
+	}
+
+	if err := u.alertRepo.RecordNotification(
+		ctx,
+		alert.ID,
+		notified,
+		strings.Join(errs, "; "),
+		u.now(),
+	); err != nil {
+		log.Printf(
+			"stock alert: record notification failed alertId=%q err=%v",
+			alert.ID,
+			err,
+		)
+	}
+}
+
+// announce は発火したアラートを console のお知らせとして作成する。
+// お知らせの ID はアラートから決まるため、再評価で重複しない。
+// 作成失敗はアラートの発火自体を失敗にしない。
+func (u *StockAlertUsecase) announce(
+	ctx context.Context,
+	tokenBlueprintID string,
+	productName string,
+	alert invdom.StockAlert,
+) {
+	tokenBlueprintID = strings.TrimSpace(tokenBlueprintID)
+	if u.announcer == nil || tokenBlueprintID == "" {
+		return
+	}
+
+	name := strings.TrimSpace(productName)
+	if name == "" {
+		name = alert.InventoryID
+	}
+
+	_, err := u.announcer.CreateAnnouncement(
+		ctx,
+		CreateAnnouncementInput{
+			ID:    "stock-alert_" + alert.ID,
+			Title: fmt.Sprintf("在庫アラート: %s", name),
+			Content: fmt.Sprintf(
+				"%s（model: %s）の販売可能数が %d になり、閾値 %d を下回りました。（在庫 %d / 引当 %d）",
+				name,
+				alert.ModelID,
+				alert.Available,
+				alert.Threshold,
+				alert.Accumulation,
+				alert.ReservedCount,
+			),
+			TargetToken: &tokenBlueprintID,
+			Published:   false,
+			CreatedBy:   stockAlertAnnouncementCreatedBy,
+		},
+	)
+	if err != nil && !errors.Is(err, ann.ErrConflict) {
+		log.Printf(
+			"stock alert: create announcement failed alertId=%q err=%v",
+			alert.ID,
+			err,
+		)
+	}
+}
+
+// ============================================================
+// Queries
+// ============================================================
+
+// ListAlerts は inventory のアラート履歴を新しい順に返す。
+func (u *StockAlertUsecase) ListAlerts(
+	ctx context.Context,
+	companyID string,
+	inventoryID string,
+	limit int,
+) ([]invdom.StockAlert, error) {
+	if u == nil || u.alertRepo == nil {
+		return nil, ErrStockAlertRepositoryMissing
+	}
+
+	_, inventoryID, err := u.authorizeInventory(
+		ctx,
+		companyID,
+		inventoryID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.alertRepo.ListByInventoryID(ctx, inventoryID, limit)
+}
+
+// ListOpenAlerts は company の発火中アラートを新しい順に返す。
+// console の在庫アラート通知一覧として使う。
+func (u *StockAlertUsecase) ListOpenAlerts(
+	ctx context.Context,
+	companyID string,
+) ([]invdom.StockAlert, error) {
+	if u == nil || u.alertRepo == nil {
+		return nil, ErrStockAlertRepositoryMissing
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return nil, ErrStockAlertCompanyIDRequired
+	}
+
+	return u.alertRepo.ListOpenByCompanyID(ctx, companyID)
+}
+
+// authorizeInventory は inventory が company に属することを検証する。
+// 他 company の inventory は存在しないものとして扱う。
+func (u *StockAlertUsecase) authorizeInventory(
+	ctx context.Context,
+	companyID string,
+	inventoryID string,
+) (string, string, error) {
+	if u.inventoryRepo == nil {
+		return "", "", ErrStockAlertInventoryRepoMissing
+	}
+	if u.productBlueprintRepo == nil {
+		return "", "", ErrStockAlertProductBlueprintRepoMissing
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	inventoryID = strings.TrimSpace(inventoryID)
+
+	if companyID == "" {
+		return "", "", ErrStockAlertCompanyIDRequired
+	}
+	if inventoryID == "" {
+		return "", "", invdom.ErrInvalidMintID
+	}
+
+	inventory, err := u.inventoryRepo.GetByID(ctx, inventoryID)
+	if err != nil {
+		return "", "", err
+	}
+	if inventory.ProductBlueprintID == "" {
+		return "", "", invdom.ErrInvalidProductBlueprintID
+	}
+
+	pb, err := u.productBlueprintRepo.GetByID(ctx, inventory.ProductBlueprintID)
+	if err != nil {
+		return "", "", err
+	}
+	if pb.CompanyID != companyID {
+		return "", "", invdom.ErrNotFound
+	}
+
+	return companyID, inventoryID, nil
+}
+
+// ============================================================
+// helpers
+// ============================================================
+
+// EvaluateStockLevelBestEffort は在庫更新後の閾値評価を行う。
+// 評価失敗は在庫更新の失敗にせず、ログのみ残す。
+func EvaluateStockLevelBestEffort(
+	ctx context.Context,
+	evaluator StockLevelEvaluator,
+	inventoryID string,
+	modelIDs ...string,
+) {
+	if evaluator == nil || strings.TrimSpace(inventoryID) == "" {
+		return
+	}
+
+	if err := evaluator.EvaluateStockLevel(
+		ctx,
+		inventoryID,
+		modelIDs...,
+	); err != nil {
+		log.Printf(
+			"stock alert: evaluate failed inventoryId=%q modelIds=%v err=%v",
+			inventoryID,
+			modelIDs,
+			err,
+		)
+	}
+}
+
+func stockAlertTargetModelIDs(
+	inventory invdom.Mint,
+	modelIDs []string,
+) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(inventory.Stock))
+
+	add := func(modelID string) {
+		modelID = strings.TrimSpace(modelID)
+		if modelID == "" {
+			return
+		}
+		if _, ok := seen[modelID]; ok {
+			return
+		}
+		seen[modelID] = struct{}{}
+		out = append(out, modelID)
+	}
+
+	if len(modelIDs) > 0 {
+		for _, modelID := range modelIDs {
+			add(modelID)
+		}
+	} else {
+		for modelID := range inventory.Stock {
+			add(modelID)
+		}
+	}
+
+	sort.Strings(out)
+
+	return out
+}
@@ -0,0 +1,234 @@
+// backend/internal/application/usecase/stripe_webhook_event_usecase.go
+package usecase
+
+/*
+責務:
+- 署名検証済みの Stripe webhook イベントを event id 単位でイベントストアに保存してから処理する。
+- 処理済み（processed / ignored）のイベントの再送は処理せずに duplicate として応答する。
+- console から保存済みイベントの一覧・参照・再処理（replay）を行う（運営のみ）。
+
+前提:
+- 実際の反映（payment / refund / dispute）は StripeEventProcessor が行い、
+  それぞれが集約の transaction 内で event id による重複排除を行う。
+  そのため同じイベントが並行して届いた場合や replay でも二重に反映されない。
+- 処理に失敗したイベントは failed として保存し、エラーを返す（Stripe が再送する）。
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	stripeeventdom "narratives/internal/domain/stripeEvent"
+)
+
+// StripeEventProcessor は Stripe イベントを集約へ反映します。
+// 対象外のイベントは handled=false を返します。
+// payload の内容が不正な場合は ErrStripeEventInvalid を wrap したエラーを返します。
+type StripeEventProcessor interface {
+	ProcessStripeEvent(
+		ctx context.Context,
+		eventType string,
+		payload []byte,
+	) (handled bool, err error)
+}
+
+var (
+	ErrStripeEventNotConfigured = errors.New(
+		"stripe event: usecase is not configured",
+	)
+	ErrStripeEventForbidden = errors.New(
+		"stripe event: only the platform operator can manage stripe events",
+	)
+	ErrStripeEventInvalid = errors.New(
+		"stripe event: invalid event data",
+	)
+)
+
+type StripeWebhookEventUsecase struct {
+	repo      stripeeventdom.RepositoryPort
+	processor StripeEventProcessor
+
+	operatorCompanyID string
+
+	now func() time.Time
+}
+
+func NewStripeWebhookEventUsecase(
+	repo stripeeventdom.RepositoryPort,
+	processor StripeEventProcessor,
+) *StripeWebhookEventUsecase {
+	return &StripeWebhookEventUsecase{
+		repo:      repo,
+		processor: processor,
+		now:       time.Now,
+	}
+}
+
+// WithOperatorCompany はイベントを管理できるプラットフォーム運営の companyId を設定します。
+func (u *StripeWebhookEventUsecase) WithOperatorCompany(
+	companyID string,
+) *StripeWebhookEventUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.operatorCompanyID = strings.TrimSpace(companyID)
+
+	return u
+}
+
+type ReceiveStripeEventInput struct {
+	ID         string
+	Type       string
+	Payload    []byte
+	OccurredAt time.Time
+}
+
+type ReceiveStripeEventResult struct {
+	Event stripeeventdom.Event
+
+	// Duplicate は処理済みのイベントの再送で、今回は処理していないことを示します。
+	Duplicate bool
+}
+
+// Receive は webhook で受信したイベントを保存して処理します。
+//
+// 処理に失敗した場合は failed として保存した結果とエラーの両方を返します。
+func (u *StripeWebhookEventUsecase) Receive(
+	ctx context.Context,
+	in ReceiveStripeEventInput,
+) (ReceiveStripeEventResult, error) {
+	if u == nil || u.repo == nil || u.processor == nil {
+		return ReceiveStripeEventResult{}, ErrStripeEventNotConfigured
+	}
+
+	e, err := stripeeventdom.NewReceived(
+		in.ID,
+		in.Type,
+		in.Payload,
+		in.OccurredAt,
+		u.now(),
+	)
+	if err != nil {
+		return ReceiveStripeEventResult{}, fmt.Errorf(
+			"%w: %v",
+			ErrStripeEventInvalid,
+			err,
+		)
+	}
+
+	stored, created, err := u.repo.Record(ctx, e)
+	if err != nil {
+		return ReceiveStripeEventResult{}, err
+	}
+
+	if !created && stored.Status.IsDone() {
+		return ReceiveStripeEventResult{
+			Event:     stored,
+			Duplicate: true,
+		}, nil
+	}
+
+	processed, err := u.process(ctx, stored)
+
+	return ReceiveStripeEventResult{
+		Event: processed,
+	}, err
+}
+
+// Replay は保存済みのイベントを再処理します（運営のみ）。
+func (u *StripeWebhookEventUsecase) Replay(
+	ctx context.Context,
+	id string,
+) (stripeeventdom.Event, error) {
+	if err := u.operatorScope(ctx); err != nil {
+		return stripeeventdom.Event{}, err
+	}
+	if u.processor == nil {
+		return stripeeventdom.Event{}, ErrStripeEventNotConfigured
+	}
+
+	e, err := u.repo.GetByID(ctx, strings.TrimSpace(id))
+	if err != nil {
+		return stripeeventdom.Event{}, err
+	}
+
+	e.MarkReplayed(MemberIDFromContext(ctx), u.now())
+
+	return u.process(ctx, e)
+}
+
+// Get は保存済みのイベントを返します（運営のみ）。
+func (u *StripeWebhookEventUsecase) Get(
+	ctx context.Context,
+	id string,
+) (stripeeventdom.Event, error) {
+	if err := u.operatorScope(ctx); err != nil {
+		return stripeeventdom.Event{}, err
+	}
+
+	return u.repo.GetByID(ctx, strings.TrimSpace(id))
+}
+
+// List は保存済みのイベントを受信の新しい順に返します（運営のみ）。
+func (u *StripeWebhookEventUsecase) List(
+	ctx context.Context,
+	filter stripeeventdom.ListFilter,
+) ([]stripeeventdom.Event, error) {
+	if err := u.operatorScope(ctx); err != nil {
+		return nil, err
+	}
+
+	filter.Type = strings.TrimSpace(filter.Type)
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, stripeeventdom.ErrInvalidStatus
+	}
+
+	return u.repo.List(ctx, filter)
+}
+
+// process はイベントを反映し、結果（processed / ignored / failed）を保存します。
+func (u *StripeWebhookEventUsecase) process(
+	ctx context.Context,
+	e stripeeventdom.Event,
+) (stripeeventdom.Event, error) {
+	handled, procErr := u.processor.ProcessStripeEvent(ctx, e.Type, e.Payload)
+
+	now := u.now()
+
+	switch {
+	case procErr != nil:
+		if err := e.MarkFailed(procErr.Error(), now); err != nil {
+			return e, err
+		}
+	case handled:
+		e.MarkProcessed(now)
+	default:
+		e.MarkIgnored(now)
+	}
+
+	if err := u.repo.Save(ctx, e); err != nil {
+		if procErr != nil {
+			return e, errors.Join(procErr, err)
+		}
+		return e, err
+	}
+
+	return e, procErr
+}
+
+func (u *StripeWebhookEventUsecase) operatorScope(ctx context.Context) error {
+	if u == nil || u.repo == nil {
+		return ErrStripeEventNotConfigured
+	}
+
+	companyID := strings.TrimSpace(CompanyIDFromContext(ctx))
+	if u.operatorCompanyID == "" || companyID != u.operatorCompanyID {
+		return ErrStripeEventForbidden
+	}
+
+	return nil
+}
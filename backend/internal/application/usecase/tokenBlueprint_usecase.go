@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	auditdom "narratives/internal/domain/audit"
 	tbdom "narratives/internal/domain/tokenBlueprint"
 	tbReview "narratives/internal/domain/tokenBlueprint_review"
 )
@@ -22,6 +23,8 @@ type TokenBlueprintUsecase struct {
 
 	metadata *tokenBlueprintMetadataUsecase
 	command  *tokenBlueprintCommandUsecase
+
+	audit AuditRecorder
 }
 
 func NewTokenBlueprintUsecase(
@@ -43,6 +46,18 @@ func NewTokenBlueprintUsecase(
 	}
 }
 
+// WithAuditRecorder はトークン設計の作成・更新・削除を監査ログに記録します。
+func (u *TokenBlueprintUsecase) WithAuditRecorder(
+	recorder AuditRecorder,
+) *TokenBlueprintUsecase {
+	if u == nil {
+		return u
+	}
+
+	u.audit = recorder
+	return u
+}
+
 type CreateBlueprintRequest struct {
 	Name        string
 	Symbol      string
@@ -58,6 +73,8 @@ type CreateBlueprintRequest struct {
 
 	ContentFiles []tbdom.ContentFile
 
+	Royalty tbdom.RoyaltyPolicy
+
 	AssigneeID string
 	CreatedBy  string
 }
@@ -79,6 +96,10 @@ func (u *TokenBlueprintUsecase) Create(
 		return nil, err
 	}
 
+	if err := in.Royalty.Validate(); err != nil {
+		return nil, err
+	}
+
 	tb, err := u.tbRepo.Create(ctx, tbdom.CreateTokenBlueprintInput{
 		Name:        in.Name,
 		Symbol:      in.Symbol,
@@ -102,6 +123,8 @@ func (u *TokenBlueprintUsecase) Create(
 		UpdatedBy: createdBy,
 
 		MetadataURI: "",
+
+		Royalty: in.Royalty,
 	})
 	if err != nil {
 		return nil, err
@@ -121,6 +144,14 @@ func (u *TokenBlueprintUsecase) Create(
 		}
 	}
 
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		CompanyID:  tb.CompanyID,
+		EntityType: auditdom.EntityTokenBlueprint,
+		EntityID:   tb.ID,
+		Action:     auditdom.ActionCreate,
+		After:      tb,
+	})
+
 	return tb, nil
 }
 
@@ -143,6 +174,9 @@ type UpdateBlueprintRequest struct {
 	MetadataURI *string
 	Minted      *bool
 	UpdatedBy   string
+
+	// Royalty は mint 前のみ変更できる。
+	Royalty *tbdom.RoyaltyPolicy
 }
 
 func (u *TokenBlueprintUsecase) Update(
@@ -169,6 +203,11 @@ func (u *TokenBlueprintUsecase) Update(
 		}
 	}
 
+	var before *tbdom.TokenBlueprint
+	if u.audit != nil {
+		before, _ = u.tbRepo.GetByID(ctx, id)
+	}
+
 	now := time.Now().UTC()
 
 	tb, err := u.tbRepo.Update(ctx, id, tbdom.UpdateTokenBlueprintInput{
@@ -189,6 +228,8 @@ func (u *TokenBlueprintUsecase) Update(
 		MetadataURI: in.MetadataURI,
 		Minted:      in.Minted,
 
+		Royalty: in.Royalty,
+
 		UpdatedAt: &now,
 		UpdatedBy: ptr(updatedBy),
 		DeletedAt: nil,
@@ -198,6 +239,14 @@ func (u *TokenBlueprintUsecase) Update(
 		return nil, err
 	}
 
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		EntityType: auditdom.EntityTokenBlueprint,
+		EntityID:   id,
+		Action:     auditdom.ActionUpdate,
+		Before:     before,
+		After:      tb,
+	})
+
 	return tb, nil
 }
 
@@ -251,7 +300,19 @@ func (u *TokenBlueprintUsecase) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	return u.tbRepo.Delete(ctx, current.ID)
+	if err := u.tbRepo.Delete(ctx, current.ID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, u.audit, RecordAuditInput{
+		CompanyID:  current.CompanyID,
+		EntityType: auditdom.EntityTokenBlueprint,
+		EntityID:   current.ID,
+		Action:     auditdom.ActionDelete,
+		Before:     current,
+	})
+
+	return nil
 }
 
 func (u *TokenBlueprintUsecase) EnsureMetadataURI(
@@ -425,11 +486,15 @@ func buildTokenBlueprintMetadataJSON(tb *tbdom.TokenBlueprint) ([]byte, error) {
 		})
 	}
 
+	// seller_fee_basis_points は Metaplex 形式のロイヤリティ料率。
+	// 外部マーケットプレイスでの二次流通時にも brand のロイヤリティが参照される。
+	// 最低額（MinimumAmount）は自社マーケットの台帳計算だけで使う。
 	payload := map[string]any{
-		"name":        name,
-		"symbol":      symbol,
-		"description": desc,
-		"image":       imageURL,
+		"name":                    name,
+		"symbol":                  symbol,
+		"description":             desc,
+		"seller_fee_basis_points": tb.Royalty.BasisPoints,
+		"image":                   imageURL,
 		"attributes": []map[string]any{
 			{
 				"trait_type": "TokenBlueprintID",
@@ -28,6 +28,9 @@ type MintProductsInput struct {
 	BlueprintSymbol string
 
 	MetadataURI string
+
+	// SellerFeeBasisPoints は cNFT の on-chain metadata に設定するロイヤリティ料率。
+	SellerFeeBasisPoints int
 }
 
 // MintedTokenForUsecase は、「どの productId に対して、どの MintResult が紐づくか」を表す DTO です。
@@ -147,6 +150,8 @@ func (u *TokenUsecase) MintProducts(
 			MetadataURI:      metadataURI,
 			Name:             name,
 			Symbol:           symbol,
+
+			SellerFeeBasisPoints: input.SellerFeeBasisPoints,
 		}
 
 		res, err := u.mintWallet.MintToken(
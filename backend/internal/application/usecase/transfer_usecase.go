@@ -10,6 +10,7 @@ import (
 	applicationport "narratives/internal/application/port"
 	avatardom "narratives/internal/domain/avatar"
 	orderdom "narratives/internal/domain/order"
+	outboxdom "narratives/internal/domain/outbox"
 )
 
 // ============================================================
@@ -159,9 +160,14 @@ type ExecuteTransferInput struct {
 	ProductID   string
 	OperationID string
 
-	FromAvatarID     string
-	ToAvatarID       string
-	FromBrandID      string
+	FromAvatarID string
+	ToAvatarID   string
+	FromBrandID  string
+
+	// ToBrandID is set instead of ToAvatarID when the token goes back to the
+	// brand wallet (e.g. a returned order item).
+	ToBrandID string
+
 	BrandID          string
 	ModelID          string
 	TokenBlueprintID string
@@ -200,6 +206,7 @@ type TransferUsecase struct {
 	avatarDisplay AvatarDisplayResolver
 
 	resaleRepo applicationport.ResaleGetter
+	outbox     OutboxPublisher
 
 	executionUC *TokenTransferExecutionUsecase
 	inventoryUC *InventoryUsecase
@@ -246,6 +253,19 @@ func (u *TransferUsecase) WithResaleTransferDependencies(
 	return u
 }
 
+// WithOutbox delivers TransferSucceeded right after the transfer is saved.
+// The escrow records resale transfers from that event; a failed delivery is
+// retried by the outbox relay.
+func (u *TransferUsecase) WithOutbox(
+	publisher OutboxPublisher,
+) *TransferUsecase {
+	if u != nil {
+		u.outbox = publisher
+	}
+
+	return u
+}
+
 var (
 	ErrTransferNotConfigured          = errors.New("transfer_uc: not configured")
 	ErrTransferAvatarIDEmpty          = errors.New("transfer_uc: avatarId is empty")
@@ -585,6 +605,8 @@ func (u *TransferUsecase) TransferToAvatarByVerifiedScan(
 			mapTransferExecutionError(err)
 	}
 
+	publishOutbox(ctx, u.outbox, outboxdom.AggregateTransfer, productID)
+
 	fromDisplayName := ""
 	if source.FromAvatarID != "" {
 		fromDisplayName = u.resolveAvatarDisplayName(
@@ -11,6 +11,7 @@ import (
 	productdom "narratives/internal/domain/product"
 	productbpdom "narratives/internal/domain/productBlueprint"
 	tokendom "narratives/internal/domain/token"
+	transferdom "narratives/internal/domain/transfer"
 	walletdom "narratives/internal/domain/wallet"
 )
 
@@ -32,6 +33,18 @@ type ModelProductBlueprintIDResolver interface {
 	GetIDByModelID(ctx context.Context, modelID string) (string, []productbpdom.ModelRef, error)
 }
 
+// WalletTokenDetailReader (productId -> tokenBlueprintId, mintedAt)
+type WalletTokenDetailReader interface {
+	GetTokenByProductID(ctx context.Context, productID string) (tokendom.GetTokenByProductIDResult, error)
+}
+
+// WalletAcquiredAtResolver (assetId -> 最新の transfer 成功日時と移譲先 avatarId)
+//
+// transfer.RepositoryPort の ResolveTransferredAtByAssetID に合わせる。
+type WalletAcquiredAtResolver interface {
+	ResolveTransferredAtByAssetID(ctx context.Context, assetID string) (transferdom.ResolveTransferredAtByAssetIDResult, error)
+}
+
 // WalletUsecase は Wallet 同期ユースケースです。
 //
 // IMPORTANT:
@@ -49,6 +62,10 @@ type WalletUsecase struct {
 	productReader           applicationport.ProductGetter
 	modelProductBlueprintID ModelProductBlueprintIDResolver
 	productBlueprintReader  applicationport.ProductBlueprintGetter
+
+	// 保有明細（tokenBlueprintId / 取得日時）。list の token gating 判定で使う。
+	tokenDetailReader  WalletTokenDetailReader
+	acquiredAtResolver WalletAcquiredAtResolver
 }
 
 // NewWalletUsecase is the only wiring entrypoint.
@@ -61,6 +78,8 @@ func NewWalletUsecase(
 	productReader applicationport.ProductGetter,
 	modelProductBlueprintID ModelProductBlueprintIDResolver,
 	productBlueprintReader applicationport.ProductBlueprintGetter,
+	tokenDetailReader WalletTokenDetailReader,
+	acquiredAtResolver WalletAcquiredAtResolver,
 ) *WalletUsecase {
 	return &WalletUsecase{
 		walletRepo:              walletRepo,
@@ -70,6 +89,8 @@ func NewWalletUsecase(
 		productReader:           productReader,
 		modelProductBlueprintID: modelProductBlueprintID,
 		productBlueprintReader:  productBlueprintReader,
+		tokenDetailReader:       tokenDetailReader,
+		acquiredAtResolver:      acquiredAtResolver,
 	}
 }
 
@@ -95,6 +116,9 @@ var (
 	ErrWalletProductBlueprintReaderNotConfigured = errors.New("wallet usecase: productBlueprint reader not configured")
 	ErrWalletResolvedModelIDEmpty                = errors.New("wallet usecase: resolved modelId is empty")
 	ErrWalletResolvedProductBlueprintIDEmpty     = errors.New("wallet usecase: resolved productBlueprintId is empty")
+
+	// Holdings
+	ErrWalletTokenDetailReaderNotConfigured = errors.New("wallet usecase: token detail reader not configured")
 )
 
 // HasOwnedProductBlueprint は avatar が指定 productBlueprint の token を
@@ -196,6 +220,135 @@ func (uc *WalletUsecase) HasOwnedProductBlueprint(
 	return false, nil
 }
 
+// WalletHolding は avatar が on-chain 上で現在保有している token 1 つ分の明細です。
+//
+// AcquiredAt は avatar への最新の transfer 成功日時です。transfer 履歴が無い場合は
+// mint 日時を使い、どちらも不明な場合は nil になります。
+type WalletHolding struct {
+	AssetID            string
+	ProductID          string
+	ProductBlueprintID string
+	TokenBlueprintID   string
+	AcquiredAt         *time.Time
+}
+
+// ListHoldings は avatar の on-chain 保有 token を
+// productBlueprintId / tokenBlueprintId / 取得日時 付きで返します.
+//
+// 判定順は HasOwnedProductBlueprint と同じで、逆引きに失敗した asset はスキップします。
+// productBlueprintId の逆引きだけに失敗した asset は tokenBlueprintId のみで返します。
+func (uc *WalletUsecase) ListHoldings(
+	ctx context.Context,
+	avatarID string,
+) ([]WalletHolding, error) {
+	if uc == nil || uc.walletRepo == nil {
+		return nil, ErrWalletUsecaseNotConfigured
+	}
+	if uc.onchainReader == nil {
+		return nil, ErrWalletSyncOnchainNotConfigured
+	}
+	if uc.tokenQuery == nil {
+		return nil, ErrWalletTokenQueryNotConfigured
+	}
+	if uc.tokenDetailReader == nil {
+		return nil, ErrWalletTokenDetailReaderNotConfigured
+	}
+
+	if avatarID == "" {
+		return nil, ErrWalletSyncAvatarIDEmpty
+	}
+
+	w, err := uc.walletRepo.GetByAvatarID(ctx, avatarID)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.WalletAddress == "" {
+		return nil, ErrWalletSyncWalletAddressEmpty
+	}
+
+	assetIDs, err := uc.onchainReader.ListOwnedAssetIDs(ctx, w.WalletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]WalletHolding, 0, len(assetIDs))
+	for _, assetID := range assetIDs {
+		if assetID == "" {
+			continue
+		}
+
+		resolvedToken, err := uc.tokenQuery.ResolveTokenByAssetID(ctx, assetID)
+		if err != nil || resolvedToken.ProductID == "" {
+			continue
+		}
+
+		token, err := uc.tokenDetailReader.GetTokenByProductID(ctx, resolvedToken.ProductID)
+		if err != nil {
+			continue
+		}
+
+		out = append(out, WalletHolding{
+			AssetID:            assetID,
+			ProductID:          resolvedToken.ProductID,
+			ProductBlueprintID: uc.resolveProductBlueprintIDByProductID(ctx, resolvedToken.ProductID),
+			TokenBlueprintID:   token.TokenBlueprintID,
+			AcquiredAt:         uc.resolveAcquiredAt(ctx, avatarID, assetID, token.MintedAt),
+		})
+	}
+
+	return out, nil
+}
+
+func (uc *WalletUsecase) resolveProductBlueprintIDByProductID(
+	ctx context.Context,
+	productID string,
+) string {
+	if uc.productReader == nil || uc.modelProductBlueprintID == nil {
+		return ""
+	}
+
+	product, err := uc.productReader.GetByID(ctx, productID)
+	if err != nil || product.ModelID == "" {
+		return ""
+	}
+
+	productBlueprintID, _, err := uc.modelProductBlueprintID.GetIDByModelID(ctx, product.ModelID)
+	if err != nil {
+		return ""
+	}
+	return productBlueprintID
+}
+
+// resolveAcquiredAt は avatar が asset を取得した日時を返します。
+// 最新の transfer が別の avatar 宛ての場合（on-chain と transfer 履歴が食い違う場合）は不明として nil を返します。
+func (uc *WalletUsecase) resolveAcquiredAt(
+	ctx context.Context,
+	avatarID string,
+	assetID string,
+	mintedAt time.Time,
+) *time.Time {
+	if uc.acquiredAtResolver != nil {
+		transferred, err := uc.acquiredAtResolver.ResolveTransferredAtByAssetID(ctx, assetID)
+		switch {
+		case err == nil:
+			if transferred.AvatarID != avatarID || transferred.TransferredAt.IsZero() {
+				return nil
+			}
+			t := transferred.TransferredAt.UTC()
+			return &t
+		case !errors.Is(err, transferdom.ErrNotFound):
+			return nil
+		}
+	}
+
+	if mintedAt.IsZero() {
+		return nil
+	}
+	t := mintedAt.UTC()
+	return &t
+}
+
 // GetWalletByAvatarIDWithReadThroughSync は avatarId から wallet を取得し、
 // persisted wallet.assetIds と on-chain 保有 assetId 一覧に差分があれば同期して返します。
 //
@@ -3,6 +3,7 @@ package account
 
 import (
 	"errors"
+	"strconv"
 	"time"
 )
 
@@ -18,6 +19,9 @@ var (
 	ErrInvalidStatus        = errors.New("account: invalid status")
 	ErrInvalidCreatedAt     = errors.New("account: invalid createdAt")
 	ErrInvalidUpdatedAt     = errors.New("account: invalid updatedAt")
+	ErrInvalidBankCode      = errors.New("account: invalid bankCode")
+	ErrInvalidBranchCode    = errors.New("account: invalid branchCode")
+	ErrInvalidHolderKana    = errors.New("account: invalid holderNameKana")
 )
 
 // Enums (mirror TS)
@@ -67,12 +71,20 @@ type Account struct {
 	AccountType   AccountType
 	Currency      string
 	Status        AccountStatus
-	CreatedAt     time.Time
-	CreatedBy     *string
-	UpdatedAt     time.Time
-	UpdatedBy     *string
-	DeletedAt     *time.Time
-	DeletedBy     *string
+
+	// 全銀協フォーマットの振込に使う項目（任意）。
+	// BankCode: 金融機関コード（4 桁）, BranchCode: 支店コード（3 桁）,
+	// HolderNameKana: 口座名義（半角カナ）
+	BankCode       string
+	BranchCode     string
+	HolderNameKana string
+
+	CreatedAt time.Time
+	CreatedBy *string
+	UpdatedAt time.Time
+	UpdatedBy *string
+	DeletedAt *time.Time
+	DeletedBy *string
 }
 
 // Policy (sync with web-app/src/shared/types/account.ts)
@@ -88,6 +100,12 @@ var (
 
 	// MemberID length limit (adjust as needed to match frontend rules).
 	MaxMemberIDLength = 100
+
+	// 全銀協フォーマット: 金融機関コード 4 桁, 支店コード 3 桁, 口座番号 7 桁, 名義 30 桁
+	BankCodeLength          = 4
+	BranchCodeLength        = 3
+	MaxZenginAccountNumber  = 9_999_999
+	MaxHolderNameKanaLength = 30
 )
 
 // Constructors
@@ -180,9 +198,82 @@ func (a Account) validate() error {
 	if a.DeletedAt != nil && a.DeletedAt.Before(a.CreatedAt) {
 		return ErrInvalidUpdatedAt
 	}
+	if a.BankCode != "" && !isDigits(a.BankCode, BankCodeLength) {
+		return ErrInvalidBankCode
+	}
+	if a.BranchCode != "" && !isDigits(a.BranchCode, BranchCodeLength) {
+		return ErrInvalidBranchCode
+	}
+	if a.HolderNameKana != "" &&
+		(!IsZenginKana(a.HolderNameKana) || len([]rune(a.HolderNameKana)) > MaxHolderNameKanaLength) {
+		return ErrInvalidHolderKana
+	}
 	return nil
 }
 
+// ZenginReady は全銀協フォーマットの振込先として使えるかを返します。
+func (a Account) ZenginReady() bool {
+	return a.Status == StatusActive &&
+		isDigits(a.BankCode, BankCodeLength) &&
+		isDigits(a.BranchCode, BranchCodeLength) &&
+		a.AccountNumber >= MinAccountNumber &&
+		a.AccountNumber <= MaxZenginAccountNumber &&
+		IsValidAccountType(a.AccountType) &&
+		a.HolderNameKana != "" &&
+		IsZenginKana(a.HolderNameKana) &&
+		len([]rune(a.HolderNameKana)) <= MaxHolderNameKanaLength
+}
+
+// ZenginAccountType は全銀協の預金種目（"1" 普通 / "2" 当座）を返します。
+func (a Account) ZenginAccountType() string {
+	if a.AccountType == TypeToza {
+		return "2"
+	}
+	return "1"
+}
+
+// ZenginAccountNumber は 7 桁ゼロ埋めの口座番号を返します。
+func (a Account) ZenginAccountNumber() string {
+	s := strconv.Itoa(a.AccountNumber)
+	for len(s) < 7 {
+		s = "0" + s
+	}
+	return s
+}
+
+// IsZenginKana は全銀協の振込で使える文字（半角カナ・英大文字・数字・一部記号）だけかを返します。
+// 小書きのカナ（ｧ〜ｯ）は使えません。
+func IsZenginKana(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z',
+			r >= '0' && r <= '9':
+		case r == ' ', r == '(', r == ')', r == '.', r == '-', r == '/', r == ',':
+		case r == '\uFF62', r == '\uFF63', r == '\uFF70': // ｢ ｣ ｰ
+		case r == '\uFF66': // ｦ
+		case r >= '\uFF71' && r <= '\uFF9F': // ｱ〜ﾝ ﾞ ﾟ
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // 口座名義（表示用）: MemberID をそのまま利用します。
 func (a Account) AccountHolderName() string {
 	return a.MemberID
@@ -17,9 +17,14 @@ type AccountPatch struct {
 	AccountType   *AccountType
 	Currency      *string
 	Status        *AccountStatus
-	UpdatedBy     *string
-	DeletedAt     *time.Time
-	DeletedBy     *string
+
+	BankCode       *string
+	BranchCode     *string
+	HolderNameKana *string
+
+	UpdatedBy *string
+	DeletedAt *time.Time
+	DeletedBy *string
 }
 
 // Filter for listing/searching accounts.
@@ -0,0 +1,195 @@
+// backend/internal/domain/audit/entity.go
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EntityType は監査ログの対象の種類です。
+type EntityType string
+
+const (
+	EntityBrand          EntityType = "brand"
+	EntityMember         EntityType = "member"
+	EntityList           EntityType = "list"
+	EntityListImage      EntityType = "listImage"
+	EntityTokenBlueprint EntityType = "tokenBlueprint"
+	EntityInventory      EntityType = "inventory"
+	EntityOrder          EntityType = "order"
+)
+
+// Action は監査ログに記録する操作です。
+type Action string
+
+const (
+	ActionCreate   Action = "create"
+	ActionUpdate   Action = "update"
+	ActionDelete   Action = "delete"
+	ActionDispatch Action = "dispatch"
+)
+
+var (
+	ErrInvalidCompanyID  = errors.New("audit: invalid companyId")
+	ErrInvalidEntityType = errors.New("audit: invalid entityType")
+	ErrInvalidEntityID   = errors.New("audit: invalid entityId")
+	ErrInvalidAction     = errors.New("audit: invalid action")
+	ErrInvalidCreatedAt  = errors.New("audit: invalid createdAt")
+	ErrInvalidRange      = errors.New("audit: invalid date range")
+)
+
+// Change は 1 フィールドの変更前後の値です。
+// 値は JSON 表現（json タグのフィールド名）で比較・保存します。
+// 作成では Before、削除では After が nil になります。
+type Change struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// Entry は console からの書き込み 1 件の監査ログです（追記のみ）。
+type Entry struct {
+	ID string `json:"id"`
+
+	CompanyID     string `json:"companyId"`
+	ActorMemberID string `json:"actorMemberId,omitempty"`
+
+	EntityType EntityType `json:"entityType"`
+	EntityID   string     `json:"entityId"`
+	Action     Action     `json:"action"`
+
+	Changes []Change `json:"changes"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type NewEntryInput struct {
+	CompanyID     string
+	ActorMemberID string
+
+	EntityType EntityType
+	EntityID   string
+	Action     Action
+
+	// Before / After は変更前後のエンティティです（JSON に変換して差分を取ります）。
+	Before any
+	After  any
+}
+
+func NewEntry(in NewEntryInput, now time.Time) (Entry, error) {
+	changes, err := Diff(in.Before, in.After)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{
+		CompanyID:     strings.TrimSpace(in.CompanyID),
+		ActorMemberID: strings.TrimSpace(in.ActorMemberID),
+
+		EntityType: EntityType(strings.TrimSpace(string(in.EntityType))),
+		EntityID:   strings.TrimSpace(in.EntityID),
+		Action:     Action(strings.TrimSpace(string(in.Action))),
+
+		Changes: changes,
+
+		CreatedAt: now.UTC(),
+	}
+
+	if err := e.Validate(); err != nil {
+		return Entry{}, err
+	}
+
+	return e, nil
+}
+
+func (e Entry) Validate() error {
+	if strings.TrimSpace(e.CompanyID) == "" {
+		return ErrInvalidCompanyID
+	}
+	if strings.TrimSpace(string(e.EntityType)) == "" {
+		return ErrInvalidEntityType
+	}
+	if strings.TrimSpace(e.EntityID) == "" {
+		return ErrInvalidEntityID
+	}
+	if strings.TrimSpace(string(e.Action)) == "" {
+		return ErrInvalidAction
+	}
+	if e.CreatedAt.IsZero() {
+		return ErrInvalidCreatedAt
+	}
+	return nil
+}
+
+// Diff は before / after を JSON のオブジェクトとして比較し、
+// 値が異なるトップレベルのフィールドをフィールド名順で返します。
+// nil は空のオブジェクトとして扱います。
+func Diff(before, after any) ([]Change, error) {
+	b, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(b)+len(a))
+	for k := range b {
+		fields = append(fields, k)
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+
+	out := make([]Change, 0)
+	for _, f := range fields {
+		bv, av := b[f], a[f]
+		if reflect.DeepEqual(bv, av) {
+			continue
+		}
+
+		out = append(out, Change{
+			Field:  f,
+			Before: bv,
+			After:  av,
+		})
+	}
+
+	return out, nil
+}
+
+// toFields は v の JSON 表現をフィールド名ごとの値にします。
+// オブジェクトでない値は "value" フィールドとして扱います。
+func toFields(v any) (map[string]any, error) {
+	if v == nil {
+		return map[string]any{}, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+
+	switch x := decoded.(type) {
+	case nil:
+		return map[string]any{}, nil
+	case map[string]any:
+		return x, nil
+	default:
+		return map[string]any{"value": x}, nil
+	}
+}
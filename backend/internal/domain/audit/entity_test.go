@@ -0,0 +1,143 @@
+// backend/internal/domain/audit/entity_test.go
+package audit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+type testBrand struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags"`
+	Price       int      `json:"price"`
+	internal    string
+}
+
+func TestDiff(t *testing.T) {
+	brand := testBrand{Name: "A", Tags: []string{"x"}, Price: 1000, internal: "ignored"}
+
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		want   []Change
+	}{
+		{
+			name:   "no change",
+			before: brand,
+			after:  testBrand{Name: "A", Tags: []string{"x"}, Price: 1000, internal: "changed"},
+			want:   []Change{},
+		},
+		{
+			// 数値は JSON の number（float64）として保存する。
+			name:   "changed fields sorted by json name",
+			before: brand,
+			after:  testBrand{Name: "B", Tags: []string{"x", "y"}, Price: 1200},
+			want: []Change{
+				{Field: "name", Before: "A", After: "B"},
+				{Field: "price", Before: float64(1000), After: float64(1200)},
+				{Field: "tags", Before: []any{"x"}, After: []any{"x", "y"}},
+			},
+		},
+		{
+			name:   "omitempty field added",
+			before: brand,
+			after:  testBrand{Name: "A", Description: "new", Tags: []string{"x"}, Price: 1000},
+			want:   []Change{{Field: "description", Before: nil, After: "new"}},
+		},
+		{
+			// null のフィールドは存在しないフィールドと同じ扱い（tags は差分に出ない）。
+			name:   "create",
+			before: nil,
+			after:  testBrand{Name: "A", Price: 1000},
+			want: []Change{
+				{Field: "name", Before: nil, After: "A"},
+				{Field: "price", Before: nil, After: float64(1000)},
+			},
+		},
+		{
+			name:   "delete with typed nil pointer",
+			before: &testBrand{Name: "A", Price: 1000},
+			after:  (*testBrand)(nil),
+			want: []Change{
+				{Field: "name", Before: "A", After: nil},
+				{Field: "price", Before: float64(1000), After: nil},
+			},
+		},
+		{
+			name:   "scalar values",
+			before: 3,
+			after:  5,
+			want:   []Change{{Field: "value", Before: float64(3), After: float64(5)}},
+		},
+		{
+			name:   "maps",
+			before: map[string]any{"status": "draft", "qty": 1},
+			after:  map[string]any{"status": "published", "qty": 1},
+			want:   []Change{{Field: "status", Before: "draft", After: "published"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Diff = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(in *NewEntryInput)
+		want        error
+		wantJSONErr bool
+	}{
+		{name: "valid", modify: func(in *NewEntryInput) {}},
+		{name: "system actor", modify: func(in *NewEntryInput) { in.ActorMemberID = "" }},
+		{name: "missing company", modify: func(in *NewEntryInput) { in.CompanyID = " " }, want: ErrInvalidCompanyID},
+		{name: "missing entity type", modify: func(in *NewEntryInput) { in.EntityType = "" }, want: ErrInvalidEntityType},
+		{name: "missing entity id", modify: func(in *NewEntryInput) { in.EntityID = "" }, want: ErrInvalidEntityID},
+		{name: "missing action", modify: func(in *NewEntryInput) { in.Action = " " }, want: ErrInvalidAction},
+		{name: "value that cannot be encoded", modify: func(in *NewEntryInput) { in.After = func() {} }, wantJSONErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := NewEntryInput{
+				CompanyID:     "company_1",
+				ActorMemberID: "member_1",
+				EntityType:    EntityBrand,
+				EntityID:      "brand_1",
+				Action:        ActionUpdate,
+				Before:        testBrand{Name: "A"},
+				After:         testBrand{Name: "B"},
+			}
+			tt.modify(&in)
+
+			e, err := NewEntry(in, testNow)
+			if tt.wantJSONErr {
+				if err == nil {
+					t.Fatalf("NewEntry err = nil, want json error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewEntry err = %v, want %v", err, tt.want)
+			}
+			if err == nil && (len(e.Changes) != 1 || !e.CreatedAt.Equal(testNow)) {
+				t.Fatalf("NewEntry = %+v", e)
+			}
+		})
+	}
+}
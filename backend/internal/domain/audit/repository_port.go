@@ -0,0 +1,37 @@
+// backend/internal/domain/audit/repository_port.go
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultSearchLimit / MaxSearchLimit は検索 1 回で返す件数です。
+const (
+	DefaultSearchLimit = 100
+	MaxSearchLimit     = 1000
+)
+
+// Filter は監査ログの検索条件です。空の条件は絞り込みません。
+// 日時は [From, To) です。
+type Filter struct {
+	CompanyID string
+
+	EntityType    EntityType
+	EntityID      string
+	ActorMemberID string
+
+	From time.Time
+	To   time.Time
+
+	Limit int
+}
+
+// RepositoryPort は監査ログの永続化ポートです。
+// 監査ログは追記のみで、更新・削除は提供しません。
+type RepositoryPort interface {
+	Append(ctx context.Context, e Entry) (Entry, error)
+
+	// Search は filter に一致する監査ログを新しい順で最大 filter.Limit 件返します。
+	Search(ctx context.Context, filter Filter) ([]Entry, error)
+}
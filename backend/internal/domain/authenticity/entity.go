@@ -0,0 +1,203 @@
+// backend/internal/domain/authenticity/entity.go
+package authenticity
+
+/*
+責務:
+- QR コード（https://amol.jp/{productId}）のスキャンによる真贋確認の結果（Verdict）を表す。
+- スキャン履歴（日時・位置・User-Agent）を記録し、不審なパターンを検出する。
+
+前提:
+- Verdict は product の検査結果・mint 済みトークン・オンチェーンの保有者から決まる（usecase で判定）。
+- 位置は任意（ブラウザの位置情報、またはロードバランサの geo ヘッダ）。
+  位置が無いスキャンは「場所の数」「移動速度」の判定には使わない。
+*/
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Verdict string
+
+const (
+	// VerdictAuthentic は検査合格・mint 済みで、記録上の保有者がオンチェーンでも資産を保有している状態です。
+	VerdictAuthentic Verdict = "authentic"
+
+	// VerdictUnverified は製品は存在するが、mint 前・オンチェーン確認不可などで真正性を証明できない状態です。
+	VerdictUnverified Verdict = "unverified"
+
+	// VerdictNotAuthentic は製品が存在しない・検査不合格・未製造の状態です。
+	VerdictNotAuthentic Verdict = "not_authentic"
+)
+
+func IsValidVerdict(v Verdict) bool {
+	switch v {
+	case VerdictAuthentic, VerdictUnverified, VerdictNotAuthentic:
+		return true
+	default:
+		return false
+	}
+}
+
+// Reason は Verdict の根拠、または不審なスキャンの理由です。
+type Reason string
+
+const (
+	ReasonProductNotFound      Reason = "product_not_found"
+	ReasonInspectionFailed     Reason = "inspection_failed"
+	ReasonNotManufactured      Reason = "not_manufactured"
+	ReasonInspectionPending    Reason = "inspection_pending"
+	ReasonNotMinted            Reason = "not_minted"
+	ReasonOwnerUnknown         Reason = "owner_unknown"
+	ReasonOnchainUnavailable   Reason = "onchain_unavailable"
+	ReasonOnchainOwnerMismatch Reason = "onchain_owner_mismatch"
+	ReasonManyLocations        Reason = "many_locations"
+	ReasonImpossibleTravel     Reason = "impossible_travel"
+	ReasonHighScanFrequency    Reason = "high_scan_frequency"
+)
+
+var (
+	ErrInvalidProductID = errors.New("authenticity: invalid productId")
+	ErrInvalidVerdict   = errors.New("authenticity: invalid verdict")
+	ErrInvalidScannedAt = errors.New("authenticity: invalid scannedAt")
+	ErrInvalidLocation  = errors.New("authenticity: invalid location")
+)
+
+// MaxUserAgentLength を超える User-Agent は切り詰めて保存します。
+const MaxUserAgentLength = 512
+
+// Location はスキャン位置です。座標・地域名はいずれも任意です。
+type Location struct {
+	Latitude  *float64
+	Longitude *float64
+
+	// Country / Region / City はロードバランサの geo ヘッダなどから取得した地域名です。
+	Country string
+	Region  string
+	City    string
+}
+
+// HasCoordinates は緯度・経度の両方があるかを返します。
+func (l Location) HasCoordinates() bool {
+	return l.Latitude != nil && l.Longitude != nil
+}
+
+// IsZero は位置情報が何も無いかを返します。
+func (l Location) IsZero() bool {
+	return !l.HasCoordinates() &&
+		l.Country == "" &&
+		l.Region == "" &&
+		l.City == ""
+}
+
+// Validate は座標の範囲を検証します。
+func (l Location) Validate() error {
+	if (l.Latitude == nil) != (l.Longitude == nil) {
+		return ErrInvalidLocation
+	}
+	if l.HasCoordinates() {
+		lat, lng := *l.Latitude, *l.Longitude
+		if math.IsNaN(lat) || math.IsNaN(lng) ||
+			lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+			return ErrInvalidLocation
+		}
+	}
+	return nil
+}
+
+// key は「別の場所」を判定するためのキーです。
+// 座標は小数 1 桁（約 10km）に丸め、座標が無い場合は地域名を使います。
+func (l Location) key() string {
+	if l.HasCoordinates() {
+		return "geo:" +
+			formatRounded(*l.Latitude) + "," +
+			formatRounded(*l.Longitude)
+	}
+	if l.IsZero() {
+		return ""
+	}
+	return "area:" + strings.ToLower(l.Country+"/"+l.Region+"/"+l.City)
+}
+
+// Scan は 1 回の真贋確認（QR スキャン）の記録です。
+type Scan struct {
+	ID        string
+	ProductID string
+
+	Verdict Verdict
+	Reasons []Reason
+
+	Location  Location
+	UserAgent string
+
+	// Suspicious は過去のスキャンと合わせて不審なパターンが検出されたかです。
+	Suspicious        bool
+	SuspiciousReasons []Reason
+
+	ScannedAt time.Time
+}
+
+// NewScan はスキャン記録を作成します（ID は保存時に採番）。
+func NewScan(
+	productID string,
+	verdict Verdict,
+	reasons []Reason,
+	loc Location,
+	userAgent string,
+	scannedAt time.Time,
+) (Scan, error) {
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return Scan{}, ErrInvalidProductID
+	}
+	if !IsValidVerdict(verdict) {
+		return Scan{}, ErrInvalidVerdict
+	}
+	if scannedAt.IsZero() {
+		return Scan{}, ErrInvalidScannedAt
+	}
+	if err := loc.Validate(); err != nil {
+		return Scan{}, err
+	}
+
+	userAgent = strings.TrimSpace(userAgent)
+	if len(userAgent) > MaxUserAgentLength {
+		userAgent = userAgent[:MaxUserAgentLength]
+	}
+
+	return Scan{
+		ProductID: productID,
+		Verdict:   verdict,
+		Reasons:   append([]Reason(nil), reasons...),
+		Location: Location{
+			Latitude:  loc.Latitude,
+			Longitude: loc.Longitude,
+			Country:   strings.TrimSpace(loc.Country),
+			Region:    strings.TrimSpace(loc.Region),
+			City:      strings.TrimSpace(loc.City),
+		},
+		UserAgent: userAgent,
+		ScannedAt: scannedAt.UTC(),
+	}, nil
+}
+
+// Flag は不審な理由を設定します（理由が無い場合は何もしません）。
+func (s *Scan) Flag(reasons []Reason) {
+	if s == nil || len(reasons) == 0 {
+		return
+	}
+	s.Suspicious = true
+	s.SuspiciousReasons = append([]Reason(nil), reasons...)
+}
+
+func formatRounded(v float64) string {
+	r := math.Round(v*10) / 10
+	// -0.0 を 0.0 と同じキーにする
+	if r == 0 {
+		r = 0
+	}
+	return strconv.FormatFloat(r, 'f', 1, 64)
+}
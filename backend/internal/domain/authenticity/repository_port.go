@@ -0,0 +1,24 @@
+// backend/internal/domain/authenticity/repository_port.go
+package authenticity
+
+import (
+	"context"
+	"time"
+)
+
+// MaxRecentScans は判定に読み込む過去のスキャンの上限です。
+const MaxRecentScans = 500
+
+// ScanRepositoryPort はスキャン履歴の永続化ポートです。
+type ScanRepositoryPort interface {
+	// Create はスキャンを保存し、採番した ID を設定して返します。
+	Create(ctx context.Context, s Scan) (Scan, error)
+
+	// ListRecentByProductID は since 以降のスキャンを新しい順に最大 limit 件返します。
+	ListRecentByProductID(
+		ctx context.Context,
+		productID string,
+		since time.Time,
+		limit int,
+	) ([]Scan, error)
+}
@@ -0,0 +1,129 @@
+// backend/internal/domain/authenticity/suspicion.go
+package authenticity
+
+import (
+	"math"
+	"time"
+)
+
+// SuspicionPolicy は不審なスキャンの判定基準です。
+//
+// QR コードの複製（同じ productId のラベルが複数の偽造品に貼られている）では、
+// 同じ productId が短時間に離れた多数の場所で読まれるため、場所の数と移動速度で判定します。
+type SuspicionPolicy struct {
+	// Window は判定に使う過去のスキャンの期間です。
+	Window time.Duration
+
+	// MaxDistinctLocations を超える数の場所で Window 内にスキャンされた場合に不審とします。
+	MaxDistinctLocations int
+
+	// MaxScans を超える回数 Window 内にスキャンされた場合に不審とします。
+	MaxScans int
+
+	// MaxTravelSpeedKmh を超える速度での移動が必要な 2 スキャンは不審とします。
+	// MinTravelDistanceKm 未満の距離（位置情報の誤差）は判定しません。
+	MaxTravelSpeedKmh   float64
+	MinTravelDistanceKm float64
+}
+
+// DefaultSuspicionPolicy は既定の判定基準です。
+func DefaultSuspicionPolicy() SuspicionPolicy {
+	return SuspicionPolicy{
+		Window:               24 * time.Hour,
+		MaxDistinctLocations: 5,
+		MaxScans:             100,
+		MaxTravelSpeedKmh:    900,
+		MinTravelDistanceKm:  100,
+	}
+}
+
+// DetectSuspicious は current と Window 内の過去のスキャン（recent）から不審な理由を返します。
+// recent の順序は問いません。Window 外のスキャンは無視します。
+func DetectSuspicious(
+	current Scan,
+	recent []Scan,
+	policy SuspicionPolicy,
+) []Reason {
+	since := current.ScannedAt.Add(-policy.Window)
+
+	var (
+		reasons []Reason
+
+		count     = 1
+		locations = map[string]struct{}{}
+		travel    bool
+	)
+
+	if k := current.Location.key(); k != "" {
+		locations[k] = struct{}{}
+	}
+
+	for _, s := range recent {
+		if s.ProductID != current.ProductID ||
+			s.ScannedAt.Before(since) ||
+			s.ScannedAt.After(current.ScannedAt) {
+			continue
+		}
+
+		count++
+
+		if k := s.Location.key(); k != "" {
+			locations[k] = struct{}{}
+		}
+
+		if !travel && impossibleTravel(s, current, policy) {
+			travel = true
+		}
+	}
+
+	if policy.MaxDistinctLocations > 0 &&
+		len(locations) > policy.MaxDistinctLocations {
+		reasons = append(reasons, ReasonManyLocations)
+	}
+	if travel {
+		reasons = append(reasons, ReasonImpossibleTravel)
+	}
+	if policy.MaxScans > 0 && count > policy.MaxScans {
+		reasons = append(reasons, ReasonHighScanFrequency)
+	}
+
+	return reasons
+}
+
+func impossibleTravel(a, b Scan, policy SuspicionPolicy) bool {
+	if policy.MaxTravelSpeedKmh <= 0 ||
+		!a.Location.HasCoordinates() ||
+		!b.Location.HasCoordinates() {
+		return false
+	}
+
+	km := haversineKm(
+		*a.Location.Latitude, *a.Location.Longitude,
+		*b.Location.Latitude, *b.Location.Longitude,
+	)
+	if km < policy.MinTravelDistanceKm {
+		return false
+	}
+
+	hours := math.Abs(b.ScannedAt.Sub(a.ScannedAt).Hours())
+	if hours == 0 {
+		return true
+	}
+
+	return km/hours > policy.MaxTravelSpeedKmh
+}
+
+const earthRadiusKm = 6371.0
+
+func haversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	rad := math.Pi / 180
+
+	dLat := (lat2 - lat1) * rad
+	dLng := (lng2 - lng1) * rad
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*
+			math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,213 @@
+// backend/internal/domain/authenticity/suspicion_test.go
+package authenticity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func at(lat, lng float64) Location {
+	return Location{Latitude: &lat, Longitude: &lng}
+}
+
+var (
+	tokyo    = at(35.68, 139.76)
+	yokohama = at(35.44, 139.64)
+	osaka    = at(34.69, 135.50)
+	newYork  = at(40.71, -74.00)
+)
+
+func scan(productID string, loc Location, ago time.Duration) Scan {
+	return Scan{ProductID: productID, Location: loc, ScannedAt: testNow.Add(-ago)}
+}
+
+func area(city string) Location {
+	return Location{Country: "JP", City: city}
+}
+
+func TestHaversineKm(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  Location
+		minKm float64
+		maxKm float64
+	}{
+		{name: "same point", a: tokyo, b: tokyo, minKm: 0, maxKm: 0},
+		{name: "tokyo to yokohama", a: tokyo, b: yokohama, minKm: 25, maxKm: 35},
+		{name: "tokyo to osaka", a: tokyo, b: osaka, minKm: 390, maxKm: 410},
+		{name: "tokyo to new york", a: tokyo, b: newYork, minKm: 10800, maxKm: 10900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := haversineKm(*tt.a.Latitude, *tt.a.Longitude, *tt.b.Latitude, *tt.b.Longitude)
+			if got < tt.minKm || got > tt.maxKm {
+				t.Fatalf("haversineKm = %.1f, want %.0f..%.0f", got, tt.minKm, tt.maxKm)
+			}
+		})
+	}
+}
+
+func TestDetectSuspicious(t *testing.T) {
+	policy := DefaultSuspicionPolicy()
+	policy.MaxScans = 6
+
+	tests := []struct {
+		name    string
+		current Scan
+		recent  []Scan
+		want    []Reason
+	}{
+		{
+			name:    "first scan",
+			current: scan("p1", tokyo, 0),
+		},
+		{
+			// 約 400km を 1 時間（約 400km/h）は移動できる。
+			name:    "tokyo then osaka an hour later",
+			current: scan("p1", osaka, 0),
+			recent:  []Scan{scan("p1", tokyo, time.Hour)},
+		},
+		{
+			name:    "tokyo then osaka 20 minutes later",
+			current: scan("p1", osaka, 0),
+			recent:  []Scan{scan("p1", tokyo, 20*time.Minute)},
+			want:    []Reason{ReasonImpossibleTravel},
+		},
+		{
+			name:    "tokyo and osaka at the same time",
+			current: scan("p1", osaka, 0),
+			recent:  []Scan{scan("p1", tokyo, 0)},
+			want:    []Reason{ReasonImpossibleTravel},
+		},
+		{
+			name:    "tokyo then new york on a flight",
+			current: scan("p1", newYork, 0),
+			recent:  []Scan{scan("p1", tokyo, 13*time.Hour)},
+		},
+		{
+			name:    "tokyo then new york 10 hours later",
+			current: scan("p1", newYork, 0),
+			recent:  []Scan{scan("p1", tokyo, 10*time.Hour)},
+			want:    []Reason{ReasonImpossibleTravel},
+		},
+		{
+			// 100km 未満は位置情報の誤差として扱う。
+			name:    "short distance at the same time",
+			current: scan("p1", yokohama, 0),
+			recent:  []Scan{scan("p1", tokyo, 0)},
+		},
+		{
+			name:    "area names only",
+			current: scan("p1", area("Osaka"), 0),
+			recent:  []Scan{scan("p1", area("Tokyo"), 0)},
+		},
+		{
+			name:    "six distinct locations",
+			current: scan("p1", area("Sapporo"), 0),
+			recent: []Scan{
+				scan("p1", area("Tokyo"), time.Hour),
+				scan("p1", area("Osaka"), time.Hour),
+				scan("p1", area("Nagoya"), time.Hour),
+				scan("p1", area("Fukuoka"), time.Hour),
+				scan("p1", area("Sendai"), time.Hour),
+			},
+			want: []Reason{ReasonManyLocations},
+		},
+		{
+			// 座標は小数 1 桁に丸めるため、近い座標は同じ場所として数える。
+			name:    "nearby coordinates count once",
+			current: scan("p1", at(35.68, 139.76), 0),
+			recent: []Scan{
+				scan("p1", at(35.681, 139.761), time.Hour),
+				scan("p1", at(35.679, 139.759), time.Hour),
+				scan("p1", area("Osaka"), time.Hour),
+				scan("p1", area("Nagoya"), time.Hour),
+				scan("p1", area("Fukuoka"), time.Hour),
+				scan("p1", area("tokyo"), time.Hour),
+				scan("p1", area("TOKYO"), time.Hour),
+			},
+			want: []Reason{ReasonHighScanFrequency},
+		},
+		{
+			name:    "many scans",
+			current: scan("p1", Location{}, 0),
+			recent: []Scan{
+				scan("p1", Location{}, time.Minute),
+				scan("p1", Location{}, 2*time.Minute),
+				scan("p1", Location{}, 3*time.Minute),
+				scan("p1", Location{}, 4*time.Minute),
+				scan("p1", Location{}, 5*time.Minute),
+				scan("p1", Location{}, 6*time.Minute),
+			},
+			want: []Reason{ReasonHighScanFrequency},
+		},
+		{
+			name:    "scans outside the window, after current or of other products are ignored",
+			current: scan("p1", osaka, 0),
+			recent: []Scan{
+				scan("p1", tokyo, policy.Window+time.Minute),
+				scan("p1", tokyo, -time.Minute),
+				scan("p2", tokyo, 0),
+				scan("p2", tokyo, 0),
+				scan("p2", tokyo, 0),
+				scan("p2", tokyo, 0),
+				scan("p2", tokyo, 0),
+			},
+		},
+		{
+			name:    "all reasons in a fixed order",
+			current: scan("p1", osaka, 0),
+			recent: []Scan{
+				scan("p1", tokyo, time.Minute),
+				scan("p1", newYork, time.Minute),
+				scan("p1", area("Nagoya"), time.Minute),
+				scan("p1", area("Fukuoka"), time.Minute),
+				scan("p1", area("Sendai"), time.Minute),
+				scan("p1", area("Sendai"), 2*time.Minute),
+			},
+			want: []Reason{ReasonManyLocations, ReasonImpossibleTravel, ReasonHighScanFrequency},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectSuspicious(tt.current, tt.recent, policy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DetectSuspicious = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewScan(t *testing.T) {
+	lat := 35.68
+
+	tests := []struct {
+		name    string
+		product string
+		verdict Verdict
+		loc     Location
+		want    error
+	}{
+		{name: "valid", product: "p1", verdict: VerdictAuthentic, loc: tokyo},
+		{name: "no location", product: "p1", verdict: VerdictUnverified},
+		{name: "missing product", product: " ", verdict: VerdictAuthentic, want: ErrInvalidProductID},
+		{name: "unknown verdict", product: "p1", verdict: "fake", want: ErrInvalidVerdict},
+		{name: "latitude only", product: "p1", verdict: VerdictAuthentic, loc: Location{Latitude: &lat}, want: ErrInvalidLocation},
+		{name: "latitude out of range", product: "p1", verdict: VerdictAuthentic, loc: at(91, 0), want: ErrInvalidLocation},
+		{name: "longitude out of range", product: "p1", verdict: VerdictAuthentic, loc: at(0, -181), want: ErrInvalidLocation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewScan(tt.product, tt.verdict, nil, tt.loc, "", testNow); !errors.Is(err, tt.want) {
+				t.Fatalf("NewScan err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
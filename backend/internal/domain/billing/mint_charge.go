@@ -0,0 +1,138 @@
+// backend/internal/domain/billing/mint_charge.go
+package billing
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MintCharge はミント申請 1 件分の手数料です（ID は mintId, ミント申請ごとに 1 回だけ計上）。
+//
+// 金額は申請時点のプランとミント見積（MintFundingEstimateResult）から計算し、保存します。
+//   - ItemFeeAmount = Quantity × MintFeePerItem
+//   - NetworkCostAmount = NetworkCostLamports を円換算し、NetworkCostBasisPoints を掛けた額
+//
+// 見積が取得できなかった場合や SOL の円換算レートが未設定の場合、NetworkCostAmount は 0 です。
+type MintCharge struct {
+	ID string `json:"id"`
+
+	CompanyID        string `json:"companyId"`
+	BrandID          string `json:"brandId"`
+	TokenBlueprintID string `json:"tokenBlueprintId"`
+
+	Quantity int `json:"quantity"`
+
+	MintFeePerItem int `json:"mintFeePerItem"`
+	ItemFeeAmount  int `json:"itemFeeAmount"`
+
+	// NetworkCostLamports はミントのトランザクション手数料の見積合計です。
+	// 共有の Merkle Tree・Collection の初回作成費は含めません。
+	NetworkCostLamports    int64 `json:"networkCostLamports"`
+	YenPerSOL              int   `json:"yenPerSol"`
+	NetworkCostBasisPoints int   `json:"networkCostBasisPoints"`
+	NetworkCostAmount      int   `json:"networkCostAmount"`
+
+	Amount int `json:"amount"`
+
+	ChargedAt time.Time `json:"chargedAt"`
+}
+
+// LamportsPerSOL は 1 SOL あたりの lamports です。
+const LamportsPerSOL = 1_000_000_000
+
+var (
+	ErrInvalidMintID           = errors.New("billing: invalid mintId")
+	ErrInvalidQuantity         = errors.New("billing: invalid quantity")
+	ErrInvalidNetworkCost      = errors.New("billing: invalid network cost")
+	ErrInvalidChargedAt        = errors.New("billing: invalid chargedAt")
+	ErrInvalidMintChargeAmount = errors.New("billing: invalid mint charge amount")
+)
+
+type NewMintChargeInput struct {
+	MintID string
+
+	BrandID          string
+	TokenBlueprintID string
+
+	Quantity int
+
+	NetworkCostLamports int64
+	YenPerSOL           int
+
+	ChargedAt time.Time
+}
+
+// NewMintCharge は plan の料金でミント申請の手数料を計算します。
+func NewMintCharge(plan Plan, in NewMintChargeInput) (MintCharge, error) {
+	c := MintCharge{
+		ID: strings.TrimSpace(in.MintID),
+
+		CompanyID:        plan.CompanyID,
+		BrandID:          strings.TrimSpace(in.BrandID),
+		TokenBlueprintID: strings.TrimSpace(in.TokenBlueprintID),
+
+		Quantity: in.Quantity,
+
+		MintFeePerItem: plan.MintFeePerItem,
+		ItemFeeAmount:  in.Quantity * plan.MintFeePerItem,
+
+		NetworkCostLamports:    in.NetworkCostLamports,
+		YenPerSOL:              in.YenPerSOL,
+		NetworkCostBasisPoints: plan.NetworkCostBasisPoints,
+		NetworkCostAmount: NetworkCostAmount(
+			in.NetworkCostLamports,
+			in.YenPerSOL,
+			plan.NetworkCostBasisPoints,
+		),
+
+		ChargedAt: in.ChargedAt.UTC(),
+	}
+	c.Amount = c.ItemFeeAmount + c.NetworkCostAmount
+
+	if err := c.Validate(); err != nil {
+		return MintCharge{}, err
+	}
+
+	return c, nil
+}
+
+// NetworkCostAmount は lamports を円換算し（1 円未満切り捨て）、請求割合を掛けます。
+func NetworkCostAmount(lamports int64, yenPerSOL int, basisPoints int) int {
+	if lamports <= 0 || yenPerSOL <= 0 || basisPoints <= 0 {
+		return 0
+	}
+
+	yen := lamports * int64(yenPerSOL) / LamportsPerSOL
+	return int(yen * int64(basisPoints) / BasisPointsDenominator)
+}
+
+func (c MintCharge) Validate() error {
+	if c.ID == "" || strings.Contains(c.ID, "/") {
+		return ErrInvalidMintID
+	}
+	if c.CompanyID == "" || strings.Contains(c.CompanyID, "/") {
+		return ErrInvalidCompanyID
+	}
+	if c.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if c.MintFeePerItem < 0 {
+		return ErrInvalidMintFeePerItem
+	}
+	if c.NetworkCostLamports < 0 || c.YenPerSOL < 0 {
+		return ErrInvalidNetworkCost
+	}
+	if c.NetworkCostBasisPoints < 0 || c.NetworkCostBasisPoints > MaxNetworkCostBasisPoints {
+		return ErrInvalidNetworkCostBasisPoints
+	}
+	if c.ItemFeeAmount != c.Quantity*c.MintFeePerItem ||
+		c.NetworkCostAmount != NetworkCostAmount(c.NetworkCostLamports, c.YenPerSOL, c.NetworkCostBasisPoints) ||
+		c.Amount != c.ItemFeeAmount+c.NetworkCostAmount {
+		return ErrInvalidMintChargeAmount
+	}
+	if c.ChargedAt.IsZero() {
+		return ErrInvalidChargedAt
+	}
+	return nil
+}
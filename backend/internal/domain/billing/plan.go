@@ -0,0 +1,112 @@
+// backend/internal/domain/billing/plan.go
+package billing
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Plan は company ごとのプラットフォーム手数料プランです（ID は companyId）。
+//
+//   - SalesBasisPoints: 販売代金・ロイヤリティに対する手数料率（支払い台帳で差し引く）
+//   - MintFeePerItem: ミント 1 点あたりの手数料（円, 税抜）
+//   - NetworkCostBasisPoints: ミントのネットワーク手数料見積（SOL）の請求割合。
+//     0 は請求しない、10000 は実費、12000 は実費 + 20%
+//   - MonthlyFee: 月額の固定料金（円, 税抜）
+//
+// プランが未登録の company には DefaultPlan を使います。
+type Plan struct {
+	CompanyID string `json:"companyId"`
+
+	SalesBasisPoints       int `json:"salesBasisPoints"`
+	MintFeePerItem         int `json:"mintFeePerItem"`
+	NetworkCostBasisPoints int `json:"networkCostBasisPoints"`
+	MonthlyFee             int `json:"monthlyFee"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+}
+
+const (
+	// BasisPointsDenominator は basis points の分母（100%）です。
+	BasisPointsDenominator = 10000
+
+	// MaxSalesBasisPoints は販売手数料率の上限（100%）です。
+	MaxSalesBasisPoints = BasisPointsDenominator
+
+	// MaxNetworkCostBasisPoints はネットワーク手数料の請求割合の上限（実費の 3 倍）です。
+	MaxNetworkCostBasisPoints = 30000
+)
+
+var (
+	ErrInvalidCompanyID              = errors.New("billing: invalid companyId")
+	ErrInvalidSalesBasisPoints       = errors.New("billing: invalid salesBasisPoints")
+	ErrInvalidMintFeePerItem         = errors.New("billing: invalid mintFeePerItem")
+	ErrInvalidNetworkCostBasisPoints = errors.New("billing: invalid networkCostBasisPoints")
+	ErrInvalidMonthlyFee             = errors.New("billing: invalid monthlyFee")
+)
+
+type NewPlanInput struct {
+	CompanyID string
+
+	SalesBasisPoints       int
+	MintFeePerItem         int
+	NetworkCostBasisPoints int
+	MonthlyFee             int
+
+	UpdatedBy string
+}
+
+func NewPlan(in NewPlanInput, now time.Time) (Plan, error) {
+	p := Plan{
+		CompanyID: strings.TrimSpace(in.CompanyID),
+
+		SalesBasisPoints:       in.SalesBasisPoints,
+		MintFeePerItem:         in.MintFeePerItem,
+		NetworkCostBasisPoints: in.NetworkCostBasisPoints,
+		MonthlyFee:             in.MonthlyFee,
+
+		UpdatedAt: now.UTC(),
+		UpdatedBy: strings.TrimSpace(in.UpdatedBy),
+	}
+
+	if err := p.Validate(); err != nil {
+		return Plan{}, err
+	}
+
+	return p, nil
+}
+
+// DefaultPlan はプラン未登録の company に適用するプランです。
+// 販売手数料はプラットフォーム共通の料率で、ミント・月額の料金はかかりません。
+func DefaultPlan(companyID string, salesBasisPoints int) Plan {
+	return Plan{
+		CompanyID:        strings.TrimSpace(companyID),
+		SalesBasisPoints: salesBasisPoints,
+	}
+}
+
+func (p Plan) Validate() error {
+	if p.CompanyID == "" || strings.Contains(p.CompanyID, "/") {
+		return ErrInvalidCompanyID
+	}
+	if p.SalesBasisPoints < 0 || p.SalesBasisPoints > MaxSalesBasisPoints {
+		return ErrInvalidSalesBasisPoints
+	}
+	if p.MintFeePerItem < 0 {
+		return ErrInvalidMintFeePerItem
+	}
+	if p.NetworkCostBasisPoints < 0 || p.NetworkCostBasisPoints > MaxNetworkCostBasisPoints {
+		return ErrInvalidNetworkCostBasisPoints
+	}
+	if p.MonthlyFee < 0 {
+		return ErrInvalidMonthlyFee
+	}
+	return nil
+}
+
+// IsDefault はプランが未登録（DefaultPlan）かを返します。
+func (p Plan) IsDefault() bool {
+	return p.UpdatedAt.IsZero()
+}
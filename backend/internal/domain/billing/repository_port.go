@@ -0,0 +1,39 @@
+// backend/internal/domain/billing/repository_port.go
+package billing
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("billing: not found")
+	ErrConflict = errors.New("billing: conflict")
+)
+
+// RepositoryPort は手数料プラン・ミント手数料・請求明細書の永続化ポートです。
+type RepositoryPort interface {
+	// GetPlan はプランを返します。未登録の場合は ErrNotFound です。
+	GetPlan(ctx context.Context, companyID string) (Plan, error)
+	SetPlan(ctx context.Context, p Plan) (Plan, error)
+	ListPlans(ctx context.Context) ([]Plan, error)
+
+	// CreateMintCharge は計上済みの mintId に対して ErrConflict を返します。
+	CreateMintCharge(ctx context.Context, c MintCharge) (MintCharge, error)
+
+	// ListMintChargesByCompanyID は [from, to) に計上したミント手数料を計上日時順で返します。
+	ListMintChargesByCompanyID(
+		ctx context.Context,
+		companyID string,
+		from time.Time,
+		to time.Time,
+	) ([]MintCharge, error)
+
+	// SaveStatement は同じ ID の明細書を上書きします。
+	SaveStatement(ctx context.Context, s Statement) (Statement, error)
+	GetStatement(ctx context.Context, id string) (Statement, error)
+
+	// ListStatementsByCompanyID は対象月の新しい順で返します。
+	ListStatementsByCompanyID(ctx context.Context, companyID string) ([]Statement, error)
+}
@@ -0,0 +1,174 @@
+// backend/internal/domain/billing/statement.go
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Statement は company の月次の請求明細書です（ID は {companyId}_{period}）。
+//
+//   - Sales: 当月に計上した販売代金・ロイヤリティと販売手数料。手数料は支払い台帳で
+//     支払額から差し引き済みのため、請求額には含めない
+//   - Mints: 当月のミント申請の手数料
+//   - Transfers: 当月のブランドから購入者への NFT の移転件数
+//   - MonthlyFee: プランの月額料金
+//
+// 請求額 = (ミント手数料 + 月額料金) + 消費税（10%, 1 円未満切り捨て）。
+// 締め済みの月だけ作成でき、再作成すると最新の集計で上書きします。
+type Statement struct {
+	ID string `json:"id"`
+
+	CompanyID   string `json:"companyId"`
+	CompanyName string `json:"companyName"`
+
+	// Period は対象月（YYYY-MM, JST）です。
+	Period      string    `json:"period"`
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+
+	Plan Plan `json:"plan"`
+
+	Sales SalesSummary `json:"sales"`
+
+	Mints        []MintCharge `json:"mints"`
+	MintQuantity int          `json:"mintQuantity"`
+	MintAmount   int          `json:"mintAmount"`
+
+	Transfers TransferSummary `json:"transfers"`
+
+	MonthlyFee int `json:"monthlyFee"`
+
+	Subtotal    int `json:"subtotal"`
+	TaxRate     int `json:"taxRate"`
+	TaxAmount   int `json:"taxAmount"`
+	TotalAmount int `json:"totalAmount"`
+
+	GeneratedAt time.Time `json:"generatedAt"`
+	GeneratedBy string    `json:"generatedBy,omitempty"`
+}
+
+// SalesSummary は当月の注文の集計です。金額は税抜、クーポン値引き後です。
+type SalesSummary struct {
+	OrderCount int `json:"orderCount"`
+
+	GrossAmount  int `json:"grossAmount"`
+	RefundAmount int `json:"refundAmount"`
+	NetAmount    int `json:"netAmount"`
+
+	// CommissionAmount は返金による戻しを差し引いた販売手数料です。
+	CommissionAmount int `json:"commissionAmount"`
+}
+
+// TransferSummary は当月の NFT の移転の集計です。
+type TransferSummary struct {
+	SucceededCount int `json:"succeededCount"`
+	FailedCount    int `json:"failedCount"`
+}
+
+// ConsumptionTaxRate は請求額にかかる消費税率（%）です。
+const ConsumptionTaxRate = 10
+
+var (
+	ErrInvalidStatementID = errors.New("billing: invalid statement id")
+	ErrInvalidPeriod      = errors.New("billing: invalid period")
+	ErrPeriodNotClosed    = errors.New("billing: period is not closed yet")
+)
+
+// StatementID は document ID を返します。
+func StatementID(companyID string, period string) string {
+	return fmt.Sprintf("%s_%s", strings.TrimSpace(companyID), strings.TrimSpace(period))
+}
+
+// PeriodRange は "YYYY-MM" の月初と翌月初（loc の 0 時）を返します。
+func PeriodRange(period string, loc *time.Location) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01", strings.TrimSpace(period), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// PeriodOf は t が属する月（YYYY-MM, loc）を返します。
+func PeriodOf(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("2006-01")
+}
+
+type NewStatementInput struct {
+	CompanyName string
+
+	Period      string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+
+	Plan Plan
+
+	Sales     SalesSummary
+	Mints     []MintCharge
+	Transfers TransferSummary
+
+	GeneratedBy string
+}
+
+// NewStatement は集計結果から請求額を計算して明細書を組み立てます。
+func NewStatement(in NewStatementInput, now time.Time) (Statement, error) {
+	if in.PeriodStart.IsZero() || !in.PeriodEnd.After(in.PeriodStart) {
+		return Statement{}, ErrInvalidPeriod
+	}
+	if now.Before(in.PeriodEnd) {
+		return Statement{}, ErrPeriodNotClosed
+	}
+	if err := in.Plan.Validate(); err != nil {
+		return Statement{}, err
+	}
+
+	mints := in.Mints
+	if mints == nil {
+		mints = []MintCharge{}
+	}
+
+	s := Statement{
+		ID: StatementID(in.Plan.CompanyID, in.Period),
+
+		CompanyID:   in.Plan.CompanyID,
+		CompanyName: strings.TrimSpace(in.CompanyName),
+
+		Period:      strings.TrimSpace(in.Period),
+		PeriodStart: in.PeriodStart.UTC(),
+		PeriodEnd:   in.PeriodEnd.UTC(),
+
+		Plan: in.Plan,
+
+		Sales: in.Sales,
+
+		Mints: mints,
+
+		Transfers: in.Transfers,
+
+		MonthlyFee: in.Plan.MonthlyFee,
+
+		TaxRate: ConsumptionTaxRate,
+
+		GeneratedAt: now.UTC(),
+		GeneratedBy: strings.TrimSpace(in.GeneratedBy),
+	}
+
+	s.Sales.NetAmount = s.Sales.GrossAmount - s.Sales.RefundAmount
+
+	for _, m := range mints {
+		if m.CompanyID != s.CompanyID {
+			return Statement{}, ErrInvalidCompanyID
+		}
+		s.MintQuantity += m.Quantity
+		s.MintAmount += m.Amount
+	}
+
+	s.Subtotal = s.MintAmount + s.MonthlyFee
+	s.TaxAmount = s.Subtotal * s.TaxRate / 100
+	s.TotalAmount = s.Subtotal + s.TaxAmount
+
+	return s, nil
+}
@@ -0,0 +1,265 @@
+// backend/internal/domain/billing/statement_test.go
+package billing
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+func testPlan() Plan {
+	return Plan{
+		CompanyID:              "company_1",
+		SalesBasisPoints:       1000,
+		MintFeePerItem:         50,
+		NetworkCostBasisPoints: 12000,
+		MonthlyFee:             9999,
+		UpdatedAt:              testNow,
+	}
+}
+
+func TestNewPlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(in *NewPlanInput)
+		want   error
+	}{
+		{name: "valid", modify: func(in *NewPlanInput) {}},
+		{name: "missing company", modify: func(in *NewPlanInput) { in.CompanyID = " " }, want: ErrInvalidCompanyID},
+		{name: "sales over 100%", modify: func(in *NewPlanInput) { in.SalesBasisPoints = MaxSalesBasisPoints + 1 }, want: ErrInvalidSalesBasisPoints},
+		{name: "negative mint fee", modify: func(in *NewPlanInput) { in.MintFeePerItem = -1 }, want: ErrInvalidMintFeePerItem},
+		{name: "network cost over 3x", modify: func(in *NewPlanInput) { in.NetworkCostBasisPoints = MaxNetworkCostBasisPoints + 1 }, want: ErrInvalidNetworkCostBasisPoints},
+		{name: "negative monthly fee", modify: func(in *NewPlanInput) { in.MonthlyFee = -1 }, want: ErrInvalidMonthlyFee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := NewPlanInput{
+				CompanyID:              "company_1",
+				SalesBasisPoints:       1000,
+				MintFeePerItem:         50,
+				NetworkCostBasisPoints: 10000,
+				MonthlyFee:             10000,
+			}
+			tt.modify(&in)
+
+			p, err := NewPlan(in, testNow)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewPlan err = %v, want %v", err, tt.want)
+			}
+			if err == nil && p.IsDefault() {
+				t.Fatalf("IsDefault = true for a saved plan")
+			}
+		})
+	}
+
+	if !DefaultPlan("company_1", 1000).IsDefault() {
+		t.Fatalf("DefaultPlan.IsDefault = false")
+	}
+}
+
+func TestNetworkCostAmount(t *testing.T) {
+	tests := []struct {
+		name        string
+		lamports    int64
+		yenPerSOL   int
+		basisPoints int
+		want        int
+	}{
+		{name: "at cost", lamports: 5_000_000, yenPerSOL: 20000, basisPoints: 10000, want: 100},
+		{name: "cost plus 20%", lamports: 5_000_000, yenPerSOL: 20000, basisPoints: 12000, want: 120},
+		// 24.69 円 → 24 円、24 × 1.2 = 28.8 → 28 円。
+		{name: "truncated twice", lamports: 1_234_567, yenPerSOL: 20000, basisPoints: 12000, want: 28},
+		{name: "not charged", lamports: 5_000_000, yenPerSOL: 20000, basisPoints: 0, want: 0},
+		{name: "no rate", lamports: 5_000_000, yenPerSOL: 0, basisPoints: 10000, want: 0},
+		{name: "no estimate", lamports: 0, yenPerSOL: 20000, basisPoints: 10000, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NetworkCostAmount(tt.lamports, tt.yenPerSOL, tt.basisPoints); got != tt.want {
+				t.Fatalf("NetworkCostAmount = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMintCharge(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(in *NewMintChargeInput)
+		wantItem    int
+		wantNetwork int
+		want        error
+	}{
+		{name: "item fee and network cost", modify: func(in *NewMintChargeInput) {}, wantItem: 5000, wantNetwork: 120},
+		{name: "without estimate", modify: func(in *NewMintChargeInput) { in.NetworkCostLamports = 0 }, wantItem: 5000},
+		{name: "zero quantity", modify: func(in *NewMintChargeInput) { in.Quantity = 0 }, want: ErrInvalidQuantity},
+		{name: "negative lamports", modify: func(in *NewMintChargeInput) { in.NetworkCostLamports = -1 }, want: ErrInvalidNetworkCost},
+		{name: "missing mint id", modify: func(in *NewMintChargeInput) { in.MintID = "" }, want: ErrInvalidMintID},
+		{name: "zero chargedAt", modify: func(in *NewMintChargeInput) { in.ChargedAt = time.Time{} }, want: ErrInvalidChargedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := NewMintChargeInput{
+				MintID:              "mint_1",
+				BrandID:             "brand_1",
+				TokenBlueprintID:    "tb_1",
+				Quantity:            100,
+				NetworkCostLamports: 5_000_000,
+				YenPerSOL:           20000,
+				ChargedAt:           testNow,
+			}
+			tt.modify(&in)
+
+			c, err := NewMintCharge(testPlan(), in)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewMintCharge err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+
+			if c.ItemFeeAmount != tt.wantItem || c.NetworkCostAmount != tt.wantNetwork || c.Amount != tt.wantItem+tt.wantNetwork {
+				t.Fatalf("ItemFee = %d, NetworkCost = %d, Amount = %d", c.ItemFeeAmount, c.NetworkCostAmount, c.Amount)
+			}
+		})
+	}
+}
+
+func TestPeriodRange(t *testing.T) {
+	tests := []struct {
+		period    string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   error
+	}{
+		{
+			period:    "2026-09",
+			wantStart: time.Date(2026, 8, 31, 15, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 9, 30, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			period:    "2026-12",
+			wantStart: time.Date(2026, 11, 30, 15, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 12, 31, 15, 0, 0, 0, time.UTC),
+		},
+		{period: "2026-13", wantErr: ErrInvalidPeriod},
+		{period: "202609", wantErr: ErrInvalidPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			start, end, err := PeriodRange(tt.period, jst)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PeriodRange err = %v, want %v", err, tt.wantErr)
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Fatalf("PeriodRange = %v - %v, want %v - %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+
+	// JST の月末 23:59 は当月、翌月 0:00 は翌月に属する。
+	if got := PeriodOf(time.Date(2026, 9, 30, 14, 59, 0, 0, time.UTC), jst); got != "2026-09" {
+		t.Fatalf("PeriodOf = %q, want 2026-09", got)
+	}
+	if got := PeriodOf(time.Date(2026, 9, 30, 15, 0, 0, 0, time.UTC), jst); got != "2026-10" {
+		t.Fatalf("PeriodOf = %q, want 2026-10", got)
+	}
+}
+
+func testStatementInput(t *testing.T) NewStatementInput {
+	t.Helper()
+
+	start, end, err := PeriodRange("2026-09", jst)
+	if err != nil {
+		t.Fatalf("PeriodRange: %v", err)
+	}
+
+	plan := testPlan()
+	first, err := NewMintCharge(plan, NewMintChargeInput{MintID: "mint_1", Quantity: 100, NetworkCostLamports: 5_000_000, YenPerSOL: 20000, ChargedAt: start})
+	if err != nil {
+		t.Fatalf("NewMintCharge: %v", err)
+	}
+	second, err := NewMintCharge(plan, NewMintChargeInput{MintID: "mint_2", Quantity: 21, ChargedAt: start})
+	if err != nil {
+		t.Fatalf("NewMintCharge: %v", err)
+	}
+
+	return NewStatementInput{
+		CompanyName: "株式会社テスト",
+		Period:      "2026-09",
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Plan:        plan,
+		Sales:       SalesSummary{OrderCount: 3, GrossAmount: 30000, RefundAmount: 5000, CommissionAmount: 2500},
+		Mints:       []MintCharge{first, second},
+	}
+}
+
+func TestNewStatement(t *testing.T) {
+	in := testStatementInput(t)
+
+	s, err := NewStatement(in, testNow)
+	if err != nil {
+		t.Fatalf("NewStatement: %v", err)
+	}
+
+	// ミント 5120 + 1050、月額 9999 → 小計 16169、消費税 1616.9 → 1616。
+	// 販売手数料は支払い台帳で差し引き済みのため請求額に含めない。
+	if s.ID != "company_1_2026-09" {
+		t.Fatalf("ID = %q, want company_1_2026-09", s.ID)
+	}
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{name: "MintQuantity", got: s.MintQuantity, want: 121},
+		{name: "MintAmount", got: s.MintAmount, want: 6170},
+		{name: "MonthlyFee", got: s.MonthlyFee, want: 9999},
+		{name: "Subtotal", got: s.Subtotal, want: 16169},
+		{name: "TaxAmount", got: s.TaxAmount, want: 1616},
+		{name: "TotalAmount", got: s.TotalAmount, want: 17785},
+		{name: "Sales.NetAmount", got: s.Sales.NetAmount, want: 25000},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestNewStatement_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(in *NewStatementInput)
+		now    time.Time
+		want   error
+	}{
+		{name: "period not closed", modify: func(in *NewStatementInput) {}, now: time.Date(2026, 9, 30, 14, 59, 0, 0, time.UTC), want: ErrPeriodNotClosed},
+		{name: "period just closed", modify: func(in *NewStatementInput) {}, now: time.Date(2026, 9, 30, 15, 0, 0, 0, time.UTC)},
+		{name: "empty period", modify: func(in *NewStatementInput) { in.PeriodEnd = in.PeriodStart }, now: testNow, want: ErrInvalidPeriod},
+		{name: "invalid plan", modify: func(in *NewStatementInput) { in.Plan.MonthlyFee = -1 }, now: testNow, want: ErrInvalidMonthlyFee},
+		{name: "other company's mint", modify: func(in *NewStatementInput) { in.Mints[1].CompanyID = "company_2" }, now: testNow, want: ErrInvalidCompanyID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := testStatementInput(t)
+			tt.modify(&in)
+
+			if _, err := NewStatement(in, tt.now); !errors.Is(err, tt.want) {
+				t.Fatalf("NewStatement err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
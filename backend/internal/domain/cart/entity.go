@@ -56,6 +56,10 @@ type Cart struct {
 	// ExpiresAt is used for Firestore TTL.
 	// This should be set to a future timestamp and refreshed on each update.
 	ExpiresAt time.Time `json:"expiresAt" firestore:"expiresAt"`
+
+	// CouponCode is the coupon the buyer entered in the cart.
+	// It is validated when set and priced again at order creation.
+	CouponCode string `json:"couponCode,omitempty" firestore:"couponCode,omitempty"`
 }
 
 // NewCart creates a new cart doc.
@@ -248,6 +252,18 @@ func (c *Cart) Consume(itemKeys []string, now time.Time) error {
 	return c.validate()
 }
 
+// SetCouponCode stores the (already validated) coupon code.
+// An empty code removes the coupon.
+func (c *Cart) SetCouponCode(code string, now time.Time) error {
+	if c == nil {
+		return ErrInvalidCart
+	}
+
+	c.CouponCode = code
+	c.touch(now)
+	return c.validate()
+}
+
 func (c *Cart) touch(now time.Time) {
 	c.UpdatedAt = now
 	c.ExpiresAt = now.Add(DefaultCartTTL)
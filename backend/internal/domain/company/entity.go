@@ -4,6 +4,7 @@ package company
 import (
 	"errors"
 	"regexp"
+	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -16,6 +17,9 @@ import (
 // - 全角数字 ０-９ を許可
 var companyNameRe = regexp.MustCompile(`^[\p{Han}\p{Hiragana}\p{Katakana}A-Za-z0-9０-９ー\s]+$`)
 
+// 適格請求書発行事業者登録番号："T" + 13 桁の数字
+var invoiceRegistrationNumberRe = regexp.MustCompile(`^T[0-9]{13}$`)
+
 // ---------------------------
 // Domain errors
 // ---------------------------
@@ -30,6 +34,8 @@ var (
 	ErrInvalidCreatedBy = errors.New("company: invalid createdBy")
 	ErrInvalidUpdatedBy = errors.New("company: invalid updatedBy")
 	ErrInvalidDeletedBy = errors.New("company: invalid deletedBy")
+
+	ErrInvalidInvoiceRegistrationNumber = errors.New("company: invalid invoiceRegistrationNumber")
 )
 
 // ----------------------------------------
@@ -42,6 +48,10 @@ type Company struct {
 	Admin    string `json:"admin"` // root権限を持ったmemberId
 	IsActive bool   `json:"isActive"`
 
+	// InvoiceRegistrationNumber は適格請求書発行事業者の登録番号（T + 13 桁）。
+	// 未登録の事業者は空。
+	InvoiceRegistrationNumber string `json:"invoiceRegistrationNumber,omitempty"`
+
 	CreatedAt time.Time  `json:"createdAt"`
 	CreatedBy string     `json:"createdBy"`
 	UpdatedAt time.Time  `json:"updatedAt"`
@@ -121,6 +131,23 @@ func (c *Company) UpdateAdmin(admin string, now time.Time, updatedBy string) err
 	return c.validateUpdateOnly()
 }
 
+// UpdateInvoiceRegistrationNumber は登録番号を更新します。空文字は登録解除です。
+func (c *Company) UpdateInvoiceRegistrationNumber(number string, now time.Time, updatedBy string) error {
+	number = NormalizeInvoiceRegistrationNumber(number)
+	if err := ValidateInvoiceRegistrationNumber(number); err != nil {
+		return err
+	}
+	c.InvoiceRegistrationNumber = number
+	c.UpdatedAt = now
+	c.UpdatedBy = updatedBy
+	return c.validateUpdateOnly()
+}
+
+// IsQualifiedInvoiceIssuer は適格請求書を発行できる事業者かを返します。
+func (c Company) IsQualifiedInvoiceIssuer() bool {
+	return c.InvoiceRegistrationNumber != ""
+}
+
 func (c *Company) SetDeleted(at *time.Time, by *string) error {
 	if at == nil {
 		c.DeletedAt = nil
@@ -158,6 +185,10 @@ func (c Company) validate() error {
 		return ErrInvalidAdmin
 	}
 
+	if err := ValidateInvoiceRegistrationNumber(c.InvoiceRegistrationNumber); err != nil {
+		return err
+	}
+
 	if c.CreatedAt.IsZero() {
 		return ErrInvalidCreatedAt
 	}
@@ -211,6 +242,27 @@ func (c Company) validateUpdateOnly() error {
 // Helpers
 // ----------------------------------------
 
+// NormalizeInvoiceRegistrationNumber は空白とハイフンを除き、先頭の t を大文字にします。
+func NormalizeInvoiceRegistrationNumber(number string) string {
+	number = strings.TrimSpace(number)
+	number = strings.NewReplacer("-", "", " ", "").Replace(number)
+	if strings.HasPrefix(number, "t") {
+		number = "T" + number[1:]
+	}
+	return number
+}
+
+// ValidateInvoiceRegistrationNumber は登録番号の形式を検証します。空文字（未登録）は有効です。
+func ValidateInvoiceRegistrationNumber(number string) error {
+	if number == "" {
+		return nil
+	}
+	if !invoiceRegistrationNumberRe.MatchString(number) {
+		return ErrInvalidInvoiceRegistrationNumber
+	}
+	return nil
+}
+
 func validateCompanyName(name string) error {
 	if name == "" {
 		return ErrInvalidName
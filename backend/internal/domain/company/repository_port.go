@@ -13,6 +13,9 @@ type CompanyPatch struct {
 	Admin    *string
 	IsActive *bool
 
+	// InvoiceRegistrationNumber は空文字で登録解除。
+	InvoiceRegistrationNumber *string
+
 	UpdatedAt *time.Time
 	UpdatedBy *string
 	DeletedAt *time.Time
@@ -0,0 +1,188 @@
+// backend/internal/domain/coupon/calculator.go
+package coupon
+
+// Line は割引計算の対象となる注文明細 1 行です。
+//
+//   - Index は呼び出し側の明細番号（order item index）
+//   - Amount は税抜の商品小計（price * qty）
+//   - ShippingAmount はこの明細に対応する送料（税抜, 不明な場合は 0）
+type Line struct {
+	Index int
+
+	CompanyID          string
+	BrandID            string
+	ProductBlueprintID string
+
+	Amount         int
+	ShippingAmount int
+}
+
+// LineDiscount は明細ごとの値引き額です。
+type LineDiscount struct {
+	Index int
+
+	Amount         int
+	ShippingAmount int
+}
+
+// Discount はクーポン適用結果です。
+//
+// ItemAmount は商品小計からの値引き、ShippingAmount は送料からの値引きで、
+// Amount はその合計です。いずれも税抜で、消費税は値引き後の金額に対して計算します。
+type Discount struct {
+	Lines []LineDiscount
+
+	ItemAmount     int
+	ShippingAmount int
+	Amount         int
+}
+
+// Eligible は line がクーポンの対象商品かを返します。
+func (c Coupon) Eligible(line Line) bool {
+	if line.CompanyID == "" || line.CompanyID != c.CompanyID {
+		return false
+	}
+
+	if len(c.BrandIDs) > 0 &&
+		!containsID(c.BrandIDs, line.BrandID) {
+		return false
+	}
+
+	if len(c.ProductBlueprintIDs) > 0 &&
+		!containsID(c.ProductBlueprintIDs, line.ProductBlueprintID) {
+		return false
+	}
+
+	return true
+}
+
+// Calculate は lines に対する値引き額を計算します。
+//
+// 値引き額は対象明細の商品小計に比例して按分し、端数は先頭の明細から
+// 1 円ずつ配分します。明細の値引きがその明細の小計を超えることはありません。
+// 有効期間・利用上限は CheckUsable で別途確認します。
+func (c Coupon) Calculate(lines []Line) (Discount, error) {
+	eligible := make([]Line, 0, len(lines))
+	subtotal := 0
+	shipping := 0
+
+	for _, line := range lines {
+		if line.Amount < 0 || line.ShippingAmount < 0 {
+			return Discount{}, ErrInvalidAmount
+		}
+
+		if !c.Eligible(line) {
+			continue
+		}
+
+		eligible = append(eligible, line)
+		subtotal += line.Amount
+		shipping += line.ShippingAmount
+	}
+
+	if len(eligible) == 0 {
+		return Discount{}, ErrNotApplicable
+	}
+
+	if c.MinSubtotal > 0 && subtotal < c.MinSubtotal {
+		return Discount{}, ErrMinSubtotalNotMet
+	}
+
+	discount := Discount{
+		Lines: make([]LineDiscount, 0, len(eligible)),
+	}
+
+	switch c.DiscountType {
+	case DiscountTypeFreeShipping:
+		for _, line := range eligible {
+			discount.Lines = append(discount.Lines, LineDiscount{
+				Index:          line.Index,
+				ShippingAmount: line.ShippingAmount,
+			})
+		}
+		discount.ShippingAmount = shipping
+
+	case DiscountTypeFixedAmount, DiscountTypePercentage:
+		total := c.itemDiscountTotal(subtotal)
+		shares := allocate(total, eligible)
+
+		for i, line := range eligible {
+			discount.Lines = append(discount.Lines, LineDiscount{
+				Index:  line.Index,
+				Amount: shares[i],
+			})
+		}
+		discount.ItemAmount = total
+
+	default:
+		return Discount{}, ErrInvalidDiscountType
+	}
+
+	discount.Amount = discount.ItemAmount + discount.ShippingAmount
+
+	return discount, nil
+}
+
+func (c Coupon) itemDiscountTotal(subtotal int) int {
+	var total int
+
+	switch c.DiscountType {
+	case DiscountTypeFixedAmount:
+		total = c.Amount
+
+	case DiscountTypePercentage:
+		total = int(int64(subtotal) * int64(c.Percent) / 100)
+		if c.MaxDiscountAmount > 0 && total > c.MaxDiscountAmount {
+			total = c.MaxDiscountAmount
+		}
+	}
+
+	if total > subtotal {
+		total = subtotal
+	}
+
+	return total
+}
+
+// allocate は total を lines の Amount に比例して按分します。
+func allocate(total int, lines []Line) []int {
+	shares := make([]int, len(lines))
+
+	subtotal := 0
+	for _, line := range lines {
+		subtotal += line.Amount
+	}
+
+	if total <= 0 || subtotal <= 0 {
+		return shares
+	}
+
+	allocated := 0
+	for i, line := range lines {
+		shares[i] = int(int64(total) * int64(line.Amount) / int64(subtotal))
+		allocated += shares[i]
+	}
+
+	for remainder := total - allocated; remainder > 0; {
+		progressed := false
+
+		for i, line := range lines {
+			if remainder == 0 {
+				break
+			}
+			if shares[i] >= line.Amount {
+				continue
+			}
+
+			shares[i]++
+			remainder--
+			progressed = true
+		}
+
+		if !progressed {
+			break
+		}
+	}
+
+	return shares
+}
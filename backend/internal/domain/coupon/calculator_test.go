@@ -0,0 +1,319 @@
+// backend/internal/domain/coupon/calculator_test.go
+package coupon
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+// testLines は company_1 の 2 明細（小計 4000, 送料 800）と他社の 1 明細です。
+func testLines() []Line {
+	return []Line{
+		{Index: 0, CompanyID: "company_1", BrandID: "brand_1", ProductBlueprintID: "pb_1", Amount: 3000, ShippingAmount: 500},
+		{Index: 1, CompanyID: "company_1", BrandID: "brand_2", ProductBlueprintID: "pb_2", Amount: 1000, ShippingAmount: 300},
+		{Index: 2, CompanyID: "company_2", BrandID: "brand_9", ProductBlueprintID: "pb_9", Amount: 5000, ShippingAmount: 700},
+	}
+}
+
+func TestCoupon_Calculate(t *testing.T) {
+	tests := []struct {
+		name   string
+		coupon Coupon
+		lines  []Line
+		want   Discount
+	}{
+		{
+			name:   "fixed amount prorated by subtotal",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypeFixedAmount, Amount: 1000},
+			lines:  testLines(),
+			want: Discount{
+				Lines:      []LineDiscount{{Index: 0, Amount: 750}, {Index: 1, Amount: 250}},
+				ItemAmount: 1000,
+				Amount:     1000,
+			},
+		},
+		{
+			name:   "fixed amount remainder goes to the first line",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypeFixedAmount, Amount: 100},
+			lines: []Line{
+				{Index: 0, CompanyID: "company_1", Amount: 1000},
+				{Index: 1, CompanyID: "company_1", Amount: 1000},
+				{Index: 2, CompanyID: "company_1", Amount: 1000},
+			},
+			want: Discount{
+				Lines:      []LineDiscount{{Index: 0, Amount: 34}, {Index: 1, Amount: 33}, {Index: 2, Amount: 33}},
+				ItemAmount: 100,
+				Amount:     100,
+			},
+		},
+		{
+			name:   "fixed amount capped at subtotal",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypeFixedAmount, Amount: 10000},
+			lines:  testLines(),
+			want: Discount{
+				Lines:      []LineDiscount{{Index: 0, Amount: 3000}, {Index: 1, Amount: 1000}},
+				ItemAmount: 4000,
+				Amount:     4000,
+			},
+		},
+		{
+			name:   "percentage",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypePercentage, Percent: 15},
+			lines:  testLines(),
+			want: Discount{
+				Lines:      []LineDiscount{{Index: 0, Amount: 450}, {Index: 1, Amount: 150}},
+				ItemAmount: 600,
+				Amount:     600,
+			},
+		},
+		{
+			name:   "percentage truncates",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypePercentage, Percent: 10},
+			lines:  []Line{{Index: 0, CompanyID: "company_1", Amount: 3339}},
+			want: Discount{
+				Lines:      []LineDiscount{{Index: 0, Amount: 333}},
+				ItemAmount: 333,
+				Amount:     333,
+			},
+		},
+		{
+			name:   "percentage capped by max discount",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypePercentage, Percent: 50, MaxDiscountAmount: 1500},
+			lines:  testLines(),
+			want: Discount{
+				Lines:      []LineDiscount{{Index: 0, Amount: 1125}, {Index: 1, Amount: 375}},
+				ItemAmount: 1500,
+				Amount:     1500,
+			},
+		},
+		{
+			name:   "free shipping",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypeFreeShipping},
+			lines:  testLines(),
+			want: Discount{
+				Lines:          []LineDiscount{{Index: 0, ShippingAmount: 500}, {Index: 1, ShippingAmount: 300}},
+				ShippingAmount: 800,
+				Amount:         800,
+			},
+		},
+		{
+			name:   "brand scope",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypeFixedAmount, Amount: 2000, BrandIDs: []string{"brand_2"}},
+			lines:  testLines(),
+			want: Discount{
+				Lines:      []LineDiscount{{Index: 1, Amount: 1000}},
+				ItemAmount: 1000,
+				Amount:     1000,
+			},
+		},
+		{
+			name:   "min subtotal met by eligible lines",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypeFixedAmount, Amount: 400, MinSubtotal: 4000},
+			lines:  testLines(),
+			want: Discount{
+				Lines:      []LineDiscount{{Index: 0, Amount: 300}, {Index: 1, Amount: 100}},
+				ItemAmount: 400,
+				Amount:     400,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.coupon.Calculate(tt.lines)
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Calculate = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoupon_Calculate_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		coupon Coupon
+		lines  []Line
+		want   error
+	}{
+		{
+			name:   "other company only",
+			coupon: Coupon{CompanyID: "company_3", DiscountType: DiscountTypeFixedAmount, Amount: 100},
+			lines:  testLines(),
+			want:   ErrNotApplicable,
+		},
+		{
+			name: "brand and product scope must both match",
+			coupon: Coupon{
+				CompanyID:           "company_1",
+				DiscountType:        DiscountTypeFixedAmount,
+				Amount:              100,
+				BrandIDs:            []string{"brand_2"},
+				ProductBlueprintIDs: []string{"pb_1"},
+			},
+			lines: testLines(),
+			want:  ErrNotApplicable,
+		},
+		{
+			// 他社明細の小計は下限の判定に含めない。
+			name:   "min subtotal not met",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypeFixedAmount, Amount: 100, MinSubtotal: 4001},
+			lines:  testLines(),
+			want:   ErrMinSubtotalNotMet,
+		},
+		{
+			name:   "negative amount",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: DiscountTypeFixedAmount, Amount: 100},
+			lines:  []Line{{Index: 0, CompanyID: "company_1", Amount: -1}},
+			want:   ErrInvalidAmount,
+		},
+		{
+			name:   "unknown discount type",
+			coupon: Coupon{CompanyID: "company_1", DiscountType: "bogo"},
+			lines:  testLines(),
+			want:   ErrInvalidDiscountType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.coupon.Calculate(tt.lines); !errors.Is(err, tt.want) {
+				t.Fatalf("Calculate err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoupon_CheckUsable(t *testing.T) {
+	from := testNow.Add(-time.Hour)
+	until := testNow.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		coupon Coupon
+		at     time.Time
+		want   error
+	}{
+		{name: "active without window", coupon: Coupon{Active: true}, at: testNow},
+		{name: "inactive", coupon: Coupon{}, at: testNow, want: ErrInactive},
+		{name: "at validFrom", coupon: Coupon{Active: true, ValidFrom: &from}, at: from},
+		{name: "before validFrom", coupon: Coupon{Active: true, ValidFrom: &from}, at: from.Add(-time.Second), want: ErrNotStarted},
+		{name: "before validUntil", coupon: Coupon{Active: true, ValidUntil: &until}, at: until.Add(-time.Second)},
+		{name: "at validUntil", coupon: Coupon{Active: true, ValidUntil: &until}, at: until, want: ErrExpired},
+		{name: "below usage limit", coupon: Coupon{Active: true, UsageLimit: 2, RedeemedCount: 1}, at: testNow},
+		{name: "usage limit reached", coupon: Coupon{Active: true, UsageLimit: 2, RedeemedCount: 2}, at: testNow, want: ErrUsageLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.coupon.CheckUsable(tt.at); !errors.Is(err, tt.want) {
+				t.Fatalf("CheckUsable err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoupon_Reserve(t *testing.T) {
+	tests := []struct {
+		name        string
+		coupon      Coupon
+		avatarCount int
+		wantCount   int
+		want        error
+	}{
+		{name: "reserves", coupon: Coupon{ID: "coupon_1", Active: true, PerAvatarLimit: 1}, wantCount: 1},
+		{name: "per avatar limit", coupon: Coupon{ID: "coupon_1", Active: true, PerAvatarLimit: 1}, avatarCount: 1, want: ErrPerAvatarLimit},
+		{name: "usage limit", coupon: Coupon{ID: "coupon_1", Active: true, UsageLimit: 1, RedeemedCount: 1}, wantCount: 1, want: ErrUsageLimitReached},
+		{name: "other coupon", coupon: Coupon{ID: "coupon_2", Active: true}, want: ErrInvalidID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Redemption{CouponID: "coupon_1", CreatedAt: testNow}
+
+			c := tt.coupon
+			if err := c.Reserve(r, tt.avatarCount); !errors.Is(err, tt.want) {
+				t.Fatalf("Reserve err = %v, want %v", err, tt.want)
+			}
+			if c.RedeemedCount != tt.wantCount {
+				t.Fatalf("RedeemedCount = %d, want %d", c.RedeemedCount, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestRedemption_Transitions(t *testing.T) {
+	c := Coupon{ID: "coupon_1", CompanyID: "company_1", Code: "OFF100"}
+
+	tests := []struct {
+		name       string
+		apply      func(r *Redemption) error
+		wantStatus RedemptionStatus
+		wantCounts bool
+		wantErr    error
+	}{
+		{
+			name:       "reserved",
+			apply:      func(r *Redemption) error { return nil },
+			wantStatus: RedemptionStatusReserved,
+			wantCounts: true,
+		},
+		{
+			name:       "redeem",
+			apply:      func(r *Redemption) error { return r.MarkRedeemed(testNow) },
+			wantStatus: RedemptionStatusRedeemed,
+			wantCounts: true,
+		},
+		{
+			name: "redeem twice is a no-op",
+			apply: func(r *Redemption) error {
+				_ = r.MarkRedeemed(testNow)
+				return r.MarkRedeemed(testNow)
+			},
+			wantStatus: RedemptionStatusRedeemed,
+			wantCounts: true,
+		},
+		{
+			name: "release",
+			apply: func(r *Redemption) error {
+				r.Release(testNow)
+				return nil
+			},
+			wantStatus: RedemptionStatusReleased,
+		},
+		{
+			name: "redeem released",
+			apply: func(r *Redemption) error {
+				r.Release(testNow)
+				return r.MarkRedeemed(testNow)
+			},
+			wantStatus: RedemptionStatusReleased,
+			wantErr:    ErrInvalidRedemptionStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRedemption(c, "order_1", "avatar_1", 100, testNow)
+			if err != nil {
+				t.Fatalf("NewRedemption: %v", err)
+			}
+
+			if err := tt.apply(&r); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if r.Status != tt.wantStatus {
+				t.Fatalf("Status = %s, want %s", r.Status, tt.wantStatus)
+			}
+			if r.Counts() != tt.wantCounts {
+				t.Fatalf("Counts = %v, want %v", r.Counts(), tt.wantCounts)
+			}
+		})
+	}
+}
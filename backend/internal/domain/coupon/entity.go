@@ -0,0 +1,485 @@
+// backend/internal/domain/coupon/entity.go
+package coupon
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ========================================
+// DiscountType
+// ========================================
+
+// DiscountType はクーポンの割引方式です。
+//
+//   - fixed_amount:  対象商品の小計から Amount 円を値引き
+//   - percentage:    対象商品の小計から Percent % を値引き（MaxDiscountAmount で上限）
+//   - free_shipping: 対象商品の送料を無料にする
+type DiscountType string
+
+const (
+	DiscountTypeFixedAmount  DiscountType = "fixed_amount"
+	DiscountTypePercentage   DiscountType = "percentage"
+	DiscountTypeFreeShipping DiscountType = "free_shipping"
+)
+
+func IsValidDiscountType(t DiscountType) bool {
+	switch t {
+	case DiscountTypeFixedAmount,
+		DiscountTypePercentage,
+		DiscountTypeFreeShipping:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// ========================================
+// Entity
+// ========================================
+
+// Coupon は company が発行するクーポンコードです。
+//
+//   - Code は大文字に正規化し、全 company を通じて一意
+//   - 対象商品は CompanyID の商品に限られる。BrandIDs / ProductBlueprintIDs が
+//     指定されている場合はさらに絞り込む（両方指定時は両方を満たす商品のみ）
+//   - UsageLimit / PerAvatarLimit が 0 の場合は無制限
+//   - RedeemedCount は reserved / redeemed な利用の件数で、repository が
+//     利用の予約・解放と同じ transaction で更新する
+type Coupon struct {
+	ID        string `json:"id"`
+	CompanyID string `json:"companyId"`
+
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	DiscountType DiscountType `json:"discountType"`
+
+	// Amount は fixed_amount の値引き額（JPY）。
+	Amount int `json:"amount,omitempty"`
+
+	// Percent は percentage の値引き率（1〜100）。
+	Percent int `json:"percent,omitempty"`
+
+	// MaxDiscountAmount は percentage の値引き上限（JPY, 0 なら上限なし）。
+	MaxDiscountAmount int `json:"maxDiscountAmount,omitempty"`
+
+	// MinSubtotal は対象商品の小計（税抜）の下限（JPY, 0 なら条件なし）。
+	MinSubtotal int `json:"minSubtotal,omitempty"`
+
+	BrandIDs            []string `json:"brandIds,omitempty"`
+	ProductBlueprintIDs []string `json:"productBlueprintIds,omitempty"`
+
+	UsageLimit     int `json:"usageLimit,omitempty"`
+	PerAvatarLimit int `json:"perAvatarLimit,omitempty"`
+	RedeemedCount  int `json:"redeemedCount"`
+
+	ValidFrom  *time.Time `json:"validFrom,omitempty"`
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+
+	Active bool `json:"active"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	CreatedBy string     `json:"createdBy"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	UpdatedBy *string    `json:"updatedBy,omitempty"`
+}
+
+// ========================================
+// Errors
+// ========================================
+
+var (
+	ErrInvalidID           = errors.New("coupon: invalid id")
+	ErrInvalidCompanyID    = errors.New("coupon: invalid companyId")
+	ErrInvalidCode         = errors.New("coupon: invalid code")
+	ErrInvalidName         = errors.New("coupon: invalid name")
+	ErrInvalidDescription  = errors.New("coupon: invalid description")
+	ErrInvalidDiscountType = errors.New("coupon: invalid discountType")
+	ErrInvalidAmount       = errors.New("coupon: invalid amount")
+	ErrInvalidPercent      = errors.New("coupon: invalid percent")
+	ErrInvalidLimit        = errors.New("coupon: invalid usage limit")
+	ErrInvalidValidity     = errors.New("coupon: invalid validity window")
+	ErrInvalidScope        = errors.New("coupon: invalid scope")
+	ErrInvalidCreatedAt    = errors.New("coupon: invalid createdAt")
+	ErrInvalidCreatedBy    = errors.New("coupon: invalid createdBy")
+	ErrInvalidUpdatedAt    = errors.New("coupon: invalid updatedAt")
+	ErrInvalidUpdatedBy    = errors.New("coupon: invalid updatedBy")
+
+	ErrInactive          = errors.New("coupon: coupon is not active")
+	ErrNotStarted        = errors.New("coupon: coupon is not valid yet")
+	ErrExpired           = errors.New("coupon: coupon has expired")
+	ErrUsageLimitReached = errors.New("coupon: usage limit reached")
+	ErrPerAvatarLimit    = errors.New("coupon: usage limit per avatar reached")
+	ErrNotApplicable     = errors.New("coupon: no item is eligible for this coupon")
+	ErrMinSubtotalNotMet = errors.New("coupon: minimum subtotal is not met")
+)
+
+// ========================================
+// Policy
+// ========================================
+
+const (
+	MaxNameLength        = 100
+	MaxDescriptionLength = 1000
+	MaxScopeIDs          = 100
+)
+
+var codeRe = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// NormalizeCode は入力されたクーポンコードを比較用に正規化します。
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ========================================
+// Constructor
+// ========================================
+
+type NewInput struct {
+	ID        string
+	CompanyID string
+
+	Code        string
+	Name        string
+	Description string
+
+	DiscountType      DiscountType
+	Amount            int
+	Percent           int
+	MaxDiscountAmount int
+	MinSubtotal       int
+
+	BrandIDs            []string
+	ProductBlueprintIDs []string
+
+	UsageLimit     int
+	PerAvatarLimit int
+
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+
+	Active bool
+
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+func New(in NewInput) (Coupon, error) {
+	c := Coupon{
+		ID:        strings.TrimSpace(in.ID),
+		CompanyID: strings.TrimSpace(in.CompanyID),
+
+		Code:        NormalizeCode(in.Code),
+		Name:        strings.TrimSpace(in.Name),
+		Description: strings.TrimSpace(in.Description),
+
+		DiscountType:      in.DiscountType,
+		Amount:            in.Amount,
+		Percent:           in.Percent,
+		MaxDiscountAmount: in.MaxDiscountAmount,
+		MinSubtotal:       in.MinSubtotal,
+
+		BrandIDs:            normalizeIDs(in.BrandIDs),
+		ProductBlueprintIDs: normalizeIDs(in.ProductBlueprintIDs),
+
+		UsageLimit:     in.UsageLimit,
+		PerAvatarLimit: in.PerAvatarLimit,
+
+		ValidFrom:  utcPtr(in.ValidFrom),
+		ValidUntil: utcPtr(in.ValidUntil),
+
+		Active: in.Active,
+
+		CreatedAt: in.CreatedAt.UTC(),
+		CreatedBy: strings.TrimSpace(in.CreatedBy),
+	}
+
+	if err := c.Validate(); err != nil {
+		return Coupon{}, err
+	}
+
+	return c, nil
+}
+
+// ========================================
+// Behavior
+// ========================================
+
+// UpdateInput は console から変更できる項目です。nil の項目は変更しません。
+// Code / CompanyID / DiscountType は発行後に変更できません。
+type UpdateInput struct {
+	Name        *string
+	Description *string
+
+	Amount            *int
+	Percent           *int
+	MaxDiscountAmount *int
+	MinSubtotal       *int
+
+	BrandIDs            *[]string
+	ProductBlueprintIDs *[]string
+
+	UsageLimit     *int
+	PerAvatarLimit *int
+
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+
+	// ClearValidFrom / ClearValidUntil は期間の下限・上限を外す。
+	ClearValidFrom  bool
+	ClearValidUntil bool
+
+	Active *bool
+}
+
+func (c *Coupon) Update(
+	in UpdateInput,
+	at time.Time,
+	by string,
+) error {
+	if c == nil {
+		return ErrInvalidID
+	}
+
+	next := *c
+
+	if in.Name != nil {
+		next.Name = strings.TrimSpace(*in.Name)
+	}
+	if in.Description != nil {
+		next.Description = strings.TrimSpace(*in.Description)
+	}
+	if in.Amount != nil {
+		next.Amount = *in.Amount
+	}
+	if in.Percent != nil {
+		next.Percent = *in.Percent
+	}
+	if in.MaxDiscountAmount != nil {
+		next.MaxDiscountAmount = *in.MaxDiscountAmount
+	}
+	if in.MinSubtotal != nil {
+		next.MinSubtotal = *in.MinSubtotal
+	}
+	if in.BrandIDs != nil {
+		next.BrandIDs = normalizeIDs(*in.BrandIDs)
+	}
+	if in.ProductBlueprintIDs != nil {
+		next.ProductBlueprintIDs = normalizeIDs(*in.ProductBlueprintIDs)
+	}
+	if in.UsageLimit != nil {
+		next.UsageLimit = *in.UsageLimit
+	}
+	if in.PerAvatarLimit != nil {
+		next.PerAvatarLimit = *in.PerAvatarLimit
+	}
+	if in.ValidFrom != nil {
+		next.ValidFrom = utcPtr(in.ValidFrom)
+	}
+	if in.ClearValidFrom {
+		next.ValidFrom = nil
+	}
+	if in.ValidUntil != nil {
+		next.ValidUntil = utcPtr(in.ValidUntil)
+	}
+	if in.ClearValidUntil {
+		next.ValidUntil = nil
+	}
+	if in.Active != nil {
+		next.Active = *in.Active
+	}
+
+	by = strings.TrimSpace(by)
+	if by == "" {
+		return ErrInvalidUpdatedBy
+	}
+	if at.IsZero() {
+		return ErrInvalidUpdatedAt
+	}
+
+	updatedAt := at.UTC()
+	next.UpdatedAt = &updatedAt
+	next.UpdatedBy = &by
+
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	*c = next
+	return nil
+}
+
+// CheckUsable は at 時点でクーポンを新しい注文に使えるかを返します。
+// 利用者ごとの上限は redemption の件数が必要なため repository が判定します。
+func (c Coupon) CheckUsable(at time.Time) error {
+	if !c.Active {
+		return ErrInactive
+	}
+
+	if c.ValidFrom != nil && at.Before(*c.ValidFrom) {
+		return ErrNotStarted
+	}
+
+	if c.ValidUntil != nil && !at.Before(*c.ValidUntil) {
+		return ErrExpired
+	}
+
+	if c.UsageLimit > 0 && c.RedeemedCount >= c.UsageLimit {
+		return ErrUsageLimitReached
+	}
+
+	return nil
+}
+
+// ========================================
+// Validation
+// ========================================
+
+func (c Coupon) Validate() error {
+	if c.ID == "" {
+		return ErrInvalidID
+	}
+
+	if c.CompanyID == "" {
+		return ErrInvalidCompanyID
+	}
+
+	if !codeRe.MatchString(c.Code) {
+		return ErrInvalidCode
+	}
+
+	if c.Name == "" || len([]rune(c.Name)) > MaxNameLength {
+		return ErrInvalidName
+	}
+
+	if len([]rune(c.Description)) > MaxDescriptionLength {
+		return ErrInvalidDescription
+	}
+
+	switch c.DiscountType {
+	case DiscountTypeFixedAmount:
+		if c.Amount <= 0 {
+			return ErrInvalidAmount
+		}
+		if c.Percent != 0 || c.MaxDiscountAmount != 0 {
+			return ErrInvalidPercent
+		}
+
+	case DiscountTypePercentage:
+		if c.Percent < 1 || c.Percent > 100 {
+			return ErrInvalidPercent
+		}
+		if c.Amount != 0 || c.MaxDiscountAmount < 0 {
+			return ErrInvalidAmount
+		}
+
+	case DiscountTypeFreeShipping:
+		if c.Amount != 0 || c.MaxDiscountAmount != 0 {
+			return ErrInvalidAmount
+		}
+		if c.Percent != 0 {
+			return ErrInvalidPercent
+		}
+
+	default:
+		return ErrInvalidDiscountType
+	}
+
+	if c.MinSubtotal < 0 {
+		return ErrInvalidAmount
+	}
+
+	if len(c.BrandIDs) > MaxScopeIDs ||
+		len(c.ProductBlueprintIDs) > MaxScopeIDs {
+		return ErrInvalidScope
+	}
+
+	if c.UsageLimit < 0 ||
+		c.PerAvatarLimit < 0 ||
+		c.RedeemedCount < 0 {
+		return ErrInvalidLimit
+	}
+
+	if c.UsageLimit > 0 &&
+		c.PerAvatarLimit > c.UsageLimit {
+		return ErrInvalidLimit
+	}
+
+	if c.ValidFrom != nil &&
+		c.ValidUntil != nil &&
+		!c.ValidFrom.Before(*c.ValidUntil) {
+		return ErrInvalidValidity
+	}
+
+	if c.CreatedAt.IsZero() {
+		return ErrInvalidCreatedAt
+	}
+
+	if c.CreatedBy == "" {
+		return ErrInvalidCreatedBy
+	}
+
+	if c.UpdatedAt != nil && c.UpdatedAt.IsZero() {
+		return ErrInvalidUpdatedAt
+	}
+
+	if c.UpdatedBy != nil && strings.TrimSpace(*c.UpdatedBy) == "" {
+		return ErrInvalidUpdatedBy
+	}
+
+	return nil
+}
+
+// ========================================
+// helpers
+// ========================================
+
+func normalizeIDs(in []string) []string {
+	seen := make(map[string]struct{}, len(in))
+	out := make([]string, 0, len(in))
+
+	for _, id := range in {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+
+	sort.Strings(out)
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	v := t.UTC()
+	return &v
+}
@@ -0,0 +1,188 @@
+// backend/internal/domain/coupon/redemption.go
+package coupon
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// RedemptionStatus はクーポン利用の状態です。
+//
+//   - reserved: 注文作成時に確保（未決済）
+//   - redeemed: 決済完了で確定
+//   - released: 決済失敗・注文取消・予約期限切れで解放（利用回数に数えない）
+type RedemptionStatus string
+
+const (
+	RedemptionStatusReserved RedemptionStatus = "reserved"
+	RedemptionStatusRedeemed RedemptionStatus = "redeemed"
+	RedemptionStatusReleased RedemptionStatus = "released"
+)
+
+func IsValidRedemptionStatus(s RedemptionStatus) bool {
+	switch s {
+	case RedemptionStatusReserved,
+		RedemptionStatusRedeemed,
+		RedemptionStatusReleased:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// Redemption は 1 注文でのクーポン利用です。
+// 1 注文に使えるクーポンは 1 枚なので ID は OrderID と同じです。
+type Redemption struct {
+	ID        string `json:"id"`
+	CouponID  string `json:"couponId"`
+	CompanyID string `json:"companyId"`
+	Code      string `json:"code"`
+
+	OrderID  string `json:"orderId"`
+	AvatarID string `json:"avatarId"`
+
+	DiscountAmount int `json:"discountAmount"`
+
+	Status RedemptionStatus `json:"status"`
+
+	CreatedAt  time.Time  `json:"createdAt"`
+	RedeemedAt *time.Time `json:"redeemedAt,omitempty"`
+	ReleasedAt *time.Time `json:"releasedAt,omitempty"`
+}
+
+var (
+	ErrInvalidRedemptionStatus = errors.New("coupon: invalid redemption status")
+	ErrInvalidOrderID          = errors.New("coupon: invalid orderId")
+	ErrInvalidAvatarID         = errors.New("coupon: invalid avatarId")
+)
+
+// NewRedemption は reserved 状態の利用を作成します。
+func NewRedemption(
+	c Coupon,
+	orderID string,
+	avatarID string,
+	discountAmount int,
+	at time.Time,
+) (Redemption, error) {
+	orderID = strings.TrimSpace(orderID)
+
+	r := Redemption{
+		ID:        orderID,
+		CouponID:  c.ID,
+		CompanyID: c.CompanyID,
+		Code:      c.Code,
+
+		OrderID:  orderID,
+		AvatarID: strings.TrimSpace(avatarID),
+
+		DiscountAmount: discountAmount,
+
+		Status:    RedemptionStatusReserved,
+		CreatedAt: at.UTC(),
+	}
+
+	if err := r.Validate(); err != nil {
+		return Redemption{}, err
+	}
+
+	return r, nil
+}
+
+// Counts は利用回数（UsageLimit / PerAvatarLimit）に数える状態かを返します。
+func (r Redemption) Counts() bool {
+	return r.Status == RedemptionStatusReserved ||
+		r.Status == RedemptionStatusRedeemed
+}
+
+// MarkRedeemed は reserved → redeemed に遷移します。redeemed なら何もしません。
+func (r *Redemption) MarkRedeemed(at time.Time) error {
+	switch r.Status {
+	case RedemptionStatusRedeemed:
+		return nil
+
+	case RedemptionStatusReserved:
+		t := at.UTC()
+		r.Status = RedemptionStatusRedeemed
+		r.RedeemedAt = &t
+		return nil
+
+	default:
+		return ErrInvalidRedemptionStatus
+	}
+}
+
+// Release は利用を解放します。released なら何もしません。
+func (r *Redemption) Release(at time.Time) {
+	if r.Status == RedemptionStatusReleased {
+		return
+	}
+
+	t := at.UTC()
+	r.Status = RedemptionStatusReleased
+	r.ReleasedAt = &t
+}
+
+// Reserve は r の利用を c に数えます。avatarCount は r.AvatarID の
+// 既存の利用（Counts() が true のもの）の件数です。
+// repository は redemption の保存と同じ transaction で呼び出します。
+func (c *Coupon) Reserve(r Redemption, avatarCount int) error {
+	if r.CouponID != c.ID {
+		return ErrInvalidID
+	}
+
+	if err := c.CheckUsable(r.CreatedAt); err != nil {
+		return err
+	}
+
+	if c.PerAvatarLimit > 0 && avatarCount >= c.PerAvatarLimit {
+		return ErrPerAvatarLimit
+	}
+
+	c.RedeemedCount++
+	return nil
+}
+
+// Unreserve は解放された利用を RedeemedCount から差し引きます。
+func (c *Coupon) Unreserve() {
+	if c.RedeemedCount > 0 {
+		c.RedeemedCount--
+	}
+}
+
+func (r Redemption) Validate() error {
+	if r.ID == "" || r.ID != r.OrderID {
+		return ErrInvalidOrderID
+	}
+
+	if strings.TrimSpace(r.CouponID) == "" {
+		return ErrInvalidID
+	}
+
+	if strings.TrimSpace(r.CompanyID) == "" {
+		return ErrInvalidCompanyID
+	}
+
+	if r.Code == "" {
+		return ErrInvalidCode
+	}
+
+	if r.AvatarID == "" {
+		return ErrInvalidAvatarID
+	}
+
+	if r.DiscountAmount < 0 {
+		return ErrInvalidAmount
+	}
+
+	if !IsValidRedemptionStatus(r.Status) {
+		return ErrInvalidRedemptionStatus
+	}
+
+	if r.CreatedAt.IsZero() {
+		return ErrInvalidCreatedAt
+	}
+
+	return nil
+}
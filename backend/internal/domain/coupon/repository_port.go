@@ -0,0 +1,60 @@
+// backend/internal/domain/coupon/repository_port.go
+package coupon
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RepositoryPort - ドメインのリポジトリ契約
+//
+// Collection:
+// - coupons/{couponId}
+type RepositoryPort interface {
+	GetByID(ctx context.Context, id string) (Coupon, error)
+
+	// GetByCode looks the coupon up by its normalized code.
+	GetByCode(ctx context.Context, code string) (Coupon, error)
+
+	// ListByCompanyID returns the company's coupons ordered by createdAt desc.
+	ListByCompanyID(ctx context.Context, companyID string) ([]Coupon, error)
+
+	// Create returns ErrConflict when the id or the code is already used.
+	// Must be atomic.
+	Create(ctx context.Context, c Coupon) (Coupon, error)
+
+	// Update replaces c. RedeemedCount is owned by RedemptionRepository and
+	// the stored value is kept.
+	Update(ctx context.Context, c Coupon) (Coupon, error)
+}
+
+// RedemptionRepository は利用回数の確保と解放を扱います。
+//
+// Collection:
+// - couponRedemptions/{orderId}
+type RedemptionRepository interface {
+	// Reserve stores r as reserved and increments the coupon's RedeemedCount
+	// in one transaction, after re-checking Coupon.CheckUsable(r.CreatedAt)
+	// and PerAvatarLimit. Reserving the same order again returns the stored
+	// redemption as long as it still counts.
+	Reserve(ctx context.Context, r Redemption) (Redemption, error)
+
+	// MarkRedeemed moves the order's redemption to redeemed.
+	// Returns ErrNotFound when the order did not use a coupon.
+	MarkRedeemed(ctx context.Context, orderID string, at time.Time) (Redemption, error)
+
+	// Release moves the order's redemption to released and decrements the
+	// coupon's RedeemedCount. Releasing twice is a no-op.
+	// Returns ErrNotFound when the order did not use a coupon.
+	Release(ctx context.Context, orderID string, at time.Time) (Redemption, error)
+
+	// ListByCouponID returns the coupon's redemptions ordered by createdAt desc.
+	ListByCouponID(ctx context.Context, couponID string) ([]Redemption, error)
+}
+
+// 共通エラー
+var (
+	ErrNotFound = errors.New("coupon: not found")
+	ErrConflict = errors.New("coupon: conflict")
+)
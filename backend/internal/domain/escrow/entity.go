@@ -0,0 +1,485 @@
+// backend/internal/domain/escrow/entity.go
+package escrow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Escrow は二次流通（resale）1 明細分の代金の預かりです。
+//
+// 流れ:
+//   - 決済完了時に resale item ごとに held で作成する（ID は {orderId}_{itemIndex}）
+//   - 購入者が商品を受け取り NFC / QR をスキャンすると NFT が移転され、TransferredAt が記録される
+//   - 購入者が受取を確認する（または移転から AutoConfirmAt を過ぎる）と ReceiptConfirmedAt が記録される
+//   - 受取確認と移転の両方が揃った時点で released になり、出品者への支払い（PayoutAmount）が確定する
+//   - 購入者は released になる前に紛争（disputed）を申し立てられる。紛争中は支払いを凍結し、
+//     ブランド側が release（出品者へ支払い）または refund（購入者へ返金）で解決する
+type Escrow struct {
+	ID string `json:"id"`
+
+	OrderID   string `json:"orderId"`
+	ItemIndex int    `json:"itemIndex"`
+
+	ResaleID  string `json:"resaleId"`
+	ProductID string `json:"productId"`
+
+	// 紛争を解決するブランド（resale 対象商品のブランド）
+	CompanyID string `json:"companyId"`
+	BrandID   string `json:"brandId"`
+
+	BuyerAvatarID  string `json:"buyerAvatarId"`
+	SellerAvatarID string `json:"sellerAvatarId"`
+
+	// SaleAmount は販売価格（税抜, price * qty）。
+	SaleAmount int `json:"saleAmount"`
+
+	// RoyaltyAmount はブランドへのロイヤリティで、出品者の受取額から差し引く。
+	RoyaltyAmount int `json:"royaltyAmount"`
+
+	// PayoutAmount は released 時に出品者へ支払う金額です。
+	PayoutAmount int `json:"payoutAmount"`
+
+	Status Status `json:"status"`
+
+	HeldAt time.Time `json:"heldAt"`
+
+	// TransferredAt は NFT が購入者へ移転された日時です。
+	TransferredAt *time.Time `json:"transferredAt,omitempty"`
+
+	// AutoConfirmAt は購入者が確認しない場合に受取確認とみなす日時です（移転時に設定）。
+	AutoConfirmAt *time.Time `json:"autoConfirmAt,omitempty"`
+
+	ReceiptConfirmedAt *time.Time  `json:"receiptConfirmedAt,omitempty"`
+	ReceiptConfirmedBy ConfirmedBy `json:"receiptConfirmedBy,omitempty"`
+
+	Dispute *Dispute `json:"dispute,omitempty"`
+
+	ReleasedAt *time.Time `json:"releasedAt,omitempty"`
+
+	// PayoutAccruedAt は released の支払いを出品者の支払い台帳に計上した日時です。
+	// released かつ未計上の escrow は sweeper が計上し直します。
+	PayoutAccruedAt *time.Time `json:"payoutAccruedAt,omitempty"`
+
+	RefundID   string     `json:"refundId,omitempty"`
+	RefundedAt *time.Time `json:"refundedAt,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Status string
+
+const (
+	StatusHeld     Status = "held"
+	StatusDisputed Status = "disputed"
+	StatusReleased Status = "released"
+	StatusRefunded Status = "refunded"
+)
+
+func IsValidStatus(s Status) bool {
+	switch s {
+	case StatusHeld, StatusDisputed, StatusReleased, StatusRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// ConfirmedBy は受取確認の経路です。
+type ConfirmedBy string
+
+const (
+	ConfirmedByBuyer ConfirmedBy = "buyer"
+	ConfirmedByAuto  ConfirmedBy = "auto"
+	ConfirmedByBrand ConfirmedBy = "brand"
+)
+
+// DisputeReason は紛争の理由です。
+type DisputeReason string
+
+const (
+	DisputeReasonNotReceived    DisputeReason = "not_received"
+	DisputeReasonNotAsDescribed DisputeReason = "not_as_described"
+	DisputeReasonDamaged        DisputeReason = "damaged"
+	DisputeReasonCounterfeit    DisputeReason = "counterfeit"
+	DisputeReasonOther          DisputeReason = "other"
+)
+
+func IsValidDisputeReason(r DisputeReason) bool {
+	switch r {
+	case DisputeReasonNotReceived,
+		DisputeReasonNotAsDescribed,
+		DisputeReasonDamaged,
+		DisputeReasonCounterfeit,
+		DisputeReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// Resolution は紛争の解決方法です。
+type Resolution string
+
+const (
+	// ResolutionRelease は出品者へ支払います（NFT の移転が必要）。
+	ResolutionRelease Resolution = "release"
+	// ResolutionRefund は購入者へ返金します。
+	ResolutionRefund Resolution = "refund"
+)
+
+func IsValidResolution(r Resolution) bool {
+	return r == ResolutionRelease || r == ResolutionRefund
+}
+
+type Dispute struct {
+	Reason  DisputeReason `json:"reason"`
+	Comment string        `json:"comment,omitempty"`
+
+	OpenedAt time.Time `json:"openedAt"`
+
+	Resolution Resolution `json:"resolution,omitempty"`
+	Note       string     `json:"note,omitempty"`
+	ResolvedBy string     `json:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+}
+
+const MaxDisputeCommentLength = 2000
+
+var (
+	ErrInvalidID             = errors.New("escrow: invalid id")
+	ErrInvalidOrderID        = errors.New("escrow: invalid orderId")
+	ErrInvalidItemIndex      = errors.New("escrow: invalid itemIndex")
+	ErrInvalidResaleID       = errors.New("escrow: invalid resaleId")
+	ErrInvalidCompanyID      = errors.New("escrow: invalid companyId")
+	ErrInvalidBuyerAvatarID  = errors.New("escrow: invalid buyerAvatarId")
+	ErrInvalidSellerAvatarID = errors.New("escrow: invalid sellerAvatarId")
+	ErrInvalidAmount         = errors.New("escrow: invalid amount")
+	ErrInvalidStatus         = errors.New("escrow: invalid status")
+	ErrInvalidHeldAt         = errors.New("escrow: invalid heldAt")
+	ErrInvalidTransferredAt  = errors.New("escrow: invalid transferredAt")
+	ErrInvalidDisputeReason  = errors.New("escrow: invalid dispute reason")
+	ErrInvalidDisputeComment = errors.New("escrow: invalid dispute comment")
+	ErrInvalidResolution     = errors.New("escrow: invalid resolution")
+	ErrInvalidRefundID       = errors.New("escrow: invalid refundId")
+
+	ErrNotHeld          = errors.New("escrow: escrow is not held")
+	ErrNotDisputed      = errors.New("escrow: escrow is not disputed")
+	ErrAlreadyConfirmed = errors.New("escrow: receipt is already confirmed")
+	ErrNotConfirmed     = errors.New("escrow: receipt is not confirmed yet")
+	ErrNotTransferred   = errors.New("escrow: token has not been transferred yet")
+	ErrNotReleased      = errors.New("escrow: escrow is not released")
+)
+
+// EscrowID は document ID を返します。
+func EscrowID(orderID string, itemIndex int) string {
+	return fmt.Sprintf("%s_%d", strings.TrimSpace(orderID), itemIndex)
+}
+
+type NewEscrowInput struct {
+	OrderID   string
+	ItemIndex int
+
+	ResaleID  string
+	ProductID string
+
+	CompanyID string
+	BrandID   string
+
+	BuyerAvatarID  string
+	SellerAvatarID string
+
+	SaleAmount    int
+	RoyaltyAmount int
+
+	HeldAt time.Time
+}
+
+func New(in NewEscrowInput) (Escrow, error) {
+	heldAt := in.HeldAt.UTC()
+
+	e := Escrow{
+		ID: EscrowID(in.OrderID, in.ItemIndex),
+
+		OrderID:   strings.TrimSpace(in.OrderID),
+		ItemIndex: in.ItemIndex,
+
+		ResaleID:  strings.TrimSpace(in.ResaleID),
+		ProductID: strings.TrimSpace(in.ProductID),
+
+		CompanyID: strings.TrimSpace(in.CompanyID),
+		BrandID:   strings.TrimSpace(in.BrandID),
+
+		BuyerAvatarID:  strings.TrimSpace(in.BuyerAvatarID),
+		SellerAvatarID: strings.TrimSpace(in.SellerAvatarID),
+
+		SaleAmount:    in.SaleAmount,
+		RoyaltyAmount: in.RoyaltyAmount,
+		PayoutAmount:  in.SaleAmount - in.RoyaltyAmount,
+
+		Status: StatusHeld,
+		HeldAt: heldAt,
+
+		UpdatedAt: heldAt,
+	}
+
+	if err := e.Validate(); err != nil {
+		return Escrow{}, err
+	}
+
+	return e, nil
+}
+
+// IsOpen は支払いも返金もされていない（held / disputed）かを返します。
+func (e Escrow) IsOpen() bool {
+	return e.Status == StatusHeld || e.Status == StatusDisputed
+}
+
+// CanRelease は出品者へ支払える状態（受取確認済み・移転済み）かを返します。
+func (e Escrow) CanRelease() bool {
+	return e.Status == StatusHeld &&
+		e.ReceiptConfirmedAt != nil &&
+		e.TransferredAt != nil
+}
+
+// NeedsPayoutAccrual は released だが支払い台帳に未計上かを返します。
+func (e Escrow) NeedsPayoutAccrual() bool {
+	return e.Status == StatusReleased && e.PayoutAccruedAt == nil
+}
+
+// MarkPayoutAccrued は支払い台帳への計上を記録します。
+func (e *Escrow) MarkPayoutAccrued(now time.Time) error {
+	if e.Status != StatusReleased {
+		return ErrNotReleased
+	}
+	if e.PayoutAccruedAt != nil {
+		return nil
+	}
+
+	now = now.UTC()
+
+	e.PayoutAccruedAt = &now
+	e.UpdatedAt = now
+
+	return nil
+}
+
+// IsAutoConfirmDue は now 時点で自動受取確認の期限を過ぎているかを返します。
+func (e Escrow) IsAutoConfirmDue(now time.Time) bool {
+	return e.Status == StatusHeld &&
+		e.ReceiptConfirmedAt == nil &&
+		e.AutoConfirmAt != nil &&
+		!now.Before(*e.AutoConfirmAt)
+}
+
+// MarkTransferred は NFT の移転を記録し、自動受取確認の期限を設定します。
+// 記録済みの場合は何もしません（false）。
+func (e *Escrow) MarkTransferred(
+	at time.Time,
+	autoConfirmAfter time.Duration,
+	now time.Time,
+) (bool, error) {
+	if at.IsZero() {
+		return false, ErrInvalidTransferredAt
+	}
+	if e.TransferredAt != nil {
+		return false, nil
+	}
+
+	transferredAt := at.UTC()
+	e.TransferredAt = &transferredAt
+
+	// 紛争中・解決後も移転の事実は記録するが、自動確認は held の場合だけ。
+	if e.Status == StatusHeld && e.ReceiptConfirmedAt == nil {
+		autoConfirmAt := transferredAt.Add(autoConfirmAfter)
+		e.AutoConfirmAt = &autoConfirmAt
+	}
+
+	e.UpdatedAt = now.UTC()
+
+	return true, nil
+}
+
+// ConfirmReceipt は受取確認を記録します。
+func (e *Escrow) ConfirmReceipt(by ConfirmedBy, now time.Time) error {
+	if e.Status != StatusHeld {
+		return ErrNotHeld
+	}
+	if e.ReceiptConfirmedAt != nil {
+		return ErrAlreadyConfirmed
+	}
+
+	now = now.UTC()
+
+	e.ReceiptConfirmedAt = &now
+	e.ReceiptConfirmedBy = by
+	e.UpdatedAt = now
+
+	return nil
+}
+
+// Release は出品者への支払いを確定します。
+func (e *Escrow) Release(now time.Time) error {
+	if e.Status != StatusHeld {
+		return ErrNotHeld
+	}
+	if e.TransferredAt == nil {
+		return ErrNotTransferred
+	}
+	if e.ReceiptConfirmedAt == nil {
+		return ErrNotConfirmed
+	}
+
+	now = now.UTC()
+
+	e.Status = StatusReleased
+	e.ReleasedAt = &now
+	e.UpdatedAt = now
+
+	return nil
+}
+
+// OpenDispute は購入者の紛争申し立てを記録し、支払いを凍結します。
+func (e *Escrow) OpenDispute(
+	reason DisputeReason,
+	comment string,
+	now time.Time,
+) error {
+	if e.Status != StatusHeld {
+		return ErrNotHeld
+	}
+	if !IsValidDisputeReason(reason) {
+		return ErrInvalidDisputeReason
+	}
+
+	comment = strings.TrimSpace(comment)
+	if len([]rune(comment)) > MaxDisputeCommentLength {
+		return ErrInvalidDisputeComment
+	}
+
+	now = now.UTC()
+
+	e.Status = StatusDisputed
+	e.Dispute = &Dispute{
+		Reason:   reason,
+		Comment:  comment,
+		OpenedAt: now,
+	}
+	e.UpdatedAt = now
+
+	return nil
+}
+
+// ResolveRelease は紛争を出品者への支払いで解決します。NFT の移転が必要です。
+func (e *Escrow) ResolveRelease(
+	note string,
+	resolvedBy string,
+	now time.Time,
+) error {
+	if e.Status != StatusDisputed || e.Dispute == nil {
+		return ErrNotDisputed
+	}
+	if e.TransferredAt == nil {
+		return ErrNotTransferred
+	}
+
+	now = now.UTC()
+
+	e.resolve(ResolutionRelease, note, resolvedBy, now)
+
+	if e.ReceiptConfirmedAt == nil {
+		e.ReceiptConfirmedAt = &now
+		e.ReceiptConfirmedBy = ConfirmedByBrand
+	}
+
+	e.Status = StatusReleased
+	e.ReleasedAt = &now
+	e.UpdatedAt = now
+
+	return nil
+}
+
+// ResolveRefund は紛争を購入者への返金で解決します。
+func (e *Escrow) ResolveRefund(
+	refundID string,
+	note string,
+	resolvedBy string,
+	now time.Time,
+) error {
+	if e.Status != StatusDisputed || e.Dispute == nil {
+		return ErrNotDisputed
+	}
+
+	refundID = strings.TrimSpace(refundID)
+	if refundID == "" {
+		return ErrInvalidRefundID
+	}
+
+	now = now.UTC()
+
+	e.resolve(ResolutionRefund, note, resolvedBy, now)
+
+	e.Status = StatusRefunded
+	e.RefundID = refundID
+	e.RefundedAt = &now
+	e.UpdatedAt = now
+
+	return nil
+}
+
+func (e *Escrow) resolve(
+	resolution Resolution,
+	note string,
+	resolvedBy string,
+	now time.Time,
+) {
+	e.Dispute.Resolution = resolution
+	e.Dispute.Note = strings.TrimSpace(note)
+	e.Dispute.ResolvedBy = strings.TrimSpace(resolvedBy)
+	e.Dispute.ResolvedAt = &now
+}
+
+func (e Escrow) Validate() error {
+	if e.OrderID == "" || strings.Contains(e.OrderID, "/") {
+		return ErrInvalidOrderID
+	}
+	if e.ItemIndex < 0 {
+		return ErrInvalidItemIndex
+	}
+	if e.ID != EscrowID(e.OrderID, e.ItemIndex) {
+		return ErrInvalidID
+	}
+	if e.ResaleID == "" {
+		return ErrInvalidResaleID
+	}
+	if e.CompanyID == "" {
+		return ErrInvalidCompanyID
+	}
+	if e.BuyerAvatarID == "" {
+		return ErrInvalidBuyerAvatarID
+	}
+	if e.SellerAvatarID == "" || e.SellerAvatarID == e.BuyerAvatarID {
+		return ErrInvalidSellerAvatarID
+	}
+	if e.SaleAmount <= 0 ||
+		e.RoyaltyAmount < 0 ||
+		e.RoyaltyAmount > e.SaleAmount ||
+		e.PayoutAmount != e.SaleAmount-e.RoyaltyAmount {
+		return ErrInvalidAmount
+	}
+	if !IsValidStatus(e.Status) {
+		return ErrInvalidStatus
+	}
+	if e.HeldAt.IsZero() {
+		return ErrInvalidHeldAt
+	}
+	if e.Status == StatusDisputed && e.Dispute == nil {
+		return ErrInvalidDisputeReason
+	}
+	if e.Status == StatusRefunded && e.RefundID == "" {
+		return ErrInvalidRefundID
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+// backend/internal/domain/escrow/entity_test.go
+package escrow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+const testAutoConfirmAfter = 7 * 24 * time.Hour
+
+func testInput() NewEscrowInput {
+	return NewEscrowInput{
+		OrderID:        "order_1",
+		ItemIndex:      1,
+		ResaleID:       "resale_1",
+		ProductID:      "product_1",
+		CompanyID:      "company_1",
+		BrandID:        "brand_1",
+		BuyerAvatarID:  "buyer_1",
+		SellerAvatarID: "seller_1",
+		SaleAmount:     10000,
+		RoyaltyAmount:  500,
+		HeldAt:         testNow,
+	}
+}
+
+func testEscrow(t *testing.T) Escrow {
+	t.Helper()
+
+	e, err := New(testInput())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	return e
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(in *NewEscrowInput)
+		wantPayout int
+		want       error
+	}{
+		{name: "payout is sale minus royalty", modify: func(in *NewEscrowInput) {}, wantPayout: 9500},
+		{name: "no royalty", modify: func(in *NewEscrowInput) { in.RoyaltyAmount = 0 }, wantPayout: 10000},
+		{name: "royalty equals sale", modify: func(in *NewEscrowInput) { in.RoyaltyAmount = 10000 }, wantPayout: 0},
+		{name: "royalty exceeds sale", modify: func(in *NewEscrowInput) { in.RoyaltyAmount = 10001 }, want: ErrInvalidAmount},
+		{name: "negative royalty", modify: func(in *NewEscrowInput) { in.RoyaltyAmount = -1 }, want: ErrInvalidAmount},
+		{name: "zero sale", modify: func(in *NewEscrowInput) { in.SaleAmount = 0; in.RoyaltyAmount = 0 }, want: ErrInvalidAmount},
+		{name: "seller is buyer", modify: func(in *NewEscrowInput) { in.SellerAvatarID = in.BuyerAvatarID }, want: ErrInvalidSellerAvatarID},
+		{name: "order id with slash", modify: func(in *NewEscrowInput) { in.OrderID = "a/b" }, want: ErrInvalidOrderID},
+		{name: "zero heldAt", modify: func(in *NewEscrowInput) { in.HeldAt = time.Time{} }, want: ErrInvalidHeldAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := testInput()
+			tt.modify(&in)
+
+			e, err := New(in)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("New err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+
+			if e.ID != "order_1_1" || e.Status != StatusHeld {
+				t.Errorf("ID = %q, Status = %s", e.ID, e.Status)
+			}
+			if e.PayoutAmount != tt.wantPayout {
+				t.Errorf("PayoutAmount = %d, want %d", e.PayoutAmount, tt.wantPayout)
+			}
+		})
+	}
+}
+
+func TestEscrow_Release(t *testing.T) {
+	transfer := func(e *Escrow) { _, _ = e.MarkTransferred(testNow, testAutoConfirmAfter, testNow) }
+	confirm := func(e *Escrow) { _ = e.ConfirmReceipt(ConfirmedByBuyer, testNow) }
+
+	tests := []struct {
+		name        string
+		prepare     []func(e *Escrow)
+		wantRelease bool
+		wantErr     error
+	}{
+		{name: "held only", wantErr: ErrNotTransferred},
+		{name: "transferred without confirmation", prepare: []func(e *Escrow){transfer}, wantErr: ErrNotConfirmed},
+		{name: "confirmed without transfer", prepare: []func(e *Escrow){confirm}, wantErr: ErrNotTransferred},
+		{name: "transferred then confirmed", prepare: []func(e *Escrow){transfer, confirm}, wantRelease: true},
+		{name: "confirmed then transferred", prepare: []func(e *Escrow){confirm, transfer}, wantRelease: true},
+		{
+			name: "disputed",
+			prepare: []func(e *Escrow){transfer, confirm, func(e *Escrow) {
+				// 受取確認後でも released 前なら申し立てられる。
+				_ = e.OpenDispute(DisputeReasonCounterfeit, "", testNow)
+			}},
+			wantErr: ErrNotHeld,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testEscrow(t)
+			for _, prepare := range tt.prepare {
+				prepare(&e)
+			}
+
+			if got := e.CanRelease(); got != tt.wantRelease {
+				t.Errorf("CanRelease = %v, want %v", got, tt.wantRelease)
+			}
+
+			if err := e.Release(testNow); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Release err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if e.Status != StatusReleased || e.ReleasedAt == nil {
+				t.Fatalf("Status = %s, ReleasedAt = %v", e.Status, e.ReleasedAt)
+			}
+			if !e.NeedsPayoutAccrual() {
+				t.Fatalf("NeedsPayoutAccrual = false, want true")
+			}
+			if err := e.MarkPayoutAccrued(testNow); err != nil {
+				t.Fatalf("MarkPayoutAccrued: %v", err)
+			}
+			if e.NeedsPayoutAccrual() {
+				t.Fatalf("NeedsPayoutAccrual = true after accrual")
+			}
+		})
+	}
+}
+
+func TestEscrow_AutoConfirm(t *testing.T) {
+	transferredAt := testNow.Add(time.Hour)
+	due := transferredAt.Add(testAutoConfirmAfter)
+
+	tests := []struct {
+		name        string
+		prepare     func(e *Escrow)
+		transferred bool
+		now         time.Time
+		want        bool
+	}{
+		{name: "not transferred", now: due.Add(time.Hour), want: false},
+		{name: "before due", transferred: true, now: due.Add(-time.Second), want: false},
+		{name: "at due", transferred: true, now: due, want: true},
+		{
+			name:        "confirmed before transfer",
+			prepare:     func(e *Escrow) { _ = e.ConfirmReceipt(ConfirmedByBuyer, testNow) },
+			transferred: true,
+			now:         due,
+			want:        false,
+		},
+		{
+			name:        "disputed before transfer",
+			prepare:     func(e *Escrow) { _ = e.OpenDispute(DisputeReasonDamaged, "", testNow) },
+			transferred: true,
+			now:         due,
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testEscrow(t)
+			if tt.prepare != nil {
+				tt.prepare(&e)
+			}
+			if tt.transferred {
+				changed, err := e.MarkTransferred(transferredAt, testAutoConfirmAfter, transferredAt)
+				if err != nil || !changed {
+					t.Fatalf("MarkTransferred = %v, %v", changed, err)
+				}
+			}
+
+			if got := e.IsAutoConfirmDue(tt.now); got != tt.want {
+				t.Fatalf("IsAutoConfirmDue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscrow_MarkTransferred_Idempotent(t *testing.T) {
+	e := testEscrow(t)
+
+	changed, err := e.MarkTransferred(testNow, testAutoConfirmAfter, testNow)
+	if err != nil || !changed {
+		t.Fatalf("MarkTransferred = %v, %v, want true, nil", changed, err)
+	}
+
+	changed, err = e.MarkTransferred(testNow.Add(time.Hour), testAutoConfirmAfter, testNow.Add(time.Hour))
+	if err != nil || changed {
+		t.Fatalf("MarkTransferred again = %v, %v, want false, nil", changed, err)
+	}
+	if !e.TransferredAt.Equal(testNow) {
+		t.Fatalf("TransferredAt = %v, want %v", e.TransferredAt, testNow)
+	}
+
+	if _, err := e.MarkTransferred(time.Time{}, testAutoConfirmAfter, testNow); !errors.Is(err, ErrInvalidTransferredAt) {
+		t.Fatalf("MarkTransferred(zero) err = %v, want %v", err, ErrInvalidTransferredAt)
+	}
+}
+
+func TestEscrow_Dispute(t *testing.T) {
+	tests := []struct {
+		name        string
+		transferred bool
+		resolve     func(e *Escrow) error
+		wantStatus  Status
+		wantErr     error
+	}{
+		{
+			name:        "resolve by release",
+			transferred: true,
+			resolve:     func(e *Escrow) error { return e.ResolveRelease("ok", "member_1", testNow) },
+			wantStatus:  StatusReleased,
+		},
+		{
+			name:       "release requires transfer",
+			resolve:    func(e *Escrow) error { return e.ResolveRelease("ok", "member_1", testNow) },
+			wantStatus: StatusDisputed,
+			wantErr:    ErrNotTransferred,
+		},
+		{
+			name:       "resolve by refund",
+			resolve:    func(e *Escrow) error { return e.ResolveRefund("refund_1", "ng", "member_1", testNow) },
+			wantStatus: StatusRefunded,
+		},
+		{
+			name:       "refund requires refund id",
+			resolve:    func(e *Escrow) error { return e.ResolveRefund(" ", "ng", "member_1", testNow) },
+			wantStatus: StatusDisputed,
+			wantErr:    ErrInvalidRefundID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testEscrow(t)
+			if tt.transferred {
+				_, _ = e.MarkTransferred(testNow, testAutoConfirmAfter, testNow)
+			}
+
+			if err := e.OpenDispute(DisputeReasonNotAsDescribed, "stain", testNow); err != nil {
+				t.Fatalf("OpenDispute: %v", err)
+			}
+			if !e.IsOpen() || e.CanRelease() {
+				t.Fatalf("disputed escrow: IsOpen = %v, CanRelease = %v", e.IsOpen(), e.CanRelease())
+			}
+
+			if err := tt.resolve(&e); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolve err = %v, want %v", err, tt.wantErr)
+			}
+			if e.Status != tt.wantStatus {
+				t.Fatalf("Status = %s, want %s", e.Status, tt.wantStatus)
+			}
+			if err := e.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+
+			switch e.Status {
+			case StatusReleased:
+				if e.ReceiptConfirmedBy != ConfirmedByBrand || e.Dispute.Resolution != ResolutionRelease {
+					t.Fatalf("ReceiptConfirmedBy = %s, Resolution = %s", e.ReceiptConfirmedBy, e.Dispute.Resolution)
+				}
+				if !e.NeedsPayoutAccrual() {
+					t.Fatalf("NeedsPayoutAccrual = false, want true")
+				}
+			case StatusRefunded:
+				if e.Dispute.Resolution != ResolutionRefund || e.NeedsPayoutAccrual() {
+					t.Fatalf("Resolution = %s, NeedsPayoutAccrual = %v", e.Dispute.Resolution, e.NeedsPayoutAccrual())
+				}
+			}
+		})
+	}
+}
+
+func TestEscrow_OpenDispute_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(e *Escrow)
+		reason  DisputeReason
+		comment string
+		want    error
+	}{
+		{name: "unknown reason", reason: "late", want: ErrInvalidDisputeReason},
+		{name: "comment too long", reason: DisputeReasonOther, comment: strings.Repeat("あ", MaxDisputeCommentLength+1), want: ErrInvalidDisputeComment},
+		{
+			name: "already released",
+			prepare: func(e *Escrow) {
+				_, _ = e.MarkTransferred(testNow, testAutoConfirmAfter, testNow)
+				_ = e.ConfirmReceipt(ConfirmedByBuyer, testNow)
+				_ = e.Release(testNow)
+			},
+			reason: DisputeReasonDamaged,
+			want:   ErrNotHeld,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testEscrow(t)
+			if tt.prepare != nil {
+				tt.prepare(&e)
+			}
+
+			if err := e.OpenDispute(tt.reason, tt.comment, testNow); !errors.Is(err, tt.want) {
+				t.Fatalf("OpenDispute err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// backend/internal/domain/escrow/repository_port.go
+package escrow
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RepositoryPort - ドメインのリポジトリ契約
+//
+// Collection:
+// - escrows/{orderId}_{itemIndex}
+type RepositoryPort interface {
+	GetByID(ctx context.Context, id string) (Escrow, error)
+
+	// Create returns ErrConflict when the escrow already exists.
+	Create(ctx context.Context, e Escrow) (Escrow, error)
+
+	// Update は escrow 全体を保存します。
+	// 保存済みの UpdatedAt が prevUpdatedAt と異なる場合（他の更新が先に保存された場合）は ErrConflict。
+	Update(ctx context.Context, e Escrow, prevUpdatedAt time.Time) (Escrow, error)
+
+	// ListByBuyerAvatarID / ListBySellerAvatarID は heldAt の新しい順に返します。
+	ListByBuyerAvatarID(ctx context.Context, avatarID string) ([]Escrow, error)
+	ListBySellerAvatarID(ctx context.Context, avatarID string) ([]Escrow, error)
+
+	// ListByCompanyID は status で絞り込みます（空の場合はすべて）。
+	ListByCompanyID(ctx context.Context, companyID string, status Status) ([]Escrow, error)
+
+	// ListDue は sweeper が処理する held の escrow を返します。
+	// 自動受取確認の期限切れ・支払い可能なものを先に、NFT 移転が未記録のもの
+	// （移転の記録漏れの確認用）を後に、それぞれ heldAt の古い順で返します。
+	ListDue(ctx context.Context, now time.Time, limit int) ([]Escrow, error)
+
+	// ListPendingPayoutAccrual は released かつ支払い台帳に未計上の escrow を
+	// releasedAt の古い順で返します。
+	ListPendingPayoutAccrual(ctx context.Context, limit int) ([]Escrow, error)
+}
+
+// 共通エラー
+var (
+	ErrNotFound = errors.New("escrow: not found")
+	ErrConflict = errors.New("escrow: conflict")
+)
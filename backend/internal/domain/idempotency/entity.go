@@ -0,0 +1,192 @@
+// backend/internal/domain/idempotency/entity.go
+package idempotency
+
+/*
+責務:
+- Idempotency-Key ヘッダ付きの書き込みリクエストを、ユーザー × キー単位で記録する。
+- 最初のリクエストの fingerprint（method / path / body のハッシュ）と、完了後のレスポンスを保持する。
+
+前提:
+- 同じキーで同じリクエストが再送された場合は、保存済みのレスポンスを返す（再実行しない）。
+- 同じキーを別のリクエスト（fingerprint 不一致）に使った場合は conflict とする。
+- 処理中（in_progress）のキーへの並行リクエストは conflict とする。処理中のまま
+  プロセスが停止した場合に備え、リース（LockedUntil）を過ぎたキーは再取得できる。
+  処理中のリクエストはリースを延長し続けるため、時間のかかる処理でも再実行されない。
+- ExpiresAt を過ぎたキーは存在しないものとして扱う（Firestore の TTL ポリシーで削除する）。
+*/
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusInProgress Status = "in_progress"
+	StatusCompleted  Status = "completed"
+)
+
+const (
+	// MaxKeyLength は Idempotency-Key の最大長です。
+	MaxKeyLength = 255
+
+	// MaxResponseBodyBytes を超えるレスポンスは保存しません（キーは解放されます）。
+	MaxResponseBodyBytes = 256 << 10
+)
+
+var (
+	ErrNotFound = errors.New("idempotency: not found")
+
+	ErrInvalidKey            = errors.New("idempotency: invalid key")
+	ErrInvalidUserID         = errors.New("idempotency: invalid userId")
+	ErrInvalidFingerprint    = errors.New("idempotency: invalid fingerprint")
+	ErrInvalidExpiresAt      = errors.New("idempotency: invalid expiresAt")
+	ErrInvalidResponseStatus = errors.New("idempotency: invalid response status")
+	ErrResponseTooLarge      = errors.New("idempotency: response body is too large")
+	ErrNotInProgress         = errors.New("idempotency: record is not in progress")
+)
+
+// Record は 1 つの Idempotency-Key の状態です。
+type Record struct {
+	// ID は UserID と Key から決まる document ID です（RecordID）。
+	ID     string
+	UserID string
+	Key    string
+
+	Fingerprint string
+	Status      Status
+
+	ResponseStatus      int
+	ResponseContentType string
+	ResponseBody        []byte
+
+	CreatedAt   time.Time
+	LockedUntil time.Time
+	ExpiresAt   time.Time
+	CompletedAt *time.Time
+}
+
+// RecordID はユーザー × キーの document ID を返します。
+// キーは任意の文字列のため、ハッシュして document ID に使える形にします。
+func RecordID(userID, key string) string {
+	sum := sha256.Sum256([]byte(
+		strings.TrimSpace(userID) + "\x00" + strings.TrimSpace(key),
+	))
+	return hex.EncodeToString(sum[:])
+}
+
+// Fingerprint はリクエストの同一性を判定するハッシュを返します。
+func Fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(strings.ToUpper(strings.TrimSpace(method))))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(strings.TrimSpace(path)))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ValidateKey は Idempotency-Key ヘッダの値を検証します。
+func ValidateKey(key string) error {
+	if key == "" || len(key) > MaxKeyLength {
+		return ErrInvalidKey
+	}
+	for _, r := range key {
+		if r < 0x20 || r == 0x7f {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// NewInProgress は処理を開始するリクエストのレコードを作成します。
+func NewInProgress(
+	userID string,
+	key string,
+	fingerprint string,
+	now time.Time,
+	lease time.Duration,
+	ttl time.Duration,
+) (Record, error) {
+	userID = strings.TrimSpace(userID)
+	key = strings.TrimSpace(key)
+	now = now.UTC()
+
+	if userID == "" {
+		return Record{}, ErrInvalidUserID
+	}
+	if err := ValidateKey(key); err != nil {
+		return Record{}, err
+	}
+	if strings.TrimSpace(fingerprint) == "" {
+		return Record{}, ErrInvalidFingerprint
+	}
+	if ttl <= 0 || lease <= 0 {
+		return Record{}, ErrInvalidExpiresAt
+	}
+
+	return Record{
+		ID:          RecordID(userID, key),
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      StatusInProgress,
+		CreatedAt:   now,
+		LockedUntil: now.Add(lease),
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+// Replaceable は既存のレコードを無視して新しいリクエストで上書きできるかを返します。
+func (r Record) Replaceable(now time.Time) bool {
+	if !now.Before(r.ExpiresAt) {
+		return true
+	}
+	return r.Status == StatusInProgress && !now.Before(r.LockedUntil)
+}
+
+// ExtendLease は処理中のレコードのリースを now + lease まで延長します。
+func (r *Record) ExtendLease(now time.Time, lease time.Duration) error {
+	if r.Status != StatusInProgress {
+		return ErrNotInProgress
+	}
+	if lease <= 0 {
+		return ErrInvalidExpiresAt
+	}
+
+	r.LockedUntil = now.UTC().Add(lease)
+
+	return nil
+}
+
+// Complete はレスポンスを保存して完了にします。
+func (r *Record) Complete(
+	status int,
+	contentType string,
+	body []byte,
+	now time.Time,
+) error {
+	if r.Status != StatusInProgress {
+		return ErrNotInProgress
+	}
+	if status < 100 || status > 599 {
+		return ErrInvalidResponseStatus
+	}
+	if len(body) > MaxResponseBodyBytes {
+		return ErrResponseTooLarge
+	}
+
+	now = now.UTC()
+
+	r.Status = StatusCompleted
+	r.ResponseStatus = status
+	r.ResponseContentType = strings.TrimSpace(contentType)
+	r.ResponseBody = append([]byte(nil), body...)
+	r.CompletedAt = &now
+
+	return nil
+}
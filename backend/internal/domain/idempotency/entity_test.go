@@ -0,0 +1,218 @@
+// backend/internal/domain/idempotency/entity_test.go
+package idempotency
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+const (
+	testLease = time.Minute
+	testTTL   = 24 * time.Hour
+)
+
+func testRecord(t *testing.T) Record {
+	t.Helper()
+
+	r, err := NewInProgress("user_1", "key-1", Fingerprint("POST", "/mall/me/orders", []byte(`{}`)), testNow, testLease, testTTL)
+	if err != nil {
+		t.Fatalf("NewInProgress: %v", err)
+	}
+
+	return r
+}
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want error
+	}{
+		{name: "uuid", key: "0f8fad5b-d9cb-469f-a165-70867728950e"},
+		{name: "max length", key: strings.Repeat("k", MaxKeyLength)},
+		{name: "non-ascii", key: "注文-1"},
+		{name: "empty", key: "", want: ErrInvalidKey},
+		{name: "too long", key: strings.Repeat("k", MaxKeyLength+1), want: ErrInvalidKey},
+		{name: "control character", key: "key\n1", want: ErrInvalidKey},
+		{name: "delete character", key: "key\x7f", want: ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateKey(tt.key); !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateKey err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	base := Fingerprint("POST", "/mall/me/orders", []byte(`{"a":1}`))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		same   bool
+	}{
+		{name: "identical", method: "POST", path: "/mall/me/orders", body: `{"a":1}`, same: true},
+		{name: "method case and spaces", method: " post ", path: " /mall/me/orders ", body: `{"a":1}`, same: true},
+		{name: "other method", method: "PUT", path: "/mall/me/orders", body: `{"a":1}`},
+		{name: "other path", method: "POST", path: "/mall/me/payments", body: `{"a":1}`},
+		{name: "other body", method: "POST", path: "/mall/me/orders", body: `{"a":2}`},
+		// 区切り文字により、path と body の境界をずらした入力は別物になる。
+		{name: "shifted boundary", method: "POST", path: "/mall/me/orders{", body: `"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Fingerprint(tt.method, tt.path, []byte(tt.body))
+			if (got == base) != tt.same {
+				t.Fatalf("Fingerprint same = %v, want %v", got == base, tt.same)
+			}
+		})
+	}
+
+	if RecordID("user_1", "key-1") == RecordID("user_2", "key-1") {
+		t.Fatalf("RecordID must differ between users")
+	}
+	if RecordID(" user_1 ", " key-1 ") != RecordID("user_1", "key-1") {
+		t.Fatalf("RecordID must ignore surrounding spaces")
+	}
+}
+
+func TestNewInProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      string
+		key         string
+		fingerprint string
+		lease       time.Duration
+		ttl         time.Duration
+		want        error
+	}{
+		{name: "valid", userID: "user_1", key: "key-1", fingerprint: "fp", lease: testLease, ttl: testTTL},
+		{name: "missing user", userID: " ", key: "key-1", fingerprint: "fp", lease: testLease, ttl: testTTL, want: ErrInvalidUserID},
+		{name: "invalid key", userID: "user_1", key: " ", fingerprint: "fp", lease: testLease, ttl: testTTL, want: ErrInvalidKey},
+		{name: "missing fingerprint", userID: "user_1", key: "key-1", lease: testLease, ttl: testTTL, want: ErrInvalidFingerprint},
+		{name: "zero lease", userID: "user_1", key: "key-1", fingerprint: "fp", ttl: testTTL, want: ErrInvalidExpiresAt},
+		{name: "zero ttl", userID: "user_1", key: "key-1", fingerprint: "fp", lease: testLease, want: ErrInvalidExpiresAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewInProgress(tt.userID, tt.key, tt.fingerprint, testNow, tt.lease, tt.ttl)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewInProgress err = %v, want %v", err, tt.want)
+			}
+			if err == nil && (r.Status != StatusInProgress || r.ID != RecordID(tt.userID, tt.key)) {
+				t.Fatalf("NewInProgress = %+v", r)
+			}
+		})
+	}
+}
+
+func TestRecord_Replaceable(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed bool
+		now       time.Time
+		want      bool
+	}{
+		{name: "in progress within lease", now: testNow.Add(testLease - time.Second), want: false},
+		{name: "in progress at lease end", now: testNow.Add(testLease), want: true},
+		{name: "completed after lease", completed: true, now: testNow.Add(testLease), want: false},
+		{name: "completed before expiry", completed: true, now: testNow.Add(testTTL - time.Second), want: false},
+		{name: "completed at expiry", completed: true, now: testNow.Add(testTTL), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testRecord(t)
+			if tt.completed {
+				if err := r.Complete(201, "application/json", []byte(`{}`), testNow); err != nil {
+					t.Fatalf("Complete: %v", err)
+				}
+			}
+
+			if got := r.Replaceable(tt.now); got != tt.want {
+				t.Fatalf("Replaceable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecord_ExtendLease(t *testing.T) {
+	r := testRecord(t)
+
+	// 処理中はリースを延長し続けるため、最初のリースを過ぎても再取得されない。
+	renewedAt := testNow.Add(testLease - time.Second)
+	if err := r.ExtendLease(renewedAt, testLease); err != nil {
+		t.Fatalf("ExtendLease: %v", err)
+	}
+	if r.Replaceable(testNow.Add(testLease)) {
+		t.Fatalf("Replaceable after renewal = true")
+	}
+	if !r.Replaceable(renewedAt.Add(testLease)) {
+		t.Fatalf("Replaceable after the renewed lease = false")
+	}
+
+	if err := r.ExtendLease(renewedAt, 0); !errors.Is(err, ErrInvalidExpiresAt) {
+		t.Fatalf("ExtendLease(0) err = %v, want %v", err, ErrInvalidExpiresAt)
+	}
+
+	if err := r.Complete(200, "", nil, renewedAt); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if err := r.ExtendLease(renewedAt, testLease); !errors.Is(err, ErrNotInProgress) {
+		t.Fatalf("ExtendLease(completed) err = %v, want %v", err, ErrNotInProgress)
+	}
+}
+
+func TestRecord_Complete(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    []byte
+		twice   bool
+		wantErr error
+	}{
+		{name: "created", status: 201, body: []byte(`{"id":"order_1"}`)},
+		{name: "client error is saved too", status: 409, body: []byte(`{"error":"conflict"}`)},
+		{name: "max body", status: 200, body: make([]byte, MaxResponseBodyBytes)},
+		{name: "body too large", status: 200, body: make([]byte, MaxResponseBodyBytes+1), wantErr: ErrResponseTooLarge},
+		{name: "invalid status", status: 99, wantErr: ErrInvalidResponseStatus},
+		{name: "complete twice", status: 200, twice: true, wantErr: ErrNotInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testRecord(t)
+			if tt.twice {
+				if err := r.Complete(tt.status, "", tt.body, testNow); err != nil {
+					t.Fatalf("Complete: %v", err)
+				}
+			}
+
+			err := r.Complete(tt.status, " application/json ", tt.body, testNow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Complete err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if r.Status != StatusCompleted || r.ResponseStatus != tt.status || r.ResponseContentType != "application/json" {
+				t.Fatalf("Complete = %s %d %q", r.Status, r.ResponseStatus, r.ResponseContentType)
+			}
+			if len(r.ResponseBody) != len(tt.body) {
+				t.Fatalf("ResponseBody length = %d, want %d", len(r.ResponseBody), len(tt.body))
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+// backend/internal/domain/idempotency/repository_port.go
+package idempotency
+
+import "context"
+
+// RepositoryPort は Idempotency-Key の永続化ポートです。
+type RepositoryPort interface {
+	// Acquire は rec.ID のレコードが無い、または既存が Replaceable(rec.CreatedAt) の場合に
+	// rec を保存して acquired=true を返します。それ以外は既存のレコードを返します。
+	// 取得と保存は 1 つの transaction で行います。
+	Acquire(ctx context.Context, rec Record) (stored Record, acquired bool, err error)
+
+	// Extend は処理中のレコードのリース（LockedUntil）を rec.LockedUntil に更新します。
+	// リースの期限切れで別のリクエストが取得し直していた場合は ErrNotInProgress を返します。
+	Extend(ctx context.Context, rec Record) error
+
+	// Complete は処理中のレコードにレスポンスを保存します。
+	// リースの期限切れで別のリクエストが取得し直していた場合は ErrNotInProgress を返します。
+	Complete(ctx context.Context, rec Record) error
+
+	// Release は処理中のレコードを削除して、同じキーで再試行できるようにします。
+	// 別のリクエストが取得し直していた場合は何もしません。
+	Release(ctx context.Context, rec Record) error
+}
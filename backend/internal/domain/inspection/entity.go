@@ -3,7 +3,10 @@ package inspection
 
 import (
 	"errors"
+	"net/url"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // ===============================
@@ -48,8 +51,32 @@ type InspectionItem struct {
 	InspectionResult *InspectionResult `json:"inspectionResult"`
 	InspectedBy      *string           `json:"inspectedBy"`
 	InspectedAt      *time.Time        `json:"inspectedAt"`
+
+	// failed の場合のみ保持する不良の詳細（任意）
+	// DefectReasons は productBlueprintCategory の不良理由 code です。
+	DefectReasons   []string `json:"defectReasons,omitempty"`
+	DefectNote      *string  `json:"defectNote,omitempty"`
+	DefectPhotoURLs []string `json:"defectPhotoUrls,omitempty"`
 }
 
+// DefectDetail は failed として記録する際の不良の詳細です。
+//
+// 写真は frontend が Firebase Storage の
+// inspections/{productionId}/{productId}/ 配下へ直接 upload し、downloadURL を渡します。
+type DefectDetail struct {
+	Reasons   []string
+	Note      *string
+	PhotoURLs []string
+}
+
+const (
+	// MaxDefectPhotos は 1 productId あたりの不良写真の上限です。
+	MaxDefectPhotos = 5
+
+	// MaxDefectNoteLength は不良メモの上限（文字数）です。
+	MaxDefectNoteLength = 500
+)
+
 // ------------------------------------------------------
 // InspectionBatch: inspections テーブル 1 レコード
 // ------------------------------------------------------
@@ -80,6 +107,10 @@ var (
 	ErrInvalidInspectionTotalPassed = errors.New("inspection: invalid totalPassed")
 	ErrInconsistentInspectionTotals = errors.New("inspection: inconsistent totals")
 
+	ErrInvalidDefectReasons   = errors.New("inspection: invalid defectReasons")
+	ErrInvalidDefectNote      = errors.New("inspection: invalid defectNote")
+	ErrInvalidDefectPhotoURLs = errors.New("inspection: invalid defectPhotoUrls")
+
 	ErrNotFound = errors.New("inspection: not found")
 )
 
@@ -220,6 +251,12 @@ func (b InspectionBatch) validate() error {
 		}
 	}
 
+	for _, ins := range b.Inspections {
+		if err := validateDefect(ins); err != nil {
+			return err
+		}
+	}
+
 	if b.TotalPassed != actualPassed {
 		return ErrInconsistentInspectionTotals
 	}
@@ -280,7 +317,40 @@ func (b *InspectionBatch) Complete(by string, at time.Time) error {
 
 // MarkFailed は指定した productId を failed として記録します。
 func (b *InspectionBatch) MarkFailed(productID string, by string, at time.Time) error {
-	return b.mark(productID, InspectionFailed, by, at)
+	return b.MarkFailedWithDefect(productID, by, at, DefectDetail{})
+}
+
+// MarkFailedWithDefect は指定した productId を不良の詳細とともに failed として記録します。
+//
+// 不良理由 code が category で選択可能かどうかは application 層で検証します。
+func (b *InspectionBatch) MarkFailedWithDefect(
+	productID string,
+	by string,
+	at time.Time,
+	defect DefectDetail,
+) error {
+	if err := b.mark(productID, InspectionFailed, by, at); err != nil {
+		return err
+	}
+
+	for i := range b.Inspections {
+		item := &b.Inspections[i]
+		if item.ProductID != productID {
+			continue
+		}
+
+		item.DefectReasons = normalizeDefectStrings(defect.Reasons)
+		item.DefectPhotoURLs = normalizeDefectStrings(defect.PhotoURLs)
+		item.DefectNote = nil
+		if defect.Note != nil {
+			if note := strings.TrimSpace(*defect.Note); note != "" {
+				item.DefectNote = &note
+			}
+		}
+		break
+	}
+
+	return b.validate()
 }
 
 // MarkNotManufactured は指定した productId を notManufactured として記録します。
@@ -329,6 +399,11 @@ func (b *InspectionBatch) mark(
 		item.InspectedBy = &by
 		item.InspectedAt = &atUTC
 
+		// 不良の詳細は failed の間のみ保持する
+		item.DefectReasons = nil
+		item.DefectNote = nil
+		item.DefectPhotoURLs = nil
+
 		b.RecalculateTotals()
 
 		return b.validate()
@@ -386,6 +461,80 @@ func (b InspectionBatch) ExcludedProductIDs() []string {
 	return out
 }
 
+// ===============================
+// Defect
+// ===============================
+
+func validateDefect(ins InspectionItem) error {
+	if ins.InspectionResult == nil || *ins.InspectionResult != InspectionFailed {
+		if len(ins.DefectReasons) > 0 {
+			return ErrInvalidDefectReasons
+		}
+		if ins.DefectNote != nil {
+			return ErrInvalidDefectNote
+		}
+		if len(ins.DefectPhotoURLs) > 0 {
+			return ErrInvalidDefectPhotoURLs
+		}
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(ins.DefectReasons))
+	for _, code := range ins.DefectReasons {
+		if code == "" {
+			return ErrInvalidDefectReasons
+		}
+		if _, ok := seen[code]; ok {
+			return ErrInvalidDefectReasons
+		}
+		seen[code] = struct{}{}
+	}
+
+	if ins.DefectNote != nil {
+		if *ins.DefectNote == "" || utf8.RuneCountInString(*ins.DefectNote) > MaxDefectNoteLength {
+			return ErrInvalidDefectNote
+		}
+	}
+
+	if len(ins.DefectPhotoURLs) > MaxDefectPhotos {
+		return ErrInvalidDefectPhotoURLs
+	}
+	for _, raw := range ins.DefectPhotoURLs {
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return ErrInvalidDefectPhotoURLs
+		}
+	}
+
+	return nil
+}
+
+// normalizeDefectStrings は空白を除去し、空文字と重複を取り除きます（入力順を保持）。
+func normalizeDefectStrings(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	out := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
 // ===============================
 // Status / Result validator
 // ===============================
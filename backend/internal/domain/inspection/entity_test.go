@@ -0,0 +1,167 @@
+// backend/internal/domain/inspection/entity_test.go
+package inspection
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testBatch(t *testing.T) InspectionBatch {
+	t.Helper()
+
+	b, err := NewInspectionBatch("production_1", InspectionStatusInspecting, []string{"p1", "p2", "p3"})
+	if err != nil {
+		t.Fatalf("NewInspectionBatch: %v", err)
+	}
+
+	return b
+}
+
+func item(t *testing.T, b InspectionBatch, productID string) InspectionItem {
+	t.Helper()
+
+	for _, it := range b.Inspections {
+		if it.ProductID == productID {
+			return it
+		}
+	}
+	t.Fatalf("productId %q not found", productID)
+	return InspectionItem{}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestInspectionBatch_MarkFailedWithDefect(t *testing.T) {
+	photo := "https://firebasestorage.googleapis.com/v0/b/bucket/o/inspections%2Fproduction_1%2Fp1%2F1.jpg"
+
+	tests := []struct {
+		name        string
+		defect      DefectDetail
+		wantReasons []string
+		wantNote    *string
+		wantPhotos  []string
+		wantErr     error
+	}{
+		{
+			name:        "reasons, note and photos",
+			defect:      DefectDetail{Reasons: []string{"stain"}, Note: strPtr("袖口に汚れ"), PhotoURLs: []string{photo}},
+			wantReasons: []string{"stain"},
+			wantNote:    strPtr("袖口に汚れ"),
+			wantPhotos:  []string{photo},
+		},
+		{
+			// 空白・空文字・重複を取り除き、入力順を保つ。
+			name:        "normalized",
+			defect:      DefectDetail{Reasons: []string{" sewing ", "", "stain", "sewing"}, Note: strPtr("  "), PhotoURLs: []string{photo, " " + photo}},
+			wantReasons: []string{"sewing", "stain"},
+			wantPhotos:  []string{photo},
+		},
+		{name: "no detail"},
+		{
+			name:    "note too long",
+			defect:  DefectDetail{Note: strPtr(strings.Repeat("汚", MaxDefectNoteLength+1))},
+			wantErr: ErrInvalidDefectNote,
+		},
+		{
+			name:    "too many photos",
+			defect:  DefectDetail{PhotoURLs: []string{photo + "1", photo + "2", photo + "3", photo + "4", photo + "5", photo + "6"}},
+			wantErr: ErrInvalidDefectPhotoURLs,
+		},
+		{
+			name:    "http photo",
+			defect:  DefectDetail{PhotoURLs: []string{"http://example.com/1.jpg"}},
+			wantErr: ErrInvalidDefectPhotoURLs,
+		},
+		{
+			name:    "relative photo",
+			defect:  DefectDetail{PhotoURLs: []string{"inspections/production_1/p1/1.jpg"}},
+			wantErr: ErrInvalidDefectPhotoURLs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testBatch(t)
+
+			err := b.MarkFailedWithDefect("p1", "member_1", testNow, tt.defect)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MarkFailedWithDefect err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := item(t, b, "p1")
+			if *got.InspectionResult != InspectionFailed {
+				t.Fatalf("InspectionResult = %s, want failed", *got.InspectionResult)
+			}
+			if !reflect.DeepEqual(got.DefectReasons, tt.wantReasons) || !reflect.DeepEqual(got.DefectPhotoURLs, tt.wantPhotos) {
+				t.Fatalf("DefectReasons = %v, DefectPhotoURLs = %v", got.DefectReasons, got.DefectPhotoURLs)
+			}
+			if !reflect.DeepEqual(got.DefectNote, tt.wantNote) {
+				t.Fatalf("DefectNote = %v, want %v", got.DefectNote, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestInspectionBatch_DefectClearedOnRemark(t *testing.T) {
+	tests := []struct {
+		name   string
+		remark func(b *InspectionBatch) error
+	}{
+		{name: "passed", remark: func(b *InspectionBatch) error { return b.MarkPassed("p1", "member_2", testNow) }},
+		{name: "not manufactured", remark: func(b *InspectionBatch) error { return b.MarkNotManufactured("p1", "member_2", testNow) }},
+		{name: "failed without detail", remark: func(b *InspectionBatch) error { return b.MarkFailed("p1", "member_2", testNow) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testBatch(t)
+			if err := b.MarkFailedWithDefect("p1", "member_1", testNow, DefectDetail{Reasons: []string{"stain"}, Note: strPtr("汚れ")}); err != nil {
+				t.Fatalf("MarkFailedWithDefect: %v", err)
+			}
+
+			if err := tt.remark(&b); err != nil {
+				t.Fatalf("remark: %v", err)
+			}
+
+			got := item(t, b, "p1")
+			if got.DefectReasons != nil || got.DefectNote != nil || got.DefectPhotoURLs != nil {
+				t.Fatalf("defect detail kept after re-marking: %+v", got)
+			}
+		})
+	}
+}
+
+func TestValidateDefect(t *testing.T) {
+	passed := InspectionPassed
+	failed := InspectionFailed
+
+	tests := []struct {
+		name string
+		item InspectionItem
+		want error
+	}{
+		{name: "reasons on passed", item: InspectionItem{ProductID: "p1", InspectionResult: &passed, DefectReasons: []string{"stain"}}, want: ErrInvalidDefectReasons},
+		{name: "note on passed", item: InspectionItem{ProductID: "p1", InspectionResult: &passed, DefectNote: strPtr("x")}, want: ErrInvalidDefectNote},
+		{name: "duplicate reasons", item: InspectionItem{ProductID: "p1", InspectionResult: &failed, DefectReasons: []string{"stain", "stain"}}, want: ErrInvalidDefectReasons},
+		{name: "empty note", item: InspectionItem{ProductID: "p1", InspectionResult: &failed, DefectNote: strPtr("")}, want: ErrInvalidDefectNote},
+		{name: "failed with detail", item: InspectionItem{ProductID: "p1", InspectionResult: &failed, DefectReasons: []string{"stain"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateDefect(tt.item); !errors.Is(err, tt.want) {
+				t.Fatalf("validateDefect err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// backend/internal/domain/inventory/reservation.go
+package inventory
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReservationStatus は注文による在庫引当レコードの状態です。
+//
+//   - active:    引当中。ExpiresAt を過ぎて決済が失敗・未完了なら sweeper が解放する
+//   - confirmed: 決済済み、または発送時に決済する注文。以降は Transfer / 注文キャンセルで解放される
+//   - released:  引当解放済み（期限切れ・決済失敗・決済キャンセル・注文キャンセル）
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "active"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	ReservationStatusReleased  ReservationStatus = "released"
+)
+
+// ReservationReleaseReason は引当を解放した理由です。
+type ReservationReleaseReason string
+
+const (
+	ReservationReleaseReasonExpired         ReservationReleaseReason = "expired"
+	ReservationReleaseReasonPaymentFailed   ReservationReleaseReason = "payment_failed"
+	ReservationReleaseReasonPaymentCanceled ReservationReleaseReason = "payment_canceled"
+	ReservationReleaseReasonOrderCanceled   ReservationReleaseReason = "order_canceled"
+
+	// 在庫不足などで注文を作成できなかった場合の巻き戻し
+	ReservationReleaseReasonOrderFailed ReservationReleaseReason = "order_failed"
+)
+
+// DefaultReservationTTL は注文作成から、決済が失敗・未完了の引当を解放するまでの既定時間です。
+// payment の無い注文（発送時に決済する注文）は期限後も解放しません。
+const DefaultReservationTTL = 72 * time.Hour
+
+var (
+	ErrInvalidReservationOrderID = errors.New("inventory: reservation orderId is required")
+	ErrInvalidReservationItems   = errors.New("inventory: reservation items are invalid")
+	ErrInvalidReservationExpiry  = errors.New("inventory: reservation expiresAt is invalid")
+	ErrInvalidReservationStatus  = errors.New("inventory: reservation status is invalid")
+	ErrInvalidReleaseReason      = errors.New("inventory: reservation release reason is invalid")
+	ErrReservationConflict       = errors.New("inventory: reservation conflict")
+	ErrInsufficientStock         = errors.New("inventory: insufficient stock")
+)
+
+// ReservationItem は 1 つの inventory / model に対する引当数です。
+type ReservationItem struct {
+	InventoryID string
+	ModelID     string
+	Qty         int
+}
+
+// Reservation は注文 1 件分の在庫引当レコードです。
+//
+// Stock[modelId].ReservedByOrder[orderId] は在庫側の集計値であり、
+// 期限と状態はこのレコードで管理します。
+// - docId: orderId（注文 1 件につき 1 レコード）
+type Reservation struct {
+	ID      string
+	OrderID string
+
+	Items []ReservationItem
+
+	Status        ReservationStatus
+	ReleaseReason ReservationReleaseReason
+
+	ExpiresAt time.Time
+
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ConfirmedAt *time.Time
+	ReleasedAt  *time.Time
+}
+
+// NewReservation は注文作成時点の引当レコードを作成します。
+// 同じ inventory / model の item は合算します。
+func NewReservation(
+	orderID string,
+	items []ReservationItem,
+	now time.Time,
+	ttl time.Duration,
+) (Reservation, error) {
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return Reservation{}, ErrInvalidReservationOrderID
+	}
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+
+	merged, err := mergeReservationItems(items)
+	if err != nil {
+		return Reservation{}, err
+	}
+
+	now = now.UTC()
+
+	r := Reservation{
+		ID:        orderID,
+		OrderID:   orderID,
+		Items:     merged,
+		Status:    ReservationStatusActive,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := r.Validate(); err != nil {
+		return Reservation{}, err
+	}
+
+	return r, nil
+}
+
+func IsValidReservationStatus(status ReservationStatus) bool {
+	switch status {
+	case ReservationStatusActive,
+		ReservationStatusConfirmed,
+		ReservationStatusReleased:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidReservationReleaseReason(reason ReservationReleaseReason) bool {
+	switch reason {
+	case ReservationReleaseReasonExpired,
+		ReservationReleaseReasonPaymentFailed,
+		ReservationReleaseReasonPaymentCanceled,
+		ReservationReleaseReasonOrderCanceled,
+		ReservationReleaseReasonOrderFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsExpired は未決済のまま期限を過ぎた引当かどうかを返します。
+func (r Reservation) IsExpired(now time.Time) bool {
+	return r.Status == ReservationStatusActive &&
+		!now.UTC().Before(r.ExpiresAt)
+}
+
+// TotalQty は引当数の合計を返します。
+func (r Reservation) TotalQty() int {
+	total := 0
+	for _, item := range r.Items {
+		total += item.Qty
+	}
+	return total
+}
+
+// Confirm は決済完了により引当を期限切れ解放の対象外にします。
+// confirmed 済みなら何もしません。released からは戻せません。
+func (r *Reservation) Confirm(now time.Time) error {
+	switch r.Status {
+	case ReservationStatusConfirmed:
+		return nil
+	case ReservationStatusReleased:
+		return ErrReservationConflict
+	}
+
+	at := now.UTC()
+	r.Status = ReservationStatusConfirmed
+	r.ConfirmedAt = &at
+	r.UpdatedAt = at
+
+	return nil
+}
+
+// Release は引当を解放済みにします。released 済みなら何もしません。
+func (r *Reservation) Release(
+	reason ReservationReleaseReason,
+	now time.Time,
+) error {
+	if !IsValidReservationReleaseReason(reason) {
+		return ErrInvalidReleaseReason
+	}
+	if r.Status == ReservationStatusReleased {
+		return nil
+	}
+
+	at := now.UTC()
+	r.Status = ReservationStatusReleased
+	r.ReleaseReason = reason
+	r.ReleasedAt = &at
+	r.UpdatedAt = at
+
+	return nil
+}
+
+// Validate は引当レコードの必須項目と整合性を検証します。
+func (r Reservation) Validate() error {
+	if r.ID == "" || r.OrderID == "" || r.ID != r.OrderID {
+		return ErrInvalidReservationOrderID
+	}
+	if len(r.Items) == 0 {
+		return ErrInvalidReservationItems
+	}
+	for _, item := range r.Items {
+		if item.InventoryID == "" || item.ModelID == "" || item.Qty <= 0 {
+			return ErrInvalidReservationItems
+		}
+	}
+	if !IsValidReservationStatus(r.Status) {
+		return ErrInvalidReservationStatus
+	}
+	if r.ExpiresAt.IsZero() || r.ExpiresAt.Before(r.CreatedAt) {
+		return ErrInvalidReservationExpiry
+	}
+	if r.Status == ReservationStatusReleased &&
+		!IsValidReservationReleaseReason(r.ReleaseReason) {
+		return ErrInvalidReleaseReason
+	}
+
+	return nil
+}
+
+func mergeReservationItems(items []ReservationItem) ([]ReservationItem, error) {
+	if len(items) == 0 {
+		return nil, ErrInvalidReservationItems
+	}
+
+	type key struct {
+		inventoryID string
+		modelID     string
+	}
+
+	qtyByKey := map[key]int{}
+	for _, item := range items {
+		inventoryID := strings.TrimSpace(item.InventoryID)
+		modelID := strings.TrimSpace(item.ModelID)
+		if inventoryID == "" || modelID == "" || item.Qty <= 0 {
+			return nil, ErrInvalidReservationItems
+		}
+		qtyByKey[key{inventoryID, modelID}] += item.Qty
+	}
+
+	out := make([]ReservationItem, 0, len(qtyByKey))
+	for k, qty := range qtyByKey {
+		out = append(out, ReservationItem{
+			InventoryID: k.inventoryID,
+			ModelID:     k.modelID,
+			Qty:         qty,
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].InventoryID != out[j].InventoryID {
+			return out[i].InventoryID < out[j].InventoryID
+		}
+		return out[i].ModelID < out[j].ModelID
+	})
+
+	return out, nil
+}
@@ -0,0 +1,56 @@
+// backend/internal/domain/inventory/reservation_repository_port.go
+package inventory
+
+import (
+	"context"
+	"time"
+)
+
+// ReservationRepository is output port for inventoryReservations persistence.
+//
+// Stock[modelId].ReservedByOrder の更新は RepositoryPort
+// （ReserveByOrder / ReleaseReservationByOrder）の責務であり、
+// この port は引当レコード（期限・状態）のみを扱う。
+type ReservationRepository interface {
+	// CreateIfAbsent stores the reservation when no record exists for its ID.
+	//
+	// Contract:
+	// - Must not overwrite an existing reservation.
+	// - Returns (existing, false, nil) when a record already exists.
+	// - Returns (reservation, true, nil) when the record was created.
+	CreateIfAbsent(
+		ctx context.Context,
+		reservation Reservation,
+	) (Reservation, bool, error)
+
+	// GetByOrderID returns ErrNotFound when the order has no reservation.
+	GetByOrderID(
+		ctx context.Context,
+		orderID string,
+	) (Reservation, error)
+
+	// ListExpired returns active reservations whose ExpiresAt <= now,
+	// ordered by ExpiresAt asc. limit <= 0 means the implementation default.
+	ListExpired(
+		ctx context.Context,
+		now time.Time,
+		limit int,
+	) ([]Reservation, error)
+
+	// ListActiveByInventoryID returns active and confirmed reservations
+	// that contain at least one item for inventoryID.
+	ListActiveByInventoryID(
+		ctx context.Context,
+		inventoryID string,
+	) ([]Reservation, error)
+
+	// Update replaces an existing reservation.
+	//
+	// Contract:
+	// - Must not create a record: return ErrNotFound when it does not exist.
+	// - A released reservation must not be changed: return ErrReservationConflict.
+	Update(
+		ctx context.Context,
+		reservation Reservation,
+	) (Reservation, error)
+}
@@ -0,0 +1,235 @@
+// backend/internal/domain/inventory/reservation_test.go
+package inventory
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNewReservation(t *testing.T) {
+	tests := []struct {
+		name          string
+		orderID       string
+		items         []ReservationItem
+		ttl           time.Duration
+		wantItems     []ReservationItem
+		wantExpiresAt time.Time
+		wantErr       error
+	}{
+		{
+			name:    "merges same inventory and model and sorts",
+			orderID: " order_1 ",
+			items: []ReservationItem{
+				{InventoryID: "inv_b", ModelID: "model_1", Qty: 1},
+				{InventoryID: "inv_a", ModelID: "model_2", Qty: 2},
+				{InventoryID: " inv_a ", ModelID: "model_2", Qty: 3},
+				{InventoryID: "inv_a", ModelID: "model_1", Qty: 1},
+			},
+			ttl: time.Hour,
+			wantItems: []ReservationItem{
+				{InventoryID: "inv_a", ModelID: "model_1", Qty: 1},
+				{InventoryID: "inv_a", ModelID: "model_2", Qty: 5},
+				{InventoryID: "inv_b", ModelID: "model_1", Qty: 1},
+			},
+			wantExpiresAt: testNow.Add(time.Hour),
+		},
+		{
+			name:    "zero ttl falls back to default",
+			orderID: "order_1",
+			items: []ReservationItem{
+				{InventoryID: "inv_a", ModelID: "model_1", Qty: 1},
+			},
+			wantItems: []ReservationItem{
+				{InventoryID: "inv_a", ModelID: "model_1", Qty: 1},
+			},
+			wantExpiresAt: testNow.Add(DefaultReservationTTL),
+		},
+		{
+			name:    "empty order id",
+			orderID: " ",
+			items: []ReservationItem{
+				{InventoryID: "inv_a", ModelID: "model_1", Qty: 1},
+			},
+			wantErr: ErrInvalidReservationOrderID,
+		},
+		{
+			name:    "no items",
+			orderID: "order_1",
+			wantErr: ErrInvalidReservationItems,
+		},
+		{
+			name:    "zero qty",
+			orderID: "order_1",
+			items: []ReservationItem{
+				{InventoryID: "inv_a", ModelID: "model_1", Qty: 0},
+			},
+			wantErr: ErrInvalidReservationItems,
+		},
+		{
+			name:    "missing model id",
+			orderID: "order_1",
+			items: []ReservationItem{
+				{InventoryID: "inv_a", Qty: 1},
+			},
+			wantErr: ErrInvalidReservationItems,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewReservation(tt.orderID, tt.items, testNow, tt.ttl)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewReservation err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if got.ID != "order_1" || got.OrderID != "order_1" {
+				t.Errorf("ID = %q, OrderID = %q, want order_1", got.ID, got.OrderID)
+			}
+			if got.Status != ReservationStatusActive {
+				t.Errorf("Status = %q, want %q", got.Status, ReservationStatusActive)
+			}
+			if !reflect.DeepEqual(got.Items, tt.wantItems) {
+				t.Errorf("Items = %+v, want %+v", got.Items, tt.wantItems)
+			}
+			if !got.ExpiresAt.Equal(tt.wantExpiresAt) {
+				t.Errorf("ExpiresAt = %v, want %v", got.ExpiresAt, tt.wantExpiresAt)
+			}
+		})
+	}
+}
+
+func TestReservation_IsExpired(t *testing.T) {
+	tests := []struct {
+		name   string
+		status ReservationStatus
+		now    time.Time
+		want   bool
+	}{
+		{name: "active before expiry", status: ReservationStatusActive, now: testNow.Add(time.Hour - time.Nanosecond), want: false},
+		{name: "active at expiry", status: ReservationStatusActive, now: testNow.Add(time.Hour), want: true},
+		{name: "active after expiry", status: ReservationStatusActive, now: testNow.Add(2 * time.Hour), want: true},
+		{name: "confirmed after expiry", status: ReservationStatusConfirmed, now: testNow.Add(2 * time.Hour), want: false},
+		{name: "released after expiry", status: ReservationStatusReleased, now: testNow.Add(2 * time.Hour), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Reservation{Status: tt.status, ExpiresAt: testNow.Add(time.Hour)}
+			if got := r.IsExpired(tt.now); got != tt.want {
+				t.Fatalf("IsExpired = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReservation_Transitions(t *testing.T) {
+	later := testNow.Add(time.Minute)
+
+	tests := []struct {
+		name       string
+		from       ReservationStatus
+		apply      func(r *Reservation) error
+		wantStatus ReservationStatus
+		wantReason ReservationReleaseReason
+		wantErr    error
+	}{
+		{
+			name:       "confirm active",
+			from:       ReservationStatusActive,
+			apply:      func(r *Reservation) error { return r.Confirm(later) },
+			wantStatus: ReservationStatusConfirmed,
+		},
+		{
+			name:       "confirm confirmed is a no-op",
+			from:       ReservationStatusConfirmed,
+			apply:      func(r *Reservation) error { return r.Confirm(later) },
+			wantStatus: ReservationStatusConfirmed,
+		},
+		{
+			name:       "confirm released conflicts",
+			from:       ReservationStatusReleased,
+			apply:      func(r *Reservation) error { return r.Confirm(later) },
+			wantStatus: ReservationStatusReleased,
+			wantReason: ReservationReleaseReasonExpired,
+			wantErr:    ErrReservationConflict,
+		},
+		{
+			name: "release active",
+			from: ReservationStatusActive,
+			apply: func(r *Reservation) error {
+				return r.Release(ReservationReleaseReasonPaymentFailed, later)
+			},
+			wantStatus: ReservationStatusReleased,
+			wantReason: ReservationReleaseReasonPaymentFailed,
+		},
+		{
+			name: "release confirmed",
+			from: ReservationStatusConfirmed,
+			apply: func(r *Reservation) error {
+				return r.Release(ReservationReleaseReasonOrderCanceled, later)
+			},
+			wantStatus: ReservationStatusReleased,
+			wantReason: ReservationReleaseReasonOrderCanceled,
+		},
+		{
+			name: "release released keeps the first reason",
+			from: ReservationStatusReleased,
+			apply: func(r *Reservation) error {
+				return r.Release(ReservationReleaseReasonOrderCanceled, later)
+			},
+			wantStatus: ReservationStatusReleased,
+			wantReason: ReservationReleaseReasonExpired,
+		},
+		{
+			name: "release with invalid reason",
+			from: ReservationStatusActive,
+			apply: func(r *Reservation) error {
+				return r.Release("unknown", later)
+			},
+			wantStatus: ReservationStatusActive,
+			wantErr:    ErrInvalidReleaseReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReservation(
+				"order_1",
+				[]ReservationItem{{InventoryID: "inv_a", ModelID: "model_1", Qty: 1}},
+				testNow,
+				time.Hour,
+			)
+			if err != nil {
+				t.Fatalf("NewReservation: %v", err)
+			}
+
+			switch tt.from {
+			case ReservationStatusConfirmed:
+				r.Status = ReservationStatusConfirmed
+			case ReservationStatusReleased:
+				r.Status = ReservationStatusReleased
+				r.ReleaseReason = ReservationReleaseReasonExpired
+			}
+
+			if err := tt.apply(&r); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if r.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", r.Status, tt.wantStatus)
+			}
+			if r.ReleaseReason != tt.wantReason {
+				t.Errorf("ReleaseReason = %q, want %q", r.ReleaseReason, tt.wantReason)
+			}
+			if err := r.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+		})
+	}
+}
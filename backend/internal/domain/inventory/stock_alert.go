@@ -0,0 +1,309 @@
+// backend/internal/domain/inventory/stock_alert.go
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StockAlertStatus は在庫アラートの状態です。
+//
+//   - open:     販売可能数が閾値以下になり発火中
+//   - resolved: 販売可能数が閾値を上回り解消済み
+//
+// 同じ inventory / model の open なアラートは常に 1 件までです。
+// open の間は再通知せず、resolved になった後に再び閾値以下になると新しいアラートを発火します。
+type StockAlertStatus string
+
+const (
+	StockAlertStatusOpen     StockAlertStatus = "open"
+	StockAlertStatusResolved StockAlertStatus = "resolved"
+)
+
+// MaxStockAlertNotifyEmails は 1 inventory あたりの通知先メールアドレスの上限です。
+const MaxStockAlertNotifyEmails = 10
+
+var (
+	ErrInvalidStockAlertThreshold   = errors.New("inventory: stock alert threshold must not be negative")
+	ErrInvalidStockAlertNotifyEmail = errors.New("inventory: stock alert notify email is invalid")
+	ErrTooManyStockAlertNotifyEmail = errors.New("inventory: too many stock alert notify emails")
+	ErrInvalidStockAlertCompanyID   = errors.New("inventory: stock alert companyId is required")
+	ErrInvalidStockAlertStatus      = errors.New("inventory: stock alert status is invalid")
+	ErrInvalidStockAlert            = errors.New("inventory: stock alert is invalid")
+)
+
+// StockAlertSetting は inventory 単位の在庫アラート設定です。
+//
+//   - docId: inventoryId
+//   - DefaultThreshold: ModelThresholds に無い model に適用する閾値（nil なら対象外）
+//   - ModelThresholds: modelId -> 閾値（model 単位の上書き）
+//   - 販売可能数（Accumulation - ReservedCount）が閾値以下になったら発火する
+//   - CompanyID は保存時に productBlueprint から解決し、console の company 境界に使う
+type StockAlertSetting struct {
+	InventoryID string
+	CompanyID   string
+
+	Enabled bool
+
+	DefaultThreshold *int
+	ModelThresholds  map[string]int
+
+	NotifyEmails []string
+
+	UpdatedAt time.Time
+	UpdatedBy string
+}
+
+// NewStockAlertSetting は入力を正規化して設定を作成します。
+// 通知先メールアドレスは小文字化・重複排除・ソートします。
+func NewStockAlertSetting(
+	inventoryID string,
+	companyID string,
+	enabled bool,
+	defaultThreshold *int,
+	modelThresholds map[string]int,
+	notifyEmails []string,
+	now time.Time,
+	updatedBy string,
+) (StockAlertSetting, error) {
+	emails, err := normalizeStockAlertNotifyEmails(notifyEmails)
+	if err != nil {
+		return StockAlertSetting{}, err
+	}
+
+	thresholds := make(map[string]int, len(modelThresholds))
+	for modelID, threshold := range modelThresholds {
+		modelID = strings.TrimSpace(modelID)
+		if modelID == "" {
+			return StockAlertSetting{}, ErrInvalidModelID
+		}
+		thresholds[modelID] = threshold
+	}
+
+	var def *int
+	if defaultThreshold != nil {
+		v := *defaultThreshold
+		def = &v
+	}
+
+	s := StockAlertSetting{
+		InventoryID:      strings.TrimSpace(inventoryID),
+		CompanyID:        strings.TrimSpace(companyID),
+		Enabled:          enabled,
+		DefaultThreshold: def,
+		ModelThresholds:  thresholds,
+		NotifyEmails:     emails,
+		UpdatedAt:        now.UTC(),
+		UpdatedBy:        strings.TrimSpace(updatedBy),
+	}
+
+	if err := s.Validate(); err != nil {
+		return StockAlertSetting{}, err
+	}
+
+	return s, nil
+}
+
+// ThresholdFor は model に適用する閾値を返します。
+// 無効な設定、または閾値が無い model の場合は false を返します。
+func (s StockAlertSetting) ThresholdFor(modelID string) (int, bool) {
+	if !s.Enabled {
+		return 0, false
+	}
+	if threshold, ok := s.ModelThresholds[modelID]; ok {
+		return threshold, true
+	}
+	if s.DefaultThreshold != nil {
+		return *s.DefaultThreshold, true
+	}
+	return 0, false
+}
+
+// Validate は設定の必須項目と整合性を検証します。
+func (s StockAlertSetting) Validate() error {
+	if s.InventoryID == "" {
+		return ErrInvalidMintID
+	}
+	if s.CompanyID == "" {
+		return ErrInvalidStockAlertCompanyID
+	}
+	if s.DefaultThreshold != nil && *s.DefaultThreshold < 0 {
+		return ErrInvalidStockAlertThreshold
+	}
+	for modelID, threshold := range s.ModelThresholds {
+		if modelID == "" {
+			return ErrInvalidModelID
+		}
+		if threshold < 0 {
+			return ErrInvalidStockAlertThreshold
+		}
+	}
+	if len(s.NotifyEmails) > MaxStockAlertNotifyEmails {
+		return ErrTooManyStockAlertNotifyEmail
+	}
+	for _, email := range s.NotifyEmails {
+		if !isValidStockAlertEmail(email) {
+			return ErrInvalidStockAlertNotifyEmail
+		}
+	}
+
+	return nil
+}
+
+// StockAlert は発火した在庫アラート 1 件（履歴）です。
+//
+//   - docId: BuildStockAlertID(inventoryId, modelId, firedAt)
+//   - Available / Accumulation / ReservedCount は発火時点の値
+//   - NotifiedEmails / NotificationError はメール通知の結果
+type StockAlert struct {
+	ID          string
+	InventoryID string
+	ModelID     string
+	CompanyID   string
+
+	Status StockAlertStatus
+
+	Threshold     int
+	Available     int
+	Accumulation  int
+	ReservedCount int
+
+	FiredAt    time.Time
+	ResolvedAt *time.Time
+
+	// 解消時点の販売可能数
+	ResolvedAvailable *int
+
+	NotifiedEmails    []string
+	NotifiedAt        *time.Time
+	NotificationError string
+}
+
+// BuildStockAlertID は履歴として一意になるアラート ID を返します。
+func BuildStockAlertID(
+	inventoryID string,
+	modelID string,
+	firedAt time.Time,
+) string {
+	if inventoryID == "" || modelID == "" || firedAt.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(
+		"%s__%s__%d",
+		inventoryID,
+		modelID,
+		firedAt.UTC().UnixNano(),
+	)
+}
+
+// NewStockAlert は ModelStock から発火時点のアラートを作成します。
+func NewStockAlert(
+	setting StockAlertSetting,
+	modelID string,
+	stock ModelStock,
+	threshold int,
+	now time.Time,
+) (StockAlert, error) {
+	firedAt := now.UTC()
+
+	a := StockAlert{
+		ID:            BuildStockAlertID(setting.InventoryID, modelID, firedAt),
+		InventoryID:   setting.InventoryID,
+		ModelID:       modelID,
+		CompanyID:     setting.CompanyID,
+		Status:        StockAlertStatusOpen,
+		Threshold:     threshold,
+		Available:     stock.Available(),
+		Accumulation:  stock.Accumulation,
+		ReservedCount: stock.ReservedCount,
+		FiredAt:       firedAt,
+	}
+
+	if err := a.Validate(); err != nil {
+		return StockAlert{}, err
+	}
+
+	return a, nil
+}
+
+// Resolve はアラートを解消済みにします。resolved 済みなら何もしません。
+func (a *StockAlert) Resolve(available int, now time.Time) {
+	if a.Status == StockAlertStatusResolved {
+		return
+	}
+
+	at := now.UTC()
+	v := available
+	a.Status = StockAlertStatusResolved
+	a.ResolvedAt = &at
+	a.ResolvedAvailable = &v
+}
+
+// Validate はアラートの必須項目と整合性を検証します。
+func (a StockAlert) Validate() error {
+	if a.ID == "" || a.ModelID == "" || a.FiredAt.IsZero() {
+		return ErrInvalidStockAlert
+	}
+	if a.InventoryID == "" {
+		return ErrInvalidMintID
+	}
+	if a.CompanyID == "" {
+		return ErrInvalidStockAlertCompanyID
+	}
+	switch a.Status {
+	case StockAlertStatusOpen, StockAlertStatusResolved:
+	default:
+		return ErrInvalidStockAlertStatus
+	}
+	if a.Threshold < 0 {
+		return ErrInvalidStockAlertThreshold
+	}
+
+	return nil
+}
+
+// Available は販売可能数（Accumulation - ReservedCount、0 未満は 0）を返します。
+func (ms ModelStock) Available() int {
+	available := ms.Accumulation - ms.ReservedCount
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+func normalizeStockAlertNotifyEmails(emails []string) ([]string, error) {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(emails))
+
+	for _, email := range emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" {
+			continue
+		}
+		if !isValidStockAlertEmail(email) {
+			return nil, ErrInvalidStockAlertNotifyEmail
+		}
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+		out = append(out, email)
+	}
+
+	if len(out) > MaxStockAlertNotifyEmails {
+		return nil, ErrTooManyStockAlertNotifyEmail
+	}
+
+	sort.Strings(out)
+
+	return out, nil
+}
+
+func isValidStockAlertEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
@@ -0,0 +1,70 @@
+// backend/internal/domain/inventory/stock_alert_repository_port.go
+package inventory
+
+import (
+	"context"
+	"time"
+)
+
+// StockAlertSettingRepository is output port for stockAlertSettings persistence.
+type StockAlertSettingRepository interface {
+	// GetByInventoryID returns ErrNotFound when the inventory has no setting.
+	GetByInventoryID(
+		ctx context.Context,
+		inventoryID string,
+	) (StockAlertSetting, error)
+
+	// Save creates or replaces the setting of setting.InventoryID.
+	Save(
+		ctx context.Context,
+		setting StockAlertSetting,
+	) (StockAlertSetting, error)
+}
+
+// StockAlertRepository is output port for stockAlerts (fired alert history).
+type StockAlertRepository interface {
+	// OpenIfAbsent stores alert as the open alert of its inventory/model.
+	//
+	// Contract:
+	// - At most one open alert exists per inventory/model.
+	// - Returns (existing open alert, false, nil) when one already exists.
+	// - Returns (alert, true, nil) when the alert was stored.
+	// - Must be atomic so that concurrent evaluations fire only once.
+	OpenIfAbsent(
+		ctx context.Context,
+		alert StockAlert,
+	) (StockAlert, bool, error)
+
+	// ResolveOpen resolves the open alert of inventory/model.
+	// Returns ErrNotFound when no open alert exists.
+	ResolveOpen(
+		ctx context.Context,
+		inventoryID string,
+		modelID string,
+		available int,
+		now time.Time,
+	) (StockAlert, error)
+
+	// RecordNotification stores the mail notification result of an alert.
+	RecordNotification(
+		ctx context.Context,
+		alertID string,
+		notifiedEmails []string,
+		notificationError string,
+		now time.Time,
+	) error
+
+	// ListByInventoryID returns alerts of the inventory ordered by FiredAt desc.
+	// limit <= 0 means the implementation default.
+	ListByInventoryID(
+		ctx context.Context,
+		inventoryID string,
+		limit int,
+	) ([]StockAlert, error)
+
+	// ListOpenByCompanyID returns open alerts of the company ordered by FiredAt desc.
+	ListOpenByCompanyID(
+		ctx context.Context,
+		companyID string,
+	) ([]StockAlert, error)
+}
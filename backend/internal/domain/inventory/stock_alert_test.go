@@ -0,0 +1,218 @@
+// backend/internal/domain/inventory/stock_alert_test.go
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestNewStockAlertSetting(t *testing.T) {
+	tooMany := make([]string, 0, MaxStockAlertNotifyEmails+1)
+	for i := 0; i <= MaxStockAlertNotifyEmails; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("user%d@example.com", i))
+	}
+
+	tests := []struct {
+		name             string
+		defaultThreshold *int
+		modelThresholds  map[string]int
+		emails           []string
+		wantEmails       []string
+		wantEmailCount   int
+		wantThresholds   map[string]int
+		wantErr          error
+	}{
+		{
+			name:             "normalizes emails and model ids",
+			defaultThreshold: intPtr(5),
+			modelThresholds:  map[string]int{" model_1 ": 0},
+			emails:           []string{" B@example.com", "a@example.com", "b@example.com ", ""},
+			wantEmails:       []string{"a@example.com", "b@example.com"},
+			wantEmailCount:   2,
+			wantThresholds:   map[string]int{"model_1": 0},
+		},
+		{
+			// 重複を除いた後の件数で上限を判定する。
+			name:           "duplicates do not count toward the limit",
+			emails:         append(append([]string(nil), tooMany[:MaxStockAlertNotifyEmails]...), "USER0@example.com"),
+			wantEmailCount: MaxStockAlertNotifyEmails,
+			wantThresholds: map[string]int{},
+		},
+		{name: "too many emails", emails: tooMany, wantErr: ErrTooManyStockAlertNotifyEmail},
+		{name: "invalid email", emails: []string{"not an email"}, wantErr: ErrInvalidStockAlertNotifyEmail},
+		{name: "display name is not accepted", emails: []string{"Alert <a@example.com>"}, wantErr: ErrInvalidStockAlertNotifyEmail},
+		{name: "negative default threshold", defaultThreshold: intPtr(-1), wantErr: ErrInvalidStockAlertThreshold},
+		{name: "negative model threshold", modelThresholds: map[string]int{"model_1": -1}, wantErr: ErrInvalidStockAlertThreshold},
+		{name: "empty model id", modelThresholds: map[string]int{" ": 1}, wantErr: ErrInvalidModelID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewStockAlertSetting("inv_1", "company_1", true, tt.defaultThreshold, tt.modelThresholds, tt.emails, testNow, "member_1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewStockAlertSetting err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(s.NotifyEmails) != tt.wantEmailCount {
+				t.Errorf("len(NotifyEmails) = %d, want %d", len(s.NotifyEmails), tt.wantEmailCount)
+			}
+			if tt.wantEmails != nil && !reflect.DeepEqual(s.NotifyEmails, tt.wantEmails) {
+				t.Errorf("NotifyEmails = %v, want %v", s.NotifyEmails, tt.wantEmails)
+			}
+			if !reflect.DeepEqual(s.ModelThresholds, tt.wantThresholds) {
+				t.Errorf("ModelThresholds = %v, want %v", s.ModelThresholds, tt.wantThresholds)
+			}
+		})
+	}
+}
+
+func TestNewStockAlertSetting_Required(t *testing.T) {
+	if _, err := NewStockAlertSetting(" ", "company_1", true, nil, nil, nil, testNow, ""); !errors.Is(err, ErrInvalidMintID) {
+		t.Fatalf("missing inventoryId err = %v, want %v", err, ErrInvalidMintID)
+	}
+	if _, err := NewStockAlertSetting("inv_1", " ", true, nil, nil, nil, testNow, ""); !errors.Is(err, ErrInvalidStockAlertCompanyID) {
+		t.Fatalf("missing companyId err = %v, want %v", err, ErrInvalidStockAlertCompanyID)
+	}
+}
+
+func TestStockAlertSetting_ThresholdFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting StockAlertSetting
+		modelID string
+		want    int
+		wantOK  bool
+	}{
+		{
+			name:    "model threshold overrides default",
+			setting: StockAlertSetting{Enabled: true, DefaultThreshold: intPtr(5), ModelThresholds: map[string]int{"model_1": 2}},
+			modelID: "model_1",
+			want:    2,
+			wantOK:  true,
+		},
+		{
+			// 0 は「売り切れで発火」を意味する有効な閾値。
+			name:    "zero model threshold is kept",
+			setting: StockAlertSetting{Enabled: true, DefaultThreshold: intPtr(5), ModelThresholds: map[string]int{"model_1": 0}},
+			modelID: "model_1",
+			want:    0,
+			wantOK:  true,
+		},
+		{
+			name:    "default threshold",
+			setting: StockAlertSetting{Enabled: true, DefaultThreshold: intPtr(5), ModelThresholds: map[string]int{"model_1": 2}},
+			modelID: "model_2",
+			want:    5,
+			wantOK:  true,
+		},
+		{
+			name:    "no threshold",
+			setting: StockAlertSetting{Enabled: true, ModelThresholds: map[string]int{"model_1": 2}},
+			modelID: "model_2",
+			wantOK:  false,
+		},
+		{
+			name:    "disabled",
+			setting: StockAlertSetting{DefaultThreshold: intPtr(5), ModelThresholds: map[string]int{"model_1": 2}},
+			modelID: "model_1",
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.setting.ThresholdFor(tt.modelID)
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("ThresholdFor = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestModelStock_Available(t *testing.T) {
+	tests := []struct {
+		stock ModelStock
+		want  int
+	}{
+		{stock: ModelStock{Accumulation: 10, ReservedCount: 3}, want: 7},
+		{stock: ModelStock{Accumulation: 3, ReservedCount: 3}, want: 0},
+		{stock: ModelStock{Accumulation: 2, ReservedCount: 3}, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.stock.Available(); got != tt.want {
+			t.Errorf("Available(%+v) = %d, want %d", tt.stock, got, tt.want)
+		}
+	}
+}
+
+func TestNewStockAlert(t *testing.T) {
+	setting := StockAlertSetting{InventoryID: "inv_1", CompanyID: "company_1", Enabled: true}
+	stock := ModelStock{Accumulation: 5, ReservedCount: 2}
+
+	a, err := NewStockAlert(setting, "model_1", stock, 3, testNow)
+	if err != nil {
+		t.Fatalf("NewStockAlert: %v", err)
+	}
+
+	want := StockAlert{
+		ID:            fmt.Sprintf("inv_1__model_1__%d", testNow.UnixNano()),
+		InventoryID:   "inv_1",
+		ModelID:       "model_1",
+		CompanyID:     "company_1",
+		Status:        StockAlertStatusOpen,
+		Threshold:     3,
+		Available:     3,
+		Accumulation:  5,
+		ReservedCount: 2,
+		FiredAt:       testNow,
+	}
+	if !reflect.DeepEqual(a, want) {
+		t.Fatalf("NewStockAlert = %+v, want %+v", a, want)
+	}
+
+	a.Resolve(4, testNow.Add(time.Hour))
+	if a.Status != StockAlertStatusResolved || a.ResolvedAvailable == nil || *a.ResolvedAvailable != 4 {
+		t.Fatalf("Resolve: Status = %s, ResolvedAvailable = %v", a.Status, a.ResolvedAvailable)
+	}
+
+	// 解消済みのアラートは最初の解消時点を保持する。
+	a.Resolve(9, testNow.Add(2*time.Hour))
+	if !a.ResolvedAt.Equal(testNow.Add(time.Hour)) || *a.ResolvedAvailable != 4 {
+		t.Fatalf("Resolve again: ResolvedAt = %v, ResolvedAvailable = %d", a.ResolvedAt, *a.ResolvedAvailable)
+	}
+}
+
+func TestNewStockAlert_Errors(t *testing.T) {
+	setting := StockAlertSetting{InventoryID: "inv_1", CompanyID: "company_1", Enabled: true}
+
+	tests := []struct {
+		name      string
+		setting   StockAlertSetting
+		modelID   string
+		threshold int
+		now       time.Time
+		want      error
+	}{
+		{name: "missing model", setting: setting, threshold: 1, now: testNow, want: ErrInvalidStockAlert},
+		{name: "zero time", setting: setting, modelID: "model_1", threshold: 1, want: ErrInvalidStockAlert},
+		{name: "missing company", setting: StockAlertSetting{InventoryID: "inv_1"}, modelID: "model_1", threshold: 1, now: testNow, want: ErrInvalidStockAlertCompanyID},
+		{name: "negative threshold", setting: setting, modelID: "model_1", threshold: -1, now: testNow, want: ErrInvalidStockAlertThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewStockAlert(tt.setting, tt.modelID, ModelStock{}, tt.threshold, tt.now); !errors.Is(err, tt.want) {
+				t.Fatalf("NewStockAlert err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,360 @@
+// backend/internal/domain/invoice/entity.go
+package invoice
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Invoice は注文 1 件・発行事業者 1 者分の領収書（適格請求書）です。
+//
+// 1 つの注文に複数の会社の商品が含まれる場合は、会社ごとに 1 通ずつ発行します。
+// 金額はすべて税抜で保持し、消費税は税率ごとの対象額の合計に対して
+// 1 回だけ端数処理（切り捨て）します（適格請求書の端数処理ルール）。
+// 決済金額の計算と同じ規則なので、発行事業者が 1 者の注文では合計額が決済額と一致します。
+type Invoice struct {
+	// Number は領収書番号（{orderId}-{連番}）。
+	Number  string `json:"number"`
+	OrderID string `json:"orderId"`
+
+	Issuer Issuer `json:"issuer"`
+
+	// TransactionDate は取引年月日（注文日時）。
+	TransactionDate time.Time `json:"transactionDate"`
+	IssuedAt        time.Time `json:"issuedAt"`
+
+	Lines []Line `json:"lines"`
+
+	// ShippingAmount は値引き前の送料、ShippingDiscountAmount はクーポンによる送料値引き。
+	// 送料は標準税率の対象です。
+	ShippingAmount         int `json:"shippingAmount"`
+	ShippingDiscountAmount int `json:"shippingDiscountAmount,omitempty"`
+
+	// Rates は税率ごとの対象額と消費税額（標準税率 → 軽減税率の順）。
+	Rates []RateSummary `json:"rates"`
+
+	Subtotal  int `json:"subtotal"`
+	TaxAmount int `json:"taxAmount"`
+	Total     int `json:"total"`
+
+	// Refunds は返還インボイス（適格返還請求書）として記載する返金です。
+	// RefundedAmount はその税込合計で、Total からは差し引きません。
+	Refunds        []Refund `json:"refunds,omitempty"`
+	RefundedAmount int      `json:"refundedAmount,omitempty"`
+}
+
+// Issuer は領収書の発行事業者です。
+// RegistrationNumber が空の場合は適格請求書の要件を満たしません。
+type Issuer struct {
+	CompanyID          string `json:"companyId,omitempty"`
+	Name               string `json:"name"`
+	RegistrationNumber string `json:"registrationNumber,omitempty"`
+}
+
+// Line は領収書の明細 1 行です。
+type Line struct {
+	ItemIndex   int    `json:"itemIndex"`
+	Description string `json:"description"`
+
+	Qty       int `json:"qty"`
+	UnitPrice int `json:"unitPrice"`
+
+	// Amount は値引き前の小計（UnitPrice * Qty）、DiscountAmount はクーポン値引き。
+	Amount         int `json:"amount"`
+	DiscountAmount int `json:"discountAmount,omitempty"`
+
+	TaxRate int `json:"taxRate"`
+}
+
+// RateSummary は税率ごとの合計です。
+type RateSummary struct {
+	Rate          int `json:"rate"`
+	TaxableAmount int `json:"taxableAmount"`
+	TaxAmount     int `json:"taxAmount"`
+}
+
+// Refund は返金 1 件分の返還インボイスの記載事項です。
+// 税率ごとの金額は返金時に計算した税抜額・消費税額をそのまま集計します（再計算しない）。
+type Refund struct {
+	RefundID   string        `json:"refundId"`
+	RefundedAt time.Time     `json:"refundedAt"`
+	Rates      []RateSummary `json:"rates"`
+
+	// Amount は税込の返金額です。
+	Amount int `json:"amount"`
+}
+
+// RefundLine は返金の税率ごとの内訳 1 行です。
+type RefundLine struct {
+	TaxRate       int
+	TaxableAmount int
+	TaxAmount     int
+}
+
+type RefundInput struct {
+	RefundID   string
+	RefundedAt time.Time
+	Lines      []RefundLine
+}
+
+// ========================================
+// Policy
+// ========================================
+
+const (
+	TaxRateReduced  = 8
+	TaxRateStandard = 10
+)
+
+// ========================================
+// Errors
+// ========================================
+
+var (
+	ErrInvalidNumber  = errors.New("invoice: invalid number")
+	ErrInvalidOrderID = errors.New("invoice: invalid orderId")
+	ErrInvalidIssuer  = errors.New("invoice: invalid issuer")
+	ErrInvalidLines   = errors.New("invoice: invalid lines")
+	ErrInvalidTaxRate = errors.New("invoice: invalid tax rate")
+	ErrInvalidAmount  = errors.New("invoice: invalid amount")
+	ErrInvalidDate    = errors.New("invoice: invalid date")
+	ErrInvalidRefund  = errors.New("invoice: invalid refund")
+)
+
+// ========================================
+// Constructor
+// ========================================
+
+type BuildInput struct {
+	Number  string
+	OrderID string
+
+	Issuer Issuer
+
+	TransactionDate time.Time
+	IssuedAt        time.Time
+
+	Lines []Line
+
+	ShippingAmount         int
+	ShippingDiscountAmount int
+
+	Refunds []RefundInput
+}
+
+// Build は明細を税率ごとに集計して Invoice を生成します。
+func Build(in BuildInput) (Invoice, error) {
+	inv := Invoice{
+		Number:  strings.TrimSpace(in.Number),
+		OrderID: strings.TrimSpace(in.OrderID),
+
+		Issuer: Issuer{
+			CompanyID:          strings.TrimSpace(in.Issuer.CompanyID),
+			Name:               strings.TrimSpace(in.Issuer.Name),
+			RegistrationNumber: strings.TrimSpace(in.Issuer.RegistrationNumber),
+		},
+
+		TransactionDate: in.TransactionDate.UTC(),
+		IssuedAt:        in.IssuedAt.UTC(),
+
+		Lines: append([]Line(nil), in.Lines...),
+
+		ShippingAmount:         in.ShippingAmount,
+		ShippingDiscountAmount: in.ShippingDiscountAmount,
+	}
+
+	if err := inv.validateHeader(); err != nil {
+		return Invoice{}, err
+	}
+
+	taxable := map[int]int{}
+
+	for _, line := range inv.Lines {
+		if err := line.validate(); err != nil {
+			return Invoice{}, err
+		}
+		taxable[line.TaxRate] += line.NetAmount()
+	}
+
+	if in.ShippingAmount < 0 ||
+		in.ShippingDiscountAmount < 0 ||
+		in.ShippingDiscountAmount > in.ShippingAmount {
+		return Invoice{}, ErrInvalidAmount
+	}
+	if shipping := inv.NetShippingAmount(); shipping > 0 {
+		taxable[TaxRateStandard] += shipping
+	}
+
+	rates := make([]int, 0, len(taxable))
+	for rate := range taxable {
+		rates = append(rates, rate)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(rates)))
+
+	for _, rate := range rates {
+		summary := RateSummary{
+			Rate:          rate,
+			TaxableAmount: taxable[rate],
+			TaxAmount:     taxable[rate] * rate / 100,
+		}
+
+		inv.Rates = append(inv.Rates, summary)
+		inv.Subtotal += summary.TaxableAmount
+		inv.TaxAmount += summary.TaxAmount
+	}
+
+	inv.Total = inv.Subtotal + inv.TaxAmount
+
+	for _, refundInput := range in.Refunds {
+		refund, err := buildRefund(refundInput)
+		if err != nil {
+			return Invoice{}, err
+		}
+		if refund.Amount == 0 {
+			continue
+		}
+
+		inv.Refunds = append(inv.Refunds, refund)
+		inv.RefundedAmount += refund.Amount
+	}
+
+	if inv.RefundedAmount > inv.Total {
+		return Invoice{}, ErrInvalidAmount
+	}
+
+	return inv, nil
+}
+
+func buildRefund(in RefundInput) (Refund, error) {
+	refund := Refund{
+		RefundID:   strings.TrimSpace(in.RefundID),
+		RefundedAt: in.RefundedAt.UTC(),
+	}
+
+	if refund.RefundID == "" {
+		return Refund{}, ErrInvalidRefund
+	}
+	if refund.RefundedAt.IsZero() {
+		return Refund{}, ErrInvalidDate
+	}
+
+	byRate := map[int]*RateSummary{}
+	for _, line := range in.Lines {
+		switch line.TaxRate {
+		case TaxRateReduced, TaxRateStandard:
+		default:
+			return Refund{}, ErrInvalidTaxRate
+		}
+		if line.TaxableAmount < 0 || line.TaxAmount < 0 {
+			return Refund{}, ErrInvalidAmount
+		}
+
+		summary, ok := byRate[line.TaxRate]
+		if !ok {
+			summary = &RateSummary{Rate: line.TaxRate}
+			byRate[line.TaxRate] = summary
+		}
+		summary.TaxableAmount += line.TaxableAmount
+		summary.TaxAmount += line.TaxAmount
+	}
+
+	rates := make([]int, 0, len(byRate))
+	for rate := range byRate {
+		rates = append(rates, rate)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(rates)))
+
+	for _, rate := range rates {
+		summary := *byRate[rate]
+		refund.Rates = append(refund.Rates, summary)
+		refund.Amount += summary.TaxableAmount + summary.TaxAmount
+	}
+
+	return refund, nil
+}
+
+// ========================================
+// Behavior
+// ========================================
+
+// Qualified は適格請求書（登録番号あり）かを返します。
+func (i Issuer) Qualified() bool {
+	return i.RegistrationNumber != ""
+}
+
+// NetAmount は値引き後の小計（税抜）です。
+func (l Line) NetAmount() int {
+	return l.Amount - l.DiscountAmount
+}
+
+// Reduced は軽減税率の対象かを返します。
+func (l Line) Reduced() bool {
+	return l.TaxRate == TaxRateReduced
+}
+
+// NetShippingAmount は値引き後の送料（税抜）です。
+func (i Invoice) NetShippingAmount() int {
+	return i.ShippingAmount - i.ShippingDiscountAmount
+}
+
+// NetTotal は返金を差し引いた合計（税込）です。
+func (i Invoice) NetTotal() int {
+	return i.Total - i.RefundedAmount
+}
+
+// HasReducedRate は軽減税率の明細を含むかを返します。
+func (i Invoice) HasReducedRate() bool {
+	for _, line := range i.Lines {
+		if line.Reduced() {
+			return true
+		}
+	}
+	return false
+}
+
+// ========================================
+// Validation
+// ========================================
+
+func (i Invoice) validateHeader() error {
+	if i.Number == "" {
+		return ErrInvalidNumber
+	}
+	if i.OrderID == "" {
+		return ErrInvalidOrderID
+	}
+	if i.Issuer.Name == "" {
+		return ErrInvalidIssuer
+	}
+	if i.TransactionDate.IsZero() || i.IssuedAt.IsZero() {
+		return ErrInvalidDate
+	}
+	if len(i.Lines) == 0 {
+		return ErrInvalidLines
+	}
+	return nil
+}
+
+func (l Line) validate() error {
+	if l.ItemIndex < 0 || strings.TrimSpace(l.Description) == "" {
+		return ErrInvalidLines
+	}
+
+	switch l.TaxRate {
+	case TaxRateReduced, TaxRateStandard:
+	default:
+		return ErrInvalidTaxRate
+	}
+
+	if l.Qty <= 0 ||
+		l.UnitPrice < 0 ||
+		l.Amount != l.UnitPrice*l.Qty ||
+		l.DiscountAmount < 0 ||
+		l.DiscountAmount > l.Amount {
+		return ErrInvalidAmount
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+// backend/internal/domain/invoice/entity_test.go
+package invoice
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testBuildInput() BuildInput {
+	return BuildInput{
+		Number:          "order_1-1",
+		OrderID:         "order_1",
+		Issuer:          Issuer{CompanyID: "company_1", Name: "株式会社テスト", RegistrationNumber: "T1234567890123"},
+		TransactionDate: testNow,
+		IssuedAt:        testNow,
+	}
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(in *BuildInput)
+		wantRates []RateSummary
+		wantTotal int
+		wantNet   int
+	}{
+		{
+			// 決済額 5814 と一致する（refund の calculator_test と同じ注文）。
+			name: "standard and reduced rate with shipping",
+			modify: func(in *BuildInput) {
+				in.Lines = []Line{
+					{ItemIndex: 0, Description: "T-shirt", Qty: 3, UnitPrice: 1000, Amount: 3000, TaxRate: TaxRateStandard},
+					{ItemIndex: 1, Description: "Coffee", Qty: 1, UnitPrice: 800, Amount: 800, TaxRate: TaxRateReduced},
+				}
+				in.ShippingAmount = 1500
+			},
+			wantRates: []RateSummary{
+				{Rate: TaxRateStandard, TaxableAmount: 4500, TaxAmount: 450},
+				{Rate: TaxRateReduced, TaxableAmount: 800, TaxAmount: 64},
+			},
+			wantTotal: 5814,
+			wantNet:   5814,
+		},
+		{
+			// 明細ごとに切り捨てると 26 * 3 = 78 だが、税率ごとの合計 999 に対して 1 回だけ切り捨てる。
+			name: "tax is truncated once per rate",
+			modify: func(in *BuildInput) {
+				in.Lines = []Line{
+					{ItemIndex: 0, Description: "A", Qty: 1, UnitPrice: 333, Amount: 333, TaxRate: TaxRateReduced},
+					{ItemIndex: 1, Description: "B", Qty: 1, UnitPrice: 333, Amount: 333, TaxRate: TaxRateReduced},
+					{ItemIndex: 2, Description: "C", Qty: 1, UnitPrice: 333, Amount: 333, TaxRate: TaxRateReduced},
+				}
+			},
+			wantRates: []RateSummary{
+				{Rate: TaxRateReduced, TaxableAmount: 999, TaxAmount: 79},
+			},
+			wantTotal: 1078,
+			wantNet:   1078,
+		},
+		{
+			name: "item and shipping discounts",
+			modify: func(in *BuildInput) {
+				in.Lines = []Line{
+					{ItemIndex: 0, Description: "T-shirt", Qty: 3, UnitPrice: 1000, Amount: 3000, DiscountAmount: 750, TaxRate: TaxRateStandard},
+				}
+				in.ShippingAmount = 500
+				in.ShippingDiscountAmount = 500
+			},
+			wantRates: []RateSummary{
+				{Rate: TaxRateStandard, TaxableAmount: 2250, TaxAmount: 225},
+			},
+			wantTotal: 2475,
+			wantNet:   2475,
+		},
+		{
+			name: "shipping is taxed at the standard rate",
+			modify: func(in *BuildInput) {
+				in.Lines = []Line{
+					{ItemIndex: 0, Description: "Coffee", Qty: 1, UnitPrice: 1000, Amount: 1000, TaxRate: TaxRateReduced},
+				}
+				in.ShippingAmount = 600
+			},
+			wantRates: []RateSummary{
+				{Rate: TaxRateStandard, TaxableAmount: 600, TaxAmount: 60},
+				{Rate: TaxRateReduced, TaxableAmount: 1000, TaxAmount: 80},
+			},
+			wantTotal: 1740,
+			wantNet:   1740,
+		},
+		{
+			name: "refunds are listed but not subtracted from total",
+			modify: func(in *BuildInput) {
+				in.Lines = []Line{
+					{ItemIndex: 0, Description: "T-shirt", Qty: 3, UnitPrice: 1000, Amount: 3000, TaxRate: TaxRateStandard},
+					{ItemIndex: 1, Description: "Coffee", Qty: 1, UnitPrice: 800, Amount: 800, TaxRate: TaxRateReduced},
+				}
+				in.ShippingAmount = 1500
+				in.Refunds = []RefundInput{
+					{
+						RefundID:   "refund_1",
+						RefundedAt: testNow,
+						Lines: []RefundLine{
+							{TaxRate: TaxRateStandard, TaxableAmount: 1000, TaxAmount: 100},
+							{TaxRate: TaxRateStandard, TaxableAmount: 500, TaxAmount: 50},
+						},
+					},
+					{
+						RefundID:   "refund_2",
+						RefundedAt: testNow,
+						Lines: []RefundLine{
+							{TaxRate: TaxRateReduced, TaxableAmount: 800, TaxAmount: 64},
+						},
+					},
+					{
+						// 金額 0 の返金は記載しない。
+						RefundID:   "refund_3",
+						RefundedAt: testNow,
+					},
+				}
+			},
+			wantRates: []RateSummary{
+				{Rate: TaxRateStandard, TaxableAmount: 4500, TaxAmount: 450},
+				{Rate: TaxRateReduced, TaxableAmount: 800, TaxAmount: 64},
+			},
+			wantTotal: 5814,
+			wantNet:   5814 - 1650 - 864,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := testBuildInput()
+			tt.modify(&in)
+
+			got, err := Build(in)
+			if err != nil {
+				t.Fatalf("Build: %v", err)
+			}
+			if !reflect.DeepEqual(got.Rates, tt.wantRates) {
+				t.Errorf("Rates = %+v, want %+v", got.Rates, tt.wantRates)
+			}
+			if got.Subtotal+got.TaxAmount != got.Total {
+				t.Errorf("Subtotal %d + TaxAmount %d != Total %d", got.Subtotal, got.TaxAmount, got.Total)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", got.Total, tt.wantTotal)
+			}
+			if got.NetTotal() != tt.wantNet {
+				t.Errorf("NetTotal = %d, want %d", got.NetTotal(), tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestBuild_Refund(t *testing.T) {
+	in := testBuildInput()
+	in.Lines = []Line{
+		{ItemIndex: 0, Description: "T-shirt", Qty: 3, UnitPrice: 1000, Amount: 3000, TaxRate: TaxRateStandard},
+		{ItemIndex: 1, Description: "Coffee", Qty: 1, UnitPrice: 800, Amount: 800, TaxRate: TaxRateReduced},
+	}
+	in.Refunds = []RefundInput{
+		{
+			RefundID:   " refund_1 ",
+			RefundedAt: testNow,
+			Lines: []RefundLine{
+				{TaxRate: TaxRateReduced, TaxableAmount: 800, TaxAmount: 64},
+				{TaxRate: TaxRateStandard, TaxableAmount: 1000, TaxAmount: 100},
+			},
+		},
+	}
+
+	got, err := Build(in)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	want := []Refund{
+		{
+			RefundID:   "refund_1",
+			RefundedAt: testNow,
+			Rates: []RateSummary{
+				{Rate: TaxRateStandard, TaxableAmount: 1000, TaxAmount: 100},
+				{Rate: TaxRateReduced, TaxableAmount: 800, TaxAmount: 64},
+			},
+			Amount: 1964,
+		},
+	}
+
+	if !reflect.DeepEqual(got.Refunds, want) {
+		t.Fatalf("Refunds = %+v, want %+v", got.Refunds, want)
+	}
+	if got.RefundedAmount != 1964 {
+		t.Fatalf("RefundedAmount = %d, want 1964", got.RefundedAmount)
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	line := Line{ItemIndex: 0, Description: "T-shirt", Qty: 1, UnitPrice: 1000, Amount: 1000, TaxRate: TaxRateStandard}
+
+	tests := []struct {
+		name   string
+		modify func(in *BuildInput)
+		want   error
+	}{
+		{name: "missing number", modify: func(in *BuildInput) { in.Number = " " }, want: ErrInvalidNumber},
+		{name: "missing issuer name", modify: func(in *BuildInput) { in.Issuer.Name = "" }, want: ErrInvalidIssuer},
+		{name: "zero issuedAt", modify: func(in *BuildInput) { in.IssuedAt = time.Time{} }, want: ErrInvalidDate},
+		{name: "no lines", modify: func(in *BuildInput) { in.Lines = nil }, want: ErrInvalidLines},
+		{
+			name: "unsupported tax rate",
+			modify: func(in *BuildInput) {
+				l := line
+				l.TaxRate = 5
+				in.Lines = []Line{l}
+			},
+			want: ErrInvalidTaxRate,
+		},
+		{
+			name: "amount does not match unit price",
+			modify: func(in *BuildInput) {
+				l := line
+				l.Amount = 999
+				in.Lines = []Line{l}
+			},
+			want: ErrInvalidAmount,
+		},
+		{
+			name: "discount exceeds amount",
+			modify: func(in *BuildInput) {
+				l := line
+				l.DiscountAmount = 1001
+				in.Lines = []Line{l}
+			},
+			want: ErrInvalidAmount,
+		},
+		{
+			name: "shipping discount exceeds shipping",
+			modify: func(in *BuildInput) {
+				in.ShippingAmount = 500
+				in.ShippingDiscountAmount = 501
+			},
+			want: ErrInvalidAmount,
+		},
+		{
+			name: "refund without id",
+			modify: func(in *BuildInput) {
+				in.Refunds = []RefundInput{{RefundedAt: testNow}}
+			},
+			want: ErrInvalidRefund,
+		},
+		{
+			name: "refund without date",
+			modify: func(in *BuildInput) {
+				in.Refunds = []RefundInput{{RefundID: "refund_1"}}
+			},
+			want: ErrInvalidDate,
+		},
+		{
+			name: "refund with unsupported tax rate",
+			modify: func(in *BuildInput) {
+				in.Refunds = []RefundInput{{
+					RefundID:   "refund_1",
+					RefundedAt: testNow,
+					Lines:      []RefundLine{{TaxRate: 5, TaxableAmount: 100, TaxAmount: 5}},
+				}}
+			},
+			want: ErrInvalidTaxRate,
+		},
+		{
+			name: "refunds exceed total",
+			modify: func(in *BuildInput) {
+				in.Refunds = []RefundInput{{
+					RefundID:   "refund_1",
+					RefundedAt: testNow,
+					Lines:      []RefundLine{{TaxRate: TaxRateStandard, TaxableAmount: 1000, TaxAmount: 101}},
+				}}
+			},
+			want: ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := testBuildInput()
+			in.Lines = []Line{line}
+			tt.modify(&in)
+
+			if _, err := Build(in); !errors.Is(err, tt.want) {
+				t.Fatalf("Build err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -116,6 +116,10 @@ type List struct {
 	Description string         `json:"description,omitempty"`
 	Prices      []ListPriceRow `json:"prices,omitempty"`
 
+	// Token gating rules. Empty means the list is open to everyone.
+	// See gating.go.
+	Gating []GatingRule `json:"gating,omitempty"`
+
 	CreatedBy string    `json:"createdBy,omitempty"`
 	CreatedAt time.Time `json:"createdAt,omitempty"`
 
@@ -332,6 +336,10 @@ func (l List) ValidateForCreate() error {
 		return err
 	}
 
+	if err := validateGatingRules(l.Gating); err != nil {
+		return err
+	}
+
 	if l.CreatedBy == "" {
 		return ErrInvalidCreatedBy
 	}
@@ -385,6 +393,10 @@ func (l List) ValidateForPersist() error {
 		return err
 	}
 
+	if err := validateGatingRules(l.Gating); err != nil {
+		return err
+	}
+
 	if l.CreatedBy == "" {
 		return ErrInvalidCreatedBy
 	}
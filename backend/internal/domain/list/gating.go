@@ -0,0 +1,202 @@
+// backend/internal/domain/list/gating.go
+package list
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ========================================
+// Token gating (holder-only lists)
+// ========================================
+
+// GateTargetType は gating rule が保有を要求する対象の種類です。
+type GateTargetType string
+
+const (
+	// GateTargetTokenBlueprint は tokenBlueprint から発行された token の保有を要求します。
+	GateTargetTokenBlueprint GateTargetType = "tokenBlueprint"
+	// GateTargetProductBlueprint は productBlueprint に紐づく product の token の保有を要求します。
+	GateTargetProductBlueprint GateTargetType = "productBlueprint"
+)
+
+func IsValidGateTargetType(t GateTargetType) bool {
+	switch t {
+	case GateTargetTokenBlueprint, GateTargetProductBlueprint:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	ErrInvalidGating = errors.New("list: invalid gating")
+	ErrHolderOnly    = errors.New("list: holder-only list: required token is not held")
+)
+
+// Policy
+var (
+	MaxGatingRules    = 10
+	MaxGatingMinCount = 1000
+)
+
+// GatingRule は list の購入・閲覧に必要な保有条件です。
+//
+// Gating に複数の rule がある場合はいずれか 1 つを満たせば holder とみなします（any-of）。
+// SnapshotAt が指定されている場合、その時点までに取得した token のみを数えます。
+type GatingRule struct {
+	TargetType GateTargetType `json:"targetType"`
+	TargetID   string         `json:"targetId"`
+	MinCount   int            `json:"minCount"`
+	SnapshotAt *time.Time     `json:"snapshotAt,omitempty"`
+}
+
+// IsHolderOnly は gating rule が設定されているかどうかを返します。
+func (l List) IsHolderOnly() bool {
+	return len(l.Gating) > 0
+}
+
+// ReplaceGating は gating rule を置き換えます。空の場合は holder-only を解除します。
+func (l *List) ReplaceGating(rules []GatingRule, now time.Time) error {
+	if l == nil {
+		return nil
+	}
+
+	normalized := NormalizeGatingRules(rules)
+	if err := validateGatingRules(normalized); err != nil {
+		return err
+	}
+
+	l.Gating = normalized
+	l.touch(now)
+	return nil
+}
+
+// NormalizeGatingRules は前後空白の除去・MinCount の既定値（1）・SnapshotAt の UTC 化を行います。
+func NormalizeGatingRules(rules []GatingRule) []GatingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	out := make([]GatingRule, 0, len(rules))
+	for _, r := range rules {
+		r.TargetType = GateTargetType(strings.TrimSpace(string(r.TargetType)))
+		r.TargetID = strings.TrimSpace(r.TargetID)
+		if r.MinCount == 0 {
+			r.MinCount = 1
+		}
+		if r.SnapshotAt != nil {
+			if r.SnapshotAt.IsZero() {
+				r.SnapshotAt = nil
+			} else {
+				t := r.SnapshotAt.UTC()
+				r.SnapshotAt = &t
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func validateGatingRules(rules []GatingRule) error {
+	if len(rules) > MaxGatingRules {
+		return ErrInvalidGating
+	}
+
+	seen := make(map[string]struct{}, len(rules))
+	for _, r := range rules {
+		if !IsValidGateTargetType(r.TargetType) || r.TargetID == "" {
+			return ErrInvalidGating
+		}
+		if r.MinCount < 1 || r.MinCount > MaxGatingMinCount {
+			return ErrInvalidGating
+		}
+		if r.SnapshotAt != nil && r.SnapshotAt.IsZero() {
+			return ErrInvalidGating
+		}
+
+		key := string(r.TargetType) + "\x00" + r.TargetID
+		if _, ok := seen[key]; ok {
+			return ErrInvalidGating
+		}
+		seen[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// ========================================
+// Evaluation
+// ========================================
+
+// Holding は avatar が保有している token 1 つ分の情報です。
+// AcquiredAt が不明な token は SnapshotAt 付きの rule では数えません。
+type Holding struct {
+	TokenBlueprintID   string
+	ProductBlueprintID string
+	AcquiredAt         *time.Time
+}
+
+// GatingRuleResult は rule ごとの判定結果です。
+type GatingRuleResult struct {
+	Rule      GatingRule `json:"rule"`
+	Held      int        `json:"held"`
+	Satisfied bool       `json:"satisfied"`
+}
+
+// GatingResult は list 全体の判定結果です。
+type GatingResult struct {
+	Eligible bool               `json:"eligible"`
+	Rules    []GatingRuleResult `json:"rules"`
+}
+
+// EvaluateGating は holdings が rules を満たすかどうかを判定します。
+// rules が空の場合は常に Eligible です。
+func EvaluateGating(rules []GatingRule, holdings []Holding) GatingResult {
+	out := GatingResult{
+		Eligible: len(rules) == 0,
+		Rules:    make([]GatingRuleResult, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		held := 0
+		for _, h := range holdings {
+			if !rule.matches(h) {
+				continue
+			}
+			if rule.SnapshotAt != nil && (h.AcquiredAt == nil || h.AcquiredAt.After(*rule.SnapshotAt)) {
+				continue
+			}
+			held++
+		}
+
+		minCount := rule.MinCount
+		if minCount < 1 {
+			minCount = 1
+		}
+		satisfied := held >= minCount
+		if satisfied {
+			out.Eligible = true
+		}
+
+		out.Rules = append(out.Rules, GatingRuleResult{
+			Rule:      rule,
+			Held:      held,
+			Satisfied: satisfied,
+		})
+	}
+
+	return out
+}
+
+func (r GatingRule) matches(h Holding) bool {
+	switch r.TargetType {
+	case GateTargetTokenBlueprint:
+		return h.TokenBlueprintID != "" && h.TokenBlueprintID == r.TargetID
+	case GateTargetProductBlueprint:
+		return h.ProductBlueprintID != "" && h.ProductBlueprintID == r.TargetID
+	default:
+		return false
+	}
+}
@@ -0,0 +1,209 @@
+// backend/internal/domain/list/gating_test.go
+package list
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNormalizeGatingRules(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	snapshot := time.Date(2026, 10, 1, 18, 0, 0, 0, jst)
+	wantSnapshot := testNow
+
+	got := NormalizeGatingRules([]GatingRule{
+		{TargetType: " tokenBlueprint ", TargetID: " tb_1 "},
+		{TargetType: GateTargetProductBlueprint, TargetID: "pb_1", MinCount: 3, SnapshotAt: &snapshot},
+		{TargetType: GateTargetProductBlueprint, TargetID: "pb_2", SnapshotAt: &time.Time{}},
+	})
+	want := []GatingRule{
+		{TargetType: GateTargetTokenBlueprint, TargetID: "tb_1", MinCount: 1},
+		{TargetType: GateTargetProductBlueprint, TargetID: "pb_1", MinCount: 3, SnapshotAt: &wantSnapshot},
+		{TargetType: GateTargetProductBlueprint, TargetID: "pb_2", MinCount: 1},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NormalizeGatingRules = %+v, want %+v", got, want)
+	}
+	if NormalizeGatingRules(nil) != nil {
+		t.Fatalf("NormalizeGatingRules(nil) != nil")
+	}
+}
+
+func TestList_ReplaceGating(t *testing.T) {
+	rule := func(id string) GatingRule {
+		return GatingRule{TargetType: GateTargetTokenBlueprint, TargetID: id}
+	}
+
+	tooMany := make([]GatingRule, 0, MaxGatingRules+1)
+	for i := 0; i <= MaxGatingRules; i++ {
+		tooMany = append(tooMany, rule(string(rune('a'+i))))
+	}
+
+	tests := []struct {
+		name           string
+		rules          []GatingRule
+		wantHolderOnly bool
+		want           error
+	}{
+		{name: "single rule", rules: []GatingRule{rule("tb_1")}, wantHolderOnly: true},
+		{name: "empty clears gating", rules: nil, wantHolderOnly: false},
+		{
+			// 同じ tokenBlueprint / productBlueprint の ID は別の対象として扱う。
+			name: "same id with different target types",
+			rules: []GatingRule{
+				{TargetType: GateTargetTokenBlueprint, TargetID: "x"},
+				{TargetType: GateTargetProductBlueprint, TargetID: "x"},
+			},
+			wantHolderOnly: true,
+		},
+		{name: "max rules", rules: tooMany[:MaxGatingRules], wantHolderOnly: true},
+		{name: "too many rules", rules: tooMany, want: ErrInvalidGating},
+		{name: "duplicate rule", rules: []GatingRule{rule("tb_1"), rule(" tb_1 ")}, want: ErrInvalidGating},
+		{name: "unknown target type", rules: []GatingRule{{TargetType: "brand", TargetID: "brand_1"}}, want: ErrInvalidGating},
+		{name: "missing target id", rules: []GatingRule{rule(" ")}, want: ErrInvalidGating},
+		{name: "negative min count", rules: []GatingRule{{TargetType: GateTargetTokenBlueprint, TargetID: "tb_1", MinCount: -1}}, want: ErrInvalidGating},
+		{
+			name:  "min count over limit",
+			rules: []GatingRule{{TargetType: GateTargetTokenBlueprint, TargetID: "tb_1", MinCount: MaxGatingMinCount + 1}},
+			want:  ErrInvalidGating,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := List{Gating: []GatingRule{{TargetType: GateTargetTokenBlueprint, TargetID: "existing", MinCount: 1}}}
+
+			err := l.ReplaceGating(tt.rules, testNow)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ReplaceGating err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				// 失敗時は既存の rule を保持する。
+				if len(l.Gating) != 1 || l.Gating[0].TargetID != "existing" {
+					t.Fatalf("Gating = %+v, want existing rule kept", l.Gating)
+				}
+				return
+			}
+			if l.IsHolderOnly() != tt.wantHolderOnly {
+				t.Fatalf("IsHolderOnly = %v, want %v", l.IsHolderOnly(), tt.wantHolderOnly)
+			}
+		})
+	}
+}
+
+func TestEvaluateGating(t *testing.T) {
+	snapshot := testNow
+	before := testNow.Add(-time.Hour)
+	after := testNow.Add(time.Hour)
+
+	tokenRule := GatingRule{TargetType: GateTargetTokenBlueprint, TargetID: "tb_1", MinCount: 1}
+	productRule := GatingRule{TargetType: GateTargetProductBlueprint, TargetID: "pb_1", MinCount: 2}
+	snapshotRule := GatingRule{TargetType: GateTargetTokenBlueprint, TargetID: "tb_1", MinCount: 1, SnapshotAt: &snapshot}
+
+	tests := []struct {
+		name         string
+		rules        []GatingRule
+		holdings     []Holding
+		wantEligible bool
+		wantHeld     []int
+	}{
+		{name: "no rules", holdings: nil, wantEligible: true, wantHeld: []int{}},
+		{name: "no holdings", rules: []GatingRule{tokenRule}, wantEligible: false, wantHeld: []int{0}},
+		{
+			name:         "token held",
+			rules:        []GatingRule{tokenRule},
+			holdings:     []Holding{{TokenBlueprintID: "tb_1"}, {TokenBlueprintID: "tb_2"}},
+			wantEligible: true,
+			wantHeld:     []int{1},
+		},
+		{
+			name:         "below min count",
+			rules:        []GatingRule{productRule},
+			holdings:     []Holding{{ProductBlueprintID: "pb_1"}, {TokenBlueprintID: "pb_1"}},
+			wantEligible: false,
+			wantHeld:     []int{1},
+		},
+		{
+			name:         "min count met",
+			rules:        []GatingRule{productRule},
+			holdings:     []Holding{{ProductBlueprintID: "pb_1"}, {ProductBlueprintID: "pb_1"}},
+			wantEligible: true,
+			wantHeld:     []int{2},
+		},
+		{
+			// いずれか 1 つの rule を満たせばよい。
+			name:         "any of rules",
+			rules:        []GatingRule{productRule, tokenRule},
+			holdings:     []Holding{{TokenBlueprintID: "tb_1", ProductBlueprintID: "pb_1"}},
+			wantEligible: true,
+			wantHeld:     []int{1, 1},
+		},
+		{
+			name:         "acquired at snapshot",
+			rules:        []GatingRule{snapshotRule},
+			holdings:     []Holding{{TokenBlueprintID: "tb_1", AcquiredAt: &snapshot}},
+			wantEligible: true,
+			wantHeld:     []int{1},
+		},
+		{
+			name:  "acquired after snapshot or unknown",
+			rules: []GatingRule{snapshotRule},
+			holdings: []Holding{
+				{TokenBlueprintID: "tb_1", AcquiredAt: &after},
+				{TokenBlueprintID: "tb_1"},
+			},
+			wantEligible: false,
+			wantHeld:     []int{0},
+		},
+		{
+			name:  "acquired before snapshot",
+			rules: []GatingRule{snapshotRule},
+			holdings: []Holding{
+				{TokenBlueprintID: "tb_1", AcquiredAt: &after},
+				{TokenBlueprintID: "tb_1", AcquiredAt: &before},
+			},
+			wantEligible: true,
+			wantHeld:     []int{1},
+		},
+		{
+			// 正規化前の MinCount 0 は 1 とみなす。
+			name:         "zero min count",
+			rules:        []GatingRule{{TargetType: GateTargetTokenBlueprint, TargetID: "tb_1"}},
+			holdings:     nil,
+			wantEligible: false,
+			wantHeld:     []int{0},
+		},
+		{
+			name:         "empty ids never match",
+			rules:        []GatingRule{{TargetType: GateTargetProductBlueprint, TargetID: "", MinCount: 1}},
+			holdings:     []Holding{{TokenBlueprintID: "tb_1"}},
+			wantEligible: false,
+			wantHeld:     []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateGating(tt.rules, tt.holdings)
+			if got.Eligible != tt.wantEligible {
+				t.Fatalf("Eligible = %v, want %v", got.Eligible, tt.wantEligible)
+			}
+
+			held := make([]int, 0, len(got.Rules))
+			for i, r := range got.Rules {
+				held = append(held, r.Held)
+				if r.Satisfied != (r.Held >= max(tt.rules[i].MinCount, 1)) {
+					t.Errorf("Rules[%d].Satisfied = %v with Held %d", i, r.Satisfied, r.Held)
+				}
+			}
+			if !reflect.DeepEqual(held, tt.wantHeld) {
+				t.Fatalf("Held = %v, want %v", held, tt.wantHeld)
+			}
+		})
+	}
+}
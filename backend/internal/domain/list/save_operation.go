@@ -882,6 +882,9 @@ func validateSaveOperationPayload(listID string, payload SaveOperationPayload) e
 	if targetListID != "" && targetListID != listID {
 		return fmt.Errorf("%w: target list id %q does not match operation list id %q", ErrInvalidSaveOperation, targetListID, listID)
 	}
+	if err := validateGatingRules(payload.TargetList.Gating); err != nil {
+		return err
+	}
 	if payload.PreviousList != nil {
 		previousListID := strings.TrimSpace(payload.PreviousList.ID)
 		if previousListID != listID {
@@ -1106,6 +1109,13 @@ func cloneSaveOperationPayload(source SaveOperationPayload) SaveOperationPayload
 func cloneSaveOperationList(source List) List {
 	target := source
 	target.Prices = append([]ListPriceRow(nil), source.Prices...)
+	target.Gating = append([]GatingRule(nil), source.Gating...)
+	for index, rule := range target.Gating {
+		if rule.SnapshotAt != nil {
+			snapshotAt := *rule.SnapshotAt
+			target.Gating[index].SnapshotAt = &snapshotAt
+		}
+	}
 	if source.UpdatedAt != nil {
 		updatedAt := *source.UpdatedAt
 		target.UpdatedAt = &updatedAt
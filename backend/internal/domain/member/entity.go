@@ -40,6 +40,7 @@ type Member struct {
 	LastNameKana   string   `json:"lastNameKana,omitempty" firestore:"lastNameKana"`
 	Email          string   `json:"email,omitempty" firestore:"email"`
 	Permissions    []string `json:"permissions" firestore:"permissions"`
+	Roles          []string `json:"roles,omitempty" firestore:"roles"`
 	AssignedBrands []string `json:"assignedBrands,omitempty" firestore:"assignedBrands"`
 
 	CompanyID string `json:"companyId,omitempty" firestore:"companyId"`
@@ -122,6 +123,12 @@ func WithPermissions(permissions []string) func(*Member) {
 	}
 }
 
+func WithRoles(roleIDs []string) func(*Member) {
+	return func(m *Member) {
+		m.Roles = append([]string(nil), roleIDs...)
+	}
+}
+
 func WithAssignedBrands(brands []string) func(*Member) {
 	return func(m *Member) {
 		m.AssignedBrands = append([]string(nil), brands...)
@@ -296,6 +303,7 @@ type MemberPatch struct {
 	LastNameKana   *string
 	Email          *string
 	Permissions    *[]string
+	Roles          *[]string
 	AssignedBrands *[]string
 	CompanyID      *string
 	Status         *string
@@ -351,6 +359,37 @@ func (m Member) ValidatePermissions(catalog []permdom.Permission) error {
 	return nil
 }
 
+// EffectivePermissions は Member.Permissions と割当ロールの権限を合算して返す。
+func (m Member) EffectivePermissions(roles []permdom.Role) []string {
+	names := append([]string(nil), m.Permissions...)
+	names = append(names, permdom.ResolveRolePermissions(m.Roles, roles)...)
+
+	seen := make(map[string]struct{}, len(names))
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		if _, dup := seen[n]; dup {
+			continue
+		}
+		seen[n] = struct{}{}
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// HasEffectivePermission は割当ロールも含めて権限の有無を判定する。
+func (m Member) HasEffectivePermission(name string, roles []permdom.Role) bool {
+	if m.HasPermission(name) {
+		return true
+	}
+	for _, n := range permdom.ResolveRolePermissions(m.Roles, roles) {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m Member) HasPermission(name string) bool {
 	for _, n := range m.Permissions {
 		if strings.EqualFold(n, name) {
@@ -0,0 +1,65 @@
+// backend/internal/domain/member/entity_test.go
+package member
+
+import (
+	"reflect"
+	"testing"
+
+	permdom "narratives/internal/domain/permission"
+)
+
+func TestMember_EffectivePermissions(t *testing.T) {
+	roles := []permdom.Role{
+		{ID: "role_1", Permissions: []string{"order.view", permdom.NameOrderDispatch}},
+		{ID: "role_2", Permissions: []string{permdom.NameInventoryUpdate}},
+	}
+
+	tests := []struct {
+		name        string
+		member      Member
+		want        []string
+		check       string
+		wantAllowed bool
+	}{
+		{
+			name:        "direct permissions only",
+			member:      Member{Permissions: []string{"order.view"}},
+			want:        []string{"order.view"},
+			check:       permdom.NameOrderDispatch,
+			wantAllowed: false,
+		},
+		{
+			name:        "role grants write permission",
+			member:      Member{Permissions: []string{"order.view"}, Roles: []string{"role_1"}},
+			want:        []string{permdom.NameOrderDispatch, "order.view"},
+			check:       permdom.NameOrderDispatch,
+			wantAllowed: true,
+		},
+		{
+			name:        "multiple roles",
+			member:      Member{Roles: []string{"role_2", "role_1"}},
+			want:        []string{permdom.NameInventoryUpdate, permdom.NameOrderDispatch, "order.view"},
+			check:       permdom.NameInventoryUpdate,
+			wantAllowed: true,
+		},
+		{
+			// 削除済みなど roles に無いロールは権限を与えない。
+			name:        "unknown role",
+			member:      Member{Roles: []string{"role_9"}},
+			want:        []string{},
+			check:       "order.view",
+			wantAllowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.member.EffectivePermissions(roles); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("EffectivePermissions = %v, want %v", got, tt.want)
+			}
+			if got := tt.member.HasEffectivePermission(tt.check, roles); got != tt.wantAllowed {
+				t.Fatalf("HasEffectivePermission(%q) = %v, want %v", tt.check, got, tt.wantAllowed)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ package mint
 import (
 	"errors"
 	"time"
+
+	outboxdom "narratives/internal/domain/outbox"
 )
 
 // ------------------------------------------------------
@@ -56,6 +58,10 @@ type Mint struct {
 
 	// 全product task完了時の代表signatureを保持します。
 	OnChainTxSignature string `json:"onChainTxSignature,omitempty"`
+
+	// events は outbox へ未保存のドメインイベントです。
+	// MintRepository.Update が Mint と同じ transaction で保存します。
+	events []outboxdom.Event
 }
 
 // ------------------------------------------------------
@@ -126,6 +132,7 @@ var (
 	ErrInconsistentMintStatus  = errors.New("mint: inconsistent status / mintedAt")
 	ErrMintAlreadyMinted       = errors.New("mint: already minted")
 	ErrNotFound                = errors.New("mint: not found")
+	ErrConflict                = errors.New("mint: conflict")
 )
 
 // ------------------------------------------------------
@@ -256,6 +263,7 @@ func (m *Mint) MarkMinted(
 	}
 
 	t := now.UTC()
+	alreadyMinted := m.Status == MintStatusMinted
 
 	m.Status = MintStatusMinted
 	m.MintedAt = &t
@@ -265,7 +273,52 @@ func (m *Mint) MarkMinted(
 			representativeSignature
 	}
 
-	return m.validate()
+	if err := m.validate(); err != nil {
+		return err
+	}
+
+	if alreadyMinted {
+		return nil
+	}
+
+	// MINTED への遷移時だけ MintCompleted を記録します。
+	actorID := m.RequestedBy
+	if actorID == "" {
+		actorID = m.CreatedBy
+	}
+
+	e, err := outboxdom.NewEvent(
+		outboxdom.EventMintCompleted,
+		outboxdom.AggregateMint,
+		m.ID,
+		outboxdom.MintCompletedPayload{
+			MintID:           m.ID,
+			BrandID:          m.BrandID,
+			TokenBlueprintID: m.TokenBlueprintID,
+			ActorID:          actorID,
+			TxSignature:      m.OnChainTxSignature,
+		},
+		t,
+	)
+	if err != nil {
+		return err
+	}
+
+	m.events = append(m.events, e)
+	return nil
+}
+
+// PendingEvents は outbox へ未保存のイベントを返します。
+func (m Mint) PendingEvents() []outboxdom.Event {
+	return append([]outboxdom.Event(nil), m.events...)
+}
+
+// ClearEvents は repository が保存した後にイベントを破棄します。
+func (m *Mint) ClearEvents() {
+	if m == nil {
+		return
+	}
+	m.events = nil
 }
 
 func (m *Mint) MarkFailedRetryable() error {
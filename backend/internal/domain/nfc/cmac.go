@@ -0,0 +1,66 @@
+// backend/internal/domain/nfc/cmac.go
+package nfc
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+)
+
+// aesCMAC は AES-CMAC（RFC 4493）を計算します。key は 16 byte（AES-128）です。
+func aesCMAC(key, msg []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	const bs = aes.BlockSize
+
+	// サブキー K1 / K2
+	l := make([]byte, bs)
+	block.Encrypt(l, l)
+	k1 := cmacShift(l)
+	k2 := cmacShift(k1)
+
+	n := (len(msg) + bs - 1) / bs
+	complete := n > 0 && len(msg)%bs == 0
+	if n == 0 {
+		n = 1
+	}
+
+	last := make([]byte, bs)
+	if complete {
+		copy(last, msg[(n-1)*bs:])
+		subtle.XORBytes(last, last, k1)
+	} else {
+		rest := msg[(n-1)*bs:]
+		copy(last, rest)
+		last[len(rest)] = 0x80
+		subtle.XORBytes(last, last, k2)
+	}
+
+	x := make([]byte, bs)
+	for i := 0; i < n-1; i++ {
+		subtle.XORBytes(x, x, msg[i*bs:(i+1)*bs])
+		block.Encrypt(x, x)
+	}
+	subtle.XORBytes(x, x, last)
+	block.Encrypt(x, x)
+
+	return x, nil
+}
+
+// cmacShift は 1 bit 左シフトし、最上位 bit が 1 の場合は Rb（0x87）を XOR します。
+func cmacShift(in []byte) []byte {
+	out := make([]byte, len(in))
+
+	var carry byte
+	for i := len(in) - 1; i >= 0; i-- {
+		out[i] = in[i]<<1 | carry
+		carry = in[i] >> 7
+	}
+	if carry == 1 {
+		out[len(out)-1] ^= 0x87
+	}
+
+	return out
+}
@@ -0,0 +1,70 @@
+// backend/internal/domain/nfc/cmac_test.go
+package nfc
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// RFC 4493 4. Test Vectors
+func TestAESCMAC(t *testing.T) {
+	key := "2b7e151628aed2a6abf7158809cf4f3c"
+
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{
+			name: "empty",
+			msg:  "",
+			want: "bb1d6929e95937287fa37d129b756746",
+		},
+		{
+			name: "one block",
+			msg:  "6bc1bee22e409f96e93d7e117393172a",
+			want: "070a16b46b4d4144f79bdd9dd04a287c",
+		},
+		{
+			name: "partial last block",
+			msg: "6bc1bee22e409f96e93d7e117393172a" +
+				"ae2d8a571e03ac9c9eb76fac45af8e51" +
+				"30c81c46a35ce411",
+			want: "dfa66747de9ae63030ca32611497c827",
+		},
+		{
+			name: "four blocks",
+			msg: "6bc1bee22e409f96e93d7e117393172a" +
+				"ae2d8a571e03ac9c9eb76fac45af8e51" +
+				"30c81c46a35ce411e5fbc1191a0a52ef" +
+				"f69f2445df4f9b17ad2b417be66c3710",
+			want: "51f0bebf7e3b9d92fc49741779363cfe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := aesCMAC(mustHex(t, key), mustHex(t, tt.msg))
+			if err != nil {
+				t.Fatalf("aesCMAC: %v", err)
+			}
+			if hex.EncodeToString(got) != tt.want {
+				t.Fatalf("aesCMAC = %x, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := aesCMAC(make([]byte, 15), nil); err == nil {
+		t.Fatalf("aesCMAC with a 15 byte key: err = nil")
+	}
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q): %v", s, err)
+	}
+	return b
+}
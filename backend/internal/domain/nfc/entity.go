@@ -0,0 +1,209 @@
+// backend/internal/domain/nfc/entity.go
+package nfc
+
+/*
+責務:
+- productIdTag が NFC の ProductBlueprint について、製品ごとの NFC タグ（NTAG 424 DNA）を管理する。
+  - assigned:    印刷（Production の Product 作成）時に割り当て。鍵は DeriveTagKeys で導出する。
+  - provisioned: 鍵を書き込んだタグの UID を登録済み。SUN メッセージの検証対象。
+  - revoked:     紛失・破損などで無効化。検証は常に失敗する。
+- 検証に成功したカウンタ（SDMReadCtr）を保持し、以下の値を拒否する（複製タグ・リプレイ対策）。
+
+前提:
+- docId = productId（1 product = 1 タグ）。
+- 鍵そのものは保存しない。KeyVersion を上げる（Reissue）と鍵が変わる。
+*/
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusAssigned    Status = "assigned"
+	StatusProvisioned Status = "provisioned"
+	StatusRevoked     Status = "revoked"
+)
+
+func IsValidStatus(s Status) bool {
+	switch s {
+	case StatusAssigned, StatusProvisioned, StatusRevoked:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	ErrNotFound = errors.New("nfc: tag not found")
+
+	ErrInvalidProductID    = errors.New("nfc: invalid productId")
+	ErrInvalidProductionID = errors.New("nfc: invalid productionId")
+	ErrInvalidCompanyID    = errors.New("nfc: invalid companyId")
+	ErrInvalidUID          = errors.New("nfc: invalid uid")
+	ErrInvalidKeyVersion   = errors.New("nfc: invalid keyVersion")
+	ErrInvalidMasterKey    = errors.New("nfc: invalid master key")
+	ErrInvalidStatus       = errors.New("nfc: invalid status transition")
+	ErrInvalidSUN          = errors.New("nfc: invalid SUN message")
+
+	// ErrUIDMismatch は SUN の UID が登録済みの UID と一致しない場合です。
+	ErrUIDMismatch = errors.New("nfc: uid does not match the provisioned tag")
+
+	// ErrCounterReplayed は SUN のカウンタが検証済みのカウンタ以下の場合です。
+	ErrCounterReplayed = errors.New("nfc: read counter was already used")
+
+	// ErrNotProvisioned は未登録・無効化済みのタグを検証しようとした場合です。
+	ErrNotProvisioned = errors.New("nfc: tag is not provisioned")
+)
+
+// Tag は 1 製品の NFC タグです。
+type Tag struct {
+	ProductID    string
+	ProductionID string
+	CompanyID    string
+
+	Status     Status
+	KeyVersion int
+
+	// UID は書き込み済みタグの UID（大文字 hex 14 文字）です。
+	UID string
+
+	// LastCounter は検証に成功した最大の SDMReadCtr です（LastVerifiedAt が nil の間は未使用）。
+	LastCounter    uint32
+	LastVerifiedAt *time.Time
+
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ProvisionedAt *time.Time
+	RevokedAt     *time.Time
+}
+
+// NewAssigned は印刷時に割り当てるタグを作成します。
+func NewAssigned(
+	productID string,
+	productionID string,
+	companyID string,
+	now time.Time,
+) (Tag, error) {
+	productID = strings.TrimSpace(productID)
+	productionID = strings.TrimSpace(productionID)
+	companyID = strings.TrimSpace(companyID)
+
+	if productID == "" {
+		return Tag{}, ErrInvalidProductID
+	}
+	if productionID == "" {
+		return Tag{}, ErrInvalidProductionID
+	}
+	if companyID == "" {
+		return Tag{}, ErrInvalidCompanyID
+	}
+
+	now = now.UTC()
+
+	return Tag{
+		ProductID:    productID,
+		ProductionID: productionID,
+		CompanyID:    companyID,
+		Status:       StatusAssigned,
+		KeyVersion:   1,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// NormalizeUID は UID を大文字 hex に正規化して検証します（区切り文字 ':' は無視）。
+func NormalizeUID(uid string) (string, error) {
+	s := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(uid), ":", ""))
+
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != UIDLength {
+		return "", ErrInvalidUID
+	}
+
+	return s, nil
+}
+
+// Provision は鍵を書き込んだタグの UID を登録します。
+func (t *Tag) Provision(uid string, now time.Time) error {
+	if t.Status != StatusAssigned {
+		return ErrInvalidStatus
+	}
+
+	normalized, err := NormalizeUID(uid)
+	if err != nil {
+		return err
+	}
+
+	now = now.UTC()
+
+	t.Status = StatusProvisioned
+	t.UID = normalized
+	t.LastCounter = 0
+	t.LastVerifiedAt = nil
+	t.ProvisionedAt = &now
+	t.UpdatedAt = now
+
+	return nil
+}
+
+// Revoke はタグを無効化します。
+func (t *Tag) Revoke(now time.Time) error {
+	if t.Status == StatusRevoked {
+		return ErrInvalidStatus
+	}
+
+	now = now.UTC()
+
+	t.Status = StatusRevoked
+	t.RevokedAt = &now
+	t.UpdatedAt = now
+
+	return nil
+}
+
+// Reissue は新しい鍵バージョンでタグを割り当て直します（タグの交換）。
+// 以前の鍵で書き込んだタグは検証できなくなります。
+func (t *Tag) Reissue(now time.Time) error {
+	if t.Status == StatusAssigned {
+		return ErrInvalidStatus
+	}
+
+	now = now.UTC()
+
+	t.Status = StatusAssigned
+	t.KeyVersion++
+	t.UID = ""
+	t.LastCounter = 0
+	t.LastVerifiedAt = nil
+	t.ProvisionedAt = nil
+	t.RevokedAt = nil
+	t.UpdatedAt = now
+
+	return nil
+}
+
+// AcceptCounter は検証済みの SUN（UID・カウンタ）を受け入れ、カウンタを進めます。
+func (t *Tag) AcceptCounter(d PICCData, now time.Time) error {
+	if t.Status != StatusProvisioned {
+		return ErrNotProvisioned
+	}
+	if d.UID != t.UID {
+		return ErrUIDMismatch
+	}
+	if t.LastVerifiedAt != nil && d.Counter <= t.LastCounter {
+		return ErrCounterReplayed
+	}
+
+	now = now.UTC()
+
+	t.LastCounter = d.Counter
+	t.LastVerifiedAt = &now
+	t.UpdatedAt = now
+
+	return nil
+}
@@ -0,0 +1,145 @@
+// backend/internal/domain/nfc/entity_test.go
+package nfc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+const testUID = "04A1B2C3D4E5F6"
+
+func testTag(t *testing.T, status Status) Tag {
+	t.Helper()
+
+	tag, err := NewAssigned("product_1", "production_1", "company_1", testNow)
+	if err != nil {
+		t.Fatalf("NewAssigned: %v", err)
+	}
+
+	switch status {
+	case StatusProvisioned:
+		if err := tag.Provision(testUID, testNow); err != nil {
+			t.Fatalf("Provision: %v", err)
+		}
+	case StatusRevoked:
+		if err := tag.Provision(testUID, testNow); err != nil {
+			t.Fatalf("Provision: %v", err)
+		}
+		if err := tag.Revoke(testNow); err != nil {
+			t.Fatalf("Revoke: %v", err)
+		}
+	}
+
+	return tag
+}
+
+func TestNormalizeUID(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{in: "04a1b2c3d4e5f6", want: testUID},
+		{in: " 04:A1:B2:C3:D4:E5:F6 ", want: testUID},
+		{in: "04A1B2C3D4E5", wantErr: ErrInvalidUID},
+		{in: "04A1B2C3D4E5F6A7", wantErr: ErrInvalidUID},
+		{in: "04A1B2C3D4E5FZ", wantErr: ErrInvalidUID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizeUID(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NormalizeUID err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("NormalizeUID = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTag_Transitions(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        Status
+		apply       func(tag *Tag) error
+		wantStatus  Status
+		wantVersion int
+		wantErr     error
+	}{
+		{name: "provision assigned", from: StatusAssigned, apply: func(tag *Tag) error { return tag.Provision(testUID, testNow) }, wantStatus: StatusProvisioned, wantVersion: 1},
+		{name: "provision twice", from: StatusProvisioned, apply: func(tag *Tag) error { return tag.Provision(testUID, testNow) }, wantStatus: StatusProvisioned, wantVersion: 1, wantErr: ErrInvalidStatus},
+		{name: "provision with bad uid", from: StatusAssigned, apply: func(tag *Tag) error { return tag.Provision("04", testNow) }, wantStatus: StatusAssigned, wantVersion: 1, wantErr: ErrInvalidUID},
+		{name: "revoke assigned", from: StatusAssigned, apply: func(tag *Tag) error { return tag.Revoke(testNow) }, wantStatus: StatusRevoked, wantVersion: 1},
+		{name: "revoke provisioned", from: StatusProvisioned, apply: func(tag *Tag) error { return tag.Revoke(testNow) }, wantStatus: StatusRevoked, wantVersion: 1},
+		{name: "revoke twice", from: StatusRevoked, apply: func(tag *Tag) error { return tag.Revoke(testNow) }, wantStatus: StatusRevoked, wantVersion: 1, wantErr: ErrInvalidStatus},
+		{name: "reissue provisioned", from: StatusProvisioned, apply: func(tag *Tag) error { return tag.Reissue(testNow) }, wantStatus: StatusAssigned, wantVersion: 2},
+		{name: "reissue revoked", from: StatusRevoked, apply: func(tag *Tag) error { return tag.Reissue(testNow) }, wantStatus: StatusAssigned, wantVersion: 2},
+		{name: "reissue assigned", from: StatusAssigned, apply: func(tag *Tag) error { return tag.Reissue(testNow) }, wantStatus: StatusAssigned, wantVersion: 1, wantErr: ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag := testTag(t, tt.from)
+
+			if err := tt.apply(&tag); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tag.Status != tt.wantStatus || tag.KeyVersion != tt.wantVersion {
+				t.Fatalf("Status = %s, KeyVersion = %d, want %s, %d", tag.Status, tag.KeyVersion, tt.wantStatus, tt.wantVersion)
+			}
+			if tt.wantErr == nil && tag.Status == StatusAssigned && (tag.UID != "" || tag.LastVerifiedAt != nil) {
+				t.Fatalf("reissued tag keeps UID %q / LastVerifiedAt %v", tag.UID, tag.LastVerifiedAt)
+			}
+		})
+	}
+}
+
+func TestTag_AcceptCounter(t *testing.T) {
+	later := testNow.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		from        Status
+		accepted    []uint32
+		read        PICCData
+		wantCounter uint32
+		wantErr     error
+	}{
+		// 未検証のタグは最初の読み取りがカウンタ 0 でも受け入れる。
+		{name: "first read at counter 0", from: StatusProvisioned, read: PICCData{UID: testUID, Counter: 0}, wantCounter: 0},
+		{name: "next read", from: StatusProvisioned, accepted: []uint32{5}, read: PICCData{UID: testUID, Counter: 6}, wantCounter: 6},
+		{name: "skipped counters", from: StatusProvisioned, accepted: []uint32{5}, read: PICCData{UID: testUID, Counter: 40}, wantCounter: 40},
+		{name: "replayed counter", from: StatusProvisioned, accepted: []uint32{5}, read: PICCData{UID: testUID, Counter: 5}, wantCounter: 5, wantErr: ErrCounterReplayed},
+		{name: "older counter", from: StatusProvisioned, accepted: []uint32{0, 5}, read: PICCData{UID: testUID, Counter: 3}, wantCounter: 5, wantErr: ErrCounterReplayed},
+		{name: "counter 0 replayed", from: StatusProvisioned, accepted: []uint32{0}, read: PICCData{UID: testUID, Counter: 0}, wantErr: ErrCounterReplayed},
+		{name: "another tag", from: StatusProvisioned, read: PICCData{UID: "04000000000000", Counter: 1}, wantErr: ErrUIDMismatch},
+		{name: "revoked", from: StatusRevoked, read: PICCData{UID: testUID, Counter: 1}, wantErr: ErrNotProvisioned},
+		{name: "not provisioned", from: StatusAssigned, read: PICCData{UID: testUID, Counter: 1}, wantErr: ErrNotProvisioned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag := testTag(t, tt.from)
+			for _, c := range tt.accepted {
+				if err := tag.AcceptCounter(PICCData{UID: testUID, Counter: c}, testNow); err != nil {
+					t.Fatalf("AcceptCounter(%d): %v", c, err)
+				}
+			}
+
+			if err := tag.AcceptCounter(tt.read, later); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AcceptCounter err = %v, want %v", err, tt.wantErr)
+			}
+			if tag.LastCounter != tt.wantCounter {
+				t.Fatalf("LastCounter = %d, want %d", tag.LastCounter, tt.wantCounter)
+			}
+			if tt.wantErr == nil && !tag.LastVerifiedAt.Equal(later) {
+				t.Fatalf("LastVerifiedAt = %v, want %v", tag.LastVerifiedAt, later)
+			}
+		})
+	}
+}
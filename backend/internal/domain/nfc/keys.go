@@ -0,0 +1,73 @@
+// backend/internal/domain/nfc/keys.go
+package nfc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// MinMasterKeyLength はマスター鍵の最小長（byte）です。
+const MinMasterKeyLength = 32
+
+// TagKeys はタグに書き込む SDM 用の鍵（AES-128）です。
+type TagKeys struct {
+	// MetaReadKey は PICCENCData（UID・カウンタ）の暗号化に使う SDMMetaReadKey です。
+	MetaReadKey [16]byte
+
+	// FileReadKey は SDMMAC の計算に使う SDMFileReadKey です。
+	FileReadKey [16]byte
+}
+
+// DeriveTagKeys はマスター鍵から productId × 鍵バージョンごとの鍵を導出します。
+//
+// 鍵は保存せず、検証のたびに導出します（マスター鍵は Secret Manager で管理）。
+// 鍵バージョンを上げる（Tag.Reissue）と、以前に書き込んだタグは検証できなくなります。
+func DeriveTagKeys(
+	masterKey []byte,
+	productID string,
+	keyVersion int,
+) (TagKeys, error) {
+	productID = strings.TrimSpace(productID)
+	if len(masterKey) < MinMasterKeyLength {
+		return TagKeys{}, ErrInvalidMasterKey
+	}
+	if productID == "" {
+		return TagKeys{}, ErrInvalidProductID
+	}
+	if keyVersion <= 0 {
+		return TagKeys{}, ErrInvalidKeyVersion
+	}
+
+	var keys TagKeys
+	copy(keys.MetaReadKey[:], deriveKey(masterKey, "sdm-meta-read", productID, keyVersion))
+	copy(keys.FileReadKey[:], deriveKey(masterKey, "sdm-file-read", productID, keyVersion))
+
+	return keys, nil
+}
+
+func deriveKey(
+	masterKey []byte,
+	purpose string,
+	productID string,
+	keyVersion int,
+) []byte {
+	mac := hmac.New(sha256.New, masterKey)
+	_, _ = mac.Write([]byte(
+		"narratives/nfc/" + purpose +
+			"/v" + strconv.Itoa(keyVersion) +
+			"/" + productID,
+	))
+	return mac.Sum(nil)[:16]
+}
+
+// ParseMasterKey は hex 文字列のマスター鍵を復号します。
+func ParseMasterKey(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(b) < MinMasterKeyLength {
+		return nil, ErrInvalidMasterKey
+	}
+	return b, nil
+}
@@ -0,0 +1,27 @@
+// backend/internal/domain/nfc/repository_port.go
+package nfc
+
+import "context"
+
+// RepositoryPort は NFC タグの永続化ポートです。
+//
+// Collection design:
+// - collection: nfcTags
+// - docId: productId
+type RepositoryPort interface {
+	GetByProductID(ctx context.Context, productID string) (Tag, error)
+
+	ListByProductionID(ctx context.Context, productionID string) ([]Tag, error)
+
+	// CreateIfAbsent は t.ProductID のタグが無ければ作成します。
+	// 既に存在する場合は何もせず created=false を返します（印刷の再実行に備える）。
+	CreateIfAbsent(ctx context.Context, t Tag) (created bool, err error)
+
+	// Update は fn で変更したタグを 1 つの transaction で保存します。
+	// fn がエラーを返した場合は保存せずにそのエラーを返します。
+	Update(
+		ctx context.Context,
+		productID string,
+		fn func(t *Tag) error,
+	) (Tag, error)
+}
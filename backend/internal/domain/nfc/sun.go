@@ -0,0 +1,179 @@
+// backend/internal/domain/nfc/sun.go
+package nfc
+
+/*
+NTAG 424 DNA の SUN（Secure Unique NFC）メッセージの検証。
+
+タグは読み取りのたびに NDEF の URL へ次の値をミラーします:
+
+	https://amol.jp/{productId}?picc={PICCENCData}&cmac={SDMMAC}
+
+  - PICCENCData（16 byte / 32 hex）:
+    SDMMetaReadKey による AES-128-CBC（IV = 0）暗号文。
+    平文 = PICCDataTag(1) || UID(7) || SDMReadCtr(3, little endian) || 乱数
+  - SDMMAC（8 byte / 16 hex）:
+    SDMFileReadKey から導出したセッション鍵による CMAC を奇数 byte で切り詰めたもの。
+    SV2 = 3C C3 00 01 00 80 || UID || SDMReadCtr
+    KSesSDMFileReadMAC = CMAC(SDMFileReadKey, SV2)
+    SDMMAC = MACt(KSesSDMFileReadMAC, 空メッセージ)
+
+SDMReadCtr は読み取りごとに増えるため、保存済みのカウンタ以下の値は
+（URL をコピーした複製タグ・リプレイとして）拒否します（Tag.AcceptCounter）。
+*/
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	piccDataTagUIDMirror = 0x80
+	piccDataTagCtrMirror = 0x40
+	piccDataTagUIDLength = 0x0F
+
+	// UIDLength は NTAG 424 DNA の UID の長さ（byte）です。
+	UIDLength = 7
+
+	piccEncDataHexLength = aes.BlockSize * 2
+	sunMACHexLength      = 16
+)
+
+// SUNMessage はタグが URL にミラーした値（hex）です。
+type SUNMessage struct {
+	PICCData string
+	CMAC     string
+}
+
+// PICCData は PICCENCData を復号した値です。
+type PICCData struct {
+	UID     string // 大文字 hex（14 文字）
+	Counter uint32 // SDMReadCtr（24 bit）
+}
+
+// VerifySUN は PICCENCData を復号し、SDMMAC を検証します。
+//
+// 復号に失敗した場合・UID / カウンタがミラーされていない場合・MAC が一致しない場合は
+// ErrInvalidSUN を返します（鍵の違いと改ざんは区別しません）。
+func VerifySUN(keys TagKeys, msg SUNMessage) (PICCData, error) {
+	encHex := strings.TrimSpace(msg.PICCData)
+	macHex := strings.TrimSpace(msg.CMAC)
+	if len(encHex) != piccEncDataHexLength || len(macHex) != sunMACHexLength {
+		return PICCData{}, ErrInvalidSUN
+	}
+
+	enc, err := hex.DecodeString(encHex)
+	if err != nil {
+		return PICCData{}, ErrInvalidSUN
+	}
+	mac, err := hex.DecodeString(macHex)
+	if err != nil {
+		return PICCData{}, ErrInvalidSUN
+	}
+
+	uid, ctr, err := decryptPICCData(keys.MetaReadKey[:], enc)
+	if err != nil {
+		return PICCData{}, err
+	}
+
+	expected, err := sunMAC(keys.FileReadKey[:], uid, ctr)
+	if err != nil {
+		return PICCData{}, err
+	}
+	if subtle.ConstantTimeCompare(expected, mac) != 1 {
+		return PICCData{}, ErrInvalidSUN
+	}
+
+	return PICCData{
+		UID:     strings.ToUpper(hex.EncodeToString(uid)),
+		Counter: uint32(ctr[0]) | uint32(ctr[1])<<8 | uint32(ctr[2])<<16,
+	}, nil
+}
+
+func decryptPICCData(key, enc []byte) (uid, ctr []byte, err error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plain := make([]byte, len(enc))
+	cipher.NewCBCDecrypter(block, make([]byte, aes.BlockSize)).
+		CryptBlocks(plain, enc)
+
+	tag := plain[0]
+	if tag&piccDataTagUIDMirror == 0 ||
+		tag&piccDataTagCtrMirror == 0 ||
+		int(tag&piccDataTagUIDLength) != UIDLength {
+		return nil, nil, ErrInvalidSUN
+	}
+
+	uid = plain[1 : 1+UIDLength]
+	ctr = plain[1+UIDLength : 1+UIDLength+3]
+
+	return uid, ctr, nil
+}
+
+// sunMAC は SDMMAC（切り詰め後の 8 byte）を計算します。
+func sunMAC(fileReadKey, uid, ctr []byte) ([]byte, error) {
+	sv2 := make([]byte, 0, aes.BlockSize)
+	sv2 = append(sv2, 0x3C, 0xC3, 0x00, 0x01, 0x00, 0x80)
+	sv2 = append(sv2, uid...)
+	sv2 = append(sv2, ctr...)
+
+	sessionKey, err := aesCMAC(fileReadKey, sv2)
+	if err != nil {
+		return nil, err
+	}
+
+	full, err := aesCMAC(sessionKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// MACt: 奇数番目の byte（1, 3, ..., 15）
+	out := make([]byte, 0, len(full)/2)
+	for i := 1; i < len(full); i += 2 {
+		out = append(out, full[i])
+	}
+
+	return out, nil
+}
+
+const (
+	// SUNPICCDataParam / SUNCMACParam は SUN の値をミラーする URL のクエリ名です。
+	SUNPICCDataParam = "picc"
+	SUNCMACParam     = "cmac"
+)
+
+// SUNURLTemplate はタグに書き込む NDEF URL と、SDM のミラー位置（URL 内の文字 offset）です。
+type SUNURLTemplate struct {
+	URL            string `json:"url"`
+	PICCDataOffset int    `json:"piccDataOffset"`
+	CMACOffset     int    `json:"cmacOffset"`
+}
+
+// BuildSUNURLTemplate は {baseURL}/{productId}?picc=0…0&cmac=0…0 を返します。
+// 書き込みツールは offset の位置へ PICCENCData・SDMMAC のミラーを設定します。
+func BuildSUNURLTemplate(baseURL, productID string) (SUNURLTemplate, error) {
+	productID = strings.TrimSpace(productID)
+	if productID == "" {
+		return SUNURLTemplate{}, ErrInvalidProductID
+	}
+
+	prefix := strings.TrimRight(strings.TrimSpace(baseURL), "/") +
+		"/" + productID +
+		"?" + SUNPICCDataParam + "="
+	piccOffset := len(prefix)
+
+	withPICC := prefix + strings.Repeat("0", piccEncDataHexLength) +
+		"&" + SUNCMACParam + "="
+	cmacOffset := len(withPICC)
+
+	return SUNURLTemplate{
+		URL:            withPICC + strings.Repeat("0", sunMACHexLength),
+		PICCDataOffset: piccOffset,
+		CMACOffset:     cmacOffset,
+	}, nil
+}
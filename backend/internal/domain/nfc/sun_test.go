@@ -0,0 +1,184 @@
+// backend/internal/domain/nfc/sun_test.go
+package nfc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// NXP AN12196 の SUN の例（鍵はすべて 0, UID 04DE5F1EACC040, SDMReadCtr 61）。
+func TestVerifySUN_KnownAnswer(t *testing.T) {
+	got, err := VerifySUN(TagKeys{}, SUNMessage{
+		PICCData: "EF963FF7828658A599F3041510671E88",
+		CMAC:     "94EED9EE65337086",
+	})
+	if err != nil {
+		t.Fatalf("VerifySUN: %v", err)
+	}
+
+	want := PICCData{UID: "04DE5F1EACC040", Counter: 61}
+	if got != want {
+		t.Fatalf("VerifySUN = %+v, want %+v", got, want)
+	}
+}
+
+// sunMessage はタグと同じ手順で SUN を生成します。
+func sunMessage(t *testing.T, keys TagKeys, tag byte, uid string, counter uint32) SUNMessage {
+	t.Helper()
+
+	plain := make([]byte, aes.BlockSize)
+	plain[0] = tag
+	copy(plain[1:], mustHex(t, uid))
+	ctr := []byte{byte(counter), byte(counter >> 8), byte(counter >> 16)}
+	copy(plain[1+UIDLength:], ctr)
+	copy(plain[1+UIDLength+3:], []byte{0x11, 0x22, 0x33, 0x44, 0x55})
+
+	block, err := aes.NewCipher(keys.MetaReadKey[:])
+	if err != nil {
+		t.Fatalf("aes.NewCipher: %v", err)
+	}
+	enc := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, make([]byte, aes.BlockSize)).CryptBlocks(enc, plain)
+
+	mac, err := sunMAC(keys.FileReadKey[:], mustHex(t, uid), ctr)
+	if err != nil {
+		t.Fatalf("sunMAC: %v", err)
+	}
+
+	return SUNMessage{
+		PICCData: strings.ToUpper(hex.EncodeToString(enc)),
+		CMAC:     strings.ToUpper(hex.EncodeToString(mac)),
+	}
+}
+
+func TestVerifySUN(t *testing.T) {
+	master := make([]byte, MinMasterKeyLength)
+	for i := range master {
+		master[i] = byte(i)
+	}
+
+	keys, err := DeriveTagKeys(master, "product_1", 1)
+	if err != nil {
+		t.Fatalf("DeriveTagKeys: %v", err)
+	}
+	otherProduct, _ := DeriveTagKeys(master, "product_2", 1)
+	reissued, _ := DeriveTagKeys(master, "product_1", 2)
+
+	const uid = "04A1B2C3D4E5F6"
+	valid := sunMessage(t, keys, 0xC7, uid, 0x012345)
+
+	tests := []struct {
+		name    string
+		keys    TagKeys
+		msg     SUNMessage
+		want    PICCData
+		wantErr error
+	}{
+		{name: "valid", keys: keys, msg: valid, want: PICCData{UID: uid, Counter: 0x012345}},
+		{name: "lower case and spaces", keys: keys, msg: SUNMessage{PICCData: " " + strings.ToLower(valid.PICCData), CMAC: strings.ToLower(valid.CMAC) + " "}, want: PICCData{UID: uid, Counter: 0x012345}},
+		{name: "counter 0", keys: keys, msg: sunMessage(t, keys, 0xC7, uid, 0), want: PICCData{UID: uid, Counter: 0}},
+		{name: "max counter", keys: keys, msg: sunMessage(t, keys, 0xC7, uid, 0xFFFFFF), want: PICCData{UID: uid, Counter: 0xFFFFFF}},
+		{name: "tampered mac", keys: keys, msg: SUNMessage{PICCData: valid.PICCData, CMAC: "0000000000000000"}, wantErr: ErrInvalidSUN},
+		{name: "mac from another read", keys: keys, msg: SUNMessage{PICCData: valid.PICCData, CMAC: sunMessage(t, keys, 0xC7, uid, 0x012346).CMAC}, wantErr: ErrInvalidSUN},
+		{name: "another product's keys", keys: otherProduct, msg: valid, wantErr: ErrInvalidSUN},
+		{name: "keys before reissue", keys: reissued, msg: valid, wantErr: ErrInvalidSUN},
+		{name: "uid not mirrored", keys: keys, msg: sunMessage(t, keys, 0x47, uid, 1), wantErr: ErrInvalidSUN},
+		{name: "counter not mirrored", keys: keys, msg: sunMessage(t, keys, 0x87, uid, 1), wantErr: ErrInvalidSUN},
+		{name: "short picc data", keys: keys, msg: SUNMessage{PICCData: valid.PICCData[:30], CMAC: valid.CMAC}, wantErr: ErrInvalidSUN},
+		{name: "non-hex mac", keys: keys, msg: SUNMessage{PICCData: valid.PICCData, CMAC: "ZZZZZZZZZZZZZZZZ"}, wantErr: ErrInvalidSUN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifySUN(tt.keys, tt.msg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifySUN err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("VerifySUN = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeriveTagKeys(t *testing.T) {
+	master := make([]byte, MinMasterKeyLength)
+
+	tests := []struct {
+		name       string
+		master     []byte
+		productID  string
+		keyVersion int
+		want       error
+	}{
+		{name: "valid", master: master, productID: "product_1", keyVersion: 1},
+		{name: "short master key", master: master[:MinMasterKeyLength-1], productID: "product_1", keyVersion: 1, want: ErrInvalidMasterKey},
+		{name: "missing product", master: master, productID: " ", keyVersion: 1, want: ErrInvalidProductID},
+		{name: "zero version", master: master, productID: "product_1", keyVersion: 0, want: ErrInvalidKeyVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := DeriveTagKeys(tt.master, tt.productID, tt.keyVersion)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("DeriveTagKeys err = %v, want %v", err, tt.want)
+			}
+			if err == nil && keys.MetaReadKey == keys.FileReadKey {
+				t.Fatalf("MetaReadKey and FileReadKey must differ")
+			}
+		})
+	}
+
+	a, _ := DeriveTagKeys(master, "product_1", 1)
+	b, _ := DeriveTagKeys(master, " product_1 ", 1)
+	if a != b {
+		t.Fatalf("DeriveTagKeys is not deterministic")
+	}
+}
+
+func TestParseMasterKey(t *testing.T) {
+	valid := strings.Repeat("ab", MinMasterKeyLength)
+
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{name: "valid", in: " " + valid + "\n"},
+		{name: "too short", in: valid[2:], want: ErrInvalidMasterKey},
+		{name: "not hex", in: strings.Repeat("zz", MinMasterKeyLength), want: ErrInvalidMasterKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseMasterKey(tt.in); !errors.Is(err, tt.want) {
+				t.Fatalf("ParseMasterKey err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSUNURLTemplate(t *testing.T) {
+	got, err := BuildSUNURLTemplate("https://amol.jp/", "product_1")
+	if err != nil {
+		t.Fatalf("BuildSUNURLTemplate: %v", err)
+	}
+
+	want := "https://amol.jp/product_1?picc=" + strings.Repeat("0", 32) + "&cmac=" + strings.Repeat("0", 16)
+	if got.URL != want {
+		t.Fatalf("URL = %q, want %q", got.URL, want)
+	}
+	if got.URL[got.PICCDataOffset-len("picc="):got.PICCDataOffset] != "picc=" ||
+		got.URL[got.CMACOffset-len("cmac="):got.CMACOffset] != "cmac=" ||
+		got.CMACOffset+16 != len(got.URL) {
+		t.Fatalf("offsets = %d, %d for %q", got.PICCDataOffset, got.CMACOffset, got.URL)
+	}
+
+	if _, err := BuildSUNURLTemplate("https://amol.jp", " "); !errors.Is(err, ErrInvalidProductID) {
+		t.Fatalf("BuildSUNURLTemplate err = %v, want %v", err, ErrInvalidProductID)
+	}
+}
@@ -0,0 +1,469 @@
+// backend/internal/domain/offer/entity.go
+package offer
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Offer は market の resale に対する価格交渉です。
+//
+// 流れ:
+//   - 購入者が出品価格より低い金額で offer を出す（pending）
+//   - 相手側（最後に金額を提示していない側）が accept / counter / decline する
+//   - counter された offer は再び相手側が accept / counter / decline できる（countered）
+//   - accept されると resale は reserved になり、購入者は CheckoutExpiresAt までに
+//     合意価格で注文する（OrderID を記録）。注文の決済で completed になる
+//   - 交渉中の offer は ExpiresAt、注文のない accepted の offer は CheckoutExpiresAt を
+//     過ぎると expired になる
+type Offer struct {
+	ID       string `json:"id"`
+	ResaleID string `json:"resaleId"`
+
+	BuyerAvatarID  string `json:"buyerAvatarId"`
+	SellerAvatarID string `json:"sellerAvatarId"`
+
+	// ListPrice は offer 作成時の出品価格です。
+	ListPrice int `json:"listPrice"`
+
+	// Amount は現在提示されている金額（accepted の場合は合意価格）です。
+	Amount int `json:"amount"`
+
+	Status Status `json:"status"`
+
+	// LastActor は最後に金額を提示した側です。
+	LastActor Party `json:"lastActor"`
+
+	Rounds []Round `json:"rounds"`
+
+	ExpiresAt         time.Time  `json:"expiresAt"`
+	CheckoutExpiresAt *time.Time `json:"checkoutExpiresAt,omitempty"`
+
+	// OrderID は合意価格で作成された注文です（accepted / completed）。
+	OrderID string `json:"orderId,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Round は 1 回分の金額提示です。
+type Round struct {
+	By        Party     `json:"by"`
+	Amount    int       `json:"amount"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusCountered Status = "countered"
+	StatusAccepted  Status = "accepted"
+	StatusDeclined  Status = "declined"
+	StatusWithdrawn Status = "withdrawn"
+	StatusExpired   Status = "expired"
+	StatusCompleted Status = "completed"
+)
+
+func IsValidStatus(s Status) bool {
+	switch s {
+	case StatusPending,
+		StatusCountered,
+		StatusAccepted,
+		StatusDeclined,
+		StatusWithdrawn,
+		StatusExpired,
+		StatusCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsOpen は交渉中（相手の返答待ち）かを返します。
+func (s Status) IsOpen() bool {
+	return s == StatusPending || s == StatusCountered
+}
+
+type Party string
+
+const (
+	PartyBuyer  Party = "buyer"
+	PartySeller Party = "seller"
+)
+
+func IsValidParty(p Party) bool {
+	return p == PartyBuyer || p == PartySeller
+}
+
+var (
+	ErrInvalidID             = errors.New("offer: invalid id")
+	ErrInvalidResaleID       = errors.New("offer: invalid resaleId")
+	ErrInvalidBuyerAvatarID  = errors.New("offer: invalid buyerAvatarId")
+	ErrInvalidSellerAvatarID = errors.New("offer: invalid sellerAvatarId")
+	ErrInvalidAmount         = errors.New("offer: invalid amount")
+	ErrInvalidStatus         = errors.New("offer: invalid status")
+	ErrInvalidParty          = errors.New("offer: invalid party")
+	ErrInvalidExpiresAt      = errors.New("offer: invalid expiresAt")
+	ErrInvalidCreatedAt      = errors.New("offer: invalid createdAt")
+
+	ErrSelfOffer      = errors.New("offer: cannot make an offer on own resale")
+	ErrNotParty       = errors.New("offer: avatar is not a party of the offer")
+	ErrNotYourTurn    = errors.New("offer: waiting for the other party")
+	ErrNotOpen        = errors.New("offer: offer is not open")
+	ErrNotAccepted    = errors.New("offer: offer is not accepted")
+	ErrOfferExpired   = errors.New("offer: offer has expired")
+	ErrInvalidOrderID = errors.New("offer: invalid orderId")
+	ErrAlreadyOrdered = errors.New("offer: agreed price has already been ordered")
+)
+
+// NewOfferInput は購入者の最初の提示です。
+type NewOfferInput struct {
+	ID       string
+	ResaleID string
+
+	BuyerAvatarID  string
+	SellerAvatarID string
+
+	ListPrice int
+	Amount    int
+
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func New(in NewOfferInput) (Offer, error) {
+	createdAt := in.CreatedAt.UTC()
+
+	o := Offer{
+		ID:             strings.TrimSpace(in.ID),
+		ResaleID:       strings.TrimSpace(in.ResaleID),
+		BuyerAvatarID:  strings.TrimSpace(in.BuyerAvatarID),
+		SellerAvatarID: strings.TrimSpace(in.SellerAvatarID),
+		ListPrice:      in.ListPrice,
+		Amount:         in.Amount,
+		Status:         StatusPending,
+		LastActor:      PartyBuyer,
+		Rounds: []Round{
+			{
+				By:        PartyBuyer,
+				Amount:    in.Amount,
+				CreatedAt: createdAt,
+			},
+		},
+		ExpiresAt: in.ExpiresAt.UTC(),
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+
+	if o.BuyerAvatarID != "" && o.BuyerAvatarID == o.SellerAvatarID {
+		return Offer{}, ErrSelfOffer
+	}
+
+	// 出品価格以上の offer は通常の購入で足りる。
+	if o.Amount >= o.ListPrice {
+		return Offer{}, ErrInvalidAmount
+	}
+
+	if err := o.Validate(); err != nil {
+		return Offer{}, err
+	}
+
+	return o, nil
+}
+
+// PartyOf は avatarID がどちら側かを返します。
+func (o Offer) PartyOf(avatarID string) (Party, error) {
+	avatarID = strings.TrimSpace(avatarID)
+
+	switch {
+	case avatarID == "":
+		return "", ErrNotParty
+	case avatarID == o.BuyerAvatarID:
+		return PartyBuyer, nil
+	case avatarID == o.SellerAvatarID:
+		return PartySeller, nil
+	default:
+		return "", ErrNotParty
+	}
+}
+
+// Counterparty は by の相手側を返します。
+func Counterparty(by Party) Party {
+	if by == PartyBuyer {
+		return PartySeller
+	}
+	return PartyBuyer
+}
+
+// IsExpired は now 時点で期限切れかを返します。
+func (o Offer) IsExpired(now time.Time) bool {
+	switch {
+	case o.Status.IsOpen():
+		return !now.Before(o.ExpiresAt)
+	case o.Status == StatusAccepted:
+		// 注文済みの場合は決済を待つ（決済は発送時に行われる）。
+		return o.OrderID == "" &&
+			o.CheckoutExpiresAt != nil &&
+			!now.Before(*o.CheckoutExpiresAt)
+	default:
+		return false
+	}
+}
+
+// Counter は相手側の提示に別の金額を返します。
+func (o *Offer) Counter(
+	by Party,
+	amount int,
+	expiresAt time.Time,
+	now time.Time,
+) error {
+	if err := o.ensureRespondable(by, now); err != nil {
+		return err
+	}
+
+	if amount <= 0 || amount >= o.ListPrice || amount == o.Amount {
+		return ErrInvalidAmount
+	}
+	if !expiresAt.After(now) {
+		return ErrInvalidExpiresAt
+	}
+
+	now = now.UTC()
+
+	o.Amount = amount
+	o.Status = StatusCountered
+	o.LastActor = by
+	o.Rounds = append(o.Rounds, Round{
+		By:        by,
+		Amount:    amount,
+		CreatedAt: now,
+	})
+	o.ExpiresAt = expiresAt.UTC()
+	o.UpdatedAt = now
+
+	return nil
+}
+
+// Accept は相手側の提示額で合意します。購入者は checkoutExpiresAt までに決済します。
+func (o *Offer) Accept(
+	by Party,
+	checkoutExpiresAt time.Time,
+	now time.Time,
+) error {
+	if err := o.ensureRespondable(by, now); err != nil {
+		return err
+	}
+
+	if !checkoutExpiresAt.After(now) {
+		return ErrInvalidExpiresAt
+	}
+
+	t := checkoutExpiresAt.UTC()
+
+	o.Status = StatusAccepted
+	o.CheckoutExpiresAt = &t
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// Decline は相手側の提示を断ります。
+func (o *Offer) Decline(by Party, now time.Time) error {
+	if err := o.ensureRespondable(by, now); err != nil {
+		return err
+	}
+
+	o.Status = StatusDeclined
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// Supersede は同じ resale の別の offer が accept されたため交渉を終了します。
+func (o *Offer) Supersede(now time.Time) error {
+	if !o.Status.IsOpen() {
+		return ErrNotOpen
+	}
+
+	o.Status = StatusDeclined
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// Withdraw は購入者が交渉を取り下げます。
+func (o *Offer) Withdraw(by Party, now time.Time) error {
+	if by != PartyBuyer {
+		return ErrNotParty
+	}
+	if !o.Status.IsOpen() {
+		return ErrNotOpen
+	}
+
+	o.Status = StatusWithdrawn
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// Expire は期限切れの offer を expired にします。
+func (o *Offer) Expire(now time.Time) error {
+	if !o.IsExpired(now) {
+		return ErrNotOpen
+	}
+
+	o.Status = StatusExpired
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// AttachOrder は合意価格で注文が作成されたことを記録します。
+func (o *Offer) AttachOrder(orderID string, now time.Time) error {
+	if o.Status != StatusAccepted {
+		return ErrNotAccepted
+	}
+	if o.IsExpired(now) {
+		return ErrOfferExpired
+	}
+
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return ErrInvalidOrderID
+	}
+	if o.OrderID != "" {
+		if o.OrderID == orderID {
+			return nil
+		}
+		return ErrAlreadyOrdered
+	}
+
+	o.OrderID = orderID
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// DetachOrder は注文の保存に失敗した場合に AttachOrder を取り消します。
+func (o *Offer) DetachOrder(orderID string, now time.Time) error {
+	if o.Status != StatusAccepted {
+		return ErrNotAccepted
+	}
+	if o.OrderID != strings.TrimSpace(orderID) {
+		return ErrInvalidOrderID
+	}
+
+	o.OrderID = ""
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// CancelCheckout は合意価格の注文が決済されなかった場合（決済失敗・決済キャンセル・
+// 引当の期限切れ・明細キャンセル）に accepted の offer を expired にします。
+// 注文 ID は履歴として残すため、同じ注文で再実行しても結果は同じです。
+func (o *Offer) CancelCheckout(orderID string, now time.Time) error {
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" {
+		return ErrInvalidOrderID
+	}
+	if o.Status == StatusExpired && o.OrderID == orderID {
+		return nil
+	}
+	if o.Status != StatusAccepted {
+		return ErrNotAccepted
+	}
+	if o.OrderID != orderID {
+		return ErrInvalidOrderID
+	}
+
+	o.Status = StatusExpired
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// Complete は合意価格の注文が決済されたことを記録します。
+func (o *Offer) Complete(orderID string, now time.Time) error {
+	if o.Status != StatusAccepted {
+		return ErrNotAccepted
+	}
+
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" || (o.OrderID != "" && o.OrderID != orderID) {
+		return ErrInvalidOrderID
+	}
+
+	o.Status = StatusCompleted
+	o.OrderID = orderID
+	o.UpdatedAt = now.UTC()
+
+	return nil
+}
+
+// ensureRespondable は by が返答できる状態かを確認します。
+func (o Offer) ensureRespondable(by Party, now time.Time) error {
+	if !IsValidParty(by) {
+		return ErrInvalidParty
+	}
+	if !o.Status.IsOpen() {
+		return ErrNotOpen
+	}
+	if o.IsExpired(now) {
+		return ErrOfferExpired
+	}
+	if by == o.LastActor {
+		return ErrNotYourTurn
+	}
+
+	return nil
+}
+
+func (o Offer) Validate() error {
+	if o.ResaleID == "" {
+		return ErrInvalidResaleID
+	}
+	if o.BuyerAvatarID == "" {
+		return ErrInvalidBuyerAvatarID
+	}
+	if o.SellerAvatarID == "" {
+		return ErrInvalidSellerAvatarID
+	}
+	if o.BuyerAvatarID == o.SellerAvatarID {
+		return ErrSelfOffer
+	}
+	if o.ListPrice <= 0 || o.Amount <= 0 || o.Amount > o.ListPrice {
+		return ErrInvalidAmount
+	}
+	if !IsValidStatus(o.Status) {
+		return ErrInvalidStatus
+	}
+	if !IsValidParty(o.LastActor) {
+		return ErrInvalidParty
+	}
+	if o.CreatedAt.IsZero() {
+		return ErrInvalidCreatedAt
+	}
+	if !o.ExpiresAt.After(o.CreatedAt) {
+		return ErrInvalidExpiresAt
+	}
+	if o.Status == StatusAccepted && o.CheckoutExpiresAt == nil {
+		return ErrInvalidExpiresAt
+	}
+	if o.Status == StatusCompleted && o.OrderID == "" {
+		return ErrInvalidOrderID
+	}
+
+	for _, round := range o.Rounds {
+		if !IsValidParty(round.By) {
+			return ErrInvalidParty
+		}
+		if round.Amount <= 0 {
+			return ErrInvalidAmount
+		}
+	}
+
+	return nil
+}
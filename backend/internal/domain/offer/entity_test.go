@@ -0,0 +1,385 @@
+// backend/internal/domain/offer/entity_test.go
+package offer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testOffer(t *testing.T) Offer {
+	t.Helper()
+
+	o, err := New(NewOfferInput{
+		ID:             "offer_1",
+		ResaleID:       "resale_1",
+		BuyerAvatarID:  "buyer_1",
+		SellerAvatarID: "seller_1",
+		ListPrice:      10000,
+		Amount:         7000,
+		ExpiresAt:      testNow.Add(48 * time.Hour),
+		CreatedAt:      testNow,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	return o
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(in *NewOfferInput)
+		want   error
+	}{
+		{name: "valid", modify: func(in *NewOfferInput) {}},
+		{name: "self offer", modify: func(in *NewOfferInput) { in.SellerAvatarID = in.BuyerAvatarID }, want: ErrSelfOffer},
+		{name: "amount equals list price", modify: func(in *NewOfferInput) { in.Amount = in.ListPrice }, want: ErrInvalidAmount},
+		{name: "zero amount", modify: func(in *NewOfferInput) { in.Amount = 0 }, want: ErrInvalidAmount},
+		{name: "missing resale", modify: func(in *NewOfferInput) { in.ResaleID = " " }, want: ErrInvalidResaleID},
+		{name: "expires at creation", modify: func(in *NewOfferInput) { in.ExpiresAt = in.CreatedAt }, want: ErrInvalidExpiresAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := NewOfferInput{
+				ResaleID:       "resale_1",
+				BuyerAvatarID:  "buyer_1",
+				SellerAvatarID: "seller_1",
+				ListPrice:      10000,
+				Amount:         7000,
+				ExpiresAt:      testNow.Add(time.Hour),
+				CreatedAt:      testNow,
+			}
+			tt.modify(&in)
+
+			o, err := New(in)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("New err = %v, want %v", err, tt.want)
+			}
+			if err == nil && (o.Status != StatusPending || o.LastActor != PartyBuyer || len(o.Rounds) != 1) {
+				t.Fatalf("New = %+v, want pending offer by buyer with one round", o)
+			}
+		})
+	}
+}
+
+func TestOffer_Respond(t *testing.T) {
+	checkout := testNow.Add(24 * time.Hour)
+	expires := testNow.Add(48 * time.Hour)
+	afterExpiry := testNow.Add(49 * time.Hour)
+
+	tests := []struct {
+		name       string
+		prepare    func(o *Offer)
+		apply      func(o *Offer) error
+		wantStatus Status
+		wantAmount int
+		wantErr    error
+	}{
+		{
+			name:       "seller accepts",
+			apply:      func(o *Offer) error { return o.Accept(PartySeller, checkout, testNow) },
+			wantStatus: StatusAccepted,
+			wantAmount: 7000,
+		},
+		{
+			name:       "buyer cannot accept own offer",
+			apply:      func(o *Offer) error { return o.Accept(PartyBuyer, checkout, testNow) },
+			wantStatus: StatusPending,
+			wantAmount: 7000,
+			wantErr:    ErrNotYourTurn,
+		},
+		{
+			name:       "seller counters",
+			apply:      func(o *Offer) error { return o.Counter(PartySeller, 9000, expires, testNow) },
+			wantStatus: StatusCountered,
+			wantAmount: 9000,
+		},
+		{
+			name:       "buyer accepts counter",
+			prepare:    func(o *Offer) { _ = o.Counter(PartySeller, 9000, expires, testNow) },
+			apply:      func(o *Offer) error { return o.Accept(PartyBuyer, checkout, testNow) },
+			wantStatus: StatusAccepted,
+			wantAmount: 9000,
+		},
+		{
+			name:       "seller cannot answer own counter",
+			prepare:    func(o *Offer) { _ = o.Counter(PartySeller, 9000, expires, testNow) },
+			apply:      func(o *Offer) error { return o.Decline(PartySeller, testNow) },
+			wantStatus: StatusCountered,
+			wantAmount: 9000,
+			wantErr:    ErrNotYourTurn,
+		},
+		{
+			name:       "counter with same amount",
+			apply:      func(o *Offer) error { return o.Counter(PartySeller, 7000, expires, testNow) },
+			wantStatus: StatusPending,
+			wantAmount: 7000,
+			wantErr:    ErrInvalidAmount,
+		},
+		{
+			name:       "counter at list price",
+			apply:      func(o *Offer) error { return o.Counter(PartySeller, 10000, expires, testNow) },
+			wantStatus: StatusPending,
+			wantAmount: 7000,
+			wantErr:    ErrInvalidAmount,
+		},
+		{
+			name:       "counter with past expiry",
+			apply:      func(o *Offer) error { return o.Counter(PartySeller, 9000, testNow, testNow) },
+			wantStatus: StatusPending,
+			wantAmount: 7000,
+			wantErr:    ErrInvalidExpiresAt,
+		},
+		{
+			name:       "seller declines",
+			apply:      func(o *Offer) error { return o.Decline(PartySeller, testNow) },
+			wantStatus: StatusDeclined,
+			wantAmount: 7000,
+		},
+		{
+			name:       "accept after expiry",
+			apply:      func(o *Offer) error { return o.Accept(PartySeller, afterExpiry.Add(time.Hour), afterExpiry) },
+			wantStatus: StatusPending,
+			wantAmount: 7000,
+			wantErr:    ErrOfferExpired,
+		},
+		{
+			name:       "respond to declined",
+			prepare:    func(o *Offer) { _ = o.Decline(PartySeller, testNow) },
+			apply:      func(o *Offer) error { return o.Accept(PartySeller, checkout, testNow) },
+			wantStatus: StatusDeclined,
+			wantAmount: 7000,
+			wantErr:    ErrNotOpen,
+		},
+		{
+			name:       "invalid party",
+			apply:      func(o *Offer) error { return o.Decline("admin", testNow) },
+			wantStatus: StatusPending,
+			wantAmount: 7000,
+			wantErr:    ErrInvalidParty,
+		},
+		{
+			name:       "buyer withdraws",
+			apply:      func(o *Offer) error { return o.Withdraw(PartyBuyer, testNow) },
+			wantStatus: StatusWithdrawn,
+			wantAmount: 7000,
+		},
+		{
+			name:       "seller cannot withdraw",
+			apply:      func(o *Offer) error { return o.Withdraw(PartySeller, testNow) },
+			wantStatus: StatusPending,
+			wantAmount: 7000,
+			wantErr:    ErrNotParty,
+		},
+		{
+			name:       "supersede open offer",
+			apply:      func(o *Offer) error { return o.Supersede(testNow) },
+			wantStatus: StatusDeclined,
+			wantAmount: 7000,
+		},
+		{
+			name:       "supersede accepted offer",
+			prepare:    func(o *Offer) { _ = o.Accept(PartySeller, checkout, testNow) },
+			apply:      func(o *Offer) error { return o.Supersede(testNow) },
+			wantStatus: StatusAccepted,
+			wantAmount: 7000,
+			wantErr:    ErrNotOpen,
+		},
+		{
+			name:       "expire open offer",
+			apply:      func(o *Offer) error { return o.Expire(expires) },
+			wantStatus: StatusExpired,
+			wantAmount: 7000,
+		},
+		{
+			name:       "expire before deadline",
+			apply:      func(o *Offer) error { return o.Expire(expires.Add(-time.Second)) },
+			wantStatus: StatusPending,
+			wantAmount: 7000,
+			wantErr:    ErrNotOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := testOffer(t)
+			if tt.prepare != nil {
+				tt.prepare(&o)
+			}
+
+			if err := tt.apply(&o); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if o.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", o.Status, tt.wantStatus)
+			}
+			if o.Amount != tt.wantAmount {
+				t.Errorf("Amount = %d, want %d", o.Amount, tt.wantAmount)
+			}
+			if err := o.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestOffer_Checkout(t *testing.T) {
+	checkout := testNow.Add(24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		apply      func(o *Offer) error
+		wantStatus Status
+		wantOrder  string
+		wantErr    error
+	}{
+		{
+			name:       "attach order",
+			apply:      func(o *Offer) error { return o.AttachOrder("order_1", testNow) },
+			wantStatus: StatusAccepted,
+			wantOrder:  "order_1",
+		},
+		{
+			name: "attach same order again",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				return o.AttachOrder("order_1", testNow)
+			},
+			wantStatus: StatusAccepted,
+			wantOrder:  "order_1",
+		},
+		{
+			name: "attach another order",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				return o.AttachOrder("order_2", testNow)
+			},
+			wantStatus: StatusAccepted,
+			wantOrder:  "order_1",
+			wantErr:    ErrAlreadyOrdered,
+		},
+		{
+			name:       "attach after checkout deadline",
+			apply:      func(o *Offer) error { return o.AttachOrder("order_1", checkout) },
+			wantStatus: StatusAccepted,
+			wantErr:    ErrOfferExpired,
+		},
+		{
+			name: "detach order",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				return o.DetachOrder("order_1", testNow)
+			},
+			wantStatus: StatusAccepted,
+		},
+		{
+			name: "ordered offer does not expire at checkout deadline",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				return o.Expire(checkout)
+			},
+			wantStatus: StatusAccepted,
+			wantOrder:  "order_1",
+			wantErr:    ErrNotOpen,
+		},
+		{
+			name:       "unordered offer expires at checkout deadline",
+			apply:      func(o *Offer) error { return o.Expire(checkout) },
+			wantStatus: StatusExpired,
+		},
+		{
+			name: "complete ordered offer",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				return o.Complete("order_1", testNow)
+			},
+			wantStatus: StatusCompleted,
+			wantOrder:  "order_1",
+		},
+		{
+			name: "cancel checkout",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				return o.CancelCheckout("order_1", testNow)
+			},
+			wantStatus: StatusExpired,
+			wantOrder:  "order_1",
+		},
+		{
+			name: "cancel checkout twice is a no-op",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				_ = o.CancelCheckout("order_1", testNow)
+				return o.CancelCheckout("order_1", testNow)
+			},
+			wantStatus: StatusExpired,
+			wantOrder:  "order_1",
+		},
+		{
+			name: "cancel checkout of another order",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				return o.CancelCheckout("order_2", testNow)
+			},
+			wantStatus: StatusAccepted,
+			wantOrder:  "order_1",
+			wantErr:    ErrInvalidOrderID,
+		},
+		{
+			name: "cancel checkout of completed offer",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				_ = o.Complete("order_1", testNow)
+				return o.CancelCheckout("order_1", testNow)
+			},
+			wantStatus: StatusCompleted,
+			wantOrder:  "order_1",
+			wantErr:    ErrNotAccepted,
+		},
+		{
+			name: "complete with another order",
+			apply: func(o *Offer) error {
+				_ = o.AttachOrder("order_1", testNow)
+				return o.Complete("order_2", testNow)
+			},
+			wantStatus: StatusAccepted,
+			wantOrder:  "order_1",
+			wantErr:    ErrInvalidOrderID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := testOffer(t)
+			if err := o.Accept(PartySeller, checkout, testNow); err != nil {
+				t.Fatalf("Accept: %v", err)
+			}
+
+			if err := tt.apply(&o); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if o.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", o.Status, tt.wantStatus)
+			}
+			if o.OrderID != tt.wantOrder {
+				t.Errorf("OrderID = %q, want %q", o.OrderID, tt.wantOrder)
+			}
+		})
+	}
+}
+
+func TestOffer_AttachOrder_NotAccepted(t *testing.T) {
+	o := testOffer(t)
+
+	if err := o.AttachOrder("order_1", testNow); !errors.Is(err, ErrNotAccepted) {
+		t.Fatalf("AttachOrder err = %v, want %v", err, ErrNotAccepted)
+	}
+	if err := o.Complete("order_1", testNow); !errors.Is(err, ErrNotAccepted) {
+		t.Fatalf("Complete err = %v, want %v", err, ErrNotAccepted)
+	}
+}
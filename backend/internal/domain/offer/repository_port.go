@@ -0,0 +1,33 @@
+// backend/internal/domain/offer/repository_port.go
+package offer
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("offer: not found")
+	ErrConflict = errors.New("offer: conflict")
+)
+
+type RepositoryPort interface {
+	GetByID(ctx context.Context, id string) (Offer, error)
+
+	// ListByResaleID は resale の offer を新しい順に返します。
+	ListByResaleID(ctx context.Context, resaleID string) ([]Offer, error)
+
+	ListByBuyerAvatarID(ctx context.Context, avatarID string) ([]Offer, error)
+	ListBySellerAvatarID(ctx context.Context, avatarID string) ([]Offer, error)
+
+	// ListDue は now 時点で期限切れの offer（交渉中 / accepted）を返します。
+	ListDue(ctx context.Context, now time.Time, limit int) ([]Offer, error)
+
+	// Create は offer を保存します。ID が空の場合は採番します。
+	Create(ctx context.Context, o Offer) (Offer, error)
+
+	// Update は offer 全体を保存します。
+	// 保存済みの UpdatedAt が prevUpdatedAt と異なる場合（他の返答が先に保存された場合）は ErrConflict。
+	Update(ctx context.Context, o Offer, prevUpdatedAt time.Time) (Offer, error)
+}
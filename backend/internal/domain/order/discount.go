@@ -0,0 +1,166 @@
+// backend/internal/domain/order/discount.go
+package order
+
+import (
+	"errors"
+	"time"
+)
+
+// DiscountItemSnapshot is the coupon discount applied to one Order item.
+//
+// Amount is taken from the item subtotal (price * qty) and ShippingAmount
+// from the item's shipping quote. Both are tax-exclusive; consumption tax is
+// charged on the discounted amounts.
+type DiscountItemSnapshot struct {
+	ItemIndex      int `json:"itemIndex"`
+	Amount         int `json:"amount"`
+	ShippingAmount int `json:"shippingAmount"`
+}
+
+// DiscountSnapshot records the coupon applied when the Order was created.
+// The coupon may be edited or deactivated later; the Order keeps the
+// discount it was charged with.
+type DiscountSnapshot struct {
+	CouponID     string `json:"couponId"`
+	Code         string `json:"code"`
+	DiscountType string `json:"discountType"`
+
+	Items []DiscountItemSnapshot `json:"items"`
+
+	// ItemAmount + ShippingAmount = Amount
+	ItemAmount     int `json:"itemAmount"`
+	ShippingAmount int `json:"shippingAmount"`
+	Amount         int `json:"amount"`
+
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+var ErrInvalidDiscount = errors.New("order: invalid discount snapshot")
+
+// ApplyDiscount sets the coupon discount. It can only be set before payment.
+func (o *Order) ApplyDiscount(d DiscountSnapshot) error {
+	if o == nil || o.Paid {
+		return ErrInvalidDiscount
+	}
+
+	d.AppliedAt = d.AppliedAt.UTC()
+	d.Items = append([]DiscountItemSnapshot(nil), d.Items...)
+
+	if err := validateDiscount(
+		o.Items,
+		o.ShippingQuoteSnapshot,
+		&d,
+	); err != nil {
+		return err
+	}
+
+	o.Discount = &d
+	return nil
+}
+
+// DiscountAmount returns the total discount (tax-exclusive).
+func (o Order) DiscountAmount() int {
+	if o.Discount == nil {
+		return 0
+	}
+
+	return o.Discount.Amount
+}
+
+// ShippingDiscountAmount returns the discount taken from shipping.
+func (o Order) ShippingDiscountAmount() int {
+	if o.Discount == nil {
+		return 0
+	}
+
+	return o.Discount.ShippingAmount
+}
+
+// ItemDiscountAmount returns the discount taken from Items[index]'s subtotal.
+func (o Order) ItemDiscountAmount(index int) int {
+	if o.Discount == nil {
+		return 0
+	}
+
+	total := 0
+	for _, item := range o.Discount.Items {
+		if item.ItemIndex == index {
+			total += item.Amount
+		}
+	}
+
+	return total
+}
+
+// ItemShippingDiscountAmount returns the discount taken from Items[index]'s
+// shipping.
+func (o Order) ItemShippingDiscountAmount(index int) int {
+	if o.Discount == nil {
+		return 0
+	}
+
+	total := 0
+	for _, item := range o.Discount.Items {
+		if item.ItemIndex == index {
+			total += item.ShippingAmount
+		}
+	}
+
+	return total
+}
+
+func validateDiscount(
+	items []OrderItemSnapshot,
+	shipping ShippingQuoteSnapshot,
+	d *DiscountSnapshot,
+) error {
+	if d == nil {
+		return nil
+	}
+
+	if d.CouponID == "" ||
+		d.Code == "" ||
+		d.DiscountType == "" ||
+		len(d.Items) == 0 ||
+		d.AppliedAt.IsZero() {
+		return ErrInvalidDiscount
+	}
+
+	seen := make(map[int]struct{}, len(d.Items))
+	itemTotal := 0
+	shippingTotal := 0
+
+	for _, item := range d.Items {
+		if item.ItemIndex < 0 ||
+			item.ItemIndex >= len(items) ||
+			item.Amount < 0 ||
+			item.ShippingAmount < 0 {
+			return ErrInvalidDiscount
+		}
+
+		if _, exists := seen[item.ItemIndex]; exists {
+			return ErrInvalidDiscount
+		}
+		seen[item.ItemIndex] = struct{}{}
+
+		target := items[item.ItemIndex]
+		if item.Amount > target.Price*target.Qty {
+			return ErrInvalidDiscount
+		}
+
+		itemTotal += item.Amount
+		shippingTotal += item.ShippingAmount
+	}
+
+	if itemTotal != d.ItemAmount ||
+		shippingTotal != d.ShippingAmount ||
+		d.ItemAmount+d.ShippingAmount != d.Amount {
+		return ErrInvalidDiscount
+	}
+
+	if d.ShippingAmount > shipping.Amount {
+		return ErrInvalidDiscount
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"strings"
 	"time"
+
+	transportationdom "narratives/internal/domain/transportation"
 )
 
 const DefaultDispatchNotificationMaxAttempts = 5
@@ -59,6 +61,10 @@ type DispatchNotificationItem struct {
 	ProductBlueprintID string
 	TokenBlueprintID   string
 	Qty                int
+
+	// Carrier / TrackingNumber は発送時の伝票情報（任意）。
+	Carrier        transportationdom.Carrier
+	TrackingNumber string
 }
 
 type DispatchNotificationDelivery struct {
@@ -133,6 +139,10 @@ func (item DispatchNotificationItem) Normalize() (
 	item.ListID = strings.TrimSpace(item.ListID)
 	item.ProductBlueprintID = strings.TrimSpace(item.ProductBlueprintID)
 	item.TokenBlueprintID = strings.TrimSpace(item.TokenBlueprintID)
+	item.Carrier = transportationdom.Carrier(
+		strings.TrimSpace(string(item.Carrier)),
+	)
+	item.TrackingNumber = strings.TrimSpace(item.TrackingNumber)
 
 	if item.InventoryID == "" ||
 		item.ListID == "" ||
@@ -142,6 +152,11 @@ func (item DispatchNotificationItem) Normalize() (
 		return DispatchNotificationItem{}, ErrDispatchNotificationItemInvalid
 	}
 
+	if item.Carrier != "" &&
+		!transportationdom.IsValidCarrier(item.Carrier) {
+		return DispatchNotificationItem{}, ErrDispatchNotificationItemInvalid
+	}
+
 	return item, nil
 }
 
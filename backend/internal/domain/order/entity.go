@@ -4,6 +4,8 @@ package order
 import (
 	"errors"
 	"time"
+
+	outboxdom "narratives/internal/domain/outbox"
 )
 
 // ========================================
@@ -39,6 +41,21 @@ type ShippingQuoteItemSnapshot struct {
 	Amount     int `json:"amount"`
 
 	Currency string `json:"currency"`
+
+	// Customs は国外宛て（carrier が ems / epacket）の場合だけ設定する。
+	Customs *CustomsDeclarationSnapshot `json:"customs,omitempty"`
+}
+
+// CustomsDeclarationSnapshot は税関告知書（CN22/CN23）に記載する内容品の情報。
+// HS code と内容品名は product blueprint から、申告価格は注文時点の値を保存する。
+type CustomsDeclarationSnapshot struct {
+	HSCode        string `json:"hsCode"`
+	Description   string `json:"description"`
+	OriginCountry string `json:"originCountry"`
+
+	// DeclaredUnitValue は 1 点あたりの申告価格。
+	DeclaredUnitValue int    `json:"declaredUnitValue"`
+	Currency          string `json:"currency"`
 }
 
 type ShippingQuoteSnapshot struct {
@@ -84,8 +101,11 @@ const (
 //   - productBlueprintId, tokenBlueprintId, brandId
 //   - productBlueprintCategoryPath, consumptionTaxRate
 //   - qty=1, price
+//   - royalty (when the token blueprint has a royalty policy)
+//   - offerId (when bought at the price agreed in a market offer)
 //
 // Transfer, cancellation, and dispatch state is maintained per item.
+// Status follows the transition rules in status.go.
 type OrderItemSnapshot struct {
 	Type OrderItemType `json:"type"`
 
@@ -113,8 +133,42 @@ type OrderItemSnapshot struct {
 	IsCancelled  bool `json:"isCancelled"`
 	IsDispatched bool `json:"isDispatched"`
 
+	// Status is the item lifecycle state. It is empty on items stored before
+	// the status machine; read it through EffectiveStatus / Order.ItemStatus.
+	Status Status `json:"status,omitempty"`
+
+	// Dispatch records the carrier and tracking number of the parcel.
+	Dispatch *DispatchSnapshot `json:"dispatch,omitempty"`
+
+	DeliveredAt *time.Time `json:"deliveredAt,omitempty"`
+	ReturnedAt  *time.Time `json:"returnedAt,omitempty"`
+
 	Transferred   bool       `json:"transferred"`
 	TransferredAt *time.Time `json:"transferredAt,omitempty"`
+
+	// Royalty is the brand royalty of a resale item (royalty.go).
+	Royalty *RoyaltySnapshot `json:"royalty,omitempty"`
+
+	// OfferID is the accepted market offer whose agreed price is Price.
+	OfferID string `json:"offerId,omitempty"`
+}
+
+// RefundItemSnapshot is the refunded portion of one Order item.
+type RefundItemSnapshot struct {
+	ItemIndex int `json:"itemIndex"`
+	Qty       int `json:"qty"`
+	Amount    int `json:"amount"`
+}
+
+// RefundSnapshot records a succeeded refund on the Order.
+//
+// RefundID is the refunds/{refundId} document ID. ApplyRefund is idempotent
+// per RefundID so a refund is never counted twice.
+type RefundSnapshot struct {
+	RefundID   string               `json:"refundId"`
+	Amount     int                  `json:"amount"`
+	Items      []RefundItemSnapshot `json:"items"`
+	RefundedAt time.Time            `json:"refundedAt"`
 }
 
 // ========================================
@@ -138,6 +192,15 @@ type Order struct {
 
 	Items     []OrderItemSnapshot `json:"items"`
 	CreatedAt time.Time           `json:"createdAt"`
+
+	// Refunds holds succeeded refunds only.
+	Refunds []RefundSnapshot `json:"refunds,omitempty"`
+
+	// Discount is the coupon applied at checkout (nil when none).
+	Discount *DiscountSnapshot `json:"discount,omitempty"`
+
+	// events are domain events not yet saved to the outbox (events.go).
+	events []outboxdom.Event
 }
 
 // ========================================
@@ -161,6 +224,7 @@ var (
 	ErrInvalidCreatedAt = errors.New("order: invalid createdAt")
 
 	ErrInvalidItemSnapshot = errors.New("order: invalid item snapshot")
+	ErrInvalidRefund       = errors.New("order: invalid refund snapshot")
 )
 
 // ========================================
@@ -170,6 +234,8 @@ var (
 const (
 	ShippingQuoteCurrencyJPY = "JPY"
 
+	DomesticCountry = "JP"
+
 	ConsumptionTaxRateReduced  = 8
 	ConsumptionTaxRateStandard = 10
 )
@@ -206,6 +272,12 @@ func New(
 		CreatedAt:             createdAt.UTC(),
 	}
 
+	for index := range o.Items {
+		if o.Items[index].Status == "" {
+			o.Items[index].Status = o.Items[index].EffectiveStatus(false)
+		}
+	}
+
 	if err := o.Validate(); err != nil {
 		return Order{}, err
 	}
@@ -217,11 +289,17 @@ func New(
 // Behavior (mutators)
 // ========================================
 
+// ReplaceItems is rejected once a coupon discount is recorded because the
+// discount is allocated per item index.
 func (o *Order) ReplaceItems(items []OrderItemSnapshot) error {
 	if err := validateItems(items); err != nil {
 		return err
 	}
 
+	if o.Discount != nil {
+		return ErrInvalidDiscount
+	}
+
 	o.Items = items
 	return nil
 }
@@ -279,6 +357,7 @@ func (o *Order) UpdateAvatarID(avatarID string) error {
 
 func (o *Order) UpdatePaid(paid bool) {
 	o.Paid = paid
+	o.syncItemStatusesWithPaid()
 }
 
 func (o *Order) CancelItem(
@@ -299,32 +378,16 @@ func (o *Order) CancelItem(
 	}
 
 	if item.IsDispatched ||
-		item.Transferred {
+		item.Transferred ||
+		!CanTransition(
+			item.EffectiveStatus(o.Paid),
+			StatusCancelled,
+		) {
 		return ErrConflict
 	}
 
 	item.IsCancelled = true
-	return nil
-}
-
-func (o *Order) UpdateItemDispatched(
-	index int,
-	isDispatched bool,
-) error {
-	if o == nil {
-		return ErrInvalidItems
-	}
-
-	if index < 0 || index >= len(o.Items) {
-		return ErrInvalidItems
-	}
-
-	if isDispatched &&
-		o.Items[index].IsCancelled {
-		return ErrConflict
-	}
-
-	o.Items[index].IsDispatched = isDispatched
+	item.Status = StatusCancelled
 	return nil
 }
 
@@ -363,6 +426,84 @@ func (o *Order) UpdateItemTransferred(
 	return nil
 }
 
+// ApplyRefund records a succeeded refund.
+// Applying a RefundID that is already recorded is a no-op.
+func (o *Order) ApplyRefund(
+	r RefundSnapshot,
+) (bool, error) {
+	if o == nil {
+		return false, ErrInvalidRefund
+	}
+
+	for _, existing := range o.Refunds {
+		if existing.RefundID == r.RefundID {
+			return false, nil
+		}
+	}
+
+	if r.RefundedAt.IsZero() {
+		return false, ErrInvalidRefund
+	}
+
+	r.RefundedAt = r.RefundedAt.UTC()
+	r.Items = append([]RefundItemSnapshot(nil), r.Items...)
+
+	next := append(
+		append([]RefundSnapshot(nil), o.Refunds...),
+		r,
+	)
+
+	if err := validateRefunds(
+		o.Paid,
+		o.Items,
+		next,
+	); err != nil {
+		return false, err
+	}
+
+	o.Refunds = next
+	return true, nil
+}
+
+// RefundedAmount returns the total amount of succeeded refunds.
+func (o Order) RefundedAmount() int {
+	total := 0
+	for _, r := range o.Refunds {
+		total += r.Amount
+	}
+
+	return total
+}
+
+// ItemRefundedQty returns the refunded qty of Items[index].
+func (o Order) ItemRefundedQty(index int) int {
+	total := 0
+	for _, r := range o.Refunds {
+		for _, item := range r.Items {
+			if item.ItemIndex == index {
+				total += item.Qty
+			}
+		}
+	}
+
+	return total
+}
+
+// ItemRefundedAmount returns the refunded amount of Items[index],
+// including its shipping share and consumption tax.
+func (o Order) ItemRefundedAmount(index int) int {
+	total := 0
+	for _, r := range o.Refunds {
+		for _, item := range r.Items {
+			if item.ItemIndex == index {
+				total += item.Amount
+			}
+		}
+	}
+
+	return total
+}
+
 // ========================================
 // Validation
 // ========================================
@@ -416,13 +557,88 @@ func (o Order) Validate() error {
 		return ErrInvalidCreatedAt
 	}
 
+	if err := validateRefunds(
+		o.Paid,
+		o.Items,
+		o.Refunds,
+	); err != nil {
+		return err
+	}
+
+	if err := validateDiscount(
+		o.Items,
+		o.ShippingQuoteSnapshot,
+		o.Discount,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateRefunds(
+	paid bool,
+	items []OrderItemSnapshot,
+	refunds []RefundSnapshot,
+) error {
+	if len(refunds) == 0 {
+		return nil
+	}
+
+	// Money can only be returned after it was collected.
+	if !paid {
+		return ErrInvalidRefund
+	}
+
+	seen := make(map[string]struct{}, len(refunds))
+	refundedQty := make(map[int]int, len(items))
+
+	for _, r := range refunds {
+		if r.RefundID == "" ||
+			r.Amount <= 0 ||
+			len(r.Items) == 0 ||
+			r.RefundedAt.IsZero() {
+			return ErrInvalidRefund
+		}
+
+		if _, exists := seen[r.RefundID]; exists {
+			return ErrInvalidRefund
+		}
+		seen[r.RefundID] = struct{}{}
+
+		total := 0
+
+		for _, item := range r.Items {
+			if item.ItemIndex < 0 ||
+				item.ItemIndex >= len(items) ||
+				item.Qty <= 0 ||
+				item.Amount < 0 {
+				return ErrInvalidRefund
+			}
+
+			refundedQty[item.ItemIndex] += item.Qty
+			if refundedQty[item.ItemIndex] >
+				items[item.ItemIndex].Qty {
+				return ErrInvalidRefund
+			}
+
+			total += item.Amount
+		}
+
+		if total != r.Amount {
+			return ErrInvalidRefund
+		}
+	}
+
 	return nil
 }
 
 func validateShippingSnapshot(
 	s ShippingSnapshot,
 ) error {
-	if s.State == "" {
+	// 国外は州・省がない国もあるため State を必須にしない。
+	if s.State == "" &&
+		(s.Country == "" || s.Country == DomesticCountry) {
 		return ErrInvalidShippingSnapshot
 	}
 
@@ -515,6 +731,16 @@ func validateShippingQuoteItemSnapshot(
 		return ErrInvalidShippingQuoteItem
 	}
 
+	if isInternationalShippingQuoteCarrier(item.Carrier) {
+		if err := validateCustomsDeclarationSnapshot(
+			item.Customs,
+		); err != nil {
+			return err
+		}
+	} else if item.Customs != nil {
+		return ErrInvalidShippingQuoteItem
+	}
+
 	if item.Carrier == "custom" {
 		if item.TransportationID == "" {
 			return ErrInvalidShippingQuoteItem
@@ -571,7 +797,9 @@ func isValidShippingQuoteCarrier(
 	case "yamato",
 		"sagawa",
 		"post",
-		"custom":
+		"custom",
+		"ems",
+		"epacket":
 		return true
 
 	default:
@@ -579,6 +807,38 @@ func isValidShippingQuoteCarrier(
 	}
 }
 
+func isInternationalShippingQuoteCarrier(
+	carrier string,
+) bool {
+	return carrier == "ems" ||
+		carrier == "epacket"
+}
+
+func validateCustomsDeclarationSnapshot(
+	c *CustomsDeclarationSnapshot,
+) error {
+	if c == nil {
+		return ErrInvalidShippingQuoteItem
+	}
+
+	if c.HSCode == "" ||
+		c.Description == "" ||
+		c.OriginCountry == "" {
+		return ErrInvalidShippingQuoteItem
+	}
+
+	if c.DeclaredUnitValue < 0 {
+		return ErrInvalidShippingQuoteItem
+	}
+
+	if c.Currency !=
+		ShippingQuoteCurrencyJPY {
+		return ErrInvalidShippingQuoteItem
+	}
+
+	return nil
+}
+
 func validatePaymentMethodSnapshot(
 	p PaymentMethodSnapshot,
 ) error {
@@ -641,6 +901,10 @@ func validateItemSnapshot(
 		return err
 	}
 
+	if err := validateRoyaltySnapshot(item); err != nil {
+		return err
+	}
+
 	switch item.Type {
 	case OrderItemTypeList:
 		return validateListItemSnapshot(item)
@@ -704,7 +968,8 @@ func validateListItemSnapshot(
 	// Resale-only identifiers must not be mixed into a list item.
 	if item.ResaleID != "" ||
 		item.ProductID != "" ||
-		item.BrandID != "" {
+		item.BrandID != "" ||
+		item.OfferID != "" {
 		return ErrInvalidItemSnapshot
 	}
 
@@ -763,6 +1028,10 @@ func validateResaleItemSnapshot(
 func validateItemTransferState(
 	item OrderItemSnapshot,
 ) error {
+	if err := validateItemStatus(item); err != nil {
+		return err
+	}
+
 	if item.IsCancelled {
 		if item.IsDispatched ||
 			item.Transferred ||
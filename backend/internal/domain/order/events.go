@@ -0,0 +1,92 @@
+// backend/internal/domain/order/events.go
+package order
+
+import (
+	"strings"
+	"time"
+
+	outboxdom "narratives/internal/domain/outbox"
+)
+
+// Domain events are recorded on the Order and written to the outbox by the
+// repository in the same transaction as the Order itself.
+
+// RecordCreated records OrderCreated. Call it once, before Repository.Create.
+func (o *Order) RecordCreated(now time.Time) error {
+	if o == nil {
+		return ErrInvalidID
+	}
+
+	return o.recordEvent(
+		outboxdom.EventOrderCreated,
+		outboxdom.OrderCreatedPayload{
+			OrderID:  o.ID,
+			UserID:   o.UserID,
+			AvatarID: o.AvatarID,
+		},
+		now,
+	)
+}
+
+// RecordItemsDispatched records ItemDispatched for the items of inventoryIDs
+// that companyID has just dispatched.
+func (o *Order) RecordItemsDispatched(
+	companyID string,
+	inventoryIDs []string,
+	now time.Time,
+) error {
+	if o == nil {
+		return ErrInvalidID
+	}
+
+	companyID = strings.TrimSpace(companyID)
+	if companyID == "" {
+		return ErrDispatchNotificationCompanyIDRequired
+	}
+	if len(inventoryIDs) == 0 {
+		return ErrDispatchNotificationItemsRequired
+	}
+
+	return o.recordEvent(
+		outboxdom.EventItemDispatched,
+		outboxdom.ItemDispatchedPayload{
+			OrderID:      o.ID,
+			CompanyID:    companyID,
+			InventoryIDs: append([]string(nil), inventoryIDs...),
+		},
+		now,
+	)
+}
+
+// PendingEvents returns the events not yet saved to the outbox.
+func (o Order) PendingEvents() []outboxdom.Event {
+	return append([]outboxdom.Event(nil), o.events...)
+}
+
+// ClearEvents drops the pending events after the repository saved them.
+func (o *Order) ClearEvents() {
+	if o == nil {
+		return
+	}
+	o.events = nil
+}
+
+func (o *Order) recordEvent(
+	eventType outboxdom.EventType,
+	payload any,
+	now time.Time,
+) error {
+	e, err := outboxdom.NewEvent(
+		eventType,
+		outboxdom.AggregateOrder,
+		o.ID,
+		payload,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	o.events = append(o.events, e)
+	return nil
+}
@@ -0,0 +1,71 @@
+// backend/internal/domain/order/royalty.go
+package order
+
+import "errors"
+
+// RoyaltySnapshot is the brand royalty on a resale item.
+//
+// It is computed from the token blueprint's royalty policy when the Order is
+// created, so later policy changes do not affect placed orders. Amount is
+// taken from the item subtotal (price * qty, tax-exclusive) and is deducted
+// from the reseller's proceeds; the buyer's payment amount is unchanged.
+type RoyaltySnapshot struct {
+	// CompanyID is the company that owns the token blueprint.
+	CompanyID string `json:"companyId"`
+
+	BasisPoints   int `json:"basisPoints"`
+	MinimumAmount int `json:"minimumAmount"`
+
+	Amount int `json:"amount"`
+}
+
+var ErrInvalidRoyalty = errors.New("order: invalid royalty snapshot")
+
+// ItemRoyaltyAmount returns the royalty of Items[index]. Cancelled items
+// have no royalty.
+func (o Order) ItemRoyaltyAmount(index int) int {
+	if index < 0 || index >= len(o.Items) {
+		return 0
+	}
+
+	item := o.Items[index]
+	if item.IsCancelled || item.Royalty == nil {
+		return 0
+	}
+
+	return item.Royalty.Amount
+}
+
+// RoyaltyAmount returns the total royalty of the Order.
+func (o Order) RoyaltyAmount() int {
+	total := 0
+	for index := range o.Items {
+		total += o.ItemRoyaltyAmount(index)
+	}
+
+	return total
+}
+
+func validateRoyaltySnapshot(
+	item OrderItemSnapshot,
+) error {
+	r := item.Royalty
+	if r == nil {
+		return nil
+	}
+
+	// Royalty is paid only on secondary sales.
+	if item.Type != OrderItemTypeResale {
+		return ErrInvalidRoyalty
+	}
+
+	if r.CompanyID == "" ||
+		r.BasisPoints < 0 ||
+		r.MinimumAmount < 0 ||
+		r.Amount < 0 ||
+		r.Amount > item.Price*item.Qty {
+		return ErrInvalidRoyalty
+	}
+
+	return nil
+}
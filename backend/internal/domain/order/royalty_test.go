@@ -0,0 +1,75 @@
+// backend/internal/domain/order/royalty_test.go
+package order
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrder_RoyaltyAmount(t *testing.T) {
+	o := Order{
+		Items: []OrderItemSnapshot{
+			{Type: OrderItemTypeResale, Price: 10000, Qty: 1, Royalty: &RoyaltySnapshot{CompanyID: "company_1", BasisPoints: 500, Amount: 500}},
+			{Type: OrderItemTypeResale, Price: 3000, Qty: 1, Royalty: &RoyaltySnapshot{CompanyID: "company_1", MinimumAmount: 300, Amount: 300}, IsCancelled: true},
+			{Type: OrderItemTypeList, Price: 2000, Qty: 1},
+		},
+	}
+
+	tests := []struct {
+		index int
+		want  int
+	}{
+		{index: 0, want: 500},
+		{index: 1, want: 0},
+		{index: 2, want: 0},
+		{index: 3, want: 0},
+		{index: -1, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := o.ItemRoyaltyAmount(tt.index); got != tt.want {
+			t.Errorf("ItemRoyaltyAmount(%d) = %d, want %d", tt.index, got, tt.want)
+		}
+	}
+
+	if got := o.RoyaltyAmount(); got != 500 {
+		t.Fatalf("RoyaltyAmount = %d, want 500", got)
+	}
+}
+
+func TestValidateRoyaltySnapshot(t *testing.T) {
+	tests := []struct {
+		name string
+		item OrderItemSnapshot
+		want error
+	}{
+		{name: "no royalty", item: OrderItemSnapshot{Type: OrderItemTypeList}},
+		{
+			name: "resale",
+			item: OrderItemSnapshot{Type: OrderItemTypeResale, Price: 1000, Qty: 2, Royalty: &RoyaltySnapshot{CompanyID: "company_1", Amount: 2000}},
+		},
+		{
+			name: "primary sale",
+			item: OrderItemSnapshot{Type: OrderItemTypeList, Price: 1000, Qty: 1, Royalty: &RoyaltySnapshot{CompanyID: "company_1", Amount: 100}},
+			want: ErrInvalidRoyalty,
+		},
+		{
+			name: "exceeds subtotal",
+			item: OrderItemSnapshot{Type: OrderItemTypeResale, Price: 1000, Qty: 2, Royalty: &RoyaltySnapshot{CompanyID: "company_1", Amount: 2001}},
+			want: ErrInvalidRoyalty,
+		},
+		{
+			name: "missing company",
+			item: OrderItemSnapshot{Type: OrderItemTypeResale, Price: 1000, Qty: 1, Royalty: &RoyaltySnapshot{Amount: 100}},
+			want: ErrInvalidRoyalty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateRoyaltySnapshot(tt.item); !errors.Is(err, tt.want) {
+				t.Fatalf("validateRoyaltySnapshot err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
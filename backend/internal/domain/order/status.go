@@ -0,0 +1,403 @@
+// backend/internal/domain/order/status.go
+package order
+
+import (
+	"errors"
+	"time"
+
+	transportationdom "narratives/internal/domain/transportation"
+)
+
+// ========================================
+// Status machine
+// ========================================
+
+// Status is the lifecycle state of an order item.
+// The order-level status is derived from its items (see Order.Status).
+//
+//	pending ──> paid ──> preparing ──> dispatched ──> delivered ──> returned
+//	   │          │          │              └──────────────────────────^
+//	   │          │          └──> cancelled
+//	   │          └──> dispatched / cancelled
+//	   └──> cancelled
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusPaid       Status = "paid"
+	StatusPreparing  Status = "preparing"
+	StatusDispatched Status = "dispatched"
+	StatusDelivered  Status = "delivered"
+	StatusReturned   Status = "returned"
+	StatusCancelled  Status = "cancelled"
+)
+
+var (
+	ErrInvalidStatus           = errors.New("order: invalid status")
+	ErrInvalidStatusTransition = errors.New("order: invalid status transition")
+	ErrInvalidDispatch         = errors.New("order: invalid dispatch snapshot")
+)
+
+var statusTransitions = map[Status][]Status{
+	StatusPending:    {StatusPaid, StatusCancelled},
+	StatusPaid:       {StatusPreparing, StatusDispatched, StatusCancelled},
+	StatusPreparing:  {StatusDispatched, StatusCancelled},
+	StatusDispatched: {StatusDelivered, StatusReturned},
+	StatusDelivered:  {StatusReturned},
+}
+
+// statusRank orders the forward progress of a non-cancelled item.
+var statusRank = map[Status]int{
+	StatusPending:    0,
+	StatusPaid:       1,
+	StatusPreparing:  2,
+	StatusDispatched: 3,
+	StatusDelivered:  4,
+	StatusReturned:   5,
+}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending,
+		StatusPaid,
+		StatusPreparing,
+		StatusDispatched,
+		StatusDelivered,
+		StatusReturned,
+		StatusCancelled:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// IsShipped reports whether the item has physically left the warehouse.
+func (s Status) IsShipped() bool {
+	return s == StatusDispatched ||
+		s == StatusDelivered ||
+		s == StatusReturned
+}
+
+// CanTransition reports whether from -> to is allowed.
+// Staying in the same status is not a transition.
+func CanTransition(from Status, to Status) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DispatchSnapshot records the parcel an item went out in.
+type DispatchSnapshot struct {
+	Carrier        transportationdom.Carrier `json:"carrier"`
+	TrackingNumber string                    `json:"trackingNumber,omitempty"`
+	DispatchedAt   time.Time                 `json:"dispatchedAt"`
+}
+
+// NewDispatchSnapshot normalizes the tracking number (hyphens and spaces are
+// removed). TrackingNumber may be empty, e.g. for a company's own delivery.
+func NewDispatchSnapshot(
+	carrier transportationdom.Carrier,
+	trackingNumber string,
+	dispatchedAt time.Time,
+) (DispatchSnapshot, error) {
+	normalized, err := transportationdom.NormalizeTrackingNumber(
+		trackingNumber,
+	)
+	if err != nil {
+		return DispatchSnapshot{}, err
+	}
+
+	d := DispatchSnapshot{
+		Carrier:        carrier,
+		TrackingNumber: normalized,
+		DispatchedAt:   dispatchedAt.UTC(),
+	}
+
+	if err := d.Validate(); err != nil {
+		return DispatchSnapshot{}, err
+	}
+
+	return d, nil
+}
+
+func (d DispatchSnapshot) Validate() error {
+	if !transportationdom.IsValidCarrier(d.Carrier) {
+		return ErrInvalidDispatch
+	}
+
+	if len(d.TrackingNumber) > transportationdom.MaxTrackingNumberLength {
+		return ErrInvalidDispatch
+	}
+
+	if d.DispatchedAt.IsZero() {
+		return ErrInvalidDispatch
+	}
+
+	return nil
+}
+
+// TrackingURL returns the carrier's tracking page, or "" when unavailable.
+func (d DispatchSnapshot) TrackingURL() string {
+	return transportationdom.TrackingURL(
+		d.Carrier,
+		d.TrackingNumber,
+	)
+}
+
+// ========================================
+// Status accessors
+// ========================================
+
+// EffectiveStatus returns the item status.
+// Items stored before the status machine have an empty Status; their state
+// is derived from the legacy booleans and the order-level Paid flag.
+func (item OrderItemSnapshot) EffectiveStatus(paid bool) Status {
+	if item.Status != "" {
+		return item.Status
+	}
+
+	switch {
+	case item.IsCancelled:
+		return StatusCancelled
+	case item.IsDispatched:
+		return StatusDispatched
+	case paid:
+		return StatusPaid
+	default:
+		return StatusPending
+	}
+}
+
+// ItemStatus returns the effective status of Items[index].
+func (o Order) ItemStatus(index int) Status {
+	if index < 0 || index >= len(o.Items) {
+		return ""
+	}
+
+	return o.Items[index].EffectiveStatus(o.Paid)
+}
+
+// Status returns the order-level status: the least advanced status among
+// non-cancelled items, or cancelled when every item is cancelled.
+func (o Order) Status() Status {
+	result := StatusCancelled
+
+	for index := range o.Items {
+		status := o.ItemStatus(index)
+		if status == StatusCancelled {
+			continue
+		}
+
+		if result == StatusCancelled ||
+			statusRank[status] < statusRank[result] {
+			result = status
+		}
+	}
+
+	return result
+}
+
+// ========================================
+// Transitions
+// ========================================
+
+// MarkItemPreparing moves Items[index] to preparing.
+// It returns false when the item is already preparing.
+func (o *Order) MarkItemPreparing(index int) (bool, error) {
+	item, current, err := o.itemForTransition(index)
+	if err != nil {
+		return false, err
+	}
+
+	if current == StatusPreparing {
+		return false, nil
+	}
+
+	if !CanTransition(current, StatusPreparing) {
+		return false, ErrInvalidStatusTransition
+	}
+
+	item.Status = StatusPreparing
+	return true, nil
+}
+
+// DispatchItem moves Items[index] to dispatched and records the parcel.
+// Re-dispatching an item that is still dispatched replaces its parcel
+// (e.g. to add or correct a tracking number).
+func (o *Order) DispatchItem(
+	index int,
+	dispatch DispatchSnapshot,
+) (bool, error) {
+	if err := dispatch.Validate(); err != nil {
+		return false, err
+	}
+
+	item, current, err := o.itemForTransition(index)
+	if err != nil {
+		return false, err
+	}
+
+	if current == StatusDispatched {
+		if item.Dispatch != nil &&
+			item.Dispatch.Carrier == dispatch.Carrier &&
+			item.Dispatch.TrackingNumber == dispatch.TrackingNumber {
+			return false, nil
+		}
+
+		if item.Dispatch != nil {
+			dispatch.DispatchedAt = item.Dispatch.DispatchedAt
+		}
+	} else if !CanTransition(current, StatusDispatched) {
+		return false, ErrInvalidStatusTransition
+	}
+
+	item.Status = StatusDispatched
+	item.IsDispatched = true
+	item.Dispatch = &dispatch
+	return true, nil
+}
+
+// MarkItemDelivered moves Items[index] to delivered.
+func (o *Order) MarkItemDelivered(
+	index int,
+	at time.Time,
+) (bool, error) {
+	if at.IsZero() {
+		return false, ErrInvalidItemSnapshot
+	}
+
+	item, current, err := o.itemForTransition(index)
+	if err != nil {
+		return false, err
+	}
+
+	if current == StatusDelivered {
+		return false, nil
+	}
+
+	if !CanTransition(current, StatusDelivered) {
+		return false, ErrInvalidStatusTransition
+	}
+
+	deliveredAt := at.UTC()
+	item.Status = StatusDelivered
+	item.DeliveredAt = &deliveredAt
+	return true, nil
+}
+
+// MarkItemReturned moves Items[index] to returned.
+func (o *Order) MarkItemReturned(
+	index int,
+	at time.Time,
+) (bool, error) {
+	if at.IsZero() {
+		return false, ErrInvalidItemSnapshot
+	}
+
+	item, current, err := o.itemForTransition(index)
+	if err != nil {
+		return false, err
+	}
+
+	if current == StatusReturned {
+		return false, nil
+	}
+
+	if !CanTransition(current, StatusReturned) {
+		return false, ErrInvalidStatusTransition
+	}
+
+	returnedAt := at.UTC()
+	item.Status = StatusReturned
+	item.ReturnedAt = &returnedAt
+	return true, nil
+}
+
+// itemForTransition returns Items[index] with its effective status.
+func (o *Order) itemForTransition(
+	index int,
+) (*OrderItemSnapshot, Status, error) {
+	if o == nil {
+		return nil, "", ErrInvalidItems
+	}
+
+	if index < 0 || index >= len(o.Items) {
+		return nil, "", ErrInvalidItems
+	}
+
+	return &o.Items[index], o.Items[index].EffectiveStatus(o.Paid), nil
+}
+
+// syncItemStatusesWithPaid keeps pending/paid items aligned with Order.Paid.
+func (o *Order) syncItemStatusesWithPaid() {
+	for index := range o.Items {
+		item := &o.Items[index]
+
+		switch {
+		case o.Paid && item.Status == StatusPending:
+			item.Status = StatusPaid
+		case !o.Paid && item.Status == StatusPaid:
+			item.Status = StatusPending
+		}
+	}
+}
+
+// ========================================
+// Validation
+// ========================================
+
+func validateItemStatus(
+	item OrderItemSnapshot,
+) error {
+	if item.Status == "" {
+		// Legacy item: no status-machine fields may be present.
+		if item.Dispatch != nil ||
+			item.DeliveredAt != nil ||
+			item.ReturnedAt != nil {
+			return ErrInvalidItemSnapshot
+		}
+
+		return nil
+	}
+
+	if !item.Status.IsValid() {
+		return ErrInvalidStatus
+	}
+
+	if item.IsCancelled != (item.Status == StatusCancelled) {
+		return ErrInvalidItemSnapshot
+	}
+
+	if item.IsDispatched != item.Status.IsShipped() {
+		return ErrInvalidItemSnapshot
+	}
+
+	// Items dispatched before the status machine have no parcel record,
+	// so Dispatch is optional once shipped.
+	if item.Dispatch != nil {
+		if !item.Status.IsShipped() {
+			return ErrInvalidDispatch
+		}
+
+		if err := item.Dispatch.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if (item.Status == StatusDelivered) &&
+		(item.DeliveredAt == nil || item.DeliveredAt.IsZero()) {
+		return ErrInvalidItemSnapshot
+	}
+
+	if (item.Status == StatusReturned) !=
+		(item.ReturnedAt != nil && !item.ReturnedAt.IsZero()) {
+		return ErrInvalidItemSnapshot
+	}
+
+	return nil
+}
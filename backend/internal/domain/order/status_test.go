@@ -0,0 +1,235 @@
+// backend/internal/domain/order/status_test.go
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	transportationdom "narratives/internal/domain/transportation"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+var allStatuses = []Status{
+	StatusPending,
+	StatusPaid,
+	StatusPreparing,
+	StatusDispatched,
+	StatusDelivered,
+	StatusReturned,
+	StatusCancelled,
+}
+
+func TestCanTransition(t *testing.T) {
+	allowed := map[Status][]Status{
+		StatusPending:    {StatusPaid, StatusCancelled},
+		StatusPaid:       {StatusPreparing, StatusDispatched, StatusCancelled},
+		StatusPreparing:  {StatusDispatched, StatusCancelled},
+		StatusDispatched: {StatusDelivered, StatusReturned},
+		StatusDelivered:  {StatusReturned},
+	}
+
+	for _, from := range allStatuses {
+		for _, to := range allStatuses {
+			want := false
+			for _, s := range allowed[from] {
+				if s == to {
+					want = true
+				}
+			}
+
+			if got := CanTransition(from, to); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestOrderItemSnapshot_EffectiveStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		item OrderItemSnapshot
+		paid bool
+		want Status
+	}{
+		{name: "explicit status wins", item: OrderItemSnapshot{Status: StatusDelivered, IsDispatched: true}, paid: true, want: StatusDelivered},
+		{name: "legacy unpaid", item: OrderItemSnapshot{}, paid: false, want: StatusPending},
+		{name: "legacy paid", item: OrderItemSnapshot{}, paid: true, want: StatusPaid},
+		{name: "legacy dispatched", item: OrderItemSnapshot{IsDispatched: true}, paid: true, want: StatusDispatched},
+		{name: "legacy cancelled wins over dispatched", item: OrderItemSnapshot{IsCancelled: true, IsDispatched: true}, paid: true, want: StatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.EffectiveStatus(tt.paid); got != tt.want {
+				t.Fatalf("EffectiveStatus = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrder_Status(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []Status
+		want  Status
+	}{
+		{name: "least advanced item", items: []Status{StatusDelivered, StatusPreparing, StatusDispatched}, want: StatusPreparing},
+		{name: "cancelled items are ignored", items: []Status{StatusCancelled, StatusDispatched}, want: StatusDispatched},
+		{name: "all cancelled", items: []Status{StatusCancelled, StatusCancelled}, want: StatusCancelled},
+		{name: "returned and delivered", items: []Status{StatusReturned, StatusDelivered}, want: StatusDelivered},
+		{name: "no items", items: nil, want: StatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := Order{Paid: true}
+			for _, s := range tt.items {
+				o.Items = append(o.Items, OrderItemSnapshot{Status: s})
+			}
+
+			if got := o.Status(); got != tt.want {
+				t.Fatalf("Status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrder_ItemTransitions(t *testing.T) {
+	dispatch := DispatchSnapshot{
+		Carrier:        transportationdom.CarrierYamato,
+		TrackingNumber: "123456789012",
+		DispatchedAt:   testNow,
+	}
+
+	preparing := func(o *Order) (bool, error) { return o.MarkItemPreparing(0) }
+	dispatchItem := func(o *Order) (bool, error) { return o.DispatchItem(0, dispatch) }
+	delivered := func(o *Order) (bool, error) { return o.MarkItemDelivered(0, testNow) }
+	returned := func(o *Order) (bool, error) { return o.MarkItemReturned(0, testNow) }
+
+	tests := []struct {
+		name        string
+		from        Status
+		apply       func(o *Order) (bool, error)
+		wantChanged bool
+		wantStatus  Status
+		wantErr     error
+	}{
+		{name: "prepare paid", from: StatusPaid, apply: preparing, wantChanged: true, wantStatus: StatusPreparing},
+		{name: "prepare preparing is a no-op", from: StatusPreparing, apply: preparing, wantStatus: StatusPreparing},
+		{name: "prepare pending", from: StatusPending, apply: preparing, wantStatus: StatusPending, wantErr: ErrInvalidStatusTransition},
+		{name: "dispatch paid", from: StatusPaid, apply: dispatchItem, wantChanged: true, wantStatus: StatusDispatched},
+		{name: "dispatch preparing", from: StatusPreparing, apply: dispatchItem, wantChanged: true, wantStatus: StatusDispatched},
+		{name: "dispatch pending", from: StatusPending, apply: dispatchItem, wantStatus: StatusPending, wantErr: ErrInvalidStatusTransition},
+		{name: "dispatch cancelled", from: StatusCancelled, apply: dispatchItem, wantStatus: StatusCancelled, wantErr: ErrInvalidStatusTransition},
+		{name: "dispatch delivered", from: StatusDelivered, apply: dispatchItem, wantStatus: StatusDelivered, wantErr: ErrInvalidStatusTransition},
+		{name: "deliver dispatched", from: StatusDispatched, apply: delivered, wantChanged: true, wantStatus: StatusDelivered},
+		{name: "deliver delivered is a no-op", from: StatusDelivered, apply: delivered, wantStatus: StatusDelivered},
+		{name: "deliver paid", from: StatusPaid, apply: delivered, wantStatus: StatusPaid, wantErr: ErrInvalidStatusTransition},
+		{name: "return dispatched", from: StatusDispatched, apply: returned, wantChanged: true, wantStatus: StatusReturned},
+		{name: "return delivered", from: StatusDelivered, apply: returned, wantChanged: true, wantStatus: StatusReturned},
+		{name: "return returned is a no-op", from: StatusReturned, apply: returned, wantStatus: StatusReturned},
+		{name: "return preparing", from: StatusPreparing, apply: returned, wantStatus: StatusPreparing, wantErr: ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := Order{
+				Paid:  tt.from != StatusPending,
+				Items: []OrderItemSnapshot{{Status: tt.from}},
+			}
+
+			changed, err := tt.apply(&o)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if got := o.ItemStatus(0); got != tt.wantStatus {
+				t.Errorf("ItemStatus = %s, want %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestOrder_ItemTransitions_InvalidIndex(t *testing.T) {
+	o := Order{Paid: true, Items: []OrderItemSnapshot{{Status: StatusPaid}}}
+
+	if _, err := o.MarkItemPreparing(1); !errors.Is(err, ErrInvalidItems) {
+		t.Fatalf("MarkItemPreparing(1) err = %v, want %v", err, ErrInvalidItems)
+	}
+	if _, err := o.MarkItemPreparing(-1); !errors.Is(err, ErrInvalidItems) {
+		t.Fatalf("MarkItemPreparing(-1) err = %v, want %v", err, ErrInvalidItems)
+	}
+}
+
+func TestOrder_DispatchItem_Redispatch(t *testing.T) {
+	first := DispatchSnapshot{
+		Carrier:        transportationdom.CarrierYamato,
+		TrackingNumber: "111122223333",
+		DispatchedAt:   testNow,
+	}
+
+	o := Order{Paid: true, Items: []OrderItemSnapshot{{Status: StatusPaid}}}
+	if _, err := o.DispatchItem(0, first); err != nil {
+		t.Fatalf("DispatchItem: %v", err)
+	}
+
+	// 同じ伝票での再送は変更なし。
+	changed, err := o.DispatchItem(0, first)
+	if err != nil || changed {
+		t.Fatalf("DispatchItem(same) = %v, %v, want false, nil", changed, err)
+	}
+
+	// 伝票番号の訂正は発送日時を維持して差し替える。
+	corrected := first
+	corrected.TrackingNumber = "444455556666"
+	corrected.DispatchedAt = testNow.Add(time.Hour)
+
+	changed, err = o.DispatchItem(0, corrected)
+	if err != nil || !changed {
+		t.Fatalf("DispatchItem(corrected) = %v, %v, want true, nil", changed, err)
+	}
+
+	got := o.Items[0].Dispatch
+	if got.TrackingNumber != "444455556666" {
+		t.Errorf("TrackingNumber = %q, want 444455556666", got.TrackingNumber)
+	}
+	if !got.DispatchedAt.Equal(testNow) {
+		t.Errorf("DispatchedAt = %v, want %v", got.DispatchedAt, testNow)
+	}
+	if !o.Items[0].IsDispatched {
+		t.Errorf("IsDispatched = false, want true")
+	}
+}
+
+func TestNewDispatchSnapshot(t *testing.T) {
+	tests := []struct {
+		name         string
+		carrier      transportationdom.Carrier
+		tracking     string
+		at           time.Time
+		wantTracking string
+		wantErr      error
+	}{
+		{name: "normalizes tracking number", carrier: transportationdom.CarrierPost, tracking: " 1234-5678 9012 ", at: testNow, wantTracking: "123456789012"},
+		{name: "empty tracking number", carrier: transportationdom.CarrierCustom, tracking: "", at: testNow, wantTracking: ""},
+		{name: "invalid carrier", carrier: "drone", tracking: "1", at: testNow, wantErr: ErrInvalidDispatch},
+		{name: "zero dispatchedAt", carrier: transportationdom.CarrierYamato, tracking: "1", wantErr: ErrInvalidDispatch},
+		{name: "invalid tracking number", carrier: transportationdom.CarrierYamato, tracking: "12#34", at: testNow, wantErr: transportationdom.ErrInvalidTrackingNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewDispatchSnapshot(tt.carrier, tt.tracking, tt.at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.TrackingNumber != tt.wantTracking {
+				t.Errorf("TrackingNumber = %q, want %q", got.TrackingNumber, tt.wantTracking)
+			}
+		})
+	}
+}
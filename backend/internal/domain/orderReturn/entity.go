@@ -0,0 +1,781 @@
+// backend/internal/domain/orderReturn/entity.go
+package orderReturn
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	transportationdom "narratives/internal/domain/transportation"
+)
+
+// ========================================
+// Status
+// ========================================
+
+// Status is the lifecycle state of a return request.
+//
+//	requested ──> approved ──> received ──> completed
+//	    │             │
+//	    │             └──> cancelled
+//	    ├──> rejected
+//	    └──> cancelled
+type Status string
+
+const (
+	StatusRequested Status = "requested"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusCancelled Status = "cancelled"
+	StatusReceived  Status = "received"
+	StatusCompleted Status = "completed"
+)
+
+var statusTransitions = map[Status][]Status{
+	StatusRequested: {StatusApproved, StatusRejected, StatusCancelled},
+	StatusApproved:  {StatusReceived, StatusCancelled},
+	StatusReceived:  {StatusCompleted},
+}
+
+func IsValidStatus(s Status) bool {
+	switch s {
+	case StatusRequested,
+		StatusApproved,
+		StatusRejected,
+		StatusCancelled,
+		StatusReceived,
+		StatusCompleted:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// IsOpen reports whether the return still blocks a new request for the same
+// order item. rejected and cancelled returns release the item.
+func (s Status) IsOpen() bool {
+	return s != StatusRejected &&
+		s != StatusCancelled
+}
+
+// CanTransition reports whether from -> to is allowed.
+func CanTransition(from Status, to Status) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ========================================
+// Reason / Resolution
+// ========================================
+
+// Reason is the buyer-selected return reason.
+type Reason string
+
+const (
+	ReasonDefective        Reason = "defective"
+	ReasonDamagedInTransit Reason = "damaged_in_transit"
+	ReasonWrongItem        Reason = "wrong_item"
+	ReasonNotAsDescribed   Reason = "not_as_described"
+	ReasonSizeFit          Reason = "size_fit"
+	ReasonChangedMind      Reason = "changed_mind"
+	ReasonOther            Reason = "other"
+)
+
+func IsValidReason(r Reason) bool {
+	switch r {
+	case ReasonDefective,
+		ReasonDamagedInTransit,
+		ReasonWrongItem,
+		ReasonNotAsDescribed,
+		ReasonSizeFit,
+		ReasonChangedMind,
+		ReasonOther:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// Resolution is what the buyer receives once the item is back.
+//   - refund:      the item is refunded through the refund flow
+//   - replacement: a zero-priced replacement order is created
+//   - none:        the item is taken back without compensation
+type Resolution string
+
+const (
+	ResolutionRefund      Resolution = "refund"
+	ResolutionReplacement Resolution = "replacement"
+	ResolutionNone        Resolution = "none"
+)
+
+func IsValidResolution(r Resolution) bool {
+	switch r {
+	case ResolutionRefund,
+		ResolutionReplacement,
+		ResolutionNone:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// ========================================
+// Snapshots
+// ========================================
+
+// ShippingQuote is the return shipping fee quoted at approval
+// (buyer address -> inventory storage address).
+type ShippingQuote struct {
+	Carrier transportationdom.Carrier `json:"carrier"`
+
+	Size     int    `json:"size"`
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+
+	QuotedAt time.Time `json:"quotedAt"`
+}
+
+// TokenReturn records the NFT of one returned product sent back to the
+// brand wallet.
+type TokenReturn struct {
+	ProductID   string    `json:"productId"`
+	AssetID     string    `json:"assetId"`
+	TxSignature string    `json:"txSignature"`
+	ReturnedAt  time.Time `json:"returnedAt"`
+}
+
+// ========================================
+// Entity
+// ========================================
+
+// Return is a buyer's return request for one list item of an Order.
+//
+// Firestore rule:
+//   - return document ID is Return.ID.
+//   - at most one open return (see Status.IsOpen) exists per order item.
+//   - ProductIDs are the products whose NFTs were transferred to the buyer;
+//     it is empty when the item was returned before the buyer scanned it.
+type Return struct {
+	ID        string `json:"id"`
+	OrderID   string `json:"orderId"`
+	ItemIndex int    `json:"itemIndex"`
+
+	UserID   string `json:"userId"`
+	AvatarID string `json:"avatarId"`
+
+	CompanyID string `json:"companyId"`
+	BrandID   string `json:"brandId,omitempty"`
+
+	InventoryID        string `json:"inventoryId"`
+	ModelID            string `json:"modelId"`
+	ListID             string `json:"listId"`
+	ProductBlueprintID string `json:"productBlueprintId"`
+	TokenBlueprintID   string `json:"tokenBlueprintId"`
+
+	Qty        int      `json:"qty"`
+	ProductIDs []string `json:"productIds"`
+
+	Reasons []Reason `json:"reasons"`
+	Comment string   `json:"comment,omitempty"`
+
+	Resolution Resolution `json:"resolution"`
+	Status     Status     `json:"status"`
+
+	ReturnShippingQuote *ShippingQuote `json:"returnShippingQuote,omitempty"`
+
+	ReviewNote string     `json:"reviewNote,omitempty"`
+	ReviewedBy string     `json:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+
+	ReceivedBy string     `json:"receivedBy,omitempty"`
+	ReceivedAt *time.Time `json:"receivedAt,omitempty"`
+
+	// Restocked is true once the products are back in inventory.ModelStock.
+	Restocked bool `json:"restocked"`
+
+	TokenReturns []TokenReturn `json:"tokenReturns,omitempty"`
+
+	RefundID           string `json:"refundId,omitempty"`
+	ReplacementOrderID string `json:"replacementOrderId,omitempty"`
+
+	CompletedBy string     `json:"completedBy,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CancelledAt *time.Time `json:"cancelledAt,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ========================================
+// Errors
+// ========================================
+
+var (
+	ErrInvalidID            = errors.New("orderReturn: invalid id")
+	ErrInvalidOrderID       = errors.New("orderReturn: invalid orderId")
+	ErrInvalidItemIndex     = errors.New("orderReturn: invalid itemIndex")
+	ErrInvalidAvatarID      = errors.New("orderReturn: invalid avatarId")
+	ErrInvalidCompanyID     = errors.New("orderReturn: invalid companyId")
+	ErrInvalidItem          = errors.New("orderReturn: invalid item snapshot")
+	ErrInvalidQty           = errors.New("orderReturn: invalid qty")
+	ErrInvalidProductIDs    = errors.New("orderReturn: invalid productIds")
+	ErrInvalidReason        = errors.New("orderReturn: invalid reason")
+	ErrInvalidComment       = errors.New("orderReturn: invalid comment")
+	ErrInvalidResolution    = errors.New("orderReturn: invalid resolution")
+	ErrInvalidStatus        = errors.New("orderReturn: invalid status")
+	ErrInvalidTransition    = errors.New("orderReturn: invalid status transition")
+	ErrInvalidShippingQuote = errors.New("orderReturn: invalid return shipping quote")
+	ErrInvalidTokenReturn   = errors.New("orderReturn: invalid token return")
+	ErrInvalidActor         = errors.New("orderReturn: invalid actor")
+	ErrInvalidTimestamp     = errors.New("orderReturn: invalid timestamp")
+	ErrNotRestocked         = errors.New("orderReturn: products are not restocked")
+	ErrResolutionPending    = errors.New("orderReturn: resolution is not applied")
+)
+
+// ========================================
+// Policy
+// ========================================
+
+const (
+	MaxReasons       = 3
+	MaxCommentLength = 2000
+	MaxNoteLength    = 2000
+
+	ShippingQuoteCurrencyJPY = "JPY"
+)
+
+// ========================================
+// Constructor
+// ========================================
+
+type NewInput struct {
+	ID        string
+	OrderID   string
+	ItemIndex int
+
+	UserID   string
+	AvatarID string
+
+	CompanyID string
+	BrandID   string
+
+	InventoryID        string
+	ModelID            string
+	ListID             string
+	ProductBlueprintID string
+	TokenBlueprintID   string
+
+	Qty        int
+	ProductIDs []string
+
+	Reasons    []Reason
+	Comment    string
+	Resolution Resolution
+
+	CreatedAt time.Time
+}
+
+func New(in NewInput) (Return, error) {
+	createdAt := in.CreatedAt.UTC()
+
+	r := Return{
+		ID:        strings.TrimSpace(in.ID),
+		OrderID:   strings.TrimSpace(in.OrderID),
+		ItemIndex: in.ItemIndex,
+
+		UserID:   strings.TrimSpace(in.UserID),
+		AvatarID: strings.TrimSpace(in.AvatarID),
+
+		CompanyID: strings.TrimSpace(in.CompanyID),
+		BrandID:   strings.TrimSpace(in.BrandID),
+
+		InventoryID:        strings.TrimSpace(in.InventoryID),
+		ModelID:            strings.TrimSpace(in.ModelID),
+		ListID:             strings.TrimSpace(in.ListID),
+		ProductBlueprintID: strings.TrimSpace(in.ProductBlueprintID),
+		TokenBlueprintID:   strings.TrimSpace(in.TokenBlueprintID),
+
+		Qty:        in.Qty,
+		ProductIDs: normalizeProductIDs(in.ProductIDs),
+
+		Reasons:    normalizeReasons(in.Reasons),
+		Comment:    strings.TrimSpace(in.Comment),
+		Resolution: in.Resolution,
+
+		Status: StatusRequested,
+
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+
+	if r.Resolution == "" {
+		r.Resolution = ResolutionRefund
+	}
+
+	if err := r.Validate(); err != nil {
+		return Return{}, err
+	}
+
+	return r, nil
+}
+
+// ========================================
+// Behavior
+// ========================================
+
+// Approve accepts the request. resolution may override the buyer's choice;
+// quote is nil when the return shipping fee could not be quoted
+// (e.g. the buyer lives abroad).
+func (r *Return) Approve(
+	resolution Resolution,
+	quote *ShippingQuote,
+	note string,
+	by string,
+	at time.Time,
+) error {
+	if resolution != "" {
+		if !IsValidResolution(resolution) {
+			return ErrInvalidResolution
+		}
+		r.Resolution = resolution
+	}
+
+	if quote != nil {
+		if err := quote.Validate(); err != nil {
+			return err
+		}
+		q := *quote
+		q.QuotedAt = q.QuotedAt.UTC()
+		r.ReturnShippingQuote = &q
+	}
+
+	return r.review(StatusApproved, note, by, at)
+}
+
+// Reject declines the request.
+func (r *Return) Reject(
+	note string,
+	by string,
+	at time.Time,
+) error {
+	return r.review(StatusRejected, note, by, at)
+}
+
+// Cancel withdraws the request on the buyer side.
+func (r *Return) Cancel(at time.Time) error {
+	if at.IsZero() {
+		return ErrInvalidTimestamp
+	}
+
+	if r.Status == StatusCancelled {
+		return nil
+	}
+
+	if !CanTransition(r.Status, StatusCancelled) {
+		return ErrInvalidTransition
+	}
+
+	cancelledAt := at.UTC()
+	r.Status = StatusCancelled
+	r.CancelledAt = &cancelledAt
+	r.UpdatedAt = cancelledAt
+
+	return nil
+}
+
+// RecordTokenReturned records the NFT of productID as sent back to the brand.
+// It returns false when the product has already been recorded.
+func (r *Return) RecordTokenReturned(
+	productID string,
+	assetID string,
+	txSignature string,
+	at time.Time,
+) (bool, error) {
+	if r.HasTokenReturned(productID) {
+		return false, nil
+	}
+
+	if !containsProductID(r.ProductIDs, productID) {
+		return false, ErrInvalidTokenReturn
+	}
+
+	tr := TokenReturn{
+		ProductID:   strings.TrimSpace(productID),
+		AssetID:     strings.TrimSpace(assetID),
+		TxSignature: strings.TrimSpace(txSignature),
+		ReturnedAt:  at.UTC(),
+	}
+
+	if err := tr.Validate(); err != nil {
+		return false, err
+	}
+
+	r.TokenReturns = append(r.TokenReturns, tr)
+	r.UpdatedAt = tr.ReturnedAt
+
+	return true, nil
+}
+
+// HasTokenReturned reports whether the NFT of productID is already back.
+func (r Return) HasTokenReturned(productID string) bool {
+	productID = strings.TrimSpace(productID)
+
+	for _, tr := range r.TokenReturns {
+		if tr.ProductID == productID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MarkRestocked records that the products are back in inventory.
+func (r *Return) MarkRestocked(at time.Time) error {
+	if at.IsZero() {
+		return ErrInvalidTimestamp
+	}
+
+	if r.Status != StatusApproved &&
+		r.Status != StatusReceived {
+		return ErrInvalidTransition
+	}
+
+	r.Restocked = true
+	r.UpdatedAt = at.UTC()
+
+	return nil
+}
+
+// MarkReceived moves the return to received once every NFT is back with the
+// brand and the products are restocked.
+func (r *Return) MarkReceived(
+	by string,
+	at time.Time,
+) error {
+	by = strings.TrimSpace(by)
+	if by == "" {
+		return ErrInvalidActor
+	}
+	if at.IsZero() {
+		return ErrInvalidTimestamp
+	}
+
+	if r.Status == StatusReceived {
+		return nil
+	}
+
+	if !CanTransition(r.Status, StatusReceived) {
+		return ErrInvalidTransition
+	}
+
+	if !r.Restocked {
+		return ErrNotRestocked
+	}
+
+	for _, productID := range r.ProductIDs {
+		if !r.HasTokenReturned(productID) {
+			return ErrInvalidTokenReturn
+		}
+	}
+
+	receivedAt := at.UTC()
+	r.Status = StatusReceived
+	r.ReceivedBy = by
+	r.ReceivedAt = &receivedAt
+	r.UpdatedAt = receivedAt
+
+	return nil
+}
+
+// RecordRefund links the refund issued for a refund resolution.
+func (r *Return) RecordRefund(refundID string, at time.Time) error {
+	refundID = strings.TrimSpace(refundID)
+	if refundID == "" ||
+		r.Resolution != ResolutionRefund ||
+		r.Status != StatusReceived {
+		return ErrInvalidResolution
+	}
+
+	r.RefundID = refundID
+	r.UpdatedAt = at.UTC()
+
+	return nil
+}
+
+// RecordReplacementOrder links the order created for a replacement resolution.
+func (r *Return) RecordReplacementOrder(orderID string, at time.Time) error {
+	orderID = strings.TrimSpace(orderID)
+	if orderID == "" ||
+		r.Resolution != ResolutionReplacement ||
+		r.Status != StatusReceived {
+		return ErrInvalidResolution
+	}
+
+	r.ReplacementOrderID = orderID
+	r.UpdatedAt = at.UTC()
+
+	return nil
+}
+
+// Complete closes the return after its resolution has been applied.
+func (r *Return) Complete(
+	by string,
+	at time.Time,
+) error {
+	by = strings.TrimSpace(by)
+	if by == "" {
+		return ErrInvalidActor
+	}
+	if at.IsZero() {
+		return ErrInvalidTimestamp
+	}
+
+	if r.Status == StatusCompleted {
+		return nil
+	}
+
+	if !CanTransition(r.Status, StatusCompleted) {
+		return ErrInvalidTransition
+	}
+
+	switch r.Resolution {
+	case ResolutionRefund:
+		if r.RefundID == "" {
+			return ErrResolutionPending
+		}
+	case ResolutionReplacement:
+		if r.ReplacementOrderID == "" {
+			return ErrResolutionPending
+		}
+	}
+
+	completedAt := at.UTC()
+	r.Status = StatusCompleted
+	r.CompletedBy = by
+	r.CompletedAt = &completedAt
+	r.UpdatedAt = completedAt
+
+	return nil
+}
+
+func (r *Return) review(
+	next Status,
+	note string,
+	by string,
+	at time.Time,
+) error {
+	note = strings.TrimSpace(note)
+	by = strings.TrimSpace(by)
+
+	if by == "" {
+		return ErrInvalidActor
+	}
+	if at.IsZero() {
+		return ErrInvalidTimestamp
+	}
+	if utf8.RuneCountInString(note) > MaxNoteLength {
+		return ErrInvalidComment
+	}
+
+	if !CanTransition(r.Status, next) {
+		return ErrInvalidTransition
+	}
+
+	reviewedAt := at.UTC()
+	r.Status = next
+	r.ReviewNote = note
+	r.ReviewedBy = by
+	r.ReviewedAt = &reviewedAt
+	r.UpdatedAt = reviewedAt
+
+	return nil
+}
+
+// ========================================
+// Validation
+// ========================================
+
+func (r Return) Validate() error {
+	if r.ID == "" || strings.Contains(r.ID, "/") {
+		return ErrInvalidID
+	}
+	if r.OrderID == "" {
+		return ErrInvalidOrderID
+	}
+	if r.ItemIndex < 0 {
+		return ErrInvalidItemIndex
+	}
+	if r.AvatarID == "" {
+		return ErrInvalidAvatarID
+	}
+	if r.CompanyID == "" {
+		return ErrInvalidCompanyID
+	}
+
+	if r.InventoryID == "" ||
+		r.ModelID == "" ||
+		r.ProductBlueprintID == "" ||
+		r.TokenBlueprintID == "" {
+		return ErrInvalidItem
+	}
+
+	if r.Qty <= 0 {
+		return ErrInvalidQty
+	}
+
+	if len(r.ProductIDs) > 0 {
+		if len(r.ProductIDs) != r.Qty {
+			return ErrInvalidProductIDs
+		}
+
+		seen := make(map[string]struct{}, len(r.ProductIDs))
+		for _, productID := range r.ProductIDs {
+			if productID == "" || strings.Contains(productID, "/") {
+				return ErrInvalidProductIDs
+			}
+			if _, dup := seen[productID]; dup {
+				return ErrInvalidProductIDs
+			}
+			seen[productID] = struct{}{}
+		}
+	}
+
+	if len(r.Reasons) == 0 || len(r.Reasons) > MaxReasons {
+		return ErrInvalidReason
+	}
+
+	seenReasons := make(map[Reason]struct{}, len(r.Reasons))
+	for _, reason := range r.Reasons {
+		if !IsValidReason(reason) {
+			return ErrInvalidReason
+		}
+		if _, dup := seenReasons[reason]; dup {
+			return ErrInvalidReason
+		}
+		seenReasons[reason] = struct{}{}
+	}
+
+	if utf8.RuneCountInString(r.Comment) > MaxCommentLength {
+		return ErrInvalidComment
+	}
+	if utf8.RuneCountInString(r.ReviewNote) > MaxNoteLength {
+		return ErrInvalidComment
+	}
+
+	if !IsValidResolution(r.Resolution) {
+		return ErrInvalidResolution
+	}
+
+	if !IsValidStatus(r.Status) {
+		return ErrInvalidStatus
+	}
+
+	if r.ReturnShippingQuote != nil {
+		if err := r.ReturnShippingQuote.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, tr := range r.TokenReturns {
+		if err := tr.Validate(); err != nil {
+			return err
+		}
+		if !containsProductID(r.ProductIDs, tr.ProductID) {
+			return ErrInvalidTokenReturn
+		}
+	}
+
+	if (r.Status == StatusReceived || r.Status == StatusCompleted) &&
+		(r.ReceivedAt == nil || !r.Restocked) {
+		return ErrInvalidStatus
+	}
+
+	if r.Status == StatusCompleted && r.CompletedAt == nil {
+		return ErrInvalidStatus
+	}
+
+	if r.Status == StatusCancelled && r.CancelledAt == nil {
+		return ErrInvalidStatus
+	}
+
+	if r.CreatedAt.IsZero() || r.UpdatedAt.IsZero() {
+		return ErrInvalidTimestamp
+	}
+
+	return nil
+}
+
+func (q ShippingQuote) Validate() error {
+	if !transportationdom.IsValidCarrier(q.Carrier) {
+		return ErrInvalidShippingQuote
+	}
+	if q.Size < 0 || q.Amount < 0 {
+		return ErrInvalidShippingQuote
+	}
+	if q.Currency != ShippingQuoteCurrencyJPY {
+		return ErrInvalidShippingQuote
+	}
+	if q.QuotedAt.IsZero() {
+		return ErrInvalidShippingQuote
+	}
+
+	return nil
+}
+
+func (tr TokenReturn) Validate() error {
+	if tr.ProductID == "" ||
+		tr.AssetID == "" ||
+		tr.TxSignature == "" ||
+		tr.ReturnedAt.IsZero() {
+		return ErrInvalidTokenReturn
+	}
+
+	return nil
+}
+
+// ========================================
+// helpers
+// ========================================
+
+func normalizeProductIDs(in []string) []string {
+	out := make([]string, 0, len(in))
+	for _, productID := range in {
+		if v := strings.TrimSpace(productID); v != "" {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+func normalizeReasons(in []Reason) []Reason {
+	out := make([]Reason, 0, len(in))
+	for _, reason := range in {
+		if v := Reason(strings.TrimSpace(string(reason))); v != "" {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+func containsProductID(productIDs []string, productID string) bool {
+	productID = strings.TrimSpace(productID)
+
+	for _, v := range productIDs {
+		if v == productID {
+			return true
+		}
+	}
+
+	return false
+}
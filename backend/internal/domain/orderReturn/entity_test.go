@@ -0,0 +1,383 @@
+// backend/internal/domain/orderReturn/entity_test.go
+package orderReturn
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	transportationdom "narratives/internal/domain/transportation"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testInput() NewInput {
+	return NewInput{
+		ID:                 "return_1",
+		OrderID:            "order_1",
+		ItemIndex:          0,
+		UserID:             "user_1",
+		AvatarID:           "avatar_1",
+		CompanyID:          "company_1",
+		InventoryID:        "inv_1",
+		ModelID:            "model_1",
+		ListID:             "list_1",
+		ProductBlueprintID: "pb_1",
+		TokenBlueprintID:   "tb_1",
+		Qty:                2,
+		ProductIDs:         []string{"product_1", "product_2"},
+		Reasons:            []Reason{ReasonDefective},
+		CreatedAt:          testNow,
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	statuses := []Status{
+		StatusRequested,
+		StatusApproved,
+		StatusRejected,
+		StatusCancelled,
+		StatusReceived,
+		StatusCompleted,
+	}
+
+	allowed := map[Status][]Status{
+		StatusRequested: {StatusApproved, StatusRejected, StatusCancelled},
+		StatusApproved:  {StatusReceived, StatusCancelled},
+		StatusReceived:  {StatusCompleted},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := false
+			for _, s := range allowed[from] {
+				if s == to {
+					want = true
+				}
+			}
+
+			if got := CanTransition(from, to); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(in *NewInput)
+		wantErr error
+	}{
+		{name: "valid", modify: func(in *NewInput) {}},
+		{name: "before scan has no products", modify: func(in *NewInput) { in.ProductIDs = nil }},
+		{name: "id with slash", modify: func(in *NewInput) { in.ID = "a/b" }, wantErr: ErrInvalidID},
+		{name: "negative item index", modify: func(in *NewInput) { in.ItemIndex = -1 }, wantErr: ErrInvalidItemIndex},
+		{name: "missing company", modify: func(in *NewInput) { in.CompanyID = " " }, wantErr: ErrInvalidCompanyID},
+		{name: "missing token blueprint", modify: func(in *NewInput) { in.TokenBlueprintID = "" }, wantErr: ErrInvalidItem},
+		{name: "zero qty", modify: func(in *NewInput) { in.Qty = 0 }, wantErr: ErrInvalidQty},
+		{name: "product count differs from qty", modify: func(in *NewInput) { in.ProductIDs = []string{"product_1"} }, wantErr: ErrInvalidProductIDs},
+		{name: "duplicate product", modify: func(in *NewInput) { in.ProductIDs = []string{"product_1", "product_1"} }, wantErr: ErrInvalidProductIDs},
+		{name: "no reason", modify: func(in *NewInput) { in.Reasons = nil }, wantErr: ErrInvalidReason},
+		{name: "unknown reason", modify: func(in *NewInput) { in.Reasons = []Reason{"bored"} }, wantErr: ErrInvalidReason},
+		{name: "duplicate reason", modify: func(in *NewInput) { in.Reasons = []Reason{ReasonOther, ReasonOther} }, wantErr: ErrInvalidReason},
+		{
+			name: "too many reasons",
+			modify: func(in *NewInput) {
+				in.Reasons = []Reason{ReasonDefective, ReasonWrongItem, ReasonSizeFit, ReasonOther}
+			},
+			wantErr: ErrInvalidReason,
+		},
+		{name: "comment too long", modify: func(in *NewInput) { in.Comment = strings.Repeat("あ", MaxCommentLength+1) }, wantErr: ErrInvalidComment},
+		{name: "unknown resolution", modify: func(in *NewInput) { in.Resolution = "store_credit" }, wantErr: ErrInvalidResolution},
+		{name: "zero createdAt", modify: func(in *NewInput) { in.CreatedAt = time.Time{} }, wantErr: ErrInvalidTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := testInput()
+			tt.modify(&in)
+
+			r, err := New(in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("New err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if r.Status != StatusRequested {
+				t.Errorf("Status = %s, want %s", r.Status, StatusRequested)
+			}
+			if r.Resolution != ResolutionRefund {
+				t.Errorf("Resolution = %s, want %s", r.Resolution, ResolutionRefund)
+			}
+		})
+	}
+}
+
+func TestReturn_Lifecycle(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolution Resolution
+		settle     func(r *Return) error
+	}{
+		{
+			name:       "refund",
+			resolution: ResolutionRefund,
+			settle:     func(r *Return) error { return r.RecordRefund("refund_1", testNow) },
+		},
+		{
+			name:       "replacement",
+			resolution: ResolutionReplacement,
+			settle:     func(r *Return) error { return r.RecordReplacementOrder("order_2", testNow) },
+		},
+		{
+			name:       "none",
+			resolution: ResolutionNone,
+			settle:     func(r *Return) error { return nil },
+		},
+	}
+
+	quote := &ShippingQuote{
+		Carrier:  transportationdom.CarrierYamato,
+		Size:     60,
+		Amount:   930,
+		Currency: ShippingQuoteCurrencyJPY,
+		QuotedAt: testNow,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(testInput())
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			if err := r.Approve(tt.resolution, quote, "ok", "member_1", testNow); err != nil {
+				t.Fatalf("Approve: %v", err)
+			}
+			if r.Resolution != tt.resolution {
+				t.Fatalf("Resolution = %s, want %s", r.Resolution, tt.resolution)
+			}
+
+			// 受領前は完了できない。
+			if err := r.Complete("member_1", testNow); !errors.Is(err, ErrInvalidTransition) {
+				t.Fatalf("Complete before receive err = %v, want %v", err, ErrInvalidTransition)
+			}
+
+			if err := r.MarkRestocked(testNow); err != nil {
+				t.Fatalf("MarkRestocked: %v", err)
+			}
+			for _, productID := range r.ProductIDs {
+				changed, err := r.RecordTokenReturned(productID, "asset_"+productID, "sig", testNow)
+				if err != nil || !changed {
+					t.Fatalf("RecordTokenReturned(%s) = %v, %v", productID, changed, err)
+				}
+			}
+			if err := r.MarkReceived("member_1", testNow); err != nil {
+				t.Fatalf("MarkReceived: %v", err)
+			}
+
+			if tt.resolution != ResolutionNone {
+				if err := r.Complete("member_1", testNow); !errors.Is(err, ErrResolutionPending) {
+					t.Fatalf("Complete before settle err = %v, want %v", err, ErrResolutionPending)
+				}
+			}
+
+			if err := tt.settle(&r); err != nil {
+				t.Fatalf("settle: %v", err)
+			}
+			if err := r.Complete("member_1", testNow); err != nil {
+				t.Fatalf("Complete: %v", err)
+			}
+			if r.Status != StatusCompleted {
+				t.Fatalf("Status = %s, want %s", r.Status, StatusCompleted)
+			}
+			if err := r.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+
+			// 完了済みへの再実行は no-op。
+			if err := r.Complete("member_1", testNow); err != nil {
+				t.Fatalf("Complete again: %v", err)
+			}
+		})
+	}
+}
+
+func TestReturn_MarkReceived_Guards(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(r *Return)
+		wantErr error
+	}{
+		{
+			name:    "not approved",
+			prepare: func(r *Return) {},
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name: "not restocked",
+			prepare: func(r *Return) {
+				_ = r.Approve("", nil, "", "member_1", testNow)
+			},
+			wantErr: ErrNotRestocked,
+		},
+		{
+			name: "token not returned",
+			prepare: func(r *Return) {
+				_ = r.Approve("", nil, "", "member_1", testNow)
+				_ = r.MarkRestocked(testNow)
+				_, _ = r.RecordTokenReturned("product_1", "asset_1", "sig", testNow)
+			},
+			wantErr: ErrInvalidTokenReturn,
+		},
+		{
+			name: "all tokens returned",
+			prepare: func(r *Return) {
+				_ = r.Approve("", nil, "", "member_1", testNow)
+				_ = r.MarkRestocked(testNow)
+				_, _ = r.RecordTokenReturned("product_1", "asset_1", "sig", testNow)
+				_, _ = r.RecordTokenReturned("product_2", "asset_2", "sig", testNow)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(testInput())
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			tt.prepare(&r)
+
+			if err := r.MarkReceived("member_1", testNow); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MarkReceived err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReturn_RecordTokenReturned(t *testing.T) {
+	r, err := New(testInput())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		productID   string
+		assetID     string
+		wantChanged bool
+		wantErr     error
+	}{
+		{name: "first record", productID: "product_1", assetID: "asset_1", wantChanged: true},
+		{name: "duplicate is a no-op", productID: "product_1", assetID: "asset_1"},
+		{name: "unknown product", productID: "product_9", assetID: "asset_9", wantErr: ErrInvalidTokenReturn},
+		{name: "missing asset", productID: "product_2", assetID: "", wantErr: ErrInvalidTokenReturn},
+	}
+
+	// 各ケースは前のケースの結果を引き継ぐ。
+	for _, tt := range tests {
+		changed, err := r.RecordTokenReturned(tt.productID, tt.assetID, "sig", testNow)
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if changed != tt.wantChanged {
+			t.Fatalf("%s: changed = %v, want %v", tt.name, changed, tt.wantChanged)
+		}
+	}
+
+	if len(r.TokenReturns) != 1 {
+		t.Fatalf("TokenReturns = %d, want 1", len(r.TokenReturns))
+	}
+}
+
+func TestReturn_ReviewAndCancel(t *testing.T) {
+	tests := []struct {
+		name       string
+		apply      func(r *Return) error
+		wantStatus Status
+		wantErr    error
+	}{
+		{
+			name:       "reject requested",
+			apply:      func(r *Return) error { return r.Reject("no", "member_1", testNow) },
+			wantStatus: StatusRejected,
+		},
+		{
+			name:       "cancel requested",
+			apply:      func(r *Return) error { return r.Cancel(testNow) },
+			wantStatus: StatusCancelled,
+		},
+		{
+			name: "cancel approved",
+			apply: func(r *Return) error {
+				_ = r.Approve("", nil, "", "member_1", testNow)
+				return r.Cancel(testNow)
+			},
+			wantStatus: StatusCancelled,
+		},
+		{
+			name: "reject approved",
+			apply: func(r *Return) error {
+				_ = r.Approve("", nil, "", "member_1", testNow)
+				return r.Reject("", "member_1", testNow)
+			},
+			wantStatus: StatusApproved,
+			wantErr:    ErrInvalidTransition,
+		},
+		{
+			name: "cancel rejected",
+			apply: func(r *Return) error {
+				_ = r.Reject("", "member_1", testNow)
+				return r.Cancel(testNow)
+			},
+			wantStatus: StatusRejected,
+			wantErr:    ErrInvalidTransition,
+		},
+		{
+			name:       "approve without actor",
+			apply:      func(r *Return) error { return r.Approve("", nil, "", " ", testNow) },
+			wantStatus: StatusRequested,
+			wantErr:    ErrInvalidActor,
+		},
+		{
+			name: "approve with invalid quote",
+			apply: func(r *Return) error {
+				return r.Approve("", &ShippingQuote{Carrier: transportationdom.CarrierYamato, Currency: "USD", QuotedAt: testNow}, "", "member_1", testNow)
+			},
+			wantStatus: StatusRequested,
+			wantErr:    ErrInvalidShippingQuote,
+		},
+		{
+			name: "approve with long note",
+			apply: func(r *Return) error {
+				return r.Approve("", nil, strings.Repeat("a", MaxNoteLength+1), "member_1", testNow)
+			},
+			wantStatus: StatusRequested,
+			wantErr:    ErrInvalidComment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(testInput())
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			if err := tt.apply(&r); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if r.Status != tt.wantStatus {
+				t.Fatalf("Status = %s, want %s", r.Status, tt.wantStatus)
+			}
+			if r.Status.IsOpen() == (r.Status == StatusRejected || r.Status == StatusCancelled) {
+				t.Fatalf("IsOpen(%s) = %v", r.Status, r.Status.IsOpen())
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// backend/internal/domain/orderReturn/image.go
+package orderReturn
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ReturnImage is a photo attached by the buyer to a return request
+// (e.g. the damaged part of the product).
+//
+// Image policy (same as list / resale images):
+// - Backend stores only Firebase Storage download URL.
+// - Image record is scoped by returnId.
+type ReturnImage struct {
+	ID           string     `json:"id"`
+	ReturnID     string     `json:"returnId"`
+	URL          string     `json:"url"`
+	DisplayOrder int        `json:"displayOrder"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	CreatedBy    string     `json:"createdBy,omitempty"`
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"`
+	UpdatedBy    *string    `json:"updatedBy,omitempty"`
+}
+
+var (
+	ErrInvalidImageID        = errors.New("orderReturn: invalid image id")
+	ErrInvalidImageReturnID  = errors.New("orderReturn: invalid image returnId")
+	ErrInvalidImageURL       = errors.New("orderReturn: invalid image url")
+	ErrInvalidImageOrder     = errors.New("orderReturn: invalid image displayOrder")
+	ErrInvalidImageCreatedAt = errors.New("orderReturn: invalid image createdAt")
+	ErrInvalidImageCreatedBy = errors.New("orderReturn: invalid image createdBy")
+	ErrInvalidImageUpdatedAt = errors.New("orderReturn: invalid image updatedAt")
+	ErrInvalidImageUpdatedBy = errors.New("orderReturn: invalid image updatedBy")
+	ErrTooManyImages         = errors.New("orderReturn: too many images")
+	ErrImageNotFound         = errors.New("orderReturn: image not found")
+	ErrImageConflict         = errors.New("orderReturn: image conflict")
+)
+
+const (
+	MaxImages        = 10
+	MaxImageIDLength = 128
+)
+
+var imageIDRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func NewReturnImage(
+	id string,
+	returnID string,
+	u string,
+	displayOrder int,
+	createdAt time.Time,
+	createdBy string,
+) (ReturnImage, error) {
+	image := ReturnImage{
+		ID:           id,
+		ReturnID:     returnID,
+		URL:          u,
+		DisplayOrder: displayOrder,
+		CreatedAt:    createdAt.UTC(),
+		CreatedBy:    createdBy,
+	}
+
+	if err := image.Validate(); err != nil {
+		return ReturnImage{}, err
+	}
+
+	return image, nil
+}
+
+func (image ReturnImage) Validate() error {
+	if !isValidImageID(image.ID) {
+		return ErrInvalidImageID
+	}
+
+	if image.ReturnID == "" {
+		return ErrInvalidImageReturnID
+	}
+
+	if err := validateImageURL(image.URL); err != nil {
+		return err
+	}
+
+	if image.DisplayOrder < 0 {
+		return ErrInvalidImageOrder
+	}
+
+	if image.CreatedAt.IsZero() {
+		return ErrInvalidImageCreatedAt
+	}
+
+	if image.CreatedBy == "" {
+		return ErrInvalidImageCreatedBy
+	}
+
+	if image.UpdatedAt != nil && (image.UpdatedAt.IsZero() || image.UpdatedAt.Before(image.CreatedAt)) {
+		return ErrInvalidImageUpdatedAt
+	}
+
+	if image.UpdatedBy != nil && *image.UpdatedBy == "" {
+		return ErrInvalidImageUpdatedBy
+	}
+
+	return nil
+}
+
+func isValidImageID(id string) bool {
+	if id == "" || len(id) > MaxImageIDLength {
+		return false
+	}
+
+	if strings.Contains(id, "/") || strings.Contains(id, "://") {
+		return false
+	}
+
+	return imageIDRe.MatchString(id)
+}
+
+func validateImageURL(u string) error {
+	if u == "" {
+		return ErrInvalidImageURL
+	}
+
+	pu, err := url.ParseRequestURI(u)
+	if err != nil {
+		return ErrInvalidImageURL
+	}
+
+	if pu.Scheme == "" || pu.Host == "" {
+		return ErrInvalidImageURL
+	}
+
+	return nil
+}
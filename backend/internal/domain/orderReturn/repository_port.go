@@ -0,0 +1,70 @@
+// backend/internal/domain/orderReturn/repository_port.go
+package orderReturn
+
+import (
+	"context"
+	"errors"
+)
+
+// RepositoryPort - ドメインのリポジトリ契約
+//
+// Create never overwrites an existing return and Update never creates one.
+type RepositoryPort interface {
+	GetByID(
+		ctx context.Context,
+		id string,
+	) (Return, error)
+
+	// ListByOrderID returns every return of the order ordered by createdAt asc.
+	ListByOrderID(
+		ctx context.Context,
+		orderID string,
+	) ([]Return, error)
+
+	// ListByAvatarID returns the buyer's returns ordered by createdAt desc.
+	ListByAvatarID(
+		ctx context.Context,
+		avatarID string,
+	) ([]Return, error)
+
+	// ListByCompanyID returns the company's returns ordered by createdAt desc.
+	// An empty status means every status.
+	ListByCompanyID(
+		ctx context.Context,
+		companyID string,
+		status Status,
+	) ([]Return, error)
+
+	// Create stores r and returns ErrConflict when another open return
+	// already exists for the same order item. Must be atomic.
+	Create(
+		ctx context.Context,
+		r Return,
+	) (Return, error)
+
+	// Update replaces r. The stored status must be able to move to r.Status
+	// (or stay the same).
+	Update(
+		ctx context.Context,
+		r Return,
+	) (Return, error)
+}
+
+// ImageRepository is the repository port for return photos.
+//
+// Collection:
+// - returns/{returnId}/images/{imageId}
+type ImageRepository interface {
+	ListByReturnID(ctx context.Context, returnID string) ([]ReturnImage, error)
+
+	Create(ctx context.Context, img ReturnImage) (ReturnImage, error)
+
+	// Delete physically deletes a return image record.
+	Delete(ctx context.Context, returnID string, imageID string) error
+}
+
+// 共通エラー
+var (
+	ErrNotFound = errors.New("orderReturn: not found")
+	ErrConflict = errors.New("orderReturn: conflict")
+)
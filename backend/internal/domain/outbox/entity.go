@@ -0,0 +1,343 @@
+// backend/internal/domain/outbox/entity.go
+package outbox
+
+/*
+責務:
+- 集約（order / payment / mint / transfer）のドメインイベントを表す。
+- イベントは集約の書き込みと同じ Firestore transaction で outbox に保存し、
+  relay が購読ハンドラへ at-least-once で配信する。
+- 配信状態（pending / processing / published / failed）と、リース・再試行の遷移を持つ。
+
+前提:
+- ハンドラは同じイベントを複数回受け取る可能性があるため、冪等である必要がある。
+- Payload は JSON で保持し、型ごとの Payload 構造体へ DecodePayload で戻す。
+*/
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxAttempts は 1 イベントの配信試行回数の上限です。
+const DefaultMaxAttempts = 10
+
+type EventType string
+
+const (
+	EventOrderCreated      EventType = "order.created"
+	EventPaymentSucceeded  EventType = "payment.succeeded"
+	EventItemDispatched    EventType = "order.item_dispatched"
+	EventMintCompleted     EventType = "mint.completed"
+	EventTransferSucceeded EventType = "transfer.succeeded"
+)
+
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventOrderCreated,
+		EventPaymentSucceeded,
+		EventItemDispatched,
+		EventMintCompleted,
+		EventTransferSucceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+type AggregateType string
+
+const (
+	AggregateOrder    AggregateType = "order"
+	AggregatePayment  AggregateType = "payment"
+	AggregateMint     AggregateType = "mint"
+	AggregateTransfer AggregateType = "transfer"
+)
+
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusPublished  Status = "published"
+	StatusFailed     Status = "failed"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending,
+		StatusProcessing,
+		StatusPublished,
+		StatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	ErrNotFound = errors.New("outbox: not found")
+
+	ErrInvalidID            = errors.New("outbox: invalid id")
+	ErrInvalidType          = errors.New("outbox: invalid event type")
+	ErrInvalidAggregateType = errors.New("outbox: invalid aggregate type")
+	ErrInvalidAggregateID   = errors.New("outbox: invalid aggregate id")
+	ErrInvalidPayload       = errors.New("outbox: invalid payload")
+	ErrInvalidStatus        = errors.New("outbox: invalid status")
+	ErrInvalidOccurredAt    = errors.New("outbox: invalid occurredAt")
+	ErrInvalidMaxAttempts   = errors.New("outbox: invalid max attempts")
+	ErrInvalidLease         = errors.New("outbox: lease is invalid")
+	ErrErrorRequired        = errors.New("outbox: error is required")
+	ErrNotClaimable         = errors.New("outbox: event is not claimable")
+)
+
+// ============================================================
+// Payloads
+// ============================================================
+
+// OrderCreatedPayload は注文の作成（注文受付）です。
+type OrderCreatedPayload struct {
+	OrderID  string `json:"orderId"`
+	UserID   string `json:"userId"`
+	AvatarID string `json:"avatarId"`
+}
+
+// PaymentSucceededPayload は決済の初回成功です。paymentId は orderId と同じです。
+type PaymentSucceededPayload struct {
+	PaymentID string `json:"paymentId"`
+}
+
+// ItemDispatchedPayload は console 企業による明細の発送です。
+type ItemDispatchedPayload struct {
+	OrderID      string   `json:"orderId"`
+	CompanyID    string   `json:"companyId"`
+	InventoryIDs []string `json:"inventoryIds"`
+}
+
+// MintCompletedPayload は mint の全 product の完了です。
+type MintCompletedPayload struct {
+	MintID           string `json:"mintId"`
+	BrandID          string `json:"brandId"`
+	TokenBlueprintID string `json:"tokenBlueprintId"`
+	ActorID          string `json:"actorId,omitempty"`
+	TxSignature      string `json:"txSignature,omitempty"`
+}
+
+// TransferSucceededPayload は NFT 移転の成功です。
+type TransferSucceededPayload struct {
+	ProductID   string `json:"productId"`
+	Attempt     int    `json:"attempt"`
+	OperationID string `json:"operationId"`
+	OrderID     string `json:"orderId"`
+	AvatarID    string `json:"avatarId"`
+	AssetID     string `json:"assetId"`
+	TxSignature string `json:"txSignature"`
+}
+
+// ============================================================
+// Event
+// ============================================================
+
+type Event struct {
+	ID   string    `json:"id"`
+	Type EventType `json:"type"`
+
+	AggregateType AggregateType `json:"aggregateType"`
+	AggregateID   string        `json:"aggregateId"`
+
+	// Payload は型ごとの Payload 構造体の JSON です。
+	Payload []byte `json:"payload"`
+
+	Status      Status `json:"status"`
+	Attempts    int    `json:"attempts"`
+	MaxAttempts int    `json:"maxAttempts"`
+	LastError   string `json:"lastError,omitempty"`
+
+	OccurredAt time.Time `json:"occurredAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+
+	NextAttemptAt   *time.Time `json:"nextAttemptAt,omitempty"`
+	ProcessingUntil *time.Time `json:"processingUntil,omitempty"`
+	PublishedAt     *time.Time `json:"publishedAt,omitempty"`
+	FailedAt        *time.Time `json:"failedAt,omitempty"`
+}
+
+// NewEvent は pending のイベントを作成します。payload は JSON に変換されます。
+func NewEvent(
+	eventType EventType,
+	aggregateType AggregateType,
+	aggregateID string,
+	payload any,
+	now time.Time,
+) (Event, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, ErrInvalidPayload
+	}
+
+	now = now.UTC()
+
+	e := Event{
+		ID:   uuid.NewString(),
+		Type: eventType,
+
+		AggregateType: aggregateType,
+		AggregateID:   strings.TrimSpace(aggregateID),
+
+		Payload: raw,
+
+		Status:      StatusPending,
+		MaxAttempts: DefaultMaxAttempts,
+
+		OccurredAt: now,
+		UpdatedAt:  now,
+	}
+
+	if err := e.Validate(); err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+func (e Event) Validate() error {
+	if strings.TrimSpace(e.ID) == "" {
+		return ErrInvalidID
+	}
+	if !e.Type.IsValid() {
+		return ErrInvalidType
+	}
+	if strings.TrimSpace(string(e.AggregateType)) == "" {
+		return ErrInvalidAggregateType
+	}
+	if strings.TrimSpace(e.AggregateID) == "" {
+		return ErrInvalidAggregateID
+	}
+	if len(e.Payload) == 0 || !json.Valid(e.Payload) {
+		return ErrInvalidPayload
+	}
+	if !e.Status.IsValid() {
+		return ErrInvalidStatus
+	}
+	if e.MaxAttempts <= 0 {
+		return ErrInvalidMaxAttempts
+	}
+	if e.OccurredAt.IsZero() {
+		return ErrInvalidOccurredAt
+	}
+	return nil
+}
+
+// DecodePayload は Payload を v（型ごとの Payload 構造体）に変換します。
+func (e Event) DecodePayload(v any) error {
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return ErrInvalidPayload
+	}
+	return nil
+}
+
+// ============================================================
+// Delivery state
+// ============================================================
+
+func (e Event) IsTerminal() bool {
+	return e.Status == StatusPublished || e.Status == StatusFailed
+}
+
+// IsDue は配信対象かを返します。処理中でもリースが切れていれば対象です。
+func (e Event) IsDue(now time.Time) bool {
+	now = now.UTC()
+
+	switch e.Status {
+	case StatusPending:
+		return e.NextAttemptAt == nil || !now.Before(e.NextAttemptAt.UTC())
+
+	case StatusProcessing:
+		return e.ProcessingUntil != nil && !now.Before(e.ProcessingUntil.UTC())
+
+	default:
+		return false
+	}
+}
+
+// Claim は processingUntil までのリースを取得し、試行回数を進めます。
+func (e Event) Claim(now time.Time, processingUntil time.Time) (Event, error) {
+	now = now.UTC()
+	processingUntil = processingUntil.UTC()
+
+	if !processingUntil.After(now) {
+		return Event{}, ErrInvalidLease
+	}
+
+	if e.IsTerminal() || e.Attempts >= e.MaxAttempts || !e.IsDue(now) {
+		return Event{}, ErrNotClaimable
+	}
+
+	e.Status = StatusProcessing
+	e.Attempts++
+	e.NextAttemptAt = nil
+	e.ProcessingUntil = &processingUntil
+	e.UpdatedAt = now
+
+	return e, nil
+}
+
+// MarkPublished は全ハンドラの処理が成功したことを記録します。
+func (e Event) MarkPublished(now time.Time) (Event, error) {
+	if e.Status == StatusPublished {
+		return e, nil
+	}
+	if e.Status != StatusProcessing {
+		return Event{}, ErrNotClaimable
+	}
+
+	now = now.UTC()
+
+	e.Status = StatusPublished
+	e.LastError = ""
+	e.NextAttemptAt = nil
+	e.ProcessingUntil = nil
+	e.PublishedAt = &now
+	e.UpdatedAt = now
+
+	return e, nil
+}
+
+// MarkRetry はハンドラの失敗を記録し、nextAttemptAt に再試行します。
+// 試行回数が上限に達している場合は failed にします。
+func (e Event) MarkRetry(
+	lastError string,
+	nextAttemptAt time.Time,
+	now time.Time,
+) (Event, error) {
+	if e.Status != StatusProcessing {
+		return Event{}, ErrNotClaimable
+	}
+
+	lastError = strings.TrimSpace(lastError)
+	if lastError == "" {
+		return Event{}, ErrErrorRequired
+	}
+
+	now = now.UTC()
+
+	e.LastError = lastError
+	e.ProcessingUntil = nil
+	e.UpdatedAt = now
+
+	if e.Attempts >= e.MaxAttempts {
+		e.Status = StatusFailed
+		e.NextAttemptAt = nil
+		e.FailedAt = &now
+		return e, nil
+	}
+
+	next := nextAttemptAt.UTC()
+	e.Status = StatusPending
+	e.NextAttemptAt = &next
+
+	return e, nil
+}
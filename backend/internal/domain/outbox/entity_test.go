@@ -0,0 +1,264 @@
+// backend/internal/domain/outbox/entity_test.go
+package outbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testEvent(t *testing.T) Event {
+	t.Helper()
+
+	e, err := NewEvent(
+		EventPaymentSucceeded,
+		AggregatePayment,
+		"order_1",
+		PaymentSucceededPayload{PaymentID: "order_1"},
+		testNow,
+	)
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+
+	return e
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestNewEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		eventType     EventType
+		aggregateType AggregateType
+		aggregateID   string
+		payload       any
+		want          error
+	}{
+		{name: "valid", eventType: EventOrderCreated, aggregateType: AggregateOrder, aggregateID: "order_1", payload: OrderCreatedPayload{OrderID: "order_1"}},
+		{name: "unknown type", eventType: "order.deleted", aggregateType: AggregateOrder, aggregateID: "order_1", payload: struct{}{}, want: ErrInvalidType},
+		{name: "missing aggregate type", eventType: EventOrderCreated, aggregateID: "order_1", payload: struct{}{}, want: ErrInvalidAggregateType},
+		{name: "missing aggregate id", eventType: EventOrderCreated, aggregateType: AggregateOrder, aggregateID: " ", payload: struct{}{}, want: ErrInvalidAggregateID},
+		{name: "payload that cannot be encoded", eventType: EventOrderCreated, aggregateType: AggregateOrder, aggregateID: "order_1", payload: make(chan int), want: ErrInvalidPayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEvent(tt.eventType, tt.aggregateType, tt.aggregateID, tt.payload, testNow)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewEvent err = %v, want %v", err, tt.want)
+			}
+			if err == nil && (e.Status != StatusPending || e.MaxAttempts != DefaultMaxAttempts || !e.IsDue(testNow)) {
+				t.Fatalf("NewEvent = %+v, want due pending event", e)
+			}
+		})
+	}
+}
+
+func TestEvent_DecodePayload(t *testing.T) {
+	e, err := NewEvent(
+		EventItemDispatched,
+		AggregateOrder,
+		"order_1",
+		ItemDispatchedPayload{OrderID: "order_1", CompanyID: "company_1", InventoryIDs: []string{"inv_1", "inv_2"}},
+		testNow,
+	)
+	if err != nil {
+		t.Fatalf("NewEvent: %v", err)
+	}
+
+	var got ItemDispatchedPayload
+	if err := e.DecodePayload(&got); err != nil {
+		t.Fatalf("DecodePayload: %v", err)
+	}
+	if got.CompanyID != "company_1" || len(got.InventoryIDs) != 2 || got.InventoryIDs[1] != "inv_2" {
+		t.Fatalf("DecodePayload = %+v", got)
+	}
+
+	var wrong []string
+	if err := e.DecodePayload(&wrong); !errors.Is(err, ErrInvalidPayload) {
+		t.Fatalf("DecodePayload(wrong type) err = %v, want %v", err, ErrInvalidPayload)
+	}
+}
+
+func TestEvent_Claim(t *testing.T) {
+	lease := testNow.Add(time.Minute)
+
+	tests := []struct {
+		name         string
+		modify       func(e *Event)
+		until        time.Time
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "new event", until: lease, wantAttempts: 1},
+		{
+			name:         "retry is due",
+			modify:       func(e *Event) { e.Attempts = 1; e.NextAttemptAt = timePtr(testNow) },
+			until:        lease,
+			wantAttempts: 2,
+		},
+		{
+			name:    "retry not yet due",
+			modify:  func(e *Event) { e.Attempts = 1; e.NextAttemptAt = timePtr(testNow.Add(time.Second)) },
+			until:   lease,
+			wantErr: ErrNotClaimable,
+		},
+		{
+			name: "lease held by another relay",
+			modify: func(e *Event) {
+				e.Status = StatusProcessing
+				e.Attempts = 1
+				e.ProcessingUntil = timePtr(testNow.Add(time.Second))
+			},
+			until:   lease,
+			wantErr: ErrNotClaimable,
+		},
+		{
+			// リースが切れた処理中のイベントは、他の relay が引き継げる。
+			name:         "lease expired",
+			modify:       func(e *Event) { e.Status = StatusProcessing; e.Attempts = 1; e.ProcessingUntil = timePtr(testNow) },
+			until:        lease,
+			wantAttempts: 2,
+		},
+		{
+			name:    "attempts exhausted",
+			modify:  func(e *Event) { e.Attempts = e.MaxAttempts },
+			until:   lease,
+			wantErr: ErrNotClaimable,
+		},
+		{
+			name:    "published",
+			modify:  func(e *Event) { e.Status = StatusPublished },
+			until:   lease,
+			wantErr: ErrNotClaimable,
+		},
+		{
+			name:    "failed",
+			modify:  func(e *Event) { e.Status = StatusFailed },
+			until:   lease,
+			wantErr: ErrNotClaimable,
+		},
+		{name: "lease in the past", until: testNow, wantErr: ErrInvalidLease},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testEvent(t)
+			if tt.modify != nil {
+				tt.modify(&e)
+			}
+
+			got, err := e.Claim(testNow, tt.until)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Claim err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Status != StatusProcessing || got.Attempts != tt.wantAttempts || got.NextAttemptAt != nil {
+				t.Fatalf("Claim = %s attempts %d next %v", got.Status, got.Attempts, got.NextAttemptAt)
+			}
+			if got.ProcessingUntil == nil || !got.ProcessingUntil.Equal(tt.until) {
+				t.Fatalf("ProcessingUntil = %v, want %v", got.ProcessingUntil, tt.until)
+			}
+			if got.IsDue(testNow) || !got.IsDue(tt.until) {
+				t.Fatalf("claimed event must be due only after the lease")
+			}
+		})
+	}
+}
+
+func TestEvent_Outcomes(t *testing.T) {
+	retryAt := testNow.Add(30 * time.Second)
+
+	tests := []struct {
+		name       string
+		attempts   int
+		apply      func(e Event) (Event, error)
+		wantStatus Status
+		wantDueAt  *time.Time
+		wantErr    error
+	}{
+		{
+			name:       "published",
+			apply:      func(e Event) (Event, error) { return e.MarkPublished(testNow) },
+			wantStatus: StatusPublished,
+		},
+		{
+			name: "published twice",
+			apply: func(e Event) (Event, error) {
+				e, _ = e.MarkPublished(testNow)
+				return e.MarkPublished(testNow)
+			},
+			wantStatus: StatusPublished,
+		},
+		{
+			name:       "retry",
+			attempts:   1,
+			apply:      func(e Event) (Event, error) { return e.MarkRetry("handler failed", retryAt, testNow) },
+			wantStatus: StatusPending,
+			wantDueAt:  &retryAt,
+		},
+		{
+			name:       "retry after the last attempt fails",
+			attempts:   DefaultMaxAttempts,
+			apply:      func(e Event) (Event, error) { return e.MarkRetry("handler failed", retryAt, testNow) },
+			wantStatus: StatusFailed,
+		},
+		{
+			name:    "retry without error",
+			apply:   func(e Event) (Event, error) { return e.MarkRetry(" ", retryAt, testNow) },
+			wantErr: ErrErrorRequired,
+		},
+		{
+			name: "retry after publish",
+			apply: func(e Event) (Event, error) {
+				e, _ = e.MarkPublished(testNow)
+				return e.MarkRetry("handler failed", retryAt, testNow)
+			},
+			wantErr: ErrNotClaimable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := testEvent(t)
+			e.Status = StatusProcessing
+			e.Attempts = tt.attempts
+			e.ProcessingUntil = timePtr(testNow.Add(time.Minute))
+
+			got, err := tt.apply(e)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Status != tt.wantStatus || got.ProcessingUntil != nil {
+				t.Fatalf("Status = %s, ProcessingUntil = %v", got.Status, got.ProcessingUntil)
+			}
+			if tt.wantDueAt == nil {
+				if got.IsDue(testNow.Add(time.Hour)) {
+					t.Fatalf("terminal event is due")
+				}
+				return
+			}
+			if got.IsDue(tt.wantDueAt.Add(-time.Second)) || !got.IsDue(*tt.wantDueAt) {
+				t.Fatalf("NextAttemptAt = %v, want %v", got.NextAttemptAt, tt.wantDueAt)
+			}
+		})
+	}
+}
+
+func TestEvent_MarkPublished_NotClaimed(t *testing.T) {
+	if _, err := testEvent(t).MarkPublished(testNow); !errors.Is(err, ErrNotClaimable) {
+		t.Fatalf("MarkPublished err = %v, want %v", err, ErrNotClaimable)
+	}
+}
@@ -0,0 +1,57 @@
+// backend/internal/domain/outbox/repository_port.go
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// RepositoryPort は outbox イベントの配信状態の永続化ポートです。
+//
+// イベントの作成は集約の repository が集約と同じ transaction で行うため、
+// ここには作成を含めません。
+//
+// MarkPublished / MarkRetry は expectedAttempts が一致する処理中のイベントだけを
+// 更新し、リースが切れた古い worker が新しい試行の結果を上書きしないようにします。
+type RepositoryPort interface {
+	GetByID(ctx context.Context, id string) (Event, error)
+
+	// ListDue は types のうち配信対象のイベントを古い順に最大 limit 件返します。
+	ListDue(
+		ctx context.Context,
+		types []EventType,
+		now time.Time,
+		limit int,
+	) ([]Event, error)
+
+	// ListPendingByAggregate は集約の未配信（pending）のイベントを古い順に返します。
+	ListPendingByAggregate(
+		ctx context.Context,
+		aggregateType AggregateType,
+		aggregateID string,
+	) ([]Event, error)
+
+	// Claim はイベントのリースを取得します。取得できない場合は ErrNotClaimable です。
+	Claim(
+		ctx context.Context,
+		id string,
+		now time.Time,
+		processingUntil time.Time,
+	) (Event, error)
+
+	MarkPublished(
+		ctx context.Context,
+		id string,
+		expectedAttempts int,
+		now time.Time,
+	) error
+
+	MarkRetry(
+		ctx context.Context,
+		id string,
+		expectedAttempts int,
+		lastError string,
+		nextAttemptAt time.Time,
+		now time.Time,
+	) error
+}
@@ -0,0 +1,112 @@
+// backend/internal/domain/payment/dispute.go
+package payment
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DisputeStatus mirrors Stripe Dispute.status.
+type DisputeStatus string
+
+const (
+	DisputeStatusWarningNeedsResponse DisputeStatus = "warning_needs_response"
+	DisputeStatusWarningUnderReview   DisputeStatus = "warning_under_review"
+	DisputeStatusWarningClosed        DisputeStatus = "warning_closed"
+	DisputeStatusNeedsResponse        DisputeStatus = "needs_response"
+	DisputeStatusUnderReview          DisputeStatus = "under_review"
+	DisputeStatusWon                  DisputeStatus = "won"
+	DisputeStatusLost                 DisputeStatus = "lost"
+)
+
+func IsValidDisputeStatus(s DisputeStatus) bool {
+	switch s {
+	case DisputeStatusWarningNeedsResponse,
+		DisputeStatusWarningUnderReview,
+		DisputeStatusWarningClosed,
+		DisputeStatusNeedsResponse,
+		DisputeStatusUnderReview,
+		DisputeStatusWon,
+		DisputeStatusLost:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsClosed reports whether Stripe has closed the dispute.
+func (s DisputeStatus) IsClosed() bool {
+	return s == DisputeStatusWon ||
+		s == DisputeStatusLost ||
+		s == DisputeStatusWarningClosed
+}
+
+var (
+	ErrInvalidStripeDisputeID = errors.New("payment: invalid stripeDisputeId")
+	ErrInvalidDisputeStatus   = errors.New("payment: invalid dispute status")
+	ErrInvalidDisputeAmount   = errors.New("payment: invalid dispute amount")
+	ErrInvalidDisputeTime     = errors.New("payment: invalid dispute time")
+)
+
+// Dispute is a Stripe dispute (chargeback) raised against the Payment.
+type Dispute struct {
+	StripeDisputeID string
+	Status          DisputeStatus
+	Reason          string
+	Amount          int
+
+	// OpenedAt is when the dispute was created on Stripe.
+	OpenedAt time.Time
+
+	// UpdatedAt is the Stripe event time of the latest applied change.
+	UpdatedAt time.Time
+}
+
+func (d Dispute) validate() error {
+	if strings.TrimSpace(d.StripeDisputeID) == "" {
+		return ErrInvalidStripeDisputeID
+	}
+	if !IsValidDisputeStatus(d.Status) {
+		return ErrInvalidDisputeStatus
+	}
+	if d.Amount < 0 {
+		return ErrInvalidDisputeAmount
+	}
+	if d.OpenedAt.IsZero() || d.UpdatedAt.IsZero() {
+		return ErrInvalidDisputeTime
+	}
+	return nil
+}
+
+// ApplyDispute records a Stripe dispute event.
+//
+// Dispute events may be retried or delivered out of order, so an event older
+// than the recorded one is ignored, and an open status never reopens a closed
+// dispute. A different dispute replaces the recorded one.
+func (p *Payment) ApplyDispute(next Dispute) (changed bool, err error) {
+	next.StripeDisputeID = strings.TrimSpace(next.StripeDisputeID)
+	next.Reason = strings.TrimSpace(next.Reason)
+	next.OpenedAt = next.OpenedAt.UTC()
+	next.UpdatedAt = next.UpdatedAt.UTC()
+
+	if err := next.validate(); err != nil {
+		return false, err
+	}
+
+	if current := p.Dispute; current != nil &&
+		current.StripeDisputeID == next.StripeDisputeID {
+		if next.UpdatedAt.Before(current.UpdatedAt) {
+			return false, nil
+		}
+		if current.Status.IsClosed() && !next.Status.IsClosed() {
+			return false, nil
+		}
+		if *current == next {
+			return false, nil
+		}
+	}
+
+	p.Dispute = &next
+	return true, nil
+}
@@ -0,0 +1,123 @@
+// backend/internal/domain/payment/dispute_test.go
+package payment
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPayment_ApplyDispute(t *testing.T) {
+	opened := testNow
+	later := testNow.Add(time.Hour)
+
+	dispute := func(id string, status DisputeStatus, updatedAt time.Time) Dispute {
+		return Dispute{
+			StripeDisputeID: id,
+			Status:          status,
+			Reason:          "fraudulent",
+			Amount:          5000,
+			OpenedAt:        opened,
+			UpdatedAt:       updatedAt,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		current     *Dispute
+		next        Dispute
+		wantChanged bool
+		wantStatus  DisputeStatus
+		wantID      string
+		wantErr     error
+	}{
+		{
+			name:        "first dispute",
+			next:        dispute("dp_1", DisputeStatusNeedsResponse, opened),
+			wantChanged: true,
+			wantStatus:  DisputeStatusNeedsResponse,
+			wantID:      "dp_1",
+		},
+		{
+			name:        "newer status",
+			current:     &Dispute{StripeDisputeID: "dp_1", Status: DisputeStatusNeedsResponse, Reason: "fraudulent", Amount: 5000, OpenedAt: opened, UpdatedAt: opened},
+			next:        dispute("dp_1", DisputeStatusUnderReview, later),
+			wantChanged: true,
+			wantStatus:  DisputeStatusUnderReview,
+			wantID:      "dp_1",
+		},
+		{
+			name:       "older event is ignored",
+			current:    &Dispute{StripeDisputeID: "dp_1", Status: DisputeStatusUnderReview, Reason: "fraudulent", Amount: 5000, OpenedAt: opened, UpdatedAt: later},
+			next:       dispute("dp_1", DisputeStatusNeedsResponse, opened),
+			wantStatus: DisputeStatusUnderReview,
+			wantID:     "dp_1",
+		},
+		{
+			name:       "closed dispute is not reopened",
+			current:    &Dispute{StripeDisputeID: "dp_1", Status: DisputeStatusWon, Reason: "fraudulent", Amount: 5000, OpenedAt: opened, UpdatedAt: opened},
+			next:       dispute("dp_1", DisputeStatusUnderReview, later),
+			wantStatus: DisputeStatusWon,
+			wantID:     "dp_1",
+		},
+		{
+			name:       "same event again",
+			current:    &Dispute{StripeDisputeID: "dp_1", Status: DisputeStatusLost, Reason: "fraudulent", Amount: 5000, OpenedAt: opened, UpdatedAt: later},
+			next:       dispute("dp_1", DisputeStatusLost, later),
+			wantStatus: DisputeStatusLost,
+			wantID:     "dp_1",
+		},
+		{
+			name:        "another dispute replaces",
+			current:     &Dispute{StripeDisputeID: "dp_1", Status: DisputeStatusWon, Reason: "fraudulent", Amount: 5000, OpenedAt: opened, UpdatedAt: later},
+			next:        dispute("dp_2", DisputeStatusNeedsResponse, opened),
+			wantChanged: true,
+			wantStatus:  DisputeStatusNeedsResponse,
+			wantID:      "dp_2",
+		},
+		{
+			name:    "unknown status",
+			next:    dispute("dp_1", "charge_refunded", opened),
+			wantErr: ErrInvalidDisputeStatus,
+		},
+		{
+			name:    "missing id",
+			next:    dispute(" ", DisputeStatusNeedsResponse, opened),
+			wantErr: ErrInvalidStripeDisputeID,
+		},
+		{
+			name:    "missing time",
+			next:    dispute("dp_1", DisputeStatusNeedsResponse, time.Time{}),
+			wantErr: ErrInvalidDisputeTime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPayment(t, StatusSucceeded)
+			p.Dispute = tt.current
+
+			changed, err := p.ApplyDispute(tt.next)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyDispute err = %v, want %v", err, tt.wantErr)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if err != nil {
+				if p.Dispute != tt.current {
+					t.Errorf("Dispute changed on error")
+				}
+				return
+			}
+
+			if p.Dispute == nil || p.Dispute.Status != tt.wantStatus || p.Dispute.StripeDisputeID != tt.wantID {
+				t.Fatalf("Dispute = %+v, want %s %s", p.Dispute, tt.wantID, tt.wantStatus)
+			}
+			// A chargeback never moves the Payment out of succeeded.
+			if p.Status != StatusSucceeded {
+				t.Fatalf("Status = %s, want %s", p.Status, StatusSucceeded)
+			}
+		})
+	}
+}
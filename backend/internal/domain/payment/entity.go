@@ -38,6 +38,48 @@ func IsValidStatus(s PaymentStatus) bool {
 	return ok
 }
 
+// IsTerminal reports whether no Stripe event may move the Payment out of s.
+func (s PaymentStatus) IsTerminal() bool {
+	return s == StatusSucceeded || s == StatusCanceled
+}
+
+// CanTransition reports whether a Stripe event may move a Payment from
+// current to next.
+//
+// Stripe retries webhooks and does not guarantee delivery order, so a stale
+// event (e.g. processing delivered after succeeded) must not regress the
+// Payment. succeeded and canceled are terminal; failed may recover after a
+// new payment method or another confirmation attempt. Applying the current
+// status again is allowed so that error metadata can be refreshed.
+func CanTransition(current, next PaymentStatus) bool {
+	if !IsValidStatus(current) || !IsValidStatus(next) {
+		return false
+	}
+
+	if current == next {
+		return true
+	}
+
+	if current.IsTerminal() {
+		return false
+	}
+
+	switch current {
+	case StatusPending,
+		StatusRequiresAction:
+		return true
+
+	case StatusProcessing:
+		// processing never goes back to pending.
+		return next != StatusPending
+
+	case StatusFailed:
+		return true
+	}
+
+	return false
+}
+
 // Payment is the application-side representation of a payment attempt/result.
 //
 // Firestore rule:
@@ -75,6 +117,10 @@ type Payment struct {
 	ErrorCode *string
 	ErrorMsg  *string
 
+	// Dispute is the latest Stripe dispute (chargeback) of the Payment.
+	// It does not change Status; a disputed Payment stays succeeded.
+	Dispute *Dispute
+
 	CreatedAt time.Time
 }
 
@@ -91,6 +137,7 @@ var (
 	ErrInvalidErrorCode           = errors.New("payment: invalid errorCode")
 	ErrInvalidErrorMsg            = errors.New("payment: invalid errorMsg")
 	ErrInvalidCreatedAt           = errors.New("payment: invalid createdAt")
+	ErrStatusTransition           = errors.New("payment: status transition is not allowed")
 )
 
 // Policy
@@ -199,6 +246,49 @@ func (p *Payment) SetStatus(next PaymentStatus) error {
 	return nil
 }
 
+// TransitionTo applies a Stripe status change.
+//
+// failed / canceled store the given error metadata; any other status clears
+// stale error metadata. A transition rejected by CanTransition returns
+// ErrStatusTransition and leaves the Payment unchanged.
+func (p *Payment) TransitionTo(
+	next PaymentStatus,
+	errType *string,
+	errCode *string,
+	errMsg *string,
+) (changed bool, err error) {
+	if !IsValidStatus(next) {
+		return false, ErrInvalidStatus
+	}
+
+	if !CanTransition(p.Status, next) {
+		return false, ErrStatusTransition
+	}
+
+	if next != StatusFailed && next != StatusCanceled {
+		errType, errCode, errMsg = nil, nil, nil
+	}
+
+	if errType != nil && *errType == "" {
+		return false, ErrInvalidErrorType
+	}
+	if errCode != nil && *errCode == "" {
+		return false, ErrInvalidErrorCode
+	}
+	if errMsg != nil && *errMsg == "" {
+		return false, ErrInvalidErrorMsg
+	}
+
+	changed = p.Status != next
+
+	p.Status = next
+	p.ErrorType = errType
+	p.ErrorCode = errCode
+	p.ErrorMsg = errMsg
+
+	return changed, nil
+}
+
 func (p *Payment) SetPaymentMethodID(paymentMethodID string) error {
 	if paymentMethodID == "" {
 		return ErrInvalidPaymentMethodID
@@ -328,5 +418,11 @@ func (p Payment) validate() error {
 		return ErrInvalidCreatedAt
 	}
 
+	if p.Dispute != nil {
+		if err := p.Dispute.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
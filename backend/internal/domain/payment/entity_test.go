@@ -0,0 +1,148 @@
+// backend/internal/domain/payment/entity_test.go
+package payment
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testPayment(t *testing.T, status PaymentStatus) Payment {
+	t.Helper()
+
+	p, err := New("order_1", "pm_1", "cus_1", "pm_stripe_1", "pi_1", 5000, status, nil, nil, nil, testNow)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	return p
+}
+
+func TestCanTransition(t *testing.T) {
+	statuses := []PaymentStatus{
+		StatusPending,
+		StatusRequiresAction,
+		StatusProcessing,
+		StatusSucceeded,
+		StatusFailed,
+		StatusCanceled,
+	}
+
+	// denied[current] lists the statuses current may not move to; re-applying the same status is always allowed.
+	denied := map[PaymentStatus][]PaymentStatus{
+		StatusPending:        {},
+		StatusRequiresAction: {},
+		StatusProcessing:     {StatusPending},
+		StatusSucceeded:      {StatusPending, StatusRequiresAction, StatusProcessing, StatusFailed, StatusCanceled},
+		StatusFailed:         {},
+		StatusCanceled:       {StatusPending, StatusRequiresAction, StatusProcessing, StatusSucceeded, StatusFailed},
+	}
+
+	for _, current := range statuses {
+		for _, next := range statuses {
+			want := true
+			for _, d := range denied[current] {
+				if d == next {
+					want = false
+				}
+			}
+
+			if got := CanTransition(current, next); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", current, next, got, want)
+			}
+		}
+	}
+
+	if CanTransition(StatusPending, "refunded") || CanTransition("", StatusPending) {
+		t.Fatalf("CanTransition accepted an unknown status")
+	}
+}
+
+func TestPayment_TransitionTo(t *testing.T) {
+	errType := "card_error"
+	errCode := "card_declined"
+	errMsg := "Your card was declined."
+	empty := ""
+
+	tests := []struct {
+		name        string
+		current     PaymentStatus
+		next        PaymentStatus
+		errMsg      *string
+		wantStatus  PaymentStatus
+		wantChanged bool
+		wantErrMeta bool
+		wantErr     error
+	}{
+		{name: "pending to succeeded", current: StatusPending, next: StatusSucceeded, wantStatus: StatusSucceeded, wantChanged: true},
+		{name: "processing to failed", current: StatusProcessing, next: StatusFailed, errMsg: &errMsg, wantStatus: StatusFailed, wantChanged: true, wantErrMeta: true},
+		{name: "failed recovers", current: StatusFailed, next: StatusProcessing, wantStatus: StatusProcessing, wantChanged: true},
+		// Re-applying the same status only refreshes the error metadata.
+		{name: "failed again", current: StatusFailed, next: StatusFailed, errMsg: &errMsg, wantStatus: StatusFailed, wantErrMeta: true},
+		{name: "stale processing after succeeded", current: StatusSucceeded, next: StatusProcessing, wantStatus: StatusSucceeded, wantErr: ErrStatusTransition},
+		{name: "stale pending after processing", current: StatusProcessing, next: StatusPending, wantStatus: StatusProcessing, wantErr: ErrStatusTransition},
+		{name: "failed after canceled", current: StatusCanceled, next: StatusFailed, errMsg: &errMsg, wantStatus: StatusCanceled, wantErr: ErrStatusTransition},
+		{name: "unknown status", current: StatusPending, next: "refunded", wantStatus: StatusPending, wantErr: ErrInvalidStatus},
+		{name: "empty error message", current: StatusPending, next: StatusFailed, errMsg: &empty, wantStatus: StatusPending, wantErr: ErrInvalidErrorMsg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPayment(t, StatusPending)
+			p.Status = tt.current
+			// Start with error metadata left over from an earlier failure.
+			p.ErrorType, p.ErrorCode, p.ErrorMsg = &errType, &errCode, &errMsg
+
+			changed, err := p.TransitionTo(tt.next, &errType, &errCode, tt.errMsg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TransitionTo err = %v, want %v", err, tt.wantErr)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if p.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", p.Status, tt.wantStatus)
+			}
+			if err != nil {
+				return
+			}
+
+			if hasMeta := p.ErrorType != nil || p.ErrorCode != nil || p.ErrorMsg != nil; hasMeta != tt.wantErrMeta {
+				t.Errorf("error metadata present = %v, want %v", hasMeta, tt.wantErrMeta)
+			}
+		})
+	}
+}
+
+// Stripe delivers events out of order and more than once; the final status must not depend on the order.
+func TestPayment_TransitionTo_OutOfOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []PaymentStatus
+		want   PaymentStatus
+	}{
+		{name: "in order", events: []PaymentStatus{StatusRequiresAction, StatusProcessing, StatusSucceeded}, want: StatusSucceeded},
+		{name: "succeeded first", events: []PaymentStatus{StatusSucceeded, StatusProcessing, StatusRequiresAction}, want: StatusSucceeded},
+		{name: "duplicated", events: []PaymentStatus{StatusProcessing, StatusSucceeded, StatusSucceeded, StatusProcessing}, want: StatusSucceeded},
+		{name: "failed then succeeded", events: []PaymentStatus{StatusFailed, StatusProcessing, StatusSucceeded}, want: StatusSucceeded},
+		{name: "canceled first", events: []PaymentStatus{StatusCanceled, StatusProcessing}, want: StatusCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPayment(t, StatusPending)
+
+			for _, next := range tt.events {
+				if _, err := p.TransitionTo(next, nil, nil, nil); err != nil && !errors.Is(err, ErrStatusTransition) {
+					t.Fatalf("TransitionTo(%s): %v", next, err)
+				}
+			}
+
+			if p.Status != tt.want {
+				t.Fatalf("Status = %s, want %s", p.Status, tt.want)
+			}
+		})
+	}
+}
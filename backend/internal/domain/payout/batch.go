@@ -0,0 +1,301 @@
+// backend/internal/domain/payout/batch.go
+package payout
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Batch は 1 回分の振込（支払いバッチ）です。
+//
+// 流れ:
+//   - draft: 締め日時（CutoffAt）より前の未払いの台帳を受取先ごとに集計して作成する
+//   - approved: 作成者以外のメンバーが承認する
+//   - exported: 全銀協フォーマットの振込ファイルを出力した（再出力可）
+//   - paid: 振込の完了を記録した
+//   - cancelled: draft / approved のバッチを取り消した（台帳は次のバッチの対象に戻る）
+type Batch struct {
+	ID string `json:"id"`
+
+	CompanyID string `json:"companyId"`
+
+	CutoffAt time.Time `json:"cutoffAt"`
+
+	// TransferDate は振込指定日（JST の日付）です。
+	TransferDate time.Time `json:"transferDate"`
+
+	Status BatchStatus `json:"status"`
+
+	Lines []Line `json:"lines"`
+
+	TotalAmount int `json:"totalAmount"`
+	EntryCount  int `json:"entryCount"`
+
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	ApprovedBy string     `json:"approvedBy,omitempty"`
+	ApprovedAt *time.Time `json:"approvedAt,omitempty"`
+
+	ExportedAt  *time.Time `json:"exportedAt,omitempty"`
+	PaidAt      *time.Time `json:"paidAt,omitempty"`
+	CancelledAt *time.Time `json:"cancelledAt,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Line は受取先 1 件分の振込です。口座はバッチ作成時点の内容を保存します。
+type Line struct {
+	RecipientType RecipientType `json:"recipientType"`
+	RecipientID   string        `json:"recipientId"`
+
+	Account BankAccount `json:"account"`
+
+	Amount int `json:"amount"`
+
+	EntryIDs []string `json:"entryIds"`
+}
+
+// BankAccount は振込先口座のスナップショットです。
+type BankAccount struct {
+	AccountID string `json:"accountId"`
+
+	BankCode   string `json:"bankCode"`
+	BankName   string `json:"bankName"`
+	BranchCode string `json:"branchCode"`
+	BranchName string `json:"branchName"`
+
+	// AccountType は全銀協の預金種目（"1" 普通 / "2" 当座）です。
+	AccountType   string `json:"accountType"`
+	AccountNumber string `json:"accountNumber"`
+
+	HolderNameKana string `json:"holderNameKana"`
+}
+
+type BatchStatus string
+
+const (
+	BatchStatusDraft     BatchStatus = "draft"
+	BatchStatusApproved  BatchStatus = "approved"
+	BatchStatusExported  BatchStatus = "exported"
+	BatchStatusPaid      BatchStatus = "paid"
+	BatchStatusCancelled BatchStatus = "cancelled"
+)
+
+func IsValidBatchStatus(s BatchStatus) bool {
+	switch s {
+	case BatchStatusDraft,
+		BatchStatusApproved,
+		BatchStatusExported,
+		BatchStatusPaid,
+		BatchStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// MaxBatchEntries は 1 バッチに含められる台帳の行数です（Firestore transaction の書き込み上限に合わせる）。
+const MaxBatchEntries = 400
+
+var (
+	ErrInvalidBatchID      = errors.New("payout: invalid batch id")
+	ErrInvalidBatchStatus  = errors.New("payout: invalid batch status")
+	ErrInvalidCutoffAt     = errors.New("payout: invalid cutoffAt")
+	ErrInvalidTransferDate = errors.New("payout: invalid transferDate")
+	ErrInvalidCreatedBy    = errors.New("payout: invalid createdBy")
+	ErrInvalidLines        = errors.New("payout: invalid lines")
+	ErrEmptyBatch          = errors.New("payout: nothing to pay out")
+	ErrTooManyEntries      = errors.New("payout: too many entries for one batch")
+
+	ErrInvalidTransition = errors.New("payout: invalid batch status transition")
+	ErrSelfApproval      = errors.New("payout: batch must be approved by another member")
+)
+
+type NewBatchInput struct {
+	ID        string
+	CompanyID string
+
+	CutoffAt     time.Time
+	TransferDate time.Time
+
+	Lines []Line
+
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+func NewBatch(in NewBatchInput) (Batch, error) {
+	createdAt := in.CreatedAt.UTC()
+
+	b := Batch{
+		ID: strings.TrimSpace(in.ID),
+
+		CompanyID: strings.TrimSpace(in.CompanyID),
+
+		CutoffAt:     in.CutoffAt.UTC(),
+		TransferDate: in.TransferDate.UTC(),
+
+		Status: BatchStatusDraft,
+
+		Lines: in.Lines,
+
+		CreatedBy: strings.TrimSpace(in.CreatedBy),
+		CreatedAt: createdAt,
+
+		UpdatedAt: createdAt,
+	}
+
+	for _, line := range b.Lines {
+		b.TotalAmount += line.Amount
+		b.EntryCount += len(line.EntryIDs)
+	}
+
+	if len(b.Lines) == 0 {
+		return Batch{}, ErrEmptyBatch
+	}
+	if b.EntryCount > MaxBatchEntries {
+		return Batch{}, ErrTooManyEntries
+	}
+
+	if err := b.Validate(); err != nil {
+		return Batch{}, err
+	}
+
+	return b, nil
+}
+
+// EntryIDs はバッチに含まれる台帳の ID を返します。
+func (b Batch) EntryIDs() []string {
+	ids := make([]string, 0, b.EntryCount)
+	for _, line := range b.Lines {
+		ids = append(ids, line.EntryIDs...)
+	}
+	return ids
+}
+
+// LineFor は受取先の振込を返します。
+func (b Batch) LineFor(
+	recipientType RecipientType,
+	recipientID string,
+) (Line, bool) {
+	for _, line := range b.Lines {
+		if line.RecipientType == recipientType && line.RecipientID == recipientID {
+			return line, true
+		}
+	}
+	return Line{}, false
+}
+
+// Approve は作成者以外のメンバーによる承認を記録します。
+func (b *Batch) Approve(by string, now time.Time) error {
+	if b.Status != BatchStatusDraft {
+		return ErrInvalidTransition
+	}
+
+	by = strings.TrimSpace(by)
+	if by == "" {
+		return ErrInvalidCreatedBy
+	}
+	if by == b.CreatedBy {
+		return ErrSelfApproval
+	}
+
+	now = now.UTC()
+
+	b.Status = BatchStatusApproved
+	b.ApprovedBy = by
+	b.ApprovedAt = &now
+	b.UpdatedAt = now
+
+	return nil
+}
+
+// MarkExported は振込ファイルの出力を記録します。出力済みのバッチは再出力できます。
+func (b *Batch) MarkExported(now time.Time) error {
+	switch b.Status {
+	case BatchStatusApproved:
+		now = now.UTC()
+
+		b.Status = BatchStatusExported
+		b.ExportedAt = &now
+		b.UpdatedAt = now
+
+		return nil
+
+	case BatchStatusExported:
+		return nil
+
+	default:
+		return ErrInvalidTransition
+	}
+}
+
+// MarkPaid は振込の完了を記録します。
+func (b *Batch) MarkPaid(now time.Time) error {
+	if b.Status != BatchStatusExported {
+		return ErrInvalidTransition
+	}
+
+	now = now.UTC()
+
+	b.Status = BatchStatusPaid
+	b.PaidAt = &now
+	b.UpdatedAt = now
+
+	return nil
+}
+
+// Cancel はバッチを取り消します。振込ファイルを出力した後は取り消せません。
+func (b *Batch) Cancel(now time.Time) error {
+	if b.Status != BatchStatusDraft && b.Status != BatchStatusApproved {
+		return ErrInvalidTransition
+	}
+
+	now = now.UTC()
+
+	b.Status = BatchStatusCancelled
+	b.CancelledAt = &now
+	b.UpdatedAt = now
+
+	return nil
+}
+
+func (b Batch) Validate() error {
+	if b.ID == "" || strings.Contains(b.ID, "/") {
+		return ErrInvalidBatchID
+	}
+	if b.CompanyID == "" {
+		return ErrInvalidCompanyID
+	}
+	if b.CutoffAt.IsZero() {
+		return ErrInvalidCutoffAt
+	}
+	if b.TransferDate.IsZero() {
+		return ErrInvalidTransferDate
+	}
+	if !IsValidBatchStatus(b.Status) {
+		return ErrInvalidBatchStatus
+	}
+	if b.CreatedBy == "" {
+		return ErrInvalidCreatedBy
+	}
+
+	total := 0
+	count := 0
+	for _, line := range b.Lines {
+		if !IsValidRecipientType(line.RecipientType) || line.RecipientID == "" {
+			return ErrInvalidLines
+		}
+		if line.Account.AccountID == "" || line.Amount <= 0 || len(line.EntryIDs) == 0 {
+			return ErrInvalidLines
+		}
+		total += line.Amount
+		count += len(line.EntryIDs)
+	}
+	if total != b.TotalAmount || count != b.EntryCount {
+		return ErrInvalidLines
+	}
+	return nil
+}
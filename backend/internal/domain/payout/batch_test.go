@@ -0,0 +1,260 @@
+// backend/internal/domain/payout/batch_test.go
+package payout
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func TestFeeAmount(t *testing.T) {
+	tests := []struct {
+		gross       int
+		basisPoints int
+		want        int
+	}{
+		{gross: 10000, basisPoints: 1000, want: 1000},
+		{gross: 999, basisPoints: 350, want: 34},
+		{gross: 10000, basisPoints: 0, want: 0},
+		{gross: 10000, basisPoints: MaxFeeBasisPoints, want: 10000},
+		// 返金調整は同じ割合で手数料も戻す（切り捨ては絶対値に対して行う）。
+		{gross: -999, basisPoints: 350, want: -34},
+		{gross: -10000, basisPoints: 1000, want: -1000},
+	}
+
+	for _, tt := range tests {
+		if got := FeeAmount(tt.gross, tt.basisPoints); got != tt.want {
+			t.Errorf("FeeAmount(%d, %d) = %d, want %d", tt.gross, tt.basisPoints, got, tt.want)
+		}
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	base := func() NewEntryInput {
+		return NewEntryInput{
+			CompanyID:      "company_1",
+			RecipientType:  RecipientTypeCompany,
+			RecipientID:    "company_1",
+			Source:         SourceBrandSale,
+			SourceID:       "order_1_0",
+			OrderID:        "order_1",
+			GrossAmount:    10000,
+			FeeBasisPoints: 1000,
+			AccruedAt:      testNow,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(in *NewEntryInput)
+		wantNet int
+		want    error
+	}{
+		{name: "brand sale", modify: func(in *NewEntryInput) {}, wantNet: 9000},
+		{
+			name: "refund adjustment is negative",
+			modify: func(in *NewEntryInput) {
+				in.Source = SourceRefundAdjustment
+				in.SourceID = "refund_1_0"
+				in.GrossAmount = -5000
+			},
+			wantNet: -4500,
+		},
+		{
+			name: "positive refund adjustment",
+			modify: func(in *NewEntryInput) {
+				in.Source = SourceRefundAdjustment
+				in.GrossAmount = 5000
+			},
+			want: ErrInvalidAmount,
+		},
+		{name: "negative sale", modify: func(in *NewEntryInput) { in.GrossAmount = -1 }, want: ErrInvalidAmount},
+		{name: "zero sale", modify: func(in *NewEntryInput) { in.GrossAmount = 0 }, want: ErrInvalidAmount},
+		{name: "fee over 100%", modify: func(in *NewEntryInput) { in.FeeBasisPoints = MaxFeeBasisPoints + 1 }, want: ErrInvalidFeeBasisPoints},
+		{name: "unknown source", modify: func(in *NewEntryInput) { in.Source = "tip" }, want: ErrInvalidSource},
+		{name: "source id with slash", modify: func(in *NewEntryInput) { in.SourceID = "a/b" }, want: ErrInvalidSourceID},
+		{name: "unknown recipient type", modify: func(in *NewEntryInput) { in.RecipientType = "user" }, want: ErrInvalidRecipientType},
+		{name: "zero accruedAt", modify: func(in *NewEntryInput) { in.AccruedAt = time.Time{} }, want: ErrInvalidAccruedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := base()
+			tt.modify(&in)
+
+			e, err := NewEntry(in)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewEntry err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+
+			if e.ID != EntryID(in.Source, in.SourceID) {
+				t.Errorf("ID = %q, want %q", e.ID, EntryID(in.Source, in.SourceID))
+			}
+			if e.NetAmount != tt.wantNet || e.GrossAmount-e.FeeAmount != e.NetAmount {
+				t.Errorf("Gross = %d, Fee = %d, Net = %d, want net %d", e.GrossAmount, e.FeeAmount, e.NetAmount, tt.wantNet)
+			}
+		})
+	}
+}
+
+func testLine(recipientID string, amount int, entryIDs ...string) Line {
+	return Line{
+		RecipientType: RecipientTypeCompany,
+		RecipientID:   recipientID,
+		Account:       BankAccount{AccountID: "account_" + recipientID},
+		Amount:        amount,
+		EntryIDs:      entryIDs,
+	}
+}
+
+func testBatchInput() NewBatchInput {
+	return NewBatchInput{
+		ID:           "batch_1",
+		CompanyID:    "company_1",
+		CutoffAt:     testNow,
+		TransferDate: testNow.Add(72 * time.Hour),
+		Lines: []Line{
+			testLine("company_1", 9000, "brand_sale_a", "brand_sale_b"),
+			testLine("company_2", 500, "royalty_a"),
+		},
+		CreatedBy: "member_1",
+		CreatedAt: testNow,
+	}
+}
+
+func TestNewBatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(in *NewBatchInput)
+		want   error
+	}{
+		{name: "valid", modify: func(in *NewBatchInput) {}},
+		{name: "no lines", modify: func(in *NewBatchInput) { in.Lines = nil }, want: ErrEmptyBatch},
+		{
+			name: "too many entries",
+			modify: func(in *NewBatchInput) {
+				ids := make([]string, MaxBatchEntries+1)
+				for i := range ids {
+					ids[i] = "entry"
+				}
+				in.Lines = []Line{testLine("company_1", 1000, ids...)}
+			},
+			want: ErrTooManyEntries,
+		},
+		{name: "zero amount line", modify: func(in *NewBatchInput) { in.Lines[1].Amount = 0 }, want: ErrInvalidLines},
+		{name: "line without entries", modify: func(in *NewBatchInput) { in.Lines[1].EntryIDs = nil }, want: ErrInvalidLines},
+		{name: "line without account", modify: func(in *NewBatchInput) { in.Lines[1].Account.AccountID = "" }, want: ErrInvalidLines},
+		{name: "missing creator", modify: func(in *NewBatchInput) { in.CreatedBy = " " }, want: ErrInvalidCreatedBy},
+		{name: "zero transfer date", modify: func(in *NewBatchInput) { in.TransferDate = time.Time{} }, want: ErrInvalidTransferDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := testBatchInput()
+			tt.modify(&in)
+
+			b, err := NewBatch(in)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewBatch err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+
+			if b.Status != BatchStatusDraft || b.TotalAmount != 9500 || b.EntryCount != 3 {
+				t.Fatalf("Status = %s, TotalAmount = %d, EntryCount = %d", b.Status, b.TotalAmount, b.EntryCount)
+			}
+			if got := b.EntryIDs(); len(got) != 3 || got[2] != "royalty_a" {
+				t.Fatalf("EntryIDs = %v", got)
+			}
+		})
+	}
+}
+
+func TestBatch_Transitions(t *testing.T) {
+	approve := func(b *Batch) error { return b.Approve("member_2", testNow) }
+	export := func(b *Batch) error { return b.MarkExported(testNow) }
+	paid := func(b *Batch) error { return b.MarkPaid(testNow) }
+	cancel := func(b *Batch) error { return b.Cancel(testNow) }
+
+	tests := []struct {
+		name       string
+		steps      []func(b *Batch) error
+		apply      func(b *Batch) error
+		wantStatus BatchStatus
+		wantErr    error
+	}{
+		{name: "approve draft", apply: approve, wantStatus: BatchStatusApproved},
+		{
+			name:       "creator cannot approve",
+			apply:      func(b *Batch) error { return b.Approve("member_1", testNow) },
+			wantStatus: BatchStatusDraft,
+			wantErr:    ErrSelfApproval,
+		},
+		{name: "export draft", apply: export, wantStatus: BatchStatusDraft, wantErr: ErrInvalidTransition},
+		{name: "export approved", steps: []func(b *Batch) error{approve}, apply: export, wantStatus: BatchStatusExported},
+		{name: "re-export", steps: []func(b *Batch) error{approve, export}, apply: export, wantStatus: BatchStatusExported},
+		{name: "pay approved", steps: []func(b *Batch) error{approve}, apply: paid, wantStatus: BatchStatusApproved, wantErr: ErrInvalidTransition},
+		{name: "pay exported", steps: []func(b *Batch) error{approve, export}, apply: paid, wantStatus: BatchStatusPaid},
+		{name: "cancel draft", apply: cancel, wantStatus: BatchStatusCancelled},
+		{name: "cancel approved", steps: []func(b *Batch) error{approve}, apply: cancel, wantStatus: BatchStatusCancelled},
+		{name: "cancel exported", steps: []func(b *Batch) error{approve, export}, apply: cancel, wantStatus: BatchStatusExported, wantErr: ErrInvalidTransition},
+		{name: "approve cancelled", steps: []func(b *Batch) error{cancel}, apply: approve, wantStatus: BatchStatusCancelled, wantErr: ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBatch(testBatchInput())
+			if err != nil {
+				t.Fatalf("NewBatch: %v", err)
+			}
+			for _, step := range tt.steps {
+				if err := step(&b); err != nil {
+					t.Fatalf("step: %v", err)
+				}
+			}
+
+			if err := tt.apply(&b); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if b.Status != tt.wantStatus {
+				t.Fatalf("Status = %s, want %s", b.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNewStatement(t *testing.T) {
+	b, err := NewBatch(testBatchInput())
+	if err != nil {
+		t.Fatalf("NewBatch: %v", err)
+	}
+
+	line, ok := b.LineFor(RecipientTypeCompany, "company_1")
+	if !ok {
+		t.Fatalf("LineFor: not found")
+	}
+	if _, ok := b.LineFor(RecipientTypeAvatar, "company_1"); ok {
+		t.Fatalf("LineFor(avatar): found, want not found")
+	}
+
+	entries := []Entry{
+		{GrossAmount: 8000, FeeAmount: 800, NetAmount: 7200},
+		{GrossAmount: 3000, FeeAmount: 300, NetAmount: 2700},
+		{GrossAmount: -1000, FeeAmount: -100, NetAmount: -900},
+	}
+
+	s := NewStatement(b, line, entries, "株式会社テスト", testNow)
+
+	if s.GrossAmount != 10000 || s.FeeAmount != 1000 || s.NetAmount != 9000 {
+		t.Fatalf("Gross = %d, Fee = %d, Net = %d", s.GrossAmount, s.FeeAmount, s.NetAmount)
+	}
+	if s.NetAmount != line.Amount {
+		t.Fatalf("NetAmount = %d, want line amount %d", s.NetAmount, line.Amount)
+	}
+}
@@ -0,0 +1,204 @@
+// backend/internal/domain/payout/entry.go
+package payout
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Entry は支払い台帳の 1 行です。
+//
+// 発生源（Source）ごとに 1 回だけ計上します（ID は {source}_{sourceId}）。
+//   - brand_sale: list item の販売代金（税抜, クーポン値引き後）。受取先はブランドの company
+//   - royalty: resale item のロイヤリティ。受取先は token blueprint の company
+//   - resale_proceeds: released になった escrow の出品者受取額。受取先は出品者の avatar
+//   - refund_adjustment: 計上済みの明細の返金による減額（金額は負）
+//
+// NetAmount = GrossAmount - FeeAmount（プラットフォーム手数料）。
+// BatchID が空の行が次の支払いバッチの対象になります。
+type Entry struct {
+	ID string `json:"id"`
+
+	// CompanyID は支払いバッチを作成・承認する company（console の company scope）です。
+	CompanyID string `json:"companyId"`
+
+	RecipientType RecipientType `json:"recipientType"`
+	RecipientID   string        `json:"recipientId"`
+
+	Source   Source `json:"source"`
+	SourceID string `json:"sourceId"`
+
+	OrderID   string `json:"orderId,omitempty"`
+	ItemIndex int    `json:"itemIndex"`
+
+	GrossAmount    int `json:"grossAmount"`
+	FeeBasisPoints int `json:"feeBasisPoints"`
+	FeeAmount      int `json:"feeAmount"`
+	NetAmount      int `json:"netAmount"`
+
+	AccruedAt time.Time `json:"accruedAt"`
+
+	BatchID string `json:"batchId,omitempty"`
+}
+
+// RecipientType は受取先の種類です。
+type RecipientType string
+
+const (
+	RecipientTypeCompany RecipientType = "company"
+	RecipientTypeAvatar  RecipientType = "avatar"
+)
+
+func IsValidRecipientType(t RecipientType) bool {
+	return t == RecipientTypeCompany || t == RecipientTypeAvatar
+}
+
+// Source は台帳の発生源です。
+type Source string
+
+const (
+	SourceBrandSale        Source = "brand_sale"
+	SourceRoyalty          Source = "royalty"
+	SourceResaleProceeds   Source = "resale_proceeds"
+	SourceRefundAdjustment Source = "refund_adjustment"
+)
+
+func IsValidSource(s Source) bool {
+	switch s {
+	case SourceBrandSale,
+		SourceRoyalty,
+		SourceResaleProceeds,
+		SourceRefundAdjustment:
+		return true
+	default:
+		return false
+	}
+}
+
+// MaxFeeBasisPoints は手数料率の上限（100%）です。
+const MaxFeeBasisPoints = 10000
+
+var (
+	ErrInvalidID             = errors.New("payout: invalid id")
+	ErrInvalidCompanyID      = errors.New("payout: invalid companyId")
+	ErrInvalidRecipientType  = errors.New("payout: invalid recipientType")
+	ErrInvalidRecipientID    = errors.New("payout: invalid recipientId")
+	ErrInvalidSource         = errors.New("payout: invalid source")
+	ErrInvalidSourceID       = errors.New("payout: invalid sourceId")
+	ErrInvalidAmount         = errors.New("payout: invalid amount")
+	ErrInvalidFeeBasisPoints = errors.New("payout: invalid feeBasisPoints")
+	ErrInvalidAccruedAt      = errors.New("payout: invalid accruedAt")
+)
+
+// EntryID は document ID を返します。
+func EntryID(source Source, sourceID string) string {
+	return fmt.Sprintf("%s_%s", source, strings.TrimSpace(sourceID))
+}
+
+// FeeAmount は gross に対する手数料です（1 円未満切り捨て）。
+// 負の gross（返金調整）には負の手数料を返し、手数料も同じ割合で戻します。
+func FeeAmount(gross int, basisPoints int) int {
+	if gross < 0 {
+		return -FeeAmount(-gross, basisPoints)
+	}
+	return gross * basisPoints / MaxFeeBasisPoints
+}
+
+type NewEntryInput struct {
+	CompanyID string
+
+	RecipientType RecipientType
+	RecipientID   string
+
+	Source   Source
+	SourceID string
+
+	OrderID   string
+	ItemIndex int
+
+	GrossAmount    int
+	FeeBasisPoints int
+
+	AccruedAt time.Time
+}
+
+func NewEntry(in NewEntryInput) (Entry, error) {
+	fee := FeeAmount(in.GrossAmount, in.FeeBasisPoints)
+
+	e := Entry{
+		ID: EntryID(in.Source, in.SourceID),
+
+		CompanyID: strings.TrimSpace(in.CompanyID),
+
+		RecipientType: in.RecipientType,
+		RecipientID:   strings.TrimSpace(in.RecipientID),
+
+		Source:   in.Source,
+		SourceID: strings.TrimSpace(in.SourceID),
+
+		OrderID:   strings.TrimSpace(in.OrderID),
+		ItemIndex: in.ItemIndex,
+
+		GrossAmount:    in.GrossAmount,
+		FeeBasisPoints: in.FeeBasisPoints,
+		FeeAmount:      fee,
+		NetAmount:      in.GrossAmount - fee,
+
+		AccruedAt: in.AccruedAt.UTC(),
+	}
+
+	if err := e.Validate(); err != nil {
+		return Entry{}, err
+	}
+
+	return e, nil
+}
+
+// IsBatched は支払いバッチに含まれているかを返します。
+func (e Entry) IsBatched() bool {
+	return e.BatchID != ""
+}
+
+func (e Entry) Validate() error {
+	if !IsValidSource(e.Source) {
+		return ErrInvalidSource
+	}
+	if e.SourceID == "" || strings.Contains(e.SourceID, "/") {
+		return ErrInvalidSourceID
+	}
+	if e.ID != EntryID(e.Source, e.SourceID) {
+		return ErrInvalidID
+	}
+	if e.CompanyID == "" {
+		return ErrInvalidCompanyID
+	}
+	if !IsValidRecipientType(e.RecipientType) {
+		return ErrInvalidRecipientType
+	}
+	if e.RecipientID == "" || strings.Contains(e.RecipientID, "/") {
+		return ErrInvalidRecipientID
+	}
+	if e.FeeBasisPoints < 0 || e.FeeBasisPoints > MaxFeeBasisPoints {
+		return ErrInvalidFeeBasisPoints
+	}
+
+	// 返金調整だけが負の金額を持つ。
+	if e.Source == SourceRefundAdjustment {
+		if e.GrossAmount >= 0 {
+			return ErrInvalidAmount
+		}
+	} else if e.GrossAmount <= 0 {
+		return ErrInvalidAmount
+	}
+	if e.FeeAmount != FeeAmount(e.GrossAmount, e.FeeBasisPoints) ||
+		e.NetAmount != e.GrossAmount-e.FeeAmount {
+		return ErrInvalidAmount
+	}
+
+	if e.AccruedAt.IsZero() {
+		return ErrInvalidAccruedAt
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// backend/internal/domain/payout/recipient.go
+package payout
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Recipient は受取先ごとの振込先口座（accounts/{accountId}）の登録です。
+//
+// company scope ごとに登録します（ID は {companyId}_{recipientType}_{recipientId}）。
+// 口座には全銀協フォーマットで必要な金融機関コード・支店コード・口座名義（カナ）が必要です。
+type Recipient struct {
+	ID string `json:"id"`
+
+	CompanyID string `json:"companyId"`
+
+	RecipientType RecipientType `json:"recipientType"`
+	RecipientID   string        `json:"recipientId"`
+
+	AccountID string `json:"accountId"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+}
+
+var ErrInvalidAccountID = errors.New("payout: invalid accountId")
+
+// RecipientKey は document ID を返します。
+func RecipientKey(
+	companyID string,
+	recipientType RecipientType,
+	recipientID string,
+) string {
+	return fmt.Sprintf(
+		"%s_%s_%s",
+		strings.TrimSpace(companyID),
+		recipientType,
+		strings.TrimSpace(recipientID),
+	)
+}
+
+func NewRecipient(
+	companyID string,
+	recipientType RecipientType,
+	recipientID string,
+	accountID string,
+	updatedBy string,
+	now time.Time,
+) (Recipient, error) {
+	r := Recipient{
+		ID: RecipientKey(companyID, recipientType, recipientID),
+
+		CompanyID: strings.TrimSpace(companyID),
+
+		RecipientType: recipientType,
+		RecipientID:   strings.TrimSpace(recipientID),
+
+		AccountID: strings.TrimSpace(accountID),
+
+		UpdatedAt: now.UTC(),
+		UpdatedBy: strings.TrimSpace(updatedBy),
+	}
+
+	if err := r.Validate(); err != nil {
+		return Recipient{}, err
+	}
+
+	return r, nil
+}
+
+func (r Recipient) Validate() error {
+	if r.CompanyID == "" || strings.Contains(r.CompanyID, "/") {
+		return ErrInvalidCompanyID
+	}
+	if !IsValidRecipientType(r.RecipientType) {
+		return ErrInvalidRecipientType
+	}
+	if r.RecipientID == "" || strings.Contains(r.RecipientID, "/") {
+		return ErrInvalidRecipientID
+	}
+	if r.ID != RecipientKey(r.CompanyID, r.RecipientType, r.RecipientID) {
+		return ErrInvalidID
+	}
+	if r.AccountID == "" {
+		return ErrInvalidAccountID
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// backend/internal/domain/payout/repository_port.go
+package payout
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// EntryFilter は台帳の絞り込み条件です（空の項目は絞り込まない）。
+type EntryFilter struct {
+	RecipientType RecipientType
+	RecipientID   string
+
+	// Unbatched が true の場合はバッチに含まれていない行だけを返します。
+	Unbatched bool
+	BatchID   string
+
+	// AccruedBefore は計上日時がこの時刻より前の行だけを返します。
+	AccruedBefore *time.Time
+}
+
+// RepositoryPort - ドメインのリポジトリ契約
+//
+// Collection:
+// - payoutEntries/{source}_{sourceId}
+// - payoutBatches/{batchId}
+// - payoutRecipients/{companyId}_{recipientType}_{recipientId}
+type RepositoryPort interface {
+	// CreateEntry returns ErrConflict when the entry already exists（同じ発生源は 1 回だけ計上する）。
+	CreateEntry(ctx context.Context, e Entry) (Entry, error)
+
+	GetEntry(ctx context.Context, id string) (Entry, error)
+
+	// ListEntriesByCompanyID は accruedAt の古い順に返します。
+	ListEntriesByCompanyID(ctx context.Context, companyID string, filter EntryFilter) ([]Entry, error)
+
+	// CreateBatch は batch を保存し、含まれる台帳の BatchID を同じ transaction で設定します。
+	// いずれかの台帳が既に別のバッチに含まれている場合は ErrConflict。
+	CreateBatch(ctx context.Context, b Batch) (Batch, error)
+
+	GetBatch(ctx context.Context, id string) (Batch, error)
+
+	// UpdateBatch は batch 全体を保存します。
+	// 保存済みの UpdatedAt が prevUpdatedAt と異なる場合は ErrConflict。
+	// cancelled に更新する場合は、含まれる台帳の BatchID を同じ transaction で外します。
+	UpdateBatch(ctx context.Context, b Batch, prevUpdatedAt time.Time) (Batch, error)
+
+	// ListBatchesByCompanyID は createdAt の新しい順に返します（status が空の場合はすべて）。
+	ListBatchesByCompanyID(ctx context.Context, companyID string, status BatchStatus) ([]Batch, error)
+
+	GetRecipient(ctx context.Context, id string) (Recipient, error)
+	SetRecipient(ctx context.Context, r Recipient) (Recipient, error)
+	ListRecipientsByCompanyID(ctx context.Context, companyID string) ([]Recipient, error)
+}
+
+// 共通エラー
+var (
+	ErrNotFound = errors.New("payout: not found")
+	ErrConflict = errors.New("payout: conflict")
+)
@@ -0,0 +1,65 @@
+// backend/internal/domain/payout/statement.go
+package payout
+
+import "time"
+
+// Statement は支払いバッチの受取先 1 件分の支払明細書です。
+type Statement struct {
+	BatchID string `json:"batchId"`
+
+	// CompanyName は支払元（バッチの company）の表示名です。
+	CompanyID   string `json:"companyId"`
+	CompanyName string `json:"companyName"`
+
+	RecipientType RecipientType `json:"recipientType"`
+	RecipientID   string        `json:"recipientId"`
+
+	Account BankAccount `json:"account"`
+
+	CutoffAt     time.Time `json:"cutoffAt"`
+	TransferDate time.Time `json:"transferDate"`
+
+	Entries []Entry `json:"entries"`
+
+	GrossAmount int `json:"grossAmount"`
+	FeeAmount   int `json:"feeAmount"`
+	NetAmount   int `json:"netAmount"`
+
+	IssuedAt time.Time `json:"issuedAt"`
+}
+
+// NewStatement は batch の受取先の明細書を組み立てます。entries は Line.EntryIDs の台帳です。
+func NewStatement(
+	b Batch,
+	line Line,
+	entries []Entry,
+	companyName string,
+	issuedAt time.Time,
+) Statement {
+	s := Statement{
+		BatchID: b.ID,
+
+		CompanyID:   b.CompanyID,
+		CompanyName: companyName,
+
+		RecipientType: line.RecipientType,
+		RecipientID:   line.RecipientID,
+
+		Account: line.Account,
+
+		CutoffAt:     b.CutoffAt,
+		TransferDate: b.TransferDate,
+
+		Entries: entries,
+
+		IssuedAt: issuedAt.UTC(),
+	}
+
+	for _, e := range entries {
+		s.GrossAmount += e.GrossAmount
+		s.FeeAmount += e.FeeAmount
+		s.NetAmount += e.NetAmount
+	}
+
+	return s
+}
@@ -1,46 +1,134 @@
 // backend/internal/domain/permission/catalog.go
 package permission
 
+import "strings"
+
+// 書き込み系の権限名（console route の PermissionMiddleware が参照する）
+const (
+	NameOrganizationSettingsUpdate = "organization.settings.update"
+	NameOrganizationBillingUpdate  = "organization.billing.update"
+	NameBrandUpdate                = "brand.update"
+	NameTokenUpdate                = "token.update"
+	NameTokenReviewUpdate          = "token.review.update"
+	NameOrderDispatch              = "order.dispatch"
+	NameOrderRefund                = "order.refund"
+	NameOrderReturnApprove         = "order.return.approve"
+	NameOrderEscrowUpdate          = "order.escrow.update"
+	NameOrderPayoutUpdate          = "order.payout.update"
+	NameOrderPayoutApprove         = "order.payout.approve"
+	NameOrderShippingUpdate        = "order.shipping.update"
+	NameMemberInvite               = "member.invite"
+	NameMemberUpdate               = "member.update"
+	NameMemberRolesAssign          = "member.roles.assign"
+	NameMemberMessageUpdate        = "member.message.update"
+	NameInquiryUpdate              = "inquiry.update"
+	NameInventoryUpdate            = "inventory.update"
+	NameProductUpdate              = "product.update"
+	NameProductionUpdate           = "production.update"
+	NameListPublish                = "list.publish"
+	NameCampaignCouponUpdate       = "campaign.coupon.update"
+	NameCampaignAnnouncementUpdate = "campaign.announcement.update"
+	NameMintRequest                = "mint.request"
+	NameSystemBillingUpdate        = "system.billing.update"
+	NameSystemPaymentUpdate        = "system.payment.update"
+)
+
+// 閲覧系の権限名（console route の PermissionMiddleware が参照する）
+const (
+	NameOrganizationAuditView   = "organization.audit.view"
+	NameOrganizationAccountView = "organization.account.view"
+	NameWalletView              = "wallet.view"
+	NameTokenRoyaltyView        = "token.royalty.view"
+	NameProductReviewView       = "product.review.view"
+	NameProductionReportView    = "production.report.view"
+	NameAnalyticsSalesView      = "analytics.sales.view"
+)
+
+// system.* は運営（プラットフォーム）向けの権限。テナントのロールには含めない。
+const systemPermissionPrefix = "system."
+
 // static な権限カタログ（バックエンドが唯一の真実）
-// Permission エンティティ側のポリシーにより、
-// action は read-only 系 ("read" / "list" / "view" / "export") のみ許可。
-// そのため name は必ず "<category>[.<subscope>].(read|list|view|export)" の形に統一する。
+// name は "<category>[.<subscope>].<action>" の形に統一する。
+// action は read-only 系 ("read" / "list" / "view" / "export") か、
+// entity.go の writeActions に定義された書き込み系のみ許可。
 var allPermissions = []Permission{
 	// Wallet
-	MustNew("perm_wallet_view", "wallet.view", "ウォレット閲覧", CategoryWallet),
+	MustNew("perm_wallet_view", NameWalletView, "ウォレット閲覧", CategoryWallet),
 	MustNew("perm_wallet_edit", "wallet.settings.view", "ウォレット設定閲覧", CategoryWallet),
 
 	// Inquiry
 	MustNew("perm_inquiry_view", "inquiry.view", "問い合わせ一覧閲覧", CategoryInquiry),
 	MustNew("perm_inquiry_manage", "inquiry.detail.view", "問い合わせ詳細・履歴閲覧", CategoryInquiry),
+	MustNew("perm_inquiry_update", NameInquiryUpdate, "問い合わせへの返信・対応状況の変更", CategoryInquiry),
 
 	// Organization
 	MustNew("perm_org_admin", "organization.settings.view", "組織設定・構成情報閲覧", CategoryOrganization),
+	MustNew("perm_org_settings_update", NameOrganizationSettingsUpdate, "組織設定の変更", CategoryOrganization),
+	MustNew("perm_org_billing_update", NameOrganizationBillingUpdate, "請求明細書の作成", CategoryOrganization),
+	MustNew("perm_org_audit_view", NameOrganizationAuditView, "監査ログの閲覧・出力", CategoryOrganization),
+	MustNew("perm_org_account_view", NameOrganizationAccountView, "口座情報閲覧", CategoryOrganization),
 
 	// Brand
 	MustNew("perm_brand_create", "brand.view", "ブランド一覧閲覧", CategoryBrand),
 	MustNew("perm_brand_edit", "brand.detail.view", "ブランド詳細閲覧", CategoryBrand),
 	MustNew("perm_brand_delete", "brand.archive.view", "アーカイブ済みブランド閲覧", CategoryBrand),
+	MustNew("perm_brand_update", NameBrandUpdate, "ブランドの作成・編集", CategoryBrand),
 
 	// Token
 	MustNew("perm_token_create", "token.view", "トークン一覧閲覧", CategoryToken),
 	MustNew("perm_token_manage", "token.distribution.view", "トークン配布・割当状況閲覧", CategoryToken),
+	MustNew("perm_token_update", NameTokenUpdate, "トークン設計の作成・編集", CategoryToken),
+	MustNew("perm_token_review_update", NameTokenReviewUpdate, "トークン設計レビューへのコメント・リアクション", CategoryToken),
+	MustNew("perm_token_royalty_view", NameTokenRoyaltyView, "ロイヤリティ閲覧", CategoryToken),
 
 	// Order
 	MustNew("perm_order_manage", "order.view", "注文情報閲覧", CategoryOrder),
+	MustNew("perm_order_dispatch", NameOrderDispatch, "注文の発送処理", CategoryOrder),
+	MustNew("perm_order_refund", NameOrderRefund, "注文の返金処理", CategoryOrder),
+	MustNew("perm_order_return_approve", NameOrderReturnApprove, "返品の承認・受領・完了処理", CategoryOrder),
+	MustNew("perm_order_escrow_update", NameOrderEscrowUpdate, "エスクロー（resale 取引）の紛争解決", CategoryOrder),
+	MustNew("perm_order_payout_update", NameOrderPayoutUpdate, "支払い（振込先・支払いバッチ・振込ファイル）の管理", CategoryOrder),
+	MustNew("perm_order_payout_approve", NameOrderPayoutApprove, "支払いバッチの承認", CategoryOrder),
+	MustNew("perm_order_shipping_update", NameOrderShippingUpdate, "配送方法・送料の設定", CategoryOrder),
 
 	// Member
 	MustNew("perm_member_view", "member.view", "メンバー一覧閲覧", CategoryMember),
 	MustNew("perm_member_edit", "member.roles.view", "メンバー権限・ロール設定閲覧", CategoryMember),
+	MustNew("perm_member_invite", NameMemberInvite, "メンバーの招待", CategoryMember),
+	MustNew("perm_member_update", NameMemberUpdate, "メンバー情報の編集・削除", CategoryMember),
+	MustNew("perm_member_roles_assign", NameMemberRolesAssign, "メンバーへのロール割当", CategoryMember),
+	MustNew("perm_member_message_update", NameMemberMessageUpdate, "メンバー間メッセージの送信", CategoryMember),
 
 	// Inventory
 	MustNew("perm_inventory_view", "inventory.view", "在庫情報閲覧", CategoryInventory),
+	MustNew("perm_inventory_update", NameInventoryUpdate, "在庫情報の編集", CategoryInventory),
+
+	// Product
+	MustNew("perm_product_update", NameProductUpdate, "商品設計の作成・編集", CategoryProduct),
+	MustNew("perm_product_review_view", NameProductReviewView, "商品設計レビュー閲覧", CategoryProduct),
 
 	// Production
 	MustNew("perm_production_manage", "production.status.view", "生産工程ステータス閲覧", CategoryProduction),
+	MustNew("perm_production_update", NameProductionUpdate, "生産・検品の登録・編集", CategoryProduction),
+	MustNew("perm_production_report_view", NameProductionReportView, "検品レポート（不良率・歩留まり）閲覧", CategoryProduction),
+
+	// List
+	MustNew("perm_list_publish", NameListPublish, "出品の作成・編集・公開", CategoryList),
+
+	// Campaign
+	MustNew("perm_campaign_coupon_update", NameCampaignCouponUpdate, "クーポンの発行・編集", CategoryCampaign),
+	MustNew("perm_campaign_announcement_update", NameCampaignAnnouncementUpdate, "お知らせの作成・編集・公開", CategoryCampaign),
+
+	// Analytics
+	MustNew("perm_analytics_sales_view", NameAnalyticsSalesView, "売上閲覧", CategoryAnalytics),
+
+	// Mint
+	MustNew("perm_mint_request", NameMintRequest, "ミント申請", CategoryMint),
 
 	// System
 	MustNew("perm_system_admin", "system.admin.view", "システム設定・管理情報閲覧", CategorySystem),
+	MustNew("perm_system_billing_update", NameSystemBillingUpdate, "手数料プランの設定（プラットフォーム運営）", CategorySystem),
+	MustNew("perm_system_payment_update", NameSystemPaymentUpdate, "Stripe イベントの再処理（プラットフォーム運営）", CategorySystem),
 }
 
 // AllPermissions は定義済みの権限一覧をコピーして返す
@@ -58,3 +146,29 @@ func AllPermissionNames() []string {
 	}
 	return names
 }
+
+// IsSystemPermission reports whether name is an operator-only (system.*) permission.
+func IsSystemPermission(name string) bool {
+	return strings.HasPrefix(name, systemPermissionPrefix)
+}
+
+// TenantPermissionNames は system.* を除いた権限名を返す（テナント管理者の権限バンドル）。
+func TenantPermissionNames() []string {
+	names := make([]string, 0, len(allPermissions))
+	for _, p := range allPermissions {
+		if !IsSystemPermission(p.Name) {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// Exists reports whether name is defined in the catalog.
+func Exists(name string) bool {
+	for _, p := range allPermissions {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -22,6 +22,8 @@ const (
 	CategoryToken        PermissionCategory = "token"
 	CategoryInventory    PermissionCategory = "inventory"
 	CategoryProduction   PermissionCategory = "production"
+	CategoryList         PermissionCategory = "list"
+	CategoryMint         PermissionCategory = "mint"
 	CategoryAnalytics    PermissionCategory = "analytics"
 	CategorySystem       PermissionCategory = "system"
 )
@@ -40,6 +42,8 @@ func CategoryValues() []PermissionCategory {
 		CategoryToken,
 		CategoryInventory,
 		CategoryProduction,
+		CategoryList,
+		CategoryMint,
 		CategoryAnalytics,
 		CategorySystem,
 	}
@@ -59,6 +63,8 @@ func IsValidCategory(c PermissionCategory) bool {
 		CategoryToken,
 		CategoryInventory,
 		CategoryProduction,
+		CategoryList,
+		CategoryMint,
 		CategoryAnalytics,
 		CategorySystem:
 		return true
@@ -74,9 +80,9 @@ func IsValidCategory(c PermissionCategory) bool {
 //   - Description: human readable
 //   - Category: one of PermissionCategory
 //
-// 【前提】ユーザーは「基本閲覧のみ」
-// Name の action は read-only に限定（read / list / view / export）
-// ※ 書き込み系（create / update / delete / patch / approve など）は禁止
+// Name の action は read-only 系（read / list / view / export）か、
+// カタログで定義された書き込み系（create / update / dispatch など）に限定する。
+// 書き込み系の権限は PermissionMiddleware により console route で強制される。
 type Permission struct {
 	ID          string             `json:"id"`
 	Name        string             `json:"name"`
@@ -88,7 +94,7 @@ var (
 	ErrInvalidID        = errors.New("permission: invalid id")
 	ErrInvalidName      = errors.New("permission: invalid name format (expected <category>.<action>)")
 	ErrInvalidCategory  = errors.New("permission: invalid category")
-	ErrForbiddenAction  = errors.New("permission: action is not allowed for read-only users")
+	ErrForbiddenAction  = errors.New("permission: action is not allowed")
 	ErrCategoryMismatch = errors.New("permission: name prefix does not match category")
 )
 
@@ -103,7 +109,21 @@ var readOnlyActions = map[string]struct{}{
 	"export": {},
 }
 
-// New creates a Permission with validation (read-only enforced).
+// 書き込み系で許可するアクション
+var writeActions = map[string]struct{}{
+	"create":   {},
+	"update":   {},
+	"delete":   {},
+	"dispatch": {},
+	"refund":   {},
+	"publish":  {},
+	"request":  {},
+	"invite":   {},
+	"assign":   {},
+	"approve":  {},
+}
+
+// New creates a Permission with validation.
 func New(id, name, description string, category PermissionCategory) (Permission, error) {
 	p := Permission{
 		ID:          id,
@@ -122,7 +142,7 @@ func (p *Permission) UpdateDescription(desc string) {
 	p.Description = desc
 }
 
-// validate performs checks aligned with read-only policy.
+// validate performs checks aligned with the action policy.
 func (p Permission) validate() error {
 	if p.ID == "" {
 		return ErrInvalidID
@@ -158,8 +178,10 @@ func (p Permission) validate() error {
 		}
 	}
 
-	// 読み取り専用アクションのみ許可
-	if _, ok := readOnlyActions[action]; !ok {
+	// 読み取り専用 / 書き込み系アクションのみ許可
+	_, readOnly := readOnlyActions[action]
+	_, write := writeActions[action]
+	if !readOnly && !write {
 		return ErrForbiddenAction
 	}
 
@@ -177,6 +199,18 @@ func MustNew(id, name, description string, category PermissionCategory) Permissi
 
 // IsReadOnlyAction reports whether the action part is read-only.
 func IsReadOnlyAction(name string) bool {
+	_, ok := readOnlyActions[actionOf(name)]
+	return ok
+}
+
+// IsWriteAction reports whether the action part is a write action.
+func IsWriteAction(name string) bool {
+	_, ok := writeActions[actionOf(name)]
+	return ok
+}
+
+// actionOf returns the last "."-separated part of name, or "" if malformed.
+func actionOf(name string) string {
 	i := -1
 	for j := len(name) - 1; j >= 0; j-- {
 		if name[j] == '.' {
@@ -185,9 +219,7 @@ func IsReadOnlyAction(name string) bool {
 		}
 	}
 	if i <= 0 || i == len(name)-1 {
-		return false
+		return ""
 	}
-	action := name[i+1:]
-	_, ok := readOnlyActions[action]
-	return ok
+	return name[i+1:]
 }
@@ -0,0 +1,209 @@
+// backend/internal/domain/permission/role.go
+package permission
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Role は会社ごとの権限バンドル。
+//
+//   - 組み込みロール（BuiltinRoles）は全社共通で、CompanyID は空。
+//   - 会社独自ロールは roles/{id} に companyId 付きで保存する。
+//   - Member.Roles に Role.ID を割り当て、実効権限は
+//     Member.Permissions ∪ 割当ロールの Permissions となる。
+type Role struct {
+	ID          string    `json:"id"`
+	CompanyID   string    `json:"companyId,omitempty"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	Builtin     bool      `json:"builtin"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// 組み込みロール ID
+const (
+	RoleIDAdmin    = "role_admin"
+	RoleIDOperator = "role_operator"
+	RoleIDViewer   = "role_viewer"
+)
+
+var (
+	ErrInvalidRoleID        = errors.New("permission: invalid role id")
+	ErrInvalidRoleName      = errors.New("permission: invalid role name")
+	ErrInvalidRoleCompanyID = errors.New("permission: invalid role companyId")
+	ErrUnknownPermission    = errors.New("permission: unknown permission name")
+	ErrBuiltinRole          = errors.New("permission: builtin role cannot be modified")
+	ErrRoleNotFound         = errors.New("permission: role not found")
+	ErrSystemPermission     = errors.New("permission: system permissions are reserved for platform operators")
+)
+
+// BuiltinRoles は全社共通の組み込みロールを返す。
+//   - admin: カタログの全権限（system.* を除く）
+//   - operator: 閲覧 + 日常業務（発送・出品・在庫・生産・ミント申請・問い合わせ対応）
+//   - viewer: 閲覧のみ
+//
+// system.* は運営向けの権限のため、どの組み込みロールにも含めない。
+func BuiltinRoles() []Role {
+	var (
+		all      []string
+		readOnly []string
+	)
+	for _, p := range allPermissions {
+		if IsSystemPermission(p.Name) {
+			continue
+		}
+		all = append(all, p.Name)
+		if IsReadOnlyAction(p.Name) {
+			readOnly = append(readOnly, p.Name)
+		}
+	}
+
+	operator := append([]string(nil), readOnly...)
+	operator = append(operator,
+		NameOrderDispatch,
+		NameInventoryUpdate,
+		NameProductionUpdate,
+		NameListPublish,
+		NameMintRequest,
+		NameInquiryUpdate,
+		NameMemberMessageUpdate,
+	)
+
+	return []Role{
+		{
+			ID:          RoleIDAdmin,
+			Name:        "admin",
+			Description: "すべての操作が可能な管理者",
+			Permissions: normalizePermissionNames(all),
+			Builtin:     true,
+		},
+		{
+			ID:          RoleIDOperator,
+			Name:        "operator",
+			Description: "発送・出品・在庫・生産など日常業務の担当者",
+			Permissions: normalizePermissionNames(operator),
+			Builtin:     true,
+		},
+		{
+			ID:          RoleIDViewer,
+			Name:        "viewer",
+			Description: "閲覧のみ",
+			Permissions: normalizePermissionNames(readOnly),
+			Builtin:     true,
+		},
+	}
+}
+
+// IsBuiltinRoleID reports whether id is one of the builtin roles.
+func IsBuiltinRoleID(id string) bool {
+	switch id {
+	case RoleIDAdmin, RoleIDOperator, RoleIDViewer:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewRole creates a company-specific Role.
+// Permission names must exist in the catalog and must not be system.*.
+func NewRole(
+	id string,
+	companyID string,
+	name string,
+	description string,
+	permissions []string,
+	now time.Time,
+) (Role, error) {
+	r := Role{
+		ID:          strings.TrimSpace(id),
+		CompanyID:   strings.TrimSpace(companyID),
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+		Permissions: normalizePermissionNames(permissions),
+		CreatedAt:   now.UTC(),
+		UpdatedAt:   now.UTC(),
+	}
+	if err := r.Validate(); err != nil {
+		return Role{}, err
+	}
+	return r, nil
+}
+
+// Validate checks a company-specific Role.
+func (r Role) Validate() error {
+	if r.ID == "" || strings.Contains(r.ID, "/") || IsBuiltinRoleID(r.ID) {
+		return ErrInvalidRoleID
+	}
+	if r.Builtin {
+		return ErrBuiltinRole
+	}
+	if r.CompanyID == "" {
+		return ErrInvalidRoleCompanyID
+	}
+	if r.Name == "" {
+		return ErrInvalidRoleName
+	}
+	for _, n := range r.Permissions {
+		if !Exists(n) {
+			return ErrUnknownPermission
+		}
+		if IsSystemPermission(n) {
+			return ErrSystemPermission
+		}
+	}
+	return nil
+}
+
+// ResolveRolePermissions は roleIDs に対応するロールの権限名を合算して返す。
+// roles に存在しない ID は無視する。
+func ResolveRolePermissions(roleIDs []string, roles []Role) []string {
+	if len(roleIDs) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]Role, len(roles))
+	for _, r := range roles {
+		byID[r.ID] = r
+	}
+
+	var out []string
+	for _, id := range roleIDs {
+		if r, ok := byID[id]; ok {
+			out = append(out, r.Permissions...)
+		}
+	}
+	return normalizePermissionNames(out)
+}
+
+func normalizePermissionNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			continue
+		}
+		if _, dup := seen[n]; dup {
+			continue
+		}
+		seen[n] = struct{}{}
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// RoleRepository は会社独自ロールの永続化ポート。
+// 組み込みロールは保存しない。
+type RoleRepository interface {
+	ListByCompanyID(ctx context.Context, companyID string) ([]Role, error)
+	GetByID(ctx context.Context, id string) (Role, error)
+	Save(ctx context.Context, r Role) (Role, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,162 @@
+// backend/internal/domain/permission/role_test.go
+package permission
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		permName string
+		category PermissionCategory
+		want     error
+	}{
+		{name: "read-only action", permName: "order.view", category: CategoryOrder},
+		{name: "write action", permName: "order.dispatch", category: CategoryOrder},
+		{name: "sub scope", permName: "order.return.approve", category: CategoryOrder},
+		{name: "unknown action", permName: "order.destroy", category: CategoryOrder, want: ErrForbiddenAction},
+		{name: "category mismatch", permName: "brand.update", category: CategoryOrder, want: ErrCategoryMismatch},
+		{name: "prefix is not a sub scope", permName: "orders.view", category: CategoryOrder, want: ErrCategoryMismatch},
+		{name: "uppercase", permName: "Order.view", category: CategoryOrder, want: ErrInvalidName},
+		{name: "no action", permName: "order", category: CategoryOrder, want: ErrInvalidName},
+		{name: "unknown category", permName: "order.view", category: "shop", want: ErrInvalidCategory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New("perm_1", tt.permName, "", tt.category); !errors.Is(err, tt.want) {
+				t.Fatalf("New err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinRoles(t *testing.T) {
+	roles := map[string]Role{}
+	for _, r := range BuiltinRoles() {
+		if !r.Builtin || r.CompanyID != "" || !IsBuiltinRoleID(r.ID) {
+			t.Fatalf("builtin role %+v", r)
+		}
+		roles[r.ID] = r
+	}
+
+	if got, want := roles[RoleIDAdmin].Permissions, normalizePermissionNames(TenantPermissionNames()); !reflect.DeepEqual(got, want) {
+		t.Fatalf("admin Permissions = %v, want all tenant permissions %v", got, want)
+	}
+
+	for _, r := range roles {
+		for _, n := range r.Permissions {
+			if IsSystemPermission(n) {
+				t.Fatalf("%s has system permission %q", r.ID, n)
+			}
+		}
+	}
+
+	for _, n := range roles[RoleIDViewer].Permissions {
+		if !IsReadOnlyAction(n) {
+			t.Fatalf("viewer has write permission %q", n)
+		}
+	}
+
+	tests := []struct {
+		role string
+		name string
+		want bool
+	}{
+		{role: RoleIDViewer, name: "order.view", want: true},
+		{role: RoleIDViewer, name: NameOrderDispatch, want: false},
+		{role: RoleIDOperator, name: "order.view", want: true},
+		{role: RoleIDOperator, name: NameOrderDispatch, want: true},
+		{role: RoleIDOperator, name: NameMintRequest, want: true},
+		{role: RoleIDOperator, name: NameOrderRefund, want: false},
+		{role: RoleIDOperator, name: NameMemberRolesAssign, want: false},
+		{role: RoleIDOperator, name: NameInquiryUpdate, want: true},
+		{role: RoleIDAdmin, name: NameOrderPayoutApprove, want: true},
+		{role: RoleIDAdmin, name: NameSystemBillingUpdate, want: false},
+		{role: RoleIDAdmin, name: NameSystemPaymentUpdate, want: false},
+		{role: RoleIDViewer, name: "system.admin.view", want: false},
+	}
+
+	for _, tt := range tests {
+		got := false
+		for _, n := range roles[tt.role].Permissions {
+			if n == tt.name {
+				got = true
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%s has %s = %v, want %v", tt.role, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		id              string
+		companyID       string
+		roleName        string
+		permissions     []string
+		wantPermissions []string
+		want            error
+	}{
+		{
+			name:            "normalizes permissions",
+			id:              "role_1",
+			companyID:       "company_1",
+			roleName:        " shipping ",
+			permissions:     []string{NameOrderDispatch, " order.view ", NameOrderDispatch, ""},
+			wantPermissions: []string{NameOrderDispatch, "order.view"},
+		},
+		{name: "builtin id", id: RoleIDAdmin, companyID: "company_1", roleName: "admin", want: ErrInvalidRoleID},
+		{name: "id with slash", id: "role/1", companyID: "company_1", roleName: "x", want: ErrInvalidRoleID},
+		{name: "missing company", id: "role_1", roleName: "x", want: ErrInvalidRoleCompanyID},
+		{name: "missing name", id: "role_1", companyID: "company_1", roleName: " ", want: ErrInvalidRoleName},
+		{name: "unknown permission", id: "role_1", companyID: "company_1", roleName: "x", permissions: []string{"order.destroy"}, want: ErrUnknownPermission},
+		{name: "system permission", id: "role_1", companyID: "company_1", roleName: "x", permissions: []string{NameSystemBillingUpdate}, want: ErrSystemPermission},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRole(tt.id, tt.companyID, tt.roleName, "", tt.permissions, testNow)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewRole err = %v, want %v", err, tt.want)
+			}
+			if err == nil && !reflect.DeepEqual(r.Permissions, tt.wantPermissions) {
+				t.Fatalf("Permissions = %v, want %v", r.Permissions, tt.wantPermissions)
+			}
+		})
+	}
+}
+
+func TestResolveRolePermissions(t *testing.T) {
+	roles := []Role{
+		{ID: "role_1", Permissions: []string{"order.view", NameOrderDispatch}},
+		{ID: "role_2", Permissions: []string{"order.view", NameInventoryUpdate}},
+	}
+
+	tests := []struct {
+		name    string
+		roleIDs []string
+		want    []string
+	}{
+		{name: "no roles", roleIDs: nil, want: nil},
+		{name: "single role", roleIDs: []string{"role_1"}, want: []string{NameOrderDispatch, "order.view"}},
+		{name: "union is deduplicated", roleIDs: []string{"role_2", "role_1"}, want: []string{NameInventoryUpdate, NameOrderDispatch, "order.view"}},
+		{name: "unknown role is ignored", roleIDs: []string{"role_9"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveRolePermissions(tt.roleIDs, roles); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ResolveRolePermissions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ type PrintLog struct {
 	// QR ペイロード一覧（例: 各 productId に対応する URL）
 	// Firestore には保存せず、レスポンス専用に使う想定。
 	// Items の並び（displayOrder 昇順）に合わせて詰めることを期待します。
+	// QR コードの画像・ラベルシート PDF はサーバ側で生成します（usecase.PrintLabelUsecase）。
 	QrPayloads []string `json:"qrPayloads,omitempty"`
 }
 
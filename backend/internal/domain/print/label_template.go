@@ -0,0 +1,176 @@
+// backend/internal/domain/print/label_template.go
+package print
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// LabelTemplate は QR ラベルシート（A4 縦）の面付けと表示項目の設定です。
+// 寸法はすべて mm 単位で、ラベルは左上から columns × rows に並べます。
+type LabelTemplate struct {
+	ID        string `json:"id"`
+	CompanyID string `json:"companyId"`
+	Name      string `json:"name"`
+
+	LabelWidthMM  float64 `json:"labelWidthMm"`
+	LabelHeightMM float64 `json:"labelHeightMm"`
+	Columns       int     `json:"columns"`
+	Rows          int     `json:"rows"`
+
+	// 用紙の上端・左端から最初のラベルまでの余白
+	MarginTopMM  float64 `json:"marginTopMm"`
+	MarginLeftMM float64 `json:"marginLeftMm"`
+	// ラベル間の間隔（横 / 縦）
+	GapXMM float64 `json:"gapXMm"`
+	GapYMM float64 `json:"gapYMm"`
+	// ラベル内側の余白
+	PaddingMM float64 `json:"paddingMm"`
+	// QR コードの一辺（0 の場合はラベルに収まる最大サイズ）
+	QRSizeMM float64 `json:"qrSizeMm"`
+
+	ShowBrandLogo   bool `json:"showBrandLogo"`
+	ShowProductName bool `json:"showProductName"`
+	ShowModelSize   bool `json:"showModelSize"`
+	ShowModelColor  bool `json:"showModelColor"`
+	ShowProductID   bool `json:"showProductId"`
+	// 切り取り線（ラベルの枠）を印刷する（台紙のない普通紙向け）
+	ShowBorder bool `json:"showBorder"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+const (
+	// DefaultLabelTemplateID は組み込みのテンプレートの ID です（保存されません）。
+	DefaultLabelTemplateID = "default"
+
+	// A4 縦
+	LabelSheetWidthMM  = 210.0
+	LabelSheetHeightMM = 297.0
+
+	// MinQRSizeMM は読み取りを保証できる QR コードの最小サイズです。
+	MinQRSizeMM = 10.0
+
+	maxLabelTemplateNameLength = 100
+)
+
+var (
+	ErrLabelTemplateNotFound      = errors.New("printLabelTemplate: not found")
+	ErrInvalidLabelTemplateID     = errors.New("printLabelTemplate: invalid id")
+	ErrInvalidLabelTemplateName   = errors.New("printLabelTemplate: invalid name")
+	ErrInvalidLabelTemplateLayout = errors.New("printLabelTemplate: invalid layout")
+	ErrLabelTemplateDoesNotFit    = errors.New("printLabelTemplate: labels do not fit on the sheet")
+	ErrLabelTemplateQRTooSmall    = errors.New("printLabelTemplate: qr code is too small")
+	ErrLabelTemplateReadOnly      = errors.New("printLabelTemplate: default template is read-only")
+)
+
+// DefaultLabelTemplate は A4 21 面（63.5 × 38.1 mm, 3 列 × 7 行）の市販ラベル用紙に合わせた設定です。
+func DefaultLabelTemplate() LabelTemplate {
+	return LabelTemplate{
+		ID:   DefaultLabelTemplateID,
+		Name: "A4 21面（63.5 × 38.1 mm）",
+
+		LabelWidthMM:  63.5,
+		LabelHeightMM: 38.1,
+		Columns:       3,
+		Rows:          7,
+
+		MarginTopMM:  15.15,
+		MarginLeftMM: 7.25,
+		GapXMM:       2.5,
+		GapYMM:       0,
+		PaddingMM:    2,
+
+		ShowBrandLogo:   true,
+		ShowProductName: true,
+		ShowModelSize:   true,
+		ShowModelColor:  true,
+		ShowProductID:   true,
+	}
+}
+
+// LabelsPerSheet は 1 ページあたりのラベル数です。
+func (t LabelTemplate) LabelsPerSheet() int {
+	return t.Columns * t.Rows
+}
+
+// EffectiveQRSizeMM は描画する QR コードの一辺です。
+func (t LabelTemplate) EffectiveQRSizeMM() float64 {
+	limit := math.Min(t.LabelWidthMM, t.LabelHeightMM) - 2*t.PaddingMM
+	if t.QRSizeMM <= 0 || t.QRSizeMM > limit {
+		return limit
+	}
+	return t.QRSizeMM
+}
+
+// Normalize は名前の前後の空白を取り除きます。
+func (t *LabelTemplate) Normalize() {
+	t.ID = strings.TrimSpace(t.ID)
+	t.CompanyID = strings.TrimSpace(t.CompanyID)
+	t.Name = strings.TrimSpace(t.Name)
+}
+
+// Validate はラベルが用紙に収まり、QR コードが読み取れる大きさであることを検証します。
+func (t LabelTemplate) Validate() error {
+	if t.Name == "" || len([]rune(t.Name)) > maxLabelTemplateNameLength {
+		return ErrInvalidLabelTemplateName
+	}
+
+	for _, v := range []float64{
+		t.MarginTopMM, t.MarginLeftMM, t.GapXMM, t.GapYMM, t.PaddingMM, t.QRSizeMM,
+	} {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return ErrInvalidLabelTemplateLayout
+		}
+	}
+	if !(t.LabelWidthMM > 0) || !(t.LabelHeightMM > 0) ||
+		t.Columns <= 0 || t.Rows <= 0 {
+		return ErrInvalidLabelTemplateLayout
+	}
+
+	// 小数の丸め誤差は許容する
+	const tolerance = 0.01
+	width := t.MarginLeftMM + float64(t.Columns)*t.LabelWidthMM + float64(t.Columns-1)*t.GapXMM
+	height := t.MarginTopMM + float64(t.Rows)*t.LabelHeightMM + float64(t.Rows-1)*t.GapYMM
+	if width > LabelSheetWidthMM+tolerance || height > LabelSheetHeightMM+tolerance {
+		return ErrLabelTemplateDoesNotFit
+	}
+
+	if t.QRSizeMM > 0 && t.QRSizeMM > math.Min(t.LabelWidthMM, t.LabelHeightMM)-2*t.PaddingMM {
+		return ErrLabelTemplateDoesNotFit
+	}
+	if t.EffectiveQRSizeMM() < MinQRSizeMM {
+		return ErrLabelTemplateQRTooSmall
+	}
+
+	return nil
+}
+
+// Label はラベル 1 枚分の表示内容です。
+// ProductName / ModelSize / ModelColor はテンプレートで非表示の場合は空です。
+type Label struct {
+	ProductID    string
+	DisplayOrder int
+	QRPayload    string
+
+	ProductName string
+	ModelSize   string
+	ModelColor  string
+}
+
+// LabelSheet は PrintLog 1 件分のラベルシートの描画入力です。
+type LabelSheet struct {
+	ProductionID string
+	Template     LabelTemplate
+
+	BrandName string
+	// BrandLogo はブランドアイコンの画像（PNG / JPEG / GIF）。取得できない場合は nil で、
+	// 代わりに BrandName を表示します。
+	BrandLogo []byte
+
+	// Labels は PrintLog.Items の displayOrder 昇順
+	Labels []Label
+}
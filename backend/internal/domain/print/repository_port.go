@@ -11,6 +11,15 @@ type RepositoryPort interface {
 	GetByProductionID(ctx context.Context, productionID string) (PrintLog, error)
 }
 
+// LabelTemplateRepositoryPort は company ごとのラベルテンプレートの永続化境界です。
+type LabelTemplateRepositoryPort interface {
+	ListByCompanyID(ctx context.Context, companyID string) ([]LabelTemplate, error)
+	GetByID(ctx context.Context, id string) (LabelTemplate, error)
+	Create(ctx context.Context, t LabelTemplate) (LabelTemplate, error)
+	Update(ctx context.Context, t LabelTemplate) (LabelTemplate, error)
+	Delete(ctx context.Context, id string) error
+}
+
 var (
 	ErrNotFound = errors.New("print: not found")
 	ErrConflict = errors.New("print: conflict")
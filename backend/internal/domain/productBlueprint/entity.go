@@ -6,7 +6,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -336,6 +338,105 @@ func cloneCategoryFieldValue(value any) any {
 	}
 }
 
+// ======================================
+// Customs
+// ======================================
+// CustomsInfoは国外発送時の税関告知書（CN22/CN23）に記載する情報です。
+//
+// HSCode:
+//   - HS条約の品目番号。6〜10桁の数字（"."は保存時に除去）
+//
+// Description:
+//   - 英語の内容品名（例: "Cotton T-shirt"）
+//
+// OriginCountry:
+//   - 原産国（ISO 3166-1 alpha-2）。空の場合はJPとして扱います。
+//
+// DeclaredValue:
+//   - 1点あたりの申告価格（JPY）。0の場合は販売価格を申告します。
+//
+// 税関情報はタグへ印刷されないため、印刷後も変更できます。
+type CustomsInfo struct {
+	HSCode        string
+	Description   string
+	OriginCountry string
+	DeclaredValue int64
+}
+
+const (
+	DefaultCustomsOriginCountry = "JP"
+	MaxCustomsDescriptionLength = 200
+)
+
+var (
+	hsCodePattern             = regexp.MustCompile(`^[0-9]{6,10}$`)
+	customsCountryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// NormalizeCustomsInfoはHSCodeの区切り文字と前後空白を除去し、
+// 原産国コードを大文字へ正規化します。
+func NormalizeCustomsInfo(customs CustomsInfo) CustomsInfo {
+	hsCode := strings.TrimSpace(customs.HSCode)
+	hsCode = strings.ReplaceAll(hsCode, ".", "")
+	hsCode = strings.ReplaceAll(hsCode, " ", "")
+
+	return CustomsInfo{
+		HSCode:        hsCode,
+		Description:   strings.TrimSpace(customs.Description),
+		OriginCountry: strings.ToUpper(strings.TrimSpace(customs.OriginCountry)),
+		DeclaredValue: customs.DeclaredValue,
+	}
+}
+
+// IsZeroは税関情報が未設定かを返します。
+func (customs CustomsInfo) IsZero() bool {
+	return customs == CustomsInfo{}
+}
+
+// IsCompleteは国外発送に必要なHSCodeと内容品名が揃っているかを返します。
+func (customs CustomsInfo) IsComplete() bool {
+	return customs.HSCode != "" && customs.Description != ""
+}
+
+// ResolvedOriginCountryは原産国を返します。未設定の場合はJPです。
+func (customs CustomsInfo) ResolvedOriginCountry() string {
+	if customs.OriginCountry == "" {
+		return DefaultCustomsOriginCountry
+	}
+	return customs.OriginCountry
+}
+
+func (customs CustomsInfo) Validate() error {
+	return customs.validate()
+}
+func (customs CustomsInfo) validate() error {
+	if customs.HSCode != "" && !hsCodePattern.MatchString(customs.HSCode) {
+		return WrapInvalid(
+			ErrInvalidCustoms,
+			"customs.hsCode must be 6-10 digits",
+		)
+	}
+	if len([]rune(customs.Description)) > MaxCustomsDescriptionLength {
+		return WrapInvalid(
+			ErrInvalidCustoms,
+			"customs.description is too long",
+		)
+	}
+	if customs.OriginCountry != "" && !customsCountryCodePattern.MatchString(customs.OriginCountry) {
+		return WrapInvalid(
+			ErrInvalidCustoms,
+			"customs.originCountry must be ISO 3166-1 alpha-2",
+		)
+	}
+	if customs.DeclaredValue < 0 {
+		return WrapInvalid(
+			ErrInvalidCustoms,
+			"customs.declaredValue must not be negative",
+		)
+	}
+	return nil
+}
+
 // ======================================
 // Model references
 // ======================================
@@ -506,6 +607,8 @@ type ProductBlueprint struct {
 	AssigneeID     string
 	// ModelRefsはModel IDと1..NのDisplayOrderを保持します。
 	ModelRefs []ModelRef
+	// Customsは国外発送時の税関告知情報です。
+	Customs CustomsInfo
 	// Printed:
 	//   - false: 未印刷
 	//   - true: 印刷済み
@@ -552,6 +655,9 @@ var (
 	ErrCategoryFieldsValidatorNotConfigured = errors.New(
 		"productBlueprint: categoryFields validator is not configured",
 	)
+	ErrInvalidCustoms = errors.New(
+		"productBlueprint: invalid customs",
+	)
 )
 
 // ======================================
@@ -822,6 +928,25 @@ func (productBlueprint *ProductBlueprint) UpdateTag(tag ProductIDTag, now time.T
 	return nil
 }
 
+// UpdateCustomsは税関情報を置換します。
+//
+// 税関情報はタグへ印刷されないため、印刷済みでも更新できます。
+func (productBlueprint *ProductBlueprint) UpdateCustoms(customs CustomsInfo, now time.Time, updatedBy *string) error {
+	if productBlueprint == nil {
+		return ErrInvalid
+	}
+	customs = NormalizeCustomsInfo(customs)
+	if err := customs.validate(); err != nil {
+		return err
+	}
+	productBlueprint.Customs = customs
+	productBlueprint.touch(
+		now,
+		updatedBy,
+	)
+	return nil
+}
+
 // UpdateModelIDsはModel IDを受け取り、入力順を維持したまま
 // DisplayOrderを1..Nで採番してModelRefsを置換します。
 func (productBlueprint *ProductBlueprint) UpdateModelIDs(modelIDs []string, now time.Time, updatedBy *string) error {
@@ -935,6 +1060,9 @@ func (productBlueprint ProductBlueprint) validate() error {
 	); err != nil {
 		return err
 	}
+	if err := productBlueprint.Customs.validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -118,6 +118,12 @@ type Patch struct {
 	// command Usecase側では基本的に設定しない。
 	ModelRefs *[]ModelRef `json:"modelRefs,omitempty"`
 
+	// Customsは国外発送時の税関告知情報。
+	//
+	// nilの場合は更新しない。
+	// 税関情報だけを更新するPatchは印刷済みでも適用できる。
+	Customs *CustomsInfo `json:"customs,omitempty"`
+
 	// UpdatedByは認証ContextからUsecaseが設定する内部項目。
 	//
 	// HTTP request bodyから任意の更新者IDを受け取らないため、
@@ -125,6 +131,20 @@ type Patch struct {
 	UpdatedBy *string `json:"-"`
 }
 
+// IsCustomsOnlyはPatchが税関情報だけを更新するかを返す。
+func (p Patch) IsCustomsOnly() bool {
+	return p.Customs != nil &&
+		p.ProductName == nil &&
+		p.Description == nil &&
+		p.BrandID == nil &&
+		p.CompanyID == nil &&
+		p.ProductBlueprintCategoryPath == nil &&
+		p.CategoryFields == nil &&
+		p.ProductIdTag == nil &&
+		p.AssigneeID == nil &&
+		p.ModelRefs == nil
+}
+
 // ========================================
 // Query contracts
 // ========================================
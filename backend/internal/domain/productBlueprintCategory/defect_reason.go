@@ -0,0 +1,139 @@
+// backend/internal/domain/productBlueprintCategory/defect_reason.go
+package productBlueprintCategory
+
+import (
+	"strings"
+)
+
+// DefectReason は検品で failed とした理由の定義です。
+// Code は inspections の defectReasons に保存され、不良率レポートの集計キーになります。
+type DefectReason struct {
+	Code    string `json:"code"`
+	LabelJa string `json:"labelJa"`
+}
+
+// 全カテゴリ共通の不良理由
+const (
+	DefectReasonStain           = "stain"
+	DefectReasonDamage          = "damage"
+	DefectReasonLabelError      = "labelError"
+	DefectReasonPackagingDamage = "packagingDamage"
+	DefectReasonOther           = "other"
+)
+
+// category kind ごとの不良理由
+const (
+	// apparel
+	DefectReasonSewing        = "sewing"
+	DefectReasonFabric        = "fabric"
+	DefectReasonPrint         = "print"
+	DefectReasonSizeMismatch  = "sizeMismatch"
+	DefectReasonColorMismatch = "colorMismatch"
+
+	// alcohol / cosmetics / healthcare
+	DefectReasonUnderfill     = "underfill"
+	DefectReasonLeakage       = "leakage"
+	DefectReasonSeal          = "seal"
+	DefectReasonForeignMatter = "foreignMatter"
+	DefectReasonContainer     = "container"
+	DefectReasonDegradation   = "degradation"
+)
+
+var defectReasonLabels = map[string]string{
+	DefectReasonStain:           "汚れ",
+	DefectReasonDamage:          "破損・傷",
+	DefectReasonLabelError:      "ラベル・表示不備",
+	DefectReasonPackagingDamage: "梱包不良",
+	DefectReasonOther:           "その他",
+
+	DefectReasonSewing:        "縫製不良",
+	DefectReasonFabric:        "生地不良",
+	DefectReasonPrint:         "プリント・刺繍不良",
+	DefectReasonSizeMismatch:  "サイズ違い",
+	DefectReasonColorMismatch: "色違い・色ムラ",
+
+	DefectReasonUnderfill:     "内容量不足",
+	DefectReasonLeakage:       "液漏れ",
+	DefectReasonSeal:          "キャップ・封緘不良",
+	DefectReasonForeignMatter: "異物混入",
+	DefectReasonContainer:     "容器不良",
+	DefectReasonDegradation:   "変質・分離",
+}
+
+// commonDefectReasonCodes は全カテゴリで選択できる不良理由です（other は常に末尾）。
+var commonDefectReasonCodes = []string{
+	DefectReasonStain,
+	DefectReasonDamage,
+	DefectReasonLabelError,
+	DefectReasonPackagingDamage,
+}
+
+// defectReasonCodesByKind は CategoryInputSchema.CategoryKind ごとの追加の不良理由です。
+var defectReasonCodesByKind = map[string][]string{
+	"apparel": {
+		DefectReasonSewing,
+		DefectReasonFabric,
+		DefectReasonPrint,
+		DefectReasonSizeMismatch,
+		DefectReasonColorMismatch,
+	},
+	"alcohol": {
+		DefectReasonUnderfill,
+		DefectReasonLeakage,
+		DefectReasonSeal,
+		DefectReasonForeignMatter,
+	},
+	"cosmetics": {
+		DefectReasonLeakage,
+		DefectReasonContainer,
+		DefectReasonForeignMatter,
+		DefectReasonDegradation,
+	},
+	"healthcare": {
+		DefectReasonContainer,
+		DefectReasonSeal,
+		DefectReasonForeignMatter,
+	},
+}
+
+// ListDefectReasons は category に対して選択できる不良理由を返します。
+// category が未登録の場合は共通の不良理由のみを返します。
+func ListDefectReasons(productBlueprintCategoryPath []string) []DefectReason {
+	kind := ""
+	if schema, ok := GetCategoryInputSchema(
+		strings.Join(productBlueprintCategoryPath, "."),
+	); ok {
+		kind = schema.CategoryKind
+	}
+
+	specific := defectReasonCodesByKind[kind]
+
+	out := make([]DefectReason, 0, len(specific)+len(commonDefectReasonCodes)+1)
+	for _, code := range specific {
+		out = append(out, DefectReason{Code: code, LabelJa: defectReasonLabels[code]})
+	}
+	for _, code := range commonDefectReasonCodes {
+		out = append(out, DefectReason{Code: code, LabelJa: defectReasonLabels[code]})
+	}
+	out = append(out, DefectReason{Code: DefectReasonOther, LabelJa: defectReasonLabels[DefectReasonOther]})
+
+	return out
+}
+
+// IsValidDefectReason は code が category で選択できる不良理由かどうかを返します。
+func IsValidDefectReason(productBlueprintCategoryPath []string, code string) bool {
+	for _, r := range ListDefectReasons(productBlueprintCategoryPath) {
+		if r.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// DefectReasonLabel は code の表示名を返します（未登録の code はそのまま返す）。
+func DefectReasonLabel(code string) string {
+	if label, ok := defectReasonLabels[code]; ok {
+		return label
+	}
+	return code
+}
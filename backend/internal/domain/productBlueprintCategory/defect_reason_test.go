@@ -0,0 +1,98 @@
+// backend/internal/domain/productBlueprintCategory/defect_reason_test.go
+package productBlueprintCategory
+
+import (
+	"reflect"
+	"testing"
+)
+
+func defectReasonCodes(reasons []DefectReason) []string {
+	out := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		out = append(out, r.Code)
+	}
+	return out
+}
+
+func TestListDefectReasons(t *testing.T) {
+	common := []string{
+		DefectReasonStain,
+		DefectReasonDamage,
+		DefectReasonLabelError,
+		DefectReasonPackagingDamage,
+		DefectReasonOther,
+	}
+
+	tests := []struct {
+		name string
+		path []string
+		want []string
+	}{
+		{
+			name: "apparel",
+			path: []string{"apparel", "bag"},
+			want: append([]string{
+				DefectReasonSewing,
+				DefectReasonFabric,
+				DefectReasonPrint,
+				DefectReasonSizeMismatch,
+				DefectReasonColorMismatch,
+			}, common...),
+		},
+		{
+			name: "alcohol",
+			path: []string{"alcohol", "sake"},
+			want: append([]string{
+				DefectReasonUnderfill,
+				DefectReasonLeakage,
+				DefectReasonSeal,
+				DefectReasonForeignMatter,
+			}, common...),
+		},
+		{name: "kind without specific reasons", path: []string{"other", "general"}, want: common},
+		{name: "unknown category", path: []string{"unknown"}, want: common},
+		{name: "no category", path: nil, want: common},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := ListDefectReasons(tt.path)
+			if got := defectReasonCodes(reasons); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ListDefectReasons = %v, want %v", got, tt.want)
+			}
+			for _, r := range reasons {
+				if r.LabelJa == "" || r.LabelJa == r.Code {
+					t.Fatalf("reason %q has no label", r.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestIsValidDefectReason(t *testing.T) {
+	tests := []struct {
+		name string
+		path []string
+		code string
+		want bool
+	}{
+		{name: "common reason", path: []string{"alcohol", "sake"}, code: DefectReasonStain, want: true},
+		{name: "other is always allowed", path: nil, code: DefectReasonOther, want: true},
+		{name: "kind reason", path: []string{"alcohol", "sake"}, code: DefectReasonLeakage, want: true},
+		{name: "reason of another kind", path: []string{"alcohol", "sake"}, code: DefectReasonSewing, want: false},
+		{name: "kind reason without category", path: nil, code: DefectReasonSewing, want: false},
+		{name: "unknown code", path: []string{"apparel", "bag"}, code: "scratch", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidDefectReason(tt.path, tt.code); got != tt.want {
+				t.Fatalf("IsValidDefectReason(%v, %q) = %v, want %v", tt.path, tt.code, got, tt.want)
+			}
+		})
+	}
+
+	if got := DefectReasonLabel("scratch"); got != "scratch" {
+		t.Fatalf("DefectReasonLabel(unknown) = %q, want the code", got)
+	}
+}
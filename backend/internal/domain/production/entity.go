@@ -61,6 +61,18 @@ type Production struct {
 	CreatedAt          time.Time // optional（ゼロ許容）
 	UpdatedAt          time.Time // optional（ゼロ許容）
 	UpdatedBy          *string
+
+	// ライフサイクル（status.go）
+	Status      Status
+	StartedAt   *time.Time // manufacturing へ遷移した日時
+	InspectedAt *time.Time // inspected へ遷移した日時
+	CompletedAt *time.Time // completed へ遷移した日時
+	CancelledAt *time.Time // cancelled へ遷移した日時
+
+	// 目標日（いずれも optional、遅延検知に使用）
+	TargetStartAt     *time.Time
+	TargetInspectedAt *time.Time
+	TargetCompletedAt *time.Time
 }
 
 // ===== Errors =====
@@ -102,6 +114,10 @@ func New(
 		PrintedBy:          nil,
 		CreatedBy:          createdBy,
 		CreatedAt:          createdAt,
+		Status:             DeriveStatus(printed),
+	}
+	if printed {
+		p.StartedAt = utcTimePtr(printedAt)
 	}
 
 	if err := p.Validate(); err != nil {
@@ -130,6 +146,10 @@ func NewForCreate(
 		PrintedBy:          nil,
 		CreatedBy:          createdBy,
 		CreatedAt:          createdAt,
+		Status:             DeriveStatus(printed),
+	}
+	if printed {
+		p.StartedAt = utcTimePtr(printedAt)
 	}
 
 	if err := p.ValidateForCreate(); err != nil {
@@ -163,6 +183,11 @@ func (p *Production) MarkPrinted(at time.Time) error {
 	p.Printed = true
 	p.PrintedAt = &at
 
+	// ラベル印刷 = 製造開始
+	if p.Status == StatusPlanned {
+		return p.TransitionTo(StatusManufacturing, at)
+	}
+
 	return p.Validate()
 }
 
@@ -226,6 +251,18 @@ func (p *Production) ApplyUpdate(
 		p.UpdatedAt = updatedAt.UTC()
 	}
 
+	// ラベル印刷 = 製造開始
+	if p.Printed && p.Status == StatusPlanned {
+		at := p.UpdatedAt
+		if p.PrintedAt != nil {
+			at = *p.PrintedAt
+		}
+		if at.IsZero() {
+			at = time.Now().UTC()
+		}
+		return p.TransitionTo(StatusManufacturing, at)
+	}
+
 	return p.Validate()
 }
 
@@ -292,5 +329,5 @@ func (p Production) validate(requireID bool) error {
 		return ErrInvalidUpdatedAt
 	}
 
-	return nil
+	return p.validateStatus()
 }
@@ -18,10 +18,16 @@ type CreateProductionInput struct {
 	AssigneeID         string          `json:"assigneeId"`
 	Models             []ModelQuantity `json:"models"`
 
-	// Status は廃止。Printed(boolean) に統一。
+	// 印刷状態は Printed(boolean)。ライフサイクルの Status は Printed から導出する
+	// （printed=true なら manufacturing、それ以外は planned）。
 	Printed   *bool      `json:"printed,omitempty"`
 	PrintedAt *time.Time `json:"printedAt,omitempty"`
 
+	// 目標日（optional）
+	TargetStartAt     *time.Time `json:"targetStartAt,omitempty"`
+	TargetInspectedAt *time.Time `json:"targetInspectedAt,omitempty"`
+	TargetCompletedAt *time.Time `json:"targetCompletedAt,omitempty"`
+
 	CreatedBy *string    `json:"createdBy,omitempty"`
 	CreatedAt *time.Time `json:"createdAt,omitempty"`
 }
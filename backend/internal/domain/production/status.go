@@ -0,0 +1,260 @@
+// backend/internal/domain/production/status.go
+package production
+
+import (
+	"errors"
+	"time"
+)
+
+// ========================================
+// Status machine
+// ========================================
+
+// Status は production（生産ロット）のライフサイクルです。
+//
+//	planned ──> manufacturing ──> inspected ──> completed
+//	   │              │
+//	   └──────────────┴──> cancelled
+//
+// planned は作成直後（ラベル未印刷）、manufacturing はラベル印刷または手動開始により製造中、
+// inspected は検品完了、completed はミント完了または手動で完了した状態です。
+type Status string
+
+const (
+	StatusPlanned       Status = "planned"
+	StatusManufacturing Status = "manufacturing"
+	StatusInspected     Status = "inspected"
+	StatusCompleted     Status = "completed"
+	StatusCancelled     Status = "cancelled"
+)
+
+var (
+	ErrInvalidStatus           = errors.New("production: invalid status")
+	ErrInvalidStatusTransition = errors.New("production: invalid status transition")
+	ErrInvalidTargetDates      = errors.New("production: invalid target dates")
+	ErrInvalidStatusAt         = errors.New("production: invalid status timestamp")
+)
+
+var statusTransitions = map[Status][]Status{
+	StatusPlanned:       {StatusManufacturing, StatusCancelled},
+	StatusManufacturing: {StatusInspected, StatusCancelled},
+	StatusInspected:     {StatusCompleted},
+}
+
+// statusOrder は cancelled 以外の進捗順です。
+var statusOrder = []Status{
+	StatusPlanned,
+	StatusManufacturing,
+	StatusInspected,
+	StatusCompleted,
+}
+
+func statusRank(s Status) int {
+	for i, v := range statusOrder {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPlanned,
+		StatusManufacturing,
+		StatusInspected,
+		StatusCompleted,
+		StatusCancelled:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// IsTerminal は以降の遷移が無い状態かどうかを返します。
+func (s Status) IsTerminal() bool {
+	return s == StatusCompleted || s == StatusCancelled
+}
+
+// CanTransitionTo は s から next へ遷移できるかどうかを返します。
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Reached は s が milestone 以上まで進んでいるかどうかを返します（cancelled は常に false）。
+func (s Status) Reached(milestone Status) bool {
+	if s == StatusCancelled {
+		return false
+	}
+
+	rank := statusRank(s)
+	return rank >= 0 && rank >= statusRank(milestone)
+}
+
+// DeriveStatus は status 導入前の document のための既定値です（printed のみから導出）。
+func DeriveStatus(printed bool) Status {
+	if printed {
+		return StatusManufacturing
+	}
+	return StatusPlanned
+}
+
+// ========================================
+// Transitions
+// ========================================
+
+// TransitionTo は status を next へ進め、対応する日時を記録します。
+func (p *Production) TransitionTo(next Status, at time.Time) error {
+	if !next.IsValid() {
+		return ErrInvalidStatus
+	}
+	if !p.Status.CanTransitionTo(next) {
+		return ErrInvalidStatusTransition
+	}
+	if at.IsZero() {
+		return ErrInvalidStatusAt
+	}
+
+	at = at.UTC()
+
+	switch next {
+	case StatusManufacturing:
+		p.StartedAt = &at
+	case StatusInspected:
+		p.InspectedAt = &at
+	case StatusCompleted:
+		p.CompletedAt = &at
+	case StatusCancelled:
+		p.CancelledAt = &at
+	}
+
+	p.Status = next
+
+	return p.Validate()
+}
+
+// AdvanceTo は milestone まで順に遷移します。すでに到達済みの場合は何もしません。
+//
+// 印刷・検品完了・ミント完了など、他の集約のイベントから進める場合に使います
+// （例: 印刷を経ずに検品が完了した場合は manufacturing を経由して inspected にする）。
+func (p *Production) AdvanceTo(milestone Status, at time.Time) error {
+	if statusRank(milestone) < 0 || p.Status == StatusCancelled {
+		return ErrInvalidStatusTransition
+	}
+
+	for !p.Status.Reached(milestone) {
+		rank := statusRank(p.Status)
+		if rank < 0 || rank+1 >= len(statusOrder) {
+			return ErrInvalidStatus
+		}
+
+		if err := p.TransitionTo(statusOrder[rank+1], at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetTargets は目標日（製造開始・検品完了・完了）を設定します。nil は未設定として扱います。
+func (p *Production) SetTargets(startAt, inspectedAt, completedAt *time.Time) error {
+	p.TargetStartAt = utcTimePtr(startAt)
+	p.TargetInspectedAt = utcTimePtr(inspectedAt)
+	p.TargetCompletedAt = utcTimePtr(completedAt)
+
+	return p.validateTargets()
+}
+
+// ========================================
+// Overdue
+// ========================================
+
+// Overdue は目標日を過ぎても到達していない milestone です。
+type Overdue struct {
+	Milestone Status    `json:"milestone"`
+	TargetAt  time.Time `json:"targetAt"`
+}
+
+// OverdueMilestones は now 時点で遅延している milestone を進捗順に返します。
+// completed / cancelled の production は遅延として扱いません。
+func (p Production) OverdueMilestones(now time.Time) []Overdue {
+	if p.Status.IsTerminal() {
+		return []Overdue{}
+	}
+
+	targets := []struct {
+		milestone Status
+		at        *time.Time
+	}{
+		{StatusManufacturing, p.TargetStartAt},
+		{StatusInspected, p.TargetInspectedAt},
+		{StatusCompleted, p.TargetCompletedAt},
+	}
+
+	out := make([]Overdue, 0, len(targets))
+	for _, t := range targets {
+		if t.at == nil || p.Status.Reached(t.milestone) || !now.After(*t.at) {
+			continue
+		}
+		out = append(out, Overdue{Milestone: t.milestone, TargetAt: t.at.UTC()})
+	}
+
+	return out
+}
+
+// IsOverdue は遅延している milestone があるかどうかを返します。
+func (p Production) IsOverdue(now time.Time) bool {
+	return len(p.OverdueMilestones(now)) > 0
+}
+
+// ========================================
+// Validation
+// ========================================
+
+func (p Production) validateStatus() error {
+	if !p.Status.IsValid() {
+		return ErrInvalidStatus
+	}
+
+	if (p.Status == StatusCancelled) != (p.CancelledAt != nil) {
+		return ErrInvalidStatusAt
+	}
+	if (p.Status == StatusCompleted) != (p.CompletedAt != nil) {
+		return ErrInvalidStatusAt
+	}
+	if p.InspectedAt != nil && !p.Status.Reached(StatusInspected) && p.Status != StatusCancelled {
+		return ErrInvalidStatusAt
+	}
+
+	return p.validateTargets()
+}
+
+// validateTargets は目標日が 製造開始 <= 検品完了 <= 完了 の順であることを検証します。
+func (p Production) validateTargets() error {
+	var prev *time.Time
+	for _, t := range []*time.Time{p.TargetStartAt, p.TargetInspectedAt, p.TargetCompletedAt} {
+		if t == nil {
+			continue
+		}
+		if t.IsZero() || (prev != nil && t.Before(*prev)) {
+			return ErrInvalidTargetDates
+		}
+		prev = t
+	}
+
+	return nil
+}
+
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	v := t.UTC()
+	return &v
+}
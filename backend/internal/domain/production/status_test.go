@@ -0,0 +1,252 @@
+// backend/internal/domain/production/status_test.go
+package production
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testProduction(t *testing.T) Production {
+	t.Helper()
+
+	p, err := New("production_1", "pb_1", "member_1", []ModelQuantity{{ModelID: "model_1", Quantity: 10}}, false, nil, nil, testNow)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	return p
+}
+
+func TestStatus_CanTransitionTo(t *testing.T) {
+	statuses := []Status{StatusPlanned, StatusManufacturing, StatusInspected, StatusCompleted, StatusCancelled}
+
+	allowed := map[Status][]Status{
+		StatusPlanned:       {StatusManufacturing, StatusCancelled},
+		StatusManufacturing: {StatusInspected, StatusCancelled},
+		StatusInspected:     {StatusCompleted},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := false
+			for _, a := range allowed[from] {
+				if a == to {
+					want = true
+				}
+			}
+
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestStatus_Reached(t *testing.T) {
+	tests := []struct {
+		status    Status
+		milestone Status
+		want      bool
+	}{
+		{status: StatusPlanned, milestone: StatusPlanned, want: true},
+		{status: StatusPlanned, milestone: StatusManufacturing, want: false},
+		{status: StatusInspected, milestone: StatusManufacturing, want: true},
+		{status: StatusInspected, milestone: StatusInspected, want: true},
+		{status: StatusInspected, milestone: StatusCompleted, want: false},
+		{status: StatusCompleted, milestone: StatusCompleted, want: true},
+		{status: StatusCancelled, milestone: StatusPlanned, want: false},
+		{status: "unknown", milestone: StatusPlanned, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.Reached(tt.milestone); got != tt.want {
+			t.Errorf("%s.Reached(%s) = %v, want %v", tt.status, tt.milestone, got, tt.want)
+		}
+	}
+}
+
+func TestProduction_TransitionTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       []Status
+		next       Status
+		at         time.Time
+		wantStatus Status
+		wantErr    error
+	}{
+		{name: "start", next: StatusManufacturing, at: testNow, wantStatus: StatusManufacturing},
+		{name: "cancel planned", next: StatusCancelled, at: testNow, wantStatus: StatusCancelled},
+		{name: "cancel manufacturing", path: []Status{StatusManufacturing}, next: StatusCancelled, at: testNow, wantStatus: StatusCancelled},
+		{name: "complete inspected", path: []Status{StatusManufacturing, StatusInspected}, next: StatusCompleted, at: testNow, wantStatus: StatusCompleted},
+		{name: "cancel inspected", path: []Status{StatusManufacturing, StatusInspected}, next: StatusCancelled, at: testNow, wantStatus: StatusInspected, wantErr: ErrInvalidStatusTransition},
+		{name: "skip manufacturing", next: StatusInspected, at: testNow, wantStatus: StatusPlanned, wantErr: ErrInvalidStatusTransition},
+		{name: "back to planned", path: []Status{StatusManufacturing}, next: StatusPlanned, at: testNow, wantStatus: StatusManufacturing, wantErr: ErrInvalidStatusTransition},
+		{name: "restart cancelled", path: []Status{StatusCancelled}, next: StatusManufacturing, at: testNow, wantStatus: StatusCancelled, wantErr: ErrInvalidStatusTransition},
+		{name: "unknown status", next: "paused", at: testNow, wantStatus: StatusPlanned, wantErr: ErrInvalidStatus},
+		{name: "zero time", next: StatusManufacturing, wantStatus: StatusPlanned, wantErr: ErrInvalidStatusAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testProduction(t)
+			for _, s := range tt.path {
+				if err := p.TransitionTo(s, testNow); err != nil {
+					t.Fatalf("TransitionTo(%s): %v", s, err)
+				}
+			}
+
+			if err := p.TransitionTo(tt.next, tt.at); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TransitionTo err = %v, want %v", err, tt.wantErr)
+			}
+			if p.Status != tt.wantStatus {
+				t.Fatalf("Status = %s, want %s", p.Status, tt.wantStatus)
+			}
+			if err := p.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestProduction_AdvanceTo(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          []Status
+		milestone     Status
+		wantStatus    Status
+		wantStarted   bool
+		wantInspected bool
+		wantErr       error
+	}{
+		// 印刷を経ずに検品が完了した場合は manufacturing を経由する。
+		{name: "planned to inspected", milestone: StatusInspected, wantStatus: StatusInspected, wantStarted: true, wantInspected: true},
+		{name: "planned to completed", milestone: StatusCompleted, wantStatus: StatusCompleted, wantStarted: true, wantInspected: true},
+		{name: "already reached", from: []Status{StatusManufacturing, StatusInspected}, milestone: StatusManufacturing, wantStatus: StatusInspected, wantStarted: true, wantInspected: true},
+		{name: "cancelled", from: []Status{StatusCancelled}, milestone: StatusInspected, wantStatus: StatusCancelled, wantErr: ErrInvalidStatusTransition},
+		{name: "cancelled is not a milestone", milestone: StatusCancelled, wantStatus: StatusPlanned, wantErr: ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testProduction(t)
+			for _, s := range tt.from {
+				if err := p.TransitionTo(s, testNow); err != nil {
+					t.Fatalf("TransitionTo(%s): %v", s, err)
+				}
+			}
+
+			if err := p.AdvanceTo(tt.milestone, testNow); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AdvanceTo err = %v, want %v", err, tt.wantErr)
+			}
+			if p.Status != tt.wantStatus {
+				t.Fatalf("Status = %s, want %s", p.Status, tt.wantStatus)
+			}
+			if (p.StartedAt != nil) != tt.wantStarted || (p.InspectedAt != nil) != tt.wantInspected {
+				t.Fatalf("StartedAt = %v, InspectedAt = %v", p.StartedAt, p.InspectedAt)
+			}
+		})
+	}
+}
+
+func TestProduction_MarkPrinted(t *testing.T) {
+	p := testProduction(t)
+
+	if err := p.MarkPrinted(testNow); err != nil {
+		t.Fatalf("MarkPrinted: %v", err)
+	}
+	if p.Status != StatusManufacturing || p.StartedAt == nil || !p.StartedAt.Equal(testNow) {
+		t.Fatalf("Status = %s, StartedAt = %v", p.Status, p.StartedAt)
+	}
+	if err := p.MarkPrinted(testNow); !errors.Is(err, ErrTransition) {
+		t.Fatalf("MarkPrinted twice err = %v, want %v", err, ErrTransition)
+	}
+
+	printedAt := testNow
+	legacy, err := New("production_2", "pb_1", "member_1", []ModelQuantity{{ModelID: "model_1", Quantity: 1}}, true, &printedAt, nil, testNow)
+	if err != nil {
+		t.Fatalf("New(printed): %v", err)
+	}
+	if legacy.Status != StatusManufacturing {
+		t.Fatalf("printed production Status = %s, want %s", legacy.Status, StatusManufacturing)
+	}
+}
+
+func TestProduction_SetTargets(t *testing.T) {
+	day := func(n int) *time.Time {
+		v := testNow.AddDate(0, 0, n)
+		return &v
+	}
+
+	tests := []struct {
+		name                   string
+		start, inspected, done *time.Time
+		want                   error
+	}{
+		{name: "all in order", start: day(1), inspected: day(10), done: day(20)},
+		{name: "same day", start: day(1), inspected: day(1), done: day(1)},
+		{name: "only completion", done: day(20)},
+		{name: "gaps are skipped", start: day(1), done: day(20)},
+		{name: "inspection before start", start: day(10), inspected: day(1), want: ErrInvalidTargetDates},
+		{name: "completion before start with gap", start: day(10), done: day(1), want: ErrInvalidTargetDates},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testProduction(t)
+			if err := p.SetTargets(tt.start, tt.inspected, tt.done); !errors.Is(err, tt.want) {
+				t.Fatalf("SetTargets err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProduction_OverdueMilestones(t *testing.T) {
+	start := testNow.AddDate(0, 0, 1)
+	inspected := testNow.AddDate(0, 0, 10)
+	done := testNow.AddDate(0, 0, 20)
+
+	tests := []struct {
+		name string
+		path []Status
+		now  time.Time
+		want []Overdue
+	}{
+		{name: "before any target", now: testNow, want: []Overdue{}},
+		{name: "at the target is not late", now: start, want: []Overdue{}},
+		{name: "start is late", now: start.Add(time.Hour), want: []Overdue{{Milestone: StatusManufacturing, TargetAt: start}}},
+		{
+			name: "started but inspection and completion are late",
+			path: []Status{StatusManufacturing},
+			now:  done.Add(time.Hour),
+			want: []Overdue{{Milestone: StatusInspected, TargetAt: inspected}, {Milestone: StatusCompleted, TargetAt: done}},
+		},
+		{name: "completed", path: []Status{StatusManufacturing, StatusInspected, StatusCompleted}, now: done.Add(time.Hour), want: []Overdue{}},
+		{name: "cancelled", path: []Status{StatusCancelled}, now: done.Add(time.Hour), want: []Overdue{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testProduction(t)
+			if err := p.SetTargets(&start, &inspected, &done); err != nil {
+				t.Fatalf("SetTargets: %v", err)
+			}
+			for _, s := range tt.path {
+				if err := p.TransitionTo(s, testNow); err != nil {
+					t.Fatalf("TransitionTo(%s): %v", s, err)
+				}
+			}
+
+			got := p.OverdueMilestones(tt.now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("OverdueMilestones = %+v, want %+v", got, tt.want)
+			}
+			if p.IsOverdue(tt.now) != (len(tt.want) > 0) {
+				t.Fatalf("IsOverdue = %v", p.IsOverdue(tt.now))
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// backend/internal/domain/refund/calculator.go
+package refund
+
+import (
+	"errors"
+
+	orderdom "narratives/internal/domain/order"
+)
+
+var (
+	ErrNothingToRefund     = errors.New("refund: nothing to refund")
+	ErrItemNotRefundable   = errors.New("refund: order item is not refundable")
+	ErrQtyExceedsRemaining = errors.New("refund: qty exceeds remaining refundable qty")
+	ErrAmountExceedsPaid   = errors.New("refund: amount exceeds remaining paid amount")
+)
+
+// ItemRequest selects Qty units of Order.Items[ItemIndex] for refund.
+type ItemRequest struct {
+	ItemIndex int
+	Qty       int
+}
+
+// CalculateInput is the server-side source of truth for a refund amount.
+//
+// RefundedQty and RefundedAmount describe refunds that are already active
+// (pending, requires_action or succeeded) for the same Payment.
+type CalculateInput struct {
+	Order orderdom.Order
+
+	PaidAmount int
+
+	Requests []ItemRequest
+
+	RefundedQty    map[int]int
+	RefundedAmount int
+}
+
+// Calculate builds per-item refund lines.
+//
+// Each line refunds:
+//
+//	price * qty
+//	+ shipping quote unit amount * qty (matched by list/inventory/model)
+//	- the coupon discount of the item, prorated by qty
+//	+ consumption tax at the item rate on the price part
+//	+ consumption tax at the standard rate on the shipping part
+//
+// Tax is truncated per line. When the request refunds every remaining unit of
+// the order, the rounding difference against the paid amount is absorbed by
+// the tax part of the last line so that the Payment is fully returned.
+func Calculate(in CalculateInput) ([]RefundItem, error) {
+	if len(in.Requests) == 0 {
+		return nil, ErrNothingToRefund
+	}
+
+	remainingPaid := in.PaidAmount - in.RefundedAmount
+	if remainingPaid <= 0 {
+		return nil, ErrAmountExceedsPaid
+	}
+
+	shippingUnits := shippingUnitAmounts(in.Order)
+
+	requested := make(map[int]int, len(in.Requests))
+	items := make([]RefundItem, 0, len(in.Requests))
+	total := 0
+
+	for _, request := range in.Requests {
+		if request.ItemIndex < 0 ||
+			request.ItemIndex >= len(in.Order.Items) ||
+			request.Qty <= 0 {
+			return nil, ErrInvalidItems
+		}
+
+		if _, exists := requested[request.ItemIndex]; exists {
+			return nil, ErrInvalidItems
+		}
+
+		orderItem := in.Order.Items[request.ItemIndex]
+
+		remainingQty :=
+			orderItem.Qty -
+				in.RefundedQty[request.ItemIndex]
+		if remainingQty <= 0 {
+			return nil, ErrItemNotRefundable
+		}
+
+		if request.Qty > remainingQty {
+			return nil, ErrQtyExceedsRemaining
+		}
+
+		requested[request.ItemIndex] = request.Qty
+
+		refundedQty := in.RefundedQty[request.ItemIndex]
+
+		itemAmount :=
+			orderItem.Price*request.Qty -
+				proratedDiscount(
+					in.Order.ItemDiscountAmount(request.ItemIndex),
+					orderItem.Qty,
+					refundedQty,
+					request.Qty,
+				)
+		shippingAmount :=
+			shippingUnits[request.ItemIndex]*request.Qty -
+				proratedDiscount(
+					in.Order.ItemShippingDiscountAmount(request.ItemIndex),
+					orderItem.Qty,
+					refundedQty,
+					request.Qty,
+				)
+
+		taxAmount :=
+			itemAmount*orderItem.ConsumptionTaxRate/100 +
+				shippingAmount*orderdom.ConsumptionTaxRateStandard/100
+
+		line := RefundItem{
+			ItemIndex:      request.ItemIndex,
+			Qty:            request.Qty,
+			ItemAmount:     itemAmount,
+			ShippingAmount: shippingAmount,
+			TaxAmount:      taxAmount,
+			Amount:         itemAmount + shippingAmount + taxAmount,
+		}
+
+		total += line.Amount
+		items = append(items, line)
+	}
+
+	if total <= 0 {
+		return nil, ErrNothingToRefund
+	}
+
+	if refundsEverything(in.Order, in.RefundedQty, requested) {
+		// Absorb truncation differences so the full payment is returned.
+		last := &items[len(items)-1]
+		difference := remainingPaid - total
+
+		if last.TaxAmount+difference < 0 {
+			return nil, ErrAmountExceedsPaid
+		}
+
+		last.TaxAmount += difference
+		last.Amount += difference
+		total += difference
+	}
+
+	if total > remainingPaid {
+		return nil, ErrAmountExceedsPaid
+	}
+
+	return items, nil
+}
+
+// shippingUnitAmounts maps order item index to its shipping quote unit
+// amount. Shipping quote items are matched to list items by
+// listId/inventoryId/modelId; each quote item is used at most once.
+func shippingUnitAmounts(order orderdom.Order) map[int]int {
+	used := make([]bool, len(order.ShippingQuoteSnapshot.Items))
+	result := make(map[int]int, len(order.Items))
+
+	for index, item := range order.Items {
+		if item.Type != orderdom.OrderItemTypeList {
+			continue
+		}
+
+		for quoteIndex, quote := range order.ShippingQuoteSnapshot.Items {
+			if used[quoteIndex] {
+				continue
+			}
+
+			if quote.ListID != item.ListID ||
+				quote.InventoryID != item.InventoryID ||
+				quote.ModelID != item.ModelID {
+				continue
+			}
+
+			used[quoteIndex] = true
+			result[index] = quote.UnitAmount
+			break
+		}
+	}
+
+	return result
+}
+
+// proratedDiscount returns the share of an item's coupon discount for qty
+// units after refundedQty units were already refunded. Shares are taken from
+// the cumulative amount so that refunding every unit returns the whole
+// discount without rounding drift.
+func proratedDiscount(
+	discount int,
+	itemQty int,
+	refundedQty int,
+	qty int,
+) int {
+	if discount <= 0 || itemQty <= 0 {
+		return 0
+	}
+
+	return discount*(refundedQty+qty)/itemQty -
+		discount*refundedQty/itemQty
+}
+
+func refundsEverything(
+	order orderdom.Order,
+	refundedQty map[int]int,
+	requested map[int]int,
+) bool {
+	for index, item := range order.Items {
+		if refundedQty[index]+requested[index] < item.Qty {
+			return false
+		}
+	}
+
+	return true
+}
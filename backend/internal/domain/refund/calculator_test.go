@@ -0,0 +1,312 @@
+// backend/internal/domain/refund/calculator_test.go
+package refund
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	orderdom "narratives/internal/domain/order"
+)
+
+// testOrder は送料付き（10%）と送料なし（8%）の 2 明細の注文です。
+//
+// 支払額: (1000*3 + 500*3) * 1.1 + 800 * 1.08 = 4950 + 864 = 5814
+func testOrder() orderdom.Order {
+	return orderdom.Order{
+		Items: []orderdom.OrderItemSnapshot{
+			{
+				Type:               orderdom.OrderItemTypeList,
+				ListID:             "list_1",
+				InventoryID:        "inv_1",
+				ModelID:            "model_1",
+				Price:              1000,
+				Qty:                3,
+				ConsumptionTaxRate: 10,
+			},
+			{
+				Type:               orderdom.OrderItemTypeList,
+				ListID:             "list_2",
+				InventoryID:        "inv_2",
+				ModelID:            "model_2",
+				Price:              800,
+				Qty:                1,
+				ConsumptionTaxRate: 8,
+			},
+		},
+		ShippingQuoteSnapshot: orderdom.ShippingQuoteSnapshot{
+			Items: []orderdom.ShippingQuoteItemSnapshot{
+				{
+					ListID:      "list_1",
+					InventoryID: "inv_1",
+					ModelID:     "model_1",
+					Qty:         3,
+					UnitAmount:  500,
+					Amount:      1500,
+				},
+			},
+		},
+	}
+}
+
+// roundingOrder は税の切り捨て差額が出る注文です（333 * 3 * 1.08 = 1078.92 → 1079 で決済）。
+func roundingOrder() orderdom.Order {
+	return orderdom.Order{
+		Items: []orderdom.OrderItemSnapshot{
+			{
+				Type:               orderdom.OrderItemTypeList,
+				ListID:             "list_1",
+				InventoryID:        "inv_1",
+				ModelID:            "model_1",
+				Price:              333,
+				Qty:                3,
+				ConsumptionTaxRate: 8,
+			},
+		},
+	}
+}
+
+// discountedOrder は 1 明細に 100 円のクーポン値引きがある注文です。
+func discountedOrder() orderdom.Order {
+	o := roundingOrder()
+	o.Discount = &orderdom.DiscountSnapshot{
+		CouponID: "coupon_1",
+		Code:     "OFF100",
+		Items: []orderdom.DiscountItemSnapshot{
+			{ItemIndex: 0, Amount: 100},
+		},
+		ItemAmount: 100,
+		Amount:     100,
+	}
+	return o
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   CalculateInput
+		want []RefundItem
+	}{
+		{
+			name: "one unit with shipping",
+			in: CalculateInput{
+				Order:      testOrder(),
+				PaidAmount: 5814,
+				Requests:   []ItemRequest{{ItemIndex: 0, Qty: 1}},
+			},
+			want: []RefundItem{
+				{ItemIndex: 0, Qty: 1, ItemAmount: 1000, ShippingAmount: 500, TaxAmount: 150, Amount: 1650},
+			},
+		},
+		{
+			name: "reduced rate item without shipping",
+			in: CalculateInput{
+				Order:      testOrder(),
+				PaidAmount: 5814,
+				Requests:   []ItemRequest{{ItemIndex: 1, Qty: 1}},
+			},
+			want: []RefundItem{
+				{ItemIndex: 1, Qty: 1, ItemAmount: 800, ShippingAmount: 0, TaxAmount: 64, Amount: 864},
+			},
+		},
+		{
+			name: "whole order",
+			in: CalculateInput{
+				Order:      testOrder(),
+				PaidAmount: 5814,
+				Requests: []ItemRequest{
+					{ItemIndex: 0, Qty: 3},
+					{ItemIndex: 1, Qty: 1},
+				},
+			},
+			want: []RefundItem{
+				{ItemIndex: 0, Qty: 3, ItemAmount: 3000, ShippingAmount: 1500, TaxAmount: 450, Amount: 4950},
+				{ItemIndex: 1, Qty: 1, ItemAmount: 800, ShippingAmount: 0, TaxAmount: 64, Amount: 864},
+			},
+		},
+		{
+			name: "partial refund truncates tax",
+			in: CalculateInput{
+				Order:      roundingOrder(),
+				PaidAmount: 1079,
+				Requests:   []ItemRequest{{ItemIndex: 0, Qty: 1}},
+			},
+			want: []RefundItem{
+				{ItemIndex: 0, Qty: 1, ItemAmount: 333, TaxAmount: 26, Amount: 359},
+			},
+		},
+		{
+			name: "last refund absorbs the rounding difference",
+			in: CalculateInput{
+				Order:          roundingOrder(),
+				PaidAmount:     1079,
+				Requests:       []ItemRequest{{ItemIndex: 0, Qty: 2}},
+				RefundedQty:    map[int]int{0: 1},
+				RefundedAmount: 359,
+			},
+			want: []RefundItem{
+				{ItemIndex: 0, Qty: 2, ItemAmount: 666, TaxAmount: 54, Amount: 720},
+			},
+		},
+		{
+			name: "first unit takes the truncated discount share",
+			in: CalculateInput{
+				Order:      discountedOrder(),
+				PaidAmount: 971,
+				Requests:   []ItemRequest{{ItemIndex: 0, Qty: 1}},
+			},
+			want: []RefundItem{
+				{ItemIndex: 0, Qty: 1, ItemAmount: 300, TaxAmount: 24, Amount: 324},
+			},
+		},
+		{
+			name: "remaining units take the rest of the discount",
+			in: CalculateInput{
+				Order:          discountedOrder(),
+				PaidAmount:     971,
+				Requests:       []ItemRequest{{ItemIndex: 0, Qty: 2}},
+				RefundedQty:    map[int]int{0: 1},
+				RefundedAmount: 324,
+			},
+			want: []RefundItem{
+				{ItemIndex: 0, Qty: 2, ItemAmount: 599, TaxAmount: 48, Amount: 647},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Calculate(tt.in)
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Calculate = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculate_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   CalculateInput
+		want error
+	}{
+		{
+			name: "no requests",
+			in:   CalculateInput{Order: testOrder(), PaidAmount: 5814},
+			want: ErrNothingToRefund,
+		},
+		{
+			name: "item index out of range",
+			in: CalculateInput{
+				Order:      testOrder(),
+				PaidAmount: 5814,
+				Requests:   []ItemRequest{{ItemIndex: 2, Qty: 1}},
+			},
+			want: ErrInvalidItems,
+		},
+		{
+			name: "zero qty",
+			in: CalculateInput{
+				Order:      testOrder(),
+				PaidAmount: 5814,
+				Requests:   []ItemRequest{{ItemIndex: 0, Qty: 0}},
+			},
+			want: ErrInvalidItems,
+		},
+		{
+			name: "duplicate item",
+			in: CalculateInput{
+				Order:      testOrder(),
+				PaidAmount: 5814,
+				Requests: []ItemRequest{
+					{ItemIndex: 0, Qty: 1},
+					{ItemIndex: 0, Qty: 1},
+				},
+			},
+			want: ErrInvalidItems,
+		},
+		{
+			name: "qty exceeds remaining",
+			in: CalculateInput{
+				Order:          testOrder(),
+				PaidAmount:     5814,
+				Requests:       []ItemRequest{{ItemIndex: 0, Qty: 2}},
+				RefundedQty:    map[int]int{0: 2},
+				RefundedAmount: 3300,
+			},
+			want: ErrQtyExceedsRemaining,
+		},
+		{
+			name: "item already refunded",
+			in: CalculateInput{
+				Order:          testOrder(),
+				PaidAmount:     5814,
+				Requests:       []ItemRequest{{ItemIndex: 1, Qty: 1}},
+				RefundedQty:    map[int]int{1: 1},
+				RefundedAmount: 864,
+			},
+			want: ErrItemNotRefundable,
+		},
+		{
+			name: "payment already fully refunded",
+			in: CalculateInput{
+				Order:          testOrder(),
+				PaidAmount:     5814,
+				Requests:       []ItemRequest{{ItemIndex: 0, Qty: 1}},
+				RefundedAmount: 5814,
+			},
+			want: ErrAmountExceedsPaid,
+		},
+		{
+			name: "amount exceeds remaining paid",
+			in: CalculateInput{
+				Order:          testOrder(),
+				PaidAmount:     5814,
+				Requests:       []ItemRequest{{ItemIndex: 0, Qty: 1}},
+				RefundedAmount: 5000,
+			},
+			want: ErrAmountExceedsPaid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Calculate(tt.in); !errors.Is(err, tt.want) {
+				t.Fatalf("Calculate err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	statuses := []RefundStatus{
+		StatusPending,
+		StatusRequiresAction,
+		StatusSucceeded,
+		StatusFailed,
+		StatusCanceled,
+	}
+
+	allowed := map[RefundStatus][]RefundStatus{
+		StatusPending:        {StatusRequiresAction, StatusSucceeded, StatusFailed, StatusCanceled},
+		StatusRequiresAction: {StatusPending, StatusSucceeded, StatusFailed, StatusCanceled},
+	}
+
+	for _, current := range statuses {
+		for _, next := range statuses {
+			want := current == next
+			for _, s := range allowed[current] {
+				if s == next {
+					want = true
+				}
+			}
+
+			if got := CanTransition(current, next); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", current, next, got, want)
+			}
+		}
+	}
+}
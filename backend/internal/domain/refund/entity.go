@@ -0,0 +1,341 @@
+// backend/internal/domain/refund/entity.go
+package refund
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// RefundStatus mirrors the Stripe Refund lifecycle.
+type RefundStatus string
+
+const (
+	StatusPending        RefundStatus = "pending"
+	StatusRequiresAction RefundStatus = "requires_action"
+	StatusSucceeded      RefundStatus = "succeeded"
+	StatusFailed         RefundStatus = "failed"
+	StatusCanceled       RefundStatus = "canceled"
+)
+
+var AllowedStatuses = map[RefundStatus]struct{}{
+	StatusPending:        {},
+	StatusRequiresAction: {},
+	StatusSucceeded:      {},
+	StatusFailed:         {},
+	StatusCanceled:       {},
+}
+
+func IsValidStatus(s RefundStatus) bool {
+	if s == "" {
+		return false
+	}
+
+	_, ok := AllowedStatuses[s]
+	return ok
+}
+
+// IsActive reports whether the refund still holds (or has already returned)
+// money against the Payment. failed and canceled refunds release their amount.
+func (s RefundStatus) IsActive() bool {
+	switch s {
+	case StatusPending,
+		StatusRequiresAction,
+		StatusSucceeded:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// CanTransition prevents stale or out-of-order Stripe events from
+// regressing a terminal Refund state.
+func CanTransition(
+	current RefundStatus,
+	next RefundStatus,
+) bool {
+	if current == next {
+		return true
+	}
+
+	switch current {
+	case StatusPending:
+		switch next {
+		case StatusRequiresAction,
+			StatusSucceeded,
+			StatusFailed,
+			StatusCanceled:
+			return true
+		}
+
+	case StatusRequiresAction:
+		switch next {
+		case StatusPending,
+			StatusSucceeded,
+			StatusFailed,
+			StatusCanceled:
+			return true
+		}
+	}
+
+	// succeeded, failed and canceled are terminal.
+	return false
+}
+
+// Reason is the refund reason accepted by Stripe.
+type Reason string
+
+const (
+	ReasonRequestedByCustomer Reason = "requested_by_customer"
+	ReasonDuplicate           Reason = "duplicate"
+	ReasonFraudulent          Reason = "fraudulent"
+)
+
+func IsValidReason(r Reason) bool {
+	switch r {
+	case ReasonRequestedByCustomer,
+		ReasonDuplicate,
+		ReasonFraudulent:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// RefundItem is the refunded portion of one Order item.
+//
+// Amount = ItemAmount + ShippingAmount + TaxAmount.
+type RefundItem struct {
+	ItemIndex int `json:"itemIndex"`
+	Qty       int `json:"qty"`
+
+	ItemAmount     int `json:"itemAmount"`
+	ShippingAmount int `json:"shippingAmount"`
+	TaxAmount      int `json:"taxAmount"`
+	Amount         int `json:"amount"`
+}
+
+// Refund is a (partial) refund of a Payment.
+//
+// Firestore rule:
+//   - refund document ID is Refund.ID.
+//   - PaymentID is the same value as order.ID.
+//   - A Payment may own several refunds; their active amounts must never
+//     exceed Payment.Amount.
+type Refund struct {
+	ID        string `json:"id"`
+	PaymentID string `json:"paymentId"`
+
+	StripePaymentIntentID string `json:"stripePaymentIntentId"`
+	StripeRefundID        string `json:"stripeRefundId,omitempty"`
+
+	Items []RefundItem `json:"items"`
+
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+
+	Reason Reason `json:"reason"`
+	Note   string `json:"note,omitempty"`
+
+	Status   RefundStatus `json:"status"`
+	ErrorMsg *string      `json:"errorMsg,omitempty"`
+
+	RequestedBy string `json:"requestedBy"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Errors
+var (
+	ErrInvalidID                    = errors.New("refund: invalid id")
+	ErrInvalidPaymentID             = errors.New("refund: invalid paymentId")
+	ErrInvalidStripePaymentIntentID = errors.New("refund: invalid stripePaymentIntentId")
+	ErrInvalidItems                 = errors.New("refund: invalid items")
+	ErrInvalidAmount                = errors.New("refund: invalid amount")
+	ErrInvalidCurrency              = errors.New("refund: invalid currency")
+	ErrInvalidReason                = errors.New("refund: invalid reason")
+	ErrInvalidStatus                = errors.New("refund: invalid status")
+	ErrInvalidRequestedBy           = errors.New("refund: invalid requestedBy")
+	ErrInvalidCreatedAt             = errors.New("refund: invalid createdAt")
+	ErrInvalidErrorMsg              = errors.New("refund: invalid errorMsg")
+	ErrInvalidTransition            = errors.New("refund: invalid status transition")
+)
+
+// Policy
+const (
+	CurrencyJPY = "JPY"
+)
+
+// Constructors
+
+func New(
+	id string,
+	paymentID string,
+	stripePaymentIntentID string,
+	items []RefundItem,
+	reason Reason,
+	note string,
+	requestedBy string,
+	createdAt time.Time,
+) (Refund, error) {
+	r := Refund{
+		ID:                    strings.TrimSpace(id),
+		PaymentID:             strings.TrimSpace(paymentID),
+		StripePaymentIntentID: strings.TrimSpace(stripePaymentIntentID),
+		Items:                 append([]RefundItem(nil), items...),
+		Amount:                sumItemAmounts(items),
+		Currency:              CurrencyJPY,
+		Reason:                reason,
+		Note:                  strings.TrimSpace(note),
+		Status:                StatusPending,
+		RequestedBy:           strings.TrimSpace(requestedBy),
+		CreatedAt:             createdAt.UTC(),
+		UpdatedAt:             createdAt.UTC(),
+	}
+
+	if r.Reason == "" {
+		r.Reason = ReasonRequestedByCustomer
+	}
+
+	if err := r.Validate(); err != nil {
+		return Refund{}, err
+	}
+
+	return r, nil
+}
+
+// Behavior
+
+// ApplyStripeResult records the Stripe Refund ID and status returned by
+// Stripe. A transition that would regress a terminal state is rejected.
+func (r *Refund) ApplyStripeResult(
+	stripeRefundID string,
+	next RefundStatus,
+	errorMsg *string,
+	now time.Time,
+) error {
+	if !IsValidStatus(next) {
+		return ErrInvalidStatus
+	}
+
+	if !CanTransition(r.Status, next) {
+		return ErrInvalidTransition
+	}
+
+	if errorMsg != nil && strings.TrimSpace(*errorMsg) == "" {
+		return ErrInvalidErrorMsg
+	}
+
+	if id := strings.TrimSpace(stripeRefundID); id != "" {
+		r.StripeRefundID = id
+	}
+
+	r.Status = next
+
+	switch next {
+	case StatusFailed, StatusCanceled:
+		r.ErrorMsg = errorMsg
+
+	default:
+		r.ErrorMsg = nil
+	}
+
+	r.UpdatedAt = now.UTC()
+	return nil
+}
+
+// Validation
+
+func (r Refund) Validate() error {
+	if r.ID == "" {
+		return ErrInvalidID
+	}
+
+	if r.PaymentID == "" {
+		return ErrInvalidPaymentID
+	}
+
+	if r.StripePaymentIntentID == "" {
+		return ErrInvalidStripePaymentIntentID
+	}
+
+	if err := validateItems(r.Items); err != nil {
+		return err
+	}
+
+	if r.Amount <= 0 ||
+		r.Amount != sumItemAmounts(r.Items) {
+		return ErrInvalidAmount
+	}
+
+	if r.Currency != CurrencyJPY {
+		return ErrInvalidCurrency
+	}
+
+	if !IsValidReason(r.Reason) {
+		return ErrInvalidReason
+	}
+
+	if !IsValidStatus(r.Status) {
+		return ErrInvalidStatus
+	}
+
+	if r.ErrorMsg != nil && strings.TrimSpace(*r.ErrorMsg) == "" {
+		return ErrInvalidErrorMsg
+	}
+
+	if r.RequestedBy == "" {
+		return ErrInvalidRequestedBy
+	}
+
+	if r.CreatedAt.IsZero() {
+		return ErrInvalidCreatedAt
+	}
+
+	return nil
+}
+
+func validateItems(items []RefundItem) error {
+	if len(items) == 0 {
+		return ErrInvalidItems
+	}
+
+	seen := make(map[int]struct{}, len(items))
+
+	for _, item := range items {
+		if item.ItemIndex < 0 || item.Qty <= 0 {
+			return ErrInvalidItems
+		}
+
+		if _, exists := seen[item.ItemIndex]; exists {
+			return ErrInvalidItems
+		}
+		seen[item.ItemIndex] = struct{}{}
+
+		if item.ItemAmount < 0 ||
+			item.ShippingAmount < 0 ||
+			item.TaxAmount < 0 {
+			return ErrInvalidItems
+		}
+
+		if item.Amount != item.ItemAmount+
+			item.ShippingAmount+
+			item.TaxAmount {
+			return ErrInvalidItems
+		}
+	}
+
+	return nil
+}
+
+func sumItemAmounts(items []RefundItem) int {
+	total := 0
+	for _, item := range items {
+		total += item.Amount
+	}
+
+	return total
+}
@@ -0,0 +1,83 @@
+// backend/internal/domain/refund/repository_port.go
+package refund
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ApplyStripeEventInput is a verified Stripe refund event.
+type ApplyStripeEventInput struct {
+	EventID string
+
+	RefundID       string
+	StripeRefundID string
+
+	Status   RefundStatus
+	ErrorMsg *string
+
+	OccurredAt time.Time
+}
+
+// ApplyStripeEventResult describes the atomic event application result.
+type ApplyStripeEventResult struct {
+	Refund Refund
+
+	// EventApplied is false when EventID has already been processed.
+	EventApplied bool
+
+	// StatusChanged is true when the stored Refund status changed.
+	StatusChanged bool
+}
+
+// RepositoryPort - ドメインのリポジトリ契約
+//
+// Create never overwrites an existing refund and Update never creates one.
+type RepositoryPort interface {
+	GetByID(
+		ctx context.Context,
+		id string,
+	) (Refund, error)
+
+	// ListByPaymentID returns every refund of the payment ordered by
+	// createdAt ascending.
+	ListByPaymentID(
+		ctx context.Context,
+		paymentID string,
+	) ([]Refund, error)
+
+	Create(
+		ctx context.Context,
+		r Refund,
+	) (Refund, error)
+
+	// CreateForPayment atomically lists the refunds of paymentID, lets build
+	// compute the new refund from them and creates it. Calls for the same
+	// payment are serialized, so build always sees every committed refund.
+	// build may be called more than once when the transaction is retried.
+	CreateForPayment(
+		ctx context.Context,
+		paymentID string,
+		build func(existing []Refund) (Refund, error),
+	) (Refund, error)
+
+	Update(
+		ctx context.Context,
+		r Refund,
+	) (Refund, error)
+
+	// ApplyStripeEvent atomically deduplicates the Stripe event ID,
+	// validates the status transition with CanTransition and records the
+	// event as processed. Stale transitions are recorded but not applied.
+	ApplyStripeEvent(
+		ctx context.Context,
+		in ApplyStripeEventInput,
+	) (ApplyStripeEventResult, error)
+}
+
+// 共通エラー
+var (
+	ErrNotFound = errors.New("refund: not found")
+	ErrConflict = errors.New("refund: conflict")
+)
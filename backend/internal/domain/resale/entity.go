@@ -16,11 +16,15 @@ const (
 	StatusListing   ResaleStatus = "listing"
 	StatusSuspended ResaleStatus = "suspended"
 	StatusSold      ResaleStatus = "sold"
+
+	// StatusReserved は offer が accept され、合意価格での決済待ちの状態。
+	// market には表示されず、offer の購入者だけが注文できる。
+	StatusReserved ResaleStatus = "reserved"
 )
 
 func IsValidStatus(s ResaleStatus) bool {
 	switch s {
-	case StatusListing, StatusSuspended, StatusSold:
+	case StatusListing, StatusSuspended, StatusSold, StatusReserved:
 		return true
 	default:
 		return false
@@ -65,6 +69,8 @@ var (
 	ErrInvalidUpdatedAt          = errors.New("resale: invalid updatedAt")
 	ErrInvalidUpdatedBy          = errors.New("resale: invalid updatedBy")
 	ErrSoldResaleCannotBeDeleted = errors.New("resale: sold resale cannot be deleted")
+	ErrNotListing                = errors.New("resale: resale is not listing")
+	ErrReserved                  = errors.New("resale: resale is reserved by an accepted offer")
 
 	ErrEmptyImageID   = errors.New("resale: imageId must not be empty")
 	ErrInvalidImageID = errors.New("resale: invalid imageId")
@@ -270,6 +276,28 @@ func (r *Resale) Resume(now time.Time) error {
 	return nil
 }
 
+// Reserve は offer の合意により resale を確保します。
+func (r *Resale) Reserve(now time.Time) error {
+	if r.Status != StatusListing {
+		return ErrNotListing
+	}
+
+	r.Status = StatusReserved
+	r.touch(now)
+	return nil
+}
+
+// Release は決済されなかった確保を解除し、出品中に戻します。
+func (r *Resale) Release(now time.Time) error {
+	if r.Status != StatusReserved {
+		return nil
+	}
+
+	r.Status = StatusListing
+	r.touch(now)
+	return nil
+}
+
 func (r *Resale) MarkSold(now time.Time) error {
 	r.Status = StatusSold
 	r.touch(now)
@@ -281,6 +309,10 @@ func (r Resale) ValidateDelete() error {
 		return ErrSoldResaleCannotBeDeleted
 	}
 
+	if r.Status == StatusReserved {
+		return ErrReserved
+	}
+
 	return nil
 }
 
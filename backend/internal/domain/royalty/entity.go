@@ -0,0 +1,182 @@
+// backend/internal/domain/royalty/entity.go
+package royalty
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry は二次流通（resale）1 件分のロイヤリティ台帳の記録です。
+//
+// - 決済完了時に resale item ごとに 1 件計上する。
+// - ID は {orderId}_{itemIndex} で、同じ明細を二重に計上しない。
+// - 金額は注文作成時の OrderItemSnapshot.Royalty をそのまま使う（税抜・円）。
+// - ロイヤリティは出品者の受取額から差し引かれ、購入者の支払額は変わらない。
+type Entry struct {
+	ID string `json:"id"`
+
+	// ロイヤリティの受取先（token blueprint を発行した company / brand）
+	CompanyID        string `json:"companyId"`
+	BrandID          string `json:"brandId"`
+	TokenBlueprintID string `json:"tokenBlueprintId"`
+
+	OrderID   string `json:"orderId"`
+	ItemIndex int    `json:"itemIndex"`
+
+	ResaleID       string `json:"resaleId"`
+	ProductID      string `json:"productId"`
+	SellerAvatarID string `json:"sellerAvatarId,omitempty"`
+
+	// SaleAmount は販売価格（税抜）。
+	SaleAmount int `json:"saleAmount"`
+
+	BasisPoints   int `json:"basisPoints"`
+	MinimumAmount int `json:"minimumAmount"`
+
+	Amount int `json:"amount"`
+
+	AccruedAt time.Time `json:"accruedAt"`
+}
+
+var (
+	ErrInvalidID               = errors.New("royalty: invalid id")
+	ErrInvalidCompanyID        = errors.New("royalty: invalid companyId")
+	ErrInvalidBrandID          = errors.New("royalty: invalid brandId")
+	ErrInvalidTokenBlueprintID = errors.New("royalty: invalid tokenBlueprintId")
+	ErrInvalidOrderID          = errors.New("royalty: invalid orderId")
+	ErrInvalidItemIndex        = errors.New("royalty: invalid itemIndex")
+	ErrInvalidResaleID         = errors.New("royalty: invalid resaleId")
+	ErrInvalidAmount           = errors.New("royalty: invalid amount")
+	ErrInvalidAccruedAt        = errors.New("royalty: invalid accruedAt")
+	ErrInvalidPeriod           = errors.New("royalty: invalid period")
+)
+
+// EntryID は台帳の document ID を返します。
+func EntryID(orderID string, itemIndex int) string {
+	return fmt.Sprintf("%s_%d", strings.TrimSpace(orderID), itemIndex)
+}
+
+type NewEntryInput struct {
+	CompanyID        string
+	BrandID          string
+	TokenBlueprintID string
+
+	OrderID   string
+	ItemIndex int
+
+	ResaleID       string
+	ProductID      string
+	SellerAvatarID string
+
+	SaleAmount    int
+	BasisPoints   int
+	MinimumAmount int
+	Amount        int
+
+	AccruedAt time.Time
+}
+
+func NewEntry(in NewEntryInput) (Entry, error) {
+	e := Entry{
+		ID: EntryID(in.OrderID, in.ItemIndex),
+
+		CompanyID:        strings.TrimSpace(in.CompanyID),
+		BrandID:          strings.TrimSpace(in.BrandID),
+		TokenBlueprintID: strings.TrimSpace(in.TokenBlueprintID),
+
+		OrderID:   strings.TrimSpace(in.OrderID),
+		ItemIndex: in.ItemIndex,
+
+		ResaleID:       strings.TrimSpace(in.ResaleID),
+		ProductID:      strings.TrimSpace(in.ProductID),
+		SellerAvatarID: strings.TrimSpace(in.SellerAvatarID),
+
+		SaleAmount:    in.SaleAmount,
+		BasisPoints:   in.BasisPoints,
+		MinimumAmount: in.MinimumAmount,
+		Amount:        in.Amount,
+
+		AccruedAt: in.AccruedAt.UTC(),
+	}
+
+	if err := e.Validate(); err != nil {
+		return Entry{}, err
+	}
+
+	return e, nil
+}
+
+func (e Entry) Validate() error {
+	if e.OrderID == "" || strings.Contains(e.OrderID, "/") {
+		return ErrInvalidOrderID
+	}
+	if e.ItemIndex < 0 {
+		return ErrInvalidItemIndex
+	}
+	if e.ID != EntryID(e.OrderID, e.ItemIndex) {
+		return ErrInvalidID
+	}
+	if e.CompanyID == "" {
+		return ErrInvalidCompanyID
+	}
+	if e.BrandID == "" {
+		return ErrInvalidBrandID
+	}
+	if e.TokenBlueprintID == "" {
+		return ErrInvalidTokenBlueprintID
+	}
+	if e.ResaleID == "" {
+		return ErrInvalidResaleID
+	}
+	if e.SaleAmount < 0 ||
+		e.BasisPoints < 0 ||
+		e.MinimumAmount < 0 ||
+		e.Amount <= 0 ||
+		e.Amount > e.SaleAmount {
+		return ErrInvalidAmount
+	}
+	if e.AccruedAt.IsZero() {
+		return ErrInvalidAccruedAt
+	}
+	return nil
+}
+
+// ========================================
+// Summary
+// ========================================
+
+// BrandSummary は brand ごとの集計です。
+type BrandSummary struct {
+	BrandID    string `json:"brandId"`
+	Count      int    `json:"count"`
+	SaleAmount int    `json:"saleAmount"`
+	Amount     int    `json:"amount"`
+}
+
+// Summarize は entries を brand ごとに集計します（brandId 昇順）。
+func Summarize(entries []Entry) []BrandSummary {
+	byBrand := map[string]*BrandSummary{}
+	for _, e := range entries {
+		s, ok := byBrand[e.BrandID]
+		if !ok {
+			s = &BrandSummary{BrandID: e.BrandID}
+			byBrand[e.BrandID] = s
+		}
+		s.Count++
+		s.SaleAmount += e.SaleAmount
+		s.Amount += e.Amount
+	}
+
+	out := make([]BrandSummary, 0, len(byBrand))
+	for _, s := range byBrand {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].BrandID < out[j].BrandID
+	})
+
+	return out
+}
@@ -0,0 +1,81 @@
+// backend/internal/domain/royalty/entity_test.go
+package royalty
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func testEntryInput() NewEntryInput {
+	return NewEntryInput{
+		CompanyID:        "company_1",
+		BrandID:          "brand_1",
+		TokenBlueprintID: "tb_1",
+		OrderID:          "order_1",
+		ItemIndex:        2,
+		ResaleID:         "resale_1",
+		ProductID:        "product_1",
+		SaleAmount:       10000,
+		BasisPoints:      500,
+		Amount:           500,
+		AccruedAt:        testNow,
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(in *NewEntryInput)
+		want   error
+	}{
+		{name: "valid", modify: func(in *NewEntryInput) {}},
+		{name: "amount equals sale", modify: func(in *NewEntryInput) { in.Amount = 10000 }},
+		{name: "order id with slash", modify: func(in *NewEntryInput) { in.OrderID = "a/b" }, want: ErrInvalidOrderID},
+		{name: "negative item index", modify: func(in *NewEntryInput) { in.ItemIndex = -1 }, want: ErrInvalidItemIndex},
+		{name: "missing brand", modify: func(in *NewEntryInput) { in.BrandID = "" }, want: ErrInvalidBrandID},
+		{name: "missing resale", modify: func(in *NewEntryInput) { in.ResaleID = " " }, want: ErrInvalidResaleID},
+		{name: "zero amount", modify: func(in *NewEntryInput) { in.Amount = 0 }, want: ErrInvalidAmount},
+		{name: "amount exceeds sale", modify: func(in *NewEntryInput) { in.Amount = 10001 }, want: ErrInvalidAmount},
+		{name: "zero accruedAt", modify: func(in *NewEntryInput) { in.AccruedAt = time.Time{} }, want: ErrInvalidAccruedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := testEntryInput()
+			tt.modify(&in)
+
+			e, err := NewEntry(in)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewEntry err = %v, want %v", err, tt.want)
+			}
+			if err == nil && e.ID != "order_1_2" {
+				t.Fatalf("ID = %q, want order_1_2", e.ID)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	entries := []Entry{
+		{BrandID: "brand_b", SaleAmount: 10000, Amount: 500},
+		{BrandID: "brand_a", SaleAmount: 3000, Amount: 300},
+		{BrandID: "brand_b", SaleAmount: 2000, Amount: 100},
+	}
+
+	want := []BrandSummary{
+		{BrandID: "brand_a", Count: 1, SaleAmount: 3000, Amount: 300},
+		{BrandID: "brand_b", Count: 2, SaleAmount: 12000, Amount: 600},
+	}
+
+	if got := Summarize(entries); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Summarize = %+v, want %+v", got, want)
+	}
+
+	if got := Summarize(nil); len(got) != 0 {
+		t.Fatalf("Summarize(nil) = %+v, want empty", got)
+	}
+}
@@ -0,0 +1,35 @@
+// backend/internal/domain/royalty/repository_port.go
+package royalty
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ListFilter は台帳一覧の絞り込み条件です。
+// From / To は AccruedAt の範囲 [From, To) で、nil は無制限。
+type ListFilter struct {
+	BrandID string
+
+	From *time.Time
+	To   *time.Time
+}
+
+// RepositoryPort - ドメインのリポジトリ契約
+//
+// Collection:
+// - royaltyEntries/{orderId}_{itemIndex}
+type RepositoryPort interface {
+	// Create returns ErrConflict when the entry already exists.
+	Create(ctx context.Context, e Entry) (Entry, error)
+
+	// ListByCompanyID returns the company's entries ordered by accruedAt desc.
+	ListByCompanyID(ctx context.Context, companyID string, filter ListFilter) ([]Entry, error)
+}
+
+// 共通エラー
+var (
+	ErrNotFound = errors.New("royalty: not found")
+	ErrConflict = errors.New("royalty: conflict")
+)
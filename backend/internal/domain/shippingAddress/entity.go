@@ -148,6 +148,14 @@ func validateZipCode(zipCode string, country string) error {
 	return nil
 }
 
+// 国外の住所は州・省がない国もあるため、Stateを任意とします。
+func validateState(state string, country string) error {
+	if country != DefaultCountry && state == "" {
+		return nil
+	}
+	return validateRequiredText(state, MaxStateLength, ErrInvalidState)
+}
+
 func validateCountry(country string) error {
 	if !countryCodePattern.MatchString(country) {
 		return ErrInvalidCountry
@@ -173,7 +181,7 @@ func (a ShippingAddress) validateAddressFields() error {
 	if err := validateZipCode(a.ZipCode, a.Country); err != nil {
 		return err
 	}
-	if err := validateRequiredText(a.State, MaxStateLength, ErrInvalidState); err != nil {
+	if err := validateState(a.State, a.Country); err != nil {
 		return err
 	}
 	if err := validateRequiredText(a.City, MaxCityLength, ErrInvalidCity); err != nil {
@@ -0,0 +1,179 @@
+// backend/internal/domain/stripeEvent/entity.go
+package stripeEvent
+
+/*
+責務:
+- 署名検証済みの Stripe webhook イベントを event id 単位で保存する（イベントストア）。
+- 受信したイベントの処理結果（processed / ignored / failed）と試行回数を持つ。
+
+前提:
+- Payload は Stripe から受け取った raw JSON のまま保持し、再処理（replay）に使う。
+- 同じ event id の再送は既存のイベントとして扱い、処理済み（processed / ignored）であれば
+  処理しない。failed のイベントは再送・replay で再処理する。
+- 個々の処理（payment / refund / dispute）は独自に event id で重複排除するため、
+  processed のイベントを replay しても二重に反映されない。
+*/
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusReceived  Status = "received"
+	StatusProcessed Status = "processed"
+	StatusIgnored   Status = "ignored"
+	StatusFailed    Status = "failed"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusReceived,
+		StatusProcessed,
+		StatusIgnored,
+		StatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsDone は再送を処理せずに応答してよい状態かを返します。
+func (s Status) IsDone() bool {
+	return s == StatusProcessed || s == StatusIgnored
+}
+
+var (
+	ErrNotFound = errors.New("stripeEvent: not found")
+
+	ErrInvalidID         = errors.New("stripeEvent: invalid id")
+	ErrInvalidType       = errors.New("stripeEvent: invalid type")
+	ErrInvalidPayload    = errors.New("stripeEvent: invalid payload")
+	ErrInvalidStatus     = errors.New("stripeEvent: invalid status")
+	ErrInvalidOccurredAt = errors.New("stripeEvent: invalid occurredAt")
+	ErrInvalidReceivedAt = errors.New("stripeEvent: invalid receivedAt")
+	ErrErrorRequired     = errors.New("stripeEvent: error is required")
+)
+
+// Event は 1 件の Stripe webhook イベントです。ID は Stripe の event id（evt_...）。
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+
+	// Payload は Stripe から受け取った raw JSON です。
+	Payload []byte `json:"-"`
+
+	Status    Status `json:"status"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"lastError,omitempty"`
+
+	// OccurredAt は Stripe の event.created です。
+	OccurredAt time.Time `json:"occurredAt"`
+	ReceivedAt time.Time `json:"receivedAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+
+	ProcessedAt *time.Time `json:"processedAt,omitempty"`
+
+	// LastReplayedAt / LastReplayedBy は console からの最後の replay です。
+	LastReplayedAt *time.Time `json:"lastReplayedAt,omitempty"`
+	LastReplayedBy string     `json:"lastReplayedBy,omitempty"`
+}
+
+// NewReceived は受信直後（未処理）のイベントを作成します。
+func NewReceived(
+	id string,
+	eventType string,
+	payload []byte,
+	occurredAt time.Time,
+	now time.Time,
+) (Event, error) {
+	now = now.UTC()
+
+	e := Event{
+		ID:         strings.TrimSpace(id),
+		Type:       strings.TrimSpace(eventType),
+		Payload:    payload,
+		Status:     StatusReceived,
+		OccurredAt: occurredAt.UTC(),
+		ReceivedAt: now,
+		UpdatedAt:  now,
+	}
+
+	if err := e.Validate(); err != nil {
+		return Event{}, err
+	}
+
+	return e, nil
+}
+
+func (e Event) Validate() error {
+	if e.ID == "" || strings.Contains(e.ID, "/") {
+		return ErrInvalidID
+	}
+	if e.Type == "" {
+		return ErrInvalidType
+	}
+	if len(e.Payload) == 0 || !json.Valid(e.Payload) {
+		return ErrInvalidPayload
+	}
+	if !e.Status.IsValid() {
+		return ErrInvalidStatus
+	}
+	if e.OccurredAt.IsZero() {
+		return ErrInvalidOccurredAt
+	}
+	if e.ReceivedAt.IsZero() {
+		return ErrInvalidReceivedAt
+	}
+	return nil
+}
+
+// MarkProcessed は処理の成功を記録します。
+func (e *Event) MarkProcessed(now time.Time) {
+	e.finish(StatusProcessed, now)
+}
+
+// MarkIgnored はこのアプリケーションの対象外（未対応の型・他アプリの決済）として記録します。
+func (e *Event) MarkIgnored(now time.Time) {
+	e.finish(StatusIgnored, now)
+}
+
+// MarkFailed は処理の失敗を記録します。再送または replay で再処理されます。
+func (e *Event) MarkFailed(lastError string, now time.Time) error {
+	lastError = strings.TrimSpace(lastError)
+	if lastError == "" {
+		return ErrErrorRequired
+	}
+
+	now = now.UTC()
+
+	e.Status = StatusFailed
+	e.Attempts++
+	e.LastError = lastError
+	e.UpdatedAt = now
+
+	return nil
+}
+
+// MarkReplayed は console からの replay を記録します。処理結果は別途記録します。
+func (e *Event) MarkReplayed(memberID string, now time.Time) {
+	now = now.UTC()
+
+	e.LastReplayedAt = &now
+	e.LastReplayedBy = strings.TrimSpace(memberID)
+	e.UpdatedAt = now
+}
+
+func (e *Event) finish(status Status, now time.Time) {
+	now = now.UTC()
+
+	e.Status = status
+	e.Attempts++
+	e.LastError = ""
+	e.ProcessedAt = &now
+	e.UpdatedAt = now
+}
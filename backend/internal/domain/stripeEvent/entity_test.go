@@ -0,0 +1,136 @@
+// backend/internal/domain/stripeEvent/entity_test.go
+package stripeEvent
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNewReceived(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		eventType  string
+		payload    string
+		occurredAt time.Time
+		want       error
+	}{
+		{name: "valid", id: "evt_1", eventType: "payment_intent.succeeded", payload: `{"id":"evt_1"}`, occurredAt: testNow},
+		{name: "missing id", id: " ", eventType: "payment_intent.succeeded", payload: `{}`, occurredAt: testNow, want: ErrInvalidID},
+		{name: "id with slash", id: "evt/1", eventType: "payment_intent.succeeded", payload: `{}`, occurredAt: testNow, want: ErrInvalidID},
+		{name: "missing type", id: "evt_1", payload: `{}`, occurredAt: testNow, want: ErrInvalidType},
+		{name: "empty payload", id: "evt_1", eventType: "payment_intent.succeeded", occurredAt: testNow, want: ErrInvalidPayload},
+		{name: "broken payload", id: "evt_1", eventType: "payment_intent.succeeded", payload: `{"id":`, occurredAt: testNow, want: ErrInvalidPayload},
+		{name: "zero occurredAt", id: "evt_1", eventType: "payment_intent.succeeded", payload: `{}`, want: ErrInvalidOccurredAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewReceived(tt.id, tt.eventType, []byte(tt.payload), tt.occurredAt, testNow)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewReceived err = %v, want %v", err, tt.want)
+			}
+			if err == nil && (e.Status != StatusReceived || e.Status.IsDone() || e.Attempts != 0) {
+				t.Fatalf("NewReceived = %+v, want received event without attempts", e)
+			}
+		})
+	}
+}
+
+func TestEvent_Outcomes(t *testing.T) {
+	later := testNow.Add(time.Minute)
+
+	tests := []struct {
+		name         string
+		apply        func(e *Event) error
+		wantStatus   Status
+		wantDone     bool
+		wantAttempts int
+		wantError    string
+		wantErr      error
+	}{
+		{
+			name:         "processed",
+			apply:        func(e *Event) error { e.MarkProcessed(later); return nil },
+			wantStatus:   StatusProcessed,
+			wantDone:     true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "ignored",
+			apply:        func(e *Event) error { e.MarkIgnored(later); return nil },
+			wantStatus:   StatusIgnored,
+			wantDone:     true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "failed",
+			apply:        func(e *Event) error { return e.MarkFailed(" order not found ", later) },
+			wantStatus:   StatusFailed,
+			wantAttempts: 1,
+			wantError:    "order not found",
+		},
+		{
+			name:       "failed without error",
+			apply:      func(e *Event) error { return e.MarkFailed(" ", later) },
+			wantStatus: StatusReceived,
+			wantErr:    ErrErrorRequired,
+		},
+		{
+			// 失敗後の再送で処理できたら、前回のエラーは消す。
+			name: "failed then processed",
+			apply: func(e *Event) error {
+				if err := e.MarkFailed("order not found", later); err != nil {
+					return err
+				}
+				e.MarkProcessed(later)
+				return nil
+			},
+			wantStatus:   StatusProcessed,
+			wantDone:     true,
+			wantAttempts: 2,
+		},
+		{
+			name: "replayed",
+			apply: func(e *Event) error {
+				e.MarkReplayed("member_1", later)
+				e.MarkProcessed(later)
+				return nil
+			},
+			wantStatus:   StatusProcessed,
+			wantDone:     true,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewReceived("evt_1", "charge.dispute.created", []byte(`{}`), testNow, testNow)
+			if err != nil {
+				t.Fatalf("NewReceived: %v", err)
+			}
+
+			if err := tt.apply(&e); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if e.Status != tt.wantStatus || e.Status.IsDone() != tt.wantDone {
+				t.Errorf("Status = %s (IsDone %v), want %s", e.Status, e.Status.IsDone(), tt.wantStatus)
+			}
+			if e.Attempts != tt.wantAttempts {
+				t.Errorf("Attempts = %d, want %d", e.Attempts, tt.wantAttempts)
+			}
+			if e.LastError != tt.wantError {
+				t.Errorf("LastError = %q, want %q", e.LastError, tt.wantError)
+			}
+			if tt.wantDone && (e.ProcessedAt == nil || !e.ProcessedAt.Equal(later)) {
+				t.Errorf("ProcessedAt = %v, want %v", e.ProcessedAt, later)
+			}
+			if err := e.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// backend/internal/domain/stripeEvent/repository_port.go
+package stripeEvent
+
+import "context"
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// ListFilter は一覧の絞り込み条件です。空の条件は絞り込みません。
+type ListFilter struct {
+	Type   string
+	Status Status
+	Limit  int
+}
+
+// RepositoryPort は Stripe webhook イベントの永続化ポートです。
+type RepositoryPort interface {
+	GetByID(ctx context.Context, id string) (Event, error)
+
+	// Record は e.ID のイベントが無ければ作成します。
+	// 既に存在する場合は保存済みのイベントを created=false で返します。
+	Record(ctx context.Context, e Event) (stored Event, created bool, err error)
+
+	// Save は処理結果を保存します。Payload は変更しません。
+	Save(ctx context.Context, e Event) error
+
+	// List は受信の新しい順に返します。
+	List(ctx context.Context, filter ListFilter) ([]Event, error)
+}
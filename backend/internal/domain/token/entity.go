@@ -36,6 +36,9 @@ type MintParams struct {
 	// トークン名 / シンボル（TokenBlueprint 由来）
 	Name   string
 	Symbol string
+
+	// ロイヤリティ料率（TokenBlueprint.Royalty 由来, 1 = 0.01%）
+	SellerFeeBasisPoints int
 }
 
 // AssetStandard は、on-chain asset の方式を表します。
@@ -99,6 +102,11 @@ type GetTokenByProductIDResult struct {
 	TreeAddress           string
 	LeafIndex             uint64
 	CoreCollectionAddress string
+
+	// ToAddress は現在の保有者ウォレット（transfer 成功時に更新される）です。
+	ToAddress          string
+	MintedAt           time.Time
+	OnChainTxSignature string
 }
 
 // ============================================================
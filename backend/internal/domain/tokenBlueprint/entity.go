@@ -210,6 +210,9 @@ type TokenBlueprint struct {
 	UpdatedBy    string        `json:"updatedBy"`
 
 	MetadataURI string `json:"metadataUri,omitempty"`
+
+	// Royalty は二次流通時のブランドへのロイヤリティ（royalty.go）。
+	Royalty RoyaltyPolicy `json:"royalty"`
 }
 
 // Errors
@@ -275,6 +278,10 @@ func (t TokenBlueprint) validate() error {
 		return err
 	}
 
+	if err := t.Royalty.Validate(); err != nil {
+		return err
+	}
+
 	if t.CreatedAt.IsZero() {
 		return ErrInvalidCreatedAt
 	}
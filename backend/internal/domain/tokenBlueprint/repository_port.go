@@ -26,6 +26,7 @@ import (
 // - contentFiles[].name / contentFiles[].size は表示・差し替え・監査用に保存する
 // - minted は create 時は常に false
 // - metadataUri は任意
+// - royalty は省略時ロイヤリティなし
 type CreateTokenBlueprintInput struct {
 	Name        string `json:"name"`
 	Symbol      string `json:"symbol"`
@@ -49,6 +50,8 @@ type CreateTokenBlueprintInput struct {
 	UpdatedBy string     `json:"updatedBy"`
 
 	MetadataURI string `json:"metadataUri,omitempty"`
+
+	Royalty RoyaltyPolicy `json:"royalty"`
 }
 
 // ===============================
@@ -89,6 +92,9 @@ type UpdateTokenBlueprintInput struct {
 	DeletedBy *string    `json:"deletedBy,omitempty"`
 
 	MetadataURI *string `json:"metadataUri,omitempty"`
+
+	// Royalty は mint 前のみ変更できる（TokenBlueprint.SetRoyalty）。
+	Royalty *RoyaltyPolicy `json:"royalty,omitempty"`
 }
 
 // ===============================
@@ -117,6 +123,8 @@ type Patch struct {
 	IconFileName    string `json:"iconFileName,omitempty"`
 	IconContentType string `json:"iconContentType,omitempty"`
 	IconSize        int64  `json:"iconSize,omitempty"`
+
+	Royalty RoyaltyPolicy `json:"royalty"`
 }
 
 // NewPatchFromTokenBlueprint builds a display Patch from TokenBlueprint.
@@ -142,6 +150,8 @@ func NewPatchFromTokenBlueprint(tb *TokenBlueprint) Patch {
 		IconFileName:    tb.IconFileName,
 		IconContentType: tb.IconContentType,
 		IconSize:        tb.IconSize,
+
+		Royalty: tb.Royalty,
 	}
 }
 
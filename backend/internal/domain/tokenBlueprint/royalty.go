@@ -0,0 +1,73 @@
+// backend/internal/domain/tokenBlueprint/royalty.go
+package tokenBlueprint
+
+import "errors"
+
+// RoyaltyPolicy は二次流通（resale）時にブランドへ支払うロイヤリティの設定です。
+//
+// - BasisPoints は販売価格に対する料率（1 = 0.01%）。metadata の seller_fee_basis_points にも使う。
+// - MinimumAmount は 1 件あたりの最低額（円）。料率で計算した額が下回る場合に適用する。
+// - どちらも 0 の場合はロイヤリティなし。
+//
+// 販売価格は税抜で、ロイヤリティは販売価格を超えない。
+type RoyaltyPolicy struct {
+	BasisPoints   int `json:"basisPoints"`
+	MinimumAmount int `json:"minimumAmount"`
+}
+
+const (
+	// MaxRoyaltyBasisPoints は料率の上限（50%）。
+	MaxRoyaltyBasisPoints = 5000
+
+	basisPointsDenominator = 10000
+)
+
+var ErrInvalidRoyalty = errors.New("tokenBlueprint: invalid royalty")
+
+func (p RoyaltyPolicy) Validate() error {
+	if p.BasisPoints < 0 || p.BasisPoints > MaxRoyaltyBasisPoints {
+		return ErrInvalidRoyalty
+	}
+	if p.MinimumAmount < 0 {
+		return ErrInvalidRoyalty
+	}
+	return nil
+}
+
+// IsZero はロイヤリティが設定されていないかを返します。
+func (p RoyaltyPolicy) IsZero() bool {
+	return p.BasisPoints == 0 && p.MinimumAmount == 0
+}
+
+// Calculate は販売価格 saleAmount に対するロイヤリティ額を返します。
+// 料率分は 1 円未満を切り捨て、最低額を下回る場合は最低額、
+// 販売価格を超える場合は販売価格にします。
+func (p RoyaltyPolicy) Calculate(saleAmount int) int {
+	if saleAmount <= 0 || p.IsZero() {
+		return 0
+	}
+
+	amount := saleAmount * p.BasisPoints / basisPointsDenominator
+	if amount < p.MinimumAmount {
+		amount = p.MinimumAmount
+	}
+	if amount > saleAmount {
+		amount = saleAmount
+	}
+
+	return amount
+}
+
+// SetRoyalty はロイヤリティ設定を変更します。
+// mint 後は配布済みの metadata と食い違うため変更できません。
+func (t *TokenBlueprint) SetRoyalty(p RoyaltyPolicy) error {
+	if err := t.ensureMutableCoreOrDeletable(); err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	t.Royalty = p
+	return nil
+}
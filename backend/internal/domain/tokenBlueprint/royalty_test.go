@@ -0,0 +1,85 @@
+// backend/internal/domain/tokenBlueprint/royalty_test.go
+package tokenBlueprint
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRoyaltyPolicy_Calculate(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     RoyaltyPolicy
+		saleAmount int
+		want       int
+	}{
+		{name: "no royalty", policy: RoyaltyPolicy{}, saleAmount: 10000, want: 0},
+		{name: "basis points", policy: RoyaltyPolicy{BasisPoints: 500}, saleAmount: 10000, want: 500},
+		{name: "truncates below one yen", policy: RoyaltyPolicy{BasisPoints: 250}, saleAmount: 999, want: 24},
+		{name: "minimum applies", policy: RoyaltyPolicy{BasisPoints: 100, MinimumAmount: 300}, saleAmount: 10000, want: 300},
+		{name: "rate above minimum", policy: RoyaltyPolicy{BasisPoints: 1000, MinimumAmount: 300}, saleAmount: 10000, want: 1000},
+		{name: "minimum only", policy: RoyaltyPolicy{MinimumAmount: 200}, saleAmount: 5000, want: 200},
+		{name: "capped at sale amount", policy: RoyaltyPolicy{MinimumAmount: 500}, saleAmount: 300, want: 300},
+		{name: "max rate", policy: RoyaltyPolicy{BasisPoints: MaxRoyaltyBasisPoints}, saleAmount: 10001, want: 5000},
+		{name: "zero sale", policy: RoyaltyPolicy{BasisPoints: 500, MinimumAmount: 100}, saleAmount: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Calculate(tt.saleAmount); got != tt.want {
+				t.Fatalf("Calculate(%d) = %d, want %d", tt.saleAmount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoyaltyPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RoyaltyPolicy
+		want   error
+	}{
+		{name: "zero", policy: RoyaltyPolicy{}},
+		{name: "max rate", policy: RoyaltyPolicy{BasisPoints: MaxRoyaltyBasisPoints, MinimumAmount: 100}},
+		{name: "over max rate", policy: RoyaltyPolicy{BasisPoints: MaxRoyaltyBasisPoints + 1}, want: ErrInvalidRoyalty},
+		{name: "negative rate", policy: RoyaltyPolicy{BasisPoints: -1}, want: ErrInvalidRoyalty},
+		{name: "negative minimum", policy: RoyaltyPolicy{MinimumAmount: -1}, want: ErrInvalidRoyalty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); !errors.Is(err, tt.want) {
+				t.Fatalf("Validate err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenBlueprint_SetRoyalty(t *testing.T) {
+	tests := []struct {
+		name   string
+		minted bool
+		policy RoyaltyPolicy
+		want   error
+	}{
+		{name: "before mint", policy: RoyaltyPolicy{BasisPoints: 500}},
+		{name: "after mint", minted: true, policy: RoyaltyPolicy{BasisPoints: 500}, want: ErrAlreadyMinted},
+		{name: "invalid policy", policy: RoyaltyPolicy{BasisPoints: -1}, want: ErrInvalidRoyalty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &TokenBlueprint{Minted: tt.minted}
+
+			if err := tb.SetRoyalty(tt.policy); !errors.Is(err, tt.want) {
+				t.Fatalf("SetRoyalty err = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && tb.Royalty != tt.policy {
+				t.Fatalf("Royalty = %+v, want %+v", tb.Royalty, tt.policy)
+			}
+			if tt.want != nil && !tb.Royalty.IsZero() {
+				t.Fatalf("Royalty = %+v, want unchanged", tb.Royalty)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// backend/internal/domain/transportation/international_rate.go
+package transportation
+
+import "errors"
+
+const (
+	InternationalRateVersion = "public-reference-2026-10-01"
+
+	emsMaxWeightGrams      = 30000
+	emsMaxLengthMM         = 1500
+	emsMaxLengthAndGirthMM = 3000
+	ePacketMaxWeightGrams  = 2000
+	ePacketMaxLengthMM     = 600
+	ePacketMaxTotalSizeMM  = 900
+)
+
+var (
+	ErrInternationalPackageTooLarge = errors.New(
+		"transportation: international package too large",
+	)
+
+	ErrInternationalPackageTooHeavy = errors.New(
+		"transportation: international package too heavy",
+	)
+
+	ErrInternationalRateNotFound = errors.New(
+		"transportation: international rate not found",
+	)
+)
+
+// internationalWeightRate は重量帯（上限 g）ごとの地帯別料金。
+type internationalWeightRate struct {
+	MaxWeightGrams int
+	Amounts        map[InternationalZone]int64
+}
+
+// EMS 料金表（第1〜第5地帯）。
+var emsRates = []internationalWeightRate{
+	{MaxWeightGrams: 500, Amounts: emsZoneAmounts(1450, 1900, 3150, 3900, 3600)},
+	{MaxWeightGrams: 600, Amounts: emsZoneAmounts(1600, 2150, 3400, 4180, 3900)},
+	{MaxWeightGrams: 700, Amounts: emsZoneAmounts(1750, 2400, 3650, 4460, 4200)},
+	{MaxWeightGrams: 800, Amounts: emsZoneAmounts(1900, 2650, 3900, 4740, 4500)},
+	{MaxWeightGrams: 900, Amounts: emsZoneAmounts(2050, 2900, 4150, 5020, 4800)},
+	{MaxWeightGrams: 1000, Amounts: emsZoneAmounts(2200, 3150, 4400, 5300, 5100)},
+	{MaxWeightGrams: 1250, Amounts: emsZoneAmounts(2500, 3500, 5000, 5990, 5850)},
+	{MaxWeightGrams: 1500, Amounts: emsZoneAmounts(2800, 3850, 5550, 6600, 6600)},
+	{MaxWeightGrams: 1750, Amounts: emsZoneAmounts(3100, 4200, 6150, 7290, 7350)},
+	{MaxWeightGrams: 2000, Amounts: emsZoneAmounts(3400, 4550, 6700, 7900, 8100)},
+	{MaxWeightGrams: 2500, Amounts: emsZoneAmounts(3900, 5150, 7750, 9100, 9600)},
+	{MaxWeightGrams: 3000, Amounts: emsZoneAmounts(4400, 5750, 8800, 10300, 11100)},
+	{MaxWeightGrams: 3500, Amounts: emsZoneAmounts(4900, 6350, 9850, 11500, 12600)},
+	{MaxWeightGrams: 4000, Amounts: emsZoneAmounts(5400, 6950, 10900, 12700, 14100)},
+	{MaxWeightGrams: 4500, Amounts: emsZoneAmounts(5900, 7550, 11950, 13900, 15600)},
+	{MaxWeightGrams: 5000, Amounts: emsZoneAmounts(6400, 8150, 13000, 15100, 17100)},
+	{MaxWeightGrams: 5500, Amounts: emsZoneAmounts(6900, 8750, 14050, 16300, 18600)},
+	{MaxWeightGrams: 6000, Amounts: emsZoneAmounts(7400, 9350, 15100, 17500, 20100)},
+	{MaxWeightGrams: 7000, Amounts: emsZoneAmounts(8200, 10350, 16900, 19400, 22500)},
+	{MaxWeightGrams: 8000, Amounts: emsZoneAmounts(9000, 11350, 18700, 21300, 24900)},
+	{MaxWeightGrams: 9000, Amounts: emsZoneAmounts(9800, 12350, 20500, 23200, 27300)},
+	{MaxWeightGrams: 10000, Amounts: emsZoneAmounts(10600, 13350, 22300, 25100, 29700)},
+	{MaxWeightGrams: 12000, Amounts: emsZoneAmounts(12200, 15350, 25900, 28900, 34500)},
+	{MaxWeightGrams: 14000, Amounts: emsZoneAmounts(13800, 17350, 29500, 32700, 39300)},
+	{MaxWeightGrams: 16000, Amounts: emsZoneAmounts(15400, 19350, 33100, 36500, 44100)},
+	{MaxWeightGrams: 18000, Amounts: emsZoneAmounts(17000, 21350, 36700, 40300, 48900)},
+	{MaxWeightGrams: 20000, Amounts: emsZoneAmounts(18600, 23350, 40300, 44100, 53700)},
+	{MaxWeightGrams: 25000, Amounts: emsZoneAmounts(22600, 28350, 49300, 53600, 65700)},
+	{MaxWeightGrams: 30000, Amounts: emsZoneAmounts(26600, 33350, 58300, 63100, 77700)},
+}
+
+// 国際eパケット料金表。
+// 地帯は EMS の地帯を 3 区分（アジア / 北米・オセアニア・中近東・欧州 / 中南米・アフリカ）へ集約する。
+var ePacketRates = []internationalWeightRate{
+	{MaxWeightGrams: 250, Amounts: ePacketZoneAmounts(880, 1140, 1300)},
+	{MaxWeightGrams: 500, Amounts: ePacketZoneAmounts(1205, 1790, 2100)},
+	{MaxWeightGrams: 750, Amounts: ePacketZoneAmounts(1530, 2440, 2900)},
+	{MaxWeightGrams: 1000, Amounts: ePacketZoneAmounts(1855, 3090, 3700)},
+	{MaxWeightGrams: 1250, Amounts: ePacketZoneAmounts(2180, 3740, 4500)},
+	{MaxWeightGrams: 1500, Amounts: ePacketZoneAmounts(2505, 4390, 5300)},
+	{MaxWeightGrams: 1750, Amounts: ePacketZoneAmounts(2830, 5040, 6100)},
+	{MaxWeightGrams: 2000, Amounts: ePacketZoneAmounts(3155, 5690, 6900)},
+}
+
+func emsZoneAmounts(
+	zone1 int64,
+	zone2 int64,
+	zone3 int64,
+	zone4 int64,
+	zone5 int64,
+) map[InternationalZone]int64 {
+	return map[InternationalZone]int64{
+		InternationalZone1: zone1,
+		InternationalZone2: zone2,
+		InternationalZone3: zone3,
+		InternationalZone4: zone4,
+		InternationalZone5: zone5,
+	}
+}
+
+func ePacketZoneAmounts(
+	asia int64,
+	northAmericaEurope int64,
+	southAmericaAfrica int64,
+) map[InternationalZone]int64 {
+	return map[InternationalZone]int64{
+		InternationalZone1: asia,
+		InternationalZone2: asia,
+		InternationalZone3: northAmericaEurope,
+		InternationalZone4: northAmericaEurope,
+		InternationalZone5: southAmericaAfrica,
+	}
+}
+
+// internationalRateCalculator は地帯 × 重量帯の料金表で国際便の料金を算出する。
+//
+// 国際便の Quote.Size は重量帯の上限（g）を表す。
+type internationalRateCalculator struct {
+	carrier Carrier
+	rates   []internationalWeightRate
+	fits    func(pkg Package) error
+}
+
+func newEMSRateCalculator() carrierCalculator {
+	return &internationalRateCalculator{
+		carrier: CarrierEMS,
+		rates:   emsRates,
+		fits:    validateEMSPackage,
+	}
+}
+
+func newEPacketRateCalculator() carrierCalculator {
+	return &internationalRateCalculator{
+		carrier: CarrierEPacket,
+		rates:   ePacketRates,
+		fits:    validateEPacketPackage,
+	}
+}
+
+func (c *internationalRateCalculator) Calculate(
+	input CarrierRateInput,
+) (Quote, error) {
+	if err := input.Package.Validate(); err != nil {
+		return Quote{}, err
+	}
+
+	if err := c.fits(input.Package); err != nil {
+		return Quote{}, err
+	}
+
+	zone, err := InternationalZoneByCountry(
+		input.DestinationCountry,
+	)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	for _, rate := range c.rates {
+		if input.Package.WeightGrams > rate.MaxWeightGrams {
+			continue
+		}
+
+		amount, ok := rate.Amounts[zone]
+		if !ok {
+			return Quote{}, ErrInternationalRateNotFound
+		}
+
+		return Quote{
+			Carrier: c.carrier,
+			Size:    rate.MaxWeightGrams,
+			Amount:  amount,
+		}, nil
+	}
+
+	return Quote{}, ErrInternationalPackageTooHeavy
+}
+
+func validateEMSPackage(pkg Package) error {
+	if pkg.WeightGrams > emsMaxWeightGrams {
+		return ErrInternationalPackageTooHeavy
+	}
+
+	longest, girth := packageLengthAndGirth(pkg)
+
+	if longest > emsMaxLengthMM ||
+		longest+girth > emsMaxLengthAndGirthMM {
+		return ErrInternationalPackageTooLarge
+	}
+
+	return nil
+}
+
+func validateEPacketPackage(pkg Package) error {
+	if pkg.WeightGrams > ePacketMaxWeightGrams {
+		return ErrInternationalPackageTooHeavy
+	}
+
+	longest, _ := packageLengthAndGirth(pkg)
+
+	if longest > ePacketMaxLengthMM ||
+		pkg.TotalSizeMM() > ePacketMaxTotalSizeMM {
+		return ErrInternationalPackageTooLarge
+	}
+
+	return nil
+}
+
+// packageLengthAndGirth は最長辺と胴回り（残り 2 辺の和 × 2）を返す。
+func packageLengthAndGirth(pkg Package) (int, int) {
+	longest := max(pkg.WidthMM, pkg.LengthMM, pkg.HeightMM)
+	girth := (pkg.TotalSizeMM() - longest) * 2
+	return longest, girth
+}
+
+// ResolveInternationalCarrier は国外宛ての配送に使う国際便を決める。
+//
+//   - ems / epacket が指定されていればそのまま使う。
+//   - 国内キャリア（yamato / sagawa / post）は日本郵便の国際便へ振り替え、
+//     国際eパケットの制限内なら epacket、超える場合は ems とする。
+//   - custom（会社独自料金）は都道府県単位の設定しか持たないため国外へは使えない。
+func ResolveInternationalCarrier(
+	carrier Carrier,
+	pkg Package,
+) (Carrier, error) {
+	switch carrier {
+	case CarrierEMS, CarrierEPacket:
+		return carrier, nil
+
+	case CarrierYamato, CarrierSagawa, CarrierPost:
+		if validateEPacketPackage(pkg) == nil {
+			return CarrierEPacket, nil
+		}
+		return CarrierEMS, nil
+
+	case CarrierCustom:
+		return "", ErrUnsupportedCountry
+
+	default:
+		return "", ErrInvalidCarrier
+	}
+}
@@ -0,0 +1,307 @@
+// backend/internal/domain/transportation/international_rate_test.go
+package transportation
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var testOrigin = Address{
+	Country: DomesticCountryCode,
+	ZipCode: "100-0001",
+	State:   "東京都",
+	City:    "千代田区",
+}
+
+func testDestination(country string, zipCode string) Address {
+	return Address{
+		Country: country,
+		ZipCode: zipCode,
+		City:    "city",
+	}
+}
+
+// smallPackage は国際eパケットの寸法制限内に収まる箱です。
+func smallPackage(weightGrams int) Package {
+	return Package{
+		WeightGrams: weightGrams,
+		WidthMM:     200,
+		LengthMM:    300,
+		HeightMM:    100,
+	}
+}
+
+func TestService_CalculateInternational(t *testing.T) {
+	tests := []struct {
+		name        string
+		carrier     Carrier
+		pkg         Package
+		destination Address
+		want        Quote
+	}{
+		{
+			name:        "ems zone 1 lowest band",
+			carrier:     CarrierEMS,
+			pkg:         smallPackage(500),
+			destination: testDestination("CN", "100000"),
+			want:        Quote{Carrier: CarrierEMS, Size: 500, Amount: 1450},
+		},
+		{
+			name:        "ems zone 3 heaviest band",
+			carrier:     CarrierEMS,
+			pkg:         smallPackage(30000),
+			destination: testDestination("GB", "SW1A 1AA"),
+			want:        Quote{Carrier: CarrierEMS, Size: 30000, Amount: 58300},
+		},
+		{
+			name:        "ems weight just over a band boundary",
+			carrier:     CarrierEMS,
+			pkg:         smallPackage(1001),
+			destination: testDestination("US", "10001"),
+			want:        Quote{Carrier: CarrierEMS, Size: 1250, Amount: 5990},
+		},
+		{
+			name:        "epacket zone 2 maps to asia",
+			carrier:     CarrierEPacket,
+			pkg:         smallPackage(251),
+			destination: testDestination("HK", ""),
+			want:        Quote{Carrier: CarrierEPacket, Size: 500, Amount: 1205},
+		},
+		{
+			name:        "epacket zone 5 maps to south america and africa",
+			carrier:     CarrierEPacket,
+			pkg:         smallPackage(250),
+			destination: testDestination("BR", "01310-100"),
+			want:        Quote{Carrier: CarrierEPacket, Size: 250, Amount: 1300},
+		},
+		{
+			name:        "domestic carrier within epacket limits uses epacket",
+			carrier:     CarrierYamato,
+			pkg:         smallPackage(2000),
+			destination: testDestination("US", "10001-1234"),
+			want:        Quote{Carrier: CarrierEPacket, Size: 2000, Amount: 5690},
+		},
+		{
+			name:        "domestic carrier over epacket weight uses ems",
+			carrier:     CarrierSagawa,
+			pkg:         smallPackage(2001),
+			destination: testDestination("US", "10001"),
+			want:        Quote{Carrier: CarrierEMS, Size: 2500, Amount: 9100},
+		},
+		{
+			name:    "domestic carrier over epacket length uses ems",
+			carrier: CarrierPost,
+			pkg: Package{
+				WeightGrams: 1000,
+				WidthMM:     601,
+				LengthMM:    100,
+				HeightMM:    100,
+			},
+			destination: testDestination("KR", "03187"),
+			want:        Quote{Carrier: CarrierEMS, Size: 1000, Amount: 2200},
+		},
+		{
+			name:    "domestic carrier over epacket total size uses ems",
+			carrier: CarrierYamato,
+			pkg: Package{
+				WeightGrams: 1000,
+				WidthMM:     400,
+				LengthMM:    400,
+				HeightMM:    101,
+			},
+			destination: testDestination("AU", "2000"),
+			want:        Quote{Carrier: CarrierEMS, Size: 1000, Amount: 4400},
+		},
+	}
+
+	service := NewService(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.Calculate(context.Background(), CalculateInput{
+				Carrier:     tt.carrier,
+				Package:     tt.pkg,
+				Origin:      testOrigin,
+				Destination: tt.destination,
+			})
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Calculate = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_CalculateInternational_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		carrier     Carrier
+		pkg         Package
+		destination Address
+		want        error
+	}{
+		{
+			name:        "ems over max weight",
+			carrier:     CarrierEMS,
+			pkg:         smallPackage(30001),
+			destination: testDestination("US", "10001"),
+			want:        ErrInternationalPackageTooHeavy,
+		},
+		{
+			name:    "ems over max length",
+			carrier: CarrierEMS,
+			pkg: Package{
+				WeightGrams: 1000,
+				WidthMM:     1501,
+				LengthMM:    100,
+				HeightMM:    100,
+			},
+			destination: testDestination("US", "10001"),
+			want:        ErrInternationalPackageTooLarge,
+		},
+		{
+			// 最長辺 1000 + 胴回り (600 + 600) * 2 = 3400
+			name:    "ems over length and girth",
+			carrier: CarrierEMS,
+			pkg: Package{
+				WeightGrams: 1000,
+				WidthMM:     1000,
+				LengthMM:    600,
+				HeightMM:    600,
+			},
+			destination: testDestination("US", "10001"),
+			want:        ErrInternationalPackageTooLarge,
+		},
+		{
+			name:        "explicit epacket over max weight",
+			carrier:     CarrierEPacket,
+			pkg:         smallPackage(2001),
+			destination: testDestination("US", "10001"),
+			want:        ErrInternationalPackageTooHeavy,
+		},
+		{
+			name:        "custom carrier cannot ship abroad",
+			carrier:     CarrierCustom,
+			pkg:         smallPackage(500),
+			destination: testDestination("US", "10001"),
+			want:        ErrUnsupportedCountry,
+		},
+		{
+			name:        "unsupported country",
+			carrier:     CarrierEMS,
+			pkg:         smallPackage(500),
+			destination: testDestination("XX", "10001"),
+			want:        ErrUnsupportedCountry,
+		},
+		{
+			name:        "invalid postal code",
+			carrier:     CarrierEMS,
+			pkg:         smallPackage(500),
+			destination: testDestination("US", "1000"),
+			want:        ErrInvalidPostalCode,
+		},
+		{
+			name:    "international carrier for a domestic destination",
+			carrier: CarrierEMS,
+			pkg:     smallPackage(500),
+			destination: Address{
+				Country: DomesticCountryCode,
+				ZipCode: "530-0001",
+				State:   "大阪府",
+				City:    "大阪市",
+			},
+			want: ErrInvalidCarrier,
+		},
+	}
+
+	service := NewService(nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Calculate(context.Background(), CalculateInput{
+				Carrier:     tt.carrier,
+				Package:     tt.pkg,
+				Origin:      testOrigin,
+				Destination: tt.destination,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Calculate err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePostalCode(t *testing.T) {
+	tests := []struct {
+		country string
+		zipCode string
+		want    error
+	}{
+		{country: "JP", zipCode: "100-0001", want: nil},
+		{country: "JP", zipCode: "1000001", want: nil},
+		{country: "JP", zipCode: "100-001", want: ErrInvalidPostalCode},
+		{country: "US", zipCode: "10001-1234", want: nil},
+		{country: "US", zipCode: "1000", want: ErrInvalidPostalCode},
+		{country: "GU", zipCode: "96910", want: nil},
+		{country: "GU", zipCode: "10001", want: ErrInvalidPostalCode},
+		{country: "CA", zipCode: "k1a 0b1", want: nil},
+		{country: "GB", zipCode: "SW1A 1AA", want: nil},
+		{country: "NL", zipCode: "1012AB", want: nil},
+		{country: "TW", zipCode: "10048", want: nil},
+		{country: "TW", zipCode: "1004", want: ErrInvalidPostalCode},
+		{country: "HK", zipCode: "", want: nil},
+		{country: "AE", zipCode: "anything", want: nil},
+		{country: "XX", zipCode: "10001", want: ErrUnsupportedCountry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+"/"+tt.zipCode, func(t *testing.T) {
+			if err := ValidatePostalCode(tt.country, tt.zipCode); !errors.Is(err, tt.want) {
+				t.Fatalf("ValidatePostalCode err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestInternationalRates_Monotonic は料金表の重量帯が昇順で、
+// 同じ地帯の料金が重量とともに下がらないことを確認します。
+func TestInternationalRates_Monotonic(t *testing.T) {
+	tables := map[string][]internationalWeightRate{
+		"ems":     emsRates,
+		"epacket": ePacketRates,
+	}
+
+	zones := []InternationalZone{
+		InternationalZone1,
+		InternationalZone2,
+		InternationalZone3,
+		InternationalZone4,
+		InternationalZone5,
+	}
+
+	for name, rates := range tables {
+		for i := 1; i < len(rates); i++ {
+			prev, cur := rates[i-1], rates[i]
+
+			if cur.MaxWeightGrams <= prev.MaxWeightGrams {
+				t.Errorf("%s: band %d weight %d <= %d", name, i, cur.MaxWeightGrams, prev.MaxWeightGrams)
+			}
+
+			for _, zone := range zones {
+				if cur.Amounts[zone] < prev.Amounts[zone] {
+					t.Errorf("%s: band %d zone %d amount %d < %d", name, i, zone, cur.Amounts[zone], prev.Amounts[zone])
+				}
+			}
+		}
+	}
+
+	if last := emsRates[len(emsRates)-1].MaxWeightGrams; last != emsMaxWeightGrams {
+		t.Errorf("ems: last band %d, want %d", last, emsMaxWeightGrams)
+	}
+	if last := ePacketRates[len(ePacketRates)-1].MaxWeightGrams; last != ePacketMaxWeightGrams {
+		t.Errorf("epacket: last band %d, want %d", last, ePacketMaxWeightGrams)
+	}
+}
@@ -0,0 +1,171 @@
+// backend/internal/domain/transportation/international_zone_master.go
+package transportation
+
+import (
+	"regexp"
+	"strings"
+)
+
+// InternationalZone は国際郵便（EMS）の地帯区分。
+type InternationalZone uint8
+
+const (
+	// 第1地帯: 中国・韓国・台湾
+	InternationalZone1 InternationalZone = iota + 1
+	// 第2地帯: アジア（第1地帯を除く）
+	InternationalZone2
+	// 第3地帯: オセアニア・カナダ・メキシコ・中近東・ヨーロッパ
+	InternationalZone3
+	// 第4地帯: 米国（グアム等の海外領土を含む）
+	InternationalZone4
+	// 第5地帯: 中南米（メキシコを除く）・アフリカ
+	InternationalZone5
+)
+
+// CountryDefinition は配送可能な国と地帯、郵便番号形式の定義。
+//
+// PostalCodePattern が空の国は郵便番号制度がないため、ZipCode の形式を検証しない。
+type CountryDefinition struct {
+	CountryCode       string            `json:"countryCode"`
+	Zone              InternationalZone `json:"zone"`
+	DisplayName       string            `json:"displayName"`
+	PostalCodePattern string            `json:"postalCodePattern,omitempty"`
+}
+
+var countryDefinitions = []CountryDefinition{
+	// 第1地帯
+	{CountryCode: "CN", Zone: InternationalZone1, DisplayName: "中国", PostalCodePattern: `^[0-9]{6}$`},
+	{CountryCode: "KR", Zone: InternationalZone1, DisplayName: "韓国", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "TW", Zone: InternationalZone1, DisplayName: "台湾", PostalCodePattern: `^[0-9]{3}([0-9]{2,3})?$`},
+
+	// 第2地帯
+	{CountryCode: "HK", Zone: InternationalZone2, DisplayName: "香港"},
+	{CountryCode: "MO", Zone: InternationalZone2, DisplayName: "マカオ"},
+	{CountryCode: "SG", Zone: InternationalZone2, DisplayName: "シンガポール", PostalCodePattern: `^[0-9]{6}$`},
+	{CountryCode: "TH", Zone: InternationalZone2, DisplayName: "タイ", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "MY", Zone: InternationalZone2, DisplayName: "マレーシア", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "PH", Zone: InternationalZone2, DisplayName: "フィリピン", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "VN", Zone: InternationalZone2, DisplayName: "ベトナム", PostalCodePattern: `^[0-9]{6}$`},
+	{CountryCode: "ID", Zone: InternationalZone2, DisplayName: "インドネシア", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "IN", Zone: InternationalZone2, DisplayName: "インド", PostalCodePattern: `^[0-9]{6}$`},
+
+	// 第3地帯
+	{CountryCode: "AU", Zone: InternationalZone3, DisplayName: "オーストラリア", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "NZ", Zone: InternationalZone3, DisplayName: "ニュージーランド", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "CA", Zone: InternationalZone3, DisplayName: "カナダ", PostalCodePattern: `^[A-Z][0-9][A-Z] ?[0-9][A-Z][0-9]$`},
+	{CountryCode: "MX", Zone: InternationalZone3, DisplayName: "メキシコ", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "AE", Zone: InternationalZone3, DisplayName: "アラブ首長国連邦"},
+	{CountryCode: "IL", Zone: InternationalZone3, DisplayName: "イスラエル", PostalCodePattern: `^[0-9]{7}$`},
+	{CountryCode: "GB", Zone: InternationalZone3, DisplayName: "イギリス", PostalCodePattern: `^[A-Z]{1,2}[0-9][A-Z0-9]? ?[0-9][A-Z]{2}$`},
+	{CountryCode: "IE", Zone: InternationalZone3, DisplayName: "アイルランド", PostalCodePattern: `^[A-Z][0-9][0-9W] ?[0-9A-Z]{4}$`},
+	{CountryCode: "FR", Zone: InternationalZone3, DisplayName: "フランス", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "DE", Zone: InternationalZone3, DisplayName: "ドイツ", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "IT", Zone: InternationalZone3, DisplayName: "イタリア", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "ES", Zone: InternationalZone3, DisplayName: "スペイン", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "NL", Zone: InternationalZone3, DisplayName: "オランダ", PostalCodePattern: `^[0-9]{4} ?[A-Z]{2}$`},
+	{CountryCode: "BE", Zone: InternationalZone3, DisplayName: "ベルギー", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "CH", Zone: InternationalZone3, DisplayName: "スイス", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "AT", Zone: InternationalZone3, DisplayName: "オーストリア", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "SE", Zone: InternationalZone3, DisplayName: "スウェーデン", PostalCodePattern: `^[0-9]{3} ?[0-9]{2}$`},
+	{CountryCode: "DK", Zone: InternationalZone3, DisplayName: "デンマーク", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "NO", Zone: InternationalZone3, DisplayName: "ノルウェー", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "FI", Zone: InternationalZone3, DisplayName: "フィンランド", PostalCodePattern: `^[0-9]{5}$`},
+
+	// 第4地帯
+	{CountryCode: "US", Zone: InternationalZone4, DisplayName: "アメリカ合衆国", PostalCodePattern: `^[0-9]{5}(-[0-9]{4})?$`},
+	{CountryCode: "GU", Zone: InternationalZone4, DisplayName: "グアム", PostalCodePattern: `^969[0-9]{2}(-[0-9]{4})?$`},
+
+	// 第5地帯
+	{CountryCode: "BR", Zone: InternationalZone5, DisplayName: "ブラジル", PostalCodePattern: `^[0-9]{5}-?[0-9]{3}$`},
+	{CountryCode: "AR", Zone: InternationalZone5, DisplayName: "アルゼンチン", PostalCodePattern: `^([A-Z][0-9]{4}[A-Z]{3}|[0-9]{4})$`},
+	{CountryCode: "CL", Zone: InternationalZone5, DisplayName: "チリ", PostalCodePattern: `^[0-9]{7}$`},
+	{CountryCode: "PE", Zone: InternationalZone5, DisplayName: "ペルー", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "ZA", Zone: InternationalZone5, DisplayName: "南アフリカ", PostalCodePattern: `^[0-9]{4}$`},
+	{CountryCode: "EG", Zone: InternationalZone5, DisplayName: "エジプト", PostalCodePattern: `^[0-9]{5}$`},
+	{CountryCode: "KE", Zone: InternationalZone5, DisplayName: "ケニア", PostalCodePattern: `^[0-9]{5}$`},
+}
+
+var countryDefinitionByCode = func() map[string]CountryDefinition {
+	result := make(map[string]CountryDefinition, len(countryDefinitions))
+
+	for _, definition := range countryDefinitions {
+		result[definition.CountryCode] = definition
+	}
+
+	return result
+}()
+
+var postalCodePatternByCountry = func() map[string]*regexp.Regexp {
+	result := make(map[string]*regexp.Regexp, len(countryDefinitions)+1)
+
+	result[DomesticCountryCode] = regexp.MustCompile(`^[0-9]{3}-?[0-9]{4}$`)
+
+	for _, definition := range countryDefinitions {
+		if definition.PostalCodePattern == "" {
+			continue
+		}
+
+		result[definition.CountryCode] =
+			regexp.MustCompile(definition.PostalCodePattern)
+	}
+
+	return result
+}()
+
+func CountryDefinitions() []CountryDefinition {
+	result := make([]CountryDefinition, len(countryDefinitions))
+	copy(result, countryDefinitions)
+	return result
+}
+
+func CountryDefinitionByCode(code string) (CountryDefinition, error) {
+	definition, ok := countryDefinitionByCode[code]
+
+	if !ok {
+		return CountryDefinition{}, ErrUnsupportedCountry
+	}
+
+	return definition, nil
+}
+
+// IsSupportedCountry は国内（JP）または国際配送可能な国かを返す。
+func IsSupportedCountry(code string) bool {
+	if code == DomesticCountryCode {
+		return true
+	}
+
+	_, ok := countryDefinitionByCode[code]
+	return ok
+}
+
+func InternationalZoneByCountry(code string) (InternationalZone, error) {
+	definition, err := CountryDefinitionByCode(code)
+	if err != nil {
+		return 0, err
+	}
+
+	return definition.Zone, nil
+}
+
+// ValidatePostalCode は国ごとの郵便番号形式を検証する。
+//
+// 英字は大文字へ正規化してから照合する。
+// 郵便番号制度がない国は形式を検証しない。
+func ValidatePostalCode(country string, zipCode string) error {
+	if !IsSupportedCountry(country) {
+		return ErrUnsupportedCountry
+	}
+
+	pattern, ok := postalCodePatternByCountry[country]
+	if !ok {
+		return nil
+	}
+
+	if !pattern.MatchString(
+		strings.ToUpper(strings.TrimSpace(zipCode)),
+	) {
+		return ErrInvalidPostalCode
+	}
+
+	return nil
+}
@@ -14,6 +14,10 @@ const (
 	CarrierPost   Carrier = "post"
 	CarrierCustom Carrier = "custom"
 
+	// 日本郵便の国際便（国外宛て専用）
+	CarrierEMS     Carrier = "ems"
+	CarrierEPacket Carrier = "epacket"
+
 	DomesticCountryCode = "JP"
 )
 
@@ -34,6 +38,10 @@ var (
 		"transportation: unsupported country",
 	)
 
+	ErrInvalidPostalCode = errors.New(
+		"transportation: invalid postal code",
+	)
+
 	ErrCarrierRateNotConfigured = errors.New(
 		"transportation: carrier rate not configured",
 	)
@@ -76,28 +84,47 @@ type Address struct {
 	IslandCode string
 }
 
+// Validate は住所を検証する。
+//
+// 国内（JP）は都道府県・郵便番号・市区町村を必須とする。
+// 国外は country-zone master に登録された国だけを許可し、
+// State（州・省）は国によって存在しないため任意とする。
 func (a Address) Validate() error {
 	if a.Country == "" {
 		return ErrInvalidAddress
 	}
 
-	if a.Country != DomesticCountryCode {
+	if !IsSupportedCountry(a.Country) {
 		return ErrUnsupportedCountry
 	}
 
-	if a.State == "" {
+	if a.City == "" {
 		return ErrInvalidAddress
 	}
 
-	if a.ZipCode == "" {
+	if !a.IsDomestic() {
+		return ValidatePostalCode(
+			a.Country,
+			a.ZipCode,
+		)
+	}
+
+	if a.State == "" {
 		return ErrInvalidAddress
 	}
 
-	if a.City == "" {
+	if a.ZipCode == "" {
 		return ErrInvalidAddress
 	}
 
-	return nil
+	return ValidatePostalCode(
+		a.Country,
+		a.ZipCode,
+	)
+}
+
+func (a Address) IsDomestic() bool {
+	return a.Country == DomesticCountryCode
 }
 
 type CalculateInput struct {
@@ -120,6 +147,9 @@ type CarrierRateInput struct {
 	DestinationPrefectureCode PrefectureCode
 
 	DestinationIslandCode string
+
+	// DestinationCountry は国外宛ての場合だけ設定する。
+	DestinationCountry string
 }
 
 type Quote struct {
@@ -153,6 +183,10 @@ func NewService(
 			CarrierSagawa: newSagawaRateCalculator(),
 
 			CarrierPost: newPostRateCalculator(),
+
+			CarrierEMS: newEMSRateCalculator(),
+
+			CarrierEPacket: newEPacketRateCalculator(),
 		},
 	}
 }
@@ -164,7 +198,9 @@ func IsValidCarrier(
 	case CarrierYamato,
 		CarrierSagawa,
 		CarrierPost,
-		CarrierCustom:
+		CarrierCustom,
+		CarrierEMS,
+		CarrierEPacket:
 		return true
 	default:
 		return false
@@ -202,6 +238,12 @@ func (s *Service) Calculate(
 		return Quote{}, err
 	}
 
+	// 発送元は国内倉庫のみ対応する。
+	if !input.Origin.IsDomestic() {
+		return Quote{},
+			ErrUnsupportedCountry
+	}
+
 	originPrefectureCode, err :=
 		PrefectureCodeFromState(
 			input.Origin.State,
@@ -210,6 +252,19 @@ func (s *Service) Calculate(
 		return Quote{}, err
 	}
 
+	if !input.Destination.IsDomestic() {
+		return s.calculateInternational(
+			input,
+			originPrefectureCode,
+		)
+	}
+
+	if input.Carrier == CarrierEMS ||
+		input.Carrier == CarrierEPacket {
+		return Quote{},
+			ErrInvalidCarrier
+	}
+
 	destinationPrefectureCode, err :=
 		PrefectureCodeFromState(
 			input.Destination.State,
@@ -237,6 +292,24 @@ func (s *Service) calculateCarrier(
 	input CalculateInput,
 	originPrefectureCode PrefectureCode,
 	destinationPrefectureCode PrefectureCode,
+) (Quote, error) {
+	return s.calculateCarrierWithRateInput(
+		input,
+		CarrierRateInput{
+			Package: input.Package,
+
+			OriginPrefectureCode: originPrefectureCode,
+
+			DestinationPrefectureCode: destinationPrefectureCode,
+
+			DestinationIslandCode: input.Destination.IslandCode,
+		},
+	)
+}
+
+func (s *Service) calculateCarrierWithRateInput(
+	input CalculateInput,
+	rateInput CarrierRateInput,
 ) (Quote, error) {
 	calculator, ok :=
 		s.calculators[input.Carrier]
@@ -248,15 +321,7 @@ func (s *Service) calculateCarrier(
 
 	quote, err :=
 		calculator.Calculate(
-			CarrierRateInput{
-				Package: input.Package,
-
-				OriginPrefectureCode: originPrefectureCode,
-
-				DestinationPrefectureCode: destinationPrefectureCode,
-
-				DestinationIslandCode: input.Destination.IslandCode,
-			},
+			rateInput,
 		)
 	if err != nil {
 		return Quote{}, err
@@ -285,6 +350,38 @@ func (s *Service) calculateCarrier(
 	return quote, nil
 }
 
+// calculateInternational は国外宛ての料金を国際便の料金表で算出する。
+//
+// 返す Quote.Carrier は実際に使う国際便（ems / epacket）であり、
+// input.Carrier とは異なる場合がある。
+func (s *Service) calculateInternational(
+	input CalculateInput,
+	originPrefectureCode PrefectureCode,
+) (Quote, error) {
+	carrier, err :=
+		ResolveInternationalCarrier(
+			input.Carrier,
+			input.Package,
+		)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	internationalInput := input
+	internationalInput.Carrier = carrier
+
+	return s.calculateCarrierWithRateInput(
+		internationalInput,
+		CarrierRateInput{
+			Package: input.Package,
+
+			OriginPrefectureCode: originPrefectureCode,
+
+			DestinationCountry: input.Destination.Country,
+		},
+	)
+}
+
 func (s *Service) calculateCustom(
 	ctx context.Context,
 	input CalculateInput,
@@ -367,7 +464,9 @@ func (s *Service) registerCarrierCalculator(
 	switch carrier {
 	case CarrierYamato,
 		CarrierSagawa,
-		CarrierPost:
+		CarrierPost,
+		CarrierEMS,
+		CarrierEPacket:
 	default:
 		return ErrInvalidCarrier
 	}
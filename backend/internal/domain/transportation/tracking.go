@@ -0,0 +1,98 @@
+// backend/internal/domain/transportation/tracking.go
+package transportation
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// MaxTrackingNumberLength は伝票番号（問い合わせ番号）の最大長です。
+const MaxTrackingNumberLength = 64
+
+var ErrInvalidTrackingNumber = errors.New("transportation: invalid trackingNumber")
+
+const (
+	yamatoTrackingURL = "https://toi.kuronekoyamato.co.jp/cgi-bin/tneko"
+	sagawaTrackingURL = "https://k2k.sagawa-exp.co.jp/p/web/okurijosearch.do"
+	postTrackingURL   = "https://trackings.post.japanpost.jp/services/srv/search/direct"
+)
+
+// NormalizeTrackingNumber は伝票番号からハイフン・空白を取り除きます。
+// 英数字以外が含まれる場合はエラーを返します。空文字は許容します。
+func NormalizeTrackingNumber(
+	trackingNumber string,
+) (string, error) {
+	replacer := strings.NewReplacer(
+		"-", "",
+		" ", "",
+		"　", "",
+	)
+
+	normalized := strings.ToUpper(
+		replacer.Replace(
+			strings.TrimSpace(trackingNumber),
+		),
+	)
+
+	if len(normalized) > MaxTrackingNumberLength {
+		return "", ErrInvalidTrackingNumber
+	}
+
+	for _, r := range normalized {
+		isDigit := r >= '0' && r <= '9'
+		isUpper := r >= 'A' && r <= 'Z'
+
+		if !isDigit && !isUpper {
+			return "", ErrInvalidTrackingNumber
+		}
+	}
+
+	return normalized, nil
+}
+
+// TrackingURL は carrier の荷物問い合わせページの URL を返します。
+//
+//   - yamato: クロネコヤマト 荷物お問い合わせ
+//   - sagawa: 佐川急便 お問い合わせサービス
+//   - post / ems / epacket: 日本郵便 個別番号検索
+//   - custom / 伝票番号なし: 空文字
+func TrackingURL(
+	carrier Carrier,
+	trackingNumber string,
+) string {
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if trackingNumber == "" {
+		return ""
+	}
+
+	switch carrier {
+	case CarrierYamato:
+		return yamatoTrackingURL + "?" + url.Values{
+			"number00": {"1"},
+			"number01": {trackingNumber},
+		}.Encode()
+
+	case CarrierSagawa:
+		return sagawaTrackingURL + "?" + url.Values{
+			"okurijoNo": {trackingNumber},
+		}.Encode()
+
+	case CarrierPost,
+		CarrierEMS,
+		CarrierEPacket:
+		locale := "ja"
+		if carrier != CarrierPost {
+			locale = "en"
+		}
+
+		return postTrackingURL + "?" + url.Values{
+			"reqCodeNo1": {trackingNumber},
+			"searchKind": {"S002"},
+			"locale":     {locale},
+		}.Encode()
+
+	default:
+		return ""
+	}
+}
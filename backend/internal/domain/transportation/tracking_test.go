@@ -0,0 +1,76 @@
+// backend/internal/domain/transportation/tracking_test.go
+package transportation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTrackingNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{in: "1234-5678-9012", want: "123456789012"},
+		{in: " 1234 5678　9012 ", want: "123456789012"},
+		{in: "ej123456789jp", want: "EJ123456789JP"},
+		{in: "", want: ""},
+		{in: "1234_5678", wantErr: ErrInvalidTrackingNumber},
+		{in: "１２３４", wantErr: ErrInvalidTrackingNumber},
+		{in: strings.Repeat("1", MaxTrackingNumberLength), want: strings.Repeat("1", MaxTrackingNumberLength)},
+		{in: strings.Repeat("1", MaxTrackingNumberLength+1), wantErr: ErrInvalidTrackingNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizeTrackingNumber(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("NormalizeTrackingNumber = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackingURL(t *testing.T) {
+	tests := []struct {
+		carrier  Carrier
+		tracking string
+		want     string
+	}{
+		{
+			carrier:  CarrierYamato,
+			tracking: "123456789012",
+			want:     "https://toi.kuronekoyamato.co.jp/cgi-bin/tneko?number00=1&number01=123456789012",
+		},
+		{
+			carrier:  CarrierSagawa,
+			tracking: "123456789012",
+			want:     "https://k2k.sagawa-exp.co.jp/p/web/okurijosearch.do?okurijoNo=123456789012",
+		},
+		{
+			carrier:  CarrierPost,
+			tracking: "123456789012",
+			want:     "https://trackings.post.japanpost.jp/services/srv/search/direct?locale=ja&reqCodeNo1=123456789012&searchKind=S002",
+		},
+		{
+			carrier:  CarrierEMS,
+			tracking: "EJ123456789JP",
+			want:     "https://trackings.post.japanpost.jp/services/srv/search/direct?locale=en&reqCodeNo1=EJ123456789JP&searchKind=S002",
+		},
+		{carrier: CarrierCustom, tracking: "123456789012", want: ""},
+		{carrier: CarrierYamato, tracking: " ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.carrier)+"/"+tt.tracking, func(t *testing.T) {
+			if got := TrackingURL(tt.carrier, tt.tracking); got != tt.want {
+				t.Fatalf("TrackingURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -111,6 +111,8 @@ type bubblegumMintRequest struct {
 	Name             string `json:"name"`
 	Symbol           string `json:"symbol"`
 	MetadataURI      string `json:"metadataUri"`
+
+	SellerFeeBasisPoints int `json:"sellerFeeBasisPoints"`
 }
 
 type bubblegumMintResponse struct {
@@ -373,6 +375,8 @@ func (c *MintClient) MintToken(ctx context.Context, params tokendom.MintParams)
 		Name:             params.Name,
 		Symbol:           params.Symbol,
 		MetadataURI:      params.MetadataURI,
+
+		SellerFeeBasisPoints: params.SellerFeeBasisPoints,
 	}
 
 	body, err := json.Marshal(requestBody)
@@ -50,7 +50,11 @@ var (
 	)
 
 	ErrTokenTransferToAvatarEmpty = errors.New(
-		"token_transfer_executor: toAvatarId is empty",
+		"token_transfer_executor: receiver identity is empty",
+	)
+
+	ErrTokenTransferReceiverAmbiguous = errors.New(
+		"token_transfer_executor: both toAvatarId and toBrandId are set",
 	)
 
 	ErrTokenTransferFromWalletEmpty = errors.New(
@@ -221,7 +225,8 @@ type bubblegumTransferRequest struct {
 
 	FromAvatarID string `json:"fromAvatarId,omitempty"`
 	FromBrandID  string `json:"fromBrandId,omitempty"`
-	ToAvatarID   string `json:"toAvatarId"`
+	ToAvatarID   string `json:"toAvatarId,omitempty"`
+	ToBrandID    string `json:"toBrandId,omitempty"`
 
 	BrandID          string `json:"brandId,omitempty"`
 	ModelID          string `json:"modelId,omitempty"`
@@ -300,11 +305,18 @@ func (e *TokenTransferExecutorSolana) ExecuteTransfer(
 			ErrTokenTransferSenderAmbiguous
 	}
 
-	if in.ToAvatarID == "" {
+	if in.ToAvatarID == "" &&
+		in.ToBrandID == "" {
 		return usecase.ExecuteTransferResult{},
 			ErrTokenTransferToAvatarEmpty
 	}
 
+	if in.ToAvatarID != "" &&
+		in.ToBrandID != "" {
+		return usecase.ExecuteTransferResult{},
+			ErrTokenTransferReceiverAmbiguous
+	}
+
 	if in.FromWalletAddress == "" {
 		return usecase.ExecuteTransferResult{},
 			ErrTokenTransferFromWalletEmpty
@@ -324,6 +336,7 @@ func (e *TokenTransferExecutorSolana) ExecuteTransfer(
 		FromAvatarID: in.FromAvatarID,
 		FromBrandID:  in.FromBrandID,
 		ToAvatarID:   in.ToAvatarID,
+		ToBrandID:    in.ToBrandID,
 
 		BrandID:          in.BrandID,
 		ModelID:          in.ModelID,
@@ -383,12 +396,13 @@ func (e *TokenTransferExecutorSolana) ExecuteTransfer(
 	)
 
 	log.Printf(
-		"[token_transfer_executor] transfer start productId=%s assetId=%s fromAvatarId=%s fromBrandId=%s toAvatarId=%s fromWallet=%s toWallet=%s",
+		"[token_transfer_executor] transfer start productId=%s assetId=%s fromAvatarId=%s fromBrandId=%s toAvatarId=%s toBrandId=%s fromWallet=%s toWallet=%s",
 		in.ProductID,
 		in.AssetID,
 		in.FromAvatarID,
 		in.FromBrandID,
 		in.ToAvatarID,
+		in.ToBrandID,
 		in.FromWalletAddress,
 		in.ToWalletAddress,
 	)
@@ -43,6 +43,8 @@ type Container struct {
 	CompanyQuery                    *query.CompanyQuery
 	InquiryUC                       *uc.InquiryUsecase
 	InventoryUC                     *uc.InventoryUsecase
+	InventoryReservationUC          *uc.InventoryReservationUsecase
+	StockAlertUC                    *uc.StockAlertUsecase
 	ListUC                          *uc.ListUsecase
 	ListSaveOperationUC             *uc.ListSaveOperationUsecase
 	MemberUC                        *uc.MemberUsecase
@@ -51,8 +53,21 @@ type Container struct {
 	OrderDispatchNotificationUC     uc.OrderDispatchNotificationUsecasePort
 	PaymentUC                       *uc.PaymentUsecase
 	PaymentFlowUC                   *uc.PaymentFlowUsecase
+	RefundUC                        *uc.RefundUsecase
+	ReturnUC                        *uc.ReturnUsecase
+	CouponUC                        *uc.CouponUsecase
+	RoyaltyUC                       *uc.RoyaltyUsecase
+	OfferUC                         *uc.OfferUsecase
+	EscrowUC                        *uc.EscrowUsecase
+	PayoutUC                        *uc.PayoutUsecase
+	BillingUC                       *uc.BillingUsecase
+	AuditUC                         *uc.AuditUsecase
+	OutboxRelayUC                   *uc.OutboxRelayUsecase
+	StripeWebhookEventUC            *uc.StripeWebhookEventUsecase
 	PermissionUC                    *uc.PermissionUsecase
 	PrintUC                         *uc.PrintUsecase
+	NFCTagUC                        *uc.NFCTagUsecase
+	PrintLabelUC                    *uc.PrintLabelUsecase
 	ProductionUC                    *uc.ProductionUsecase
 	ProductBlueprintUC              *uc.ProductBlueprintUsecase
 	ProductBlueprintCategoryUC      *uc.ProductBlueprintCategoryUsecase
@@ -66,6 +81,8 @@ type Container struct {
 	CompanyProductionQueryService   *query.CompanyProductionQueryService
 	MintRequestQueryService         *query.MintRequestQueryService
 	MintFundingEstimateQuery        *query.MintFundingEstimateQuery
+	InspectionAnalyticsQuery        *query.InspectionAnalyticsQuery
+	ProductionProgressQuery         *query.ProductionProgressQuery
 	BrandManagementQuery            *query.BrandManagementQuery
 	BrandDetailQuery                *query.BrandDetailQuery
 	ProductBlueprintManagementQuery *query.ProductBlueprintManagementQuery
@@ -227,6 +244,8 @@ func NewContainer(
 		CompanyQuery:                    q.companyQuery,
 		InquiryUC:                       u.inquiryUC,
 		InventoryUC:                     u.inventoryUC,
+		InventoryReservationUC:          u.inventoryReservationUC,
+		StockAlertUC:                    u.stockAlertUC,
 		ListUC:                          u.listUC,
 		ListSaveOperationUC:             u.listSaveOperationUC,
 		MemberUC:                        u.memberUC,
@@ -235,8 +254,21 @@ func NewContainer(
 		OrderDispatchNotificationUC:     u.orderDispatchNotificationUC,
 		PaymentUC:                       u.paymentUC,
 		PaymentFlowUC:                   u.paymentFlowUC,
+		RefundUC:                        u.refundUC,
+		ReturnUC:                        u.returnUC,
+		CouponUC:                        u.couponUC,
+		RoyaltyUC:                       u.royaltyUC,
+		OfferUC:                         u.offerUC,
+		EscrowUC:                        u.escrowUC,
+		PayoutUC:                        u.payoutUC,
+		BillingUC:                       u.billingUC,
+		AuditUC:                         u.auditUC,
+		OutboxRelayUC:                   u.outboxRelayUC,
+		StripeWebhookEventUC:            u.stripeWebhookEventUC,
 		PermissionUC:                    u.permissionUC,
 		PrintUC:                         u.printUC,
+		NFCTagUC:                        u.nfcTagUC,
+		PrintLabelUC:                    u.printLabelUC,
 		ProductionUC:                    u.productionUC,
 		ProductBlueprintUC:              u.productBlueprintUC,
 		ProductBlueprintCategoryUC:      u.productBlueprintCategoryUC,
@@ -250,6 +282,8 @@ func NewContainer(
 		CompanyProductionQueryService:   q.companyProductionQueryService,
 		MintRequestQueryService:         q.mintRequestQueryService,
 		MintFundingEstimateQuery:        q.mintFundingEstimateQuery,
+		InspectionAnalyticsQuery:        q.inspectionAnalyticsQuery,
+		ProductionProgressQuery:         q.productionProgressQuery,
 		BrandManagementQuery:            q.brandManagementQuery,
 		BrandDetailQuery:                q.brandDetailQuery,
 		ProductBlueprintManagementQuery: q.productBlueprintManagementQuery,
@@ -4,6 +4,7 @@ package console
 import (
 	"context"
 	"log"
+	"strconv"
 
 	outfirebase "narratives/internal/adapters/out/firebase"
 	fsrepo "narratives/internal/adapters/out/firestore"
@@ -19,6 +20,8 @@ type queries struct {
 	companyProductionQueryService *companyquery.CompanyProductionQueryService
 	mintRequestQueryService       *companyquery.MintRequestQueryService
 	mintFundingEstimateQuery      *companyquery.MintFundingEstimateQuery
+	inspectionAnalyticsQuery      *companyquery.InspectionAnalyticsQuery
+	productionProgressQuery       *companyquery.ProductionProgressQuery
 
 	brandManagementQuery *companyquery.BrandManagementQuery
 	brandDetailQuery     *companyquery.BrandDetailQuery
@@ -159,6 +162,20 @@ func buildQueries(
 		usecase.CompanyIDFromContext,
 	)
 
+	inspectionAnalyticsQuery := companyquery.NewInspectionAnalyticsQuery(
+		companyProductionQueryService,
+		r.inspectionRepo,
+		res.nameResolver,
+	)
+
+	productionProgressQuery := companyquery.NewProductionProgressQuery(
+		companyProductionQueryService,
+		r.inspectionRepo,
+		r.printLogRepo,
+		r.mintRepo,
+		res.nameResolver,
+	)
+
 	var mintTaskProgressQuery companyquery.MintTaskProgressQuery
 	if r.mintRepo != nil && r.mintRepo.Client != nil {
 		mintTaskProgressQuery = fsrepo.NewMintTaskProgressQueryFS(r.mintRepo.Client)
@@ -238,6 +255,35 @@ func buildQueries(
 			estimateExecutor,
 			usecase.CompanyIDFromContext,
 		)
+
+		// ミント手数料のネットワーク手数料分は、ミント申請時点の見積から計上する。
+		if u.billingUC != nil {
+			estimateQuery := mintFundingEstimateQuery
+			u.billingUC.WithMintCostEstimate(
+				func(
+					ctx context.Context,
+					productionID string,
+					tokenBlueprintID string,
+				) (int64, error) {
+					result, err := estimateQuery.GetMintFundingEstimate(
+						ctx,
+						companyquery.GetMintFundingEstimateInput{
+							ProductionID:     productionID,
+							TokenBlueprintID: tokenBlueprintID,
+						},
+					)
+					if err != nil {
+						return 0, err
+					}
+
+					if result == nil {
+						return 0, nil
+					}
+
+					return strconv.ParseInt(result.Estimate.MintTransactionFeeTotalLamports, 10, 64)
+				},
+			)
+		}
 	}
 
 	inventoryManagementQuery := companyquery.NewInventoryManagementQuery(
@@ -256,6 +302,8 @@ func buildQueries(
 		r.transportationRepo,
 		res.nameResolver,
 		usecase.CompanyIDFromContext,
+	).WithReservationRepository(
+		r.inventoryReservationRepo,
 	)
 
 	// modelRepo(variations) を廃止したため、WithInventory のみを使用
@@ -378,6 +426,8 @@ func buildQueries(
 		companyProductionQueryService: companyProductionQueryService,
 		mintRequestQueryService:       mintRequestQueryService,
 		mintFundingEstimateQuery:      mintFundingEstimateQuery,
+		inspectionAnalyticsQuery:      inspectionAnalyticsQuery,
+		productionProgressQuery:       productionProgressQuery,
 
 		brandManagementQuery: brandManagementQuery,
 		brandDetailQuery:     brandDetailQuery,
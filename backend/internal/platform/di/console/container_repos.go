@@ -17,6 +17,9 @@ type repos struct {
 	inquiryRepo                   *fs.InquiryRepositoryFS
 	inquiryReplyRepo              *fs.InquiryReplyRepositoryFS
 	inventoryRepo                 *fs.InventoryRepositoryFS
+	inventoryReservationRepo      *fs.InventoryReservationRepositoryFS
+	stockAlertSettingRepo         *fs.StockAlertSettingRepositoryFS
+	stockAlertRepo                *fs.StockAlertRepositoryFS
 	listRepoFS                    *fs.ListRepositoryFS
 	listImageRecordRepo           *fs.ListImageRepositoryFS
 	listSaveOperationRepo         *fs.ListSaveOperationRepositoryFS
@@ -30,7 +33,22 @@ type repos struct {
 	orderDispatchNotificationRepo *fs.OrderDispatchNotificationRepositoryFS
 	orderConsoleLister            *fs.OrderConsoleListerFS
 	paymentRepo                   *fs.PaymentRepositoryFS
+	refundRepo                    *fs.RefundRepositoryFS
+	returnRepo                    *fs.ReturnRepositoryFS
+	couponRepo                    *fs.CouponRepositoryFS
+	royaltyRepo                   *fs.RoyaltyRepositoryFS
+	offerRepo                     *fs.OfferRepositoryFS
+	escrowRepo                    *fs.EscrowRepositoryFS
+	payoutRepo                    *fs.PayoutRepositoryFS
+	billingRepo                   *fs.BillingRepositoryFS
+	auditRepo                     *fs.AuditRepositoryFS
+	outboxRepo                    *fs.OutboxRepositoryFS
+	stripeEventRepo               *fs.StripeEventRepositoryFS
+	nfcTagRepo                    *fs.NFCTagRepositoryFS
+	printLabelTemplateRepo        *fs.PrintLabelTemplateRepositoryFS
+	returnImageRepo               *fs.ReturnImageRepositoryFS
 	permissionRepo                *fs.PermissionRepositoryFS
+	roleRepo                      *fs.RoleRepositoryFS
 	productRepo                   *fs.ProductRepositoryFS
 	productBlueprintRepo          *fs.ProductBlueprintRepositoryFS
 	productBlueprintCategoryRepo  *fs.ProductBlueprintCategoryRepositoryFS
@@ -64,6 +82,9 @@ func buildRepos(c *clients) *repos {
 	inquiryRepo := fs.NewInquiryRepositoryFS(fsClient)
 	inquiryReplyRepo := fs.NewInquiryReplyRepositoryFS(fsClient)
 	inventoryRepo := fs.NewInventoryRepositoryFS(fsClient)
+	inventoryReservationRepo := fs.NewInventoryReservationRepositoryFS(fsClient)
+	stockAlertSettingRepo := fs.NewStockAlertSettingRepositoryFS(fsClient)
+	stockAlertRepo := fs.NewStockAlertRepositoryFS(fsClient)
 	listRepoFS := fs.NewListRepositoryFS(fsClient)
 	listImageRecordRepo := fs.NewListImageRepositoryFS(fsClient)
 	listSaveOperationRepo := fs.NewListSaveOperationRepositoryFS(fsClient)
@@ -77,7 +98,22 @@ func buildRepos(c *clients) *repos {
 	orderDispatchNotificationRepo := fs.NewOrderDispatchNotificationRepositoryFS(fsClient)
 	orderConsoleLister := fs.NewOrderConsoleListerFS(fsClient)
 	paymentRepo := fs.NewPaymentRepositoryFS(fsClient)
+	refundRepo := fs.NewRefundRepositoryFS(fsClient)
+	returnRepo := fs.NewReturnRepositoryFS(fsClient)
+	couponRepo := fs.NewCouponRepositoryFS(fsClient)
+	royaltyRepo := fs.NewRoyaltyRepositoryFS(fsClient)
+	offerRepo := fs.NewOfferRepositoryFS(fsClient)
+	escrowRepo := fs.NewEscrowRepositoryFS(fsClient)
+	payoutRepo := fs.NewPayoutRepositoryFS(fsClient)
+	billingRepo := fs.NewBillingRepositoryFS(fsClient)
+	auditRepo := fs.NewAuditRepositoryFS(fsClient)
+	outboxRepo := fs.NewOutboxRepositoryFS(fsClient)
+	stripeEventRepo := fs.NewStripeEventRepositoryFS(fsClient)
+	nfcTagRepo := fs.NewNFCTagRepositoryFS(fsClient)
+	printLabelTemplateRepo := fs.NewPrintLabelTemplateRepositoryFS(fsClient)
+	returnImageRepo := fs.NewReturnImageRepositoryFS(fsClient)
 	permissionRepo := fs.NewPermissionRepositoryFS(fsClient)
+	roleRepo := fs.NewRoleRepositoryFS(fsClient)
 	productRepo := fs.NewProductRepositoryFS(fsClient)
 	productBlueprintRepo := fs.NewProductBlueprintRepositoryFS(fsClient)
 	productBlueprintCategoryRepo := fs.NewProductBlueprintCategoryRepositoryFS(fsClient)
@@ -105,6 +141,9 @@ func buildRepos(c *clients) *repos {
 		inquiryRepo:                   inquiryRepo,
 		inquiryReplyRepo:              inquiryReplyRepo,
 		inventoryRepo:                 inventoryRepo,
+		inventoryReservationRepo:      inventoryReservationRepo,
+		stockAlertSettingRepo:         stockAlertSettingRepo,
+		stockAlertRepo:                stockAlertRepo,
 		listRepoFS:                    listRepoFS,
 		listImageRecordRepo:           listImageRecordRepo,
 		listSaveOperationRepo:         listSaveOperationRepo,
@@ -118,7 +157,22 @@ func buildRepos(c *clients) *repos {
 		orderDispatchNotificationRepo: orderDispatchNotificationRepo,
 		orderConsoleLister:            orderConsoleLister,
 		paymentRepo:                   paymentRepo,
+		refundRepo:                    refundRepo,
+		returnRepo:                    returnRepo,
+		couponRepo:                    couponRepo,
+		royaltyRepo:                   royaltyRepo,
+		offerRepo:                     offerRepo,
+		escrowRepo:                    escrowRepo,
+		payoutRepo:                    payoutRepo,
+		billingRepo:                   billingRepo,
+		auditRepo:                     auditRepo,
+		outboxRepo:                    outboxRepo,
+		stripeEventRepo:               stripeEventRepo,
+		nfcTagRepo:                    nfcTagRepo,
+		printLabelTemplateRepo:        printLabelTemplateRepo,
+		returnImageRepo:               returnImageRepo,
 		permissionRepo:                permissionRepo,
+		roleRepo:                      roleRepo,
 		productRepo:                   productRepo,
 		productBlueprintRepo:          productBlueprintRepo,
 		productBlueprintCategoryRepo:  productBlueprintCategoryRepo,
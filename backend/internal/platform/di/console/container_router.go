@@ -18,6 +18,11 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 		authMw = &middleware.AuthMiddleware{FirebaseAuth: c.Infra.FirebaseAuth, MemberRepo: c.MemberRepo}
 	}
 
+	var permissionMw *middleware.PermissionMiddleware
+	if c.PermissionUC != nil {
+		permissionMw = &middleware.PermissionMiddleware{Authorizer: c.PermissionUC}
+	}
+
 	var bootstrapMw *middleware.BootstrapAuthMiddleware
 	if c.Infra.FirebaseAuth != nil {
 		bootstrapMw = &middleware.BootstrapAuthMiddleware{FirebaseAuth: c.Infra.FirebaseAuth}
@@ -46,6 +51,9 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 		productBPReviewH                           http.Handler
 		messagesH                                  http.Handler
 		ordersH                                    http.Handler
+		returnsH                                   http.Handler
+		couponsH                                   http.Handler
+		royaltiesH                                 http.Handler
 		walletsH                                   http.Handler
 		membersH                                   http.Handler
 		productionsH                               http.Handler
@@ -58,6 +66,18 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 		internalInvitationDeliveryDispatchH        http.Handler
 		internalOrderDispatchNotificationProcessH  http.Handler
 		internalOrderDispatchNotificationDispatchH http.Handler
+		internalInventoryReservationReleaseH       http.Handler
+		internalOfferExpireH                       http.Handler
+		escrowsH                                   http.Handler
+		internalEscrowAutoConfirmH                 http.Handler
+		payoutsH                                   http.Handler
+		billingH                                   http.Handler
+		auditLogsH                                 http.Handler
+		internalOutboxRelayH                       http.Handler
+		stripeEventsH                              http.Handler
+		nfcTagsH                                   http.Handler
+		printLabelsH                               http.Handler
+		inspectionReportsH                         http.Handler
 		ownerResolveH                              http.Handler
 	)
 
@@ -111,6 +131,8 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 			c.InventoryManagementQuery,
 			c.InventoryDetailQuery,
 			c.ListCreateQuery,
+		).WithStockAlertUsecase(
+			c.StockAlertUC,
 		)
 	}
 
@@ -142,6 +164,18 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 		productsPrintH = consoleHandler.NewPrintHandler(c.PrintUC, c.PrintQueryService)
 	}
 
+	if c.NFCTagUC != nil {
+		nfcTagsH = consoleHandler.NewNFCTagHandler(c.NFCTagUC)
+	}
+
+	if c.PrintLabelUC != nil {
+		printLabelsH = consoleHandler.NewPrintLabelHandler(c.PrintLabelUC)
+	}
+
+	if c.InspectionAnalyticsQuery != nil {
+		inspectionReportsH = consoleHandler.NewInspectionReportHandler(c.InspectionAnalyticsQuery)
+	}
+
 	if c.ProductBlueprintUC != nil && c.ProductBlueprintManagementQuery != nil && c.ProductBlueprintDetailQuery != nil {
 		productBPH = consoleHandler.NewProductBlueprintHandler(
 			c.ProductBlueprintUC,
@@ -193,12 +227,22 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 		ordersH = consoleHandler.NewOrderHandler(
 			c.OrderUC,
 			c.PaymentFlowUC,
+			c.RefundUC,
 			c.OrderManagementQuery,
 			c.OrderDetailQuery,
-			c.OrderDispatchNotificationUC,
 		)
 	}
 
+	if c.ReturnUC != nil {
+		returnsH = consoleHandler.NewReturnHandler(c.ReturnUC)
+	}
+	if c.CouponUC != nil {
+		couponsH = consoleHandler.NewCouponHandler(c.CouponUC)
+	}
+	if c.RoyaltyUC != nil {
+		royaltiesH = consoleHandler.NewRoyaltyHandler(c.RoyaltyUC)
+	}
+
 	if c.WalletUC != nil {
 		walletsH = consoleHandler.NewWalletHandler(c.WalletUC)
 	}
@@ -208,7 +252,7 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 	}
 
 	if c.ProductionUC != nil && c.CompanyProductionQueryService != nil {
-		productionsH = consoleHandler.NewProductionHandler(c.CompanyProductionQueryService, c.ProductionUC)
+		productionsH = consoleHandler.NewProductionHandler(c.CompanyProductionQueryService, c.ProductionProgressQuery, c.ProductionUC)
 	}
 
 	if c.ModelUC != nil && c.ProductBlueprintRepo != nil {
@@ -274,6 +318,47 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 		)
 	}
 
+	if c.InventoryReservationUC != nil {
+		internalInventoryReservationReleaseH = internalHandler.NewInventoryReservationHandler(
+			c.InventoryReservationUC,
+		)
+	}
+
+	if c.OfferUC != nil {
+		internalOfferExpireH = internalHandler.NewOfferExpiryHandler(
+			c.OfferUC,
+		)
+	}
+
+	if c.EscrowUC != nil {
+		escrowsH = consoleHandler.NewEscrowHandler(c.EscrowUC)
+		internalEscrowAutoConfirmH = internalHandler.NewEscrowAutoConfirmHandler(
+			c.EscrowUC,
+		)
+	}
+
+	if c.PayoutUC != nil {
+		payoutsH = consoleHandler.NewPayoutHandler(c.PayoutUC)
+	}
+
+	if c.BillingUC != nil {
+		billingH = consoleHandler.NewBillingHandler(c.BillingUC)
+	}
+
+	if c.AuditUC != nil {
+		auditLogsH = consoleHandler.NewAuditHandler(c.AuditUC)
+	}
+
+	if c.OutboxRelayUC != nil {
+		internalOutboxRelayH = internalHandler.NewOutboxRelayHandler(
+			c.OutboxRelayUC,
+		)
+	}
+
+	if c.StripeWebhookEventUC != nil {
+		stripeEventsH = consoleHandler.NewStripeEventHandler(c.StripeWebhookEventUC)
+	}
+
 	if c.OwnerResolveQ != nil {
 		ownerResolveH = consoleHandler.NewOwnerResolveHandler(c.OwnerResolveQ)
 	}
@@ -290,6 +375,7 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 	return httpin.RouterDeps{
 		AuthMw:                                   authMw,
 		BootstrapMw:                              bootstrapMw,
+		PermissionMw:                             permissionMw,
 		AuthBootstrap:                            authBootstrapH,
 		Accounts:                                 accountsH,
 		Announcements:                            announcementsH,
@@ -308,6 +394,8 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 		TokenBP:                                  tokenBPH,
 		Messages:                                 messagesH,
 		Orders:                                   ordersH,
+		Returns:                                  returnsH,
+		Coupons:                                  couponsH,
 		Wallets:                                  walletsH,
 		Members:                                  membersH,
 		Productions:                              productionsH,
@@ -320,10 +408,34 @@ func (c *Container) RouterDeps() httpin.RouterDeps {
 		InternalInvitationDeliveryDispatch:       internalInvitationDeliveryDispatchH,
 		InternalOrderDispatchNotificationProcess: internalOrderDispatchNotificationProcessH,
 		InternalOrderDispatchNotificationDispatch: internalOrderDispatchNotificationDispatchH,
-		OwnerResolve:    ownerResolveH,
-		Invitation:      invitationH,
-		Sales:           salesH,
-		TokenBPReview:   tokenBPReviewH,
-		ProductBPReview: productBPReviewH,
+		InternalInventoryReservationRelease:       internalInventoryReservationReleaseH,
+		OwnerResolve:                              ownerResolveH,
+		Invitation:                                invitationH,
+		Sales:                                     salesH,
+		TokenBPReview:                             tokenBPReviewH,
+		ProductBPReview:                           productBPReviewH,
+
+		Royalties: royaltiesH,
+
+		InternalOfferExpire: internalOfferExpireH,
+
+		Escrows:                   escrowsH,
+		InternalEscrowAutoConfirm: internalEscrowAutoConfirmH,
+
+		Payouts: payoutsH,
+
+		Billing: billingH,
+
+		AuditLogs: auditLogsH,
+
+		InternalOutboxRelay: internalOutboxRelayH,
+
+		StripeEvents: stripeEventsH,
+
+		NFCTags: nfcTagsH,
+
+		PrintLabels: printLabelsH,
+
+		InspectionReports: inspectionReportsH,
 	}
 }
@@ -4,15 +4,24 @@ package console
 import (
 	"context"
 	"errors"
+	"log"
 	"os"
 
+	mallwebhook "narratives/internal/adapters/in/http/mall/webhook"
 	listcloudtasksadp "narratives/internal/adapters/out/cloudtasks"
+	csvadp "narratives/internal/adapters/out/csv"
 	firebaseadp "narratives/internal/adapters/out/firebase"
 	fsrepo "narratives/internal/adapters/out/firestore"
 	cloudtasksadp "narratives/internal/adapters/out/firestore/cloudtasks"
+	mallfs "narratives/internal/adapters/out/firestore/mall"
+	httpout "narratives/internal/adapters/out/http"
 	mailadp "narratives/internal/adapters/out/mail"
+	pdfadp "narratives/internal/adapters/out/pdf"
+	qrcodeadp "narratives/internal/adapters/out/qrcode"
 	stripeadapter "narratives/internal/adapters/out/stripe"
+	zenginadp "narratives/internal/adapters/out/zengin"
 	uc "narratives/internal/application/usecase"
+	outboxdom "narratives/internal/domain/outbox"
 	"narratives/internal/infra/arweave"
 	solanainfra "narratives/internal/infra/solana"
 )
@@ -29,6 +38,8 @@ type usecases struct {
 	companyUC                      *uc.CompanyUsecase
 	inquiryUC                      *uc.InquiryUsecase
 	inventoryUC                    *uc.InventoryUsecase
+	inventoryReservationUC         *uc.InventoryReservationUsecase
+	stockAlertUC                   *uc.StockAlertUsecase
 	listUC                         *uc.ListUsecase
 	listSaveOperationUC            *uc.ListSaveOperationUsecase
 	listSaveOperationStorage       *firebaseadp.ListSaveOperationStorage
@@ -40,8 +51,21 @@ type usecases struct {
 	orderDispatchNotificationQueue *listcloudtasksadp.OrderDispatchNotificationQueue
 	paymentUC                      *uc.PaymentUsecase
 	paymentFlowUC                  *uc.PaymentFlowUsecase
+	refundUC                       *uc.RefundUsecase
+	returnUC                       *uc.ReturnUsecase
+	couponUC                       *uc.CouponUsecase
+	royaltyUC                      *uc.RoyaltyUsecase
+	offerUC                        *uc.OfferUsecase
+	escrowUC                       *uc.EscrowUsecase
+	payoutUC                       *uc.PayoutUsecase
+	billingUC                      *uc.BillingUsecase
+	auditUC                        *uc.AuditUsecase
+	outboxRelayUC                  *uc.OutboxRelayUsecase
+	stripeWebhookEventUC           *uc.StripeWebhookEventUsecase
 	permissionUC                   *uc.PermissionUsecase
 	printUC                        *uc.PrintUsecase
+	nfcTagUC                       *uc.NFCTagUsecase
+	printLabelUC                   *uc.PrintLabelUsecase
 	productionUC                   *uc.ProductionUsecase
 	productBlueprintUC             *uc.ProductBlueprintUsecase
 	productBlueprintCategoryUC     *uc.ProductBlueprintCategoryUsecase
@@ -146,10 +170,18 @@ func buildUsecases(
 		c.infra.PaymentMethodGateway,
 	)
 
+	// console からの書き込みは各 usecase から監査ログに記録する。
+	auditUC := uc.NewAuditUsecase(
+		r.auditRepo,
+	).WithRenderer(
+		csvadp.NewAuditLogRenderer(),
+	)
+
 	brandUC := uc.NewBrandUsecase(
 		r.brandRepo,
 		r.memberRepo,
 		uc.WithBrandWalletService(brandWalletSvc),
+		uc.WithBrandAuditRecorder(auditUC),
 	)
 
 	companyUC := uc.NewCompanyUsecase(r.companyRepo)
@@ -164,7 +196,22 @@ func buildUsecases(
 		nil,
 	)
 
-	inventoryUC := uc.NewInventoryUsecase(r.inventoryRepo)
+	stockAlertUC := uc.NewStockAlertUsecase(
+		r.stockAlertSettingRepo,
+		r.stockAlertRepo,
+		r.inventoryRepo,
+		r.productBlueprintRepo,
+	).WithMailer(
+		mailadp.NewStockAlertMailerWithResend(),
+	).WithAnnouncer(
+		announcementUC,
+	)
+
+	inventoryUC := uc.NewInventoryUsecase(r.inventoryRepo).WithStockLevelEvaluator(
+		stockAlertUC,
+	).WithAuditRecorder(
+		auditUC,
+	)
 
 	inventoryUC.WithShippingAddressAssignment(
 		r.shippingAddressRepo,
@@ -177,11 +224,112 @@ func buildUsecases(
 		}
 	}
 
+	couponUC := uc.NewCouponUsecase(
+		r.couponRepo,
+		r.couponRepo,
+		r.productBlueprintRepo,
+	)
+
+	royaltyUC := uc.NewRoyaltyUsecase(
+		r.royaltyRepo,
+		r.tokenBlueprintRepo,
+		r.resaleRepo,
+	)
+
+	authUserReader := firebaseadp.NewAuthUserReader(
+		c.infra.FirebaseAuth,
+	)
+
+	// 集約と同じ transaction で保存したドメインイベントの配信。
+	// 購読は全ハンドラの生成後に行う（末尾）。
+	outboxRelayUC := uc.NewOutboxRelayUsecase(r.outboxRepo)
+
+	offerUC := uc.NewOfferUsecase(
+		r.offerRepo,
+		r.resaleRepo,
+	).WithMailer(
+		mailadp.NewOfferMailerWithResend(),
+		r.avatarRepo,
+		authUserReader,
+	)
+
+	// 手数料プランは payout の手数料率とミント手数料の計上にも使う。
+	billingUC := uc.NewBillingUsecase(
+		r.billingRepo,
+		r.payoutRepo,
+		r.brandRepo,
+		r.companyRepo,
+	).WithDefaultSalesFee(
+		c.infra.PayoutPlatformFeeBasisPoints,
+	).WithSOLRate(
+		c.infra.BillingYenPerSOL,
+	).WithOperatorCompany(
+		c.infra.BillingOperatorCompanyID,
+	).WithTransferLister(
+		r.transferRepo,
+	).WithStatementRenderers(
+		csvadp.NewBillingStatementRenderer(),
+		pdfadp.NewBillingStatementRenderer(),
+	)
+
+	payoutUC := uc.NewPayoutUsecase(
+		r.payoutRepo,
+		r.accountRepo,
+		r.brandRepo,
+		r.companyRepo,
+	).WithMemberRepo(
+		r.memberRepo,
+	).WithPlatformFee(
+		c.infra.PayoutPlatformFeeBasisPoints,
+	).WithFeePlans(
+		billingUC,
+	).WithStatementRenderer(
+		pdfadp.NewPayoutStatementRenderer(),
+	)
+
+	// 振込ファイルの出力は振込依頼人の設定（PAYOUT_ZENGIN_*）がある場合だけ有効にする。
+	if transferFileWriter, err := zenginadp.NewTransferFileWriterFromEnv(); err == nil {
+		payoutUC.WithTransferFileRenderer(transferFileWriter)
+	}
+
+	escrowUC := uc.NewEscrowUsecase(
+		r.escrowRepo,
+		r.orderRepo,
+		r.resaleRepo,
+		r.brandRepo,
+	).WithPayoutLedger(
+		payoutUC,
+	)
+
+	inventoryReservationUC := uc.NewInventoryReservationUsecase(
+		r.inventoryReservationRepo,
+		r.inventoryRepo,
+		r.orderRepo,
+	).WithTTL(
+		c.infra.InventoryReservationTTL,
+	).WithStockLevelEvaluator(
+		stockAlertUC,
+	).WithCouponReleaser(
+		couponUC,
+	).WithOfferReleaser(
+		offerUC,
+	).WithPaymentCanceler(
+		r.paymentRepo,
+		c.infra.PaymentMethodGateway,
+	)
+
 	paymentUC := uc.NewPaymentUsecase(
 		uc.NewPaymentUsecaseInput{
-			PaymentRepo: r.paymentRepo,
-			OrderRepo:   r.orderRepo,
-			ResaleRepo:  r.resaleRepo,
+			PaymentRepo:           r.paymentRepo,
+			OrderRepo:             r.orderRepo,
+			ResaleRepo:            r.resaleRepo,
+			InventoryReservations: inventoryReservationUC,
+			CouponRedemptions:     couponUC,
+			RoyaltyLedger:         royaltyUC,
+			Offers:                offerUC,
+			Escrows:               escrowUC,
+			Payouts:               payoutUC,
+			Outbox:                outboxRelayUC,
 		},
 	)
 
@@ -202,6 +350,8 @@ func buildUsecases(
 		r.listRepoFS,
 		r.listImageRecordRepo,
 		listSaveOperationStorage,
+	).WithAuditRecorder(
+		auditUC,
 	)
 
 	listSaveOperationRetryQueue, err := listcloudtasksadp.NewListSaveOperationQueueFromEnv(ctx)
@@ -219,6 +369,8 @@ func buildUsecases(
 			Storage:             listSaveOperationStorage,
 			RetryQueue:          listSaveOperationRetryQueue,
 		},
+	).WithAuditRecorder(
+		auditUC,
 	)
 
 	modelUC := uc.NewModelUsecase(
@@ -232,6 +384,8 @@ func buildUsecases(
 		r.modelRepo,
 		r.shippingAddressRepo,
 		s.transportationSvc,
+	).WithProductBlueprintRepo(
+		r.productBlueprintRepo,
 	)
 
 	orderUC := uc.NewOrderUsecase(
@@ -243,6 +397,20 @@ func buildUsecases(
 		r.paymentMethodRepo,
 		r.shippingAddressRepo,
 		shippingQuoteUC,
+	).WithInventoryReserver(
+		inventoryReservationUC,
+	).WithStockLevelEvaluator(
+		stockAlertUC,
+	).WithCouponApplier(
+		couponUC,
+	).WithRoyaltyQuoter(
+		royaltyUC,
+	).WithOfferCheckout(
+		offerUC,
+	).WithAuditRecorder(
+		auditUC,
+	).WithOutbox(
+		outboxRelayUC,
 	)
 
 	if paymentUC == nil {
@@ -291,7 +459,75 @@ func buildUsecases(
 		)
 	}
 
-	permissionUC := uc.NewPermissionUsecase(r.permissionRepo)
+	refundUC := uc.NewRefundUsecase(
+		r.refundRepo,
+		r.orderRepo,
+		r.paymentRepo,
+		c.infra.PaymentMethodGateway,
+	).WithPayoutLedger(
+		payoutUC,
+	)
+
+	// 紛争の返金による解決は通常の返金と同じ経路で行う。
+	escrowUC.WithRefundIssuer(refundUC)
+
+	// 保存済みの Stripe イベントの再処理は webhook と同じ processor で反映する。
+	stripeWebhookEventUC := uc.NewStripeWebhookEventUsecase(
+		r.stripeEventRepo,
+		mallwebhook.NewStripeEventProcessor(paymentUC, refundUC),
+	).WithOperatorCompany(
+		c.infra.BillingOperatorCompanyID,
+	)
+
+	// 返品受領時に NFT を brand wallet へ戻すための依存。
+	walletResolver := fsrepo.NewWalletResolverRepoFS(
+		r.brandRepo,
+		r.walletRepo,
+	)
+
+	returnUC := uc.NewReturnUsecase(
+		r.returnRepo,
+		r.returnImageRepo,
+		r.orderRepo,
+		r.productBlueprintRepo,
+		r.inventoryRepo,
+	).WithShippingQuoter(
+		shippingQuoteUC,
+	).WithTokenReturn(
+		uc.ReturnTokenDependencies{
+			Tokens:       mallfs.NewTokenResolverFS(c.fsClient, "tokens"),
+			AvatarWallet: walletResolver,
+			BrandWallet:  walletResolver,
+			Executor:     solanainfra.NewTokenTransferExecutorSolana(""),
+			TokenOwner:   fsrepo.NewTokenOwnerUpdaterFS(c.fsClient),
+			WalletItems:  r.walletRepo,
+		},
+	).WithRefundIssuer(
+		refundUC,
+	).WithReplacementOrderCreator(
+		orderUC,
+	).WithStockLevelEvaluator(
+		stockAlertUC,
+	).WithTransferVerification(
+		r.transferRepo,
+		r.productRepo,
+	)
+
+	permissionUC := uc.NewPermissionUsecase(r.permissionRepo).
+		WithRoles(
+			r.roleRepo,
+			r.memberRepo,
+			r.companyRepo,
+		)
+
+	// productIdTag が NFC の製品は印刷時にタグを割り当てる。
+	// マスター鍵が無い場合も割り当ては行い、鍵の発行のみ無効（503）にする。
+	nfcTagUC := uc.NewNFCTagUsecase(r.nfcTagRepo)
+	if masterKey, err := c.infra.NFCMasterKeyFromSecret(ctx); err != nil {
+		log.Printf("[di.console] WARN: nfc master key unavailable: %v", err)
+	} else {
+		nfcTagUC.WithMasterKey(masterKey)
+	}
 
 	printUC := uc.NewPrintUsecase(
 		r.productionRepo,
@@ -299,7 +535,19 @@ func buildUsecases(
 		r.printLogRepo,
 		r.inspectionRepo,
 		r.productBlueprintRepo,
-	)
+	).WithNFCTagAssigner(nfcTagUC)
+
+	printLabelUC := uc.NewPrintLabelUsecase(
+		r.productionRepo,
+		r.productRepo,
+		r.printLogRepo,
+		r.productBlueprintRepo,
+		r.modelRepo,
+		r.brandRepo,
+		r.printLabelTemplateRepo,
+		pdfadp.NewLabelSheetRenderer(),
+		qrcodeadp.NewRenderer(),
+	).WithImageFetcher(httpout.NewImageFetcher())
 
 	productionUC := uc.NewProductionUsecase(r.productionRepo)
 
@@ -315,7 +563,10 @@ func buildUsecases(
 	inspectionUC := uc.NewInspectionUsecase(
 		r.inspectionRepo,
 		r.productRepo,
-	)
+	).WithDefectReasonCatalog(
+		r.productionRepo,
+		r.productBlueprintRepo,
+	).WithProductionProgress(productionUC)
 
 	mintUC := uc.NewMintUsecase(
 		r.productionRepo,
@@ -326,6 +577,7 @@ func buildUsecases(
 	)
 
 	mintUC.SetInventoryUsecase(inventoryUC)
+	mintUC.SetMintChargeRecorder(billingUC)
 
 	// 1件ずつmintするためのtask repositoryと
 	// token保存recorderを注入します。
@@ -368,10 +620,13 @@ func buildUsecases(
 		tbReviewRepo,
 		tokenBlueprintAssetStorage,
 		uploader,
+	).WithAuditRecorder(
+		auditUC,
 	)
 
 	mintUC.SetTokenBlueprintMetadataEnsurer(tokenBlueprintUC)
 	mintUC.SetTokenBlueprintMintMarker(tokenBlueprintUC)
+	mintUC.SetOutbox(outboxRelayUC)
 
 	shippingAddressUC := uc.NewShippingAddressUsecase(
 		r.shippingAddressRepo,
@@ -404,6 +659,8 @@ func buildUsecases(
 		r.productRepo,
 		r.productBlueprintRepo,
 		r.productBlueprintRepo,
+		tokenQuery,
+		r.transferRepo,
 	)
 
 	cartUC := uc.NewCartUsecase(r.cartRepo)
@@ -443,10 +700,6 @@ func buildUsecases(
 		return nil, err
 	}
 
-	authUserReader := firebaseadp.NewAuthUserReader(
-		c.infra.FirebaseAuth,
-	)
-
 	orderDispatchNotificationMailer := mailadp.NewOrderDispatchNotificationMailerWithResend()
 
 	orderDispatchNotificationUC := uc.NewOrderDispatchNotificationUsecase(
@@ -456,9 +709,56 @@ func buildUsecases(
 		r.productBlueprintRepo,
 		orderDispatchNotificationMailer,
 		orderDispatchNotificationQueue,
+	).WithOrderReader(
+		r.orderRepo,
+	)
+
+	// 注文受付メールは mall の注文作成で保存した OrderCreated から送る。
+	// console では internal endpoint の再配信（RelayDue）で使う。
+	orderAcceptanceMailUC := uc.NewOrderAcceptanceMailUsecase(
+		r.orderRepo,
+		authUserReader,
+		mailadp.NewOrderMailer(
+			mailadp.NewResendClient(os.Getenv("RESEND_API_KEY")),
+			r.modelRepo,
+			r.inventoryRepo,
+			r.productBlueprintRepo,
+			r.tokenBlueprintRepo,
+			r.brandRepo,
+			r.companyRepo,
+		).WithInvoiceAttachment(
+			uc.NewInvoiceUsecase(
+				r.productBlueprintRepo,
+				r.companyRepo,
+				pdfadp.NewInvoiceRenderer(),
+			).WithRefundLister(
+				r.refundRepo,
+			),
+		),
+		os.Getenv("RESEND_FROM"),
+	)
+
+	outboxRelayUC.Subscribe(
+		outboxdom.EventOrderCreated,
+		orderAcceptanceMailUC,
+	).Subscribe(
+		outboxdom.EventPaymentSucceeded,
+		paymentUC,
+	).Subscribe(
+		outboxdom.EventItemDispatched,
+		orderDispatchNotificationUC,
+	).Subscribe(
+		outboxdom.EventMintCompleted,
+		mintUC,
+	).Subscribe(
+		outboxdom.EventMintCompleted,
+		productionUC,
+	).Subscribe(
+		outboxdom.EventTransferSucceeded,
+		escrowUC,
 	)
 
-	memberUC := uc.NewMemberUsecase(r.memberRepo)
+	memberUC := uc.NewMemberUsecase(r.memberRepo).WithAuditRecorder(auditUC)
 
 	authBootstrapSvc := &uc.BootstrapService{
 		Members:   r.memberRepo,
@@ -479,6 +779,8 @@ func buildUsecases(
 		companyUC:                      companyUC,
 		inquiryUC:                      inquiryUC,
 		inventoryUC:                    inventoryUC,
+		inventoryReservationUC:         inventoryReservationUC,
+		stockAlertUC:                   stockAlertUC,
 		listUC:                         listUC,
 		listSaveOperationUC:            listSaveOperationUC,
 		listSaveOperationStorage:       listSaveOperationStorage,
@@ -490,8 +792,21 @@ func buildUsecases(
 		orderDispatchNotificationQueue: orderDispatchNotificationQueue,
 		paymentUC:                      paymentUC,
 		paymentFlowUC:                  paymentFlowUC,
+		refundUC:                       refundUC,
+		returnUC:                       returnUC,
+		couponUC:                       couponUC,
+		royaltyUC:                      royaltyUC,
+		offerUC:                        offerUC,
+		escrowUC:                       escrowUC,
+		payoutUC:                       payoutUC,
+		billingUC:                      billingUC,
+		auditUC:                        auditUC,
+		outboxRelayUC:                  outboxRelayUC,
+		stripeWebhookEventUC:           stripeWebhookEventUC,
 		permissionUC:                   permissionUC,
 		printUC:                        printUC,
+		nfcTagUC:                       nfcTagUC,
+		printLabelUC:                   printLabelUC,
 		productionUC:                   productionUC,
 		productBlueprintUC:             productBlueprintUC,
 		productBlueprintCategoryUC:     productBlueprintCategoryUC,
@@ -4,6 +4,7 @@ package mall
 import (
 	"context"
 	"errors"
+	"log"
 	"os"
 
 	mallquery "narratives/internal/application/query/mall"
@@ -13,17 +14,20 @@ import (
 	usecase "narratives/internal/application/usecase"
 
 	mallhandler "narratives/internal/adapters/in/http/mall/handler"
+	mallwebhook "narratives/internal/adapters/in/http/mall/webhook"
 
 	outfirebase "narratives/internal/adapters/out/firebase"
 	outfs "narratives/internal/adapters/out/firestore"
 	mallfs "narratives/internal/adapters/out/firestore/mall"
 	sharedfs "narratives/internal/adapters/out/firestore/shared"
 	mailadp "narratives/internal/adapters/out/mail"
+	pdfadp "narratives/internal/adapters/out/pdf"
 	outsolana "narratives/internal/adapters/out/solana"
 	stripeadapter "narratives/internal/adapters/out/stripe"
 
 	avatardom "narratives/internal/domain/avatar"
 	branddom "narratives/internal/domain/brand"
+	outboxdom "narratives/internal/domain/outbox"
 	resaledom "narratives/internal/domain/resale"
 	tokenblueprintreview "narratives/internal/domain/tokenBlueprint_review"
 	transferdom "narratives/internal/domain/transfer"
@@ -49,12 +53,25 @@ type Container struct {
 	PaymentMethodUC   *usecase.PaymentMethodUsecase
 	UserUC            *usecase.UserUsecase
 	WalletUC          *usecase.WalletUsecase
+	ListAccessUC      *usecase.ListAccessUsecase
+	AuthenticityUC    *usecase.AuthenticityUsecase
+	NFCTagUC          *usecase.NFCTagUsecase
 	CartUC            *usecase.CartUsecase
 	PaymentUC         *usecase.PaymentUsecase
+	RefundUC          *usecase.RefundUsecase
 	OrderUC           *usecase.OrderUsecase
 	InquiryUC         *usecase.InquiryUsecase
+	ReturnUC          *usecase.ReturnUsecase
+	InvoiceUC         *usecase.InvoiceUsecase
 	AnnouncementUC    *usecase.AnnouncementUsecase
 	ResaleUC          *usecase.ResaleUsecase
+	OfferUC           *usecase.OfferUsecase
+	EscrowUC          *usecase.EscrowUsecase
+
+	StripeWebhookEventUC *usecase.StripeWebhookEventUsecase
+
+	// Idempotency-Key の記録（POST /mall/me/orders など）
+	IdempotencyUC *usecase.IdempotencyUsecase
 
 	OrderMailer   *mailadp.OrderMailer
 	OrderMailFrom string
@@ -252,6 +269,11 @@ func NewContainer(
 			fsClient,
 		)
 
+	inventoryReservationRepo :=
+		outfs.NewInventoryReservationRepositoryFS(
+			fsClient,
+		)
+
 	tokenBlueprintRepo :=
 		outfs.NewTokenBlueprintRepositoryFS(
 			fsClient,
@@ -385,6 +407,19 @@ func NewContainer(
 			avatarRepo,
 		)
 
+	// Receipts are rendered from the order snapshot on demand.
+	c.InvoiceUC =
+		usecase.NewInvoiceUsecase(
+			productBlueprintRepoFS,
+			companyRepo,
+			pdfadp.NewInvoiceRenderer(),
+		).
+			WithRefundLister(
+				outfs.NewRefundRepositoryFS(
+					fsClient,
+				),
+			)
+
 	c.OrderMailer =
 		mailadp.NewOrderMailer(
 			mailadp.NewResendClient(
@@ -396,7 +431,10 @@ func NewContainer(
 			tokenBlueprintRepo,
 			brandRepo,
 			companyRepo,
-		)
+		).
+			WithInvoiceAttachment(
+				c.InvoiceUC,
+			)
 
 	c.OrderMailFrom =
 		os.Getenv("RESEND_FROM")
@@ -444,6 +482,8 @@ func NewContainer(
 			modelRepoFS,
 			shippingAddressRepo,
 			transportationSvc,
+		).WithProductBlueprintRepo(
+			productBlueprintRepoFS,
 		)
 
 	c.ListUC =
@@ -493,8 +533,54 @@ func NewContainer(
 			productRepo,
 			productBlueprintRepoFS,
 			productBlueprintRepoFS,
+			tokenQuery,
+			outfs.NewTransferRepositoryFS(
+				fsClient,
+			),
 		)
 
+	// holder-only list の保有判定（cart / order / me catalog で共有）
+	c.ListAccessUC =
+		usecase.NewListAccessUsecase(
+			listRepoFS,
+			c.WalletUC,
+		)
+
+	// QR コードの真贋確認（public）。スキャンは products/{id}/authenticityScans に記録する。
+	c.AuthenticityUC =
+		usecase.NewAuthenticityUsecase(
+			productRepo,
+			tokenQuery,
+			onchainReader,
+			brandRepo,
+			tokenBlueprintRepo,
+			outfs.NewAuthenticityScanRepositoryFS(
+				fsClient,
+			),
+		)
+
+	// NFC タグの SUN 検証（真贋確認の前に複製タグを拒否する）
+	c.NFCTagUC =
+		usecase.NewNFCTagUsecase(
+			outfs.NewNFCTagRepositoryFS(
+				fsClient,
+			),
+		)
+
+	if nfcMasterKey, err :=
+		infra.NFCMasterKeyFromSecret(
+			ctx,
+		); err != nil {
+		log.Printf(
+			"[di.mall] WARN: nfc master key unavailable: %v",
+			err,
+		)
+	} else {
+		c.NFCTagUC.WithMasterKey(
+			nfcMasterKey,
+		)
+	}
+
 	c.ProductBlueprintReviewUC =
 		usecase.NewProductBlueprintReviewUsecase(
 			productBlueprintReviewRepo,
@@ -509,8 +595,156 @@ func NewContainer(
 	c.CartUC =
 		usecase.NewCartUsecase(
 			cartRepo,
+		).
+			WithListAccessGuard(
+				c.ListAccessUC,
+			)
+
+	// Low-stock alerts are evaluated after every reservation change.
+	stockAlertUC :=
+		usecase.NewStockAlertUsecase(
+			outfs.NewStockAlertSettingRepositoryFS(
+				fsClient,
+			),
+			outfs.NewStockAlertRepositoryFS(
+				fsClient,
+			),
+			inventoryRepo,
+			productBlueprintRepoFS,
+		).
+			WithMailer(
+				mailadp.NewStockAlertMailerWithResend(),
+			).
+			WithAnnouncer(
+				c.AnnouncementUC,
+			)
+
+	// Coupons are reserved on order creation, confirmed on payment and
+	// released when the payment fails or the reservation expires.
+	couponRepo :=
+		outfs.NewCouponRepositoryFS(
+			fsClient,
+		)
+
+	couponUC :=
+		usecase.NewCouponUsecase(
+			couponRepo,
+			couponRepo,
+			productBlueprintRepoFS,
+		)
+
+	// Resale royalties are quoted on order creation and accrued on payment.
+	royaltyUC :=
+		usecase.NewRoyaltyUsecase(
+			outfs.NewRoyaltyRepositoryFS(
+				fsClient,
+			),
+			tokenBlueprintRepo,
+			resaleRepo,
+		)
+
+	// Market offers reserve the resale on accept; the agreed price is checked
+	// out through order creation and completed on payment.
+	c.OfferUC =
+		usecase.NewOfferUsecase(
+			outfs.NewOfferRepositoryFS(
+				fsClient,
+			),
+			resaleRepo,
+		).
+			WithMailer(
+				mailadp.NewOfferMailerWithResend(),
+				avatarRepo,
+				authUserReader,
+			)
+
+	payoutRepo :=
+		outfs.NewPayoutRepositoryFS(
+			fsClient,
+		)
+
+	// The commission rate comes from the company's billing plan;
+	// plans and statements are managed from the console.
+	billingUC :=
+		usecase.NewBillingUsecase(
+			outfs.NewBillingRepositoryFS(
+				fsClient,
+			),
+			payoutRepo,
+			brandRepo,
+			companyRepo,
+		).
+			WithDefaultSalesFee(
+				infra.PayoutPlatformFeeBasisPoints,
+			)
+
+	// Brand sales, royalties and released resale proceeds are accrued into
+	// the payout ledger; batches are operated from the console.
+	payoutUC :=
+		usecase.NewPayoutUsecase(
+			payoutRepo,
+			outfs.NewAccountRepositoryFS(
+				fsClient,
+			),
+			brandRepo,
+			companyRepo,
+		).
+			WithPlatformFee(
+				infra.PayoutPlatformFeeBasisPoints,
+			).
+			WithFeePlans(
+				billingUC,
+			)
+
+	// Resale proceeds are held on payment and released to the seller once
+	// the buyer confirms receipt and the token has been transferred.
+	c.EscrowUC =
+		usecase.NewEscrowUsecase(
+			outfs.NewEscrowRepositoryFS(
+				fsClient,
+			),
+			orderRepo,
+			resaleRepo,
+			brandRepo,
+		).
+			WithPayoutLedger(
+				payoutUC,
+			)
+
+	// Domain events stored with the order / payment / transfer are delivered
+	// right after the write. Events left behind are redelivered by the
+	// console relay endpoint (POST /internal/outbox/relay).
+	outboxRelayUC :=
+		usecase.NewOutboxRelayUsecase(
+			outfs.NewOutboxRepositoryFS(
+				fsClient,
+			),
 		)
 
+	// Order creation reserves stock; payment webhooks confirm or release it.
+	inventoryReservationUC :=
+		usecase.NewInventoryReservationUsecase(
+			inventoryReservationRepo,
+			inventoryRepo,
+			orderRepo,
+		).
+			WithTTL(
+				infra.InventoryReservationTTL,
+			).
+			WithStockLevelEvaluator(
+				stockAlertUC,
+			).
+			WithCouponReleaser(
+				couponUC,
+			).
+			WithOfferReleaser(
+				c.OfferUC,
+			).
+			WithPaymentCanceler(
+				paymentRepo,
+				infra.PaymentMethodGateway,
+			)
+
 	c.PaymentUC =
 		usecase.NewPaymentUsecase(
 			usecase.NewPaymentUsecaseInput{
@@ -521,12 +755,84 @@ func NewContainer(
 				InventoryRepo: inventoryRepo,
 				ResaleRepo:    resaleRepo,
 
+				InventoryReservations: inventoryReservationUC,
+				CouponRedemptions:     couponUC,
+				RoyaltyLedger:         royaltyUC,
+				Offers:                c.OfferUC,
+				Escrows:               c.EscrowUC,
+				Payouts:               payoutUC,
+
 				AuthUserGetter: authUserReader,
 				MailSender:     c.OrderMailer,
 				MailFrom:       c.OrderMailFrom,
+
+				Outbox: outboxRelayUC,
 			},
 		)
 
+	outboxRelayUC.
+		Subscribe(
+			outboxdom.EventOrderCreated,
+			usecase.NewOrderAcceptanceMailUsecase(
+				orderRepo,
+				authUserReader,
+				c.OrderMailer,
+				c.OrderMailFrom,
+			),
+		).
+		Subscribe(
+			outboxdom.EventPaymentSucceeded,
+			c.PaymentUC,
+		).
+		Subscribe(
+			outboxdom.EventTransferSucceeded,
+			c.EscrowUC,
+		)
+
+	{
+		var refundGateway usecase.StripeRefundGateway
+		if infra.PaymentMethodGateway != nil {
+			refundGateway = infra.PaymentMethodGateway
+		}
+
+		c.RefundUC =
+			usecase.NewRefundUsecase(
+				outfs.NewRefundRepositoryFS(
+					fsClient,
+				),
+				orderRepo,
+				paymentRepo,
+				refundGateway,
+			).
+				WithPayoutLedger(
+					payoutUC,
+				)
+	}
+
+	// Stripe webhook events are stored by event ID before being applied, so
+	// redeliveries are suppressed and failed events can be replayed from the
+	// console.
+	c.StripeWebhookEventUC =
+		usecase.NewStripeWebhookEventUsecase(
+			outfs.NewStripeEventRepositoryFS(
+				fsClient,
+			),
+			mallwebhook.NewStripeEventProcessor(
+				c.PaymentUC,
+				c.RefundUC,
+			),
+		)
+
+	c.IdempotencyUC =
+		usecase.NewIdempotencyUsecase(
+			outfs.NewIdempotencyRepositoryFS(
+				fsClient,
+			),
+		).
+			WithTTL(
+				infra.IdempotencyKeyTTL,
+			)
+
 	c.OrderUC =
 		usecase.NewOrderUsecase(
 			orderRepo,
@@ -540,6 +846,58 @@ func NewContainer(
 		).
 			WithCartRepository(
 				cartRepo,
+			).
+			WithInventoryReserver(
+				inventoryReservationUC,
+			).
+			WithStockLevelEvaluator(
+				stockAlertUC,
+			).
+			WithCouponApplier(
+				couponUC,
+			).
+			WithRoyaltyQuoter(
+				royaltyUC,
+			).
+			WithOfferCheckout(
+				c.OfferUC,
+			).
+			WithListAccessGuard(
+				c.ListAccessUC,
+			).
+			WithOutbox(
+				outboxRelayUC,
+			)
+
+	c.CartUC.WithCouponPreviewer(
+		c.OrderUC,
+	)
+
+	if infra.PaymentMethodGateway != nil {
+		c.OrderUC.WithRefundIssuer(
+			c.RefundUC,
+		)
+	}
+
+	// Buyer side of returns only (request / cancel / photos).
+	// Approval, restocking and refunds are handled by the console container.
+	c.ReturnUC =
+		usecase.NewReturnUsecase(
+			outfs.NewReturnRepositoryFS(
+				fsClient,
+			),
+			outfs.NewReturnImageRepositoryFS(
+				fsClient,
+			),
+			orderRepo,
+			productBlueprintRepoFS,
+			inventoryRepo,
+		).
+			WithTransferVerification(
+				outfs.NewTransferRepositoryFS(
+					fsClient,
+				),
+				productRepo,
 			)
 
 	c.InquiryUC =
@@ -571,7 +929,10 @@ func NewContainer(
 	c.InventoryUC =
 		usecase.NewInventoryUsecase(
 			inventoryRepo,
-		)
+		).
+			WithStockLevelEvaluator(
+				stockAlertUC,
+			)
 
 	{
 		c.NameResolver =
@@ -634,6 +995,7 @@ func NewContainer(
 				tokenBlueprintRepo,
 				productBlueprintReviewRepo,
 				c.NameResolver,
+				c.ListAccessUC,
 			)
 
 		c.CartQ =
@@ -778,6 +1140,9 @@ func NewContainer(
 			).
 				WithResaleTransferDependencies(
 					resaleRepo,
+				).
+				WithOutbox(
+					outboxRelayUC,
 				)
 
 		c.ShareTransferUC =
@@ -51,6 +51,18 @@ func Register(mux *http.ServeMux, cont *Container) {
 		}
 	}
 
+	// ------------------------------------------------------------
+	// Idempotency middleware (Idempotency-Key on checkout)
+	//
+	// Only order creation is covered. The PaymentIntent is started from
+	// the order with the order ID as payment ID, so a retried checkout
+	// cannot start a second payment; /me/payments has no write endpoint.
+	// ------------------------------------------------------------
+	idempotencyMW := &middleware.IdempotencyMiddleware{}
+	if cont.IdempotencyUC != nil {
+		idempotencyMW.Store = cont.IdempotencyUC
+	}
+
 	// ------------------------------------------------------------
 	// Local repositories recreated from Firestore when needed
 	// ------------------------------------------------------------
@@ -96,15 +108,19 @@ func Register(mux *http.ServeMux, cont *Container) {
 	cartH := notImplemented("Cart")
 	payH := notImplemented("Payment")
 	orderH := notImplemented("Order")
+	returnH := notImplemented("Return")
 	inquiryH := notImplemented("Inquiry")
 	meAvatarsH := notImplemented("MeAvatars")
 	announcementH := notImplemented("Announcement")
 
 	marketH := notImplemented("Market")
+	marketOfferH := notImplemented("MarketOffer")
+	escrowH := notImplemented("Escrow")
 	resaleH := notImplemented("Resale")
 
 	previewPublicH := notImplemented("PreviewPublic")
 	previewMeH := notImplemented("PreviewMe")
+	authenticityH := notImplemented("Authenticity")
 
 	orderScanTransferH :=
 		transferUsecaseNotConfiguredHandler()
@@ -204,9 +220,20 @@ func Register(mux *http.ServeMux, cont *Container) {
 	}
 
 	if cont.ShippingQuoteUC != nil {
+		var quoteOpts []mallhandler.ShippingQuoteHandlerOption
+		if cont.OrderUC != nil {
+			quoteOpts = append(
+				quoteOpts,
+				mallhandler.WithShippingQuoteDiscountPreviewer(
+					cont.OrderUC,
+				),
+			)
+		}
+
 		shippingQuoteH =
 			mallhandler.NewShippingQuoteHandler(
 				cont.ShippingQuoteUC,
+				quoteOpts...,
 			)
 	}
 
@@ -301,10 +328,46 @@ func Register(mux *http.ServeMux, cont *Container) {
 
 	// Order
 	if cont.OrderUC != nil {
-		orderH = mallhandler.NewOrderHandler(
-			cont.OrderUC,
-			cont.HistoryQ,
-			cont.OrderDetailQ,
+		var orderOpts []mallhandler.OrderHandlerOption
+		if cont.InvoiceUC != nil {
+			orderOpts = append(
+				orderOpts,
+				mallhandler.WithOrderInvoiceRenderer(
+					cont.InvoiceUC,
+				),
+			)
+		}
+
+		// A retried checkout with the same Idempotency-Key returns the
+		// first response instead of creating a second order.
+		orderH = idempotencyMW.Handler(
+			mallhandler.NewOrderHandler(
+				cont.OrderUC,
+				cont.HistoryQ,
+				cont.OrderDetailQ,
+				orderOpts...,
+			),
+		)
+	}
+
+	// Market offers
+	if cont.OfferUC != nil {
+		marketOfferH = mallhandler.NewMarketOfferHandler(
+			cont.OfferUC,
+		)
+	}
+
+	// Resale escrows
+	if cont.EscrowUC != nil {
+		escrowH = mallhandler.NewEscrowHandler(
+			cont.EscrowUC,
+		)
+	}
+
+	// Return
+	if cont.ReturnUC != nil {
+		returnH = mallhandler.NewReturnHandler(
+			cont.ReturnUC,
 		)
 	}
 
@@ -356,6 +419,27 @@ func Register(mux *http.ServeMux, cont *Container) {
 			)
 	}
 
+	// Authenticity (QR code verification)
+	if cont.AuthenticityUC != nil {
+		authenticityOpts :=
+			[]mallhandler.AuthenticityHandlerOption{}
+
+		if cont.NFCTagUC != nil {
+			authenticityOpts = append(
+				authenticityOpts,
+				mallhandler.WithNFCSUNVerifier(
+					cont.NFCTagUC,
+				),
+			)
+		}
+
+		authenticityH =
+			mallhandler.NewAuthenticityHandler(
+				cont.AuthenticityUC,
+				authenticityOpts...,
+			)
+	}
+
 	// Order scan transfer
 	if cont.TransferUC != nil {
 		orderScanTransferH =
@@ -408,12 +492,15 @@ func Register(mux *http.ServeMux, cont *Container) {
 		MeWallet: meWalletH,
 		Cart:     cartH,
 
-		Market: marketH,
-		Resale: resaleH,
+		Market:      marketH,
+		MarketOffer: marketOfferH,
+		Resale:      resaleH,
 
 		Preview:   previewPublicH,
 		PreviewMe: previewMeH,
 
+		Authenticity: authenticityH,
+
 		OrderScanTransfer: orderScanTransferH,
 
 		OwnerResolve: notImplemented(
@@ -422,10 +509,13 @@ func Register(mux *http.ServeMux, cont *Container) {
 
 		Payment:      payH,
 		Order:        orderH,
+		Return:       returnH,
 		Inquiry:      inquiryH,
 		Announcement: announcementH,
 
 		SetupStatus: setupStatusH,
+
+		Escrow: escrowH,
 	}
 
 	mallhttp.Register(
@@ -438,7 +528,7 @@ func Register(mux *http.ServeMux, cont *Container) {
 	// ----------------------------
 	// Webhooks (no auth)
 	// ----------------------------
-	if cont.PaymentUC != nil {
+	if cont.StripeWebhookEventUC != nil {
 		secret :=
 			os.Getenv(
 				"STRIPE_WEBHOOK_SECRET",
@@ -450,7 +540,7 @@ func Register(mux *http.ServeMux, cont *Container) {
 
 		stripeWH :=
 			mallwebhook.NewStripeWebhookHandler(
-				cont.PaymentUC,
+				cont.StripeWebhookEventUC,
 				secret,
 			)
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
@@ -17,6 +18,7 @@ import (
 	"google.golang.org/api/option"
 
 	stripeadapter "narratives/internal/adapters/out/stripe"
+	nfcdom "narratives/internal/domain/nfc"
 	appcfg "narratives/internal/infra/config"
 )
 
@@ -28,6 +30,9 @@ const (
 	defaultAvatarWalletSecretPrefix = "avatar-wallet-"
 
 	stripeSecretKeySecretID = "stripe-secret-key"
+
+	// NFC タグ（NTAG 424 DNA）の鍵を導出するマスター鍵（hex, 32 byte 以上）
+	nfcMasterKeySecretID = "nfc-master-key"
 )
 
 // Infra is shared runtime infrastructure for DI.
@@ -51,6 +56,16 @@ type Infra struct {
 	AvatarsCollection        string
 	BrandWalletSecretPrefix  string
 	AvatarWalletSecretPrefix string
+
+	InventoryReservationTTL           time.Duration
+	InventoryReservationSweepInterval time.Duration
+
+	IdempotencyKeyTTL time.Duration
+
+	PayoutPlatformFeeBasisPoints int
+
+	BillingOperatorCompanyID string
+	BillingYenPerSOL         int
 }
 
 func NewInfra(ctx context.Context) (*Infra, error) {
@@ -105,6 +120,13 @@ func NewInfra(ctx context.Context) (*Infra, error) {
 		inf.AvatarWalletSecretPrefix = defaultAvatarWalletSecretPrefix
 	}
 
+	inf.InventoryReservationTTL = settings.InventoryReservationTTL
+	inf.InventoryReservationSweepInterval = settings.InventoryReservationSweepInterval
+	inf.IdempotencyKeyTTL = settings.IdempotencyKeyTTL
+	inf.PayoutPlatformFeeBasisPoints = settings.PayoutPlatformFeeBasisPoints
+	inf.BillingOperatorCompanyID = settings.BillingOperatorCompanyID
+	inf.BillingYenPerSOL = settings.BillingYenPerSOL
+
 	// --------------------------------------------------------
 	// Credentials file
 	// --------------------------------------------------------
@@ -397,6 +419,34 @@ func (i *Infra) RegisterPaymentMethodGatewayFromSecret(
 	return nil
 }
 
+// NFCMasterKeyFromSecret reads the NFC tag master key from Google Secret Manager.
+func (i *Infra) NFCMasterKeyFromSecret(
+	ctx context.Context,
+) ([]byte, error) {
+	value, err := i.AccessSecretVersion(
+		ctx,
+		nfcMasterKeySecretID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"shared.infra: failed to access %s: %w",
+			nfcMasterKeySecretID,
+			err,
+		)
+	}
+
+	key, err := nfcdom.ParseMasterKey(value)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"shared.infra: %s: %w",
+			nfcMasterKeySecretID,
+			err,
+		)
+	}
+
+	return key, nil
+}
+
 func (i *Infra) Close() error {
 	if i == nil {
 		return nil
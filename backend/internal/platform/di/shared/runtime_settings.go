@@ -3,8 +3,11 @@ package shared
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	appcfg "narratives/internal/infra/config"
 )
@@ -32,6 +35,24 @@ type RuntimeSettings struct {
 
 	// Used by ShareTransfer signer provider
 	AvatarWalletSecretPrefix string
+
+	// Used by InventoryReservation usecase (0 = usecase default)
+	InventoryReservationTTL time.Duration
+
+	// Used by local reservation sweeper (0 = disabled)
+	InventoryReservationSweepInterval time.Duration
+
+	// Used by Idempotency usecase (0 = usecase default)
+	IdempotencyKeyTTL time.Duration
+
+	// Used by Payout usecase (platform fee in basis points; 0 = no fee)
+	PayoutPlatformFeeBasisPoints int
+
+	// Used by Billing usecase (company allowed to manage commission plans)
+	BillingOperatorCompanyID string
+
+	// Used by Billing usecase (JPY per SOL for mint network cost; 0 = not charged)
+	BillingYenPerSOL int
 }
 
 // ResolveRuntimeSettings resolves and normalizes runtime settings from cfg/env.
@@ -75,9 +96,89 @@ func ResolveRuntimeSettings(cfg *appcfg.Config) (RuntimeSettings, []string, erro
 		s.AvatarWalletSecretPrefix = defaultAvatarWalletSecretPrefix
 	}
 
+	// Inventory reservation TTL / local sweep interval (env only; invalid values are ignored)
+	s.InventoryReservationTTL, warns = getenvDuration(
+		"INVENTORY_RESERVATION_TTL",
+		warns,
+	)
+	s.InventoryReservationSweepInterval, warns = getenvDuration(
+		"INVENTORY_RESERVATION_SWEEP_INTERVAL",
+		warns,
+	)
+
+	// Idempotency-Key retention (env only; invalid values are ignored)
+	s.IdempotencyKeyTTL, warns = getenvDuration(
+		"IDEMPOTENCY_KEY_TTL",
+		warns,
+	)
+
+	// Payout platform fee (env only; invalid values are ignored)
+	s.PayoutPlatformFeeBasisPoints, warns = getenvBasisPoints(
+		"PAYOUT_PLATFORM_FEE_BASIS_POINTS",
+		warns,
+	)
+
+	// Billing operator / SOL rate (env only; invalid values are ignored)
+	s.BillingOperatorCompanyID = getenvTrim("BILLING_OPERATOR_COMPANY_ID")
+	s.BillingYenPerSOL, warns = getenvNonNegativeInt(
+		"BILLING_SOL_JPY_RATE",
+		warns,
+	)
+
 	return s, warns, nil
 }
 
+func getenvBasisPoints(key string, warns []string) (int, []string) {
+	v := getenvTrim(key)
+	if v == "" {
+		return 0, warns
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 || n > 10000 {
+		return 0, append(
+			warns,
+			fmt.Sprintf("%s must be 0..10000 basis points (got %q); ignored", key, v),
+		)
+	}
+
+	return n, warns
+}
+
+func getenvNonNegativeInt(key string, warns []string) (int, []string) {
+	v := getenvTrim(key)
+	if v == "" {
+		return 0, warns
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, append(
+			warns,
+			fmt.Sprintf("%s must be a non-negative integer (got %q); ignored", key, v),
+		)
+	}
+
+	return n, warns
+}
+
+func getenvDuration(key string, warns []string) (time.Duration, []string) {
+	v := getenvTrim(key)
+	if v == "" {
+		return 0, warns
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, append(
+			warns,
+			fmt.Sprintf("%s is not a valid duration (got %q); ignored", key, v),
+		)
+	}
+
+	return d, warns
+}
+
 func getenvTrim(key string) string {
 	return strings.TrimSpace(os.Getenv(key))
 }
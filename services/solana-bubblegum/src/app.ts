@@ -34,6 +34,12 @@ import {
   MintOperationStateConflictError,
 } from "./application/ports/mint-operation-registry-port.js";
 import { isMintV2TransactionError } from "./application/ports/mint-v2-transaction-port.js";
+import {
+  DEFAULT_SELLER_FEE_BASIS_POINTS,
+  SELLER_FEE_BASIS_POINTS_MAX,
+  SELLER_FEE_BASIS_POINTS_MIN,
+  isValidSellerFeeBasisPoints,
+} from "./application/seller-fee-basis-points.js";
 import { env } from "./config/env.js";
 import {
   getBubblegumRuntime,
@@ -49,6 +55,7 @@ type MintRequestBody = {
   name?: unknown;
   symbol?: unknown;
   metadataUri?: unknown;
+  sellerFeeBasisPoints?: unknown;
 };
 
 type MintEstimateRequestBody = {
@@ -310,6 +317,24 @@ function requiredPositiveInteger(field: string, value: unknown): number {
   return value;
 }
 
+function optionalSellerFeeBasisPoints(
+  field: string,
+  value: unknown,
+): number {
+  if (value === undefined || value === null) {
+    return DEFAULT_SELLER_FEE_BASIS_POINTS;
+  }
+
+  if (!isValidSellerFeeBasisPoints(value)) {
+    throw new HttpRequestValidationError(
+      field,
+      `value must be an integer between ${SELLER_FEE_BASIS_POINTS_MIN} and ${SELLER_FEE_BASIS_POINTS_MAX}`,
+    );
+  }
+
+  return value;
+}
+
 function parseSolanaPublicKey(
   field: string,
   value: string,
@@ -1695,6 +1720,12 @@ app.post(
           body.metadataUri,
         );
 
+      const sellerFeeBasisPoints =
+        optionalSellerFeeBasisPoints(
+          "sellerFeeBasisPoints",
+          body.sellerFeeBasisPoints,
+        );
+
       const [
         runtime,
         mintV2Usecase,
@@ -1721,7 +1752,7 @@ app.post(
             name,
             symbol,
             uri: metadataUri,
-            sellerFeeBasisPoints: 0,
+            sellerFeeBasisPoints,
             primarySaleHappened:
               false,
             isMutable: false,
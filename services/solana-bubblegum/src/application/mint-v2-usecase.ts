@@ -34,9 +34,12 @@ import {
   type MintV2TransactionPort,
 } from "./ports/mint-v2-transaction-port.js";
 
+import {
+  SELLER_FEE_BASIS_POINTS_MAX,
+  SELLER_FEE_BASIS_POINTS_MIN,
+} from "./seller-fee-basis-points.js";
+
 const ASSET_STANDARD = "bubblegum-v2";
-const SELLER_FEE_BASIS_POINTS_MIN = 0;
-const SELLER_FEE_BASIS_POINTS_MAX = 10_000;
 const CREATOR_SHARE_MIN = 0;
 const CREATOR_SHARE_MAX = 100;
 const CREATOR_SHARE_TOTAL = 100;
@@ -0,0 +1,33 @@
+// services/solana-bubblegum/src/application/seller-fee-basis-points.ts
+
+/**
+ * Mint metadataのロイヤリティ（sellerFeeBasisPoints, 100 = 1%）。
+ *
+ * - backendのtoken blueprintのロイヤリティ率がそのまま渡される。
+ * - 省略された場合はロイヤリティなし（0）として扱う。
+ */
+export const SELLER_FEE_BASIS_POINTS_MIN =
+  0;
+
+export const SELLER_FEE_BASIS_POINTS_MAX =
+  10_000;
+
+export const DEFAULT_SELLER_FEE_BASIS_POINTS =
+  SELLER_FEE_BASIS_POINTS_MIN;
+
+
+export function isValidSellerFeeBasisPoints(
+  value: unknown,
+): value is number {
+  return (
+    typeof value ===
+      "number" &&
+    Number.isInteger(
+      value,
+    ) &&
+    value >=
+      SELLER_FEE_BASIS_POINTS_MIN &&
+    value <=
+      SELLER_FEE_BASIS_POINTS_MAX
+  );
+}
@@ -17,12 +17,11 @@ import {
   type MintV2Creator,
 } from "../../application/ports/mint-v2-transaction-port.js";
 
+import {
+  SELLER_FEE_BASIS_POINTS_MAX,
+  SELLER_FEE_BASIS_POINTS_MIN,
+} from "../../application/seller-fee-basis-points.js";
 
-const SELLER_FEE_BASIS_POINTS_MIN =
-  0;
-
-const SELLER_FEE_BASIS_POINTS_MAX =
-  10_000;
 
 const CREATOR_SHARE_MIN =
   0;
@@ -0,0 +1,169 @@
+// services/solana-bubblegum/src/scripts/verify-mint-seller-fee.ts
+
+import type { AddressInfo } from "node:net";
+
+import { app } from "../app.js";
+import { createMintPayloadHash } from "../application/mint-payload-hash.js";
+import { isValidSellerFeeBasisPoints } from "../application/seller-fee-basis-points.js";
+
+
+const TEST_PRODUCT_ID = "verify-seller-fee-product";
+
+
+const validationCases: {
+  name: string;
+  value: unknown;
+  valid: boolean;
+}[] = [
+  { name: "no royalty", value: 0, valid: true },
+  { name: "5%", value: 500, valid: true },
+  { name: "100%", value: 10_000, valid: true },
+  { name: "negative", value: -1, valid: false },
+  { name: "over 100%", value: 10_001, valid: false },
+  { name: "fraction", value: 1.5, valid: false },
+  { name: "string", value: "500", valid: false },
+  { name: "NaN", value: Number.NaN, valid: false },
+  { name: "boolean", value: true, valid: false },
+];
+
+
+function verifyValidation(): void {
+  for (const testCase of validationCases) {
+    const actual =
+      isValidSellerFeeBasisPoints(
+        testCase.value,
+      );
+
+    if (actual !== testCase.valid) {
+      throw new Error(
+        [
+          "verify_mint_seller_fee: unexpected validation result",
+          `case=${testCase.name}`,
+          `expected=${testCase.valid}`,
+          `actual=${actual}`,
+        ].join(" "),
+      );
+    }
+  }
+}
+
+
+// ロイヤリティだけが異なる再送は、同じ mint として扱われない（payload conflict になる）。
+function verifyPayloadHash(): void {
+  const payload =
+    (sellerFeeBasisPoints: number) => ({
+      productId:
+        TEST_PRODUCT_ID,
+      metadata: {
+        name: "verify",
+        symbol: "",
+        uri: "https://metadata.invalid/verify.json",
+        sellerFeeBasisPoints,
+      },
+    });
+
+  if (
+    createMintPayloadHash(payload(0)) ===
+    createMintPayloadHash(payload(500))
+  ) {
+    throw new Error(
+      "verify_mint_seller_fee: payload hash ignores sellerFeeBasisPoints",
+    );
+  }
+}
+
+
+// 範囲外の sellerFeeBasisPoints は runtime に触れる前に 400 で拒否される。
+async function verifyHttpRejectsInvalid(): Promise<void> {
+  const server =
+    app.listen(0, "127.0.0.1");
+
+  await new Promise<void>(
+    (resolve) => server.once("listening", () => resolve()),
+  );
+
+  const { port } =
+    server.address() as AddressInfo;
+
+  try {
+    for (const testCase of validationCases) {
+      // NaN は JSON では null（省略扱い）になり、検証を通って mint まで進むため送らない。
+      if (
+        testCase.valid ||
+        Number.isNaN(testCase.value)
+      ) {
+        continue;
+      }
+
+      const response =
+        await fetch(
+          `http://127.0.0.1:${port}/mint`,
+          {
+            method: "POST",
+            headers: {
+              "Content-Type": "application/json",
+              "Idempotency-Key": TEST_PRODUCT_ID,
+            },
+            body: JSON.stringify({
+              productId: TEST_PRODUCT_ID,
+              tokenBlueprintId: "verify-token-blueprint",
+              brandId: "verify-brand",
+              toAddress: "verify-leaf-owner-address",
+              name: "verify",
+              symbol: "",
+              metadataUri: "https://metadata.invalid/verify.json",
+              sellerFeeBasisPoints: testCase.value,
+            }),
+          },
+        );
+
+      const body =
+        await response.json() as { field?: unknown };
+
+      if (
+        response.status !== 400 ||
+        body.field !== "sellerFeeBasisPoints"
+      ) {
+        throw new Error(
+          [
+            "verify_mint_seller_fee: invalid value was not rejected",
+            `case=${testCase.name}`,
+            `status=${response.status}`,
+            `field=${String(body.field)}`,
+          ].join(" "),
+        );
+      }
+    }
+  } finally {
+    await new Promise<void>(
+      (resolve) => server.close(() => resolve()),
+    );
+  }
+}
+
+
+async function main(): Promise<void> {
+  verifyValidation();
+  verifyPayloadHash();
+  await verifyHttpRejectsInvalid();
+
+  console.log(
+    "MintV2 seller fee verification: OK",
+  );
+}
+
+main().catch(
+  (error: unknown) => {
+    console.error(
+      "MintV2 seller fee verification: FAILED",
+    );
+
+    if (error instanceof Error) {
+      console.error(error.message);
+    } else {
+      console.error(String(error));
+    }
+
+    process.exitCode = 1;
+  },
+);